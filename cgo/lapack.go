@@ -20,6 +20,8 @@ import (
 const (
 	absIncNotOne    = "lapack: increment not one or negative one"
 	badAlpha        = "lapack: bad alpha length"
+	badAP           = "lapack: ap has insufficient length"
+	badARF          = "lapack: arf has insufficient length"
 	badAuxv         = "lapack: auxv has insufficient length"
 	badBeta         = "lapack: bad beta length"
 	badD            = "lapack: d has insufficient length"
@@ -27,15 +29,26 @@ const (
 	badDiag         = "lapack: bad diag"
 	badDims         = "lapack: bad input dimensions"
 	badDirect       = "lapack: bad direct"
+	badDL           = "lapack: dl has insufficient length"
+	badDU           = "lapack: du has insufficient length"
+	badDU2          = "lapack: du2 has insufficient length"
 	badE            = "lapack: e has insufficient length"
 	badEVComp       = "lapack: bad EVComp"
 	badEVJob        = "lapack: bad EVJob"
+	badEVOrder      = "lapack: eigenvalues not ordered by block"
 	badEVSide       = "lapack: bad EVSide"
 	badGSVDJob      = "lapack: bad GSVDJob"
 	badHowMany      = "lapack: bad HowMany"
 	badIlo          = "lapack: ilo out of range"
+	badIndex        = "lapack: index slice has insufficient length"
 	badIhi          = "lapack: ihi out of range"
+	badIl           = "lapack: il out of range"
+	badInterval     = "lapack: vl >= vu"
+	badItype        = "lapack: bad itype"
 	badIpiv         = "lapack: bad permutation length"
+	badIsgn         = "lapack: bad isgn"
+	badIsuppz       = "lapack: isuppz has insufficient length"
+	badIu           = "lapack: iu out of range"
 	badJob          = "lapack: bad Job"
 	badK1           = "lapack: k1 out of range"
 	badK2           = "lapack: k2 out of range"
@@ -44,18 +57,22 @@ const (
 	badNb           = "lapack: nb out of range"
 	badNorm         = "lapack: bad norm"
 	badPivot        = "lapack: bad pivot"
+	badRange        = "lapack: bad EVRange"
 	badS            = "lapack: s has insufficient length"
 	badShifts       = "lapack: bad shifts"
 	badSide         = "lapack: bad side"
 	badSlice        = "lapack: bad input slice length"
 	badSort         = "lapack: bad Sort"
 	badStore        = "lapack: bad store"
+	badSVDComp      = "lapack: bad SVDComp"
+	badSVDJob       = "lapack: bad SVDJob"
 	badTau          = "lapack: tau has insufficient length"
 	badTauQ         = "lapack: tauQ has insufficient length"
 	badTauP         = "lapack: tauP has insufficient length"
 	badTrans        = "lapack: bad trans"
 	badVn1          = "lapack: vn1 has insufficient length"
 	badVn2          = "lapack: vn2 has insufficient length"
+	badW            = "lapack: w has insufficient length"
 	badUplo         = "lapack: illegal triangle"
 	badWork         = "lapack: insufficient working memory"
 	badWorkStride   = "lapack: insufficient working array stride"
@@ -68,6 +85,7 @@ const (
 	nanScale        = "lapack: NaN scale factor"
 	negDimension    = "lapack: negative matrix dimension"
 	negZ            = "lapack: negative z value"
+	negVl           = "lapack: negative vl"
 	nLT0            = "lapack: n < 0"
 	nLTM            = "lapack: n < m"
 	offsetGTM       = "lapack: offset > m"
@@ -106,6 +124,122 @@ func checkMatrix(m, n int, a []float64, lda int) {
 	}
 }
 
+// checkBandMatrix verifies the parameters of an m×n band matrix input with kl
+// subdiagonals and ku superdiagonals stored in row-major band format.
+// Copied from lapack/native. Keep in sync.
+func checkBandMatrix(m, n, kl, ku int, ab []float64, ldab int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if kl < 0 {
+		panic("lapack: kl < 0")
+	}
+	if ku < 0 {
+		panic("lapack: ku < 0")
+	}
+	if ldab < kl+ku+1 {
+		panic("lapack: stride less than band width")
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	if len(ab) < (min(m, n+kl)-1)*ldab+kl+ku+1 {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// toLapackeBand returns a copy of the m×n band matrix A with kl subdiagonals
+// and ku superdiagonals stored in ab in the row-major band format of
+// blas64.Band, converted to the row-major band format used by LAPACKE. In the
+// LAPACKE format the element A[i,j] is stored in abT[(ku+i-j)*ldabT+j].
+func toLapackeBand(m, n, kl, ku int, ab []float64, ldab int) (abT []float64, ldabT int) {
+	ldabT = max(1, n)
+	abT = make([]float64, (kl+ku+1)*ldabT)
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			abT[(ku+i-j)*ldabT+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	return abT, ldabT
+}
+
+// fromLapackeBand copies the m×n band matrix A with kl subdiagonals and ku
+// superdiagonals stored in abT in the row-major band format used by LAPACKE
+// into ab stored in the row-major band format of blas64.Band.
+func fromLapackeBand(m, n, kl, ku int, ab []float64, ldab int, abT []float64, ldabT int) {
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			ab[i*ldab+kl+j-i] = abT[(ku+i-j)*ldabT+j]
+		}
+	}
+}
+
+// checkSymBandMatrix verifies the parameters of an n×n symmetric or triangular
+// band matrix input with kd sub- or superdiagonals stored in row-major band
+// format.
+// Copied from lapack/native. Keep in sync.
+func checkSymBandMatrix(n, kd int, ab []float64, ldab int) {
+	if n < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if kd < 0 {
+		panic("lapack: kd < 0")
+	}
+	if ldab < kd+1 {
+		panic("lapack: stride less than band width")
+	}
+	if n == 0 {
+		return
+	}
+	if len(ab) < (n-1)*ldab+kd+1 {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// toLapackeSymBand returns a copy of the upper or lower triangle of the n×n
+// symmetric band matrix A with kd super- or subdiagonals stored in ab in the
+// row-major band format of blas64.SymmetricBand, converted to the row-major
+// band format used by LAPACKE. In the LAPACKE format the element A[i,j] is
+// stored in abT[(kd+i-j)*ldabT+j] if uplo == blas.Upper and in
+// abT[(i-j)*ldabT+j] if uplo == blas.Lower.
+func toLapackeSymBand(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (abT []float64, ldabT int) {
+	ldabT = max(1, n)
+	abT = make([]float64, (kd+1)*ldabT)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				abT[(kd+i-j)*ldabT+j] = ab[i*ldab+j-i]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				abT[(i-j)*ldabT+j] = ab[i*ldab+kd+j-i]
+			}
+		}
+	}
+	return abT, ldabT
+}
+
+// fromLapackeSymBand copies the upper or lower triangle of the n×n symmetric
+// band matrix A with kd super- or subdiagonals stored in abT in the row-major
+// band format used by LAPACKE into ab stored in the row-major band format of
+// blas64.SymmetricBand.
+func fromLapackeSymBand(uplo blas.Uplo, n, kd int, ab []float64, ldab int, abT []float64, ldabT int) {
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				ab[i*ldab+j-i] = abT[(kd+i-j)*ldabT+j]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				ab[i*ldab+kd+j-i] = abT[(i-j)*ldabT+j]
+			}
+		}
+	}
+}
+
 // checkVector verifies the parameters of a vector input.
 // Copied from lapack/native. Keep in sync.
 func checkVector(n int, v []float64, inc int) {
@@ -482,6 +616,50 @@ func (Implementation) Dlarft(direct lapack.Direct, store lapack.StoreV, n, k int
 	lapacke.Dlarft(byte(direct), byte(store), n, k, v, ldv, tau, t, ldt)
 }
 
+// Dlangb computes the specified norm of an m×n band matrix A with kl
+// subdiagonals and ku superdiagonals. The input norm specifies the norm
+// computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// The matrix A is stored in ab in row-major band format as used by
+// blas64.Band, that is the element A[i,j] is stored in ab[i*ldab+kl+j-i] for
+// max(0,i-kl) <= j <= min(n-1,i+ku). ldab must be at least kl+ku+1.
+//
+// If norm == lapack.MaxColumnSum, work must be of length n, and this function
+// will panic otherwise. There are no restrictions on work for the other matrix
+// norms.
+func (impl Implementation) Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	checkBandMatrix(m, n, kl, ku, ab, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if norm == lapack.MaxColumnSum && len(work) < n {
+		panic(badWork)
+	}
+	if m == 0 || n == 0 {
+		return 0
+	}
+
+	// LAPACKE only handles square band matrices, so A is embedded in a band
+	// matrix of order max(m,n) whose additional rows or columns are zero.
+	// This does not change any of the norms.
+	nn := max(m, n)
+	abT := make([]float64, (kl+ku+1)*nn)
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			abT[(ku+i-j)*nn+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	// LAPACKE computes the norm of the transposed column-major copy, which
+	// needs workspace of length max(m,n) for the row sums.
+	return lapacke.Dlangb(byte(norm), nn, kl, ku, abT, nn, make([]float64, nn))
+}
+
 // Dlange computes the matrix norm of the general m×n matrix a. The input norm
 // specifies the norm computed.
 //  lapack.MaxAbs: the maximum absolute value of an element.
@@ -503,6 +681,40 @@ func (impl Implementation) Dlange(norm lapack.MatrixNorm, m, n int, a []float64,
 	return lapacke.Dlange(byte(norm), m, n, a, lda, work)
 }
 
+// Dlangt computes the specified norm of an n×n tridiagonal matrix A. The
+// input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// dl, d and du contain the n-1 subdiagonal, n diagonal and n-1 superdiagonal
+// elements of A, respectively. dl and du must have length at least n-1,
+// otherwise Dlangt will panic.
+func (impl Implementation) Dlangt(norm lapack.MatrixNorm, n int, dl, d, du []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if n == 0 {
+		return 0
+	}
+	return lapacke.Dlangt(byte(norm), n, dl, d, du)
+}
+
 // Dlansy computes the specified norm of an n×n symmetric matrix. If
 // norm == lapack.MaxColumnSum or norm == lapackMaxRowSum work must have length
 // at least n, otherwise work is unused.
@@ -678,6 +890,107 @@ func (impl Implementation) Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok
 	return lapacke.Dpotrf(ul, n, a, lda)
 }
 
+// Dpotri computes the inverse of a real symmetric positive definite matrix A
+// using its Cholesky factorization.
+//
+// On entry, a contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Dpotrf.
+// On return, a contains the upper or lower triangle of the (symmetric)
+// inverse of A, overwriting the input factor U or L.
+//
+// Dpotri returns whether the matrix A is invertible. If Dpotri returns false,
+// a diagonal element of the factor is zero and the inverse could not be
+// computed.
+func (impl Implementation) Dpotri(uplo blas.Uplo, n int, a []float64, lda int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dpotri(uplo, n, a, lda)
+}
+
+// Dpotrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// as computed by Dpotrf. On entry, B contains the right-hand side matrix B, on
+// return it contains the solution matrix X.
+func (impl Implementation) Dpotrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	lapacke.Dpotrs(uplo, n, nrhs, a, lda, b, ldb)
+}
+
+// Dpstrf computes the Cholesky factorization with complete pivoting of an n×n
+// symmetric positive semidefinite matrix A.
+//
+// The factorization has the form
+//  P^T * A * P = U^T * U ,  if uplo = blas.Upper,
+//  P^T * A * P = L   * L^T, if uplo = blas.Lower,
+// where U is an upper triangular matrix, L is lower triangular, and P is a
+// permutation matrix.
+//
+// tol is a user-defined tolerance. The algorithm terminates if the pivot is
+// less than or equal to tol. If tol is negative, then n*eps*max(A[k,k]) will be
+// used instead.
+//
+// On return, A contains the factor U or L from the Cholesky factorization and
+// piv contains the pivot indices. The k-th column of P is the piv[k]-th column
+// of the identity matrix. piv must have length n, and work must have length at
+// least 2*n, otherwise Dpstrf will panic.
+//
+// Dpstrf returns the computed rank of A in rank and whether the factorization
+// could be completed with rank == n. If ok is false, the matrix A is either
+// rank deficient or is not positive semidefinite, and only the leading rank
+// rows (if uplo == blas.Upper) or columns (if uplo == blas.Lower) of the
+// factor are meaningful.
+func (impl Implementation) Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	switch {
+	case len(piv) != n:
+		panic(badIpiv)
+	case len(work) < 2*n:
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return 0, true
+	}
+	piv32 := make([]int32, n)
+	var _rank [1]int32
+	ok = lapacke.Dpstrf(uplo, n, a, lda, piv32, _rank[:], tol, work)
+	for i, v := range piv32 {
+		piv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return int(_rank[0]), ok
+}
+
 // Dgebal balances an n×n matrix A. Balancing consists of two stages, permuting
 // and scaling. Both steps are optional and depend on the value of job.
 //
@@ -935,6 +1248,197 @@ func (impl Implementation) Dgebrd(m, n int, a []float64, lda int, d, e, tauQ, ta
 	lapacke.Dgebrd(m, n, a, lda, d, e, tauQ, tauP, work, lwork)
 }
 
+// Dgbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A with kl subdiagonals and ku superdiagonals given its LU
+// factorization computed by Dgbtrf. The condition number computed may be
+// based on the 1-norm or the ∞-norm.
+//
+// ab and ipiv contain the factorization of A as returned by Dgbtrf, in
+// particular ldab must be at least 2*kl+ku+1. ipiv must have length at least
+// n, otherwise Dgbcon will panic.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Dgbcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgbcon will panic
+// otherwise. Elements of iwork must fit within the int32 type or Dgbcon will
+// panic.
+func (impl Implementation) Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	kv := kl + ku
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	checkBandMatrix(n, n, kl, kv, ab, ldab)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 1
+	}
+	abT, ldabT := toLapackeBand(n, n, kl, kv, ab, ldab)
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+	}
+	rcond := make([]float64, 1)
+	_iwork := make([]int32, len(iwork))
+	for i, v := range iwork {
+		if v != int(int32(v)) {
+			panic("lapack: iwork element out of range")
+		}
+		_iwork[i] = int32(v)
+	}
+	lapacke.Dgbcon(byte(norm), n, kl, ku, abT, ldabT, ipiv32, anorm, rcond, work, _iwork)
+	for i, v := range _iwork {
+		iwork[i] = int(v)
+	}
+	return rcond[0]
+}
+
+// Dgbsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n band matrix with kl subdiagonals and ku superdiagonals,
+// and X and B are n×nrhs matrices.
+//
+// The LU decomposition with partial pivoting and row interchanges is used to
+// factor A as
+//  A = P * L * U
+// where P is a permutation matrix, L is a product of permutation and unit
+// lower triangular matrices with kl subdiagonals, and U is upper triangular
+// with kl+ku superdiagonals. On entry, ab contains the matrix A in the band
+// storage format described in the documentation of Dgbtrf, and on return it
+// contains the factors L and U. ldab must be at least 2*kl+ku+1. The row
+// pivot indices that define the permutation matrix P are stored in ipiv. ipiv
+// must have length at least n and Dgbsv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dgbsv returns false if U is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dgbsv(n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) (ok bool) {
+	kv := kl + ku
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkBandMatrix(n, n, kl, kv, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	abT, ldabT := toLapackeBand(n, n, kl, kv, ab, ldab)
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dgbsv(n, kl, ku, nrhs, abT, ldabT, ipiv32, b, ldb)
+	fromLapackeBand(n, n, kl, kv, ab, ldab, abT, ldabT)
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return ok
+}
+
+// Dgbtrf computes an LU factorization of the m×n band matrix A with kl
+// subdiagonals and ku superdiagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix with at
+// most kl non-zero elements below the diagonal in each column, and U is an
+// upper triangular band matrix with kl+ku superdiagonals.
+//
+// The matrix A is stored in ab in row-major band format as used by
+// blas64.Band, but with kl additional superdiagonals that are needed to store
+// the fill-in of U. The element A[i,j] is stored in ab[i*ldab+kl+j-i]. ldab
+// must be at least 2*kl+ku+1, otherwise Dgbtrf will panic. The elements of
+// the additional superdiagonals need not be set on entry.
+//
+// On return, U is stored in ab in the same format as an upper triangular band
+// matrix with kl+ku superdiagonals, that is U[i,j] is stored in
+// ab[i*ldab+kl+j-i] for i <= j <= i+kl+ku, and the multipliers used during
+// the factorization are stored in the elements of the subdiagonals of A.
+//
+// ipiv must have length at least min(m,n), otherwise Dgbtrf will panic. On
+// return, row i of the matrix was interchanged with row ipiv[i]. ipiv is
+// zero-indexed.
+//
+// Dgbtrf returns whether U is nonsingular. If ok is false, the factorization
+// has been completed, but division by zero will occur if it is used to solve a
+// system of equations.
+func (impl Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	kv := kl + ku
+	mn := min(m, n)
+	checkBandMatrix(m, n, kl, kv, ab, ldab)
+	if len(ipiv) < mn {
+		panic(badIpiv)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+	abT, ldabT := toLapackeBand(m, n, kl, kv, ab, ldab)
+	ipiv32 := make([]int32, mn)
+	ok = lapacke.Dgbtrf(m, n, kl, ku, abT, ldabT, ipiv32)
+	fromLapackeBand(m, n, kl, kv, ab, ldab, abT, ldabT)
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return ok
+}
+
+// Dgbtrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans or blas.ConjTrans,
+// where A is an n×n band matrix with kl subdiagonals and ku superdiagonals,
+// using the LU factorization computed by Dgbtrf. ab and ipiv contain the
+// factorization of A as returned by Dgbtrf, in particular ldab must be at
+// least 2*kl+ku+1. ipiv must have length at least n, otherwise Dgbtrs will
+// panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (impl Implementation) Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) {
+	kv := kl + ku
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkBandMatrix(n, n, kl, kv, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	abT, ldabT := toLapackeBand(n, n, kl, kv, ab, ldab)
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+	}
+	lapacke.Dgbtrs(trans, n, kl, ku, nrhs, abT, ldabT, ipiv32, b, ldb)
+}
+
 // Dgecon estimates the reciprocal of the condition number of the n×n matrix A
 // given the LU decomposition of the matrix. The condition number computed may
 // be based on the 1-norm or the ∞-norm.
@@ -973,6 +1477,80 @@ func (impl Implementation) Dgecon(norm lapack.MatrixNorm, n int, a []float64, ld
 	return rcond[0]
 }
 
+// Dgeequ computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. On return, r and c contain the
+// row and column scale factors, respectively, chosen so that the largest
+// element in each row and column of the matrix B with elements
+//  B[i,j] = r[i] * A[i,j] * c[j]
+// has absolute value 1.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dgeequ will panic.
+//
+// rowcnd is the ratio of the smallest r[i] to the largest r[i]. If
+// rowcnd >= 0.1 and amax is neither too large nor too small, it is not worth
+// scaling by r. colcnd is the ratio of the smallest c[j] to the largest c[j].
+// If colcnd >= 0.1, it is not worth scaling by c. amax is the absolute value
+// of the largest element of A. If amax is very close to overflow or very close
+// to underflow, the matrix should be scaled.
+//
+// Dgeequ returns false if A has a row or a column of zeros. In that case
+// rowcnd and colcnd are not computed, and r and c may only be partially
+// computed.
+//
+// Dgeequ does not attempt to prevent overflow or underflow of the elements of
+// B, see Dgeequb for a version that chooses powers of the radix as the scale
+// factors.
+func (impl Implementation) Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+	var _rowcnd, _colcnd, _amax [1]float64
+	ok = lapacke.Dgeequ(m, n, a, lda, r, c, _rowcnd[:], _colcnd[:], _amax[:])
+	return _rowcnd[0], _colcnd[0], _amax[0], ok
+}
+
+// Dgeequb computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. On return, r and c contain the
+// row and column scale factors, respectively. The scale factors are powers of
+// the radix chosen so that the largest element in each row and column of the
+// matrix B with elements
+//  B[i,j] = r[i] * A[i,j] * c[j]
+// has absolute value in the interval [1/radix, 1].
+//
+// Dgeequb differs from Dgeequ only in restricting the scale factors to powers
+// of the radix. Scaling by such factors does not introduce any rounding
+// errors, and the scaled entries of A keep their original significands.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dgeequb will panic.
+//
+// rowcnd and colcnd have the same meaning as in Dgeequ. amax approximates the
+// absolute value of the largest element of A by a power of the radix. Dgeequb
+// returns false if A has a row or a column of zeros.
+func (impl Implementation) Dgeequb(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+	var _rowcnd, _colcnd, _amax [1]float64
+	ok = lapacke.Dgeequb(m, n, a, lda, r, c, _rowcnd[:], _colcnd[:], _amax[:])
+	return _rowcnd[0], _colcnd[0], _amax[0], ok
+}
+
 // Dgelq2 computes the LQ factorization of the m×n matrix A.
 //
 // In an LQ factorization, L is a lower triangular m×n matrix, and Q is an n×n
@@ -1216,37 +1794,236 @@ func (impl Implementation) Dgels(trans blas.Transpose, m, n, nrhs int, a []float
 	return lapacke.Dgels(trans, m, n, nrhs, a, lda, b, ldb, work, lwork)
 }
 
-const noSVDO = "dgesvd: not coded for overwrite"
-
-// Dgesvd computes the singular value decomposition of the input matrix A.
+// Dgelsd computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient. The bidiagonal least squares problem is solved using a
+// divide and conquer algorithm.
 //
-// The singular value decomposition is
-//  A = U * Sigma * V^T
-// where Sigma is an m×n diagonal matrix containing the singular values of A,
-// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
-// min(m,n) columns of U and V are the left and right singular vectors of A
-// respectively.
-//
-// jobU and jobVT are options for computing the singular vectors. The behavior
-// is as follows
-//  jobU == lapack.SVDAll       All m columns of U are returned in u
-//  jobU == lapack.SVDInPlace   The first min(m,n) columns are returned in u
-//  jobU == lapack.SVDOverwrite The first min(m,n) columns of U are written into a
-//  jobU == lapack.SVDNone      The columns of U are not computed.
-// The behavior is the same for jobVT and the rows of V^T. At most one of jobU
-// and jobVT can equal lapack.SVDOverwrite, and Dgesvd will panic otherwise.
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X. nrhs must be at least 1.
 //
-// On entry, a contains the data for the m×n matrix A. During the call to Dgesvd
-// the data is overwritten. On exit, A contains the appropriate singular vectors
-// if either job is lapack.SVDOverwrite.
+// On return, a is overwritten.
 //
-// s is a slice of length at least min(m,n) and on exit contains the singular
-// values in decreasing order.
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond <= 0 or rcond >= 1, machine precision is used instead.
 //
-// u contains the left singular vectors on exit, stored column-wise. If
-// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDInPlace u is
-// of size m×min(m,n). If jobU == lapack.SVDOverwrite or lapack.SVDNone, u is
-// not used.
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// If lwork == -1, instead of performing Dgelsd, the optimal work length will
+// be stored into work[0] and the minimum length of iwork into iwork[0].
+// iwork must have length at least 3*mn*nlvl + 11*mn, where
+// mn = max(1,min(m,n)) and
+//  nlvl = max(0, int(log_2(mn/26)) + 1).
+//
+// Dgelsd returns the effective rank of A and whether the singular value
+// decomposition converged.
+func (impl Implementation) Dgelsd(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, ok bool) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	checkMatrix(m, n, a, lda)
+	minmn := min(m, n)
+	checkMatrix(max(m, n), nrhs, b, ldb)
+	if len(s) < minmn {
+		panic(badS)
+	}
+	rank32 := []int32{0}
+	if lwork == -1 {
+		iwork32 := []int32{0}
+		lapacke.Dgelsd(m, n, nrhs, a, lda, b, ldb, s, rcond, rank32, work, -1, iwork32)
+		iwork[0] = int(iwork32[0])
+		return 0, true
+	}
+	mn := max(1, minmn)
+	nlvl := max(0, int(math.Log2(float64(mn)/26))+1)
+	liwork := 3*mn*nlvl + 11*mn
+	if len(work) < lwork || len(iwork) < liwork {
+		panic(badWork)
+	}
+	iwork32 := make([]int32, liwork)
+	ok = lapacke.Dgelsd(m, n, nrhs, a, lda, b, ldb, s, rcond, rank32, work, lwork, iwork32)
+	return int(rank32[0]), ok
+}
+
+// Dgelss computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient.
+//
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X. nrhs must be at least 1.
+//
+// On return, a is overwritten with its right singular vectors, stored
+// row-wise in its first min(m,n) rows.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond < 0, machine precision is used instead.
+//
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  max(1, 3*min(m,n) + max(2*min(m,n), max(m,n), nrhs)).
+// If lwork == -1, instead of performing Dgelss, the optimal work length will
+// be stored into work[0].
+//
+// Dgelss returns the effective rank of A and whether the singular value
+// decomposition converged.
+func (impl Implementation) Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	checkMatrix(m, n, a, lda)
+	minmn := min(m, n)
+	checkMatrix(max(m, n), nrhs, b, ldb)
+	if len(s) < minmn {
+		panic(badS)
+	}
+	rank32 := []int32{0}
+	if lwork == -1 {
+		lapacke.Dgelss(m, n, nrhs, a, lda, b, ldb, s, rcond, rank32, work, -1)
+		return 0, true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < max(1, 3*minmn+max(2*minmn, max(max(m, n), nrhs))) {
+		panic(badWork)
+	}
+	ok = lapacke.Dgelss(m, n, nrhs, a, lda, b, ldb, s, rcond, rank32, work, lwork)
+	return int(rank32[0]), ok
+}
+
+// Dgelsy computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using a complete orthogonal factorization of the m×n matrix A, which may be
+// rank-deficient.
+//
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X.
+//
+// On return, a contains its complete orthogonal factorization.
+//
+// jpvt specifies a column pivot to be applied to A. If jpvt[j] is at least
+// zero, the j-th column of A is permuted to the front of A*P (a leading
+// column), and if jpvt[j] is -1, the j-th column of A is a free column. On
+// return, jpvt holds the permutation that was applied; the j-th column of A*P
+// was the jpvt[j] column of A. jpvt must have length n.
+//
+// rcond is used to determine the effective rank of A, which is defined as
+// the order of the largest leading triangular submatrix R11 in the QR
+// factorization with pivoting of A, whose estimated condition number is less
+// than 1/rcond.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+//  max(min(m,n) + 3*n + 1, 2*min(m,n) + nrhs),
+// or 1 if min(m,n) == 0 or nrhs == 0, otherwise Dgelsy will panic. For
+// optimal performance lwork should be larger. If lwork == -1, instead of
+// performing Dgelsy, the optimal work length will be stored into work[0].
+//
+// Dgelsy returns the effective rank of A.
+func (impl Implementation) Dgelsy(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, jpvt []int, rcond float64, work []float64, lwork int) (rank int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 0 {
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(m, n, a, lda)
+	checkMatrix(max(m, n), nrhs, b, ldb)
+	if len(jpvt) != n {
+		panic(badIpiv)
+	}
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	minmn := min(m, n)
+	jpvt32 := make([]int32, len(jpvt))
+	rank32 := []int32{0}
+	if lwork == -1 {
+		lapacke.Dgelsy(m, n, nrhs, a, lda, b, ldb, jpvt32, rcond, rank32, work, -1)
+		return 0
+	}
+	if minmn > 0 && nrhs > 0 && lwork < max(minmn+3*n+1, 2*minmn+nrhs) {
+		panic(badWork)
+	}
+
+	for i, v := range jpvt {
+		if v < -1 || v != int(int32(v)) {
+			panic("lapack: jpvt element out of range")
+		}
+		if v >= 0 {
+			jpvt32[i] = 1
+		}
+	}
+	lapacke.Dgelsy(m, n, nrhs, a, lda, b, ldb, jpvt32, rcond, rank32, work, max(1, lwork))
+	for i, v := range jpvt32 {
+		jpvt[i] = int(v - 1)
+	}
+	return int(rank32[0])
+}
+
+const noSVDO = "dgesvd: not coded for overwrite"
+
+// Dgesvd computes the singular value decomposition of the input matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobU and jobVT are options for computing the singular vectors. The behavior
+// is as follows
+//  jobU == lapack.SVDAll       All m columns of U are returned in u
+//  jobU == lapack.SVDInPlace   The first min(m,n) columns are returned in u
+//  jobU == lapack.SVDOverwrite The first min(m,n) columns of U are written into a
+//  jobU == lapack.SVDNone      The columns of U are not computed.
+// The behavior is the same for jobVT and the rows of V^T. At most one of jobU
+// and jobVT can equal lapack.SVDOverwrite, and Dgesvd will panic otherwise.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Dgesvd
+// the data is overwritten. On exit, A contains the appropriate singular vectors
+// if either job is lapack.SVDOverwrite.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobU == lapack.SVDAll, u is of size m×m. If jobU == lapack.SVDInPlace u is
+// of size m×min(m,n). If jobU == lapack.SVDOverwrite or lapack.SVDNone, u is
+// not used.
 //
 // vt contains the left singular vectors on exit, stored rowwise. If
 // jobV == lapack.SVDAll, vt is of size n×m. If jobVT == lapack.SVDInPlace vt is
@@ -1297,6 +2074,195 @@ func (impl Implementation) Dgesvd(jobU, jobVT lapack.SVDJob, m, n int, a []float
 	return lapacke.Dgesvd(lapack.Job(jobU), lapack.Job(jobVT), m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork)
 }
 
+// Dgesdd computes the singular value decomposition of the input matrix A
+// using a divide and conquer algorithm.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies the singular vectors that are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll       All m columns of U and all n rows of V^T are
+//                              returned in u and vt.
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U and rows of V^T
+//                              are returned in u and vt.
+//  jobz == lapack.SVDOverwrite If m >= n, the first n columns of U are written
+//                              into a and all rows of V^T are returned in vt.
+//                              Otherwise, all columns of U are returned in u
+//                              and the first m rows of V^T are written into a.
+//  jobz == lapack.SVDNone      No columns of U or rows of V^T are computed.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to
+// Dgesdd the data is overwritten. On exit, A contains the appropriate singular
+// vectors if jobz is lapack.SVDOverwrite.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m < n, u is of
+// size m×m. If jobz == lapack.SVDInPlace, u is of size m×min(m,n). Otherwise
+// u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m >= n, vt is of
+// size n×n. If jobz == lapack.SVDInPlace, vt is of size min(m,n)×n. Otherwise
+// vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size
+// of the slice. If lwork == -1, instead of performing Dgesdd, the optimal work
+// length will be stored into work[0]. iwork must have length at least
+// 8*min(m,n).
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	minmn := min(m, n)
+	switch jobz {
+	default:
+		panic(badSVDJob)
+	case lapack.SVDAll, lapack.SVDInPlace, lapack.SVDOverwrite, lapack.SVDNone:
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(m, n, a, lda)
+	if jobz == lapack.SVDAll || (jobz == lapack.SVDOverwrite && m < n) {
+		checkMatrix(m, m, u, ldu)
+	} else if jobz == lapack.SVDInPlace {
+		checkMatrix(m, minmn, u, ldu)
+	} else {
+		ldu = max(1, ldu)
+	}
+	if jobz == lapack.SVDAll || (jobz == lapack.SVDOverwrite && m >= n) {
+		checkMatrix(n, n, vt, ldvt)
+	} else if jobz == lapack.SVDInPlace {
+		checkMatrix(minmn, n, vt, ldvt)
+	} else {
+		ldvt = max(1, ldvt)
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+	if lwork == -1 {
+		return lapacke.Dgesdd(lapack.Job(jobz), m, n, a, lda, s, u, ldu, vt, ldvt, work, -1, []int32{0})
+	}
+	if len(work) < lwork || len(iwork) < 8*minmn {
+		panic(badWork)
+	}
+	iwork32 := make([]int32, 8*minmn)
+	return lapacke.Dgesdd(lapack.Job(jobz), m, n, a, lda, s, u, ldu, vt, ldvt, work, lwork, iwork32)
+}
+
+// Dgesvdx computes selected singular values and, optionally, the
+// corresponding left and right singular vectors of an m×n matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// The singular values to compute are selected by rng. If rng is
+// lapack.RangeAll, all singular values are computed. If rng is
+// lapack.RangeInterval, the singular values in the half-open interval (vl,vu]
+// are computed, and vl and vu must satisfy 0 <= vl < vu. If rng is
+// lapack.RangeIndex, the il-th through iu-th largest singular values are
+// computed, with indices starting at 0, and il and iu must satisfy
+// 0 <= il <= iu < min(m,n).
+//
+// jobU and jobVT specify whether the left and right singular vectors
+// corresponding to the selected singular values are computed. They must be
+// either lapack.SVDInPlace, in which case the singular vectors are computed,
+// or lapack.SVDNone.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to
+// Dgesvdx the data is overwritten.
+//
+// On return, the first ns elements of s contain the selected singular values
+// in decreasing order. s must have length at least min(m,n).
+//
+// If jobU == lapack.SVDInPlace, the first ns columns of u contain on return
+// the left singular vectors corresponding to the selected singular values.
+// If jobVT == lapack.SVDInPlace, the first ns rows of vt contain on return
+// the right singular vectors, stored row-wise. u must be m×(iu-il+1) and vt
+// (iu-il+1)×n if rng is lapack.RangeIndex, and otherwise u must be
+// m×min(m,n) and vt min(m,n)×n. u and vt are not referenced if the
+// corresponding singular vectors are not computed.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size
+// of the slice. If lwork == -1, instead of performing Dgesvdx, the optimal
+// work length will be stored into work[0].
+//
+// iwork must have length at least 10*min(m,n).
+//
+// Dgesvdx returns the number of singular values found and whether the
+// computation succeeded.
+func (impl Implementation) Dgesvdx(jobU, jobVT lapack.SVDJob, rng lapack.EVRange, m, n int, a []float64, lda int, vl, vu float64, il, iu int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ns int, ok bool) {
+	minmn := min(m, n)
+	wantu := jobU == lapack.SVDInPlace
+	wantvt := jobVT == lapack.SVDInPlace
+	switch {
+	case !wantu && jobU != lapack.SVDNone:
+		panic(badSVDJob)
+	case !wantvt && jobVT != lapack.SVDNone:
+		panic(badSVDJob)
+	case rng != lapack.RangeAll && rng != lapack.RangeInterval && rng != lapack.RangeIndex:
+		panic(badRange)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.RangeInterval && vl < 0:
+		panic(negVl)
+	case rng == lapack.RangeInterval && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.RangeIndex && (il < 0 || il > max(0, minmn-1)):
+		panic(badIl)
+	case rng == lapack.RangeIndex && (iu < min(minmn-1, il) || iu >= max(1, minmn)):
+		panic(badIu)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(s) < minmn {
+		panic(badS)
+	}
+	ncol := minmn
+	if rng == lapack.RangeIndex {
+		ncol = iu - il + 1
+	}
+	var jobu, jobvt lapack.Job = 'N', 'N'
+	if wantu {
+		checkMatrix(m, ncol, u, ldu)
+		jobu = 'V'
+	} else {
+		ldu = max(1, ldu)
+	}
+	if wantvt {
+		checkMatrix(ncol, n, vt, ldvt)
+		jobvt = 'V'
+	} else {
+		ldvt = max(1, ldvt)
+	}
+	ns32 := []int32{0}
+	if lwork == -1 {
+		lapacke.Dgesvdx(jobu, jobvt, byte(rng), m, n, a, lda, vl, vu, il+1, iu+1, ns32, s, u, ldu, vt, ldvt, work, -1, []int32{0})
+		return 0, true
+	}
+	if len(work) < lwork || len(iwork) < 10*minmn {
+		panic(badWork)
+	}
+	iwork32 := make([]int32, 12*minmn)
+	ok = lapacke.Dgesvdx(jobu, jobvt, byte(rng), m, n, a, lda, vl, vu, il+1, iu+1, ns32, s, u, ldu, vt, ldvt, work, lwork, iwork32)
+	return int(ns32[0]), ok
+}
+
 // Dgetf2 computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of a into
 //  A = P * L * U
@@ -1415,69 +2381,555 @@ func (impl Implementation) Dgetrs(trans blas.Transpose, n, nrhs int, a []float64
 	lapacke.Dgetrs(trans, n, nrhs, a, lda, ipiv32, b, ldb)
 }
 
-// Dggsvd3 computes the generalized singular value decomposition (GSVD)
-// of an m×n matrix A and p×n matrix B:
-//  U^T*A*Q = D1*[ 0 R ]
-//
-//  V^T*B*Q = D2*[ 0 R ]
-// where U, V and Q are orthogonal matrices.
-//
-// Dggsvd3 returns k and l, the dimensions of the sub-blocks. k+l
-// is the effective numerical rank of the (m+p)×n matrix [ A^T B^T ]^T.
-// R is a (k+l)×(k+l) nonsingular upper triangular matrix, D1 and
-// D2 are m×(k+l) and p×(k+l) diagonal matrices and of the following
-// structures, respectively:
-//
-// If m-k-l >= 0,
-//
-//                    k  l
-//       D1 =     k [ I  0 ]
-//                l [ 0  C ]
-//            m-k-l [ 0  0 ]
-//
-//                  k  l
-//       D2 = l   [ 0  S ]
-//            p-l [ 0  0 ]
-//
-//               n-k-l  k    l
-//  [ 0 R ] = k [  0   R11  R12 ] k
-//            l [  0    0   R22 ] l
-//
-// where
-//
-//  C = diag( alpha_k, ... , alpha_{k+l} ),
-//  S = diag( beta_k,  ... , beta_{k+l} ),
-//  C^2 + S^2 = I.
-//
-// R is stored in
-//  A[0:k+l, n-k-l:n]
-// on exit.
-//
-// If m-k-l < 0,
-//
-//                 k m-k k+l-m
-//      D1 =   k [ I  0    0  ]
-//           m-k [ 0  C    0  ]
-//
-//                   k m-k k+l-m
-//      D2 =   m-k [ 0  S    0  ]
-//           k+l-m [ 0  0    I  ]
-//             p-l [ 0  0    0  ]
-//
-//                 n-k-l  k   m-k  k+l-m
-//  [ 0 R ] =    k [ 0    R11  R12  R13 ]
-//             m-k [ 0     0   R22  R23 ]
-//           k+l-m [ 0     0    0   R33 ]
-//
-// where
-//  C = diag( alpha_k, ... , alpha_m ),
-//  S = diag( beta_k,  ... , beta_m ),
-//  C^2 + S^2 = I.
-//
-//  R = [ R11 R12 R13 ] is stored in A[1:m, n-k-l+1:n]
-//      [  0  R22 R23 ]
-// and R33 is stored in
-//  B[m-k:l, n+m-k-l:n] on exit.
+// Dgerfs improves the computed solution to a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// and provides error bounds and backward error estimates for the solution.
+//
+// A is an n×n matrix and af contains its LU factorization with the pivot
+// indices in ipiv as computed by Dgetrf. B is an n×nrhs matrix of right hand
+// sides and x on entry contains the n×nrhs solution matrix as computed by
+// Dgetrs. On return, x contains the improved solution.
+//
+// For each right hand side j, ferr[j] contains on return an estimated bound
+// for the forward error
+//  max_i |X[i,j] - Xtrue[i,j]| / max_i |X[i,j]|,
+// where Xtrue is the true solution. The estimate is almost always a slight
+// overestimate of the true error. berr[j] contains the componentwise relative
+// backward error of the j-th solution vector, that is, the smallest relative
+// change in any element of A or B that makes X[:,j] an exact solution.
+//
+// ferr and berr must have length at least nrhs, work must have length at
+// least 3*n and iwork must have length at least n, otherwise Dgerfs will
+// panic.
+func (impl Implementation) Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ipiv) < n:
+		panic(badIpiv)
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 3*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+	if n == 0 || nrhs == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+	}
+	lapacke.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv32, b, ldb, x, ldx, ferr, berr, work, make([]int32, n))
+}
+
+// Dgesv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n matrix and X and B are n×nrhs matrices.
+//
+// The LU decomposition with partial pivoting and row interchanges is used to
+// factor A as
+//  A = P * L * U
+// where P is a permutation matrix, L is unit lower triangular, and U is upper
+// triangular. On return, the factors L and U are stored in a; the unit
+// diagonal elements of L are not stored. The row pivot indices that define
+// the permutation matrix P are stored in ipiv. ipiv must have length at least
+// n and Dgesv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dgesv returns false if U is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dgesv(n, nrhs, a, lda, ipiv32, b, ldb)
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return ok
+}
+
+// Dgesvx uses the LU factorization to compute the solution to a real system
+// of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n matrix and X and B are n×nrhs matrices. Error bounds on
+// the solution and a condition estimate are also provided.
+//
+// Dgesvx performs the following steps:
+//
+//  1. If fact == lapack.EquilibrateAndFactor, real scaling factors are
+//     computed by Dgeequ to equilibrate the system:
+//      trans == blas.NoTrans: diag(r)*A*diag(c) * inv(diag(c))*X = diag(r)*B,
+//      trans == blas.Trans:   (diag(r)*A*diag(c))^T * inv(diag(r))*X = diag(c)*B.
+//     Whether or not the system will be equilibrated depends on the scaling
+//     of the matrix A, but if equilibration is used, A is overwritten by
+//     diag(r)*A*diag(c) and B by diag(r)*B if trans == blas.NoTrans, or by
+//     diag(c)*B if trans == blas.Trans.
+//  2. If fact == lapack.NotFactored or lapack.EquilibrateAndFactor, the LU
+//     decomposition is used to factor the matrix A (after equilibration if
+//     fact == lapack.EquilibrateAndFactor) as
+//      A = P * L * U,
+//     where P is a permutation matrix, L is a unit lower triangular matrix,
+//     and U is upper triangular.
+//  3. If some U[i,i] == 0, so that U is exactly singular, then the routine
+//     returns with ok == false. Otherwise, the factored form of A is used to
+//     estimate the condition number of the matrix A. If the reciprocal of the
+//     condition number is less than machine precision, ok == false is
+//     returned as a warning, but the routine still goes on to solve for X and
+//     compute error bounds as described below.
+//  4. The system of equations is solved for X using the factored form of A.
+//  5. Iterative refinement is applied to improve the computed solution matrix
+//     and calculate error bounds and backward error estimates for it.
+//  6. If equilibration was used, the matrix X is premultiplied by diag(c) if
+//     trans == blas.NoTrans, or by diag(r) if trans == blas.Trans, so that it
+//     solves the original system before equilibration.
+//
+// If fact == lapack.Factored, af and ipiv must contain on entry the LU
+// factorization of A as computed by Dgetrf, and equed specifies the form of
+// equilibration that was applied to A, which must then contain the
+// equilibrated matrix. If equed is not lapack.NoEquilibration, the scale
+// factors in r and/or c must be positive. If fact is not lapack.Factored,
+// equed is ignored, and on return af and ipiv contain the LU factorization of
+// the (equilibrated) matrix A.
+//
+// r and c must have length at least n, and on return contain the row and
+// column scale factors of A, respectively. They are only referenced if
+// row or column equilibration is used.
+//
+// On entry, b contains the n×nrhs right hand side matrix B. On return, b is
+// overwritten by its equilibrated form if equilibration was used, and is not
+// modified otherwise. x is an n×nrhs matrix that on return contains the
+// solution matrix X of the original system.
+//
+// ferr and berr must have length at least nrhs and on return contain the
+// estimated forward error bound and the componentwise relative backward error
+// of each solution vector, respectively. See Dgerfs for details.
+//
+// work must have length at least 4*n and iwork must have length at least n,
+// otherwise Dgesvx will panic.
+//
+// Dgesvx returns the form of equilibration that was applied to A in equedOut,
+// the estimate of the reciprocal condition number of A after equilibration in
+// rcond, and the reciprocal pivot growth factor
+//  min_j ( max_i |A[i,j]| / max_i |U[i,j]| )
+// in rpvgrw. If rpvgrw is much less than 1, the stability of the LU
+// factorization of the (equilibrated) matrix A could be poor. This also means
+// that the solution X, condition estimate rcond, and forward error bound ferr
+// could be unreliable. If the factorization fails because U is exactly
+// singular, rpvgrw contains the reciprocal pivot growth factor for the leading
+// columns of A up to and including the first column with a zero pivot, rcond
+// is zero, and X and the error bounds are not computed.
+func (impl Implementation) Dgesvx(fact lapack.FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	switch {
+	case fact != lapack.NotFactored && fact != lapack.EquilibrateAndFactor && fact != lapack.Factored:
+		panic("lapack: bad FactorizationType")
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ipiv) < n:
+		panic(badIpiv)
+	case len(r) < n:
+		panic("lapack: r has insufficient length")
+	case len(c) < n:
+		panic("lapack: c has insufficient length")
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 4*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+	if fact != lapack.Factored {
+		equed = lapack.NoEquilibration
+	}
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return equed, 1, 1, true
+	}
+
+	ipiv32 := make([]int32, n)
+	if fact == lapack.Factored {
+		for i, v := range ipiv[:n] {
+			ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+		}
+	}
+	_equed := []byte{byte(equed)}
+	var _rcond [1]float64
+	ok = lapacke.Dgesvx(byte(fact), trans, n, nrhs, a, lda, af, ldaf, ipiv32, _equed, r, c, b, ldb, x, ldx, _rcond[:], ferr, berr, work, make([]int32, n))
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	equedOut = lapack.EquilibrationType(_equed[0])
+	rcond = _rcond[0]
+
+	// DGESVX returns the reciprocal pivot growth factor based on the max
+	// norm of A and U. Compute the columnwise factor used by the native
+	// implementation instead.
+	ncols := n
+	if !ok && rcond == 0 {
+		for i := 0; i < n; i++ {
+			if af[i*ldaf+i] == 0 {
+				ncols = i + 1
+				break
+			}
+		}
+	}
+	rpvgrw = 1
+	for j := 0; j < ncols; j++ {
+		var amax, umax float64
+		for i := 0; i < n; i++ {
+			amax = math.Max(amax, math.Abs(a[i*lda+j]))
+		}
+		for i := 0; i <= j; i++ {
+			umax = math.Max(umax, math.Abs(af[i*ldaf+j]))
+		}
+		if umax != 0 {
+			rpvgrw = math.Min(rpvgrw, amax/umax)
+		}
+	}
+	return equedOut, rcond, rpvgrw, ok
+}
+
+// Dggglm solves a general Gauss-Markov linear model (GLM) problem
+//  minimize |y|_2 subject to d = A*x + B*y,
+// where A is an n×m matrix, B is an n×p matrix, and d is a given vector of
+// length n. It must hold that
+//  m <= n <= m+p,
+// and
+//  rank(A) = m and rank([A B]) = n,
+// which ensure that the problem has a unique solution. The problem is solved
+// using a generalized QR factorization of the matrices A and B.
+//
+// In particular, if B is square and nonsingular, the GLM problem is
+// equivalent to the weighted linear least squares problem
+//  minimize |inv(B)*(d-A*x)|_2.
+//
+// On return, a and b contain their generalized QR factorization, x of length
+// m and y of length p contain the solution. d is overwritten on return.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, n+m+p), otherwise Dggglm will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggglm, the optimal
+// work length will be stored into work[0].
+//
+// Dggglm returns whether the solution was computed successfully. If the upper
+// triangular factor T22 of B in the generalized QR factorization is singular,
+// which means that rank([A B]) < n, or if the upper triangular factor R11 of
+// A is singular, which means that rank(A) < m, Dggglm returns false.
+func (impl Implementation) Dggglm(n, m, p int, a []float64, lda int, b []float64, ldb int, d, x, y, work []float64, lwork int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case m < 0 || n < m:
+		panic("lapack: bad value of m")
+	case p < 0 || m+p < n:
+		panic("lapack: bad value of p")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, n+m+p) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, m, a, lda)
+	checkMatrix(n, p, b, ldb)
+	if len(d) < n {
+		panic("lapack: d has insufficient length")
+	}
+	if len(x) < m {
+		panic("lapack: x has insufficient length")
+	}
+	if len(y) < p {
+		panic("lapack: y has insufficient length")
+	}
+
+	return lapacke.Dggglm(n, m, p, a, lda, b, ldb, d, x, y, work, lwork)
+}
+
+// Dgglse solves the linear equality-constrained least squares problem
+//  minimize |c - A*x|_2 subject to B*x = d,
+// where A is an m×n matrix, B is a p×n matrix, c is a vector of length m, and
+// d is a vector of length p. It must hold that
+//  p <= n <= m+p,
+// and
+//  rank(B) = p and rank([A; B]) = n,
+// which ensure that the problem has a unique solution. The problem is solved
+// using a generalized RQ factorization of the matrices B and A.
+//
+// On return, a and b contain their generalized RQ factorization, and x of
+// length n contains the solution. On return, c is overwritten and the
+// residual sum of squares of the solution is given by the sum of squares of
+// the elements c[n-p:m].
+//
+// d is overwritten on return.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, m+n+p), otherwise Dgglse will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dgglse, the optimal
+// work length will be stored into work[0].
+//
+// Dgglse returns whether the solution was computed successfully. If the upper
+// triangular factor T12 of B in the generalized RQ factorization is singular,
+// which means that rank(B) < p, or if the upper triangular factor R11 of A is
+// singular, which means that rank([A; B]) < n, Dgglse returns false.
+func (impl Implementation) Dgglse(m, n, p int, a []float64, lda int, b []float64, ldb int, c, d, x, work []float64, lwork int) (ok bool) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case p < 0 || n < p || m+p < n:
+		panic("lapack: bad value of p")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, m+n+p) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(m, n, a, lda)
+	checkMatrix(p, n, b, ldb)
+	if len(c) < m {
+		panic("lapack: c has insufficient length")
+	}
+	if len(d) < p {
+		panic("lapack: d has insufficient length")
+	}
+	if len(x) < n {
+		panic("lapack: x has insufficient length")
+	}
+
+	return lapacke.Dgglse(m, n, p, a, lda, b, ldb, c, d, x, work, lwork)
+}
+
+// Dggqrf computes a generalized QR factorization of the n×m matrix A and the
+// n×p matrix B:
+//  A = Q * R,    B = Q * T * Z,
+// where Q is an n×n orthogonal matrix, Z is a p×p orthogonal matrix, and R
+// and T assume one of the forms
+//  R = [ R11 ] m  , if n >= m,    R = [ R11 R12 ] n    , if n < m,
+//      [  0  ] n-m                      n   m-n
+//        m
+// where R11 is upper triangular, and
+//  T = [ 0 T12 ] n  , if n <= p,   T = [ T11 ] n-p , if n > p,
+//       p-n  n                          [ T21 ] p
+//                                          p
+// where T12 or T21 is a p×p upper triangular matrix.
+//
+// In particular, if B is square and nonsingular, the generalized QR
+// factorization of A and B implicitly gives the QR factorization of
+// inv(B)*A:
+//  inv(B)*A = Z^T * (inv(T)*R).
+//
+// On return, the elements on and above the diagonal of a contain the
+// min(n,m)×m upper trapezoidal matrix R, and the elements below the diagonal,
+// together with taua, represent the orthogonal matrix Q as a product of
+// min(n,m) elementary reflectors as returned by Dgeqrf. taua must have length
+// at least min(n,m).
+//
+// On return, if n <= p, the upper triangle of the subarray B[0:n, p-n:p]
+// contains the n×n upper triangular matrix T, and if n > p, the elements on
+// and above the (n-p)-th subdiagonal contain the n×p upper trapezoidal matrix
+// T. The remaining elements, together with taub, represent the orthogonal
+// matrix Z as a product of min(n,p) elementary reflectors as returned by
+// Dgerqf. taub must have length at least min(n,p).
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, n, m, p), otherwise Dggqrf will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggqrf, the optimal
+// work length will be stored into work[0].
+//
+// Dggqrf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggqrf(n, m, p int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case m < 0:
+		panic(mLT0)
+	case p < 0:
+		panic("lapack: p < 0")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, max(n, max(m, p))) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, m, a, lda)
+	checkMatrix(n, p, b, ldb)
+	if len(taua) < min(n, m) || len(taub) < min(n, p) {
+		panic(badTau)
+	}
+
+	lapacke.Dggqrf(n, m, p, a, lda, taua, b, ldb, taub, work, lwork)
+}
+
+// Dggrqf computes a generalized RQ factorization of the m×n matrix A and the
+// p×n matrix B:
+//  A = R * Q,    B = Z * T * Q,
+// where Q is an n×n orthogonal matrix, Z is a p×p orthogonal matrix, and R
+// and T assume one of the forms
+//  R = [ 0 R12 ] m  , if m <= n,   R = [ R11 ] m-n , if m > n,
+//       n-m  m                         [ R21 ] n
+//                                         n
+// where R12 or R21 is upper triangular, and
+//  T = [ T11 ] n  , if p >= n,    T = [ T11 T12 ] p    , if p < n,
+//      [  0  ] p-n                      p   n-p
+//        n
+// where T11 is upper triangular.
+//
+// In particular, if B is square and nonsingular, the generalized RQ
+// factorization of A and B implicitly gives the RQ factorization of
+// A*inv(B):
+//  A*inv(B) = (R*inv(T))*Z^T.
+//
+// On return, if m <= n, the upper triangle of the subarray A[0:m, n-m:n]
+// contains the m×m upper triangular matrix R, and if m > n, the elements on
+// and above the (m-n)-th subdiagonal contain the m×n upper trapezoidal matrix
+// R. The remaining elements, together with taua, represent the orthogonal
+// matrix Q as a product of min(m,n) elementary reflectors as returned by
+// Dgerqf. taua must have length at least min(m,n).
+//
+// On return, the elements on and above the diagonal of b contain the
+// min(p,n)×n upper trapezoidal matrix T, and the elements below the diagonal,
+// together with taub, represent the orthogonal matrix Z as a product of
+// min(p,n) elementary reflectors as returned by Dgeqrf. taub must have length
+// at least min(p,n).
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, m, p, n), otherwise Dggrqf will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggrqf, the optimal
+// work length will be stored into work[0].
+//
+// Dggrqf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggrqf(m, p, n int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case p < 0:
+		panic("lapack: p < 0")
+	case n < 0:
+		panic(nLT0)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, max(m, max(p, n))) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(m, n, a, lda)
+	checkMatrix(p, n, b, ldb)
+	if len(taua) < min(m, n) || len(taub) < min(p, n) {
+		panic(badTau)
+	}
+
+	lapacke.Dggrqf(m, p, n, a, lda, taua, b, ldb, taub, work, lwork)
+}
+
+// Dggsvd3 computes the generalized singular value decomposition (GSVD)
+// of an m×n matrix A and p×n matrix B:
+//  U^T*A*Q = D1*[ 0 R ]
+//
+//  V^T*B*Q = D2*[ 0 R ]
+// where U, V and Q are orthogonal matrices.
+//
+// Dggsvd3 returns k and l, the dimensions of the sub-blocks. k+l
+// is the effective numerical rank of the (m+p)×n matrix [ A^T B^T ]^T.
+// R is a (k+l)×(k+l) nonsingular upper triangular matrix, D1 and
+// D2 are m×(k+l) and p×(k+l) diagonal matrices and of the following
+// structures, respectively:
+//
+// If m-k-l >= 0,
+//
+//                    k  l
+//       D1 =     k [ I  0 ]
+//                l [ 0  C ]
+//            m-k-l [ 0  0 ]
+//
+//                  k  l
+//       D2 = l   [ 0  S ]
+//            p-l [ 0  0 ]
+//
+//               n-k-l  k    l
+//  [ 0 R ] = k [  0   R11  R12 ] k
+//            l [  0    0   R22 ] l
+//
+// where
+//
+//  C = diag( alpha_k, ... , alpha_{k+l} ),
+//  S = diag( beta_k,  ... , beta_{k+l} ),
+//  C^2 + S^2 = I.
+//
+// R is stored in
+//  A[0:k+l, n-k-l:n]
+// on exit.
+//
+// If m-k-l < 0,
+//
+//                 k m-k k+l-m
+//      D1 =   k [ I  0    0  ]
+//           m-k [ 0  C    0  ]
+//
+//                   k m-k k+l-m
+//      D2 =   m-k [ 0  S    0  ]
+//           k+l-m [ 0  0    I  ]
+//             p-l [ 0  0    0  ]
+//
+//                 n-k-l  k   m-k  k+l-m
+//  [ 0 R ] =    k [ 0    R11  R12  R13 ]
+//             m-k [ 0     0   R22  R23 ]
+//           k+l-m [ 0     0    0   R33 ]
+//
+// where
+//  C = diag( alpha_k, ... , alpha_m ),
+//  S = diag( beta_k,  ... , beta_m ),
+//  C^2 + S^2 = I.
+//
+//  R = [ R11 R12 R13 ] is stored in A[1:m, n-k-l+1:n]
+//      [  0  R22 R23 ]
+// and R33 is stored in
+//  B[m-k:l, n+m-k-l:n] on exit.
 //
 // Dggsvd3 computes C, S, R, and optionally the orthogonal transformation
 // matrices U, V and Q.
@@ -1673,68 +3125,313 @@ func (impl Implementation) Dggsvp3(jobU, jobV, jobQ lapack.GSVDJob, m, p, n int,
 	return int(_k[0]), int(_l[0])
 }
 
-// Dorgbr generates one of the matrices Q or P^T computed by Dgebrd.
-// See Dgebrd for the description of Q and P^T.
+// Dgtcon estimates the reciprocal of the condition number of an n×n
+// tridiagonal matrix A given its LU factorization computed by Dgttrf. The
+// condition number computed may be based on the 1-norm or the ∞-norm.
 //
-// If vect == lapack.ApplyQ, then a is assumed to have been an m×k matrix and
-// Q is of order m. If m >= k, then Dorgbr returns the first n columns of Q
-// where m >= n >= k. If m < k, then Dorgbr returns Q as an m×m matrix.
+// dl, d, du, du2 and ipiv contain the factorization of A as returned by
+// Dgttrf. dl, du and du2 must have length at least n-1, n-1 and n-2,
+// respectively, and ipiv must have length at least n, otherwise Dgtcon will
+// panic.
 //
-// If vect == lapack.ApplyP, then A is assumed to have been a k×n matrix, and
-// P^T is of order n. If k < n, then Dorgbr returns the first m rows of P^T,
-// where n >= m >= k. If k >= n, then Dorgbr returns P^T as an n×n matrix.
-func (impl Implementation) Dorgbr(vect lapack.DecompUpdate, m, n, k int, a []float64, lda int, tau, work []float64, lwork int) {
-	mn := min(m, n)
-	wantq := vect == lapack.ApplyQ
-	if wantq {
-		if m < n || n < min(m, k) || m < min(m, k) {
-			panic(badDims)
-		}
-	} else {
-		if n < m || m < min(n, k) || n < min(n, k) {
-			panic(badDims)
-		}
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dgtcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgtcon will panic
+// otherwise.
+func (impl Implementation) Dgtcon(norm lapack.MatrixNorm, n int, dl, d, du, du2 []float64, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
 	}
-	if wantq {
-		checkMatrix(m, k, a, lda)
-	} else {
-		checkMatrix(k, n, a, lda)
+	if len(dl) < n-1 {
+		panic(badDL)
 	}
-	if lwork == -1 {
-		work[0] = float64(mn)
-		return
+	if len(d) < n {
+		panic(badD)
 	}
-	if len(work) < lwork {
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 2*n {
 		panic(badWork)
 	}
-	if lwork < mn {
+	if len(iwork) < n {
 		panic(badWork)
 	}
-	lapacke.Dorgbr(byte(vect), m, n, k, a, lda, tau, work, lwork)
+	if n == 0 {
+		return 1
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+	}
+	rcond := make([]float64, 1)
+	_iwork := make([]int32, len(iwork))
+	for i, v := range iwork {
+		if v != int(int32(v)) {
+			panic("lapack: iwork element out of range")
+		}
+		_iwork[i] = int32(v)
+	}
+	lapacke.Dgtcon(byte(norm), n, dl, d, du, du2, ipiv32, anorm, rcond, work, _iwork)
+	for i, v := range _iwork {
+		iwork[i] = int(v)
+	}
+	return rcond[0]
 }
 
-// Dorghr generates an n×n orthogonal matrix Q which is defined as the product
-// of ihi-ilo elementary reflectors:
-//  Q = H_{ilo} H_{ilo+1} ... H_{ihi-1}.
-//
-// a and lda represent an n×n matrix that contains the elementary reflectors, as
-// returned by Dgehrd. On return, a is overwritten by the n×n orthogonal matrix
-// Q. Q will be equal to the identity matrix except in the submatrix
-// Q[ilo+1:ihi+1,ilo+1:ihi+1].
-//
-// ilo and ihi must have the same values as in the previous call of Dgehrd. It
-// must hold that
-//  0 <= ilo <= ihi < n,  if n > 0,
-//  ilo = 0, ihi = -1,    if n == 0.
-//
-// tau contains the scalar factors of the elementary reflectors, as returned by
-// Dgehrd. tau must have length n-1.
-//
-// work must have length at least max(1,lwork) and lwork must be at least
-// ihi-ilo. For optimum performance lwork must be at least (ihi-ilo)*nb where nb
-// is the optimal blocksize. On return, work[0] will contain the optimal value
-// of lwork.
-//
+// Dgtsv solves the equation
+//  A * X = B
+// where A is an n×n tridiagonal matrix, by Gaussian elimination with partial
+// pivoting. Note that the equation A^T * X = B may be solved by interchanging
+// the order of the arguments du and dl.
+//
+// On entry, dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively. On return, dl contains the n-2
+// elements of the second superdiagonal of the upper triangular matrix U from
+// the LU factorization of A, d contains the n diagonal elements of U, and du
+// contains the n-1 elements of the first superdiagonal of U. dl and du must
+// have length at least n-1, otherwise Dgtsv will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, if ok
+// is true, b contains the solution matrix X.
+//
+// Dgtsv returns whether U is nonsingular. If U is exactly singular, the
+// solution has not been computed.
+func (impl Implementation) Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dgtsv(n, nrhs, dl, d, du, b, ldb)
+}
+
+// Dgttrf computes an LU factorization of an n×n tridiagonal matrix A using
+// elimination with partial pivoting and row interchanges. The factorization
+// has the form
+//  A = L * U
+// where L is a product of permutation and unit lower bidiagonal matrices and
+// U is upper triangular with nonzeros in only the main diagonal and first two
+// superdiagonals.
+//
+// On entry, dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively. On return, dl contains the n-1
+// multipliers that define the matrix L, d contains the n diagonal elements of
+// U, du contains the n-1 elements of the first superdiagonal of U and du2
+// contains the n-2 elements of the second superdiagonal of U.
+//
+// ipiv contains the pivot indices. For 0 <= i < n, row i of the matrix was
+// interchanged with row ipiv[i], where ipiv[i] is always either i or i+1.
+// ipiv[i] == i indicates that a row interchange was not required.
+//
+// dl, du and du2 must have length at least n-1, n-1 and n-2, respectively,
+// and ipiv must have length at least n, otherwise Dgttrf will panic.
+//
+// Dgttrf returns whether U is nonsingular. If U is exactly singular, the
+// factorization has been completed, but U cannot be used to solve a system of
+// equations.
+func (impl Implementation) Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dgttrf(n, dl, d, du, du2, ipiv32)
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return ok
+}
+
+// Dgttrs solves one of the systems of equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans or blas.ConjTrans,
+// where A is an n×n tridiagonal matrix, using the LU factorization computed
+// by Dgttrf. dl, d, du, du2 and ipiv contain the factorization of A as
+// returned by Dgttrf. dl, du and du2 must have length at least n-1, n-1 and
+// n-2, respectively, and ipiv must have length at least n, otherwise Dgttrs
+// will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (impl Implementation) Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int) {
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		ipiv32[i] = int32(v) + 1 // Transform to one-indexed.
+	}
+	lapacke.Dgttrs(trans, n, nrhs, dl, d, du, du2, ipiv32, b, ldb)
+}
+
+// Dopgtr generates a real orthogonal matrix Q which is defined as the product
+// of n-1 elementary reflectors of order n as returned by Dsptrd using packed
+// storage.
+//
+// The construction of Q depends on the value of uplo:
+//  Q = H_{n-1} * ... * H_1 * H_0  if uplo == blas.Upper
+//  Q = H_0 * H_1 * ... * H_{n-1}  if uplo == blas.Lower
+// where H_i is constructed from the elementary reflectors as computed by Dsptrd.
+// See the documentation for Dsptrd for more information.
+//
+// ap must have length at least n*(n+1)/2, tau must have length at least n-1,
+// and work must have length at least n-1, otherwise Dopgtr will panic.
+//
+// On return, q contains the n×n orthogonal matrix Q.
+//
+// Dopgtr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dopgtr(uplo blas.Uplo, n int, ap, tau, q []float64, ldq int, work []float64) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(tau) < n-1:
+		panic(badTau)
+	case len(work) < n-1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, q, max(1, ldq))
+	if n == 0 {
+		return
+	}
+	lapacke.Dopgtr(uplo, n, ap, tau, q, ldq, work)
+}
+
+// Dorgbr generates one of the matrices Q or P^T computed by Dgebrd.
+// See Dgebrd for the description of Q and P^T.
+//
+// If vect == lapack.ApplyQ, then a is assumed to have been an m×k matrix and
+// Q is of order m. If m >= k, then Dorgbr returns the first n columns of Q
+// where m >= n >= k. If m < k, then Dorgbr returns Q as an m×m matrix.
+//
+// If vect == lapack.ApplyP, then A is assumed to have been a k×n matrix, and
+// P^T is of order n. If k < n, then Dorgbr returns the first m rows of P^T,
+// where n >= m >= k. If k >= n, then Dorgbr returns P^T as an n×n matrix.
+func (impl Implementation) Dorgbr(vect lapack.DecompUpdate, m, n, k int, a []float64, lda int, tau, work []float64, lwork int) {
+	mn := min(m, n)
+	wantq := vect == lapack.ApplyQ
+	if wantq {
+		if m < n || n < min(m, k) || m < min(m, k) {
+			panic(badDims)
+		}
+	} else {
+		if n < m || m < min(n, k) || n < min(n, k) {
+			panic(badDims)
+		}
+	}
+	if wantq {
+		checkMatrix(m, k, a, lda)
+	} else {
+		checkMatrix(k, n, a, lda)
+	}
+	if lwork == -1 {
+		work[0] = float64(mn)
+		return
+	}
+	if len(work) < lwork {
+		panic(badWork)
+	}
+	if lwork < mn {
+		panic(badWork)
+	}
+	lapacke.Dorgbr(byte(vect), m, n, k, a, lda, tau, work, lwork)
+}
+
+// Dorghr generates an n×n orthogonal matrix Q which is defined as the product
+// of ihi-ilo elementary reflectors:
+//  Q = H_{ilo} H_{ilo+1} ... H_{ihi-1}.
+//
+// a and lda represent an n×n matrix that contains the elementary reflectors, as
+// returned by Dgehrd. On return, a is overwritten by the n×n orthogonal matrix
+// Q. Q will be equal to the identity matrix except in the submatrix
+// Q[ilo+1:ihi+1,ilo+1:ihi+1].
+//
+// ilo and ihi must have the same values as in the previous call of Dgehrd. It
+// must hold that
+//  0 <= ilo <= ihi < n,  if n > 0,
+//  ilo = 0, ihi = -1,    if n == 0.
+//
+// tau contains the scalar factors of the elementary reflectors, as returned by
+// Dgehrd. tau must have length n-1.
+//
+// work must have length at least max(1,lwork) and lwork must be at least
+// ihi-ilo. For optimum performance lwork must be at least (ihi-ilo)*nb where nb
+// is the optimal blocksize. On return, work[0] will contain the optimal value
+// of lwork.
+//
 // If lwork == -1, instead of performing Dorghr, only the optimal value of lwork
 // will be stored into work[0].
 //
@@ -1891,6 +3588,54 @@ func (impl Implementation) Dorgqr(m, n, k int, a []float64, lda int, tau, work [
 	lapacke.Dorgqr(m, n, k, a, lda, tau, work, lwork)
 }
 
+// Dorgrq generates the m×n matrix Q with orthonormal rows defined as the
+// last m rows of a product of k elementary reflectors of order n
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dgerqf.
+//
+// It must hold that
+//  0 <= k <= m <= n,
+// and Dorgrq will panic otherwise.
+//
+// On entry, the (m-k+i)-th row of A must contain the vector which defines the
+// elementary reflector H_i, for i=0,...,k-1, and tau[i] must contain its
+// scalar factor. On return, a contains the m×n matrix Q.
+//
+// tau must have length at least k, and Dorgrq will panic otherwise.
+//
+// work must have length at least max(1,lwork), and lwork must be at least
+// max(1,m), otherwise Dorgrq will panic. For optimum performance lwork must
+// be a sufficiently large multiple of m.
+//
+// If lwork == -1, instead of computing Dorgrq the optimal work length is stored
+// into work[0].
+//
+// Dorgrq is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dorgrq(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < m:
+		panic(nLTM)
+	case k < 0:
+		panic(kLT0)
+	case k > m:
+		panic(kGTM)
+	case lwork < max(1, m) && lwork != -1:
+		panic(badWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+	if lwork != -1 {
+		checkMatrix(m, n, a, lda)
+		if len(tau) < k {
+			panic(badTau)
+		}
+	}
+
+	lapacke.Dorgrq(m, n, k, a, lda, tau, work, lwork)
+}
+
 // Dorgtr generates a real orthogonal matrix Q which is defined as the product
 // of n-1 elementary reflectors of order n as returned by Dsytrd.
 //
@@ -2187,27 +3932,100 @@ func (impl Implementation) Dormqr(side blas.Side, trans blas.Transpose, m, n, k
 	lapacke.Dormqr(side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
 }
 
-// Dpocon estimates the reciprocal of the condition number of a positive-definite
-// matrix A given the Cholesky decomposition of A. The condition number computed
-// is based on the 1-norm and the ∞-norm.
+// Dormrq multiplies an m×n matrix C by an orthogonal matrix Q as
+//  C = Q * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Q,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^T,  if side == blas.Right and trans == blas.Trans,
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dgerqf.
+//
+// If side == blas.Left, A is a k×m matrix and 0 <= k <= m.
+// If side == blas.Right, A is a k×n matrix and 0 <= k <= n.
+// The ith row of A contains the vector which defines the elementary
+// reflector H_i and tau[i] contains its scalar factor. tau must have length
+// at least k and Dormrq will panic otherwise.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Dormrq will
+// panic. Larger values of lwork will generally give better performance. On
+// return, work[0] will contain the optimal value of lwork.
+//
+// If lwork is -1, instead of performing Dormrq, the optimal workspace size will
+// be stored into work[0].
+//
+// Dormrq is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormrq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case k < 0 || nq < k:
+		panic("lapack: invalid value of k")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	if lwork != -1 {
+		checkMatrix(k, nq, a, lda)
+		checkMatrix(m, n, c, ldc)
+		if len(tau) < k {
+			panic(badTau)
+		}
+	}
+
+	lapacke.Dormrq(side, trans, m, n, k, a, lda, tau, c, ldc, work, lwork)
+}
+
+// Dpbcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite band matrix A with kd super- or subdiagonals given the
+// Cholesky factorization of A computed by Dpbtrf. The condition number
+// computed is based on the 1-norm and the ∞-norm.
+//
+// ab contains the factor U or L of the factorization of A as returned by
+// Dpbtrf. See the documentation for Dpbtrf for a description of the band
+// storage format of ab.
 //
 // anorm is the 1-norm and the ∞-norm of the original matrix A.
 //
-// work is a temporary data slice of length at least 3*n and Dpocon will panic otherwise.
+// work is a temporary data slice of length at least 3*n and Dpbcon will panic
+// otherwise.
 //
-// iwork is a temporary data slice of length at least n and Dpocon will panic otherwise.
-// Elements of iwork must fit within the int32 type or Dpocon will panic.
-func (impl Implementation) Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64 {
-	checkMatrix(n, n, a, lda)
-	if uplo != blas.Upper && uplo != blas.Lower {
+// iwork is a temporary data slice of length at least n and Dpbcon will panic
+// otherwise. Elements of iwork must fit within the int32 type or Dpbcon will
+// panic.
+func (impl Implementation) Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
 		panic(badUplo)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
 	}
+	checkSymBandMatrix(n, kd, ab, ldab)
 	if len(work) < 3*n {
 		panic(badWork)
 	}
 	if len(iwork) < n {
 		panic(badWork)
 	}
+	if n == 0 {
+		return 1
+	}
+	abT, ldabT := toLapackeSymBand(uplo, n, kd, ab, ldab)
 	rcond := make([]float64, 1)
 	_iwork := make([]int32, len(iwork))
 	for i, v := range iwork {
@@ -2216,204 +4034,503 @@ func (impl Implementation) Dpocon(uplo blas.Uplo, n int, a []float64, lda int, a
 		}
 		_iwork[i] = int32(v)
 	}
-	lapacke.Dpocon(uplo, n, a, lda, anorm, rcond, work, _iwork)
+	lapacke.Dpbcon(uplo, n, kd, abT, ldabT, anorm, rcond, work, _iwork)
 	for i, v := range _iwork {
 		iwork[i] = int(v)
 	}
 	return rcond[0]
 }
 
-// Dsteqr computes the eigenvalues and optionally the eigenvectors of a symmetric
-// tridiagonal matrix using the implicit QL or QR method. The eigenvectors of a
-// full or band symmetric matrix can also be found if Dsytrd, Dsptrd, or Dsbtrd
-// have been used to reduce this matrix to tridiagonal form.
-//
-// d, on entry, contains the diagonal elements of the tridiagonal matrix. On exit,
-// d contains the eigenvalues in ascending order. d must have length n and
-// Dsteqr will panic otherwise.
-//
-// e, on entry, contains the off-diagonal elements of the tridiagonal matrix on
-// entry, and is overwritten during the call to Dsteqr. e must have length n-1 and
-// Dsteqr will panic otherwise.
-//
-// z, on entry, contains the n×n orthogonal matrix used in the reduction to
-// tridiagonal form if compz == lapack.OriginalEV. On exit, if
-// compz == lapack.OriginalEV, z contains the orthonormal eigenvectors of the
-// original symmetric matrix, and if compz == lapack.TridiagEV, z contains the
-// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
-// if compz == lapack.None.
-//
-// work must have length at least max(1, 2*n-2) if the eigenvectors are computed,
-// and Dsteqr will panic otherwise.
-//
-// Dsteqr is an internal routine. It is exported for testing purposes.
-func (impl Implementation) Dsteqr(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64) (ok bool) {
-	if n < 0 {
-		panic(nLT0)
+// Dpbsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite band matrix with kd super- or
+// subdiagonals, and X and B are n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with kd off-diagonals. On entry, ab contains the upper or lower
+// triangle of A in the band storage format described in the documentation of
+// Dpbtrf, and on return it contains the factor U or L in the same format.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dpbsv returns whether the factorization was successfully completed. If ok
+// is false, the leading minor of some order of A is not positive definite, so
+// the factorization could not be completed and the solution has not been
+// computed.
+func (impl Implementation) Dpbsv(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
 	}
-	if len(d) < n {
-		panic(badD)
+	checkSymBandMatrix(n, kd, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return true
 	}
-	if len(e) < n-1 {
-		panic(badE)
+	abT, ldabT := toLapackeSymBand(uplo, n, kd, ab, ldab)
+	ok = lapacke.Dpbsv(uplo, n, kd, nrhs, abT, ldabT, b, ldb)
+	fromLapackeSymBand(uplo, n, kd, ab, ldab, abT, ldabT)
+	return ok
+}
+
+// Dpbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A with kd super- or subdiagonals. The factorization has
+// the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with kd off-diagonals.
+//
+// The upper or lower triangle of A is stored in ab in row-major band format as
+// used by blas64.SymmetricBand. If uplo == blas.Upper, the element A[i,j] is
+// stored in ab[i*ldab+j-i] for i <= j <= min(i+kd,n-1), and if
+// uplo == blas.Lower, A[i,j] is stored in ab[i*ldab+kd+j-i] for
+// max(0,i-kd) <= j <= i. ldab must be at least kd+1, otherwise Dpbtrf will
+// panic. On return, ab contains the factor U or L in the same format.
+//
+// Dpbtrf returns whether the factorization was successfully completed. If ok
+// is false, the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (impl Implementation) Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
 	}
-	if compz != lapack.None && compz != lapack.TridiagEV && compz != lapack.OriginalEV {
-		panic(badEVComp)
+	checkSymBandMatrix(n, kd, ab, ldab)
+	if n == 0 {
+		return true
 	}
-	if compz != lapack.None {
-		if len(work) < max(1, 2*n-2) {
-			panic(badWork)
-		}
-		checkMatrix(n, n, z, ldz)
+	abT, ldabT := toLapackeSymBand(uplo, n, kd, ab, ldab)
+	ok = lapacke.Dpbtrf(uplo, n, kd, abT, ldabT)
+	fromLapackeSymBand(uplo, n, kd, ab, ldab, abT, ldabT)
+	return ok
+}
+
+// Dpbtrs solves a system of linear equations A*X = B with an n×n symmetric
+// positive definite band matrix A using the Cholesky factorization
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// computed by Dpbtrf. kd is the number of super- or subdiagonals of A. See the
+// documentation for Dpbtrf for a description of the band storage format of ab.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, it is
+// overwritten with the solution matrix X.
+func (impl Implementation) Dpbtrs(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
 	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	abT, ldabT := toLapackeSymBand(uplo, n, kd, ab, ldab)
+	lapacke.Dpbtrs(uplo, n, kd, nrhs, abT, ldabT, b, ldb)
+}
 
-	return lapacke.Dsteqr(lapack.Comp(compz), n, d, e, z, ldz, work)
+// Dpftrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in rectangular full packed (RFP) format. The
+// factorization has the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+//
+// On entry, a contains the upper or lower triangle of A in the RFP format
+// described in the documentation of Dtrttf. On return, a contains the factor
+// U or L in the same format. transr must be blas.NoTrans or blas.Trans, and a
+// must have length at least n*(n+1)/2, otherwise Dpftrf will panic.
+//
+// Dpftrf returns whether the matrix A is positive definite. If ok is false,
+// the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (impl Implementation) Dpftrf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dpftrf(transr, uplo, n, a)
 }
 
-// Dsterf computes all eigenvalues of a symmetric tridiagonal matrix using the
-// Pal-Walker-Kahan variant of the QL or QR algorithm.
-//
-// d contains the diagonal elements of the tridiagonal matrix on entry, and
-// contains the eigenvalues in ascending order on exit. d must have length at
-// least n, or Dsterf will panic.
-//
-// e contains the off-diagonal elements of the tridiagonal matrix on entry, and is
-// overwritten during the call to Dsterf. e must have length of at least n-1 or
-// Dsterf will panic.
+// Dpftri computes the inverse of a real symmetric positive definite matrix A
+// stored in rectangular full packed (RFP) format using its Cholesky
+// factorization.
+//
+// On entry, a contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Dpftrf, in the RFP
+// format described in the documentation of Dtrttf. On return, a contains the
+// upper or lower triangle of the (symmetric) inverse of A in the same format,
+// overwriting the input factor U or L. transr must be blas.NoTrans or
+// blas.Trans, and a must have length at least n*(n+1)/2, otherwise Dpftri will
+// panic.
 //
-// Dsterf is an internal routine. It is exported for testing purposes.
-func (impl Implementation) Dsterf(n int, d, e []float64) (ok bool) {
-	if n < 0 {
+// Dpftri returns whether the matrix A is invertible. If Dpftri returns false,
+// a diagonal element of the factor is zero and the inverse could not be
+// computed.
+func (impl Implementation) Dpftri(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
 		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
 	}
 	if n == 0 {
 		return true
 	}
-	if len(d) < n {
-		panic(badD)
+	return lapacke.Dpftri(transr, uplo, n, a)
+}
+
+// Dpftrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// as computed by Dpftrf and stored in a in the rectangular full packed format
+// described in the documentation of Dtrttf. transr must be blas.NoTrans or
+// blas.Trans, and a must have length at least n*(n+1)/2, otherwise Dpftrs will
+// panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) Dpftrs(transr blas.Transpose, uplo blas.Uplo, n, nrhs int, a []float64, b []float64, ldb int) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
 	}
-	if len(e) < n-1 {
-		panic(badE)
+	checkMatrix(n, nrhs, b, max(1, ldb))
+	if n == 0 || nrhs == 0 {
+		return
 	}
-
-	return lapacke.Dsterf(n, d, e)
+	lapacke.Dpftrs(transr, uplo, n, nrhs, a, b, max(1, ldb))
 }
 
-// Dsyev computes all eigenvalues and, optionally, the eigenvectors of a real
-// symmetric matrix A.
+// Dpocon estimates the reciprocal of the condition number of a positive-definite
+// matrix A given the Cholesky decomposition of A. The condition number computed
+// is based on the 1-norm and the ∞-norm.
 //
-// w contains the eigenvalues in ascending order upon return. w must have length
-// at least n, and Dsyev will panic otherwise.
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
 //
-// On entry, a contains the elements of the symmetric matrix A in the triangular
-// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
-// orthonormal eigenvectors of A on exit, otherwise on exit the specified
-// triangular region is overwritten.
+// work is a temporary data slice of length at least 3*n and Dpocon will panic otherwise.
 //
-// work is temporary storage, and lwork specifies the usable memory length. At minimum,
-// lwork >= 3*n-1, and Dsyev will panic otherwise. The amount of blocking is
-// limited by the usable length. If lwork == -1, instead of computing Dsyev the
-// optimal work length is stored into work[0].
-func (impl Implementation) Dsyev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool) {
+// iwork is a temporary data slice of length at least n and Dpocon will panic otherwise.
+// Elements of iwork must fit within the int32 type or Dpocon will panic.
+func (impl Implementation) Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64 {
 	checkMatrix(n, n, a, lda)
-	if lwork == -1 {
-		work[0] = 3*float64(n) - 1
-		return
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
 	}
-	if len(work) < lwork {
+	if len(work) < 3*n {
 		panic(badWork)
 	}
-	if lwork < 3*n-1 {
+	if len(iwork) < n {
 		panic(badWork)
 	}
-	return lapacke.Dsyev(lapack.Job(jobz), uplo, n, a, lda, w, work, lwork)
+	rcond := make([]float64, 1)
+	_iwork := make([]int32, len(iwork))
+	for i, v := range iwork {
+		if v != int(int32(v)) {
+			panic("lapack: iwork element out of range")
+		}
+		_iwork[i] = int32(v)
+	}
+	lapacke.Dpocon(uplo, n, a, lda, anorm, rcond, work, _iwork)
+	for i, v := range _iwork {
+		iwork[i] = int(v)
+	}
+	return rcond[0]
 }
 
-// Dsytrd reduces a symmetric n×n matrix A to symmetric tridiagonal form by an
-// orthogonal similarity transformation
-//  Q^T * A * Q = T
-// where Q is an orthonormal matrix and T is symmetric and tridiagonal.
-//
-// On entry, a contains the elements of the input matrix in the triangle specified
-// by uplo. On exit, the diagonal and sub/super-diagonal are overwritten by the
-// corresponding elements of the tridiagonal matrix T. The remaining elements in
-// the triangle, along with the array tau, contain the data to construct Q as
-// the product of elementary reflectors.
-//
-// If uplo == blas.Upper, Q is constructed with
-//  Q = H_{n-2} * ... * H_1 * H_0
-// where
-//  H_i = I - tau_i * v * v^T
-// v is constructed as v[i+1:n] = 0, v[i] = 1, v[0:i-1] is stored in A[0:i-1, i+1].
-// The elements of A are
-//  [ d   e  v1  v2  v3]
-//  [     d   e  v2  v3]
-//  [         d   e  v3]
-//  [             d   e]
-//  [                 e]
-//
-// If uplo == blas.Lower, Q is constructed with
-//  Q = H_0 * H_1 * ... * H_{n-2}
-// where
-//  H_i = I - tau_i * v * v^T
-// v is constructed as v[0:i+1] = 0, v[i+1] = 1, v[i+2:n] is stored in A[i+2:n, i].
-// The elements of A are
-//  [ d                ]
-//  [ e   d            ]
-//  [v0   e   d        ]
-//  [v0  v1   e   d    ]
-//  [v0  v1  v2   e   d]
-//
-// d must have length n, and e and tau must have length n-1. Dsytrd will panic if
-// these conditions are not met.
-//
-// work is temporary storage, and lwork specifies the usable memory length. At minimum,
-// lwork >= 1, and Dsytrd will panic otherwise. The amount of blocking is
-// limited by the usable length.
-// If lwork == -1, instead of computing Dsytrd the optimal work length is stored
-// into work[0].
-//
-// Dsytrd is an internal routine. It is exported for testing purposes.
-func (impl Implementation) Dsytrd(uplo blas.Uplo, n int, a []float64, lda int, d, e, tau, work []float64, lwork int) {
+// Dpoequ computes row and column scalings intended to equilibrate the n×n
+// symmetric positive definite matrix A and reduce its condition number (with
+// respect to the two-norm). On return, s contains the scale factors
+//  s[i] = 1 / sqrt(A[i,i]),
+// chosen so that the scaled matrix B with elements
+//  B[i,j] = s[i] * A[i,j] * s[j]
+// has ones on the diagonal. This choice of s puts the condition number of B
+// within a factor n of the smallest possible condition number over all
+// possible diagonal scalings. Only the diagonal of A is referenced.
+//
+// s must have length at least n, otherwise Dpoequ will panic.
+//
+// scond is the ratio of the smallest s[i] to the largest s[i]. If
+// scond >= 0.1 and amax is neither too large nor too small, it is not worth
+// scaling by s. amax is the absolute value of the largest element of A. If
+// amax is very close to overflow or very close to underflow, the matrix should
+// be scaled.
+//
+// Dpoequ returns false if a diagonal element of A is not positive. In that
+// case scond is not computed and s is only partially computed.
+func (impl Implementation) Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool) {
 	checkMatrix(n, n, a, lda)
-	if len(d) < n {
-		panic(badD)
+	if len(s) < n {
+		panic(badSlice)
 	}
-	if len(e) < n-1 {
-		panic(badE)
-	}
-	if len(tau) < n-1 {
-		panic(badTau)
+
+	if n == 0 {
+		return 1, 0, true
 	}
-	if len(work) < lwork {
-		panic(shortWork)
+	var _scond, _amax [1]float64
+	ok = lapacke.Dpoequ(n, a, lda, s, _scond[:], _amax[:])
+	return _scond[0], _amax[0], ok
+}
+
+// Dporfs improves the computed solution to a system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix, and provides error
+// bounds and backward error estimates for the solution.
+//
+// a contains the triangle of A specified by uplo, and af contains the
+// corresponding triangular factor from the Cholesky factorization of A as
+// computed by Dpotrf. B is an n×nrhs matrix of right hand sides and x on entry
+// contains the n×nrhs solution matrix as computed by Dpotrs. On return, x
+// contains the improved solution.
+//
+// For each right hand side j, ferr[j] contains on return an estimated bound
+// for the forward error
+//  max_i |X[i,j] - Xtrue[i,j]| / max_i |X[i,j]|,
+// where Xtrue is the true solution. The estimate is almost always a slight
+// overestimate of the true error. berr[j] contains the componentwise relative
+// backward error of the j-th solution vector, that is, the smallest relative
+// change in any element of A or B that makes X[:,j] an exact solution.
+//
+// ferr and berr must have length at least nrhs, work must have length at
+// least 3*n and iwork must have length at least n, otherwise Dporfs will
+// panic.
+func (impl Implementation) Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
 	}
-	if lwork != -1 && lwork < 1 {
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 3*n:
+		panic(badWork)
+	case len(iwork) < n:
 		panic(badWork)
 	}
-	if uplo != blas.Upper && uplo != blas.Lower {
+
+	if n == 0 || nrhs == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+	lapacke.Dporfs(uplo, n, nrhs, a, lda, af, ldaf, b, ldb, x, ldx, ferr, berr, work, make([]int32, n))
+}
+
+// Dposv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular. On return,
+// the factor U or L is stored in the corresponding triangle of a. The opposite
+// triangle of a is not referenced.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dposv returns false if the leading minor of some order of A is not positive
+// definite. In that case the factorization could not be completed and the
+// solution has not been computed.
+func (impl Implementation) Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
 		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
 	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
 
-	lapacke.Dsytrd(uplo, n, a, lda, d, e, tau, work, lwork)
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dposv(uplo, n, nrhs, a, lda, b, ldb)
 }
 
-// Dtrcon estimates the reciprocal of the condition number of a triangular matrix A.
-// The condition number computed may be based on the 1-norm or the ∞-norm.
+// Dposvx uses the Cholesky factorization to compute the solution to a real
+// system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are n×nrhs
+// matrices. Error bounds on the solution and a condition estimate are also
+// provided.
+//
+// Dposvx performs the following steps:
+//
+//  1. If fact == lapack.EquilibrateAndFactor, real scaling factors are
+//     computed by Dpoequ to equilibrate the system:
+//      diag(s)*A*diag(s) * inv(diag(s))*X = diag(s)*B.
+//     Whether or not the system will be equilibrated depends on the scaling
+//     of the matrix A, but if equilibration is used, A is overwritten by
+//     diag(s)*A*diag(s) and B by diag(s)*B.
+//  2. If fact == lapack.NotFactored or lapack.EquilibrateAndFactor, the
+//     Cholesky decomposition is used to factor the matrix A (after
+//     equilibration if fact == lapack.EquilibrateAndFactor) as
+//      A = U^T * U  if uplo == blas.Upper,
+//      A = L * L^T  if uplo == blas.Lower,
+//     where U is an upper triangular matrix and L is lower triangular.
+//  3. If the leading minor of some order of A is not positive definite, then
+//     the routine returns with ok == false. Otherwise, the factored form of A
+//     is used to estimate the condition number of the matrix A. If the
+//     reciprocal of the condition number is less than machine precision,
+//     ok == false is returned as a warning, but the routine still goes on to
+//     solve for X and compute error bounds as described below.
+//  4. The system of equations is solved for X using the factored form of A.
+//  5. Iterative refinement is applied to improve the computed solution matrix
+//     and calculate error bounds and backward error estimates for it.
+//  6. If equilibration was used, the matrix X is premultiplied by diag(s) so
+//     that it solves the original system before equilibration.
+//
+// Only the triangle of a and af specified by uplo is referenced.
+//
+// If fact == lapack.Factored, af must contain on entry the triangular factor
+// from the Cholesky factorization of A as computed by Dpotrf, and equed
+// specifies the form of equilibration that was applied to A, which must then
+// contain the equilibrated matrix. equed must be either lapack.NoEquilibration
+// or lapack.SymmetricEquilibration, and in the latter case the scale factors
+// in s must be positive. If fact is not lapack.Factored, equed is ignored, and
+// on return af contains the triangular factor of the (equilibrated) matrix A.
+//
+// s must have length at least n, and on return contains the scale factors
+// for A. It is only referenced if equilibration is used.
+//
+// On entry, b contains the n×nrhs right hand side matrix B. On return, b is
+// overwritten by diag(s)*B if equilibration was used, and is not modified
+// otherwise. x is an n×nrhs matrix that on return contains the solution matrix
+// X of the original system.
+//
+// ferr and berr must have length at least nrhs and on return contain the
+// estimated forward error bound and the componentwise relative backward error
+// of each solution vector, respectively. See Dporfs for details.
+//
+// work must have length at least 3*n and iwork must have length at least n,
+// otherwise Dposvx will panic.
+//
+// Dposvx returns the form of equilibration that was applied to A in equedOut
+// and the estimate of the reciprocal condition number of A after
+// equilibration in rcond. If the factorization fails because A is not
+// positive definite, rcond is zero, and X and the error bounds are not
+// computed.
+func (impl Implementation) Dposvx(fact lapack.FactorizationType, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed lapack.EquilibrationType, s, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond float64, ok bool) {
+	switch {
+	case fact != lapack.NotFactored && fact != lapack.EquilibrateAndFactor && fact != lapack.Factored:
+		panic("lapack: bad FactorizationType")
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(s) < n:
+		panic(badSlice)
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 3*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+
+	if fact != lapack.Factored {
+		equed = lapack.NoEquilibration
+	}
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return equed, 1, true
+	}
+
+	_equed := []byte{byte(equed)}
+	var _rcond [1]float64
+	ok = lapacke.Dposvx(byte(fact), uplo, n, nrhs, a, lda, af, ldaf, _equed, s, b, ldb, x, ldx, _rcond[:], ferr, berr, work, make([]int32, n))
+	return lapack.EquilibrationType(_equed[0]), _rcond[0], ok
+}
+
+// Dppcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite matrix A stored in packed format given the Cholesky
+// factorization of A computed by Dpptrf. The condition number computed is
+// based on the 1-norm and the ∞-norm.
 //
-// work is a temporary data slice of length at least 3*n and Dtrcon will panic otherwise.
+// ap contains the factor U or L of the factorization of A as returned by
+// Dpptrf. See the documentation for Dpptrf for a description of the packed
+// storage format of ap.
 //
-// iwork is a temporary data slice of length at least n and Dtrcon will panic otherwise.
-// Elements of iwork must fit within the int32 type or Dtrcon will panic.
-func (impl Implementation) Dtrcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64 {
-	if norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum {
-		panic(badNorm)
-	}
-	if uplo != blas.Upper && uplo != blas.Lower {
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Dppcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dppcon will panic
+// otherwise. Elements of iwork must fit within the int32 type or Dppcon will
+// panic.
+func (impl Implementation) Dppcon(uplo blas.Uplo, n int, ap []float64, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
 		panic(badUplo)
-	}
-	if diag != blas.NonUnit && diag != blas.Unit {
-		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
 	}
 	if len(work) < 3*n {
 		panic(badWork)
@@ -2421,7 +4538,10 @@ func (impl Implementation) Dtrcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag b
 	if len(iwork) < n {
 		panic(badWork)
 	}
-	rcond := []float64{0}
+	if n == 0 {
+		return 1
+	}
+	rcond := make([]float64, 1)
 	_iwork := make([]int32, len(iwork))
 	for i, v := range iwork {
 		if v != int(int32(v)) {
@@ -2429,57 +4549,2123 @@ func (impl Implementation) Dtrcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag b
 		}
 		_iwork[i] = int32(v)
 	}
-	lapacke.Dtrcon(byte(norm), uplo, diag, n, a, lda, rcond, work, _iwork)
-	for i, v := range _iwork {
-		iwork[i] = int(v)
+	lapacke.Dppcon(uplo, n, ap, anorm, rcond, work, _iwork)
+	for i, v := range _iwork {
+		iwork[i] = int(v)
+	}
+	return rcond[0]
+}
+
+// Dpptrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in packed format. The factorization has the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+//
+// The matrix A is stored in ap in row-major packed format as used by
+// blas64.SymmetricPacked, that is if uplo == blas.Upper, the rows of the upper
+// triangle are stored one after another and the element A[i,j] for i <= j is
+// stored in ap[i*n-i*(i-1)/2+j-i], and if uplo == blas.Lower, the rows of the
+// lower triangle are stored one after another and the element A[i,j] for
+// i >= j is stored in ap[i*(i+1)/2+j]. ap must have length at least
+// n*(n+1)/2, otherwise Dpptrf will panic. On return, ap contains the factor U
+// or L in the same format.
+//
+// Dpptrf returns whether the matrix A is positive definite. If ok is false,
+// the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (impl Implementation) Dpptrf(uplo blas.Uplo, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dpptrf(uplo, n, ap)
+}
+
+// Dpptri computes the inverse of an n×n symmetric positive definite matrix A
+// stored in packed format, using its Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// as computed by Dpptrf.
+//
+// On entry, ap contains the factor U or L in the packed format described in
+// the documentation of Dpptrf. On return, ap contains the upper or lower
+// triangle of the symmetric inverse of A in the same format. ap must have
+// length at least n*(n+1)/2, otherwise Dpptri will panic.
+//
+// Dpptri returns whether the factor U or L is nonsingular. If ok is false, A
+// is singular and the inverse has not been computed.
+func (impl Implementation) Dpptri(uplo blas.Uplo, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dpptri(uplo, n, ap)
+}
+
+// Dpptrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// computed by Dpptrf. ap contains the factor U or L in the packed format
+// described in the documentation of Dpptrf, and must have length at least
+// n*(n+1)/2, otherwise Dpptrs will panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) Dpptrs(uplo blas.Uplo, n, nrhs int, ap []float64, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	lapacke.Dpptrs(uplo, n, nrhs, ap, b, ldb)
+}
+
+// Dptcon computes the reciprocal of the condition number (in the 1-norm) of
+// an n×n symmetric positive definite tridiagonal matrix A using the
+// factorization A = L*D*L^T or A = U^T*D*U computed by Dpttrf.
+//
+// The reciprocal of the condition number is computed as
+//  rcond = 1 / (anorm * |inv(A)|)
+// and |inv(A)| is computed by a direct method.
+//
+// d and e contain the factorization of A as returned by Dpttrf. d must have
+// length at least n and e must have length at least n-1, otherwise Dptcon
+// will panic.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least n and Dptcon will panic
+// otherwise.
+func (impl Implementation) Dptcon(n int, d, e []float64, anorm float64, work []float64) float64 {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 1
+	}
+	rcond := make([]float64, 1)
+	lapacke.Dptcon(n, d, e, anorm, rcond, work)
+	return rcond[0]
+}
+
+// Dptsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, and X and
+// B are n×nrhs matrices.
+//
+// A is factored as A = L*D*L^T, and the factored form of A is then used to
+// solve the system of equations. On entry, d and e contain the n diagonal and
+// n-1 subdiagonal elements of A, respectively. On return, d and e contain the
+// factorization of A as computed by Dpttrf. d must have length at least n and
+// e must have length at least n-1, otherwise Dptsv will panic.
+//
+// On entry, b contains the right hand side matrix B. On return, if ok is true,
+// b contains the solution matrix X.
+//
+// Dptsv returns whether the factorization was successfully completed. If ok
+// is false, the leading principal minor of some order is not positive
+// definite and the solution has not been computed.
+func (impl Implementation) Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dptsv(n, nrhs, d, e, b, ldb)
+}
+
+// Dpttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A. The factorization may also be regarded as
+// having the form A = U^T*D*U.
+//
+// On entry, d and e contain the n diagonal and n-1 subdiagonal elements of A,
+// respectively. On return, d contains the n diagonal elements of the diagonal
+// matrix D and e contains the n-1 subdiagonal elements of the unit lower
+// bidiagonal factor L. e can also be regarded as the superdiagonal of the unit
+// upper bidiagonal factor U from the U^T*D*U factorization of A. d must have
+// length at least n and e must have length at least n-1, otherwise Dpttrf will
+// panic.
+//
+// Dpttrf returns whether the factorization was successfully completed. If ok
+// is false, the leading principal minor of some order is not positive
+// definite and the factorization could not be completed.
+func (impl Implementation) Dpttrf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dpttrf(n, d, e)
+}
+
+// Dpttrs solves a system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, using the
+// L*D*L^T factorization of A computed by Dpttrf. d and e contain the
+// factorization of A as returned by Dpttrf. d must have length at least n and
+// e must have length at least n-1, otherwise Dpttrs will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (impl Implementation) Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	lapacke.Dpttrs(n, nrhs, d, e, b, ldb)
+}
+
+// Dsfrk performs one of the symmetric rank-k operations
+//  C = alpha * A * A^T + beta * C  if trans == blas.NoTrans,
+//  C = alpha * A^T * A + beta * C  if trans == blas.Trans,
+// where alpha and beta are scalars, C is an n×n symmetric matrix stored in
+// rectangular full packed (RFP) format and A is an n×k matrix if
+// trans == blas.NoTrans and a k×n matrix otherwise.
+//
+// c contains the upper or lower triangle of C in the RFP format described in
+// the documentation of Dtrttf. transr must be blas.NoTrans or blas.Trans, and
+// c must have length at least n*(n+1)/2, otherwise Dsfrk will panic.
+func (impl Implementation) Dsfrk(transr blas.Transpose, uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case k < 0:
+		panic(kLT0)
+	case len(c) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if n > 0 && k > 0 {
+		if trans == blas.NoTrans {
+			checkMatrix(n, k, a, lda)
+		} else {
+			checkMatrix(k, n, a, lda)
+		}
+	}
+	if n == 0 {
+		return
+	}
+	lapacke.Dsfrk(transr, uplo, trans, n, k, alpha, a, max(1, lda), beta, c)
+}
+
+// Dsgesv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n matrix and X and B are n×nrhs matrices, using mixed
+// precision iterative refinement.
+//
+// Dsgesv first attempts to factorize the matrix in single precision and use
+// this factorization within an iterative refinement procedure to produce a
+// solution with double precision normwise backward error quality. If the
+// approach fails, the method switches to a double precision factorization and
+// solve.
+//
+// The iterative refinement process is stopped if iter > 30 or
+//  ||R||_∞ < sqrt(n) * ||X||_∞ * ||A||_∞ * eps,
+// for all columns of the residual matrix R = B - A*X, where eps is the double
+// precision machine epsilon. The value 30 for the maximum number of
+// iterations is consistent with LAPACK.
+//
+// On entry, b contains the right hand side matrix B and is not modified. On
+// return, x contains the n×nrhs solution matrix X. If the iterative refinement
+// was successful, a is not modified and ipiv contains the pivot indices of the
+// single precision LU factorization of A. Otherwise, a and ipiv contain the
+// double precision LU factorization of A as computed by Dgetrf. ipiv must have
+// length at least n.
+//
+// work must have length at least n*nrhs and swork must have length at least
+// n*(n+nrhs), otherwise Dsgesv will panic.
+//
+// Dsgesv returns the number of iterations in iter:
+//  iter >= 0: iterative refinement converged after iter iterations,
+//  iter == -2: an element of A or B or of an intermediate result overflowed
+//              when converted to single precision,
+//  iter == -3: the single precision LU factorization failed because the
+//              matrix is singular in single precision,
+//  iter == -31: iterative refinement failed to converge.
+// If iter is negative, the double precision factorization was used. ok is
+// false if the double precision factorization found that A is exactly
+// singular, in which case the solution has not been computed.
+func (impl Implementation) Dsgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ipiv) < n:
+		panic(badIpiv)
+	case len(work) < n*nrhs:
+		panic(badWork)
+	case len(swork) < n*(n+nrhs):
+		panic(badWork)
+	}
+
+	if n == 0 || nrhs == 0 {
+		return 0, true
+	}
+	ipiv32 := make([]int32, n)
+	iter32 := make([]int32, 1)
+	ok = lapacke.Dsgesv(n, nrhs, a, lda, ipiv32, b, ldb, x, ldx, work, swork, iter32)
+	for i, v := range ipiv32 {
+		ipiv[i] = int(v) - 1 // Transform to zero-indexed.
+	}
+	return int(iter32[0]), ok
+}
+
+// Dsposv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices, using mixed precision iterative refinement.
+//
+// Dsposv first attempts to compute the Cholesky factorization of the matrix
+// in single precision and use
+// this factorization within an iterative refinement procedure to produce a
+// solution with double precision normwise backward error quality. If the
+// approach fails, the method switches to a double precision factorization and
+// solve.
+//
+// The iterative refinement process is stopped if iter > 30 or
+//  ||R||_∞ < sqrt(n) * ||X||_∞ * ||A||_∞ * eps,
+// for all columns of the residual matrix R = B - A*X, where eps is the double
+// precision machine epsilon. The value 30 for the maximum number of
+// iterations is consistent with LAPACK.
+//
+// On entry, a contains the upper or lower triangle of A as specified by uplo.
+// The opposite triangle is not referenced. b contains the right hand side
+// matrix B and is not modified. On return, x contains the n×nrhs solution
+// matrix X. If the iterative refinement was successful, a is not modified.
+// Otherwise, the triangle of a contains the double precision Cholesky factor
+// of A as computed by Dpotrf.
+//
+// work must have length at least n*nrhs and swork must have length at least
+// n*(n+nrhs), otherwise Dsposv will panic.
+//
+// Dsposv returns the number of iterations in iter:
+//  iter >= 0: iterative refinement converged after iter iterations,
+//  iter == -2: an element of A or B or of an intermediate result overflowed
+//              when converted to single precision,
+//  iter == -3: the single precision Cholesky factorization failed because
+//              the matrix is not positive definite in single precision,
+//  iter == -31: iterative refinement failed to converge.
+// If iter is negative, the double precision factorization was used. ok is
+// false if the double precision factorization found that A is not positive
+// definite, in which case the solution has not been computed.
+func (impl Implementation) Dsposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(work) < n*nrhs:
+		panic(badWork)
+	case len(swork) < n*(n+nrhs):
+		panic(badWork)
+	}
+
+	if n == 0 || nrhs == 0 {
+		return 0, true
+	}
+	iter32 := make([]int32, 1)
+	ok = lapacke.Dsposv(uplo, n, nrhs, a, lda, b, ldb, x, ldx, work, swork, iter32)
+	return int(iter32[0]), ok
+}
+
+// Dsteqr computes the eigenvalues and optionally the eigenvectors of a symmetric
+// tridiagonal matrix using the implicit QL or QR method. The eigenvectors of a
+// full or band symmetric matrix can also be found if Dsytrd, Dsptrd, or Dsbtrd
+// have been used to reduce this matrix to tridiagonal form.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix. On exit,
+// d contains the eigenvalues in ascending order. d must have length n and
+// Dsteqr will panic otherwise.
+//
+// e, on entry, contains the off-diagonal elements of the tridiagonal matrix on
+// entry, and is overwritten during the call to Dsteqr. e must have length n-1 and
+// Dsteqr will panic otherwise.
+//
+// z, on entry, contains the n×n orthogonal matrix used in the reduction to
+// tridiagonal form if compz == lapack.OriginalEV. On exit, if
+// compz == lapack.OriginalEV, z contains the orthonormal eigenvectors of the
+// original symmetric matrix, and if compz == lapack.TridiagEV, z contains the
+// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
+// if compz == lapack.None.
+//
+// work must have length at least max(1, 2*n-2) if the eigenvectors are computed,
+// and Dsteqr will panic otherwise.
+//
+// Dsteqr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsteqr(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if compz != lapack.None && compz != lapack.TridiagEV && compz != lapack.OriginalEV {
+		panic(badEVComp)
+	}
+	if compz != lapack.None {
+		if len(work) < max(1, 2*n-2) {
+			panic(badWork)
+		}
+		checkMatrix(n, n, z, ldz)
+	}
+
+	return lapacke.Dsteqr(lapack.Comp(compz), n, d, e, z, ldz, work)
+}
+
+// Dsterf computes all eigenvalues of a symmetric tridiagonal matrix using the
+// Pal-Walker-Kahan variant of the QL or QR algorithm.
+//
+// d contains the diagonal elements of the tridiagonal matrix on entry, and
+// contains the eigenvalues in ascending order on exit. d must have length at
+// least n, or Dsterf will panic.
+//
+// e contains the off-diagonal elements of the tridiagonal matrix on entry, and is
+// overwritten during the call to Dsterf. e must have length of at least n-1 or
+// Dsterf will panic.
+//
+// Dsterf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsterf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	return lapacke.Dsterf(n, d, e)
+}
+
+// Dsbev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric band matrix A with kd super- or subdiagonals.
+//
+// On entry, ab contains the upper or lower triangle of A in the row-major band
+// format described in the documentation of Dpbtrf. On return, ab is
+// overwritten by values generated during the reduction to tridiagonal form.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsbev will panic otherwise.
+//
+// If jobz == lapack.ComputeEV, z contains the orthonormal eigenvectors of A on
+// return, with the i-th column of z holding the eigenvector associated with
+// w[i]. z must be an n×n matrix with stride ldz. If jobz == lapack.None, z is
+// not referenced.
+//
+// work is temporary storage and must have length at least max(1,3*n-2),
+// otherwise Dsbev will panic.
+//
+// Dsbev returns whether the computation succeeded. If ok is false, the
+// QR algorithm failed to converge and some of the eigenvalues, and
+// eigenvectors if requested, have not been computed.
+func (impl Implementation) Dsbev(jobz lapack.EVJob, uplo blas.Uplo, n, kd int, ab []float64, ldab int, w, z []float64, ldz int, work []float64) (ok bool) {
+	switch {
+	case jobz != lapack.None && jobz != lapack.ComputeEV:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	if len(w) < n {
+		panic(badW)
+	}
+	wantz := jobz == lapack.ComputeEV
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	} else {
+		// LAPACKE requires ldz >= n even if z is not referenced.
+		ldz = max(1, n)
+	}
+	if len(work) < max(1, 3*n-2) {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+	abT, ldabT := toLapackeSymBand(uplo, n, kd, ab, ldab)
+	ok = lapacke.Dsbev(lapack.Job(jobz), uplo, n, kd, abT, ldabT, w, z, ldz, work)
+	fromLapackeSymBand(uplo, n, kd, ab, ldab, abT, ldabT)
+	return ok
+}
+
+// Dspev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric matrix A stored in packed format.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On return, ap is
+// overwritten by values generated during the reduction to tridiagonal form.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dspev will panic otherwise.
+//
+// If jobz == lapack.ComputeEV, z contains the orthonormal eigenvectors of A on
+// return, with the i-th column of z holding the eigenvector associated with
+// w[i]. z must be an n×n matrix with stride ldz. If jobz == lapack.None, z is
+// not referenced.
+//
+// work is temporary storage and must have length at least 3*n, otherwise
+// Dspev will panic.
+//
+// Dspev returns whether the computation succeeded. If ok is false, the
+// QR algorithm failed to converge and some of the eigenvalues, and
+// eigenvectors if requested, have not been computed.
+func (impl Implementation) Dspev(jobz lapack.EVJob, uplo blas.Uplo, n int, ap, w, z []float64, ldz int, work []float64) (ok bool) {
+	switch {
+	case jobz != lapack.None && jobz != lapack.ComputeEV:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	wantz := jobz == lapack.ComputeEV
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	} else {
+		// LAPACKE requires ldz >= n even if z is not referenced.
+		ldz = max(1, n)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dspev(lapack.Job(jobz), uplo, n, ap, w, z, ldz, work)
+}
+
+// Dsptrd reduces a symmetric n×n matrix A stored in packed format to symmetric
+// tridiagonal form T by an orthogonal similarity transformation
+//  Q^T * A * Q = T
+// where Q is an orthonormal matrix and T is symmetric and tridiagonal.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On exit, the
+// diagonal and sub/super-diagonal are overwritten by the corresponding elements
+// of the tridiagonal matrix T. The remaining elements in the triangle, along
+// with the array tau, contain the data to construct Q as the product of
+// elementary reflectors as described in the documentation of Dsytrd.
+//
+// d must have length n, and e and tau must have length n-1. Dsptrd will panic
+// if these conditions are not met.
+//
+// Dsptrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsptrd(uplo blas.Uplo, n int, ap, d, e, tau []float64) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(d) < n:
+		panic(badD)
+	case len(e) < n-1:
+		panic(badE)
+	case len(tau) < n-1:
+		panic(badTau)
+	}
+	if n == 0 {
+		return
+	}
+	lapacke.Dsptrd(uplo, n, ap, d, e, tau)
+}
+
+// Dsptrf computes the factorization of a real symmetric indefinite n×n
+// matrix A stored in packed format using the Bunch-Kaufman diagonal pivoting
+// method. The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On return, ap
+// contains the block diagonal matrix D and the multipliers used to obtain the
+// factor U or L, stored in the same way as by Dsytrf in the corresponding
+// triangle of the unpacked matrix. The pivot indices are stored in ipiv as
+// described in the documentation for Dsytrf. ipiv must have length at least
+// n, otherwise Dsptrf will panic.
+//
+// Dsptrf returns whether the block diagonal matrix D is nonsingular. If ok is
+// false, the factorization has been completed, but D is exactly singular and
+// division by zero will occur if it is used to solve a system of equations.
+func (impl Implementation) Dsptrf(uplo blas.Uplo, n int, ap []float64, ipiv []int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(ipiv) < n:
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dsptrf(uplo, n, ap, ipiv32)
+	for i, v := range ipiv32 {
+		if v > 0 {
+			v-- // Transform to zero-indexed.
+		}
+		ipiv[i] = int(v)
+	}
+	return ok
+}
+
+// Dsycon estimates the reciprocal of the condition number of a symmetric
+// indefinite matrix A given the factorization of A computed by Dsytrf. The
+// condition number computed is based on the 1-norm and the ∞-norm.
+//
+// An estimate is obtained for norm(inv(A)), and the reciprocal of the
+// condition number is computed as
+//  rcond = 1 / (anorm * norm(inv(A))).
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// ipiv must have length at least n and contain the pivot indices as returned
+// by Dsytrf.
+//
+// work is a temporary data slice of length at least 2*n and Dsycon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dsycon will panic otherwise.
+func (impl Implementation) Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 1
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		if v >= 0 {
+			v++ // Transform to one-indexed.
+		}
+		ipiv32[i] = int32(v)
+	}
+	rcond := make([]float64, 1)
+	_iwork := make([]int32, len(iwork))
+	lapacke.Dsycon(uplo, n, a, lda, ipiv32, anorm, rcond, work, _iwork)
+	return rcond[0]
+}
+
+// Dsyev computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsyev will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At minimum,
+// lwork >= 3*n-1, and Dsyev will panic otherwise. The amount of blocking is
+// limited by the usable length. If lwork == -1, instead of computing Dsyev the
+// optimal work length is stored into work[0].
+func (impl Implementation) Dsyev(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool) {
+	checkMatrix(n, n, a, lda)
+	if lwork == -1 {
+		work[0] = 3*float64(n) - 1
+		return
+	}
+	if len(work) < lwork {
+		panic(badWork)
+	}
+	if lwork < 3*n-1 {
+		panic(badWork)
+	}
+	return lapacke.Dsyev(lapack.Job(jobz), uplo, n, a, lda, w, work, lwork)
+}
+
+// Dsyevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A. If eigenvectors are desired, it uses a divide and conquer
+// algorithm.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsyevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. Dsyevd will panic if
+// these conditions are not met.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dsyevd the optimal work
+// length is stored into work[0] and the minimum iwork length is stored into
+// iwork[0].
+//
+// Dsyevd returns whether the computation succeeded.
+func (impl Implementation) Dsyevd(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	checkMatrix(n, n, a, lda)
+	if lwork == -1 || liwork == -1 {
+		_iwork := []int32{0}
+		ok = lapacke.Dsyevd(lapack.Job(jobz), uplo, n, a, lda, w, work, -1, _iwork, -1)
+		iwork[0] = int(_iwork[0])
+		return ok
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if len(work) < lwork || len(iwork) < liwork {
+		panic(badWork)
+	}
+	_iwork := make([]int32, liwork)
+	ok = lapacke.Dsyevd(lapack.Job(jobz), uplo, n, a, lda, w, work, lwork, _iwork, liwork)
+	if liwork > 0 {
+		iwork[0] = int(_iwork[0])
+	}
+	return ok
+}
+
+// Dsyevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. The matrix is first reduced to tridiagonal form by
+// Dsytrd, and the eigenpairs of the tridiagonal matrix are then computed by
+// the Multiple Relatively Robust Representations algorithm.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by uplo. On return, this triangular portion
+// of a, including the diagonal, is overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// orthonormal eigenvectors of A, the i-th column corresponding to w[i], and
+// the support of the i-th eigenvector is stored in isuppz[2*i] through
+// isuppz[2*i+1]. z must have at least iu-il+1 columns if rng is
+// lapack.RangeIndex and n columns otherwise, and isuppz must have length at
+// least twice the number of columns of z. If jobz != lapack.ComputeEV, z and
+// isuppz are not referenced.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. lwork must be at least max(1,26*n) and liwork must be at
+// least max(1,10*n), and Dsyevr will panic otherwise. If lwork == -1 or
+// liwork == -1, instead of computing Dsyevr the optimal work length is stored
+// into work[0] and the minimum iwork length is stored into iwork[0].
+//
+// Dsyevr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case rng != lapack.RangeAll && rng != lapack.RangeInterval && rng != lapack.RangeIndex:
+		panic(badRange)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.RangeInterval && n > 0 && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.RangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.RangeIndex && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	checkMatrix(n, n, a, lda)
+	if lwork == -1 || liwork == -1 {
+		_iwork := []int32{0}
+		lapacke.Dsyevr(lapack.Job(jobz), byte(rng), uplo, n, a, lda, vl, vu, il+1, iu+1, abstol, []int32{0}, w, z, max(1, ldz), nil, work, -1, _iwork, -1)
+		iwork[0] = int(_iwork[0])
+		return 0, true
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if len(work) < lwork || len(iwork) < liwork {
+		panic(badWork)
+	}
+	nzc := 1
+	if wantz {
+		nzc = n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(isuppz) < 2*nzc {
+			panic(badIsuppz)
+		}
+	} else {
+		ldz = max(1, ldz)
+	}
+	m32 := []int32{0}
+	isuppz32 := make([]int32, 2*max(1, nzc))
+	_iwork := make([]int32, liwork)
+	ok = lapacke.Dsyevr(lapack.Job(jobz), byte(rng), uplo, n, a, lda, vl, vu, il+1, iu+1, abstol, m32, w, z, ldz, isuppz32, work, lwork, _iwork, liwork)
+	m = int(m32[0])
+	if wantz {
+		for i := 0; i < 2*m; i++ {
+			isuppz[i] = int(isuppz32[i]) - 1 // Transform to zero-indexed.
+		}
+	}
+	if liwork > 0 {
+		iwork[0] = int(_iwork[0])
+	}
+	return m, ok
+}
+
+// Dsyevx computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. The matrix is first reduced to tridiagonal form by
+// Dsytrd, the eigenvalues are then computed by bisection and the
+// eigenvectors by inverse iteration.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by uplo. On return, this triangular portion
+// of a, including the diagonal, is overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// orthonormal eigenvectors of A, the i-th column corresponding to w[i]. z must
+// have at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise. ifail[:m] holds the indices of the eigenvectors that failed to
+// converge, followed by -1, and ifail must have length at least n. If jobz !=
+// lapack.ComputeEV, z and ifail are not referenced.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n), and Dsyevx will panic otherwise. If
+// lwork == -1, instead of computing Dsyevx the optimal work length is stored
+// into work[0]. iwork must have length at least 5*n.
+//
+// Dsyevx returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dsyevx(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case rng != lapack.RangeAll && rng != lapack.RangeInterval && rng != lapack.RangeIndex:
+		panic(badRange)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.RangeInterval && n > 0 && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.RangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.RangeIndex && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	checkMatrix(n, n, a, lda)
+	if lwork == -1 {
+		lapacke.Dsyevx(lapack.Job(jobz), byte(rng), uplo, n, a, lda, vl, vu, il+1, iu+1, abstol, []int32{0}, w, z, max(1, ldz), work, -1, nil, nil)
+		return 0, true
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if len(work) < lwork || len(iwork) < 5*n {
+		panic(badWork)
+	}
+	if wantz {
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(ifail) < n {
+			panic(badIndex)
+		}
+	} else {
+		ldz = max(1, ldz)
+	}
+	m32 := []int32{0}
+	iwork32 := make([]int32, 5*n)
+	ifail32 := make([]int32, n)
+	ok = lapacke.Dsyevx(lapack.Job(jobz), byte(rng), uplo, n, a, lda, vl, vu, il+1, iu+1, abstol, m32, w, z, ldz, work, lwork, iwork32, ifail32)
+	m = int(m32[0])
+	if wantz {
+		for i := 0; i < m; i++ {
+			ifail[i] = int(ifail32[i]) - 1 // Transform to zero-indexed.
+		}
+	}
+	return m, ok
+}
+
+// Dsygv computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form
+//  A*x = lambda*B*x   if itype == 1,
+//  A*B*x = lambda*x   if itype == 2,
+//  B*A*x = lambda*x   if itype == 3,
+// where A and B are n×n symmetric matrices and B is also positive definite.
+//
+// On entry, a and b contain the upper or lower triangles of A and B as
+// specified by uplo. On return, if jobz == lapack.ComputeEV, a contains the
+// matrix Z of eigenvectors normalized so that Z^T*B*Z = I if itype == 1 or 2,
+// and Z^T*inv(B)*Z = I if itype == 3. b contains the triangular factor from
+// the Cholesky factorization of B.
+//
+// w contains the eigenvalues in ascending order upon return. w must have
+// length at least n, and Dsygv will panic otherwise.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,3*n-1), and Dsygv will panic otherwise. If
+// lwork == -1, instead of computing Dsygv the optimal work length is stored
+// into work[0].
+//
+// Dsygv returns whether the computation succeeded. If ok is false, either B
+// is not positive definite or the eigenvalue computation did not converge.
+func (impl Implementation) Dsygv(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int) (ok bool) {
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if lwork == -1 {
+		return lapacke.Dsygv(itype, lapack.Job(jobz), uplo, n, a, lda, b, ldb, w, work, -1)
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < max(1, 3*n-1) || len(work) < lwork {
+		panic(badWork)
+	}
+	return lapacke.Dsygv(itype, lapack.Job(jobz), uplo, n, a, lda, b, ldb, w, work, lwork)
+}
+
+// Dsygvd computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem. It has the same interface as
+// Dsygv but uses a divide and conquer algorithm if eigenvectors are desired.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. If lwork == -1 or
+// liwork == -1, instead of computing Dsygvd the optimal work length is stored
+// into work[0] and the minimum iwork length is stored into iwork[0].
+//
+// Dsygvd returns whether the computation succeeded.
+func (impl Implementation) Dsygvd(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if lwork == -1 || liwork == -1 {
+		_iwork := []int32{0}
+		ok = lapacke.Dsygvd(itype, lapack.Job(jobz), uplo, n, a, lda, b, ldb, w, work, -1, _iwork, -1)
+		iwork[0] = int(_iwork[0])
+		return ok
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if len(work) < lwork || len(iwork) < liwork {
+		panic(badWork)
+	}
+	_iwork := make([]int32, liwork)
+	ok = lapacke.Dsygvd(itype, lapack.Job(jobz), uplo, n, a, lda, b, ldb, w, work, lwork, _iwork, liwork)
+	if liwork > 0 {
+		iwork[0] = int(_iwork[0])
+	}
+	return ok
+}
+
+// Dsygvx computes selected eigenvalues and, optionally, eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form given in the
+// documentation of Dsygv.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n. If jobz ==
+// lapack.ComputeEV, the first m columns of z contain the eigenvectors,
+// normalized as in Dsygv, the i-th column corresponding to w[i]. z must have
+// at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise. ifail[:m] holds the indices of the eigenvectors that failed to
+// converge, and ifail must have length at least n.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n). If lwork == -1, instead of computing
+// Dsygvx the optimal work length is stored into work[0]. iwork must have
+// length at least 5*n.
+//
+// Dsygvx returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dsygvx(itype int, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case rng != lapack.RangeAll && rng != lapack.RangeInterval && rng != lapack.RangeIndex:
+		panic(badRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case rng == lapack.RangeInterval && n > 0 && vl >= vu:
+		panic(badInterval)
+	case rng == lapack.RangeIndex && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case rng == lapack.RangeIndex && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if lwork == -1 {
+		lapacke.Dsygvx(itype, lapack.Job(jobz), byte(rng), uplo, n, a, lda, b, ldb, vl, vu, il+1, iu+1, abstol, []int32{0}, w, z, max(1, ldz), work, -1, nil, nil)
+		return 0, true
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if len(work) < lwork || len(iwork) < 5*n {
+		panic(badWork)
+	}
+	if wantz {
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(ifail) < n {
+			panic(badIndex)
+		}
+	} else {
+		ldz = max(1, ldz)
+	}
+	m32 := []int32{0}
+	iwork32 := make([]int32, 5*n)
+	ifail32 := make([]int32, n)
+	ok = lapacke.Dsygvx(itype, lapack.Job(jobz), byte(rng), uplo, n, a, lda, b, ldb, vl, vu, il+1, iu+1, abstol, m32, w, z, ldz, work, lwork, iwork32, ifail32)
+	m = int(m32[0])
+	if wantz {
+		for i := 0; i < m; i++ {
+			ifail[i] = int(ifail32[i]) - 1 // Transform to zero-indexed.
+		}
+	}
+	return m, ok
+}
+
+// Dsysv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric indefinite matrix and X and B are n×nrhs
+// matrices.
+//
+// The Bunch-Kaufman diagonal pivoting method is used to factor A as
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks. On return, the factorization is stored in a and ipiv as
+// described in the documentation for Dsytrf. ipiv must have length at least n
+// and Dsysv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Dsysv will panic otherwise. If lwork == -1, instead
+// of computing Dsysv the optimal work length is stored into work[0].
+//
+// Dsysv returns false if D is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dsysv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		return lapacke.Dsysv(uplo, n, nrhs, a, lda, nil, b, ldb, work, -1)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dsysv(uplo, n, nrhs, a, lda, ipiv32, b, ldb, work, lwork)
+	for i, v := range ipiv32 {
+		if v > 0 {
+			v-- // Transform to zero-indexed.
+		}
+		ipiv[i] = int(v)
+	}
+	return ok
+}
+
+// DsysvRook computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric indefinite matrix and X and B are n×nrhs
+// matrices.
+//
+// The bounded Bunch-Kaufman ("rook") diagonal pivoting method is used to
+// factor A as
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks. On return, the factorization is stored in a and ipiv as
+// described in the documentation for DsytrfRook. ipiv must have length at
+// least n and DsysvRook will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and DsysvRook will panic otherwise. If lwork == -1,
+// instead of computing DsysvRook the optimal work length is stored into
+// work[0].
+//
+// DsysvRook returns false if D is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) DsysvRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		return lapacke.DsysvRook(uplo, n, nrhs, a, lda, nil, b, ldb, work, -1)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.DsysvRook(uplo, n, nrhs, a, lda, ipiv32, b, ldb, work, lwork)
+	for i, v := range ipiv32 {
+		if v > 0 {
+			v-- // Transform to zero-indexed.
+		}
+		ipiv[i] = int(v)
+	}
+	return ok
+}
+
+// Dsytrd reduces a symmetric n×n matrix A to symmetric tridiagonal form by an
+// orthogonal similarity transformation
+//  Q^T * A * Q = T
+// where Q is an orthonormal matrix and T is symmetric and tridiagonal.
+//
+// On entry, a contains the elements of the input matrix in the triangle specified
+// by uplo. On exit, the diagonal and sub/super-diagonal are overwritten by the
+// corresponding elements of the tridiagonal matrix T. The remaining elements in
+// the triangle, along with the array tau, contain the data to construct Q as
+// the product of elementary reflectors.
+//
+// If uplo == blas.Upper, Q is constructed with
+//  Q = H_{n-2} * ... * H_1 * H_0
+// where
+//  H_i = I - tau_i * v * v^T
+// v is constructed as v[i+1:n] = 0, v[i] = 1, v[0:i-1] is stored in A[0:i-1, i+1].
+// The elements of A are
+//  [ d   e  v1  v2  v3]
+//  [     d   e  v2  v3]
+//  [         d   e  v3]
+//  [             d   e]
+//  [                 e]
+//
+// If uplo == blas.Lower, Q is constructed with
+//  Q = H_0 * H_1 * ... * H_{n-2}
+// where
+//  H_i = I - tau_i * v * v^T
+// v is constructed as v[0:i+1] = 0, v[i+1] = 1, v[i+2:n] is stored in A[i+2:n, i].
+// The elements of A are
+//  [ d                ]
+//  [ e   d            ]
+//  [v0   e   d        ]
+//  [v0  v1   e   d    ]
+//  [v0  v1  v2   e   d]
+//
+// d must have length n, and e and tau must have length n-1. Dsytrd will panic if
+// these conditions are not met.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At minimum,
+// lwork >= 1, and Dsytrd will panic otherwise. The amount of blocking is
+// limited by the usable length.
+// If lwork == -1, instead of computing Dsytrd the optimal work length is stored
+// into work[0].
+//
+// Dsytrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsytrd(uplo blas.Uplo, n int, a []float64, lda int, d, e, tau, work []float64, lwork int) {
+	checkMatrix(n, n, a, lda)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(tau) < n-1 {
+		panic(badTau)
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork != -1 && lwork < 1 {
+		panic(badWork)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+
+	lapacke.Dsytrd(uplo, n, a, lda, d, e, tau, work, lwork)
+}
+
+// Dsytrf computes the factorization of a real symmetric indefinite n×n matrix
+// A using the Bunch-Kaufman diagonal pivoting method. The form of the
+// factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// If uplo == blas.Upper, U is stored as
+//  U = P(n-1)*U(n-1)* ... *P(k)*U(k)* ...,
+// where k decreases from n-1 to 0 in steps of 1 or 2, and if uplo ==
+// blas.Lower, L is stored as
+//  L = P(0)*L(0)* ... *P(k)*L(k)* ...,
+// where k increases from 0 to n-1 in steps of 1 or 2. P(k) is a permutation
+// matrix as defined by ipiv[k], and U(k) (or L(k)) is a unit upper (lower)
+// triangular matrix that differs from the identity only in the column of the
+// 1×1 or the two columns of the 2×2 pivot block D(k). The non-trivial
+// elements of U(k) (or L(k)) and the elements of D are stored in the
+// corresponding triangle of a.
+//
+// ipiv contains details of the interchanges and the block structure of D.
+// ipiv must have length at least n and the pivot indices are zero-based. If
+// ipiv[k] >= 0, then rows and columns k and ipiv[k] were interchanged and
+// D[k,k] is a 1×1 diagonal block. If uplo == blas.Upper and
+//  ipiv[k] = ipiv[k-1] = -p-1 < 0,
+// then rows and columns k-1 and p were interchanged and D[k-1:k+1,k-1:k+1] is
+// a 2×2 diagonal block. If uplo == blas.Lower and
+//  ipiv[k] = ipiv[k+1] = -p-1 < 0,
+// then rows and columns k+1 and p were interchanged and D[k:k+2,k:k+2] is a
+// 2×2 diagonal block.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Dsytrf will panic otherwise. The amount of blocking
+// is limited by the usable length. If lwork == -1, instead of computing
+// Dsytrf the optimal work length is stored into work[0].
+//
+// Dsytrf returns whether the block diagonal matrix D is nonsingular. If ok is
+// false, the factorization has been completed, but D is exactly singular and
+// division by zero will occur if it is used to solve a system of equations.
+func (impl Implementation) Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		return lapacke.Dsytrf(uplo, n, a, lda, nil, work, -1)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.Dsytrf(uplo, n, a, lda, ipiv32, work, lwork)
+	for i, v := range ipiv32 {
+		if v > 0 {
+			v-- // Transform to zero-indexed.
+		}
+		ipiv[i] = int(v)
+	}
+	return ok
+}
+
+// DsytrfRook computes the factorization of a real symmetric indefinite n×n
+// matrix A using the bounded Bunch-Kaufman ("rook") diagonal pivoting method.
+// The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// In contrast to Dsytrf, the rook pivoting strategy bounds the elements of U
+// (or L), which makes the factorization more accurate for some matrices at
+// the cost of a possibly larger number of comparisons during the pivot search.
+//
+// The factors U (or L) and D are stored in a in the same way as in Dsytrf,
+// but the pivot indices in ipiv differ for 2×2 diagonal blocks. ipiv must
+// have length at least n and the pivot indices are zero-based. If
+// ipiv[k] >= 0, then rows and columns k and ipiv[k] were interchanged and
+// D[k,k] is a 1×1 diagonal block. If uplo == blas.Upper and both
+//  ipiv[k] = -p-1 < 0  and  ipiv[k-1] = -q-1 < 0,
+// then rows and columns k and p were interchanged, rows and columns k-1 and q
+// were interchanged, and D[k-1:k+1,k-1:k+1] is a 2×2 diagonal block. If
+// uplo == blas.Lower and both
+//  ipiv[k] = -p-1 < 0  and  ipiv[k+1] = -q-1 < 0,
+// then rows and columns k and p were interchanged, rows and columns k+1 and q
+// were interchanged, and D[k:k+2,k:k+2] is a 2×2 diagonal block.
+//
+// The factorization computed by DsytrfRook must be used with DsytrsRook.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and DsytrfRook will panic otherwise. The amount of
+// blocking is limited by the usable length. If lwork == -1, instead of
+// computing DsytrfRook the optimal work length is stored into work[0].
+//
+// DsytrfRook returns whether the block diagonal matrix D is nonsingular. If ok
+// is false, the factorization has been completed, but D is exactly singular
+// and division by zero will occur if it is used to solve a system of
+// equations.
+func (impl Implementation) DsytrfRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork == -1 {
+		return lapacke.DsytrfRook(uplo, n, a, lda, nil, work, -1)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	ok = lapacke.DsytrfRook(uplo, n, a, lda, ipiv32, work, lwork)
+	for i, v := range ipiv32 {
+		if v > 0 {
+			v-- // Transform to zero-indexed.
+		}
+		ipiv[i] = int(v)
+	}
+	return ok
+}
+
+// Dsytri computes the inverse of a real symmetric indefinite n×n matrix A
+// using its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by Dsytrf.
+//
+// On entry, a and ipiv must contain the details of the block diagonal matrix D
+// and the multipliers used to obtain the factor U or L as returned by Dsytrf.
+// On return, a contains the upper or lower triangle of the (symmetric) inverse
+// of A, overwriting the factors.
+//
+// ipiv must have length at least n and work must have length at least n,
+// otherwise Dsytri will panic.
+//
+// Dsytri returns whether the matrix A is invertible. If Dsytri returns false,
+// D is exactly singular and the inverse could not be computed.
+func (impl Implementation) Dsytri(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+	if n == 0 {
+		return true
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		if v >= 0 {
+			v++ // Transform to one-indexed.
+		}
+		ipiv32[i] = int32(v)
+	}
+	return lapacke.Dsytri(uplo, n, a, lda, ipiv32, work)
+}
+
+// Dsytrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric indefinite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by Dsytrf. a and ipiv must contain the details of the block
+// diagonal matrix D and the multipliers used to obtain the factor U or L as
+// returned by Dsytrf. ipiv must have length at least n, otherwise Dsytrs will
+// panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		if v >= 0 {
+			v++ // Transform to one-indexed.
+		}
+		ipiv32[i] = int32(v)
+	}
+	lapacke.Dsytrs(uplo, n, nrhs, a, lda, ipiv32, b, ldb)
+}
+
+// DsytrsRook solves a system of n linear equations A*X = B where A is an n×n
+// symmetric indefinite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by DsytrfRook. a and ipiv must contain the details of the block
+// diagonal matrix D and the multipliers used to obtain the factor U or L as
+// returned by DsytrfRook. ipiv must have length at least n, otherwise
+// DsytrsRook will panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) DsytrsRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	ipiv32 := make([]int32, n)
+	for i, v := range ipiv[:n] {
+		if v >= 0 {
+			v++ // Transform to one-indexed.
+		}
+		ipiv32[i] = int32(v)
+	}
+	lapacke.DsytrsRook(uplo, n, nrhs, a, lda, ipiv32, b, ldb)
+}
+
+// Dtrcon estimates the reciprocal of the condition number of a triangular matrix A.
+// The condition number computed may be based on the 1-norm or the ∞-norm.
+//
+// work is a temporary data slice of length at least 3*n and Dtrcon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dtrcon will panic otherwise.
+// Elements of iwork must fit within the int32 type or Dtrcon will panic.
+func (impl Implementation) Dtrcon(norm lapack.MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64 {
+	if norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum {
+		panic(badNorm)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if diag != blas.NonUnit && diag != blas.Unit {
+		panic(badDiag)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+	rcond := []float64{0}
+	_iwork := make([]int32, len(iwork))
+	for i, v := range iwork {
+		if v != int(int32(v)) {
+			panic("lapack: iwork element out of range")
+		}
+		_iwork[i] = int32(v)
+	}
+	lapacke.Dtrcon(byte(norm), uplo, diag, n, a, lda, rcond, work, _iwork)
+	for i, v := range _iwork {
+		iwork[i] = int(v)
+	}
+	return rcond[0]
+}
+
+// Dtrexc reorders the real Schur factorization of a n×n real matrix
+//  A = Q*T*Q^T
+// so that the diagonal block of T with row index ifst is moved to row ilst.
+//
+// On entry, T must be in Schur canonical form, that is, block upper triangular
+// with 1×1 and 2×2 diagonal blocks; each 2×2 diagonal block has its diagonal
+// elements equal and its off-diagonal elements of opposite sign.
+//
+// On return, T will be reordered by an orthogonal similarity transformation Z
+// as Z^T*T*Z, and will be again in Schur canonical form.
+//
+// If compq is lapack.UpdateSchur, on return the matrix Q of Schur vectors will be
+// updated by postmultiplying it with Z.
+// If compq is lapack.None, the matrix Q is not referenced and will not be
+// updated.
+// For other values of compq Dtrexc will panic.
+//
+// ifst and ilst specify the reordering of the diagonal blocks of T. The block
+// with row index ifst is moved to row ilst, by a sequence of transpositions
+// between adjacent blocks.
+//
+// If ifst points to the second row of a 2×2 block, ifstOut will point to the
+// first row, otherwise it will be equal to ifst.
+//
+// ilstOut will point to the first row of the block in its final position. If ok
+// is true, ilstOut may differ from ilst by +1 or -1.
+//
+// It must hold that
+//  0 <= ifst < n, and  0 <= ilst < n,
+// otherwise Dtrexc will panic.
+//
+// If ok is false, two adjacent blocks were too close to swap because the
+// problem is very ill-conditioned. T may have been partially reordered, and
+// ilstOut will point to the first row of the block at the position to which it
+// has been moved.
+//
+// work must have length at least n, otherwise Dtrexc will panic.
+//
+// Dtrexc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtrexc(compq lapack.EVComp, n int, t []float64, ldt int, q []float64, ldq int, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
+	checkMatrix(n, n, t, ldt)
+	switch compq {
+	default:
+		panic("lapack: bad value of compq")
+	case lapack.None:
+		// q is not referenced but LAPACKE checks that ldq >= n always.
+		q = nil
+		ldq = max(1, n)
+	case lapack.UpdateSchur:
+		checkMatrix(n, n, q, ldq)
+	}
+	if (ifst < 0 || n <= ifst) && n > 0 {
+		panic("lapack: ifst out of range")
+	}
+	if (ilst < 0 || n <= ilst) && n > 0 {
+		panic("lapack: ilst out of range")
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n <= 1 {
+		return ifst, ilst, true
+	}
+
+	ifst32 := []int32{int32(ifst + 1)}
+	ilst32 := []int32{int32(ilst + 1)}
+	ok = lapacke.Dtrexc(lapack.Comp(compq), n, t, ldt, q, ldq, ifst32, ilst32, work)
+	ifst = int(ifst32[0] - 1)
+	ilst = int(ilst32[0] - 1)
+	return ifst, ilst, ok
+}
+
+// Dtftri computes the inverse of an n×n triangular matrix A stored in
+// rectangular full packed (RFP) format. a contains the upper or lower triangle
+// of A in the RFP format described in the documentation of Dtrttf. transr must
+// be blas.NoTrans or blas.Trans, and a must have length at least n*(n+1)/2,
+// otherwise Dtftri will panic. On return, a contains the inverse of A in the
+// same format.
+//
+// Dtftri returns whether the matrix A is nonsingular. If ok is false, A is
+// exactly singular and the inverse has not been computed.
+func (impl Implementation) Dtftri(transr blas.Transpose, uplo blas.Uplo, diag blas.Diag, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dtftri(transr, uplo, diag, n, a)
+}
+
+// Dtfttr copies an n×n symmetric or triangular matrix A stored in rectangular
+// full packed (RFP) format in arf into the upper or lower triangle of a in
+// conventional format. The RFP format is described in the documentation of
+// Dtrttf. The elements of a outside of the triangle are not referenced.
+//
+// transr must be blas.NoTrans or blas.Trans, and arf must have length at
+// least n*(n+1)/2, otherwise Dtfttr will panic.
+func (impl Implementation) Dtfttr(transr blas.Transpose, uplo blas.Uplo, n int, arf []float64, a []float64, lda int) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+	checkMatrix(n, n, a, max(1, lda))
+	if n == 0 {
+		return
+	}
+	lapacke.Dtfttr(transr, uplo, n, arf, a, lda)
+}
+
+// Dtgexc reorders the generalized real Schur decomposition of an n×n real
+// matrix pair (A,B) using an orthogonal equivalence transformation
+//  (A,B) := Q^T * (A,B) * Z,
+// so that the diagonal block of (A,B) with row index ifst is moved to row
+// ilst.
+//
+// (A,B) must be in generalized real Schur canonical form as returned by Dgges.
+// If wantq is true, the n×n orthogonal matrix Q is updated as Q := Q * Qt,
+// otherwise Q is not referenced. If wantz is true, the n×n orthogonal matrix Z
+// is updated as Z := Z * Zt, otherwise Z is not referenced.
+//
+// It must hold that
+//  0 <= ifst < n, and  0 <= ilst < n,
+// otherwise Dtgexc will panic. If ifst points to the second row of a 2×2
+// block, ifstOut will point to the first row, otherwise it will be equal to
+// ifst. ilstOut will point to the first row of the block in its final
+// position.
+//
+// If ok is false, two adjacent blocks were too close to swap because the
+// problem is very ill-conditioned.
+//
+// work must have length at least lwork, and lwork must be at least 1 if
+// n <= 1, and at least 4*n+16 otherwise. If lwork is -1, instead of performing
+// Dtgexc, the minimum value of lwork is stored into work[0].
+//
+// Dtgexc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgexc(wantq, wantz bool, n int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, ifst, ilst int, work []float64, lwork int) (ifstOut, ilstOut int, ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	// q and z are not referenced if they are not wanted but LAPACKE checks
+	// that ldq, ldz >= n always.
+	var wantq32, wantz32 int32
+	if wantq {
+		wantq32 = 1
+	} else {
+		q = nil
+		ldq = max(1, n)
+	}
+	if wantz {
+		wantz32 = 1
+	} else {
+		z = nil
+		ldz = max(1, n)
+	}
+	if lwork == -1 {
+		ifst32 := []int32{1}
+		ilst32 := []int32{1}
+		ok = lapacke.Dtgexc(wantq32, wantz32, n, a, max(1, lda), b, max(1, ldb), q, ldq, z, ldz, ifst32, ilst32, work, -1)
+		return ifst, ilst, ok
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	if (ifst < 0 || n <= ifst) && n > 0 {
+		panic("lapack: ifst out of range")
+	}
+	if (ilst < 0 || n <= ilst) && n > 0 {
+		panic("lapack: ilst out of range")
+	}
+	lwmin := 1
+	if n > 1 {
+		lwmin = 4*n + 16
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n <= 1 {
+		return ifst, ilst, true
+	}
+
+	ifst32 := []int32{int32(ifst + 1)}
+	ilst32 := []int32{int32(ilst + 1)}
+	ok = lapacke.Dtgexc(wantq32, wantz32, n, a, lda, b, ldb, q, ldq, z, ldz, ifst32, ilst32, work, lwork)
+	ifst = int(ifst32[0] - 1)
+	ilst = int(ilst32[0] - 1)
+	return ifst, ilst, ok
+}
+
+// Dtgsen reorders the generalized real Schur decomposition of an n×n real
+// matrix pair (A,B) (in terms of an orthogonal equivalence transformation
+//  (A,B) := Q^T * (A,B) * Z)
+// so that a selected cluster of eigenvalues appears in the leading diagonal
+// blocks of the upper quasi-triangular matrix A and the upper triangular B.
+// Optionally, Dtgsen computes estimates of the reciprocal condition numbers of
+// the cluster of eigenvalues and of the deflating subspaces as specified by
+// ijob.
+//
+// If wantq is true, the Schur vectors in q are updated, otherwise q is not
+// referenced. If wantz is true, the Schur vectors in z are updated, otherwise
+// z is not referenced. selected must have length n. To select a complex
+// conjugate pair of eigenvalues corresponding to a 2×2 diagonal block,
+// either selected[j] or selected[j+1] must be true. On return, m is the number
+// of selected eigenvalues, and alphar, alphai and beta contain the reordered
+// generalized eigenvalues of (A,B).
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If lwork == -1 or liwork == -1, instead of performing Dtgsen,
+// the minimum lengths of work and iwork are stored into work[0] and iwork[0].
+//
+// Dtgsen returns whether the reordering was successful.
+func (impl Implementation) Dtgsen(ijob int, wantq, wantz bool, selected []bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, pl, pr, difu, difl float64, ok bool) {
+	if ijob < 0 || 5 < ijob {
+		panic("lapack: bad ijob")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(selected) != n {
+		panic("lapack: bad length of selected")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	// q and z are not referenced if they are not wanted but LAPACKE checks
+	// that ldq, ldz >= n always.
+	var wantq32, wantz32 int32
+	if wantq {
+		wantq32 = 1
+		checkMatrix(n, n, q, ldq)
+	} else {
+		q = nil
+		ldq = max(1, n)
+	}
+	if wantz {
+		wantz32 = 1
+		checkMatrix(n, n, z, ldz)
+	} else {
+		z = nil
+		ldz = max(1, n)
+	}
+
+	sel := make([]int32, n)
+	for i, v := range selected {
+		if v {
+			sel[i] = 1
+		}
+	}
+	m32 := []int32{0}
+	_pl := []float64{0}
+	_pr := []float64{0}
+	dif := []float64{0, 0}
+	if lwork == -1 || liwork == -1 {
+		_iwork := []int32{0}
+		ok = lapacke.Dtgsen(lapack.Job(ijob), wantq32, wantz32, sel, n, a, lda, b, ldb,
+			make([]float64, n), make([]float64, n), make([]float64, n), q, ldq, z, ldz,
+			m32, _pl, _pr, dif, work, -1, _iwork, -1)
+		iwork[0] = int(_iwork[0])
+		return int(m32[0]), 0, 0, 0, 0, ok
+	}
+	switch {
+	case len(alphar) != n:
+		panic("lapack: bad length of alphar")
+	case len(alphai) != n:
+		panic("lapack: bad length of alphai")
+	case len(beta) != n:
+		panic("lapack: bad length of beta")
+	case len(work) < lwork || len(iwork) < liwork:
+		panic(badWork)
+	}
+	_iwork := make([]int32, max(1, liwork))
+	ok = lapacke.Dtgsen(lapack.Job(ijob), wantq32, wantz32, sel, n, a, lda, b, ldb, alphar, alphai, beta,
+		q, ldq, z, ldz, m32, _pl, _pr, dif, work, lwork, _iwork, liwork)
+	if liwork > 0 {
+		iwork[0] = int(_iwork[0])
+	}
+	return int(m32[0]), _pl[0], _pr[0], dif[0], dif[1], ok
+}
+
+// Dtgsna estimates reciprocal condition numbers for specified eigenvalues
+// and/or eigenvectors of an n×n matrix pair (A,B) in generalized real Schur
+// canonical form, or of any matrix pair (Q*A*Z^T, Q*B*Z^T) with orthogonal Q
+// and Z.
+//
+// If job is lapack.CondEigenvalues or lapack.CondBoth, the condition numbers
+// of the eigenvalues are stored in s, and vl and vr must contain the
+// corresponding left and right eigenvectors as returned by Dtgevc. If job is
+// lapack.CondSubspace or lapack.CondBoth, the condition numbers of the
+// eigenvectors are stored in dif.
+//
+// howmny must be lapack.AllEV or lapack.SelectedEV. In the latter case the
+// condition numbers are computed only for the eigenpairs specified by
+// selected.
+//
+// work must have length at least lwork, and lwork must be at least max(1,n)
+// if job is lapack.CondEigenvalues, and at least 2*n*(n+2)+16 otherwise. If
+// lwork is -1, instead of performing Dtgsna, the minimum value of lwork is
+// stored into work[0]. iwork must have length at least n+2 if job is
+// lapack.CondSubspace or lapack.CondBoth.
+//
+// Dtgsna returns m, the number of selected eigenvalues counting each complex
+// conjugate pair as two.
+func (impl Implementation) Dtgsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, a []float64, lda int, b []float64, ldb int, vl []float64, ldvl int, vr []float64, ldvr int, s, dif []float64, mm int, work []float64, lwork int, iwork []int) (m int) {
+	var wants, wantdf bool
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantdf = true
+	case lapack.CondBoth:
+		wants = true
+		wantdf = true
+	}
+	sel := make([]int32, n)
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV:
+	case lapack.SelectedEV:
+		if len(selected) != n {
+			panic("lapack: bad length of selected")
+		}
+		for i, v := range selected {
+			if v {
+				sel[i] = 1
+			}
+		}
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if !wants {
+		// vl and vr are not referenced but LAPACKE checks that
+		// ldvl, ldvr >= mm always.
+		vl = nil
+		ldvl = max(1, mm)
+		vr = nil
+		ldvr = max(1, mm)
+	}
+	m32 := []int32{0}
+	_iwork := make([]int32, n+6)
+	if lwork == -1 {
+		lapacke.Dtgsna(lapack.Job(job), byte(howmny), sel, n, a, max(1, lda), b, max(1, ldb), vl, max(1, ldvl), vr, max(1, ldvr),
+			s, dif, mm, m32, work, -1, _iwork)
+		return int(m32[0])
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wants {
+		checkMatrix(n, mm, vl, ldvl)
+		checkMatrix(n, mm, vr, ldvr)
+	}
+	lwmin := max(1, n)
+	if wantdf && n > 0 {
+		lwmin = 2*n*(n+2) + 16
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if wantdf && len(iwork) < n+2 {
+		panic(badWork)
+	}
+	if n == 0 {
+		return 0
+	}
+
+	lapacke.Dtgsna(lapack.Job(job), byte(howmny), sel, n, a, lda, b, ldb, vl, ldvl, vr, ldvr,
+		s, dif, mm, m32, work, lwork, _iwork)
+	return int(m32[0])
+}
+
+// Dtptri computes the inverse of an n×n triangular matrix A stored in packed
+// format. ap contains the upper or lower triangle of A in the row-major packed
+// format as used by blas64.TriangularPacked, see the documentation of Dpptrf
+// for the details of the storage. ap must have length at least n*(n+1)/2,
+// otherwise Dtptri will panic. On return, ap contains the inverse of A in the
+// same format.
+//
+// Dtptri returns whether the matrix A is nonsingular. If ok is false, A is
+// exactly singular and the inverse has not been computed.
+func (impl Implementation) Dtptri(uplo blas.Uplo, diag blas.Diag, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if n == 0 {
+		return true
+	}
+	return lapacke.Dtptri(uplo, diag, n, ap)
+}
+
+// Dtpttf copies an n×n symmetric or triangular matrix A stored in packed
+// format in ap into arf in rectangular full packed (RFP) format. The packed
+// format is described in the documentation of Dpptrf and the RFP format in
+// the documentation of Dtrttf.
+//
+// transr must be blas.NoTrans or blas.Trans, and ap and arf must have length
+// at least n*(n+1)/2, otherwise Dtpttf will panic.
+func (impl Implementation) Dtpttf(transr blas.Transpose, uplo blas.Uplo, n int, ap, arf []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if n == 0 {
+		return
 	}
-	return rcond[0]
+	lapacke.Dtpttf(transr, uplo, n, ap, arf)
 }
 
-// Dtrexc reorders the real Schur factorization of a n×n real matrix
+// Dtrsen reorders the real Schur factorization of an n×n real matrix
 //  A = Q*T*Q^T
-// so that the diagonal block of T with row index ifst is moved to row ilst.
-//
-// On entry, T must be in Schur canonical form, that is, block upper triangular
-// with 1×1 and 2×2 diagonal blocks; each 2×2 diagonal block has its diagonal
-// elements equal and its off-diagonal elements of opposite sign.
-//
-// On return, T will be reordered by an orthogonal similarity transformation Z
-// as Z^T*T*Z, and will be again in Schur canonical form.
-//
-// If compq is lapack.UpdateSchur, on return the matrix Q of Schur vectors will be
-// updated by postmultiplying it with Z.
-// If compq is lapack.None, the matrix Q is not referenced and will not be
-// updated.
-// For other values of compq Dtrexc will panic.
-//
-// ifst and ilst specify the reordering of the diagonal blocks of T. The block
-// with row index ifst is moved to row ilst, by a sequence of transpositions
-// between adjacent blocks.
-//
-// If ifst points to the second row of a 2×2 block, ifstOut will point to the
-// first row, otherwise it will be equal to ifst.
-//
-// ilstOut will point to the first row of the block in its final position. If ok
-// is true, ilstOut may differ from ilst by +1 or -1.
-//
-// It must hold that
-//  0 <= ifst < n, and  0 <= ilst < n,
-// otherwise Dtrexc will panic.
-//
-// If ok is false, two adjacent blocks were too close to swap because the
-// problem is very ill-conditioned. T may have been partially reordered, and
-// ilstOut will point to the first row of the block at the position to which it
-// has been moved.
-//
-// work must have length at least n, otherwise Dtrexc will panic.
-//
-// Dtrexc is an internal routine. It is exported for testing purposes.
-func (impl Implementation) Dtrexc(compq lapack.EVComp, n int, t []float64, ldt int, q []float64, ldq int, ifst, ilst int, work []float64) (ifstOut, ilstOut int, ok bool) {
-	checkMatrix(n, n, t, ldt)
+// so that a selected cluster of eigenvalues appears in the leading diagonal
+// blocks of the upper quasi-triangular matrix T, and the leading columns of Q
+// form an orthonormal basis of the corresponding right invariant subspace.
+// Optionally, Dtrsen computes the reciprocal condition numbers s and sep of the
+// cluster of eigenvalues and of the invariant subspace as specified by job.
+//
+// If compq == lapack.UpdateSchur, the Schur vectors in q are updated, otherwise
+// compq must be lapack.None and q is not referenced. selected must have length
+// n. To select a complex conjugate pair of eigenvalues corresponding to a 2×2
+// diagonal block T[j:j+2,j:j+2], either selected[j] or selected[j+1] must be
+// true. On return, m is the number of selected eigenvalues, and wr and wi
+// contain the reordered eigenvalues of T.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If lwork == -1 or liwork == -1, instead of performing Dtrsen,
+// the minimum lengths of work and iwork are stored into work[0] and iwork[0].
+//
+// Dtrsen returns whether the reordering was successful.
+func (impl Implementation) Dtrsen(job lapack.CondJob, compq lapack.EVComp, selected []bool, n int, t []float64, ldt int, q []float64, ldq int, wr, wi, work []float64, lwork int, iwork []int, liwork int) (m int, s, sep float64, ok bool) {
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondJob(lapack.None), lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth:
+	}
 	switch compq {
 	default:
-		panic("lapack: bad value of compq")
+		panic(badEVComp)
 	case lapack.None:
 		// q is not referenced but LAPACKE checks that ldq >= n always.
 		q = nil
@@ -2487,27 +6673,167 @@ func (impl Implementation) Dtrexc(compq lapack.EVComp, n int, t []float64, ldt i
 	case lapack.UpdateSchur:
 		checkMatrix(n, n, q, ldq)
 	}
-	if (ifst < 0 || n <= ifst) && n > 0 {
-		panic("lapack: ifst out of range")
+	if n < 0 {
+		panic(nLT0)
 	}
-	if (ilst < 0 || n <= ilst) && n > 0 {
-		panic("lapack: ilst out of range")
+	if len(selected) != n {
+		panic("lapack: bad length of selected")
 	}
-	if len(work) < n {
+	checkMatrix(n, n, t, ldt)
+
+	sel := make([]int32, n)
+	for i, v := range selected {
+		if v {
+			sel[i] = 1
+		}
+	}
+	m32 := []int32{0}
+	_s := []float64{0}
+	_sep := []float64{0}
+	if lwork == -1 || liwork == -1 {
+		_iwork := []int32{0}
+		ok = lapacke.Dtrsen(lapack.Job(job), lapack.Comp(compq), sel, n, t, ldt, q, ldq, make([]float64, n), make([]float64, n),
+			m32, _s, _sep, work, -1, _iwork, -1)
+		iwork[0] = int(_iwork[0])
+		return int(m32[0]), 0, 0, ok
+	}
+	switch {
+	case len(wr) != n:
+		panic("lapack: bad length of wr")
+	case len(wi) != n:
+		panic("lapack: bad length of wi")
+	case len(work) < lwork || len(iwork) < liwork:
 		panic(badWork)
 	}
+	_iwork := make([]int32, max(1, liwork))
+	ok = lapacke.Dtrsen(lapack.Job(job), lapack.Comp(compq), sel, n, t, ldt, q, ldq, wr, wi,
+		m32, _s, _sep, work, lwork, _iwork, liwork)
+	if liwork > 0 {
+		iwork[0] = int(_iwork[0])
+	}
+	return int(m32[0]), _s[0], _sep[0], ok
+}
+
+// Dtrsna estimates reciprocal condition numbers for specified eigenvalues
+// and/or right eigenvectors of an n×n upper quasi-triangular matrix T in
+// Schur canonical form, or of a matrix A = Q*T*Q^T with orthogonal Q.
+//
+// If job is lapack.CondEigenvalues or lapack.CondBoth, the condition numbers
+// of the eigenvalues are stored in s, and vl and vr must contain the
+// corresponding left and right eigenvectors as returned by Dtrevc3. If job is
+// lapack.CondSubspace or lapack.CondBoth, the condition numbers of the right
+// eigenvectors are stored in sep.
+//
+// howmny must be lapack.AllEV or lapack.SelectedEV. In the latter case the
+// condition numbers are computed only for the eigenpairs specified by
+// selected.
+//
+// work must have length at least ldwork*(n+6) with ldwork >= n, and iwork must
+// have length at least 2*(n-1) if job is lapack.CondSubspace or lapack.CondBoth.
+//
+// Dtrsna returns m, the number of selected eigenvalues counting each complex
+// conjugate pair as two.
+func (impl Implementation) Dtrsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, t []float64, ldt int, vl []float64, ldvl int, vr []float64, ldvr int, s, sep []float64, mm int, work []float64, ldwork int, iwork []int) (m int) {
+	var wants, wantsp bool
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantsp = true
+	case lapack.CondBoth:
+		wants = true
+		wantsp = true
+	}
+	sel := make([]int32, n)
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV:
+	case lapack.SelectedEV:
+		if len(selected) != n {
+			panic("lapack: bad length of selected")
+		}
+		for i, v := range selected {
+			if v {
+				sel[i] = 1
+			}
+		}
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, t, ldt)
+	if wants {
+		checkMatrix(n, mm, vl, ldvl)
+		checkMatrix(n, mm, vr, ldvr)
+	} else {
+		// vl and vr are not referenced but LAPACKE checks that
+		// ldvl, ldvr >= mm always.
+		vl = nil
+		ldvl = max(1, mm)
+		vr = nil
+		ldvr = max(1, mm)
+	}
+	if wantsp {
+		if ldwork < max(1, n) {
+			panic(badWorkStride)
+		}
+		if len(work) < ldwork*(n+6) || len(iwork) < 2*(n-1) {
+			panic(badWork)
+		}
+	}
+	if n == 0 {
+		return 0
+	}
+
+	m32 := []int32{0}
+	_iwork := make([]int32, max(1, 2*(n-1)))
+	lapacke.Dtrsna(lapack.Job(job), byte(howmny), sel, n, t, ldt, vl, ldvl, vr, ldvr, s, sep,
+		mm, m32, work, max(1, ldwork), _iwork)
+	return int(m32[0])
+}
+
+// Dtrsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where A is an m×m and B an n×n upper quasi-triangular matrix in Schur
+// canonical form, C and X are m×n matrices, op(A) is A or A^T as specified
+// by trana, and op(B) is B or B^T as specified by tranb. isgn must be 1 or -1.
+//
+// On entry, c contains the right-hand side matrix C. On return, it is
+// overwritten by the solution matrix X.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X.
+//
+// ok will be false if A and -isgn*B have common or very close eigenvalues,
+// in which case perturbed values are used to solve the equation.
+func (impl Implementation) Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool) {
+	switch {
+	case trana != blas.NoTrans && trana != blas.Trans && trana != blas.ConjTrans:
+		panic(badTrans)
+	case tranb != blas.NoTrans && tranb != blas.Trans && tranb != blas.ConjTrans:
+		panic(badTrans)
+	case isgn != 1 && isgn != -1:
+		panic(badIsgn)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
 
 	// Quick return if possible.
-	if n <= 1 {
-		return ifst, ilst, true
+	if m == 0 || n == 0 {
+		return 1, true
 	}
+	checkMatrix(m, n, c, ldc)
 
-	ifst32 := []int32{int32(ifst + 1)}
-	ilst32 := []int32{int32(ilst + 1)}
-	ok = lapacke.Dtrexc(lapack.Comp(compq), n, t, ldt, q, ldq, ifst32, ilst32, work)
-	ifst = int(ifst32[0] - 1)
-	ilst = int(ilst32[0] - 1)
-	return ifst, ilst, ok
+	_scale := []float64{0}
+	ok = lapacke.Dtrsyl(trana, tranb, isgn, m, n, a, lda, b, ldb, c, ldc, _scale)
+	return _scale[0], ok
 }
 
 // Dtrtri computes the inverse of a triangular matrix, storing the result in place
@@ -2534,6 +6860,36 @@ func (impl Implementation) Dtrtrs(uplo blas.Uplo, trans blas.Transpose, diag bla
 	return lapacke.Dtrtrs(uplo, trans, diag, n, nrhs, a, lda, b, ldb)
 }
 
+// Dtrttf copies the upper or lower triangle of an n×n matrix A stored in
+// conventional format in a into arf in rectangular full packed (RFP) format.
+//
+// The RFP format stores the n*(n+1)/2 elements of the triangle of a symmetric
+// or triangular matrix in a rectangular array so that the blocks of the
+// matrix can be accessed with Level 3 BLAS. The RFP array is stored in
+// row-major order and is the transpose of the column-major array used by the
+// Fortran LAPACK, see the documentation of Dtrttf in the native package for
+// the details of the format.
+//
+// transr must be blas.NoTrans or blas.Trans, and arf must have length at
+// least n*(n+1)/2, otherwise Dtrttf will panic.
+func (impl Implementation) Dtrttf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64, lda int, arf []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+	checkMatrix(n, n, a, max(1, lda))
+	if n == 0 {
+		return
+	}
+	lapacke.Dtrttf(transr, uplo, n, a, lda, arf)
+}
+
 // Dhseqr computes the eigenvalues of an n×n Hessenberg matrix H and,
 // optionally, the matrices T and Z from the Schur decomposition
 //  H = Z T Z^T,
@@ -2793,6 +7149,283 @@ func (impl Implementation) Dgeev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob
 	return first
 }
 
+// Dgeevx computes the eigenvalues and, optionally, the left and/or right
+// eigenvectors for an n×n real nonsymmetric matrix A. Optionally, it also
+// balances A and computes the reciprocal condition numbers of the eigenvalues
+// and of the right eigenvectors.
+//
+// balanc specifies how A is balanced as in Dgebal and must be one of
+// lapack.None, lapack.Permute, lapack.Scale or lapack.PermuteScale. jobvl and
+// jobvr specify whether the left and right eigenvectors are computed as in
+// Dgeev. sense specifies which reciprocal condition numbers are computed and
+// must be lapack.None, lapack.CondEigenvalues, lapack.CondSubspace or
+// lapack.CondBoth. If sense is lapack.CondEigenvalues or lapack.CondBoth, both
+// left and right eigenvectors must be computed.
+//
+// On return, ilo, ihi and scale describe the balancing of A as in Dgebal,
+// abnrm is the 1-norm of the balanced matrix, and rconde and rcondv contain
+// the reciprocal condition numbers of the eigenvalues and the right
+// eigenvectors, respectively, if they were requested.
+//
+// work must have length at least lwork and lwork must be at least
+//  max(1,2*n)            if no eigenvectors are computed and sense is lapack.None,
+//  max(1,3*n)            if eigenvectors are computed and sense is lapack.None or lapack.CondEigenvalues,
+//  max(1,n*n+6*n)        otherwise.
+// If lwork == -1, instead of performing Dgeevx, the function only calculates
+// the optimal vaule of lwork and stores it into work[0].
+//
+// iwork must have length at least 2*n-2 if sense is lapack.CondSubspace or
+// lapack.CondBoth.
+//
+// On return, first is the index of the first valid eigenvalue. If first == 0,
+// all eigenvalues and eigenvectors have been computed. If first is positive,
+// Dgeevx failed to compute all the eigenvalues, no eigenvectors or condition
+// numbers have been computed and wr[first:] and wi[first:] contain those
+// eigenvalues which have converged.
+func (impl Implementation) Dgeevx(balanc lapack.Job, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, sense lapack.CondJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, scale, rconde, rcondv, work []float64, lwork int, iwork []int) (ilo, ihi int, abnrm float64, first int) {
+	switch balanc {
+	default:
+		panic(badJob)
+	case lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale:
+	}
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+		wantvl = false
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+		wantvr = false
+	}
+	var wants, wantsp bool
+	switch sense {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondJob(lapack.None):
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantsp = true
+	case lapack.CondBoth:
+		wants = true
+		wantsp = true
+	}
+	if wants && (!wantvl || !wantvr) {
+		panic("lapack: eigenvalue condition numbers require left and right eigenvectors")
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	wantv := wantvl || wantvr
+	var minwrk int
+	if wantv {
+		minwrk = 3 * n
+		if wantsp {
+			minwrk = max(minwrk, n*n+6*n)
+		}
+	} else {
+		minwrk = 2 * n
+		if sense != lapack.CondJob(lapack.None) {
+			minwrk = max(minwrk, n*n+6*n)
+		}
+	}
+	minwrk = max(1, minwrk)
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(wr) != n:
+			panic("lapack: bad length of wr")
+		case len(wi) != n:
+			panic("lapack: bad length of wi")
+		case len(scale) != n:
+			panic("lapack: bad length of scale")
+		case wants && len(rconde) != n:
+			panic("lapack: bad length of rconde")
+		case wantsp && len(rcondv) != n:
+			panic("lapack: bad length of rcondv")
+		case wantsp && len(iwork) < 2*n-2:
+			panic("lapack: insufficient length of iwork")
+		case lwork < minwrk:
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0, -1, 0, 0
+	}
+
+	ilo32 := []int32{0}
+	ihi32 := []int32{0}
+	_abnrm := []float64{0}
+	_iwork := make([]int32, max(1, 2*n-2))
+	first = lapacke.Dgeevx(byte(balanc), lapack.Job(jobvl), lapack.Job(jobvr), byte(sense), n, a, max(n, lda), wr, wi,
+		vl, max(n, ldvl), vr, max(n, ldvr), ilo32, ihi32, scale, _abnrm, rconde, rcondv, work, lwork, _iwork)
+	if lwork == -1 {
+		if int(work[0]) < minwrk {
+			work[0] = float64(minwrk)
+		}
+		return 0, 0, 0, first
+	}
+	ilo = int(ilo32[0]) - 1
+	ihi = int(ihi32[0]) - 1
+	for j := 0; j < ilo; j++ {
+		scale[j]--
+	}
+	for j := ihi + 1; j < n; j++ {
+		scale[j]--
+	}
+	return ilo, ihi, _abnrm[0], first
+}
+
+// Dggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// A generalized eigenvalue for the pair (A,B) is a scalar λ or a ratio
+// α/β = λ, such that A - λ*B is singular. It is usually represented as the
+// pair (α,β), as there is a reasonable interpretation for β = 0, and even for
+// both being zero.
+//
+// The right generalized eigenvector v_j corresponding to the generalized
+// eigenvalue λ_j of (A,B) satisfies
+//  A v_j = λ_j B v_j,
+// and the left generalized eigenvector u_j corresponding to λ_j satisfies
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR
+// in the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that the
+// largest component has |real part| + |imag. part| = 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Dggev will panic.
+//
+// On return, alphar[j] + i*alphai[j] and beta[j] for j = 0,...,n-1 will be the
+// generalized eigenvalues. If alphai[j] is zero, then the j-th eigenvalue is
+// real; if positive, then the j-th and (j+1)-st eigenvalues are a complex
+// conjugate pair, with alphai[j+1] negative. alphar, alphai and beta must have
+// length n, otherwise Dggev will panic.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n),
+// otherwise Dggev will panic. For good performance, lwork must generally be
+// larger. On return, optimal value of lwork will be stored in work[0].
+//
+// If lwork == -1, instead of performing Dggev, the function only calculates
+// the optimal value of lwork and stores it into work[0].
+//
+// On return, ok will be false if the QZ iteration failed to compute all the
+// eigenvalues, in which case no eigenvectors have been computed and only some
+// of the eigenvalues are correct, or if the computation of eigenvectors failed.
+func (impl Implementation) Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	if checkDggev(jobvl, jobvr, n, a, lda, b, ldb, alphar, alphai, beta, vl, ldvl, vr, ldvr, work, lwork) {
+		work[0] = 1
+		return true
+	}
+	ok = lapacke.Dggev(lapack.Job(jobvl), lapack.Job(jobvr), n, a, max(1, lda), b, max(1, ldb),
+		alphar, alphai, beta, vl, max(1, ldvl), vr, max(1, ldvr), work, lwork)
+	if lwork == -1 && int(work[0]) < max(1, 8*n) {
+		work[0] = float64(max(1, 8*n))
+	}
+	return ok
+}
+
+// Dggev3 computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B). It has the same interface as Dggev but uses a blocked reduction of
+// (A,B) to generalized upper Hessenberg form.
+func (impl Implementation) Dggev3(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	if checkDggev(jobvl, jobvr, n, a, lda, b, ldb, alphar, alphai, beta, vl, ldvl, vr, ldvr, work, lwork) {
+		work[0] = 1
+		return true
+	}
+	ok = lapacke.Dggev3(lapack.Job(jobvl), lapack.Job(jobvr), n, a, max(1, lda), b, max(1, ldb),
+		alphar, alphai, beta, vl, max(1, ldvl), vr, max(1, ldvr), work, lwork)
+	if lwork == -1 && int(work[0]) < max(1, 8*n) {
+		work[0] = float64(max(1, 8*n))
+	}
+	return ok
+}
+
+// checkDggev checks the parameters of Dggev and Dggev3 and panics if any of
+// them is invalid. It returns whether the problem is empty.
+func checkDggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (empty bool) {
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		checkMatrix(n, n, b, ldb)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(alphar) != n:
+			panic("lapack: bad length of alphar")
+		case len(alphai) != n:
+			panic("lapack: bad length of alphai")
+		case len(beta) != n:
+			panic("lapack: bad length of beta")
+		case lwork < max(1, 8*n):
+			panic(badWork)
+		}
+	}
+	return n == 0
+}
+
 // Dtgsja computes the generalized singular value decomposition (GSVD)
 // of two real upper triangular or trapezoidal matrices A and B.
 //
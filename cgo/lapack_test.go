@@ -44,10 +44,94 @@ func (bl blockedTranslate) Dorgl2(m, n, k int, a []float64, lda int, tau, work [
 	impl.Dorglq(m, n, k, a, lda, tau, work, len(work))
 }
 
+func TestDgbcon(t *testing.T) {
+	testlapack.DgbconTest(t, impl)
+}
+
+func TestDgbsv(t *testing.T) {
+	testlapack.DgbsvTest(t, impl)
+}
+
+func TestDgbtrf(t *testing.T) {
+	testlapack.DgbtrfTest(t, impl)
+}
+
+func TestDgbtrs(t *testing.T) {
+	testlapack.DgbtrsTest(t, impl)
+}
+
+func TestDgeequ(t *testing.T) {
+	testlapack.DgeequTest(t, impl)
+}
+
+func TestDgeequb(t *testing.T) {
+	testlapack.DgeequbTest(t, impl)
+}
+
+func TestDgeevx(t *testing.T) {
+	testlapack.DgeevxTest(t, impl)
+}
+
+func TestDgelsy(t *testing.T) {
+	testlapack.DgelsyTest(t, impl)
+}
+
 func TestDgeqp3(t *testing.T) {
 	testlapack.Dgeqp3Test(t, impl)
 }
 
+func TestDgerfs(t *testing.T) {
+	testlapack.DgerfsTest(t, impl)
+}
+
+func TestDgesv(t *testing.T) {
+	testlapack.DgesvTest(t, impl)
+}
+
+func TestDgesvx(t *testing.T) {
+	testlapack.DgesvxTest(t, impl)
+}
+
+func TestDggev(t *testing.T) {
+	testlapack.DggevTest(t, impl)
+}
+
+func TestDggev3(t *testing.T) {
+	testlapack.Dggev3Test(t, impl)
+}
+
+func TestDggglm(t *testing.T) {
+	testlapack.DggglmTest(t, impl)
+}
+
+func TestDgglse(t *testing.T) {
+	testlapack.DgglseTest(t, impl)
+}
+
+func TestDggqrf(t *testing.T) {
+	testlapack.DggqrfTest(t, impl)
+}
+
+func TestDggrqf(t *testing.T) {
+	testlapack.DggrqfTest(t, impl)
+}
+
+func TestDgtcon(t *testing.T) {
+	testlapack.DgtconTest(t, impl)
+}
+
+func TestDgtsv(t *testing.T) {
+	testlapack.DgtsvTest(t, impl)
+}
+
+func TestDgttrf(t *testing.T) {
+	testlapack.DgttrfTest(t, impl)
+}
+
+func TestDgttrs(t *testing.T) {
+	testlapack.DgttrsTest(t, impl)
+}
+
 func TestDlacn2(t *testing.T) {
 	testlapack.Dlacn2Test(t, impl)
 }
@@ -60,10 +144,18 @@ func TestDlacpy(t *testing.T) {
 	testlapack.DlacpyTest(t, impl)
 }
 
+func TestDlangb(t *testing.T) {
+	testlapack.DlangbTest(t, impl)
+}
+
 func TestDlange(t *testing.T) {
 	testlapack.DlangeTest(t, impl)
 }
 
+func TestDlangt(t *testing.T) {
+	testlapack.DlangtTest(t, impl)
+}
+
 func TestDlarfb(t *testing.T) {
 	testlapack.DlarfbTest(t, impl)
 }
@@ -104,6 +196,86 @@ func TestDlaswp(t *testing.T) {
 	testlapack.DlaswpTest(t, impl)
 }
 
+func TestDopgtr(t *testing.T) {
+	testlapack.DopgtrTest(t, impl)
+}
+
+func TestDorgrq(t *testing.T) {
+	testlapack.DorgrqTest(t, impl)
+}
+
+func TestDpbcon(t *testing.T) {
+	testlapack.DpbconTest(t, impl)
+}
+
+func TestDpbsv(t *testing.T) {
+	testlapack.DpbsvTest(t, impl)
+}
+
+func TestDpbtrf(t *testing.T) {
+	testlapack.DpbtrfTest(t, impl)
+}
+
+func TestDpbtrs(t *testing.T) {
+	testlapack.DpbtrsTest(t, impl)
+}
+
+func TestDpftrf(t *testing.T) {
+	testlapack.DpftrfTest(t, impl)
+}
+
+func TestDpftri(t *testing.T) {
+	testlapack.DpftriTest(t, impl)
+}
+
+func TestDpftrs(t *testing.T) {
+	testlapack.DpftrsTest(t, impl)
+}
+
+func TestDpoequ(t *testing.T) {
+	testlapack.DpoequTest(t, impl)
+}
+
+func TestDporfs(t *testing.T) {
+	testlapack.DporfsTest(t, impl)
+}
+
+func TestDposv(t *testing.T) {
+	testlapack.DposvTest(t, impl)
+}
+
+func TestDposvx(t *testing.T) {
+	testlapack.DposvxTest(t, impl)
+}
+
+func TestDpotri(t *testing.T) {
+	testlapack.DpotriTest(t, impl)
+}
+
+func TestDpotrs(t *testing.T) {
+	testlapack.DpotrsTest(t, impl)
+}
+
+func TestDppcon(t *testing.T) {
+	testlapack.DppconTest(t, impl)
+}
+
+func TestDpptrf(t *testing.T) {
+	testlapack.DpptrfTest(t, impl)
+}
+
+func TestDpptri(t *testing.T) {
+	testlapack.DpptriTest(t, impl)
+}
+
+func TestDpptrs(t *testing.T) {
+	testlapack.DpptrsTest(t, impl)
+}
+
+func TestDpstrf(t *testing.T) {
+	testlapack.DpstrfTest(t, impl)
+}
+
 func TestDpotrf(t *testing.T) {
 	testlapack.DpotrfTest(t, impl)
 }
@@ -140,6 +312,14 @@ func TestDgels(t *testing.T) {
 	testlapack.DgelsTest(t, impl)
 }
 
+func TestDgelsd(t *testing.T) {
+	testlapack.DgelsdTest(t, impl)
+}
+
+func TestDgelss(t *testing.T) {
+	testlapack.DgelssTest(t, impl)
+}
+
 func TestDgelqf(t *testing.T) {
 	testlapack.DgelqfTest(t, impl)
 }
@@ -156,10 +336,18 @@ func TestDgerqf(t *testing.T) {
 	testlapack.DgerqfTest(t, impl)
 }
 
+func TestDgesdd(t *testing.T) {
+	testlapack.DgesddTest(t, impl)
+}
+
 func TestDgesvd(t *testing.T) {
 	testlapack.DgesvdTest(t, impl)
 }
 
+func TestDgesvdx(t *testing.T) {
+	testlapack.DgesvdxTest(t, impl)
+}
+
 func TestDgetf2(t *testing.T) {
 	testlapack.Dgetf2Test(t, impl)
 }
@@ -240,6 +428,50 @@ func TestDpocon(t *testing.T) {
 	testlapack.DpoconTest(t, impl)
 }
 
+func TestDptcon(t *testing.T) {
+	testlapack.DptconTest(t, impl)
+}
+
+func TestDptsv(t *testing.T) {
+	testlapack.DptsvTest(t, impl)
+}
+
+func TestDpttrf(t *testing.T) {
+	testlapack.DpttrfTest(t, impl)
+}
+
+func TestDpttrs(t *testing.T) {
+	testlapack.DpttrsTest(t, impl)
+}
+
+func TestDsbev(t *testing.T) {
+	testlapack.DsbevTest(t, impl)
+}
+
+func TestDsfrk(t *testing.T) {
+	testlapack.DsfrkTest(t, impl)
+}
+
+func TestDsgesv(t *testing.T) {
+	testlapack.DsgesvTest(t, impl)
+}
+
+func TestDspev(t *testing.T) {
+	testlapack.DspevTest(t, impl)
+}
+
+func TestDsposv(t *testing.T) {
+	testlapack.DsposvTest(t, impl)
+}
+
+func TestDsptrd(t *testing.T) {
+	testlapack.DsptrdTest(t, impl)
+}
+
+func TestDsptrf(t *testing.T) {
+	testlapack.DsptrfTest(t, impl)
+}
+
 func TestDsteqr(t *testing.T) {
 	testlapack.DsteqrTest(t, impl)
 }
@@ -248,18 +480,98 @@ func TestDsterf(t *testing.T) {
 	testlapack.DsterfTest(t, impl)
 }
 
+func TestDsycon(t *testing.T) {
+	testlapack.DsyconTest(t, impl)
+}
+
 func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
 
+func TestDsyevd(t *testing.T) {
+	testlapack.DsyevdTest(t, impl)
+}
+
+func TestDsyevr(t *testing.T) {
+	testlapack.DsyevrTest(t, impl)
+}
+
+func TestDsyevx(t *testing.T) {
+	testlapack.DsyevxTest(t, impl)
+}
+
+func TestDsygv(t *testing.T) {
+	testlapack.DsygvTest(t, impl)
+}
+
+func TestDsygvd(t *testing.T) {
+	testlapack.DsygvdTest(t, impl)
+}
+
+func TestDsygvx(t *testing.T) {
+	testlapack.DsygvxTest(t, impl)
+}
+
+func TestDsysv(t *testing.T) {
+	testlapack.DsysvTest(t, impl)
+}
+
+func TestDsysvRook(t *testing.T) {
+	testlapack.DsysvRookTest(t, impl)
+}
+
 func TestDsytrd(t *testing.T) {
 	testlapack.DsytrdTest(t, impl)
 }
 
+func TestDsytrf(t *testing.T) {
+	testlapack.DsytrfTest(t, impl)
+}
+
+func TestDsytrfRook(t *testing.T) {
+	testlapack.DsytrfRookTest(t, impl)
+}
+
+func TestDsytri(t *testing.T) {
+	testlapack.DsytriTest(t, impl)
+}
+
+func TestDsytrs(t *testing.T) {
+	testlapack.DsytrsTest(t, impl)
+}
+
+func TestDsytrsRook(t *testing.T) {
+	testlapack.DsytrsRookTest(t, impl)
+}
+
+func TestDtftri(t *testing.T) {
+	testlapack.DtftriTest(t, impl)
+}
+
+func TestDtfttr(t *testing.T) {
+	testlapack.DtfttrTest(t, impl)
+}
+
+func TestDtgexc(t *testing.T) {
+	testlapack.DtgexcTest(t, impl)
+}
+
+func TestDtgsen(t *testing.T) {
+	testlapack.DtgsenTest(t, impl)
+}
+
 func TestDtgsja(t *testing.T) {
 	testlapack.DtgsjaTest(t, impl)
 }
 
+func TestDtptri(t *testing.T) {
+	testlapack.DtptriTest(t, impl)
+}
+
+func TestDtpttf(t *testing.T) {
+	testlapack.DtpttfTest(t, impl)
+}
+
 func TestDtrexc(t *testing.T) {
 	testlapack.DtrexcTest(t, impl)
 }
@@ -268,6 +580,22 @@ func TestDtrcon(t *testing.T) {
 	testlapack.DtrconTest(t, impl)
 }
 
+func TestDtrsen(t *testing.T) {
+	testlapack.DtrsenTest(t, impl)
+}
+
+func TestDtrsna(t *testing.T) {
+	testlapack.DtrsnaTest(t, impl)
+}
+
+func TestDtrsyl(t *testing.T) {
+	testlapack.DtrsylTest(t, impl)
+}
+
 func TestDtrtri(t *testing.T) {
 	testlapack.DtrtriTest(t, impl)
 }
+
+func TestDtrttf(t *testing.T) {
+	testlapack.DtrttfTest(t, impl)
+}
@@ -272,8 +272,6 @@ func main() {
 			continue
 		case strings.HasSuffix(lapackeName, "vxx"):
 			continue
-		case strings.HasSuffix(lapackeName, "rook"):
-			continue
 		}
 		if hasFuncParameter(d) {
 			continue
@@ -314,7 +312,7 @@ func hasFuncParameter(d binding.Declaration) bool {
 
 func goSignature(buf *bytes.Buffer, d binding.Declaration) {
 	lapackeName := strings.TrimSuffix(strings.TrimPrefix(d.Name, prefix), suffix)
-	goName := binding.UpperCaseFirst(lapackeName)
+	goName := binding.UpperCaseFirst(strings.Replace(lapackeName, "_rook", "Rook", 1))
 
 	parameters := d.Parameters()
 
@@ -127,7 +127,7 @@ func Dbdsdc(ul blas.Uplo, compq lapack.Comp, n int, d, e, u []float64, ldu int,
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/sbdsvdx.f.
-func Sbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float32, vl, vu, il, iu, ns int, s, z []float32, ldz int, work []float32, iwork []int32) bool {
+func Sbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float32, vl, vu float32, il, iu int, ns []int32, s, z []float32, ldz int, work []float32, iwork []int32) bool {
 	switch ul {
 	case blas.Upper:
 		ul = 'U'
@@ -144,6 +144,10 @@ func Sbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float32, vl,
 	if len(e) > 0 {
 		_e = &e[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float32
 	if len(s) > 0 {
 		_s = &s[0]
@@ -160,11 +164,11 @@ func Sbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float32, vl,
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_sbdsvdx_work((C.int)(rowMajor), (C.char)(ul), (C.char)(jobz), (C.char)(rng), (C.lapack_int)(n), (*C.float)(_d), (*C.float)(_e), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.float)(_s), (*C.float)(_z), (C.lapack_int)(ldz), (*C.float)(_work), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_sbdsvdx_work((C.int)(rowMajor), (C.char)(ul), (C.char)(jobz), (C.char)(rng), (C.lapack_int)(n), (*C.float)(_d), (*C.float)(_e), (C.float)(vl), (C.float)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.float)(_s), (*C.float)(_z), (C.lapack_int)(ldz), (*C.float)(_work), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dbdsvdx.f.
-func Dbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float64, vl, vu, il, iu, ns int, s, z []float64, ldz int, work []float64, iwork []int32) bool {
+func Dbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float64, vl, vu float64, il, iu int, ns []int32, s, z []float64, ldz int, work []float64, iwork []int32) bool {
 	switch ul {
 	case blas.Upper:
 		ul = 'U'
@@ -181,6 +185,10 @@ func Dbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float64, vl,
 	if len(e) > 0 {
 		_e = &e[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float64
 	if len(s) > 0 {
 		_s = &s[0]
@@ -197,7 +205,7 @@ func Dbdsvdx(ul blas.Uplo, jobz lapack.Job, rng byte, n int, d, e []float64, vl,
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_dbdsvdx_work((C.int)(rowMajor), (C.char)(ul), (C.char)(jobz), (C.char)(rng), (C.lapack_int)(n), (*C.double)(_d), (*C.double)(_e), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.double)(_s), (*C.double)(_z), (C.lapack_int)(ldz), (*C.double)(_work), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_dbdsvdx_work((C.int)(rowMajor), (C.char)(ul), (C.char)(jobz), (C.char)(rng), (C.lapack_int)(n), (*C.double)(_d), (*C.double)(_e), (C.double)(vl), (C.double)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.double)(_s), (*C.double)(_z), (C.lapack_int)(ldz), (*C.double)(_work), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/sbdsqr.f.
@@ -4265,11 +4273,15 @@ func Zgesvd(jobu, jobvt lapack.Job, m, n int, a []complex128, lda int, s []float
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/sgesvdx.f.
-func Sgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float32, lda, vl, vu, il, iu, ns int, s, u []float32, ldu int, vt []float32, ldvt int, work []float32, lwork int, iwork []int32) bool {
+func Sgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float32, lda int, vl, vu float32, il, iu int, ns []int32, s, u []float32, ldu int, vt []float32, ldvt int, work []float32, lwork int, iwork []int32) bool {
 	var _a *float32
 	if len(a) > 0 {
 		_a = &a[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float32
 	if len(s) > 0 {
 		_s = &s[0]
@@ -4290,15 +4302,19 @@ func Sgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float32, lda, vl, v
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_sgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.float)(_a), (C.lapack_int)(lda), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.float)(_s), (*C.float)(_u), (C.lapack_int)(ldu), (*C.float)(_vt), (C.lapack_int)(ldvt), (*C.float)(_work), (C.lapack_int)(lwork), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_sgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.float)(_a), (C.lapack_int)(lda), (C.float)(vl), (C.float)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.float)(_s), (*C.float)(_u), (C.lapack_int)(ldu), (*C.float)(_vt), (C.lapack_int)(ldvt), (*C.float)(_work), (C.lapack_int)(lwork), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dgesvdx.f.
-func Dgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float64, lda, vl, vu, il, iu, ns int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int32) bool {
+func Dgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float64, lda int, vl, vu float64, il, iu int, ns []int32, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int32) bool {
 	var _a *float64
 	if len(a) > 0 {
 		_a = &a[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float64
 	if len(s) > 0 {
 		_s = &s[0]
@@ -4319,15 +4335,19 @@ func Dgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []float64, lda, vl, v
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_dgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.double)(_a), (C.lapack_int)(lda), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.double)(_s), (*C.double)(_u), (C.lapack_int)(ldu), (*C.double)(_vt), (C.lapack_int)(ldvt), (*C.double)(_work), (C.lapack_int)(lwork), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_dgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.double)(_a), (C.lapack_int)(lda), (C.double)(vl), (C.double)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.double)(_s), (*C.double)(_u), (C.lapack_int)(ldu), (*C.double)(_vt), (C.lapack_int)(ldvt), (*C.double)(_work), (C.lapack_int)(lwork), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/cgesvdx.f.
-func Cgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex64, lda, vl, vu, il, iu, ns int, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int, rwork []float32, iwork []int32) bool {
+func Cgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex64, lda int, vl, vu float32, il, iu int, ns []int32, s []float32, u []complex64, ldu int, vt []complex64, ldvt int, work []complex64, lwork int, rwork []float32, iwork []int32) bool {
 	var _a *complex64
 	if len(a) > 0 {
 		_a = &a[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float32
 	if len(s) > 0 {
 		_s = &s[0]
@@ -4352,15 +4372,19 @@ func Cgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex64, lda, vl,
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_cgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.float)(_s), (*C.lapack_complex_float)(_u), (C.lapack_int)(ldu), (*C.lapack_complex_float)(_vt), (C.lapack_int)(ldvt), (*C.lapack_complex_float)(_work), (C.lapack_int)(lwork), (*C.float)(_rwork), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_cgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (C.float)(vl), (C.float)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.float)(_s), (*C.lapack_complex_float)(_u), (C.lapack_int)(ldu), (*C.lapack_complex_float)(_vt), (C.lapack_int)(ldvt), (*C.lapack_complex_float)(_work), (C.lapack_int)(lwork), (*C.float)(_rwork), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zgesvdx.f.
-func Zgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex128, lda, vl, vu, il, iu, ns int, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64, iwork []int32) bool {
+func Zgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex128, lda int, vl, vu float64, il, iu int, ns []int32, s []float64, u []complex128, ldu int, vt []complex128, ldvt int, work []complex128, lwork int, rwork []float64, iwork []int32) bool {
 	var _a *complex128
 	if len(a) > 0 {
 		_a = &a[0]
 	}
+	var _ns *int32
+	if len(ns) > 0 {
+		_ns = &ns[0]
+	}
 	var _s *float64
 	if len(s) > 0 {
 		_s = &s[0]
@@ -4385,7 +4409,7 @@ func Zgesvdx(jobu, jobvt lapack.Job, rng byte, m, n int, a []complex128, lda, vl
 	if len(iwork) > 0 {
 		_iwork = &iwork[0]
 	}
-	return isZero(C.LAPACKE_zgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (C.lapack_int)(vl), (C.lapack_int)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (C.lapack_int)(ns), (*C.double)(_s), (*C.lapack_complex_double)(_u), (C.lapack_int)(ldu), (*C.lapack_complex_double)(_vt), (C.lapack_int)(ldvt), (*C.lapack_complex_double)(_work), (C.lapack_int)(lwork), (*C.double)(_rwork), (*C.lapack_int)(_iwork)))
+	return isZero(C.LAPACKE_zgesvdx_work((C.int)(rowMajor), (C.char)(jobu), (C.char)(jobvt), (C.char)(rng), (C.lapack_int)(m), (C.lapack_int)(n), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (C.double)(vl), (C.double)(vu), (C.lapack_int)(il), (C.lapack_int)(iu), (*C.lapack_int)(_ns), (*C.double)(_s), (*C.lapack_complex_double)(_u), (C.lapack_int)(ldu), (*C.lapack_complex_double)(_vt), (C.lapack_int)(ldvt), (*C.lapack_complex_double)(_work), (C.lapack_int)(lwork), (*C.double)(_rwork), (*C.lapack_int)(_iwork)))
 }
 
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/sgesvj.f.
@@ -12054,6 +12078,58 @@ func Dlamch(cmach byte) float64 {
 	return float64(C.LAPACKE_dlamch_work((C.char)(cmach)))
 }
 
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/slangb.f.
+func Slangb(norm byte, n, kl, ku int, ab []float32, ldab int, work []float32) float32 {
+	var _ab *float32
+	if len(ab) > 0 {
+		_ab = &ab[0]
+	}
+	var _work *float32
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return float32(C.LAPACKE_slangb_work((C.int)(rowMajor), (C.char)(norm), (C.lapack_int)(n), (C.lapack_int)(kl), (C.lapack_int)(ku), (*C.float)(_ab), (C.lapack_int)(ldab), (*C.float)(_work)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dlangb.f.
+func Dlangb(norm byte, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	var _ab *float64
+	if len(ab) > 0 {
+		_ab = &ab[0]
+	}
+	var _work *float64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return float64(C.LAPACKE_dlangb_work((C.int)(rowMajor), (C.char)(norm), (C.lapack_int)(n), (C.lapack_int)(kl), (C.lapack_int)(ku), (*C.double)(_ab), (C.lapack_int)(ldab), (*C.double)(_work)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/clangb.f.
+func Clangb(norm byte, n, kl, ku int, ab []complex64, ldab int, work []float32) float32 {
+	var _ab *complex64
+	if len(ab) > 0 {
+		_ab = &ab[0]
+	}
+	var _work *float32
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return float32(C.LAPACKE_clangb_work((C.int)(rowMajor), (C.char)(norm), (C.lapack_int)(n), (C.lapack_int)(kl), (C.lapack_int)(ku), (*C.lapack_complex_float)(_ab), (C.lapack_int)(ldab), (*C.float)(_work)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zlangb.f.
+func Zlangb(norm byte, n, kl, ku int, ab []complex128, ldab int, work []float64) float64 {
+	var _ab *complex128
+	if len(ab) > 0 {
+		_ab = &ab[0]
+	}
+	var _work *float64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return float64(C.LAPACKE_zlangb_work((C.int)(rowMajor), (C.char)(norm), (C.lapack_int)(n), (C.lapack_int)(kl), (C.lapack_int)(ku), (*C.lapack_complex_double)(_ab), (C.lapack_int)(ldab), (*C.double)(_work)))
+}
+
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/slange.f.
 func Slange(norm byte, m, n int, a []float32, lda int, work []float32) float32 {
 	var _a *float32
@@ -12106,6 +12182,74 @@ func Zlange(norm byte, m, n int, a []complex128, lda int, work []float64) float6
 	return float64(C.LAPACKE_zlange_work((C.int)(rowMajor), (C.char)(norm), (C.lapack_int)(m), (C.lapack_int)(n), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.double)(_work)))
 }
 
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/slangt.f.
+func Slangt(norm byte, n int, dl, d, du []float32) float32 {
+	var _dl *float32
+	if len(dl) > 0 {
+		_dl = &dl[0]
+	}
+	var _d *float32
+	if len(d) > 0 {
+		_d = &d[0]
+	}
+	var _du *float32
+	if len(du) > 0 {
+		_du = &du[0]
+	}
+	return float32(C.LAPACKE_slangt_work((C.char)(norm), (C.lapack_int)(n), (*C.float)(_dl), (*C.float)(_d), (*C.float)(_du)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dlangt.f.
+func Dlangt(norm byte, n int, dl, d, du []float64) float64 {
+	var _dl *float64
+	if len(dl) > 0 {
+		_dl = &dl[0]
+	}
+	var _d *float64
+	if len(d) > 0 {
+		_d = &d[0]
+	}
+	var _du *float64
+	if len(du) > 0 {
+		_du = &du[0]
+	}
+	return float64(C.LAPACKE_dlangt_work((C.char)(norm), (C.lapack_int)(n), (*C.double)(_dl), (*C.double)(_d), (*C.double)(_du)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/clangt.f.
+func Clangt(norm byte, n int, dl, d, du []complex64) float32 {
+	var _dl *complex64
+	if len(dl) > 0 {
+		_dl = &dl[0]
+	}
+	var _d *complex64
+	if len(d) > 0 {
+		_d = &d[0]
+	}
+	var _du *complex64
+	if len(du) > 0 {
+		_du = &du[0]
+	}
+	return float32(C.LAPACKE_clangt_work((C.char)(norm), (C.lapack_int)(n), (*C.lapack_complex_float)(_dl), (*C.lapack_complex_float)(_d), (*C.lapack_complex_float)(_du)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zlangt.f.
+func Zlangt(norm byte, n int, dl, d, du []complex128) float64 {
+	var _dl *complex128
+	if len(dl) > 0 {
+		_dl = &dl[0]
+	}
+	var _d *complex128
+	if len(d) > 0 {
+		_d = &d[0]
+	}
+	var _du *complex128
+	if len(du) > 0 {
+		_du = &du[0]
+	}
+	return float64(C.LAPACKE_zlangt_work((C.char)(norm), (C.lapack_int)(n), (*C.lapack_complex_double)(_dl), (*C.lapack_complex_double)(_d), (*C.lapack_complex_double)(_du)))
+}
+
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/clanhe.f.
 func Clanhe(norm byte, ul blas.Uplo, n int, a []complex64, lda int, work []float32) float32 {
 	switch ul {
@@ -31404,6 +31548,422 @@ func Ztprfb(side blas.Side, trans blas.Transpose, direct, storev byte, m, n, k,
 	return isZero(C.LAPACKE_ztprfb_work((C.int)(rowMajor), (C.char)(side), (C.char)(trans), (C.char)(direct), (C.char)(storev), (C.lapack_int)(m), (C.lapack_int)(n), (C.lapack_int)(k), (C.lapack_int)(l), (*C.lapack_complex_double)(_v), (C.lapack_int)(ldv), (*C.lapack_complex_double)(_t), (C.lapack_int)(ldt), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_complex_double)(_b), (C.lapack_int)(ldb), (*C.lapack_complex_double)(_work), (C.lapack_int)(ldwork)))
 }
 
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/ssysv_rook.f.
+func SsysvRook(ul blas.Uplo, n, nrhs int, a []float32, lda int, ipiv []int32, b []float32, ldb int, work []float32, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float32
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *float32
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	var _work *float32
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_ssysv_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.float)(_b), (C.lapack_int)(ldb), (*C.float)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dsysv_rook.f.
+func DsysvRook(ul blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int32, b []float64, ldb int, work []float64, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *float64
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	var _work *float64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_dsysv_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.double)(_b), (C.lapack_int)(ldb), (*C.double)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/csysv_rook.f.
+func CsysvRook(ul blas.Uplo, n, nrhs int, a []complex64, lda int, ipiv []int32, b []complex64, ldb int, work []complex64, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex64
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	var _work *complex64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_csysv_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_float)(_b), (C.lapack_int)(ldb), (*C.lapack_complex_float)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zsysv_rook.f.
+func ZsysvRook(ul blas.Uplo, n, nrhs int, a []complex128, lda int, ipiv []int32, b []complex128, ldb int, work []complex128, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex128
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex128
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	var _work *complex128
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_zsysv_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_double)(_b), (C.lapack_int)(ldb), (*C.lapack_complex_double)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/ssytrf_rook.f.
+func SsytrfRook(ul blas.Uplo, n int, a []float32, lda int, ipiv []int32, work []float32, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float32
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *float32
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_ssytrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.float)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dsytrf_rook.f.
+func DsytrfRook(ul blas.Uplo, n int, a []float64, lda int, ipiv []int32, work []float64, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *float64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_dsytrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.double)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/csytrf_rook.f.
+func CsytrfRook(ul blas.Uplo, n int, a []complex64, lda int, ipiv []int32, work []complex64, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *complex64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_csytrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_float)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zsytrf_rook.f.
+func ZsytrfRook(ul blas.Uplo, n int, a []complex128, lda int, ipiv []int32, work []complex128, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex128
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *complex128
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_zsytrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_double)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/ssytrs_rook.f.
+func SsytrsRook(ul blas.Uplo, n, nrhs int, a []float32, lda int, ipiv []int32, b []float32, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float32
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *float32
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_ssytrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.float)(_b), (C.lapack_int)(ldb)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/dsytrs_rook.f.
+func DsytrsRook(ul blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int32, b []float64, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *float64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *float64
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_dsytrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.double)(_b), (C.lapack_int)(ldb)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/csytrs_rook.f.
+func CsytrsRook(ul blas.Uplo, n, nrhs int, a []complex64, lda int, ipiv []int32, b []complex64, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex64
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_csytrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_float)(_b), (C.lapack_int)(ldb)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zsytrs_rook.f.
+func ZsytrsRook(ul blas.Uplo, n, nrhs int, a []complex128, lda int, ipiv []int32, b []complex128, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex128
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex128
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_zsytrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_double)(_b), (C.lapack_int)(ldb)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/chetrf_rook.f.
+func ChetrfRook(ul blas.Uplo, n int, a []complex64, lda int, ipiv []int32, work []complex64, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *complex64
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_chetrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_float)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zhetrf_rook.f.
+func ZhetrfRook(ul blas.Uplo, n int, a []complex128, lda int, ipiv []int32, work []complex128, lwork int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex128
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _work *complex128
+	if len(work) > 0 {
+		_work = &work[0]
+	}
+	return isZero(C.LAPACKE_zhetrf_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_double)(_work), (C.lapack_int)(lwork)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/chetrs_rook.f.
+func ChetrsRook(ul blas.Uplo, n, nrhs int, a []complex64, lda int, ipiv []int32, b []complex64, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex64
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex64
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_chetrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_float)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_float)(_b), (C.lapack_int)(ldb)))
+}
+
+// See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/zhetrs_rook.f.
+func ZhetrsRook(ul blas.Uplo, n, nrhs int, a []complex128, lda int, ipiv []int32, b []complex128, ldb int) bool {
+	switch ul {
+	case blas.Upper:
+		ul = 'U'
+	case blas.Lower:
+		ul = 'L'
+	default:
+		panic("lapack: illegal triangle")
+	}
+	var _a *complex128
+	if len(a) > 0 {
+		_a = &a[0]
+	}
+	var _ipiv *int32
+	if len(ipiv) > 0 {
+		_ipiv = &ipiv[0]
+	}
+	var _b *complex128
+	if len(b) > 0 {
+		_b = &b[0]
+	}
+	return isZero(C.LAPACKE_zhetrs_rook_work((C.int)(rowMajor), (C.char)(ul), (C.lapack_int)(n), (C.lapack_int)(nrhs), (*C.lapack_complex_double)(_a), (C.lapack_int)(lda), (*C.lapack_int)(_ipiv), (*C.lapack_complex_double)(_b), (C.lapack_int)(ldb)))
+}
+
 // See http://www.netlib.org/cgi-bin/netlibfiles.txt?format=txt&filename=/lapack/lapack_routine/csyr.f.
 func Csyr(ul blas.Uplo, n int, alpha complex64, x []complex64, incx int, a []complex64, lda int) bool {
 	switch ul {
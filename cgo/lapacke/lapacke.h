@@ -180,14 +180,14 @@ lapack_int LAPACKE_zbdsqr( int matrix_layout, char uplo, lapack_int n,
                            lapack_int ldc );
 lapack_int LAPACKE_sbdsvdx( int matrix_layout, char uplo, char jobz, char range,
                            lapack_int n, float* d, float* e,
-                           lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           float vl, float vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            float* s, float* z, lapack_int ldz,
                            lapack_int* superb );
 lapack_int LAPACKE_dbdsvdx( int matrix_layout, char uplo, char jobz, char range,
                            lapack_int n, double* d, double* e,
-                           lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           double vl, double vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            double* s, double* z, lapack_int ldz,
                            lapack_int* superb );
 lapack_int LAPACKE_sdisna( char job, lapack_int m, lapack_int n, const float* d,
@@ -999,29 +999,29 @@ lapack_int LAPACKE_zgesvd( int matrix_layout, char jobu, char jobvt,
 
 lapack_int LAPACKE_sgesvdx( int matrix_layout, char jobu, char jobvt, char range,
                            lapack_int m, lapack_int n, float* a,
-                           lapack_int lda, lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           lapack_int lda, float vl, float vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            float* s, float* u, lapack_int ldu,
                            float* vt, lapack_int ldvt,
                            lapack_int* superb );
 lapack_int LAPACKE_dgesvdx( int matrix_layout, char jobu, char jobvt, char range,
                            lapack_int m, lapack_int n, double* a,
-                           lapack_int lda, lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           lapack_int lda, double vl, double vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            double* s, double* u, lapack_int ldu,
                            double* vt, lapack_int ldvt,
                            lapack_int* superb );
 lapack_int LAPACKE_cgesvdx( int matrix_layout, char jobu, char jobvt, char range,
                            lapack_int m, lapack_int n, lapack_complex_float* a,
-                           lapack_int lda, lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           lapack_int lda, float vl, float vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            float* s, lapack_complex_float* u, lapack_int ldu,
                            lapack_complex_float* vt, lapack_int ldvt,
                            lapack_int* superb );
 lapack_int LAPACKE_zgesvdx( int matrix_layout, char jobu, char jobvt, char range,
                            lapack_int m, lapack_int n, lapack_complex_double* a,
-                           lapack_int lda, lapack_int vl, lapack_int vu,
-                           lapack_int il, lapack_int iu, lapack_int ns,
+                           lapack_int lda, double vl, double vu,
+                           lapack_int il, lapack_int iu, lapack_int* ns,
                            double* s, lapack_complex_double* u, lapack_int ldu,
                            lapack_complex_double* vt, lapack_int ldvt,
                            lapack_int* superb );
@@ -2382,6 +2382,19 @@ lapack_int LAPACKE_zlagge( int matrix_layout, lapack_int m, lapack_int n,
 float LAPACKE_slamch( char cmach );
 double LAPACKE_dlamch( char cmach );
 
+float LAPACKE_slangb( int matrix_layout, char norm, lapack_int n,
+                           lapack_int kl, lapack_int ku, const float* ab,
+                           lapack_int ldab );
+double LAPACKE_dlangb( int matrix_layout, char norm, lapack_int n,
+                           lapack_int kl, lapack_int ku, const double* ab,
+                           lapack_int ldab );
+float LAPACKE_clangb( int matrix_layout, char norm, lapack_int n,
+                           lapack_int kl, lapack_int ku,
+                           const lapack_complex_float* ab, lapack_int ldab );
+double LAPACKE_zlangb( int matrix_layout, char norm, lapack_int n,
+                           lapack_int kl, lapack_int ku,
+                           const lapack_complex_double* ab, lapack_int ldab );
+
 float LAPACKE_slange( int matrix_layout, char norm, lapack_int m,
                            lapack_int n, const float* a, lapack_int lda );
 double LAPACKE_dlange( int matrix_layout, char norm, lapack_int m,
@@ -2393,6 +2406,19 @@ double LAPACKE_zlange( int matrix_layout, char norm, lapack_int m,
                            lapack_int n, const lapack_complex_double* a,
                            lapack_int lda );
 
+float LAPACKE_slangt( char norm, lapack_int n, const float* dl,
+                           const float* d, const float* du );
+double LAPACKE_dlangt( char norm, lapack_int n, const double* dl,
+                           const double* d, const double* du );
+float LAPACKE_clangt( char norm, lapack_int n,
+                           const lapack_complex_float* dl,
+                           const lapack_complex_float* d,
+                           const lapack_complex_float* du );
+double LAPACKE_zlangt( char norm, lapack_int n,
+                           const lapack_complex_double* dl,
+                           const lapack_complex_double* d,
+                           const lapack_complex_double* du );
+
 float LAPACKE_clanhe( int matrix_layout, char norm, char uplo, lapack_int n,
                            const lapack_complex_float* a, lapack_int lda );
 double LAPACKE_zlanhe( int matrix_layout, char norm, char uplo, lapack_int n,
@@ -4739,14 +4765,14 @@ lapack_int LAPACKE_dbdsdc_work( int matrix_layout, char uplo, char compq,
 
 lapack_int LAPACKE_sbdsvdx_work( int matrix_layout, char uplo, char jobz, char range,
                            		lapack_int n, float* d, float* e,
-                           		lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                           		float vl, float vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		float* s, float* z, lapack_int ldz,	
                                 float* work, lapack_int* iwork );
 lapack_int LAPACKE_dbdsvdx_work( int matrix_layout, char uplo, char jobz, char range,
                            		lapack_int n, double* d, double* e,
-                           		lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                           		double vl, double vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		double* s, double* z, lapack_int ldz,	
                                 double* work, lapack_int* iwork );
 
@@ -5760,30 +5786,30 @@ lapack_int LAPACKE_zgesvd_work( int matrix_layout, char jobu, char jobvt,
 
 lapack_int LAPACKE_sgesvdx_work( int matrix_layout, char jobu, char jobvt, char range,
                            		lapack_int m, lapack_int n, float* a,
-                          		lapack_int lda, lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                          		lapack_int lda, float vl, float vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		float* s, float* u, lapack_int ldu,
                            		float* vt, lapack_int ldvt,	
                                 float* work, lapack_int lwork, lapack_int* iwork );
 lapack_int LAPACKE_dgesvdx_work( int matrix_layout, char jobu, char jobvt, char range,
                            		lapack_int m, lapack_int n, double* a,
-                          		lapack_int lda, lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                          		lapack_int lda, double vl, double vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		double* s, double* u, lapack_int ldu,
                            		double* vt, lapack_int ldvt,	
                                 double* work, lapack_int lwork, lapack_int* iwork );
 lapack_int LAPACKE_cgesvdx_work( int matrix_layout, char jobu, char jobvt, char range,
                            		lapack_int m, lapack_int n, lapack_complex_float* a,
-                          		lapack_int lda, lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                          		lapack_int lda, float vl, float vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		float* s, lapack_complex_float* u, lapack_int ldu,
                            		lapack_complex_float* vt, lapack_int ldvt,	
                                 lapack_complex_float* work, lapack_int lwork,
                                 float* rwork, lapack_int* iwork );
 lapack_int LAPACKE_zgesvdx_work( int matrix_layout, char jobu, char jobvt, char range,
                            		lapack_int m, lapack_int n, lapack_complex_double* a,
-                          		lapack_int lda, lapack_int vl, lapack_int vu,
-                           		lapack_int il, lapack_int iu, lapack_int ns,
+                          		lapack_int lda, double vl, double vu,
+                           		lapack_int il, lapack_int iu, lapack_int* ns,
                            		double* s, lapack_complex_double* u, lapack_int ldu,
                            		lapack_complex_double* vt, lapack_int ldvt,	
                                 lapack_complex_double* work, lapack_int lwork, 
@@ -7561,6 +7587,21 @@ double LAPACKE_dlapy3_work( double x, double y, double z );
 float LAPACKE_slamch_work( char cmach );
 double LAPACKE_dlamch_work( char cmach );
 
+float LAPACKE_slangb_work( int matrix_layout, char norm, lapack_int n,
+                                lapack_int kl, lapack_int ku, const float* ab,
+                                lapack_int ldab, float* work );
+double LAPACKE_dlangb_work( int matrix_layout, char norm, lapack_int n,
+                                lapack_int kl, lapack_int ku, const double* ab,
+                                lapack_int ldab, double* work );
+float LAPACKE_clangb_work( int matrix_layout, char norm, lapack_int n,
+                                lapack_int kl, lapack_int ku,
+                                const lapack_complex_float* ab, lapack_int ldab,
+                                float* work );
+double LAPACKE_zlangb_work( int matrix_layout, char norm, lapack_int n,
+                                lapack_int kl, lapack_int ku,
+                                const lapack_complex_double* ab,
+                                lapack_int ldab, double* work );
+
 float LAPACKE_slange_work( int matrix_layout, char norm, lapack_int m,
                                 lapack_int n, const float* a, lapack_int lda,
                                 float* work );
@@ -7574,6 +7615,19 @@ double LAPACKE_zlange_work( int matrix_layout, char norm, lapack_int m,
                                 lapack_int n, const lapack_complex_double* a,
                                 lapack_int lda, double* work );
 
+float LAPACKE_slangt_work( char norm, lapack_int n, const float* dl,
+                                const float* d, const float* du );
+double LAPACKE_dlangt_work( char norm, lapack_int n, const double* dl,
+                                const double* d, const double* du );
+float LAPACKE_clangt_work( char norm, lapack_int n,
+                                const lapack_complex_float* dl,
+                                const lapack_complex_float* d,
+                                const lapack_complex_float* du );
+double LAPACKE_zlangt_work( char norm, lapack_int n,
+                                const lapack_complex_double* dl,
+                                const lapack_complex_double* d,
+                                const lapack_complex_double* du );
+
 float LAPACKE_clanhe_work( int matrix_layout, char norm, char uplo,
                                 lapack_int n, const lapack_complex_float* a,
                                 lapack_int lda, float* work );
@@ -12354,10 +12408,18 @@ void LAPACKE_ilaver( const lapack_int* vers_major,
 #define LAPACK_dlaswp LAPACK_GLOBAL(dlaswp,DLASWP)
 #define LAPACK_claswp LAPACK_GLOBAL(claswp,CLASWP)
 #define LAPACK_zlaswp LAPACK_GLOBAL(zlaswp,ZLASWP)
+#define LAPACK_slangb LAPACK_GLOBAL(slangb,SLANGB)
+#define LAPACK_dlangb LAPACK_GLOBAL(dlangb,DLANGB)
+#define LAPACK_clangb LAPACK_GLOBAL(clangb,CLANGB)
+#define LAPACK_zlangb LAPACK_GLOBAL(zlangb,ZLANGB)
 #define LAPACK_slange LAPACK_GLOBAL(slange,SLANGE)
 #define LAPACK_dlange LAPACK_GLOBAL(dlange,DLANGE)
 #define LAPACK_clange LAPACK_GLOBAL(clange,CLANGE)
 #define LAPACK_zlange LAPACK_GLOBAL(zlange,ZLANGE)
+#define LAPACK_slangt LAPACK_GLOBAL(slangt,SLANGT)
+#define LAPACK_dlangt LAPACK_GLOBAL(dlangt,DLANGT)
+#define LAPACK_clangt LAPACK_GLOBAL(clangt,CLANGT)
+#define LAPACK_zlangt LAPACK_GLOBAL(zlangt,ZLANGT)
 #define LAPACK_clanhe LAPACK_GLOBAL(clanhe,CLANHE)
 #define LAPACK_zlanhe LAPACK_GLOBAL(zlanhe,ZLANHE)
 #define LAPACK_slansy LAPACK_GLOBAL(slansy,SLANSY)
@@ -14784,13 +14846,13 @@ void LAPACK_dbdsdc( char* uplo, char* compq, lapack_int* n, double* d,
                     lapack_int* iwork, lapack_int *info );
 void LAPACK_sbdsvdx( char* uplo, char* jobz, char* range,
                      lapack_int* n, float* d, float* e,
-                     lapack_int* vl, lapack_int* vu,
+                     float* vl, float* vu,
                      lapack_int* il, lapack_int* iu, lapack_int* ns,
                      float* s, float* z, lapack_int* ldz,
                      float* work, lapack_int *iwork, lapack_int *info );
 void LAPACK_dbdsvdx( char* uplo, char* jobz, char* range,
                      lapack_int* n, double* d, double* e,
-                     lapack_int* vl, lapack_int* vu,
+                     double* vl, double* vu,
                      lapack_int* il, lapack_int* iu, lapack_int* ns,
                      double* s, double* z, lapack_int* ldz,
                      double* work, lapack_int *iwork, lapack_int *info );
@@ -16115,24 +16177,24 @@ void LAPACK_zgesvd( char* jobu, char* jobvt, lapack_int* m, lapack_int* n,
                     lapack_complex_double* work, lapack_int* lwork,
                     double* rwork, lapack_int *info );
 void LAPACK_sgesvdx( char* jobu, char* jobvt, char* range, lapack_int* m, lapack_int* n,
-                    float* a, lapack_int* lda, lapack_int* vl, lapack_int* vu,
+                    float* a, lapack_int* lda, float* vl, float* vu,
                     lapack_int* il, lapack_int* iu, lapack_int* ns, float* s, float* u,
                     lapack_int* ldu, float* vt, lapack_int* ldvt, float* work,
                     lapack_int* lwork, lapack_int *iwork, lapack_int *info );
 void LAPACK_dgesvdx( char* jobu, char* jobvt, char* range, lapack_int* m, lapack_int* n,
-                    double* a, lapack_int* lda, lapack_int* vl, lapack_int* vu,
+                    double* a, lapack_int* lda, double* vl, double* vu,
                     lapack_int* il, lapack_int* iu, lapack_int* ns, double* s, double* u,
                     lapack_int* ldu, double* vt, lapack_int* ldvt, double* work,
                     lapack_int* lwork, lapack_int *iwork, lapack_int *info );
 void LAPACK_cgesvdx( char* jobu, char* jobvt, char* range, lapack_int* m, lapack_int* n,
-                    lapack_complex_float* a, lapack_int* lda, lapack_int* vl, lapack_int* vu,
+                    lapack_complex_float* a, lapack_int* lda, float* vl, float* vu,
                     lapack_int* il, lapack_int* iu, lapack_int* ns, float* s,
                     lapack_complex_float* u, lapack_int* ldu,
                     lapack_complex_float* vt, lapack_int* ldvt,
                     lapack_complex_float* work, lapack_int* lwork, float* rwork,
                     lapack_int *iwork, lapack_int *info );
 void LAPACK_zgesvdx( char* jobu, char* jobvt, char* range, lapack_int* m, lapack_int* n,
-                    lapack_complex_double* a, lapack_int* lda, lapack_int* vl, lapack_int* vu,
+                    lapack_complex_double* a, lapack_int* lda, double* vl, double* vu,
                     lapack_int* il, lapack_int* iu, lapack_int* ns, double* s,
                     lapack_complex_double* u, lapack_int* ldu,
                     lapack_complex_double* vt, lapack_int* ldvt,
@@ -16824,6 +16886,18 @@ void LAPACK_claswp( lapack_int* n, lapack_complex_float* a, lapack_int* lda,
 void LAPACK_zlaswp( lapack_int* n, lapack_complex_double* a, lapack_int* lda,
                     lapack_int* k1, lapack_int* k2, const lapack_int* ipiv,
                     lapack_int* incx );
+float LAPACK_slangb( char* norm, lapack_int* n, lapack_int* kl,
+                    lapack_int* ku, const float* ab, lapack_int* ldab,
+                    float* work );
+double LAPACK_dlangb( char* norm, lapack_int* n, lapack_int* kl,
+                    lapack_int* ku, const double* ab, lapack_int* ldab,
+                    double* work );
+float LAPACK_clangb( char* norm, lapack_int* n, lapack_int* kl,
+                    lapack_int* ku, const lapack_complex_float* ab,
+                    lapack_int* ldab, float* work );
+double LAPACK_zlangb( char* norm, lapack_int* n, lapack_int* kl,
+                    lapack_int* ku, const lapack_complex_double* ab,
+                    lapack_int* ldab, double* work );
 float LAPACK_slange( char* norm, lapack_int* m, lapack_int* n, const float* a,
                     lapack_int* lda, float* work );
 double LAPACK_dlange( char* norm, lapack_int* m, lapack_int* n, const double* a,
@@ -16832,6 +16906,16 @@ float LAPACK_clange( char* norm, lapack_int* m, lapack_int* n,
                     const lapack_complex_float* a, lapack_int* lda, float* work );
 double LAPACK_zlange( char* norm, lapack_int* m, lapack_int* n,
                     const lapack_complex_double* a, lapack_int* lda, double* work );
+float LAPACK_slangt( char* norm, lapack_int* n, const float* dl,
+                    const float* d, const float* du );
+double LAPACK_dlangt( char* norm, lapack_int* n, const double* dl,
+                    const double* d, const double* du );
+float LAPACK_clangt( char* norm, lapack_int* n, const lapack_complex_float* dl,
+                    const lapack_complex_float* d,
+                    const lapack_complex_float* du );
+double LAPACK_zlangt( char* norm, lapack_int* n, const lapack_complex_double* dl,
+                    const lapack_complex_double* d,
+                    const lapack_complex_double* du );
 float LAPACK_clanhe( char* norm, char* uplo, lapack_int* n,
                     const lapack_complex_float* a, lapack_int* lda, float* work );
 double LAPACK_zlanhe( char* norm, char* uplo, lapack_int* n,
@@ -19,27 +19,106 @@ type Complex128 interface{}
 
 // Float64 defines the public float64 LAPACK API supported by gonum/lapack.
 type Float64 interface {
+	Dgbcon(norm MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgbsv(n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) (ok bool)
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
 	Dgecon(norm MatrixNorm, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+	Dgeequb(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
 	Dgeev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (first int)
+	Dgeevx(balanc Job, jobvl LeftEVJob, jobvr RightEVJob, sense CondJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, scale, rconde, rcondv, work []float64, lwork int, iwork []int) (ilo, ihi int, abnrm float64, first int)
+	Dgehrd(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dgels(trans blas.Transpose, m, n, nrhs int, a []float64, lda int, b []float64, ldb int, work []float64, lwork int) bool
+	Dgelsd(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, ok bool)
+	Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool)
+	Dgelsy(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, jpvt []int, rcond float64, work []float64, lwork int) (rank int)
 	Dgelqf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
 	Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dgesdd(jobz SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+	Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool)
 	Dgesvd(jobU, jobVT SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int) (ok bool)
+	Dgesvdx(jobU, jobVT SVDJob, rng EVRange, m, n int, a []float64, lda int, vl, vu float64, il, iu int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ns int, ok bool)
+	Dgesvx(fact FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed EquilibrationType, r, c, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut EquilibrationType, rcond, rpvgrw float64, ok bool)
 	Dgetrf(m, n int, a []float64, lda int, ipiv []int) (ok bool)
 	Dgetri(n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
 	Dgetrs(trans blas.Transpose, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dggev(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+	Dggev3(jobvl LeftEVJob, jobvr RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+	Dggglm(n, m, p int, a []float64, lda int, b []float64, ldb int, d, x, y, work []float64, lwork int) (ok bool)
+	Dgglse(m, n, p int, a []float64, lda int, b []float64, ldb int, c, d, x, work []float64, lwork int) (ok bool)
 	Dggsvd3(jobU, jobV, jobQ GSVDJob, m, n, p int, a []float64, lda int, b []float64, ldb int, alpha, beta, u []float64, ldu int, v []float64, ldv int, q []float64, ldq int, work []float64, lwork int, iwork []int) (k, l int, ok bool)
+	Dgtcon(norm MatrixNorm, n int, dl, d, du, du2 []float64, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+	Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool)
+	Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int)
+	Dhseqr(job EVJob, compz EVComp, n, ilo, ihi int, h []float64, ldh int, wr, wi []float64, z []float64, ldz int, work []float64, lwork int) (unconverged int)
 	Dlantr(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, m, n int, a []float64, lda int, work []float64) float64
+	Dlangb(norm MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
 	Dlange(norm MatrixNorm, m, n int, a []float64, lda int, work []float64) float64
+	Dlangt(norm MatrixNorm, n int, dl, d, du []float64) float64
 	Dlansy(norm MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
 	Dlapmt(forward bool, m, n int, x []float64, ldx int, k []int)
+	Dorghr(n, ilo, ihi int, a []float64, lda int, tau, work []float64, lwork int)
 	Dormqr(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
 	Dormlq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+	Dpbsv(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool)
+	Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+	Dpbtrs(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+	Dpftrf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool)
+	Dpftri(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool)
+	Dpftrs(transr blas.Transpose, uplo blas.Uplo, n, nrhs int, a []float64, b []float64, ldb int)
 	Dpocon(uplo blas.Uplo, n int, a []float64, lda int, anorm float64, work []float64, iwork []int) float64
+	Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool)
+	Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
+	Dposvx(fact FactorizationType, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed EquilibrationType, s, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut EquilibrationType, rcond float64, ok bool)
 	Dpotrf(ul blas.Uplo, n int, a []float64, lda int) (ok bool)
+	Dpotri(uplo blas.Uplo, n int, a []float64, lda int) (ok bool)
+	Dpotrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int)
+	Dppcon(uplo blas.Uplo, n int, ap []float64, anorm float64, work []float64, iwork []int) float64
+	Dpptrf(uplo blas.Uplo, n int, ap []float64) (ok bool)
+	Dpptri(uplo blas.Uplo, n int, ap []float64) (ok bool)
+	Dpptrs(uplo blas.Uplo, n, nrhs int, ap []float64, b []float64, ldb int)
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+	Dptcon(n int, d, e []float64, anorm float64, work []float64) float64
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+	Dpttrf(n int, d, e []float64) (ok bool)
+	Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int)
+	Dsbev(jobz EVJob, uplo blas.Uplo, n, kd int, ab []float64, ldab int, w, z []float64, ldz int, work []float64) (ok bool)
+	Dsfrk(transr blas.Transpose, uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64)
+	Dsgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool)
+	Dspev(jobz EVJob, uplo blas.Uplo, n int, ap, w, z []float64, ldz int, work []float64) (ok bool)
+	Dsposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool)
+	Dsptrf(uplo blas.Uplo, n int, ap []float64, ipiv []int) (ok bool)
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
 	Dsyev(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int) (ok bool)
+	Dsyevd(jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsyevr(jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsyevx(jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool)
+	Dsygv(itype int, jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int) (ok bool)
+	Dsygvd(itype int, jobz EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsygvx(itype int, jobz EVJob, rng EVRange, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool)
+	Dsysv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool)
+	DsysvRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool)
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+	DsytrfRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+	Dsytri(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool)
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	DsytrsRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dtftri(transr blas.Transpose, uplo blas.Uplo, diag blas.Diag, n int, a []float64) (ok bool)
+	Dtfttr(transr blas.Transpose, uplo blas.Uplo, n int, arf []float64, a []float64, lda int)
+	Dtgsen(ijob int, wantq, wantz bool, selected []bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, pl, pr, difu, difl float64, ok bool)
+	Dtgsna(job CondJob, howmny HowMany, selected []bool, n int, a []float64, lda int, b []float64, ldb int, vl []float64, ldvl int, vr []float64, ldvr int, s, dif []float64, mm int, work []float64, lwork int, iwork []int) (m int)
+	Dtpttf(transr blas.Transpose, uplo blas.Uplo, n int, ap, arf []float64)
 	Dtrcon(norm MatrixNorm, uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int, work []float64, iwork []int) float64
+	Dtrsen(job CondJob, compq EVComp, selected []bool, n int, t []float64, ldt int, q []float64, ldq int, wr, wi, work []float64, lwork int, iwork []int, liwork int) (m int, s, sep float64, ok bool)
+	Dtrsna(job CondJob, howmny HowMany, selected []bool, n int, t []float64, ldt int, vl []float64, ldvl int, vr []float64, ldvr int, s, sep []float64, mm int, work []float64, ldwork int, iwork []int) (m int)
+	Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool)
 	Dtrtri(uplo blas.Uplo, diag blas.Diag, n int, a []float64, lda int) (ok bool)
+	Dtrttf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64, lda int, arf []float64)
 	Dtrtrs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
 }
 
@@ -123,6 +202,67 @@ const (
 	GSVDNone GSVDJob = 'N' // Do not compute orthogonal matrix
 )
 
+// SVDComp specifies how singular vectors are computed by Dbdsdc and Dbdsvdx.
+type SVDComp byte
+
+const (
+	// BidiagSV specifies to compute the singular vectors of the input
+	// bidiagonal matrix.
+	BidiagSV SVDComp = 'I'
+)
+
+// Job types for the computation of singular vectors by the one-sided Jacobi
+// SVD routines Dgesvj and Dgejsv.
+type (
+	LeftSVJob  byte
+	RightSVJob byte
+)
+
+// LeftSVJob constants for Dgesvj and Dgejsv.
+const (
+	ComputeLeftSV    LeftSVJob = 'U' // Compute the leading left singular vectors.
+	ControlLeftSV    LeftSVJob = 'C' // Compute the left singular vectors with a user supplied orthogonality threshold (Dgesvj only).
+	ComputeAllLeftSV LeftSVJob = 'F' // Compute the full set of left singular vectors (Dgejsv only).
+	LeftSVWork       LeftSVJob = 'W' // Do not compute the left singular vectors, but u may be used as workspace (Dgejsv only).
+)
+
+// RightSVJob constants for Dgesvj and Dgejsv.
+const (
+	ComputeRightSV       RightSVJob = 'V' // Compute the right singular vectors.
+	ApplyRightSV         RightSVJob = 'A' // Apply the Jacobi rotations to the input matrix in v (Dgesvj only).
+	ComputeRightSVJacobi RightSVJob = 'J' // Compute the right singular vectors by accumulating the Jacobi rotations (Dgejsv only).
+	RightSVWork          RightSVJob = 'W' // Do not compute the right singular vectors, but v may be used as workspace (Dgejsv only).
+)
+
+// SVDAccuracy specifies the accuracy requirements and the preprocessing
+// performed by Dgejsv.
+type SVDAccuracy byte
+
+const (
+	// ColumnAccuracy computes the singular values with high relative
+	// accuracy for matrices of the form A = B*D where B has well
+	// conditioned columns and D is diagonal.
+	ColumnAccuracy SVDAccuracy = 'C'
+	// ColumnAccuracyCond is like ColumnAccuracy but also estimates the
+	// condition number of B.
+	ColumnAccuracyCond SVDAccuracy = 'E'
+	// RowColumnAccuracy computes the singular values with high relative
+	// accuracy for matrices of the form A = D1*C*D2 where C has well
+	// conditioned columns and D1 and D2 are diagonal.
+	RowColumnAccuracy SVDAccuracy = 'F'
+	// RowColumnAccuracyCond is like RowColumnAccuracy but also estimates
+	// the condition number of C.
+	RowColumnAccuracyCond SVDAccuracy = 'G'
+	// AbsoluteAccuracy computes the singular values with the usual
+	// backward stability of orthogonal transformations, and small
+	// singular values may be set to zero.
+	AbsoluteAccuracy SVDAccuracy = 'A'
+	// RankRevealing is like AbsoluteAccuracy but more aggressively sets
+	// small singular values to zero, treating A as numerically rank
+	// deficient.
+	RankRevealing SVDAccuracy = 'R'
+)
+
 // EVComp specifies how eigenvectors are computed.
 type EVComp byte
 
@@ -140,6 +280,15 @@ const (
 	// UpdateSchur specifies that the matrix of Schur vectors will be
 	// updated by Dtrexc.
 	UpdateSchur EVComp = 'V'
+
+	// ExplicitQZ specifies that the orthogonal matrices Q and Z of a
+	// generalized reduction will be formed explicitly by Dgghrd, Dgghd3
+	// and Dhgeqz.
+	ExplicitQZ EVComp = 'I'
+	// UpdateQZ specifies that the orthogonal matrices Q and Z of a
+	// generalized reduction will be postmultiplied into the input matrices
+	// by Dgghrd, Dgghd3 and Dhgeqz.
+	UpdateQZ EVComp = 'V'
 )
 
 // Job types for computation of eigenvectors.
@@ -156,23 +305,41 @@ const (
 	ComputeRightEV RightEVJob = 'V' // Compute right eigenvectors.
 )
 
-// Jobs for Dgebal.
+// Jobs for Dgebal and Dggbal.
 const (
 	Permute      Job = 'P'
 	Scale        Job = 'S'
 	PermuteScale Job = 'B'
 )
 
-// Job constants for Dhseqr.
+// Job constants for Dhseqr and Dhgeqz.
 const (
 	EigenvaluesOnly     EVJob = 'E'
 	EigenvaluesAndSchur EVJob = 'S'
 )
 
+// SchurJob specifies whether the Schur vectors are computed.
+type SchurJob byte
+
+// SchurJob constants for Dgees and Dgeesx.
+const (
+	ComputeSchurVectors SchurJob = 'V' // Compute the Schur vectors.
+)
+
+// EVRange specifies which eigenvalues will be computed.
+type EVRange byte
+
+// EVRange constants for Dsyevr, Dsyevx, Dsygvx, Dstemr, Dstebz, Dgesvdx and Dbdsvdx.
+const (
+	RangeAll      EVRange = 'A' // Compute all eigenvalues.
+	RangeInterval EVRange = 'V' // Compute eigenvalues in the half-open interval (vl,vu].
+	RangeIndex    EVRange = 'I' // Compute eigenvalues with indices il through iu.
+)
+
 // EVSide specifies what eigenvectors will be computed.
 type EVSide byte
 
-// EVSide constants for Dtrevc3.
+// EVSide constants for Dtrevc3 and Dtgevc.
 const (
 	RightEV     EVSide = 'R' // Compute right eigenvectors only.
 	LeftEV      EVSide = 'L' // Compute left eigenvectors only.
@@ -188,3 +355,41 @@ const (
 	AllEVMulQ  HowMany = 'B' // Compute all right and/or left eigenvectors multiplied by an input matrix.
 	SelectedEV HowMany = 'S' // Compute selected right and/or left eigenvectors.
 )
+
+// CondJob specifies which reciprocal condition numbers are computed.
+type CondJob byte
+
+// CondJob constants for Dtrsen, Dtrsna, Dgeesx, Dgeevx, Dtgsna and Dggesx. For
+// Dtrsna and Dgeevx the condition numbers of the invariant subspace are those
+// of the right eigenvectors, for Dtgsna those of the left and right
+// eigenvectors, and for Dggesx those of the deflating subspaces.
+const (
+	CondEigenvalues CondJob = 'E' // Compute condition numbers for the eigenvalues only.
+	CondSubspace    CondJob = 'V' // Compute condition numbers for the invariant subspace only.
+	CondBoth        CondJob = 'B' // Compute condition numbers for both the eigenvalues and the invariant subspace.
+)
+
+// FactorizationType specifies whether the factored form of a matrix is
+// supplied to an expert driver routine and whether the matrix should be
+// equilibrated before it is factored.
+type FactorizationType byte
+
+// FactorizationType constants for Dgesvx and Dposvx.
+const (
+	Factored             FactorizationType = 'F' // The factored form of the matrix is supplied on entry.
+	NotFactored          FactorizationType = 'N' // The matrix will be copied and factored.
+	EquilibrateAndFactor FactorizationType = 'E' // The matrix will be equilibrated if necessary, then copied and factored.
+)
+
+// EquilibrationType specifies the form of equilibration that has been applied
+// to a matrix.
+type EquilibrationType byte
+
+// EquilibrationType constants for Dlaqge, Dlaqsy, Dgesvx and Dposvx.
+const (
+	NoEquilibration        EquilibrationType = 'N' // No equilibration.
+	RowEquilibration       EquilibrationType = 'R' // Row equilibration, A is overwritten by diag(r)*A.
+	ColumnEquilibration    EquilibrationType = 'C' // Column equilibration, A is overwritten by A*diag(c).
+	BothEquilibration      EquilibrationType = 'B' // Both row and column equilibration, A is overwritten by diag(r)*A*diag(c).
+	SymmetricEquilibration EquilibrationType = 'Y' // Symmetric equilibration, A is overwritten by diag(s)*A*diag(s).
+)
@@ -37,6 +37,28 @@ func Use(l lapack.Float64) {
 	lapack64 = l
 }
 
+// SymmetricRFP represents an n×n symmetric matrix stored in rectangular full
+// packed (RFP) format. The upper or lower triangle of the matrix, given by
+// Uplo, is stored in Data in the format described in the documentation of
+// native.Implementation.Dtrttf. TransR specifies whether the RFP array is
+// stored transposed and must be blas.NoTrans or blas.Trans.
+type SymmetricRFP struct {
+	N      int
+	TransR blas.Transpose
+	Uplo   blas.Uplo
+	Data   []float64
+}
+
+// TriangularRFP represents an n×n triangular matrix stored in rectangular full
+// packed (RFP) format. The layout of Data is the same as for SymmetricRFP.
+type TriangularRFP struct {
+	N      int
+	TransR blas.Transpose
+	Uplo   blas.Uplo
+	Diag   blas.Diag
+	Data   []float64
+}
+
 // Potrf computes the Cholesky factorization of a.
 // The factorization has the form
 //  A = U^T * U if a.Uplo == blas.Upper, or
@@ -55,6 +77,145 @@ func Potrf(a blas64.Symmetric) (t blas64.Triangular, ok bool) {
 	return
 }
 
+// Potri computes the inverse of a real symmetric positive definite matrix A
+// using its Cholesky factorization.
+//
+// On entry, t contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Potrf.
+//
+// On return, the upper or lower triangle of the (symmetric) inverse of A is
+// stored in a, overwriting the input factor U or L, and also returned in a.
+// The underlying data between a and t is shared.
+//
+// The returned bool indicates whether the inverse was computed successfully.
+func Potri(t blas64.Triangular) (a blas64.Symmetric, ok bool) {
+	ok = lapack64.Dpotri(t.Uplo, t.N, t.Data, t.Stride)
+	a.Uplo = t.Uplo
+	a.N = t.N
+	a.Data = t.Data
+	a.Stride = t.Stride
+	return
+}
+
+// Potrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization A = U^T*U or A = L*L^T. t contains the corresponding
+// triangular factor as returned by Potrf. On entry, B contains the right-hand
+// side matrix B, on return it contains the solution matrix X.
+func Potrs(t blas64.Triangular, b blas64.General) {
+	lapack64.Dpotrs(t.Uplo, t.N, b.Cols, t.Data, t.Stride, b.Data, b.Stride)
+}
+
+// Pstrf computes the Cholesky factorization with complete pivoting of an n×n
+// symmetric positive semidefinite matrix A.
+//
+// The factorization has the form
+//  P^T * A * P = U^T * U ,  if a.Uplo = blas.Upper,
+//  P^T * A * P = L   * L^T, if a.Uplo = blas.Lower,
+// where U is an upper triangular matrix, L is lower triangular, and P is a
+// permutation matrix.
+//
+// tol is a user-defined tolerance. The algorithm terminates if the pivot is
+// less than or equal to tol. If tol is negative, then n*eps*max(A[k,k]) will be
+// used instead.
+//
+// The triangular factor U or L from the Cholesky factorization is returned in t
+// and the underlying data between a and t is shared. P is stored on return in
+// vector piv such that P[piv[k],k] = 1.
+//
+// Pstrf returns the computed rank of A and whether the factorization could be
+// completed with full rank. If ok is false, the matrix A is either rank
+// deficient or is not positive semidefinite, and only the leading rank rows of
+// U or columns of L are meaningful.
+//
+// The length of piv must be n and the length of work must be at least 2*n,
+// otherwise Pstrf will panic.
+func Pstrf(a blas64.Symmetric, piv []int, tol float64, work []float64) (t blas64.Triangular, rank int, ok bool) {
+	rank, ok = lapack64.Dpstrf(a.Uplo, a.N, a.Data, a.Stride, piv, tol, work)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, rank, ok
+}
+
+// Gbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A given its LU factorization computed by Gbtrf. The condition number
+// computed may be based on the 1-norm or the ∞-norm.
+//
+// a and ipiv contain the factorization of A as returned by Gbtrf, in
+// particular a.Stride must be at least 2*a.KL+a.KU+1. ipiv must have length
+// at least n.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Gbcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Gbcon will panic
+// otherwise.
+func Gbcon(norm lapack.MatrixNorm, a blas64.Band, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgbcon(norm, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
+// Gbsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n band matrix, and X and B are n×nrhs matrices. The LU
+// decomposition with partial pivoting and row interchanges is used to factor
+// A as
+//  A = P * L * U
+// where P is a permutation matrix, L is a product of permutation and unit
+// lower triangular matrices with a.KL subdiagonals, and U is upper triangular
+// with a.KL+a.KU superdiagonals.
+//
+// a.Stride must be at least 2*a.KL+a.KU+1 so that a.Data has room for the
+// fill-in of U, see the documentation of Gbtrf for the details of the storage.
+// On return, a contains the factors L and U with the pivot indices stored in
+// ipiv, and if ok is true, b contains the solution matrix X. ipiv must have
+// length at least n.
+//
+// Gbsv returns false if the matrix A is exactly singular, in which case the
+// solution has not been computed.
+func Gbsv(a blas64.Band, ipiv []int, b blas64.General) (ok bool) {
+	return lapack64.Dgbsv(a.Cols, a.KL, a.KU, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Gbtrf computes an LU factorization of the m×n band matrix A using partial
+// pivoting with row interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix with at
+// most a.KL non-zero elements below the diagonal in each column, and U is an
+// upper triangular band matrix with a.KL+a.KU superdiagonals.
+//
+// The band storage of a must have room for the fill-in of U, that is
+// a.Stride must be at least 2*a.KL+a.KU+1, and the element A[i,j] is stored
+// in a.Data[i*a.Stride+a.KL+j-i] as for a band matrix with a.KL subdiagonals
+// and a.KL+a.KU superdiagonals. On return, a contains U and the multipliers
+// used during the factorization in the same format.
+//
+// ipiv must have length at least min(m,n). On return, row i of the matrix was
+// interchanged with row ipiv[i]. ipiv is zero-indexed.
+//
+// Gbtrf returns whether U is nonsingular. If ok is false, the factorization
+// has been completed, but division by zero will occur if it is used to solve a
+// system of equations.
+func Gbtrf(a blas64.Band, ipiv []int) (ok bool) {
+	return lapack64.Dgbtrf(a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, ipiv)
+}
+
+// Gbtrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n band matrix, using the LU factorization computed by Gbtrf.
+// a and ipiv contain the factorization of A as returned by Gbtrf.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func Gbtrs(trans blas.Transpose, a blas64.Band, ipiv []int, b blas64.General) {
+	lapack64.Dgbtrs(trans, a.Cols, a.KL, a.KU, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
 // Gecon estimates the reciprocal of the condition number of the n×n matrix A
 // given the LU decomposition of the matrix. The condition number computed may
 // be based on the 1-norm or the ∞-norm.
@@ -70,6 +231,33 @@ func Gecon(norm lapack.MatrixNorm, a blas64.General, anorm float64, work []float
 	return lapack64.Dgecon(norm, a.Cols, a.Data, a.Stride, anorm, work, iwork)
 }
 
+// Geequ computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. On return, r and c contain the
+// row and column scale factors, respectively, chosen so that the largest
+// element in each row and column of the matrix with elements
+// r[i]*A[i,j]*c[j] has absolute value 1.
+//
+// r must have length at least m and c must have length at least n.
+//
+// rowcnd and colcnd are the ratios of the smallest to the largest scale factor
+// in r and c, respectively, and amax is the absolute value of the largest
+// element of A. Geequ returns false if A has a row or a column of zeros.
+func Geequ(a blas64.General, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	return lapack64.Dgeequ(a.Rows, a.Cols, a.Data, a.Stride, r, c)
+}
+
+// Geequb computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. Geequb differs from Geequ only in
+// restricting the scale factors to powers of the radix, so that scaling does
+// not introduce any rounding errors.
+//
+// r must have length at least m and c must have length at least n.
+//
+// Geequb returns false if A has a row or a column of zeros.
+func Geequb(a blas64.General, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	return lapack64.Dgeequb(a.Rows, a.Cols, a.Data, a.Stride, r, c)
+}
+
 // Gels finds a minimum-norm solution based on the matrices A and B using the
 // QR or LQ factorization. Gels returns false if the matrix
 // A is singular, and true if this solution was successfully found.
@@ -103,6 +291,97 @@ func Gels(trans blas.Transpose, a blas64.General, b blas64.General, work []float
 	return lapack64.Dgels(trans, a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, work, lwork)
 }
 
+// Gelsd computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient. The bidiagonal least squares problem is solved using a
+// divide and conquer algorithm.
+//
+// B is a max(m,n)×nrhs matrix. On entry, its leading m×nrhs submatrix
+// contains the right hand sides, and on return its leading n×nrhs submatrix
+// contains the solution X. A is overwritten during the call.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond <= 0 or rcond >= 1, machine precision is used instead.
+//
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// If lwork == -1, instead of performing Gelsd, the optimal work length will be
+// stored into work[0] and the minimum length of iwork into iwork[0]. Gelsd
+// will panic if the working memory has insufficient storage. iwork must have
+// length at least 3*mn*nlvl + 11*mn,
+// where mn = max(1,min(m,n)) and nlvl = max(0, int(log_2(mn/26)) + 1).
+//
+// Gelsd returns the effective rank of A and whether the singular value
+// decomposition converged.
+func Gelsd(a, b blas64.General, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, ok bool) {
+	return lapack64.Dgelsd(a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, lwork, iwork)
+}
+
+// Gelss computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient.
+//
+// B is a max(m,n)×nrhs matrix. On entry, its leading m×nrhs submatrix
+// contains the right hand sides, and on return its leading n×nrhs submatrix
+// contains the solution X. On return, the first min(m,n) rows of A contain
+// the right singular vectors of A.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond < 0, machine precision is used instead.
+//
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  max(1, 3*min(m,n) + max(2*min(m,n), max(m,n), nrhs)).
+// If lwork == -1, instead of performing Gelss, the optimal work length will be
+// stored into work[0].
+//
+// Gelss returns the effective rank of A and whether the singular value
+// decomposition converged.
+func Gelss(a, b blas64.General, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool) {
+	return lapack64.Dgelss(a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, lwork)
+}
+
+// Gelsy computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using a complete orthogonal factorization of the m×n matrix A, which may be
+// rank-deficient.
+//
+// B is a max(m,n)×nrhs matrix. On entry, its leading m×nrhs submatrix
+// contains the right hand sides, and on return its leading n×nrhs submatrix
+// contains the solution X. On return, A contains its complete orthogonal
+// factorization.
+//
+// jpvt specifies a column pivot to be applied to A. If jpvt[j] is at least
+// zero, the j-th column of A is permuted to the front of A*P (a leading
+// column), and if jpvt[j] is -1, the j-th column of A is a free column. On
+// return, jpvt holds the permutation that was applied; the j-th column of A*P
+// was the jpvt[j] column of A. jpvt must have length n.
+//
+// rcond is used to determine the effective rank of A, which is defined as
+// the order of the largest leading triangular submatrix R11 in the QR
+// factorization with pivoting of A, whose estimated condition number is less
+// than 1/rcond.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  max(min(m,n) + 3*n + 1, 2*min(m,n) + nrhs),
+// or 1 if min(m,n) == 0 or nrhs == 0. If lwork == -1, instead of performing
+// Gelsy, the optimal work length will be stored into work[0].
+//
+// Gelsy returns the effective rank of A.
+func Gelsy(a, b blas64.General, jpvt []int, rcond float64, work []float64, lwork int) int {
+	return lapack64.Dgelsy(a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, jpvt, rcond, work, lwork)
+}
+
 // Geqrf computes the QR factorization of the m×n matrix A using a blocked
 // algorithm. A is modified to contain the information to construct Q and R.
 // The upper triangle of a contains the matrix R. The lower triangular elements
@@ -149,6 +428,125 @@ func Gelqf(a blas64.General, tau, work []float64, lwork int) {
 	lapack64.Dgelqf(a.Rows, a.Cols, a.Data, a.Stride, tau, work, lwork)
 }
 
+// Gesdd computes the singular value decomposition of the input matrix A using
+// a divide and conquer algorithm.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// jobz specifies the singular vectors that are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll       All m columns of U and all n rows of V^T are
+//                              returned in u and vt.
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U and rows of V^T
+//                              are returned in u and vt.
+//  jobz == lapack.SVDOverwrite If m >= n, the first n columns of U are written
+//                              into a and all rows of V^T are returned in vt.
+//                              Otherwise, all columns of U are returned in u
+//                              and the first m rows of V^T are written into a.
+//  jobz == lapack.SVDNone      No columns of U or rows of V^T are computed.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesdd
+// the data is overwritten. On exit, A contains the appropriate singular vectors
+// if jobz is lapack.SVDOverwrite.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored columnwise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m < n, u is of
+// size m×m. If jobz == lapack.SVDInPlace, u is of size m×min(m,n). Otherwise
+// u is not used.
+//
+// vt contains the right singular vectors on exit, stored rowwise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m >= n, vt is of
+// size n×n. If jobz == lapack.SVDInPlace, vt is of size min(m,n)×n. Otherwise
+// vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If lwork == -1, instead of performing Gesdd, the optimal work
+// length will be stored into work[0]. Gesdd will panic if the working memory
+// has insufficient storage. iwork must have length at least 8*min(m,n).
+//
+// Gesdd returns whether the decomposition successfully completed.
+func Gesdd(jobz lapack.SVDJob, a, u, vt blas64.General, s, work []float64, lwork int, iwork []int) (ok bool) {
+	return lapack64.Dgesdd(jobz, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork, iwork)
+}
+
+// Gerfs improves the computed solution to a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// and provides error bounds and backward error estimates for the solution.
+//
+// af and ipiv contain the LU factorization of A as computed by Getrf. b
+// contains the right hand sides B and x on entry contains the solution X as
+// computed by Getrs. On return, x contains the improved solution.
+//
+// On return, ferr[j] contains an estimated bound for the forward error of the
+// j-th solution vector, and berr[j] contains its componentwise relative
+// backward error. ferr and berr must have length at least nrhs.
+//
+// work must have length at least 3*n and iwork must have length at least n.
+func Gerfs(trans blas.Transpose, a, af blas64.General, ipiv []int, b, x blas64.General, ferr, berr, work []float64, iwork []int) {
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	lapack64.Dgerfs(trans, a.Cols, b.Cols, a.Data, a.Stride, af.Data, af.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
+// Gesv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n matrix and X and B are n×nrhs matrices.
+//
+// On return, a contains the LU factorization of A with the pivot indices
+// stored in ipiv, and if ok is true, b contains the solution matrix X. ipiv
+// must have length at least n.
+//
+// Gesv returns false if the matrix A is exactly singular, in which case the
+// solution has not been computed.
+func Gesv(a blas64.General, ipiv []int, b blas64.General) (ok bool) {
+	return lapack64.Dgesv(a.Cols, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Gesvx uses the LU factorization to compute the solution to a real system
+// of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n matrix and X and B are n×nrhs matrices. Error bounds on
+// the solution and a condition estimate are also provided.
+//
+// If fact == lapack.EquilibrateAndFactor, the system is equilibrated first if
+// that is beneficial, and A and B are overwritten by their equilibrated forms.
+// If fact is lapack.NotFactored or lapack.EquilibrateAndFactor, the LU
+// factorization of A is computed and stored in af and ipiv. If
+// fact == lapack.Factored, af and ipiv must contain the LU factorization of A
+// on entry, and equed specifies the equilibration that was applied to A
+// using the scale factors in r and c.
+//
+// On return, x contains the solution of the original system, ferr[j]
+// contains an estimated bound for the forward error of the j-th solution
+// vector, and berr[j] contains its componentwise relative backward error.
+//
+// work must have length at least 4*n and iwork must have length at least n.
+//
+// Gesvx returns the form of equilibration that was applied to A, the
+// reciprocal condition number estimate rcond of the (equilibrated) matrix A,
+// and the reciprocal pivot growth factor rpvgrw of its LU factorization. If
+// rpvgrw is much less than 1, the solution and error bounds could be
+// unreliable. Gesvx returns ok == false if A is singular to working
+// precision. If A is exactly singular, rcond is zero and the solution is not
+// computed.
+func Gesvx(fact lapack.FactorizationType, trans blas.Transpose, a, af blas64.General, ipiv []int, equed lapack.EquilibrationType, r, c []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	return lapack64.Dgesvx(fact, trans, a.Cols, b.Cols, a.Data, a.Stride, af.Data, af.Stride, ipiv, equed, r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
 // Gesvd computes the singular value decomposition of the input matrix A.
 //
 // The singular value decomposition is
@@ -195,6 +593,41 @@ func Gesvd(jobU, jobVT lapack.SVDJob, a, u, vt blas64.General, s, work []float64
 	return lapack64.Dgesvd(jobU, jobVT, a.Rows, a.Cols, a.Data, a.Stride, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork)
 }
 
+// Gesvdx computes selected singular values and, optionally, the corresponding
+// left and right singular vectors of the m×n matrix A.
+//
+// The singular values to compute are selected by rng. If rng is
+// lapack.RangeAll, all singular values are computed. If rng is
+// lapack.RangeInterval, the singular values in the half-open interval (vl,vu]
+// are computed, and 0 <= vl < vu must hold. If rng is lapack.RangeIndex, the
+// il-th through iu-th largest singular values are computed, with indices
+// starting at 0, and 0 <= il <= iu < min(m,n) must hold.
+//
+// jobU and jobVT must be either lapack.SVDInPlace, in which case the
+// corresponding singular vectors are computed, or lapack.SVDNone.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to Gesvdx
+// the data is overwritten.
+//
+// On return, the first ns elements of s contain the selected singular values in
+// decreasing order, the first ns columns of u the corresponding left singular
+// vectors and the first ns rows of vt the corresponding right singular vectors.
+// s must have length at least min(m,n). u must be m×(iu-il+1) and vt
+// (iu-il+1)×n if rng is lapack.RangeIndex, and otherwise u must be m×min(m,n)
+// and vt min(m,n)×n. u and vt are not used if the corresponding singular vectors
+// are not computed.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size of
+// the slice. If lwork == -1, instead of performing Gesvdx, the optimal work
+// length will be stored into work[0]. Gesvdx will panic if the working memory
+// has insufficient storage. iwork must have length at least 10*min(m,n).
+//
+// Gesvdx returns the number of singular values found and whether the
+// computation successfully completed.
+func Gesvdx(jobU, jobVT lapack.SVDJob, rng lapack.EVRange, a blas64.General, vl, vu float64, il, iu int, s []float64, u, vt blas64.General, work []float64, lwork int, iwork []int) (ns int, ok bool) {
+	return lapack64.Dgesvdx(jobU, jobVT, rng, a.Rows, a.Cols, a.Data, a.Stride, vl, vu, il, iu, s, u.Data, u.Stride, vt.Data, vt.Stride, work, lwork, iwork)
+}
+
 // Getrf computes the LU decomposition of the m×n matrix A.
 // The LU decomposition is a factorization of A into
 //  A = P * L * U
@@ -247,6 +680,52 @@ func Getrs(trans blas.Transpose, a blas64.General, b blas64.General, ipiv []int)
 	lapack64.Dgetrs(trans, a.Cols, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
 }
 
+// Ggglm solves a general Gauss-Markov linear model (GLM) problem
+//  minimize |y|_2 subject to d = A*x + B*y,
+// where A is an n×m matrix, B is an n×p matrix, and d is a given vector of
+// length n. It must hold that m <= n <= m+p, and rank(A) = m and
+// rank([A B]) = n.
+//
+// On return, A and B contain their generalized QR factorization, x of length
+// m and y of length p contain the solution. d is overwritten on return.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1, n+m+p). If lwork == -1, instead of performing
+// Ggglm, the optimal work length will be stored into work[0].
+//
+// Ggglm returns whether the solution was computed successfully. It returns
+// false if rank(A) < m or rank([A B]) < n.
+func Ggglm(a, b blas64.General, d, x, y, work []float64, lwork int) (ok bool) {
+	if a.Rows != b.Rows {
+		panic("lapack64: bad number of rows of B")
+	}
+	return lapack64.Dggglm(a.Rows, a.Cols, b.Cols, a.Data, a.Stride, b.Data, b.Stride, d, x, y, work, lwork)
+}
+
+// Gglse solves the linear equality-constrained least squares problem
+//  minimize |c - A*x|_2 subject to B*x = d,
+// where A is an m×n matrix, B is a p×n matrix, c is a vector of length m, and
+// d is a vector of length p. It must hold that p <= n <= m+p, and
+// rank(B) = p and rank([A; B]) = n.
+//
+// On return, A and B contain their generalized RQ factorization, and x of
+// length n contains the solution. The residual sum of squares of the solution
+// is given by the sum of squares of the elements c[n-p:m] on return. d is
+// overwritten on return.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1, m+n+p). If lwork == -1, instead of performing
+// Gglse, the optimal work length will be stored into work[0].
+//
+// Gglse returns whether the solution was computed successfully. It returns
+// false if rank(B) < p or rank([A; B]) < n.
+func Gglse(a, b blas64.General, c, d, x, work []float64, lwork int) (ok bool) {
+	if a.Cols != b.Cols {
+		panic("lapack64: bad number of columns of B")
+	}
+	return lapack64.Dgglse(a.Rows, a.Cols, b.Rows, a.Data, a.Stride, b.Data, b.Stride, c, d, x, work, lwork)
+}
+
 // Ggsvd3 computes the generalized singular value decomposition (GSVD)
 // of an m×n matrix A and p×n matrix B:
 //  U^T*A*Q = D1*[ 0 R ]
@@ -347,6 +826,88 @@ func Ggsvd3(jobU, jobV, jobQ lapack.GSVDJob, a, b blas64.General, alpha, beta []
 	return lapack64.Dggsvd3(jobU, jobV, jobQ, a.Rows, a.Cols, b.Rows, a.Data, a.Stride, b.Data, b.Stride, alpha, beta, u.Data, u.Stride, v.Data, v.Stride, q.Data, q.Stride, work, lwork, iwork)
 }
 
+// Gtcon estimates the reciprocal of the condition number of an n×n
+// tridiagonal matrix A given its LU factorization computed by Gttrf. The
+// condition number computed may be based on the 1-norm or the ∞-norm.
+//
+// dl, d, du, du2 and ipiv contain the factorization of A as returned by Gttrf,
+// where n is the length of d.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Gtcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Gtcon will panic
+// otherwise.
+func Gtcon(norm lapack.MatrixNorm, dl, d, du, du2 []float64, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dgtcon(norm, len(d), dl, d, du, du2, ipiv, anorm, work, iwork)
+}
+
+// Gtsv solves the equation
+//  A * X = B
+// where A is an n×n tridiagonal matrix, by Gaussian elimination with partial
+// pivoting. dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively, where n is the length of d. On
+// return, dl contains the n-2 elements of the second superdiagonal of the
+// upper triangular matrix U from the LU factorization of A, d contains the
+// diagonal of U and du contains the first superdiagonal of U.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, if ok
+// is true, b contains the solution matrix X.
+//
+// Gtsv returns false if the matrix A is exactly singular, in which case the
+// solution has not been computed.
+func Gtsv(dl, d, du []float64, b blas64.General) (ok bool) {
+	return lapack64.Dgtsv(len(d), b.Cols, dl, d, du, b.Data, b.Stride)
+}
+
+// Gttrf computes an LU factorization of an n×n tridiagonal matrix A using
+// elimination with partial pivoting and row interchanges. The factorization
+// has the form
+//  A = L * U
+// where L is a product of permutation and unit lower bidiagonal matrices and
+// U is upper triangular with nonzeros in only the main diagonal and first two
+// superdiagonals.
+//
+// On entry, dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively, where n is the length of d. On
+// return, dl contains the multipliers that define L, d, du and du2 contain the
+// diagonal and the first and second superdiagonals of U. du2 must have length
+// at least n-2 and ipiv must have length at least n. ipiv is zero-indexed.
+//
+// Gttrf returns whether U is nonsingular. If ok is false, the factorization
+// has been completed, but division by zero will occur if it is used to solve a
+// system of equations.
+func Gttrf(dl, d, du, du2 []float64, ipiv []int) (ok bool) {
+	return lapack64.Dgttrf(len(d), dl, d, du, du2, ipiv)
+}
+
+// Gttrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n tridiagonal matrix, using the LU factorization computed by
+// Gttrf. dl, d, du, du2 and ipiv contain the factorization of A as returned by
+// Gttrf, where n is the length of d.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func Gttrs(trans blas.Transpose, dl, d, du, du2 []float64, ipiv []int, b blas64.General) {
+	lapack64.Dgttrs(trans, len(d), b.Cols, dl, d, du, du2, ipiv, b.Data, b.Stride)
+}
+
+// Langb computes the specified norm of an m×n band matrix A. The input norm
+// specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.Frobenius: the square root of the sum of the squares of the entries.
+// If norm == lapack.MaxColumnSum, work must be of length n, and this function will panic otherwise.
+// There are no restrictions on work for the other matrix norms.
+func Langb(norm lapack.MatrixNorm, a blas64.Band, work []float64) float64 {
+	return lapack64.Dlangb(norm, a.Rows, a.Cols, a.KL, a.KU, a.Data, a.Stride, work)
+}
+
 // Lange computes the matrix norm of the general m×n matrix A. The input norm
 // specifies the norm computed.
 //  lapack.MaxAbs: the maximum absolute value of an element.
@@ -359,6 +920,17 @@ func Lange(norm lapack.MatrixNorm, a blas64.General, work []float64) float64 {
 	return lapack64.Dlange(norm, a.Rows, a.Cols, a.Data, a.Stride, work)
 }
 
+// Langt computes the specified norm of an n×n tridiagonal matrix A with
+// subdiagonal dl, diagonal d and superdiagonal du, where n is the length of d.
+// The input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.Frobenius: the square root of the sum of the squares of the entries.
+func Langt(norm lapack.MatrixNorm, dl, d, du []float64) float64 {
+	return lapack64.Dlangt(norm, len(d), dl, d, du)
+}
+
 // Lansy computes the specified norm of an n×n symmetric matrix. If
 // norm == lapack.MaxColumnSum or norm == lapackMaxRowSum work must have length
 // at least n and this function will panic otherwise.
@@ -443,6 +1015,119 @@ func Ormqr(side blas.Side, trans blas.Transpose, a blas64.General, tau []float64
 	lapack64.Dormqr(side, trans, c.Rows, c.Cols, a.Cols, a.Data, a.Stride, tau, c.Data, c.Stride, work, lwork)
 }
 
+// Pbcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite band matrix A given the Cholesky factorization of A
+// computed by Pbtrf. The condition number computed is based on the 1-norm and
+// the ∞-norm.
+//
+// t contains the triangular factor U or L as returned by Pbtrf, and anorm is
+// the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Pbcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Pbcon will panic
+// otherwise.
+func Pbcon(t blas64.TriangularBand, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dpbcon(t.Uplo, t.N, t.K, t.Data, t.Stride, anorm, work, iwork)
+}
+
+// Pbsv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite band matrix and X and B are
+// n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if a.Uplo == blas.Upper,
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// and the factor is stored in the corresponding triangle of a. On entry, b
+// contains the right hand side matrix B. On return, if ok is true, b contains
+// the solution matrix X.
+//
+// Pbsv returns false if A is not positive definite. In that case the solution
+// has not been computed.
+func Pbsv(a blas64.SymmetricBand, b blas64.General) (ok bool) {
+	return lapack64.Dpbsv(a.Uplo, a.N, a.K, b.Cols, a.Data, a.Stride, b.Data, b.Stride)
+}
+
+// Pbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A. The factorization has the form
+//  A = U^T * U  if a.Uplo == blas.Upper,
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with a.K off-diagonals. The triangular band matrix is returned
+// in t, and the underlying data between a and t is shared. The returned bool
+// indicates whether A is positive definite and the factorization could be
+// finished.
+func Pbtrf(a blas64.SymmetricBand) (t blas64.TriangularBand, ok bool) {
+	ok = lapack64.Dpbtrf(a.Uplo, a.N, a.K, a.Data, a.Stride)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.K = a.K
+	t.Data = a.Data
+	t.Stride = a.Stride
+	return t, ok
+}
+
+// Pbtrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite band matrix and B is an n×nrhs matrix, using
+// the Cholesky factorization A = U^T*U or A = L*L^T. t contains the
+// corresponding triangular band factor as returned by Pbtrf. On entry, B
+// contains the right-hand side matrix B, on return it contains the solution
+// matrix X.
+func Pbtrs(t blas64.TriangularBand, b blas64.General) {
+	lapack64.Dpbtrs(t.Uplo, t.N, t.K, b.Cols, t.Data, t.Stride, b.Data, b.Stride)
+}
+
+// Pftrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in rectangular full packed format. The
+// factorization has the form
+//  A = U^T * U  if a.Uplo == blas.Upper,
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular. The
+// triangular matrix is returned in t, and the underlying data between a and t
+// is shared. The returned bool indicates whether A is positive definite and
+// the factorization could be finished.
+func Pftrf(a SymmetricRFP) (t TriangularRFP, ok bool) {
+	ok = lapack64.Dpftrf(a.TransR, a.Uplo, a.N, a.Data)
+	t.N = a.N
+	t.TransR = a.TransR
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.Data = a.Data
+	return t, ok
+}
+
+// Pftri computes the inverse of a real symmetric positive definite matrix A
+// stored in rectangular full packed format using its Cholesky factorization.
+//
+// On entry, t contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Pftrf.
+//
+// On return, the upper or lower triangle of the (symmetric) inverse of A is
+// stored in a, overwriting the input factor U or L, and also returned in a.
+// The underlying data between a and t is shared.
+//
+// The returned bool indicates whether the inverse was computed successfully.
+func Pftri(t TriangularRFP) (a SymmetricRFP, ok bool) {
+	ok = lapack64.Dpftri(t.TransR, t.Uplo, t.N, t.Data)
+	a.N = t.N
+	a.TransR = t.TransR
+	a.Uplo = t.Uplo
+	a.Data = t.Data
+	return a, ok
+}
+
+// Pftrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization A = U^T*U or A = L*L^T computed by Pftrf and stored
+// in rectangular full packed format in t. On entry, b contains the right-hand
+// side matrix B, on return it contains the solution matrix X.
+func Pftrs(t TriangularRFP, b blas64.General) {
+	lapack64.Dpftrs(t.TransR, t.Uplo, t.N, b.Cols, t.Data, b.Data, b.Stride)
+}
+
 // Pocon estimates the reciprocal of the condition number of a positive-definite
 // matrix A given the Cholesky decmposition of A. The condition number computed
 // is based on the 1-norm and the ∞-norm.
@@ -456,6 +1141,344 @@ func Pocon(a blas64.Symmetric, anorm float64, work []float64, iwork []int) float
 	return lapack64.Dpocon(a.Uplo, a.N, a.Data, a.Stride, anorm, work, iwork)
 }
 
+// Poequ computes scale factors intended to equilibrate the symmetric positive
+// definite matrix A and reduce its condition number. On return, s contains
+// the scale factors
+//  s[i] = 1 / sqrt(A[i,i]),
+// chosen so that the scaled matrix diag(s)*A*diag(s) has ones on the diagonal.
+// Only the diagonal of A is referenced.
+//
+// s must have length at least n.
+//
+// scond is the ratio of the smallest s[i] to the largest s[i], and amax is the
+// absolute value of the largest element of A. Poequ returns false if a
+// diagonal element of A is not positive.
+func Poequ(a blas64.Symmetric, s []float64) (scond, amax float64, ok bool) {
+	return lapack64.Dpoequ(a.N, a.Data, a.Stride, s)
+}
+
+// Porfs improves the computed solution to a system of linear equations
+//  A * X = B,
+// where A is a symmetric positive definite matrix, and provides error bounds
+// and backward error estimates for the solution.
+//
+// af contains the triangular factor from the Cholesky factorization of A as
+// computed by Potrf, and must be stored in the same triangle as a. b contains
+// the right hand sides B and x on entry contains the solution X as computed by
+// Potrs. On return, x contains the improved solution.
+//
+// On return, ferr[j] contains an estimated bound for the forward error of the
+// j-th solution vector, and berr[j] contains its componentwise relative
+// backward error. ferr and berr must have length at least nrhs.
+//
+// work must have length at least 3*n and iwork must have length at least n.
+func Porfs(a blas64.Symmetric, af blas64.Triangular, b, x blas64.General, ferr, berr, work []float64, iwork []int) {
+	if a.Uplo != af.Uplo {
+		panic("lapack64: mismatched triangles")
+	}
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	lapack64.Dporfs(a.Uplo, a.N, b.Cols, a.Data, a.Stride, af.Data, af.Stride, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
+// Posv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if a.Uplo == blas.Upper,
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// and the factor is stored in the corresponding triangle of a. On entry, b
+// contains the right hand side matrix B. On return, if ok is true, b contains
+// the solution matrix X.
+//
+// Posv returns false if A is not positive definite. In that case the solution
+// has not been computed.
+func Posv(a blas64.Symmetric, b blas64.General) (ok bool) {
+	return lapack64.Dposv(a.Uplo, a.N, b.Cols, a.Data, a.Stride, b.Data, b.Stride)
+}
+
+// Posvx uses the Cholesky factorization to compute the solution to a real
+// system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are n×nrhs
+// matrices. Error bounds on the solution and a condition estimate are also
+// provided.
+//
+// If fact == lapack.EquilibrateAndFactor, the system is equilibrated first if
+// that is beneficial, and A and B are overwritten by their equilibrated forms.
+// If fact is lapack.NotFactored or lapack.EquilibrateAndFactor, the Cholesky
+// factorization of A is computed and stored in af. If fact == lapack.Factored,
+// af must contain the Cholesky factorization of A on entry, and equed
+// specifies the equilibration that was applied to A using the scale factors
+// in s. af must be stored in the same triangle as a.
+//
+// On return, x contains the solution of the original system, ferr[j]
+// contains an estimated bound for the forward error of the j-th solution
+// vector, and berr[j] contains its componentwise relative backward error.
+//
+// work must have length at least 3*n and iwork must have length at least n.
+//
+// Posvx returns the equilibration that was applied and the reciprocal
+// condition number of the (equilibrated) matrix A. ok is false if A is not
+// positive definite or if it is singular to working precision.
+func Posvx(fact lapack.FactorizationType, a blas64.Symmetric, af blas64.Triangular, equed lapack.EquilibrationType, s []float64, b, x blas64.General, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond float64, ok bool) {
+	if a.Uplo != af.Uplo {
+		panic("lapack64: mismatched triangles")
+	}
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	return lapack64.Dposvx(fact, a.Uplo, a.N, b.Cols, a.Data, a.Stride, af.Data, af.Stride, equed, s, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+}
+
+// Ppcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite matrix A stored in packed format given the Cholesky
+// factorization of A computed by Pptrf. The condition number computed is
+// based on the 1-norm and the ∞-norm.
+//
+// t contains the triangular factor U or L as returned by Pptrf, and anorm is
+// the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Ppcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Ppcon will panic
+// otherwise.
+func Ppcon(t blas64.TriangularPacked, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dppcon(t.Uplo, t.N, t.Data, anorm, work, iwork)
+}
+
+// Pptrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in packed format. The factorization has the form
+//  A = U^T * U  if a.Uplo == blas.Upper,
+//  A = L * L^T  if a.Uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular. The
+// triangular matrix is returned in t, and the underlying data between a and t
+// is shared. The returned bool indicates whether A is positive definite and
+// the factorization could be finished.
+func Pptrf(a blas64.SymmetricPacked) (t blas64.TriangularPacked, ok bool) {
+	ok = lapack64.Dpptrf(a.Uplo, a.N, a.Data)
+	t.Uplo = a.Uplo
+	t.Diag = blas.NonUnit
+	t.N = a.N
+	t.Data = a.Data
+	return t, ok
+}
+
+// Pptri computes the inverse of a real symmetric positive definite matrix A
+// stored in packed format using its Cholesky factorization.
+//
+// On entry, t contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Pptrf.
+//
+// On return, the upper or lower triangle of the (symmetric) inverse of A is
+// stored in a, overwriting the input factor U or L, and also returned in a.
+// The underlying data between a and t is shared.
+//
+// The returned bool indicates whether the inverse was computed successfully.
+func Pptri(t blas64.TriangularPacked) (a blas64.SymmetricPacked, ok bool) {
+	ok = lapack64.Dpptri(t.Uplo, t.N, t.Data)
+	a.Uplo = t.Uplo
+	a.N = t.N
+	a.Data = t.Data
+	return
+}
+
+// Pptrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix stored in packed format and B is an
+// n×nrhs matrix, using the Cholesky factorization A = U^T*U or A = L*L^T. t
+// contains the corresponding triangular factor as returned by Pptrf. On entry,
+// B contains the right-hand side matrix B, on return it contains the solution
+// matrix X.
+func Pptrs(t blas64.TriangularPacked, b blas64.General) {
+	lapack64.Dpptrs(t.Uplo, t.N, b.Cols, t.Data, b.Data, b.Stride)
+}
+
+// Ptcon computes the reciprocal of the condition number (in the 1-norm) of
+// an n×n symmetric positive definite tridiagonal matrix A using the
+// factorization A = L*D*L^T computed by Pttrf. d and e contain the
+// factorization of A as returned by Pttrf, where n is the length of d.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least n and Ptcon will panic
+// otherwise.
+func Ptcon(d, e []float64, anorm float64, work []float64) float64 {
+	return lapack64.Dptcon(len(d), d, e, anorm, work)
+}
+
+// Ptsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix with
+// diagonal d and subdiagonal e, where n is the length of d, and X and B are
+// n×nrhs matrices. On return, d and e contain the L*D*L^T factorization of A
+// as computed by Pttrf, and if ok is true, b contains the solution matrix X.
+//
+// Ptsv returns whether the factorization was successfully completed. If ok is
+// false, A is not positive definite and the solution has not been computed.
+func Ptsv(d, e []float64, b blas64.General) (ok bool) {
+	return lapack64.Dptsv(len(d), b.Cols, d, e, b.Data, b.Stride)
+}
+
+// Pttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A. On entry, d and e contain the diagonal and
+// subdiagonal elements of A, where n is the length of d. On return, d contains
+// the diagonal of D and e contains the subdiagonal of the unit lower
+// bidiagonal factor L.
+//
+// Pttrf returns whether the factorization was successfully completed. If ok
+// is false, A is not positive definite.
+func Pttrf(d, e []float64) (ok bool) {
+	return lapack64.Dpttrf(len(d), d, e)
+}
+
+// Pttrs solves a system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, using the
+// L*D*L^T factorization of A computed by Pttrf. d and e contain the
+// factorization of A as returned by Pttrf, where n is the length of d.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func Pttrs(d, e []float64, b blas64.General) {
+	lapack64.Dpttrs(len(d), b.Cols, d, e, b.Data, b.Stride)
+}
+
+// Sbev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric band matrix A.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Sbev will panic otherwise.
+//
+// On return, a is overwritten by values generated during the reduction to
+// tridiagonal form. If jobz == lapack.ComputeEV, z contains the orthonormal
+// eigenvectors of A on return, with the i-th column of z holding the
+// eigenvector associated with w[i]. If jobz == lapack.None, z is not
+// referenced.
+//
+// work is temporary storage and must have length at least max(1,3*n-2),
+// otherwise Sbev will panic.
+//
+// Sbev returns whether the computation succeeded.
+func Sbev(jobz lapack.EVJob, a blas64.SymmetricBand, w []float64, z blas64.General, work []float64) (ok bool) {
+	return lapack64.Dsbev(jobz, a.Uplo, a.N, a.K, a.Data, a.Stride, w, z.Data, z.Stride, work)
+}
+
+// Sfrk performs one of the symmetric rank-k operations
+//  C = alpha * A * A^T + beta * C  if trans == blas.NoTrans,
+//  C = alpha * A^T * A + beta * C  if trans == blas.Trans,
+// where alpha and beta are scalars, C is an n×n symmetric matrix stored in
+// rectangular full packed format and A is an n×k matrix if
+// trans == blas.NoTrans and a k×n matrix otherwise.
+func Sfrk(trans blas.Transpose, alpha float64, a blas64.General, beta float64, c SymmetricRFP) {
+	k := a.Cols
+	if trans != blas.NoTrans {
+		k = a.Rows
+	}
+	lapack64.Dsfrk(c.TransR, c.Uplo, trans, c.N, k, alpha, a.Data, a.Stride, beta, c.Data)
+}
+
+// Sgesv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n matrix and X and B are n×nrhs matrices, using an LU
+// factorization computed in single precision followed by iterative
+// refinement to double precision accuracy. If the refinement fails, the
+// system is solved with a double precision factorization instead.
+//
+// b is not modified and x contains the solution on return. If the iterative
+// refinement was successful, a is not modified. Otherwise, a and ipiv
+// contain the double precision LU factorization of A.
+//
+// work must have length at least n*nrhs and swork must have length at least
+// n*(n+nrhs), otherwise Sgesv will panic.
+//
+// Sgesv returns the number of refinement iterations in iter. A negative iter
+// indicates that the double precision factorization was used. ok is false if
+// A is exactly singular, in which case the solution has not been computed.
+func Sgesv(a blas64.General, ipiv []int, b, x blas64.General, work []float64, swork []float32) (iter int, ok bool) {
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	return lapack64.Dsgesv(a.Cols, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride, work, swork)
+}
+
+// Spev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric matrix A stored in packed format.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Spev will panic otherwise.
+//
+// On return, a is overwritten by values generated during the reduction to
+// tridiagonal form. If jobz == lapack.ComputeEV, z contains the orthonormal
+// eigenvectors of A on return, with the i-th column of z holding the
+// eigenvector associated with w[i]. If jobz == lapack.None, z is not
+// referenced.
+//
+// work is temporary storage and must have length at least 3*n, otherwise
+// Spev will panic.
+//
+// Spev returns whether the computation succeeded.
+func Spev(jobz lapack.EVJob, a blas64.SymmetricPacked, w []float64, z blas64.General, work []float64) (ok bool) {
+	return lapack64.Dspev(jobz, a.Uplo, a.N, a.Data, w, z.Data, z.Stride, work)
+}
+
+// Sposv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices, using a Cholesky factorization computed in single precision
+// followed by iterative refinement to double precision accuracy. If the
+// refinement fails, the system is solved with a double precision
+// factorization instead.
+//
+// b is not modified and x contains the solution on return. If the iterative
+// refinement was successful, a is not modified. Otherwise, a contains the
+// double precision Cholesky factorization of A.
+//
+// work must have length at least n*nrhs and swork must have length at least
+// n*(n+nrhs), otherwise Sposv will panic.
+//
+// Sposv returns the number of refinement iterations in iter. A negative iter
+// indicates that the double precision factorization was used. ok is false if
+// A is not positive definite, in which case the solution has not been
+// computed.
+func Sposv(a blas64.Symmetric, b, x blas64.General, work []float64, swork []float32) (iter int, ok bool) {
+	if b.Cols != x.Cols {
+		panic("lapack64: mismatched number of right hand sides")
+	}
+	return lapack64.Dsposv(a.Uplo, a.N, b.Cols, a.Data, a.Stride, b.Data, b.Stride, x.Data, x.Stride, work, swork)
+}
+
+// Sptrf computes the factorization of a real symmetric indefinite matrix A
+// stored in packed format using the Bunch-Kaufman diagonal pivoting method.
+// The form of the factorization is
+//  A = U*D*U^T  if a.Uplo == blas.Upper,
+//  A = L*D*L^T  if a.Uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks. On return, a and ipiv contain the details of D and of the factor U
+// or L as described in the documentation of native.Implementation.Dsptrf.
+// ipiv must have length at least n.
+//
+// Sptrf returns whether D is nonsingular.
+func Sptrf(a blas64.SymmetricPacked, ipiv []int) (ok bool) {
+	return lapack64.Dsptrf(a.Uplo, a.N, a.Data, ipiv)
+}
+
+// Sycon estimates the reciprocal of the condition number in the 1-norm of a
+// symmetric indefinite matrix A using the factorization computed by Sytrf.
+//
+// a and ipiv must contain the factorization of A as returned by Sytrf. anorm
+// must be the 1-norm of the original matrix A.
+//
+// work must have length at least 2*n and iwork must have length at least n,
+// otherwise Sycon will panic.
+func Sycon(a blas64.Symmetric, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return lapack64.Dsycon(a.Uplo, a.N, a.Data, a.Stride, ipiv, anorm, work, iwork)
+}
+
 // Syev computes all eigenvalues and, optionally, the eigenvectors of a real
 // symmetric matrix A.
 //
@@ -475,6 +1498,383 @@ func Syev(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int) (
 	return lapack64.Dsyev(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork)
 }
 
+// Syevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A. If eigenvectors are desired, it uses a divide and conquer
+// algorithm.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Syevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. Syevd will panic if
+// these conditions are not met. If lwork == -1 or liwork == -1, instead of
+// computing Syevd the optimal work length is stored into work[0] and the
+// minimum iwork length is stored into iwork[0].
+func Syevd(jobz lapack.EVJob, a blas64.Symmetric, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	return lapack64.Dsyevd(jobz, a.Uplo, a.N, a.Data, a.Stride, w, work, lwork, iwork, liwork)
+}
+
+// Syevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A using the Multiple Relatively Robust Representations
+// algorithm. Once A has been reduced to tridiagonal form, k eigenpairs are
+// computed in O(n*k) operations.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by a.Uplo. On return, this triangular portion,
+// including the diagonal, is overwritten. abstol is the absolute error
+// tolerance for the eigenvalues.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n. If jobz ==
+// lapack.ComputeEV, the first m columns of z contain the orthonormal
+// eigenvectors of A, the i-th column corresponding to w[i], and the support
+// of the i-th eigenvector is stored in isuppz[2*i] through isuppz[2*i+1]. z
+// must have at least iu-il+1 columns if rng is lapack.RangeIndex and n
+// columns otherwise, and isuppz must have length at least 2*z.Cols.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. lwork must be at least max(1,26*n) and liwork must be at
+// least max(1,10*n), and Syevr will panic otherwise. If lwork == -1 or
+// liwork == -1, instead of computing Syevr the optimal work length is stored
+// into work[0] and the minimum iwork length is stored into iwork[0].
+//
+// Syevr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func Syevr(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, abstol float64, w []float64, z blas64.General, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	return lapack64.Dsyevr(jobz, rng, a.Uplo, a.N, a.Data, a.Stride, vl, vu, il, iu, abstol, w, z.Data, z.Stride, isuppz, work, lwork, iwork, liwork)
+}
+
+// Syevx computes selected eigenvalues and, optionally, eigenvectors of the
+// symmetric n×n matrix A using bisection and inverse iteration.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by a.Uplo. On return, this triangular portion,
+// including the diagonal, is overwritten. abstol is the absolute error
+// tolerance for the eigenvalues. If abstol is not positive, eps times the
+// 1-norm of the tridiagonal form of A is used.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n. If jobz ==
+// lapack.ComputeEV, the first m columns of z contain the orthonormal
+// eigenvectors of A, the i-th column corresponding to w[i]. z must have at
+// least iu-il+1 columns if rng is lapack.RangeIndex and n columns otherwise.
+// ifail[:m] holds the indices of the eigenvectors that failed to converge,
+// followed by -1, and ifail must have length at least n.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n), and Syevx will panic otherwise. If
+// lwork == -1, instead of computing Syevx the optimal work length is stored
+// into work[0]. iwork must have length at least 5*n.
+//
+// Syevx returns the number of eigenvalues found and whether the computation
+// succeeded.
+func Syevx(jobz lapack.EVJob, rng lapack.EVRange, a blas64.Symmetric, vl, vu float64, il, iu int, abstol float64, w []float64, z blas64.General, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	return lapack64.Dsyevx(jobz, rng, a.Uplo, a.N, a.Data, a.Stride, vl, vu, il, iu, abstol, w, z.Data, z.Stride, work, lwork, iwork, ifail)
+}
+
+// Sygv computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form
+//  A*x = lambda*B*x   if itype == 1,
+//  A*B*x = lambda*x   if itype == 2,
+//  B*A*x = lambda*x   if itype == 3,
+// where A and B are n×n symmetric matrices and B is also positive definite.
+// a and b must use the same triangle, otherwise Sygv will panic.
+//
+// w contains the eigenvalues in ascending order upon return. If jobz ==
+// lapack.ComputeEV, a contains on return the eigenvectors normalized so that
+// Z^T*B*Z = I if itype == 1 or 2, and Z^T*inv(B)*Z = I if itype == 3. On
+// return, b contains the Cholesky factor of B.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,3*n-1), and Sygv will panic otherwise. If
+// lwork == -1, instead of computing Sygv the optimal work length is stored
+// into work[0].
+//
+// Sygv returns whether the computation succeeded. If ok is false, either B is
+// not positive definite or the eigenvalue computation did not converge.
+func Sygv(itype int, jobz lapack.EVJob, a, b blas64.Symmetric, w, work []float64, lwork int) (ok bool) {
+	if a.Uplo != b.Uplo {
+		panic("lapack64: a.Uplo != b.Uplo")
+	}
+	return lapack64.Dsygv(itype, jobz, a.Uplo, a.N, a.Data, a.Stride, b.Data, b.Stride, w, work, lwork)
+}
+
+// Sygvd computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem. It has the same interface as
+// Sygv but uses a divide and conquer algorithm if eigenvectors are desired.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. Sygvd will panic if
+// these conditions are not met. If lwork == -1 or liwork == -1, instead of
+// computing Sygvd the optimal work length is stored into work[0] and the
+// minimum iwork length is stored into iwork[0].
+func Sygvd(itype int, jobz lapack.EVJob, a, b blas64.Symmetric, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	if a.Uplo != b.Uplo {
+		panic("lapack64: a.Uplo != b.Uplo")
+	}
+	return lapack64.Dsygvd(itype, jobz, a.Uplo, a.N, a.Data, a.Stride, b.Data, b.Stride, w, work, lwork, iwork, liwork)
+}
+
+// Sygvx computes selected eigenvalues and, optionally, eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form given in the
+// documentation of Sygv. a and b must use the same triangle, otherwise Sygvx
+// will panic.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n. If jobz ==
+// lapack.ComputeEV, the first m columns of z contain the eigenvectors,
+// normalized as in Sygv, the i-th column corresponding to w[i]. z must have
+// at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise. ifail[:m] holds the indices of the eigenvectors that failed to
+// converge, followed by -1, and ifail must have length at least n.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n), and Sygvx will panic otherwise. If
+// lwork == -1, instead of computing Sygvx the optimal work length is stored
+// into work[0]. iwork must have length at least 5*n.
+//
+// Sygvx returns the number of eigenvalues found and whether the computation
+// succeeded.
+func Sygvx(itype int, jobz lapack.EVJob, rng lapack.EVRange, a, b blas64.Symmetric, vl, vu float64, il, iu int, abstol float64, w []float64, z blas64.General, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	if a.Uplo != b.Uplo {
+		panic("lapack64: a.Uplo != b.Uplo")
+	}
+	return lapack64.Dsygvx(itype, jobz, rng, a.Uplo, a.N, a.Data, a.Stride, b.Data, b.Stride, vl, vu, il, iu, abstol, w, z.Data, z.Stride, work, lwork, iwork, ifail)
+}
+
+// Sysv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric indefinite matrix and X and B are n×nrhs
+// matrices. The factorization A = U*D*U^T or A = L*D*L^T is computed by Sytrf
+// and used to solve the system.
+//
+// On return, a and ipiv contain the factorization of A as returned by Sytrf
+// and b contains the solution matrix X.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Sysv will panic otherwise. If lwork == -1, instead
+// of computing Sysv the optimal work length is stored into work[0].
+//
+// Sysv returns whether the block diagonal matrix D is nonsingular. If ok is
+// false, the solution has not been computed.
+func Sysv(a blas64.Symmetric, ipiv []int, b blas64.General, work []float64, lwork int) (ok bool) {
+	return lapack64.Dsysv(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride, work, lwork)
+}
+
+// SysvRook computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric indefinite matrix and X and B are n×nrhs
+// matrices, using the factorization of A computed by SytrfRook.
+//
+// On return, a and ipiv contain the factorization of A as returned by
+// SytrfRook and b contains the solution matrix X.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and SysvRook will panic otherwise. If lwork == -1,
+// instead of computing SysvRook the optimal work length is stored into work[0].
+//
+// SysvRook returns whether the block diagonal matrix D is nonsingular. If ok
+// is false, the solution has not been computed.
+func SysvRook(a blas64.Symmetric, ipiv []int, b blas64.General, work []float64, lwork int) (ok bool) {
+	return lapack64.DsysvRook(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride, work, lwork)
+}
+
+// Sytrf computes the factorization of a real symmetric indefinite matrix A
+// using the Bunch-Kaufman diagonal pivoting method. The form of the
+// factorization is
+//  A = U*D*U^T  if a.Uplo == blas.Upper,
+//  A = L*D*L^T  if a.Uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower) triangular
+// matrices, and D is symmetric and block diagonal with 1×1 and 2×2 diagonal
+// blocks. On return, a and ipiv contain the details of D and of the factor U
+// or L as described in the documentation of native.Implementation.Dsytrf.
+// ipiv must have length at least n.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Sytrf will panic otherwise. If lwork == -1, instead
+// of computing Sytrf the optimal work length is stored into work[0].
+//
+// Sytrf returns whether D is nonsingular. The number of positive, negative and
+// zero eigenvalues of A can be obtained from the factorization by Inertia.
+func Sytrf(a blas64.Symmetric, ipiv []int, work []float64, lwork int) (ok bool) {
+	return lapack64.Dsytrf(a.Uplo, a.N, a.Data, a.Stride, ipiv, work, lwork)
+}
+
+// SytrfRook computes the factorization of a real symmetric indefinite matrix A
+// using the bounded Bunch-Kaufman ("rook") diagonal pivoting method. The form
+// of the factorization is the same as for Sytrf, but the elements of U (or L)
+// are bounded in magnitude and the pivot indices in ipiv are stored as
+// described in the documentation of native.Implementation.DsytrfRook. ipiv
+// must have length at least n.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and SytrfRook will panic otherwise. If lwork == -1,
+// instead of computing SytrfRook the optimal work length is stored into
+// work[0].
+//
+// SytrfRook returns whether D is nonsingular.
+func SytrfRook(a blas64.Symmetric, ipiv []int, work []float64, lwork int) (ok bool) {
+	return lapack64.DsytrfRook(a.Uplo, a.N, a.Data, a.Stride, ipiv, work, lwork)
+}
+
+// Inertia returns the inertia of a real symmetric matrix A, that is the number
+// of its positive, negative and zero eigenvalues, computed from the block
+// diagonal matrix D of the factorization of A as returned by Sytrf or
+// SytrfRook. By Sylvester's law of inertia, A and D have the same inertia.
+//
+// Each 2×2 diagonal block of D computed by the diagonal pivoting methods has a
+// negative determinant, and so contributes one positive and one negative
+// eigenvalue. The zero count is exact only for exactly singular D; a nearly
+// singular A may be reported as having no zero eigenvalues.
+//
+// ipiv must have length at least n, otherwise Inertia will panic.
+func Inertia(a blas64.Symmetric, ipiv []int) (pos, neg, zero int) {
+	n := a.N
+	if len(ipiv) < n {
+		panic("lapack64: insufficient ipiv length")
+	}
+	for k := 0; k < n; {
+		if ipiv[k] < 0 {
+			// 2×2 diagonal block. The pivot indices of both of its rows
+			// are negative.
+			pos++
+			neg++
+			k += 2
+			continue
+		}
+		switch d := a.Data[k*a.Stride+k]; {
+		case d > 0:
+			pos++
+		case d < 0:
+			neg++
+		default:
+			zero++
+		}
+		k++
+	}
+	return pos, neg, zero
+}
+
+// Sytri computes the inverse of a real symmetric indefinite matrix A using its
+// factorization as computed by Sytrf. On return, the upper or lower triangle
+// of the (symmetric) inverse of A is stored in a, overwriting the factors.
+//
+// ipiv must have length at least n and work must have length at least n,
+// otherwise Sytri will panic.
+//
+// Sytri returns whether A is invertible.
+func Sytri(a blas64.Symmetric, ipiv []int, work []float64) (ok bool) {
+	return lapack64.Dsytri(a.Uplo, a.N, a.Data, a.Stride, ipiv, work)
+}
+
+// Sytrs solves a system of linear equations A*X = B with a real symmetric
+// indefinite matrix A using its factorization as computed by Sytrf. On entry,
+// b contains the right-hand side matrix B, on return it contains the solution
+// matrix X.
+func Sytrs(a blas64.Symmetric, ipiv []int, b blas64.General) {
+	lapack64.Dsytrs(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// SytrsRook solves a system of linear equations A*X = B with a real symmetric
+// indefinite matrix A using its factorization as computed by SytrfRook. On
+// entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func SytrsRook(a blas64.Symmetric, ipiv []int, b blas64.General) {
+	lapack64.DsytrsRook(a.Uplo, a.N, b.Cols, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+}
+
+// Tftri computes the inverse of a triangular matrix A stored in rectangular
+// full packed format. On return, t contains the inverse of A in the same
+// format. The returned bool indicates whether A is nonsingular and the inverse
+// was computed.
+func Tftri(t TriangularRFP) (ok bool) {
+	return lapack64.Dtftri(t.TransR, t.Uplo, t.Diag, t.N, t.Data)
+}
+
+// Tfttr copies the triangular matrix a stored in rectangular full packed
+// format into b in conventional format. b must have the same size and the
+// same triangle as a.
+func Tfttr(a TriangularRFP, b blas64.Triangular) {
+	lapack64.Dtfttr(a.TransR, a.Uplo, a.N, a.Data, b.Data, b.Stride)
+}
+
+// Tpttf copies the triangular matrix a stored in packed format into arf in
+// rectangular full packed format with the RFP array transposed if
+// transr == blas.Trans. The matrix in arf is returned in t.
+func Tpttf(transr blas.Transpose, a blas64.TriangularPacked, arf []float64) (t TriangularRFP) {
+	lapack64.Dtpttf(transr, a.Uplo, a.N, a.Data, arf)
+	t.N = a.N
+	t.TransR = transr
+	t.Uplo = a.Uplo
+	t.Diag = a.Diag
+	t.Data = arf
+	return t
+}
+
+// Tgsen reorders the generalized real Schur factorization (A,B) = Q*(S,T)*Z^T
+// so that the eigenvalues given by selected appear in the leading diagonal
+// blocks of (S,T), and optionally computes estimates of the reciprocal
+// condition numbers of the selected cluster of eigenvalues (pl and pr) and of
+// the corresponding deflating subspaces (difu and difl) as specified by ijob.
+// m is the dimension of the deflating subspaces.
+//
+// On entry, a and b must contain S and T. If wantq is true, the left Schur
+// vectors in q are updated, otherwise q is not referenced. If wantz is true,
+// the right Schur vectors in z are updated, otherwise z is not referenced.
+// See the documentation of Dtgsen for the requirements on ijob, work and
+// iwork.
+//
+// Tgsen returns whether the reordering was successful. If ok is false, some
+// selected eigenvalues were too close to unselected ones to be swapped.
+func Tgsen(ijob int, wantq, wantz bool, selected []bool, a, b, q, z blas64.General, alphar, alphai, beta, work []float64, lwork int, iwork []int, liwork int) (m int, pl, pr, difu, difl float64, ok bool) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if b.Rows != n || b.Cols != n {
+		panic("lapack64: bad size of B")
+	}
+	if wantq && (q.Rows != n || q.Cols != n) {
+		panic("lapack64: bad size of Q")
+	}
+	if wantz && (z.Rows != n || z.Cols != n) {
+		panic("lapack64: bad size of Z")
+	}
+	return lapack64.Dtgsen(ijob, wantq, wantz, selected, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, lwork, iwork, liwork)
+}
+
 // Trcon estimates the reciprocal of the condition number of a triangular matrix A.
 // The condition number computed may be based on the 1-norm or the ∞-norm.
 //
@@ -485,6 +1885,53 @@ func Trcon(norm lapack.MatrixNorm, a blas64.Triangular, work []float64, iwork []
 	return lapack64.Dtrcon(norm, a.Uplo, a.Diag, a.N, a.Data, a.Stride, work, iwork)
 }
 
+// Trsen reorders the real Schur factorization A = Q*T*Q^T so that the
+// eigenvalues given by selected appear in the leading diagonal blocks of the
+// upper quasi-triangular matrix T, and optionally computes the reciprocal
+// condition numbers s and sep of the selected cluster of eigenvalues and of
+// the corresponding invariant subspace. m is the dimension of the invariant
+// subspace.
+//
+// If compq == lapack.UpdateSchur, the Schur vectors in q are updated,
+// otherwise compq must be lapack.None and q is not referenced. See the
+// documentation of Dtrsen for the requirements on job, work and iwork.
+//
+// Trsen returns whether the reordering was successful. If ok is false, some
+// selected eigenvalues were too close to unselected ones to be swapped.
+func Trsen(job lapack.CondJob, compq lapack.EVComp, selected []bool, t, q blas64.General, wr, wi, work []float64, lwork int, iwork []int, liwork int) (m int, s, sep float64, ok bool) {
+	n := t.Rows
+	if t.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if compq == lapack.UpdateSchur && (q.Rows != n || q.Cols != n) {
+		panic("lapack64: bad size of Q")
+	}
+	return lapack64.Dtrsen(job, compq, selected, n, t.Data, t.Stride, q.Data, q.Stride, wr, wi, work, lwork, iwork, liwork)
+}
+
+// Trsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where A and B are upper quasi-triangular matrices in Schur canonical form,
+// op(A) is A or A^T as specified by trana, and op(B) is B or B^T as specified
+// by tranb. isgn must be 1 or -1. On return, c is overwritten by the solution
+// X.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X. Trsyl returns whether the equation is nonsingular. If ok is
+// false, A and -isgn*B have common or very close eigenvalues and perturbed
+// values were used to solve the equation.
+func Trsyl(trana, tranb blas.Transpose, isgn int, a, b, c blas64.General) (scale float64, ok bool) {
+	m := a.Rows
+	n := b.Rows
+	if a.Cols != m || b.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if c.Rows != m || c.Cols != n {
+		panic("lapack64: bad size of C")
+	}
+	return lapack64.Dtrsyl(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride)
+}
+
 // Trtri computes the inverse of a triangular matrix, storing the result in place
 // into a.
 //
@@ -500,6 +1947,19 @@ func Trtrs(trans blas.Transpose, a blas64.Triangular, b blas64.General) (ok bool
 	return lapack64.Dtrtrs(a.Uplo, trans, a.Diag, a.N, b.Cols, a.Data, a.Stride, b.Data, b.Stride)
 }
 
+// Trttf copies the triangular matrix a stored in conventional format into arf
+// in rectangular full packed format with the RFP array transposed if
+// transr == blas.Trans. The matrix in arf is returned in t.
+func Trttf(transr blas.Transpose, a blas64.Triangular, arf []float64) (t TriangularRFP) {
+	lapack64.Dtrttf(transr, a.Uplo, a.N, a.Data, a.Stride, arf)
+	t.N = a.N
+	t.TransR = transr
+	t.Uplo = a.Uplo
+	t.Diag = a.Diag
+	t.Data = arf
+	return t
+}
+
 // Geev computes the eigenvalues and, optionally, the left and/or right
 // eigenvectors for an n×n real nonsymmetric matrix A.
 //
@@ -561,3 +2021,104 @@ func Geev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a blas64.General, wr,
 	}
 	return lapack64.Dgeev(jobvl, jobvr, n, a.Data, a.Stride, wr, wi, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
 }
+
+// Geevx computes the eigenvalues and, optionally, the left and/or right
+// eigenvectors for an n×n real nonsymmetric matrix A like Geev. In addition,
+// balanc gives explicit control over the balancing of A, and the reciprocal
+// condition numbers of the eigenvalues and of the right eigenvectors can be
+// computed.
+//
+// balanc must be one of lapack.None, lapack.Permute, lapack.Scale or
+// lapack.PermuteScale. sense must be one of lapack.None,
+// lapack.CondEigenvalues, lapack.CondSubspace or lapack.CondBoth, and if it is
+// lapack.CondEigenvalues or lapack.CondBoth, both left and right eigenvectors
+// must be computed.
+//
+// On return, ilo, ihi and scale describe the balancing of A as in Dgebal, abnrm
+// is the 1-norm of the balanced matrix, and rconde[j] and rcondv[j] contain the
+// reciprocal condition numbers of the j-th eigenvalue and right eigenvector,
+// respectively, if they were requested. See the documentation of Dgeevx for
+// the requirements on scale, rconde, rcondv, work and iwork.
+//
+// On return, first will be the index of the first valid eigenvalue.
+// If first == 0, all eigenvalues and eigenvectors have been computed.
+// If first is positive, Geevx failed to compute all the eigenvalues, no
+// eigenvectors or condition numbers have been computed and wr[first:] and
+// wi[first:] contain those eigenvalues which have converged.
+func Geevx(balanc lapack.Job, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, sense lapack.CondJob, a blas64.General, wr, wi []float64, vl, vr blas64.General, scale, rconde, rcondv, work []float64, lwork int, iwork []int) (ilo, ihi int, abnrm float64, first int) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if jobvl == lapack.ComputeLeftEV && (vl.Rows != n || vl.Cols != n) {
+		panic("lapack64: bad size of VL")
+	}
+	if jobvr == lapack.ComputeRightEV && (vr.Rows != n || vr.Cols != n) {
+		panic("lapack64: bad size of VR")
+	}
+	return lapack64.Dgeevx(balanc, jobvl, jobvr, sense, n, a.Data, a.Stride, wr, wi, vl.Data, vl.Stride, vr.Data, vr.Stride, scale, rconde, rcondv, work, lwork, iwork)
+}
+
+// Ggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// The right generalized eigenvector v_j corresponding to the generalized
+// eigenvalue λ_j = α_j/β_j of (A,B) satisfies
+//  β_j A v_j = α_j B v_j,
+// and the left generalized eigenvector u_j satisfies
+//  β_j u_j^H A = α_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR
+// in the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that the
+// largest component has |real part| + |imag. part| = 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None.
+// Right eigenvectors will be computed only if jobvr == lapack.ComputeRightEV,
+// otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Ggev will panic.
+//
+// On return, alphar[j] + i*alphai[j] and beta[j] will be the generalized
+// eigenvalues. Complex conjugate pairs of eigenvalues appear consecutively
+// with the eigenvalue having the positive imaginary part first. alphar, alphai
+// and beta must have length n, and Ggev will panic otherwise. The ratio
+// α_j/β_j may easily over- or underflow and β_j may even be zero, so it should
+// not be computed naively.
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n).
+// For good performance, lwork must generally be larger. On return, optimal
+// value of lwork will be stored in work[0].
+//
+// If lwork == -1, instead of performing Ggev, the function only calculates the
+// optimal value of lwork and stores it into work[0].
+//
+// Ggev returns whether all the eigenvalues and requested eigenvectors have
+// been computed successfully.
+func Ggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, a, b blas64.General, alphar, alphai, beta []float64, vl, vr blas64.General, work []float64, lwork int) (ok bool) {
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if b.Rows != n || b.Cols != n {
+		panic("lapack64: bad size of B")
+	}
+	if jobvl == lapack.ComputeLeftEV && (vl.Rows != n || vl.Cols != n) {
+		panic("lapack64: bad size of VL")
+	}
+	if jobvr == lapack.ComputeRightEV && (vr.Rows != n || vr.Cols != n) {
+		panic("lapack64: bad size of VR")
+	}
+	return lapack64.Dggev3(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta, vl.Data, vl.Stride, vr.Data, vr.Stride, work, lwork)
+}
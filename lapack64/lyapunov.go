@@ -0,0 +1,359 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack64
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// ContinuousLyapunov solves the continuous-time Lyapunov equation
+//  op(A)^T*X + X*op(A) = scale*C,
+// where A, C and X are n×n matrices and op(A) is A if trans is blas.NoTrans
+// and A^T if trans is blas.Trans, using the Bartels-Stewart method. A is
+// reduced to real Schur form
+//  A = U*S*U^T
+// by Dgehrd, Dorghr and Dhseqr, the transformed equation
+//  op(S)^T*Y + Y*op(S) = scale*U^T*C*U
+// is solved by Dtrsyl, and the solution is transformed back as X = U*Y*U^T.
+//
+// A is not modified. On entry, c must contain the right-hand side C and on
+// return it is overwritten by the solution X. If C is symmetric, so is X up to
+// rounding errors.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X.
+//
+// work must have length at least lwork, and lwork must be at least
+// max(1,3*n*n+3*n), otherwise ContinuousLyapunov will panic. For good
+// performance lwork must generally be larger. If lwork is -1, instead of
+// solving the equation, the optimal value of lwork is stored into work[0].
+//
+// ok is false if the Schur form of A could not be computed, in which case C is
+// not modified, or if A has eigenvalues λ_i and λ_j with λ_i + λ_j (close to)
+// zero, in which case the equation is singular and perturbed values were used
+// to solve it.
+func ContinuousLyapunov(trans blas.Transpose, a, c blas64.General, work []float64, lwork int) (scale float64, ok bool) {
+	n := checkLyapunov(trans, a, c)
+	if lwork == -1 {
+		work[0] = float64(lyapunovWork(a, work))
+		return 1, true
+	}
+	s, u, rest, ok := lyapunovSchur(a, c, work, lwork)
+	if !ok {
+		return 1, false
+	}
+	if n == 0 {
+		return 1, true
+	}
+
+	// Solve op(S)^T*Y + Y*op(S) = scale*F.
+	trana := blas.Trans
+	tranb := blas.NoTrans
+	if trans != blas.NoTrans {
+		trana, tranb = tranb, trana
+	}
+	scale, ok = lapack64.Dtrsyl(trana, tranb, 1, n, n, s.Data, s.Stride, s.Data, s.Stride, c.Data, c.Stride)
+
+	lyapunovBack(u, c, rest)
+	return scale, ok
+}
+
+// DiscreteLyapunov solves the discrete-time Lyapunov (Stein) equation
+//  op(A)^T*X*op(A) - X = scale*C,
+// where A, C and X are n×n matrices and op(A) is A if trans is blas.NoTrans
+// and A^T if trans is blas.Trans, using the Bartels-Stewart method. A is
+// reduced to real Schur form
+//  A = U*S*U^T
+// by Dgehrd, Dorghr and Dhseqr, the transformed equation
+//  op(S)^T*Y*op(S) - Y = scale*U^T*C*U
+// is solved by forward substitution over the diagonal blocks of S, and the
+// solution is transformed back as X = U*Y*U^T.
+//
+// A is not modified. On entry, c must contain the right-hand side C and on
+// return it is overwritten by the solution X. If C is symmetric, so is X up to
+// rounding errors.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X.
+//
+// work must have length at least lwork, and lwork must be at least
+// max(1,3*n*n+3*n), otherwise DiscreteLyapunov will panic. For good
+// performance lwork must generally be larger. If lwork is -1, instead of
+// solving the equation, the optimal value of lwork is stored into work[0].
+//
+// ok is false if the Schur form of A could not be computed, in which case C is
+// not modified, or if A has eigenvalues λ_i and λ_j with λ_i*λ_j (close to)
+// one, in which case the equation is singular and perturbed values were used
+// to solve it.
+func DiscreteLyapunov(trans blas.Transpose, a, c blas64.General, work []float64, lwork int) (scale float64, ok bool) {
+	n := checkLyapunov(trans, a, c)
+	if lwork == -1 {
+		work[0] = float64(lyapunovWork(a, work))
+		return 1, true
+	}
+	s, u, rest, ok := lyapunovSchur(a, c, work, lwork)
+	if !ok {
+		return 1, false
+	}
+	if n == 0 {
+		return 1, true
+	}
+
+	if trans == blas.NoTrans {
+		// Solve S^T*Y*S - Y = scale*F.
+		scale, ok = steinSchur(n, s.Data, s.Stride, c.Data, c.Stride, rest)
+	} else {
+		// The equation S*Y*S^T - Y = scale*F is equivalent to
+		//  T^T*(J*Y*J)*T - J*Y*J = scale*J*F*J,
+		// where J is the reversal permutation and T = J*S^T*J is again
+		// upper quasi-triangular.
+		antiTranspose(n, s.Data, s.Stride)
+		reverse(n, c.Data, c.Stride)
+		scale, ok = steinSchur(n, s.Data, s.Stride, c.Data, c.Stride, rest)
+		reverse(n, c.Data, c.Stride)
+	}
+
+	lyapunovBack(u, c, rest)
+	return scale, ok
+}
+
+// checkLyapunov checks the arguments of the Lyapunov solvers and returns the
+// order of A.
+func checkLyapunov(trans blas.Transpose, a, c blas64.General) int {
+	if trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans {
+		panic("lapack64: bad trans")
+	}
+	n := a.Rows
+	if a.Cols != n {
+		panic("lapack64: matrix not square")
+	}
+	if c.Rows != n || c.Cols != n {
+		panic("lapack64: bad size of C")
+	}
+	return n
+}
+
+// lyapunovWork returns the optimal length of the workspace for the Lyapunov
+// solvers. work must have length at least 1.
+func lyapunovWork(a blas64.General, work []float64) int {
+	n := a.Rows
+	if n == 0 {
+		return 1
+	}
+	lwopt := n * n
+	lapack64.Dgehrd(n, 0, n-1, a.Data, a.Stride, nil, work, -1)
+	lwopt = max(lwopt, int(work[0]))
+	lapack64.Dorghr(n, 0, n-1, a.Data, a.Stride, nil, work, -1)
+	lwopt = max(lwopt, int(work[0]))
+	lapack64.Dhseqr(lapack.EigenvaluesAndSchur, lapack.OriginalEV, n, 0, n-1, a.Data, a.Stride, nil, nil, a.Data, a.Stride, work, -1)
+	lwopt = max(lwopt, int(work[0]))
+	return 2*n*n + 3*n + lwopt
+}
+
+// lyapunovSchur computes the real Schur factorization A = U*S*U^T and, if it
+// succeeds, overwrites C with U^T*C*U. S and U are stored in the leading
+// 2*n*n elements of work, and rest is the part of work that is not used by
+// them.
+func lyapunovSchur(a, c blas64.General, work []float64, lwork int) (s, u blas64.General, rest []float64, ok bool) {
+	n := a.Rows
+	if lwork < max(1, 3*n*n+3*n) || len(work) < lwork {
+		panic("lapack64: insufficient work")
+	}
+	if n == 0 {
+		return s, u, work, true
+	}
+
+	s = blas64.General{Rows: n, Cols: n, Stride: n, Data: work[:n*n]}
+	u = blas64.General{Rows: n, Cols: n, Stride: n, Data: work[n*n : 2*n*n]}
+	wr := work[2*n*n : 2*n*n+n]
+	wi := work[2*n*n+n : 2*n*n+2*n]
+	tau := work[2*n*n+2*n : 2*n*n+3*n-1]
+	rest = work[2*n*n+3*n : lwork]
+
+	// Reduce A to upper Hessenberg form and form the orthogonal matrix U
+	// of the reduction.
+	for i := 0; i < n; i++ {
+		copy(s.Data[i*n:i*n+n], a.Data[i*a.Stride:i*a.Stride+n])
+	}
+	lapack64.Dgehrd(n, 0, n-1, s.Data, n, tau, rest, len(rest))
+	copy(u.Data, s.Data)
+	lapack64.Dorghr(n, 0, n-1, u.Data, n, tau, rest, len(rest))
+
+	// Compute the real Schur form S of the Hessenberg matrix and
+	// accumulate the Schur vectors into U.
+	unconverged := lapack64.Dhseqr(lapack.EigenvaluesAndSchur, lapack.OriginalEV, n, 0, n-1, s.Data, n, wr, wi, u.Data, n, rest, len(rest))
+	if unconverged > 0 {
+		return s, u, rest, false
+	}
+
+	// Compute F = U^T*C*U.
+	tmp := blas64.General{Rows: n, Cols: n, Stride: n, Data: rest[:n*n]}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, c, u, 0, tmp)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, u, tmp, 0, c)
+	return s, u, rest, true
+}
+
+// lyapunovBack overwrites c with U*C*U^T. work must have length at least
+// n*n.
+func lyapunovBack(u, c blas64.General, work []float64) {
+	n := u.Rows
+	tmp := blas64.General{Rows: n, Cols: n, Stride: n, Data: work[:n*n]}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, u, c, 0, tmp)
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, u, 0, c)
+}
+
+// steinSchur solves the equation
+//  T^T*Y*T - Y = scale*F,
+// where T is an n×n upper quasi-triangular matrix in Schur form, by
+// forward substitution over the 1×1 and 2×2 diagonal blocks of T. On entry,
+// c contains F and on return it is overwritten by Y. work must have length at
+// least 2*n.
+//
+// The j-th block column of Y satisfies
+//  T^T*Y[:,j]*T[j,j] - Y[:,j] = scale*F[:,j] - T^T*(sum_{l<j} Y[:,l]*T[l,j]),
+// and the blocks of Y[:,j] are computed from the top down.
+func steinSchur(n int, t []float64, ldt int, c []float64, ldc int, work []float64) (scale float64, ok bool) {
+	// eps is the machine precision and smlnum is the smallest safe number
+	// divided by eps.
+	const (
+		eps    = 2.0 / (1 << 53)
+		smlnum = 1.0 / (1 << 256) / (1 << 256) / (1 << 256) / (1 << 254) / eps
+	)
+
+	bi := blas64.Implementation()
+
+	// blockSize returns the size of the diagonal block of T starting at
+	// row k.
+	blockSize := func(k int) int {
+		if k < n-1 && t[(k+1)*ldt+k] != 0 {
+			return 2
+		}
+		return 1
+	}
+
+	scale = 1
+	ok = true
+	for l1 := 0; l1 < n; {
+		nl := blockSize(l1)
+
+		if l1 > 0 {
+			// Compute P = Y[:,:l1]*T[:l1,l1:l1+nl] and update
+			//  F[:,l1:l1+nl] -= T^T*P.
+			p := work[:n*nl]
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nl, l1, 1, c, ldc, t[l1:], ldt, 0, p, nl)
+			bi.Dgemm(blas.Trans, blas.NoTrans, n, nl, n, -1, t, ldt, p, nl, 1, c[l1:], ldc)
+		}
+
+		for k1 := 0; k1 < n; {
+			nk := blockSize(k1)
+
+			// Compute the right-hand side
+			//  F[k,l] - (T[:k1,k]^T*Y[:k1,l])*T[l,l].
+			var rhs [4]float64
+			for i := 0; i < nk; i++ {
+				for j := 0; j < nl; j++ {
+					rhs[i*nl+j] = c[(k1+i)*ldc+l1+j]
+				}
+			}
+			if k1 > 0 {
+				var z [4]float64
+				bi.Dgemm(blas.Trans, blas.NoTrans, nk, nl, k1, 1, t[k1:], ldt, c[l1:], ldc, 0, z[:], nl)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, nk, nl, nl, -1, z[:], nl, t[l1*ldt+l1:], ldt, 1, rhs[:], nl)
+			}
+
+			// Solve T[k,k]^T*Y[k,l]*T[l,l] - Y[k,l] = rhs as a linear
+			// system of order nk*nl in the elements of Y[k,l].
+			d := nk * nl
+			var m [16]float64
+			var mmax float64
+			for r := 0; r < nk; r++ {
+				for s := 0; s < nl; s++ {
+					for p := 0; p < nk; p++ {
+						for q := 0; q < nl; q++ {
+							v := t[(k1+p)*ldt+k1+r] * t[(l1+q)*ldt+l1+s]
+							if p == r && q == s {
+								v--
+							}
+							m[(r*nl+s)*d+p*nl+q] = v
+							mmax = math.Max(mmax, math.Abs(v))
+						}
+					}
+				}
+			}
+			var ipiv [4]int
+			lapack64.Dgetrf(d, d, m[:], d, ipiv[:d])
+			// Replace small pivots so that a (nearly) singular
+			// system is solved for perturbed values.
+			smin := math.Max(eps*mmax, smlnum)
+			umin := math.Inf(1)
+			for i := 0; i < d; i++ {
+				if math.Abs(m[i*d+i]) < smin {
+					m[i*d+i] = smin
+					ok = false
+				}
+				umin = math.Min(umin, math.Abs(m[i*d+i]))
+			}
+			// Scale the right-hand side if the solution could
+			// overflow.
+			scaloc := 1.0
+			if bmax := math.Abs(rhs[bi.Idamax(d, rhs[:d], 1)]); 2*smlnum*bmax > umin {
+				scaloc = 0.5 / bmax
+				bi.Dscal(d, scaloc, rhs[:d], 1)
+			}
+			lapack64.Dgetrs(blas.NoTrans, d, 1, m[:], d, ipiv[:d], rhs[:d], 1)
+			if scaloc != 1 {
+				for i := 0; i < n; i++ {
+					bi.Dscal(n, scaloc, c[i*ldc:], 1)
+				}
+				scale *= scaloc
+			}
+			for i := 0; i < nk; i++ {
+				for j := 0; j < nl; j++ {
+					c[(k1+i)*ldc+l1+j] = rhs[i*nl+j]
+				}
+			}
+			k1 += nk
+		}
+		l1 += nl
+	}
+	return scale, ok
+}
+
+// antiTranspose overwrites the n×n matrix a with J*A^T*J, where J is the
+// reversal permutation.
+func antiTranspose(n int, a []float64, lda int) {
+	for i := 0; i < n; i++ {
+		for j := 0; j < n-1-i; j++ {
+			a[i*lda+j], a[(n-1-j)*lda+n-1-i] = a[(n-1-j)*lda+n-1-i], a[i*lda+j]
+		}
+	}
+}
+
+// reverse overwrites the n×n matrix a with J*A*J, where J is the reversal
+// permutation.
+func reverse(n int, a []float64, lda int) {
+	for i := 0; i < n/2; i++ {
+		for j := 0; j < n; j++ {
+			a[i*lda+j], a[(n-1-i)*lda+n-1-j] = a[(n-1-i)*lda+n-1-j], a[i*lda+j]
+		}
+	}
+	if n%2 == 1 {
+		row := a[(n/2)*lda : (n/2)*lda+n]
+		for j := 0; j < n/2; j++ {
+			row[j], row[n-1-j] = row[n-1-j], row[j]
+		}
+	}
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,230 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package lapack64
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+func TestContinuousLyapunov(t *testing.T) {
+	testLyapunov(t, "ContinuousLyapunov", ContinuousLyapunov, func(trans blas.Transpose, a, x, r blas64.General) {
+		// r = op(A)^T*X + X*op(A) - r.
+		if trans == blas.NoTrans {
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, a, x, -1, r)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, x, a, 1, r)
+		} else {
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, -1, r)
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, x, a, 1, r)
+		}
+	}, true)
+}
+
+func TestDiscreteLyapunov(t *testing.T) {
+	testLyapunov(t, "DiscreteLyapunov", DiscreteLyapunov, func(trans blas.Transpose, a, x, r blas64.General) {
+		// r = op(A)^T*X*op(A) - X - r.
+		n := a.Rows
+		tmp := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+		if trans == blas.NoTrans {
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, a, x, 0, tmp)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tmp, a, -1, r)
+		} else {
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, x, 0, tmp)
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmp, a, -1, r)
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				r.Data[i*r.Stride+j] -= x.Data[i*x.Stride+j]
+			}
+		}
+	}, false)
+}
+
+// testLyapunov checks the residual of the Lyapunov equation solved by solve.
+// residual must overwrite r with the residual of the equation for the
+// solution x and the right-hand side scale*C given in r. continuous specifies
+// whether solve solves the continuous-time or the discrete-time equation.
+func testLyapunov(t *testing.T, name string, solve func(blas.Transpose, blas64.General, blas64.General, []float64, int) (float64, bool), residual func(trans blas.Transpose, a, x, r blas64.General), continuous bool) {
+	const tol = 1e-12
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 60} {
+			for _, extra := range []int{0, 5} {
+				for _, wl := range []string{"minimum", "optimum"} {
+					// Generate a random matrix A with eigenvalues
+					// whose real parts are negative in the
+					// continuous case, and that lie inside the
+					// unit disc in the discrete case.
+					a := randomGeneral(n, n, n+extra, rnd)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							a.Data[i*a.Stride+j] /= 2 * math.Sqrt(float64(n))
+						}
+						if continuous {
+							a.Data[i*a.Stride+i] -= 2
+						}
+					}
+					aCopy := cloneGeneral(a)
+
+					// Generate a random symmetric right-hand side.
+					c := randomGeneral(n, n, n+extra, rnd)
+					for i := 0; i < n; i++ {
+						for j := 0; j < i; j++ {
+							c.Data[i*c.Stride+j] = c.Data[j*c.Stride+i]
+						}
+					}
+					cCopy := cloneGeneral(c)
+
+					var lwork int
+					switch wl {
+					case "minimum":
+						lwork = max(1, 3*n*n+3*n)
+					case "optimum":
+						work := make([]float64, 1)
+						solve(trans, a, c, work, -1)
+						lwork = int(work[0])
+					}
+					work := make([]float64, lwork)
+
+					prefix := fmt.Sprintf("%v: trans=%v, n=%v, extra=%v, work=%v", name, trans, n, extra, wl)
+
+					scale, ok := solve(trans, a, c, work, lwork)
+					if !ok {
+						t.Errorf("%v: unexpected failure", prefix)
+						continue
+					}
+					if scale <= 0 || 1 < scale {
+						t.Errorf("%v: scale out of range (0,1], got %v", prefix, scale)
+					}
+					if !equalGeneral(a, aCopy) {
+						t.Errorf("%v: unexpected modification of A", prefix)
+					}
+					if n == 0 {
+						continue
+					}
+
+					// Check that X is symmetric.
+					x := c
+					var asym float64
+					for i := 0; i < n; i++ {
+						for j := 0; j < i; j++ {
+							asym = math.Max(asym, math.Abs(x.Data[i*x.Stride+j]-x.Data[j*x.Stride+i]))
+						}
+					}
+					xnorm := maxAbs(x)
+					if asym > tol*math.Max(1, xnorm) {
+						t.Errorf("%v: X not symmetric, max|X-X^T|=%v", prefix, asym)
+					}
+
+					// Check the residual of the equation.
+					r := cloneGeneral(cCopy)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							r.Data[i*r.Stride+j] *= scale
+						}
+					}
+					residual(trans, aCopy, x, r)
+					anorm := maxAbs(aCopy)
+					resid := maxAbs(r) / math.Max(1, float64(n)*anorm*anorm*xnorm+xnorm+scale*maxAbs(cCopy))
+					if resid > tol {
+						t.Errorf("%v: unexpected residual %v", prefix, resid)
+					}
+				}
+			}
+		}
+	}
+}
+
+func randomGeneral(r, c, stride int, rnd *rand.Rand) blas64.General {
+	a := blas64.General{Rows: r, Cols: c, Stride: max(1, stride), Data: make([]float64, max(1, r)*max(1, stride))}
+	for i := range a.Data {
+		a.Data[i] = math.NaN()
+	}
+	for i := 0; i < r; i++ {
+		for j := 0; j < c; j++ {
+			a.Data[i*a.Stride+j] = rnd.NormFloat64()
+		}
+	}
+	return a
+}
+
+func cloneGeneral(a blas64.General) blas64.General {
+	b := a
+	b.Data = make([]float64, len(a.Data))
+	copy(b.Data, a.Data)
+	return b
+}
+
+// equalGeneral returns whether a and b have the same elements, including
+// the NaN padding outside of the matrix.
+func equalGeneral(a, b blas64.General) bool {
+	if len(a.Data) != len(b.Data) {
+		return false
+	}
+	for i, v := range a.Data {
+		if v != b.Data[i] && !(math.IsNaN(v) && math.IsNaN(b.Data[i])) {
+			return false
+		}
+	}
+	return true
+}
+
+// maxAbs returns the maximum absolute value of the elements of a.
+func maxAbs(a blas64.General) float64 {
+	var v float64
+	for i := 0; i < a.Rows; i++ {
+		for _, aij := range a.Data[i*a.Stride : i*a.Stride+a.Cols] {
+			v = math.Max(v, math.Abs(aij))
+		}
+	}
+	return v
+}
+
+// TestDiscreteLyapunovSingular checks that DiscreteLyapunov reports a
+// singular equation and still returns a finite solution.
+func TestDiscreteLyapunovSingular(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10} {
+		// A has the eigenvalue 1, so that the equation is singular.
+		a := randomGeneral(n, n, n, rnd)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if j < i {
+					a.Data[i*a.Stride+j] = 0
+				}
+				a.Data[i*a.Stride+j] /= 2 * math.Sqrt(float64(n))
+			}
+		}
+		a.Data[0] = 1
+		c := randomGeneral(n, n, n, rnd)
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				c.Data[i*c.Stride+j] = c.Data[j*c.Stride+i]
+			}
+		}
+		lwork := max(1, 3*n*n+3*n)
+		scale, ok := DiscreteLyapunov(blas.NoTrans, a, c, make([]float64, lwork), lwork)
+		if ok {
+			t.Errorf("n=%v: unexpected success for singular equation", n)
+		}
+		if scale <= 0 || 1 < scale {
+			t.Errorf("n=%v: scale out of range (0,1], got %v", n, scale)
+		}
+		for i := 0; i < n; i++ {
+			for _, v := range c.Data[i*c.Stride : i*c.Stride+n] {
+				if math.IsNaN(v) || math.IsInf(v, 0) {
+					t.Errorf("n=%v: solution not finite", n)
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dbdsdc computes the singular value decomposition of an n×n upper or lower
+// bidiagonal matrix B using a divide and conquer method,
+//  B = U * S * VT
+// where S is a diagonal matrix with non-negative diagonal elements (the
+// singular values of B), and U and VT are orthogonal matrices of left and
+// transposed right singular vectors. Dbdsdc is used by Dgesdd.
+//
+// The divide and conquer algorithm makes very mild assumptions about floating
+// point arithmetic. It will work on machines with a guard digit in
+// add/subtract, or on those binary machines without guard digits which
+// subtract like the Cray X-MP, Cray Y-MP, Cray C-90, or Cray-2.
+//
+// uplo specifies whether B is upper or lower bidiagonal. compq specifies
+// whether singular vectors are computed. If compq is lapack.None, only the
+// singular values are computed and u and vt are not referenced. If compq is
+// lapack.BidiagSV, the left and right singular vectors of B are computed and
+// stored in u and vt, which must be n×n.
+//
+// On entry, d contains the diagonal and e the off-diagonal elements of B. d
+// must have length at least n and e must have length at least n-1. On
+// return, d contains the singular values of B in decreasing order and e is
+// overwritten.
+//
+// work must have length at least 4*n if compq is lapack.None, and at least
+// 3*n*n+4*n if compq is lapack.BidiagSV. iwork must have length at least 8*n.
+//
+// Dbdsdc returns whether all the singular values were computed successfully.
+func (impl Implementation) Dbdsdc(uplo blas.Uplo, compq lapack.SVDComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	wantvec := compq == lapack.BidiagSV
+	if compq != lapack.None && !wantvec {
+		panic(badSVDComp)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if wantvec {
+		checkMatrix(n, n, u, ldu)
+		checkMatrix(n, n, vt, ldvt)
+		if len(work) < 3*n*n+4*n {
+			panic(badWork)
+		}
+	} else if len(work) < 4*n {
+		panic(badWork)
+	}
+	if len(iwork) < 8*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	smlsiz := impl.Ilaenv(9, "DBDSDC", " ", 0, 0, 0, 0)
+	if n == 1 {
+		if wantvec {
+			u[0] = math.Copysign(1, d[0])
+			vt[0] = 1
+		}
+		d[0] = math.Abs(d[0])
+		return true
+	}
+
+	// If the matrix is lower bidiagonal, rotate it to be upper bidiagonal by
+	// applying Givens rotations on the left. The rotations are stored in
+	// work so that they can be applied to u at the end.
+	wstart := 0
+	if uplo == blas.Lower {
+		if wantvec {
+			wstart = 2*n - 2
+		}
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if wantvec {
+				work[i] = cs
+				work[n-1+i] = -sn
+			}
+		}
+	}
+
+	bi := blas64.Implementation()
+	switch {
+	case !wantvec:
+		// Use Dlasdq to compute the singular values.
+		ok = impl.Dlasdq(blas.Upper, 0, n, 0, 0, 0, d, e, nil, 1, nil, 1, nil, 1, work)
+	case n <= smlsiz:
+		// If n is smaller than the minimum divide size smlsiz, solve the
+		// problem with another solver.
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+		ok = impl.Dlasdq(blas.Upper, 0, n, n, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work[wstart:])
+	default:
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, n, n, 0, 1, vt, ldvt)
+
+		// Scale.
+		orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+		if orgnrm == 0 {
+			return true
+		}
+		impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+		impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+		eps := 0.9 * dlamchE
+		for i, v := range d[:n] {
+			if math.Abs(v) < eps {
+				d[i] = math.Copysign(eps, v)
+			}
+		}
+
+		// Split the matrix at negligible off-diagonal elements and apply
+		// divide and conquer to each of the resulting subproblems.
+		start := 0
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) >= eps && i < n-2 {
+				continue
+			}
+			var nsize int
+			switch {
+			case i < n-2:
+				// A subproblem with e[i] small for i < n-2.
+				nsize = i - start + 1
+			case math.Abs(e[i]) >= eps:
+				// A subproblem with e[n-2] not too small but i == n-2.
+				nsize = n - start
+			default:
+				// A subproblem with e[n-2] small. This implies a 1×1
+				// subproblem at d[n-1]. Solve this 1×1 problem first.
+				nsize = i - start + 1
+				u[(n-1)*ldu+n-1] = math.Copysign(1, d[n-1])
+				vt[(n-1)*ldvt+n-1] = 1
+				d[n-1] = math.Abs(d[n-1])
+			}
+			ok = impl.Dlasd0(nsize, 0, d[start:], e[start:], u[start*ldu+start:], ldu,
+				vt[start*ldvt+start:], ldvt, smlsiz, iwork, work[wstart:])
+			if !ok {
+				return false
+			}
+			start = i + 1
+		}
+
+		// Unscale.
+		impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	}
+	if !ok {
+		return false
+	}
+
+	// Use selection sort to minimize swaps of singular vectors.
+	for i := 0; i < n-1; i++ {
+		kk := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] > p {
+				kk = j
+				p = d[j]
+			}
+		}
+		if kk != i {
+			d[kk] = d[i]
+			d[i] = p
+			if wantvec {
+				bi.Dswap(n, u[i:], ldu, u[kk:], ldu)
+				bi.Dswap(n, vt[i*ldvt:], 1, vt[kk*ldvt:], 1)
+			}
+		}
+	}
+
+	// If B is lower bidiagonal, update u by the Givens rotations which
+	// rotated B to be upper bidiagonal.
+	if uplo == blas.Lower && wantvec {
+		impl.Dlasr(blas.Left, lapack.Variable, lapack.Backward, n, n, work, work[n-1:], u, ldu)
+	}
+	return true
+}
@@ -0,0 +1,382 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dbdsvdx computes selected singular values and, optionally, singular vectors
+// of an n×n upper or lower bidiagonal matrix B,
+//  B = U * S * V^T
+// where S is a diagonal matrix with non-negative diagonal elements (the
+// singular values of B), and U and V are orthogonal matrices of left and right
+// singular vectors.
+//
+// The singular values of B are computed as the positive eigenvalues of the
+// 2n×2n Tridiagonal Golub-Kahan matrix
+//  TGK = [ 0  d[0]  0    0    0   ... ]
+//        [d[0] 0   e[0]  0    0   ... ]
+//        [ 0  e[0]  0   d[1]  0   ... ]
+//        [ 0   0   d[1]  0   e[1] ... ]
+//        [ .   .    .    .    .   ... ]
+// using bisection in Dstebz, and the singular vectors are obtained from the
+// corresponding eigenvectors of TGK computed by inverse iteration in Dstein.
+// If s is a singular value of B with left and right singular vectors u and v,
+// then the eigenvector of TGK belonging to s is
+//  (v[0], u[0], v[1], u[1], ..., v[n-1], u[n-1]) / sqrt(2)
+// for upper bidiagonal B, and u and v are interchanged for lower bidiagonal B.
+//
+// uplo specifies whether B is upper or lower bidiagonal. compq specifies
+// whether singular vectors are computed. If compq is lapack.None, only the
+// singular values are computed and z is not referenced. If compq is
+// lapack.BidiagSV, the singular vectors are computed as well.
+//
+// The singular values to compute are selected by rng. If rng is
+// lapack.RangeAll, all singular values are computed. If rng is
+// lapack.RangeInterval, the singular values in the half-open interval (vl,vu]
+// are computed, and vl and vu must satisfy 0 <= vl < vu. If rng is
+// lapack.RangeIndex, the il-th through iu-th largest singular values are
+// computed, with indices starting at 0, and il and iu must satisfy
+// 0 <= il <= iu < n.
+//
+// d contains the diagonal and e the off-diagonal elements of B. d must have
+// length at least n and e must have length at least n-1. d and e are not
+// modified.
+//
+// On return, the first ns elements of s contain the selected singular values
+// in decreasing order. s must have length at least n.
+//
+// If compq is lapack.BidiagSV, the first ns columns of z contain on return
+// the singular vectors corresponding to the selected singular values, with
+// the left singular vectors stored in the first n rows of z and the right
+// singular vectors in the last n rows, that is
+//  Z = [ U ]
+//      [ V ].
+// z must have 2*n rows and at least iu-il+1 columns if rng is
+// lapack.RangeIndex and n columns otherwise.
+//
+// work must have length at least 16*n and iwork must have length at least
+// 10*n.
+//
+// Dbdsvdx returns the number of singular values found and whether the
+// computation succeeded. If ok is false, some of the eigenvalues or
+// eigenvectors of TGK failed to converge.
+//
+// Dbdsvdx is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dbdsvdx(uplo blas.Uplo, compq lapack.SVDComp, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, s, z []float64, ldz int, work []float64, iwork []int) (ns int, ok bool) {
+	wantz := compq == lapack.BidiagSV
+	allsv := rng == lapack.RangeAll
+	valsv := rng == lapack.RangeInterval
+	indsv := rng == lapack.RangeIndex
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case compq != lapack.None && !wantz:
+		panic(badSVDComp)
+	case !allsv && !valsv && !indsv:
+		panic(badRange)
+	case n < 0:
+		panic(nLT0)
+	case valsv && vl < 0:
+		panic(negVl)
+	case valsv && vl >= vu:
+		panic(badInterval)
+	case indsv && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case indsv && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(s) < n {
+		panic(badS)
+	}
+	if wantz {
+		ncol := n
+		if indsv {
+			ncol = iu - il + 1
+		}
+		checkMatrix(2*n, ncol, z, ldz)
+	}
+	if len(work) < 16*n || len(iwork) < 10*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		sv := math.Abs(d[0])
+		if valsv && (sv <= vl || vu < sv) {
+			return 0, true
+		}
+		s[0] = sv
+		if wantz {
+			z[0] = math.Copysign(1, d[0])
+			z[ldz] = 1
+		}
+		return 1, true
+	}
+
+	bi := blas64.Implementation()
+
+	eps := dlamchE
+	safmin := dlamchS
+
+	// The criterion for neglecting elements of B is taken from Dbdsqr when
+	// the singular values are computed to high relative accuracy. See
+	// J. Demmel and W. Kahan, Accurate singular values of bidiagonal
+	// matrices, SIAM J. Sci. and Stat. Comput., 11:873–912, 1990.
+	tol := math.Max(10, math.Min(100, math.Pow(eps, -0.125))) * eps
+
+	// Compute an approximation to the smallest singular value and the
+	// threshold for neglecting elements of B.
+	smin := math.Abs(d[0])
+	if smin != 0 {
+		mu := smin
+		for i := 1; i < n; i++ {
+			mu = math.Abs(d[i]) * (mu / (mu + math.Abs(e[i-1])))
+			smin = math.Min(smin, mu)
+			if smin == 0 {
+				break
+			}
+		}
+	}
+	smin /= math.Sqrt(float64(n))
+	thresh := tol * smin
+
+	// Form the off-diagonal of TGK, setting negligible elements to zero.
+	// Elements whose square underflows are also neglected, so that the
+	// unreduced blocks of TGK are the same as those found by Dstebz.
+	n2 := 2 * n
+	tgkd := work[:n2]
+	tgke := work[n2 : 2*n2]
+	w := work[2*n2 : 3*n2]
+	wrk := work[3*n2:]
+	for i := range tgkd {
+		tgkd[i] = 0
+	}
+	for i := 0; i < n; i++ {
+		tgke[2*i] = d[i]
+		if i < n-1 {
+			tgke[2*i+1] = e[i]
+		}
+	}
+	tgke[n2-1] = 0
+	for i, v := range tgke[:n2-1] {
+		if math.Abs(v) <= thresh || v*v < safmin {
+			tgke[i] = 0
+		}
+	}
+
+	// Each unreduced block of TGK of odd order has exactly one zero
+	// eigenvalue, whose eigenvector has non-zero elements only in the rows
+	// of the same parity as the first row of the block. The first row of
+	// such blocks alternates between even and odd, so the odd blocks come
+	// in pairs, and each pair yields a zero singular value of B with the
+	// right singular vector (for upper B) taken from the first block and
+	// the left singular vector from the second.
+	var nodd int
+	for start, i := 0, 0; i < n2; i++ {
+		if tgke[i] == 0 {
+			if (i-start)%2 == 0 {
+				nodd++
+			}
+			start = i + 1
+		}
+	}
+	nz := nodd / 2
+	npos := n - nz
+
+	// The remaining singular values are the positive eigenvalues of TGK,
+	// which have indices n+nz through 2n-1. Determine the indices lo
+	// through hi of the eigenvalues to compute, and the number of zero
+	// singular values that are selected.
+	tmax := math.Abs(tgke[bi.Idamax(n2-1, tgke, 1)])
+	pivmin := safmin * math.Max(1, tmax*tmax)
+	lo, hi := n2-npos, n2-1
+	var nzsel int
+	switch rng {
+	case lapack.RangeAll:
+		nzsel = nz
+	case lapack.RangeInterval:
+		lo = max(lo, dtgkCount(n2, tgke, vl, pivmin))
+		hi = min(hi, dtgkCount(n2, tgke, vu, pivmin)-1)
+	case lapack.RangeIndex:
+		if il < npos {
+			lo = n2 - 1 - min(iu, npos-1)
+			hi = n2 - 1 - il
+		} else {
+			lo, hi = n2, n2-1
+		}
+		nzsel = max(0, iu-max(il, npos)+1)
+	}
+
+	iblock := iwork[:n2]
+	isplit := iwork[n2 : 2*n2]
+	if lo <= hi {
+		ns, _, ok = impl.Dstebz(lapack.RangeIndex, true, n2, 0, 0, lo, hi, 2*safmin, tgkd, tgke, w, iblock, isplit, wrk, iwork[2*n2:])
+		if !ok {
+			return 0, false
+		}
+		for i, v := range w[:ns] {
+			s[i] = math.Abs(v)
+		}
+		if wantz {
+			ok = impl.Dstein(n2, tgkd, tgke, ns, w, iblock, isplit, z, ldz, wrk, iwork[2*n2:], iwork[3*n2:])
+			if !ok {
+				return 0, false
+			}
+		}
+	}
+
+	// The elements of u are stored from row ru of z and those of v from
+	// row rv.
+	ru, rv := 0, n
+	if uplo == blas.Lower {
+		ru, rv = n, 0
+	}
+	if wantz {
+		// Split the eigenvectors of TGK into u and v, and normalize them.
+		// Eigenvectors belonging to close eigenvalues are orthogonal
+		// as a whole, but their parts u and v need not be, so these
+		// are reorthogonalized against the preceding ones in the same
+		// cluster, using the clustering criterion of Dstein.
+		ortol := 1e-3 * 2 * tmax
+		for j := 0; j < ns; j++ {
+			bi.Dcopy(n2, z[j:], ldz, wrk, 1)
+			bi.Dcopy(n, wrk[1:], 2, z[ru*ldz+j:], ldz)
+			bi.Dcopy(n, wrk, 2, z[rv*ldz+j:], ldz)
+			first := j
+			for first > 0 && iblock[first-1] == iblock[j] && w[first]-w[first-1] <= ortol {
+				first--
+			}
+			for _, off := range []int{ru, rv} {
+				zj := z[off*ldz+j:]
+				nrm := bi.Dnrm2(n, zj, ldz)
+				if nrm == 0 {
+					return 0, false
+				}
+				bi.Dscal(n, 1/nrm, zj, ldz)
+				if first == j {
+					continue
+				}
+				for k := 0; k < 2; k++ {
+					for i := first; i < j; i++ {
+						zi := z[off*ldz+i:]
+						bi.Daxpy(n, -bi.Ddot(n, zi, ldz, zj, ldz), zi, ldz, zj, ldz)
+					}
+				}
+				bi.Dscal(n, 1/bi.Dnrm2(n, zj, ldz), zj, ldz)
+			}
+		}
+	}
+
+	// Sort the singular values into decreasing order.
+	for i := 0; i < ns-1; i++ {
+		k := i
+		smax := s[i]
+		for j := i + 1; j < ns; j++ {
+			if s[j] > smax {
+				k = j
+				smax = s[j]
+			}
+		}
+		if k != i {
+			s[k] = s[i]
+			s[i] = smax
+			if wantz {
+				bi.Dswap(n2, z[k:], ldz, z[i:], ldz)
+			}
+		}
+	}
+
+	// Append the selected zero singular values and their singular vectors.
+	if nzsel > 0 {
+		for i := ns; i < ns+nzsel; i++ {
+			s[i] = 0
+			if wantz {
+				for k := 0; k < n2; k++ {
+					z[k*ldz+i] = 0
+				}
+			}
+		}
+		if wantz {
+			var k int
+			for start, i := 0, 0; i < n2 && k < 2*nzsel; i++ {
+				if tgke[i] != 0 {
+					continue
+				}
+				if (i-start)%2 == 0 {
+					// The null vector of an odd block starting
+					// at an even row provides v, and one
+					// starting at an odd row provides u.
+					off := rv
+					if start%2 == 1 {
+						off = ru
+					}
+					dtgkNull(start, i, tgke, z[(off+start/2)*ldz+ns+k/2:], ldz)
+					k++
+				}
+				start = i + 1
+			}
+		}
+		ns += nzsel
+	}
+	return ns, true
+}
+
+// dtgkCount returns the number of eigenvalues less than x of the n×n
+// symmetric tridiagonal matrix with zero diagonal and off-diagonal elements
+// stored in e.
+func dtgkCount(n int, e []float64, x, pivmin float64) int {
+	var cnt int
+	q := -x
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			q = -x - e[i-1]*e[i-1]/q
+		}
+		if math.Abs(q) < pivmin {
+			q = -pivmin
+		}
+		if q < 0 {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// dtgkNull computes the normalized null vector of the unreduced block of odd
+// order in rows start through end of the tridiagonal matrix with zero
+// diagonal and off-diagonal elements stored in e. The null vector has
+// non-zero elements only in the rows start, start+2, ..., end, and these
+// elements are stored in x[0], x[incX], ..., x[(end-start)/2*incX]. The
+// remaining elements of x are not modified.
+func dtgkNull(start, end int, e []float64, x []float64, incX int) {
+	const big = 1 / dlamchP
+	bi := blas64.Implementation()
+	n := (end-start)/2 + 1
+	x[0] = 1
+	for i := 1; i < n; i++ {
+		r := start + 2*i - 1
+		v := -e[r-1] * x[(i-1)*incX] / e[r]
+		if math.Abs(v) > big {
+			bi.Dscal(i, 1/math.Abs(v), x, incX)
+			v = math.Copysign(1, v)
+		}
+		x[i*incX] = v
+	}
+	bi.Dscal(n, 1/bi.Dnrm2(n, x, incX), x, incX)
+}
@@ -0,0 +1,124 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgbcon estimates the reciprocal of the condition number of the n×n band
+// matrix A with kl subdiagonals and ku superdiagonals given its LU
+// factorization computed by Dgbtrf. The condition number computed may be
+// based on the 1-norm or the ∞-norm.
+//
+// ab and ipiv contain the factorization of A as returned by Dgbtrf, in
+// particular ldab must be at least 2*kl+ku+1. ipiv must have length at least
+// n, otherwise Dgbcon will panic.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Dgbcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgbcon will panic
+// otherwise.
+func (impl Implementation) Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	kv := kl + ku
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	checkBandMatrix(n, n, kl, kv, ab, ldab)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	bi := blas64.Implementation()
+	var rcond, ainvnm float64
+	var kase int
+	var normin bool
+	var isave [3]int
+	onenrm := norm == lapack.MaxColumnSum
+	smlnum := dlamchS
+	kase1 := 2
+	if onenrm {
+		kase1 = 1
+	}
+
+	// Estimate the norm of inv(A). In the band storage, the multipliers of L
+	// in column j are stored in ab[(j+1)*ldab+kl-1:] with stride ldab-1, and
+	// U is stored in row-major band format in ab[kl:].
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			if ainvnm != 0 {
+				rcond = (1 / ainvnm) / anorm
+			}
+			return rcond
+		}
+		var scale float64
+		if kase == kase1 {
+			// Multiply by inv(L).
+			if kl > 0 {
+				for j := 0; j < n-1; j++ {
+					lm := min(kl, n-j-1)
+					jp := ipiv[j]
+					t := work[jp]
+					if jp != j {
+						work[jp] = work[j]
+						work[j] = t
+					}
+					bi.Daxpy(lm, -t, ab[(j+1)*ldab+kl-1:], ldab-1, work[j+1:], 1)
+				}
+			}
+			// Multiply by inv(U).
+			scale = impl.Dlatbs(blas.Upper, blas.NoTrans, blas.NonUnit, normin, n, kv, ab[kl:], ldab, work, work[2*n:])
+		} else {
+			// Multiply by inv(U^T).
+			scale = impl.Dlatbs(blas.Upper, blas.Trans, blas.NonUnit, normin, n, kv, ab[kl:], ldab, work, work[2*n:])
+			// Multiply by inv(L^T).
+			if kl > 0 {
+				for j := n - 2; j >= 0; j-- {
+					lm := min(kl, n-j-1)
+					work[j] -= bi.Ddot(lm, ab[(j+1)*ldab+kl-1:], ldab-1, work[j+1:], 1)
+					jp := ipiv[j]
+					if jp != j {
+						work[jp], work[j] = work[j], work[jp]
+					}
+				}
+			}
+		}
+		// Divide x by 1/scale if doing so will not cause overflow.
+		normin = true
+		if scale != 1 {
+			ix := bi.Idamax(n, work, 1)
+			if scale == 0 || scale < math.Abs(work[ix])*smlnum {
+				return rcond
+			}
+			impl.Drscl(n, scale, work, 1)
+		}
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dgbsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n band matrix with kl subdiagonals and ku superdiagonals,
+// and X and B are n×nrhs matrices.
+//
+// The LU decomposition with partial pivoting and row interchanges is used to
+// factor A as
+//  A = P * L * U
+// where P is a permutation matrix, L is a product of permutation and unit
+// lower triangular matrices with kl subdiagonals, and U is upper triangular
+// with kl+ku superdiagonals. On entry, ab contains the matrix A in the band
+// storage format described in the documentation of Dgbtrf, and on return it
+// contains the factors L and U. ldab must be at least 2*kl+ku+1. The row
+// pivot indices that define the permutation matrix P are stored in ipiv. ipiv
+// must have length at least n and Dgbsv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dgbsv returns false if U is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dgbsv(n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkBandMatrix(n, n, kl, kl+ku, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the LU factorization of A.
+	ok = impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.Dgbtrs(blas.NoTrans, n, kl, ku, nrhs, ab, ldab, ipiv, b, ldb)
+	return true
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas/blas64"
+
+// Dgbtf2 computes an LU factorization of the m×n band matrix A with kl
+// subdiagonals and ku superdiagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix with at
+// most kl non-zero elements below the diagonal in each column, and U is an
+// upper triangular band matrix with kl+ku superdiagonals.
+//
+// The storage of ab is described in the documentation of Dgbtrf. ldab must be
+// at least 2*kl+ku+1.
+//
+// ipiv must have length at least min(m,n), otherwise Dgbtf2 will panic. On
+// return, row i of the matrix was interchanged with row ipiv[i].
+//
+// Dgbtf2 returns whether U is nonsingular. If ok is false, the factorization
+// has been completed, but division by zero will occur if it is used to solve a
+// system of equations.
+//
+// Dgbtf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Dgbtf2(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	kv := ku + kl
+	checkBandMatrix(m, n, kl, kv, ab, ldab)
+	if len(ipiv) < min(m, n) {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	// Set the fill-in elements in the superdiagonals ku+1 to kv to zero.
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := i + ku + 1; j <= min(i+kv, n-1); j++ {
+			ab[i*ldab+kl+j-i] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+
+	// In the band storage, the element A[i,j] is stored in ab[i*ldab+kl+j-i],
+	// so that the elements of a column of A are ldab-1 apart.
+	ok = true
+	// ju is the index of the last column affected by the current stage of
+	// the factorization.
+	var ju int
+	for j := 0; j < min(m, n); j++ {
+		// Find the pivot and test for singularity. km is the number of
+		// subdiagonal elements in the current column.
+		km := min(kl, m-j-1)
+		var jp int
+		if km > 0 {
+			jp = bi.Idamax(km+1, ab[j*ldab+kl:], ldab-1)
+		}
+		ipiv[j] = j + jp
+		if ab[(j+jp)*ldab+kl-jp] == 0 {
+			ok = false
+			continue
+		}
+		ju = max(ju, min(j+ku+jp, n-1))
+		// Apply the interchange to columns j to ju.
+		if jp != 0 {
+			bi.Dswap(ju-j+1, ab[(j+jp)*ldab+kl-jp:], 1, ab[j*ldab+kl:], 1)
+		}
+		if km > 0 {
+			// Compute the multipliers.
+			bi.Dscal(km, 1/ab[j*ldab+kl], ab[(j+1)*ldab+kl-1:], ldab-1)
+			// Update the trailing submatrix within the band.
+			if ju > j {
+				bi.Dger(km, ju-j, -1, ab[(j+1)*ldab+kl-1:], ldab-1, ab[j*ldab+kl+1:], 1,
+					ab[(j+1)*ldab+kl:], ldab-1)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,271 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dgbtrf computes an LU factorization of the m×n band matrix A with kl
+// subdiagonals and ku superdiagonals using partial pivoting with row
+// interchanges. The factorization has the form
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix with at
+// most kl non-zero elements below the diagonal in each column, and U is an
+// upper triangular band matrix with kl+ku superdiagonals.
+//
+// The matrix A is stored in ab in row-major band format as used by
+// blas64.Band, but with kl additional superdiagonals that are needed to store
+// the fill-in of U. The element A[i,j] is stored in ab[i*ldab+kl+j-i], so
+// that row i of A occupies the elements ab[i*ldab:i*ldab+ldab]. For example,
+// when m = n = 6, kl = 2 and ku = 1, the layout of ab is
+//   *   *  a00 a01  +   +
+//   *  a10 a11 a12  +   +
+//  a20 a21 a22 a23  +   +
+//  a31 a32 a33 a34  +   *
+//  a42 a43 a44 a45  *   *
+//  a53 a54 a55  *   *   *
+// where the elements marked * are not used and the elements marked + need not
+// be set on entry. ldab must be at least 2*kl+ku+1, otherwise Dgbtrf will
+// panic.
+//
+// On return, U is stored in ab in the same format as an upper triangular band
+// matrix with kl+ku superdiagonals, that is U[i,j] is stored in
+// ab[i*ldab+kl+j-i] for i <= j <= i+kl+ku, and the multipliers used during
+// the factorization are stored in the elements of the subdiagonals of A.
+//
+// ipiv must have length at least min(m,n), otherwise Dgbtrf will panic. On
+// return, row i of the matrix was interchanged with row ipiv[i]. ipiv is
+// zero-indexed.
+//
+// Dgbtrf returns whether U is nonsingular. If ok is false, the factorization
+// has been completed, but division by zero will occur if it is used to solve a
+// system of equations.
+func (impl Implementation) Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool) {
+	kv := ku + kl
+	checkBandMatrix(m, n, kl, kv, ab, ldab)
+	if len(ipiv) < min(m, n) {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return true
+	}
+
+	// nbmax is the maximum block size and the number of columns of the
+	// local work arrays.
+	const (
+		nbmax  = 64
+		ldwork = nbmax + 1
+	)
+
+	nb := impl.Ilaenv(1, "DGBTRF", " ", m, n, kl, ku)
+	// The block size must not exceed the limit set by the size of the local
+	// work arrays.
+	nb = min(nb, nbmax)
+
+	if nb <= 1 || nb > kl {
+		// Use unblocked code.
+		return impl.Dgbtf2(m, n, kl, ku, ab, ldab, ipiv)
+	}
+
+	// Use blocked code.
+
+	// The elements of the blocks A13 and A31 that lie outside the band are
+	// stored in the work arrays work13 and work31. The strictly upper
+	// triangle of work13 and the strictly lower triangle of work31 must be
+	// zero.
+	work13 := make([]float64, ldwork*nbmax)
+	work31 := make([]float64, ldwork*nbmax)
+
+	// Set the fill-in elements in the superdiagonals ku+1 to kv to zero.
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := i + ku + 1; j <= min(i+kv, n-1); j++ {
+			ab[i*ldab+kl+j-i] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+
+	// In the band storage, the element A[i,j] is stored in ab[i*ldab+kl+j-i],
+	// so that a submatrix of A within the band is a general matrix with
+	// stride ldab-1.
+	ok = true
+	// ju is the index of the last column affected by the current stage of
+	// the factorization.
+	var ju int
+	mn := min(m, n)
+	for j := 0; j < mn; j += nb {
+		jb := min(nb, mn-j)
+
+		// The active part of the matrix is partitioned
+		//  A11   A12   A13
+		//  A21   A22   A23
+		//  A31   A32   A33
+		// Here A11, A21 and A31 denote the current block of jb columns which
+		// is about to be factorized. The number of rows in the partitioning
+		// are jb, i2, i3 respectively, and the numbers of columns are jb,
+		// j2, j3. The superdiagonal elements of A13 and the subdiagonal
+		// elements of A31 lie outside the band.
+		i2 := min(kl-jb, m-j-jb)
+		i3 := min(jb, m-j-kl)
+
+		// j2 and j3 are computed after ju has been updated.
+
+		// Factorize the current block of jb columns.
+		for jj := j; jj < j+jb; jj++ {
+			// Find the pivot and test for singularity. km is the number of
+			// subdiagonal elements in the current column.
+			km := min(kl, m-jj-1)
+			var jp int
+			if km > 0 {
+				jp = bi.Idamax(km+1, ab[jj*ldab+kl:], ldab-1)
+			}
+			ipiv[jj] = jp + jj - j
+			if ab[(jj+jp)*ldab+kl-jp] != 0 {
+				ju = max(ju, min(jj+ku+jp, n-1))
+				if jp != 0 {
+					// Apply the interchange to columns j to j+jb-1.
+					if jp+jj < j+kl {
+						bi.Dswap(jb, ab[jj*ldab+kl+j-jj:], 1, ab[(jj+jp)*ldab+kl+j-jj-jp:], 1)
+					} else {
+						// The interchange affects columns j to jj-1 of
+						// A31 which are stored in the work array work31.
+						bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, work31[(jp+jj-j-kl)*ldwork:], 1)
+						bi.Dswap(j+jb-jj, ab[jj*ldab+kl:], 1, ab[(jj+jp)*ldab+kl-jp:], 1)
+					}
+				}
+				if km > 0 {
+					// Compute the multipliers.
+					bi.Dscal(km, 1/ab[jj*ldab+kl], ab[(jj+1)*ldab+kl-1:], ldab-1)
+					// Update the trailing submatrix within the band and
+					// within the current block. jm is the index of the
+					// last column which needs to be updated.
+					jm := min(ju, j+jb-1)
+					if jm > jj {
+						bi.Dger(km, jm-jj, -1, ab[(jj+1)*ldab+kl-1:], ldab-1, ab[jj*ldab+kl+1:], 1,
+							ab[(jj+1)*ldab+kl:], ldab-1)
+					}
+				}
+			} else {
+				ok = false
+			}
+
+			// Copy the current column of A31 into the work array work31.
+			nw := min(jj-j+1, i3)
+			if nw > 0 {
+				bi.Dcopy(nw, ab[(j+kl)*ldab+jj-j:], ldab-1, work31[jj-j:], ldwork)
+			}
+		}
+
+		if j+jb < n {
+			// Apply the row interchanges to the other blocks.
+			j2 := min(ju-j+1, kv) - jb
+			j3 := max(0, ju-j-kv+1)
+
+			// Use Dlaswp to apply the row interchanges to A12, A22 and A32.
+			if j2 > 0 {
+				impl.Dlaswp(j2, ab[j*ldab+kl+jb:], ldab-1, 0, jb-1, ipiv[j:j+jb], 1)
+			}
+
+			// Adjust the pivot indices.
+			for i := j; i < j+jb; i++ {
+				ipiv[i] += j
+			}
+
+			// Apply the row interchanges to A13, A23 and A33 columnwise.
+			k2 := j + jb + j2
+			for i := 0; i < j3; i++ {
+				jj := k2 + i
+				for ii := j + i; ii < j+jb; ii++ {
+					ip := ipiv[ii]
+					if ip != ii {
+						ab[ii*ldab+kl+jj-ii], ab[ip*ldab+kl+jj-ip] = ab[ip*ldab+kl+jj-ip], ab[ii*ldab+kl+jj-ii]
+					}
+				}
+			}
+
+			// Update the relevant part of the trailing submatrix.
+			if j2 > 0 {
+				// Update A12.
+				bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit, jb, j2, 1,
+					ab[j*ldab+kl:], ldab-1, ab[j*ldab+kl+jb:], ldab-1)
+				if i2 > 0 {
+					// Update A22.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i2, j2, jb, -1,
+						ab[(j+jb)*ldab+kl-jb:], ldab-1, ab[j*ldab+kl+jb:], ldab-1,
+						1, ab[(j+jb)*ldab+kl:], ldab-1)
+				}
+				if i3 > 0 {
+					// Update A32.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i3, j2, jb, -1,
+						work31, ldwork, ab[j*ldab+kl+jb:], ldab-1,
+						1, ab[(j+kl)*ldab+jb:], ldab-1)
+				}
+			}
+
+			if j3 > 0 {
+				// Copy the lower triangle of A13 into the work array
+				// work13.
+				for jj := 0; jj < j3; jj++ {
+					for ii := jj; ii < jb; ii++ {
+						work13[ii*ldwork+jj] = ab[(j+ii)*ldab+kl+kv+jj-ii]
+					}
+				}
+				// Update A13 in the work array.
+				bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.Unit, jb, j3, 1,
+					ab[j*ldab+kl:], ldab-1, work13, ldwork)
+				if i2 > 0 {
+					// Update A23.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i2, j3, jb, -1,
+						ab[(j+jb)*ldab+kl-jb:], ldab-1, work13, ldwork,
+						1, ab[(j+jb)*ldab+kl+kv-jb:], ldab-1)
+				}
+				if i3 > 0 {
+					// Update A33.
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, i3, j3, jb, -1,
+						work31, ldwork, work13, ldwork,
+						1, ab[(j+kl)*ldab+kv:], ldab-1)
+				}
+				// Copy the lower triangle of A13 back into place.
+				for jj := 0; jj < j3; jj++ {
+					for ii := jj; ii < jb; ii++ {
+						ab[(j+ii)*ldab+kl+kv+jj-ii] = work13[ii*ldwork+jj]
+					}
+				}
+			}
+		} else {
+			// Adjust the pivot indices.
+			for i := j; i < j+jb; i++ {
+				ipiv[i] += j
+			}
+		}
+
+		// Partially undo the interchanges in the current block to restore
+		// the upper triangular form of A31 and copy the upper triangle of
+		// A31 back into place.
+		for jj := j + jb - 1; jj >= j; jj-- {
+			jp := ipiv[jj] - jj
+			if jp != 0 {
+				// Apply the interchange to columns j to jj-1.
+				if jp+jj < j+kl {
+					// The interchange does not affect A31.
+					bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, ab[(jj+jp)*ldab+kl+j-jj-jp:], 1)
+				} else {
+					// The interchange does affect A31.
+					bi.Dswap(jj-j, ab[jj*ldab+kl+j-jj:], 1, work31[(jp+jj-j-kl)*ldwork:], 1)
+				}
+			}
+			// Copy the current column of A31 back into place.
+			nw := min(i3, jj-j+1)
+			if nw > 0 {
+				bi.Dcopy(nw, work31[jj-j:], ldwork, ab[(j+kl)*ldab+jj-j:], ldab-1)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dgbtrs solves a system of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans or blas.ConjTrans,
+// where A is an n×n band matrix with kl subdiagonals and ku superdiagonals,
+// using the LU factorization computed by Dgbtrf. ab and ipiv contain the
+// factorization of A as returned by Dgbtrf, in particular ldab must be at
+// least 2*kl+ku+1. ipiv must have length at least n, otherwise Dgbtrs will
+// panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (Implementation) Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) {
+	kv := ku + kl
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkBandMatrix(n, n, kl, kv, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+
+	// In the band storage, the multipliers of L in column j are stored in
+	// ab[(j+1)*ldab+kl-1:] with stride ldab-1, and the row i of U is stored
+	// in row-major band format in ab[i*ldab+kl:].
+	if trans == blas.NoTrans {
+		// Solve L*X = B, overwriting B with X.
+		//
+		// L is represented as a product of permutations and unit lower
+		// triangular matrices L = P(0) * L(0) * ... * P(n-2) * L(n-2),
+		// where each transformation L(j) is a rank-one modification of
+		// the identity matrix.
+		if kl > 0 {
+			for j := 0; j < n-1; j++ {
+				lm := min(kl, n-j-1)
+				l := ipiv[j]
+				if l != j {
+					bi.Dswap(nrhs, b[l*ldb:], 1, b[j*ldb:], 1)
+				}
+				bi.Dger(lm, nrhs, -1, ab[(j+1)*ldab+kl-1:], ldab-1, b[j*ldb:], 1, b[(j+1)*ldb:], ldb)
+			}
+		}
+		// Solve U*X = B, overwriting B with X.
+		for i := 0; i < nrhs; i++ {
+			bi.Dtbsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, kv, ab[kl:], ldab, b[i:], ldb)
+		}
+		return
+	}
+
+	// Solve A^T * X = B.
+
+	// Solve U^T * X = B, overwriting B with X.
+	for i := 0; i < nrhs; i++ {
+		bi.Dtbsv(blas.Upper, blas.Trans, blas.NonUnit, n, kv, ab[kl:], ldab, b[i:], ldb)
+	}
+	// Solve L^T * X = B, overwriting B with X.
+	if kl > 0 {
+		for j := n - 2; j >= 0; j-- {
+			lm := min(kl, n-j-1)
+			bi.Dgemv(blas.Trans, lm, nrhs, -1, b[(j+1)*ldb:], ldb, ab[(j+1)*ldab+kl-1:], ldab-1, 1, b[j*ldb:], 1)
+			l := ipiv[j]
+			if l != j {
+				bi.Dswap(nrhs, b[l*ldb:], 1, b[j*ldb:], 1)
+			}
+		}
+	}
+}
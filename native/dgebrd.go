@@ -85,23 +85,18 @@ func (impl Implementation) Dgebrd(m, n int, a []float64, lda int, d, e, tauQ, ta
 	if len(work) < lwork {
 		panic(badWork)
 	}
-	var nx int
 	if nb > 1 && nb < minmn {
-		nx = max(nb, impl.Ilaenv(3, "DGEBRD", " ", m, n, -1, -1))
-		if nx < minmn {
-			ws = (m + n) * nb
-			if lwork < ws {
-				nbmin := impl.Ilaenv(2, "DGEBRD", " ", m, n, -1, -1)
-				if lwork >= (m+n)*nbmin {
-					nb = lwork / (m + n)
-				} else {
-					nb = minmn
-					nx = minmn
-				}
+		// The blocked code is used for all but the last block, so make
+		// sure that the workspace is large enough for the block size.
+		ws = (m + n) * nb
+		if lwork < ws {
+			nbmin := impl.Ilaenv(2, "DGEBRD", " ", m, n, -1, -1)
+			if lwork >= (m+n)*nbmin {
+				nb = lwork / (m + n)
+			} else {
+				nb = minmn
 			}
 		}
-	} else {
-		nx = minmn
 	}
 	bi := blas64.Implementation()
 	ldworkx := nb
@@ -0,0 +1,101 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dgeequ computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. On return, r and c contain the
+// row and column scale factors, respectively, chosen so that the largest
+// element in each row and column of the matrix B with elements
+//  B[i,j] = r[i] * A[i,j] * c[j]
+// has absolute value 1.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dgeequ will panic.
+//
+// rowcnd is the ratio of the smallest r[i] to the largest r[i]. If
+// rowcnd >= 0.1 and amax is neither too large nor too small, it is not worth
+// scaling by r. colcnd is the ratio of the smallest c[j] to the largest c[j].
+// If colcnd >= 0.1, it is not worth scaling by c. amax is the absolute value
+// of the largest element of A. If amax is very close to overflow or very close
+// to underflow, the matrix should be scaled.
+//
+// Dgeequ returns false if A has a row or a column of zeros. In that case
+// rowcnd and colcnd are not computed, and r and c may only be partially
+// computed.
+//
+// Dgeequ does not attempt to prevent overflow or underflow of the elements of
+// B, see Dgeequb for a version that chooses powers of the radix as the scale
+// factors.
+func (impl Implementation) Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+
+	// Compute the row scale factors.
+	for i := 0; i < m; i++ {
+		var rmax float64
+		for _, v := range a[i*lda : i*lda+n] {
+			rmax = math.Max(rmax, math.Abs(v))
+		}
+		r[i] = rmax
+	}
+	rcmin := bignum
+	var rcmax float64
+	for _, ri := range r[:m] {
+		rcmax = math.Max(rcmax, ri)
+		rcmin = math.Min(rcmin, ri)
+	}
+	amax = rcmax
+	if rcmin == 0 {
+		// A has a zero row.
+		return 0, 0, amax, false
+	}
+	// Invert the scale factors.
+	for i, ri := range r[:m] {
+		r[i] = 1 / math.Min(math.Max(ri, smlnum), bignum)
+	}
+	rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+
+	// Compute the column scale factors assuming that the row scaling has
+	// been applied.
+	for j := range c[:n] {
+		c[j] = 0
+	}
+	for i := 0; i < m; i++ {
+		ri := r[i]
+		for j, v := range a[i*lda : i*lda+n] {
+			c[j] = math.Max(c[j], math.Abs(v)*ri)
+		}
+	}
+	rcmin = bignum
+	rcmax = 0
+	for _, cj := range c[:n] {
+		rcmin = math.Min(rcmin, cj)
+		rcmax = math.Max(rcmax, cj)
+	}
+	if rcmin == 0 {
+		// A has a zero column.
+		return rowcnd, 0, amax, false
+	}
+	for j, cj := range c[:n] {
+		c[j] = 1 / math.Min(math.Max(cj, smlnum), bignum)
+	}
+	colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+	return rowcnd, colcnd, amax, true
+}
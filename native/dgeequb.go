@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dgeequb computes row and column scalings intended to equilibrate the m×n
+// matrix A and reduce its condition number. On return, r and c contain the
+// row and column scale factors, respectively. The scale factors are powers of
+// the radix chosen so that the largest element in each row and column of the
+// matrix B with elements
+//  B[i,j] = r[i] * A[i,j] * c[j]
+// has absolute value in the interval [1/radix, 1].
+//
+// Dgeequb differs from Dgeequ only in restricting the scale factors to powers
+// of the radix. Scaling by such factors does not introduce any rounding
+// errors, and the scaled entries of A keep their original significands.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dgeequb will panic.
+//
+// rowcnd and colcnd have the same meaning as in Dgeequ. amax approximates the
+// absolute value of the largest element of A by a power of the radix. Dgeequb
+// returns false if A has a row or a column of zeros.
+func (impl Implementation) Dgeequb(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool) {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, 1, 0, true
+	}
+
+	smlnum := dlamchS
+	bignum := 1 / smlnum
+	radix := float64(dlamchB)
+	logrdx := math.Log(radix)
+
+	// Compute the row scale factors.
+	for i := 0; i < m; i++ {
+		var rmax float64
+		for _, v := range a[i*lda : i*lda+n] {
+			rmax = math.Max(rmax, math.Abs(v))
+		}
+		if rmax > 0 {
+			rmax = math.Pow(radix, float64(int(math.Log(rmax)/logrdx)))
+		}
+		r[i] = rmax
+	}
+	rcmin := bignum
+	var rcmax float64
+	for _, ri := range r[:m] {
+		rcmax = math.Max(rcmax, ri)
+		rcmin = math.Min(rcmin, ri)
+	}
+	amax = rcmax
+	if rcmin == 0 {
+		// A has a zero row.
+		return 0, 0, amax, false
+	}
+	// Invert the scale factors.
+	for i, ri := range r[:m] {
+		r[i] = 1 / math.Min(math.Max(ri, smlnum), bignum)
+	}
+	rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+
+	// Compute the column scale factors assuming that the row scaling has
+	// been applied.
+	for j := range c[:n] {
+		c[j] = 0
+	}
+	for i := 0; i < m; i++ {
+		ri := r[i]
+		for j, v := range a[i*lda : i*lda+n] {
+			c[j] = math.Max(c[j], math.Abs(v)*ri)
+		}
+	}
+	for j, cj := range c[:n] {
+		if cj > 0 {
+			c[j] = math.Pow(radix, float64(int(math.Log(cj)/logrdx)))
+		}
+	}
+	rcmin = bignum
+	rcmax = 0
+	for _, cj := range c[:n] {
+		rcmin = math.Min(rcmin, cj)
+		rcmax = math.Max(rcmax, cj)
+	}
+	if rcmin == 0 {
+		// A has a zero column.
+		return rowcnd, 0, amax, false
+	}
+	for j, cj := range c[:n] {
+		c[j] = 1 / math.Min(math.Max(cj, smlnum), bignum)
+	}
+	colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+	return rowcnd, colcnd, amax, true
+}
@@ -0,0 +1,61 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/lapack"
+
+// Dgees computes for an n×n real nonsymmetric matrix A the eigenvalues, the
+// real Schur form T and, optionally, the matrix of Schur vectors Z. This gives
+// the Schur factorization
+//  A = Z*T*Z^T.
+// Optionally, Dgees also orders the eigenvalues on the diagonal of the Schur
+// form so that selected eigenvalues are at the top left. The leading columns
+// of Z then form an orthonormal basis for the invariant subspace
+// corresponding to the selected eigenvalues.
+//
+// A matrix is in real Schur form if it is upper quasi-triangular with 1×1 and
+// 2×2 blocks. 2×2 blocks will be standardized in the form
+//  [  a  b ]
+//  [  c  a ]
+// where b*c < 0. The eigenvalues of such a block are a ± sqrt(b*c).
+//
+// If jobvs == lapack.ComputeSchurVectors, the Schur vectors are computed and
+// stored in vs, otherwise jobvs must be lapack.None and vs is not referenced.
+// For other values of jobvs Dgees will panic.
+//
+// selctg specifies the eigenvalues to be moved to the top left of the Schur
+// form. If selctg is nil, the eigenvalues are not ordered. Otherwise, an
+// eigenvalue wr[j] + i*wi[j] is selected if selctg(wr[j], wi[j]) is true. If
+// either one of a complex conjugate pair of eigenvalues is selected, then
+// both are selected.
+//
+// On return, a is overwritten by its real Schur form T, and wr and wi contain
+// the real and imaginary parts, respectively, of the computed eigenvalues in
+// the same order that they appear on the diagonal of T. Complex conjugate
+// pairs of eigenvalues appear consecutively with the eigenvalue having the
+// positive imaginary part first. wr and wi must have length n.
+//
+// sdim is the number of eigenvalues, after sorting, for which selctg is true,
+// counting complex conjugate pairs for which selctg is true for either
+// eigenvalue as two. If selctg is nil, sdim is zero.
+//
+// work must have length at least lwork and lwork must be at least max(1,3*n),
+// otherwise Dgees will panic. For good performance, lwork must generally be
+// larger. If lwork == -1, instead of performing Dgees, the optimal value of
+// lwork is stored into work[0].
+//
+// bwork is temporary storage. It must have length at least n if selctg is not
+// nil, otherwise it is not referenced.
+//
+// ok will be false if the QR algorithm failed to compute all the eigenvalues,
+// if the eigenvalues could not be reordered because some of them were too
+// close to separate, or if, after reordering, roundoff changed the values of
+// some complex eigenvalues so that leading eigenvalues in the Schur form no
+// longer satisfy selctg.
+func (impl Implementation) Dgees(jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, n int, a []float64, lda int, wr, wi []float64, vs []float64, ldvs int, work []float64, lwork int, bwork []bool) (sdim int, ok bool) {
+	var iwork [1]int
+	sdim, _, _, ok = impl.Dgeesx(jobvs, selctg, lapack.CondJob(lapack.None), n, a, lda, wr, wi, vs, ldvs, work, lwork, iwork[:], 1, bwork)
+	return sdim, ok
+}
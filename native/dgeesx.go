@@ -0,0 +1,310 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgeesx computes for an n×n real nonsymmetric matrix A the eigenvalues, the
+// real Schur form T and, optionally, the matrix of Schur vectors Z, and
+// optionally orders the eigenvalues on the diagonal of T so that selected
+// eigenvalues are at the top left. It has the same interface as Dgees but in
+// addition it can compute the reciprocal condition numbers of the average of
+// the selected eigenvalues and of the right invariant subspace corresponding
+// to them.
+//
+// sense specifies which reciprocal condition numbers are computed. If sense
+// is lapack.CondEigenvalues, lapack.CondSubspace or lapack.CondBoth, the
+// condition number for the average of the selected eigenvalues, for the
+// invariant subspace, or for both, respectively, are computed and selctg must
+// not be nil. If sense is lapack.None, no condition numbers are computed. For
+// other values of sense Dgeesx will panic. rconde and rcondv are the computed
+// condition numbers, see the documentation of Dtrsen for details.
+//
+// work must have length at least lwork and lwork must be at least max(1,3*n)
+// if sense == lapack.None, and at least max(1,3*n,n+n*n/2) otherwise.
+// iwork must have length at least liwork and liwork must be at least
+// max(1,n*n/4) if sense is lapack.CondSubspace or lapack.CondBoth, and at
+// least 1 otherwise. Dgeesx will panic if these conditions are not met. If
+// lwork == -1 or liwork == -1, instead of performing Dgeesx, the optimal
+// value of lwork is stored into work[0] and the minimum value of liwork is
+// stored into iwork[0].
+//
+// bwork is temporary storage. It must have length at least n if selctg is not
+// nil, otherwise it is not referenced.
+//
+// ok will be false if the QR algorithm failed to compute all the eigenvalues,
+// if the eigenvalues could not be reordered because some of them were too
+// close to separate, or if, after reordering, roundoff changed the values of
+// some complex eigenvalues so that leading eigenvalues in the Schur form no
+// longer satisfy selctg.
+func (impl Implementation) Dgeesx(jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, sense lapack.CondJob, n int, a []float64, lda int, wr, wi []float64, vs []float64, ldvs int, work []float64, lwork int, iwork []int, liwork int, bwork []bool) (sdim int, rconde, rcondv float64, ok bool) {
+	var wantvs bool
+	switch jobvs {
+	default:
+		panic("lapack: bad SchurJob")
+	case lapack.ComputeSchurVectors:
+		wantvs = true
+	case lapack.None:
+	}
+	wantst := selctg != nil
+	var wantse, wantsv bool
+	switch sense {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.None:
+	case lapack.CondEigenvalues:
+		wantse = true
+	case lapack.CondSubspace:
+		wantsv = true
+	case lapack.CondBoth:
+		wantse = true
+		wantsv = true
+	}
+	wantsn := !wantse && !wantsv
+	switch {
+	case !wantsn && !wantst:
+		panic("lapack: condition numbers require selctg")
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+
+	// Compute the minimal and the optimal workspace.
+	var minwrk, maxwrk, liwmin int
+	if n == 0 {
+		minwrk = 1
+		maxwrk = 1
+		liwmin = 1
+	} else {
+		minwrk = 3 * n
+		maxwrk = 2*n + n*impl.Ilaenv(1, "DGEHRD", " ", n, 1, n, 0)
+		impl.Dhseqr(lapack.EigenvaluesAndSchur, lapack.None, n, 0, n-1,
+			nil, 1, nil, nil, nil, 1, work, -1)
+		hswork := int(work[0])
+		if wantvs {
+			maxwrk = max(maxwrk, 2*n+(n-1)*impl.Ilaenv(1, "DORGHR", " ", n, 1, n, -1))
+		}
+		maxwrk = max(maxwrk, n+hswork)
+		if !wantsn {
+			minwrk = max(minwrk, n+n*n/2)
+		}
+		maxwrk = max(maxwrk, minwrk)
+		liwmin = 1
+		if wantsv {
+			liwmin = max(1, n*n/4)
+		}
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(maxwrk)
+		iwork[0] = liwmin
+		return 0, 0, 0, true
+	}
+	checkMatrix(n, n, a, lda)
+	if wantvs {
+		checkMatrix(n, n, vs, ldvs)
+	}
+	switch {
+	case len(wr) != n:
+		panic("lapack: bad length of wr")
+	case len(wi) != n:
+		panic("lapack: bad length of wi")
+	case lwork < minwrk:
+		panic(badWork)
+	case liwork < liwmin || len(iwork) < liwork:
+		panic(badWork)
+	case wantst && len(bwork) < n:
+		panic("lapack: insufficient bwork length")
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		iwork[0] = 1
+		return 0, 0, 0, true
+	}
+
+	// Get machine constants.
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum,bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil)
+	var scalea bool
+	var cscale float64
+	if 0 < anrm && anrm < smlnum {
+		scalea = true
+		cscale = smlnum
+	} else if anrm > bignum {
+		scalea = true
+		cscale = bignum
+	}
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrm, cscale, n, n, a, lda)
+	}
+
+	// Permute the matrix to make it more nearly triangular.
+	workbal := work[:n]
+	ilo, ihi := impl.Dgebal(lapack.Permute, n, a, lda, workbal)
+
+	// Reduce to upper Hessenberg form.
+	iwrk := 2 * n
+	tau := work[n : iwrk-1]
+	impl.Dgehrd(n, ilo, ihi, a, lda, tau, work[iwrk:], lwork-iwrk)
+
+	compz := lapack.EVComp(lapack.None)
+	if wantvs {
+		// Copy Householder vectors to VS.
+		impl.Dlacpy(blas.Lower, n, n, a, lda, vs, ldvs)
+		// Generate orthogonal matrix in VS.
+		impl.Dorghr(n, ilo, ihi, vs, ldvs, tau, work[iwrk:], lwork-iwrk)
+		compz = lapack.OriginalEV
+	}
+
+	// Perform QR iteration, accumulating Schur vectors in VS if desired.
+	iwrk = n
+	first := impl.Dhseqr(lapack.EigenvaluesAndSchur, compz, n, ilo, ihi,
+		a, lda, wr, wi, vs, ldvs, work[iwrk:], lwork-iwrk)
+	ok = first == 0
+
+	reordered := true
+	if ok && wantst {
+		// Undo scaling of the eigenvalues before evaluating selctg.
+		if scalea {
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, 1, wr, 1)
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, 1, wi, 1)
+		}
+		for i := 0; i < n; i++ {
+			bwork[i] = selctg(wr[i], wi[i])
+		}
+
+		// Reorder eigenvalues, transform Schur vectors, and compute
+		// reciprocal condition numbers.
+		compq := lapack.EVComp(lapack.None)
+		if wantvs {
+			compq = lapack.UpdateSchur
+		}
+		sdim, rconde, rcondv, reordered = impl.Dtrsen(sense, compq, bwork[:n], n, a, lda, vs, ldvs, wr, wi,
+			work[iwrk:], lwork-iwrk, iwork, liwork)
+		if !wantsn {
+			maxwrk = max(maxwrk, n+2*sdim*(n-sdim))
+		}
+		ok = reordered
+	}
+
+	if wantvs {
+		// Undo balancing.
+		impl.Dgebak(lapack.Permute, lapack.RightEV, n, ilo, ihi, workbal, n, vs, ldvs)
+	}
+
+	if scalea {
+		// Undo scaling for the Schur form of A.
+		impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, n, a, lda)
+		bi := blas64.Implementation()
+		bi.Dcopy(n, a, lda+1, wr, 1)
+		if wantsv && ok {
+			dum := []float64{rcondv}
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, 1, 1, dum, 1)
+			rcondv = dum[0]
+		}
+		if cscale == smlnum {
+			// If scaling back towards underflow, adjust wi if an
+			// offdiagonal element of a 2×2 block in the Schur form
+			// underflows.
+			var i1, i2 int
+			switch {
+			case first > 0:
+				i1 = first
+				i2 = ihi - 1
+				impl.Dlascl(lapack.General, 0, 0, cscale, anrm, ilo, 1, wi, 1)
+			case wantst:
+				i1 = 0
+				i2 = n - 2
+			default:
+				i1 = ilo
+				i2 = ihi - 1
+			}
+			for i := i1; i <= i2; i++ {
+				if wi[i] == 0 {
+					continue
+				}
+				if a[(i+1)*lda+i] == 0 {
+					wi[i] = 0
+					wi[i+1] = 0
+				} else if a[i*lda+i+1] == 0 {
+					wi[i] = 0
+					wi[i+1] = 0
+					// Swap the rows and columns of the block to make
+					// it upper triangular.
+					if i > 0 {
+						bi.Dswap(i, a[i:], lda, a[i+1:], lda)
+					}
+					if n > i+2 {
+						bi.Dswap(n-i-2, a[i*lda+i+2:], 1, a[(i+1)*lda+i+2:], 1)
+					}
+					if wantvs {
+						bi.Dswap(n, vs[i:], ldvs, vs[i+1:], ldvs)
+					}
+					a[i*lda+i+1] = a[(i+1)*lda+i]
+					a[(i+1)*lda+i] = 0
+				}
+				i++
+			}
+		}
+		// Undo scaling for the imaginary parts of the eigenvalues.
+		impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n-first, 1, wi[first:], 1)
+	}
+
+	if ok && wantst {
+		// Check if reordering was successful, that is, whether the
+		// leading sdim eigenvalues still satisfy selctg.
+		lastsl := true
+		lst2sl := true
+		sdim = 0
+		ip := 0
+		for i := 0; i < n; i++ {
+			cursl := selctg(wr[i], wi[i])
+			if wi[i] == 0 {
+				if cursl {
+					sdim++
+				}
+				ip = 0
+				if cursl && !lastsl {
+					ok = false
+				}
+			} else if ip == 1 {
+				// Last eigenvalue of conjugate pair.
+				cursl = cursl || lastsl
+				lastsl = cursl
+				if cursl {
+					sdim += 2
+				}
+				ip = -1
+				if cursl && !lst2sl {
+					ok = false
+				}
+			} else {
+				// First eigenvalue of conjugate pair.
+				ip = 1
+			}
+			lst2sl = lastsl
+			lastsl = cursl
+		}
+	}
+
+	work[0] = float64(maxwrk)
+	if wantsv {
+		iwork[0] = max(1, sdim*(n-sdim))
+	} else {
+		iwork[0] = 1
+	}
+	return sdim, rconde, rcondv, ok
+}
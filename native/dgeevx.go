@@ -0,0 +1,375 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgeevx computes the eigenvalues and, optionally, the left and/or right
+// eigenvectors for an n×n real nonsymmetric matrix A. Optionally, it also
+// balances A and computes the reciprocal condition numbers of the eigenvalues
+// and of the right eigenvectors.
+//
+// The eigenvectors are defined and stored in vl and vr as in Dgeev, they are
+// normalized to have Euclidean norm equal to 1 and largest component real.
+//
+// balanc specifies how A is balanced before computing the eigenvalues.
+// Balancing A involves permuting its rows and columns to make it more nearly
+// upper triangular and applying a diagonal similarity transformation D*A*D^{-1}
+// to make its rows and columns closer in norm. balanc can be one of
+//  lapack.None          A is neither permuted nor scaled,
+//  lapack.Permute       A is permuted but not scaled,
+//  lapack.Scale         A is scaled but not permuted,
+//  lapack.PermuteScale  A is both permuted and scaled.
+// Balancing does not change the eigenvalues and eigenvectors of A but it does
+// change their condition numbers because they are computed for the balanced
+// matrix. For other values of balanc Dgeevx will panic.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Dgeevx will panic.
+//
+// sense specifies which reciprocal condition numbers are computed. It can be
+// one of
+//  lapack.None             none are computed,
+//  lapack.CondEigenvalues  only for the eigenvalues,
+//  lapack.CondSubspace     only for the right eigenvectors,
+//  lapack.CondBoth         for the eigenvalues and the right eigenvectors.
+// If sense is lapack.CondEigenvalues or lapack.CondBoth, both left and right
+// eigenvectors must be computed. For other values of sense, or if this
+// condition is not met, Dgeevx will panic.
+//
+// On return, A will be overwritten. If eigenvectors or condition numbers are
+// requested, it contains the real Schur form of the balanced version of A.
+//
+// wr and wi contain the real and imaginary parts, respectively, of the computed
+// eigenvalues. Complex conjugate pairs of eigenvalues appear consecutively with
+// the eigenvalue having the positive imaginary part first.
+// wr and wi must have length n, and Dgeevx will panic otherwise.
+//
+// On return, ilo and ihi are the values computed by Dgebal when balancing A,
+// so that A[i,j] == 0 for i > j and j < ilo or i > ihi, and scale contains the
+// details of the permutations and scaling factors applied when balancing A as
+// described in Dgebal. scale must have length n. If balanc is lapack.None or
+// lapack.Scale, ilo == 0 and ihi == n-1.
+//
+// abnrm is the 1-norm of the balanced matrix, that is, the maximum absolute
+// column sum.
+//
+// If sense is lapack.CondEigenvalues or lapack.CondBoth, rconde[j] contains
+// the reciprocal condition number of the j-th eigenvalue, and rconde must have
+// length n. If sense is lapack.CondSubspace or lapack.CondBoth, rcondv[j]
+// contains the reciprocal condition number of the j-th right eigenvector, and
+// rcondv must have length n. Otherwise rconde and rcondv are not referenced,
+// respectively.
+//
+// work must have length at least lwork and lwork must be at least
+//  max(1,2*n)            if no eigenvectors are computed and sense is lapack.None,
+//  max(1,3*n)            if eigenvectors are computed and sense is lapack.None or lapack.CondEigenvalues,
+//  max(1,n*n+6*n)        otherwise.
+// For good performance, lwork must generally be larger. On return, optimal
+// value of lwork will be stored in work[0].
+//
+// iwork must have length at least 2*n-2 if sense is lapack.CondSubspace or
+// lapack.CondBoth, otherwise it is not referenced.
+//
+// If lwork == -1, instead of performing Dgeevx, the function only calculates
+// the optimal vaule of lwork and stores it into work[0].
+//
+// On return, first is the index of the first valid eigenvalue. If first == 0,
+// all eigenvalues and eigenvectors have been computed. If first is positive,
+// Dgeevx failed to compute all the eigenvalues, no eigenvectors or condition
+// numbers have been computed and wr[first:] and wi[first:] contain those
+// eigenvalues which have converged.
+func (impl Implementation) Dgeevx(balanc lapack.Job, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, sense lapack.CondJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, scale, rconde, rcondv, work []float64, lwork int, iwork []int) (ilo, ihi int, abnrm float64, first int) {
+	switch balanc {
+	default:
+		panic(badJob)
+	case lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale:
+	}
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	var wants, wantsp bool
+	switch sense {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.None:
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantsp = true
+	case lapack.CondBoth:
+		wants = true
+		wantsp = true
+	}
+	if wants && (!wantvl || !wantvr) {
+		panic("lapack: eigenvalue condition numbers require left and right eigenvectors")
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	wantv := wantvl || wantvr
+	var minwrk int
+	if wantv {
+		minwrk = 3 * n
+		if wantsp {
+			minwrk = max(minwrk, n*n+6*n)
+		}
+	} else {
+		minwrk = 2 * n
+		if sense != lapack.None {
+			minwrk = max(minwrk, n*n+6*n)
+		}
+	}
+	minwrk = max(1, minwrk)
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(wr) != n:
+			panic("lapack: bad length of wr")
+		case len(wi) != n:
+			panic("lapack: bad length of wi")
+		case len(scale) != n:
+			panic("lapack: bad length of scale")
+		case wants && len(rconde) != n:
+			panic("lapack: bad length of rconde")
+		case wantsp && len(rcondv) != n:
+			panic("lapack: bad length of rcondv")
+		case wantsp && len(iwork) < 2*n-2:
+			panic("lapack: insufficient length of iwork")
+		case lwork < minwrk:
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0, -1, 0, 0
+	}
+
+	maxwrk := n + n*impl.Ilaenv(1, "DGEHRD", " ", n, 1, n, 0)
+	if wantv {
+		maxwrk = max(maxwrk, n+(n-1)*impl.Ilaenv(1, "DORGHR", " ", n, 1, n, -1))
+		impl.Dhseqr(lapack.EigenvaluesAndSchur, lapack.OriginalEV, n, 0, n-1,
+			nil, 1, nil, nil, nil, 1, work, -1)
+		maxwrk = max(maxwrk, int(work[0]))
+		side := lapack.LeftEV
+		if wantvr {
+			side = lapack.RightEV
+		}
+		impl.Dtrevc3(side, lapack.AllEVMulQ, nil, n, nil, 1, nil, 1, nil, 1,
+			n, work, -1)
+		maxwrk = max(maxwrk, n+int(work[0]))
+		maxwrk = max(maxwrk, 3*n)
+	} else {
+		job := lapack.EigenvaluesOnly
+		if sense != lapack.None {
+			job = lapack.EigenvaluesAndSchur
+		}
+		impl.Dhseqr(job, lapack.None, n, 0, n-1,
+			nil, 1, nil, nil, nil, 1, work, -1)
+		maxwrk = max(maxwrk, int(work[0]))
+	}
+	maxwrk = max(maxwrk, minwrk)
+
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return 0, 0, 0, 0
+	}
+
+	// Get machine constants.
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum,bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil)
+	var scalea bool
+	var cscale float64
+	if 0 < anrm && anrm < smlnum {
+		scalea = true
+		cscale = smlnum
+	} else if anrm > bignum {
+		scalea = true
+		cscale = bignum
+	}
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrm, cscale, n, n, a, lda)
+	}
+
+	// Balance the matrix and compute its 1-norm.
+	ilo, ihi = impl.Dgebal(balanc, n, a, lda, scale)
+	abnrm = impl.Dlange(lapack.MaxColumnSum, n, n, a, lda, work)
+	if scalea {
+		dum := []float64{abnrm}
+		impl.Dlascl(lapack.General, 0, 0, cscale, anrm, 1, 1, dum, 1)
+		abnrm = dum[0]
+	}
+
+	// Reduce to upper Hessenberg form.
+	iwrk := n
+	tau := work[:iwrk-1]
+	impl.Dgehrd(n, ilo, ihi, a, lda, tau, work[iwrk:], lwork-iwrk)
+
+	var side lapack.EVSide
+	if wantvl {
+		side = lapack.LeftEV
+		// Copy Householder vectors to VL.
+		impl.Dlacpy(blas.Lower, n, n, a, lda, vl, ldvl)
+		// Generate orthogonal matrix in VL.
+		impl.Dorghr(n, ilo, ihi, vl, ldvl, tau, work[iwrk:], lwork-iwrk)
+		// Perform QR iteration, accumulating Schur vectors in VL.
+		iwrk = 0
+		first = impl.Dhseqr(lapack.EigenvaluesAndSchur, lapack.OriginalEV, n, ilo, ihi,
+			a, lda, wr, wi, vl, ldvl, work[iwrk:], lwork-iwrk)
+		if wantvr {
+			// Want left and right eigenvectors.
+			// Copy Schur vectors to VR.
+			side = lapack.RightLeftEV
+			impl.Dlacpy(blas.All, n, n, vl, ldvl, vr, ldvr)
+		}
+	} else if wantvr {
+		side = lapack.RightEV
+		// Copy Householder vectors to VR.
+		impl.Dlacpy(blas.Lower, n, n, a, lda, vr, ldvr)
+		// Generate orthogonal matrix in VR.
+		impl.Dorghr(n, ilo, ihi, vr, ldvr, tau, work[iwrk:], lwork-iwrk)
+		// Perform QR iteration, accumulating Schur vectors in VR.
+		iwrk = 0
+		first = impl.Dhseqr(lapack.EigenvaluesAndSchur, lapack.OriginalEV, n, ilo, ihi,
+			a, lda, wr, wi, vr, ldvr, work[iwrk:], lwork-iwrk)
+	} else {
+		// Compute eigenvalues only. If condition numbers are desired,
+		// compute the Schur form.
+		job := lapack.EigenvaluesOnly
+		if sense != lapack.None {
+			job = lapack.EigenvaluesAndSchur
+		}
+		iwrk = 0
+		first = impl.Dhseqr(job, lapack.None, n, ilo, ihi,
+			a, lda, wr, wi, nil, 1, work[iwrk:], lwork-iwrk)
+	}
+
+	if first > 0 {
+		if scalea {
+			// Undo scaling.
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n-first, 1, wr[first:], 1)
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n-first, 1, wi[first:], 1)
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, ilo, 1, wr, 1)
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, ilo, 1, wi, 1)
+		}
+		work[0] = float64(maxwrk)
+		return ilo, ihi, abnrm, first
+	}
+
+	if wantv {
+		// Compute left and/or right eigenvectors.
+		impl.Dtrevc3(side, lapack.AllEVMulQ, nil, n,
+			a, lda, vl, ldvl, vr, ldvr, n, work[iwrk:], lwork-iwrk)
+	}
+
+	if sense != lapack.None {
+		// Compute the reciprocal condition numbers.
+		impl.Dtrsna(sense, lapack.AllEV, nil, n, a, lda, vl, ldvl, vr, ldvr,
+			rconde, rcondv, n, work[iwrk:], n, iwork)
+	}
+
+	bi := blas64.Implementation()
+	if wantvl {
+		// Undo balancing of left eigenvectors.
+		impl.Dgebak(balanc, lapack.LeftEV, n, ilo, ihi, scale, n, vl, ldvl)
+		// Normalize left eigenvectors and make largest component real.
+		for i, wii := range wi {
+			if wii < 0 {
+				continue
+			}
+			if wii == 0 {
+				scl := 1 / bi.Dnrm2(n, vl[i:], ldvl)
+				bi.Dscal(n, scl, vl[i:], ldvl)
+				continue
+			}
+			scl := 1 / impl.Dlapy2(bi.Dnrm2(n, vl[i:], ldvl), bi.Dnrm2(n, vl[i+1:], ldvl))
+			bi.Dscal(n, scl, vl[i:], ldvl)
+			bi.Dscal(n, scl, vl[i+1:], ldvl)
+			for k := 0; k < n; k++ {
+				vi := vl[k*ldvl+i]
+				vi1 := vl[k*ldvl+i+1]
+				work[k] = vi*vi + vi1*vi1
+			}
+			k := bi.Idamax(n, work[:n], 1)
+			cs, sn, _ := impl.Dlartg(vl[k*ldvl+i], vl[k*ldvl+i+1])
+			bi.Drot(n, vl[i:], ldvl, vl[i+1:], ldvl, cs, sn)
+			vl[k*ldvl+i+1] = 0
+		}
+	}
+	if wantvr {
+		// Undo balancing of right eigenvectors.
+		impl.Dgebak(balanc, lapack.RightEV, n, ilo, ihi, scale, n, vr, ldvr)
+		// Normalize right eigenvectors and make largest component real.
+		for i, wii := range wi {
+			if wii < 0 {
+				continue
+			}
+			if wii == 0 {
+				scl := 1 / bi.Dnrm2(n, vr[i:], ldvr)
+				bi.Dscal(n, scl, vr[i:], ldvr)
+				continue
+			}
+			scl := 1 / impl.Dlapy2(bi.Dnrm2(n, vr[i:], ldvr), bi.Dnrm2(n, vr[i+1:], ldvr))
+			bi.Dscal(n, scl, vr[i:], ldvr)
+			bi.Dscal(n, scl, vr[i+1:], ldvr)
+			for k := 0; k < n; k++ {
+				vi := vr[k*ldvr+i]
+				vi1 := vr[k*ldvr+i+1]
+				work[k] = vi*vi + vi1*vi1
+			}
+			k := bi.Idamax(n, work[:n], 1)
+			cs, sn, _ := impl.Dlartg(vr[k*ldvr+i], vr[k*ldvr+i+1])
+			bi.Drot(n, vr[i:], ldvr, vr[i+1:], ldvr, cs, sn)
+			vr[k*ldvr+i+1] = 0
+		}
+	}
+
+	if scalea {
+		// Undo scaling.
+		impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, 1, wr, 1)
+		impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, 1, wi, 1)
+		if wantsp {
+			impl.Dlascl(lapack.General, 0, 0, cscale, anrm, n, 1, rcondv, 1)
+		}
+	}
+
+	work[0] = float64(maxwrk)
+	return ilo, ihi, abnrm, first
+}
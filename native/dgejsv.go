@@ -0,0 +1,1115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgejsv computes the singular value decomposition of an m×n matrix A with
+// m >= n using the preconditioned one-sided Jacobi method. The singular value
+// decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an n×n diagonal matrix containing the singular values of A,
+// U is an m×n matrix with orthonormal columns and V is an n×n orthogonal
+// matrix. A is first preconditioned by a rank-revealing QR factorization, and
+// the one-sided Jacobi method of Dgesvj is applied to the triangular factor.
+// The singular values of matrices of the form A = D1*C*D2, where D1 and D2
+// are diagonal and C has well conditioned columns, are computed to high
+// relative accuracy.
+//
+// joba specifies the accuracy requirements.
+//  joba == lapack.ColumnAccuracy        High relative accuracy is computed
+//                                       for A = B*D where B has well
+//                                       conditioned columns.
+//  joba == lapack.ColumnAccuracyCond    As lapack.ColumnAccuracy, and the
+//                                       condition number of B is estimated.
+//  joba == lapack.RowColumnAccuracy     As lapack.ColumnAccuracy, but the
+//                                       rows of A are also pivoted so that
+//                                       A = D1*C*D2 may be badly row scaled.
+//  joba == lapack.RowColumnAccuracyCond As lapack.RowColumnAccuracy, and the
+//                                       condition number of C is estimated.
+//  joba == lapack.AbsoluteAccuracy      The singular values are computed with
+//                                       the usual backward stability, and
+//                                       singular values smaller than about
+//                                       sqrt(n)*eps*||A|| are set to zero.
+//  joba == lapack.RankRevealing         As lapack.AbsoluteAccuracy, but A is
+//                                       treated as numerically rank deficient
+//                                       if its QR factor has a sudden drop on
+//                                       the diagonal.
+//
+// jobu specifies whether the left singular vectors are computed.
+//  jobu == lapack.ComputeLeftSV    The first n left singular vectors are
+//                                  returned in the m×n matrix u.
+//  jobu == lapack.ComputeAllLeftSV All m left singular vectors are returned
+//                                  in the m×m matrix u.
+//  jobu == lapack.LeftSVWork       The left singular vectors are not
+//                                  computed, but u may be used as workspace
+//                                  (see transpose).
+//  jobu == lapack.None             u is not referenced.
+//
+// jobv specifies whether the right singular vectors are computed.
+//  jobv == lapack.ComputeRightSV       The n×n matrix V is returned in v.
+//  jobv == lapack.ComputeRightSVJacobi The n×n matrix V is returned in v and
+//                                      is computed by accumulating the Jacobi
+//                                      rotations. jobu must request the left
+//                                      singular vectors.
+//  jobv == lapack.RightSVWork          The right singular vectors are not
+//                                      computed, but v may be used as
+//                                      workspace (see transpose).
+//  jobv == lapack.None                 v is not referenced.
+//
+// If restrictRange is true, the columns of A with norm below the square root
+// of the safe minimum relative to the largest column are set to zero, that is
+// the range of the computed singular values is restricted. Otherwise only the
+// columns with norm below the underflow threshold are set to zero.
+//
+// If transpose is true and A is square, Dgejsv may decide to compute the SVD
+// of A^T instead of A, if that is expected to converge faster. When only one
+// set of singular vectors is requested, transposition is attempted only if
+// the other set is specified as workspace, in which case u or v must be of
+// size n×n.
+//
+// If perturb is true, small perturbations are introduced into the triangular
+// factors to avoid computation with denormalized numbers.
+//
+// On return, sva holds the n computed singular values of A scaled by
+// work[1]/work[0] in non-increasing order. That is, the singular values of
+// A are work[0]/work[1]*sva[i]. sva must have length at least n.
+//
+// work must have length at least lwork. If lwork == -1, instead of
+// performing Dgejsv, the minimum work length will be stored into work[0].
+// Otherwise lwork must be at least
+//  max(7, 4*n+1, 2*m+n)         if no singular vectors are computed and
+//                               the condition number is not estimated,
+//  max(7, 4*n+n*n, 2*m+n)       if no singular vectors are computed and
+//                               the condition number is estimated,
+//  max(7, 2*m+n, 4*n+1)         if one set of singular vectors is computed,
+//  max(2*m+n, 6*n+2*n*n)        if both sets of singular vectors are computed
+//                               and jobv is lapack.ComputeRightSV,
+//  max(2*m+n, 4*n+n*n, 2*n+n*n+6) if both sets of singular vectors are computed
+//                               and jobv is lapack.ComputeRightSVJacobi.
+// On return,
+//  work[0] and work[1] are the scaling factors of the singular values,
+//  work[2] is an estimate of the scaled condition number of A if joba is
+//          lapack.ColumnAccuracyCond or lapack.RowColumnAccuracyCond,
+//  work[3] and work[4] are estimates of the scaled condition numbers of the
+//          triangular factors if both sets of singular vectors are computed,
+//  work[5] and work[6] are the entropies of A^T*A and A*A^T if transposition
+//          was considered.
+//
+// iwork must have length at least m+3*n. On return,
+//  iwork[0] is the numerical rank determined after the first QR
+//           factorization,
+//  iwork[1] is the number of computed nonzero singular values,
+//  iwork[2] is 1 if column norms of A were below the underflow threshold
+//           and high relative accuracy could not be guaranteed, and 0
+//           otherwise.
+//
+// Dgejsv returns whether the Jacobi iteration converged.
+func (impl Implementation) Dgejsv(joba lapack.SVDAccuracy, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, restrictRange, transpose, perturb bool, m, n int, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (ok bool) {
+	switch joba {
+	default:
+		panic(badSVDAccuracy)
+	case lapack.ColumnAccuracy, lapack.ColumnAccuracyCond, lapack.RowColumnAccuracy,
+		lapack.RowColumnAccuracyCond, lapack.AbsoluteAccuracy, lapack.RankRevealing:
+	}
+	rowpiv := joba == lapack.RowColumnAccuracy || joba == lapack.RowColumnAccuracyCond
+	l2rank := joba == lapack.RankRevealing
+	l2aber := joba == lapack.AbsoluteAccuracy
+	errest := joba == lapack.ColumnAccuracyCond || joba == lapack.RowColumnAccuracyCond
+
+	lsvec := jobu == lapack.ComputeLeftSV || jobu == lapack.ComputeAllLeftSV
+	if !lsvec && jobu != lapack.LeftSVWork && jobu != lapack.None {
+		panic(badLeftSVJob)
+	}
+	jracc := jobv == lapack.ComputeRightSVJacobi
+	rsvec := jobv == lapack.ComputeRightSV || jracc
+	if !rsvec && jobv != lapack.RightSVWork && jobv != lapack.None {
+		panic(badRightSVJob)
+	}
+	if jracc && !lsvec {
+		panic(badRightSVJob)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 || n > m {
+		panic(badDims)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(sva) < n {
+		panic(badSVA)
+	}
+	n1 := n
+	if jobu == lapack.ComputeAllLeftSV {
+		n1 = m
+	}
+	// Transposition is only possible if both u and v are available.
+	l2tran := transpose && m == n &&
+		(lsvec == rsvec || (lsvec && jobv == lapack.RightSVWork) || (rsvec && jobu == lapack.LeftSVWork))
+	if lsvec || (l2tran && jobu == lapack.LeftSVWork) {
+		checkMatrix(m, n1, u, ldu)
+	}
+	if rsvec || (l2tran && jobv == lapack.RightSVWork) {
+		checkMatrix(n, n, v, ldv)
+	}
+	var minWork int
+	switch {
+	case !lsvec && !rsvec && !errest:
+		minWork = max(7, max(4*n+1, 2*m+n))
+	case !lsvec && !rsvec:
+		minWork = max(7, max(4*n+n*n, 2*m+n))
+	case lsvec != rsvec:
+		minWork = max(7, max(2*m+n, 4*n+1))
+	case !jracc:
+		minWork = max(2*m+n, 6*n+2*n*n)
+	default:
+		minWork = max(2*m+n, max(4*n+n*n, 2*n+n*n+6))
+	}
+	if lwork == -1 {
+		work[0] = float64(minWork)
+		return true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minWork {
+		panic(badWork)
+	}
+	if len(iwork) < m+3*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	bi := blas64.Implementation()
+
+	const (
+		eps   = dlamchE
+		sfmin = dlamchS
+		small = sfmin / eps
+		big   = math.MaxFloat64
+	)
+
+	// Initialize sva with the column norms of A. If necessary, scale them
+	// to protect the largest norm from overflow.
+	scalem := 1 / math.Sqrt(float64(m)*float64(n))
+	noscal := true
+	goscal := true
+	for p := 0; p < n; p++ {
+		aapp, aaqq := impl.Dlassq(m, a[p:], lda, 0, 1)
+		aaqq = math.Sqrt(aaqq)
+		if aapp < big/aaqq && noscal {
+			sva[p] = aapp * aaqq
+		} else {
+			noscal = false
+			sva[p] = aapp * (aaqq * scalem)
+			if goscal {
+				goscal = false
+				bi.Dscal(p, scalem, sva, 1)
+			}
+		}
+	}
+	if noscal {
+		scalem = 1
+	}
+
+	aapp := 0.0
+	aaqq := big
+	for _, s := range sva[:n] {
+		aapp = math.Max(aapp, s)
+		if s != 0 {
+			aaqq = math.Min(aaqq, s)
+		}
+	}
+
+	// Quick return for the zero matrix.
+	if aapp == 0 {
+		if lsvec {
+			impl.Dlaset(blas.All, m, n1, 0, 1, u, ldu)
+		}
+		if rsvec {
+			impl.Dlaset(blas.All, n, n, 0, 1, v, ldv)
+		}
+		work[0] = 1
+		work[1] = 1
+		if errest {
+			work[2] = 1
+		}
+		if lsvec && rsvec {
+			work[3] = 1
+			work[4] = 1
+		}
+		if l2tran {
+			work[5] = 0
+			work[6] = 0
+		}
+		iwork[0] = 0
+		iwork[1] = 0
+		iwork[2] = 0
+		return true
+	}
+
+	// Issue a warning if denormalized column norms are detected. High
+	// relative accuracy cannot be guaranteed in that case, so the columns
+	// whose norms are less than about sigma_max/big may be set to zero.
+	l2kill := restrictRange
+	var warning int
+	if aaqq <= sfmin {
+		l2rank = true
+		l2kill = true
+		warning = 1
+	}
+
+	// Quick return for a one-column matrix.
+	if n == 1 {
+		if lsvec {
+			impl.Dlascl(lapack.General, 0, 0, sva[0], scalem, m, 1, a, lda)
+			impl.Dlacpy(blas.All, m, 1, a, lda, u, ldu)
+			if n1 != n {
+				// Compute all m left singular vectors of the m×1
+				// matrix.
+				impl.Dgeqrf(m, n, u, ldu, work[:1], work[n:], lwork-n)
+				impl.Dorgqr(m, n1, 1, u, ldu, work[:1], work[n:], lwork-n)
+				bi.Dcopy(m, a, lda, u, ldu)
+			}
+		}
+		if rsvec {
+			v[0] = 1
+		}
+		if sva[0] < big*scalem {
+			sva[0] /= scalem
+			scalem = 1
+		}
+		work[0] = 1 / scalem
+		work[1] = 1
+		iwork[0] = 0
+		iwork[1] = 0
+		if sva[0] != 0 {
+			iwork[0] = 1
+			if sva[0]/scalem >= sfmin {
+				iwork[1] = 1
+			}
+		}
+		iwork[2] = 0
+		if errest {
+			work[2] = 1
+		}
+		if lsvec && rsvec {
+			work[3] = 1
+			work[4] = 1
+		}
+		if l2tran {
+			work[5] = 0
+			work[6] = 0
+		}
+		return true
+	}
+
+	aatmax := -1.0
+	aatmin := big
+	if rowpiv || l2tran {
+		// Compute the row norms needed to determine the row pivoting
+		// sequence and to compare the structures of A*A^T and A^T*A.
+		if l2tran {
+			for p := 0; p < m; p++ {
+				// Dlassq gets both the 2-norm and the infinity norm
+				// in one pass through the vector.
+				xsc, temp1 := impl.Dlassq(n, a[p*lda:], 1, 0, 1)
+				work[m+n+p] = xsc * scalem
+				work[n+p] = xsc * (scalem * math.Sqrt(temp1))
+				aatmax = math.Max(aatmax, work[n+p])
+				if work[n+p] != 0 {
+					aatmin = math.Min(aatmin, work[n+p])
+				}
+			}
+		} else {
+			for p := 0; p < m; p++ {
+				work[m+n+p] = scalem * math.Abs(a[p*lda+bi.Idamax(n, a[p*lda:], 1)])
+				aatmax = math.Max(aatmax, work[m+n+p])
+				aatmin = math.Min(aatmin, work[m+n+p])
+			}
+		}
+	}
+
+	// For a square matrix A, determine whether A^T would be a better input
+	// for the preconditioned Jacobi SVD, with faster convergence. The
+	// decision is based on the Shannon entropy of the distributions of the
+	// squared column and row norms, which are the diagonals of A^T*A and
+	// A*A^T scaled by their common trace. Smaller entropy usually means
+	// better input for the algorithm.
+	var transp bool
+	var entra, entrat float64
+	if l2tran {
+		xsc, temp1 := impl.Dlassq(n, sva, 1, 0, 1)
+		temp1 = 1 / temp1
+		for p := 0; p < n; p++ {
+			big1 := (sva[p] / xsc) * (sva[p] / xsc) * temp1
+			if big1 != 0 {
+				entra += big1 * math.Log(big1)
+			}
+		}
+		entra = -entra / math.Log(float64(n))
+		for p := n; p < n+m; p++ {
+			big1 := (work[p] / xsc) * (work[p] / xsc) * temp1
+			if big1 != 0 {
+				entrat += big1 * math.Log(big1)
+			}
+		}
+		entrat = -entrat / math.Log(float64(m))
+
+		transp = entrat < entra
+		if transp {
+			for p := 0; p < n-1; p++ {
+				for q := p + 1; q < n; q++ {
+					a[q*lda+p], a[p*lda+q] = a[p*lda+q], a[q*lda+p]
+				}
+			}
+			for p := 0; p < n; p++ {
+				work[m+n+p] = sva[p]
+				sva[p] = work[n+p]
+			}
+			aapp, aatmax = aatmax, aapp
+			aaqq, aatmin = aatmin, aaqq
+			lsvec, rsvec = rsvec, lsvec
+			if lsvec {
+				n1 = n
+			}
+			rowpiv = true
+		}
+	}
+
+	// Scale the matrix so that its largest column has Euclidean norm
+	// sqrt(big/n), which keeps the largest singular value below sqrt(big).
+	big1 := math.Sqrt(big)
+	temp1 := math.Sqrt(big / float64(n))
+	impl.Dlascl(lapack.General, 0, 0, aapp, temp1, n, 1, sva, 1)
+	if aaqq > aapp*sfmin {
+		aaqq = (aaqq / aapp) * temp1
+	} else {
+		aaqq = (aaqq * temp1) / aapp
+	}
+	temp1 *= scalem
+	impl.Dlascl(lapack.General, 0, 0, aapp, temp1, m, n, a, lda)
+
+	// To undo the scaling at the end, the computed singular values are
+	// multiplied by uscal2/uscal1.
+	uscal1 := temp1
+	uscal2 := aapp
+
+	var xsc float64
+	if l2kill {
+		// Restrict the computation of nonzero singular values to the
+		// range of condition numbers of about sqrt(big)/sqrt(sfmin).
+		xsc = math.Sqrt(sfmin)
+	} else {
+		xsc = small
+		// If the condition number of A is too large, the full SVD is
+		// computed with accumulated Jacobi rotations as a precaution.
+		if aaqq < math.Sqrt(sfmin) && lsvec && rsvec {
+			jracc = true
+		}
+	}
+	if aaqq < xsc {
+		for p := 0; p < n; p++ {
+			if sva[p] < xsc {
+				impl.Dlaset(blas.All, m, 1, 0, 0, a[p:], lda)
+				sva[p] = 0
+			}
+		}
+	}
+
+	if rowpiv {
+		// Pivot the rows so that their infinity norms are
+		// non-increasing. Combined with column pivoting, this has an
+		// effect similar to complete pivoting.
+		for p := 0; p < m-1; p++ {
+			q := bi.Idamax(m-p, work[m+n+p:], 1) + p
+			iwork[2*n+p] = q
+			if p != q {
+				work[m+n+p], work[m+n+q] = work[m+n+q], work[m+n+p]
+			}
+		}
+		impl.Dlaswp(n, a, lda, 0, m-2, iwork[2*n:2*n+m-1], 1)
+	}
+
+	// Precondition A with the QR factorization with column pivoting
+	//  A * P1 = Q1 * [ R1 ]
+	//                [ 0  ]
+	for p := 0; p < n; p++ {
+		iwork[p] = -1
+	}
+	impl.Dgeqp3(m, n, a, lda, iwork[:n], work[:n], work[n:], lwork-n)
+
+	// Inspect R1 for numerical rank deficiency.
+	nr := 1
+	switch {
+	case l2aber:
+		// Flush all sigma_i < sqrt(n)*eps*||A|| to zero. This enforces
+		// a lower numerical rank by a backward error of the order of
+		// sqrt(n)*eps*||A||.
+		temp1 := math.Sqrt(float64(n)) * eps
+		for p := 1; p < n; p++ {
+			if math.Abs(a[p*lda+p]) < temp1*math.Abs(a[0]) {
+				break
+			}
+			nr++
+		}
+	case l2rank:
+		// Use a sudden drop on the diagonal of R1 as the criterion for
+		// rank deficiency.
+		temp1 := math.Sqrt(sfmin)
+		for p := 1; p < n; p++ {
+			d := math.Abs(a[p*lda+p])
+			if d < eps*math.Abs(a[(p-1)*lda+p-1]) || d < small || (l2kill && d < temp1) {
+				break
+			}
+			nr++
+		}
+	default:
+		// Only remove the underflowed part of R1, because high
+		// relative accuracy is the goal.
+		temp1 := math.Sqrt(sfmin)
+		for p := 1; p < n; p++ {
+			d := math.Abs(a[p*lda+p])
+			if d < small || (l2kill && d < temp1) {
+				break
+			}
+			nr++
+		}
+	}
+
+	var almort bool
+	if nr == n {
+		maxprj := 1.0
+		for p := 1; p < n; p++ {
+			maxprj = math.Min(maxprj, math.Abs(a[p*lda+p])/sva[iwork[p]])
+		}
+		almort = maxprj*maxprj >= 1-float64(n)*eps
+	}
+
+	sconda := -1.0
+	condr1 := -1.0
+	condr2 := -1.0
+	if errest && n == nr {
+		// Estimate the condition number of R1 with unit columns.
+		var rcond float64
+		switch {
+		case rsvec:
+			// V is available as workspace.
+			impl.Dlacpy(blas.Upper, n, n, a, lda, v, ldv)
+			for p := 0; p < n; p++ {
+				bi.Dscal(p+1, 1/sva[iwork[p]], v[p:], ldv)
+			}
+			rcond = impl.Dpocon(blas.Upper, n, v, ldv, 1, work[n:], iwork[2*n+m:])
+		case lsvec:
+			// U is available as workspace.
+			impl.Dlacpy(blas.Upper, n, n, a, lda, u, ldu)
+			for p := 0; p < n; p++ {
+				bi.Dscal(p+1, 1/sva[iwork[p]], u[p:], ldu)
+			}
+			rcond = impl.Dpocon(blas.Upper, n, u, ldu, 1, work[n:], iwork[2*n+m:])
+		default:
+			impl.Dlacpy(blas.Upper, n, n, a, lda, work[n:], n)
+			for p := 0; p < n; p++ {
+				bi.Dscal(p+1, 1/sva[iwork[p]], work[n+p:], n)
+			}
+			rcond = impl.Dpocon(blas.Upper, n, work[n:], n, 1, work[n+n*n:], iwork[2*n+m:])
+		}
+		// sconda is an estimate of sqrt(||(R^T * R)^{-1}||_1) so that
+		//  n^{-1/4} * sconda <= ||R^{-1}||_2 <= n^{1/4} * sconda.
+		sconda = 1 / math.Sqrt(rcond)
+	}
+
+	// Without violent scaling the artificial perturbation is not needed.
+	l2pert := perturb && math.Abs(a[0]/a[(nr-1)*lda+nr-1]) > math.Sqrt(big1)
+
+	var scalem2 float64
+	var numrank int
+	switch {
+	case !rsvec && !lsvec:
+		// Compute the singular values only.
+
+		// Transpose the leading nr rows of A into the lower triangle.
+		for p := 0; p < min(n-1, nr); p++ {
+			bi.Dcopy(n-p-1, a[p*lda+p+1:], 1, a[(p+1)*lda+p:], lda)
+		}
+		if !almort {
+			if l2pert {
+				// Perturb the small entries to avoid
+				// denormalized numbers in the second QR
+				// factorization, without changing the singular
+				// values.
+				xsc := eps / float64(n)
+				for q := 0; q < nr; q++ {
+					temp1 := xsc * math.Abs(a[q*lda+q])
+					for p := 0; p < n; p++ {
+						if (p > q && math.Abs(a[p*lda+q]) <= temp1) || p < q {
+							a[p*lda+q] = math.Copysign(temp1, a[p*lda+q])
+						}
+					}
+				}
+			} else {
+				impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, a[1:], lda)
+			}
+
+			// Precondition with the second QR factorization and
+			// transpose the upper triangular factor to lower.
+			impl.Dgeqrf(n, nr, a, lda, work[:nr], work[n:], lwork-n)
+			for p := 0; p < nr-1; p++ {
+				bi.Dcopy(nr-p-1, a[p*lda+p+1:], 1, a[(p+1)*lda+p:], lda)
+			}
+		}
+
+		// Apply the one-sided Jacobi rotations to the lower triangular
+		// matrix, again perturbed to drown denormals.
+		if l2pert {
+			xsc := eps / float64(n)
+			for q := 0; q < nr; q++ {
+				temp1 := xsc * math.Abs(a[q*lda+q])
+				for p := 0; p < nr; p++ {
+					if (p > q && math.Abs(a[p*lda+q]) <= temp1) || p < q {
+						a[p*lda+q] = math.Copysign(temp1, a[p*lda+q])
+					}
+				}
+			}
+		} else {
+			impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, a[1:], lda)
+		}
+		ok = impl.Dgesvj(lapack.LowerTri, lapack.None, lapack.None, nr, nr, a, lda, sva, n, nil, 1, work, lwork)
+		scalem2 = work[0]
+		numrank = int(work[1])
+
+	case rsvec && !lsvec:
+		// Compute the singular values and the right singular vectors.
+		if almort {
+			// In this case nr equals n.
+			for p := 0; p < nr; p++ {
+				bi.Dcopy(n-p, a[p*lda+p:], 1, v[p*ldv+p:], ldv)
+			}
+			impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+			ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.None, n, nr, v, ldv, sva, nr, nil, 1, work, lwork)
+			scalem2 = work[0]
+			numrank = int(work[1])
+		} else {
+			// Two more QR factorizations avoid the need to
+			// accumulate the Jacobi rotations.
+			impl.Dlaset(blas.Lower, nr-1, nr-1, 0, 0, a[lda:], lda)
+			impl.Dgelqf(nr, n, a, lda, work[:nr], work[n:], lwork-n)
+			impl.Dlacpy(blas.Lower, nr, nr, a, lda, v, ldv)
+			impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+			impl.Dgeqrf(nr, nr, v, ldv, work[n:n+nr], work[2*n:], lwork-2*n)
+			for p := 0; p < nr; p++ {
+				bi.Dcopy(nr-p, v[p*ldv+p:], 1, v[p*ldv+p:], ldv)
+			}
+			impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+
+			ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.None, nr, nr, v, ldv, sva, nr, nil, 1, work[n:], lwork-n)
+			scalem2 = work[n]
+			numrank = int(work[n+1])
+			if nr < n {
+				impl.Dlaset(blas.All, n-nr, nr, 0, 0, v[nr*ldv:], ldv)
+				impl.Dlaset(blas.All, nr, n-nr, 0, 0, v[nr:], ldv)
+				impl.Dlaset(blas.All, n-nr, n-nr, 0, 1, v[nr*ldv+nr:], ldv)
+			}
+			impl.Dormlq(blas.Left, blas.Trans, n, n, nr, a, lda, work[:nr], v, ldv, work[n:], lwork-n)
+		}
+
+		// Permute the rows of V with the permutation from the first QR
+		// factorization.
+		for p := 0; p < n; p++ {
+			bi.Dcopy(n, v[p*ldv:], 1, a[iwork[p]*lda:], 1)
+		}
+		impl.Dlacpy(blas.All, n, n, a, lda, v, ldv)
+		if transp {
+			impl.Dlacpy(blas.All, n, n, v, ldv, u, ldu)
+		}
+
+	case lsvec && !rsvec:
+		// Compute the singular values and the left singular vectors.
+
+		// The second preconditioning step avoids the need to
+		// accumulate the Jacobi rotations.
+		for p := 0; p < nr; p++ {
+			bi.Dcopy(n-p, a[p*lda+p:], 1, u[p*ldu+p:], ldu)
+		}
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, u[1:], ldu)
+		impl.Dgeqrf(n, nr, u, ldu, work[n:n+nr], work[2*n:], lwork-2*n)
+		for p := 0; p < nr-1; p++ {
+			bi.Dcopy(nr-p-1, u[p*ldu+p+1:], 1, u[(p+1)*ldu+p:], ldu)
+		}
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, u[1:], ldu)
+
+		ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.None, nr, nr, u, ldu, sva, nr, nil, 1, work[n:], lwork-n)
+		scalem2 = work[n]
+		numrank = int(work[n+1])
+
+		if nr < m {
+			impl.Dlaset(blas.All, m-nr, nr, 0, 0, u[nr*ldu:], ldu)
+			if nr < n1 {
+				impl.Dlaset(blas.All, nr, n1-nr, 0, 0, u[nr:], ldu)
+				impl.Dlaset(blas.All, m-nr, n1-nr, 0, 1, u[nr*ldu+nr:], ldu)
+			}
+		}
+		impl.Dormqr(blas.Left, blas.NoTrans, m, n1, n, a, lda, work[:n], u, ldu, work[n:], lwork-n)
+		if rowpiv {
+			impl.Dlaswp(n1, u, ldu, 0, m-2, iwork[2*n:2*n+m-1], -1)
+		}
+		for p := 0; p < n1; p++ {
+			bi.Dscal(m, 1/bi.Dnrm2(m, u[p:], ldu), u[p:], ldu)
+		}
+		if transp {
+			impl.Dlacpy(blas.All, n, n, u, ldu, v, ldv)
+		}
+
+	default:
+		// Compute the full SVD.
+		if jracc {
+			scalem2, numrank, ok = impl.dgejsvJacobi(m, n, n1, nr, l2pert, rowpiv, a, lda, sva, u, ldu, v, ldv, work, lwork, iwork)
+		} else if almort {
+			scalem2, numrank, ok = impl.dgejsvAlmort(m, n, n1, l2pert, rowpiv, a, lda, sva, u, ldu, v, ldv, work, lwork, iwork)
+		} else {
+			scalem2, numrank, condr1, condr2, ok = impl.dgejsvFull(m, n, n1, nr, l2pert, rowpiv, a, lda, sva, u, ldu, v, ldv, work, lwork, iwork)
+		}
+		if transp {
+			// Swap U and V because the SVD of A^T was computed.
+			for p := 0; p < n; p++ {
+				bi.Dswap(n, u[p*ldu:], 1, v[p*ldv:], 1)
+			}
+		}
+	}
+
+	// Undo the scaling if possible.
+	if uscal2 <= (big/sva[0])*uscal1 {
+		impl.Dlascl(lapack.General, 0, 0, uscal1, uscal2, nr, 1, sva, 1)
+		uscal1 = 1
+		uscal2 = 1
+	}
+	for p := nr; p < n; p++ {
+		sva[p] = 0
+	}
+
+	work[0] = uscal2 * scalem2
+	work[1] = uscal1
+	if errest {
+		work[2] = sconda
+	}
+	if lsvec && rsvec {
+		work[3] = condr1
+		work[4] = condr2
+	}
+	if l2tran {
+		work[5] = entra
+		work[6] = entrat
+	}
+	iwork[0] = nr
+	iwork[1] = numrank
+	iwork[2] = warning
+	return ok
+}
+
+// dgejsvFull computes the full SVD in Dgejsv after the first QR factorization
+// with column pivoting of A stored in a, work[:n] and iwork[:n]. R1 is
+// preconditioned by a second QR factorization, with column pivoting if it is
+// ill-conditioned, and the right singular vectors are recovered from a well
+// conditioned triangular matrix equation.
+//
+// dgejsvFull returns the scaling factor of the singular values in sva, the
+// number of nonzero singular values, the estimated condition numbers of the
+// triangular factors and whether the Jacobi iteration converged.
+func (impl Implementation) dgejsvFull(m, n, n1, nr int, l2pert, rowpiv bool, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (scalem float64, numrank int, condr1, condr2 float64, ok bool) {
+	const (
+		eps   = dlamchE
+		small = dlamchS / dlamchE
+	)
+	bi := blas64.Implementation()
+
+	// The workspace is laid out as
+	//  work[:n]                      the reflectors of Q1,
+	//  work[n:n+nr]                  the reflectors of Q2,
+	//  work[2*n:2*n+n*nr]            a copy of the second QR factor as an
+	//                                n×nr matrix,
+	//  work[2*n+n*nr:2*n+n*nr+nr]    the reflectors of Q3,
+	//  work[2*n+n*nr+nr:]            scratch space.
+	tau2 := work[n : n+nr]
+	qr2 := work[2*n : 2*n+n*nr]
+	tau3 := work[2*n+n*nr : 2*n+n*nr+nr]
+	scr := work[2*n+n*nr+nr : lwork]
+
+	// Copy R1^T into the lower triangle of V.
+	for p := 0; p < nr; p++ {
+		bi.Dcopy(n-p, a[p*lda+p:], 1, v[p*ldv+p:], ldv)
+	}
+	if l2pert {
+		// Perturb the small entries to avoid denormalized numbers in
+		// the second QR factorization, where they are as good as zeros.
+		xsc := math.Sqrt(small)
+		for q := 0; q < nr; q++ {
+			temp1 := xsc * math.Abs(v[q*ldv+q])
+			for p := 0; p < n; p++ {
+				if (p > q && math.Abs(v[p*ldv+q]) <= temp1) || p < q {
+					v[p*ldv+q] = math.Copysign(temp1, v[p*ldv+q])
+				}
+				if p < q {
+					v[p*ldv+q] = -v[p*ldv+q]
+				}
+			}
+		}
+	} else {
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+	}
+
+	// Estimate the row scaled condition number of R1. If R1 is not square,
+	// the condition number of its leading nr×nr submatrix is estimated.
+	impl.Dlacpy(blas.Lower, nr, nr, v, ldv, qr2, nr)
+	for p := 0; p < nr; p++ {
+		temp1 := bi.Dnrm2(nr-p, qr2[p*nr+p:], nr)
+		bi.Dscal(nr-p, 1/temp1, qr2[p*nr+p:], nr)
+	}
+	rcond := impl.Dpocon(blas.Lower, nr, qr2, nr, 1, work[2*n+nr*nr:], iwork[m+2*n:])
+	condr1 = 1 / math.Sqrt(rcond)
+
+	// R1 is considered well conditioned for inversion if its scaled
+	// condition number is less than condOK.
+	condOK := math.Sqrt(float64(nr))
+
+	if condr1 < condOK {
+		// The second QR factorization without pivoting
+		//  R1^T = Q2 * R2.
+		impl.Dgeqrf(n, nr, v, ldv, tau2, work[2*n:], lwork-2*n)
+		if l2pert {
+			xsc := math.Sqrt(small) / eps
+			for p := 1; p < nr; p++ {
+				for q := 0; q < p; q++ {
+					temp1 := xsc * math.Min(math.Abs(v[p*ldv+p]), math.Abs(v[q*ldv+q]))
+					if math.Abs(v[q*ldv+p]) <= temp1 {
+						v[q*ldv+p] = math.Copysign(temp1, v[q*ldv+p])
+					}
+				}
+			}
+		}
+		if nr != n {
+			impl.Dlacpy(blas.All, n, nr, v, ldv, qr2, nr)
+		}
+		// Transpose R2 into the lower triangle.
+		for p := 0; p < nr-1; p++ {
+			bi.Dcopy(nr-p-1, v[p*ldv+p+1:], 1, v[(p+1)*ldv+p:], ldv)
+		}
+		condr2 = condr1
+	} else {
+		// The ill-conditioned case: the second QR factorization with
+		// pivoting
+		//  R1^T * P2 = Q2 * R2.
+		for p := 0; p < nr; p++ {
+			iwork[n+p] = -1
+		}
+		impl.Dgeqp3(n, nr, v, ldv, iwork[n:n+nr], tau2, work[2*n:], lwork-2*n)
+		if l2pert {
+			xsc := math.Sqrt(small)
+			for p := 1; p < nr; p++ {
+				for q := 0; q < p; q++ {
+					temp1 := xsc * math.Min(math.Abs(v[p*ldv+p]), math.Abs(v[q*ldv+q]))
+					if math.Abs(v[q*ldv+p]) <= temp1 {
+						v[q*ldv+p] = math.Copysign(temp1, v[q*ldv+p])
+					}
+				}
+			}
+		}
+		impl.Dlacpy(blas.All, n, nr, v, ldv, qr2, nr)
+		if l2pert {
+			xsc := math.Sqrt(small)
+			for p := 1; p < nr; p++ {
+				for q := 0; q < p; q++ {
+					temp1 := xsc * math.Min(math.Abs(v[p*ldv+p]), math.Abs(v[q*ldv+q]))
+					v[p*ldv+q] = -math.Copysign(temp1, v[q*ldv+p])
+				}
+			}
+		} else {
+			impl.Dlaset(blas.Lower, nr-1, nr-1, 0, 0, v[ldv:], ldv)
+		}
+
+		// Compute the LQ factorization R2 = L3 * Q3 and estimate the
+		// condition number of L3.
+		impl.Dgelqf(nr, nr, v, ldv, tau3, scr, len(scr))
+		impl.Dlacpy(blas.Lower, nr, nr, v, ldv, scr, nr)
+		for p := 0; p < nr; p++ {
+			temp1 := bi.Dnrm2(p+1, scr[p*nr:], 1)
+			bi.Dscal(p+1, 1/temp1, scr[p*nr:], 1)
+		}
+		rcond = impl.Dpocon(blas.Lower, nr, scr, nr, 1, scr[nr*nr:], iwork[m+2*n:])
+		condr2 = 1 / math.Sqrt(rcond)
+		if condr2 >= condOK {
+			// Save the reflectors of Q3 in the upper triangle of the
+			// copy of R2, which is not needed in this case. The
+			// reflectors of Q2 are kept.
+			impl.Dlacpy(blas.Upper, nr, nr, v, ldv, qr2, nr)
+		}
+	}
+
+	if l2pert {
+		xsc := math.Sqrt(small)
+		for q := 1; q < nr; q++ {
+			temp1 := xsc * v[q*ldv+q]
+			for p := 0; p < q; p++ {
+				v[p*ldv+q] = -math.Copysign(temp1, v[p*ldv+q])
+			}
+		}
+	} else {
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+	}
+
+	// The second preconditioning is finished and the Jacobi SVD is applied
+	// to the lower triangular matrix in V. The right singular vectors are
+	// recovered as the solution of a well conditioned triangular matrix
+	// equation.
+	switch {
+	case condr1 < condOK:
+		ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.None, nr, nr, v, ldv, sva, nr, nil, 1, scr, len(scr))
+		scalem = scr[0]
+		numrank = int(scr[1])
+		for p := 0; p < nr; p++ {
+			bi.Dcopy(nr, v[p:], ldv, u[p:], ldu)
+			bi.Dscal(nr, sva[p], v[p:], ldv)
+		}
+		if nr == n {
+			// R1 is inverted. The solution is Q2*V2, the product of
+			// the Jacobi rotations premultiplied by the orthogonal
+			// matrix from the second QR factorization.
+			bi.Dtrsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, nr, nr, 1, a, lda, v, ldv)
+		} else {
+			// R1 is well conditioned but not square. R2^T is
+			// inverted to get the product of the Jacobi rotations,
+			// and Q2 is applied explicitly.
+			bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, nr, nr, 1, qr2, nr, v, ldv)
+			impl.Dlaset(blas.All, n-nr, nr, 0, 0, v[nr*ldv:], ldv)
+			impl.Dlaset(blas.All, nr, n-nr, 0, 0, v[nr:], ldv)
+			impl.Dlaset(blas.All, n-nr, n-nr, 0, 1, v[nr*ldv+nr:], ldv)
+			impl.Dormqr(blas.Left, blas.NoTrans, n, n, nr, qr2, nr, tau2, v, ldv, scr, len(scr))
+		}
+
+	case condr2 < condOK:
+		// R2 is inverted. The solution is Q3^T*V3, the product of the
+		// Jacobi rotations applied to L3 premultiplied by Q3^T.
+		ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.None, nr, nr, v, ldv, sva, nr, nil, 1, scr, len(scr))
+		scalem = scr[0]
+		numrank = int(scr[1])
+		for p := 0; p < nr; p++ {
+			bi.Dcopy(nr, v[p:], ldv, u[p:], ldu)
+			bi.Dscal(nr, sva[p], u[p:], ldu)
+		}
+		bi.Dtrsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, nr, nr, 1, qr2, nr, u, ldu)
+		// Apply the permutation from the second QR factorization.
+		for q := 0; q < nr; q++ {
+			for p := 0; p < nr; p++ {
+				scr[iwork[n+p]] = u[p*ldu+q]
+			}
+			for p := 0; p < nr; p++ {
+				u[p*ldu+q] = scr[p]
+			}
+		}
+		if nr < n {
+			impl.Dlaset(blas.All, n-nr, nr, 0, 0, v[nr*ldv:], ldv)
+			impl.Dlaset(blas.All, nr, n-nr, 0, 0, v[nr:], ldv)
+			impl.Dlaset(blas.All, n-nr, n-nr, 0, 1, v[nr*ldv+nr:], ldv)
+		}
+		impl.Dormqr(blas.Left, blas.NoTrans, n, n, nr, qr2, nr, tau2, v, ldv, scr, len(scr))
+
+	default:
+		// The last line of defense. There was no improvement of the
+		// scaled condition number after two pivoted QR factorizations,
+		// which should not happen unless the rank revealing QR
+		// factorization or the condition estimator failed. Compute the
+		// SVD of L3 with explicit accumulation of the Jacobi rotations.
+		ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.ComputeRightSV, nr, nr, v, ldv, sva, nr, u, ldu, scr, len(scr))
+		scalem = scr[0]
+		numrank = int(scr[1])
+		if nr < n {
+			impl.Dlaset(blas.All, n-nr, nr, 0, 0, v[nr*ldv:], ldv)
+			impl.Dlaset(blas.All, nr, n-nr, 0, 0, v[nr:], ldv)
+			impl.Dlaset(blas.All, n-nr, n-nr, 0, 1, v[nr*ldv+nr:], ldv)
+		}
+		impl.Dormqr(blas.Left, blas.NoTrans, n, n, nr, qr2, nr, tau2, v, ldv, scr, len(scr))
+		impl.Dormlq(blas.Left, blas.Trans, nr, nr, nr, qr2, nr, tau3, u, ldu, scr, len(scr))
+		for q := 0; q < nr; q++ {
+			for p := 0; p < nr; p++ {
+				scr[iwork[n+p]] = u[p*ldu+q]
+			}
+			for p := 0; p < nr; p++ {
+				u[p*ldu+q] = scr[p]
+			}
+		}
+	}
+
+	impl.dgejsvFinishV(n, v, ldv, iwork, scr)
+	impl.dgejsvFinishU(m, n, n1, nr, true, rowpiv, a, lda, u, ldu, work, lwork, iwork)
+	return scalem, numrank, condr1, condr2, ok
+}
+
+// dgejsvAlmort computes the full SVD in Dgejsv when the columns of A are
+// almost orthogonal so that the second QR factorization is not needed. It
+// returns the scaling factor of the singular values in sva, the number of
+// nonzero singular values and whether the Jacobi iteration converged.
+func (impl Implementation) dgejsvAlmort(m, n, n1 int, l2pert, rowpiv bool, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (scalem float64, numrank int, ok bool) {
+	const small = dlamchS / dlamchE
+	bi := blas64.Implementation()
+
+	r := work[n : n+n*n]
+	impl.Dlacpy(blas.Upper, n, n, a, lda, r, n)
+	if l2pert {
+		xsc := math.Sqrt(small)
+		for p := 1; p < n; p++ {
+			temp1 := xsc * r[p*n+p]
+			for q := 0; q < p; q++ {
+				r[p*n+q] = -math.Copysign(temp1, r[q*n+p])
+			}
+		}
+	} else {
+		impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, r[n:], n)
+	}
+
+	scr := work[n+n*n : lwork]
+	ok = impl.Dgesvj(lapack.UpperTri, lapack.ComputeLeftSV, lapack.None, n, n, r, n, sva, n, nil, 1, scr, len(scr))
+	scalem = scr[0]
+	numrank = int(scr[1])
+	for p := 0; p < n; p++ {
+		bi.Dcopy(n, r[p:], n, u[p:], ldu)
+		bi.Dscal(n, sva[p], r[p:], n)
+	}
+	bi.Dtrsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, n, n, 1, a, lda, r, n)
+	impl.Dlacpy(blas.All, n, n, r, n, v, ldv)
+	impl.dgejsvFinishV(n, v, ldv, iwork, scr)
+	impl.dgejsvFinishU(m, n, n1, n, true, rowpiv, a, lda, u, ldu, work, lwork, iwork)
+	return scalem, numrank, ok
+}
+
+// dgejsvJacobi computes the full SVD in Dgejsv by applying the Jacobi
+// iteration with explicitly accumulated rotations to the second QR factor. It
+// is used if the condition number of A is predicted to be larger than the
+// overflow threshold. It returns the scaling factor of the singular values in
+// sva, the number of nonzero singular values and whether the Jacobi iteration
+// converged.
+func (impl Implementation) dgejsvJacobi(m, n, n1, nr int, l2pert, rowpiv bool, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (scalem float64, numrank int, ok bool) {
+	const small = dlamchS / dlamchE
+	bi := blas64.Implementation()
+
+	tau2 := work[n : n+nr]
+	qr2 := work[2*n : 2*n+n*nr]
+
+	for p := 0; p < nr; p++ {
+		bi.Dcopy(n-p, a[p*lda+p:], 1, v[p*ldv+p:], ldv)
+	}
+	if l2pert {
+		xsc := math.Sqrt(small / dlamchE)
+		for q := 0; q < nr; q++ {
+			temp1 := xsc * math.Abs(v[q*ldv+q])
+			for p := 0; p < n; p++ {
+				if (p > q && math.Abs(v[p*ldv+q]) <= temp1) || p < q {
+					v[p*ldv+q] = math.Copysign(temp1, v[p*ldv+q])
+				}
+				if p < q {
+					v[p*ldv+q] = -v[p*ldv+q]
+				}
+			}
+		}
+	} else {
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, v[1:], ldv)
+	}
+	impl.Dgeqrf(n, nr, v, ldv, tau2, work[2*n:], lwork-2*n)
+	impl.Dlacpy(blas.Lower, n, nr, v, ldv, qr2, nr)
+
+	// Copy R2^T into the lower triangle of U.
+	for p := 0; p < nr; p++ {
+		bi.Dcopy(nr-p, v[p*ldv+p:], 1, u[p*ldu+p:], ldu)
+	}
+	if l2pert {
+		xsc := math.Sqrt(small / dlamchE)
+		for q := 1; q < nr; q++ {
+			for p := 0; p < q; p++ {
+				temp1 := xsc * math.Min(math.Abs(u[p*ldu+p]), math.Abs(u[q*ldu+q]))
+				u[p*ldu+q] = -math.Copysign(temp1, u[q*ldu+p])
+			}
+		}
+	} else {
+		impl.Dlaset(blas.Upper, nr-1, nr-1, 0, 0, u[1:], ldu)
+	}
+
+	scr := work[2*n+n*nr : lwork]
+	ok = impl.Dgesvj(lapack.LowerTri, lapack.ComputeLeftSV, lapack.ComputeRightSV, nr, nr, u, ldu, sva, n, v, ldv, scr, len(scr))
+	scalem = scr[0]
+	numrank = int(scr[1])
+
+	if nr < n {
+		impl.Dlaset(blas.All, n-nr, nr, 0, 0, v[nr*ldv:], ldv)
+		impl.Dlaset(blas.All, nr, n-nr, 0, 0, v[nr:], ldv)
+		impl.Dlaset(blas.All, n-nr, n-nr, 0, 1, v[nr*ldv+nr:], ldv)
+	}
+	scr = work[2*n+n*nr+nr : lwork]
+	impl.Dormqr(blas.Left, blas.NoTrans, n, n, nr, qr2, nr, tau2, v, ldv, scr, len(scr))
+
+	// Permute the rows of V using the column permutation from the first
+	// QR factorization and make the columns unit in Euclidean norm.
+	impl.dgejsvFinishV(n, v, ldv, iwork, scr)
+	impl.dgejsvFinishU(m, n, n1, nr, false, rowpiv, a, lda, u, ldu, work, lwork, iwork)
+	return scalem, numrank, ok
+}
+
+// dgejsvFinishV permutes the rows of the n×n matrix V using the column
+// permutation from the first QR factorization of Dgejsv stored in iwork[:n]
+// and makes the columns of V unit in Euclidean norm. scr is scratch space of
+// length at least n.
+func (impl Implementation) dgejsvFinishV(n int, v []float64, ldv int, iwork []int, scr []float64) {
+	bi := blas64.Implementation()
+	tol := math.Sqrt(float64(n)) * dlamchE
+	for q := 0; q < n; q++ {
+		for p := 0; p < n; p++ {
+			scr[iwork[p]] = v[p*ldv+q]
+		}
+		for p := 0; p < n; p++ {
+			v[p*ldv+q] = scr[p]
+		}
+		xsc := 1 / bi.Dnrm2(n, v[q:], ldv)
+		if xsc < 1-tol || xsc > 1+tol {
+			bi.Dscal(n, xsc, v[q:], ldv)
+		}
+	}
+}
+
+// dgejsvFinishU assembles the m×n1 matrix U of left singular vectors in
+// Dgejsv from the left singular vectors of the leading nr×nr triangular
+// factor stored in U, by applying Q1 from the first QR factorization stored
+// in a and work[:n]. If normalize is true, the first nr columns of U are made
+// unit in Euclidean norm. If rowpiv is true, the row permutation stored in
+// iwork[2*n:2*n+m-1] is undone.
+func (impl Implementation) dgejsvFinishU(m, n, n1, nr int, normalize, rowpiv bool, a []float64, lda int, u []float64, ldu int, work []float64, lwork int, iwork []int) {
+	bi := blas64.Implementation()
+	if nr < m {
+		impl.Dlaset(blas.All, m-nr, nr, 0, 0, u[nr*ldu:], ldu)
+		if nr < n1 {
+			impl.Dlaset(blas.All, nr, n1-nr, 0, 0, u[nr:], ldu)
+			impl.Dlaset(blas.All, m-nr, n1-nr, 0, 1, u[nr*ldu+nr:], ldu)
+		}
+	}
+	impl.Dormqr(blas.Left, blas.NoTrans, m, n1, n, a, lda, work[:n], u, ldu, work[n:], lwork-n)
+	if normalize {
+		tol := math.Sqrt(float64(m)) * dlamchE
+		for p := 0; p < nr; p++ {
+			xsc := 1 / bi.Dnrm2(m, u[p:], ldu)
+			if xsc < 1-tol || xsc > 1+tol {
+				bi.Dscal(m, xsc, u[p:], ldu)
+			}
+		}
+	}
+	if rowpiv {
+		impl.Dlaswp(n1, u, ldu, 0, m-2, iwork[2*n:2*n+m-1], -1)
+	}
+}
@@ -0,0 +1,321 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dgelsd computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient. The problem is solved in three steps. First A is reduced to
+// bidiagonal form, then the bidiagonal least squares problem is solved by
+// Dlalsd using a divide and conquer algorithm, and finally the solution is
+// transformed back to the solution of the original problem.
+//
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X. If m > n and the rank of A is n, the
+// residual sum of squares for the solution in the j-th column is given by the
+// sum of squares of the elements in rows n to m-1 of that column. nrhs must
+// be at least 1.
+//
+// On return, a is overwritten.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond <= 0 or rcond >= 1, machine precision is used instead.
+//
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order. The condition number of A in the 2-norm is
+// s[0]/s[min(m,n)-1].
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  3*min(m,n) + max(max(m,n), nrhs, wlalsd)
+// if m >= n, and
+//  3*min(m,n) + max(m, nrhs, wlalsd)
+// otherwise, where
+//  wlalsd = 9*k + 2*k*smlsiz + 8*k*nlvl + k*nrhs + (smlsiz+1)*(smlsiz+1),
+//  k = min(m,n),
+//  nlvl = max(0, int(log_2(max(1,k)/(smlsiz+1))) + 1),
+// and smlsiz = 25 is the maximum size of the subproblems at the bottom of the
+// computation tree. For good performance, lwork should generally be larger.
+// If lwork == -1, instead of performing Dgelsd, the optimal work length will
+// be stored into work[0] and the minimum length of iwork into iwork[0].
+//
+// iwork must have length at least 3*mn*nlvl + 11*mn, where mn = max(1,k).
+//
+// Dgelsd returns the effective rank of A and whether the singular value
+// decomposition converged.
+func (impl Implementation) Dgelsd(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, ok bool) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	checkMatrix(m, n, a, lda)
+	minmn := min(m, n)
+	maxmn := max(m, n)
+	checkMatrix(maxmn, nrhs, b, ldb)
+	if len(s) < minmn {
+		panic(badS)
+	}
+
+	// Compute the minimal and optimal workspace.
+	mnthr := impl.Ilaenv(6, "DGELSD", " ", m, n, nrhs, -1)
+	smlsiz := impl.Ilaenv(9, "DGELSD", " ", 0, 0, 0, 0)
+	mn := max(1, minmn)
+	nlvl := max(0, int(math.Log2(float64(mn)/float64(smlsiz+1)))+1)
+	liwork := 3*mn*nlvl + 11*mn
+	var minwrk, maxwrk, wlalsd int
+	mm := m
+	if m >= n && m >= mnthr {
+		// Path 1a (m >> n).
+		mm = n
+		maxwrk = max(maxwrk, n+n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1))
+		maxwrk = max(maxwrk, n+nrhs*impl.Ilaenv(1, "DORMQR", "LT", m, nrhs, n, -1))
+	}
+	if m >= n {
+		// Path 1 (m >= n).
+		wlalsd = 9*n + 2*n*smlsiz + 8*n*nlvl + n*nrhs + (smlsiz+1)*(smlsiz+1)
+		maxwrk = max(maxwrk, 3*n+(mm+n)*impl.Ilaenv(1, "DGEBRD", " ", mm, n, -1, -1))
+		maxwrk = max(maxwrk, 3*n+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", mm, nrhs, n, -1))
+		maxwrk = max(maxwrk, 3*n+(n-1)*impl.Ilaenv(1, "DORMBR", "PLN", n, nrhs, n, -1))
+		maxwrk = max(maxwrk, 3*n+wlalsd)
+		minwrk = 3*n + max(max(mm, nrhs), wlalsd)
+	} else {
+		wlalsd = 9*m + 2*m*smlsiz + 8*m*nlvl + m*nrhs + (smlsiz+1)*(smlsiz+1)
+		if n >= mnthr {
+			// Path 2a (n >> m).
+			maxwrk = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+			maxwrk = max(maxwrk, m*m+4*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+			maxwrk = max(maxwrk, m*m+4*m+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", m, nrhs, m, -1))
+			maxwrk = max(maxwrk, m*m+4*m+(m-1)*impl.Ilaenv(1, "DORMBR", "PLN", m, nrhs, m, -1))
+			if nrhs > 1 {
+				maxwrk = max(maxwrk, m*m+m+m*nrhs)
+			} else {
+				maxwrk = max(maxwrk, m*m+2*m)
+			}
+			maxwrk = max(maxwrk, m+nrhs*impl.Ilaenv(1, "DORMLQ", "LT", n, nrhs, m, -1))
+			maxwrk = max(maxwrk, m*m+4*m+wlalsd)
+			maxwrk = max(maxwrk, 4*m+m*m+max(max(m, 2*m-4), max(nrhs, n-3*m)))
+		} else {
+			// Path 2 (n > m, but not much larger).
+			maxwrk = 3*m + (n+m)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			maxwrk = max(maxwrk, 3*m+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", m, nrhs, n, -1))
+			maxwrk = max(maxwrk, 3*m+m*impl.Ilaenv(1, "DORMBR", "PLN", n, nrhs, m, -1))
+			maxwrk = max(maxwrk, 3*m+wlalsd)
+		}
+		minwrk = 3*m + max(max(nrhs, m), wlalsd)
+	}
+	maxwrk = max(maxwrk, minwrk)
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		iwork[0] = liwork
+		return 0, true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwrk {
+		panic(badWork)
+	}
+	if len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 0, true
+	}
+
+	// Get machine parameters.
+	eps := dlamchP
+	sfmin := dlamchS
+	smlnum := sfmin / eps
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iascl int
+	if anrm > 0 && anrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+		iascl = 1
+	} else if anrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+		iascl = 2
+	} else if anrm == 0 {
+		// Matrix is all zeros.
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		for i := range s[:minmn] {
+			s[i] = 0
+		}
+		work[0] = float64(maxwrk)
+		iwork[0] = liwork
+		return 0, true
+	}
+
+	// Scale B if max element outside range [smlnum, bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, m, nrhs, b, ldb, nil)
+	var ibscl int
+	if bnrm > 0 && bnrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, smlnum, m, nrhs, b, ldb)
+		ibscl = 1
+	} else if bnrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bignum, m, nrhs, b, ldb)
+		ibscl = 2
+	}
+
+	// If m < n make sure certain entries of B are zero.
+	if m < n {
+		impl.Dlaset(blas.All, n-m, nrhs, 0, 0, b[m*ldb:], ldb)
+	}
+
+	if m >= n {
+		// Path 1 (m >= n).
+		mm := m
+		if m >= mnthr {
+			// Path 1a (m >> n). Compute A = Q*R.
+			mm = n
+			itau := 0
+			nwork := itau + n
+			impl.Dgeqrf(m, n, a, lda, work[itau:itau+n], work[nwork:], lwork-nwork)
+
+			// Multiply B by Q^T.
+			impl.Dormqr(blas.Left, blas.Trans, m, nrhs, n, a, lda, work[itau:itau+n],
+				b, ldb, work[nwork:], lwork-nwork)
+
+			// Zero out below R.
+			if n > 1 {
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+			}
+		}
+		ie := 0
+		itauq := ie + n
+		itaup := itauq + n
+		nwork := itaup + n
+
+		// Bidiagonalize R in A.
+		impl.Dgebrd(mm, n, a, lda, s, work[ie:], work[itauq:itauq+n], work[itaup:itaup+n],
+			work[nwork:], lwork-nwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors
+		// of R.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, mm, nrhs, n, a, lda, work[itauq:itauq+n],
+			b, ldb, work[nwork:], lwork-nwork)
+
+		// Solve the bidiagonal least squares problem.
+		rank, ok = impl.Dlalsd(blas.Upper, smlsiz, n, nrhs, s, work[ie:], b, ldb, rcond,
+			work[nwork:], iwork)
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the right bidiagonalizing vectors of R.
+		impl.Dormbr(lapack.ApplyP, blas.Left, blas.NoTrans, n, nrhs, n, a, lda, work[itaup:itaup+n],
+			b, ldb, work[nwork:], lwork-nwork)
+	} else if n >= mnthr && lwork >= 4*m+m*m+max(max(m, 2*m-4), max(max(nrhs, n-3*m), wlalsd)) {
+		// Path 2a (n >> m) with sufficient workspace for an efficient
+		// algorithm.
+		ldwork := m
+		if lwork >= max(max(4*m+m*lda+max(max(m, 2*m-4), max(nrhs, n-3*m)), m*lda+m+m*nrhs), 4*m+m*lda+wlalsd) {
+			ldwork = lda
+		}
+		itau := 0
+		nwork := m
+
+		// Compute A = L*Q.
+		impl.Dgelqf(m, n, a, lda, work[itau:itau+m], work[nwork:], lwork-nwork)
+		il := nwork
+
+		// Copy L to work[il:], zeroing out above its diagonal.
+		impl.Dlacpy(blas.Lower, m, m, a, lda, work[il:], ldwork)
+		impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[il+1:], ldwork)
+		ie := il + ldwork*m
+		itauq := ie + m
+		itaup := itauq + m
+		nwork = itaup + m
+
+		// Bidiagonalize L in work[il:].
+		impl.Dgebrd(m, m, work[il:], ldwork, s, work[ie:], work[itauq:itauq+m], work[itaup:itaup+m],
+			work[nwork:], lwork-nwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors
+		// of L.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, m, work[il:], ldwork, work[itauq:itauq+m],
+			b, ldb, work[nwork:], lwork-nwork)
+
+		// Solve the bidiagonal least squares problem.
+		rank, ok = impl.Dlalsd(blas.Upper, smlsiz, m, nrhs, s, work[ie:], b, ldb, rcond,
+			work[nwork:], iwork)
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the right bidiagonalizing vectors of L.
+		impl.Dormbr(lapack.ApplyP, blas.Left, blas.NoTrans, m, nrhs, m, work[il:], ldwork, work[itaup:itaup+m],
+			b, ldb, work[nwork:], lwork-nwork)
+
+		// Zero out below the first m rows of B.
+		impl.Dlaset(blas.All, n-m, nrhs, 0, 0, b[m*ldb:], ldb)
+		nwork = itau + m
+
+		// Multiply Q^T by B.
+		impl.Dormlq(blas.Left, blas.Trans, n, nrhs, m, a, lda, work[itau:itau+m], b, ldb,
+			work[nwork:], lwork-nwork)
+	} else {
+		// Path 2 (n > m, but not much larger).
+		ie := 0
+		itauq := ie + m
+		itaup := itauq + m
+		nwork := itaup + m
+
+		// Bidiagonalize A.
+		impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:itauq+m], work[itaup:itaup+m],
+			work[nwork:], lwork-nwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, n, a, lda, work[itauq:itauq+m],
+			b, ldb, work[nwork:], lwork-nwork)
+
+		// Solve the bidiagonal least squares problem.
+		rank, ok = impl.Dlalsd(blas.Lower, smlsiz, m, nrhs, s, work[ie:], b, ldb, rcond,
+			work[nwork:], iwork)
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the right bidiagonalizing vectors of A.
+		impl.Dormbr(lapack.ApplyP, blas.Left, blas.NoTrans, n, nrhs, m, a, lda, work[itaup:itaup+m],
+			b, ldb, work[nwork:], lwork-nwork)
+	}
+
+	// Undo scaling.
+	if iascl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, 1)
+	} else if iascl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, 1)
+	}
+	if ibscl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, smlnum, bnrm, n, nrhs, b, ldb)
+	} else if ibscl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, bignum, bnrm, n, nrhs, b, ldb)
+	}
+	work[0] = float64(maxwrk)
+	return rank, true
+}
@@ -0,0 +1,378 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgelss computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using the singular value decomposition of the m×n matrix A, which may be
+// rank-deficient. The singular value decomposition is computed by Dgebrd and
+// Dbdsqr, as in Dgesvd.
+//
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X. If m > n and the rank of A is n, the
+// residual sum of squares for the solution in the j-th column is given by the
+// sum of squares of the elements in rows n to m-1 of that column. nrhs must
+// be at least 1.
+//
+// On return, a is overwritten with its right singular vectors, stored
+// row-wise in its first min(m,n) rows.
+//
+// The effective rank of A is determined by treating as zero those singular
+// values which are less than or equal to rcond times the largest singular
+// value. If rcond < 0, machine precision is used instead.
+//
+// s must have length at least min(m,n) and on return it contains the singular
+// values of A in decreasing order. The condition number of A in the 2-norm is
+// s[0]/s[min(m,n)-1].
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least
+//  max(1, 3*min(m,n) + max(2*min(m,n), max(m,n), nrhs)).
+// For good performance, lwork should generally be larger. If lwork == -1,
+// instead of performing Dgelss, the optimal work length will be stored into
+// work[0].
+//
+// Dgelss returns the effective rank of A and whether the singular value
+// decomposition converged.
+func (impl Implementation) Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	checkMatrix(m, n, a, lda)
+	minmn := min(m, n)
+	maxmn := max(m, n)
+	checkMatrix(maxmn, nrhs, b, ldb)
+	if len(s) < minmn {
+		panic(badS)
+	}
+
+	// Compute the minimal and optimal workspace.
+	minwrk := 1
+	maxwrk := 1
+	mnthr := impl.Ilaenv(6, "DGELSS", " ", m, n, nrhs, -1)
+	if minmn > 0 {
+		mm := m
+		if m >= n && m >= mnthr {
+			// Path 1a (m >> n).
+			mm = n
+			maxwrk = max(maxwrk, n+n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1))
+			maxwrk = max(maxwrk, n+nrhs*impl.Ilaenv(1, "DORMQR", "LT", m, nrhs, n, -1))
+		}
+		if m >= n {
+			// Path 1 (m >= n).
+			bdspac := max(1, 5*n)
+			maxwrk = max(maxwrk, 3*n+(mm+n)*impl.Ilaenv(1, "DGEBRD", " ", mm, n, -1, -1))
+			maxwrk = max(maxwrk, 3*n+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", mm, nrhs, n, -1))
+			maxwrk = max(maxwrk, 3*n+(n-1)*impl.Ilaenv(1, "DORGBR", "P", n, n, n, -1))
+			maxwrk = max(maxwrk, bdspac)
+			maxwrk = max(maxwrk, n*nrhs)
+			minwrk = max(3*n+max(mm, nrhs), bdspac)
+		} else {
+			bdspac := max(1, 5*m)
+			minwrk = max(3*m+max(nrhs, n), bdspac)
+			if n >= mnthr {
+				// Path 2a (n >> m).
+				maxwrk = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+				maxwrk = max(maxwrk, m*m+4*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+				maxwrk = max(maxwrk, m*m+4*m+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", m, nrhs, m, -1))
+				maxwrk = max(maxwrk, m*m+4*m+(m-1)*impl.Ilaenv(1, "DORGBR", "P", m, m, m, -1))
+				maxwrk = max(maxwrk, m*m+m+bdspac)
+				if nrhs > 1 {
+					maxwrk = max(maxwrk, m*m+m+m*nrhs)
+				} else {
+					maxwrk = max(maxwrk, m*m+2*m)
+				}
+				maxwrk = max(maxwrk, m+nrhs*impl.Ilaenv(1, "DORMLQ", "LT", n, nrhs, m, -1))
+				maxwrk = max(maxwrk, 4*m+m*m+max(max(m, 2*m-4), max(nrhs, n-3*m)))
+			} else {
+				// Path 2 (n > m, but not much larger).
+				maxwrk = 3*m + (n+m)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+				maxwrk = max(maxwrk, 3*m+nrhs*impl.Ilaenv(1, "DORMBR", "QLT", m, nrhs, m, -1))
+				maxwrk = max(maxwrk, 3*m+m*impl.Ilaenv(1, "DORGBR", "P", m, n, m, -1))
+				maxwrk = max(maxwrk, bdspac)
+				maxwrk = max(maxwrk, n*nrhs)
+			}
+		}
+		maxwrk = max(minwrk, maxwrk)
+	}
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return 0, true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minwrk {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 0, true
+	}
+
+	// Get machine parameters.
+	eps := dlamchP
+	sfmin := dlamchS
+	smlnum := sfmin / eps
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iascl int
+	if anrm > 0 && anrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+		iascl = 1
+	} else if anrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+		iascl = 2
+	} else if anrm == 0 {
+		// Matrix is all zeros.
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		for i := range s[:minmn] {
+			s[i] = 0
+		}
+		work[0] = float64(maxwrk)
+		return 0, true
+	}
+
+	// Scale B if max element outside range [smlnum, bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, m, nrhs, b, ldb, nil)
+	var ibscl int
+	if bnrm > 0 && bnrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, smlnum, m, nrhs, b, ldb)
+		ibscl = 1
+	} else if bnrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bignum, m, nrhs, b, ldb)
+		ibscl = 2
+	}
+
+	bi := blas64.Implementation()
+
+	// thresh returns the threshold below which singular values are treated
+	// as zero.
+	thresh := func() float64 {
+		if rcond < 0 {
+			return math.Max(eps*s[0], sfmin)
+		}
+		return math.Max(rcond*s[0], sfmin)
+	}
+
+	if m >= n {
+		// Path 1 (m >= n).
+		mm := m
+		if m >= mnthr {
+			// Path 1a (m >> n). Compute A = Q*R.
+			mm = n
+			itau := 0
+			iwork := itau + n
+			impl.Dgeqrf(m, n, a, lda, work[itau:itau+n], work[iwork:], lwork-iwork)
+
+			// Multiply B by Q^T.
+			impl.Dormqr(blas.Left, blas.Trans, m, nrhs, n, a, lda, work[itau:itau+n],
+				b, ldb, work[iwork:], lwork-iwork)
+
+			// Zero out below R.
+			if n > 1 {
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+			}
+		}
+		ie := 0
+		itauq := ie + n
+		itaup := itauq + n
+		iwork := itaup + n
+
+		// Bidiagonalize R in A.
+		impl.Dgebrd(mm, n, a, lda, s, work[ie:], work[itauq:itauq+n], work[itaup:itaup+n],
+			work[iwork:], lwork-iwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors
+		// of R.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, mm, nrhs, n, a, lda, work[itauq:itauq+n],
+			b, ldb, work[iwork:], lwork-iwork)
+
+		// Generate the right bidiagonalizing vectors of R in A.
+		impl.Dorgbr(lapack.ApplyP, n, n, n, a, lda, work[itaup:itaup+n], work[iwork:], lwork-iwork)
+		iwork = ie + n
+
+		// Perform bidiagonal QR iteration, multiplying B by the transpose
+		// of the left singular vectors and computing the right singular
+		// vectors in A.
+		ok = impl.Dbdsqr(blas.Upper, n, n, 0, nrhs, s, work[ie:], a, lda, nil, 1, b, ldb, work[iwork:])
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the reciprocals of the singular values.
+		thr := thresh()
+		for i := 0; i < n; i++ {
+			if s[i] > thr {
+				impl.Drscl(nrhs, s[i], b[i*ldb:], 1)
+				rank++
+			} else {
+				impl.Dlaset(blas.All, 1, nrhs, 0, 0, b[i*ldb:], ldb)
+			}
+		}
+
+		// Multiply B by the right singular vectors in blocks of columns
+		// that fit into work.
+		chunk := lwork / n
+		for i := 0; i < nrhs; i += chunk {
+			bl := min(nrhs-i, chunk)
+			bi.Dgemm(blas.Trans, blas.NoTrans, n, bl, n, 1, a, lda, b[i:], ldb, 0, work, bl)
+			impl.Dlacpy(blas.All, n, bl, work, bl, b[i:], ldb)
+		}
+	} else if n >= mnthr && lwork >= 4*m+m*m+max(max(m, 2*m-4), max(nrhs, n-3*m)) {
+		// Path 2a (n >> m) with sufficient workspace for an efficient
+		// algorithm.
+		ldwork := m
+		if lwork >= max(4*m+m*lda+max(max(m, 2*m-4), max(nrhs, n-3*m)), m*lda+m+m*nrhs) {
+			ldwork = lda
+		}
+		itau := 0
+		iwork := m
+
+		// Compute A = L*Q.
+		impl.Dgelqf(m, n, a, lda, work[itau:itau+m], work[iwork:], lwork-iwork)
+		il := iwork
+
+		// Copy L to work[il:], zeroing out above it.
+		impl.Dlacpy(blas.Lower, m, m, a, lda, work[il:], ldwork)
+		impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[il+1:], ldwork)
+		ie := il + ldwork*m
+		itauq := ie + m
+		itaup := itauq + m
+		iwork = itaup + m
+
+		// Bidiagonalize L in work[il:].
+		impl.Dgebrd(m, m, work[il:], ldwork, s, work[ie:], work[itauq:itauq+m], work[itaup:itaup+m],
+			work[iwork:], lwork-iwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors
+		// of L.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, m, work[il:], ldwork, work[itauq:itauq+m],
+			b, ldb, work[iwork:], lwork-iwork)
+
+		// Generate the right bidiagonalizing vectors of L in work[il:].
+		impl.Dorgbr(lapack.ApplyP, m, m, m, work[il:], ldwork, work[itaup:itaup+m], work[iwork:], lwork-iwork)
+		iwork = ie + m
+
+		// Perform bidiagonal QR iteration, computing the right singular
+		// vectors of L in work[il:] and multiplying B by the transpose of
+		// the left singular vectors.
+		ok = impl.Dbdsqr(blas.Upper, m, m, 0, nrhs, s, work[ie:], work[il:], ldwork, nil, 1, b, ldb, work[iwork:])
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the reciprocals of the singular values.
+		thr := thresh()
+		for i := 0; i < m; i++ {
+			if s[i] > thr {
+				impl.Drscl(nrhs, s[i], b[i*ldb:], 1)
+				rank++
+			} else {
+				impl.Dlaset(blas.All, 1, nrhs, 0, 0, b[i*ldb:], ldb)
+			}
+		}
+		iwork = ie
+
+		// Multiply B by the right singular vectors of L in work[il:] in
+		// blocks of columns that fit into work.
+		chunk := (lwork - iwork) / m
+		for i := 0; i < nrhs; i += chunk {
+			bl := min(nrhs-i, chunk)
+			bi.Dgemm(blas.Trans, blas.NoTrans, m, bl, m, 1, work[il:], ldwork, b[i:], ldb, 0, work[iwork:], bl)
+			impl.Dlacpy(blas.All, m, bl, work[iwork:], bl, b[i:], ldb)
+		}
+
+		// Zero out below the first m rows of B.
+		impl.Dlaset(blas.All, n-m, nrhs, 0, 0, b[m*ldb:], ldb)
+		iwork = itau + m
+
+		// Multiply Q^T by B.
+		impl.Dormlq(blas.Left, blas.Trans, n, nrhs, m, a, lda, work[itau:itau+m], b, ldb,
+			work[iwork:], lwork-iwork)
+	} else {
+		// Path 2 (n > m, but not much larger).
+		ie := 0
+		itauq := ie + m
+		itaup := itauq + m
+		iwork := itaup + m
+
+		// Bidiagonalize A.
+		impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:itauq+m], work[itaup:itaup+m],
+			work[iwork:], lwork-iwork)
+
+		// Multiply B by the transpose of the left bidiagonalizing vectors.
+		impl.Dormbr(lapack.ApplyQ, blas.Left, blas.Trans, m, nrhs, n, a, lda, work[itauq:itauq+m],
+			b, ldb, work[iwork:], lwork-iwork)
+
+		// Generate the right bidiagonalizing vectors in A.
+		impl.Dorgbr(lapack.ApplyP, m, n, m, a, lda, work[itaup:itaup+m], work[iwork:], lwork-iwork)
+		iwork = ie + m
+
+		// Perform bidiagonal QR iteration, computing the right singular
+		// vectors of A in A and multiplying B by the transpose of the left
+		// singular vectors.
+		ok = impl.Dbdsqr(blas.Lower, m, n, 0, nrhs, s, work[ie:], a, lda, nil, 1, b, ldb, work[iwork:])
+		if !ok {
+			return 0, false
+		}
+
+		// Multiply B by the reciprocals of the singular values.
+		thr := thresh()
+		for i := 0; i < m; i++ {
+			if s[i] > thr {
+				impl.Drscl(nrhs, s[i], b[i*ldb:], 1)
+				rank++
+			} else {
+				impl.Dlaset(blas.All, 1, nrhs, 0, 0, b[i*ldb:], ldb)
+			}
+		}
+
+		// Multiply B by the right singular vectors of A in blocks of
+		// columns that fit into work.
+		chunk := lwork / n
+		for i := 0; i < nrhs; i += chunk {
+			bl := min(nrhs-i, chunk)
+			bi.Dgemm(blas.Trans, blas.NoTrans, n, bl, m, 1, a, lda, b[i:], ldb, 0, work, bl)
+			impl.Dlacpy(blas.All, n, bl, work, bl, b[i:], ldb)
+		}
+	}
+
+	// Undo scaling.
+	if iascl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, 1)
+	} else if iascl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, 1)
+	}
+	if ibscl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, smlnum, bnrm, n, nrhs, b, ldb)
+	} else if ibscl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, bignum, bnrm, n, nrhs, b, ldb)
+	}
+	work[0] = float64(maxwrk)
+	return rank, true
+}
@@ -0,0 +1,227 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgelsy computes the minimum-norm solution to a linear least squares problem
+//  minimize |B - A*X|_2
+// using a complete orthogonal factorization of the m×n matrix A, which may be
+// rank-deficient.
+//
+// The routine first computes a QR factorization with column pivoting
+//  A * P = Q * [ R11 R12 ]
+//              [  0  R22 ]
+// with R11 defined as the largest leading submatrix whose estimated condition
+// number is less than 1/rcond. The order of R11, rank, is the effective rank
+// of A. Then, R22 is considered to be negligible, and R12 is annihilated by
+// orthogonal transformations from the right, arriving at the complete
+// orthogonal factorization
+//  A * P = Q * [ T11 0 ] * Z.
+//              [  0  0 ]
+// The minimum-norm solution is then
+//  X = P * Z^T * [ inv(T11)*Q1^T*B ],
+//                [        0        ]
+// where Q1 consists of the first rank columns of Q.
+//
+// Several right hand side vectors b and solution vectors x can be handled in
+// a single call. They are stored as the columns of the max(m,n)×nrhs matrix B
+// and the n×nrhs solution matrix X. On entry, the leading m×nrhs submatrix of
+// b contains the right hand sides, and on return its leading n×nrhs
+// submatrix contains the solution X.
+//
+// On return, a contains its complete orthogonal factorization.
+//
+// jpvt specifies a column pivot to be applied to A. If jpvt[j] is at least
+// zero, the j-th column of A is permuted to the front of A*P (a leading
+// column), and if jpvt[j] is -1, the j-th column of A is a free column. On
+// return, jpvt holds the permutation that was applied; the j-th column of A*P
+// was the jpvt[j] column of A. jpvt must have length n.
+//
+// rcond is used to determine the effective rank of A, which is defined as
+// the order of the largest leading triangular submatrix R11 in the QR
+// factorization with pivoting of A, whose estimated condition number is less
+// than 1/rcond.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+//  max(min(m,n) + 3*n + 1, 2*min(m,n) + nrhs),
+// or 1 if min(m,n) == 0 or nrhs == 0, otherwise Dgelsy will panic. For
+// optimal performance lwork should be larger. If lwork == -1, instead of
+// performing Dgelsy, the optimal work length will be stored into work[0].
+//
+// Dgelsy returns the effective rank of A.
+func (impl Implementation) Dgelsy(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, jpvt []int, rcond float64, work []float64, lwork int) (rank int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 0 {
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(m, n, a, lda)
+	maxmn := max(m, n)
+	checkMatrix(maxmn, nrhs, b, ldb)
+	if len(jpvt) != n {
+		panic(badIpiv)
+	}
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+
+	// Compute the minimal and optimal workspace.
+	mn := min(m, n)
+	lwkmin := 1
+	lwkopt := 1
+	if mn > 0 && nrhs > 0 {
+		nb1 := impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+		nb2 := impl.Ilaenv(1, "DGERQF", " ", m, n, -1, -1)
+		nb3 := impl.Ilaenv(1, "DORMQR", " ", m, n, nrhs, -1)
+		nb4 := impl.Ilaenv(1, "DORMRQ", " ", m, n, nrhs, -1)
+		nb := max(max(nb1, nb2), max(nb3, nb4))
+		lwkmin = max(mn+3*n+1, 2*mn+nrhs)
+		lwkopt = max(lwkmin, max(mn+2*n+nb*(n+1), 2*mn+nb*nrhs))
+	}
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return 0
+	}
+	if lwork < lwkmin {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if mn == 0 || nrhs == 0 {
+		return 0
+	}
+
+	// Get machine parameters.
+	smlnum := dlamchS / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	var iascl int
+	if anrm > 0 && anrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+		iascl = 1
+	} else if anrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+		iascl = 2
+	} else if anrm == 0 {
+		// Matrix is all zeros.
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		work[0] = float64(lwkopt)
+		return 0
+	}
+
+	// Scale B if max element outside range [smlnum, bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, m, nrhs, b, ldb, nil)
+	var ibscl int
+	if bnrm > 0 && bnrm < smlnum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, smlnum, m, nrhs, b, ldb)
+		ibscl = 1
+	} else if bnrm > bignum {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bignum, m, nrhs, b, ldb)
+		ibscl = 2
+	}
+
+	// Compute the QR factorization with column pivoting of A
+	//  A * P = Q * R.
+	// The scalar factors of the elementary reflectors are stored in
+	// work[0:mn].
+	impl.Dgeqp3(m, n, a, lda, jpvt, work[:mn], work[mn:], lwork-mn)
+
+	// Determine the rank using incremental condition estimation. The
+	// approximate singular vectors for the smallest and largest singular
+	// values of R11 are stored in work[ismin:] and work[ismax:] and the
+	// column of R being added is copied to work[iw:].
+	ismin := mn
+	ismax := ismin + mn
+	iw := ismax + mn
+	work[ismin] = 1
+	work[ismax] = 1
+	smax := math.Abs(a[0])
+	smin := smax
+	if smax == 0 {
+		impl.Dlaset(blas.All, maxmn, nrhs, 0, 0, b, ldb)
+		work[0] = float64(lwkopt)
+		return 0
+	}
+	bi := blas64.Implementation()
+	rank = 1
+	for rank < mn {
+		i := rank
+		bi.Dcopy(rank, a[i:], lda, work[iw:], 1)
+		sminpr, s1, c1 := impl.Dlaic1(2, rank, work[ismin:], smin, work[iw:], a[i*lda+i])
+		smaxpr, s2, c2 := impl.Dlaic1(1, rank, work[ismax:], smax, work[iw:], a[i*lda+i])
+		if smaxpr*rcond > sminpr {
+			break
+		}
+		bi.Dscal(rank, s1, work[ismin:], 1)
+		bi.Dscal(rank, s2, work[ismax:], 1)
+		work[ismin+rank] = c1
+		work[ismax+rank] = c2
+		smin = sminpr
+		smax = smaxpr
+		rank++
+	}
+
+	// Logically partition R as
+	//  R = [ R11 R12 ]
+	//      [  0  R22 ]
+	// where R11 = R[0:rank, 0:rank], and compute
+	//  [R11, R12] = [T11, 0] * Z.
+	// The scalar factors of the elementary reflectors of Z are stored in
+	// work[mn:mn+rank].
+	if rank < n {
+		impl.Dtzrzf(rank, n, a, lda, work[mn:mn+rank], work[2*mn:], lwork-2*mn)
+	}
+
+	// B[0:m, 0:nrhs] = Q^T * B[0:m, 0:nrhs]
+	impl.Dormqr(blas.Left, blas.Trans, m, nrhs, mn, a, lda, work[:mn], b, ldb,
+		work[2*mn:], lwork-2*mn)
+
+	// B[0:rank, 0:nrhs] = inv(T11) * B[0:rank, 0:nrhs]
+	bi.Dtrsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, rank, nrhs, 1, a, lda, b, ldb)
+	impl.Dlaset(blas.All, n-rank, nrhs, 0, 0, b[rank*ldb:], ldb)
+
+	// B[0:n, 0:nrhs] = Z^T * B[0:n, 0:nrhs]
+	if rank < n {
+		impl.Dormrz(blas.Left, blas.Trans, n, nrhs, rank, n-rank, a, lda, work[mn:mn+rank], b, ldb,
+			work[2*mn:], lwork-2*mn)
+	}
+
+	// B[0:n, 0:nrhs] = P * B[0:n, 0:nrhs]
+	for j := 0; j < nrhs; j++ {
+		for i, p := range jpvt {
+			work[p] = b[i*ldb+j]
+		}
+		bi.Dcopy(n, work, 1, b[j:], ldb)
+	}
+
+	// Undo scaling.
+	if iascl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.UpperTri, 0, 0, smlnum, anrm, rank, rank, a, lda)
+	} else if iascl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, n, nrhs, b, ldb)
+		impl.Dlascl(lapack.UpperTri, 0, 0, bignum, anrm, rank, rank, a, lda)
+	}
+	if ibscl == 1 {
+		impl.Dlascl(lapack.General, 0, 0, smlnum, bnrm, n, nrhs, b, ldb)
+	} else if ibscl == 2 {
+		impl.Dlascl(lapack.General, 0, 0, bignum, bnrm, n, nrhs, b, ldb)
+	}
+	work[0] = float64(lwkopt)
+	return rank
+}
@@ -0,0 +1,87 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dgesc2 solves a system of linear equations
+//  A * x = scale * rhs
+// with a general n×n matrix A using the LU factorization with complete
+// pivoting computed by Dgetc2.
+//
+// On entry, a must contain the LU factorization of A and ipiv and jpiv the
+// row and column pivot indices as returned by Dgetc2. ipiv and jpiv must have
+// length at least n.
+//
+// On entry, rhs must contain the right hand side vector, on return it is
+// overwritten with the solution vector x. rhs must have length at least n.
+//
+// scale is a scaling factor in (0,1] chosen so that the elements of the
+// solution do not overflow.
+//
+// Dgesc2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgesc2(n int, a []float64, lda int, rhs []float64, ipiv, jpiv []int) (scale float64) {
+	checkMatrix(n, n, a, lda)
+	switch {
+	case len(rhs) < n:
+		panic("lapack: insufficient length of rhs")
+	case len(ipiv) < n || len(jpiv) < n:
+		panic(badIpiv)
+	}
+
+	scale = 1
+	if n == 0 {
+		return scale
+	}
+
+	// Set constants to control overflow.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+
+	// Apply permutations ipiv to rhs.
+	for i := 0; i < n-1; i++ {
+		if ipiv[i] != i {
+			rhs[i], rhs[ipiv[i]] = rhs[ipiv[i]], rhs[i]
+		}
+	}
+
+	// Solve for L part.
+	for i := 0; i < n-1; i++ {
+		for j := i + 1; j < n; j++ {
+			rhs[j] -= a[j*lda+i] * rhs[i]
+		}
+	}
+
+	// Check for scaling.
+	bi := blas64.Implementation()
+	i := bi.Idamax(n, rhs, 1)
+	if 2*smlnum*math.Abs(rhs[i]) > math.Abs(a[(n-1)*lda+n-1]) {
+		temp := 0.5 / math.Abs(rhs[i])
+		bi.Dscal(n, temp, rhs, 1)
+		scale *= temp
+	}
+
+	// Solve for U part.
+	for i := n - 1; i >= 0; i-- {
+		temp := 1 / a[i*lda+i]
+		rhs[i] *= temp
+		for j := i + 1; j < n; j++ {
+			rhs[i] -= rhs[j] * (a[i*lda+j] * temp)
+		}
+	}
+
+	// Apply permutations jpiv to the solution.
+	for i := n - 2; i >= 0; i-- {
+		if jpiv[i] != i {
+			rhs[i], rhs[jpiv[i]] = rhs[jpiv[i]], rhs[i]
+		}
+	}
+
+	return scale
+}
@@ -0,0 +1,895 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgesdd computes the singular value decomposition of the input matrix A
+// using a divide and conquer algorithm.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively. If singular vectors are desired, Dgesdd is usually
+// significantly faster than Dgesvd for large matrices.
+//
+// jobz specifies the singular vectors that are computed. The behavior is as
+// follows
+//  jobz == lapack.SVDAll       All m columns of U and all n rows of V^T are
+//                              returned in u and vt.
+//  jobz == lapack.SVDInPlace   The first min(m,n) columns of U and rows of V^T
+//                              are returned in u and vt.
+//  jobz == lapack.SVDOverwrite If m >= n, the first n columns of U are written
+//                              into a and all rows of V^T are returned in vt.
+//                              Otherwise, all columns of U are returned in u
+//                              and the first m rows of V^T are written into a.
+//  jobz == lapack.SVDNone      No columns of U or rows of V^T are computed.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to
+// Dgesdd the data is overwritten. On exit, A contains the appropriate singular
+// vectors if jobz is lapack.SVDOverwrite.
+//
+// s is a slice of length at least min(m,n) and on exit contains the singular
+// values in decreasing order.
+//
+// u contains the left singular vectors on exit, stored column-wise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m < n, u is of
+// size m×m. If jobz == lapack.SVDInPlace, u is of size m×min(m,n). Otherwise
+// u is not used.
+//
+// vt contains the right singular vectors on exit, stored row-wise. If
+// jobz == lapack.SVDAll or if jobz == lapack.SVDOverwrite and m >= n, vt is of
+// size n×n. If jobz == lapack.SVDInPlace, vt is of size min(m,n)×n. Otherwise
+// vt is not used.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size
+// of the slice. With mn = min(m,n) and mx = max(m,n), lwork must be at least
+//  3*mn + max(mx, 7*mn)                     if jobz == lapack.SVDNone,
+//  3*mn + max(mx, 5*mn*mn + 4*mn)           if jobz == lapack.SVDOverwrite,
+//  4*mn*mn + 7*mn                           if jobz == lapack.SVDInPlace,
+//  4*mn*mn + 6*mn + mx                      if jobz == lapack.SVDAll,
+// and Dgesdd will panic otherwise. For good performance, lwork should
+// generally be larger. If lwork == -1, instead of performing Dgesdd, the
+// optimal work length will be stored into work[0].
+//
+// iwork must have length at least 8*min(m,n).
+//
+// Dgesdd returns whether the decomposition successfully completed.
+func (impl Implementation) Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool) {
+	minmn := min(m, n)
+	wntqa := jobz == lapack.SVDAll
+	wntqs := jobz == lapack.SVDInPlace
+	wntqo := jobz == lapack.SVDOverwrite
+	wntqn := jobz == lapack.SVDNone
+	if !wntqa && !wntqs && !wntqo && !wntqn {
+		panic(badSVDJob)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(m, n, a, lda)
+	if wntqa || (wntqo && m < n) {
+		checkMatrix(m, m, u, ldu)
+	} else if wntqs {
+		checkMatrix(m, minmn, u, ldu)
+	}
+	if wntqa || (wntqo && m >= n) {
+		checkMatrix(n, n, vt, ldvt)
+	} else if wntqs {
+		checkMatrix(minmn, n, vt, ldvt)
+	}
+	if len(s) < minmn {
+		panic(badS)
+	}
+
+	// Compute the minimal and optimal workspace.
+	minwrk := 1
+	maxwrk := 1
+	var bdspac int
+	mnthr := int(float64(minmn) * 11 / 6)
+	if m >= n && minmn > 0 {
+		// Compute space needed for Dbdsdc.
+		if wntqn {
+			bdspac = 7 * n
+		} else {
+			bdspac = 3*n*n + 4*n
+		}
+		var wrkbl int
+		if m >= mnthr {
+			switch {
+			case wntqn:
+				// Path 1 (m >> n, jobz == lapack.SVDNone).
+				wrkbl = n + n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, 3*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+				maxwrk = max(wrkbl, bdspac+n)
+				minwrk = bdspac + n
+			case wntqo:
+				// Path 2 (m >> n, jobz == lapack.SVDOverwrite).
+				wrkbl = n + n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, n+n*impl.Ilaenv(1, "DORGQR", " ", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = wrkbl + 2*n*n
+				minwrk = bdspac + 2*n*n + 3*n
+			case wntqs:
+				// Path 3 (m >> n, jobz == lapack.SVDInPlace).
+				wrkbl = n + n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, n+n*impl.Ilaenv(1, "DORGQR", " ", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = wrkbl + n*n
+				minwrk = bdspac + n*n + 3*n
+			case wntqa:
+				// Path 4 (m >> n, jobz == lapack.SVDAll).
+				wrkbl = n + n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, n+m*impl.Ilaenv(1, "DORGQR", " ", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = wrkbl + n*n
+				minwrk = n*n + max(3*n+bdspac, n+m)
+			}
+		} else {
+			// Path 5 (m >= n, but not much larger).
+			wrkbl = 3*n + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			switch {
+			case wntqn:
+				// Path 5n (m >= n, jobz == lapack.SVDNone).
+				maxwrk = max(wrkbl, 3*n+bdspac)
+				minwrk = 3*n + max(m, bdspac)
+			case wntqo:
+				// Path 5o (m >= n, jobz == lapack.SVDOverwrite).
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+bdspac)
+				maxwrk = wrkbl + m*n
+				minwrk = 3*n + max(m, n*n+bdspac)
+			case wntqs:
+				// Path 5s (m >= n, jobz == lapack.SVDInPlace).
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "QLN", m, n, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				maxwrk = max(wrkbl, 3*n+bdspac)
+				minwrk = 3*n + max(m, bdspac)
+			case wntqa:
+				// Path 5a (m >= n, jobz == lapack.SVDAll).
+				wrkbl = max(wrkbl, 3*n+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+				maxwrk = max(wrkbl, 3*n+bdspac)
+				minwrk = 3*n + max(m, bdspac)
+			}
+		}
+	} else if minmn > 0 {
+		// Compute space needed for Dbdsdc.
+		if wntqn {
+			bdspac = 7 * m
+		} else {
+			bdspac = 3*m*m + 4*m
+		}
+		var wrkbl int
+		if n >= mnthr {
+			switch {
+			case wntqn:
+				// Path 1t (n >> m, jobz == lapack.SVDNone).
+				wrkbl = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, 3*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+				maxwrk = max(wrkbl, bdspac+m)
+				minwrk = bdspac + m
+			case wntqo:
+				// Path 2t (n >> m, jobz == lapack.SVDOverwrite).
+				wrkbl = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, m+m*impl.Ilaenv(1, "DORGLQ", " ", m, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = wrkbl + 2*m*m
+				minwrk = bdspac + 2*m*m + 3*m
+			case wntqs:
+				// Path 3t (n >> m, jobz == lapack.SVDInPlace).
+				wrkbl = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, m+m*impl.Ilaenv(1, "DORGLQ", " ", m, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = wrkbl + m*m
+				minwrk = bdspac + m*m + 3*m
+			case wntqa:
+				// Path 4t (n >> m, jobz == lapack.SVDAll).
+				wrkbl = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+				wrkbl = max(wrkbl, m+n*impl.Ilaenv(1, "DORGLQ", " ", n, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = wrkbl + m*m
+				minwrk = m*m + max(3*m+bdspac, m+n)
+			}
+		} else {
+			// Path 5t (n > m, but not much larger).
+			wrkbl = 3*m + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			switch {
+			case wntqn:
+				// Path 5tn (n > m, jobz == lapack.SVDNone).
+				maxwrk = max(wrkbl, 3*m+bdspac)
+				minwrk = 3*m + max(n, bdspac)
+			case wntqo:
+				// Path 5to (n > m, jobz == lapack.SVDOverwrite).
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, n, m, -1))
+				wrkbl = max(wrkbl, 3*m+bdspac)
+				maxwrk = wrkbl + m*n
+				minwrk = 3*m + max(n, m*m+bdspac)
+			case wntqs:
+				// Path 5ts (n > m, jobz == lapack.SVDInPlace).
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, n, m, -1))
+				maxwrk = max(wrkbl, 3*m+bdspac)
+				minwrk = 3*m + max(n, bdspac)
+			case wntqa:
+				// Path 5ta (n > m, jobz == lapack.SVDAll).
+				wrkbl = max(wrkbl, 3*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+				wrkbl = max(wrkbl, 3*m+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, m, -1))
+				maxwrk = max(wrkbl, 3*m+bdspac)
+				minwrk = 3*m + max(n, bdspac)
+			}
+		}
+	}
+	maxwrk = max(maxwrk, minwrk)
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+	if lwork < minwrk || len(work) < lwork {
+		panic(badWork)
+	}
+	if len(iwork) < 8*minmn {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// Get machine constants.
+	eps := dlamchP
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	if math.IsNaN(anrm) {
+		panic("lapack: NaN in input matrix")
+	}
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+	}
+
+	bi := blas64.Implementation()
+	if m >= n {
+		// A has at least as many rows as columns. If A has sufficiently
+		// more rows than columns, first reduce using the QR decomposition.
+		if m >= mnthr {
+			switch {
+			case wntqn:
+				// Path 1 (m >> n, jobz == lapack.SVDNone).
+				// No singular vectors to be computed.
+				itau := 0
+				nwork := itau + n
+
+				// Compute A = Q * R.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Zero out below R.
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+				ie := 0
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in A.
+				impl.Dgebrd(n, n, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+				nwork = ie + n
+
+				// Perform bidiagonal SVD, computing singular values only.
+				ok = impl.Dbdsdc(blas.Upper, lapack.None, n, s, work[ie:], nil, 1, nil, 1,
+					work[nwork:], iwork)
+
+			case wntqo:
+				// Path 2 (m >> n, jobz == lapack.SVDOverwrite).
+				// n left singular vectors to be overwritten on A and n
+				// right singular vectors to be computed in vt.
+
+				// work[ir:] holds the n×n R and later the ldwrkr×n
+				// chunks of the left singular vectors of A.
+				ir := 0
+				ldwrkr := m
+				if lwork < m*n+n*n+3*n+bdspac {
+					ldwrkr = (lwork - n*n - 3*n - bdspac) / n
+				}
+				itau := ir + ldwrkr*n
+				nwork := itau + n
+
+				// Compute A = Q * R.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy R to work[ir:], zeroing out below it.
+				impl.Dlacpy(blas.Upper, n, n, a, lda, work[ir:], n)
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, work[ir+n:], n)
+
+				// Generate Q in A.
+				impl.Dorgqr(m, n, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in work[ir:].
+				impl.Dgebrd(n, n, work[ir:], n, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// work[iu:] is n×n.
+				iu := nwork
+				nwork = iu + n*n
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in work[iu:] and computing right
+				// singular vectors of bidiagonal matrix in vt.
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], work[iu:], n,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite work[iu:] by left singular vectors of R and vt
+				// by right singular vectors of R.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, work[ir:], n,
+					work[itauq:itauq+n], work[iu:], n, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, work[ir:], n,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply Q in A by left singular vectors of R in
+				// work[iu:], storing result in work[ir:] and copying to A.
+				for i := 0; i < m; i += ldwrkr {
+					chunk := min(m-i, ldwrkr)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, chunk, n, n, 1, a[i*lda:], lda,
+						work[iu:], n, 0, work[ir:], n)
+					impl.Dlacpy(blas.All, chunk, n, work[ir:], n, a[i*lda:], lda)
+				}
+
+			case wntqs:
+				// Path 3 (m >> n, jobz == lapack.SVDInPlace).
+				// n left singular vectors to be computed in u and n
+				// right singular vectors to be computed in vt.
+
+				// work[ir:] is n×n.
+				ir := 0
+				ldwrkr := n
+				itau := ir + ldwrkr*n
+				nwork := itau + n
+
+				// Compute A = Q * R.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy R to work[ir:], zeroing out below it.
+				impl.Dlacpy(blas.Upper, n, n, a, lda, work[ir:], ldwrkr)
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, work[ir+ldwrkr:], ldwrkr)
+
+				// Generate Q in A.
+				impl.Dorgqr(m, n, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in work[ir:].
+				impl.Dgebrd(n, n, work[ir:], ldwrkr, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of R and vt by
+				// right singular vectors of R.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, work[ir:], ldwrkr,
+					work[itauq:itauq+n], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, work[ir:], ldwrkr,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply Q in A by left singular vectors of R in
+				// work[ir:], storing result in u.
+				impl.Dlacpy(blas.All, n, n, u, ldu, work[ir:], ldwrkr)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, n, 1, a, lda, work[ir:], ldwrkr,
+					0, u, ldu)
+
+			case wntqa:
+				// Path 4 (m >> n, jobz == lapack.SVDAll).
+				// m left singular vectors to be computed in u and n
+				// right singular vectors to be computed in vt.
+
+				// work[iu:] is n×n.
+				iu := 0
+				ldwrku := n
+				itau := iu + ldwrku*n
+				nwork := itau + n
+
+				// Compute A = Q * R, copying result to u.
+				impl.Dgeqrf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				impl.Dlacpy(blas.Lower, m, n, a, lda, u, ldu)
+
+				// Generate Q in u.
+				impl.Dorgqr(m, m, n, u, ldu, work[itau:], work[nwork:], lwork-nwork)
+
+				// Produce R in A, zeroing out other entries.
+				impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, a[lda:], lda)
+				ie := itau
+				itauq := ie + n
+				itaup := itauq + n
+				nwork = itaup + n
+
+				// Bidiagonalize R in A.
+				impl.Dgebrd(n, n, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in work[iu:] and computing right
+				// singular vectors of bidiagonal matrix in vt.
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], work[iu:], ldwrku,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite work[iu:] by left singular vectors of R and vt
+				// by right singular vectors of R.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, n, n, a, lda,
+					work[itauq:itauq+n], work[iu:], ldwrku, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply Q in u by left singular vectors of R in
+				// work[iu:], storing result in A.
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, n, 1, u, ldu, work[iu:], ldwrku,
+					0, a, lda)
+
+				// Copy left singular vectors of A from A to u.
+				impl.Dlacpy(blas.All, m, n, a, lda, u, ldu)
+			}
+		} else {
+			// Path 5 (m >= n, but not much larger).
+			// Reduce to bidiagonal form without QR decomposition.
+			ie := 0
+			itauq := ie + n
+			itaup := itauq + n
+			nwork := itaup + n
+
+			// Bidiagonalize A.
+			impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+				work[nwork:], lwork-nwork)
+
+			switch {
+			case wntqn:
+				// Path 5n (m >= n, jobz == lapack.SVDNone).
+				// Perform bidiagonal SVD, only computing singular values.
+				ok = impl.Dbdsdc(blas.Upper, lapack.None, n, s, work[ie:], nil, 1, nil, 1,
+					work[nwork:], iwork)
+
+			case wntqo:
+				// Path 5o (m >= n, jobz == lapack.SVDOverwrite).
+				// If there is enough workspace, work[iu:] is m×n.
+				// Otherwise, work[iu:] is n×n and work[ir:] holds
+				// ldwrkr×n chunks of the left singular vectors of A.
+				iu := nwork
+				fast := lwork >= m*n+3*n+bdspac
+				var ir, ldwrkr int
+				if fast {
+					nwork = iu + m*n
+					impl.Dlaset(blas.All, m, n, 0, 0, work[iu:], n)
+				} else {
+					nwork = iu + n*n
+					ir = nwork
+					ldwrkr = (lwork - n*n - 3*n) / n
+				}
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in work[iu:] and computing right
+				// singular vectors of bidiagonal matrix in vt.
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], work[iu:], n,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite vt by right singular vectors of A.
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+				if fast {
+					// Overwrite work[iu:] by left singular vectors of A.
+					impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, n, n, a, lda,
+						work[itauq:itauq+n], work[iu:], n, work[nwork:], lwork-nwork)
+
+					// Copy left singular vectors of A from work[iu:] to A.
+					impl.Dlacpy(blas.All, m, n, work[iu:], n, a, lda)
+				} else {
+					// Generate Q in A.
+					impl.Dorgbr(lapack.ApplyQ, m, n, n, a, lda, work[itauq:],
+						work[nwork:], lwork-nwork)
+
+					// Multiply Q in A by left singular vectors of
+					// bidiagonal matrix in work[iu:], storing result in
+					// work[ir:] and copying to A.
+					for i := 0; i < m; i += ldwrkr {
+						chunk := min(m-i, ldwrkr)
+						bi.Dgemm(blas.NoTrans, blas.NoTrans, chunk, n, n, 1, a[i*lda:], lda,
+							work[iu:], n, 0, work[ir:], n)
+						impl.Dlacpy(blas.All, chunk, n, work[ir:], n, a[i*lda:], lda)
+					}
+				}
+
+			case wntqs:
+				// Path 5s (m >= n, jobz == lapack.SVDInPlace).
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				impl.Dlaset(blas.All, m, n, 0, 0, u, ldu)
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of A and vt by
+				// right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, n, n, a, lda,
+					work[itauq:itauq+n], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, n, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+			case wntqa:
+				// Path 5a (m >= n, jobz == lapack.SVDAll).
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				impl.Dlaset(blas.All, m, m, 0, 0, u, ldu)
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, n, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Set the right corner of u to identity matrix.
+				if m > n {
+					impl.Dlaset(blas.All, m-n, m-n, 0, 1, u[n*ldu+n:], ldu)
+				}
+
+				// Overwrite u by left singular vectors of A and vt by
+				// right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda,
+					work[itauq:itauq+n], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, m, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	} else {
+		// A has more columns than rows. If A has sufficiently more
+		// columns than rows, first reduce using the LQ decomposition.
+		if n >= mnthr {
+			switch {
+			case wntqn:
+				// Path 1t (n >> m, jobz == lapack.SVDNone).
+				// No singular vectors to be computed.
+				itau := 0
+				nwork := itau + m
+
+				// Compute A = L * Q.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Zero out above L.
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, a[1:], lda)
+				ie := 0
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in A.
+				impl.Dgebrd(m, m, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+				nwork = ie + m
+
+				// Perform bidiagonal SVD, computing singular values only.
+				ok = impl.Dbdsdc(blas.Upper, lapack.None, m, s, work[ie:], nil, 1, nil, 1,
+					work[nwork:], iwork)
+
+			case wntqo:
+				// Path 2t (n >> m, jobz == lapack.SVDOverwrite).
+				// m right singular vectors to be overwritten on A and m
+				// left singular vectors to be computed in u.
+
+				// work[ivt:] is m×m. work[il:] holds the m×m L and later
+				// the m×chunk blocks of the right singular vectors of A.
+				ivt := 0
+				il := ivt + m*m
+				chunk := n
+				if lwork < m*n+m*m+3*m+bdspac {
+					chunk = (lwork - m*m) / m
+				}
+				itau := il + m*m
+				nwork := itau + m
+
+				// Compute A = L * Q.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy L to work[il:], zeroing out above it.
+				impl.Dlacpy(blas.Lower, m, m, a, lda, work[il:], m)
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[il+1:], m)
+
+				// Generate Q in A.
+				impl.Dorglq(m, n, m, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in work[il:].
+				impl.Dgebrd(m, m, work[il:], m, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in work[ivt:].
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					work[ivt:], m, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of L and work[ivt:]
+				// by right singular vectors of L.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, work[il:], m,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, work[il:], m,
+					work[itaup:], work[ivt:], m, work[nwork:], lwork-nwork)
+
+				// Multiply right singular vectors of L in work[ivt:] by Q
+				// in A, storing result in work[il:] and copying to A.
+				for i := 0; i < n; i += chunk {
+					blk := min(n-i, chunk)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, m, blk, m, 1, work[ivt:], m,
+						a[i:], lda, 0, work[il:], chunk)
+					impl.Dlacpy(blas.All, m, blk, work[il:], chunk, a[i:], lda)
+				}
+
+			case wntqs:
+				// Path 3t (n >> m, jobz == lapack.SVDInPlace).
+				// m right singular vectors to be computed in vt and m
+				// left singular vectors to be computed in u.
+
+				// work[il:] is m×m.
+				il := 0
+				ldwrkl := m
+				itau := il + ldwrkl*m
+				nwork := itau + m
+
+				// Compute A = L * Q.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+
+				// Copy L to work[il:], zeroing out above it.
+				impl.Dlacpy(blas.Lower, m, m, a, lda, work[il:], ldwrkl)
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[il+1:], ldwrkl)
+
+				// Generate Q in A.
+				impl.Dorglq(m, n, m, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				ie := itau
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in work[il:].
+				impl.Dgebrd(m, m, work[il:], ldwrkl, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of L and vt by
+				// right singular vectors of L.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, work[il:], ldwrkl,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, work[il:], ldwrkl,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+				// Multiply right singular vectors of L in work[il:] by Q
+				// in A, storing result in vt.
+				impl.Dlacpy(blas.All, m, m, vt, ldvt, work[il:], ldwrkl)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, m, 1, work[il:], ldwrkl, a, lda,
+					0, vt, ldvt)
+
+			case wntqa:
+				// Path 4t (n >> m, jobz == lapack.SVDAll).
+				// n right singular vectors to be computed in vt and m
+				// left singular vectors to be computed in u.
+
+				// work[ivt:] is m×m.
+				ivt := 0
+				ldwkvt := m
+				itau := ivt + ldwkvt*m
+				nwork := itau + m
+
+				// Compute A = L * Q, copying result to vt.
+				impl.Dgelqf(m, n, a, lda, work[itau:], work[nwork:], lwork-nwork)
+				impl.Dlacpy(blas.Upper, m, n, a, lda, vt, ldvt)
+
+				// Generate Q in vt.
+				impl.Dorglq(n, n, m, vt, ldvt, work[itau:], work[nwork:], lwork-nwork)
+
+				// Produce L in A, zeroing out other entries.
+				impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, a[1:], lda)
+				ie := itau
+				itauq := ie + m
+				itaup := itauq + m
+				nwork = itaup + m
+
+				// Bidiagonalize L in A.
+				impl.Dgebrd(m, m, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+					work[nwork:], lwork-nwork)
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in work[ivt:].
+				ok = impl.Dbdsdc(blas.Upper, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					work[ivt:], ldwkvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of L and work[ivt:]
+				// by right singular vectors of L.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, m, a, lda,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, m, m, a, lda,
+					work[itaup:], work[ivt:], ldwkvt, work[nwork:], lwork-nwork)
+
+				// Multiply right singular vectors of L in work[ivt:] by Q
+				// in vt, storing result in A.
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, m, n, m, 1, work[ivt:], ldwkvt, vt, ldvt,
+					0, a, lda)
+
+				// Copy right singular vectors of A from A to vt.
+				impl.Dlacpy(blas.All, m, n, a, lda, vt, ldvt)
+			}
+		} else {
+			// Path 5t (n > m, but not much larger).
+			// Reduce to bidiagonal form without LQ decomposition.
+			ie := 0
+			itauq := ie + m
+			itaup := itauq + m
+			nwork := itaup + m
+
+			// Bidiagonalize A.
+			impl.Dgebrd(m, n, a, lda, s, work[ie:], work[itauq:], work[itaup:],
+				work[nwork:], lwork-nwork)
+
+			switch {
+			case wntqn:
+				// Path 5tn (n > m, jobz == lapack.SVDNone).
+				// Perform bidiagonal SVD, only computing singular values.
+				ok = impl.Dbdsdc(blas.Lower, lapack.None, m, s, work[ie:], nil, 1, nil, 1,
+					work[nwork:], iwork)
+
+			case wntqo:
+				// Path 5to (n > m, jobz == lapack.SVDOverwrite).
+				// If there is enough workspace, work[ivt:] is m×n.
+				// Otherwise, work[ivt:] is m×m and work[il:] holds
+				// m×chunk blocks of the right singular vectors of A.
+				ivt := nwork
+				fast := lwork >= m*n+3*m+bdspac
+				var ldwkvt, il, chunk int
+				if fast {
+					ldwkvt = n
+					impl.Dlaset(blas.All, m, n, 0, 0, work[ivt:], ldwkvt)
+					nwork = ivt + m*n
+				} else {
+					ldwkvt = m
+					nwork = ivt + m*m
+					il = nwork
+					chunk = (lwork - m*m - 3*m) / m
+				}
+
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in work[ivt:].
+				ok = impl.Dbdsdc(blas.Lower, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					work[ivt:], ldwkvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+
+				if fast {
+					// Overwrite work[ivt:] by right singular vectors of A.
+					impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, n, m, a, lda,
+						work[itaup:], work[ivt:], ldwkvt, work[nwork:], lwork-nwork)
+
+					// Copy right singular vectors of A from work[ivt:] to A.
+					impl.Dlacpy(blas.All, m, n, work[ivt:], ldwkvt, a, lda)
+				} else {
+					// Generate P^T in A.
+					impl.Dorgbr(lapack.ApplyP, m, n, m, a, lda, work[itaup:],
+						work[nwork:], lwork-nwork)
+
+					// Multiply right singular vectors of bidiagonal matrix
+					// in work[ivt:] by P^T in A, storing result in
+					// work[il:] and copying to A.
+					for i := 0; i < n; i += chunk {
+						blk := min(n-i, chunk)
+						bi.Dgemm(blas.NoTrans, blas.NoTrans, m, blk, m, 1, work[ivt:], ldwkvt,
+							a[i:], lda, 0, work[il:], chunk)
+						impl.Dlacpy(blas.All, m, blk, work[il:], chunk, a[i:], lda)
+					}
+				}
+
+			case wntqs:
+				// Path 5ts (n > m, jobz == lapack.SVDInPlace).
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				impl.Dlaset(blas.All, m, n, 0, 0, vt, ldvt)
+				ok = impl.Dbdsdc(blas.Lower, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Overwrite u by left singular vectors of A and vt by
+				// right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, m, n, m, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+
+			case wntqa:
+				// Path 5ta (n > m, jobz == lapack.SVDAll).
+				// Perform bidiagonal SVD, computing left singular vectors
+				// of bidiagonal matrix in u and computing right singular
+				// vectors of bidiagonal matrix in vt.
+				impl.Dlaset(blas.All, n, n, 0, 0, vt, ldvt)
+				ok = impl.Dbdsdc(blas.Lower, lapack.BidiagSV, m, s, work[ie:], u, ldu,
+					vt, ldvt, work[nwork:], iwork)
+
+				// Set the right corner of vt to identity matrix.
+				if n > m {
+					impl.Dlaset(blas.All, n-m, n-m, 0, 1, vt[m*ldvt+m:], ldvt)
+				}
+
+				// Overwrite u by left singular vectors of A and vt by
+				// right singular vectors of A.
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, m, n, a, lda,
+					work[itauq:itauq+m], u, ldu, work[nwork:], lwork-nwork)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, n, n, m, a, lda,
+					work[itaup:], vt, ldvt, work[nwork:], lwork-nwork)
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, minmn, 1, s, 1)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, minmn, 1, s, 1)
+		}
+	}
+
+	work[0] = float64(maxwrk)
+	return ok
+}
@@ -0,0 +1,54 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dgesv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n matrix and X and B are n×nrhs matrices.
+//
+// The LU decomposition with partial pivoting and row interchanges is used to
+// factor A as
+//  A = P * L * U
+// where P is a permutation matrix, L is unit lower triangular, and U is upper
+// triangular. On return, the factors L and U are stored in a; the unit
+// diagonal elements of L are not stored. The row pivot indices that define
+// the permutation matrix P are stored in ipiv. ipiv must have length at least
+// n and Dgesv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dgesv returns false if U is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the LU factorization of A.
+	ok = impl.Dgetrf(n, n, a, lda, ipiv)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.Dgetrs(blas.NoTrans, n, nrhs, a, lda, ipiv, b, ldb)
+	return true
+}
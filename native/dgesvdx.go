@@ -0,0 +1,373 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgesvdx computes selected singular values and, optionally, the
+// corresponding left and right singular vectors of an m×n matrix A.
+//
+// The singular value decomposition is
+//  A = U * Sigma * V^T
+// where Sigma is an m×n diagonal matrix containing the singular values of A,
+// U is an m×m orthogonal matrix and V is an n×n orthogonal matrix. The first
+// min(m,n) columns of U and V are the left and right singular vectors of A
+// respectively.
+//
+// A is first reduced to bidiagonal form by Dgebrd, preceded by a QR or LQ
+// factorization if A has many more rows than columns or vice versa. The
+// selected singular values and vectors of the bidiagonal matrix are then
+// computed by Dbdsvdx, and the singular vectors are transformed back to
+// those of A. If only a few singular values are needed, Dgesvdx is usually
+// significantly faster than Dgesvd.
+//
+// The singular values to compute are selected by rng. If rng is
+// lapack.RangeAll, all singular values are computed. If rng is
+// lapack.RangeInterval, the singular values in the half-open interval (vl,vu]
+// are computed, and vl and vu must satisfy 0 <= vl < vu. If rng is
+// lapack.RangeIndex, the il-th through iu-th largest singular values are
+// computed, with indices starting at 0, and il and iu must satisfy
+// 0 <= il <= iu < min(m,n).
+//
+// jobU and jobVT specify whether the left and right singular vectors
+// corresponding to the selected singular values are computed. They must be
+// either lapack.SVDInPlace, in which case the singular vectors are computed,
+// or lapack.SVDNone.
+//
+// On entry, a contains the data for the m×n matrix A. During the call to
+// Dgesvdx the data is overwritten.
+//
+// On return, the first ns elements of s contain the selected singular values
+// in decreasing order. s must have length at least min(m,n).
+//
+// If jobU == lapack.SVDInPlace, the first ns columns of u contain on return
+// the left singular vectors corresponding to the selected singular values.
+// If jobVT == lapack.SVDInPlace, the first ns rows of vt contain on return
+// the right singular vectors, stored row-wise. u must be m×(iu-il+1) and vt
+// (iu-il+1)×n if rng is lapack.RangeIndex, and otherwise u must be
+// m×min(m,n) and vt min(m,n)×n. u and vt are not referenced if the
+// corresponding singular vectors are not computed.
+//
+// work is a slice for storing temporary memory, and lwork is the usable size
+// of the slice. With mn = min(m,n) and mx = max(m,n), lwork must be at least
+//  3*mn*mn + 21*mn                 if A is first reduced by a QR or LQ factorization,
+//  4*mn + max(mx, 2*mn*mn + 16*mn) otherwise,
+// and Dgesvdx will panic otherwise. Whether the QR or LQ factorization is used
+// depends on how much larger mx is than mn, as determined by Ilaenv. For good
+// performance, lwork should generally be larger. If lwork == -1, instead of
+// performing Dgesvdx, the optimal work length will be stored into work[0].
+//
+// iwork must have length at least 10*min(m,n).
+//
+// Dgesvdx returns the number of singular values found and whether the
+// computation succeeded. If ok is false, some of the singular values or
+// vectors of the bidiagonal matrix failed to converge.
+func (impl Implementation) Dgesvdx(jobU, jobVT lapack.SVDJob, rng lapack.EVRange, m, n int, a []float64, lda int, vl, vu float64, il, iu int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ns int, ok bool) {
+	minmn := min(m, n)
+	wantu := jobU == lapack.SVDInPlace
+	wantvt := jobVT == lapack.SVDInPlace
+	alls := rng == lapack.RangeAll
+	vals := rng == lapack.RangeInterval
+	inds := rng == lapack.RangeIndex
+	switch {
+	case !wantu && jobU != lapack.SVDNone:
+		panic(badSVDJob)
+	case !wantvt && jobVT != lapack.SVDNone:
+		panic(badSVDJob)
+	case !alls && !vals && !inds:
+		panic(badRange)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case vals && vl < 0:
+		panic(negVl)
+	case vals && vl >= vu:
+		panic(badInterval)
+	case inds && (il < 0 || il > max(0, minmn-1)):
+		panic(badIl)
+	case inds && (iu < min(minmn-1, il) || iu >= max(1, minmn)):
+		panic(badIu)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(s) < minmn {
+		panic(badS)
+	}
+	ncol := minmn
+	if inds {
+		ncol = iu - il + 1
+	}
+	if wantu {
+		checkMatrix(m, ncol, u, ldu)
+	}
+	if wantvt {
+		checkMatrix(ncol, n, vt, ldvt)
+	}
+
+	// Compute the minimal and optimal workspace.
+	minwrk := 1
+	maxwrk := 1
+	opts := string(jobU) + string(jobVT)
+	mnthr := impl.Ilaenv(6, "DGESVD", opts, m, n, 0, 0)
+	if m >= n && minmn > 0 {
+		bdspac := 2*n*n + 16*n
+		if m >= mnthr {
+			// Path 1 (m >> n).
+			maxwrk = n + n*impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+			maxwrk = max(maxwrk, n*n+5*n+2*n*impl.Ilaenv(1, "DGEBRD", " ", n, n, -1, -1))
+			if wantu {
+				maxwrk = max(maxwrk, n*n+5*n+bdspac+n*impl.Ilaenv(1, "DORMBR", "QLN", n, n, n, -1))
+				maxwrk = max(maxwrk, n*n+5*n+bdspac+n*impl.Ilaenv(1, "DORMQR", "LN", m, n, n, -1))
+			}
+			if wantvt {
+				maxwrk = max(maxwrk, n*n+5*n+bdspac+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+			}
+			minwrk = n*n + 5*n + bdspac
+		} else {
+			// Path 2 (m >= n, but not much larger).
+			maxwrk = 4*n + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			if wantu {
+				maxwrk = max(maxwrk, 4*n+2*n*n+n*impl.Ilaenv(1, "DORMBR", "QLN", m, n, n, -1))
+			}
+			if wantvt {
+				maxwrk = max(maxwrk, 4*n+2*n*n+n*impl.Ilaenv(1, "DORMBR", "PRT", n, n, n, -1))
+			}
+			minwrk = 4*n + max(m, bdspac)
+		}
+	} else if minmn > 0 {
+		bdspac := 2*m*m + 16*m
+		if n >= mnthr {
+			// Path 1t (n >> m).
+			maxwrk = m + m*impl.Ilaenv(1, "DGELQF", " ", m, n, -1, -1)
+			maxwrk = max(maxwrk, m*m+5*m+2*m*impl.Ilaenv(1, "DGEBRD", " ", m, m, -1, -1))
+			if wantu {
+				maxwrk = max(maxwrk, m*m+5*m+bdspac+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, m, -1))
+			}
+			if wantvt {
+				maxwrk = max(maxwrk, m*m+5*m+bdspac+m*impl.Ilaenv(1, "DORMBR", "PRT", m, m, m, -1))
+				maxwrk = max(maxwrk, m*m+5*m+bdspac+m*impl.Ilaenv(1, "DORMLQ", "RN", m, n, m, -1))
+			}
+			minwrk = m*m + 5*m + bdspac
+		} else {
+			// Path 2t (n > m, but not much larger).
+			maxwrk = 4*m + (m+n)*impl.Ilaenv(1, "DGEBRD", " ", m, n, -1, -1)
+			if wantu {
+				maxwrk = max(maxwrk, 4*m+2*m*m+m*impl.Ilaenv(1, "DORMBR", "QLN", m, m, n, -1))
+			}
+			if wantvt {
+				maxwrk = max(maxwrk, 4*m+2*m*m+m*impl.Ilaenv(1, "DORMBR", "PRT", m, n, m, -1))
+			}
+			minwrk = 4*m + max(n, bdspac)
+		}
+	}
+	maxwrk = max(maxwrk, minwrk)
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return 0, true
+	}
+	if lwork < minwrk || len(work) < lwork {
+		panic(badWork)
+	}
+	if len(iwork) < 10*minmn {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		work[0] = 1
+		return 0, true
+	}
+
+	// Get machine constants.
+	eps := dlamchP
+	smlnum := math.Sqrt(dlamchS) / eps
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum, bignum], and scale the
+	// interval for the singular values accordingly.
+	anrm := impl.Dlange(lapack.MaxAbs, m, n, a, lda, nil)
+	if math.IsNaN(anrm) {
+		panic("lapack: NaN in input matrix")
+	}
+	var iscl bool
+	if anrm > 0 && anrm < smlnum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, smlnum, m, n, a, lda)
+		vl *= smlnum / anrm
+		vu *= smlnum / anrm
+	} else if anrm > bignum {
+		iscl = true
+		impl.Dlascl(lapack.General, 0, 0, anrm, bignum, m, n, a, lda)
+		vl *= bignum / anrm
+		vu *= bignum / anrm
+	}
+
+	compq := lapack.SVDComp(lapack.None)
+	if wantu || wantvt {
+		compq = lapack.BidiagSV
+	}
+
+	bi := blas64.Implementation()
+	if m >= n {
+		// The singular vectors of the bidiagonal matrix are stored in
+		// the 2n×n matrix Z, whose first n rows hold the left singular
+		// vectors and last n rows the right singular vectors.
+		if m >= mnthr {
+			// Path 1 (m >> n).
+			// Compute A = Q * R.
+			itau := 0
+			itemp := itau + n
+			impl.Dgeqrf(m, n, a, lda, work[itau:itau+n], work[itemp:], lwork-itemp)
+
+			// Copy R into work and bidiagonalize it.
+			iqrf := itemp
+			id := iqrf + n*n
+			ie := id + n
+			itauq := ie + n
+			itaup := itauq + n
+			itemp = itaup + n
+			impl.Dlacpy(blas.Upper, n, n, a, lda, work[iqrf:], n)
+			impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, work[iqrf+n:], n)
+			impl.Dgebrd(n, n, work[iqrf:], n, work[id:], work[ie:], work[itauq:itauq+n], work[itaup:itaup+n], work[itemp:], lwork-itemp)
+
+			// Compute the selected singular values and vectors of the
+			// bidiagonal matrix.
+			itgkz := itemp
+			itemp = itgkz + 2*n*n
+			ns, ok = impl.Dbdsvdx(blas.Upper, compq, rng, n, work[id:], work[ie:], vl, vu, il, iu, s, work[itgkz:], n, work[itemp:], iwork)
+			if wantu && ns > 0 {
+				// Compute U = Q * QB * UB.
+				impl.Dlacpy(blas.All, n, ns, work[itgkz:], n, u, ldu)
+				impl.Dlaset(blas.All, m-n, ns, 0, 0, u[n*ldu:], ldu)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, n, ns, n, work[iqrf:], n, work[itauq:itauq+n], u, ldu, work[itemp:], lwork-itemp)
+				impl.Dormqr(blas.Left, blas.NoTrans, m, ns, n, a, lda, work[itau:itau+n], u, ldu, work[itemp:], lwork-itemp)
+			}
+			if wantvt && ns > 0 {
+				// Compute V^T = VB^T * PB^T.
+				for i := 0; i < ns; i++ {
+					bi.Dcopy(n, work[itgkz+n*n+i:], n, vt[i*ldvt:], 1)
+				}
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ns, n, n, work[iqrf:], n, work[itaup:itaup+n], vt, ldvt, work[itemp:], lwork-itemp)
+			}
+		} else {
+			// Path 2 (m >= n, but not much larger).
+			// Bidiagonalize A.
+			id := 0
+			ie := id + n
+			itauq := ie + n
+			itaup := itauq + n
+			itemp := itaup + n
+			impl.Dgebrd(m, n, a, lda, work[id:], work[ie:], work[itauq:itauq+n], work[itaup:itaup+n], work[itemp:], lwork-itemp)
+
+			// Compute the selected singular values and vectors of the
+			// bidiagonal matrix.
+			itgkz := itemp
+			itemp = itgkz + 2*n*n
+			ns, ok = impl.Dbdsvdx(blas.Upper, compq, rng, n, work[id:], work[ie:], vl, vu, il, iu, s, work[itgkz:], n, work[itemp:], iwork)
+			if wantu && ns > 0 {
+				// Compute U = Q * UB.
+				impl.Dlacpy(blas.All, n, ns, work[itgkz:], n, u, ldu)
+				impl.Dlaset(blas.All, m-n, ns, 0, 0, u[n*ldu:], ldu)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ns, n, a, lda, work[itauq:itauq+n], u, ldu, work[itemp:], lwork-itemp)
+			}
+			if wantvt && ns > 0 {
+				// Compute V^T = VB^T * P^T.
+				for i := 0; i < ns; i++ {
+					bi.Dcopy(n, work[itgkz+n*n+i:], n, vt[i*ldvt:], 1)
+				}
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ns, n, n, a, lda, work[itaup:itaup+n], vt, ldvt, work[itemp:], lwork-itemp)
+			}
+		}
+	} else {
+		// The singular vectors of the bidiagonal matrix are stored in
+		// the 2m×m matrix Z, whose first m rows hold the left singular
+		// vectors and last m rows the right singular vectors.
+		if n >= mnthr {
+			// Path 1t (n >> m).
+			// Compute A = L * Q.
+			itau := 0
+			itemp := itau + m
+			impl.Dgelqf(m, n, a, lda, work[itau:itau+m], work[itemp:], lwork-itemp)
+
+			// Copy L into work and bidiagonalize it.
+			ilqf := itemp
+			id := ilqf + m*m
+			ie := id + m
+			itauq := ie + m
+			itaup := itauq + m
+			itemp = itaup + m
+			impl.Dlacpy(blas.Lower, m, m, a, lda, work[ilqf:], m)
+			impl.Dlaset(blas.Upper, m-1, m-1, 0, 0, work[ilqf+1:], m)
+			impl.Dgebrd(m, m, work[ilqf:], m, work[id:], work[ie:], work[itauq:itauq+m], work[itaup:itaup+m], work[itemp:], lwork-itemp)
+
+			// Compute the selected singular values and vectors of the
+			// bidiagonal matrix.
+			itgkz := itemp
+			itemp = itgkz + 2*m*m
+			ns, ok = impl.Dbdsvdx(blas.Upper, compq, rng, m, work[id:], work[ie:], vl, vu, il, iu, s, work[itgkz:], m, work[itemp:], iwork)
+			if wantu && ns > 0 {
+				// Compute U = QB * UB.
+				impl.Dlacpy(blas.All, m, ns, work[itgkz:], m, u, ldu)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ns, m, work[ilqf:], m, work[itauq:itauq+m], u, ldu, work[itemp:], lwork-itemp)
+			}
+			if wantvt && ns > 0 {
+				// Compute V^T = VB^T * PB^T * Q.
+				for i := 0; i < ns; i++ {
+					bi.Dcopy(m, work[itgkz+m*m+i:], m, vt[i*ldvt:], 1)
+				}
+				impl.Dlaset(blas.All, ns, n-m, 0, 0, vt[m:], ldvt)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ns, m, m, work[ilqf:], m, work[itaup:itaup+m], vt, ldvt, work[itemp:], lwork-itemp)
+				impl.Dormlq(blas.Right, blas.NoTrans, ns, n, m, a, lda, work[itau:itau+m], vt, ldvt, work[itemp:], lwork-itemp)
+			}
+		} else {
+			// Path 2t (n > m, but not much larger).
+			// Bidiagonalize A.
+			id := 0
+			ie := id + m
+			itauq := ie + m
+			itaup := itauq + m
+			itemp := itaup + m
+			impl.Dgebrd(m, n, a, lda, work[id:], work[ie:], work[itauq:itauq+m], work[itaup:itaup+m], work[itemp:], lwork-itemp)
+
+			// Compute the selected singular values and vectors of the
+			// bidiagonal matrix, which is lower bidiagonal.
+			itgkz := itemp
+			itemp = itgkz + 2*m*m
+			ns, ok = impl.Dbdsvdx(blas.Lower, compq, rng, m, work[id:], work[ie:], vl, vu, il, iu, s, work[itgkz:], m, work[itemp:], iwork)
+			if wantu && ns > 0 {
+				// Compute U = Q * UB.
+				impl.Dlacpy(blas.All, m, ns, work[itgkz:], m, u, ldu)
+				impl.Dormbr(lapack.ApplyQ, blas.Left, blas.NoTrans, m, ns, n, a, lda, work[itauq:itauq+m], u, ldu, work[itemp:], lwork-itemp)
+			}
+			if wantvt && ns > 0 {
+				// Compute V^T = VB^T * P^T.
+				for i := 0; i < ns; i++ {
+					bi.Dcopy(m, work[itgkz+m*m+i:], m, vt[i*ldvt:], 1)
+				}
+				impl.Dlaset(blas.All, ns, n-m, 0, 0, vt[m:], ldvt)
+				impl.Dormbr(lapack.ApplyP, blas.Right, blas.Trans, ns, n, m, a, lda, work[itaup:itaup+m], vt, ldvt, work[itemp:], lwork-itemp)
+			}
+		}
+	}
+
+	// Undo scaling if necessary.
+	if iscl {
+		if anrm > bignum {
+			impl.Dlascl(lapack.General, 0, 0, bignum, anrm, ns, 1, s, 1)
+		}
+		if anrm < smlnum {
+			impl.Dlascl(lapack.General, 0, 0, smlnum, anrm, ns, 1, s, 1)
+		}
+	}
+
+	work[0] = float64(maxwrk)
+	return ns, ok
+}
@@ -0,0 +1,342 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgesvj computes the singular value decomposition of an m×n matrix A with
+// m >= n using the one-sided Jacobi method. The singular value decomposition
+// is
+//  A = U * Sigma * V^T
+// where Sigma is an n×n diagonal matrix containing the singular values of A,
+// U is an m×n matrix with orthonormal columns and V is an n×n orthogonal
+// matrix. The singular values of matrices of the form A = B*D, where D is
+// diagonal and B has well conditioned columns, are computed to high relative
+// accuracy regardless of the condition of D.
+//
+// joba specifies the structure of A and must be lapack.General,
+// lapack.UpperTri or lapack.LowerTri. The triangular structure is exploited
+// by a quasi-block pre-processing of the columns.
+//
+// jobu specifies whether the left singular vectors are computed.
+//  jobu == lapack.ComputeLeftSV  The left singular vectors corresponding to
+//                                the nonzero singular values are returned in
+//                                the columns of a.
+//  jobu == lapack.ControlLeftSV  As lapack.ComputeLeftSV, but the threshold
+//                                ctol for the orthogonality of the columns is
+//                                read from work[0] and must be at least 1.
+//                                Rotations are applied when the cosine of the
+//                                angle between two columns is larger than
+//                                ctol*eps.
+//  jobu == lapack.None           The left singular vectors are not computed
+//                                and a is overwritten.
+//
+// jobv specifies whether the right singular vectors are computed.
+//  jobv == lapack.ComputeRightSV The n×n matrix V is returned in v and mv is
+//                                ignored.
+//  jobv == lapack.ApplyRightSV   The product of the Jacobi rotations is
+//                                applied to the mv×n matrix in v.
+//  jobv == lapack.None           v is not referenced.
+//
+// On return, sva holds the computed singular values scaled by work[0], in
+// non-increasing order. That is, the singular values of A are work[0]*sva[i].
+// work[0] is different from 1 only if the singular values would otherwise
+// overflow or underflow. sva must have length at least n.
+//
+// work must have length at least lwork, and lwork must be at least
+// max(6, m+n). If lwork == -1, instead of performing Dgesvj, the minimum work
+// length will be stored into work[0]. On return,
+//  work[0] is the scaling factor of the singular values,
+//  work[1] is the number of nonzero computed singular values,
+//  work[2] is the number of computed singular values larger than the
+//          underflow threshold,
+//  work[3] is the number of sweeps of rotations performed,
+//  work[4] is the largest absolute cosine of the angle between two columns
+//          in the last sweep,
+//  work[5] is the largest absolute sine of a rotation in the last sweep.
+//
+// Dgesvj returns whether the iteration converged within 30 sweeps. If it did
+// not, the computed results may still be useful and work[4] and work[5] can
+// be inspected to judge their quality.
+func (impl Implementation) Dgesvj(joba lapack.MatrixType, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, m, n int, a []float64, lda int, sva []float64, mv int, v []float64, ldv int, work []float64, lwork int) (ok bool) {
+	const nsweep = 30
+
+	upper := joba == lapack.UpperTri
+	lower := joba == lapack.LowerTri
+	if !upper && !lower && joba != lapack.General {
+		panic(badMatrixType)
+	}
+	uctol := jobu == lapack.ControlLeftSV
+	lsvec := jobu == lapack.ComputeLeftSV || uctol
+	if !lsvec && jobu != lapack.None {
+		panic(badLeftSVJob)
+	}
+	rsvec := jobv == lapack.ComputeRightSV
+	applv := jobv == lapack.ApplyRightSV
+	if !rsvec && !applv && jobv != lapack.None {
+		panic(badRightSVJob)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 || n > m {
+		panic(badDims)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(sva) < n {
+		panic(badSVA)
+	}
+	mvl := n
+	if applv {
+		if mv < 0 {
+			panic(badDims)
+		}
+		mvl = mv
+	}
+	if rsvec || applv {
+		checkMatrix(mvl, n, v, ldv)
+	}
+	minWork := max(6, m+n)
+	if lwork == -1 {
+		work[0] = float64(minWork)
+		return true
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < minWork {
+		panic(badWork)
+	}
+	if uctol && work[0] < 1 {
+		panic(badCtol)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	bi := blas64.Implementation()
+
+	var ctol float64
+	switch {
+	case uctol:
+		ctol = work[0]
+	case lsvec || rsvec || applv:
+		ctol = math.Sqrt(float64(m))
+	default:
+		ctol = float64(m)
+	}
+	// Set the machine constants.
+	const (
+		eps   = dlamchE
+		sfmin = dlamchS
+		big   = math.MaxFloat64
+	)
+	tol := ctol * eps
+
+	if rsvec {
+		impl.Dlaset(blas.All, n, n, 0, 1, v, ldv)
+	}
+	rsvec = rsvec || applv
+
+	// Initialize sva with the column norms of A, scaled by skl if necessary
+	// to avoid overflow. The diagonal part is exploited when A is
+	// triangular.
+	skl := 1 / math.Sqrt(float64(m)*float64(n))
+	noscale := true
+	goscale := true
+	for p := 0; p < n; p++ {
+		var aapp, aaqq float64
+		switch {
+		case lower:
+			aapp, aaqq = impl.Dlassq(m-p, a[p*lda+p:], lda, 0, 1)
+		case upper:
+			aapp, aaqq = impl.Dlassq(p+1, a[p:], lda, 0, 1)
+		default:
+			aapp, aaqq = impl.Dlassq(m, a[p:], lda, 0, 1)
+		}
+		aaqq = math.Sqrt(aaqq)
+		if aapp < big/aaqq && noscale {
+			sva[p] = aapp * aaqq
+		} else {
+			noscale = false
+			sva[p] = aapp * (aaqq * skl)
+			if goscale {
+				goscale = false
+				bi.Dscal(p, skl, sva, 1)
+			}
+		}
+	}
+	if noscale {
+		skl = 1
+	}
+
+	// Find the largest and the smallest nonzero column norms.
+	aapp := 0.0
+	aaqq := big
+	for _, s := range sva[:n] {
+		if s != 0 {
+			aaqq = math.Min(aaqq, s)
+		}
+		aapp = math.Max(aapp, s)
+	}
+
+	// Quick return for the zero matrix.
+	if aapp == 0 {
+		if lsvec {
+			impl.Dlaset(blas.All, m, n, 0, 1, a, lda)
+		}
+		work[0] = 1
+		for i := 1; i < 6; i++ {
+			work[i] = 0
+		}
+		return true
+	}
+
+	// Quick return for a one-column matrix.
+	if n == 1 {
+		if lsvec {
+			impl.Dlascl(lapack.General, 0, 0, sva[0], skl, m, 1, a, lda)
+		}
+		work[0] = 1 / skl
+		if sva[0] >= sfmin {
+			work[1] = 1
+		} else {
+			work[1] = 0
+		}
+		for i := 2; i < 6; i++ {
+			work[i] = 0
+		}
+		return true
+	}
+
+	// Protect small singular values from underflow, and try to avoid
+	// underflows and overflows in computing the Jacobi rotations.
+	sn := math.Sqrt(sfmin / eps)
+	temp1 := math.Sqrt(big / float64(n))
+	switch {
+	case aapp <= sn || aaqq >= temp1 || (sn <= aaqq && aapp <= temp1):
+		temp1 = math.Min(big, temp1/aapp)
+	case aaqq <= sn && aapp <= temp1:
+		temp1 = math.Min(sn/aaqq, big/(aapp*math.Sqrt(float64(n))))
+	case aaqq >= sn && aapp >= temp1:
+		temp1 = math.Max(sn/aaqq, temp1/aapp)
+	case aaqq <= sn && aapp >= temp1:
+		temp1 = math.Min(sn/aaqq, big/(math.Sqrt(float64(n))*aapp))
+	default:
+		temp1 = 1
+	}
+	if temp1 != 1 {
+		impl.Dlascl(lapack.General, 0, 0, 1, temp1, n, 1, sva, 1)
+	}
+	skl *= temp1
+	if skl != 1 {
+		impl.Dlascl(joba, 0, 0, 1, skl, m, n, a, lda)
+		skl = 1 / skl
+	}
+
+	// The first n elements of work hold the diagonal scaling of the fast
+	// scaled rotations, so that A*diag(d) is the transformed matrix.
+	d := work[:n]
+	for i := range d {
+		d[i] = 1
+	}
+	wrk := work[n:lwork]
+
+	// Pre-process triangular matrices with a few sweeps over the blocks of
+	// a 4×4 block partition.
+	kbl := min(8, n)
+	if (lower || upper) && n > max(64, 4*kbl) {
+		n4 := n / 4
+		n2 := n / 2
+		n34 := 3 * n4
+		// The rows of V that are rotated start at the diagonal of the
+		// block if V is computed, since V is initially the identity.
+		var q int
+		if !applv {
+			q = 1
+		}
+		vsub := func(i int) []float64 {
+			if !rsvec {
+				return v
+			}
+			return v[i*q*ldv+i:]
+		}
+		if lower {
+			// This works very well on lower triangular matrices, in
+			// particular in the framework of the preconditioned
+			// Jacobi SVD in Dgejsv.
+			impl.Dgsvj0(jobv, m-n34, n-n34, a[n34*lda+n34:], lda, d[n34:], sva[n34:], mvl, vsub(n34), ldv, eps, sfmin, tol, 2, wrk, len(wrk))
+			impl.Dgsvj0(jobv, m-n2, n34-n2, a[n2*lda+n2:], lda, d[n2:], sva[n2:], mvl, vsub(n2), ldv, eps, sfmin, tol, 2, wrk, len(wrk))
+			impl.Dgsvj1(jobv, m-n2, n-n2, n4, a[n2*lda+n2:], lda, d[n2:], sva[n2:], mvl, vsub(n2), ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+			impl.Dgsvj0(jobv, m-n4, n2-n4, a[n4*lda+n4:], lda, d[n4:], sva[n4:], mvl, vsub(n4), ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+			impl.Dgsvj0(jobv, m, n4, a, lda, d, sva, mvl, v, ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+			impl.Dgsvj1(jobv, m, n2, n4, a, lda, d, sva, mvl, v, ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+		} else {
+			impl.Dgsvj0(jobv, n4, n4, a, lda, d, sva, mvl, v, ldv, eps, sfmin, tol, 2, wrk, len(wrk))
+			impl.Dgsvj0(jobv, n2, n4, a[n4:], lda, d[n4:], sva[n4:], mvl, vsub(n4), ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+			impl.Dgsvj1(jobv, n2, n2, n4, a, lda, d, sva, mvl, v, ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+			impl.Dgsvj0(jobv, n2+n4, n4, a[n2:], lda, d[n2:], sva[n2:], mvl, vsub(n2), ldv, eps, sfmin, tol, 1, wrk, len(wrk))
+		}
+	}
+
+	// Apply row-cyclic sweeps of rotations with de Rijk's pivoting until
+	// convergence.
+	sweeps, mxaapq, mxsinj, ok := impl.dgsvjSweeps(m, n, a, lda, d, sva, mvl, v, ldv, rsvec, eps, sfmin, tol, nsweep, 3, wrk)
+
+	// Sort the singular values and find how many are nonzero and how many
+	// are above the underflow threshold.
+	impl.dgsvjSort(m, n, a, lda, d, sva, mvl, v, ldv, rsvec)
+	var n2, n4 int
+	for _, s := range sva[:n] {
+		if s != 0 {
+			n4++
+			if s*skl > sfmin {
+				n2++
+			}
+		}
+	}
+
+	// Normalize the left singular vectors.
+	if lsvec {
+		for p := 0; p < n4; p++ {
+			bi.Dscal(m, d[p]/sva[p], a[p:], lda)
+		}
+	}
+
+	// Scale the product of the Jacobi rotations.
+	if rsvec {
+		if applv {
+			for p := 0; p < n; p++ {
+				bi.Dscal(mvl, d[p], v[p:], ldv)
+			}
+		} else {
+			for p := 0; p < n; p++ {
+				bi.Dscal(mvl, 1/bi.Dnrm2(mvl, v[p:], ldv), v[p:], ldv)
+			}
+		}
+	}
+
+	// Undo the scaling if possible.
+	if (skl > 1 && sva[0] < big/skl) || (skl < 1 && sva[max(n2, 1)-1] > sfmin/skl) {
+		bi.Dscal(n, skl, sva, 1)
+		skl = 1
+	}
+
+	work[0] = skl
+	work[1] = float64(n4)
+	work[2] = float64(n2)
+	work[3] = float64(sweeps)
+	work[4] = mxaapq
+	work[5] = mxsinj
+	return ok
+}
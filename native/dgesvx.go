@@ -0,0 +1,274 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dgesvx uses the LU factorization to compute the solution to a real system
+// of linear equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans,
+// where A is an n×n matrix and X and B are n×nrhs matrices. Error bounds on
+// the solution and a condition estimate are also provided.
+//
+// Dgesvx performs the following steps:
+//
+//  1. If fact == lapack.EquilibrateAndFactor, real scaling factors are
+//     computed by Dgeequ to equilibrate the system:
+//      trans == blas.NoTrans: diag(r)*A*diag(c) * inv(diag(c))*X = diag(r)*B,
+//      trans == blas.Trans:   (diag(r)*A*diag(c))^T * inv(diag(r))*X = diag(c)*B.
+//     Whether or not the system will be equilibrated depends on the scaling
+//     of the matrix A, but if equilibration is used, A is overwritten by
+//     diag(r)*A*diag(c) and B by diag(r)*B if trans == blas.NoTrans, or by
+//     diag(c)*B if trans == blas.Trans.
+//  2. If fact == lapack.NotFactored or lapack.EquilibrateAndFactor, the LU
+//     decomposition is used to factor the matrix A (after equilibration if
+//     fact == lapack.EquilibrateAndFactor) as
+//      A = P * L * U,
+//     where P is a permutation matrix, L is a unit lower triangular matrix,
+//     and U is upper triangular.
+//  3. If some U[i,i] == 0, so that U is exactly singular, then the routine
+//     returns with ok == false. Otherwise, the factored form of A is used to
+//     estimate the condition number of the matrix A. If the reciprocal of the
+//     condition number is less than machine precision, ok == false is
+//     returned as a warning, but the routine still goes on to solve for X and
+//     compute error bounds as described below.
+//  4. The system of equations is solved for X using the factored form of A.
+//  5. Iterative refinement is applied to improve the computed solution matrix
+//     and calculate error bounds and backward error estimates for it.
+//  6. If equilibration was used, the matrix X is premultiplied by diag(c) if
+//     trans == blas.NoTrans, or by diag(r) if trans == blas.Trans, so that it
+//     solves the original system before equilibration.
+//
+// If fact == lapack.Factored, af and ipiv must contain on entry the LU
+// factorization of A as computed by Dgetrf, and equed specifies the form of
+// equilibration that was applied to A, which must then contain the
+// equilibrated matrix. If equed is not lapack.NoEquilibration, the scale
+// factors in r and/or c must be positive. If fact is not lapack.Factored,
+// equed is ignored, and on return af and ipiv contain the LU factorization of
+// the (equilibrated) matrix A.
+//
+// r and c must have length at least n, and on return contain the row and
+// column scale factors of A, respectively. They are only referenced if
+// row or column equilibration is used.
+//
+// On entry, b contains the n×nrhs right hand side matrix B. On return, b is
+// overwritten by its equilibrated form if equilibration was used, and is not
+// modified otherwise. x is an n×nrhs matrix that on return contains the
+// solution matrix X of the original system.
+//
+// ferr and berr must have length at least nrhs and on return contain the
+// estimated forward error bound and the componentwise relative backward error
+// of each solution vector, respectively. See Dgerfs for details.
+//
+// work must have length at least 4*n and iwork must have length at least n,
+// otherwise Dgesvx will panic.
+//
+// Dgesvx returns the form of equilibration that was applied to A in equedOut,
+// the estimate of the reciprocal condition number of A after equilibration in
+// rcond, and the reciprocal pivot growth factor
+//  min_j ( max_i |A[i,j]| / max_i |U[i,j]| )
+// in rpvgrw. If rpvgrw is much less than 1, the stability of the LU
+// factorization of the (equilibrated) matrix A could be poor. This also means
+// that the solution X, condition estimate rcond, and forward error bound ferr
+// could be unreliable. If the factorization fails because U is exactly
+// singular, rpvgrw contains the reciprocal pivot growth factor for the leading
+// columns of A up to and including the first column with a zero pivot, rcond
+// is zero, and X and the error bounds are not computed.
+func (impl Implementation) Dgesvx(fact lapack.FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool) {
+	nofact := fact == lapack.NotFactored
+	equil := fact == lapack.EquilibrateAndFactor
+	notran := trans == blas.NoTrans
+	switch {
+	case !nofact && !equil && fact != lapack.Factored:
+		panic("lapack: bad FactorizationType")
+	case !notran && trans != blas.Trans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ipiv) < n:
+		panic(badIpiv)
+	case len(r) < n:
+		panic("lapack: r has insufficient length")
+	case len(c) < n:
+		panic("lapack: c has insufficient length")
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 4*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+
+	var rowequ, colequ bool
+	var rowcnd, colcnd float64
+	if nofact || equil {
+		equed = lapack.NoEquilibration
+	} else {
+		switch equed {
+		default:
+			panic("lapack: bad EquilibrationType")
+		case lapack.NoEquilibration:
+		case lapack.RowEquilibration:
+			rowequ = true
+		case lapack.ColumnEquilibration:
+			colequ = true
+		case lapack.BothEquilibration:
+			rowequ = true
+			colequ = true
+		}
+		smlnum := dlamchS
+		bignum := 1 / smlnum
+		if rowequ {
+			rcmin := bignum
+			var rcmax float64
+			for _, v := range r[:n] {
+				rcmin = math.Min(rcmin, v)
+				rcmax = math.Max(rcmax, v)
+			}
+			if rcmin <= 0 {
+				panic("lapack: non-positive row scale factor")
+			}
+			rowcnd = 1
+			if n > 0 {
+				rowcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+			}
+		}
+		if colequ {
+			rcmin := bignum
+			var rcmax float64
+			for _, v := range c[:n] {
+				rcmin = math.Min(rcmin, v)
+				rcmax = math.Max(rcmax, v)
+			}
+			if rcmin <= 0 {
+				panic("lapack: non-positive column scale factor")
+			}
+			colcnd = 1
+			if n > 0 {
+				colcnd = math.Max(rcmin, smlnum) / math.Min(rcmax, bignum)
+			}
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return equed, 1, 1, true
+	}
+
+	if equil {
+		// Compute row and column scalings to equilibrate the matrix A.
+		var amax float64
+		var eqok bool
+		rowcnd, colcnd, amax, eqok = impl.Dgeequ(n, n, a, lda, r, c)
+		if eqok {
+			// Equilibrate the matrix.
+			equed = impl.Dlaqge(n, n, a, lda, r, c, rowcnd, colcnd, amax)
+			rowequ = equed == lapack.RowEquilibration || equed == lapack.BothEquilibration
+			colequ = equed == lapack.ColumnEquilibration || equed == lapack.BothEquilibration
+		}
+	}
+
+	// Scale the right hand side.
+	if notran {
+		if rowequ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < nrhs; j++ {
+					b[i*ldb+j] *= r[i]
+				}
+			}
+		}
+	} else if colequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= c[i]
+			}
+		}
+	}
+
+	if nofact || equil {
+		// Compute the LU factorization of A.
+		impl.Dlacpy(blas.All, n, n, a, lda, af, ldaf)
+		if !impl.Dgetrf(n, n, af, ldaf, ipiv) {
+			// U is exactly singular. Compute the reciprocal pivot
+			// growth factor of the leading columns that were
+			// successfully factored up to the first zero pivot.
+			ncols := n
+			for i := 0; i < n; i++ {
+				if af[i*ldaf+i] == 0 {
+					ncols = i + 1
+					break
+				}
+			}
+			rpvgrw = impl.Dlagerpvgrw(n, ncols, a, lda, af, ldaf)
+			return equed, 0, rpvgrw, false
+		}
+	}
+
+	// Compute the reciprocal pivot growth factor.
+	rpvgrw = impl.Dlagerpvgrw(n, n, a, lda, af, ldaf)
+
+	// Compute the norm of the matrix A and the reciprocal of its
+	// condition number.
+	norm := lapack.MaxColumnSum
+	if !notran {
+		norm = lapack.MaxRowSum
+	}
+	anorm := impl.Dlange(norm, n, n, a, lda, work)
+	rcond = impl.Dgecon(norm, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution matrix X.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dgetrs(trans, n, nrhs, af, ldaf, ipiv, x, ldx)
+
+	// Use iterative refinement to improve the computed solution and
+	// compute error bounds and backward error estimates for it.
+	impl.Dgerfs(trans, n, nrhs, a, lda, af, ldaf, ipiv, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution matrix X to a solution of the original system.
+	if notran {
+		if colequ {
+			for i := 0; i < n; i++ {
+				for j := 0; j < nrhs; j++ {
+					x[i*ldx+j] *= c[i]
+				}
+			}
+			for j := 0; j < nrhs; j++ {
+				ferr[j] /= colcnd
+			}
+		}
+	} else if rowequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= r[i]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= rowcnd
+		}
+	}
+
+	// Set ok to false if the matrix is singular to working precision.
+	return equed, rcond, rpvgrw, rcond >= dlamchE
+}
@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dgetc2 computes an LU factorization with complete pivoting of the n×n
+// matrix A. The factorization has the form
+//  A = P * L * U * Q,
+// where P and Q are permutation matrices, L is lower triangular with unit
+// diagonal elements and U is upper triangular. On return, L and U are stored
+// in place into a.
+//
+// ipiv and jpiv are the row and column pivot indices, respectively. They
+// indicate that for 0 <= i < n, row i of the matrix was interchanged with row
+// ipiv[i] and column i was interchanged with column jpiv[i]. ipiv and jpiv
+// must have length at least n, otherwise Dgetc2 will panic.
+//
+// If a pivot element is smaller in magnitude than max(eps*max|A|, smlnum),
+// where eps is the machine precision and smlnum is the smallest safe number
+// divided by eps, it is replaced by that value and ok is returned false. In
+// that case the factorization is that of a slightly perturbed A.
+//
+// Dgetc2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgetc2(n int, a []float64, lda int, ipiv, jpiv []int) (ok bool) {
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n || len(jpiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Set constants to control overflow.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+
+	ok = true
+	if n == 1 {
+		ipiv[0] = 0
+		jpiv[0] = 0
+		if math.Abs(a[0]) < smlnum {
+			a[0] = smlnum
+			ok = false
+		}
+		return ok
+	}
+
+	// Factorize A using complete pivoting.
+	bi := blas64.Implementation()
+	var smin float64
+	for i := 0; i < n-1; i++ {
+		// Find max element in the trailing submatrix.
+		var xmax float64
+		var ipv, jpv int
+		for ip := i; ip < n; ip++ {
+			for jp := i; jp < n; jp++ {
+				if math.Abs(a[ip*lda+jp]) >= xmax {
+					xmax = math.Abs(a[ip*lda+jp])
+					ipv = ip
+					jpv = jp
+				}
+			}
+		}
+		if i == 0 {
+			smin = math.Max(eps*xmax, smlnum)
+		}
+
+		// Swap rows.
+		if ipv != i {
+			bi.Dswap(n, a[ipv*lda:], 1, a[i*lda:], 1)
+		}
+		ipiv[i] = ipv
+
+		// Swap columns.
+		if jpv != i {
+			bi.Dswap(n, a[jpv:], lda, a[i:], lda)
+		}
+		jpiv[i] = jpv
+
+		// Check for singularity.
+		if math.Abs(a[i*lda+i]) < smin {
+			a[i*lda+i] = smin
+			ok = false
+		}
+		for j := i + 1; j < n; j++ {
+			a[j*lda+i] /= a[i*lda+i]
+		}
+		bi.Dger(n-i-1, n-i-1, -1, a[(i+1)*lda+i:], lda, a[i*lda+i+1:], 1,
+			a[(i+1)*lda+i+1:], lda)
+	}
+
+	if math.Abs(a[(n-1)*lda+n-1]) < smin {
+		a[(n-1)*lda+n-1] = smin
+		ok = false
+	}
+
+	// Set last pivots to n-1.
+	ipiv[n-1] = n - 1
+	jpiv[n-1] = n - 1
+
+	return ok
+}
@@ -0,0 +1,88 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dggbak updates an n×m matrix V as
+//  V = P_R D_R V,   if side == lapack.RightEV,
+//  V = P_L D_L V,   if side == lapack.LeftEV,
+// where P_L, P_R and D_L, D_R are n×n permutation and scaling matrices,
+// respectively, implicitly represented by job, lscale, rscale, ilo and ihi as
+// returned by Dggbal.
+//
+// Typically, columns of the matrix V contain the right or left (determined by
+// side) generalized eigenvectors of the balanced matrix pair output by Dggbal,
+// and Dggbak forms the generalized eigenvectors of the original pair.
+//
+// lscale and rscale must have length at least n, otherwise Dggbak will panic.
+//
+// Dggbak is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggbak(job lapack.Job, side lapack.EVSide, n, ilo, ihi int, lscale, rscale []float64, m int, v []float64, ldv int) {
+	switch job {
+	default:
+		panic(badJob)
+	case lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale:
+	}
+	switch side {
+	default:
+		panic(badSide)
+	case lapack.LeftEV, lapack.RightEV:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case m < 0:
+		panic(mLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	case len(lscale) < n:
+		panic("lapack: insufficient length of lscale")
+	case len(rscale) < n:
+		panic("lapack: insufficient length of rscale")
+	}
+	checkMatrix(n, m, v, ldv)
+
+	// Quick return if possible.
+	if n == 0 || m == 0 || job == lapack.None {
+		return
+	}
+
+	scale := rscale
+	if side == lapack.LeftEV {
+		scale = lscale
+	}
+
+	bi := blas64.Implementation()
+	if ilo != ihi && job != lapack.Permute {
+		// Backward balance.
+		for i := ilo; i <= ihi; i++ {
+			bi.Dscal(m, scale[i], v[i*ldv:], 1)
+		}
+	}
+	if job == lapack.Scale {
+		return
+	}
+	// Backward permutation.
+	for i := ilo - 1; i >= 0; i-- {
+		k := int(scale[i])
+		if k == i {
+			continue
+		}
+		bi.Dswap(m, v[i*ldv:], 1, v[k*ldv:], 1)
+	}
+	for i := ihi + 1; i < n; i++ {
+		k := int(scale[i])
+		if k == i {
+			continue
+		}
+		bi.Dswap(m, v[i*ldv:], 1, v[k*ldv:], 1)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dggbal balances a pair of n×n real matrices (A,B). Balancing consists of two
+// stages, permuting and scaling. Both steps are optional and depend on the
+// value of job.
+//
+// Permuting consists of applying permutation matrices P_L and P_R such that
+// the matrices P_L*A*P_R and P_L*B*P_R are upper triangular in rows and columns
+// [0:ilo] and [ihi+1:n]. The eigenvalues of the pair (A,B) isolated in these
+// rows and columns can be read off the diagonals without any roundoff error.
+//
+// Scaling consists of applying diagonal matrices D_L and D_R such that the
+// elements of D_L*A*D_R and D_L*B*D_R in rows and columns [ilo:ihi+1] are as
+// close in magnitude to 1 as possible. Scaling may improve the accuracy of the
+// computed eigenvalues and/or eigenvectors of the generalized eigenproblem
+//  A*x = λ*B*x.
+//
+// job specifies the operations that will be performed on A and B.
+// If job is lapack.None, Dggbal sets lscale[i] = rscale[i] = 1 for all i and
+// returns ilo=0, ihi=n-1.
+// If job is lapack.Permute, only permuting will be done.
+// If job is lapack.Scale, only scaling will be done.
+// If job is lapack.PermuteScale, both permuting and scaling will be done.
+//
+// On return, lscale and rscale will contain information about the permutations
+// and scaling factors applied to the rows and columns of A and B,
+// respectively. If π_L(j) and π_R(j) denote the indices of the row and column
+// interchanged with row and column j, and D_L[j,j] and D_R[j,j] denote the
+// scaling factors applied to row and column j, then
+//  lscale[j] == π_L(j),     for j ∈ {0, ..., ilo-1, ihi+1, ..., n-1},
+//            == D_L[j,j],   for j ∈ {ilo, ..., ihi},
+//  rscale[j] == π_R(j),     for j ∈ {0, ..., ilo-1, ihi+1, ..., n-1},
+//            == D_R[j,j],   for j ∈ {ilo, ..., ihi}.
+// The order in which the interchanges are made is n-1 to ihi+1, then 0 to
+// ilo-1. lscale and rscale must have length n, otherwise Dggbal will panic.
+//
+// work must have length at least 6*n if job is lapack.Scale or
+// lapack.PermuteScale, otherwise work is not referenced.
+//
+// Dggbal is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggbal(job lapack.Job, n int, a []float64, lda int, b []float64, ldb int, lscale, rscale, work []float64) (ilo, ihi int) {
+	switch job {
+	default:
+		panic(badJob)
+	case lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale:
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	switch {
+	case len(lscale) != n:
+		panic("lapack: bad length of lscale")
+	case len(rscale) != n:
+		panic("lapack: bad length of rscale")
+	case (job == lapack.Scale || job == lapack.PermuteScale) && len(work) < 6*n:
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, -1
+	}
+	if job == lapack.None {
+		for i := range lscale {
+			lscale[i] = 1
+			rscale[i] = 1
+		}
+		return 0, n - 1
+	}
+	if n == 1 {
+		lscale[0] = 1
+		rscale[0] = 1
+		return 0, 0
+	}
+
+	bi := blas64.Implementation()
+	k := 0
+	l := n - 1
+	if job != lapack.Scale {
+		// Permute the matrices A and B to isolate the eigenvalues.
+
+		// Search for rows with at most one nonzero element in columns
+		// [0:l+1] and push them down.
+	rowSearch:
+		for {
+			for i := l; i >= 0; i-- {
+				j := l
+				var nz int
+				for jj := 0; jj <= l; jj++ {
+					if a[i*lda+jj] != 0 || b[i*ldb+jj] != 0 {
+						nz++
+						j = jj
+						if nz > 1 {
+							break
+						}
+					}
+				}
+				if nz > 1 {
+					continue
+				}
+				// Interchange rows i and l, and columns j and l.
+				lscale[l] = float64(i)
+				if i != l {
+					bi.Dswap(n-k, a[i*lda+k:], 1, a[l*lda+k:], 1)
+					bi.Dswap(n-k, b[i*ldb+k:], 1, b[l*ldb+k:], 1)
+				}
+				rscale[l] = float64(j)
+				if j != l {
+					bi.Dswap(l+1, a[j:], lda, a[l:], lda)
+					bi.Dswap(l+1, b[j:], ldb, b[l:], ldb)
+				}
+				l--
+				if l == 0 {
+					lscale[0] = 1
+					rscale[0] = 1
+					break rowSearch
+				}
+				continue rowSearch
+			}
+			break
+		}
+
+		// Search for columns with at most one nonzero element in rows
+		// [k:l+1] and push them left.
+	colSearch:
+		for l > 0 {
+			for j := k; j <= l; j++ {
+				i := l
+				var nz int
+				for ii := k; ii <= l; ii++ {
+					if a[ii*lda+j] != 0 || b[ii*ldb+j] != 0 {
+						nz++
+						i = ii
+						if nz > 1 {
+							break
+						}
+					}
+				}
+				if nz > 1 {
+					continue
+				}
+				// Interchange rows i and k, and columns j and k.
+				lscale[k] = float64(i)
+				if i != k {
+					bi.Dswap(n-k, a[i*lda+k:], 1, a[k*lda+k:], 1)
+					bi.Dswap(n-k, b[i*ldb+k:], 1, b[k*ldb+k:], 1)
+				}
+				rscale[k] = float64(j)
+				if j != k {
+					bi.Dswap(l+1, a[j:], lda, a[k:], lda)
+					bi.Dswap(l+1, b[j:], ldb, b[k:], ldb)
+				}
+				k++
+				continue colSearch
+			}
+			break
+		}
+	}
+
+	ilo = k
+	ihi = l
+	for i := ilo; i <= ihi; i++ {
+		lscale[i] = 1
+		rscale[i] = 1
+	}
+	if job == lapack.Permute || ilo == ihi {
+		return ilo, ihi
+	}
+
+	// Balance the submatrices in rows and columns [ilo:ihi+1] by the
+	// generalized conjugate gradient iteration described in
+	//  R.C. Ward, Balancing the generalized eigenvalue problem,
+	//  SIAM J. Sci. Stat. Comp. 2(2) (1981), pp. 141-152.
+	// The logarithms of the scaling factors are accumulated in lscale and
+	// rscale.
+	const sclfac = 10
+	nr := ihi - ilo + 1
+	for i := ilo; i <= ihi; i++ {
+		lscale[i] = 0
+		rscale[i] = 0
+		for p := 0; p < 6; p++ {
+			work[i+p*n] = 0
+		}
+	}
+
+	// Compute the right hand side vector of the resulting linear equations.
+	basl := math.Log10(sclfac)
+	for i := ilo; i <= ihi; i++ {
+		for j := ilo; j <= ihi; j++ {
+			ta := a[i*lda+j]
+			if ta != 0 {
+				ta = math.Log10(math.Abs(ta)) / basl
+			}
+			tb := b[i*ldb+j]
+			if tb != 0 {
+				tb = math.Log10(math.Abs(tb)) / basl
+			}
+			work[i+4*n] -= ta + tb
+			work[j+5*n] -= ta + tb
+		}
+	}
+
+	coef := 1 / float64(2*nr)
+	coef2 := coef * coef
+	coef5 := 0.5 * coef2
+	var beta, pgamma float64
+	for it := 1; it <= nr+2; it++ {
+		gamma := bi.Ddot(nr, work[ilo+4*n:], 1, work[ilo+4*n:], 1) +
+			bi.Ddot(nr, work[ilo+5*n:], 1, work[ilo+5*n:], 1)
+		var ew, ewc float64
+		for i := ilo; i <= ihi; i++ {
+			ew += work[i+4*n]
+			ewc += work[i+5*n]
+		}
+		gamma = coef*gamma - coef2*(ew*ew+ewc*ewc) - coef5*(ew-ewc)*(ew-ewc)
+		if gamma == 0 {
+			break
+		}
+		if it != 1 {
+			beta = gamma / pgamma
+		}
+		t := coef5 * (ewc - 3*ew)
+		tc := coef5 * (ew - 3*ewc)
+		bi.Dscal(nr, beta, work[ilo:], 1)
+		bi.Dscal(nr, beta, work[ilo+n:], 1)
+		bi.Daxpy(nr, coef, work[ilo+4*n:], 1, work[ilo+n:], 1)
+		bi.Daxpy(nr, coef, work[ilo+5*n:], 1, work[ilo:], 1)
+		for i := ilo; i <= ihi; i++ {
+			work[i] += tc
+			work[i+n] += t
+		}
+
+		// Apply the matrix to the vector.
+		for i := ilo; i <= ihi; i++ {
+			var kount int
+			var sum float64
+			for j := ilo; j <= ihi; j++ {
+				if a[i*lda+j] != 0 {
+					kount++
+					sum += work[j]
+				}
+				if b[i*ldb+j] != 0 {
+					kount++
+					sum += work[j]
+				}
+			}
+			work[i+2*n] = float64(kount)*work[i+n] + sum
+		}
+		for j := ilo; j <= ihi; j++ {
+			var kount int
+			var sum float64
+			for i := ilo; i <= ihi; i++ {
+				if a[i*lda+j] != 0 {
+					kount++
+					sum += work[i+n]
+				}
+				if b[i*ldb+j] != 0 {
+					kount++
+					sum += work[i+n]
+				}
+			}
+			work[j+3*n] = float64(kount)*work[j] + sum
+		}
+		sum := bi.Ddot(nr, work[ilo+n:], 1, work[ilo+2*n:], 1) +
+			bi.Ddot(nr, work[ilo:], 1, work[ilo+3*n:], 1)
+		alpha := gamma / sum
+
+		// Determine the correction to the current iteration.
+		var cmax float64
+		for i := ilo; i <= ihi; i++ {
+			cor := alpha * work[i+n]
+			cmax = math.Max(cmax, math.Abs(cor))
+			lscale[i] += cor
+			cor = alpha * work[i]
+			cmax = math.Max(cmax, math.Abs(cor))
+			rscale[i] += cor
+		}
+		if cmax < 0.5 {
+			break
+		}
+		bi.Daxpy(nr, -alpha, work[ilo+2*n:], 1, work[ilo+4*n:], 1)
+		bi.Daxpy(nr, -alpha, work[ilo+3*n:], 1, work[ilo+5*n:], 1)
+		pgamma = gamma
+	}
+
+	// Compute the scaling factors, making sure that the scaled matrices
+	// do not overflow.
+	lsfmin := int(math.Log10(dlamchS)/basl + 1)
+	lsfmax := int(math.Log10(1/dlamchS) / basl)
+	for i := ilo; i <= ihi; i++ {
+		irab := bi.Idamax(n-ilo, a[i*lda+ilo:], 1)
+		rab := math.Abs(a[i*lda+ilo+irab])
+		irab = bi.Idamax(n-ilo, b[i*ldb+ilo:], 1)
+		rab = math.Max(rab, math.Abs(b[i*ldb+ilo+irab]))
+		lrab := int(math.Log10(rab+dlamchS)/basl + 1)
+		ir := int(lscale[i] + math.Copysign(0.5, lscale[i]))
+		ir = min(min(max(ir, lsfmin), lsfmax), lsfmax-lrab)
+		lscale[i] = math.Pow(sclfac, float64(ir))
+
+		icab := bi.Idamax(ihi+1, a[i:], lda)
+		cab := math.Abs(a[icab*lda+i])
+		icab = bi.Idamax(ihi+1, b[i:], ldb)
+		cab = math.Max(cab, math.Abs(b[icab*ldb+i]))
+		lcab := int(math.Log10(cab+dlamchS)/basl + 1)
+		jc := int(rscale[i] + math.Copysign(0.5, rscale[i]))
+		jc = min(min(max(jc, lsfmin), lsfmax), lsfmax-lcab)
+		rscale[i] = math.Pow(sclfac, float64(jc))
+	}
+
+	// Scale the rows of A and B.
+	for i := ilo; i <= ihi; i++ {
+		bi.Dscal(n-ilo, lscale[i], a[i*lda+ilo:], 1)
+		bi.Dscal(n-ilo, lscale[i], b[i*ldb+ilo:], 1)
+	}
+	// Scale the columns of A and B.
+	for j := ilo; j <= ihi; j++ {
+		bi.Dscal(ihi+1, rscale[j], a[j:], lda)
+		bi.Dscal(ihi+1, rscale[j], b[j:], ldb)
+	}
+	return ilo, ihi
+}
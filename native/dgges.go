@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/lapack"
+
+// Dgges computes for a pair of n×n real nonsymmetric matrices (A,B) the
+// generalized eigenvalues, the generalized real Schur form (S,T) and,
+// optionally, the left and/or right matrices of Schur vectors (VSL and VSR).
+// This gives the generalized Schur factorization
+//  (A,B) = ( VSL*S*VSR^T, VSL*T*VSR^T ).
+// Optionally, Dgges also orders the eigenvalues so that a selected cluster of
+// eigenvalues appears in the leading diagonal blocks of the upper
+// quasi-triangular matrix S and the upper triangular matrix T. The leading
+// columns of VSL and VSR then form orthonormal bases for the corresponding
+// left and right eigenspaces (deflating subspaces).
+//
+// A generalized eigenvalue for a pair of matrices (A,B) is a scalar w or a
+// ratio alpha/beta = w, such that A - w*B is singular. It is usually
+// represented as the pair (alpha,beta), as there is a reasonable
+// interpretation for beta = 0 or both being zero.
+//
+// A pair of matrices (S,T) is in generalized real Schur form if T is upper
+// triangular with non-negative diagonal and S is block upper triangular with
+// 1×1 and 2×2 blocks. 1×1 blocks correspond to real generalized eigenvalues,
+// while 2×2 blocks of S will be "standardized" by making the corresponding
+// elements of T have the form
+//  [ a 0 ]
+//  [ 0 b ],
+// and the pair of corresponding 2×2 blocks in S and T will have a complex
+// conjugate pair of generalized eigenvalues.
+//
+// If jobvsl == lapack.ComputeSchurVectors, the left Schur vectors are
+// computed and stored in vsl, otherwise jobvsl must be lapack.None and vsl is
+// not referenced. If jobvsr == lapack.ComputeSchurVectors, the right Schur
+// vectors are computed and stored in vsr, otherwise jobvsr must be
+// lapack.None and vsr is not referenced. For other values of jobvsl or jobvsr
+// Dgges will panic.
+//
+// selctg specifies the eigenvalues to be moved to the top left of the Schur
+// form. If selctg is nil, the eigenvalues are not ordered. Otherwise, an
+// eigenvalue (alphar[j]+i*alphai[j])/beta[j] is selected if
+// selctg(alphar[j], alphai[j], beta[j]) is true. If either one of a complex
+// conjugate pair of eigenvalues is selected, then both are selected.
+//
+// On return, a and b are overwritten by S and T, respectively, and the
+// generalized eigenvalues are
+//  (alphar[j] + i*alphai[j]) / beta[j], j = 0, ..., n-1,
+// in the same order that they appear on the diagonal of (S,T). If alphai[j]
+// is zero, the j-th eigenvalue is real. Otherwise, alphai[j] > 0 and the j-th
+// and (j+1)-st eigenvalues form a complex conjugate pair. alphar[j] and
+// beta[j] are the diagonals of the complex Schur form that would result if
+// the 2×2 diagonal blocks of the real Schur form of (A,B) were further
+// reduced to triangular form using 2×2 complex unitary transformations. Note
+// that the quotients alphar[j]/beta[j] and alphai[j]/beta[j] may easily over-
+// or underflow, and beta[j] may even be zero. alphar, alphai and beta must
+// have length n.
+//
+// sdim is the number of eigenvalues, after sorting, for which selctg is true,
+// counting complex conjugate pairs for which selctg is true for either
+// eigenvalue as two. If selctg is nil, sdim is zero.
+//
+// work must have length at least lwork and lwork must be at least
+// max(8*n,6*n+16), otherwise Dgges will panic. For good performance, lwork
+// must generally be larger. If lwork == -1, instead of performing Dgges, the
+// optimal value of lwork is stored into work[0].
+//
+// bwork is temporary storage. It must have length at least n if selctg is not
+// nil, otherwise it is not referenced.
+//
+// ok will be false if the QZ iteration failed to compute all the
+// eigenvalues, if the eigenvalues could not be reordered because some of them
+// were too close to separate, or if, after reordering, roundoff changed the
+// values of some complex eigenvalues so that leading eigenvalues in the
+// generalized Schur form no longer satisfy selctg.
+func (impl Implementation) Dgges(jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vsl []float64, ldvsl int, vsr []float64, ldvsr int, work []float64, lwork int, bwork []bool) (sdim int, ok bool) {
+	var iwork [1]int
+	sdim, _, _, ok = impl.Dggesx(jobvsl, jobvsr, selctg, lapack.CondJob(lapack.None), n, a, lda, b, ldb,
+		alphar, alphai, beta, vsl, ldvsl, vsr, ldvsr, work, lwork, iwork[:], 1, bwork)
+	return sdim, ok
+}
@@ -0,0 +1,357 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dggesx computes for a pair of n×n real nonsymmetric matrices (A,B) the
+// generalized eigenvalues, the generalized real Schur form (S,T) and,
+// optionally, the left and/or right matrices of Schur vectors (VSL and VSR),
+// and optionally orders the eigenvalues on the diagonal of (S,T) so that
+// selected eigenvalues are at the top left. It has the same interface as
+// Dgges but in addition it can compute the reciprocal condition numbers of
+// the selected cluster of eigenvalues and of the deflating subspaces
+// corresponding to them.
+//
+// sense specifies which reciprocal condition numbers are computed. If sense
+// is lapack.CondEigenvalues, lapack.CondSubspace or lapack.CondBoth, the
+// condition numbers for the selected cluster of eigenvalues, for the
+// deflating subspaces, or for both, respectively, are computed and selctg
+// must not be nil. If sense is lapack.None, no condition numbers are
+// computed. For other values of sense Dggesx will panic.
+//
+// rconde contains the reciprocal condition numbers pl and pr of the average
+// of the selected eigenvalues and rcondv contains the reciprocal condition
+// numbers difu and difl of the right and left deflating subspaces, see the
+// documentation of Dtgsen for details. They are computed only if sense
+// requests them, otherwise they are zero.
+//
+// work must have length at least lwork and lwork must be at least
+// max(8*n,6*n+16) if sense == lapack.None, and at least
+// max(8*n,6*n+16)+n*n/2 otherwise. iwork must have length at least liwork
+// and liwork must be at least n+2 if sense is not lapack.None, and at least 1
+// otherwise. Dggesx will panic if these conditions are not met. If
+// lwork == -1 or liwork == -1, instead of performing Dggesx, the optimal
+// value of lwork is stored into work[0] and the minimum value of liwork is
+// stored into iwork[0].
+//
+// bwork is temporary storage. It must have length at least n if selctg is not
+// nil, otherwise it is not referenced.
+//
+// ok will be false if the QZ iteration failed to compute all the
+// eigenvalues, if the eigenvalues could not be reordered because some of them
+// were too close to separate, or if, after reordering, roundoff changed the
+// values of some complex eigenvalues so that leading eigenvalues in the
+// generalized Schur form no longer satisfy selctg.
+func (impl Implementation) Dggesx(jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, sense lapack.CondJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vsl []float64, ldvsl int, vsr []float64, ldvsr int, work []float64, lwork int, iwork []int, liwork int, bwork []bool) (sdim int, rconde, rcondv [2]float64, ok bool) {
+	var wantvsl bool
+	switch jobvsl {
+	default:
+		panic("lapack: bad SchurJob")
+	case lapack.ComputeSchurVectors:
+		wantvsl = true
+	case lapack.None:
+	}
+	var wantvsr bool
+	switch jobvsr {
+	default:
+		panic("lapack: bad SchurJob")
+	case lapack.ComputeSchurVectors:
+		wantvsr = true
+	case lapack.None:
+	}
+	wantst := selctg != nil
+	var ijob int
+	switch sense {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.None:
+	case lapack.CondEigenvalues:
+		ijob = 1
+	case lapack.CondSubspace:
+		ijob = 2
+	case lapack.CondBoth:
+		ijob = 4
+	}
+	wantsn := ijob == 0
+	switch {
+	case !wantsn && !wantst:
+		panic("lapack: condition numbers require selctg")
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+
+	// Compute the minimal and the optimal workspace.
+	var minwrk, maxwrk, liwmin int
+	if n == 0 {
+		minwrk = 1
+		maxwrk = 1
+		liwmin = 1
+	} else {
+		minwrk = max(8*n, 6*n+16)
+		maxwrk = minwrk - n + n*impl.Ilaenv(1, "DGEQRF", " ", n, 1, n, 0)
+		maxwrk = max(maxwrk, minwrk-n+n*impl.Ilaenv(1, "DORMQR", " ", n, 1, n, -1))
+		if wantvsl {
+			maxwrk = max(maxwrk, minwrk-n+n*impl.Ilaenv(1, "DORGQR", " ", n, 1, n, -1))
+		}
+		liwmin = 1
+		if !wantsn {
+			minwrk += n * n / 2
+			liwmin = n + 2
+		}
+		maxwrk = max(maxwrk, minwrk)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(maxwrk)
+		iwork[0] = liwmin
+		return 0, rconde, rcondv, true
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantvsl {
+		checkMatrix(n, n, vsl, ldvsl)
+	}
+	if wantvsr {
+		checkMatrix(n, n, vsr, ldvsr)
+	}
+	switch {
+	case len(alphar) != n:
+		panic("lapack: bad length of alphar")
+	case len(alphai) != n:
+		panic("lapack: bad length of alphai")
+	case len(beta) != n:
+		panic("lapack: bad length of beta")
+	case lwork < minwrk:
+		panic(badWork)
+	case liwork < liwmin || len(iwork) < liwork:
+		panic(badWork)
+	case wantst && len(bwork) < n:
+		panic("lapack: insufficient bwork length")
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		iwork[0] = 1
+		return 0, rconde, rcondv, true
+	}
+
+	// Get machine constants.
+	safmin := dlamchS
+	safmax := 1 / safmin
+	smlnum := math.Sqrt(safmin) / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum,bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil)
+	var scalea bool
+	var anrmto float64
+	if 0 < anrm && anrm < smlnum {
+		scalea = true
+		anrmto = smlnum
+	} else if anrm > bignum {
+		scalea = true
+		anrmto = bignum
+	}
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrm, anrmto, n, n, a, lda)
+	}
+
+	// Scale B if max element outside range [smlnum,bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, n, n, b, ldb, nil)
+	var scaleb bool
+	var bnrmto float64
+	if 0 < bnrm && bnrm < smlnum {
+		scaleb = true
+		bnrmto = smlnum
+	} else if bnrm > bignum {
+		scaleb = true
+		bnrmto = bignum
+	}
+	if scaleb {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bnrmto, n, n, b, ldb)
+	}
+
+	// Permute the matrix pair to make it more nearly triangular.
+	lscale := work[:n]
+	rscale := work[n : 2*n]
+	ilo, ihi := impl.Dggbal(lapack.Permute, n, a, lda, b, ldb, lscale, rscale, nil)
+
+	// Reduce B to triangular form (QR decomposition of B).
+	irows := ihi + 1 - ilo
+	icols := n - ilo
+	itau := 2 * n
+	iwrk := itau + irows
+	tau := work[itau:iwrk]
+	impl.Dgeqrf(irows, icols, b[ilo*ldb+ilo:], ldb, tau, work[iwrk:], lwork-iwrk)
+
+	// Apply the orthogonal transformation to matrix A.
+	impl.Dormqr(blas.Left, blas.Trans, irows, icols, irows, b[ilo*ldb+ilo:], ldb, tau,
+		a[ilo*lda+ilo:], lda, work[iwrk:], lwork-iwrk)
+
+	// Initialize VSL.
+	if wantvsl {
+		impl.Dlaset(blas.All, n, n, 0, 1, vsl, ldvsl)
+		if irows > 1 {
+			impl.Dlacpy(blas.Lower, irows-1, irows-1, b[(ilo+1)*ldb+ilo:], ldb, vsl[(ilo+1)*ldvsl+ilo:], ldvsl)
+		}
+		impl.Dorgqr(irows, irows, irows, vsl[ilo*ldvsl+ilo:], ldvsl, tau, work[iwrk:], lwork-iwrk)
+	}
+
+	// Initialize VSR.
+	if wantvsr {
+		impl.Dlaset(blas.All, n, n, 0, 1, vsr, ldvsr)
+	}
+
+	// Reduce to generalized Hessenberg form.
+	compq := lapack.EVComp(lapack.None)
+	if wantvsl {
+		compq = lapack.UpdateQZ
+	}
+	compz := lapack.EVComp(lapack.None)
+	if wantvsr {
+		compz = lapack.UpdateQZ
+	}
+	impl.Dgghrd(compq, compz, n, ilo, ihi, a, lda, b, ldb, vsl, ldvsl, vsr, ldvsr)
+
+	// Perform QZ algorithm, computing Schur vectors if desired.
+	iwrk = itau
+	ok = impl.Dhgeqz(lapack.EigenvaluesAndSchur, compq, compz, n, ilo, ihi, a, lda, b, ldb,
+		alphar, alphai, beta, vsl, ldvsl, vsr, ldvsr, work[iwrk:], lwork-iwrk) == 0
+	if !ok {
+		work[0] = float64(maxwrk)
+		iwork[0] = liwmin
+		return 0, rconde, rcondv, false
+	}
+
+	// Sort eigenvalues alpha/beta and compute the reciprocal of condition
+	// numbers.
+	if wantst {
+		// Undo scaling on eigenvalues before evaluating selctg.
+		if scalea {
+			impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphar, 1)
+			impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphai, 1)
+		}
+		if scaleb {
+			impl.Dlascl(lapack.General, 0, 0, bnrmto, bnrm, n, 1, beta, 1)
+		}
+
+		// Select eigenvalues.
+		for i := 0; i < n; i++ {
+			bwork[i] = selctg(alphar[i], alphai[i], beta[i])
+		}
+
+		// Reorder eigenvalues, transform generalized Schur vectors, and
+		// compute reciprocal condition numbers.
+		var pl, pr, difu, difl float64
+		sdim, pl, pr, difu, difl, ok = impl.Dtgsen(ijob, wantvsl, wantvsr, bwork[:n], n, a, lda, b, ldb,
+			alphar, alphai, beta, vsl, ldvsl, vsr, ldvsr, work[iwrk:], lwork-iwrk, iwork, liwork)
+		if !wantsn {
+			maxwrk = max(maxwrk, 2*sdim*(n-sdim))
+		}
+		if ijob == 1 || ijob == 4 {
+			rconde = [2]float64{pl, pr}
+		}
+		if ijob == 2 || ijob == 4 {
+			rcondv = [2]float64{difu, difl}
+		}
+	}
+
+	// Apply permutation to VSL and VSR.
+	if wantvsl {
+		impl.Dggbak(lapack.Permute, lapack.LeftEV, n, ilo, ihi, lscale, rscale, n, vsl, ldvsl)
+	}
+	if wantvsr {
+		impl.Dggbak(lapack.Permute, lapack.RightEV, n, ilo, ihi, lscale, rscale, n, vsr, ldvsr)
+	}
+
+	// Check if unscaling would cause over/underflow, if so, rescale
+	// (alphar[i],alphai[i],beta[i]) so that beta[i] is on the order of
+	// B[i,i] and alphar[i] and alphai[i] are on the order of A[i,i].
+	rescale := func(c []float64, ldc int, cnrm, cnrmto float64) {
+		for i := 0; i < n; i++ {
+			if alphai[i] == 0 {
+				continue
+			}
+			var f float64
+			switch {
+			case alphar[i]/safmax > cnrmto/cnrm || safmin/alphar[i] > cnrm/cnrmto:
+				f = math.Abs(c[i*ldc+i] / alphar[i])
+			case i+1 < n && (alphai[i]/safmax > cnrmto/cnrm || safmin/alphai[i] > cnrm/cnrmto):
+				f = math.Abs(c[i*ldc+i+1] / alphai[i])
+			default:
+				continue
+			}
+			beta[i] *= f
+			alphar[i] *= f
+			alphai[i] *= f
+		}
+	}
+	if scalea {
+		rescale(a, lda, anrm, anrmto)
+	}
+	if scaleb {
+		rescale(b, ldb, bnrm, bnrmto)
+	}
+
+	// Undo scaling.
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, n, a, lda)
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphar, 1)
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphai, 1)
+	}
+	if scaleb {
+		impl.Dlascl(lapack.UpperTri, 0, 0, bnrmto, bnrm, n, n, b, ldb)
+		impl.Dlascl(lapack.General, 0, 0, bnrmto, bnrm, n, 1, beta, 1)
+	}
+
+	if ok && wantst {
+		// Check if reordering was successful, that is, whether the
+		// leading sdim eigenvalues still satisfy selctg.
+		lastsl := true
+		lst2sl := true
+		sdim = 0
+		ip := 0
+		for i := 0; i < n; i++ {
+			cursl := selctg(alphar[i], alphai[i], beta[i])
+			if alphai[i] == 0 {
+				if cursl {
+					sdim++
+				}
+				ip = 0
+				if cursl && !lastsl {
+					ok = false
+				}
+			} else if ip == 1 {
+				// Last eigenvalue of conjugate pair.
+				cursl = cursl || lastsl
+				lastsl = cursl
+				if cursl {
+					sdim += 2
+				}
+				ip = -1
+				if cursl && !lst2sl {
+					ok = false
+				}
+			} else {
+				// First eigenvalue of conjugate pair.
+				ip = 1
+			}
+			lst2sl = lastsl
+			lastsl = cursl
+		}
+	}
+
+	work[0] = float64(maxwrk)
+	iwork[0] = liwmin
+	return sdim, rconde, rcondv, ok
+}
@@ -0,0 +1,272 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dggev computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B). It has the same interface and computes the same results as Dggev3 but
+// uses the unblocked reduction Dgghrd instead of Dgghd3 to reduce (A,B) to
+// generalized upper Hessenberg form.
+func (impl Implementation) Dggev(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	return impl.dggev(false, jobvl, jobvr, n, a, lda, b, ldb, alphar, alphai, beta, vl, ldvl, vr, ldvr, work, lwork)
+}
+
+// dggev implements Dggev and Dggev3. If blocked is true, (A,B) is reduced to
+// generalized upper Hessenberg form using Dgghd3, otherwise Dgghrd is used.
+func (impl Implementation) dggev(blocked bool, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	var wantvl bool
+	switch jobvl {
+	default:
+		panic("lapack: invalid LeftEVJob")
+	case lapack.ComputeLeftEV:
+		wantvl = true
+	case lapack.None:
+	}
+	var wantvr bool
+	switch jobvr {
+	default:
+		panic("lapack: invalid RightEVJob")
+	case lapack.ComputeRightEV:
+		wantvr = true
+	case lapack.None:
+	}
+	wantv := wantvl || wantvr
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	minwrk := max(1, 8*n)
+	if lwork != -1 {
+		checkMatrix(n, n, a, lda)
+		checkMatrix(n, n, b, ldb)
+		if wantvl {
+			checkMatrix(n, n, vl, ldvl)
+		}
+		if wantvr {
+			checkMatrix(n, n, vr, ldvr)
+		}
+		switch {
+		case len(alphar) != n:
+			panic("lapack: bad length of alphar")
+		case len(alphai) != n:
+			panic("lapack: bad length of alphai")
+		case len(beta) != n:
+			panic("lapack: bad length of beta")
+		case lwork < minwrk:
+			panic(badWork)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// Compute the optimal workspace.
+	maxwrk := minwrk
+	impl.Dgeqrf(n, n, nil, n, nil, work, -1)
+	maxwrk = max(maxwrk, 3*n+int(work[0]))
+	impl.Dormqr(blas.Left, blas.Trans, n, n, n, nil, n, nil, nil, n, work, -1)
+	maxwrk = max(maxwrk, 3*n+int(work[0]))
+	if wantvl {
+		impl.Dorgqr(n, n, n, nil, n, nil, work, -1)
+		maxwrk = max(maxwrk, 3*n+int(work[0]))
+	}
+	if blocked {
+		impl.Dgghd3(lapack.None, lapack.None, n, 0, n-1, nil, n, nil, n, nil, n, nil, n, work, -1)
+		maxwrk = max(maxwrk, 3*n+int(work[0]))
+	}
+	impl.Dhgeqz(lapack.EigenvaluesAndSchur, lapack.None, lapack.None, n, 0, n-1,
+		nil, n, nil, n, nil, nil, nil, nil, n, nil, n, work, -1)
+	maxwrk = max(maxwrk, 2*n+int(work[0]))
+
+	if lwork == -1 {
+		work[0] = float64(maxwrk)
+		return true
+	}
+
+	// Get machine constants.
+	smlnum := math.Sqrt(dlamchS) / dlamchP
+	bignum := 1 / smlnum
+
+	// Scale A if max element outside range [smlnum,bignum].
+	anrm := impl.Dlange(lapack.MaxAbs, n, n, a, lda, nil)
+	var scalea bool
+	var anrmto float64
+	if 0 < anrm && anrm < smlnum {
+		scalea = true
+		anrmto = smlnum
+	} else if anrm > bignum {
+		scalea = true
+		anrmto = bignum
+	}
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrm, anrmto, n, n, a, lda)
+	}
+
+	// Scale B if max element outside range [smlnum,bignum].
+	bnrm := impl.Dlange(lapack.MaxAbs, n, n, b, ldb, nil)
+	var scaleb bool
+	var bnrmto float64
+	if 0 < bnrm && bnrm < smlnum {
+		scaleb = true
+		bnrmto = smlnum
+	} else if bnrm > bignum {
+		scaleb = true
+		bnrmto = bignum
+	}
+	if scaleb {
+		impl.Dlascl(lapack.General, 0, 0, bnrm, bnrmto, n, n, b, ldb)
+	}
+
+	// Permute the matrices A and B to isolate eigenvalues if possible.
+	lscale := work[:n]
+	rscale := work[n : 2*n]
+	ilo, ihi := impl.Dggbal(lapack.Permute, n, a, lda, b, ldb, lscale, rscale, nil)
+
+	// Reduce B to triangular form (QR decomposition of B).
+	irows := ihi + 1 - ilo
+	icols := irows
+	if wantv {
+		icols = n - ilo
+	}
+	itau := 2 * n
+	iwrk := itau + irows
+	tau := work[itau:iwrk]
+	impl.Dgeqrf(irows, icols, b[ilo*ldb+ilo:], ldb, tau, work[iwrk:], lwork-iwrk)
+
+	// Apply the orthogonal transformation to matrix A.
+	impl.Dormqr(blas.Left, blas.Trans, irows, icols, irows, b[ilo*ldb+ilo:], ldb, tau,
+		a[ilo*lda+ilo:], lda, work[iwrk:], lwork-iwrk)
+
+	// Initialize VL.
+	if wantvl {
+		impl.Dlaset(blas.All, n, n, 0, 1, vl, ldvl)
+		if irows > 1 {
+			impl.Dlacpy(blas.Lower, irows-1, irows-1, b[(ilo+1)*ldb+ilo:], ldb, vl[(ilo+1)*ldvl+ilo:], ldvl)
+		}
+		impl.Dorgqr(irows, irows, irows, vl[ilo*ldvl+ilo:], ldvl, tau, work[iwrk:], lwork-iwrk)
+	}
+
+	// Initialize VR.
+	if wantvr {
+		impl.Dlaset(blas.All, n, n, 0, 1, vr, ldvr)
+	}
+
+	// Reduce to generalized Hessenberg form.
+	compq := lapack.EVComp(lapack.None)
+	if wantvl {
+		compq = lapack.UpdateQZ
+	}
+	compz := lapack.EVComp(lapack.None)
+	if wantvr {
+		compz = lapack.UpdateQZ
+	}
+	if wantv {
+		// Eigenvectors requested, work on the whole matrices.
+		if blocked {
+			impl.Dgghd3(compq, compz, n, ilo, ihi, a, lda, b, ldb, vl, ldvl, vr, ldvr, work[iwrk:], lwork-iwrk)
+		} else {
+			impl.Dgghrd(compq, compz, n, ilo, ihi, a, lda, b, ldb, vl, ldvl, vr, ldvr)
+		}
+	} else {
+		// Eigenvalues only, work only on the submatrices in rows and
+		// columns [ilo:ihi+1].
+		if blocked {
+			impl.Dgghd3(lapack.None, lapack.None, irows, 0, irows-1, a[ilo*lda+ilo:], lda,
+				b[ilo*ldb+ilo:], ldb, nil, 1, nil, 1, work[iwrk:], lwork-iwrk)
+		} else {
+			impl.Dgghrd(lapack.None, lapack.None, irows, 0, irows-1, a[ilo*lda+ilo:], lda,
+				b[ilo*ldb+ilo:], ldb, nil, 1, nil, 1)
+		}
+	}
+
+	// Perform QZ algorithm (compute eigenvalues, and optionally, the Schur
+	// forms and Schur vectors).
+	iwrk = itau
+	job := lapack.EigenvaluesOnly
+	if wantv {
+		job = lapack.EigenvaluesAndSchur
+	}
+	ok = impl.Dhgeqz(job, compq, compz, n, ilo, ihi, a, lda, b, ldb, alphar, alphai, beta,
+		vl, ldvl, vr, ldvr, work[iwrk:], lwork-iwrk) == 0
+
+	if ok && wantv {
+		// Compute eigenvectors.
+		var side lapack.EVSide
+		switch {
+		case wantvl && wantvr:
+			side = lapack.RightLeftEV
+		case wantvl:
+			side = lapack.LeftEV
+		default:
+			side = lapack.RightEV
+		}
+		_, ok = impl.Dtgevc(side, lapack.AllEVMulQ, nil, n, a, lda, b, ldb,
+			vl, ldvl, vr, ldvr, n, work[iwrk:])
+	}
+
+	if ok && wantv {
+		// Undo balancing and normalize the eigenvectors so that the
+		// largest component of each has |real part| + |imag. part| = 1.
+		bi := blas64.Implementation()
+		normalize := func(v []float64, ldv int) {
+			for j := 0; j < n; j++ {
+				if alphai[j] < 0 {
+					continue
+				}
+				var temp float64
+				if alphai[j] == 0 {
+					for i := 0; i < n; i++ {
+						temp = math.Max(temp, math.Abs(v[i*ldv+j]))
+					}
+				} else {
+					for i := 0; i < n; i++ {
+						temp = math.Max(temp, math.Abs(v[i*ldv+j])+math.Abs(v[i*ldv+j+1]))
+					}
+				}
+				if temp < smlnum {
+					continue
+				}
+				temp = 1 / temp
+				bi.Dscal(n, temp, v[j:], ldv)
+				if alphai[j] > 0 {
+					bi.Dscal(n, temp, v[j+1:], ldv)
+				}
+			}
+		}
+		if wantvl {
+			impl.Dggbak(lapack.Permute, lapack.LeftEV, n, ilo, ihi, lscale, rscale, n, vl, ldvl)
+			normalize(vl, ldvl)
+		}
+		if wantvr {
+			impl.Dggbak(lapack.Permute, lapack.RightEV, n, ilo, ihi, lscale, rscale, n, vr, ldvr)
+			normalize(vr, ldvr)
+		}
+	}
+
+	// Undo scaling if necessary.
+	if scalea {
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphar, 1)
+		impl.Dlascl(lapack.General, 0, 0, anrmto, anrm, n, 1, alphai, 1)
+	}
+	if scaleb {
+		impl.Dlascl(lapack.General, 0, 0, bnrmto, bnrm, n, 1, beta, 1)
+	}
+
+	work[0] = float64(maxwrk)
+	return ok
+}
@@ -0,0 +1,68 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/lapack"
+
+// Dggev3 computes the generalized eigenvalues and, optionally, the left and/or
+// right generalized eigenvectors for a pair of n×n real nonsymmetric matrices
+// (A,B).
+//
+// A generalized eigenvalue for the pair (A,B) is a scalar λ or a ratio
+// α/β = λ, such that A - λ*B is singular. It is usually represented as the
+// pair (α,β), as there is a reasonable interpretation for β = 0, and even for
+// both being zero.
+//
+// The right generalized eigenvector v_j corresponding to the generalized
+// eigenvalue λ_j of (A,B) satisfies
+//  A v_j = λ_j B v_j,
+// and the left generalized eigenvector u_j corresponding to λ_j satisfies
+//  u_j^H A = λ_j u_j^H B,
+// where u_j^H is the conjugate transpose of u_j.
+//
+// On return, A and B will be overwritten and the left and right eigenvectors
+// will be stored, respectively, in the columns of the n×n matrices VL and VR
+// in the same order as their eigenvalues. If the j-th eigenvalue is real, then
+//  u_j = VL[:,j],
+//  v_j = VR[:,j],
+// and if it is not real, then j and j+1 form a complex conjugate pair and the
+// eigenvectors can be recovered as
+//  u_j     = VL[:,j] + i*VL[:,j+1],
+//  u_{j+1} = VL[:,j] - i*VL[:,j+1],
+//  v_j     = VR[:,j] + i*VR[:,j+1],
+//  v_{j+1} = VR[:,j] - i*VR[:,j+1],
+// where i is the imaginary unit. Each eigenvector is scaled so that the
+// largest component has |real part| + |imag. part| = 1.
+//
+// Left eigenvectors will be computed only if jobvl == lapack.ComputeLeftEV,
+// otherwise jobvl must be lapack.None. Right eigenvectors will be computed
+// only if jobvr == lapack.ComputeRightEV, otherwise jobvr must be lapack.None.
+// For other values of jobvl and jobvr Dggev3 will panic.
+//
+// On return, alphar[j] + i*alphai[j] and beta[j] for j = 0,...,n-1 will be the
+// generalized eigenvalues. If alphai[j] is zero, then the j-th eigenvalue is
+// real; if positive, then the j-th and (j+1)-st eigenvalues are a complex
+// conjugate pair, with alphai[j+1] negative. alphar, alphai and beta must have
+// length n, otherwise Dggev3 will panic.
+//
+// Note: the quotients alphar[j]/beta[j] and alphai[j]/beta[j] may easily over-
+// or underflow, and beta[j] may even be zero. Thus, the user should avoid
+// naively computing the ratio α/β. However, alphar and alphai will be always
+// less than and usually comparable with norm(A) in magnitude, and beta always
+// less than and usually comparable with norm(B).
+//
+// work must have length at least lwork and lwork must be at least max(1,8*n),
+// otherwise Dggev3 will panic. For good performance, lwork must generally be
+// larger. On return, optimal value of lwork will be stored in work[0].
+//
+// If lwork == -1, instead of performing Dggev3, the function only calculates
+// the optimal value of lwork and stores it into work[0].
+//
+// On return, ok will be false if the QZ iteration failed to compute all the
+// eigenvalues, in which case no eigenvectors have been computed and only some
+// of the eigenvalues are correct, or if the computation of eigenvectors failed.
+func (impl Implementation) Dggev3(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool) {
+	return impl.dggev(true, jobvl, jobvr, n, a, lda, b, ldb, alphar, alphai, beta, vl, ldvl, vr, ldvr, work, lwork)
+}
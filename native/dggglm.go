@@ -0,0 +1,143 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dggglm solves a general Gauss-Markov linear model (GLM) problem
+//  minimize |y|_2 subject to d = A*x + B*y,
+// where A is an n×m matrix, B is an n×p matrix, and d is a given vector of
+// length n. It must hold that
+//  m <= n <= m+p,
+// and
+//  rank(A) = m and rank([A B]) = n,
+// which ensure that the problem has a unique solution. The problem is solved
+// using a generalized QR factorization of the matrices A and B.
+//
+// In particular, if B is square and nonsingular, the GLM problem is
+// equivalent to the weighted linear least squares problem
+//  minimize |inv(B)*(d-A*x)|_2.
+//
+// On return, a and b contain their generalized QR factorization, x of length
+// m and y of length p contain the solution. d is overwritten on return.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, n+m+p), otherwise Dggglm will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggglm, the optimal
+// work length will be stored into work[0].
+//
+// Dggglm returns whether the solution was computed successfully. If the upper
+// triangular factor T22 of B in the generalized QR factorization is singular,
+// which means that rank([A B]) < n, or if the upper triangular factor R11 of
+// A is singular, which means that rank(A) < m, Dggglm returns false.
+func (impl Implementation) Dggglm(n, m, p int, a []float64, lda int, b []float64, ldb int, d, x, y, work []float64, lwork int) (ok bool) {
+	np := min(n, p)
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case m < 0 || n < m:
+		panic("lapack: bad value of m")
+	case p < 0 || m+p < n:
+		panic("lapack: bad value of p")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, n+m+p) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, m, a, lda)
+	checkMatrix(n, p, b, ldb)
+	if len(d) < n {
+		panic("lapack: d has insufficient length")
+	}
+	if len(x) < m {
+		panic("lapack: x has insufficient length")
+	}
+	if len(y) < p {
+		panic("lapack: y has insufficient length")
+	}
+
+	// Calculate the workspace requirements.
+	lwkopt := 1
+	if n > 0 {
+		nb1 := impl.Ilaenv(1, "DGEQRF", " ", n, m, -1, -1)
+		nb2 := impl.Ilaenv(1, "DGERQF", " ", n, m, -1, -1)
+		nb3 := impl.Ilaenv(1, "DORMQR", " ", n, m, p, -1)
+		nb4 := impl.Ilaenv(1, "DORMRQ", " ", n, m, p, -1)
+		nb := max(max(nb1, nb2), max(nb3, nb4))
+		lwkopt = m + np + max(n, p)*nb
+	}
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		for i := range x[:m] {
+			x[i] = 0
+		}
+		for i := range y[:p] {
+			y[i] = 0
+		}
+		return true
+	}
+
+	// Compute the generalized QR factorization of matrices A and B:
+	//  Q^T * A = [ R11 ] m,    Q^T * B * Z^T = [ T11 T12 ] m
+	//            [  0  ] n-m                   [  0  T22 ] n-m
+	//              m                          m+p-n  n-m
+	// where R11 and T22 are upper triangular, and Q and Z are orthogonal.
+	// The scalar factors of Q are stored in work[:m] and those of Z in
+	// work[m:m+np].
+	impl.Dggqrf(n, m, p, a, lda, work[:m], b, ldb, work[m:m+np], work[m+np:], lwork-m-np)
+	lopt := int(work[m+np])
+
+	// Update left-hand-side vector d = Q^T * d = [ d1 ] m
+	//                                            [ d2 ] n-m
+	impl.Dormqr(blas.Left, blas.Trans, n, 1, m, a, lda, work[:m], d, 1, work[m+np:], lwork-m-np)
+	lopt = max(lopt, int(work[m+np]))
+
+	bi := blas64.Implementation()
+	if n > m {
+		// Solve T22 * y2 = d2 for y2.
+		ok = impl.Dtrtrs(blas.Upper, blas.NoTrans, blas.NonUnit, n-m, 1, b[m*ldb+m+p-n:], ldb, d[m:], 1)
+		if !ok {
+			return false
+		}
+		bi.Dcopy(n-m, d[m:], 1, y[m+p-n:], 1)
+	}
+
+	// Set y1 = 0.
+	for i := range y[:m+p-n] {
+		y[i] = 0
+	}
+
+	if m > 0 && n > m {
+		// Update d1 = d1 - T12 * y2.
+		bi.Dgemv(blas.NoTrans, m, n-m, -1, b[m+p-n:], ldb, y[m+p-n:], 1, 1, d, 1)
+	}
+
+	if m > 0 {
+		// Solve triangular system R11 * x = d1.
+		ok = impl.Dtrtrs(blas.Upper, blas.NoTrans, blas.NonUnit, m, 1, a, lda, d, 1)
+		if !ok {
+			return false
+		}
+
+		// Copy d to x.
+		bi.Dcopy(m, d, 1, x, 1)
+	}
+
+	if p > 0 {
+		// Backward transformation y = Z^T * y.
+		impl.Dormrq(blas.Left, blas.Trans, p, 1, np, b[max(0, n-p)*ldb:], ldb, work[m:m+np], y, 1, work[m+np:], lwork-m-np)
+		lopt = max(lopt, int(work[m+np]))
+	}
+	work[0] = float64(m + np + lopt)
+	return true
+}
@@ -0,0 +1,496 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgghd3 reduces a pair of real matrices (A,B) to generalized upper Hessenberg
+// form using orthogonal transformations, where A is a general matrix and B is
+// upper triangular.
+//
+// Dgghd3 simultaneously reduces A to a Hessenberg matrix H
+//  Q^T * A * Z = H,
+// and transforms B to another upper triangular matrix T
+//  Q^T * B * Z = T.
+//
+// The orthogonal matrices Q and Z are determined as products of Givens
+// rotations. They may either be formed explicitly, or they may be
+// postmultiplied into input matrices Q1 and Z1, so that
+//  Q1 * A * Z1^T = (Q1*Q) * H * (Z1*Z)^T,
+//  Q1 * B * Z1^T = (Q1*Q) * T * (Z1*Z)^T.
+// If Q1 is the orthogonal matrix from the QR factorization of B in the
+// original equation A*x = λ*B*x, then Dgghd3 reduces the original problem to
+// generalized Hessenberg form.
+//
+// If compq == lapack.None, Q is not computed and q is not referenced.
+// If compq == lapack.ExplicitQZ, q is initialized to the identity and on return
+// it will contain the orthogonal matrix Q.
+// If compq == lapack.UpdateQZ, q must contain an orthogonal matrix Q1 on entry
+// and on return it will contain the product Q1*Q.
+// The same applies to compz and Z. For other values of compq and compz Dgghd3
+// will panic.
+//
+// ilo and ihi determine the block of A that will be reduced. It is assumed
+// that A is already upper triangular in rows and columns [0:ilo] and
+// [ihi+1:n]. ilo and ihi are normally set by a previous call to Dggbal,
+// otherwise they should be set to 0 and n-1, respectively. It must hold that
+//  0 <= ilo <= ihi < n,     if n > 0,
+//  ilo == 0 and ihi == -1,  if n == 0,
+// otherwise Dgghd3 will panic.
+//
+// work must have length at least lwork and lwork must be at least 1, otherwise
+// Dgghd3 will panic. For optimum performance lwork should be at least 6*n*nb
+// where nb is the optimal block size. On return, work[0] will contain the
+// optimal value of lwork.
+//
+// If lwork == -1, instead of performing Dgghd3, the function only calculates
+// the optimal value of lwork and stores it into work[0].
+//
+// Dgghd3 is a blocked version of Dgghrd. It uses level 3 BLAS to apply the
+// accumulated Givens rotations, see
+//  B. Kågström, D. Kressner, E.S. Quintana-Orti, G. Quintana-Orti. Blocked
+//  algorithms for the reduction to Hessenberg-triangular form revisited.
+//  BIT Numerical Mathematics 48(3) (2008), pp. 563-584.
+//
+// Dgghd3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgghd3(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int) {
+	var initq, wantq bool
+	switch compq {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.ExplicitQZ:
+		initq = true
+		wantq = true
+	case lapack.UpdateQZ:
+		wantq = true
+	}
+	var initz, wantz bool
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+	case lapack.ExplicitQZ:
+		initz = true
+		wantz = true
+	case lapack.UpdateQZ:
+		wantz = true
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	case len(work) < lwork:
+		panic(shortWork)
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+
+	nb := impl.Ilaenv(1, "DGGHD3", " ", n, ilo, ihi, -1)
+	lwkopt := max(6*n*nb, 1)
+	if lwork == -1 {
+		work[0] = float64(lwkopt)
+		return
+	}
+
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	// Initialize Q and Z if desired.
+	if initq {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+	if initz {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	// Zero out the lower triangle of B.
+	if n > 1 {
+		impl.Dlaset(blas.Lower, n-1, n-1, 0, 0, b[ldb:], ldb)
+	}
+
+	// Quick return if possible.
+	nh := ihi - ilo + 1
+	if nh <= 1 {
+		work[0] = 1
+		return
+	}
+
+	// Determine the block size.
+	nbmin := impl.Ilaenv(2, "DGGHD3", " ", n, ilo, ihi, -1)
+	nx := nh
+	if nb > 1 && nb < nh {
+		// Determine when to use unblocked instead of blocked code.
+		nx = max(nb, impl.Ilaenv(3, "DGGHD3", " ", n, ilo, ihi, -1))
+		if nx < nh {
+			// Determine if workspace is large enough for blocked code.
+			if lwork < lwkopt {
+				// Not enough workspace to use optimal nb. Determine
+				// the minimum value of nb, and reduce nb or force
+				// use of unblocked code.
+				nbmin = max(2, nbmin)
+				if lwork >= 6*n*nbmin {
+					nb = lwork / (6 * n)
+				} else {
+					nb = 1
+				}
+			}
+		}
+	}
+
+	jcol := ilo
+	if nb >= nbmin && nb < nh && nx < nh {
+		// Use blocked code.
+		//
+		// The accumulated orthogonal factors U are stored in work in
+		// column-major order, or equivalently, U^T is stored in row-major
+		// order. The factors are therefore applied using transposed
+		// operations below.
+		bi := blas64.Implementation()
+		var top, pw, nnb, nblst int
+		for ; jcol <= ihi-2; jcol += nb {
+			nnb = min(nb, ihi-jcol-1)
+
+			// Initialize small orthogonal factors that will hold the
+			// accumulated Givens rotations in workspace.
+			// n2nb denotes the number of 2*nnb×2*nnb factors and
+			// nblst denotes the (possibly smaller) order of the last
+			// factor.
+			n2nb := (ihi-jcol-1)/nnb - 1
+			nblst = ihi - jcol - n2nb*nnb
+			impl.Dlaset(blas.All, nblst, nblst, 0, 1, work, nblst)
+			pw = nblst * nblst
+			for i := 0; i < n2nb; i++ {
+				impl.Dlaset(blas.All, 2*nnb, 2*nnb, 0, 1, work[pw:], 2*nnb)
+				pw += 4 * nnb * nnb
+			}
+
+			// Reduce columns jcol:jcol+nnb of A to Hessenberg form.
+			for j := jcol; j < jcol+nnb; j++ {
+				// Reduce j-th column of A. Store cosines and sines
+				// in j-th column of A and B, respectively.
+				for i := ihi; i >= j+2; i-- {
+					var c, s float64
+					c, s, a[(i-1)*lda+j] = impl.Dlartg(a[(i-1)*lda+j], a[i*lda+j])
+					a[i*lda+j] = c
+					b[i*ldb+j] = s
+				}
+
+				// Accumulate Givens rotations into workspace array.
+				ppw := (nblst+1)*(nblst-2) - j + jcol
+				length := 2 + j - jcol
+				jrow := j + n2nb*nnb + 2
+				for i := ihi; i >= jrow; i-- {
+					c := a[i*lda+j]
+					s := b[i*ldb+j]
+					for jj := ppw; jj < ppw+length; jj++ {
+						temp := work[jj+nblst]
+						work[jj+nblst] = c*temp - s*work[jj]
+						work[jj] = s*temp + c*work[jj]
+					}
+					length++
+					ppw -= nblst + 1
+				}
+
+				ppwo := nblst*nblst + (nnb+j-jcol-1)*2*nnb + nnb - 1
+				for jrow = jrow - nnb; jrow >= j+2; jrow -= nnb {
+					ppw = ppwo
+					length = 2 + j - jcol
+					for i := jrow + nnb - 1; i >= jrow; i-- {
+						c := a[i*lda+j]
+						s := b[i*ldb+j]
+						for jj := ppw; jj < ppw+length; jj++ {
+							temp := work[jj+2*nnb]
+							work[jj+2*nnb] = c*temp - s*work[jj]
+							work[jj] = s*temp + c*work[jj]
+						}
+						length++
+						ppw -= 2*nnb + 1
+					}
+					ppwo += 4 * nnb * nnb
+				}
+
+				// top denotes the number of top rows in A and B that
+				// will not be updated during the next steps.
+				if jcol <= 1 {
+					top = 0
+				} else {
+					top = jcol + 1
+				}
+
+				// Propagate transformations through B and replace
+				// stored left sines/cosines by right sines/cosines.
+				for jj := n - 1; jj >= j+1; jj-- {
+					// Update jj-th column of B.
+					for i := min(jj+1, ihi); i >= j+2; i-- {
+						c := a[i*lda+j]
+						s := b[i*ldb+j]
+						temp := b[i*ldb+jj]
+						b[i*ldb+jj] = c*temp - s*b[(i-1)*ldb+jj]
+						b[(i-1)*ldb+jj] = s*temp + c*b[(i-1)*ldb+jj]
+					}
+
+					// Annihilate B[jj+1,jj].
+					if jj < ihi {
+						var c, s float64
+						c, s, b[(jj+1)*ldb+jj+1] = impl.Dlartg(b[(jj+1)*ldb+jj+1], b[(jj+1)*ldb+jj])
+						b[(jj+1)*ldb+jj] = 0
+						bi.Drot(jj+1-top, b[top*ldb+jj+1:], ldb, b[top*ldb+jj:], ldb, c, s)
+						a[(jj+1)*lda+j] = c
+						b[(jj+1)*ldb+j] = -s
+					}
+				}
+
+				// Update A by transformations from right.
+				jj := (ihi - j - 1) % 3
+				for i := ihi - j - 3; i >= jj+1; i -= 3 {
+					c := a[(j+1+i)*lda+j]
+					s := -b[(j+1+i)*ldb+j]
+					c1 := a[(j+2+i)*lda+j]
+					s1 := -b[(j+2+i)*ldb+j]
+					c2 := a[(j+3+i)*lda+j]
+					s2 := -b[(j+3+i)*ldb+j]
+					for k := top; k <= ihi; k++ {
+						temp := a[k*lda+j+i]
+						temp1 := a[k*lda+j+i+1]
+						temp2 := a[k*lda+j+i+2]
+						temp3 := a[k*lda+j+i+3]
+						a[k*lda+j+i+3] = c2*temp3 + s2*temp2
+						temp2 = -s2*temp3 + c2*temp2
+						a[k*lda+j+i+2] = c1*temp2 + s1*temp1
+						temp1 = -s1*temp2 + c1*temp1
+						a[k*lda+j+i+1] = c*temp1 + s*temp
+						a[k*lda+j+i] = -s*temp1 + c*temp
+					}
+				}
+				for i := jj; i > 0; i-- {
+					bi.Drot(ihi-top+1, a[top*lda+j+i+1:], lda, a[top*lda+j+i:], lda,
+						a[(j+1+i)*lda+j], -b[(j+1+i)*ldb+j])
+				}
+
+				// Update (j+1)-th column of A by transformations from
+				// left.
+				if j < jcol+nnb-1 {
+					length = 1 + j - jcol
+
+					// Multiply with the trailing accumulated
+					// orthogonal matrix, which takes the form
+					//      [ U11 U12 ]
+					//  U = [         ],
+					//      [ U21 U22 ]
+					// where U21 is a length×length matrix and U12
+					// is lower triangular.
+					jrow = ihi - nblst + 1
+					bi.Dgemv(blas.NoTrans, length, nblst, 1, work, nblst,
+						a[jrow*lda+j+1:], lda, 0, work[pw:], 1)
+					ppw = pw + length
+					for i := jrow; i < jrow+nblst-length; i++ {
+						work[ppw] = a[i*lda+j+1]
+						ppw++
+					}
+					bi.Dtrmv(blas.Upper, blas.NoTrans, blas.NonUnit, nblst-length,
+						work[length*nblst:], nblst, work[pw+length:], 1)
+					bi.Dgemv(blas.NoTrans, nblst-length, length, 1, work[(length+1)*nblst-length:], nblst,
+						a[(jrow+nblst-length)*lda+j+1:], lda, 1, work[pw+length:], 1)
+					ppw = pw
+					for i := jrow; i < jrow+nblst; i++ {
+						a[i*lda+j+1] = work[ppw]
+						ppw++
+					}
+
+					// Multiply with the other accumulated
+					// orthogonal matrices, which take the form
+					//      [ U11 U12 0 ]
+					//      [           ]
+					//  U = [ U21 U22 0 ],
+					//      [           ]
+					//      [  0   0  I ]
+					// where I denotes the (nnb-length)×(nnb-length)
+					// identity matrix, U21 is a length×length upper
+					// triangular matrix and U12 is an nnb×nnb lower
+					// triangular matrix.
+					ppwo := nblst * nblst
+					for jrow = jrow - nnb; jrow >= jcol+1; jrow -= nnb {
+						ppw = pw + length
+						for i := jrow; i < jrow+nnb; i++ {
+							work[ppw] = a[i*lda+j+1]
+							ppw++
+						}
+						ppw = pw
+						for i := jrow + nnb; i < jrow+nnb+length; i++ {
+							work[ppw] = a[i*lda+j+1]
+							ppw++
+						}
+						bi.Dtrmv(blas.Lower, blas.NoTrans, blas.NonUnit, length,
+							work[ppwo+nnb:], 2*nnb, work[pw:], 1)
+						bi.Dtrmv(blas.Upper, blas.NoTrans, blas.NonUnit, nnb,
+							work[ppwo+2*length*nnb:], 2*nnb, work[pw+length:], 1)
+						bi.Dgemv(blas.NoTrans, length, nnb, 1, work[ppwo:], 2*nnb,
+							a[jrow*lda+j+1:], lda, 1, work[pw:], 1)
+						bi.Dgemv(blas.NoTrans, nnb, length, 1, work[ppwo+2*length*nnb+nnb:], 2*nnb,
+							a[(jrow+nnb)*lda+j+1:], lda, 1, work[pw+length:], 1)
+						ppw = pw
+						for i := jrow; i < jrow+length+nnb; i++ {
+							a[i*lda+j+1] = work[ppw]
+							ppw++
+						}
+						ppwo += 4 * nnb * nnb
+					}
+				}
+			}
+
+			// Apply accumulated orthogonal matrices to A.
+			cola := n - jcol - nnb
+			j := ihi - nblst + 1
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, nblst, cola, nblst,
+				1, work, nblst, a[j*lda+jcol+nnb:], lda,
+				0, work[pw:], cola)
+			impl.Dlacpy(blas.All, nblst, cola, work[pw:], cola, a[j*lda+jcol+nnb:], lda)
+			ppwo := nblst * nblst
+			for j -= nnb; j >= jcol+1; j -= nnb {
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, 2*nnb, cola, 2*nnb,
+					1, work[ppwo:], 2*nnb, a[j*lda+jcol+nnb:], lda,
+					0, work[pw:], cola)
+				impl.Dlacpy(blas.All, 2*nnb, cola, work[pw:], cola, a[j*lda+jcol+nnb:], lda)
+				ppwo += 4 * nnb * nnb
+			}
+
+			// Apply accumulated orthogonal matrices to Q.
+			if wantq {
+				impl.dgghd3ApplyU(initq, n, jcol, ihi, nnb, nblst, pw, q, ldq, work)
+			}
+
+			// Accumulate right Givens rotations if required.
+			if wantz || top > 0 {
+				// Initialize small orthogonal factors that will
+				// hold the accumulated Givens rotations in
+				// workspace.
+				impl.Dlaset(blas.All, nblst, nblst, 0, 1, work, nblst)
+				pw = nblst * nblst
+				for i := 0; i < n2nb; i++ {
+					impl.Dlaset(blas.All, 2*nnb, 2*nnb, 0, 1, work[pw:], 2*nnb)
+					pw += 4 * nnb * nnb
+				}
+
+				// Accumulate Givens rotations into workspace array.
+				for j := jcol; j < jcol+nnb; j++ {
+					ppw := (nblst+1)*(nblst-2) - j + jcol
+					length := 2 + j - jcol
+					jrow := j + n2nb*nnb + 2
+					for i := ihi; i >= jrow; i-- {
+						c := a[i*lda+j]
+						a[i*lda+j] = 0
+						s := b[i*ldb+j]
+						b[i*ldb+j] = 0
+						for jj := ppw; jj < ppw+length; jj++ {
+							temp := work[jj+nblst]
+							work[jj+nblst] = c*temp - s*work[jj]
+							work[jj] = s*temp + c*work[jj]
+						}
+						length++
+						ppw -= nblst + 1
+					}
+
+					ppwo := nblst*nblst + (nnb+j-jcol-1)*2*nnb + nnb - 1
+					for jrow = jrow - nnb; jrow >= j+2; jrow -= nnb {
+						ppw = ppwo
+						length = 2 + j - jcol
+						for i := jrow + nnb - 1; i >= jrow; i-- {
+							c := a[i*lda+j]
+							a[i*lda+j] = 0
+							s := b[i*ldb+j]
+							b[i*ldb+j] = 0
+							for jj := ppw; jj < ppw+length; jj++ {
+								temp := work[jj+2*nnb]
+								work[jj+2*nnb] = c*temp - s*work[jj]
+								work[jj] = s*temp + c*work[jj]
+							}
+							length++
+							ppw -= 2*nnb + 1
+						}
+						ppwo += 4 * nnb * nnb
+					}
+				}
+			} else {
+				impl.Dlaset(blas.Lower, ihi-jcol-1, nnb, 0, 0, a[(jcol+2)*lda+jcol:], lda)
+				impl.Dlaset(blas.Lower, ihi-jcol-1, nnb, 0, 0, b[(jcol+2)*ldb+jcol:], ldb)
+			}
+
+			// Apply accumulated orthogonal matrices to A and B.
+			if top > 0 {
+				impl.dgghd3ApplyU(false, top, jcol, ihi, nnb, nblst, pw, a, lda, work)
+				impl.dgghd3ApplyU(false, top, jcol, ihi, nnb, nblst, pw, b, ldb, work)
+			}
+
+			// Apply accumulated orthogonal matrices to Z.
+			if wantz {
+				impl.dgghd3ApplyU(initz, n, jcol, ihi, nnb, nblst, pw, z, ldz, work)
+			}
+		}
+	}
+
+	// Use unblocked code to reduce the rest of the matrix. Avoid
+	// re-initialization of modified Q and Z.
+	compq2 := compq
+	compz2 := compz
+	if jcol != ilo {
+		if wantq {
+			compq2 = lapack.UpdateQZ
+		}
+		if wantz {
+			compz2 = lapack.UpdateQZ
+		}
+	}
+	if jcol < ihi {
+		impl.Dgghrd(compq2, compz2, n, jcol, ihi, a, lda, b, ldb, q, ldq, z, ldz)
+	}
+
+	work[0] = float64(lwkopt)
+}
+
+// dgghd3ApplyU postmultiplies the rows [0:m] of the columns [jcol+1:ihi+1] of
+// the matrix C by the orthogonal factors accumulated by Dgghd3 in work. If
+// init is true, C is assumed to be the product of the transformations from
+// the previous blocks applied to the identity, and only the nonzero rows of
+// C are updated. pw is the offset in work of the free workspace.
+func (impl Implementation) dgghd3ApplyU(init bool, m, jcol, ihi, nnb, nblst, pw int, c []float64, ldc int, work []float64) {
+	bi := blas64.Implementation()
+	top := 0
+	nh := m
+	j := ihi - nblst + 1
+	if init {
+		top = max(1, j-jcol)
+		nh = ihi - top + 1
+	}
+	bi.Dgemm(blas.NoTrans, blas.Trans, nh, nblst, nblst,
+		1, c[top*ldc+j:], ldc, work, nblst,
+		0, work[pw:], nblst)
+	impl.Dlacpy(blas.All, nh, nblst, work[pw:], nblst, c[top*ldc+j:], ldc)
+	ppwo := nblst * nblst
+	for j -= nnb; j >= jcol+1; j -= nnb {
+		if init {
+			top = max(1, j-jcol)
+			nh = ihi - top + 1
+		}
+		bi.Dgemm(blas.NoTrans, blas.Trans, nh, 2*nnb, 2*nnb,
+			1, c[top*ldc+j:], ldc, work[ppwo:], 2*nnb,
+			0, work[pw:], 2*nnb)
+		impl.Dlacpy(blas.All, nh, 2*nnb, work[pw:], 2*nnb, c[top*ldc+j:], ldc)
+		ppwo += 4 * nnb * nnb
+	}
+}
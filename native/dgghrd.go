@@ -0,0 +1,124 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgghrd reduces a pair of real matrices (A,B) to generalized upper Hessenberg
+// form using orthogonal transformations, where A is a general matrix and B is
+// upper triangular. It is an unblocked version of Dgghd3.
+//
+// Dgghrd simultaneously reduces A to a Hessenberg matrix H
+//  Q^T * A * Z = H,
+// and transforms B to another upper triangular matrix T
+//  Q^T * B * Z = T.
+//
+// The orthogonal matrices Q and Z are determined as products of Givens
+// rotations. They may either be formed explicitly, or they may be
+// postmultiplied into input matrices Q1 and Z1, so that
+//  Q1 * A * Z1^T = (Q1*Q) * H * (Z1*Z)^T,
+//  Q1 * B * Z1^T = (Q1*Q) * T * (Z1*Z)^T.
+// If Q1 is the orthogonal matrix from the QR factorization of B in the
+// original equation A*x = λ*B*x, then Dgghrd reduces the original problem to
+// generalized Hessenberg form.
+//
+// If compq == lapack.None, Q is not computed and q is not referenced.
+// If compq == lapack.ExplicitQZ, q is initialized to the identity and on return
+// it will contain the orthogonal matrix Q.
+// If compq == lapack.UpdateQZ, q must contain an orthogonal matrix Q1 on entry
+// and on return it will contain the product Q1*Q.
+// The same applies to compz and Z. For other values of compq and compz Dgghrd
+// will panic.
+//
+// ilo and ihi determine the block of A that will be reduced. It is assumed
+// that A is already upper triangular in rows and columns [0:ilo] and
+// [ihi+1:n]. ilo and ihi are normally set by a previous call to Dggbal,
+// otherwise they should be set to 0 and n-1, respectively. It must hold that
+//  0 <= ilo <= ihi < n,     if n > 0,
+//  ilo == 0 and ihi == -1,  if n == 0,
+// otherwise Dgghrd will panic.
+//
+// Dgghrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int) {
+	switch compq {
+	default:
+		panic(badEVComp)
+	case lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ:
+	}
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ:
+	}
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case ilo < 0 || max(0, n-1) < ilo:
+		panic(badIlo)
+	case ihi < min(ilo, n-1) || n <= ihi:
+		panic(badIhi)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if compq != lapack.None {
+		checkMatrix(n, n, q, ldq)
+	}
+	if compz != lapack.None {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	// Initialize Q and Z if desired.
+	if compq == lapack.ExplicitQZ {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+	if compz == lapack.ExplicitQZ {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	if n == 1 {
+		return
+	}
+
+	// Zero out the lower triangle of B.
+	for i := 1; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b[i*ldb+j] = 0
+		}
+	}
+
+	bi := blas64.Implementation()
+	// Reduce A and B.
+	for jcol := ilo; jcol <= ihi-2; jcol++ {
+		for jrow := ihi; jrow >= jcol+2; jrow-- {
+			// Step 1: rotate rows jrow-1 and jrow to kill A[jrow,jcol].
+			var c, s float64
+			c, s, a[(jrow-1)*lda+jcol] = impl.Dlartg(a[(jrow-1)*lda+jcol], a[jrow*lda+jcol])
+			a[jrow*lda+jcol] = 0
+			bi.Drot(n-jcol-1, a[(jrow-1)*lda+jcol+1:], 1, a[jrow*lda+jcol+1:], 1, c, s)
+			bi.Drot(n-jrow+1, b[(jrow-1)*ldb+jrow-1:], 1, b[jrow*ldb+jrow-1:], 1, c, s)
+			if compq != lapack.None {
+				bi.Drot(n, q[jrow-1:], ldq, q[jrow:], ldq, c, s)
+			}
+
+			// Step 2: rotate columns jrow and jrow-1 to kill B[jrow,jrow-1].
+			c, s, b[jrow*ldb+jrow] = impl.Dlartg(b[jrow*ldb+jrow], b[jrow*ldb+jrow-1])
+			b[jrow*ldb+jrow-1] = 0
+			bi.Drot(ihi+1, a[jrow:], lda, a[jrow-1:], lda, c, s)
+			bi.Drot(jrow, b[jrow:], ldb, b[jrow-1:], ldb, c, s)
+			if compz != lapack.None {
+				bi.Drot(n, z[jrow:], ldz, z[jrow-1:], ldz, c, s)
+			}
+		}
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dgglse solves the linear equality-constrained least squares problem
+//  minimize |c - A*x|_2 subject to B*x = d,
+// where A is an m×n matrix, B is a p×n matrix, c is a vector of length m, and
+// d is a vector of length p. It must hold that
+//  p <= n <= m+p,
+// and
+//  rank(B) = p and rank([A; B]) = n,
+// which ensure that the problem has a unique solution. The problem is solved
+// using a generalized RQ factorization of the matrices B and A.
+//
+// On return, a and b contain their generalized RQ factorization, and x of
+// length n contains the solution. On return, c is overwritten and the
+// residual sum of squares of the solution is given by the sum of squares of
+// the elements c[n-p:m].
+//
+// d is overwritten on return.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, m+n+p), otherwise Dgglse will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dgglse, the optimal
+// work length will be stored into work[0].
+//
+// Dgglse returns whether the solution was computed successfully. If the upper
+// triangular factor T12 of B in the generalized RQ factorization is singular,
+// which means that rank(B) < p, or if the upper triangular factor R11 of A is
+// singular, which means that rank([A; B]) < n, Dgglse returns false.
+func (impl Implementation) Dgglse(m, n, p int, a []float64, lda int, b []float64, ldb int, c, d, x, work []float64, lwork int) (ok bool) {
+	mn := min(m, n)
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case p < 0 || n < p || m+p < n:
+		panic("lapack: bad value of p")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, m+n+p) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(m, n, a, lda)
+	checkMatrix(p, n, b, ldb)
+	if len(c) < m {
+		panic("lapack: c has insufficient length")
+	}
+	if len(d) < p {
+		panic("lapack: d has insufficient length")
+	}
+	if len(x) < n {
+		panic("lapack: x has insufficient length")
+	}
+
+	// Calculate the workspace requirements.
+	lwkopt := 1
+	if n > 0 {
+		nb1 := impl.Ilaenv(1, "DGEQRF", " ", m, n, -1, -1)
+		nb2 := impl.Ilaenv(1, "DGERQF", " ", m, n, -1, -1)
+		nb3 := impl.Ilaenv(1, "DORMQR", " ", m, n, p, -1)
+		nb4 := impl.Ilaenv(1, "DORMRQ", " ", m, n, p, -1)
+		nb := max(max(nb1, nb2), max(nb3, nb4))
+		lwkopt = p + mn + max(m, n)*nb
+	}
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return true
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the generalized RQ factorization of matrices B and A:
+	//  B * Q^T = [ 0 T12 ] p
+	//             n-p  p
+	//  Z^T * A * Q^T = [ R11 R12 ] n-p
+	//                  [  0  R22 ] m-n+p
+	//                   n-p    p
+	// where T12 and R11 are upper triangular, and Q and Z are orthogonal.
+	// The scalar factors of Q are stored in work[:p] and those of Z in
+	// work[p:p+mn].
+	impl.Dggrqf(p, m, n, b, ldb, work[:p], a, lda, work[p:p+mn], work[p+mn:], lwork-p-mn)
+	lopt := int(work[p+mn])
+
+	// Update c = Z^T * c = [ c1 ] n-p
+	//                      [ c2 ] m-n+p
+	impl.Dormqr(blas.Left, blas.Trans, m, 1, mn, a, lda, work[p:p+mn], c, 1, work[p+mn:], lwork-p-mn)
+	lopt = max(lopt, int(work[p+mn]))
+
+	bi := blas64.Implementation()
+	if p > 0 {
+		// Solve T12 * x2 = d for x2.
+		ok = impl.Dtrtrs(blas.Upper, blas.NoTrans, blas.NonUnit, p, 1, b[n-p:], ldb, d, 1)
+		if !ok {
+			return false
+		}
+
+		// Put the solution in x.
+		bi.Dcopy(p, d, 1, x[n-p:], 1)
+
+		// Update c1 = c1 - R12 * x2.
+		bi.Dgemv(blas.NoTrans, n-p, p, -1, a[n-p:], lda, d, 1, 1, c, 1)
+	}
+
+	if n > p {
+		// Solve R11 * x1 = c1 for x1.
+		ok = impl.Dtrtrs(blas.Upper, blas.NoTrans, blas.NonUnit, n-p, 1, a, lda, c, 1)
+		if !ok {
+			return false
+		}
+
+		// Put the solution in x.
+		bi.Dcopy(n-p, c, 1, x, 1)
+	}
+
+	// Compute the residual vector.
+	var nr int
+	if m < n {
+		nr = m + p - n
+		if nr > 0 {
+			bi.Dgemv(blas.NoTrans, nr, n-m, -1, a[(n-p)*lda+m:], lda, d[nr:], 1, 1, c[n-p:], 1)
+		}
+	} else {
+		nr = p
+	}
+	if nr > 0 {
+		bi.Dtrmv(blas.Upper, blas.NoTrans, blas.NonUnit, nr, a[(n-p)*lda+n-p:], lda, d, 1)
+		bi.Daxpy(nr, -1, d, 1, c[n-p:], 1)
+	}
+
+	// Backward transformation x = Q^T * x.
+	impl.Dormrq(blas.Left, blas.Trans, n, 1, p, b, ldb, work[:p], x, 1, work[p+mn:], lwork-p-mn)
+	work[0] = float64(p + mn + max(lopt, int(work[p+mn])))
+	return true
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dggqrf computes a generalized QR factorization of the n×m matrix A and the
+// n×p matrix B:
+//  A = Q * R,    B = Q * T * Z,
+// where Q is an n×n orthogonal matrix, Z is a p×p orthogonal matrix, and R
+// and T assume one of the forms
+//  R = [ R11 ] m  , if n >= m,    R = [ R11 R12 ] n    , if n < m,
+//      [  0  ] n-m                      n   m-n
+//        m
+// where R11 is upper triangular, and
+//  T = [ 0 T12 ] n  , if n <= p,   T = [ T11 ] n-p , if n > p,
+//       p-n  n                          [ T21 ] p
+//                                          p
+// where T12 or T21 is a p×p upper triangular matrix.
+//
+// In particular, if B is square and nonsingular, the generalized QR
+// factorization of A and B implicitly gives the QR factorization of
+// inv(B)*A:
+//  inv(B)*A = Z^T * (inv(T)*R).
+//
+// On return, the elements on and above the diagonal of a contain the
+// min(n,m)×m upper trapezoidal matrix R, and the elements below the diagonal,
+// together with taua, represent the orthogonal matrix Q as a product of
+// min(n,m) elementary reflectors as returned by Dgeqrf. taua must have length
+// at least min(n,m).
+//
+// On return, if n <= p, the upper triangle of the subarray B[0:n, p-n:p]
+// contains the n×n upper triangular matrix T, and if n > p, the elements on
+// and above the (n-p)-th subdiagonal contain the n×p upper trapezoidal matrix
+// T. The remaining elements, together with taub, represent the orthogonal
+// matrix Z as a product of min(n,p) elementary reflectors as returned by
+// Dgerqf. taub must have length at least min(n,p).
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, n, m, p), otherwise Dggqrf will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggqrf, the optimal
+// work length will be stored into work[0].
+//
+// Dggqrf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggqrf(n, m, p int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case m < 0:
+		panic(mLT0)
+	case p < 0:
+		panic("lapack: p < 0")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, max(n, max(m, p))) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, m, a, lda)
+	checkMatrix(n, p, b, ldb)
+	if len(taua) < min(n, m) || len(taub) < min(n, p) {
+		panic(badTau)
+	}
+
+	nb1 := impl.Ilaenv(1, "DGEQRF", " ", n, m, -1, -1)
+	nb2 := impl.Ilaenv(1, "DGERQF", " ", n, p, -1, -1)
+	nb3 := impl.Ilaenv(1, "DORMQR", " ", n, m, p, -1)
+	nb := max(nb1, max(nb2, nb3))
+	lwkopt := max(1, max(n, max(m, p))*nb)
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return
+	}
+
+	// QR factorization of the n×m matrix A:
+	//  A = Q * R.
+	impl.Dgeqrf(n, m, a, lda, taua, work, lwork)
+	lopt := int(work[0])
+
+	// Update B := Q^T * B.
+	k := min(n, m)
+	impl.Dormqr(blas.Left, blas.Trans, n, p, k, a, lda, taua[:k], b, ldb, work, lwork)
+	lopt = max(lopt, int(work[0]))
+
+	// RQ factorization of the n×p matrix B:
+	//  B = T * Z.
+	impl.Dgerqf(n, p, b, ldb, taub[:min(n, p)], work, lwork)
+	work[0] = float64(max(lopt, int(work[0])))
+}
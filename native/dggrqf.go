@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dggrqf computes a generalized RQ factorization of the m×n matrix A and the
+// p×n matrix B:
+//  A = R * Q,    B = Z * T * Q,
+// where Q is an n×n orthogonal matrix, Z is a p×p orthogonal matrix, and R
+// and T assume one of the forms
+//  R = [ 0 R12 ] m  , if m <= n,   R = [ R11 ] m-n , if m > n,
+//       n-m  m                         [ R21 ] n
+//                                         n
+// where R12 or R21 is upper triangular, and
+//  T = [ T11 ] n  , if p >= n,    T = [ T11 T12 ] p    , if p < n,
+//      [  0  ] p-n                      p   n-p
+//        n
+// where T11 is upper triangular.
+//
+// In particular, if B is square and nonsingular, the generalized RQ
+// factorization of A and B implicitly gives the RQ factorization of
+// A*inv(B):
+//  A*inv(B) = (R*inv(T))*Z^T.
+//
+// On return, if m <= n, the upper triangle of the subarray A[0:m, n-m:n]
+// contains the m×m upper triangular matrix R, and if m > n, the elements on
+// and above the (m-n)-th subdiagonal contain the m×n upper trapezoidal matrix
+// R. The remaining elements, together with taua, represent the orthogonal
+// matrix Q as a product of min(m,n) elementary reflectors as returned by
+// Dgerqf. taua must have length at least min(m,n).
+//
+// On return, the elements on and above the diagonal of b contain the
+// min(p,n)×n upper trapezoidal matrix T, and the elements below the diagonal,
+// together with taub, represent the orthogonal matrix Z as a product of
+// min(p,n) elementary reflectors as returned by Dgeqrf. taub must have length
+// at least min(p,n).
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, m, p, n), otherwise Dggrqf will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dggrqf, the optimal
+// work length will be stored into work[0].
+//
+// Dggrqf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dggrqf(m, p, n int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case p < 0:
+		panic("lapack: p < 0")
+	case n < 0:
+		panic(nLT0)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, max(m, max(p, n))) && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(m, n, a, lda)
+	checkMatrix(p, n, b, ldb)
+	if len(taua) < min(m, n) || len(taub) < min(p, n) {
+		panic(badTau)
+	}
+
+	nb1 := impl.Ilaenv(1, "DGERQF", " ", m, n, -1, -1)
+	nb2 := impl.Ilaenv(1, "DGEQRF", " ", p, n, -1, -1)
+	nb3 := impl.Ilaenv(1, "DORMRQ", " ", m, n, p, -1)
+	nb := max(nb1, max(nb2, nb3))
+	lwkopt := max(1, max(n, max(m, p))*nb)
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return
+	}
+
+	// RQ factorization of the m×n matrix A:
+	//  A = R * Q.
+	k := min(m, n)
+	impl.Dgerqf(m, n, a, lda, taua[:k], work, lwork)
+	lopt := int(work[0])
+
+	// Update B := B * Q^T.
+	impl.Dormrq(blas.Right, blas.Trans, p, n, k, a[max(0, m-n)*lda:], lda, taua, b, ldb, work, lwork)
+	lopt = max(lopt, int(work[0]))
+
+	// QR factorization of the p×n matrix B:
+	//  B = Z * T.
+	impl.Dgeqrf(p, n, b, ldb, taub, work, lwork)
+	work[0] = float64(max(lopt, int(work[0])))
+}
@@ -0,0 +1,425 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgsvj0 is a pre-processor for Dgesvj. It applies Jacobi rotations to the
+// columns of the m×n matrix A*diag(d) in the same way as Dgesvj, but it does
+// not check convergence as carefully and it performs at most nsweep sweeps.
+// m must be at least n.
+//
+// On entry, a and d hold the matrix A and the n diagonal elements of the
+// scaling matrix D, and sva holds the Euclidean norms of the columns of
+// A*diag(d). On return, A*diag(d) has been post-multiplied by the product of
+// the applied rotations, and the columns of a, the elements of d and sva are
+// permuted so that the column norms in sva are non-increasing.
+//
+// jobv specifies whether the transformations applied to the columns of a are
+// accumulated. Since the rotations are applied to A*diag(d) in scaled form,
+// these are not orthogonal in general.
+//  jobv == lapack.ComputeRightSV  The transformations are applied to the n×n
+//                                 matrix in v and mv is ignored.
+//  jobv == lapack.ApplyRightSV    The transformations are applied to the mv×n
+//                                 matrix in v.
+//  jobv == lapack.None            v is not referenced.
+//
+// eps and sfmin are the machine precision and the safe minimum. A rotation is
+// applied to a pair of columns only if the absolute value of the cosine of the
+// angle between them is larger than tol.
+//
+// work must have length at least lwork, and lwork must be at least m.
+//
+// Dgsvj0 returns whether convergence was detected within nsweep sweeps.
+//
+// Dgsvj0 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgsvj0(jobv lapack.RightSVJob, m, n int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64, lwork int) (ok bool) {
+	rsvec := jobv == lapack.ComputeRightSV
+	applv := jobv == lapack.ApplyRightSV
+	if !rsvec && !applv && jobv != lapack.None {
+		panic(badRightSVJob)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 || n > m {
+		panic(badDims)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(sva) < n {
+		panic(badSVA)
+	}
+	mvl := n
+	if applv {
+		if mv < 0 {
+			panic(badDims)
+		}
+		mvl = mv
+	}
+	if rsvec || applv {
+		checkMatrix(mvl, n, v, ldv)
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < m {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return true
+	}
+
+	_, _, _, ok = impl.dgsvjSweeps(m, n, a, lda, d, sva, mvl, v, ldv, rsvec || applv, eps, sfmin, tol, nsweep, 0, work)
+
+	impl.dgsvjSort(m, n, a, lda, d, sva, mvl, v, ldv, rsvec || applv)
+	return ok
+}
+
+// dgsvjSweeps performs at most nsweep sweeps of row-cyclic one-sided Jacobi
+// rotations with de Rijk's pivoting on the columns of the m×n matrix
+// A*diag(d). During the first swband sweeps, rows of blocks in which the
+// columns are already nearly orthogonal are skipped. The rotations are
+// accumulated in the mvl×n matrix V if rsvec is true.
+//
+// dgsvjSweeps returns the number of sweeps performed, the largest absolute
+// cosine and sine of the last sweep, and whether convergence was detected.
+func (impl Implementation) dgsvjSweeps(m, n int, a []float64, lda int, d, sva []float64, mvl int, v []float64, ldv int, rsvec bool, eps, sfmin, tol float64, nsweep, swband int, work []float64) (sweeps int, mxaapq, mxsinj float64, ok bool) {
+	bi := blas64.Implementation()
+
+	roottol := math.Sqrt(tol)
+	emptsw := n * (n - 1) / 2
+
+	// The columns are processed in blocks of kbl columns. Within the first
+	// swband sweeps, the rest of a row of a block is skipped after rowskip
+	// consecutive pairs need no rotation, and the rest of an off-diagonal
+	// block is skipped after blskip pairs need no rotation.
+	kbl := min(8, n)
+	nbl := (n + kbl - 1) / kbl
+	blskip := kbl * kbl
+	rowskip := min(5, kbl)
+	const lkahead = 1
+
+	for i := 1; i <= nsweep; i++ {
+		mxaapq = 0
+		mxsinj = 0
+		var iswrot, notrot, pskipped int
+		for ibr := 0; ibr < nbl; ibr++ {
+			// Apply the rotations to the diagonal block and lkahead
+			// following diagonal blocks.
+			igl := ibr * kbl
+			for ir1 := 0; ir1 <= min(lkahead, nbl-ibr-1); ir1++ {
+				igl += ir1 * kbl
+				for p := igl; p < min(igl+kbl, n-1); p++ {
+					// de Rijk's pivoting.
+					q := bi.Idamax(n-p, sva[p:], 1) + p
+					if p != q {
+						bi.Dswap(m, a[p:], lda, a[q:], lda)
+						if rsvec {
+							bi.Dswap(mvl, v[p:], ldv, v[q:], ldv)
+						}
+						sva[p], sva[q] = sva[q], sva[p]
+						d[p], d[q] = d[q], d[p]
+					}
+					if ir1 == 0 {
+						// Column norms are periodically updated by
+						// explicit norm computation.
+						sva[p] = bi.Dnrm2(m, a[p:], lda) * d[p]
+					}
+					aapp := sva[p]
+					if aapp <= 0 {
+						if ir1 == 0 && aapp == 0 {
+							notrot += min(igl+kbl, n) - p - 1
+						}
+						continue
+					}
+					pskipped = 0
+					for q := p + 1; q < min(igl+kbl, n); q++ {
+						var rotated bool
+						if sva[q] > 0 {
+							var aapq, sinj float64
+							aapp, aapq, sinj, rotated = impl.dgsvjPair(m, a, lda, d, sva, mvl, v, ldv, rsvec, p, q, aapp, eps, sfmin, tol, work)
+							mxaapq = math.Max(mxaapq, aapq)
+							if rotated {
+								mxsinj = math.Max(mxsinj, sinj)
+								if ir1 == 0 {
+									notrot = 0
+									pskipped = 0
+									iswrot++
+								}
+							}
+						}
+						if !rotated {
+							notrot++
+							pskipped++
+						}
+						if i <= swband && pskipped > rowskip {
+							if ir1 == 0 {
+								aapp = -aapp
+							}
+							notrot = 0
+							break
+						}
+					}
+					sva[p] = aapp
+				}
+			}
+
+			// Apply the rotations to the off-diagonal blocks in the
+			// block row.
+			igl = ibr * kbl
+		offDiag:
+			for jbc := ibr + 1; jbc < nbl; jbc++ {
+				jgl := jbc * kbl
+				var ijblsk int
+				for p := igl; p < min(igl+kbl, n); p++ {
+					aapp := sva[p]
+					if aapp <= 0 {
+						if aapp == 0 {
+							notrot += min(jgl+kbl, n) - jgl
+						} else {
+							notrot = 0
+						}
+						continue
+					}
+					pskipped = 0
+					for q := jgl; q < min(jgl+kbl, n); q++ {
+						var rotated bool
+						if sva[q] > 0 {
+							var aapq, sinj float64
+							aapp, aapq, sinj, rotated = impl.dgsvjPair(m, a, lda, d, sva, mvl, v, ldv, rsvec, p, q, aapp, eps, sfmin, tol, work)
+							mxaapq = math.Max(mxaapq, aapq)
+							if rotated {
+								mxsinj = math.Max(mxsinj, sinj)
+								notrot = 0
+								pskipped = 0
+								iswrot++
+							}
+						}
+						if !rotated {
+							notrot++
+							pskipped++
+							ijblsk++
+						}
+						if i <= swband && ijblsk >= blskip {
+							sva[p] = aapp
+							notrot = 0
+							break offDiag
+						}
+						if i <= swband && pskipped > rowskip {
+							aapp = -aapp
+							notrot = 0
+							break
+						}
+					}
+					sva[p] = aapp
+				}
+			}
+			// Negative norms mark skipped rows, so restore them.
+			for p := igl; p < min(igl+kbl, n); p++ {
+				sva[p] = math.Abs(sva[p])
+			}
+		}
+		sva[n-1] = bi.Dnrm2(m, a[n-1:], lda) * d[n-1]
+
+		if i < swband && (mxaapq <= roottol || iswrot <= n) {
+			swband = i
+		}
+		if i > swband+1 && mxaapq < math.Sqrt(float64(n))*tol && float64(n)*mxaapq*mxsinj < tol {
+			return i, mxaapq, mxsinj, true
+		}
+		if notrot >= emptsw {
+			return i, mxaapq, mxsinj, true
+		}
+	}
+	return nsweep, mxaapq, mxsinj, false
+}
+
+// dgsvjPair computes the cosine of the angle between the columns p and q of
+// the m×n matrix A*diag(d), whose norms are aapp and sva[q], and if its
+// absolute value is larger than tol, applies a Jacobi rotation that makes the
+// two columns orthogonal. The rotation is also applied to the columns of the
+// mvl×n matrix V if rsvec is true. The norm of column q is updated in sva[q].
+//
+// dgsvjPair returns the updated norm of column p, the absolute value of the
+// cosine, the absolute value of the sine of the rotation, and whether the
+// columns were rotated. work must have length at least m.
+func (impl Implementation) dgsvjPair(m int, a []float64, lda int, d, sva []float64, mvl int, v []float64, ldv int, rsvec bool, p, q int, aapp, eps, sfmin, tol float64, work []float64) (aappNew, aapq, sinj float64, rotated bool) {
+	bi := blas64.Implementation()
+
+	small := sfmin / eps
+	big := 1 / sfmin
+	rooteps := math.Sqrt(eps)
+	bigtheta := 1 / rooteps
+
+	aaqq := sva[q]
+	aapp0 := aapp
+
+	// Compute the cosine of the angle while avoiding overflow and
+	// underflow.
+	var rotok bool
+	if aaqq >= 1 {
+		if aapp >= aaqq {
+			rotok = small*aapp <= aaqq
+		} else {
+			rotok = small*aaqq <= aapp
+		}
+		if aapp < big/aaqq {
+			aapq = bi.Ddot(m, a[p:], lda, a[q:], lda) * d[p] * d[q] / aaqq / aapp
+		} else {
+			bi.Dcopy(m, a[p:], lda, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aapp, d[p], m, 1, work, 1)
+			aapq = bi.Ddot(m, work, 1, a[q:], lda) * d[q] / aaqq
+		}
+	} else {
+		if aapp >= aaqq {
+			rotok = aapp <= aaqq/small
+		} else {
+			rotok = aaqq <= aapp/small
+		}
+		if aapp > small/aaqq {
+			aapq = bi.Ddot(m, a[p:], lda, a[q:], lda) * d[p] * d[q] / aaqq / aapp
+		} else {
+			bi.Dcopy(m, a[q:], lda, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aaqq, d[q], m, 1, work, 1)
+			aapq = bi.Ddot(m, work, 1, a[p:], lda) * d[p] / aapp
+		}
+	}
+	if math.Abs(aapq) <= tol {
+		return aapp, math.Abs(aapq), 0, false
+	}
+
+	if rotok {
+		aqoap := aaqq / aapp
+		apoaq := aapp / aaqq
+		theta := -0.5 * math.Abs(aqoap-apoaq) / aapq
+		if aaqq > aapp0 {
+			theta = -theta
+		}
+		if math.Abs(theta) > bigtheta {
+			// The rotation is close to the identity, so the
+			// diagonal scaling does not need to be updated.
+			t := 0.5 / theta
+			fastr := blas.DrotmParams{
+				Flag: blas.OffDiagonal,
+				H:    [4]float64{0, t * d[p] / d[q], -t * d[q] / d[p], 0},
+			}
+			bi.Drotm(m, a[p:], lda, a[q:], lda, fastr)
+			if rsvec {
+				bi.Drotm(mvl, v[p:], ldv, v[q:], ldv, fastr)
+			}
+			sva[q] = aaqq * math.Sqrt(math.Max(0, 1+t*apoaq*aapq))
+			aapp *= math.Sqrt(math.Max(0, 1-t*aqoap*aapq))
+			sinj = math.Abs(t)
+		} else {
+			thsign := -math.Copysign(1, aapq)
+			if aaqq > aapp0 {
+				thsign = -thsign
+			}
+			t := 1 / (theta + thsign*math.Sqrt(1+theta*theta))
+			cs := math.Sqrt(1 / (1 + t*t))
+			sn := t * cs
+			sinj = math.Abs(sn)
+			sva[q] = aaqq * math.Sqrt(math.Max(0, 1+t*apoaq*aapq))
+			aapp *= math.Sqrt(math.Max(0, 1-t*aqoap*aapq))
+
+			// Apply the rotation as a fast scaled rotation, with
+			// the cosine absorbed into the diagonal scaling so that
+			// the elements of d stay away from underflow and
+			// overflow.
+			apoaq = d[p] / d[q]
+			aqoap = d[q] / d[p]
+			switch {
+			case d[p] >= 1 && d[q] >= 1:
+				fastr := blas.DrotmParams{
+					Flag: blas.OffDiagonal,
+					H:    [4]float64{0, t * apoaq, -t * aqoap, 0},
+				}
+				d[p] *= cs
+				d[q] *= cs
+				bi.Drotm(m, a[p:], lda, a[q:], lda, fastr)
+				if rsvec {
+					bi.Drotm(mvl, v[p:], ldv, v[q:], ldv, fastr)
+				}
+			case d[p] >= 1 || (d[q] < 1 && d[p] >= d[q]):
+				bi.Daxpy(m, -t*aqoap, a[q:], lda, a[p:], lda)
+				bi.Daxpy(m, cs*sn*apoaq, a[p:], lda, a[q:], lda)
+				if rsvec {
+					bi.Daxpy(mvl, -t*aqoap, v[q:], ldv, v[p:], ldv)
+					bi.Daxpy(mvl, cs*sn*apoaq, v[p:], ldv, v[q:], ldv)
+				}
+				d[p] *= cs
+				d[q] /= cs
+			default:
+				bi.Daxpy(m, t*apoaq, a[p:], lda, a[q:], lda)
+				bi.Daxpy(m, -cs*sn*aqoap, a[q:], lda, a[p:], lda)
+				if rsvec {
+					bi.Daxpy(mvl, t*apoaq, v[p:], ldv, v[q:], ldv)
+					bi.Daxpy(mvl, -cs*sn*aqoap, v[q:], ldv, v[p:], ldv)
+				}
+				d[p] /= cs
+				d[q] *= cs
+			}
+		}
+	} else {
+		// The norms of the columns differ too much for a rotation, so
+		// use a modified Gram-Schmidt like transformation on the
+		// smaller column.
+		if aapp > aaqq {
+			bi.Dcopy(m, a[p:], lda, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aapp, 1, m, 1, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aaqq, 1, m, 1, a[q:], lda)
+			bi.Daxpy(m, -aapq*d[p]/d[q], work, 1, a[q:], lda)
+			impl.Dlascl(lapack.General, 0, 0, 1, aaqq, m, 1, a[q:], lda)
+			sva[q] = aaqq * math.Sqrt(math.Max(0, 1-aapq*aapq))
+		} else {
+			bi.Dcopy(m, a[q:], lda, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aaqq, 1, m, 1, work, 1)
+			impl.Dlascl(lapack.General, 0, 0, aapp, 1, m, 1, a[p:], lda)
+			bi.Daxpy(m, -aapq*d[q]/d[p], work, 1, a[p:], lda)
+			impl.Dlascl(lapack.General, 0, 0, 1, aapp, m, 1, a[p:], lda)
+			aapp *= math.Sqrt(math.Max(0, 1-aapq*aapq))
+		}
+		sinj = sfmin
+	}
+
+	// Recompute the norms of the columns if cancellation may have
+	// destroyed their accuracy. Dnrm2 is safe from overflow and underflow.
+	if r := sva[q] / aaqq; r*r <= rooteps {
+		sva[q] = bi.Dnrm2(m, a[q:], lda) * d[q]
+	}
+	if r := aapp / aapp0; r*r <= rooteps {
+		aapp = bi.Dnrm2(m, a[p:], lda) * d[p]
+	}
+	return aapp, math.Abs(aapq), sinj, true
+}
+
+// dgsvjSort permutes the columns of the m×n matrix A, the elements of d and of
+// sva, and if rsvec is true the columns of the mvl×n matrix V, so that the
+// elements of sva are non-increasing.
+func (impl Implementation) dgsvjSort(m, n int, a []float64, lda int, d, sva []float64, mvl int, v []float64, ldv int, rsvec bool) {
+	bi := blas64.Implementation()
+	for p := 0; p < n-1; p++ {
+		q := bi.Idamax(n-p, sva[p:], 1) + p
+		if p == q {
+			continue
+		}
+		sva[p], sva[q] = sva[q], sva[p]
+		d[p], d[q] = d[q], d[p]
+		bi.Dswap(m, a[p:], lda, a[q:], lda)
+		if rsvec {
+			bi.Dswap(mvl, v[p:], ldv, v[q:], ldv)
+		}
+	}
+}
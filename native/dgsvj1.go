@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dgsvj1 is a pre-processor for Dgesvj. It applies Jacobi rotations to the
+// columns of the m×n matrix A*diag(d) in the same way as Dgsvj0, but it only
+// rotates pairs of columns where one column is among the first n1 columns and
+// the other is among the last n-n1 columns. That is, if A*diag(d) is
+// partitioned as
+//  [ A1 A2 ]
+// where A1 has n1 columns, the columns of A1 are orthogonalized against the
+// columns of A2. m must be at least n.
+//
+// On entry, a and d hold the matrix A and the n diagonal elements of the
+// scaling matrix D, and sva holds the Euclidean norms of the columns of
+// A*diag(d). On return, A*diag(d) has been post-multiplied by the product of
+// the applied rotations, and the columns of a, the elements of d and sva are
+// permuted so that the column norms in sva are non-increasing.
+//
+// jobv specifies whether the transformations applied to the columns of a are
+// accumulated. Since the rotations are applied to A*diag(d) in scaled form,
+// these are not orthogonal in general.
+//  jobv == lapack.ComputeRightSV  The transformations are applied to the n×n
+//                                 matrix in v and mv is ignored.
+//  jobv == lapack.ApplyRightSV    The transformations are applied to the mv×n
+//                                 matrix in v.
+//  jobv == lapack.None            v is not referenced.
+//
+// eps and sfmin are the machine precision and the safe minimum. A rotation is
+// applied to a pair of columns only if the absolute value of the cosine of the
+// angle between them is larger than tol.
+//
+// work must have length at least lwork, and lwork must be at least m.
+//
+// Dgsvj1 returns whether convergence was detected within nsweep sweeps.
+//
+// Dgsvj1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dgsvj1(jobv lapack.RightSVJob, m, n, n1 int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64, lwork int) (ok bool) {
+	rsvec := jobv == lapack.ComputeRightSV
+	applv := jobv == lapack.ApplyRightSV
+	if !rsvec && !applv && jobv != lapack.None {
+		panic(badRightSVJob)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < 0 || n > m {
+		panic(badDims)
+	}
+	if n1 < 0 || n1 > n {
+		panic(badDims)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(sva) < n {
+		panic(badSVA)
+	}
+	mvl := n
+	if applv {
+		if mv < 0 {
+			panic(badDims)
+		}
+		mvl = mv
+	}
+	if rsvec || applv {
+		checkMatrix(mvl, n, v, ldv)
+	}
+	if len(work) < lwork {
+		panic(shortWork)
+	}
+	if lwork < m {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return true
+	}
+	rsvec = rsvec || applv
+
+	bi := blas64.Implementation()
+
+	emptsw := n1 * (n - n1)
+
+	// The pairs of columns are processed in kbl×kbl tiles.
+	kbl := min(8, n)
+	nblr := (n1 + kbl - 1) / kbl
+	nblc := (n - n1 + kbl - 1) / kbl
+
+	for i := 1; i <= nsweep; i++ {
+		var mxaapq, mxsinj float64
+		var notrot int
+		for ibr := 0; ibr < nblr; ibr++ {
+			igl := ibr * kbl
+			for jbc := 0; jbc < nblc; jbc++ {
+				jgl := n1 + jbc*kbl
+				for p := igl; p < min(igl+kbl, n1); p++ {
+					aapp := sva[p]
+					if aapp <= 0 {
+						notrot += min(jgl+kbl, n) - jgl
+						continue
+					}
+					for q := jgl; q < min(jgl+kbl, n); q++ {
+						var rotated bool
+						if sva[q] > 0 {
+							var aapq, sinj float64
+							aapp, aapq, sinj, rotated = impl.dgsvjPair(m, a, lda, d, sva, mvl, v, ldv, rsvec, p, q, aapp, eps, sfmin, tol, work)
+							mxaapq = math.Max(mxaapq, aapq)
+							if rotated {
+								mxsinj = math.Max(mxsinj, sinj)
+								notrot = 0
+							}
+						}
+						if !rotated {
+							notrot++
+						}
+					}
+					sva[p] = aapp
+				}
+			}
+		}
+		sva[n-1] = bi.Dnrm2(m, a[n-1:], lda) * d[n-1]
+
+		if i > 1 && mxaapq < float64(n)*tol && float64(n)*mxaapq*mxsinj < tol {
+			ok = true
+			break
+		}
+		if notrot >= emptsw {
+			ok = true
+			break
+		}
+	}
+
+	impl.dgsvjSort(m, n, a, lda, d, sva, mvl, v, ldv, rsvec)
+	return ok
+}
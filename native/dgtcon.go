@@ -0,0 +1,101 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dgtcon estimates the reciprocal of the condition number of an n×n
+// tridiagonal matrix A given its LU factorization computed by Dgttrf. The
+// condition number computed may be based on the 1-norm or the ∞-norm.
+//
+// dl, d, du, du2 and ipiv contain the factorization of A as returned by
+// Dgttrf. dl, du and du2 must have length at least n-1, n-1 and n-2,
+// respectively, and ipiv must have length at least n, otherwise Dgtcon will
+// panic.
+//
+// anorm is the corresponding 1-norm or ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 2*n and Dgtcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dgtcon will panic
+// otherwise.
+func (impl Implementation) Dgtcon(norm lapack.MatrixNorm, n int, dl, d, du, du2 []float64, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case norm != lapack.MaxColumnSum && norm != lapack.MaxRowSum:
+		panic(badNorm)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	// Check that d[0:n] is non-zero.
+	for i := 0; i < n; i++ {
+		if d[i] == 0 {
+			return 0
+		}
+	}
+
+	var ainvnm float64
+	var kase int
+	var isave [3]int
+	kase1 := 2
+	if norm == lapack.MaxColumnSum {
+		kase1 = 1
+	}
+
+	// Estimate the norm of inv(A).
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			break
+		}
+		if kase == kase1 {
+			// Multiply by inv(U)*inv(L).
+			impl.Dgttrs(blas.NoTrans, n, 1, dl, d, du, du2, ipiv, work, 1)
+		} else {
+			// Multiply by inv(L^T)*inv(U^T).
+			impl.Dgttrs(blas.Trans, n, 1, dl, d, du, du2, ipiv, work, 1)
+		}
+	}
+
+	// Compute the estimate of the reciprocal condition number.
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,100 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dgtsv solves the equation
+//  A * X = B
+// where A is an n×n tridiagonal matrix, by Gaussian elimination with partial
+// pivoting. Note that the equation A^T * X = B may be solved by interchanging
+// the order of the arguments du and dl.
+//
+// On entry, dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively. On return, dl contains the n-2
+// elements of the second superdiagonal of the upper triangular matrix U from
+// the LU factorization of A, d contains the n diagonal elements of U, and du
+// contains the n-1 elements of the first superdiagonal of U. dl and du must
+// have length at least n-1, otherwise Dgtsv will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B. On return, if ok
+// is true, b contains the solution matrix X.
+//
+// Dgtsv returns whether U is nonsingular. If U is exactly singular, the
+// solution has not been computed.
+func (Implementation) Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	for i := 0; i < n-1; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required.
+			if d[i] == 0 {
+				return false
+			}
+			fact := dl[i] / d[i]
+			d[i+1] -= fact * du[i]
+			for j := range bi1 {
+				bi1[j] -= fact * bi[j]
+			}
+			dl[i] = 0
+		} else {
+			// Interchange rows i and i+1.
+			fact := d[i] / dl[i]
+			d[i] = dl[i]
+			temp := d[i+1]
+			d[i+1] = du[i] - fact*temp
+			if i < n-2 {
+				dl[i] = du[i+1]
+				du[i+1] = -fact * dl[i]
+			}
+			du[i] = temp
+			for j := range bi {
+				temp := bi[j]
+				bi[j] = bi1[j]
+				bi1[j] = temp - fact*bi1[j]
+			}
+		}
+	}
+	if d[n-1] == 0 {
+		return false
+	}
+
+	// Back solve with the matrix U from the factorization.
+	for i := n - 1; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for j := range bi {
+			if i < n-1 {
+				bi[j] -= du[i] * b[(i+1)*ldb+j]
+			}
+			if i < n-2 {
+				bi[j] -= dl[i] * b[(i+2)*ldb+j]
+			}
+			bi[j] /= d[i]
+		}
+	}
+	return true
+}
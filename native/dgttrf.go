@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dgttrf computes an LU factorization of an n×n tridiagonal matrix A using
+// elimination with partial pivoting and row interchanges. The factorization
+// has the form
+//  A = L * U
+// where L is a product of permutation and unit lower bidiagonal matrices and
+// U is upper triangular with nonzeros in only the main diagonal and first two
+// superdiagonals.
+//
+// On entry, dl, d and du contain the n-1 subdiagonal, n diagonal and n-1
+// superdiagonal elements of A, respectively. On return, dl contains the n-1
+// multipliers that define the matrix L, d contains the n diagonal elements of
+// U, du contains the n-1 elements of the first superdiagonal of U and du2
+// contains the n-2 elements of the second superdiagonal of U.
+//
+// ipiv contains the pivot indices. For 0 <= i < n, row i of the matrix was
+// interchanged with row ipiv[i], where ipiv[i] is always either i or i+1.
+// ipiv[i] == i indicates that a row interchange was not required.
+//
+// dl, du and du2 must have length at least n-1, n-1 and n-2, respectively,
+// and ipiv must have length at least n, otherwise Dgttrf will panic.
+//
+// Dgttrf returns whether U is nonsingular. If U is exactly singular, the
+// factorization has been completed, but U cannot be used to solve a system of
+// equations.
+func (Implementation) Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Initialize ipiv[i] = i and du2[i] = 0.
+	for i := 0; i < n; i++ {
+		ipiv[i] = i
+	}
+	for i := 0; i < n-2; i++ {
+		du2[i] = 0
+	}
+
+	for i := 0; i < n-2; i++ {
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			// No row interchange required, eliminate dl[i].
+			if d[i] != 0 {
+				fact := dl[i] / d[i]
+				dl[i] = fact
+				d[i+1] -= fact * du[i]
+			}
+		} else {
+			// Interchange rows i and i+1, eliminate dl[i].
+			fact := d[i] / dl[i]
+			d[i] = dl[i]
+			dl[i] = fact
+			temp := du[i]
+			du[i] = d[i+1]
+			d[i+1] = temp - fact*d[i+1]
+			du2[i] = du[i+1]
+			du[i+1] = -fact * du[i+1]
+			ipiv[i] = i + 1
+		}
+	}
+	if n > 1 {
+		i := n - 2
+		if math.Abs(d[i]) >= math.Abs(dl[i]) {
+			if d[i] != 0 {
+				fact := dl[i] / d[i]
+				dl[i] = fact
+				d[i+1] -= fact * du[i]
+			}
+		} else {
+			fact := d[i] / dl[i]
+			d[i] = dl[i]
+			dl[i] = fact
+			temp := du[i]
+			du[i] = d[i+1]
+			d[i+1] = temp - fact*d[i+1]
+			ipiv[i] = i + 1
+		}
+	}
+
+	// Check for a zero on the diagonal of U.
+	for i := 0; i < n; i++ {
+		if d[i] == 0 {
+			return false
+		}
+	}
+	return true
+}
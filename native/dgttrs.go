@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dgttrs solves one of the systems of equations
+//  A * X = B    if trans == blas.NoTrans,
+//  A^T * X = B  if trans == blas.Trans or blas.ConjTrans,
+// where A is an n×n tridiagonal matrix, using the LU factorization computed
+// by Dgttrf. dl, d, du, du2 and ipiv contain the factorization of A as
+// returned by Dgttrf. dl, du and du2 must have length at least n-1, n-1 and
+// n-2, respectively, and ipiv must have length at least n, otherwise Dgttrs
+// will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (Implementation) Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int) {
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans && trans != blas.ConjTrans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+	if len(du2) < n-2 {
+		panic(badDU2)
+	}
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	if trans == blas.NoTrans {
+		// Solve L*X = B, overwriting B with X.
+		for i := 0; i < n-1; i++ {
+			bi := b[i*ldb : i*ldb+nrhs]
+			bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+			if ipiv[i] == i {
+				for j := range bi1 {
+					bi1[j] -= dl[i] * bi[j]
+				}
+			} else {
+				for j := range bi1 {
+					temp := bi[j]
+					bi[j] = bi1[j]
+					bi1[j] = temp - dl[i]*bi[j]
+				}
+			}
+		}
+		// Solve U*X = B, overwriting B with X.
+		for i := n - 1; i >= 0; i-- {
+			bi := b[i*ldb : i*ldb+nrhs]
+			for j := range bi {
+				if i < n-1 {
+					bi[j] -= du[i] * b[(i+1)*ldb+j]
+				}
+				if i < n-2 {
+					bi[j] -= du2[i] * b[(i+2)*ldb+j]
+				}
+				bi[j] /= d[i]
+			}
+		}
+		return
+	}
+
+	// Solve U^T*X = B, overwriting B with X.
+	for i := 0; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for j := range bi {
+			if i > 0 {
+				bi[j] -= du[i-1] * b[(i-1)*ldb+j]
+			}
+			if i > 1 {
+				bi[j] -= du2[i-2] * b[(i-2)*ldb+j]
+			}
+			bi[j] /= d[i]
+		}
+	}
+	// Solve L^T*X = B, overwriting B with X.
+	for i := n - 2; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		bi1 := b[(i+1)*ldb : (i+1)*ldb+nrhs]
+		if ipiv[i] == i {
+			for j := range bi {
+				bi[j] -= dl[i] * bi1[j]
+			}
+		} else {
+			for j := range bi {
+				temp := bi1[j]
+				bi1[j] = bi[j] - dl[i]*temp
+				bi[j] = temp
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlaebz contains the iteration loops which compute and use the function
+// N(w), which is the count of eigenvalues of a symmetric tridiagonal matrix T
+// less than or equal to its argument w. It performs a choice of two types of
+// loops:
+//  ijob == 1: Compute N(w) for the bounds of the intervals in ab.
+//  ijob == 2: Perform a bisection iteration to find eigenvalues of T.
+//  ijob == 3: Perform a bisection iteration to invert N(w), i.e., to find a
+//             point which has a specified number of eigenvalues of T to its
+//             left.
+//
+// T is given by its diagonal d and its squared off-diagonal e2, both of which
+// must have length at least n and n-1 respectively. e is not referenced. Sturm
+// sequence pivots that are smaller in magnitude than pivmin are replaced by
+// -pivmin.
+//
+// ab is an mmax×2 matrix, stored row-wise, whose first minp rows hold the
+// intervals (ab[2*j], ab[2*j+1]] to be processed, and nab is an mmax×2 matrix
+// holding the counts N(w) at the interval bounds. If ijob == 1, nab is
+// computed by Dlaebz, otherwise it must be supplied on entry. When an
+// interval is split, the new interval is appended to ab and nab. An interval
+// is considered converged when its width is less than
+// max(abstol, pivmin, reltol*max(|left|,|right|)), or when it contains no
+// eigenvalues. At most nitmax iterations are performed.
+//
+// If ijob == 2, c must contain on entry the midpoints of the minp intervals.
+// If ijob == 3, c must contain the starting search points on entry and nval
+// must hold the desired values of N(w) for each interval; on return nval
+// holds the values reached for the corresponding rows of ab. c is overwritten
+// with the current midpoints. If ijob == 1, nval and c are not referenced.
+//
+// nbmin is the smallest number of intervals that will be processed by the
+// vector loop, which is not used if nbmin is zero. work and iwork must have
+// length at least mmax.
+//
+// Dlaebz returns mout, the number of intervals in the output. If ijob == 1,
+// mout is instead the number of eigenvalues in the union of the input
+// intervals. info is zero if all intervals converged, a value between 1 and
+// mmax if info intervals did not converge, and mmax+1 if more than mmax
+// intervals were generated.
+//
+// Dlaebz is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlaebz(ijob, nitmax, n, mmax, minp, nbmin int, abstol, reltol, pivmin float64, d, e, e2 []float64, nval []int, ab, c []float64, nab []int, work []float64, iwork []int) (mout, info int) {
+	if ijob < 1 || 3 < ijob {
+		panic("lapack: bad ijob")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e2) < n-1 {
+		panic(badE)
+	}
+	if len(ab) < 2*mmax || len(nab) < 2*mmax {
+		panic(badSlice)
+	}
+
+	// Compute the number of eigenvalues in the initial intervals.
+	if ijob == 1 {
+		for ji := 0; ji < minp; ji++ {
+			for jp := 0; jp < 2; jp++ {
+				tmp1 := d[0] - ab[2*ji+jp]
+				if math.Abs(tmp1) < pivmin {
+					tmp1 = -pivmin
+				}
+				nab[2*ji+jp] = 0
+				if tmp1 <= 0 {
+					nab[2*ji+jp] = 1
+				}
+				for j := 1; j < n; j++ {
+					tmp1 = d[j] - e2[j-1]/tmp1 - ab[2*ji+jp]
+					if math.Abs(tmp1) < pivmin {
+						tmp1 = -pivmin
+					}
+					if tmp1 <= 0 {
+						nab[2*ji+jp]++
+					}
+				}
+			}
+			mout += nab[2*ji+1] - nab[2*ji]
+		}
+		return mout, 0
+	}
+
+	if len(c) < mmax {
+		panic(badSlice)
+	}
+	if ijob == 3 && len(nval) < mmax {
+		panic(badIndex)
+	}
+	if len(work) < mmax || len(iwork) < mmax {
+		panic(badWork)
+	}
+
+	// Intervals 0, ..., kf-1 have converged and intervals kf, ..., kl
+	// still need to be refined.
+	kf := 0
+	kl := minp - 1
+
+	// If ijob == 2, initialize c. If ijob == 3, use the user-supplied
+	// starting point.
+	if ijob == 2 {
+		for ji := 0; ji < minp; ji++ {
+			c[ji] = 0.5 * (ab[2*ji] + ab[2*ji+1])
+		}
+	}
+
+	for jit := 0; jit < nitmax; jit++ {
+		if kl-kf+1 >= nbmin && nbmin > 0 {
+			// Vector version of the loop. Compute N(c), the number of
+			// eigenvalues less than c, for all intervals.
+			for ji := kf; ji <= kl; ji++ {
+				work[ji] = d[0] - c[ji]
+				iwork[ji] = 0
+				if work[ji] <= pivmin {
+					iwork[ji] = 1
+					work[ji] = math.Min(work[ji], -pivmin)
+				}
+				for j := 1; j < n; j++ {
+					work[ji] = d[j] - e2[j-1]/work[ji] - c[ji]
+					if work[ji] <= pivmin {
+						iwork[ji]++
+						work[ji] = math.Min(work[ji], -pivmin)
+					}
+				}
+			}
+			if ijob <= 2 {
+				// Choose all intervals containing eigenvalues.
+				klnew := kl
+				for ji := kf; ji <= kl; ji++ {
+					// Ensure that N(w) is monotone.
+					iwork[ji] = min(nab[2*ji+1], max(nab[2*ji], iwork[ji]))
+
+					// Update the queue, adding intervals if both halves
+					// contain eigenvalues.
+					switch {
+					case iwork[ji] == nab[2*ji+1]:
+						// No eigenvalue in the upper interval: use the
+						// lower interval.
+						ab[2*ji+1] = c[ji]
+					case iwork[ji] == nab[2*ji]:
+						// No eigenvalue in the lower interval: use the
+						// upper interval.
+						ab[2*ji] = c[ji]
+					default:
+						klnew++
+						if klnew < mmax {
+							// Eigenvalues in both intervals: add the
+							// upper one to the queue.
+							ab[2*klnew+1] = ab[2*ji+1]
+							nab[2*klnew+1] = nab[2*ji+1]
+							ab[2*klnew] = c[ji]
+							nab[2*klnew] = iwork[ji]
+							ab[2*ji+1] = c[ji]
+							nab[2*ji+1] = iwork[ji]
+						} else {
+							info = mmax + 1
+						}
+					}
+				}
+				if info != 0 {
+					return kl + 1, info
+				}
+				kl = klnew
+			} else {
+				// Binary search. Keep only the interval containing w such
+				// that N(w) = nval.
+				for ji := kf; ji <= kl; ji++ {
+					if iwork[ji] <= nval[ji] {
+						ab[2*ji] = c[ji]
+						nab[2*ji] = iwork[ji]
+					}
+					if iwork[ji] >= nval[ji] {
+						ab[2*ji+1] = c[ji]
+						nab[2*ji+1] = iwork[ji]
+					}
+				}
+			}
+		} else {
+			// Serial version of the loop.
+			klnew := kl
+			for ji := kf; ji <= kl; ji++ {
+				// Compute N(w), the number of eigenvalues less than w.
+				tmp1 := c[ji]
+				tmp2 := d[0] - tmp1
+				var itmp1 int
+				if tmp2 <= pivmin {
+					itmp1 = 1
+					tmp2 = math.Min(tmp2, -pivmin)
+				}
+				for j := 1; j < n; j++ {
+					tmp2 = d[j] - e2[j-1]/tmp2 - tmp1
+					if tmp2 <= pivmin {
+						itmp1++
+						tmp2 = math.Min(tmp2, -pivmin)
+					}
+				}
+
+				if ijob <= 2 {
+					// Choose all intervals containing eigenvalues,
+					// ensuring that N(w) is monotone.
+					itmp1 = min(nab[2*ji+1], max(nab[2*ji], itmp1))
+
+					// Update the queue, adding intervals if both halves
+					// contain eigenvalues.
+					switch {
+					case itmp1 == nab[2*ji+1]:
+						ab[2*ji+1] = tmp1
+					case itmp1 == nab[2*ji]:
+						ab[2*ji] = tmp1
+					case klnew < mmax-1:
+						klnew++
+						ab[2*klnew+1] = ab[2*ji+1]
+						nab[2*klnew+1] = nab[2*ji+1]
+						ab[2*klnew] = tmp1
+						nab[2*klnew] = itmp1
+						ab[2*ji+1] = tmp1
+						nab[2*ji+1] = itmp1
+					default:
+						return kl + 1, mmax + 1
+					}
+				} else {
+					// Binary search. Keep only the interval containing w
+					// such that N(w) = nval.
+					if itmp1 <= nval[ji] {
+						ab[2*ji] = tmp1
+						nab[2*ji] = itmp1
+					}
+					if itmp1 >= nval[ji] {
+						ab[2*ji+1] = tmp1
+						nab[2*ji+1] = itmp1
+					}
+				}
+			}
+			kl = klnew
+		}
+
+		// Check for convergence.
+		kfnew := kf
+		for ji := kf; ji <= kl; ji++ {
+			tmp1 := math.Abs(ab[2*ji+1] - ab[2*ji])
+			tmp2 := math.Max(math.Abs(ab[2*ji+1]), math.Abs(ab[2*ji]))
+			if tmp1 < math.Max(math.Max(abstol, pivmin), reltol*tmp2) || nab[2*ji] >= nab[2*ji+1] {
+				// Converged. Swap with position kfnew, then increment
+				// kfnew.
+				if ji > kfnew {
+					ab[2*ji], ab[2*kfnew] = ab[2*kfnew], ab[2*ji]
+					ab[2*ji+1], ab[2*kfnew+1] = ab[2*kfnew+1], ab[2*ji+1]
+					nab[2*ji], nab[2*kfnew] = nab[2*kfnew], nab[2*ji]
+					nab[2*ji+1], nab[2*kfnew+1] = nab[2*kfnew+1], nab[2*ji+1]
+					if ijob == 3 {
+						nval[ji], nval[kfnew] = nval[kfnew], nval[ji]
+					}
+				}
+				kfnew++
+			}
+		}
+		kf = kfnew
+
+		// Choose midpoints.
+		for ji := kf; ji <= kl; ji++ {
+			c[ji] = 0.5 * (ab[2*ji] + ab[2*ji+1])
+		}
+
+		// If no more intervals to refine, quit.
+		if kf > kl {
+			break
+		}
+	}
+
+	return kl + 1, max(kl+1-kf, 0)
+}
@@ -0,0 +1,273 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlaed0 computes all eigenvalues and corresponding eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method.
+//
+// icompq specifies the computation:
+//  0: compute eigenvalues only,
+//  1: compute eigenvectors of the original dense symmetric matrix also. On
+//     entry, q contains the qsiz×n orthogonal matrix used to reduce the
+//     original matrix to tridiagonal form.
+//  2: compute eigenvalues and eigenvectors of the tridiagonal matrix. q is
+//     then n×n and qsiz is not referenced.
+//
+// On entry, d contains the n diagonal elements of the tridiagonal matrix and
+// e contains its n-1 off-diagonal elements. On return, d contains the
+// eigenvalues in ascending order and e has been destroyed.
+//
+// If icompq == 1, q contains on return the qsiz×n matrix of eigenvectors of
+// the original matrix, and qstore is qsiz×n workspace with stride ldqs. If
+// icompq == 2, q must contain the identity matrix on entry and contains the
+// eigenvectors of the tridiagonal matrix on return.
+//
+// If icompq is 0 or 1, work must have length at least
+// 1 + 3*n + 2*n*lg(n) + n^2 + 2*qsiz*n and iwork must have length at least
+// 6 + 6*n + 5*n*lg(n), where lg(n) is the smallest integer k such that
+// 2^k >= n and qsiz is taken to be n when icompq == 0. If icompq == 2, work
+// must have length at least 4*n + n^2 and iwork must have length at least
+// 3 + 5*n.
+//
+// Dlaed0 returns whether the computation succeeded.
+//
+// Dlaed0 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed0(icompq, n, qsiz int, d, e, q []float64, ldq int, qstore []float64, ldqs int, work []float64, iwork []int) (ok bool) {
+	switch {
+	case icompq < 0 || 2 < icompq:
+		panic("lapack: bad icompq")
+	case n < 0:
+		panic(nLT0)
+	case icompq == 1 && qsiz < max(0, n):
+		panic("lapack: qsiz < n")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	var lgn int
+	for 1<<uint(lgn) < n {
+		lgn++
+	}
+	switch icompq {
+	case 1:
+		checkMatrix(qsiz, n, q, ldq)
+		checkMatrix(qsiz, n, qstore, ldqs)
+	case 2:
+		checkMatrix(n, n, q, ldq)
+	}
+	if icompq == 2 {
+		if len(work) < 4*n+n*n {
+			panic(badWork)
+		}
+		if len(iwork) < 3+5*n {
+			panic(badWork)
+		}
+	} else {
+		lq := n
+		if icompq == 1 {
+			lq = qsiz
+		}
+		if len(work) < 1+3*n+2*n*lgn+n*n+2*lq*n {
+			panic(badWork)
+		}
+		if len(iwork) < 6+6*n+5*n*lgn {
+			panic(badWork)
+		}
+	}
+
+	// A 1×1 matrix is already diagonal. The pointer setup below would
+	// otherwise need more than the documented iwork.
+	if n == 1 {
+		return true
+	}
+
+	smlsiz := impl.Ilaenv(9, "DSTEDC", " ", 0, 0, 0, 0)
+
+	// Determine the size and placement of the submatrices, and save in the
+	// leading elements of iwork.
+	iwork[0] = n
+	subpbs := 1
+	var tlvls int
+	for iwork[subpbs-1] > smlsiz {
+		for j := subpbs - 1; j >= 0; j-- {
+			iwork[2*j+1] = (iwork[j] + 1) / 2
+			iwork[2*j] = iwork[j] / 2
+		}
+		tlvls++
+		subpbs *= 2
+	}
+	for j := 1; j < subpbs; j++ {
+		iwork[j] += iwork[j-1]
+	}
+
+	// Divide the matrix into subpbs submatrices of size at most smlsiz+1
+	// using rank-1 modifications (cuts).
+	spm1 := subpbs - 1
+	for i := 0; i < spm1; i++ {
+		submat := iwork[i]
+		smm1 := submat - 1
+		d[smm1] -= math.Abs(e[smm1])
+		d[submat] -= math.Abs(e[smm1])
+	}
+
+	indxq := 4*n + 3
+
+	// Set up workspaces for eigenvalues only/accumulate new vectors
+	// routine.
+	var iprmpt, iperm, iqptr, igivpt, igivcl, igivnm, iq, iwrem int
+	if icompq != 2 {
+		iprmpt = indxq + n
+		iperm = iprmpt + n*lgn
+		iqptr = iperm + n*lgn
+		igivpt = iqptr + n + 2
+		igivcl = igivpt + n*lgn
+
+		igivnm = 0
+		iq = igivnm + 2*n*lgn
+		iwrem = iq + n*n + 1
+
+		// Initialize pointers.
+		for i := 0; i <= subpbs; i++ {
+			iwork[iprmpt+i] = 0
+			iwork[igivpt+i] = 0
+		}
+		iwork[iqptr] = 0
+	}
+
+	bi := blas64.Implementation()
+
+	// Solve each submatrix eigenproblem at the bottom of the divide and
+	// conquer tree.
+	var curr int
+	for i := 0; i <= spm1; i++ {
+		var submat, matsiz int
+		if i == 0 {
+			submat = 0
+			matsiz = iwork[0]
+		} else {
+			submat = iwork[i-1]
+			matsiz = iwork[i] - iwork[i-1]
+		}
+		if icompq == 2 {
+			ok = impl.Dsteqr(lapack.TridiagEV, matsiz, d[submat:], e[submat:],
+				q[submat*ldq+submat:], ldq, work)
+			if !ok {
+				return false
+			}
+		} else {
+			ok = impl.Dsteqr(lapack.TridiagEV, matsiz, d[submat:], e[submat:],
+				work[iq+iwork[iqptr+curr]:], matsiz, work)
+			if !ok {
+				return false
+			}
+			if icompq == 1 {
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, qsiz, matsiz, matsiz, 1,
+					q[submat:], ldq, work[iq+iwork[iqptr+curr]:], matsiz,
+					0, qstore[submat:], ldqs)
+			}
+			iwork[iqptr+curr+1] = iwork[iqptr+curr] + matsiz*matsiz
+			curr++
+		}
+		k := 0
+		for j := submat; j < iwork[i]; j++ {
+			iwork[indxq+j] = k
+			k++
+		}
+	}
+
+	// Successively merge eigensystems of adjacent submatrices into
+	// eigensystem for the corresponding larger matrix.
+	//
+	// while (subpbs > 1)
+	curlvl := 1
+	for subpbs > 1 {
+		spm2 := subpbs - 2
+		var curprb int
+		for i := 0; i <= spm2; i += 2 {
+			var submat, matsiz, msd2 int
+			if i == 0 {
+				submat = 0
+				matsiz = iwork[1]
+				msd2 = iwork[0]
+			} else {
+				submat = iwork[i-1]
+				matsiz = iwork[i+1] - iwork[i-1]
+				msd2 = matsiz / 2
+				curprb++
+			}
+
+			// Merge lower order eigensystems (of size msd2 and matsiz-msd2)
+			// into an eigensystem of size matsiz. Dlaed1 is used only for
+			// the full eigensystem of a tridiagonal matrix. Dlaed7 handles
+			// the cases in which eigenvalues only or eigenvalues and
+			// eigenvectors of a full symmetric matrix (which was reduced to
+			// tridiagonal form) are desired.
+			if icompq == 2 {
+				ok = impl.Dlaed1(matsiz, d[submat:], q[submat*ldq+submat:], ldq,
+					iwork[indxq+submat:], e[submat+msd2-1], msd2, work, iwork[subpbs:])
+			} else {
+				var qs []float64
+				if icompq == 1 {
+					qs = qstore[submat:]
+				}
+				ok = impl.Dlaed7(icompq, matsiz, qsiz, tlvls, curlvl, curprb,
+					d[submat:], qs, ldqs, iwork[indxq+submat:],
+					e[submat+msd2-1], msd2, work[iq:], iwork[iqptr:],
+					iwork[iprmpt:], iwork[iperm:], iwork[igivpt:],
+					iwork[igivcl:], work[igivnm:], work[iwrem:], iwork[subpbs:])
+			}
+			if !ok {
+				return false
+			}
+			iwork[i/2] = iwork[i+1]
+		}
+		subpbs /= 2
+		curlvl++
+	}
+
+	// Re-merge the eigenvalues/vectors which were deflated at the final
+	// merge step.
+	switch icompq {
+	case 0:
+		for i := 0; i < n; i++ {
+			j := iwork[indxq+i]
+			work[i] = d[j]
+		}
+		bi.Dcopy(n, work, 1, d, 1)
+	case 1:
+		for i := 0; i < n; i++ {
+			j := iwork[indxq+i]
+			work[i] = d[j]
+			bi.Dcopy(qsiz, qstore[j:], ldqs, q[i:], ldq)
+		}
+		bi.Dcopy(n, work, 1, d, 1)
+	case 2:
+		for i := 0; i < n; i++ {
+			j := iwork[indxq+i]
+			work[i] = d[j]
+			bi.Dcopy(n, q[j:], ldq, work[n+i:], n)
+		}
+		bi.Dcopy(n, work, 1, d, 1)
+		impl.Dlacpy(blas.All, n, n, work[n:], n, q, ldq)
+	}
+	return true
+}
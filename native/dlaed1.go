@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas/blas64"
+
+// Dlaed1 computes the updated eigensystem of a diagonal matrix after
+// modification by a rank-one symmetric matrix. It is used when the original
+// matrix is tridiagonal and only the eigenvectors of the tridiagonal matrix
+// are required.
+//
+// On entry, the n×n matrix
+//  T = Q * (D + rho * z * z^T) * Q^T
+// is described by d, q and rho, where D = diag(d) and
+//  Q = [ Q1  0 ]
+//      [  0 Q2 ]
+// is block diagonal with Q1 of size cutpnt×cutpnt. z is formed from the last
+// row of Q1 and the first row of Q2. The eigenvalues in d[:cutpnt] and
+// d[cutpnt:] are each sorted according to the permutation in indxq, so that
+// d[indxq[0]] <= d[indxq[1]] <= ... within each half, where the indices of the
+// second half are relative to cutpnt.
+//
+// On return, d contains the eigenvalues of T, q contains the orthonormal
+// eigenvectors and indxq contains the permutation that sorts d into ascending
+// order.
+//
+// work must have length at least 4*n+n*n and iwork must have length at least
+// 4*n, otherwise Dlaed1 will panic.
+//
+// Dlaed1 returns whether the secular equation solver converged.
+//
+// Dlaed1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed1(n int, d, q []float64, ldq int, indxq []int, rho float64, cutpnt int, work []float64, iwork []int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n > 0 && (cutpnt < min(1, n/2) || n/2 < cutpnt) {
+		panic("lapack: cutpnt out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(indxq) < n {
+		panic(badIndex)
+	}
+	if len(work) < 4*n+n*n {
+		panic(badWork)
+	}
+	if len(iwork) < 4*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// The following values are integer pointers which indicate the portion
+	// of the workspace used by a particular array in Dlaed2 and Dlaed3.
+	iz := 0
+	idlmda := iz + n
+	iw := idlmda + n
+	iq2 := iw + n
+
+	indx := 0
+	indxc := indx + n
+	coltyp := indxc + n
+	indxp := coltyp + n
+
+	// Form the z vector which consists of the last row of Q1 and the first
+	// row of Q2.
+	bi := blas64.Implementation()
+	bi.Dcopy(cutpnt, q[(cutpnt-1)*ldq:], 1, work[iz:], 1)
+	bi.Dcopy(n-cutpnt, q[cutpnt*ldq+cutpnt:], 1, work[iz+cutpnt:], 1)
+
+	// Deflate eigenvalues.
+	k, rho := impl.Dlaed2(n, cutpnt, d, q, ldq, indxq, rho, work[iz:], work[idlmda:],
+		work[iw:], work[iq2:], iwork[indx:], iwork[indxc:], iwork[indxp:], iwork[coltyp:])
+
+	if k == 0 {
+		for i := 0; i < n; i++ {
+			indxq[i] = i
+		}
+		return true
+	}
+
+	// Solve the secular equation.
+	ctot := iwork[coltyp : coltyp+4]
+	is := (ctot[0]+ctot[1])*cutpnt + (ctot[1]+ctot[2])*(n-cutpnt) + iq2
+	ok = impl.Dlaed3(k, n, cutpnt, d, q, ldq, rho, work[idlmda:], work[iq2:],
+		iwork[indxc:], ctot, work[iw:], work[is:])
+	if !ok {
+		return false
+	}
+
+	// Prepare the indxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, indxq)
+	return true
+}
@@ -0,0 +1,286 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaed2 merges the two sets of eigenvalues together into a single sorted set
+// and deflates the size of the problem. It is called by Dlaed1.
+//
+// There are two ways in which deflation can occur: when two or more
+// eigenvalues are close together or if there is a tiny entry in the z vector.
+// For each such occurrence the order of the related secular equation problem
+// is reduced by one.
+//
+// n1 is the location of the last eigenvalue in the leading sub-matrix, and
+// min(1,n) <= n1 <= n/2. On entry, d contains the eigenvalues of the two
+// submatrices to be combined, and q contains the eigenvectors of the two
+// submatrices in the two square blocks with corners at (0,0) and (n1,n1).
+// indxq contains the permutations which separately sort the two
+// sub-problems in d into ascending order, with the indices of the second
+// half relative to n1. z contains the updating vector formed from the last
+// row of the first sub-eigenvector matrix and the first row of the second
+// sub-eigenvector matrix.
+//
+// On return, the first k elements of dlamda and w contain the old roots of
+// the deflated updating problem and the components of the deflation-adjusted
+// updating vector, where k is the returned order of the secular problem.
+// The trailing n-k elements of d and columns of q contain the deflated
+// eigenvalues and eigenvectors. q2 contains copies of the first k
+// eigenvectors packed column-wise for use by Dlaed3. indxc contains the
+// permutation used to arrange the columns of the deflated q matrix into
+// three groups: the first group contains non-zero elements only at and above
+// n1, the second contains non-zero elements only below n1, and the third is
+// dense. On return, the first four elements of coltyp contain the number of
+// columns of each type, including the deflated columns.
+//
+// z, dlamda and w must have length at least n, and q2 must have length at
+// least n*n. indx, indxc, indxp and coltyp must have length at least n.
+//
+// Dlaed2 returns k and the modified value of rho.
+//
+// Dlaed2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed2(n, n1 int, d, q []float64, ldq int, indxq []int, rho float64, z, dlamda, w, q2 []float64, indx, indxc, indxp, coltyp []int) (k int, rhoOut float64) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n > 0 && (n1 < min(1, n) || n/2 < n1) {
+		panic("lapack: n1 out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(indxq) < n || len(indx) < n || len(indxc) < n || len(indxp) < n || len(coltyp) < n {
+		panic(badIndex)
+	}
+	if len(z) < n {
+		panic(badZ)
+	}
+	if len(dlamda) < n || len(w) < n {
+		panic(badSlice)
+	}
+	if len(q2) < n*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, rho
+	}
+
+	bi := blas64.Implementation()
+
+	n2 := n - n1
+	if rho < 0 {
+		bi.Dscal(n2, -1, z[n1:], 1)
+	}
+
+	// Normalize z so that norm(z) = 1. Since z is the concatenation of two
+	// normalized vectors, norm2(z) = sqrt(2).
+	bi.Dscal(n, 1/math.Sqrt2, z, 1)
+
+	// rho = abs(norm(z)^2 * rho).
+	rho = math.Abs(2 * rho)
+
+	// Sort the eigenvalues into increasing order.
+	for i := n1; i < n; i++ {
+		indxq[i] += n1
+	}
+
+	// Re-integrate the deflated parts from the last pass.
+	for i := 0; i < n; i++ {
+		dlamda[i] = d[indxq[i]]
+	}
+	impl.Dlamrg(n1, n2, dlamda, 1, 1, indxc)
+	for i := 0; i < n; i++ {
+		indx[i] = indxq[indxc[i]]
+	}
+
+	// Calculate the allowable deflation tolerance.
+	imax := bi.Idamax(n, z, 1)
+	jmax := bi.Idamax(n, d, 1)
+	eps := dlamchE
+	tol := 8 * eps * math.Max(math.Abs(d[jmax]), math.Abs(z[imax]))
+
+	// If the rank-1 modifier is small enough, no more needs to be done
+	// except to reorganize q so that its columns correspond with the
+	// elements in d.
+	if rho*math.Abs(z[imax]) <= tol {
+		iq2 := 0
+		for j := 0; j < n; j++ {
+			i := indx[j]
+			bi.Dcopy(n, q[i:], ldq, q2[iq2:], 1)
+			dlamda[j] = d[i]
+			iq2 += n
+		}
+		for j := 0; j < n; j++ {
+			bi.Dcopy(n, q2[j*n:], 1, q[j:], ldq)
+		}
+		bi.Dcopy(n, dlamda, 1, d, 1)
+		return 0, rho
+	}
+
+	// If there are multiple eigenvalues then the problem deflates. Here the
+	// number of equal eigenvalues are found. As each equal eigenvalue is
+	// found, an elementary reflector is computed to rotate the
+	// corresponding eigensubspace so that the corresponding components of z
+	// are zero in this new basis.
+	//
+	// The column types are
+	//  1: non-zero in the upper half only,
+	//  2: dense,
+	//  3: non-zero in the lower half only,
+	//  4: deflated.
+	for i := 0; i < n1; i++ {
+		coltyp[i] = 1
+	}
+	for i := n1; i < n; i++ {
+		coltyp[i] = 3
+	}
+
+	k = 0
+	k2 := n
+	pj := -1
+	for j := 0; j < n; j++ {
+		nj := indx[j]
+		if rho*math.Abs(z[nj]) <= tol {
+			// Deflate due to small z component.
+			k2--
+			coltyp[nj] = 4
+			indxp[k2] = nj
+			continue
+		}
+		if pj < 0 {
+			pj = nj
+			continue
+		}
+
+		// Check if eigenvalues are close enough to allow deflation.
+		s := z[pj]
+		c := z[nj]
+		// Find sqrt(a^2+b^2) without overflow or destructive underflow.
+		tau := impl.Dlapy2(c, s)
+		t := d[nj] - d[pj]
+		c /= tau
+		s = -s / tau
+		if math.Abs(t*c*s) <= tol {
+			// Deflation is possible.
+			z[nj] = tau
+			z[pj] = 0
+			if coltyp[nj] != coltyp[pj] {
+				coltyp[nj] = 2
+			}
+			coltyp[pj] = 4
+			bi.Drot(n, q[pj:], ldq, q[nj:], ldq, c, s)
+			t = d[pj]*c*c + d[nj]*s*s
+			d[nj] = d[pj]*s*s + d[nj]*c*c
+			d[pj] = t
+			k2--
+			i := 1
+			for k2+i < n && d[pj] < d[indxp[k2+i]] {
+				indxp[k2+i-1] = indxp[k2+i]
+				indxp[k2+i] = pj
+				i++
+			}
+			indxp[k2+i-1] = pj
+		} else {
+			dlamda[k] = d[pj]
+			w[k] = z[pj]
+			indxp[k] = pj
+			k++
+		}
+		pj = nj
+	}
+	if pj >= 0 {
+		// Record the last eigenvalue.
+		dlamda[k] = d[pj]
+		w[k] = z[pj]
+		indxp[k] = pj
+		k++
+	}
+
+	// Count up the total number of the various types of columns, then form
+	// a permutation which positions the four column types into four uniform
+	// groups (although one or more of these groups may be empty).
+	var ctot [4]int
+	for j := 0; j < n; j++ {
+		ctot[coltyp[j]-1]++
+	}
+
+	// psm is the position in the sub-matrix of types 1 through 4.
+	var psm [4]int
+	psm[1] = ctot[0]
+	psm[2] = psm[1] + ctot[1]
+	psm[3] = psm[2] + ctot[2]
+	k = n - ctot[3]
+
+	// Fill out the indxc array so that the permutation which it induces
+	// will place all type-1 columns first, all type-2 columns next, then
+	// all type-3's, and finally all type-4's.
+	for j := 0; j < n; j++ {
+		js := indxp[j]
+		ct := coltyp[js] - 1
+		indx[psm[ct]] = js
+		indxc[psm[ct]] = j
+		psm[ct]++
+	}
+
+	// Sort the eigenvalues and corresponding eigenvectors into dlamda and
+	// q2 respectively. The eigenvalues/vectors which were not deflated go
+	// into the first k slots of dlamda and q2 respectively, while those
+	// which were deflated go into the last n-k slots.
+	i := 0
+	iq1 := 0
+	iq2 := (ctot[0] + ctot[1]) * n1
+	for j := 0; j < ctot[0]; j++ {
+		js := indx[i]
+		bi.Dcopy(n1, q[js:], ldq, q2[iq1:], 1)
+		z[i] = d[js]
+		i++
+		iq1 += n1
+	}
+	for j := 0; j < ctot[1]; j++ {
+		js := indx[i]
+		bi.Dcopy(n1, q[js:], ldq, q2[iq1:], 1)
+		bi.Dcopy(n2, q[n1*ldq+js:], ldq, q2[iq2:], 1)
+		z[i] = d[js]
+		i++
+		iq1 += n1
+		iq2 += n2
+	}
+	for j := 0; j < ctot[2]; j++ {
+		js := indx[i]
+		bi.Dcopy(n2, q[n1*ldq+js:], ldq, q2[iq2:], 1)
+		z[i] = d[js]
+		i++
+		iq2 += n2
+	}
+	iq1 = iq2
+	for j := 0; j < ctot[3]; j++ {
+		js := indx[i]
+		bi.Dcopy(n, q[js:], ldq, q2[iq2:], 1)
+		iq2 += n
+		z[i] = d[js]
+		i++
+	}
+
+	// The deflated eigenvalues and their corresponding vectors go back into
+	// the last n-k slots of d and q respectively.
+	if k < n {
+		for j := 0; j < ctot[3]; j++ {
+			bi.Dcopy(n, q2[iq1+j*n:], 1, q[k+j:], ldq)
+		}
+		bi.Dcopy(n-k, z[k:], 1, d[k:], 1)
+	}
+
+	// Copy ctot into coltyp for referencing in Dlaed3.
+	copy(coltyp[:4], ctot[:])
+	return k, rho
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaed3 finds the roots of the secular equation, as defined by the values in
+// d, w and rho, between 0 and k-1. It makes the appropriate calls to Dlaed4 and
+// then updates the eigenvectors by multiplying the matrix of eigenvectors of
+// the pair of eigensystems being combined by the matrix of eigenvectors of
+// the k×k system which is solved here. It is called by Dlaed1.
+//
+// n1 is the location of the last eigenvalue in the leading submatrix. On
+// return, d contains the updated eigenvalues in the first k positions and q
+// contains the updated eigenvectors in its first k columns.
+//
+// dlamda contains the old roots of the deflated updating problem and w
+// contains the components of the deflation-adjusted updating vector, as
+// returned by Dlaed2. w is overwritten. q2 contains the eigenvectors packed
+// by Dlaed2, indx contains the permutation used to arrange the columns of the
+// deflated q matrix into the three column groups, and ctot contains the
+// number of columns of each type as returned by Dlaed2 in coltyp.
+//
+// s is workspace of length at least max(k, (ctot[0]+ctot[1])*k, (ctot[1]+ctot[2])*k).
+//
+// Dlaed3 returns whether the secular equation solver converged.
+//
+// Dlaed3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed3(k, n, n1 int, d, q []float64, ldq int, rho float64, dlamda, q2 []float64, indx, ctot []int, w, s []float64) (ok bool) {
+	if k < 0 {
+		panic(kLT0)
+	}
+	if n < k {
+		panic(kGTN)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	checkMatrix(n, n, q, ldq)
+	if len(ctot) < 4 {
+		panic("lapack: ctot has insufficient length")
+	}
+	n12 := ctot[0] + ctot[1]
+	n23 := ctot[1] + ctot[2]
+	if len(s) < max(k, max(n12, n23)*k) {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if k == 0 {
+		return true
+	}
+
+	// Dlamc3 is used in the reference implementation to force dlamda[i] to
+	// be computed in working precision so that differences dlamda[i]-dlamda[j]
+	// have high relative accuracy. Go floating point arithmetic is always
+	// performed in working precision, so this step is not needed.
+
+	bi := blas64.Implementation()
+
+	for j := 0; j < k; j++ {
+		var ok4 bool
+		d[j], ok4 = impl.Dlaed4(k, j, dlamda, w, s, rho)
+		// If the zero finder fails, the computation is terminated.
+		if !ok4 {
+			return false
+		}
+		bi.Dcopy(k, s, 1, q[j:], ldq)
+	}
+
+	switch k {
+	case 1:
+	case 2:
+		for j := 0; j < k; j++ {
+			w[0] = q[j]
+			w[1] = q[ldq+j]
+			q[j] = w[indx[0]]
+			q[ldq+j] = w[indx[1]]
+		}
+	default:
+		// Compute updated w.
+		bi.Dcopy(k, w, 1, s, 1)
+
+		// Initialize w[i] = q[i,i].
+		bi.Dcopy(k, q, ldq+1, w, 1)
+		for j := 0; j < k; j++ {
+			for i := 0; i < j; i++ {
+				w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+			}
+			for i := j + 1; i < k; i++ {
+				w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+			}
+		}
+		for i := 0; i < k; i++ {
+			w[i] = math.Copysign(math.Sqrt(-w[i]), s[i])
+		}
+
+		// Compute eigenvectors of the modified rank-1 modification.
+		for j := 0; j < k; j++ {
+			for i := 0; i < k; i++ {
+				s[i] = w[i] / q[i*ldq+j]
+			}
+			temp := bi.Dnrm2(k, s, 1)
+			for i := 0; i < k; i++ {
+				q[i*ldq+j] = s[indx[i]] / temp
+			}
+		}
+	}
+
+	// Compute the updated eigenvectors. The blocks of q2 are stored packed
+	// column-wise, so they are used in their transposed form.
+	n2 := n - n1
+	impl.Dlacpy(blas.All, n23, k, q[ctot[0]*ldq:], ldq, s, max(1, k))
+	iq2 := n1 * n12
+	if n23 != 0 {
+		bi.Dgemm(blas.Trans, blas.NoTrans, n2, k, n23, 1, q2[iq2:], n2, s, k, 0, q[n1*ldq:], ldq)
+	} else {
+		impl.Dlaset(blas.All, n2, k, 0, 0, q[n1*ldq:], ldq)
+	}
+	impl.Dlacpy(blas.All, n12, k, q, ldq, s, max(1, k))
+	if n12 != 0 {
+		bi.Dgemm(blas.Trans, blas.NoTrans, n1, k, n12, 1, q2, n1, s, k, 0, q, ldq)
+	} else {
+		impl.Dlaset(blas.All, n1, k, 0, 0, q, ldq)
+	}
+	return true
+}
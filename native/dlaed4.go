@@ -0,0 +1,457 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlaed4 computes the i-th updated eigenvalue of a symmetric rank-one
+// modification of a diagonal matrix
+//  diag(d) + rho * z * z^T,
+// where d has length n, the elements of d are distinct and sorted in
+// ascending order, z has unit Euclidean norm and rho > 0. The eigenvalue is
+// found as the (i+1)-th root of the secular equation
+//  1/rho + z^T * inv(diag(d) - λ*I) * z = 0.
+//
+// On return, delta[j] contains d[j] - λ_i for j = 0, ..., n-1, except when
+// n == 1 or n == 2 in which case delta contains the normalized eigenvector
+// corresponding to λ_i. d, z and delta must have length at least n and Dlaed4
+// will panic otherwise.
+//
+// Dlaed4 returns the computed eigenvalue λ_i and whether the iteration
+// converged.
+//
+// Dlaed4 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool) {
+	if n < 1 {
+		panic("lapack: n < 1")
+	}
+	if i < 0 || n <= i {
+		panic(badIndex)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(z) < n {
+		panic(badZ)
+	}
+	if len(delta) < n {
+		panic(badDelta)
+	}
+
+	if n == 1 {
+		// Presumably, i == 0 upon entry.
+		delta[0] = 1
+		return d[0] + rho*z[0]*z[0], true
+	}
+	if n == 2 {
+		return impl.Dlaed5(i, d, z, delta, rho), true
+	}
+
+	const maxit = 30
+	eps := dlamchE
+	rhoinv := 1 / rho
+
+	if i == n-1 {
+		// The case i == n-1.
+		ii := n - 2
+
+		// Calculate the initial guess.
+		midpt := rho / 2
+		// If ||z||_2 is not one, then temp should be set to
+		// rho * ||z||_2^2 / 2.
+		for j := 0; j < n; j++ {
+			delta[j] = (d[j] - d[i]) - midpt
+		}
+		var psi float64
+		for j := 0; j < n-2; j++ {
+			psi += z[j] * z[j] / delta[j]
+		}
+		c := rhoinv + psi
+		w := c + z[ii]*z[ii]/delta[ii] + z[n-1]*z[n-1]/delta[n-1]
+
+		var tau, dltlb, dltub float64
+		if w <= 0 {
+			temp := z[n-2]*z[n-2]/(d[n-1]-d[n-2]+rho) + z[n-1]*z[n-1]/rho
+			if c <= temp {
+				tau = rho
+			} else {
+				del := d[n-1] - d[n-2]
+				a := -c*del + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+				b := z[n-1] * z[n-1] * del
+				if a < 0 {
+					tau = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+				} else {
+					tau = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+				}
+			}
+			// It can be proved that
+			//  d[n-1]+rho/2 <= λ_{n-1} < d[n-1]+tau <= d[n-1]+rho.
+			dltlb = midpt
+			dltub = rho
+		} else {
+			del := d[n-1] - d[n-2]
+			a := -c*del + z[n-2]*z[n-2] + z[n-1]*z[n-1]
+			b := z[n-1] * z[n-1] * del
+			if a < 0 {
+				tau = 2 * b / (math.Sqrt(a*a+4*b*c) - a)
+			} else {
+				tau = (a + math.Sqrt(a*a+4*b*c)) / (2 * c)
+			}
+			// It can be proved that
+			//  d[n-1] < d[n-1]+tau < λ_{n-1} < d[n-1]+rho/2.
+			dltlb = 0
+			dltub = midpt
+		}
+		for j := 0; j < n; j++ {
+			delta[j] = (d[j] - d[i]) - tau
+		}
+
+		// Evaluate psi and the derivative dpsi.
+		var dpsi, erretm float64
+		psi = 0
+		for j := 0; j <= ii; j++ {
+			temp := z[j] / delta[j]
+			psi += z[j] * temp
+			dpsi += temp * temp
+			erretm += psi
+		}
+		erretm = math.Abs(erretm)
+
+		// Evaluate phi and the derivative dphi.
+		temp := z[n-1] / delta[n-1]
+		phi := z[n-1] * temp
+		dphi := temp * temp
+		erretm = 8*(-phi-psi) + erretm - phi + rhoinv + math.Abs(tau)*(dpsi+dphi)
+		w = rhoinv + phi + psi
+
+		for niter := 2; niter <= maxit; niter++ {
+			// Test for convergence.
+			if math.Abs(w) <= eps*erretm {
+				return d[i] + tau, true
+			}
+			if w <= 0 {
+				dltlb = math.Max(dltlb, tau)
+			} else {
+				dltub = math.Min(dltub, tau)
+			}
+
+			// Calculate the new step.
+			c = w - delta[n-2]*dpsi - delta[n-1]*dphi
+			a := (delta[n-2]+delta[n-1])*w - delta[n-2]*delta[n-1]*(dpsi+dphi)
+			b := delta[n-2] * delta[n-1] * w
+			var eta float64
+			if niter == 2 {
+				if c < 0 {
+					c = math.Abs(c)
+				}
+			}
+			switch {
+			case niter == 2 && c == 0:
+				// Update proposed by Li, Ren-Cang.
+				eta = -w / (dpsi + dphi)
+			case a >= 0:
+				eta = (a + math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+			default:
+				eta = 2 * b / (a - math.Sqrt(math.Abs(a*a-4*b*c)))
+			}
+
+			// Note, eta should be positive if w is negative, and eta
+			// should be negative otherwise. However, if for some reason
+			// caused by roundoff, eta*w > 0, we simply use one Newton
+			// step instead. This way will guarantee eta*w < 0.
+			if w*eta > 0 {
+				eta = -w / (dpsi + dphi)
+			}
+			temp := tau + eta
+			if temp > dltub || temp < dltlb {
+				if w < 0 {
+					eta = (dltub - tau) / 2
+				} else {
+					eta = (dltlb - tau) / 2
+				}
+			}
+			for j := 0; j < n; j++ {
+				delta[j] -= eta
+			}
+			tau += eta
+
+			// Evaluate psi and the derivative dpsi.
+			dpsi = 0
+			psi = 0
+			erretm = 0
+			for j := 0; j <= ii; j++ {
+				temp := z[j] / delta[j]
+				psi += z[j] * temp
+				dpsi += temp * temp
+				erretm += psi
+			}
+			erretm = math.Abs(erretm)
+
+			// Evaluate phi and the derivative dphi.
+			temp = z[n-1] / delta[n-1]
+			phi = z[n-1] * temp
+			dphi = temp * temp
+			erretm = 8*(-phi-psi) + erretm - phi + rhoinv + math.Abs(tau)*(dpsi+dphi)
+			w = rhoinv + phi + psi
+		}
+		// Return with ok = false, niter = maxit and not converged.
+		return d[i] + tau, false
+	}
+
+	// The case for i < n-1.
+	ip1 := i + 1
+
+	// Calculate the initial guess.
+	del := d[ip1] - d[i]
+	midpt := del / 2
+	for j := 0; j < n; j++ {
+		delta[j] = (d[j] - d[i]) - midpt
+	}
+	var psi float64
+	for j := 0; j < i; j++ {
+		psi += z[j] * z[j] / delta[j]
+	}
+	var phi float64
+	for j := n - 1; j >= i+2; j-- {
+		phi += z[j] * z[j] / delta[j]
+	}
+	c := rhoinv + psi + phi
+	w := c + z[i]*z[i]/delta[i] + z[ip1]*z[ip1]/delta[ip1]
+
+	var (
+		orgati       bool
+		tau          float64
+		dltlb, dltub float64
+	)
+	if w > 0 {
+		// d[i] < λ_i < (d[i]+d[i+1])/2.
+		// We choose d[i] as origin.
+		orgati = true
+		a := c*del + z[i]*z[i] + z[ip1]*z[ip1]
+		b := z[i] * z[i] * del
+		if a > 0 {
+			tau = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+		} else {
+			tau = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+		}
+		dltlb = 0
+		dltub = midpt
+	} else {
+		// (d[i]+d[i+1])/2 <= λ_i < d[i+1].
+		// We choose d[i+1] as origin.
+		orgati = false
+		a := c*del - z[i]*z[i] - z[ip1]*z[ip1]
+		b := z[ip1] * z[ip1] * del
+		if a < 0 {
+			tau = 2 * b / (a - math.Sqrt(math.Abs(a*a+4*b*c)))
+		} else {
+			tau = -(a + math.Sqrt(math.Abs(a*a+4*b*c))) / (2 * c)
+		}
+		dltlb = -midpt
+		dltub = 0
+	}
+
+	var origin float64
+	if orgati {
+		origin = d[i]
+	} else {
+		origin = d[ip1]
+	}
+	for j := 0; j < n; j++ {
+		delta[j] = (d[j] - origin) - tau
+	}
+	ii := i
+	if !orgati {
+		ii = i + 1
+	}
+	iim1 := ii - 1
+	iip1 := ii + 1
+
+	// Evaluate psi and the derivative dpsi.
+	var dpsi, erretm float64
+	psi = 0
+	for j := 0; j <= iim1; j++ {
+		temp := z[j] / delta[j]
+		psi += z[j] * temp
+		dpsi += temp * temp
+		erretm += psi
+	}
+	erretm = math.Abs(erretm)
+
+	// Evaluate phi and the derivative dphi.
+	var dphi float64
+	phi = 0
+	for j := n - 1; j >= iip1; j-- {
+		temp := z[j] / delta[j]
+		phi += z[j] * temp
+		dphi += temp * temp
+		erretm += phi
+	}
+
+	w = rhoinv + phi + psi
+
+	// w is the value of the secular function with its ii-th element
+	// removed.
+	var swtch3 bool
+	if orgati {
+		swtch3 = w < 0
+	} else {
+		swtch3 = w > 0
+	}
+	if ii == 0 || ii == n-1 {
+		swtch3 = false
+	}
+
+	temp := z[ii] / delta[ii]
+	dw := dpsi + dphi + temp*temp
+	temp *= z[ii]
+	w += temp
+	erretm = 8*(phi-psi) + erretm + 2*rhoinv + 3*math.Abs(temp) + math.Abs(tau)*dw
+
+	var swtch bool
+	var zz [3]float64
+	for niter := 2; niter <= maxit; niter++ {
+		// Test for convergence.
+		if math.Abs(w) <= eps*erretm {
+			return origin + tau, true
+		}
+		if w <= 0 {
+			dltlb = math.Max(dltlb, tau)
+		} else {
+			dltub = math.Min(dltub, tau)
+		}
+
+		// Calculate the new step.
+		var eta float64
+		if !swtch3 {
+			if !swtch {
+				if orgati {
+					c = w - delta[ip1]*dw - (d[i]-d[ip1])*(z[i]/delta[i])*(z[i]/delta[i])
+				} else {
+					c = w - delta[i]*dw - (d[ip1]-d[i])*(z[ip1]/delta[ip1])*(z[ip1]/delta[ip1])
+				}
+			} else {
+				temp := z[ii] / delta[ii]
+				if orgati {
+					dpsi += temp * temp
+				} else {
+					dphi += temp * temp
+				}
+				c = w - delta[i]*dpsi - delta[ip1]*dphi
+			}
+			a := (delta[i]+delta[ip1])*w - delta[i]*delta[ip1]*dw
+			b := delta[i] * delta[ip1] * w
+			switch {
+			case c == 0:
+				if a == 0 {
+					if !swtch {
+						if orgati {
+							a = z[i]*z[i] + delta[ip1]*delta[ip1]*(dpsi+dphi)
+						} else {
+							a = z[ip1]*z[ip1] + delta[i]*delta[i]*(dpsi+dphi)
+						}
+					} else {
+						a = delta[i]*delta[i]*dpsi + delta[ip1]*delta[ip1]*dphi
+					}
+				}
+				eta = b / a
+			case a <= 0:
+				eta = (a - math.Sqrt(math.Abs(a*a-4*b*c))) / (2 * c)
+			default:
+				eta = 2 * b / (a + math.Sqrt(math.Abs(a*a-4*b*c)))
+			}
+		} else {
+			// Interpolation using three most relevant poles.
+			temp := rhoinv + psi + phi
+			if swtch {
+				c = temp - delta[iim1]*dpsi - delta[iip1]*dphi
+				zz[0] = delta[iim1] * delta[iim1] * dpsi
+				zz[2] = delta[iip1] * delta[iip1] * dphi
+			} else {
+				if orgati {
+					temp1 := z[iim1] / delta[iim1]
+					temp1 *= temp1
+					c = temp - delta[iip1]*(dpsi+dphi) - (d[iim1]-d[iip1])*temp1
+					zz[0] = z[iim1] * z[iim1]
+					zz[2] = delta[iip1] * delta[iip1] * ((dpsi - temp1) + dphi)
+				} else {
+					temp1 := z[iip1] / delta[iip1]
+					temp1 *= temp1
+					c = temp - delta[iim1]*(dpsi+dphi) - (d[iip1]-d[iim1])*temp1
+					zz[0] = delta[iim1] * delta[iim1] * (dpsi + (dphi - temp1))
+					zz[2] = z[iip1] * z[iip1]
+				}
+			}
+			zz[1] = z[ii] * z[ii]
+			var ok6 bool
+			eta, ok6 = impl.Dlaed6(niter, orgati, c, delta[iim1:], zz[:], w)
+			if !ok6 {
+				return origin + tau, false
+			}
+		}
+
+		// Note, eta should be positive if w is negative, and eta should be
+		// negative otherwise. However, if for some reason caused by
+		// roundoff, eta*w > 0, we simply use one Newton step instead. This
+		// way will guarantee eta*w < 0.
+		if w*eta >= 0 {
+			eta = -w / dw
+		}
+		temp := tau + eta
+		if temp > dltub || temp < dltlb {
+			if w < 0 {
+				eta = (dltub - tau) / 2
+			} else {
+				eta = (dltlb - tau) / 2
+			}
+		}
+		prew := w
+
+		for j := 0; j < n; j++ {
+			delta[j] -= eta
+		}
+
+		// Evaluate psi and the derivative dpsi.
+		dpsi = 0
+		psi = 0
+		erretm = 0
+		for j := 0; j <= iim1; j++ {
+			temp := z[j] / delta[j]
+			psi += z[j] * temp
+			dpsi += temp * temp
+			erretm += psi
+		}
+		erretm = math.Abs(erretm)
+
+		// Evaluate phi and the derivative dphi.
+		dphi = 0
+		phi = 0
+		for j := n - 1; j >= iip1; j-- {
+			temp := z[j] / delta[j]
+			phi += z[j] * temp
+			dphi += temp * temp
+			erretm += phi
+		}
+
+		temp = z[ii] / delta[ii]
+		dw = dpsi + dphi + temp*temp
+		temp *= z[ii]
+		w = rhoinv + phi + psi + temp
+		erretm = 8*(phi-psi) + erretm + 2*rhoinv + 3*math.Abs(temp) + math.Abs(tau+eta)*dw
+
+		if niter == 2 {
+			if orgati {
+				swtch = -w > math.Abs(prew)/10
+			} else {
+				swtch = w > math.Abs(prew)/10
+			}
+		} else if w*prew > 0 && math.Abs(w) > math.Abs(prew)/10 {
+			swtch = !swtch
+		}
+		tau += eta
+	}
+	// Return with ok = false, niter = maxit and not converged.
+	return origin + tau, false
+}
@@ -0,0 +1,74 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlaed5 computes the i-th eigenvalue of a symmetric rank-one modification of
+// a 2×2 diagonal matrix
+//  diag(d) + rho * z * z^T.
+// The diagonal elements in d are assumed to satisfy d[0] < d[1] and rho is
+// assumed to be positive. i must be 0 or 1.
+//
+// On return, delta contains the normalized eigenvector corresponding to the
+// computed eigenvalue dlam. d, z and delta must have length at least 2.
+//
+// Dlaed5 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed5(i int, d, z, delta []float64, rho float64) (dlam float64) {
+	if i != 0 && i != 1 {
+		panic(badIndex)
+	}
+	if len(d) < 2 {
+		panic(badD)
+	}
+	if len(z) < 2 {
+		panic(badZ)
+	}
+	if len(delta) < 2 {
+		panic(badDelta)
+	}
+
+	del := d[1] - d[0]
+	if i == 0 {
+		w := 1 + 2*rho*(z[1]*z[1]-z[0]*z[0])/del
+		if w > 0 {
+			b := del + rho*(z[0]*z[0]+z[1]*z[1])
+			c := rho * z[0] * z[0] * del
+			// b > 0, always.
+			tau := 2 * c / (b + math.Sqrt(math.Abs(b*b-4*c)))
+			dlam = d[0] + tau
+			delta[0] = -z[0] / tau
+			delta[1] = z[1] / (del - tau)
+		} else {
+			b := -del + rho*(z[0]*z[0]+z[1]*z[1])
+			c := rho * z[1] * z[1] * del
+			var tau float64
+			if b > 0 {
+				tau = -2 * c / (b + math.Sqrt(b*b+4*c))
+			} else {
+				tau = (b - math.Sqrt(b*b+4*c)) / 2
+			}
+			dlam = d[1] + tau
+			delta[0] = -z[0] / (del + tau)
+			delta[1] = -z[1] / tau
+		}
+	} else {
+		b := -del + rho*(z[0]*z[0]+z[1]*z[1])
+		c := rho * z[1] * z[1] * del
+		var tau float64
+		if b > 0 {
+			tau = (b + math.Sqrt(b*b+4*c)) / 2
+		} else {
+			tau = 2 * c / (-b + math.Sqrt(b*b+4*c))
+		}
+		dlam = d[1] + tau
+		delta[0] = -z[0] / (del + tau)
+		delta[1] = -z[1] / tau
+	}
+	temp := math.Hypot(delta[0], delta[1])
+	delta[0] /= temp
+	delta[1] /= temp
+	return dlam
+}
@@ -0,0 +1,142 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaed7 computes the updated eigensystem of a diagonal matrix after
+// modification by a rank-one symmetric matrix. It is used when the original
+// matrix is dense and has been reduced to tridiagonal form, or when only the
+// eigenvalues of a tridiagonal matrix are required. It is called by Dlaed0.
+//
+// On entry, the matrix
+//  T = Q * (D + rho * z * z^T) * Q^T
+// is described by d, q and rho as in Dlaed1, where cutpnt is the location of
+// the last eigenvalue in the leading sub-matrix and z is computed from the
+// data stored by the previous merges.
+//
+// icompq specifies whether eigenvectors are computed. If icompq == 0 only the
+// eigenvalues are computed. If icompq == 1 the eigenvectors of the original
+// dense matrix are also computed, and q is a qsiz×n matrix containing on
+// entry the eigenvectors of the partially solved system and on return the
+// updated eigenvectors.
+//
+// tlvls is the total number of merging levels in the overall divide and
+// conquer tree, curlvl is the current level and curpbm is the current
+// problem in the current level. qstore, qptr, prmptr, perm, givptr, givcol
+// and givnum hold the eigenvector blocks, permutations and Givens rotations
+// recorded by the previous merges and are updated for the current problem.
+//
+// On return, d contains the updated eigenvalues and indxq contains the
+// permutation which sorts them into ascending order.
+//
+// work must have length at least 3*n+2*qsiz*n and iwork must have length at
+// least 4*n, otherwise Dlaed7 will panic. If icompq == 0, qsiz is treated as
+// n for the purpose of the workspace requirement.
+//
+// Dlaed7 returns whether the secular equation solver converged.
+//
+// Dlaed7 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed7(icompq, n, qsiz, tlvls, curlvl, curpbm int, d, q []float64, ldq int, indxq []int, rho float64, cutpnt int, qstore []float64, qptr, prmptr, perm, givptr, givcol []int, givnum, work []float64, iwork []int) (ok bool) {
+	switch {
+	case icompq != 0 && icompq != 1:
+		panic("lapack: bad icompq")
+	case n < 0:
+		panic(nLT0)
+	case icompq == 1 && qsiz < n:
+		panic("lapack: qsiz < n")
+	case n > 0 && (cutpnt < min(1, n) || n < cutpnt):
+		panic("lapack: cutpnt out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(indxq) < n {
+		panic(badIndex)
+	}
+	ldq2 := n
+	lq := n
+	if icompq == 1 {
+		checkMatrix(qsiz, n, q, ldq)
+		lq = qsiz
+	}
+	if len(work) < 3*n+2*lq*n {
+		panic(badWork)
+	}
+	if len(iwork) < 4*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// The following values are for bookkeeping purposes only. They are
+	// integer pointers which indicate the portion of the workspace used by
+	// a particular array in Dlaed8 and Dlaed9.
+	iz := 0
+	idlmda := iz + n
+	iw := idlmda + n
+	iq2 := iw + n
+	is := iq2 + lq*ldq2
+
+	indx := 0
+	indxp := indx + 3*n
+
+	// Form the z vector which consists of the last row of Q_1 and the first
+	// row of Q_2.
+	ptr := 1 << uint(tlvls)
+	for i := 1; i < curlvl; i++ {
+		ptr += 1 << uint(tlvls-i)
+	}
+	curr := ptr + curpbm
+	impl.Dlaeda(n, tlvls, curlvl, curpbm, prmptr, perm, givptr, givcol, givnum,
+		qstore, qptr, work[iz:], work[iz+n:])
+
+	// When solving the final problem, we no longer need the stored data, so
+	// we will overwrite the data from this level onto the previously used
+	// storage space.
+	if curlvl == tlvls {
+		qptr[curr] = 0
+		prmptr[curr] = 0
+		givptr[curr] = 0
+	}
+
+	// Sort and deflate eigenvalues.
+	k, ngiv, rho := impl.Dlaed8(icompq, n, qsiz, d, q, ldq, indxq, rho, cutpnt,
+		work[iz:], work[idlmda:], work[iq2:], ldq2, work[iw:], perm[prmptr[curr]:],
+		givcol[2*givptr[curr]:], givnum[2*givptr[curr]:], iwork[indxp:], iwork[indx:])
+	prmptr[curr+1] = prmptr[curr] + n
+	givptr[curr+1] = givptr[curr] + ngiv
+
+	if k == 0 {
+		qptr[curr+1] = qptr[curr]
+		for i := 0; i < n; i++ {
+			indxq[i] = i
+		}
+		return true
+	}
+
+	// Solve the secular equation.
+	ok = impl.Dlaed9(k, 0, k-1, n, d, work[is:], k, rho, work[idlmda:], work[iw:],
+		qstore[qptr[curr]:], k)
+	if !ok {
+		return false
+	}
+	if icompq == 1 {
+		bi := blas64.Implementation()
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, qsiz, k, k, 1, work[iq2:], ldq2,
+			qstore[qptr[curr]:], k, 0, q, ldq)
+	}
+	qptr[curr+1] = qptr[curr] + k*k
+
+	// Prepare the indxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, indxq)
+	return true
+}
@@ -0,0 +1,222 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaed8 merges the two sets of eigenvalues together into a single sorted set
+// and deflates the size of the problem. It is called by Dlaed7 and is the
+// counterpart of Dlaed2 used when the eigenvectors of a full or band matrix
+// are being updated.
+//
+// There are two ways in which deflation can occur: when two or more
+// eigenvalues are close together or if there is a tiny element in the z
+// vector. For each such occurrence the order of the related secular equation
+// problem is reduced by one.
+//
+// icompq specifies whether eigenvectors are updated. If icompq == 0 only the
+// eigenvalues are computed and q is not referenced. If icompq == 1 the qsiz×n
+// matrix q contains on entry the eigenvectors of the partially solved system
+// and on return the trailing n-k columns contain the deflated eigenvectors.
+// q2 is then a qsiz×n matrix with stride ldq2 that receives a copy of the
+// first k eigenvectors for use by Dlaed7.
+//
+// cutpnt is the location of the last eigenvalue in the leading sub-matrix.
+// indxq contains the permutation which separately sorts the two sub-problems
+// in d into ascending order, with the indices of the second half relative to
+// cutpnt. z contains the updating vector and is destroyed.
+//
+// On return, the first k elements of dlamda and w contain the old roots of
+// the deflated updating problem and the components of the deflation-adjusted
+// updating vector. perm contains the permutations, relative to the input
+// order, applied to each eigenblock. The Givens rotations used in deflation
+// are stored in givcol and givnum: the i-th rotation acts on columns
+// givcol[2*i] and givcol[2*i+1] with cosine givnum[2*i] and sine
+// givnum[2*i+1].
+//
+// d, z, dlamda, w, perm, indxp and indx must have length at least n, and
+// givcol and givnum must have length at least 2*n.
+//
+// Dlaed8 returns the order k of the secular problem, the number of Givens
+// rotations applied and the modified value of rho.
+//
+// Dlaed8 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed8(icompq, n, qsiz int, d, q []float64, ldq int, indxq []int, rho float64, cutpnt int, z, dlamda, q2 []float64, ldq2 int, w []float64, perm []int, givcol []int, givnum []float64, indxp, indx []int) (k, givptr int, rhoOut float64) {
+	switch {
+	case icompq != 0 && icompq != 1:
+		panic("lapack: bad icompq")
+	case n < 0:
+		panic(nLT0)
+	case icompq == 1 && qsiz < n:
+		panic("lapack: qsiz < n")
+	case cutpnt < min(1, n) || n < cutpnt:
+		panic("lapack: cutpnt out of range")
+	}
+	if len(d) < n || len(z) < n || len(dlamda) < n || len(w) < n {
+		panic(badSlice)
+	}
+	if len(indxq) < n || len(perm) < n || len(indxp) < n || len(indx) < n {
+		panic(badIndex)
+	}
+	if len(givcol) < 2*n || len(givnum) < 2*n {
+		panic(badSlice)
+	}
+	if icompq == 1 {
+		checkMatrix(qsiz, n, q, ldq)
+		checkMatrix(qsiz, n, q2, ldq2)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, 0, rho
+	}
+
+	bi := blas64.Implementation()
+
+	n1 := cutpnt
+	n2 := n - n1
+	if rho < 0 {
+		bi.Dscal(n2, -1, z[n1:], 1)
+	}
+
+	// Normalize z so that norm(z) = 1.
+	for j := 0; j < n; j++ {
+		indx[j] = j
+	}
+	bi.Dscal(n, 1/math.Sqrt2, z, 1)
+	rho = math.Abs(2 * rho)
+
+	// Sort the eigenvalues into increasing order.
+	for i := cutpnt; i < n; i++ {
+		indxq[i] += cutpnt
+	}
+	for i := 0; i < n; i++ {
+		dlamda[i] = d[indxq[i]]
+		w[i] = z[indxq[i]]
+	}
+	impl.Dlamrg(n1, n2, dlamda, 1, 1, indx)
+	for i := 0; i < n; i++ {
+		d[i] = dlamda[indx[i]]
+		z[i] = w[indx[i]]
+	}
+
+	// Calculate the allowable deflation tolerance.
+	imax := bi.Idamax(n, z, 1)
+	jmax := bi.Idamax(n, d, 1)
+	eps := dlamchE
+	tol := 8 * eps * math.Abs(d[jmax])
+
+	// If the rank-1 modifier is small enough, no more needs to be done
+	// except to reorganize q so that its columns correspond with the
+	// elements in d.
+	if rho*math.Abs(z[imax]) <= tol {
+		for j := 0; j < n; j++ {
+			perm[j] = indxq[indx[j]]
+		}
+		if icompq == 1 {
+			for j := 0; j < n; j++ {
+				bi.Dcopy(qsiz, q[perm[j]:], ldq, q2[j:], ldq2)
+			}
+			impl.Dlacpy(blas.All, qsiz, n, q2, ldq2, q, ldq)
+		}
+		return 0, 0, rho
+	}
+
+	// If there are multiple eigenvalues then the problem deflates. Here the
+	// number of equal eigenvalues are found. As each equal eigenvalue is
+	// found, an elementary reflector is computed to rotate the
+	// corresponding eigensubspace so that the corresponding components of z
+	// are zero in this new basis.
+	k2 := n
+	jlam := -1
+	for j := 0; j < n; j++ {
+		if rho*math.Abs(z[j]) <= tol {
+			// Deflate due to small z component.
+			k2--
+			indxp[k2] = j
+			continue
+		}
+		if jlam < 0 {
+			jlam = j
+			continue
+		}
+
+		// Check if eigenvalues are close enough to allow deflation.
+		s := z[jlam]
+		c := z[j]
+		// Find sqrt(a^2+b^2) without overflow or destructive underflow.
+		tau := impl.Dlapy2(c, s)
+		t := d[j] - d[jlam]
+		c /= tau
+		s = -s / tau
+		if math.Abs(t*c*s) <= tol {
+			// Deflation is possible.
+			z[j] = tau
+			z[jlam] = 0
+
+			// Record the appropriate Givens rotation.
+			givcol[2*givptr] = indxq[indx[jlam]]
+			givcol[2*givptr+1] = indxq[indx[j]]
+			givnum[2*givptr] = c
+			givnum[2*givptr+1] = s
+			givptr++
+			if icompq == 1 {
+				bi.Drot(qsiz, q[indxq[indx[jlam]]:], ldq, q[indxq[indx[j]]:], ldq, c, s)
+			}
+			t = d[jlam]*c*c + d[j]*s*s
+			d[j] = d[jlam]*s*s + d[j]*c*c
+			d[jlam] = t
+			k2--
+			i := 1
+			for k2+i < n && d[jlam] < d[indxp[k2+i]] {
+				indxp[k2+i-1] = indxp[k2+i]
+				indxp[k2+i] = jlam
+				i++
+			}
+			indxp[k2+i-1] = jlam
+		} else {
+			w[k] = z[jlam]
+			dlamda[k] = d[jlam]
+			indxp[k] = jlam
+			k++
+		}
+		jlam = j
+	}
+	if jlam >= 0 {
+		// Record the last eigenvalue.
+		w[k] = z[jlam]
+		dlamda[k] = d[jlam]
+		indxp[k] = jlam
+		k++
+	}
+
+	// Sort the eigenvalues and corresponding eigenvectors into dlamda and
+	// q2 respectively. The eigenvalues/vectors which were not deflated go
+	// into the first k slots of dlamda and q2 respectively, while those
+	// which were deflated go into the last n-k slots.
+	for j := 0; j < n; j++ {
+		jp := indxp[j]
+		dlamda[j] = d[jp]
+		perm[j] = indxq[indx[jp]]
+		if icompq == 1 {
+			bi.Dcopy(qsiz, q[perm[j]:], ldq, q2[j:], ldq2)
+		}
+	}
+
+	// The deflated eigenvalues and their corresponding vectors go back into
+	// the last n-k slots of d and q respectively.
+	if k < n {
+		bi.Dcopy(n-k, dlamda[k:], 1, d[k:], 1)
+		if icompq == 1 {
+			impl.Dlacpy(blas.All, qsiz, n-k, q2[k:], ldq2, q[k:], ldq)
+		}
+	}
+	return k, givptr, rho
+}
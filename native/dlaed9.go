@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaed9 finds the roots of the secular equation, as defined by the values in
+// d, w and rho, for the eigenvalues with indices kstart through kstop. It makes
+// the appropriate calls to Dlaed4 and then stores the new matrix of
+// eigenvectors for use in calculating the next level of z vectors. It is
+// called by Dlaed7.
+//
+// On return, d[kstart:kstop+1] contains the updated eigenvalues. q is k×k
+// workspace, and s contains the k×k matrix of eigenvectors of the secular
+// problem. dlamda contains the old roots of the deflated updating problem and
+// w contains the components of the deflation-adjusted updating vector, as
+// returned by Dlaed8. w is overwritten.
+//
+// Dlaed9 returns whether the secular equation solver converged.
+//
+// Dlaed9 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaed9(k, kstart, kstop, n int, d, q []float64, ldq int, rho float64, dlamda, w, s []float64, lds int) (ok bool) {
+	if k < 0 {
+		panic(kLT0)
+	}
+	if kstart < 0 || max(0, k-1) < kstart {
+		panic("lapack: kstart out of range")
+	}
+	if kstop < kstart || max(0, k-1) < kstop {
+		panic("lapack: kstop out of range")
+	}
+	if n < k {
+		panic(kGTN)
+	}
+	if k == 0 {
+		return true
+	}
+	if len(d) < k {
+		panic(badD)
+	}
+	checkMatrix(k, k, q, ldq)
+	checkMatrix(k, k, s, lds)
+	if len(dlamda) < k || len(w) < k {
+		panic(badSlice)
+	}
+
+	// Dlamc3 is used in the reference implementation to force dlamda[i] to
+	// be computed in working precision. This is not needed in Go.
+
+	bi := blas64.Implementation()
+
+	for j := kstart; j <= kstop; j++ {
+		var ok4 bool
+		d[j], ok4 = impl.Dlaed4(k, j, dlamda, w, s, rho)
+		// If the zero finder fails, the computation is terminated.
+		if !ok4 {
+			return false
+		}
+		bi.Dcopy(k, s, 1, q[j:], ldq)
+	}
+
+	if k == 1 || k == 2 {
+		impl.Dlacpy(blas.All, k, k, q, ldq, s, lds)
+		return true
+	}
+
+	// Compute updated w.
+	bi.Dcopy(k, w, 1, s, lds)
+
+	// Initialize w[i] = q[i,i].
+	bi.Dcopy(k, q, ldq+1, w, 1)
+	for j := 0; j < k; j++ {
+		for i := 0; i < j; i++ {
+			w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+		}
+		for i := j + 1; i < k; i++ {
+			w[i] *= q[i*ldq+j] / (dlamda[i] - dlamda[j])
+		}
+	}
+	for i := 0; i < k; i++ {
+		w[i] = math.Copysign(math.Sqrt(-w[i]), s[i*lds])
+	}
+
+	// Compute eigenvectors of the modified rank-1 modification.
+	for j := 0; j < k; j++ {
+		for i := 0; i < k; i++ {
+			q[i*ldq+j] = w[i] / q[i*ldq+j]
+		}
+		temp := bi.Dnrm2(k, q[j:], ldq)
+		for i := 0; i < k; i++ {
+			s[i*lds+j] = q[i*ldq+j] / temp
+		}
+	}
+	return true
+}
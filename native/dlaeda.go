@@ -0,0 +1,101 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaeda computes the z vector corresponding to the merge step in the
+// curlvl-th level of the merge process with tlvls steps for the curpbm-th
+// problem. It is called by Dlaed7.
+//
+// prmptr, perm, givptr, givcol, givnum, q and qptr describe the permutations,
+// Givens rotations and eigenvector blocks stored by Dlaed0 and Dlaed7 during
+// earlier merges. The stored eigenvector blocks are square, row-major and
+// densely packed in q, with the i-th block starting at q[qptr[i]].
+//
+// z must have length at least n and contains the updating vector on return.
+// ztemp is workspace of length at least n.
+//
+// Dlaeda is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaeda(n, tlvls, curlvl, curpbm int, prmptr, perm, givptr, givcol []int, givnum, q []float64, qptr []int, z, ztemp []float64) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(z) < n || len(ztemp) < n {
+		panic(badZ)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+
+	// Determine location of first number in second half.
+	mid := n / 2
+
+	// Determine location of lowest level subproblem in the full storage
+	// scheme.
+	curr := curpbm*(1<<uint(curlvl)) + (1 << uint(curlvl-1)) - 1
+
+	// Determine size of these matrices. We add 0.5 to the value of the
+	// square root in case the machine underestimates one of these square
+	// roots.
+	bsiz1 := int(0.5 + math.Sqrt(float64(qptr[curr+1]-qptr[curr])))
+	bsiz2 := int(0.5 + math.Sqrt(float64(qptr[curr+2]-qptr[curr+1])))
+	for k := 0; k < mid-bsiz1; k++ {
+		z[k] = 0
+	}
+	bi.Dcopy(bsiz1, q[qptr[curr]+(bsiz1-1)*bsiz1:], 1, z[mid-bsiz1:], 1)
+	bi.Dcopy(bsiz2, q[qptr[curr+1]:], 1, z[mid:], 1)
+	for k := mid + bsiz2; k < n; k++ {
+		z[k] = 0
+	}
+
+	// Loop through remaining levels 1 -> curlvl applying the Givens
+	// rotations and permutation and then multiplying the center matrices
+	// against the current z.
+	ptr := 1 << uint(tlvls)
+	for k := 1; k < curlvl; k++ {
+		curr = ptr + curpbm*(1<<uint(curlvl-k)) + (1 << uint(curlvl-k-1)) - 1
+		psiz1 := prmptr[curr+1] - prmptr[curr]
+		psiz2 := prmptr[curr+2] - prmptr[curr+1]
+		zptr1 := mid - psiz1
+
+		// Apply Givens at curr and curr+1.
+		for i := givptr[curr]; i < givptr[curr+1]; i++ {
+			bi.Drot(1, z[zptr1+givcol[2*i]:], 1, z[zptr1+givcol[2*i+1]:], 1, givnum[2*i], givnum[2*i+1])
+		}
+		for i := givptr[curr+1]; i < givptr[curr+2]; i++ {
+			bi.Drot(1, z[mid+givcol[2*i]:], 1, z[mid+givcol[2*i+1]:], 1, givnum[2*i], givnum[2*i+1])
+		}
+		for i := 0; i < psiz1; i++ {
+			ztemp[i] = z[zptr1+perm[prmptr[curr]+i]]
+		}
+		for i := 0; i < psiz2; i++ {
+			ztemp[psiz1+i] = z[mid+perm[prmptr[curr+1]+i]]
+		}
+
+		// Multiply blocks at curr and curr+1.
+		bsiz1 = int(0.5 + math.Sqrt(float64(qptr[curr+1]-qptr[curr])))
+		bsiz2 = int(0.5 + math.Sqrt(float64(qptr[curr+2]-qptr[curr+1])))
+		if bsiz1 > 0 {
+			bi.Dgemv(blas.Trans, bsiz1, bsiz1, 1, q[qptr[curr]:], bsiz1, ztemp, 1, 0, z[zptr1:], 1)
+		}
+		bi.Dcopy(psiz1-bsiz1, ztemp[bsiz1:], 1, z[zptr1+bsiz1:], 1)
+		if bsiz2 > 0 {
+			bi.Dgemv(blas.Trans, bsiz2, bsiz2, 1, q[qptr[curr+1]:], bsiz2, ztemp[psiz1:], 1, 0, z[mid:], 1)
+		}
+		bi.Dcopy(psiz2-bsiz2, ztemp[psiz1+bsiz2:], 1, z[mid+bsiz2:], 1)
+
+		ptr += 1 << uint(tlvls-k)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlag2s converts the m×n float64 matrix A to the float32 matrix SA.
+//
+// Dlag2s returns false if an element of A is larger in absolute value than
+// the largest finite float32 number, in which case the conversion is aborted
+// and SA may be only partially filled.
+//
+// Dlag2s is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlag2s(m, n int, a []float64, lda int, sa []float32, ldsa int) (ok bool) {
+	checkMatrix(m, n, a, lda)
+	checkMatrix32(m, n, sa, ldsa)
+
+	const rmax = math.MaxFloat32
+	for i := 0; i < m; i++ {
+		for j, v := range a[i*lda : i*lda+n] {
+			if v < -rmax || rmax < v {
+				return false
+			}
+			sa[i*ldsa+j] = float32(v)
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlagerpvgrw computes the reciprocal pivot growth factor
+//  min_j ( max_i |A[i,j]| / max_i |U[i,j]| )
+// over the first ncols columns of the n×n matrix A, where U is the upper
+// triangular factor of the LU factorization of A stored in af as computed by
+// Dgetrf. Columns of U with all elements equal to zero are skipped.
+//
+// A small reciprocal pivot growth factor indicates that the LU factorization
+// may be unstable, and that the computed solution and error bounds of a
+// linear system solved using it may be unreliable. If the factorization
+// breaks down because U is exactly singular, ncols is the number of leading
+// columns of U that have been successfully factored.
+//
+// Dlagerpvgrw corresponds to the LAPACK routine DLA_GERPVGRW.
+//
+// Dlagerpvgrw is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlagerpvgrw(n, ncols int, a []float64, lda int, af []float64, ldaf int) float64 {
+	if ncols < 0 || n < ncols {
+		panic("lapack: bad ncols")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+
+	rpvgrw := 1.0
+	for j := 0; j < ncols; j++ {
+		var amax, umax float64
+		for i := 0; i < n; i++ {
+			amax = math.Max(amax, math.Abs(a[i*lda+j]))
+		}
+		for i := 0; i <= j; i++ {
+			umax = math.Max(umax, math.Abs(af[i*ldaf+j]))
+		}
+		if umax != 0 {
+			rpvgrw = math.Min(rpvgrw, amax/umax)
+		}
+	}
+	return rpvgrw
+}
@@ -0,0 +1,110 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlagtf factorizes the matrix (T - lambda*I), where T is an n×n tridiagonal
+// matrix and lambda is a scalar, as
+//  T - lambda*I = P*L*U,
+// where P is a permutation matrix, L is a unit lower tridiagonal matrix with
+// at most one non-zero sub-diagonal element per column and U is an upper
+// triangular matrix with at most two non-zero super-diagonal elements per
+// column.
+//
+// On entry, a must contain the diagonal elements of T, b the n-1
+// super-diagonal elements and c the n-1 sub-diagonal elements. On return, a
+// contains the n diagonal elements of U, b the n-1 elements of the first
+// super-diagonal of U, c the n-1 sub-diagonal elements of L and d the n-2
+// elements of the second super-diagonal of U.
+//
+// tol is a relative tolerance used to indicate whether or not the matrix
+// (T - lambda*I) is nearly singular. tol should normally be chosen as
+// approximately the largest relative error in the elements of T. If tol is
+// less than the machine precision, the machine precision is used instead.
+//
+// On return, in[k] for k < n-1 is 0 if no interchange occurred at the k-th
+// step of the elimination and 1 otherwise. in[n-1] holds the smallest index
+// k such that the k-th pivot, relative to its row of the matrix, is not
+// larger than tol in absolute value, or -1 if there is no such pivot.
+//
+// Dlagtf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlagtf(n int, a []float64, lambda float64, b, c []float64, tol float64, d []float64, in []int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return
+	}
+	if len(a) < n {
+		panic(badSlice)
+	}
+	if len(b) < n-1 || len(c) < n-1 || len(d) < n-2 {
+		panic(badSlice)
+	}
+	if len(in) < n {
+		panic(badIndex)
+	}
+
+	a[0] -= lambda
+	in[n-1] = -1
+	if n == 1 {
+		if a[0] == 0 {
+			in[0] = 0
+		}
+		return
+	}
+
+	tl := math.Max(tol, dlamchE)
+	scale1 := math.Abs(a[0]) + math.Abs(b[0])
+	for k := 0; k < n-1; k++ {
+		a[k+1] -= lambda
+		scale2 := math.Abs(c[k]) + math.Abs(a[k+1])
+		if k < n-2 {
+			scale2 += math.Abs(b[k+1])
+		}
+		var piv1 float64
+		if a[k] != 0 {
+			piv1 = math.Abs(a[k]) / scale1
+		}
+		var piv2 float64
+		if c[k] == 0 {
+			in[k] = 0
+			scale1 = scale2
+			if k < n-2 {
+				d[k] = 0
+			}
+		} else {
+			piv2 = math.Abs(c[k]) / scale2
+			if piv2 <= piv1 {
+				in[k] = 0
+				scale1 = scale2
+				c[k] /= a[k]
+				a[k+1] -= c[k] * b[k]
+				if k < n-2 {
+					d[k] = 0
+				}
+			} else {
+				in[k] = 1
+				mult := a[k] / c[k]
+				a[k] = c[k]
+				temp := a[k+1]
+				a[k+1] = b[k] - mult*temp
+				if k < n-2 {
+					d[k] = b[k+1]
+					b[k+1] = -mult * d[k]
+				}
+				b[k] = temp
+				c[k] = mult
+			}
+		}
+		if math.Max(piv1, piv2) <= tl && in[n-1] == -1 {
+			in[n-1] = k
+		}
+	}
+	if math.Abs(a[n-1]) <= scale1*tl && in[n-1] == -1 {
+		in[n-1] = n - 1
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlagts solves one of the systems of equations
+//  (T - lambda*I)*x = y  or  (T - lambda*I)^T*x = y,
+// where T is an n×n tridiagonal matrix and lambda is a scalar, using the
+// factorization of (T - lambda*I) computed by Dlagtf. The system is selected
+// by job:
+//  job ==  1: Solve (T - lambda*I)*x = y.
+//  job == -1: Solve (T - lambda*I)*x = y, perturbing the diagonal elements
+//             of U if overflow would otherwise occur.
+//  job ==  2: Solve (T - lambda*I)^T*x = y.
+//  job == -2: Solve (T - lambda*I)^T*x = y, perturbing the diagonal elements
+//             of U if overflow would otherwise occur.
+//
+// a, b, c, d and in must contain the factorization as returned by Dlagtf. On
+// entry y contains the right-hand side vector, and on return it is
+// overwritten by the solution x.
+//
+// tol is only used when job is negative. A diagonal element of U that is too
+// small is perturbed by a multiple of tol. If tol is not positive, it is
+// computed as eps times the largest element of U, where eps is the machine
+// precision.
+//
+// Dlagts returns whether the solution was computed without overflow. If job
+// is negative, ok is always true.
+//
+// Dlagts is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlagts(job, n int, a, b, c, d []float64, in []int, y []float64, tol float64) (ok bool) {
+	if job != 1 && job != -1 && job != 2 && job != -2 {
+		panic(badJob)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return true
+	}
+	if len(a) < n || len(y) < n {
+		panic(badSlice)
+	}
+	if len(b) < n-1 || len(c) < n-1 || len(d) < n-2 {
+		panic(badSlice)
+	}
+	if len(in) < n {
+		panic(badIndex)
+	}
+
+	eps := dlamchE
+	sfmin := dlamchS
+	bignum := 1 / sfmin
+
+	if job < 0 && tol <= 0 {
+		tol = math.Abs(a[0])
+		if n > 1 {
+			tol = math.Max(tol, math.Max(math.Abs(a[1]), math.Abs(b[0])))
+		}
+		for k := 2; k < n; k++ {
+			tol = math.Max(tol, math.Max(math.Abs(a[k]), math.Max(math.Abs(b[k-1]), math.Abs(d[k-2]))))
+		}
+		tol *= eps
+		if tol == 0 {
+			tol = eps
+		}
+	}
+
+	// solve returns temp/ak, perturbing ak if the division would overflow
+	// and perturb is true. If ak cannot be perturbed, solve returns false.
+	solve := func(temp, ak float64, perturb bool) (float64, bool) {
+		pert := math.Copysign(tol, ak)
+		for {
+			absak := math.Abs(ak)
+			if absak >= 1 {
+				return temp / ak, true
+			}
+			if absak < sfmin {
+				if absak != 0 && math.Abs(temp)*sfmin <= absak {
+					return (temp * bignum) / (ak * bignum), true
+				}
+			} else if math.Abs(temp) <= absak*bignum {
+				return temp / ak, true
+			}
+			if !perturb {
+				return 0, false
+			}
+			ak += pert
+			pert *= 2
+		}
+	}
+
+	if job == 1 || job == -1 {
+		for k := 1; k < n; k++ {
+			if in[k-1] == 0 {
+				y[k] -= c[k-1] * y[k-1]
+			} else {
+				temp := y[k-1]
+				y[k-1] = y[k]
+				y[k] = temp - c[k-1]*y[k]
+			}
+		}
+		for k := n - 1; k >= 0; k-- {
+			temp := y[k]
+			if k < n-2 {
+				temp = y[k] - b[k]*y[k+1] - d[k]*y[k+2]
+			} else if k == n-2 {
+				temp = y[k] - b[k]*y[k+1]
+			}
+			v, ok := solve(temp, a[k], job < 0)
+			if !ok {
+				return false
+			}
+			y[k] = v
+		}
+		return true
+	}
+
+	// Solve the transposed system.
+	for k := 0; k < n; k++ {
+		temp := y[k]
+		if k >= 2 {
+			temp = y[k] - b[k-1]*y[k-1] - d[k-2]*y[k-2]
+		} else if k == 1 {
+			temp = y[k] - b[k-1]*y[k-1]
+		}
+		v, ok := solve(temp, a[k], job < 0)
+		if !ok {
+			return false
+		}
+		y[k] = v
+	}
+	for k := n - 1; k >= 1; k-- {
+		if in[k-1] == 0 {
+			y[k-1] -= c[k-1] * y[k]
+		} else {
+			temp := y[k-1]
+			y[k-1] = y[k]
+			y[k] = temp - c[k-1]*y[k]
+		}
+	}
+	return true
+}
@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlagv2 computes the generalized Schur factorization of a real 2×2 matrix
+// pair (A,B) where B is upper triangular. It computes orthogonal (rotation)
+// matrices given by (csl,snl) and (csr,snr) such that
+//  1) if the pair (A,B) has two real eigenvalues (including 0/0 or 1/0 types),
+//     then
+//      [ a11 a12 ] := [  csl snl ] [ a11 a12 ] [ csr -snr ]
+//      [  0  a22 ]    [ -snl csl ] [ a21 a22 ] [ snr  csr ]
+//
+//      [ b11 b12 ] := [  csl snl ] [ b11 b12 ] [ csr -snr ]
+//      [  0  b22 ]    [ -snl csl ] [  0  b22 ] [ snr  csr ],
+//  2) if the pair (A,B) has a pair of complex conjugate eigenvalues, then
+//      [ a11 a12 ] := [  csl snl ] [ a11 a12 ] [ csr -snr ]
+//      [ a21 a22 ]    [ -snl csl ] [ a21 a22 ] [ snr  csr ]
+//
+//      [ b11  0  ] := [  csl snl ] [ b11 b12 ] [ csr -snr ]
+//      [  0  b22 ]    [ -snl csl ] [  0  b22 ] [ snr  csr ],
+//     where |b11| >= |b22| > 0.
+//
+// On entry, a and b must contain the 2×2 matrices A and B, the element B[1,0]
+// must be zero. On return, they are overwritten by the transformed matrices.
+//
+// The eigenvalues of the pair are
+//  (alphar[k] + i*alphai[k]) / beta[k], k = 0, 1.
+// If alphai[0] is not zero, the eigenvalues are complex conjugates,
+// alphai[0] > 0, alphai[1] = -alphai[0], and beta[0] = beta[1] = 1.
+//
+// Dlagv2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlagv2(a []float64, lda int, b []float64, ldb int) (alphar, alphai, beta [2]float64, csl, snl, csr, snr float64) {
+	checkMatrix(2, 2, a, lda)
+	checkMatrix(2, 2, b, ldb)
+
+	safmin := dlamchS
+	ulp := dlamchP
+
+	// Scale A.
+	anorm := math.Max(math.Max(math.Abs(a[0])+math.Abs(a[lda]), math.Abs(a[1])+math.Abs(a[lda+1])), safmin)
+	ascale := 1 / anorm
+	a[0] *= ascale
+	a[1] *= ascale
+	a[lda] *= ascale
+	a[lda+1] *= ascale
+
+	// Scale B.
+	bnorm := math.Max(math.Max(math.Abs(b[0]), math.Abs(b[1])+math.Abs(b[ldb+1])), safmin)
+	bscale := 1 / bnorm
+	b[0] *= bscale
+	b[1] *= bscale
+	b[ldb+1] *= bscale
+
+	bi := blas64.Implementation()
+	var wr1, wi, scale1 float64
+	switch {
+	case math.Abs(a[lda]) <= ulp:
+		// A can be deflated.
+		csl = 1
+		snl = 0
+		csr = 1
+		snr = 0
+		a[lda] = 0
+		b[ldb] = 0
+	case math.Abs(b[0]) <= ulp:
+		// B is singular.
+		csl, snl, _ = impl.Dlartg(a[0], a[lda])
+		csr = 1
+		snr = 0
+		bi.Drot(2, a, 1, a[lda:], 1, csl, snl)
+		bi.Drot(2, b, 1, b[ldb:], 1, csl, snl)
+		a[lda] = 0
+		b[0] = 0
+		b[ldb] = 0
+	case math.Abs(b[ldb+1]) <= ulp:
+		csr, snr, _ = impl.Dlartg(a[lda+1], a[lda])
+		snr *= -1
+		bi.Drot(2, a, lda, a[1:], lda, csr, snr)
+		bi.Drot(2, b, ldb, b[1:], ldb, csr, snr)
+		csl = 1
+		snl = 0
+		a[lda] = 0
+		b[ldb] = 0
+		b[ldb+1] = 0
+	default:
+		// B is nonsingular, first compute the eigenvalues of (A,B).
+		scale1, _, wr1, _, wi = impl.Dlag2(a, lda, b, ldb, safmin)
+		if wi == 0 {
+			// Two real eigenvalues, compute s*A-w*B.
+			h1 := scale1*a[0] - wr1*b[0]
+			h2 := scale1*a[1] - wr1*b[1]
+			h3 := scale1*a[lda+1] - wr1*b[ldb+1]
+			rr := impl.Dlapy2(h1, h2)
+			qq := impl.Dlapy2(scale1*a[lda], h3)
+			if rr > qq {
+				// Find right rotation matrix to zero [0,0] element
+				// of (sA - wB).
+				csr, snr, _ = impl.Dlartg(h2, h1)
+			} else {
+				// Find right rotation matrix to zero [1,0] element
+				// of (sA - wB).
+				csr, snr, _ = impl.Dlartg(h3, scale1*a[lda])
+			}
+			snr *= -1
+			bi.Drot(2, a, lda, a[1:], lda, csr, snr)
+			bi.Drot(2, b, ldb, b[1:], ldb, csr, snr)
+
+			// Compute inf norms of A and B.
+			h1 = math.Max(math.Abs(a[0])+math.Abs(a[1]), math.Abs(a[lda])+math.Abs(a[lda+1]))
+			h2 = math.Max(math.Abs(b[0])+math.Abs(b[1]), math.Abs(b[ldb])+math.Abs(b[ldb+1]))
+			if scale1*h1 >= math.Abs(wr1)*h2 {
+				// Find left rotation matrix Q to zero out B[1,0].
+				csl, snl, _ = impl.Dlartg(b[0], b[ldb])
+			} else {
+				// Find left rotation matrix Q to zero out A[1,0].
+				csl, snl, _ = impl.Dlartg(a[0], a[lda])
+			}
+			bi.Drot(2, a, 1, a[lda:], 1, csl, snl)
+			bi.Drot(2, b, 1, b[ldb:], 1, csl, snl)
+			a[lda] = 0
+			b[ldb] = 0
+		} else {
+			// A pair of complex conjugate eigenvalues, first compute
+			// the SVD of the matrix B.
+			_, _, snr, csr, snl, csl = impl.Dlasv2(b[0], b[1], b[ldb+1])
+
+			// Form (A,B) := Q*(A,B)*Z^T where Q is the left rotation
+			// matrix and Z is the right rotation matrix computed
+			// from Dlasv2.
+			bi.Drot(2, a, 1, a[lda:], 1, csl, snl)
+			bi.Drot(2, b, 1, b[ldb:], 1, csl, snl)
+			bi.Drot(2, a, lda, a[1:], lda, csr, snr)
+			bi.Drot(2, b, ldb, b[1:], ldb, csr, snr)
+			b[ldb] = 0
+			b[1] = 0
+		}
+	}
+
+	// Unscale.
+	a[0] *= anorm
+	a[lda] *= anorm
+	a[1] *= anorm
+	a[lda+1] *= anorm
+	b[0] *= bnorm
+	b[ldb] *= bnorm
+	b[1] *= bnorm
+	b[ldb+1] *= bnorm
+
+	if wi == 0 {
+		alphar[0] = a[0]
+		alphar[1] = a[lda+1]
+		beta[0] = b[0]
+		beta[1] = b[ldb+1]
+	} else {
+		alphar[0] = anorm * wr1 / scale1 / bnorm
+		alphai[0] = anorm * wi / scale1 / bnorm
+		alphar[1] = alphar[0]
+		alphai[1] = -alphai[0]
+		beta[0] = 1
+		beta[1] = 1
+	}
+	return alphar, alphai, beta, csl, snl, csr, snr
+}
@@ -0,0 +1,181 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlaic1 applies one step of incremental condition estimation in its simplest
+// version.
+//
+// Let x, with |x|_2 = 1, be an approximate singular vector of a j×j lower
+// triangular matrix L, such that
+//  |L*x|_2 = sest.
+// Then Dlaic1 computes sestpr, s and c such that the vector
+//  xhat = [ s*x ]
+//         [  c  ]
+// is an approximate singular vector of
+//  Lhat = [ L     0     ]
+//         [ w^T   gamma ]
+// in the sense that
+//  |Lhat*xhat|_2 = sestpr.
+//
+// If job == 1, an estimate for the largest singular value is computed, and
+// if job == 2, an estimate for the smallest singular value is computed.
+//
+// Depending on job, the returned s and c are chosen such that
+//  [ s  c ]^T * ([ sest^2  0 ] + [ alpha ] * [ alpha gamma ]) * [ s ]
+//               ([ 0       0 ]   [ gamma ]                    ) [ c ]
+// is maximized (job == 1) or minimized (job == 2) subject to s^2 + c^2 = 1,
+// where alpha = x^T*w.
+//
+// x and w must have length at least j.
+//
+// Dlaic1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaic1(job, j int, x []float64, sest float64, w []float64, gamma float64) (sestpr, s, c float64) {
+	if job != 1 && job != 2 {
+		panic("lapack: job must be 1 or 2")
+	}
+	if j < 0 {
+		panic("lapack: j < 0")
+	}
+	if len(x) < j {
+		panic(badSlice)
+	}
+	if len(w) < j {
+		panic(badW)
+	}
+
+	eps := dlamchE
+	alpha := blas64.Implementation().Ddot(j, x, 1, w, 1)
+
+	absalp := math.Abs(alpha)
+	absgam := math.Abs(gamma)
+	absest := math.Abs(sest)
+
+	if job == 1 {
+		// Estimating the largest singular value.
+
+		// Special cases.
+		switch {
+		case sest == 0:
+			s1 := math.Max(absgam, absalp)
+			if s1 == 0 {
+				return 0, 0, 1
+			}
+			s = alpha / s1
+			c = gamma / s1
+			tmp := math.Sqrt(s*s + c*c)
+			return s1 * tmp, s / tmp, c / tmp
+		case absgam <= eps*absest:
+			tmp := math.Max(absest, absalp)
+			s1 := absest / tmp
+			s2 := absalp / tmp
+			return tmp * math.Sqrt(s1*s1+s2*s2), 1, 0
+		case absalp <= eps*absest:
+			if absgam <= absest {
+				return absest, 1, 0
+			}
+			return absgam, 0, 1
+		case absest <= eps*absalp || absest <= eps*absgam:
+			if absgam <= absalp {
+				tmp := absgam / absalp
+				s = math.Sqrt(1 + tmp*tmp)
+				return absalp * s, math.Copysign(1, alpha) / s, (gamma / absalp) / s
+			}
+			tmp := absalp / absgam
+			c = math.Sqrt(1 + tmp*tmp)
+			return absgam * c, (alpha / absgam) / c, math.Copysign(1, gamma) / c
+		}
+
+		// Normal case.
+		zeta1 := alpha / absest
+		zeta2 := gamma / absest
+
+		b := (1 - zeta1*zeta1 - zeta2*zeta2) * 0.5
+		c = zeta1 * zeta1
+		var t float64
+		if b > 0 {
+			t = c / (b + math.Sqrt(b*b+c))
+		} else {
+			t = math.Sqrt(b*b+c) - b
+		}
+		sine := -zeta1 / t
+		cosine := -zeta2 / (1 + t)
+		tmp := math.Sqrt(sine*sine + cosine*cosine)
+		return math.Sqrt(t+1) * absest, sine / tmp, cosine / tmp
+	}
+
+	// Estimating the smallest singular value.
+
+	// Special cases.
+	switch {
+	case sest == 0:
+		sine := 1.0
+		cosine := 0.0
+		if math.Max(absgam, absalp) != 0 {
+			sine = -gamma
+			cosine = alpha
+		}
+		s1 := math.Max(math.Abs(sine), math.Abs(cosine))
+		s = sine / s1
+		c = cosine / s1
+		tmp := math.Sqrt(s*s + c*c)
+		return 0, s / tmp, c / tmp
+	case absgam <= eps*absest:
+		return absgam, 0, 1
+	case absalp <= eps*absest:
+		if absgam <= absest {
+			return absgam, 0, 1
+		}
+		return absest, 1, 0
+	case absest <= eps*absalp || absest <= eps*absgam:
+		if absgam <= absalp {
+			tmp := absgam / absalp
+			c = math.Sqrt(1 + tmp*tmp)
+			return absest * (tmp / c), -(gamma / absalp) / c, math.Copysign(1, alpha) / c
+		}
+		tmp := absalp / absgam
+		s = math.Sqrt(1 + tmp*tmp)
+		return absest / s, -math.Copysign(1, gamma) / s, (alpha / absgam) / s
+	}
+
+	// Normal case.
+	zeta1 := alpha / absest
+	zeta2 := gamma / absest
+
+	norma := math.Max(1+zeta1*zeta1+math.Abs(zeta1*zeta2), math.Abs(zeta1*zeta2)+zeta2*zeta2)
+
+	// See if root is closer to zero or to one.
+	test := 1 + 2*(zeta1-zeta2)*(zeta1+zeta2)
+	var sine, cosine float64
+	if test >= 0 {
+		// Root is close to zero, compute directly.
+		b := (zeta1*zeta1 + zeta2*zeta2 + 1) * 0.5
+		c = zeta2 * zeta2
+		t := c / (b + math.Sqrt(math.Abs(b*b-c)))
+		sine = zeta1 / (1 - t)
+		cosine = -zeta2 / t
+		sestpr = math.Sqrt(t+4*eps*eps*norma) * absest
+	} else {
+		// Root is closer to one, shift by that amount.
+		b := (zeta2*zeta2 + zeta1*zeta1 - 1) * 0.5
+		c = zeta1 * zeta1
+		var t float64
+		if b >= 0 {
+			t = -c / (b + math.Sqrt(b*b+c))
+		} else {
+			t = b - math.Sqrt(b*b+c)
+		}
+		sine = -zeta1 / t
+		cosine = -zeta2 / (1 + t)
+		sestpr = math.Sqrt(1+t+4*eps*eps*norma) * absest
+	}
+	tmp := math.Sqrt(sine*sine + cosine*cosine)
+	return sestpr, sine / tmp, cosine / tmp
+}
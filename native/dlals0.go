@@ -0,0 +1,205 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlals0 applies back the multiplying factors of either the left or the
+// right singular vector matrix of an n×m upper bidiagonal matrix B, where
+// n = nl + nr + 1 and m = n + sqre, to the right hand side matrix B of a
+// least squares problem. The factored singular vectors are those computed by
+// Dlasd6 for a single node of the divide and conquer tree.
+//
+// If icompq == 0, the left singular vector matrix is applied as
+//  B := U^T * B
+// by undoing the Givens rotations and the permutation applied during
+// deflation and by then multiplying by the inverse of the left singular
+// vector matrix of the deflated problem. If icompq == 1, the right singular
+// vector matrix is applied as
+//  B := V * B
+// by performing the inverse steps in reverse order.
+//
+// nl and nr are the row dimensions of the upper and lower blocks, and sqre
+// must be 0 or 1. nrhs is the number of columns of B.
+//
+// b is an m×nrhs matrix with stride ldb containing the right hand sides on
+// entry and the result on return. bx is an m×nrhs matrix with stride ldbx
+// that is used as workspace.
+//
+// perm, givptr, givcol, givnum, poles, difl, difr, z, k, c and s are the
+// values returned by Dlasd6 for the node. givcol is a 2×n matrix with stride
+// ldgcol and givnum, poles and difr are 2×n matrices with stride ldgnum.
+//
+// work must have length at least k.
+//
+// Dlals0 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlals0(icompq, nl, nr, sqre, nrhs int, b []float64, ldb int, bx []float64, ldbx int, perm []int, givptr int, givcol []int, ldgcol int, givnum []float64, ldgnum int, poles, difl, difr, z []float64, k int, c, s float64, work []float64) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if nl < 1 {
+		panic("lapack: nl < 1")
+	}
+	if nr < 1 {
+		panic("lapack: nr < 1")
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	checkMatrix(m, nrhs, b, ldb)
+	checkMatrix(m, nrhs, bx, ldbx)
+	if givptr < 0 {
+		panic("lapack: givptr < 0")
+	}
+	if len(perm) < n || ldgcol < n || len(givcol) < ldgcol+n {
+		panic(badIndex)
+	}
+	checkMatrix(2, n, givnum, ldgnum)
+	if k < 1 || k > n {
+		panic("lapack: k out of range")
+	}
+	checkMatrix(2, k, poles, ldgnum)
+	checkMatrix(2, k, difr, ldgnum)
+	if len(difl) < k {
+		panic(badSlice)
+	}
+	if len(z) < k {
+		panic(badZ)
+	}
+	if len(work) < k {
+		panic(badWork)
+	}
+
+	bi := blas64.Implementation()
+
+	if icompq == 0 {
+		// Apply back orthogonal transformations from the left.
+
+		// Apply back the Givens rotations performed.
+		for i := 0; i < givptr; i++ {
+			bi.Drot(nrhs, b[givcol[ldgcol+i]*ldb:], 1, b[givcol[i]*ldb:], 1,
+				givnum[ldgnum+i], givnum[i])
+		}
+
+		// Permute rows of B.
+		bi.Dcopy(nrhs, b[nl*ldb:], 1, bx, 1)
+		for i := 1; i < n; i++ {
+			bi.Dcopy(nrhs, b[perm[i]*ldb:], 1, bx[i*ldbx:], 1)
+		}
+
+		// Apply the inverse of the left singular vector matrix to BX.
+		if k == 1 {
+			bi.Dcopy(nrhs, bx, 1, b, 1)
+			if z[0] < 0 {
+				bi.Dscal(nrhs, -1, b, 1)
+			}
+		} else {
+			for j := 0; j < k; j++ {
+				diflj := difl[j]
+				dj := poles[j]
+				dsigj := -poles[ldgnum+j]
+				var difrj, dsigjp float64
+				if j < k-1 {
+					difrj = -difr[j]
+					dsigjp = -poles[ldgnum+j+1]
+				}
+				if z[j] == 0 || poles[ldgnum+j] == 0 {
+					work[j] = 0
+				} else {
+					work[j] = -poles[ldgnum+j] * z[j] / diflj / (poles[ldgnum+j] + dj)
+				}
+				for i := 0; i < j; i++ {
+					if z[i] == 0 || poles[ldgnum+i] == 0 {
+						work[i] = 0
+					} else {
+						work[i] = poles[ldgnum+i] * z[i] / (poles[ldgnum+i] + dsigj - diflj) / (poles[ldgnum+i] + dj)
+					}
+				}
+				for i := j + 1; i < k; i++ {
+					if z[i] == 0 || poles[ldgnum+i] == 0 {
+						work[i] = 0
+					} else {
+						work[i] = poles[ldgnum+i] * z[i] / (poles[ldgnum+i] + dsigjp + difrj) / (poles[ldgnum+i] + dj)
+					}
+				}
+				work[0] = -1
+				temp := bi.Dnrm2(k, work, 1)
+				bi.Dgemv(blas.Trans, k, nrhs, 1, bx, ldbx, work, 1, 0, b[j*ldb:], 1)
+				impl.Dlascl(lapack.General, 0, 0, temp, 1, 1, nrhs, b[j*ldb:], ldb)
+			}
+		}
+
+		// Move the deflated rows of BX to B also.
+		if k < max(m, n) {
+			impl.Dlacpy(blas.All, n-k, nrhs, bx[k*ldbx:], ldbx, b[k*ldb:], ldb)
+		}
+		return
+	}
+
+	// Apply back the right orthogonal transformations.
+
+	// Apply back the new right singular vector matrix to B.
+	if k == 1 {
+		bi.Dcopy(nrhs, b, 1, bx, 1)
+	} else {
+		for j := 0; j < k; j++ {
+			dsigj := poles[ldgnum+j]
+			if z[j] == 0 {
+				work[j] = 0
+			} else {
+				work[j] = -z[j] / difl[j] / (dsigj + poles[j]) / difr[ldgnum+j]
+			}
+			for i := 0; i < j; i++ {
+				if z[j] == 0 {
+					work[i] = 0
+				} else {
+					work[i] = z[j] / (dsigj - poles[ldgnum+i+1] - difr[i]) / (dsigj + poles[i]) / difr[ldgnum+i]
+				}
+			}
+			for i := j + 1; i < k; i++ {
+				if z[j] == 0 {
+					work[i] = 0
+				} else {
+					work[i] = z[j] / (dsigj - poles[ldgnum+i] - difl[i]) / (dsigj + poles[i]) / difr[ldgnum+i]
+				}
+			}
+			bi.Dgemv(blas.Trans, k, nrhs, 1, b, ldb, work, 1, 0, bx[j*ldbx:], 1)
+		}
+	}
+
+	// If sqre == 1, apply back the rotation that is related to the right
+	// null space of the subproblem.
+	if sqre == 1 {
+		bi.Dcopy(nrhs, b[(m-1)*ldb:], 1, bx[(m-1)*ldbx:], 1)
+		bi.Drot(nrhs, bx, 1, bx[(m-1)*ldbx:], 1, c, s)
+	}
+	if k < max(m, n) {
+		impl.Dlacpy(blas.All, n-k, nrhs, b[k*ldb:], ldb, bx[k*ldbx:], ldbx)
+	}
+
+	// Permute rows of B.
+	bi.Dcopy(nrhs, bx, 1, b[nl*ldb:], 1)
+	if sqre == 1 {
+		bi.Dcopy(nrhs, bx[(m-1)*ldbx:], 1, b[(m-1)*ldb:], 1)
+	}
+	for i := 1; i < n; i++ {
+		bi.Dcopy(nrhs, bx[i*ldbx:], 1, b[perm[i]*ldb:], 1)
+	}
+
+	// Apply back the Givens rotations performed.
+	for i := givptr - 1; i >= 0; i-- {
+		bi.Drot(nrhs, b[givcol[ldgcol+i]*ldb:], 1, b[givcol[i]*ldb:], 1,
+			givnum[ldgnum+i], -givnum[i])
+	}
+}
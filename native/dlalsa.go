@@ -0,0 +1,170 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlalsa applies the singular vectors of an n×n upper bidiagonal matrix,
+// computed in compact form by Dlasda, to the right hand side matrix of a least
+// squares problem. It is an intermediate step of Dlalsd.
+//
+// If icompq == 0, the left singular vector matrix is applied as
+//  BX = U^T * B.
+// If icompq == 1, the right singular vector matrix is applied as
+//  BX = V * B.
+//
+// smlsiz is the maximum size of the subproblems at the bottom of the
+// computation tree and must be the value used in the call to Dlasda. nrhs is
+// the number of columns of B.
+//
+// b is an n×nrhs matrix with stride ldb that contains the right hand sides on
+// entry. It is overwritten on return. bx is an n×nrhs matrix with stride ldbx
+// that contains the result on return.
+//
+// u, vt, k, difl, difr, z, poles, givptr, givcol, perm, givnum, c and s are
+// the values computed by Dlasda with icompq == 1 and sqre == 0, and have the
+// same layout and strides as described there.
+//
+// work must have length at least n and iwork must have length at least 3*n.
+//
+// Dlalsa is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlalsa(icompq, smlsiz, n, nrhs int, b []float64, ldb int, bx []float64, ldbx int, u []float64, ldu int, vt []float64, ldvt int, k []int, difl, difr, z, poles []float64, givptr, givcol []int, ldgcol int, perm []int, givnum []float64, ldgnum int, c, s, work []float64, iwork []int) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if smlsiz < 3 {
+		panic("lapack: smlsiz < 3")
+	}
+	if n < smlsiz {
+		panic("lapack: n < smlsiz")
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, bx, ldbx)
+	checkMatrix(n, smlsiz, u, ldu)
+	checkMatrix(n, smlsiz+1, vt, ldvt)
+	if ldgnum < n || ldgcol < n {
+		panic(badLdA)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+	if len(iwork) < 3*n {
+		panic(badWork)
+	}
+
+	bi := blas64.Implementation()
+
+	// Book-keeping and set up the computation tree.
+	const inode = 0
+	ndiml := inode + n
+	ndimr := ndiml + n
+	nlvl, nd := impl.Dlasdt(n, iwork[inode:], iwork[ndiml:], iwork[ndimr:], smlsiz)
+
+	if icompq == 0 {
+		// The nodes on the bottom level of the tree were solved by
+		// Dlasdq. The corresponding left and right singular vector
+		// matrices are in explicit form. First apply back the left
+		// singular vector matrices.
+		for i := (nd - 1) / 2; i < nd; i++ {
+			ic := iwork[inode+i]
+			nl := iwork[ndiml+i]
+			nr := iwork[ndimr+i]
+			nlf := ic - nl
+			nrf := ic + 1
+			bi.Dgemm(blas.Trans, blas.NoTrans, nl, nrhs, nl, 1, u[nlf*ldu:], ldu,
+				b[nlf*ldb:], ldb, 0, bx[nlf*ldbx:], ldbx)
+			bi.Dgemm(blas.Trans, blas.NoTrans, nr, nrhs, nr, 1, u[nrf*ldu:], ldu,
+				b[nrf*ldb:], ldb, 0, bx[nrf*ldbx:], ldbx)
+		}
+
+		// Next copy the rows of B that correspond to unchanged rows in
+		// the bidiagonal matrix to BX.
+		for i := 0; i < nd; i++ {
+			ic := iwork[inode+i]
+			bi.Dcopy(nrhs, b[ic*ldb:], 1, bx[ic*ldbx:], 1)
+		}
+
+		// Finally go through the left singular vector matrices of all
+		// the other subproblems bottom-up on the tree.
+		j := 1<<uint(nlvl) - 1
+		for lvl := nlvl; lvl >= 1; lvl-- {
+			lf, ll := 0, 0
+			if lvl > 1 {
+				lf = 1<<uint(lvl-1) - 1
+				ll = 2 * lf
+			}
+			r1 := lvl - 1
+			r2 := 2 * (lvl - 1)
+			for i := lf; i <= ll; i++ {
+				ic := iwork[inode+i]
+				nl := iwork[ndiml+i]
+				nr := iwork[ndimr+i]
+				nlf := ic - nl
+				j--
+				impl.Dlals0(0, nl, nr, 0, nrhs, bx[nlf*ldbx:], ldbx, b[nlf*ldb:], ldb,
+					perm[r1*ldgcol+nlf:], givptr[j], givcol[r2*ldgcol+nlf:], ldgcol,
+					givnum[r2*ldgnum+nlf:], ldgnum, poles[r2*ldgnum+nlf:],
+					difl[r1*ldgnum+nlf:], difr[r2*ldgnum+nlf:], z[r1*ldgnum+nlf:],
+					k[j], c[j], s[j], work)
+			}
+		}
+		return
+	}
+
+	// Apply back the right singular vector factors. First go through the
+	// right singular vector matrices of all the tree nodes top-down.
+	var j int
+	for lvl := 1; lvl <= nlvl; lvl++ {
+		lf, ll := 0, 0
+		if lvl > 1 {
+			lf = 1<<uint(lvl-1) - 1
+			ll = 2 * lf
+		}
+		r1 := lvl - 1
+		r2 := 2 * (lvl - 1)
+		for i := ll; i >= lf; i-- {
+			ic := iwork[inode+i]
+			nl := iwork[ndiml+i]
+			nr := iwork[ndimr+i]
+			nlf := ic - nl
+			sqre := 1
+			if i == ll {
+				sqre = 0
+			}
+			impl.Dlals0(1, nl, nr, sqre, nrhs, b[nlf*ldb:], ldb, bx[nlf*ldbx:], ldbx,
+				perm[r1*ldgcol+nlf:], givptr[j], givcol[r2*ldgcol+nlf:], ldgcol,
+				givnum[r2*ldgnum+nlf:], ldgnum, poles[r2*ldgnum+nlf:],
+				difl[r1*ldgnum+nlf:], difr[r2*ldgnum+nlf:], z[r1*ldgnum+nlf:],
+				k[j], c[j], s[j], work)
+			j++
+		}
+	}
+
+	// The nodes on the bottom level of the tree were solved by Dlasdq. The
+	// corresponding right singular vector matrices are in explicit form.
+	// Apply them back.
+	for i := (nd - 1) / 2; i < nd; i++ {
+		ic := iwork[inode+i]
+		nl := iwork[ndiml+i]
+		nr := iwork[ndimr+i]
+		nlf := ic - nl
+		nrf := ic + 1
+		nlp1 := nl + 1
+		nrp1 := nr + 1
+		if i == nd-1 {
+			nrp1 = nr
+		}
+		bi.Dgemm(blas.Trans, blas.NoTrans, nlp1, nrhs, nlp1, 1, vt[nlf*ldvt:], ldvt,
+			b[nlf*ldb:], ldb, 0, bx[nlf*ldbx:], ldbx)
+		bi.Dgemm(blas.Trans, blas.NoTrans, nrp1, nrhs, nrp1, 1, vt[nrf*ldvt:], ldvt,
+			b[nrf*ldb:], ldb, 0, bx[nrf*ldbx:], ldbx)
+	}
+}
@@ -0,0 +1,282 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlalsd uses the singular value decomposition of an n×n bidiagonal matrix B
+// to solve the least squares problem of finding X to minimize
+//  |B*X - B_in|_2
+// where B_in is the n×nrhs right hand side matrix given in b. The singular
+// values of B that are less than or equal to rcond times the largest
+// singular value are treated as zero in solving the least squares problem.
+// If rcond is negative or not less than 1, machine precision is used
+// instead. The singular value decomposition is computed by a divide and
+// conquer algorithm as described in Dlasda and applied in factored form by
+// Dlalsa.
+//
+// uplo specifies whether B is upper or lower bidiagonal. d contains the n
+// diagonal elements of B and e contains the n-1 off-diagonal elements. On
+// return, d contains the singular values of B in decreasing order and e is
+// overwritten.
+//
+// smlsiz is the maximum size of the subproblems at the bottom of the
+// computation tree, and must be at least 3.
+//
+// b is an n×nrhs matrix with stride ldb. On entry it contains the right hand
+// sides and on return it contains the solution X.
+//
+// work must have length at least
+//  9*n + 2*n*smlsiz + 8*n*nlvl + n*nrhs + (smlsiz+1)*(smlsiz+1)
+// and iwork must have length at least 3*n*nlvl + 11*n, where
+//  nlvl = max(0, int(log_2(n/(smlsiz+1))) + 1).
+//
+// Dlalsd returns the effective rank of B, the number of singular values
+// greater than the threshold, and whether the singular values were computed
+// successfully.
+//
+// Dlalsd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlalsd(uplo blas.Uplo, smlsiz, n, nrhs int, d, e, b []float64, ldb int, rcond float64, work []float64, iwork []int) (rank int, ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if smlsiz < 3 {
+		panic("lapack: smlsiz < 3")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if nrhs < 1 {
+		panic("lapack: nrhs < 1")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+	var nlvl int
+	if n > 0 {
+		nlvl = max(0, int(math.Log2(float64(n)/float64(smlsiz+1)))+1)
+	}
+	smlszp := smlsiz + 1
+	if len(work) < 9*n+2*n*smlsiz+8*n*nlvl+n*nrhs+smlszp*smlszp {
+		panic(badWork)
+	}
+	if len(iwork) < 3*n*nlvl+11*n {
+		panic(badWork)
+	}
+
+	eps := dlamchE
+
+	// Set up the tolerance.
+	rcnd := rcond
+	if rcond <= 0 || rcond >= 1 {
+		rcnd = eps
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if d[0] == 0 {
+			impl.Dlaset(blas.All, 1, nrhs, 0, 0, b, ldb)
+			return 0, true
+		}
+		impl.Dlascl(lapack.General, 0, 0, d[0], 1, 1, nrhs, b, ldb)
+		d[0] = math.Abs(d[0])
+		return 1, true
+	}
+
+	bi := blas64.Implementation()
+
+	// Rotate the matrix if it is lower bidiagonal.
+	if uplo == blas.Lower {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			bi.Drot(nrhs, b[i*ldb:], 1, b[(i+1)*ldb:], 1, cs, sn)
+		}
+	}
+
+	// Scale.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		impl.Dlaset(blas.All, n, nrhs, 0, 0, b, ldb)
+		return 0, true
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n-1, 1, e, 1)
+
+	// If n is smaller than the minimum divide size smlsiz, then solve the
+	// problem with another solver.
+	if n <= smlsiz {
+		nwork := n * n
+		impl.Dlaset(blas.All, n, n, 0, 1, work, n)
+		ok = impl.Dlasdq(blas.Upper, 0, n, n, 0, nrhs, d, e, work, n, nil, 1, b, ldb, work[nwork:])
+		if !ok {
+			return 0, false
+		}
+		tol := rcnd * math.Abs(d[bi.Idamax(n, d, 1)])
+		for i := 0; i < n; i++ {
+			if d[i] <= tol {
+				impl.Dlaset(blas.All, 1, nrhs, 0, 0, b[i*ldb:], ldb)
+			} else {
+				impl.Dlascl(lapack.General, 0, 0, d[i], 1, 1, nrhs, b[i*ldb:], ldb)
+				rank++
+			}
+		}
+		bi.Dgemm(blas.Trans, blas.NoTrans, n, nrhs, n, 1, work, n, b, ldb, 0, work[nwork:], nrhs)
+		impl.Dlacpy(blas.All, n, nrhs, work[nwork:], nrhs, b, ldb)
+
+		// Unscale.
+		impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+		impl.Dlasrt(lapack.SortDecreasing, n, d)
+		impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, nrhs, b, ldb)
+		return rank, true
+	}
+
+	// Book-keeping and setting up some constants. The left and right
+	// singular vectors of the bottom level subproblems are stored in u and
+	// vt, and the level arrays of the computation tree are stored as nlvl×n
+	// or (2*nlvl)×n matrices with stride n.
+	const u = 0
+	ldu := smlsiz
+	vt := u + smlsiz*n
+	ldvt := smlszp
+	difl := vt + smlszp*n
+	difr := difl + nlvl*n
+	z := difr + 2*nlvl*n
+	c := z + nlvl*n
+	s := c + n
+	poles := s + n
+	givnum := poles + 2*nlvl*n
+	bx := givnum + 2*nlvl*n
+	ldbx := nrhs
+	nwork := bx + n*nrhs
+
+	const isub = 0
+	sizei := isub + n
+	k := sizei + n
+	givptr := k + n
+	perm := givptr + n
+	givcol := perm + nlvl*n
+	iwk := givcol + 2*nlvl*n
+
+	for i := 0; i < n; i++ {
+		if math.Abs(d[i]) < eps {
+			d[i] = math.Copysign(eps, d[i])
+		}
+	}
+
+	// Split the bidiagonal matrix into independent subproblems at the
+	// negligible off-diagonal elements. The starting rows of the
+	// subproblems are stored in iwork[isub:] and their sizes in
+	// iwork[sizei:].
+	var nsub int
+	st := 0
+	for i := 0; i < n-1; i++ {
+		if math.Abs(e[i]) >= eps && i < n-2 {
+			continue
+		}
+		// A subproblem is found. First determine its size and then
+		// apply it.
+		iwork[isub+nsub] = st
+		nsize := i - st + 1
+		if i == n-2 && math.Abs(e[i]) >= eps {
+			// A subproblem with e[n-2] not too small but i = n-2.
+			nsize = n - st
+		}
+		iwork[sizei+nsub] = nsize
+		nsub++
+		if i == n-2 && math.Abs(e[i]) < eps {
+			// A subproblem with e[n-2] small. This implies a 1×1
+			// subproblem at d[n-1], which is not solved explicitly.
+			iwork[isub+nsub] = n - 1
+			iwork[sizei+nsub] = 1
+			nsub++
+			bi.Dcopy(nrhs, b[(n-1)*ldb:], 1, work[bx+(n-1)*ldbx:], 1)
+		}
+		switch {
+		case nsize == 1:
+			// This is a 1×1 subproblem and is not solved explicitly.
+			bi.Dcopy(nrhs, b[st*ldb:], 1, work[bx+st*ldbx:], 1)
+		case nsize <= smlsiz:
+			// This is a small subproblem and is solved by Dlasdq.
+			impl.Dlaset(blas.All, nsize, nsize, 0, 1, work[vt+st*ldvt:], ldvt)
+			ok = impl.Dlasdq(blas.Upper, 0, nsize, nsize, 0, nrhs, d[st:], e[st:],
+				work[vt+st*ldvt:], ldvt, nil, 1, b[st*ldb:], ldb, work[nwork:])
+			if !ok {
+				return 0, false
+			}
+			impl.Dlacpy(blas.All, nsize, nrhs, b[st*ldb:], ldb, work[bx+st*ldbx:], ldbx)
+		default:
+			// A large problem. Solve it using divide and conquer.
+			ok = impl.Dlasda(1, smlsiz, nsize, 0, d[st:], e[st:],
+				work[u+st*ldu:], ldu, work[vt+st*ldvt:], ldvt, iwork[k+st:],
+				work[difl+st:], work[difr+st:], work[z+st:], work[poles+st:],
+				iwork[givptr+st:], iwork[givcol+st:], n, iwork[perm+st:],
+				work[givnum+st:], n, work[c+st:], work[s+st:], work[nwork:], iwork[iwk:])
+			if !ok {
+				return 0, false
+			}
+			impl.Dlalsa(0, smlsiz, nsize, nrhs, b[st*ldb:], ldb, work[bx+st*ldbx:], ldbx,
+				work[u+st*ldu:], ldu, work[vt+st*ldvt:], ldvt, iwork[k+st:],
+				work[difl+st:], work[difr+st:], work[z+st:], work[poles+st:],
+				iwork[givptr+st:], iwork[givcol+st:], n, iwork[perm+st:],
+				work[givnum+st:], n, work[c+st:], work[s+st:], work[nwork:], iwork[iwk:])
+		}
+		st = i + 1
+	}
+
+	// Apply the singular values and treat the tiny ones as zero.
+	tol := rcnd * math.Abs(d[bi.Idamax(n, d, 1)])
+	for i := 0; i < n; i++ {
+		// Some of the elements in d can be negative because 1×1
+		// subproblems were not solved explicitly.
+		if math.Abs(d[i]) <= tol {
+			impl.Dlaset(blas.All, 1, nrhs, 0, 0, work[bx+i*ldbx:], ldbx)
+		} else {
+			rank++
+			impl.Dlascl(lapack.General, 0, 0, d[i], 1, 1, nrhs, work[bx+i*ldbx:], ldbx)
+		}
+		d[i] = math.Abs(d[i])
+	}
+
+	// Now apply back the right singular vectors.
+	for i := 0; i < nsub; i++ {
+		st := iwork[isub+i]
+		nsize := iwork[sizei+i]
+		switch {
+		case nsize == 1:
+			bi.Dcopy(nrhs, work[bx+st*ldbx:], 1, b[st*ldb:], 1)
+		case nsize <= smlsiz:
+			bi.Dgemm(blas.Trans, blas.NoTrans, nsize, nrhs, nsize, 1, work[vt+st*ldvt:], ldvt,
+				work[bx+st*ldbx:], ldbx, 0, b[st*ldb:], ldb)
+		default:
+			impl.Dlalsa(1, smlsiz, nsize, nrhs, work[bx+st*ldbx:], ldbx, b[st*ldb:], ldb,
+				work[u+st*ldu:], ldu, work[vt+st*ldvt:], ldvt, iwork[k+st:],
+				work[difl+st:], work[difr+st:], work[z+st:], work[poles+st:],
+				iwork[givptr+st:], iwork[givcol+st:], n, iwork[perm+st:],
+				work[givnum+st:], n, work[c+st:], work[s+st:], work[nwork:], iwork[iwk:])
+		}
+	}
+
+	// Unscale and sort the singular values.
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+	impl.Dlasrt(lapack.SortDecreasing, n, d)
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, nrhs, b, ldb)
+	return rank, true
+}
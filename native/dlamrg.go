@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dlamrg creates a permutation list to merge the entries of two independently
+// sorted sets into a single set sorted in ascending order.
+//
+// The first n1 elements of a and the following n2 elements of a are sorted
+// independently. If dtrd1 == 1, the first set is sorted in ascending order and
+// if dtrd1 == -1, it is sorted in descending order. dtrd2 plays the same role
+// for the second set.
+//
+// On return, index contains the permutation such that
+//  a[index[0]] <= a[index[1]] <= ... <= a[index[n1+n2-1]].
+// index must have length at least n1+n2, and Dlamrg will panic otherwise.
+//
+// Dlamrg is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlamrg(n1, n2 int, a []float64, dtrd1, dtrd2 int, index []int) {
+	if n1 < 0 || n2 < 0 {
+		panic(negDimension)
+	}
+	if dtrd1 != 1 && dtrd1 != -1 {
+		panic("lapack: dtrd1 must be 1 or -1")
+	}
+	if dtrd2 != 1 && dtrd2 != -1 {
+		panic("lapack: dtrd2 must be 1 or -1")
+	}
+	if len(a) < n1+n2 {
+		panic(badSlice)
+	}
+	if len(index) < n1+n2 {
+		panic(badIndex)
+	}
+
+	var ind1, ind2 int
+	if dtrd1 > 0 {
+		ind1 = 0
+	} else {
+		ind1 = n1 - 1
+	}
+	if dtrd2 > 0 {
+		ind2 = n1
+	} else {
+		ind2 = n1 + n2 - 1
+	}
+	var i int
+	for n1 > 0 && n2 > 0 {
+		if a[ind1] <= a[ind2] {
+			index[i] = ind1
+			i++
+			ind1 += dtrd1
+			n1--
+		} else {
+			index[i] = ind2
+			i++
+			ind2 += dtrd2
+			n2--
+		}
+	}
+	if n1 == 0 {
+		for ; n2 > 0; n2-- {
+			index[i] = ind2
+			i++
+			ind2 += dtrd2
+		}
+	} else {
+		for ; n1 > 0; n1-- {
+			index[i] = ind1
+			i++
+			ind1 += dtrd1
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlaneg computes the Sturm count, the number of negative pivots encountered
+// while factoring the tridiagonal matrix T - sigma*I = L * D * L^T, where
+//  T = L * D * L^T
+// is described by its diagonal d and the squared off-diagonal of L times D
+// stored in lld (lld[i] = l[i]^2 * d[i]). The count is formed with a twisted
+// factorization with twist index r, where 0 <= r < n.
+//
+// d must have length at least n and lld must have length at least n-1.
+//
+// Dlaneg is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlaneg(n int, d, lld []float64, sigma, pivmin float64, r int) int {
+	// Some architectures propagate NaNs very slowly, so the code computes
+	// counts in blklen chunks. Then a NaN can propagate at most blklen
+	// columns before being detected. This is not a general tuning
+	// parameter; it needs only to be just large enough that the overhead
+	// is tiny in common cases.
+	const blklen = 128
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if r < 0 || n <= r {
+		panic("lapack: r out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(lld) < n-1 {
+		panic(badE)
+	}
+
+	var negcnt int
+
+	// Upper part: L * D * L^T - sigma*I = L+ * D+ * L+^T.
+	t := -sigma
+	for bj := 0; bj < r; bj += blklen {
+		var neg1 int
+		bsav := t
+		for j := bj; j < min(bj+blklen, r); j++ {
+			dplus := d[j] + t
+			if dplus < 0 {
+				neg1++
+			}
+			tmp := t / dplus
+			t = tmp*lld[j] - sigma
+		}
+		// Run a slower version of the above loop if a NaN is detected.
+		if math.IsNaN(t) {
+			neg1 = 0
+			t = bsav
+			for j := bj; j < min(bj+blklen, r); j++ {
+				dplus := d[j] + t
+				if dplus < 0 {
+					neg1++
+				}
+				tmp := t / dplus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				t = tmp*lld[j] - sigma
+			}
+		}
+		negcnt += neg1
+	}
+
+	// Lower part: L * D * L^T - sigma*I = U- * D- * U-^T.
+	p := d[n-1] - sigma
+	for bj := n - 2; bj >= r; bj -= blklen {
+		var neg2 int
+		bsav := p
+		for j := bj; j >= max(bj-blklen+1, r); j-- {
+			dminus := lld[j] + p
+			if dminus < 0 {
+				neg2++
+			}
+			tmp := p / dminus
+			p = tmp*d[j] - sigma
+		}
+		// As above, run a slower version that substitutes 1 for Inf/Inf.
+		if math.IsNaN(p) {
+			neg2 = 0
+			p = bsav
+			for j := bj; j >= max(bj-blklen+1, r); j-- {
+				dminus := lld[j] + p
+				if dminus < 0 {
+					neg2++
+				}
+				tmp := p / dminus
+				if math.IsNaN(tmp) {
+					tmp = 1
+				}
+				p = tmp*d[j] - sigma
+			}
+		}
+		negcnt += neg2
+	}
+
+	// Twist index. t was shifted by sigma initially.
+	gamma := (t + sigma) + p
+	if gamma < 0 {
+		negcnt++
+	}
+	return negcnt
+}
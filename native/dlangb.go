@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlangb computes the specified norm of an m×n band matrix A with kl
+// subdiagonals and ku superdiagonals. The input norm specifies the norm
+// computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// The matrix A is stored in ab in row-major band format as used by
+// blas64.Band, that is the element A[i,j] is stored in ab[i*ldab+kl+j-i] for
+// max(0,i-kl) <= j <= min(n-1,i+ku). ldab must be at least kl+ku+1.
+//
+// If norm == lapack.MaxColumnSum, work must be of length n, and this function
+// will panic otherwise. There are no restrictions on work for the other matrix
+// norms.
+func (impl Implementation) Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64 {
+	checkBandMatrix(m, n, kl, ku, ab, ldab)
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if norm == lapack.MaxColumnSum && len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 0
+	}
+
+	switch norm {
+	case lapack.MaxAbs:
+		var value float64
+		for i := 0; i < min(m, n+kl); i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				aij := math.Abs(ab[i*ldab+kl+j-i])
+				if aij > value || math.IsNaN(aij) {
+					value = aij
+				}
+			}
+		}
+		return value
+	case lapack.MaxColumnSum:
+		for j := 0; j < n; j++ {
+			work[j] = 0
+		}
+		for i := 0; i < min(m, n+kl); i++ {
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				work[j] += math.Abs(ab[i*ldab+kl+j-i])
+			}
+		}
+		var value float64
+		for j := 0; j < n; j++ {
+			if work[j] > value || math.IsNaN(work[j]) {
+				value = work[j]
+			}
+		}
+		return value
+	case lapack.MaxRowSum:
+		var value float64
+		for i := 0; i < min(m, n+kl); i++ {
+			var sum float64
+			for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+				sum += math.Abs(ab[i*ldab+kl+j-i])
+			}
+			if sum > value || math.IsNaN(sum) {
+				value = sum
+			}
+		}
+		return value
+	default:
+		scale := 0.0
+		sum := 1.0
+		for i := 0; i < min(m, n+kl); i++ {
+			l := max(0, i-kl)
+			u := min(n-1, i+ku)
+			scale, sum = impl.Dlassq(u-l+1, ab[i*ldab+kl+l-i:], 1, scale, sum)
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
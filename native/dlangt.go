@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlangt computes the specified norm of an n×n tridiagonal matrix A. The
+// input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// dl, d and du contain the n-1 subdiagonal, n diagonal and n-1 superdiagonal
+// elements of A, respectively. dl and du must have length at least n-1,
+// otherwise Dlangt will panic.
+func (impl Implementation) Dlangt(norm lapack.MatrixNorm, n int, dl, d, du []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(dl) < n-1 {
+		panic(badDL)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(du) < n-1 {
+		panic(badDU)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	dl = dl[:n-1]
+	d = d[:n]
+	du = du[:n-1]
+
+	switch norm {
+	case lapack.MaxAbs:
+		var value float64
+		for _, v := range [][]float64{dl, d, du} {
+			for _, x := range v {
+				x = math.Abs(x)
+				if x > value || math.IsNaN(x) {
+					value = x
+				}
+			}
+		}
+		return value
+	case lapack.MaxColumnSum, lapack.MaxRowSum:
+		if norm == lapack.MaxRowSum {
+			// The ∞-norm of A is the 1-norm of A^T.
+			dl, du = du, dl
+		}
+		var value float64
+		for j := 0; j < n; j++ {
+			sum := math.Abs(d[j])
+			if j > 0 {
+				sum += math.Abs(du[j-1])
+			}
+			if j < n-1 {
+				sum += math.Abs(dl[j])
+			}
+			if sum > value || math.IsNaN(sum) {
+				value = sum
+			}
+		}
+		return value
+	default:
+		scale := 0.0
+		sum := 1.0
+		scale, sum = impl.Dlassq(n, d, 1, scale, sum)
+		if n > 1 {
+			scale, sum = impl.Dlassq(n-1, dl, 1, scale, sum)
+			scale, sum = impl.Dlassq(n-1, du, 1, scale, sum)
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlanhs computes the specified norm of an n×n upper Hessenberg matrix A. The
+// input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// Elements of A below the first subdiagonal are not referenced.
+//
+// If norm == lapack.MaxColumnSum, work must be of length n, and this function
+// will panic otherwise. There are no restrictions on work for the other matrix
+// norms.
+func (impl Implementation) Dlanhs(norm lapack.MatrixNorm, n int, a []float64, lda int, work []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	checkMatrix(n, n, a, lda)
+	if norm == lapack.MaxColumnSum && len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	switch norm {
+	case lapack.MaxAbs:
+		var value float64
+		for i := 0; i < n; i++ {
+			for j := max(0, i-1); j < n; j++ {
+				aij := math.Abs(a[i*lda+j])
+				if aij > value || math.IsNaN(aij) {
+					value = aij
+				}
+			}
+		}
+		return value
+	case lapack.MaxColumnSum:
+		for j := 0; j < n; j++ {
+			work[j] = 0
+		}
+		for i := 0; i < n; i++ {
+			for j := max(0, i-1); j < n; j++ {
+				work[j] += math.Abs(a[i*lda+j])
+			}
+		}
+		var value float64
+		for j := 0; j < n; j++ {
+			if work[j] > value || math.IsNaN(work[j]) {
+				value = work[j]
+			}
+		}
+		return value
+	case lapack.MaxRowSum:
+		var value float64
+		for i := 0; i < n; i++ {
+			var sum float64
+			for j := max(0, i-1); j < n; j++ {
+				sum += math.Abs(a[i*lda+j])
+			}
+			if sum > value || math.IsNaN(sum) {
+				value = sum
+			}
+		}
+		return value
+	default:
+		scale := 0.0
+		sum := 1.0
+		for i := 0; i < n; i++ {
+			l := max(0, i-1)
+			scale, sum = impl.Dlassq(n-l, a[i*lda+l:], 1, scale, sum)
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dlansb computes the specified norm of an n×n symmetric band matrix A with kd
+// super- or subdiagonals. The input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// The upper or lower triangle of A is stored in ab in row-major band format as
+// used by blas64.SymmetricBand, see the documentation for Dpbtrf for a
+// description of the format.
+//
+// If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum, work must have
+// length at least n, and this function will panic otherwise. There are no
+// restrictions on work for the other matrix norms.
+func (impl Implementation) Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	if (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		if uplo == blas.Upper {
+			for i := 0; i < n; i++ {
+				for j := i; j <= min(n-1, i+kd); j++ {
+					aij := math.Abs(ab[i*ldab+j-i])
+					if aij > value || math.IsNaN(aij) {
+						value = aij
+					}
+				}
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				for j := max(0, i-kd); j <= i; j++ {
+					aij := math.Abs(ab[i*ldab+kd+j-i])
+					if aij > value || math.IsNaN(aij) {
+						value = aij
+					}
+				}
+			}
+		}
+		return value
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm.
+		for i := 0; i < n; i++ {
+			work[i] = 0
+		}
+		if uplo == blas.Upper {
+			for i := 0; i < n; i++ {
+				work[i] += math.Abs(ab[i*ldab])
+				for j := i + 1; j <= min(n-1, i+kd); j++ {
+					aij := math.Abs(ab[i*ldab+j-i])
+					work[i] += aij
+					work[j] += aij
+				}
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				for j := max(0, i-kd); j < i; j++ {
+					aij := math.Abs(ab[i*ldab+kd+j-i])
+					work[i] += aij
+					work[j] += aij
+				}
+				work[i] += math.Abs(ab[i*ldab+kd])
+			}
+		}
+		var value float64
+		for i := 0; i < n; i++ {
+			if work[i] > value || math.IsNaN(work[i]) {
+				value = work[i]
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		// Sum the off-diagonal elements twice, then add the diagonal.
+		scale := 0.0
+		sum := 1.0
+		if kd > 0 {
+			if uplo == blas.Upper {
+				for i := 0; i < n-1; i++ {
+					scale, sum = impl.Dlassq(min(kd, n-i-1), ab[i*ldab+1:], 1, scale, sum)
+				}
+			} else {
+				for i := 1; i < n; i++ {
+					l := min(kd, i)
+					scale, sum = impl.Dlassq(l, ab[i*ldab+kd-l:], 1, scale, sum)
+				}
+			}
+			sum *= 2
+		}
+		diag := 0
+		if uplo == blas.Lower {
+			diag = kd
+		}
+		scale, sum = impl.Dlassq(n, ab[diag:], ldab, scale, sum)
+		return scale * math.Sqrt(sum)
+	}
+}
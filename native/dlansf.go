@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dlansf computes the specified norm of an n×n symmetric matrix A stored in
+// rectangular full packed (RFP) format. The input norm specifies the norm
+// computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// The upper or lower triangle of A is stored in a in the RFP format described
+// in the documentation of Dtrttf. transr must be blas.NoTrans or blas.Trans,
+// and a must have length at least n*(n+1)/2, otherwise Dlansf will panic.
+//
+// If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum, work must have
+// length at least n, and this function will panic otherwise. There are no
+// restrictions on work for the other matrix norms.
+func (impl Implementation) Dlansf(norm lapack.MatrixNorm, transr blas.Transpose, uplo blas.Uplo, n int, a []float64, work []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	l := rfpBlocks(transr, uplo, n)
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		for _, v := range a[:n*(n+1)/2] {
+			aij := math.Abs(v)
+			if aij > value || math.IsNaN(aij) {
+				value = aij
+			}
+		}
+		return value
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm. Each
+		// off-diagonal element of the lower triangle contributes to the
+		// sums of the row i and of the column j.
+		for i := 0; i < n; i++ {
+			work[i] = 0
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < i; j++ {
+				aij := math.Abs(a[l.index(i, j)])
+				work[i] += aij
+				work[j] += aij
+			}
+			work[i] += math.Abs(a[l.index(i, i)])
+		}
+		var value float64
+		for i := 0; i < n; i++ {
+			if work[i] > value || math.IsNaN(work[i]) {
+				value = work[i]
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		// Sum the off-diagonal elements twice, then add the diagonal.
+		scale := 0.0
+		sum := 1.0
+		for i := 1; i < n; i++ {
+			for j := 0; j < i; j++ {
+				scale, sum = impl.Dlassq(1, a[l.index(i, j):], 1, scale, sum)
+			}
+		}
+		sum *= 2
+		for i := 0; i < n; i++ {
+			scale, sum = impl.Dlassq(1, a[l.index(i, i):], 1, scale, sum)
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
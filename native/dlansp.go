@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dlansp computes the specified norm of an n×n symmetric matrix A stored in
+// packed format. The input norm specifies the norm computed.
+//  lapack.MaxAbs: the maximum absolute value of an element.
+//  lapack.MaxColumnSum: the maximum column sum of the absolute values of the entries.
+//  lapack.MaxRowSum: the maximum row sum of the absolute values of the entries.
+//  lapack.NormFrob: the square root of the sum of the squares of the entries.
+//
+// The upper or lower triangle of A is stored in ap in row-major packed format
+// as used by blas64.SymmetricPacked, see the documentation for Dpptrf for a
+// description of the format.
+//
+// If norm == lapack.MaxColumnSum or norm == lapack.MaxRowSum, work must have
+// length at least n, and this function will panic otherwise. There are no
+// restrictions on work for the other matrix norms.
+func (impl Implementation) Dlansp(norm lapack.MatrixNorm, uplo blas.Uplo, n int, ap []float64, work []float64) float64 {
+	switch norm {
+	case lapack.MaxRowSum, lapack.MaxColumnSum, lapack.NormFrob, lapack.MaxAbs:
+	default:
+		panic(badNorm)
+	}
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if (norm == lapack.MaxColumnSum || norm == lapack.MaxRowSum) && len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0
+	}
+
+	switch norm {
+	default:
+		panic("unreachable")
+	case lapack.MaxAbs:
+		var value float64
+		for _, v := range ap[:n*(n+1)/2] {
+			aij := math.Abs(v)
+			if aij > value || math.IsNaN(aij) {
+				value = aij
+			}
+		}
+		return value
+	case lapack.MaxRowSum, lapack.MaxColumnSum:
+		// A symmetric matrix has the same 1-norm and ∞-norm.
+		for i := 0; i < n; i++ {
+			work[i] = 0
+		}
+		if uplo == blas.Upper {
+			var k int
+			for i := 0; i < n; i++ {
+				work[i] += math.Abs(ap[k])
+				for j := i + 1; j < n; j++ {
+					aij := math.Abs(ap[k+j-i])
+					work[i] += aij
+					work[j] += aij
+				}
+				k += n - i
+			}
+		} else {
+			var k int
+			for i := 0; i < n; i++ {
+				for j := 0; j < i; j++ {
+					aij := math.Abs(ap[k+j])
+					work[i] += aij
+					work[j] += aij
+				}
+				work[i] += math.Abs(ap[k+i])
+				k += i + 1
+			}
+		}
+		var value float64
+		for i := 0; i < n; i++ {
+			if work[i] > value || math.IsNaN(work[i]) {
+				value = work[i]
+			}
+		}
+		return value
+	case lapack.NormFrob:
+		// Sum the off-diagonal elements twice, then add the diagonal.
+		scale := 0.0
+		sum := 1.0
+		if uplo == blas.Upper {
+			k := 1
+			for i := 0; i < n-1; i++ {
+				scale, sum = impl.Dlassq(n-i-1, ap[k:], 1, scale, sum)
+				k += n - i
+			}
+		} else {
+			for i := 1; i < n; i++ {
+				scale, sum = impl.Dlassq(i, ap[i*(i+1)/2:], 1, scale, sum)
+			}
+		}
+		sum *= 2
+		if uplo == blas.Upper {
+			k := 0
+			for i := 0; i < n; i++ {
+				scale, sum = impl.Dlassq(1, ap[k:], 1, scale, sum)
+				k += n - i
+			}
+		} else {
+			for i := 0; i < n; i++ {
+				scale, sum = impl.Dlassq(1, ap[i*(i+1)/2+i:], 1, scale, sum)
+			}
+		}
+		return scale * math.Sqrt(sum)
+	}
+}
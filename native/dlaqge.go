@@ -0,0 +1,75 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/lapack"
+
+// Dlaqge equilibrates the m×n matrix A using the row and column scaling
+// factors in r and c as computed by Dgeequ. rowcnd, colcnd and amax are the
+// corresponding values returned by Dgeequ.
+//
+// Dlaqge returns the form of equilibration that was applied:
+//  lapack.NoEquilibration:     A is not modified,
+//  lapack.RowEquilibration:    A is overwritten by diag(r)*A,
+//  lapack.ColumnEquilibration: A is overwritten by A*diag(c),
+//  lapack.BothEquilibration:   A is overwritten by diag(r)*A*diag(c).
+// Row scaling is applied if rowcnd is less than 0.1 or if amax is close to
+// underflow or overflow. Column scaling is applied if colcnd is less than 0.1.
+//
+// r must have length at least m and c must have length at least n, otherwise
+// Dlaqge will panic.
+//
+// Dlaqge is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) lapack.EquilibrationType {
+	checkMatrix(m, n, a, lda)
+	if len(r) < m {
+		panic(badSlice)
+	}
+	if len(c) < n {
+		panic(badSlice)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return lapack.NoEquilibration
+	}
+
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	rowScale := rowcnd < thresh || amax < small || amax > large
+	colScale := colcnd < thresh
+	switch {
+	case !rowScale && !colScale:
+		return lapack.NoEquilibration
+	case !rowScale:
+		// Column scaling.
+		for i := 0; i < m; i++ {
+			for j, cj := range c[:n] {
+				a[i*lda+j] *= cj
+			}
+		}
+		return lapack.ColumnEquilibration
+	case !colScale:
+		// Row scaling.
+		for i := 0; i < m; i++ {
+			ri := r[i]
+			for j := range a[i*lda : i*lda+n] {
+				a[i*lda+j] *= ri
+			}
+		}
+		return lapack.RowEquilibration
+	default:
+		// Row and column scaling.
+		for i := 0; i < m; i++ {
+			ri := r[i]
+			for j, cj := range c[:n] {
+				a[i*lda+j] *= ri * cj
+			}
+		}
+		return lapack.BothEquilibration
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dlaqsy equilibrates the n×n symmetric matrix A using the scaling factors in
+// s as computed by Dpoequ. scond and amax are the corresponding values
+// returned by Dpoequ. Only the triangle of A specified by uplo is referenced.
+//
+// Dlaqsy returns the form of equilibration that was applied:
+//  lapack.NoEquilibration:        A is not modified,
+//  lapack.SymmetricEquilibration: A is overwritten by diag(s)*A*diag(s).
+// Scaling is applied if scond is less than 0.1 or if amax is close to
+// underflow or overflow.
+//
+// s must have length at least n, otherwise Dlaqsy will panic.
+//
+// Dlaqsy is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) lapack.EquilibrationType {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(s) < n {
+		panic(badSlice)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return lapack.NoEquilibration
+	}
+
+	const thresh = 0.1
+	small := dlamchS / dlamchP
+	large := 1 / small
+
+	if scond >= thresh && amax >= small && amax <= large {
+		// No equilibration.
+		return lapack.NoEquilibration
+	}
+
+	// Replace A by diag(s) * A * diag(s).
+	if uplo == blas.Upper {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := i; j < n; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	} else {
+		for i := 0; i < n; i++ {
+			si := s[i]
+			for j := 0; j <= i; j++ {
+				a[i*lda+j] *= si * s[j]
+			}
+		}
+	}
+	return lapack.SymmetricEquilibration
+}
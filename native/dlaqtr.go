@@ -0,0 +1,472 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlaqtr solves the real quasi-triangular system
+//  op(T)*p = scale*c,                if isReal is true,
+// or the complex quasi-triangular system
+//  op(T + i*B)*(p + i*q) = scale*(c + i*d),  if isReal is false,
+// in real arithmetic, where T is an n×n upper quasi-triangular matrix in
+// Schur canonical form. If trans is false, op(A) = A, otherwise op(A) is the
+// conjugate transpose of A.
+//
+// If isReal is false, the first diagonal block of T must be 1×1 and B is the
+// specially structured n×n matrix
+//  B = [ b[0] b[1] ... b[n-1] ]
+//      [       w              ]
+//      [            w         ]
+//      [                .     ]
+//      [                    w ]
+// and b must have length at least n. If isReal is true, b and w are not
+// referenced.
+//
+// On entry, x contains the right-hand side. If isReal is true, x holds c and
+// must have length at least n. If isReal is false, x[:n] holds c and x[n:2*n]
+// holds d, and x must have length at least 2*n. On return, x is overwritten by
+// the solution, p or p and q, respectively.
+//
+// scale is a scaling factor less than or equal to 1 chosen to avoid overflow
+// in the solution.
+//
+// work must have length at least n, otherwise Dlaqtr will panic.
+//
+// ok will be false if some diagonal block of T, or of T + i*B, had to be
+// perturbed by a small number to keep it nonsingular.
+//
+// Dlaqtr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlaqtr(trans, isReal bool, n int, t []float64, ldt int, b []float64, w float64, x, work []float64) (scale float64, ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, t, ldt)
+	switch {
+	case !isReal && len(b) < n:
+		panic("lapack: insufficient length of b")
+	case isReal && len(x) < n:
+		panic("lapack: insufficient length of x")
+	case !isReal && len(x) < 2*n:
+		panic("lapack: insufficient length of x")
+	case len(work) < n:
+		panic(badWork)
+	}
+
+	ok = true
+	scale = 1
+	if n == 0 {
+		return scale, ok
+	}
+
+	bi := blas64.Implementation()
+
+	// Set constants to control overflow.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+	bignum := 1 / smlnum
+
+	xnorm := impl.Dlange(lapack.MaxAbs, n, n, t, ldt, nil)
+	if !isReal {
+		xnorm = math.Max(xnorm, math.Abs(w))
+		for _, v := range b[:n] {
+			xnorm = math.Max(xnorm, math.Abs(v))
+		}
+	}
+	smin := math.Max(smlnum, eps*xnorm)
+
+	// Compute the 1-norm of each column of the strictly upper triangular
+	// part of T to control overflow in the triangular solver.
+	work[0] = 0
+	for j := 1; j < n; j++ {
+		work[j] = bi.Dasum(j, t[j:], ldt)
+	}
+	if !isReal {
+		for i := 1; i < n; i++ {
+			work[i] += math.Abs(b[i])
+		}
+	}
+
+	n2 := 2 * n
+	n1 := n
+	if !isReal {
+		n1 = n2
+	}
+	k := bi.Idamax(n1, x, 1)
+	xmax := math.Abs(x[k])
+	if xmax > bignum {
+		scale = bignum / xmax
+		bi.Dscal(n1, scale, x, 1)
+		xmax = bignum
+	}
+
+	var d, v [4]float64
+	const ldd, ldv = 2, 2
+
+	if isReal {
+		if !trans {
+			// Solve T*p = scale*c.
+			for j := n - 1; j >= 0; j-- {
+				j1, j2 := j, j
+				if j > 0 && t[j*ldt+j-1] != 0 {
+					j1 = j - 1
+				}
+				if j1 == j2 {
+					// Meet 1×1 diagonal block. Scale to avoid
+					// overflow when computing x[j] = b[j]/T[j,j].
+					xj := math.Abs(x[j1])
+					tjj := math.Abs(t[j1*ldt+j1])
+					tmp := t[j1*ldt+j1]
+					if tjj < smin {
+						tmp = smin
+						tjj = smin
+						ok = false
+					}
+					if xj == 0 {
+						continue
+					}
+					if tjj < 1 && xj > bignum*tjj {
+						rec := 1 / xj
+						bi.Dscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+					x[j1] /= tmp
+					xj = math.Abs(x[j1])
+
+					// Scale x if necessary to avoid overflow
+					// when adding a multiple of column j1 of T.
+					if xj > 1 {
+						rec := 1 / xj
+						if work[j1] > (bignum-xmax)*rec {
+							bi.Dscal(n, rec, x, 1)
+							scale *= rec
+						}
+					}
+					if j1 > 0 {
+						bi.Daxpy(j1, -x[j1], t[j1:], ldt, x, 1)
+						k = bi.Idamax(j1, x, 1)
+						xmax = math.Abs(x[k])
+					}
+					continue
+				}
+
+				// Meet 2×2 diagonal block. Call 2×2 linear system
+				// solver to take care of possible overflow by
+				// scaling factor.
+				d[0] = x[j1]
+				d[ldd] = x[j2]
+				scaloc, _, okloc := impl.Dlaln2(false, 2, 1, smin, 1, t[j1*ldt+j1:], ldt, 1, 1, d[:], ldd, 0, 0, v[:], ldv)
+				if !okloc {
+					ok = false
+				}
+				if scaloc != 1 {
+					bi.Dscal(n, scaloc, x, 1)
+					scale *= scaloc
+				}
+				x[j1] = v[0]
+				x[j2] = v[ldv]
+
+				// Scale v[0] (= x[j1]) and/or v[ldv] (= x[j2]) to
+				// avoid overflow in updating the right-hand side.
+				xj := math.Max(math.Abs(v[0]), math.Abs(v[ldv]))
+				if xj > 1 {
+					rec := 1 / xj
+					if math.Max(work[j1], work[j2]) > (bignum-xmax)*rec {
+						bi.Dscal(n, rec, x, 1)
+						scale *= rec
+					}
+				}
+
+				// Update the right-hand side.
+				if j1 > 0 {
+					bi.Daxpy(j1, -x[j1], t[j1:], ldt, x, 1)
+					bi.Daxpy(j1, -x[j2], t[j2:], ldt, x, 1)
+					k = bi.Idamax(j1, x, 1)
+					xmax = math.Abs(x[k])
+				}
+				j--
+			}
+			return scale, ok
+		}
+
+		// Solve T^T*p = scale*c.
+		for j := 0; j < n; j++ {
+			j1, j2 := j, j
+			if j < n-1 && t[(j+1)*ldt+j] != 0 {
+				j2 = j + 1
+			}
+			if j1 == j2 {
+				// 1×1 diagonal block. Scale if necessary to avoid
+				// overflow in forming the right-hand side element by
+				// inner product.
+				xj := math.Abs(x[j1])
+				if xmax > 1 {
+					rec := 1 / xmax
+					if work[j1] > (bignum-xj)*rec {
+						bi.Dscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+				}
+
+				x[j1] -= bi.Ddot(j1, t[j1:], ldt, x, 1)
+
+				xj = math.Abs(x[j1])
+				tjj := math.Abs(t[j1*ldt+j1])
+				tmp := t[j1*ldt+j1]
+				if tjj < smin {
+					tmp = smin
+					tjj = smin
+					ok = false
+				}
+				if tjj < 1 && xj > bignum*tjj {
+					rec := 1 / xj
+					bi.Dscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+				x[j1] /= tmp
+				xmax = math.Max(xmax, math.Abs(x[j1]))
+				continue
+			}
+
+			// 2×2 diagonal block. Scale if necessary to avoid overflow
+			// in forming the right-hand side elements by inner product.
+			xj := math.Max(math.Abs(x[j1]), math.Abs(x[j2]))
+			if xmax > 1 {
+				rec := 1 / xmax
+				if math.Max(work[j2], work[j1]) > (bignum-xj)*rec {
+					bi.Dscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+			}
+
+			d[0] = x[j1] - bi.Ddot(j1, t[j1:], ldt, x, 1)
+			d[ldd] = x[j2] - bi.Ddot(j1, t[j2:], ldt, x, 1)
+			scaloc, _, okloc := impl.Dlaln2(true, 2, 1, smin, 1, t[j1*ldt+j1:], ldt, 1, 1, d[:], ldd, 0, 0, v[:], ldv)
+			if !okloc {
+				ok = false
+			}
+			if scaloc != 1 {
+				bi.Dscal(n, scaloc, x, 1)
+				scale *= scaloc
+			}
+			x[j1] = v[0]
+			x[j2] = v[ldv]
+			xmax = math.Max(math.Abs(x[j1]), math.Max(math.Abs(x[j2]), xmax))
+			j++
+		}
+		return scale, ok
+	}
+
+	sminw := math.Max(eps*math.Abs(w), smin)
+	if !trans {
+		// Solve (T + i*B)*(p + i*q) = c + i*d.
+		for j := n - 1; j >= 0; j-- {
+			j1, j2 := j, j
+			if j > 0 && t[j*ldt+j-1] != 0 {
+				j1 = j - 1
+			}
+			if j1 == j2 {
+				// 1×1 diagonal block. Scale if necessary to avoid
+				// overflow in division.
+				z := w
+				if j1 == 0 {
+					z = b[0]
+				}
+				xj := math.Abs(x[j1]) + math.Abs(x[n+j1])
+				tjj := math.Abs(t[j1*ldt+j1]) + math.Abs(z)
+				tmp := t[j1*ldt+j1]
+				if tjj < sminw {
+					tmp = sminw
+					tjj = sminw
+					ok = false
+				}
+				if xj == 0 {
+					continue
+				}
+				if tjj < 1 && xj > bignum*tjj {
+					rec := 1 / xj
+					bi.Dscal(n2, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+				s := complex(x[j1], x[n+j1]) / complex(tmp, z)
+				x[j1] = real(s)
+				x[n+j1] = imag(s)
+				xj = math.Abs(x[j1]) + math.Abs(x[n+j1])
+
+				// Scale x if necessary to avoid overflow when adding
+				// a multiple of column j1 of T.
+				if xj > 1 {
+					rec := 1 / xj
+					if work[j1] > (bignum-xmax)*rec {
+						bi.Dscal(n2, rec, x, 1)
+						scale *= rec
+					}
+				}
+
+				if j1 > 0 {
+					bi.Daxpy(j1, -x[j1], t[j1:], ldt, x, 1)
+					bi.Daxpy(j1, -x[n+j1], t[j1:], ldt, x[n:], 1)
+
+					x[0] += b[j1] * x[n+j1]
+					x[n] -= b[j1] * x[j1]
+
+					xmax = 0
+					for k := 0; k < j1; k++ {
+						xmax = math.Max(xmax, math.Abs(x[k])+math.Abs(x[k+n]))
+					}
+				}
+				continue
+			}
+
+			// Meet 2×2 diagonal block.
+			d[0] = x[j1]
+			d[ldd] = x[j2]
+			d[1] = x[n+j1]
+			d[ldd+1] = x[n+j2]
+			scaloc, _, okloc := impl.Dlaln2(false, 2, 2, sminw, 1, t[j1*ldt+j1:], ldt, 1, 1, d[:], ldd, 0, -w, v[:], ldv)
+			if !okloc {
+				ok = false
+			}
+			if scaloc != 1 {
+				bi.Dscal(n2, scaloc, x, 1)
+				scale *= scaloc
+			}
+			x[j1] = v[0]
+			x[j2] = v[ldv]
+			x[n+j1] = v[1]
+			x[n+j2] = v[ldv+1]
+
+			// Scale x[j1], ... to avoid overflow in updating the
+			// right-hand side.
+			xj := math.Max(math.Abs(v[0])+math.Abs(v[1]), math.Abs(v[ldv])+math.Abs(v[ldv+1]))
+			if xj > 1 {
+				rec := 1 / xj
+				if math.Max(work[j1], work[j2]) > (bignum-xmax)*rec {
+					bi.Dscal(n2, rec, x, 1)
+					scale *= rec
+				}
+			}
+
+			// Update the right-hand side.
+			if j1 > 0 {
+				bi.Daxpy(j1, -x[j1], t[j1:], ldt, x, 1)
+				bi.Daxpy(j1, -x[j2], t[j2:], ldt, x, 1)
+
+				bi.Daxpy(j1, -x[n+j1], t[j1:], ldt, x[n:], 1)
+				bi.Daxpy(j1, -x[n+j2], t[j2:], ldt, x[n:], 1)
+
+				x[0] += b[j1]*x[n+j1] + b[j2]*x[n+j2]
+				x[n] -= b[j1]*x[j1] + b[j2]*x[j2]
+
+				xmax = 0
+				for k := 0; k < j1; k++ {
+					xmax = math.Max(xmax, math.Abs(x[k])+math.Abs(x[k+n]))
+				}
+			}
+			j--
+		}
+		return scale, ok
+	}
+
+	// Solve (T + i*B)^H*(p + i*q) = c + i*d.
+	for j := 0; j < n; j++ {
+		j1, j2 := j, j
+		if j < n-1 && t[(j+1)*ldt+j] != 0 {
+			j2 = j + 1
+		}
+		if j1 == j2 {
+			// 1×1 diagonal block. Scale if necessary to avoid overflow
+			// in forming the right-hand side element by inner product.
+			xj := math.Abs(x[j1]) + math.Abs(x[j1+n])
+			if xmax > 1 {
+				rec := 1 / xmax
+				if work[j1] > (bignum-xj)*rec {
+					bi.Dscal(n2, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+			}
+
+			x[j1] -= bi.Ddot(j1, t[j1:], ldt, x, 1)
+			x[n+j1] -= bi.Ddot(j1, t[j1:], ldt, x[n:], 1)
+			if j1 > 0 {
+				x[j1] -= b[j1] * x[n]
+				x[n+j1] += b[j1] * x[0]
+			}
+			xj = math.Abs(x[j1]) + math.Abs(x[j1+n])
+
+			z := w
+			if j1 == 0 {
+				z = b[0]
+			}
+
+			// Scale if necessary to avoid overflow in complex division.
+			tjj := math.Abs(t[j1*ldt+j1]) + math.Abs(z)
+			tmp := t[j1*ldt+j1]
+			if tjj < sminw {
+				tmp = sminw
+				tjj = sminw
+				ok = false
+			}
+			if tjj < 1 && xj > bignum*tjj {
+				rec := 1 / xj
+				bi.Dscal(n2, rec, x, 1)
+				scale *= rec
+				xmax *= rec
+			}
+			s := complex(x[j1], x[n+j1]) / complex(tmp, -z)
+			x[j1] = real(s)
+			x[j1+n] = imag(s)
+			xmax = math.Max(math.Abs(x[j1])+math.Abs(x[j1+n]), xmax)
+			continue
+		}
+
+		// 2×2 diagonal block. Scale if necessary to avoid overflow in
+		// forming the right-hand side element by inner product.
+		xj := math.Max(math.Abs(x[j1])+math.Abs(x[n+j1]), math.Abs(x[j2])+math.Abs(x[n+j2]))
+		if xmax > 1 {
+			rec := 1 / xmax
+			if math.Max(work[j1], work[j2]) > (bignum-xj)/xmax {
+				bi.Dscal(n2, rec, x, 1)
+				scale *= rec
+				xmax *= rec
+			}
+		}
+
+		d[0] = x[j1] - bi.Ddot(j1, t[j1:], ldt, x, 1)
+		d[ldd] = x[j2] - bi.Ddot(j1, t[j2:], ldt, x, 1)
+		d[1] = x[n+j1] - bi.Ddot(j1, t[j1:], ldt, x[n:], 1)
+		d[ldd+1] = x[n+j2] - bi.Ddot(j1, t[j2:], ldt, x[n:], 1)
+		d[0] -= b[j1] * x[n]
+		d[ldd] -= b[j2] * x[n]
+		d[1] += b[j1] * x[0]
+		d[ldd+1] += b[j2] * x[0]
+
+		scaloc, _, okloc := impl.Dlaln2(true, 2, 2, sminw, 1, t[j1*ldt+j1:], ldt, 1, 1, d[:], ldd, 0, w, v[:], ldv)
+		if !okloc {
+			ok = false
+		}
+		if scaloc != 1 {
+			bi.Dscal(n2, scaloc, x, 1)
+			scale *= scaloc
+		}
+		x[j1] = v[0]
+		x[j2] = v[ldv]
+		x[n+j1] = v[1]
+		x[n+j2] = v[ldv+1]
+		xmax = math.Max(math.Abs(x[j1])+math.Abs(x[n+j1]), math.Max(math.Abs(x[j2])+math.Abs(x[n+j2]), xmax))
+		j++
+	}
+	return scale, ok
+}
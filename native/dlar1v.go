@@ -0,0 +1,285 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlar1v computes the (scaled) r-th column of the inverse of the submatrix in
+// rows b1 through bn of the tridiagonal matrix
+//  L * D * L^T - lambda*I.
+// When lambda is close to an eigenvalue, the computed vector is an accurate
+// eigenvector. Usually r corresponds to the index where the eigenvector is
+// largest in magnitude.
+//
+// The following steps accomplish this computation:
+//  - Stationary qd transform, L * D * L^T - lambda*I = L+ * D+ * L+^T,
+//  - Progressive qd transform, L * D * L^T - lambda*I = U- * D- * U-^T,
+//  - Computation of the diagonal elements of the inverse of
+//    L * D * L^T - lambda*I by combining the above transforms, and choosing
+//    r as the index where the diagonal of the inverse is (one of the) largest
+//    in magnitude,
+//  - Computation of the (scaled) r-th column of the inverse using the twisted
+//    factorization obtained by combining the top part of the stationary and
+//    the bottom part of the progressive transform.
+//
+// d contains the n diagonal elements of D, and l, ld and lld contain the n-1
+// sub-diagonal elements of L, L*D and L*L*D respectively. pivmin is the
+// minimum pivot allowed in the Sturm sequence and gaptol is the tolerance
+// that indicates when eigenvector entries are negligible with respect to
+// their contribution to the residual.
+//
+// On entry, z must have its elements in rows b1 through bn set to zero. On
+// return, z contains the (scaled) r-th column of the inverse and isuppz[0]
+// and isuppz[1] hold the first and last indices of the support of z. z must
+// have length at least n and isuppz must have length at least 2.
+//
+// If r is in [b1,bn], it is used as the twist index. Otherwise the twist
+// index is chosen as the index in [b1,bn] at which the diagonal of the
+// inverse is largest in magnitude. The twist index used is returned in rOut.
+//
+// If wantnc is true, negcnt is the number of pivots < pivmin in the matrix
+// factorization L * D * L^T, otherwise negcnt is -1. ztz is the square of the
+// 2-norm of z, mingma is the reciprocal of the largest (in magnitude) diagonal
+// element of the inverse, nrminv is 1/sqrt(ztz), resid is the residual of the
+// FP vector, resid = |mingma|/sqrt(ztz), and rqcorr is the Rayleigh quotient
+// correction to lambda, rqcorr = mingma/ztz.
+//
+// work must have length at least 4*n.
+//
+// Dlar1v is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlar1v(n, b1, bn int, lambda float64, d, l, ld, lld []float64, pivmin, gaptol float64, z []float64, wantnc bool, r int, isuppz []int, work []float64) (negcnt int, ztz, mingma float64, rOut int, nrminv, resid, rqcorr float64) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if b1 < 0 || n <= b1 || bn < b1 || n <= bn {
+		panic("lapack: bad submatrix bounds")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(l) < n-1 || len(ld) < n-1 || len(lld) < n-1 {
+		panic(badE)
+	}
+	if len(z) < n {
+		panic(badZ)
+	}
+	if len(isuppz) < 2 {
+		panic(badIsuppz)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+
+	eps := dlamchP
+
+	var r1, r2 int
+	if r < b1 || bn < r {
+		r1 = b1
+		r2 = bn
+	} else {
+		r1 = r
+		r2 = r
+	}
+
+	// Storage for L+ is in work[:n], storage for U- is in work[n:2*n].
+	// The values of the stationary transform at index i are stored in
+	// work[inds+i] for i >= b1-1, and the values of the progressive
+	// transform are stored in work[indp+i].
+	const indlpl = 0
+	indumn := n
+	inds := 2*n + 1
+	indp := 3*n + 1
+
+	if b1 == 0 {
+		work[inds-1] = 0
+	} else {
+		work[inds+b1-1] = lld[b1-1]
+	}
+
+	// Compute the stationary transform (using the differential form)
+	// until the index r2.
+	var neg1 int
+	s := work[inds+b1-1] - lambda
+	for i := b1; i < r1; i++ {
+		dplus := d[i] + s
+		work[indlpl+i] = ld[i] / dplus
+		if dplus < 0 {
+			neg1++
+		}
+		work[inds+i] = s * work[indlpl+i] * l[i]
+		s = work[inds+i] - lambda
+	}
+	sawnan1 := math.IsNaN(s)
+	if !sawnan1 {
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + s
+			work[indlpl+i] = ld[i] / dplus
+			work[inds+i] = s * work[indlpl+i] * l[i]
+			s = work[inds+i] - lambda
+		}
+		sawnan1 = math.IsNaN(s)
+	}
+	if sawnan1 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg1 = 0
+		s = work[inds+b1-1] - lambda
+		for i := b1; i < r1; i++ {
+			dplus := d[i] + s
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			work[indlpl+i] = ld[i] / dplus
+			if dplus < 0 {
+				neg1++
+			}
+			work[inds+i] = s * work[indlpl+i] * l[i]
+			if work[indlpl+i] == 0 {
+				work[inds+i] = lld[i]
+			}
+			s = work[inds+i] - lambda
+		}
+		for i := r1; i < r2; i++ {
+			dplus := d[i] + s
+			if math.Abs(dplus) < pivmin {
+				dplus = -pivmin
+			}
+			work[indlpl+i] = ld[i] / dplus
+			work[inds+i] = s * work[indlpl+i] * l[i]
+			if work[indlpl+i] == 0 {
+				work[inds+i] = lld[i]
+			}
+			s = work[inds+i] - lambda
+		}
+	}
+
+	// Compute the progressive transform (using the differential form)
+	// until the index r1.
+	var neg2 int
+	work[indp+bn-1] = d[bn] - lambda
+	for i := bn - 1; i >= r1; i-- {
+		dminus := lld[i] + work[indp+i]
+		tmp := d[i] / dminus
+		if dminus < 0 {
+			neg2++
+		}
+		work[indumn+i] = l[i] * tmp
+		work[indp+i-1] = work[indp+i]*tmp - lambda
+	}
+	sawnan2 := math.IsNaN(work[indp+r1-1])
+	if sawnan2 {
+		// Run a slower version of the above loop if a NaN is detected.
+		neg2 = 0
+		for i := bn - 1; i >= r1; i-- {
+			dminus := lld[i] + work[indp+i]
+			if math.Abs(dminus) < pivmin {
+				dminus = -pivmin
+			}
+			tmp := d[i] / dminus
+			if dminus < 0 {
+				neg2++
+			}
+			work[indumn+i] = l[i] * tmp
+			work[indp+i-1] = work[indp+i]*tmp - lambda
+			if tmp == 0 {
+				work[indp+i-1] = d[i] - lambda
+			}
+		}
+	}
+
+	// Find the index (from r1 to r2) of the largest (in magnitude) diagonal
+	// element of the inverse.
+	mingma = work[inds+r1-1] + work[indp+r1-1]
+	if mingma < 0 {
+		neg1++
+	}
+	if wantnc {
+		negcnt = neg1 + neg2
+	} else {
+		negcnt = -1
+	}
+	if math.Abs(mingma) == 0 {
+		mingma = eps * work[inds+r1-1]
+	}
+	r = r1
+	for i := r1; i < r2; i++ {
+		tmp := work[inds+i] + work[indp+i]
+		if tmp == 0 {
+			tmp = eps * work[inds+i]
+		}
+		if math.Abs(tmp) <= math.Abs(mingma) {
+			mingma = tmp
+			r = i + 1
+		}
+	}
+
+	// Compute the FP vector: solve N^T * v = e_r.
+	isuppz[0] = b1
+	isuppz[1] = bn
+	z[r] = 1
+	ztz = 1
+
+	// Compute the FP vector upwards from r.
+	if !sawnan1 && !sawnan2 {
+		for i := r - 1; i >= b1; i-- {
+			z[i] = -(work[indlpl+i] * z[i+1])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+	} else {
+		// Run a slower loop if a NaN occurred.
+		for i := r - 1; i >= b1; i-- {
+			if z[i+1] == 0 {
+				z[i] = -(ld[i+1] / ld[i]) * z[i+2]
+			} else {
+				z[i] = -(work[indlpl+i] * z[i+1])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i] = 0
+				isuppz[0] = i + 1
+				break
+			}
+			ztz += z[i] * z[i]
+		}
+	}
+
+	// Compute the FP vector downwards from r.
+	if !sawnan1 && !sawnan2 {
+		for i := r; i < bn; i++ {
+			z[i+1] = -(work[indumn+i] * z[i])
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	} else {
+		// Run a slower loop if a NaN occurred.
+		for i := r; i < bn; i++ {
+			if z[i] == 0 {
+				z[i+1] = -(ld[i-1] / ld[i]) * z[i-1]
+			} else {
+				z[i+1] = -(work[indumn+i] * z[i])
+			}
+			if (math.Abs(z[i])+math.Abs(z[i+1]))*math.Abs(ld[i]) < gaptol {
+				z[i+1] = 0
+				isuppz[1] = i
+				break
+			}
+			ztz += z[i+1] * z[i+1]
+		}
+	}
+
+	// Compute quantities for the convergence test.
+	tmp := 1 / ztz
+	nrminv = math.Sqrt(tmp)
+	resid = math.Abs(mingma) * nrminv
+	rqcorr = mingma * tmp
+	return negcnt, ztz, mingma, r, nrminv, resid, rqcorr
+}
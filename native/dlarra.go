@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarra computes the splitting points of the symmetric tridiagonal matrix T
+// with diagonal d and off-diagonal e, both of length at least n and n-1
+// respectively. e2 contains the squares of the off-diagonal elements.
+//
+// If spltol < 0, T is split where |e[i]| <= |spltol|*tnrm, where tnrm is the
+// norm of T. Otherwise T is split where
+//  |e[i]| <= spltol * sqrt(|d[i]|) * sqrt(|d[i+1]|),
+// a criterion that guarantees relative accuracy. The off-diagonal elements at
+// the splitting points are set to zero in e and e2.
+//
+// Dlarra returns the number of blocks nsplit. On return, isplit[j] contains
+// the index of the last row of the j-th block. isplit must have length at
+// least n.
+//
+// Dlarra is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarra(n int, d, e, e2 []float64, spltol, tnrm float64, isplit []int) (nsplit int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 1
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 || len(e2) < n-1 {
+		panic(badE)
+	}
+	if len(isplit) < n {
+		panic(badIndex)
+	}
+
+	if spltol < 0 {
+		// Criterion based on the absolute off-diagonal value.
+		tmp1 := math.Abs(spltol) * tnrm
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= tmp1 {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	} else {
+		// Criterion that guarantees relative accuracy.
+		for i := 0; i < n-1; i++ {
+			if math.Abs(e[i]) <= spltol*math.Sqrt(math.Abs(d[i]))*math.Sqrt(math.Abs(d[i+1])) {
+				e[i] = 0
+				e2[i] = 0
+				isplit[nsplit] = i
+				nsplit++
+			}
+		}
+	}
+	isplit[nsplit] = n - 1
+	return nsplit + 1
+}
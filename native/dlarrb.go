@@ -0,0 +1,199 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarrb refines, given the relatively robust representation
+//  L * D * L^T
+// of a symmetric tridiagonal matrix, the eigenvalue approximations of
+// L * D * L^T with indices ifirst through ilast by bisection.
+//
+// d contains the n diagonal elements of D and lld contains the n-1 elements
+// l[i]*l[i]*d[i]. The eigenvalue with index i is stored in w[i-offset] with
+// error bound werr[i-offset], so that the eigenvalue lies in
+//  [w[i-offset]-werr[i-offset], w[i-offset]+werr[i-offset]].
+// wgap[i-offset] is the gap to the right of the i-th eigenvalue. On return, w,
+// werr and wgap contain the refined approximations, error bounds and gaps.
+//
+// An interval [left,right] has converged if
+//  right-left < max(rtol1*gap, rtol2*max(|left|,|right|)),
+// where gap is the (estimated) distance to the nearest eigenvalue.
+//
+// work must have length at least 2*n and iwork must have length at least 2*n.
+// pivmin is the minimum pivot in the Sturm sequence, spdiam is the spectral
+// diameter of the matrix and twist is the twist index for the twisted
+// factorization used to compute the Sturm counts. If twist is not in
+// [0,n), n-1 is used.
+//
+// Dlarrb is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrb(n int, d, lld []float64, ifirst, ilast int, rtol1, rtol2 float64, offset int, w, wgap, werr, work []float64, iwork []int, pivmin, spdiam float64, twist int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(lld) < n-1 {
+		panic(badE)
+	}
+	if len(work) < 2*n || len(iwork) < 2*n {
+		panic(badWork)
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+	mnwdth := 2 * pivmin
+
+	r := twist
+	if r < 0 || n <= r {
+		r = n - 1
+	}
+
+	// Initialize the unconverged intervals in [work[2*i], work[2*i+1]]. The
+	// Sturm count of work[2*i] is arranged to be i, while the count of
+	// work[2*i+1] is stored in iwork[2*i+1]. iwork[2*i] for an unconverged
+	// interval is set to the index of the next unconverged interval, and is
+	// -1 or 0 for a converged interval. Thus a linked list of unconverged
+	// intervals is set up.
+	i1 := ifirst
+	// The number of unconverged intervals.
+	var nint int
+	// The last unconverged interval found.
+	prev := -1
+	rgap := wgap[i1-offset]
+	for i := i1; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		right := w[ii] + werr[ii]
+		lgap := rgap
+		rgap = wgap[ii]
+		gap := math.Min(lgap, rgap)
+
+		// Make sure that [left,right] contains the desired eigenvalue.
+		// Compute the negcount from the dstqds factorization
+		//  L+ * D+ * L+^T = L * D * L^T - left.
+		back := werr[ii]
+		for {
+			negcnt := impl.Dlaneg(n, d, lld, left, pivmin, r)
+			if negcnt <= i {
+				break
+			}
+			left -= back
+			back *= 2
+		}
+
+		// Compute the negcount from the dstqds factorization
+		//  L+ * D+ * L+^T = L * D * L^T - right.
+		back = werr[ii]
+		var negcnt int
+		for {
+			negcnt = impl.Dlaneg(n, d, lld, right, pivmin, r)
+			if negcnt > i {
+				break
+			}
+			right += back
+			back *= 2
+		}
+
+		width := 0.5 * math.Abs(left-right)
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+		cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+		if width <= cvrgd || width <= mnwdth {
+			// This interval has already converged and does not need
+			// refinement. Note that the gaps might change through
+			// refining the eigenvalues, however, they can only get
+			// bigger. Remove it from the list.
+			iwork[k] = -1
+			// Make sure that i1 always points to the first unconverged
+			// interval.
+			if i == i1 && i < ilast {
+				i1 = i + 1
+			}
+			if prev >= i1 && i <= ilast {
+				iwork[2*prev] = i + 1
+			}
+		} else {
+			// Unconverged interval found.
+			prev = i
+			nint++
+			iwork[k] = i + 1
+			iwork[k+1] = negcnt
+		}
+		work[k] = left
+		work[k+1] = right
+	}
+
+	// Do while there are still unconverged intervals and while
+	// iter < maxitr.
+	for iter := 0; nint > 0 && iter <= maxitr; iter++ {
+		prev = i1 - 1
+		i := i1
+		olnint := nint
+
+		for ip := 0; ip < olnint; ip++ {
+			k := 2 * i
+			ii := i - offset
+			rgap := wgap[ii]
+			lgap := rgap
+			if ii > 0 {
+				lgap = wgap[ii-1]
+			}
+			gap := math.Min(lgap, rgap)
+			next := iwork[k]
+			left := work[k]
+			right := work[k+1]
+			mid := 0.5 * (left + right)
+			// Semi-width of the interval.
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			cvrgd := math.Max(rtol1*gap, rtol2*tmp)
+			if width <= cvrgd || width <= mnwdth || iter == maxitr {
+				// Reduce the number of unconverged intervals.
+				nint--
+				// Mark the interval as converged.
+				iwork[k] = 0
+				if i1 == i {
+					i1 = next
+				} else if prev >= i1 {
+					// prev holds the last unconverged interval
+					// previously examined.
+					iwork[2*prev] = next
+				}
+				i = next
+				continue
+			}
+			prev = i
+
+			// Perform one bisection step.
+			negcnt := impl.Dlaneg(n, d, lld, mid, pivmin, r)
+			if negcnt <= i {
+				work[k] = mid
+			} else {
+				work[k+1] = mid
+			}
+			i = next
+		}
+	}
+
+	// At this point, all the intervals have converged.
+	for i := ifirst; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		// All intervals marked by 0 have been refined.
+		if iwork[k] == 0 {
+			w[ii] = 0.5 * (work[k] + work[k+1])
+			werr[ii] = work[k+1] - w[ii]
+		}
+	}
+
+	for i := ifirst + 1; i <= ilast; i++ {
+		ii := i - offset
+		wgap[ii-1] = math.Max(0, w[ii]-werr[ii]-w[ii-1]-werr[ii-1])
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dlarrc finds the number of eigenvalues of the symmetric tridiagonal matrix T
+// that lie in the half-open interval (vl,vu].
+//
+// If tridiag is true, T is given by its diagonal d and off-diagonal e.
+// Otherwise T is given by its factorization L * D * L^T, where d holds the
+// diagonal of D and e holds the off-diagonal of the unit bidiagonal matrix L.
+// d must have length at least n and e must have length at least n-1.
+//
+// Dlarrc returns the number of eigenvalues in (vl,vu], the number of
+// eigenvalues less than or equal to vl and the number of eigenvalues less
+// than or equal to vu.
+//
+// Dlarrc is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarrc(tridiag bool, n int, vl, vu float64, d, e []float64, pivmin float64) (eigcnt, lcnt, rcnt int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0, 0, 0
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	if tridiag {
+		// Sturm sequence count on T.
+		lpivot := d[0] - vl
+		rpivot := d[0] - vu
+		if lpivot <= 0 {
+			lcnt++
+		}
+		if rpivot <= 0 {
+			rcnt++
+		}
+		for i := 0; i < n-1; i++ {
+			tmp := e[i] * e[i]
+			lpivot = (d[i+1] - vl) - tmp/lpivot
+			rpivot = (d[i+1] - vu) - tmp/rpivot
+			if lpivot <= 0 {
+				lcnt++
+			}
+			if rpivot <= 0 {
+				rcnt++
+			}
+		}
+		return rcnt - lcnt, lcnt, rcnt
+	}
+
+	// Sturm sequence count on L * D * L^T.
+	sl := -vl
+	su := -vu
+	for i := 0; i < n-1; i++ {
+		lpivot := d[i] + sl
+		rpivot := d[i] + su
+		if lpivot <= 0 {
+			lcnt++
+		}
+		if rpivot <= 0 {
+			rcnt++
+		}
+		tmp := e[i] * d[i] * e[i]
+		tmp2 := tmp / lpivot
+		if tmp2 == 0 {
+			sl = tmp - vl
+		} else {
+			sl = sl*tmp2 - vl
+		}
+		tmp2 = tmp / rpivot
+		if tmp2 == 0 {
+			su = tmp - vu
+		} else {
+			su = su*tmp2 - vu
+		}
+	}
+	if d[n-1]+sl <= 0 {
+		lcnt++
+	}
+	if d[n-1]+su <= 0 {
+		rcnt++
+	}
+	return rcnt - lcnt, lcnt, rcnt
+}
@@ -0,0 +1,443 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlarrd computes the eigenvalues of a symmetric tridiagonal matrix T to
+// suitable accuracy. This is an auxiliary code to be called from Dstemr.
+//
+// The user may ask for all eigenvalues, all eigenvalues in the half-open
+// interval (vl,vu], or the il-th through iu-th eigenvalues, with indices
+// starting at 0, by setting rng to lapack.RangeAll, lapack.RangeInterval or
+// lapack.RangeIndex respectively.
+//
+// To avoid overflow, the matrix must be scaled so that its largest element is
+// no greater than overflow^(1/2) * underflow^(1/4) in absolute value, and for
+// greatest accuracy, it should not be much smaller than that.
+//
+// gers holds the n Gerschgorin intervals of T, the i-th interval being
+// [gers[2*i], gers[2*i+1]]. reltol is the minimum relative width of an
+// interval, d contains the diagonal of T, e the off-diagonal and e2 the
+// squared off-diagonal elements of T. pivmin is the minimum pivot allowed in
+// the Sturm sequence.
+//
+// T is split into nsplit unreduced blocks, where the j-th block ends at row
+// isplit[j] (inclusive), as computed by Dlarra.
+//
+// On return, the first m elements of w contain the eigenvalue approximations
+// and werr contains their error bounds. iblock[i] holds the block number of
+// the i-th eigenvalue and indexw[i] holds the index of the eigenvalue within
+// its block. If the eigenvalue did not converge, iblock[i] is set to
+// -iblock[i]-1. If byBlock is true, the eigenvalues are grouped by block,
+// otherwise they are sorted in ascending order over the entire matrix.
+//
+// w, werr, iblock and indexw must have length at least n, work must have
+// length at least 4*n and iwork must have length at least 3*n.
+//
+// Dlarrd also returns wl and wu such that the interval (wl,wu] contains all
+// the wanted eigenvalues. info is zero on success, and otherwise is the sum of
+// 1 if some eigenvalues failed to converge and 2 if the number of eigenvalues
+// found does not match the number requested. info == 4 indicates that the
+// Gerschgorin interval was too small and bisection failed to find the
+// eigenvalue indices. Any other value of info is an error code from Dlaebz.
+//
+// Dlarrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrd(rng lapack.EVRange, byBlock bool, n int, vl, vu float64, il, iu int, gers []float64, reltol float64, d, e, e2 []float64, pivmin float64, nsplit int, isplit []int, w, werr []float64, iblock, indexw []int, work []float64, iwork []int) (m int, wl, wu float64, info int) {
+	const fudge = 2
+
+	switch rng {
+	default:
+		panic(badRange)
+	case lapack.RangeAll:
+	case lapack.RangeInterval:
+		if vl >= vu {
+			panic(badInterval)
+		}
+	case lapack.RangeIndex:
+		if il < 0 || max(0, n-1) < il {
+			panic(badIl)
+		}
+		if iu < min(n-1, il) || n <= iu {
+			panic(badIu)
+		}
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, 0, 0, 0
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 || len(e2) < n-1 {
+		panic(badE)
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	if len(isplit) < nsplit {
+		panic(badIndex)
+	}
+	if len(w) < n || len(werr) < n {
+		panic(badW)
+	}
+	if len(iblock) < n || len(indexw) < n {
+		panic(badIndex)
+	}
+	if len(work) < 4*n || len(iwork) < 3*n {
+		panic(badWork)
+	}
+
+	// Simplification.
+	if rng == lapack.RangeIndex && il == 0 && iu == n-1 {
+		rng = lapack.RangeAll
+	}
+
+	eps := dlamchP
+	uflow := dlamchS
+
+	// Treat the case of a 1×1 matrix for quick return.
+	if n == 1 {
+		if rng == lapack.RangeAll ||
+			(rng == lapack.RangeInterval && d[0] > vl && d[0] <= vu) ||
+			(rng == lapack.RangeIndex && il == 0 && iu == 0) {
+			w[0] = d[0]
+			// The computation error of the eigenvalue is zero.
+			werr[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+			return 1, wl, wu, 0
+		}
+		return 0, wl, wu, 0
+	}
+
+	// nb is the minimum vector length for vector bisection, or 0 if only
+	// scalar is to be done.
+	nb := impl.Ilaenv(1, "DSTEBZ", " ", n, -1, -1, -1)
+	if nb <= 1 {
+		nb = 0
+	}
+
+	// Find the global spectral radius.
+	gl := d[0]
+	gu := d[0]
+	for i := 0; i < n; i++ {
+		gl = math.Min(gl, gers[2*i])
+		gu = math.Max(gu, gers[2*i+1])
+	}
+	// Compute the global Gerschgorin bounds and spectral diameter.
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	gl -= fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+	gu += fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	// The relative tolerance. An interval (a,b] lies within relative
+	// tolerance if b-a < reltol*max(|a|,|b|).
+	rtoli := reltol
+	// Set the absolute tolerance for interval convergence to zero to force
+	// interval convergence based on the relative size of the interval. This
+	// is dangerous because intervals might not converge when reltol is
+	// small, but at least a very small number should be selected so that
+	// for strongly graded matrices the code can get relatively accurate
+	// eigenvalues.
+	atoli := fudge*2*uflow + fudge*2*pivmin
+
+	var (
+		wlu, wul float64
+		nwl, nwu int
+	)
+	switch rng {
+	case lapack.RangeIndex:
+		// Compute an interval containing eigenvalues il through iu. The
+		// initial interval [gl,gu] from the global Gerschgorin bounds is
+		// refined by Dlaebz.
+		itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		ab := work[n : n+4]
+		c := work[n+4 : n+6]
+		nab := iwork[:4]
+		nval := iwork[4:6]
+		ab[0], ab[1] = gl, gu
+		ab[2], ab[3] = gl, gu
+		c[0], c[1] = gl, gu
+		nab[0], nab[1] = -1, n+1
+		nab[2], nab[3] = -1, n+1
+		nval[0] = il
+		nval[1] = iu + 1
+		_, iinfo := impl.Dlaebz(3, itmax, n, 2, 2, nb, atoli, rtoli, pivmin,
+			d, e, e2, nval, ab, c, nab, w, iblock)
+		if iinfo != 0 {
+			return 0, wl, wu, iinfo
+		}
+		// On exit, output intervals may not be ordered by ascending
+		// negcount.
+		if nval[1] == iu+1 {
+			wl, wlu, nwl = ab[0], ab[1], nab[0]
+			wu, wul, nwu = ab[3], ab[2], nab[3]
+		} else {
+			wl, wlu, nwl = ab[2], ab[3], nab[2]
+			wu, wul, nwu = ab[1], ab[0], nab[1]
+		}
+		// On exit, the interval [wl,wlu] contains a value with negcount
+		// nwl, and [wul,wu] contains a value with negcount nwu.
+		if nwl < 0 || nwl >= n || nwu < 1 || nwu > n {
+			return 0, wl, wu, 4
+		}
+	case lapack.RangeInterval:
+		wl = vl
+		wu = vu
+	case lapack.RangeAll:
+		wl = gl
+		wu = gu
+	}
+
+	// Find eigenvalues. Loop over blocks and recompute nwl and nwu. nwl
+	// accumulates the number of eigenvalues <= wl and nwu accumulates the
+	// number of eigenvalues <= wu.
+	var ncnvrg, toofew bool
+	nwl = 0
+	nwu = 0
+	iend := -1
+	for jblk := 0; jblk < nsplit; jblk++ {
+		ioff := iend + 1
+		ibegin := ioff
+		iend = isplit[jblk]
+		in := iend - ioff + 1
+
+		if in == 1 {
+			// 1×1 block.
+			if wl >= d[ibegin]-pivmin {
+				nwl++
+			}
+			if wu >= d[ibegin]-pivmin {
+				nwu++
+			}
+			if rng == lapack.RangeAll || (wl < d[ibegin]-pivmin && wu >= d[ibegin]-pivmin) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				// The gap for a single block doesn't matter for the
+				// later algorithm and is assigned an arbitrary large
+				// value.
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+			}
+			continue
+		}
+
+		// General case: block of size in >= 2. Compute the local
+		// Gerschgorin interval and use it as the initial interval for
+		// Dlaebz.
+		gu := d[ibegin]
+		gl := d[ibegin]
+		for j := ibegin; j <= iend; j++ {
+			gl = math.Min(gl, gers[2*j])
+			gu = math.Max(gu, gers[2*j+1])
+		}
+		gl -= fudge*tnorm*eps*float64(in) + fudge*pivmin
+		gu += fudge*tnorm*eps*float64(in) + fudge*pivmin
+
+		if rng != lapack.RangeAll {
+			if gu < wl {
+				// The local block contains none of the wanted
+				// eigenvalues.
+				nwl += in
+				nwu += in
+				continue
+			}
+			// Refine the search interval if possible, only the range
+			// (wl,wu] matters.
+			gl = math.Max(gl, wl)
+			gu = math.Min(gu, wu)
+			if gl >= gu {
+				continue
+			}
+		}
+
+		// Find the negcount of the initial interval boundaries gl and gu.
+		ab := work[n : n+2*in]
+		c := work[n+2*in : n+3*in]
+		nab := iwork[:2*in]
+		ab[0] = gl
+		ab[1] = gu
+		im, _ := impl.Dlaebz(1, 0, in, in, 1, nb, atoli, rtoli, pivmin,
+			d[ibegin:], e[ibegin:], e2[ibegin:], nil, ab, c, nab, w[m:], iblock[m:])
+
+		nwl += nab[0]
+		nwu += nab[1]
+		iwoff := m - nab[0]
+
+		// Compute eigenvalues.
+		itmax := int((math.Log(gu-gl+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		iout, iinfo := impl.Dlaebz(2, itmax, in, in, 1, nb, atoli, rtoli, pivmin,
+			d[ibegin:], e[ibegin:], e2[ibegin:], nil, ab, c, nab, w[m:], iblock[m:])
+		if iinfo > in {
+			return m, wl, wu, iinfo
+		}
+
+		// Copy eigenvalues into w and iblock. Use -jblk-1 for the block
+		// number of unconverged eigenvalues. Loop over the number of
+		// output intervals from Dlaebz.
+		for j := 0; j < iout; j++ {
+			// The eigenvalue approximation is the middle point of the
+			// interval.
+			tmp1 := 0.5 * (ab[2*j] + ab[2*j+1])
+			// Semi-length of the error interval.
+			tmp2 := 0.5 * math.Abs(ab[2*j]-ab[2*j+1])
+			ib := jblk
+			if j >= iout-iinfo {
+				// Flag non-convergence.
+				ncnvrg = true
+				ib = -jblk - 1
+			}
+			for je := nab[2*j] + iwoff; je < nab[2*j+1]+iwoff; je++ {
+				w[je] = tmp1
+				werr[je] = tmp2
+				indexw[je] = je - iwoff
+				iblock[je] = ib
+			}
+		}
+		m += im
+	}
+
+	// If rng is lapack.RangeIndex, then (wl,wu] contains eigenvalues
+	// nwl, ..., nwu-1. If nwl < il or nwu > iu+1, discard extra eigenvalues.
+	if rng == lapack.RangeIndex {
+		idiscl := il - nwl
+		idiscu := nwu - iu - 1
+
+		if idiscl > 0 {
+			// Remove some of the smallest eigenvalues from the left so
+			// that at the end idiscl == 0. Move all eigenvalues up to the
+			// left.
+			var im int
+			for je := 0; je < m; je++ {
+				if w[je] <= wlu && idiscl > 0 {
+					idiscl--
+				} else {
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscu > 0 {
+			// Remove some of the largest eigenvalues from the right so
+			// that at the end idiscu == 0. Move all eigenvalues up to the
+			// left.
+			im := m
+			for je := m - 1; je >= 0; je-- {
+				if w[je] >= wul && idiscu > 0 {
+					idiscu--
+				} else {
+					im--
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+				}
+			}
+			for je := im; je < m; je++ {
+				w[je-im] = w[je]
+				werr[je-im] = werr[je]
+				indexw[je-im] = indexw[je]
+				iblock[je-im] = iblock[je]
+			}
+			m -= im
+		}
+
+		if idiscl > 0 || idiscu > 0 {
+			// Code to deal with effects of bad arithmetic. Some low
+			// eigenvalues to be discarded are not in (wl,wlu], or high
+			// eigenvalues to be discarded are not in (wul,wu], so just
+			// kill off the smallest idiscl/largest idiscu eigenvalues by
+			// marking the corresponding indexw as -1.
+			if idiscl > 0 {
+				wkill := wu
+				for jdisc := 0; jdisc < idiscl; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if indexw[je] >= 0 && (w[je] < wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					indexw[iw] = -1
+				}
+			}
+			if idiscu > 0 {
+				wkill := wl
+				for jdisc := 0; jdisc < idiscu; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if indexw[je] >= 0 && (w[je] >= wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					indexw[iw] = -1
+				}
+			}
+			// Now erase all eigenvalues that were killed.
+			var im int
+			for je := 0; je < m; je++ {
+				if indexw[je] >= 0 {
+					w[im] = w[je]
+					werr[im] = werr[je]
+					indexw[im] = indexw[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscl < 0 || idiscu < 0 {
+			toofew = true
+		}
+	}
+
+	if (rng == lapack.RangeAll && m != n) || (rng == lapack.RangeIndex && m != iu-il+1) {
+		toofew = true
+	}
+
+	// If byBlock is true, do nothing since the eigenvalues are already
+	// sorted by block. Otherwise sort the eigenvalues from smallest to
+	// largest.
+	if !byBlock && nsplit > 1 {
+		for je := 0; je < m-1; je++ {
+			ie := -1
+			tmp1 := w[je]
+			for j := je + 1; j < m; j++ {
+				if w[j] < tmp1 {
+					ie = j
+					tmp1 = w[j]
+				}
+			}
+			if ie != -1 {
+				w[ie], w[je] = w[je], tmp1
+				werr[ie], werr[je] = werr[je], werr[ie]
+				iblock[ie], iblock[je] = iblock[je], iblock[ie]
+				indexw[ie], indexw[je] = indexw[je], indexw[ie]
+			}
+		}
+	}
+
+	if ncnvrg {
+		info++
+	}
+	if toofew {
+		info += 2
+	}
+	return m, wl, wu, info
+}
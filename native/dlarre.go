@@ -0,0 +1,569 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlarre finds, given the symmetric tridiagonal matrix T, the desired
+// eigenvalues to a suitable accuracy, with a relatively robust representation
+//  T_i - sigma_i * I = L_i * D_i * L_i^T
+// for each unreduced block T_i of T.
+//
+// To achieve this, Dlarre
+//  - sets any "small" off-diagonal elements to zero,
+//  - for each unreduced block T_i, finds a shift sigma_i near an extremal
+//    eigenvalue and a base representation L_i * D_i * L_i^T with not too much
+//    element growth,
+//  - computes the eigenvalues of each L_i * D_i * L_i^T using dqds if a large
+//    fraction of the eigenvalues is wanted, and bisection otherwise.
+//
+// The user may ask for all eigenvalues, all eigenvalues in the half-open
+// interval (vl,vu], or the il-th through iu-th eigenvalues, with indices
+// starting at 0, by setting rng to lapack.RangeAll, lapack.RangeInterval or
+// lapack.RangeIndex respectively.
+//
+// On entry, d contains the n diagonal elements of T and e contains the n-1
+// off-diagonal elements in its first n-1 elements. On return, d contains the
+// diagonal elements of the D_i, e contains the sub-diagonal elements of the
+// unit bidiagonal matrices L_i and e[isplit[j]] contains the shift sigma_j of
+// the j-th block. e2 contains the squares of the off-diagonal elements of T
+// and is overwritten. d, e and e2 must have length at least n.
+//
+// rtol1 and rtol2 are the parameters for bisection, and an interval
+// [left,right] has converged if
+//  right-left < max(rtol1*gap, rtol2*max(|left|,|right|)).
+// spltol is the threshold for splitting as used by Dlarra.
+//
+// On return, isplit[j] contains the index of the last row of the j-th block
+// and the first m elements of w contain the eigenvalue approximations of the
+// L_i * D_i * L_i^T in ascending order within each block, with werr holding
+// their error bounds and wgap the separation from the right neighbor
+// eigenvalue. iblock[i] holds the block number of the i-th eigenvalue and
+// indexw[i] its index within the block. gers contains the n Gerschgorin
+// intervals of T, the i-th being [gers[2*i], gers[2*i+1]]. isplit, w, werr,
+// wgap, iblock and indexw must have length at least n and gers must have length
+// at least 2*n.
+//
+// work must have length at least 6*n and iwork must have length at least 5*n.
+//
+// Dlarre returns the interval (vl,vu] that contains all the wanted
+// eigenvalues, the number of blocks nsplit, the number of eigenvalues found m
+// and the minimum pivot in the Sturm sequence for T. ok is false if the
+// computation of the eigenvalues or of a base representation failed.
+//
+// Dlarre is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarre(rng lapack.EVRange, n int, vl, vu float64, il, iu int, d, e, e2 []float64, rtol1, rtol2, spltol float64, isplit []int, w, werr, wgap []float64, iblock, indexw []int, gers []float64, work []float64, iwork []int) (vlOut, vuOut float64, nsplit, m int, pivmin float64, ok bool) {
+	const (
+		fac       = 0.5
+		maxgrowth = 64
+		fudge     = 2
+		pert      = 8
+		hndrd     = 100
+		maxtry    = 6
+	)
+
+	if rng != lapack.RangeAll && rng != lapack.RangeInterval && rng != lapack.RangeIndex {
+		panic(badRange)
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return vl, vu, 0, 0, 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n || len(e2) < n {
+		panic(badE)
+	}
+	if len(isplit) < n || len(iblock) < n || len(indexw) < n {
+		panic(badIndex)
+	}
+	if len(w) < n || len(werr) < n || len(wgap) < n {
+		panic(badW)
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	if len(work) < 6*n || len(iwork) < 5*n {
+		panic(badWork)
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+
+	// Set parameters.
+	rtl := math.Sqrt(eps)
+	bsrtol := math.Sqrt(eps)
+
+	// Treat the case of a 1×1 matrix for quick return.
+	if n == 1 {
+		if rng == lapack.RangeAll ||
+			(rng == lapack.RangeInterval && d[0] > vl && d[0] <= vu) ||
+			(rng == lapack.RangeIndex && il == 0 && iu == 0) {
+			m = 1
+			w[0] = d[0]
+			// The computation error of the eigenvalue is zero.
+			werr[0] = 0
+			wgap[0] = 0
+			iblock[0] = 0
+			indexw[0] = 0
+			gers[0] = d[0]
+			gers[1] = d[0]
+		}
+		// Store the shift for the initial RRR, which is zero in this
+		// case.
+		e[0] = 0
+		isplit[0] = 0
+		return vl, vu, 1, m, safmin, true
+	}
+
+	// General case: tridiagonal matrix of order > 1.
+	//
+	// Initialize werr and wgap. Compute the Gerschgorin intervals and the
+	// spectral diameter. Compute the maximum off-diagonal entry and pivmin.
+	gl := d[0]
+	gu := d[0]
+	var eold, emax float64
+	e[n-1] = 0
+	for i := 0; i < n; i++ {
+		werr[i] = 0
+		wgap[i] = 0
+		eabs := math.Abs(e[i])
+		if eabs >= emax {
+			emax = eabs
+		}
+		tmp1 := eabs + eold
+		gers[2*i] = d[i] - tmp1
+		gl = math.Min(gl, gers[2*i])
+		gers[2*i+1] = d[i] + tmp1
+		gu = math.Max(gu, gers[2*i+1])
+		eold = eabs
+	}
+	// The minimum pivot allowed in the Sturm sequence for T.
+	pivmin = safmin * math.Max(1, emax*emax)
+	// Compute the spectral diameter. The Gerschgorin bounds give an
+	// estimate that is wrong by at most a factor of sqrt(2).
+	spdiam := gu - gl
+
+	// Compute the splitting points.
+	nsplit = impl.Dlarra(n, d, e, e2, spltol, spdiam, isplit)
+
+	// dqds is used for lapack.RangeAll.
+	allrng := rng == lapack.RangeAll
+	usedqd := allrng
+
+	var mm int
+	if allrng {
+		// Set the interval [vl,vu] that contains all eigenvalues.
+		vl = gl
+		vu = gu
+	} else {
+		// Call Dlarrd to find crude approximations to the eigenvalues in
+		// the desired range. In case rng is lapack.RangeIndex, also obtain
+		// the interval (vl,vu] that contains all the wanted eigenvalues.
+		// An interval [left,right] has converged if
+		//  right-left < rtol*max(|left|,|right|).
+		var iinfo int
+		mm, vl, vu, iinfo = impl.Dlarrd(rng, true, n, vl, vu, il, iu, gers,
+			bsrtol, d, e, e2, pivmin, nsplit, isplit, w, werr, iblock, indexw, work, iwork)
+		if iinfo != 0 {
+			return vl, vu, nsplit, 0, pivmin, false
+		}
+		// Make sure that the entries mm to n-1 in w, werr, iblock and
+		// indexw are 0.
+		for i := mm; i < n; i++ {
+			w[i] = 0
+			werr[i] = 0
+			iblock[i] = 0
+			indexw[i] = 0
+		}
+	}
+
+	// Loop over unreduced blocks.
+	ibegin := 0
+	wbegin := 0
+	for jblk := 0; jblk < nsplit; jblk++ {
+		iend := isplit[jblk]
+		in := iend - ibegin + 1
+
+		// 1×1 block.
+		if in == 1 {
+			if allrng || (wbegin < mm && iblock[wbegin] == jblk) {
+				w[m] = d[ibegin]
+				werr[m] = 0
+				// The gap for a single block doesn't matter for the
+				// later algorithm and is assigned an arbitrary large
+				// value.
+				wgap[m] = 0
+				iblock[m] = jblk
+				indexw[m] = 0
+				m++
+				wbegin++
+			}
+			// e[iend] holds the shift for the initial RRR.
+			e[iend] = 0
+			ibegin = iend + 1
+			continue
+		}
+
+		// Blocks of size larger than 1×1.
+		//
+		// e[iend] will hold the shift for the initial RRR, for now set it
+		// to 0.
+		e[iend] = 0
+
+		// Find the local outer bounds gl and gu for the block.
+		gl := d[ibegin]
+		gu := d[ibegin]
+		for i := ibegin; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam := gu - gl
+
+		var (
+			mb         int
+			wend       int
+			indl, indu int
+		)
+		if !allrng {
+			// Count the number of eigenvalues in the current block.
+			for i := wbegin; i < mm; i++ {
+				if iblock[i] != jblk {
+					break
+				}
+				mb++
+			}
+			if mb == 0 {
+				// No eigenvalue in the current block lies in the
+				// desired range. e[iend] holds the shift for the
+				// initial RRR.
+				e[iend] = 0
+				ibegin = iend + 1
+				continue
+			}
+			// Decide whether dqds or bisection is more efficient.
+			usedqd = float64(mb) > fac*float64(in)
+			wend = wbegin + mb - 1
+			// Calculate the gaps for the current block. In later stages,
+			// when representations for individual eigenvalues are
+			// different, sigma = e[iend] is used.
+			for i := wbegin; i < wend; i++ {
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[wend] = math.Max(0, vu-(w[wend]+werr[wend]))
+			// Find the local index of the first and last desired
+			// eigenvalue.
+			indl = indexw[wbegin]
+			indu = indexw[wend]
+		}
+
+		var isleft, isrght float64
+		if usedqd {
+			// Case of dqds. Find approximations to the extremal
+			// eigenvalues of the block.
+			tmp, tmp1, ok1 := impl.Dlarrk(in, 0, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !ok1 {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			isleft = math.Max(gl, tmp-tmp1-hndrd*eps*math.Abs(tmp-tmp1))
+			tmp, tmp1, ok1 = impl.Dlarrk(in, in-1, gl, gu, d[ibegin:], e2[ibegin:], pivmin, rtl)
+			if !ok1 {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			isrght = math.Min(gu, tmp+tmp1+hndrd*eps*math.Abs(tmp+tmp1))
+			// Improve the estimate of the spectral diameter.
+			spdiam = isrght - isleft
+		} else {
+			// Case of bisection. Find approximations to the wanted
+			// extremal eigenvalues.
+			isleft = math.Max(gl, w[wbegin]-werr[wbegin]-hndrd*eps*math.Abs(w[wbegin]-werr[wbegin]))
+			isrght = math.Min(gu, w[wend]+werr[wend]+hndrd*eps*math.Abs(w[wend]+werr[wend]))
+		}
+
+		// Decide whether the base representation for the current block
+		//  L_jblk * D_jblk * L_jblk^T = T_jblk - sigma_jblk * I
+		// should be on the left or the right end of the current block.
+		// The strategy is to shift to the end which is "more populated".
+		// Furthermore, decide whether to use dqds for the computation of
+		// the eigenvalue approximations at the end of Dlarre or bisection.
+		// dqds is chosen if all eigenvalues are desired or the number of
+		// eigenvalues to be computed is large compared to the block size.
+		var s1, s2 float64
+		if allrng {
+			// If all the eigenvalues have to be computed, use dqd.
+			usedqd = true
+			// indl is the local index of the first eigenvalue to
+			// compute.
+			indl = 0
+			indu = in - 1
+			// mb is the number of eigenvalues to compute.
+			mb = in
+			wend = wbegin + mb - 1
+			// Define the 1/4 and 3/4 points of the spectrum.
+			s1 = isleft + 0.25*spdiam
+			s2 = isrght - 0.25*spdiam
+		} else {
+			// Dlarrd has computed iblock and indexw for each eigenvalue
+			// approximation. Choose sigma.
+			if usedqd {
+				s1 = isleft + 0.25*spdiam
+				s2 = isrght - 0.25*spdiam
+			} else {
+				tmp := math.Min(isrght, vu) - math.Max(isleft, vl)
+				s1 = math.Max(isleft, vl) + 0.25*tmp
+				s2 = math.Min(isrght, vu) - 0.25*tmp
+			}
+		}
+
+		// Compute the negcount at the 1/4 and 3/4 points.
+		var cnt1, cnt2 int
+		if mb > 1 {
+			_, cnt1, cnt2 = impl.Dlarrc(true, in, s1, s2, d[ibegin:], e[ibegin:], pivmin)
+		}
+
+		var sigma, sgndef float64
+		switch {
+		case mb == 1:
+			sigma = gl
+			sgndef = 1
+		case cnt1-indl-1 >= indu+1-cnt2:
+			switch {
+			case allrng:
+				sigma = math.Max(isleft, gl)
+			case usedqd:
+				// Use the Gerschgorin bound as shift to get a positive
+				// definite matrix for dqds.
+				sigma = isleft
+			default:
+				// Use an approximation of the first desired eigenvalue
+				// of the block as shift.
+				sigma = math.Max(isleft, vl)
+			}
+			sgndef = 1
+		default:
+			switch {
+			case allrng:
+				sigma = math.Min(isrght, gu)
+			case usedqd:
+				// Use the Gerschgorin bound as shift to get a negative
+				// definite matrix for dqds.
+				sigma = isrght
+			default:
+				// Use an approximation of the last desired eigenvalue
+				// of the block as shift.
+				sigma = math.Min(isrght, vu)
+			}
+			sgndef = -1
+		}
+
+		// An initial sigma has been chosen that will be used for
+		// computing T - sigma*I = L * D * L^T. Define the increment tau
+		// of the shift in case the initial shift needs to be refined to
+		// obtain a factorization with not too much element growth.
+		var tau float64
+		switch {
+		case usedqd:
+			// The initial sigma was to the outer end of the spectrum, so
+			// the matrix is definite and there is no need to retreat.
+			tau = spdiam*eps*float64(n) + 2*pivmin
+			tau = math.Max(tau, 2*eps*math.Abs(sigma))
+		case mb > 1:
+			clwdth := w[wend] + werr[wend] - w[wbegin] - werr[wbegin]
+			avgap := math.Abs(clwdth / float64(wend-wbegin))
+			if sgndef == 1 {
+				tau = 0.5 * math.Max(wgap[wbegin], avgap)
+				tau = math.Max(tau, werr[wbegin])
+			} else {
+				tau = 0.5 * math.Max(wgap[wend-1], avgap)
+				tau = math.Max(tau, werr[wend])
+			}
+		default:
+			tau = werr[wbegin]
+		}
+
+		var found bool
+		for idum := 0; idum < maxtry; idum++ {
+			// Compute the L * D * L^T factorization of the tridiagonal
+			// matrix T - sigma*I. Store D in work[:in], L in
+			// work[in:2*in] and the reciprocals of the pivots in
+			// work[2*in:3*in].
+			dpivot := d[ibegin] - sigma
+			work[0] = dpivot
+			dmax := math.Abs(work[0])
+			for i := 0; i < in-1; i++ {
+				j := ibegin + i
+				work[2*in+i] = 1 / work[i]
+				tmp := e[j] * work[2*in+i]
+				work[in+i] = tmp
+				dpivot = (d[j+1] - sigma) - tmp*e[j]
+				work[i+1] = dpivot
+				dmax = math.Max(dmax, math.Abs(dpivot))
+			}
+			// Check for element growth.
+			norep := dmax > maxgrowth*spdiam
+			if usedqd && !norep {
+				// Ensure the definiteness of the representation. All
+				// entries of D must have the same sign.
+				for i := 0; i < in; i++ {
+					if sgndef*work[i] < 0 {
+						norep = true
+					}
+				}
+			}
+			if !norep {
+				// An initial RRR is found.
+				found = true
+				break
+			}
+			// Note that in the case of lapack.RangeAll, the
+			// Gerschgorin shift which makes the matrix definite is
+			// used, so this should really only happen in the case of
+			// lapack.RangeInterval or lapack.RangeIndex.
+			if idum == maxtry-2 {
+				if sgndef == 1 {
+					// The fudged Gerschgorin shift should succeed.
+					sigma = gl - fudge*spdiam*eps*float64(n) - fudge*2*pivmin
+				} else {
+					sigma = gu + fudge*spdiam*eps*float64(n) + fudge*2*pivmin
+				}
+			} else {
+				sigma -= sgndef * tau
+				tau *= 2
+			}
+		}
+		if !found {
+			// No base representation could be found in maxtry
+			// iterations.
+			return vl, vu, nsplit, m, pivmin, false
+		}
+
+		// At this point, an initial base representation
+		// T - sigma*I = L * D * L^T with not too much element growth has
+		// been found. Store the shift.
+		e[iend] = sigma
+		// Store D and L.
+		copy(d[ibegin:ibegin+in], work[:in])
+		copy(e[ibegin:ibegin+in-1], work[in:2*in-1])
+
+		if mb > 1 {
+			// Perturb each entry of the base representation by a small
+			// multiple of its size. Perturbation is not needed for
+			// relative accuracy, but it may be needed to avoid
+			// stagnation of the algorithm. The same fixed seed is used
+			// for every block so that the result is reproducible.
+			rnd := rand.New(rand.NewSource(1))
+			for i := 0; i < 2*in-1; i++ {
+				work[i] = 2*rnd.Float64() - 1
+			}
+			for i := 0; i < in-1; i++ {
+				d[ibegin+i] *= 1 + eps*pert*work[i]
+				e[ibegin+i] *= 1 + eps*pert*work[in+i]
+			}
+			d[iend] *= 1 + eps*4*work[in-1]
+		}
+
+		// Don't update the Gerschgorin intervals because keeping track of
+		// the updates would be too much work in Dlarrv. Update w instead
+		// and use it to locate the proper Gerschgorin intervals.
+
+		// Compute the required eigenvalues of L * D * L^T by bisection or
+		// dqds.
+		if !usedqd {
+			// If Dlarrd has been used, shift the eigenvalue
+			// approximations according to their representation. This is
+			// necessary for a uniform Dlarrv since dqds computes
+			// eigenvalues of the shifted representation.
+			for j := wbegin; j <= wend; j++ {
+				w[j] -= sigma
+				werr[j] += math.Abs(w[j]) * eps
+			}
+			// Call Dlarrb to reduce the eigenvalue error of the
+			// approximations from Dlarrd.
+			for i := ibegin; i < iend; i++ {
+				work[i] = d[i] * e[i] * e[i]
+			}
+			// Use bisection to find the eigenvalues from indl to indu.
+			impl.Dlarrb(in, d[ibegin:], work[ibegin:], indl, indu, rtol1, rtol2, indl,
+				w[wbegin:], wgap[wbegin:], werr[wbegin:], work[2*n:], iwork, pivmin, spdiam, in-1)
+			// Dlarrb computes all gaps correctly except for the last
+			// one. Record the distance to vu/gu.
+			wgap[wend] = math.Max(0, (vu-sigma)-(w[wend]+werr[wend]))
+			for i := indl; i <= indu; i++ {
+				iblock[m] = jblk
+				indexw[m] = i
+				m++
+			}
+		} else {
+			// Call dqds to get all eigenvalues (and then possibly delete
+			// unwanted eigenvalues). Note that dqds finds the
+			// eigenvalues of the L * D * L^T representation of T to high
+			// relative accuracy. High relative accuracy might be lost
+			// when the shift of the RRR is subtracted to obtain the
+			// eigenvalues of T. However, T is not guaranteed to define
+			// its eigenvalues to high relative accuracy anyway.
+			//
+			// Set rtol to the order of the tolerance used in Dlasq2. This
+			// is an estimated error, the worst case bound is 4*n*eps
+			// which is usually too large and requires unnecessary work
+			// to be done by bisection when computing the eigenvectors.
+			rtol := math.Log(float64(in)) * 4 * eps
+			for i := 0; i < in-1; i++ {
+				j := ibegin + i
+				work[2*i] = math.Abs(d[j])
+				work[2*i+1] = e[j] * e[j] * work[2*i]
+			}
+			work[2*in-2] = math.Abs(d[iend])
+			work[2*in-1] = 0
+			iinfo := impl.Dlasq2(in, work)
+			if iinfo != 0 {
+				return vl, vu, nsplit, m, pivmin, false
+			}
+			// Test that all eigenvalues are positive as expected.
+			for i := 0; i < in; i++ {
+				if work[i] < 0 {
+					return vl, vu, nsplit, m, pivmin, false
+				}
+			}
+			if sgndef > 0 {
+				for i := indl; i <= indu; i++ {
+					w[m] = work[in-1-i]
+					iblock[m] = jblk
+					indexw[m] = i
+					m++
+				}
+			} else {
+				for i := indl; i <= indu; i++ {
+					w[m] = -work[i]
+					iblock[m] = jblk
+					indexw[m] = i
+					m++
+				}
+			}
+			for i := m - mb; i < m; i++ {
+				// The value of rtol below should be the tolerance in
+				// Dlasq2.
+				werr[i] = rtol * math.Abs(w[i])
+			}
+			for i := m - mb; i < m-1; i++ {
+				// Compute the right gap between the intervals.
+				wgap[i] = math.Max(0, w[i+1]-werr[i+1]-(w[i]+werr[i]))
+			}
+			wgap[m-1] = math.Max(0, (vu-sigma)-(w[m-1]+werr[m-1]))
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return vl, vu, nsplit, m, pivmin, true
+}
@@ -0,0 +1,275 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarrf finds, given the initial representation L * D * L^T and its cluster
+// of close eigenvalues (in a relative measure) with indices clstrt through
+// clend, a new relatively robust representation
+//  L * D * L^T - sigma*I = L+ * D+ * L+^T
+// such that at least one of the eigenvalues of L+ * D+ * L+^T is relatively
+// isolated.
+//
+// d contains the n diagonal elements of D, and l and ld contain the n-1
+// sub-diagonal elements of L and L*D respectively. w contains the eigenvalue
+// approximations of L * D * L^T, with wgap holding the separation from the
+// right neighbor eigenvalue and werr holding the error bounds. spdiam is the
+// spectral diameter estimate, clgapl and clgapr are the gaps to the left and
+// right of the cluster, and pivmin is the minimum pivot allowed in the Sturm
+// sequence.
+//
+// On return, dplus contains the n diagonal elements of D+ and lplus contains
+// the n-1 sub-diagonal elements of L+. work must have length at least 2*n.
+//
+// Dlarrf returns the shift sigma used to form L+ * D+ * L+^T. ok is false if
+// no acceptable representation was found.
+//
+// Dlarrf is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarrf(n int, d, l, ld []float64, clstrt, clend int, w, wgap, werr []float64, spdiam, clgapl, clgapr, pivmin float64, dplus, lplus, work []float64) (sigma float64, ok bool) {
+	const (
+		maxgrowth1 = 8
+		maxgrowth2 = 8
+		ktrymax    = 1
+		sleft      = 1
+		sright     = 2
+	)
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(l) < n-1 || len(ld) < n-1 {
+		panic(badE)
+	}
+	if len(dplus) < n || len(lplus) < n-1 {
+		panic(badSlice)
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+
+	fact := float64(int(1) << ktrymax)
+	eps := dlamchP
+	var shift int
+	var forcer bool
+
+	// Note that it cannot be guaranteed that for any of the shifts tried,
+	// the factorization has a small or even moderate element growth. There
+	// could be Ritz values at both ends of the cluster and despite backing
+	// off, there are examples where all factorizations tried (allowing zero
+	// pivots and infinities) have infinite element growth. For this reason,
+	// pivmin is used so that at least the L * D * L^T factorization exists.
+	// It can be checked afterwards whether the element growth caused bad
+	// residuals or orthogonality.
+
+	// Decide whether the code should accept the best among all
+	// representations despite large element growth or signal failure.
+	const nofail = false
+
+	// Compute the average gap length of the cluster.
+	clwdth := math.Abs(w[clend]-w[clstrt]) + werr[clend] + werr[clstrt]
+	avgap := clwdth / float64(clend-clstrt)
+	mingap := math.Min(clgapl, clgapr)
+	// Initial values for shifts to both ends of the cluster.
+	lsigma := math.Min(w[clstrt], w[clend]) - werr[clstrt]
+	rsigma := math.Max(w[clstrt], w[clend]) + werr[clend]
+
+	// Use a small fudge to make sure that we really shift to the outside.
+	lsigma -= math.Abs(lsigma) * 4 * eps
+	rsigma += math.Abs(rsigma) * 4 * eps
+
+	// Compute upper bounds for how much to back off the initial shifts.
+	ldmax := 0.25*mingap + 2*pivmin
+	rdmax := 0.25*mingap + 2*pivmin
+
+	ldelta := math.Max(avgap, wgap[clstrt]) / fact
+	rdelta := math.Max(avgap, wgap[clend-1]) / fact
+
+	// Initialize the record of the best representation found.
+	smlgrowth := 1 / dlamchS
+	fail := float64(n-1) * mingap / (spdiam * eps)
+	fail2 := float64(n-1) * mingap / (spdiam * math.Sqrt(eps))
+	bestshift := lsigma
+
+	ktry := 0
+	growthbound := maxgrowth1 * spdiam
+
+	for {
+		var sawnan1, sawnan2 bool
+		// Ensure that we do not back off too much of the initial shifts.
+		ldelta = math.Min(ldmax, ldelta)
+		rdelta = math.Min(rdmax, rdelta)
+
+		// Compute the element growth when shifting to both ends of the
+		// cluster. Accept the shift if there is no element growth at one
+		// of the two ends.
+
+		// Left end.
+		s := -lsigma
+		dplus[0] = d[0] + s
+		if math.Abs(dplus[0]) < pivmin {
+			dplus[0] = -pivmin
+			// Need to set sawnan1 because the refined RRR test should
+			// not be used in this case.
+			sawnan1 = true
+		}
+		max1 := math.Abs(dplus[0])
+		for i := 0; i < n-1; i++ {
+			lplus[i] = ld[i] / dplus[i]
+			s = s*lplus[i]*l[i] - lsigma
+			dplus[i+1] = d[i+1] + s
+			if math.Abs(dplus[i+1]) < pivmin {
+				dplus[i+1] = -pivmin
+				sawnan1 = true
+			}
+			max1 = math.Max(max1, math.Abs(dplus[i+1]))
+		}
+		sawnan1 = sawnan1 || math.IsNaN(max1)
+
+		if forcer || (max1 <= growthbound && !sawnan1) {
+			sigma = lsigma
+			shift = sleft
+			break
+		}
+
+		// Right end.
+		s = -rsigma
+		work[0] = d[0] + s
+		if math.Abs(work[0]) < pivmin {
+			work[0] = -pivmin
+			// Need to set sawnan2 because the refined RRR test should
+			// not be used in this case.
+			sawnan2 = true
+		}
+		max2 := math.Abs(work[0])
+		for i := 0; i < n-1; i++ {
+			work[n+i] = ld[i] / work[i]
+			s = s*work[n+i]*l[i] - rsigma
+			work[i+1] = d[i+1] + s
+			if math.Abs(work[i+1]) < pivmin {
+				work[i+1] = -pivmin
+				sawnan2 = true
+			}
+			max2 = math.Max(max2, math.Abs(work[i+1]))
+		}
+		sawnan2 = sawnan2 || math.IsNaN(max2)
+
+		if forcer || (max2 <= growthbound && !sawnan2) {
+			sigma = rsigma
+			shift = sright
+			break
+		}
+
+		// If we are at this point, both shifts led to too much element
+		// growth.
+		if !sawnan1 || !sawnan2 {
+			// Record the better of the two shifts, provided it didn't
+			// lead to NaN.
+			var indx int
+			if !sawnan1 {
+				indx = 1
+				if max1 <= smlgrowth {
+					smlgrowth = max1
+					bestshift = lsigma
+				}
+			}
+			if !sawnan2 {
+				if sawnan1 || max2 <= max1 {
+					indx = 2
+				}
+				if max2 <= smlgrowth {
+					smlgrowth = max2
+					bestshift = rsigma
+				}
+			}
+
+			// If we are here, both the left and the right shift led to
+			// element growth. If the element growth is moderate, then
+			// the representation may still be accepted, if it passes a
+			// refined test for RRR. This test supposes that no NaN
+			// occurred. Moreover, the refined RRR test is used only for
+			// isolated clusters.
+			dorrr1 := clwdth < mingap/128 && math.Min(max1, max2) < fail2 && !sawnan1 && !sawnan2
+			if dorrr1 {
+				if indx == 1 {
+					tmp := math.Abs(dplus[n-1])
+					znm2 := 1.0
+					prod := 1.0
+					oldp := 1.0
+					for i := n - 2; i >= 0; i-- {
+						if prod <= eps {
+							prod = ((dplus[i+1] * work[n+i+1]) / (dplus[i] * work[n+i])) * oldp
+						} else {
+							prod *= math.Abs(work[n+i])
+						}
+						oldp = prod
+						znm2 += prod * prod
+						tmp = math.Max(tmp, math.Abs(dplus[i]*prod))
+					}
+					rrr1 := tmp / (spdiam * math.Sqrt(znm2))
+					if rrr1 <= maxgrowth2 {
+						sigma = lsigma
+						shift = sleft
+						break
+					}
+				} else if indx == 2 {
+					tmp := math.Abs(work[n-1])
+					znm2 := 1.0
+					prod := 1.0
+					oldp := 1.0
+					for i := n - 2; i >= 0; i-- {
+						if prod <= eps {
+							prod = ((work[i+1] * lplus[i+1]) / (work[i] * lplus[i])) * oldp
+						} else {
+							prod *= math.Abs(lplus[i])
+						}
+						oldp = prod
+						znm2 += prod * prod
+						tmp = math.Max(tmp, math.Abs(work[i]*prod))
+					}
+					rrr2 := tmp / (spdiam * math.Sqrt(znm2))
+					if rrr2 <= maxgrowth2 {
+						sigma = rsigma
+						shift = sright
+						break
+					}
+				}
+			}
+		}
+
+		if ktry < ktrymax {
+			// If we are here, both shifts failed also the RRR test.
+			// Back off to the outside.
+			lsigma = math.Max(lsigma-ldelta, lsigma-ldmax)
+			rsigma = math.Min(rsigma+rdelta, rsigma+rdmax)
+			ldelta *= 2
+			rdelta *= 2
+			ktry++
+			continue
+		}
+		// None of the representations investigated satisfied our
+		// criteria. Take the best one we found.
+		if smlgrowth < fail || nofail {
+			lsigma = bestshift
+			rsigma = bestshift
+			forcer = true
+			continue
+		}
+		return sigma, false
+	}
+
+	if shift == sright {
+		// Store the new L and D back into dplus and lplus.
+		copy(dplus[:n], work[:n])
+		copy(lplus[:n-1], work[n:2*n-1])
+	}
+	return sigma, true
+}
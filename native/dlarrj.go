@@ -0,0 +1,175 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarrj refines, given the initial eigenvalue approximations of a symmetric
+// tridiagonal matrix T, the eigenvalues with indices ifirst through ilast by
+// bisection on T.
+//
+// d contains the n diagonal elements of T and e2 contains the n-1 squared
+// off-diagonal elements. The eigenvalue with index i is stored in w[i-offset]
+// with error bound werr[i-offset]. On return, w and werr contain the refined
+// approximations and error bounds. An interval [left,right] has converged if
+//  right-left < rtol*max(|left|,|right|).
+//
+// work must have length at least 2*n and iwork must have length at least 2*n.
+// pivmin is the minimum pivot in the Sturm sequence and spdiam is the spectral
+// diameter of T.
+//
+// Dlarrj is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarrj(n int, d, e2 []float64, ifirst, ilast int, rtol float64, offset int, w, werr, work []float64, iwork []int, pivmin, spdiam float64) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e2) < n-1 {
+		panic(badE)
+	}
+	if len(work) < 2*n || len(iwork) < 2*n {
+		panic(badWork)
+	}
+
+	// count returns the number of eigenvalues of T less than s.
+	count := func(s float64) int {
+		var cnt int
+		dplus := d[0] - s
+		if dplus < 0 {
+			cnt++
+		}
+		for j := 1; j < n; j++ {
+			dplus = d[j] - s - e2[j-1]/dplus
+			if dplus < 0 {
+				cnt++
+			}
+		}
+		return cnt
+	}
+
+	maxitr := int((math.Log(spdiam+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	// Initialize the unconverged intervals in [work[2*i], work[2*i+1]]. The
+	// Sturm count of work[2*i] is arranged to be i, while the count of
+	// work[2*i+1] is stored in iwork[2*i+1]. iwork[2*i] for an unconverged
+	// interval is set to the index of the next unconverged interval, and is
+	// -1 or 0 for a converged interval. Thus a linked list of unconverged
+	// intervals is set up.
+	i1 := ifirst
+	i2 := ilast
+	// The number of unconverged intervals.
+	var nint int
+	// The last unconverged interval found.
+	prev := -1
+	for i := i1; i <= i2; i++ {
+		k := 2 * i
+		ii := i - offset
+		left := w[ii] - werr[ii]
+		mid := w[ii]
+		right := w[ii] + werr[ii]
+		width := right - mid
+		tmp := math.Max(math.Abs(left), math.Abs(right))
+
+		// The following test prevents the test of converged intervals.
+		if width < rtol*tmp {
+			// This interval has already converged and does not need
+			// refinement. Note that the gaps might change through
+			// refining the eigenvalues, however, they can only get
+			// bigger. Remove it from the list.
+			iwork[k] = -1
+			// Make sure that i1 always points to the first unconverged
+			// interval.
+			if i == i1 && i < i2 {
+				i1 = i + 1
+			}
+			if prev >= i1 && i <= i2 {
+				iwork[2*prev] = i + 1
+			}
+		} else {
+			// Unconverged interval found.
+			prev = i
+			// Make sure that [left,right] contains the desired
+			// eigenvalue.
+			fac := 1.0
+			for count(left) > i {
+				left -= werr[ii] * fac
+				fac *= 2
+			}
+			fac = 1
+			cnt := count(right)
+			for cnt <= i {
+				right += werr[ii] * fac
+				fac *= 2
+				cnt = count(right)
+			}
+			nint++
+			iwork[k] = i + 1
+			iwork[k+1] = cnt
+		}
+		work[k] = left
+		work[k+1] = right
+	}
+
+	savi1 := i1
+
+	// Do while there are still unconverged intervals and while
+	// iter < maxitr.
+	for iter := 0; nint > 0 && iter <= maxitr; iter++ {
+		prev = i1 - 1
+		i := i1
+		olnint := nint
+
+		for p := 0; p < olnint; p++ {
+			k := 2 * i
+			next := iwork[k]
+			left := work[k]
+			right := work[k+1]
+			mid := 0.5 * (left + right)
+			// Semi-width of the interval.
+			width := right - mid
+			tmp := math.Max(math.Abs(left), math.Abs(right))
+			if width < rtol*tmp || iter == maxitr {
+				// Reduce the number of unconverged intervals.
+				nint--
+				// Mark the interval as converged.
+				iwork[k] = 0
+				if i1 == i {
+					i1 = next
+				} else if prev >= i1 {
+					// prev holds the last unconverged interval
+					// previously examined.
+					iwork[2*prev] = next
+				}
+				i = next
+				continue
+			}
+			prev = i
+
+			// Perform one bisection step.
+			if count(mid) <= i {
+				work[k] = mid
+			} else {
+				work[k+1] = mid
+			}
+			i = next
+		}
+	}
+
+	// At this point, all the intervals have converged.
+	for i := savi1; i <= ilast; i++ {
+		k := 2 * i
+		ii := i - offset
+		// All intervals marked by 0 have been refined.
+		if iwork[k] == 0 {
+			w[ii] = 0.5 * (work[k] + work[k+1])
+			werr[ii] = work[k+1] - w[ii]
+		}
+	}
+}
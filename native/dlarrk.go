@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarrk computes one eigenvalue of a symmetric tridiagonal matrix T to
+// suitable accuracy using bisection. iw is the index of the wanted eigenvalue,
+// with the eigenvalues numbered from 0 in ascending order, and [gl,gu] is an
+// interval containing all eigenvalues of T.
+//
+// d contains the n diagonal elements of T and e2 contains the n-1 squared
+// off-diagonal elements of T. pivmin is the minimum pivot allowed in the Sturm
+// sequence and reltol is the minimum relative width of the final interval.
+//
+// Dlarrk returns the eigenvalue approximation w and the error bound werr, the
+// half-width of the final interval containing the eigenvalue. ok is false if
+// the bisection did not converge in the maximum number of steps.
+//
+// Dlarrk is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarrk(n, iw int, gl, gu float64, d, e2 []float64, pivmin, reltol float64) (w, werr float64, ok bool) {
+	const fudge = 2
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return 0, 0, true
+	}
+	if iw < 0 || n <= iw {
+		panic("lapack: iw out of range")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e2) < n-1 {
+		panic(badE)
+	}
+
+	eps := dlamchP
+	tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+	rtoli := reltol
+	atoli := fudge * 2 * pivmin
+	itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+
+	left := gl - fudge*tnorm*eps*float64(n) - fudge*2*pivmin
+	right := gu + fudge*tnorm*eps*float64(n) + fudge*2*pivmin
+
+	for it := 0; ; it++ {
+		// Check if the interval converged or the maximum number of
+		// iterations was reached.
+		tmp1 := math.Abs(right - left)
+		tmp2 := math.Max(math.Abs(right), math.Abs(left))
+		if tmp1 < math.Max(math.Max(atoli, pivmin), rtoli*tmp2) {
+			ok = true
+			break
+		}
+		if it > itmax {
+			break
+		}
+
+		// Count the number of negative pivots for the mid-point.
+		mid := 0.5 * (left + right)
+		var negcnt int
+		tmp1 = d[0] - mid
+		if math.Abs(tmp1) < pivmin {
+			tmp1 = -pivmin
+		}
+		if tmp1 <= 0 {
+			negcnt++
+		}
+		for i := 1; i < n; i++ {
+			tmp1 = d[i] - e2[i-1]/tmp1 - mid
+			if math.Abs(tmp1) < pivmin {
+				tmp1 = -pivmin
+			}
+			if tmp1 <= 0 {
+				negcnt++
+			}
+		}
+		if negcnt > iw {
+			right = mid
+		} else {
+			left = mid
+		}
+	}
+	w = 0.5 * (left + right)
+	werr = 0.5 * math.Abs(right-left)
+	return w, werr, ok
+}
@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlarrr performs tests to decide whether the symmetric tridiagonal matrix T
+// with diagonal d and off-diagonal e warrants expensive computations which
+// guarantee high relative accuracy in the eigenvalues. d must have length at
+// least n and e must have length at least n-1.
+//
+// Dlarrr returns true if relative accuracy computations are warranted.
+//
+// Dlarrr is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarrr(n int, d, e []float64) bool {
+	// relcond corresponds to losing at most 3 decimal digits of accuracy.
+	const relcond = 0.999
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if n == 0 {
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	rmin := math.Sqrt(dlamchS / dlamchP)
+
+	// Test for scaled diagonal dominance. Scale the diagonal entries to one
+	// and check whether the sum of the off-diagonals is less than one.
+	//
+	// The scaled diagonally dominant relative error bounds have a
+	// 1/(1-2*x) factor in them, x = max(offdig + offdig2), so when x is
+	// close to 1/2, no relative accuracy is promised. In the notation of
+	// the code fragment below, 1/(1-(offdig+offdig2)) is the condition
+	// number. It is not worth going into this mode unless the relative
+	// condition number is reasonable, so offdig+offdig2 <= relcond is
+	// required, corresponding to 1/(1-(offdig+offdig2)) <= 1000.
+	var offdig float64
+	tmp := math.Sqrt(math.Abs(d[0]))
+	if tmp < rmin {
+		return false
+	}
+	for i := 1; i < n; i++ {
+		tmp2 := math.Sqrt(math.Abs(d[i]))
+		if tmp2 < rmin {
+			return false
+		}
+		offdig2 := math.Abs(e[i-1]) / (tmp * tmp2)
+		if offdig+offdig2 >= relcond {
+			return false
+		}
+		tmp = tmp2
+		offdig = offdig2
+	}
+	return true
+}
@@ -0,0 +1,703 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlarrv computes the eigenvectors of the tridiagonal matrix
+//  T = L * D * L^T
+// given L, D and approximations to the eigenvalues of L * D * L^T. The input
+// eigenvalues should have been computed by Dlarre.
+//
+// vl and vu are the bounds of the interval that contains the desired
+// eigenvalues and are needed to compute the gaps on the left or right end of
+// the extremal eigenvalues in the desired range.
+//
+// On entry, d and l contain the diagonal elements of D and the sub-diagonal
+// elements of the unit bidiagonal matrices L for each block of T, with
+// l[isplit[j]] holding the shift of the j-th block, as returned by Dlarre.
+// They are overwritten on return. pivmin is the minimum pivot allowed in the
+// Sturm sequence and isplit[j] contains the index of the last row of the j-th
+// block of T.
+//
+// m is the total number of input eigenvalues and dol through dou (starting
+// from 0) are the indices of the eigenvectors to compute. To compute all
+// eigenvectors, dol must be 0 and dou must be m-1.
+//
+// minrgp is the threshold on the relative gap for an eigenvalue to be
+// considered as not belonging to a cluster, and rtol1 and rtol2 are the
+// parameters for bisection, as in Dlarrb.
+//
+// On entry, the first m elements of w contain the eigenvalue approximations
+// of the L * D * L^T representations, ordered by block, with werr holding
+// their error bounds and wgap the separation from the right neighbor. On
+// return, w holds the refined approximations of the eigenvalues of T. iblock
+// and indexw hold the block number and the index within the block of each
+// eigenvalue, and gers contains the Gerschgorin intervals of T, as computed by
+// Dlarre.
+//
+// On return, columns dol through dou of the n×m matrix z contain the
+// orthonormal eigenvectors of T. isuppz[2*i] and isuppz[2*i+1] hold the
+// indices of the first and last non-zero elements of the i-th eigenvector.
+// isuppz must have length at least 2*m.
+//
+// work must have length at least 12*n and iwork must have length at least 7*n.
+//
+// Dlarrv returns whether all the eigenvectors were successfully computed.
+//
+// Dlarrv is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarrv(n int, vl, vu float64, d, l []float64, pivmin float64, isplit []int, m, dol, dou int, minrgp, rtol1, rtol2 float64, w, werr, wgap []float64, iblock, indexw []int, gers, z []float64, ldz int, isuppz []int, work []float64, iwork []int) (ok bool) {
+	const maxitr = 10
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if m < 0 || n < m {
+		panic("lapack: m out of range")
+	}
+
+	// Quick return if possible.
+	if n == 0 || m == 0 {
+		return true
+	}
+	if dol < 0 || m <= dol || dou < dol || m <= dou {
+		panic("lapack: bad dol or dou")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(l) < n {
+		panic(badE)
+	}
+	if len(isplit) < n || len(iblock) < m || len(indexw) < m {
+		panic(badIndex)
+	}
+	if len(w) < m || len(werr) < m || len(wgap) < m {
+		panic(badW)
+	}
+	if len(gers) < 2*n {
+		panic(badSlice)
+	}
+	checkMatrix(n, m, z, ldz)
+	if len(isuppz) < 2*m {
+		panic(badIsuppz)
+	}
+	if len(work) < 12*n || len(iwork) < 7*n {
+		panic(badWork)
+	}
+
+	bi := blas64.Implementation()
+
+	// The first n entries of work are reserved for the eigenvalues.
+	indld := n
+	indlld := 2 * n
+	indwrk := 3 * n
+	for i := 0; i < 12*n; i++ {
+		work[i] = 0
+	}
+	// The new relatively robust representation of a cluster is computed by
+	// Dlarrf in dplus and lplus before being stored in z, and the
+	// eigenvector of a singleton is computed by Dlar1v in zvec before being
+	// stored in z.
+	dplus := work[indwrk+4*n : indwrk+5*n]
+	lplus := work[indwrk+5*n : indwrk+6*n]
+	zvec := work[indwrk+6*n : indwrk+7*n]
+
+	// iwork[iindr:iindr+n] hold the twist indices r for the factorization
+	// used to compute the FP vector, with -1 meaning that the twist index is
+	// to be chosen by Dlar1v. iwork[iindc1:iindc2+n] are used to store the
+	// clusters of the current layer and the one above.
+	iindr := 0
+	iindc1 := n
+	iindc2 := 2 * n
+	iindwk := 3 * n
+	for i := 0; i < 7*n; i++ {
+		iwork[i] = 0
+	}
+	for i := iindr; i < iindr+n; i++ {
+		iwork[i] = -1
+	}
+
+	// Set the bounds for the use of z.
+	zusedl := 0
+	if dol > 0 {
+		zusedl = dol - 1
+	}
+	zusedu := m - 1
+	if dou < m-1 {
+		zusedu = dou + 1
+	}
+	// The width of the part of z that is used.
+	zusedw := zusedu - zusedl + 1
+	impl.Dlaset(blas.All, n, zusedw, 0, 0, z[zusedl:], ldz)
+
+	eps := dlamchP
+	rqtol := 2 * eps
+
+	// Set expert flags for standard code.
+	const tryrqc = true
+
+	allvec := dol == 0 && dou == m-1
+	if !allvec {
+		// Only selected eigenpairs are computed. Since the other
+		// eigenvalues are not refined by Rayleigh quotient iteration,
+		// bisection has to compute to full accuracy.
+		rtol1 = 4 * eps
+		rtol2 = 4 * eps
+	}
+
+	// rrrColumn returns the column of z in which the representation for a
+	// cluster starting at the w index j is stored.
+	rrrColumn := func(j int) int {
+		switch {
+		case allvec:
+			// Store the representation at the location of the
+			// leftmost eigenvalue of the cluster.
+			return j
+		case j < dol:
+			// Store the representation at the left end of z.
+			return dol - 1
+		case j > dou:
+			// Store the representation at the right end of z.
+			return dou
+		}
+		return j
+	}
+
+	// The entries wbegin through wend in w, werr and wgap correspond to the
+	// desired eigenvalues. The support of the non-zero eigenvector entries
+	// is contained in the interval ibegin through iend. Note that if k
+	// eigenpairs are desired, then the eigenvectors are stored in k
+	// contiguous columns of z.
+	ibegin := 0
+	wbegin := 0
+	for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+		iend := isplit[jblk]
+		sigma := l[iend]
+
+		// Find the eigenvectors of the submatrix indexed ibegin through
+		// iend.
+		wend := wbegin - 1
+		for wend < m-1 && iblock[wend+1] == jblk {
+			wend++
+		}
+		if wend < wbegin {
+			ibegin = iend + 1
+			continue
+		}
+		if wend < dol || wbegin > dou {
+			ibegin = iend + 1
+			wbegin = wend + 1
+			continue
+		}
+
+		// Find the local spectral diameter of the block.
+		gl := gers[2*ibegin]
+		gu := gers[2*ibegin+1]
+		for i := ibegin + 1; i <= iend; i++ {
+			gl = math.Min(gers[2*i], gl)
+			gu = math.Max(gers[2*i+1], gu)
+		}
+		spdiam := gu - gl
+
+		// oldien is the number of rows before the current block.
+		oldien := ibegin
+		// The size of the current block.
+		in := iend - ibegin + 1
+		// The number of eigenvalues in the current block.
+		im := wend - wbegin + 1
+
+		// This is for a 1×1 block.
+		if ibegin == iend {
+			z[ibegin*ldz+wbegin] = 1
+			isuppz[2*wbegin] = ibegin
+			isuppz[2*wbegin+1] = ibegin
+			w[wbegin] += sigma
+			work[wbegin] = w[wbegin]
+			ibegin = iend + 1
+			wbegin++
+			continue
+		}
+
+		// The desired (shifted) eigenvalues are stored in
+		// w[wbegin:wend+1]. Note that these can be approximations, in
+		// which case the corresponding entries of werr give the size of
+		// the uncertainty interval. The eigenvalue approximations will be
+		// refined when necessary as high relative accuracy is required
+		// for the computation of the corresponding eigenvectors.
+		copy(work[wbegin:wbegin+im], w[wbegin:wbegin+im])
+
+		// Store in w the eigenvalue approximations with respect to the
+		// original matrix T.
+		for i := 0; i < im; i++ {
+			w[wbegin+i] += sigma
+		}
+
+		// ndepth is the current depth of the representation tree.
+		ndepth := 0
+		// parity is either 1 or 0.
+		parity := 1
+		// nclus is the number of clusters for the next level of the
+		// representation tree, starting with nclus = 1 for the root.
+		nclus := 1
+		iwork[iindc1] = 0
+		iwork[iindc1+1] = im - 1
+
+		// idone is the number of eigenvectors already computed in the
+		// current block. Generate the representation tree for the
+		// current block and compute the eigenvectors.
+		idone := 0
+		for idone < im {
+			// This is a crude protection against infinitely deep trees.
+			if ndepth > m {
+				return false
+			}
+			// Breadth first processing of the current level of the
+			// representation tree: oldncl is the number of clusters on
+			// the current level.
+			oldncl := nclus
+			// Reset nclus to count the number of child clusters.
+			nclus = 0
+
+			parity = 1 - parity
+			var oldcls, newcls int
+			if parity == 0 {
+				oldcls = iindc1
+				newcls = iindc2
+			} else {
+				oldcls = iindc2
+				newcls = iindc1
+			}
+
+			// Process the clusters on the current level.
+			for i := 0; i < oldncl; i++ {
+				// oldfst and oldlst are the first and last indices of
+				// the current cluster. Cluster indices start with 0 and
+				// are relative to wbegin when accessing w, wgap, werr
+				// and z.
+				oldfst := iwork[oldcls+2*i]
+				oldlst := iwork[oldcls+2*i+1]
+				if ndepth > 0 {
+					// Retrieve the relatively robust representation
+					// (RRR) of the cluster that has been computed at the
+					// previous level. The RRR is stored in z and
+					// overwritten once the eigenvectors have been
+					// computed or when the cluster is refined.
+					j := rrrColumn(wbegin + oldfst)
+					bi.Dcopy(in, z[ibegin*ldz+j:], ldz, d[ibegin:], 1)
+					bi.Dcopy(in-1, z[ibegin*ldz+j+1:], ldz, l[ibegin:], 1)
+					sigma = z[iend*ldz+j+1]
+
+					// Set the corresponding entries in z to zero.
+					impl.Dlaset(blas.All, in, 2, 0, 0, z[ibegin*ldz+j:], ldz)
+				}
+
+				// Compute d*l and d*l*l of the current RRR.
+				for j := ibegin; j < iend; j++ {
+					tmp := d[j] * l[j]
+					work[indld+j] = tmp
+					work[indlld+j] = tmp * l[j]
+				}
+
+				if ndepth > 0 {
+					// p and q are the indices of the first and last
+					// eigenvalue to compute within the current block.
+					p := indexw[wbegin+oldfst]
+					q := indexw[wbegin+oldlst]
+					// Offset for the arrays work, wgap and werr, i.e.,
+					// the p-offset through the q-offset elements of
+					// these arrays are to be used.
+					offset := indexw[wbegin]
+					// Perform limited bisection (if necessary) to get
+					// approximate eigenvalues to the precision needed.
+					impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], p, q, rtol1, rtol2, offset,
+						work[wbegin:], wgap[wbegin:], werr[wbegin:], work[indwrk:], iwork[iindwk:],
+						pivmin, spdiam, in-1)
+					// Also recompute the extremal gaps. w holds all
+					// eigenvalues of the unshifted matrix and must be
+					// used for the computation of wgap, since the entries
+					// of work might stem from RRRs with different shifts.
+					// The gaps from wbegin+oldfst to wbegin+oldlst are
+					// correctly computed in Dlarrb. However, the gaps are
+					// only allowed to become greater since this is what
+					// should happen when werr is decreased.
+					if oldfst > 0 {
+						j := wbegin + oldfst
+						wgap[j-1] = math.Max(wgap[j-1], w[j]-werr[j]-w[j-1]-werr[j-1])
+					}
+					if wbegin+oldlst < wend {
+						j := wbegin + oldlst
+						wgap[j] = math.Max(wgap[j], w[j+1]-werr[j+1]-w[j]-werr[j])
+					}
+					// Each time the eigenvalues in work get refined, the
+					// newly found approximation with all shifts applied
+					// is stored in w.
+					for j := oldfst; j <= oldlst; j++ {
+						w[wbegin+j] = work[wbegin+j] + sigma
+					}
+				}
+
+				// Process the current node.
+				newfst := oldfst
+				for j := oldfst; j <= oldlst; j++ {
+					var newlst int
+					switch {
+					case j == oldlst:
+						// We are at the right end of the cluster, this is
+						// also the boundary of the child cluster.
+						newlst = j
+					case wgap[wbegin+j] >= minrgp*math.Abs(work[wbegin+j]):
+						// The right relative gap is big enough, the child
+						// cluster (newfst, ..., newlst) is well separated
+						// from the following.
+						newlst = j
+					default:
+						// Inside a child cluster, the relative gap is not
+						// big enough.
+						continue
+					}
+
+					// Compute the size of the child cluster found.
+					newsiz := newlst - newfst + 1
+
+					// newftt is the place in z where the new RRR or the
+					// computed eigenvector is to be stored.
+					newftt := rrrColumn(wbegin + newfst)
+
+					if newsiz > 1 {
+						// The current child is not a singleton but a
+						// cluster. Compute and store the new
+						// representation of the child.
+						//
+						// Compute the left and right cluster gaps. lgap
+						// and rgap are not computed from work because the
+						// eigenvalue approximations may stem from RRRs
+						// with different shifts. However, w holds all
+						// eigenvalues of the unshifted matrix. Still, the
+						// entries in wgap have to be computed from work
+						// since the entries in w might be of the same
+						// order so that gaps are not exhibited correctly
+						// for very close eigenvalues.
+						var lgap float64
+						if newfst == 0 {
+							lgap = math.Max(0, w[wbegin]-werr[wbegin]-vl)
+						} else {
+							lgap = wgap[wbegin+newfst-1]
+						}
+						rgap := wgap[wbegin+newlst]
+
+						// Compute the left- and rightmost eigenvalue of
+						// the child to high precision in order to shift
+						// as close as possible and obtain as large
+						// relative gaps as possible.
+						offset := indexw[wbegin]
+						for _, p := range [2]int{indexw[wbegin+newfst], indexw[wbegin+newlst]} {
+							impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], p, p, rqtol, rqtol, offset,
+								work[wbegin:], wgap[wbegin:], werr[wbegin:], work[indwrk:], iwork[iindwk:],
+								pivmin, spdiam, in-1)
+						}
+
+						if wbegin+newlst < dol || wbegin+newfst > dou {
+							// If the cluster contains no desired
+							// eigenvalues, skip the computation of that
+							// branch of the representation tree.
+							//
+							// We could skip before the refinement of the
+							// extremal eigenvalues of the child, but then
+							// the representation tree could be different
+							// from the one when nothing is skipped. For
+							// this reason we skip at this place.
+							idone += newlst - newfst + 1
+							newfst = j + 1
+							continue
+						}
+
+						// Compute the RRR of the child cluster and store
+						// it in z.
+						tau, ok := impl.Dlarrf(in, d[ibegin:], l[ibegin:], work[indld+ibegin:],
+							newfst, newlst, work[wbegin:], wgap[wbegin:], werr[wbegin:],
+							spdiam, lgap, rgap, pivmin, dplus, lplus, work[indwrk:])
+						if !ok {
+							return false
+						}
+						bi.Dcopy(in, dplus, 1, z[ibegin*ldz+newftt:], ldz)
+						bi.Dcopy(in-1, lplus, 1, z[ibegin*ldz+newftt+1:], ldz)
+						// A new RRR for the cluster was found by Dlarrf.
+						// Update the shift and store it.
+						ssigma := sigma + tau
+						z[iend*ldz+newftt+1] = ssigma
+						// work holds the midpoints and werr the
+						// semi-widths. Note that the entries in w are
+						// unchanged.
+						for k := newfst; k <= newlst; k++ {
+							fudge := 3 * eps * math.Abs(work[wbegin+k])
+							work[wbegin+k] -= tau
+							fudge += 4 * eps * math.Abs(work[wbegin+k])
+							// Fudge errors.
+							werr[wbegin+k] += fudge
+							// Gaps are not fudged. Provided that werr is
+							// small when eigenvalues are close, a zero gap
+							// indicates that a new representation is
+							// needed for resolving the cluster. A fudge
+							// could lead to a wrong decision of judging
+							// eigenvalues "separated" which in reality
+							// are not. This could have a negative impact
+							// on the orthogonality of the computed
+							// eigenvectors.
+						}
+
+						k := newcls + 2*nclus
+						iwork[k] = newfst
+						iwork[k+1] = newlst
+						nclus++
+						newfst = j + 1
+						continue
+					}
+
+					// Compute the eigenvector of a singleton.
+					tol := 4 * math.Log(float64(in)) * eps
+
+					k := newfst
+					windex := wbegin + k
+					windmn := max(windex-1, 0)
+					windpl := min(windex+1, m-1)
+					lambda := work[windex]
+					// Check if the eigenvector computation is to be
+					// skipped.
+					eskip := windex < dol || windex > dou
+					var savgap float64
+					if !eskip {
+						left := work[windex] - werr[windex]
+						right := work[windex] + werr[windex]
+						indeig := indexw[windex]
+						// Note that since the eigenpairs for a child are
+						// computed, all eigenvalue approximations are
+						// with respect to the same shift. In this case,
+						// the entries in work should be used for
+						// computing the gaps since they exhibit even very
+						// small differences in the eigenvalues, as
+						// opposed to the entries in w which might "look"
+						// the same.
+						var lgap, rgap float64
+						if k == 0 {
+							// In the case of lapack.RangeIndex and with
+							// not much initial accuracy in lambda and vl,
+							// the formula
+							//  lgap = max(0, (sigma - vl) + lambda)
+							// can lead to an overestimation of the left
+							// gap and thus to inadequately early
+							// Rayleigh quotient iteration convergence.
+							// Prevent this by forcing a small left gap.
+							lgap = eps * math.Max(math.Abs(left), math.Abs(right))
+						} else {
+							lgap = wgap[windmn]
+						}
+						if k == im-1 {
+							// As above, prevent an overestimation of the
+							// right gap by forcing a small right gap.
+							rgap = eps * math.Max(math.Abs(left), math.Abs(right))
+						} else {
+							rgap = wgap[windex]
+						}
+						gap := math.Min(lgap, rgap)
+						var gaptol float64
+						if k != 0 && k != im-1 {
+							// The eigenvector support can become wrong
+							// because significant entries could be cut
+							// off due to a large gaptol parameter in
+							// Dlar1v. Prevent this for the extremal
+							// eigenvalues.
+							gaptol = gap * eps
+						}
+						// Update wgap so that it holds the minimum gap to
+						// the left or the right. This is crucial in the
+						// case where bisection is used to ensure that the
+						// eigenvalue is refined up to the required
+						// precision. The correct value is restored
+						// afterwards.
+						savgap = wgap[windex]
+						wgap[windex] = gap
+						// We want to use the Rayleigh quotient correction
+						// as often as possible since it converges
+						// quadratically when we are close enough to the
+						// desired eigenvalue. However, the Rayleigh
+						// quotient can have the wrong sign and lead us
+						// away from the desired eigenvalue. In this case,
+						// the best we can do is to use bisection.
+						var usedbs, usedrq bool
+						// Bisection is initially turned off unless it is
+						// forced.
+						needbs := !tryrqc
+						var (
+							iter          int
+							bstres, bstw  float64
+							nrminv, resid float64
+						)
+						for {
+							// Check if bisection should be used to refine
+							// the eigenvalue.
+							if needbs {
+								// Take the bisection as the new iterate.
+								usedbs = true
+								itmp1 := iwork[iindr+windex]
+								offset := indexw[wbegin]
+								impl.Dlarrb(in, d[ibegin:], work[indlld+ibegin:], indeig, indeig, 0, 2*eps, offset,
+									work[wbegin:], wgap[wbegin:], werr[wbegin:], work[indwrk:], iwork[iindwk:],
+									pivmin, spdiam, itmp1)
+								lambda = work[windex]
+								// Reset the twist index from the
+								// inaccurate lambda to force the
+								// computation of the true mingma.
+								iwork[iindr+windex] = -1
+							}
+							// Given lambda, compute the eigenvector.
+							var negcnt int
+							var rqcorr float64
+							negcnt, _, _, iwork[iindr+windex], nrminv, resid, rqcorr = impl.Dlar1v(in, 0, in-1, lambda,
+								d[ibegin:], l[ibegin:], work[indld+ibegin:], work[indlld+ibegin:], pivmin, gaptol,
+								zvec, !usedbs, iwork[iindr+windex], isuppz[2*windex:], work[indwrk:])
+							if iter == 0 || resid < bstres {
+								bstres = resid
+								bstw = lambda
+							}
+							iter++
+
+							// sin alpha <= |resid|/gap. Note that both
+							// the residual and the gap are proportional
+							// to the matrix, so ||T|| doesn't play a role
+							// in the quotient.
+							//
+							// Convergence test for Rayleigh quotient
+							// iteration, omitted if bisection has been
+							// used.
+							if resid > tol*gap && math.Abs(rqcorr) > rqtol*math.Abs(lambda) && !usedbs {
+								// Check that the Rayleigh quotient
+								// correction doesn't move the eigenvalue
+								// away from the desired one and towards a
+								// neighbor, protecting with bisection.
+								var sgndef float64
+								if indeig < negcnt {
+									// The wanted eigenvalue lies to the
+									// left.
+									sgndef = -1
+								} else {
+									// The wanted eigenvalue lies to the
+									// right.
+									sgndef = 1
+								}
+								// Only use the correction if it improves
+								// the iterate reasonably.
+								if rqcorr*sgndef >= 0 && lambda+rqcorr <= right && lambda+rqcorr >= left {
+									usedrq = true
+									// Store the new midpoint of the
+									// bisection interval in work.
+									if sgndef == 1 {
+										// The current lambda is on the left
+										// of the true eigenvalue.
+										left = lambda
+									} else {
+										// The current lambda is on the
+										// right of the true eigenvalue.
+										right = lambda
+									}
+									work[windex] = 0.5 * (right + left)
+									// Take the correction since it has the
+									// correct sign and improves the iterate
+									// reasonably.
+									lambda += rqcorr
+									// Update the width of the error
+									// interval.
+									werr[windex] = 0.5 * (right - left)
+								} else {
+									needbs = true
+								}
+								switch {
+								case right-left < rqtol*math.Abs(lambda):
+									// The eigenvalue is computed to
+									// bisection accuracy. Compute the
+									// eigenvector and stop.
+									usedbs = true
+								case iter < maxitr:
+								case iter == maxitr:
+									needbs = true
+								default:
+									return false
+								}
+								continue
+							}
+							if !usedbs && (k == 0 || k == im-1) &&
+								!(negcnt == indeig && rqcorr >= 0) && !(negcnt == indeig+1 && rqcorr <= 0) {
+								// At the ends of the wanted part of the
+								// spectrum the neighboring eigenvalues may
+								// not be wanted and lie within the error
+								// interval, so Rayleigh quotient
+								// iteration may have converged to one of
+								// them. This is detected from the number
+								// of eigenvalues less than lambda and the
+								// direction of the last correction, and
+								// bisection is used instead.
+								usedrq = false
+								needbs = true
+								continue
+							}
+							if usedrq && usedbs && bstres <= resid {
+								// Improve the error angle by a second
+								// step.
+								lambda = bstw
+								_, _, _, iwork[iindr+windex], nrminv, resid, _ = impl.Dlar1v(in, 0, in-1, lambda,
+									d[ibegin:], l[ibegin:], work[indld+ibegin:], work[indlld+ibegin:], pivmin, gaptol,
+									zvec, !usedbs, iwork[iindr+windex], isuppz[2*windex:], work[indwrk:])
+							}
+							work[windex] = lambda
+							break
+						}
+
+						// Compute the FP vector support with respect to
+						// the whole matrix and store the normalized
+						// eigenvector in z.
+						zfrom := isuppz[2*windex]
+						zto := isuppz[2*windex+1]
+						isuppz[2*windex] += oldien
+						isuppz[2*windex+1] += oldien
+						for ii := 0; ii < in; ii++ {
+							if ii < zfrom || zto < ii {
+								z[(ibegin+ii)*ldz+windex] = 0
+							} else {
+								z[(ibegin+ii)*ldz+windex] = nrminv * zvec[ii]
+							}
+						}
+					}
+					// Update w.
+					w[windex] = lambda + sigma
+					// Recompute the gaps on the left and right, but only
+					// allow them to become larger and not smaller (which
+					// can only happen through "bad" cancellation and
+					// doesn't reflect the theory where the initial gaps
+					// are underestimated due to werr being too crude).
+					if !eskip {
+						if k > 0 {
+							wgap[windmn] = math.Max(wgap[windmn], w[windex]-werr[windex]-w[windmn]-werr[windmn])
+						}
+						if windex < wend {
+							wgap[windex] = math.Max(savgap, w[windpl]-werr[windpl]-w[windex]-werr[windex])
+						}
+					}
+					idone++
+					// Proceed to any remaining child nodes.
+					newfst = j + 1
+				}
+			}
+			ndepth++
+		}
+		ibegin = iend + 1
+		wbegin = wend + 1
+	}
+	return true
+}
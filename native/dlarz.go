@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlarz applies an elementary reflector H to an m×n matrix C from either the
+// left or the right. H is represented in the form
+//  H = I - tau * v * v^T
+// where tau is a scalar and v is a vector. The reflector is of the kind
+// produced by Dtzrzf, so that the first element of v is 1, the next elements
+// are zero, and only the last l elements of v are stored in v with increment
+// incv.
+//
+// If side == blas.Left, C is overwritten by H * C and the last l rows of C
+// are updated together with the first row. If side == blas.Right, C is
+// overwritten by C * H and the last l columns of C are updated together with
+// the first column. If tau is zero, H is the identity and C is not changed.
+//
+// work must have length at least n if side == blas.Left and at least m if
+// side == blas.Right.
+//
+// Dlarz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlarz(side blas.Side, m, n, l int, v []float64, incv int, tau float64, c []float64, ldc int, work []float64) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	if l < 0 || nq < l {
+		panic("lapack: l out of range")
+	}
+	if incv == 0 {
+		panic("lapack: zero increment")
+	}
+	checkMatrix(m, n, c, ldc)
+	if l > 0 {
+		checkVector(l, v, incv)
+	}
+	if len(work) < nw {
+		panic(badWork)
+	}
+
+	if tau == 0 || m == 0 || n == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	if side == blas.Left {
+		// Form H * C.
+
+		// w[0:n] = C[0, 0:n]
+		bi.Dcopy(n, c, 1, work, 1)
+		if l > 0 {
+			// w[0:n] += C[m-l:m, 0:n]^T * v[0:l]
+			bi.Dgemv(blas.Trans, l, n, 1, c[(m-l)*ldc:], ldc, v, incv, 1, work, 1)
+		}
+		// C[0, 0:n] -= tau * w[0:n]
+		bi.Daxpy(n, -tau, work, 1, c, 1)
+		if l > 0 {
+			// C[m-l:m, 0:n] -= tau * v[0:l] * w[0:n]^T
+			bi.Dger(l, n, -tau, v, incv, work, 1, c[(m-l)*ldc:], ldc)
+		}
+		return
+	}
+
+	// Form C * H.
+
+	// w[0:m] = C[0:m, 0]
+	bi.Dcopy(m, c, ldc, work, 1)
+	if l > 0 {
+		// w[0:m] += C[0:m, n-l:n] * v[0:l]
+		bi.Dgemv(blas.NoTrans, m, l, 1, c[n-l:], ldc, v, incv, 1, work, 1)
+	}
+	// C[0:m, 0] -= tau * w[0:m]
+	bi.Daxpy(m, -tau, work, 1, c, ldc)
+	if l > 0 {
+		// C[0:m, n-l:n] -= tau * w[0:m] * v[0:l]^T
+		bi.Dger(m, l, -tau, work, 1, v, incv, c[n-l:], ldc)
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlarzb applies a block reflector H or its transpose H^T to the m×n matrix C
+// from the left or the right. H is a product of k elementary reflectors of the
+// kind produced by Dtzrzf,
+//  H = I - V^T * T * V,
+// where the k×k lower triangular matrix T has been formed by Dlarzt. Only
+// direct == lapack.Backward and store == lapack.RowWise are supported.
+//
+// C is overwritten by
+//  H * C    if side == blas.Left  and trans == blas.NoTrans,
+//  H^T * C  if side == blas.Left  and trans == blas.Trans,
+//  C * H    if side == blas.Right and trans == blas.NoTrans,
+//  C * H^T  if side == blas.Right and trans == blas.Trans.
+// The reflectors act on the first k and the last l rows of C if
+// side == blas.Left, and on the first k and the last l columns of C if
+// side == blas.Right.
+//
+// v is a k×l matrix with stride ldv containing the stored part of the vectors
+// defining the reflectors. t is a k×k matrix with stride ldt.
+//
+// work is a temporary matrix with stride ldwork. It must be of size at least
+// n×k if side == blas.Left and m×k if side == blas.Right.
+//
+// Dlarzb is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarzb(side blas.Side, trans blas.Transpose, direct lapack.Direct, store lapack.StoreV, m, n, k, l int, v []float64, ldv int, t []float64, ldt int, c []float64, ldc int, work []float64, ldwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	if trans != blas.Trans && trans != blas.NoTrans {
+		panic(badTrans)
+	}
+	if direct != lapack.Forward && direct != lapack.Backward {
+		panic(badDirect)
+	}
+	if store != lapack.ColumnWise && store != lapack.RowWise {
+		panic(badStore)
+	}
+	if direct != lapack.Backward || store != lapack.RowWise {
+		panic("lapack: Dlarzb only supports backward direction and row-wise storage")
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if l < 0 || nq < l {
+		panic("lapack: l out of range")
+	}
+	checkMatrix(m, n, c, ldc)
+	checkMatrix(k, l, v, ldv)
+	checkMatrix(k, k, t, ldt)
+	checkMatrix(nw, k, work, ldwork)
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return
+	}
+
+	transt := blas.Trans
+	if trans == blas.Trans {
+		transt = blas.NoTrans
+	}
+
+	bi := blas64.Implementation()
+	if side == blas.Left {
+		// Form H * C or H^T * C.
+
+		// W[0:n, 0:k] = C[0:k, 0:n]^T
+		for j := 0; j < k; j++ {
+			bi.Dcopy(n, c[j*ldc:], 1, work[j:], ldwork)
+		}
+		if l > 0 {
+			// W[0:n, 0:k] += C[m-l:m, 0:n]^T * V[0:k, 0:l]^T
+			bi.Dgemm(blas.Trans, blas.Trans, n, k, l, 1, c[(m-l)*ldc:], ldc, v, ldv,
+				1, work, ldwork)
+		}
+		// W[0:n, 0:k] = W[0:n, 0:k] * T^T or W[0:n, 0:k] * T
+		bi.Dtrmm(blas.Right, blas.Lower, transt, blas.NonUnit, n, k, 1, t, ldt, work, ldwork)
+		// C[0:k, 0:n] -= W[0:n, 0:k]^T
+		for i := 0; i < k; i++ {
+			for j := 0; j < n; j++ {
+				c[i*ldc+j] -= work[j*ldwork+i]
+			}
+		}
+		if l > 0 {
+			// C[m-l:m, 0:n] -= V[0:k, 0:l]^T * W[0:n, 0:k]^T
+			bi.Dgemm(blas.Trans, blas.Trans, l, n, k, -1, v, ldv, work, ldwork,
+				1, c[(m-l)*ldc:], ldc)
+		}
+		return
+	}
+
+	// Form C * H or C * H^T.
+
+	// W[0:m, 0:k] = C[0:m, 0:k]
+	for j := 0; j < k; j++ {
+		bi.Dcopy(m, c[j:], ldc, work[j:], ldwork)
+	}
+	if l > 0 {
+		// W[0:m, 0:k] += C[0:m, n-l:n] * V[0:k, 0:l]^T
+		bi.Dgemm(blas.NoTrans, blas.Trans, m, k, l, 1, c[n-l:], ldc, v, ldv,
+			1, work, ldwork)
+	}
+	// W[0:m, 0:k] = W[0:m, 0:k] * T or W[0:m, 0:k] * T^T
+	bi.Dtrmm(blas.Right, blas.Lower, trans, blas.NonUnit, m, k, 1, t, ldt, work, ldwork)
+	// C[0:m, 0:k] -= W[0:m, 0:k]
+	for i := 0; i < m; i++ {
+		for j := 0; j < k; j++ {
+			c[i*ldc+j] -= work[i*ldwork+j]
+		}
+	}
+	if l > 0 {
+		// C[0:m, n-l:n] -= W[0:m, 0:k] * V[0:k, 0:l]
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, m, l, k, -1, work, ldwork, v, ldv,
+			1, c[n-l:], ldc)
+	}
+}
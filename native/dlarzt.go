@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlarzt forms the triangular factor T of a block reflector H of order n,
+// which is defined as a product of k elementary reflectors of the kind
+// produced by Dtzrzf. Only direct == lapack.Backward and
+// store == lapack.RowWise are supported, so that
+//  H = H_{k-1} * ... * H_1 * H_0
+// and
+//  H = I - V^T * T * V,
+// where T is a k×k lower triangular matrix.
+//
+// v is a k×n matrix with stride ldv whose i-th row contains the stored part
+// of the vector defining H_i, as returned by Dtzrzf in the last columns of
+// A. tau contains the scalar factors of the elementary reflectors and must
+// have length at least k.
+//
+// On return, the lower triangle of the k×k matrix t with stride ldt contains
+// the triangular factor T. The strictly upper triangle of t is not
+// referenced.
+//
+// Dlarzt is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlarzt(direct lapack.Direct, store lapack.StoreV, n, k int, v []float64, ldv int, tau, t []float64, ldt int) {
+	if direct != lapack.Forward && direct != lapack.Backward {
+		panic(badDirect)
+	}
+	if store != lapack.ColumnWise && store != lapack.RowWise {
+		panic(badStore)
+	}
+	if direct != lapack.Backward || store != lapack.RowWise {
+		panic("lapack: Dlarzt only supports backward direction and row-wise storage")
+	}
+	if n < 0 || k < 0 {
+		panic(negDimension)
+	}
+	checkMatrix(k, n, v, ldv)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	checkMatrix(k, k, t, ldt)
+
+	bi := blas64.Implementation()
+	for i := k - 1; i >= 0; i-- {
+		if tau[i] == 0 {
+			for j := i; j < k; j++ {
+				t[j*ldt+i] = 0
+			}
+			continue
+		}
+		if i < k-1 {
+			// T[i+1:k, i] = -tau[i] * V[i+1:k, 0:n] * V[i, 0:n]^T
+			if n > 0 {
+				bi.Dgemv(blas.NoTrans, k-i-1, n, -tau[i], v[(i+1)*ldv:], ldv, v[i*ldv:], 1,
+					0, t[(i+1)*ldt+i:], ldt)
+			} else {
+				// Dgemv does not modify T when n == 0.
+				for j := i + 1; j < k; j++ {
+					t[j*ldt+i] = 0
+				}
+			}
+
+			// T[i+1:k, i] = T[i+1:k, i+1:k] * T[i+1:k, i]
+			bi.Dtrmv(blas.Lower, blas.NoTrans, blas.NonUnit, k-i-1, t[(i+1)*ldt+i+1:], ldt,
+				t[(i+1)*ldt+i:], ldt)
+		}
+		t[i*ldt+i] = tau[i]
+	}
+}
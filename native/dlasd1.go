@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlasd1 computes the singular value decomposition of an n×m upper
+// bidiagonal matrix B, where n = nl + nr + 1 and m = n + sqre, when the
+// decompositions of its leading nl×(nl+1) and trailing nr×(nr+sqre) blocks
+// are already known. B has the form
+//  B = [ B1    0  ]
+//      [ alpha beta ]
+//      [ 0     B2 ]
+// where alpha is in column nl and beta in column nl+1 of the added row. It is
+// called by Dlasd0 to merge two subproblems of the divide and conquer tree.
+//
+// The singular values of B are computed as the square roots of the roots of a
+// secular equation using Dlasd2 to deflate the problem and Dlasd3 to solve
+// the secular equation and update the singular vectors.
+//
+// On entry, d[:nl] contains the singular values of the upper block and
+// d[nl+1:n] those of the lower block. On return, d contains the singular
+// values of B. The order of d is given by idxq, which on return contains the
+// permutation that sorts d into ascending order. On entry, idxq must contain
+// the permutations that separately sort the two subproblems.
+//
+// u is an n×n matrix that contains on entry the left singular vectors of the
+// upper block in its leading nl×nl submatrix and those of the lower block in
+// its trailing nr×nr submatrix. vt is an m×m matrix that contains on entry the
+// transposed right singular vectors of the upper block in its leading
+// (nl+1)×(nl+1) submatrix and those of the lower block in its trailing
+// (nr+sqre)×(nr+sqre) submatrix. On return, u and vt contain the left and
+// transposed right singular vectors of B.
+//
+// iwork must have length at least 4*n and work must have length at least
+// 3*m*m+2*m.
+//
+// Dlasd1 returns whether the secular equation solver converged.
+//
+// Dlasd1 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd1(nl, nr, sqre int, d []float64, alpha, beta float64, u []float64, ldu int, vt []float64, ldvt int, idxq, iwork []int, work []float64) (ok bool) {
+	if nl < 1 {
+		panic("lapack: nl < 1")
+	}
+	if nr < 1 {
+		panic("lapack: nr < 1")
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	if len(d) < n {
+		panic(badD)
+	}
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(m, m, vt, ldvt)
+	if len(idxq) < n {
+		panic(badIndex)
+	}
+	if len(iwork) < 4*n {
+		panic(badWork)
+	}
+	if len(work) < 3*m*m+2*m {
+		panic(badWork)
+	}
+
+	// The following values are for bookkeeping purposes only. They are
+	// integer pointers which indicate the portion of the workspace used by a
+	// particular array in Dlasd2 and Dlasd3.
+	ldu2 := n
+	ldvt2 := m
+	const iz = 0
+	isigma := iz + m
+	iu2 := isigma + n
+	ivt2 := iu2 + ldu2*n
+	iq := ivt2 + ldvt2*m
+
+	const idx = 0
+	idxc := idx + n
+	coltyp := idxc + n
+	idxp := coltyp + n
+
+	// Scale.
+	orgnrm := math.Max(math.Abs(alpha), math.Abs(beta))
+	d[nl] = 0
+	for _, v := range d[:n] {
+		orgnrm = math.Max(orgnrm, math.Abs(v))
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	alpha /= orgnrm
+	beta /= orgnrm
+
+	// Deflate singular values.
+	k := impl.Dlasd2(nl, nr, sqre, d, work[iz:], alpha, beta, u, ldu, vt, ldvt,
+		work[isigma:], work[iu2:], ldu2, work[ivt2:], ldvt2,
+		iwork[idxp:], iwork[idx:], iwork[idxc:], idxq, iwork[coltyp:])
+
+	// Solve the secular equation and update the singular vectors.
+	ldq := k
+	ok = impl.Dlasd3(nl, nr, sqre, k, d, work[iq:], ldq, work[isigma:], u, ldu,
+		work[iu2:], ldu2, vt, ldvt, work[ivt2:], ldvt2, iwork[idxc:], iwork[coltyp:], work[iz:])
+	if !ok {
+		return false
+	}
+
+	// Unscale.
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+
+	// Prepare the idxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, idxq)
+	return true
+}
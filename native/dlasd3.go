@@ -0,0 +1,199 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlasd3 finds all the square roots of the roots of the secular equation, as
+// defined by the values in dsigma and z, and updates the singular vectors. It
+// is called by Dlasd1 after Dlasd2 has deflated the problem.
+//
+// nl and nr are the row dimensions of the upper and lower blocks of the
+// bidiagonal matrix being merged, n = nl + nr + 1 and m = n + sqre, where
+// sqre must be 0 or 1. k is the size of the secular equation, 1 <= k <= n.
+//
+// On return, d[:k] contains the square roots of the roots of the secular
+// equation in ascending order. q is k×k workspace.
+//
+// dsigma contains the old roots of the secular equation in its first k
+// elements, and z contains the components of the deflation-adjusted updating
+// row vector in its first k elements. z is overwritten.
+//
+// u2 (n×n) and vt2 (m×m) contain the non-deflated left and right singular
+// vectors of the subproblems as computed by Dlasd2. The last n-k columns of u
+// and rows of vt contain the deflated singular vectors. On return, the first
+// k columns of u and rows of vt contain the updated singular vectors. The
+// first row of vt2 is modified.
+//
+// idxc and ctot are the permutation of the columns of u2 and the column type
+// counts computed by Dlasd2. idxc must have length at least k and ctot must
+// have length at least 4.
+//
+// Dlasd3 returns whether the secular equation solver converged.
+//
+// Dlasd3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd3(nl, nr, sqre, k int, d, q []float64, ldq int, dsigma, u []float64, ldu int, u2 []float64, ldu2 int, vt []float64, ldvt int, vt2 []float64, ldvt2 int, idxc, ctot []int, z []float64) (ok bool) {
+	if nl < 1 {
+		panic("lapack: nl < 1")
+	}
+	if nr < 1 {
+		panic("lapack: nr < 1")
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	if k < 1 || n < k {
+		panic("lapack: k out of range")
+	}
+	if len(d) < k {
+		panic(badD)
+	}
+	checkMatrix(k, k, q, ldq)
+	if len(dsigma) < k {
+		panic(badSlice)
+	}
+	checkMatrix(n, n, u, ldu)
+	checkMatrix(n, n, u2, ldu2)
+	checkMatrix(m, m, vt, ldvt)
+	checkMatrix(m, m, vt2, ldvt2)
+	if len(idxc) < k {
+		panic(badIndex)
+	}
+	if len(ctot) < 4 {
+		panic("lapack: ctot has insufficient length")
+	}
+	if len(z) < k {
+		panic(badZ)
+	}
+
+	bi := blas64.Implementation()
+
+	// Quick return if possible.
+	if k == 1 {
+		d[0] = math.Abs(z[0])
+		copy(vt[:m], vt2[:m])
+		if z[0] > 0 {
+			bi.Dcopy(n, u2, ldu2, u, ldu)
+		} else {
+			for i := 0; i < n; i++ {
+				u[i*ldu] = -u2[i*ldu2]
+			}
+		}
+		return true
+	}
+
+	// Keep a copy of z.
+	copy(q[:k], z[:k])
+
+	// Normalize z.
+	rho := bi.Dnrm2(k, z, 1)
+	impl.Dlascl(lapack.General, 0, 0, rho, 1, k, 1, z, 1)
+	rho *= rho
+
+	// Find the new singular values. The differences and sums between the
+	// old and new singular values returned by Dlasd4 are stored
+	// transposed in the rows of u and vt, so that the j-th row of u holds
+	// dsigma - d[j] and the j-th row of vt holds dsigma + d[j].
+	for j := 0; j < k; j++ {
+		d[j], ok = impl.Dlasd4(k, j, dsigma, z, u[j*ldu:], rho, vt[j*ldvt:])
+		if !ok {
+			return false
+		}
+	}
+
+	// Compute updated z.
+	for i := 0; i < k; i++ {
+		z[i] = u[(k-1)*ldu+i] * vt[(k-1)*ldvt+i]
+		for j := 0; j < i; j++ {
+			z[i] *= u[j*ldu+i] * vt[j*ldvt+i] / (dsigma[i] - dsigma[j]) / (dsigma[i] + dsigma[j])
+		}
+		for j := i; j < k-1; j++ {
+			z[i] *= u[j*ldu+i] * vt[j*ldvt+i] / (dsigma[i] - dsigma[j+1]) / (dsigma[i] + dsigma[j+1])
+		}
+		z[i] = math.Copysign(math.Sqrt(math.Abs(z[i])), q[i])
+	}
+
+	// Compute left singular vectors of the modified diagonal matrix, and
+	// store related information for the right singular vectors.
+	for i := 0; i < k; i++ {
+		vt[i*ldvt] = z[0] / u[i*ldu] / vt[i*ldvt]
+		u[i*ldu] = -1
+		for j := 1; j < k; j++ {
+			vt[i*ldvt+j] = z[j] / u[i*ldu+j] / vt[i*ldvt+j]
+			u[i*ldu+j] = dsigma[j] * vt[i*ldvt+j]
+		}
+		temp := bi.Dnrm2(k, u[i*ldu:], 1)
+		q[i] = u[i*ldu] / temp
+		for j := 1; j < k; j++ {
+			q[j*ldq+i] = u[i*ldu+idxc[j]] / temp
+		}
+	}
+
+	// Update the left singular vector matrix.
+	if k == 2 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, k, k, 1, u2, ldu2, q, ldq, 0, u, ldu)
+	} else {
+		switch {
+		case ctot[0] > 0:
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[0], 1, u2[1:], ldu2, q[ldq:], ldq, 0, u, ldu)
+			if ctot[2] > 0 {
+				ktemp := 1 + ctot[0] + ctot[1]
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[2], 1, u2[ktemp:], ldu2, q[ktemp*ldq:], ldq, 1, u, ldu)
+			}
+		case ctot[2] > 0:
+			ktemp := 1 + ctot[0] + ctot[1]
+			bi.Dgemm(blas.NoTrans, blas.NoTrans, nl, k, ctot[2], 1, u2[ktemp:], ldu2, q[ktemp*ldq:], ldq, 0, u, ldu)
+		default:
+			impl.Dlacpy(blas.All, nl, k, u2, ldu2, u, ldu)
+		}
+		copy(u[nl*ldu:nl*ldu+k], q[:k])
+		ktemp := 1 + ctot[0]
+		ctemp := ctot[1] + ctot[2]
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, nr, k, ctemp, 1, u2[(nl+1)*ldu2+ktemp:], ldu2, q[ktemp*ldq:], ldq, 0, u[(nl+1)*ldu:], ldu)
+	}
+
+	// Generate the right singular vectors.
+	for i := 0; i < k; i++ {
+		temp := bi.Dnrm2(k, vt[i*ldvt:], 1)
+		q[i*ldq] = vt[i*ldvt] / temp
+		for j := 1; j < k; j++ {
+			q[i*ldq+j] = vt[i*ldvt+idxc[j]] / temp
+		}
+	}
+
+	// Update the right singular vector matrix.
+	if k == 2 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, k, m, k, 1, q, ldq, vt2, ldvt2, 0, vt, ldvt)
+		return true
+	}
+	ktemp := 1 + ctot[0]
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nl+1, ktemp, 1, q, ldq, vt2, ldvt2, 0, vt, ldvt)
+	if ctot[2] > 0 {
+		ktemp = 1 + ctot[0] + ctot[1]
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nl+1, ctot[2], 1, q[ktemp:], ldq, vt2[ktemp*ldvt2:], ldvt2, 1, vt, ldvt)
+	}
+
+	ktemp = ctot[0]
+	nrp1 := nr + sqre
+	if ktemp > 0 {
+		for i := 0; i < k; i++ {
+			q[i*ldq+ktemp] = q[i*ldq]
+		}
+		for i := nl + 1; i < m; i++ {
+			vt2[ktemp*ldvt2+i] = vt2[i]
+		}
+	}
+	ctemp := 1 + ctot[1] + ctot[2]
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, k, nrp1, ctemp, 1, q[ktemp:], ldq, vt2[ktemp*ldvt2+nl+1:], ldvt2, 0, vt[nl+1:], ldvt)
+	return true
+}
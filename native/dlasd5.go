@@ -0,0 +1,100 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlasd5 computes the square root of the i-th eigenvalue of a positive
+// symmetric rank-one modification of a 2×2 diagonal matrix
+//  diag(d)*diag(d) + rho * z * z^T.
+// The diagonal elements in d are assumed to satisfy 0 <= d[0] < d[1], rho is
+// assumed to be positive and z is assumed to have unit Euclidean norm. i must
+// be 0 or 1.
+//
+// On return, delta[j] contains d[j] - sigma and work[j] contains d[j] + sigma,
+// where sigma is the computed singular value. d, z, delta and work must have
+// length at least 2.
+//
+// Dlasd5 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd5(i int, d, z, delta []float64, rho float64, work []float64) (dsigma float64) {
+	if i != 0 && i != 1 {
+		panic(badIndex)
+	}
+	if len(d) < 2 {
+		panic(badD)
+	}
+	if len(z) < 2 {
+		panic(badZ)
+	}
+	if len(delta) < 2 {
+		panic(badDelta)
+	}
+	if len(work) < 2 {
+		panic(badWork)
+	}
+
+	del := d[1] - d[0]
+	delsq := del * (d[1] + d[0])
+	if i == 0 {
+		w := 1 + 4*rho*(z[1]*z[1]/(d[0]+3*d[1])-z[0]*z[0]/(3*d[0]+d[1]))/del
+		if w > 0 {
+			b := delsq + rho*(z[0]*z[0]+z[1]*z[1])
+			c := rho * z[0] * z[0] * delsq
+
+			// b > 0, always. The following tau is dsigma*dsigma - d[0]*d[0].
+			tau := 2 * c / (b + math.Sqrt(math.Abs(b*b-4*c)))
+
+			// The following tau is dsigma - d[0].
+			tau /= d[0] + math.Sqrt(d[0]*d[0]+tau)
+			dsigma = d[0] + tau
+			delta[0] = -tau
+			delta[1] = del - tau
+			work[0] = 2*d[0] + tau
+			work[1] = (d[0] + tau) + d[1]
+			return dsigma
+		}
+
+		b := -delsq + rho*(z[0]*z[0]+z[1]*z[1])
+		c := rho * z[1] * z[1] * delsq
+
+		// The following tau is dsigma*dsigma - d[1]*d[1].
+		var tau float64
+		if b > 0 {
+			tau = -2 * c / (b + math.Sqrt(b*b+4*c))
+		} else {
+			tau = (b - math.Sqrt(b*b+4*c)) / 2
+		}
+
+		// The following tau is dsigma - d[1].
+		tau /= d[1] + math.Sqrt(math.Abs(d[1]*d[1]+tau))
+		dsigma = d[1] + tau
+		delta[0] = -(del + tau)
+		delta[1] = -tau
+		work[0] = d[0] + tau + d[1]
+		work[1] = 2*d[1] + tau
+		return dsigma
+	}
+
+	// Now i == 1.
+	b := -delsq + rho*(z[0]*z[0]+z[1]*z[1])
+	c := rho * z[1] * z[1] * delsq
+
+	// The following tau is dsigma*dsigma - d[1]*d[1].
+	var tau float64
+	if b > 0 {
+		tau = (b + math.Sqrt(b*b+4*c)) / 2
+	} else {
+		tau = 2 * c / (-b + math.Sqrt(b*b+4*c))
+	}
+
+	// The following tau is dsigma - d[1].
+	tau /= d[1] + math.Sqrt(d[1]*d[1]+tau)
+	dsigma = d[1] + tau
+	delta[0] = -(del + tau)
+	delta[1] = -tau
+	work[0] = d[0] + tau + d[1]
+	work[1] = 2*d[1] + tau
+	return dsigma
+}
@@ -0,0 +1,153 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dlasd6 computes the singular values of an n×m upper bidiagonal matrix B,
+// where n = nl + nr + 1 and m = n + sqre, when the singular values of its
+// leading nl×(nl+1) and trailing nr×(nr+sqre) blocks are already known, and
+// keeps the singular vectors in factored form. B has the form
+//  B = [ B1    0  ]
+//      [ alpha beta ]
+//      [ 0     B2 ]
+// where alpha is in column nl and beta in column nl+1 of the added row. It is
+// called by Dlasda to merge two subproblems of the divide and conquer tree
+// and is the analogue of Dlasd1 for the case where the singular vectors are
+// not formed explicitly.
+//
+// The singular values of B are computed as the square roots of the roots of a
+// secular equation using Dlasd7 to deflate the problem and Dlasd8 to solve the
+// secular equation.
+//
+// icompq specifies whether the factored form of the singular vectors is
+// computed. If icompq == 0, only the singular values and the first and last
+// components of the right singular vectors are updated. If icompq == 1, the
+// information needed to apply the singular vectors is returned in perm,
+// givcol, givnum, poles, difl, difr and z.
+//
+// On entry, d[:nl] contains the singular values of the upper block and
+// d[nl+1:n] those of the lower block. On return, d contains the singular
+// values of B and idxq contains the permutation that sorts d into ascending
+// order. On entry, idxq must contain the permutations that separately sort
+// the two subproblems.
+//
+// On entry, vf and vl contain the first and last components of all right
+// singular vectors of the two blocks. On return, they contain the first and
+// last components of the right singular vectors of B. vf and vl must have
+// length at least m.
+//
+// If icompq == 1, perm, givcol and givnum are set as described in Dlasd7, the
+// first k elements of z contain the components of the deflation-adjusted
+// updating vector, difl and the first row of difr are set as described in
+// Dlasd8 and the second row of difr contains the normalizing factors of the
+// right singular vectors. The first row of the 2×n matrix poles contains the
+// new singular values and the second row contains the old ones. givcol,
+// givnum, poles and difr are 2×n matrices with strides ldgcol and ldgnum.
+//
+// work must have length at least 4*m and iwork must have length at least
+// 2*n.
+//
+// Dlasd6 returns the number of Givens rotations, the order k of the secular
+// equation, the cosine c and sine s of the rotation used when sqre == 1, and
+// whether the secular equation solver converged.
+//
+// Dlasd6 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd6(icompq, nl, nr, sqre int, d, vf, vl []float64, alpha, beta float64, idxq, perm, givcol []int, ldgcol int, givnum []float64, ldgnum int, poles, difl, difr, z, work []float64, iwork []int) (givptr, k int, c, s float64, ok bool) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if nl < 1 {
+		panic("lapack: nl < 1")
+	}
+	if nr < 1 {
+		panic("lapack: nr < 1")
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(vf) < m || len(vl) < m {
+		panic(badSlice)
+	}
+	if len(z) < m {
+		panic(badZ)
+	}
+	if len(idxq) < n {
+		panic(badIndex)
+	}
+	if icompq == 1 {
+		if ldgcol < n || len(givcol) < ldgcol+n {
+			panic(badIndex)
+		}
+		checkMatrix(2, n, givnum, ldgnum)
+		checkMatrix(2, n, poles, ldgnum)
+		checkMatrix(2, n, difr, ldgnum)
+		if len(difl) < n {
+			panic(badSlice)
+		}
+	} else if len(difl) < n || len(difr) < n {
+		panic(badSlice)
+	}
+	if len(work) < 4*m {
+		panic(badWork)
+	}
+	if len(iwork) < 2*n {
+		panic(badWork)
+	}
+
+	// The following values are for bookkeeping purposes only. They are
+	// integer pointers which indicate the portion of the workspace used by a
+	// particular array in Dlasd7 and Dlasd8.
+	const isigma = 0
+	iw := isigma + n
+	ivfw := iw + m
+	ivlw := ivfw + m
+
+	const idx = 0
+	idxp := idx + n
+
+	// Scale.
+	orgnrm := math.Max(math.Abs(alpha), math.Abs(beta))
+	d[nl] = 0
+	for _, v := range d[:n] {
+		orgnrm = math.Max(orgnrm, math.Abs(v))
+	}
+	impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, n, 1, d, 1)
+	alpha /= orgnrm
+	beta /= orgnrm
+
+	// Sort and deflate singular values.
+	k, givptr, c, s = impl.Dlasd7(icompq, nl, nr, sqre, d, z, work[iw:], vf, work[ivfw:], vl, work[ivlw:],
+		alpha, beta, work[isigma:], iwork[idx:], iwork[idxp:], idxq, perm, givcol, ldgcol, givnum, ldgnum)
+
+	// Solve the secular equation, compute difl and difr, and update vf
+	// and vl.
+	ok = impl.Dlasd8(icompq, k, d, z, vf, vl, difl, difr, ldgnum, work[isigma:], work[iw:])
+	if !ok {
+		return givptr, k, c, s, false
+	}
+
+	// Save the poles if icompq == 1.
+	if icompq == 1 {
+		copy(poles[:k], d[:k])
+		copy(poles[ldgnum:ldgnum+k], work[isigma:isigma+k])
+	}
+
+	// Unscale.
+	impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, n, 1, d, 1)
+
+	// Prepare the idxq sorting permutation.
+	impl.Dlamrg(k, n-k, d, 1, -1, idxq)
+	return givptr, k, c, s, true
+}
@@ -0,0 +1,266 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlasd7 merges the two sets of singular values together into a single sorted
+// set and deflates the size of the problem. It is called by Dlasd6 and is
+// the analogue of Dlasd2 for the case where the singular vectors are kept in
+// factored form.
+//
+// There are two ways in which deflation can occur: when two or more singular
+// values are close together or if there is a tiny entry in the z vector. For
+// each such occurrence the order of the related secular equation problem is
+// reduced by one.
+//
+// icompq specifies whether the information needed to apply the deflating
+// transformations to the singular vectors is stored. If icompq == 1, perm,
+// givcol and givnum are set on return. Otherwise they are not referenced.
+//
+// nl and nr are the row dimensions of the upper and lower blocks of the
+// bidiagonal matrix being merged, and n = nl + nr + 1. sqre must be 0 or 1;
+// the lower block is nr×(nr+sqre) and m = n + sqre.
+//
+// On entry, d[:nl] and d[nl+1:n] contain the singular values of the two
+// submatrices to be combined, and alpha and beta contain the diagonal and
+// off-diagonal elements associated with the added row. vf and vl contain the
+// first and last components of the right singular vectors of the two
+// submatrices. idxq contains the permutations which separately sort the two
+// sub-problems in d into ascending order, with the indices of the second half
+// relative to nl+1.
+//
+// On return, the first k elements of dsigma and z contain the singular values
+// and the components of the updating vector of the deflated secular
+// equation, and vf and vl contain the first and last components of the
+// corresponding right singular vectors. The trailing n-k elements of d
+// contain the deflated singular values.
+//
+// If icompq == 1, perm contains on return the permutation applied to the
+// rows of each subproblem, and the first givptr columns of the 2×n matrices
+// givcol and givnum, with strides ldgcol and ldgnum, contain the pairs of
+// rows and the sines and cosines of the Givens rotations applied during
+// deflation.
+//
+// z, zw, vf, vfw, vl and vlw must have length at least m, dsigma, idx, idxp
+// and idxq must have length at least n, and if icompq == 1, perm must have
+// length at least n.
+//
+// Dlasd7 returns the order k of the secular equation, the number of Givens
+// rotations, and the cosine c and sine s of the rotation that is used to
+// annihilate the extra column when sqre == 1.
+//
+// Dlasd7 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd7(icompq, nl, nr, sqre int, d, z, zw, vf, vfw, vl, vlw []float64, alpha, beta float64, dsigma []float64, idx, idxp, idxq, perm, givcol []int, ldgcol int, givnum []float64, ldgnum int) (k, givptr int, c, s float64) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if nl < 1 {
+		panic("lapack: nl < 1")
+	}
+	if nr < 1 {
+		panic("lapack: nr < 1")
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	n := nl + nr + 1
+	m := n + sqre
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(z) < m {
+		panic(badZ)
+	}
+	if len(zw) < m || len(vf) < m || len(vfw) < m || len(vl) < m || len(vlw) < m || len(dsigma) < n {
+		panic(badSlice)
+	}
+	if len(idx) < n || len(idxp) < n || len(idxq) < n {
+		panic(badIndex)
+	}
+	if icompq == 1 {
+		if len(perm) < n {
+			panic(badIndex)
+		}
+		if ldgcol < n || len(givcol) < ldgcol+n {
+			panic(badIndex)
+		}
+		checkMatrix(2, n, givnum, ldgnum)
+	}
+
+	// Generate the first part of the vector z and move the singular values
+	// in the first part of d one position backward.
+	z1 := alpha * vl[nl]
+	vl[nl] = 0
+	tau := vf[nl]
+	for i := nl - 1; i >= 0; i-- {
+		z[i+1] = alpha * vl[i]
+		vl[i] = 0
+		vf[i+1] = vf[i]
+		d[i+1] = d[i]
+		idxq[i+1] = idxq[i] + 1
+	}
+	vf[0] = tau
+
+	// Generate the second part of the vector z.
+	for i := nl + 1; i < m; i++ {
+		z[i] = beta * vf[i]
+		vf[i] = 0
+	}
+
+	// Sort the singular values into increasing order.
+	for i := nl + 1; i < n; i++ {
+		idxq[i] += nl + 1
+	}
+
+	// dsigma, zw, vfw and vlw are used as storage space.
+	for i := 1; i < n; i++ {
+		dsigma[i] = d[idxq[i]]
+		zw[i] = z[idxq[i]]
+		vfw[i] = vf[idxq[i]]
+		vlw[i] = vl[idxq[i]]
+	}
+	impl.Dlamrg(nl, nr, dsigma[1:], 1, 1, idx[1:])
+	for i := 1; i < n; i++ {
+		idxi := 1 + idx[i]
+		d[i] = dsigma[idxi]
+		z[i] = zw[idxi]
+		vf[i] = vfw[idxi]
+		vl[i] = vlw[idxi]
+	}
+
+	// Calculate the allowable deflation tolerance.
+	eps := dlamchE
+	tol := math.Max(math.Abs(alpha), math.Abs(beta))
+	tol = 64 * eps * math.Max(math.Abs(d[n-1]), tol)
+
+	// If the value in the z vector is small, the corresponding singular
+	// value is moved to the end. If two values in d are close, a Givens
+	// rotation is applied to make one of the corresponding z entries zero
+	// and the deflated singular value is then moved to the end.
+	k = 1
+	k2 := n
+	jprev := -1
+	for j := 1; j < n; j++ {
+		if math.Abs(z[j]) > tol {
+			jprev = j
+			break
+		}
+		// Deflate due to small z component.
+		k2--
+		idxp[k2] = j
+	}
+	if jprev != -1 {
+		for j := jprev + 1; j < n; j++ {
+			if math.Abs(z[j]) <= tol {
+				// Deflate due to small z component.
+				k2--
+				idxp[k2] = j
+				continue
+			}
+
+			// Check if singular values are close enough to allow
+			// deflation.
+			if math.Abs(d[j]-d[jprev]) <= tol {
+				// Deflation is possible.
+				sr := z[jprev]
+				cr := z[j]
+				tau := impl.Dlapy2(cr, sr)
+				z[j] = tau
+				z[jprev] = 0
+				cr /= tau
+				sr = -sr / tau
+
+				// Record the appropriate Givens rotation.
+				if icompq == 1 {
+					idxjp := idxq[idx[jprev]+1]
+					idxj := idxq[idx[j]+1]
+					if idxjp <= nl {
+						idxjp--
+					}
+					if idxj <= nl {
+						idxj--
+					}
+					givcol[givptr] = idxj
+					givcol[ldgcol+givptr] = idxjp
+					givnum[givptr] = sr
+					givnum[ldgnum+givptr] = cr
+					givptr++
+				}
+				vf[jprev], vf[j] = cr*vf[jprev]+sr*vf[j], cr*vf[j]-sr*vf[jprev]
+				vl[jprev], vl[j] = cr*vl[jprev]+sr*vl[j], cr*vl[j]-sr*vl[jprev]
+				k2--
+				idxp[k2] = jprev
+			} else {
+				k++
+				zw[k-1] = z[jprev]
+				dsigma[k-1] = d[jprev]
+				idxp[k-1] = jprev
+			}
+			jprev = j
+		}
+
+		// Record the last singular value.
+		k++
+		zw[k-1] = z[jprev]
+		dsigma[k-1] = d[jprev]
+		idxp[k-1] = jprev
+	}
+
+	// Sort the singular values into dsigma. The singular values which were
+	// not deflated go into the first k slots of dsigma, except that
+	// dsigma[0] is treated separately.
+	for j := 1; j < n; j++ {
+		jp := idxp[j]
+		dsigma[j] = d[jp]
+		vfw[j] = vf[jp]
+		vlw[j] = vl[jp]
+	}
+	if icompq == 1 {
+		for j := 1; j < n; j++ {
+			jp := idxp[j]
+			perm[j] = idxq[idx[jp]+1]
+			if perm[j] <= nl {
+				perm[j]--
+			}
+		}
+	}
+
+	// The deflated singular values go back into the last n-k slots of d.
+	copy(d[k:n], dsigma[k:n])
+
+	// Determine dsigma[0], dsigma[1], z[0], vf[0], vl[0], vf[m-1] and
+	// vl[m-1].
+	dsigma[0] = 0
+	hlftol := tol / 2
+	if math.Abs(dsigma[1]) <= hlftol {
+		dsigma[1] = hlftol
+	}
+	if m > n {
+		z[0] = impl.Dlapy2(z1, z[m-1])
+		if z[0] <= tol {
+			c = 1
+			s = 0
+			z[0] = tol
+		} else {
+			c = z1 / z[0]
+			s = -z[m-1] / z[0]
+		}
+		vf[m-1], vf[0] = c*vf[m-1]+s*vf[0], c*vf[0]-s*vf[m-1]
+		vl[m-1], vl[0] = c*vl[m-1]+s*vl[0], c*vl[0]-s*vl[m-1]
+	} else {
+		if math.Abs(z1) <= tol {
+			z[0] = tol
+		} else {
+			z[0] = z1
+		}
+	}
+
+	// Restore z, vf and vl.
+	copy(z[1:k], zw[1:k])
+	copy(vf[1:n], vfw[1:n])
+	copy(vl[1:n], vlw[1:n])
+	return k, givptr, c, s
+}
@@ -0,0 +1,155 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlasd8 finds the square roots of the roots of the secular equation, as
+// defined by the values in dsigma and z, and computes the factors needed to
+// construct the updated singular vectors in factored form. It is called by
+// Dlasd6 after Dlasd7 has deflated the problem.
+//
+// icompq specifies whether the factored form of the singular vectors is
+// stored. If icompq == 0, only the singular values and the first and last
+// components of the right singular vectors are updated. If icompq == 1, the
+// normalizing factors of the right singular vectors are also returned in
+// difr.
+//
+// k is the number of terms in the secular equation, k >= 1. On return, d[:k]
+// contains the updated singular values in ascending order. dsigma contains
+// the old roots of the secular equation in its first k elements, and z
+// contains the components of the deflation-adjusted updating row vector in
+// its first k elements. On return, z contains the updating vector that
+// corresponds to the computed singular values.
+//
+// On entry, vf and vl contain the first and last components of the right
+// singular vectors of the deflated problem. On return, they contain the
+// first and last components of the updated right singular vectors.
+//
+// On return, difl[i] contains d[i] - dsigma[i] for i < k. difr[i] contains
+// d[i] - dsigma[i+1] for i < k-1, and if icompq == 1, difr[lddifr+i] contains
+// the normalizing factor of the i-th right singular vector, so that difr is a
+// 2×k matrix with stride lddifr. If k == 1 and icompq == 1, difl must have
+// length at least 2.
+//
+// work must have length at least 3*k.
+//
+// Dlasd8 returns whether the secular equation solver converged.
+//
+// Dlasd8 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasd8(icompq, k int, d, z, vf, vl, difl, difr []float64, lddifr int, dsigma, work []float64) (ok bool) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if k < 1 {
+		panic("lapack: k < 1")
+	}
+	if len(d) < k {
+		panic(badD)
+	}
+	if len(z) < k {
+		panic(badZ)
+	}
+	if len(vf) < k || len(vl) < k || len(dsigma) < k {
+		panic(badSlice)
+	}
+	if icompq == 1 {
+		if len(difl) < max(2, k) {
+			panic(badSlice)
+		}
+		checkMatrix(2, k, difr, lddifr)
+	} else if len(difl) < k || len(difr) < k {
+		panic(badSlice)
+	}
+	if len(work) < 3*k {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if k == 1 {
+		d[0] = math.Abs(z[0])
+		difl[0] = d[0]
+		if icompq == 1 {
+			difl[1] = 1
+			difr[lddifr] = 1
+		}
+		return true
+	}
+
+	bi := blas64.Implementation()
+
+	// Book keeping.
+	const iwk1 = 0
+	iwk2 := iwk1 + k
+	iwk3 := iwk2 + k
+
+	// Normalize z.
+	rho := bi.Dnrm2(k, z, 1)
+	impl.Dlascl(lapack.General, 0, 0, rho, 1, k, 1, z, 1)
+	rho *= rho
+
+	// Initialize work[iwk3:].
+	for i := iwk3; i < iwk3+k; i++ {
+		work[i] = 1
+	}
+
+	// Compute the updated singular values, the arrays difl and difr, and
+	// the updated z. The differences between the old and new singular
+	// values returned by Dlasd4 are stored in work[iwk1:iwk2] and their
+	// sums in work[iwk2:iwk3].
+	for j := 0; j < k; j++ {
+		d[j], ok = impl.Dlasd4(k, j, dsigma, z, work[iwk1:], rho, work[iwk2:])
+		if !ok {
+			return false
+		}
+		work[iwk3+j] *= work[j] * work[iwk2+j]
+		difl[j] = -work[j]
+		difr[j] = -work[j+1]
+		for i := 0; i < j; i++ {
+			work[iwk3+i] *= work[i] * work[iwk2+i] / (dsigma[i] - dsigma[j]) / (dsigma[i] + dsigma[j])
+		}
+		for i := j + 1; i < k; i++ {
+			work[iwk3+i] *= work[i] * work[iwk2+i] / (dsigma[i] - dsigma[j]) / (dsigma[i] + dsigma[j])
+		}
+	}
+
+	// Compute updated z.
+	for i := 0; i < k; i++ {
+		z[i] = math.Copysign(math.Sqrt(math.Abs(work[iwk3+i])), z[i])
+	}
+
+	// Update vf and vl.
+	for j := 0; j < k; j++ {
+		diflj := difl[j]
+		dj := d[j]
+		dsigj := -dsigma[j]
+		var difrj, dsigjp float64
+		if j < k-1 {
+			difrj = -difr[j]
+			dsigjp = -dsigma[j+1]
+		}
+		work[j] = -z[j] / diflj / (dsigma[j] + dj)
+		for i := 0; i < j; i++ {
+			work[i] = z[i] / (dsigma[i] + dsigj - diflj) / (dsigma[i] + dj)
+		}
+		for i := j + 1; i < k; i++ {
+			work[i] = z[i] / (dsigma[i] + dsigjp + difrj) / (dsigma[i] + dj)
+		}
+		temp := bi.Dnrm2(k, work, 1)
+		work[iwk2+j] = bi.Ddot(k, work, 1, vf, 1) / temp
+		work[iwk3+j] = bi.Ddot(k, work, 1, vl, 1) / temp
+		if icompq == 1 {
+			difr[lddifr+j] = temp
+		}
+	}
+	copy(vf[:k], work[iwk2:iwk2+k])
+	copy(vl[:k], work[iwk3:iwk3+k])
+	return true
+}
@@ -0,0 +1,228 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlasda computes the singular value decomposition of a real n×m upper
+// bidiagonal matrix B with diagonal d and off-diagonal e, where m = n + sqre,
+// using a divide and conquer approach. It is used by Dlalsd and, unlike
+// Dlasd0, keeps the singular vectors in a compact factored form that can be
+// applied by Dlalsa.
+//
+// icompq specifies whether the singular vectors are computed. If icompq == 0,
+// only the singular values are computed. If icompq == 1, the singular vectors
+// are computed in compact form.
+//
+// smlsiz is the maximum size of the subproblems at the bottom of the
+// computation tree. sqre must be 0 or 1.
+//
+// On entry, d contains the diagonal and e contains the off-diagonal elements
+// of B. d must have length at least n and e must have length at least m-1.
+// On return, d contains the singular values of B and e is overwritten.
+//
+// If icompq == 1, the following are returned. nlvl is the number of levels of
+// the computation tree as computed by Dlasdt.
+//  - u is an n×smlsiz matrix with stride ldu and vt is an m×(smlsiz+1)
+//    matrix with stride ldvt. They contain the left and transposed right
+//    singular vector matrices of the subproblems at the bottom level.
+//  - k, givptr, c and s are indexed by the nodes of the computation tree
+//    and contain the order of the secular equation, the number of Givens
+//    rotations, and the cosine and sine of the rotation annihilating the
+//    extra column of each node.
+//  - difl and z are nlvl×n matrices and poles, difr and givnum are
+//    (2*nlvl)×n matrices with stride ldgnum. Row lvl of difl and z and rows
+//    2*lvl and 2*lvl+1 of poles, difr and givnum contain the values computed
+//    by Dlasd6 for the nodes at level lvl of the tree, starting at the first
+//    row of each node.
+//  - perm is an nlvl×n matrix and givcol is a (2*nlvl)×n matrix with stride
+//    ldgcol, organized in the same way.
+// ldgnum must be at least m and ldgcol must be at least n.
+//
+// If icompq == 0, u, vt, givcol, perm, givnum and poles are not referenced,
+// difl and difr must have length at least n, z must have length at least m
+// and k, givptr, c and s must have length at least 1.
+//
+// work must have length at least 6*m+(smlsiz+1)*(smlsiz+1) and iwork must have
+// length at least 7*n.
+//
+// Dlasda returns whether all the singular values were computed successfully.
+//
+// Dlasda is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasda(icompq, smlsiz, n, sqre int, d, e, u []float64, ldu int, vt []float64, ldvt int, k []int, difl, difr, z, poles []float64, givptr, givcol []int, ldgcol int, perm []int, givnum []float64, ldgnum int, c, s, work []float64, iwork []int) (ok bool) {
+	if icompq != 0 && icompq != 1 {
+		panic("lapack: icompq must be 0 or 1")
+	}
+	if smlsiz < 3 {
+		panic("lapack: smlsiz < 3")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	m := n + sqre
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < m-1 {
+		panic(badE)
+	}
+	if icompq == 1 && n > 0 {
+		checkMatrix(n, smlsiz, u, ldu)
+		checkMatrix(m, smlsiz+1, vt, ldvt)
+		if ldgnum < m || ldgcol < n {
+			panic(badLdA)
+		}
+	}
+	if len(work) < 6*m+(smlsiz+1)*(smlsiz+1) {
+		panic(badWork)
+	}
+	if len(iwork) < 7*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// If the input matrix is too small, call Dlasdq to find the SVD.
+	if n <= smlsiz {
+		if icompq == 0 {
+			return impl.Dlasdq(blas.Upper, sqre, n, 0, 0, 0, d, e, nil, 1, nil, 1, nil, 1, work)
+		}
+		impl.Dlaset(blas.All, n, n, 0, 1, u, ldu)
+		impl.Dlaset(blas.All, m, m, 0, 1, vt, ldvt)
+		return impl.Dlasdq(blas.Upper, sqre, n, m, n, 0, d, e, vt, ldvt, u, ldu, nil, 1, work)
+	}
+
+	bi := blas64.Implementation()
+
+	// Book-keeping and set up the computation tree.
+	const inode = 0
+	ndiml := inode + n
+	ndimr := ndiml + n
+	idxq := ndimr + n
+	iwk := idxq + n
+
+	smlszp := smlsiz + 1
+	const vf = 0
+	vl := vf + m
+	nwork1 := vl + m
+	nwork2 := nwork1 + smlszp*smlszp
+
+	nlvl, nd := impl.Dlasdt(n, iwork[inode:], iwork[ndiml:], iwork[ndimr:], smlsiz)
+
+	// For the nodes on the bottom level of the tree, solve their
+	// subproblems by Dlasdq.
+	for i := (nd - 1) / 2; i < nd; i++ {
+		// ic is the center row of the node, nl and nr are the numbers of
+		// rows of the left and right subproblems and nlf and nrf are their
+		// starting rows.
+		ic := iwork[inode+i]
+		nl := iwork[ndiml+i]
+		nlp1 := nl + 1
+		nr := iwork[ndimr+i]
+		nlf := ic - nl
+		nrf := ic + 1
+		if icompq == 0 {
+			impl.Dlaset(blas.All, nlp1, nlp1, 0, 1, work[nwork1:], smlszp)
+			ok = impl.Dlasdq(blas.Upper, 1, nl, nlp1, 0, 0, d[nlf:], e[nlf:],
+				work[nwork1:], smlszp, nil, 1, nil, 1, work[nwork2:])
+			bi.Dcopy(nlp1, work[nwork1:], smlszp, work[vf+nlf:], 1)
+			bi.Dcopy(nlp1, work[nwork1+nl:], smlszp, work[vl+nlf:], 1)
+		} else {
+			impl.Dlaset(blas.All, nl, nl, 0, 1, u[nlf*ldu:], ldu)
+			impl.Dlaset(blas.All, nlp1, nlp1, 0, 1, vt[nlf*ldvt:], ldvt)
+			ok = impl.Dlasdq(blas.Upper, 1, nl, nlp1, nl, 0, d[nlf:], e[nlf:],
+				vt[nlf*ldvt:], ldvt, u[nlf*ldu:], ldu, nil, 1, work[nwork1:])
+			bi.Dcopy(nlp1, vt[nlf*ldvt:], ldvt, work[vf+nlf:], 1)
+			bi.Dcopy(nlp1, vt[nlf*ldvt+nl:], ldvt, work[vl+nlf:], 1)
+		}
+		if !ok {
+			return false
+		}
+		for j := 0; j < nl; j++ {
+			iwork[idxq+nlf+j] = j
+		}
+
+		sqrei := 1
+		if i == nd-1 {
+			sqrei = sqre
+		}
+		nrp1 := nr + sqrei
+		if icompq == 0 {
+			impl.Dlaset(blas.All, nrp1, nrp1, 0, 1, work[nwork1:], smlszp)
+			ok = impl.Dlasdq(blas.Upper, sqrei, nr, nrp1, 0, 0, d[nrf:], e[nrf:],
+				work[nwork1:], smlszp, nil, 1, nil, 1, work[nwork2:])
+			bi.Dcopy(nrp1, work[nwork1:], smlszp, work[vf+nrf:], 1)
+			bi.Dcopy(nrp1, work[nwork1+nrp1-1:], smlszp, work[vl+nrf:], 1)
+		} else {
+			impl.Dlaset(blas.All, nr, nr, 0, 1, u[nrf*ldu:], ldu)
+			impl.Dlaset(blas.All, nrp1, nrp1, 0, 1, vt[nrf*ldvt:], ldvt)
+			ok = impl.Dlasdq(blas.Upper, sqrei, nr, nrp1, nr, 0, d[nrf:], e[nrf:],
+				vt[nrf*ldvt:], ldvt, u[nrf*ldu:], ldu, nil, 1, work[nwork1:])
+			bi.Dcopy(nrp1, vt[nrf*ldvt:], ldvt, work[vf+nrf:], 1)
+			bi.Dcopy(nrp1, vt[nrf*ldvt+nrp1-1:], ldvt, work[vl+nrf:], 1)
+		}
+		if !ok {
+			return false
+		}
+		for j := 0; j < nr; j++ {
+			iwork[idxq+nrf+j] = j
+		}
+	}
+
+	// Now conquer each subproblem bottom-up. The nodes are numbered in the
+	// reverse order of their processing.
+	j := 1<<uint(nlvl) - 1
+	for lvl := nlvl; lvl >= 1; lvl-- {
+		// Find the first node lf and last node ll on the current level
+		// lvl.
+		lf, ll := 0, 0
+		if lvl > 1 {
+			lf = 1<<uint(lvl-1) - 1
+			ll = 2 * lf
+		}
+		// Rows of the level arrays that belong to the current level.
+		r1 := lvl - 1
+		r2 := 2 * (lvl - 1)
+		for i := lf; i <= ll; i++ {
+			ic := iwork[inode+i]
+			nl := iwork[ndiml+i]
+			nr := iwork[ndimr+i]
+			nlf := ic - nl
+			sqrei := 1
+			if i == ll {
+				sqrei = sqre
+			}
+			alpha := d[ic]
+			beta := e[ic]
+			if icompq == 0 {
+				givptr[0], k[0], c[0], s[0], ok = impl.Dlasd6(0, nl, nr, sqrei, d[nlf:],
+					work[vf+nlf:], work[vl+nlf:], alpha, beta, iwork[idxq+nlf:],
+					nil, nil, ldgcol, nil, ldgnum, nil, difl, difr, z,
+					work[nwork1:], iwork[iwk:])
+			} else {
+				j--
+				givptr[j], k[j], c[j], s[j], ok = impl.Dlasd6(1, nl, nr, sqrei, d[nlf:],
+					work[vf+nlf:], work[vl+nlf:], alpha, beta, iwork[idxq+nlf:],
+					perm[r1*ldgcol+nlf:], givcol[r2*ldgcol+nlf:], ldgcol,
+					givnum[r2*ldgnum+nlf:], ldgnum, poles[r2*ldgnum+nlf:],
+					difl[r1*ldgnum+nlf:], difr[r2*ldgnum+nlf:], z[r1*ldgnum+nlf:],
+					work[nwork1:], iwork[iwk:])
+			}
+			if !ok {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,187 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlasdq computes the singular value decomposition of a real bidiagonal matrix
+// B with diagonal d and off-diagonal e. B may be upper or lower bidiagonal as
+// specified by uplo, and it is n×n if sqre == 0 and n×(n+1) (if uplo is
+// blas.Upper) or (n+1)×n (if uplo is blas.Lower) if sqre == 1. The SVD of B
+// is
+//  B = Q * S * P^T
+// where S is a diagonal matrix of singular values, and Q and P are orthogonal
+// matrices of left and right singular vectors. Dlasdq is used by Dbdsdc to
+// solve the subproblems at the bottom of the divide and conquer tree.
+//
+// d must have length at least n and e must have length at least n-1+sqre. On
+// return, d contains the singular values in ascending order.
+//
+// If ncvt > 0, VT is an (n+sqre)×ncvt matrix which is overwritten by P^T * VT.
+// If nru > 0, U is an nru×(n+sqre) matrix which is overwritten by U * Q. If
+// ncc > 0, C is an (n+sqre)×ncc matrix which is overwritten by Q^T * C. The
+// extra row of VT is only referenced if uplo == blas.Upper, and the extra
+// column of U and row of C only if uplo == blas.Lower.
+//
+// work must have length at least 4*n.
+//
+// Dlasdq returns whether the iterative computation of the singular values
+// converged.
+//
+// Dlasdq is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasdq(uplo blas.Uplo, sqre, n, ncvt, nru, ncc int, d, e, vt []float64, ldvt int, u []float64, ldu int, c []float64, ldc int, work []float64) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if sqre != 0 && sqre != 1 {
+		panic("lapack: sqre must be 0 or 1")
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	if ncvt < 0 || nru < 0 || ncc < 0 {
+		panic(negDimension)
+	}
+	np1 := n + 1
+	// The extra row or column of a non-square B is only transformed on
+	// the side that it belongs to.
+	nvt, nu := n+sqre, n
+	if uplo == blas.Lower {
+		nvt, nu = n, n+sqre
+	}
+	if ncvt > 0 {
+		checkMatrix(nvt, ncvt, vt, ldvt)
+	}
+	if nru > 0 {
+		checkMatrix(nru, nu, u, ldu)
+	}
+	if ncc > 0 {
+		checkMatrix(nu, ncc, c, ldc)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1+sqre {
+		panic(badE)
+	}
+	if len(work) < 4*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// rotate is true if any singular vectors are desired.
+	rotate := ncvt > 0 || nru > 0 || ncc > 0
+	sqre1 := sqre
+
+	// If the matrix is non-square upper bidiagonal, rotate it to be lower
+	// bidiagonal. The rotations are on the right but do not affect the
+	// singular values.
+	if uplo == blas.Upper && sqre1 == 1 {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if rotate {
+				work[i] = cs
+				work[n+i] = sn
+			}
+		}
+		cs, sn, r := impl.Dlartg(d[n-1], e[n-1])
+		d[n-1] = r
+		e[n-1] = 0
+		if rotate {
+			work[n-1] = cs
+			work[2*n-1] = sn
+		}
+		uplo = blas.Lower
+		sqre1 = 0
+
+		// Update singular vectors if desired.
+		if ncvt > 0 {
+			impl.Dlasr(blas.Left, lapack.Variable, lapack.Forward, np1, ncvt, work, work[n:], vt, ldvt)
+		}
+	}
+
+	// If the matrix is lower bidiagonal, rotate it to be upper bidiagonal by
+	// applying Givens rotations on the left.
+	if uplo == blas.Lower {
+		for i := 0; i < n-1; i++ {
+			cs, sn, r := impl.Dlartg(d[i], e[i])
+			d[i] = r
+			e[i] = sn * d[i+1]
+			d[i+1] *= cs
+			if rotate {
+				work[i] = cs
+				work[n+i] = sn
+			}
+		}
+
+		// If the matrix is (n+1)×n lower bidiagonal, one additional
+		// rotation is needed.
+		if sqre1 == 1 {
+			cs, sn, r := impl.Dlartg(d[n-1], e[n-1])
+			d[n-1] = r
+			if rotate {
+				work[n-1] = cs
+				work[2*n-1] = sn
+			}
+		}
+
+		// Update singular vectors if desired.
+		nrot := n
+		if sqre1 == 1 {
+			nrot = np1
+		}
+		if nru > 0 {
+			impl.Dlasr(blas.Right, lapack.Variable, lapack.Forward, nru, nrot, work, work[n:], u, ldu)
+		}
+		if ncc > 0 {
+			impl.Dlasr(blas.Left, lapack.Variable, lapack.Forward, nrot, ncc, work, work[n:], c, ldc)
+		}
+	}
+
+	// Call Dbdsqr to compute the SVD of the reduced real n×n upper
+	// bidiagonal matrix.
+	ok = impl.Dbdsqr(blas.Upper, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work)
+
+	// Sort the singular values into ascending order (insertion sort on
+	// singular values, but only one transposition per singular vector).
+	bi := blas64.Implementation()
+	for i := 0; i < n; i++ {
+		// Scan for smallest d[i].
+		isub := i
+		smin := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < smin {
+				isub = j
+				smin = d[j]
+			}
+		}
+		if isub != i {
+			// Swap singular values and vectors.
+			d[isub] = d[i]
+			d[i] = smin
+			if ncvt > 0 {
+				bi.Dswap(ncvt, vt[isub*ldvt:], 1, vt[i*ldvt:], 1)
+			}
+			if nru > 0 {
+				bi.Dswap(nru, u[isub:], ldu, u[i:], ldu)
+			}
+			if ncc > 0 {
+				bi.Dswap(ncc, c[isub*ldc:], 1, c[i*ldc:], 1)
+			}
+		}
+	}
+	return ok
+}
@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dlasdt creates a tree of subproblems for bidiagonal divide and conquer.
+//
+// n is the number of diagonal elements of the bidiagonal matrix and msub is
+// the maximum row dimension of each subproblem at the bottom of the tree.
+//
+// On return, inode[i] contains the center row of the i-th node, and ndiml[i]
+// and ndimr[i] contain the number of rows of its left and right subproblems.
+// The nodes are numbered level by level, so that the children of node i are
+// nodes 2*i+1 and 2*i+2. inode, ndiml and ndimr must have length at least n.
+//
+// Dlasdt returns the number of levels and the number of nodes in the tree.
+//
+// Dlasdt is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlasdt(n int, inode, ndiml, ndimr []int, msub int) (lvl, nd int) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if msub < 1 {
+		panic("lapack: msub < 1")
+	}
+	if len(inode) < n || len(ndiml) < n || len(ndimr) < n {
+		panic(badIndex)
+	}
+
+	// Find the number of levels on the tree.
+	maxn := max(1, n)
+	temp := math.Log(float64(maxn)/float64(msub+1)) / math.Ln2
+	lvl = int(temp) + 1
+
+	i := n / 2
+	inode[0] = i
+	ndiml[0] = i
+	ndimr[0] = n - i - 1
+	il := -1
+	ir := 0
+	llst := 1
+	for nlvl := 1; nlvl < lvl; nlvl++ {
+		// Construct the tree at level nlvl+1. The number of nodes created
+		// on this level is llst*2.
+		for i := 0; i < llst; i++ {
+			il += 2
+			ir += 2
+			ncrnt := llst + i - 1
+			ndiml[il] = ndiml[ncrnt] / 2
+			ndimr[il] = ndiml[ncrnt] - ndiml[il] - 1
+			inode[il] = inode[ncrnt] - ndimr[il] - 1
+			ndiml[ir] = ndimr[ncrnt] / 2
+			ndimr[ir] = ndimr[ncrnt] - ndiml[ir] - 1
+			inode[ir] = inode[ncrnt] + ndiml[ir] + 1
+		}
+		llst *= 2
+	}
+	nd = 2*llst - 1
+	return lvl, nd
+}
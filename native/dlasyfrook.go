@@ -0,0 +1,480 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// DlasyfRook computes a partial factorization of a real symmetric n×n matrix
+// A using the bounded Bunch-Kaufman ("rook") diagonal pivoting method.
+// DlasyfRook is called by DsytrfRook to factorize a panel of at most nb
+// columns of A.
+//
+// If uplo == blas.Upper, the last kb columns of A are factorized as
+//  A = ( I  U12 ) * ( A11  0  ) * (  I     0  )
+//      ( 0  U22 )   (  0   D  )   ( U12^T U22^T )
+// and if uplo == blas.Lower, the first kb columns of A are factorized as
+//  A = ( L11  0 ) * ( D   0  ) * ( L11^T L21^T )
+//      ( L21  I )   ( 0  A22 )   (   0     I   )
+// where the order of D is at most nb. The actual order, kb, is returned and
+// is either nb or nb-1, or n if n <= nb. On return, the trailing (or leading)
+// submatrix A11 (or A22) has been updated using the blocked Level 3 BLAS code
+// as
+//  A11 := A11 - U12*D*U12^T  or  A22 := A22 - L21*D*L21^T.
+// The storage of the factors in a and the pivot indices in ipiv are described
+// in the documentation for DsytrfRook.
+//
+// ipiv must have length at least n. w is an n×nb workspace matrix with stride
+// ldw and ldw must be at least max(1,nb). DlasyfRook will panic if these
+// conditions are not met.
+//
+// DlasyfRook returns whether the factorized block of D is nonsingular.
+//
+// DlasyfRook is an internal routine. It is exported for testing purposes.
+func (Implementation) DlasyfRook(uplo blas.Uplo, n, nb int, a []float64, lda int, ipiv []int, w []float64, ldw int) (kb int, ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nb < 2:
+		panic(badNb)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nb, w, ldw)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	bi := blas64.Implementation()
+
+	// alpha is used for pivot selection.
+	alpha := (1 + math.Sqrt(17)) / 8
+	sfmin := dlamchS
+
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize the trailing columns of A using the upper triangle of
+		// A and working backwards, and compute the matrix W = U12*D for
+		// use in updating A11. k is the main loop index, decreasing from
+		// n-1 in steps of 1 or 2, and kw is the column of W that
+		// corresponds to column k of A.
+		k := n - 1
+		for ; k >= 0 && (k > n-nb || nb >= n); k-- {
+			kw := nb + k - n
+			kstep := 1
+			p := k
+
+			// Copy column k of A to column kw of W and update it.
+			bi.Dcopy(k+1, a[k:], lda, w[kw:], ldw)
+			if k < n-1 {
+				bi.Dgemv(blas.NoTrans, k+1, n-k-1, -1, a[k+1:], lda, w[k*ldw+kw+1:], 1, 1, w[kw:], ldw)
+			}
+
+			// Determine rows and columns to be interchanged and whether a
+			// 1×1 or 2×2 pivot block will be used.
+			absakk := math.Abs(w[k*ldw+kw])
+			// imax is the row index of the largest off-diagonal element
+			// in column k, and colmax is its absolute value.
+			var imax int
+			var colmax float64
+			if k > 0 {
+				imax = bi.Idamax(k, w[kw:], ldw)
+				colmax = math.Abs(w[imax*ldw+kw])
+			}
+
+			var kp int
+			if math.Max(absakk, colmax) == 0 {
+				// Column k is zero.
+				ok = false
+				kp = k
+				bi.Dcopy(k+1, w[kw:], ldw, a[k:], lda)
+			} else {
+				// The negated comparisons below handle NaN and Inf.
+				if !(absakk < alpha*colmax) {
+					// No interchange, use 1×1 pivot block.
+					kp = k
+				} else {
+					for {
+						// Copy column imax to column kw-1 of W and update
+						// it.
+						bi.Dcopy(imax+1, a[imax:], lda, w[kw-1:], ldw)
+						bi.Dcopy(k-imax, a[imax*lda+imax+1:], 1, w[(imax+1)*ldw+kw-1:], ldw)
+						if k < n-1 {
+							bi.Dgemv(blas.NoTrans, k+1, n-k-1, -1, a[k+1:], lda, w[imax*ldw+kw+1:], 1, 1, w[kw-1:], ldw)
+						}
+
+						// jmax is the column index of the largest
+						// off-diagonal element in row imax, and rowmax is
+						// its absolute value.
+						var jmax int
+						var rowmax float64
+						if imax != k {
+							jmax = imax + 1 + bi.Idamax(k-imax, w[(imax+1)*ldw+kw-1:], ldw)
+							rowmax = math.Abs(w[jmax*ldw+kw-1])
+						}
+						if imax > 0 {
+							itemp := bi.Idamax(imax, w[kw-1:], ldw)
+							dtemp := math.Abs(w[itemp*ldw+kw-1])
+							if dtemp > rowmax {
+								rowmax = dtemp
+								jmax = itemp
+							}
+						}
+
+						if !(math.Abs(w[imax*ldw+kw-1]) < alpha*rowmax) {
+							// Interchange rows and columns k and imax, use
+							// 1×1 pivot block.
+							kp = imax
+							// Copy column kw-1 of W to column kw.
+							bi.Dcopy(k+1, w[kw-1:], ldw, w[kw:], ldw)
+							break
+						}
+						if p == jmax || rowmax <= colmax {
+							// Interchange rows and columns k-1 and imax,
+							// use 2×2 pivot block.
+							kp = imax
+							kstep = 2
+							break
+						}
+						// Pivot not found, set variables and repeat.
+						p = imax
+						colmax = rowmax
+						imax = jmax
+						// Copy updated column jmax (the next imax) to
+						// column kw of W.
+						bi.Dcopy(k+1, w[kw-1:], ldw, w[kw:], ldw)
+					}
+				}
+
+				// kk is the column of A where pivoting step stopped, and
+				// kkw is the corresponding column of W.
+				kk := k - kstep + 1
+				kkw := nb + kk - n
+
+				if kstep == 2 && p != k {
+					// Copy non-updated column k to column p.
+					bi.Dcopy(k-p, a[(p+1)*lda+k:], lda, a[p*lda+p+1:], 1)
+					bi.Dcopy(p+1, a[k:], lda, a[p:], lda)
+					// Interchange rows k and p in the last columns of A
+					// and W.
+					bi.Dswap(n-k, a[k*lda+k:], 1, a[p*lda+k:], 1)
+					bi.Dswap(n-kk, w[k*ldw+kkw:], 1, w[p*ldw+kkw:], 1)
+				}
+
+				// Interchange rows and columns kp and kk. The updated
+				// column kp is already stored in column kkw of W.
+				if kp != kk {
+					// Copy non-updated column kk to column kp.
+					a[kp*lda+k] = a[kk*lda+k]
+					bi.Dcopy(k-1-kp, a[(kp+1)*lda+kk:], lda, a[kp*lda+kp+1:], 1)
+					bi.Dcopy(kp+1, a[kk:], lda, a[kp:], lda)
+					// Interchange rows kk and kp in the last columns of A
+					// and W.
+					bi.Dswap(n-kk, a[kk*lda+kk:], 1, a[kp*lda+kk:], 1)
+					bi.Dswap(n-kk, w[kk*ldw+kkw:], 1, w[kp*ldw+kkw:], 1)
+				}
+
+				if kstep == 1 {
+					// 1×1 pivot block D(k): column kw of W now holds
+					//  W(k) = U(k)*D(k),
+					// where U(k) is the k-th column of U. Store U(k) in
+					// column k of A.
+					bi.Dcopy(k+1, w[kw:], ldw, a[k:], lda)
+					if k > 0 {
+						akk := a[k*lda+k]
+						if math.Abs(akk) >= sfmin {
+							bi.Dscal(k, 1/akk, a[k:], lda)
+						} else if akk != 0 {
+							for i := 0; i < k; i++ {
+								a[i*lda+k] /= akk
+							}
+						}
+					}
+				} else {
+					// 2×2 pivot block D(k): columns kw and kw-1 of W now
+					// hold
+					//  ( W(k-1) W(k) ) = ( U(k-1) U(k) )*D(k),
+					// where U(k) and U(k-1) are the k-th and (k-1)-th
+					// columns of U.
+					if k > 1 {
+						// Store U(k) and U(k-1) in columns k and k-1 of A.
+						d12 := w[(k-1)*ldw+kw]
+						d11 := w[k*ldw+kw] / d12
+						d22 := w[(k-1)*ldw+kw-1] / d12
+						t := 1 / (d11*d22 - 1)
+						for j := 0; j < k-1; j++ {
+							a[j*lda+k-1] = t * ((d11*w[j*ldw+kw-1] - w[j*ldw+kw]) / d12)
+							a[j*lda+k] = t * ((d22*w[j*ldw+kw] - w[j*ldw+kw-1]) / d12)
+						}
+					}
+					// Copy D(k) to A.
+					a[(k-1)*lda+k-1] = w[(k-1)*ldw+kw-1]
+					a[(k-1)*lda+k] = w[(k-1)*ldw+kw]
+					a[k*lda+k] = w[k*ldw+kw]
+				}
+			}
+
+			// Store details of the interchanges in ipiv.
+			if kstep == 1 {
+				ipiv[k] = kp
+			} else {
+				ipiv[k] = -p - 1
+				ipiv[k-1] = -kp - 1
+				k--
+			}
+		}
+		kw := nb + k - n
+
+		// Update the upper triangle of A11 = A[0:k+1,0:k+1] as
+		//  A11 := A11 - U12*D*U12^T = A11 - U12*W^T
+		// computing blocks of nb columns at a time.
+		for j := (k / nb) * nb; k >= 0 && j >= 0; j -= nb {
+			jb := min(nb, k-j+1)
+			// Update the upper triangle of the diagonal block.
+			for jj := j; jj < j+jb; jj++ {
+				bi.Dgemv(blas.NoTrans, jj-j+1, n-k-1, -1, a[j*lda+k+1:], lda, w[jj*ldw+kw+1:], 1, 1, a[j*lda+jj:], lda)
+			}
+			// Update the rectangular superdiagonal block.
+			if j > 0 {
+				bi.Dgemm(blas.NoTrans, blas.Trans, j, jb, n-k-1, -1, a[k+1:], lda, w[j*ldw+kw+1:], ldw, 1, a[j:], lda)
+			}
+		}
+
+		// Put U12 in standard form by partially undoing the interchanges
+		// in columns k+1:n.
+		for j := k + 1; j < n; {
+			kstep := 1
+			jp1 := -1
+			jj := j
+			jp2 := ipiv[j]
+			if jp2 < 0 {
+				jp2 = -jp2 - 1
+				j++
+				jp1 = -ipiv[j] - 1
+				kstep = 2
+			}
+			j++
+			if jp2 != jj && j < n {
+				bi.Dswap(n-j, a[jp2*lda+j:], 1, a[jj*lda+j:], 1)
+			}
+			jj = j - 1
+			if jp1 != jj && kstep == 2 {
+				bi.Dswap(n-j, a[jp1*lda+j:], 1, a[jj*lda+j:], 1)
+			}
+		}
+		// Return the number of columns factorized.
+		return n - k - 1, ok
+	}
+
+	// Factorize the leading columns of A using the lower triangle of A and
+	// working forwards, and compute the matrix W = L21*D for use in
+	// updating A22. k is the main loop index, increasing from 0 in steps of
+	// 1 or 2.
+	k := 0
+	for ; k < n && (k < nb-1 || nb >= n); k++ {
+		kstep := 1
+		p := k
+
+		// Copy column k of A to column k of W and update it.
+		bi.Dcopy(n-k, a[k*lda+k:], lda, w[k*ldw+k:], ldw)
+		if k > 0 {
+			bi.Dgemv(blas.NoTrans, n-k, k, -1, a[k*lda:], lda, w[k*ldw:], 1, 1, w[k*ldw+k:], ldw)
+		}
+
+		// Determine rows and columns to be interchanged and whether a 1×1
+		// or 2×2 pivot block will be used.
+		absakk := math.Abs(w[k*ldw+k])
+		// imax is the row index of the largest off-diagonal element in
+		// column k, and colmax is its absolute value.
+		var imax int
+		var colmax float64
+		if k < n-1 {
+			imax = k + 1 + bi.Idamax(n-k-1, w[(k+1)*ldw+k:], ldw)
+			colmax = math.Abs(w[imax*ldw+k])
+		}
+
+		var kp int
+		if math.Max(absakk, colmax) == 0 {
+			// Column k is zero.
+			ok = false
+			kp = k
+			bi.Dcopy(n-k, w[k*ldw+k:], ldw, a[k*lda+k:], lda)
+		} else {
+			// The negated comparisons below handle NaN and Inf.
+			if !(absakk < alpha*colmax) {
+				// No interchange, use 1×1 pivot block.
+				kp = k
+			} else {
+				for {
+					// Copy column imax to column k+1 of W and update it.
+					bi.Dcopy(imax-k, a[imax*lda+k:], 1, w[k*ldw+k+1:], ldw)
+					bi.Dcopy(n-imax, a[imax*lda+imax:], lda, w[imax*ldw+k+1:], ldw)
+					if k > 0 {
+						bi.Dgemv(blas.NoTrans, n-k, k, -1, a[k*lda:], lda, w[imax*ldw:], 1, 1, w[k*ldw+k+1:], ldw)
+					}
+
+					// jmax is the column index of the largest off-diagonal
+					// element in row imax, and rowmax is its absolute
+					// value.
+					var jmax int
+					var rowmax float64
+					if imax != k {
+						jmax = k + bi.Idamax(imax-k, w[k*ldw+k+1:], ldw)
+						rowmax = math.Abs(w[jmax*ldw+k+1])
+					}
+					if imax < n-1 {
+						itemp := imax + 1 + bi.Idamax(n-imax-1, w[(imax+1)*ldw+k+1:], ldw)
+						dtemp := math.Abs(w[itemp*ldw+k+1])
+						if dtemp > rowmax {
+							rowmax = dtemp
+							jmax = itemp
+						}
+					}
+
+					if !(math.Abs(w[imax*ldw+k+1]) < alpha*rowmax) {
+						// Interchange rows and columns k and imax, use 1×1
+						// pivot block.
+						kp = imax
+						// Copy column k+1 of W to column k.
+						bi.Dcopy(n-k, w[k*ldw+k+1:], ldw, w[k*ldw+k:], ldw)
+						break
+					}
+					if p == jmax || rowmax <= colmax {
+						// Interchange rows and columns k+1 and imax, use
+						// 2×2 pivot block.
+						kp = imax
+						kstep = 2
+						break
+					}
+					// Pivot not found, set variables and repeat.
+					p = imax
+					colmax = rowmax
+					imax = jmax
+					// Copy updated column jmax (the next imax) to column k
+					// of W.
+					bi.Dcopy(n-k, w[k*ldw+k+1:], ldw, w[k*ldw+k:], ldw)
+				}
+			}
+
+			// kk is the column of A where pivoting step stopped.
+			kk := k + kstep - 1
+
+			if kstep == 2 && p != k {
+				// Copy non-updated column k to column p.
+				bi.Dcopy(p-k, a[k*lda+k:], lda, a[p*lda+k:], 1)
+				bi.Dcopy(n-p, a[p*lda+k:], lda, a[p*lda+p:], lda)
+				// Interchange rows k and p in the first columns of A and
+				// W.
+				bi.Dswap(k+1, a[k*lda:], 1, a[p*lda:], 1)
+				bi.Dswap(kk+1, w[k*ldw:], 1, w[p*ldw:], 1)
+			}
+
+			// Interchange rows and columns kp and kk. The updated column
+			// kp is already stored in column kk of W.
+			if kp != kk {
+				// Copy non-updated column kk to column kp.
+				a[kp*lda+k] = a[kk*lda+k]
+				bi.Dcopy(kp-k-1, a[(k+1)*lda+kk:], lda, a[kp*lda+k+1:], 1)
+				bi.Dcopy(n-kp, a[kp*lda+kk:], lda, a[kp*lda+kp:], lda)
+				// Interchange rows kk and kp in the first columns of A
+				// and W.
+				bi.Dswap(kk+1, a[kk*lda:], 1, a[kp*lda:], 1)
+				bi.Dswap(kk+1, w[kk*ldw:], 1, w[kp*ldw:], 1)
+			}
+
+			if kstep == 1 {
+				// 1×1 pivot block D(k): column k of W now holds
+				//  W(k) = L(k)*D(k),
+				// where L(k) is the k-th column of L. Store L(k) in column
+				// k of A.
+				bi.Dcopy(n-k, w[k*ldw+k:], ldw, a[k*lda+k:], lda)
+				if k < n-1 {
+					akk := a[k*lda+k]
+					if math.Abs(akk) >= sfmin {
+						bi.Dscal(n-k-1, 1/akk, a[(k+1)*lda+k:], lda)
+					} else if akk != 0 {
+						for i := k + 1; i < n; i++ {
+							a[i*lda+k] /= akk
+						}
+					}
+				}
+			} else {
+				// 2×2 pivot block D(k): columns k and k+1 of W now hold
+				//  ( W(k) W(k+1) ) = ( L(k) L(k+1) )*D(k),
+				// where L(k) and L(k+1) are the k-th and (k+1)-th columns
+				// of L.
+				if k < n-2 {
+					// Store L(k) and L(k+1) in columns k and k+1 of A.
+					d21 := w[(k+1)*ldw+k]
+					d11 := w[(k+1)*ldw+k+1] / d21
+					d22 := w[k*ldw+k] / d21
+					t := 1 / (d11*d22 - 1)
+					for j := k + 2; j < n; j++ {
+						a[j*lda+k] = t * ((d11*w[j*ldw+k] - w[j*ldw+k+1]) / d21)
+						a[j*lda+k+1] = t * ((d22*w[j*ldw+k+1] - w[j*ldw+k]) / d21)
+					}
+				}
+				// Copy D(k) to A.
+				a[k*lda+k] = w[k*ldw+k]
+				a[(k+1)*lda+k] = w[(k+1)*ldw+k]
+				a[(k+1)*lda+k+1] = w[(k+1)*ldw+k+1]
+			}
+		}
+
+		// Store details of the interchanges in ipiv.
+		if kstep == 1 {
+			ipiv[k] = kp
+		} else {
+			ipiv[k] = -p - 1
+			ipiv[k+1] = -kp - 1
+			k++
+		}
+	}
+
+	// Update the lower triangle of A22 = A[k:n,k:n] as
+	//  A22 := A22 - L21*D*L21^T = A22 - L21*W^T
+	// computing blocks of nb columns at a time.
+	for j := k; j < n; j += nb {
+		jb := min(nb, n-j)
+		// Update the lower triangle of the diagonal block.
+		for jj := j; jj < j+jb; jj++ {
+			bi.Dgemv(blas.NoTrans, j+jb-jj, k, -1, a[jj*lda:], lda, w[jj*ldw:], 1, 1, a[jj*lda+jj:], lda)
+		}
+		// Update the rectangular subdiagonal block.
+		if j+jb < n {
+			bi.Dgemm(blas.NoTrans, blas.Trans, n-j-jb, jb, k, -1, a[(j+jb)*lda:], lda, w[j*ldw:], ldw, 1, a[(j+jb)*lda+j:], lda)
+		}
+	}
+
+	// Put L21 in standard form by partially undoing the interchanges of
+	// rows in columns 0:k.
+	for j := k - 1; j >= 0; {
+		kstep := 1
+		jp1 := -1
+		jj := j
+		jp2 := ipiv[j]
+		if jp2 < 0 {
+			jp2 = -jp2 - 1
+			j--
+			jp1 = -ipiv[j] - 1
+			kstep = 2
+		}
+		j--
+		if jp2 != jj && j >= 0 {
+			bi.Dswap(j+1, a[jp2*lda:], 1, a[jj*lda:], 1)
+		}
+		jj = j + 1
+		if jp1 != jj && kstep == 2 {
+			bi.Dswap(j+1, a[jp1*lda:], 1, a[jj*lda:], 1)
+		}
+	}
+	// Return the number of columns factorized.
+	return k, ok
+}
@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// Dlat2s converts the triangle of the n×n float64 matrix A specified by uplo
+// to the float32 matrix SA. The opposite triangle of SA is not referenced.
+//
+// Dlat2s returns false if an element in the triangle of A is larger in
+// absolute value than the largest finite float32 number, in which case the
+// conversion is aborted and SA may be only partially filled.
+//
+// Dlat2s is an internal routine. It is exported for testing purposes.
+func (Implementation) Dlat2s(uplo blas.Uplo, n int, a []float64, lda int, sa []float32, ldsa int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix32(n, n, sa, ldsa)
+
+	const rmax = math.MaxFloat32
+	for i := 0; i < n; i++ {
+		j0, j1 := i, n
+		if uplo == blas.Lower {
+			j0, j1 = 0, i+1
+		}
+		for j := j0; j < j1; j++ {
+			v := a[i*lda+j]
+			if v < -rmax || rmax < v {
+				return false
+			}
+			sa[i*ldsa+j] = float32(v)
+		}
+	}
+	return true
+}
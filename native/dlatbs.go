@@ -0,0 +1,392 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dlatbs solves a triangular band system of equations scaled to prevent
+// overflow. It solves
+//  A * x = scale * b if trans == blas.NoTrans
+//  A^T * x = scale * b if trans == blas.Trans
+// where the scale s is set for numeric stability.
+//
+// A is an n×n triangular band matrix with kd superdiagonals if
+// uplo == blas.Upper or kd subdiagonals if uplo == blas.Lower, stored in ab in
+// row-major band format as used by blas64.TriangularBand. ldab must be at least
+// kd+1. On entry, the slice x contains the values of b, and on exit it contains
+// the solution vector x.
+//
+// If normin == true, cnorm is an input and cnorm[j] contains the norm of the off-diagonal
+// part of the j^th column of A. If trans == blas.NoTrans, cnorm[j] must be greater
+// than or equal to the infinity norm, and greater than or equal to the one-norm
+// otherwise. If normin == false, then cnorm is treated as an output, and is set
+// to contain the 1-norm of the off-diagonal part of the j^th column of A.
+//
+// Dlatbs is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlatbs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, normin bool, n, kd int, ab []float64, ldab int, x []float64, cnorm []float64) (scale float64) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	if trans != blas.Trans && trans != blas.NoTrans {
+		panic(badTrans)
+	}
+	if diag != blas.Unit && diag != blas.NonUnit {
+		panic(badDiag)
+	}
+	upper := uplo == blas.Upper
+	noTrans := trans == blas.NoTrans
+	nonUnit := diag == blas.NonUnit
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if kd < 0 {
+		panic("lapack: kd < 0")
+	}
+	if ldab < kd+1 {
+		panic(badLdA)
+	}
+	if n > 0 && len(ab) < (n-1)*ldab+kd+1 {
+		panic("lapack: insufficient matrix slice length")
+	}
+	checkVector(n, x, 1)
+	checkVector(n, cnorm, 1)
+
+	if n == 0 {
+		return 1
+	}
+
+	// In the band storage, the diagonal element A[j,j] is stored in
+	// ab[j*ldab+maind], and the elements of a column of A are ldab-1 apart.
+	var maind int
+	if !upper {
+		maind = kd
+	}
+	smlnum := dlamchS / dlamchP
+	bignum := 1 / smlnum
+	scale = 1
+	bi := blas64.Implementation()
+	if !normin {
+		if upper {
+			for j := 0; j < n; j++ {
+				jlen := min(kd, j)
+				cnorm[j] = 0
+				if jlen > 0 {
+					cnorm[j] = bi.Dasum(jlen, ab[(j-jlen)*ldab+jlen:], ldab-1)
+				}
+			}
+		} else {
+			for j := 0; j < n; j++ {
+				jlen := min(kd, n-j-1)
+				cnorm[j] = 0
+				if jlen > 0 {
+					cnorm[j] = bi.Dasum(jlen, ab[(j+1)*ldab+kd-1:], ldab-1)
+				}
+			}
+		}
+	}
+	// Scale the column norms by tscal if the maximum element in cnorm is greater than bignum.
+	imax := bi.Idamax(n, cnorm, 1)
+	tmax := cnorm[imax]
+	var tscal float64
+	if tmax <= bignum {
+		tscal = 1
+	} else {
+		tscal = 1 / (smlnum * tmax)
+		bi.Dscal(n, tscal, cnorm, 1)
+	}
+
+	// Compute a bound on the computed solution vector to see if bi.Dtbsv can be used.
+	j := bi.Idamax(n, x, 1)
+	xmax := math.Abs(x[j])
+	xbnd := xmax
+	var grow float64
+	var jfirst, jlast, jinc int
+	if noTrans {
+		if upper {
+			jfirst = n - 1
+			jlast = -1
+			jinc = -1
+		} else {
+			jfirst = 0
+			jlast = n
+			jinc = 1
+		}
+		// Compute the growth in A * x = b.
+		if tscal != 1 {
+			grow = 0
+			goto Solve
+		}
+		if nonUnit {
+			grow = 1 / math.Max(xbnd, smlnum)
+			xbnd = grow
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				tjj := math.Abs(ab[j*ldab+maind])
+				xbnd = math.Min(xbnd, math.Min(1, tjj)*grow)
+				if tjj+cnorm[j] >= smlnum {
+					grow *= tjj / (tjj + cnorm[j])
+				} else {
+					grow = 0
+				}
+			}
+			grow = xbnd
+		} else {
+			grow = math.Min(1, 1/math.Max(xbnd, smlnum))
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				grow *= 1 / (1 + cnorm[j])
+			}
+		}
+	} else {
+		if upper {
+			jfirst = 0
+			jlast = n
+			jinc = 1
+		} else {
+			jfirst = n - 1
+			jlast = -1
+			jinc = -1
+		}
+		if tscal != 1 {
+			grow = 0
+			goto Solve
+		}
+		if nonUnit {
+			grow = 1 / (math.Max(xbnd, smlnum))
+			xbnd = grow
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				xj := 1 + cnorm[j]
+				grow = math.Min(grow, xbnd/xj)
+				tjj := math.Abs(ab[j*ldab+maind])
+				if xj > tjj {
+					xbnd *= tjj / xj
+				}
+			}
+			grow = math.Min(grow, xbnd)
+		} else {
+			grow = math.Min(1, 1/math.Max(xbnd, smlnum))
+			for j := jfirst; j != jlast; j += jinc {
+				if grow <= smlnum {
+					goto Solve
+				}
+				xj := 1 + cnorm[j]
+				grow /= xj
+			}
+		}
+	}
+
+Solve:
+	if grow*tscal > smlnum {
+		// Use the Level 2 BLAS solve if the reciprocal of the bound on
+		// elements of X is not too small.
+		bi.Dtbsv(uplo, trans, diag, n, kd, ab, ldab, x, 1)
+		if tscal != 1 {
+			bi.Dscal(n, 1/tscal, cnorm, 1)
+		}
+		return scale
+	}
+
+	// Use a Level 1 BLAS solve, scaling intermediate results.
+	if xmax > bignum {
+		scale = bignum / xmax
+		bi.Dscal(n, scale, x, 1)
+		xmax = bignum
+	}
+	if noTrans {
+		for j := jfirst; j != jlast; j += jinc {
+			xj := math.Abs(x[j])
+			var tjj, tjjs float64
+			if nonUnit {
+				tjjs = ab[j*ldab+maind] * tscal
+			} else {
+				tjjs = tscal
+				if tscal == 1 {
+					goto Skip1
+				}
+			}
+			tjj = math.Abs(tjjs)
+			if tjj > smlnum {
+				if tjj < 1 {
+					if xj > tjj*bignum {
+						rec := 1 / xj
+						bi.Dscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+				}
+				x[j] /= tjjs
+				xj = math.Abs(x[j])
+			} else if tjj > 0 {
+				if xj > tjj*bignum {
+					rec := (tjj * bignum) / xj
+					if cnorm[j] > 1 {
+						rec /= cnorm[j]
+					}
+					bi.Dscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+				x[j] /= tjjs
+				xj = math.Abs(x[j])
+			} else {
+				for i := 0; i < n; i++ {
+					x[i] = 0
+				}
+				x[j] = 1
+				xj = 1
+				scale = 0
+				xmax = 0
+			}
+		Skip1:
+			if xj > 1 {
+				rec := 1 / xj
+				if cnorm[j] > (bignum-xmax)*rec {
+					rec *= 0.5
+					bi.Dscal(n, rec, x, 1)
+					scale *= rec
+				}
+			} else if xj*cnorm[j] > bignum-xmax {
+				bi.Dscal(n, 0.5, x, 1)
+				scale *= 0.5
+			}
+			if upper {
+				if j > 0 {
+					// Compute the update
+					//  x[max(0,j-kd):j] -= x[j] * A[max(0,j-kd):j,j].
+					jlen := min(kd, j)
+					if jlen > 0 {
+						bi.Daxpy(jlen, -x[j]*tscal, ab[(j-jlen)*ldab+jlen:], ldab-1, x[j-jlen:], 1)
+					}
+					i := bi.Idamax(j, x, 1)
+					xmax = math.Abs(x[i])
+				}
+			} else {
+				if j < n-1 {
+					// Compute the update
+					//  x[j+1:min(j+kd,n-1)+1] -= x[j] * A[j+1:min(j+kd,n-1)+1,j].
+					jlen := min(kd, n-j-1)
+					if jlen > 0 {
+						bi.Daxpy(jlen, -x[j]*tscal, ab[(j+1)*ldab+kd-1:], ldab-1, x[j+1:], 1)
+					}
+					i := j + 1 + bi.Idamax(n-j-1, x[j+1:], 1)
+					xmax = math.Abs(x[i])
+				}
+			}
+		}
+	} else {
+		for j := jfirst; j != jlast; j += jinc {
+			xj := math.Abs(x[j])
+			uscal := tscal
+			rec := 1 / math.Max(xmax, 1)
+			var tjjs float64
+			if cnorm[j] > (bignum-xj)*rec {
+				rec *= 0.5
+				if nonUnit {
+					tjjs = ab[j*ldab+maind] * tscal
+				} else {
+					tjjs = tscal
+				}
+				tjj := math.Abs(tjjs)
+				if tjj > 1 {
+					rec = math.Min(1, rec*tjj)
+					uscal /= tjjs
+				}
+				if rec < 1 {
+					bi.Dscal(n, rec, x, 1)
+					scale *= rec
+					xmax *= rec
+				}
+			}
+			// Compute the dot product of the off-diagonal part of column j
+			// of A with x.
+			var sumj float64
+			if upper {
+				jlen := min(kd, j)
+				if uscal == 1 {
+					if jlen > 0 {
+						sumj = bi.Ddot(jlen, ab[(j-jlen)*ldab+jlen:], ldab-1, x[j-jlen:], 1)
+					}
+				} else {
+					for i := 0; i < jlen; i++ {
+						sumj += (ab[(j-jlen+i)*ldab+jlen-i] * uscal) * x[j-jlen+i]
+					}
+				}
+			} else {
+				jlen := min(kd, n-j-1)
+				if uscal == 1 {
+					if jlen > 0 {
+						sumj = bi.Ddot(jlen, ab[(j+1)*ldab+kd-1:], ldab-1, x[j+1:], 1)
+					}
+				} else {
+					for i := 0; i < jlen; i++ {
+						sumj += (ab[(j+1+i)*ldab+kd-1-i] * uscal) * x[j+1+i]
+					}
+				}
+			}
+			if uscal == tscal {
+				x[j] -= sumj
+				xj := math.Abs(x[j])
+				var tjjs float64
+				if nonUnit {
+					tjjs = ab[j*ldab+maind] * tscal
+				} else {
+					tjjs = tscal
+					if tscal == 1 {
+						goto Skip2
+					}
+				}
+				tjj := math.Abs(tjjs)
+				if tjj > smlnum {
+					if tjj < 1 {
+						if xj > tjj*bignum {
+							rec = 1 / xj
+							bi.Dscal(n, rec, x, 1)
+							scale *= rec
+							xmax *= rec
+						}
+					}
+					x[j] /= tjjs
+				} else if tjj > 0 {
+					if xj > tjj*bignum {
+						rec = (tjj * bignum) / xj
+						bi.Dscal(n, rec, x, 1)
+						scale *= rec
+						xmax *= rec
+					}
+					x[j] /= tjjs
+				} else {
+					for i := 0; i < n; i++ {
+						x[i] = 0
+					}
+					x[j] = 1
+					scale = 0
+					xmax = 0
+				}
+			} else {
+				x[j] = x[j]/tjjs - sumj
+			}
+		Skip2:
+			xmax = math.Max(xmax, math.Abs(x[j]))
+		}
+	}
+	scale /= tscal
+	if tscal != 1 {
+		bi.Dscal(n, 1/tscal, cnorm, 1)
+	}
+	return scale
+}
@@ -0,0 +1,159 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dlatdf computes the contribution to the reciprocal Dif-estimate by solving
+// for x in
+//  Z * x = b,
+// where b is chosen such that the norm of x is as large as possible. It is
+// used by Dtgsy2 when computing an estimate of Dif, the separation of two
+// matrix pairs. The n×n matrix Z must contain the LU factorization with
+// complete pivoting of Z and ipiv and jpiv the corresponding pivot indices as
+// returned by Dgetc2. n must be at most 8.
+//
+// If ijob == 2, Dlatdf first computes an approximate null vector e of Z using
+// Dgecon, e is normalized and the right hand side is chosen as
+//  b = rhs ± e
+// whichever gives the larger solution. For other values of ijob Dlatdf uses a
+// local look-ahead strategy where the entries of the right hand side b are
+// chosen as ±1.
+//
+// On entry, rhs must contain the contribution from the previously solved
+// subsystems, on return it contains the solution vector x. rhs must have
+// length at least n.
+//
+// On entry, rdsum and rdscal must contain the sum of squares and the scaling
+// factor of the contributions from the previously solved subsystems. On
+// return, sum and scale are updated by the contribution of the solution x so
+// that
+//  scale^2 * sum = rdscal^2 * rdsum + x^T * x.
+//
+// Dlatdf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlatdf(ijob, n int, z []float64, ldz int, rhs []float64, rdsum, rdscal float64, ipiv, jpiv []int) (sum, scale float64) {
+	const maxdim = 8
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case n > maxdim:
+		panic("lapack: n > 8")
+	case len(rhs) < n:
+		panic("lapack: insufficient length of rhs")
+	case len(ipiv) < n || len(jpiv) < n:
+		panic(badIpiv)
+	}
+	checkMatrix(n, n, z, ldz)
+	if n == 0 {
+		return rdsum, rdscal
+	}
+
+	bi := blas64.Implementation()
+	var xp [maxdim]float64
+	if ijob != 2 {
+		// Apply permutations ipiv to rhs.
+		for i := 0; i < n-1; i++ {
+			if ipiv[i] != i {
+				rhs[i], rhs[ipiv[i]] = rhs[ipiv[i]], rhs[i]
+			}
+		}
+
+		// Solve for L-part choosing rhs either to +1 or -1.
+		pmone := -1.0
+		for j := 0; j < n-1; j++ {
+			bp := rhs[j] + 1
+			bm := rhs[j] - 1
+
+			// Look-ahead for L-part rhs[0:n-1] = ±1.
+			splus := 1 + bi.Ddot(n-j-1, z[(j+1)*ldz+j:], ldz, z[(j+1)*ldz+j:], ldz)
+			sminu := bi.Ddot(n-j-1, z[(j+1)*ldz+j:], ldz, rhs[j+1:], 1)
+			splus *= rhs[j]
+			switch {
+			case splus > sminu:
+				rhs[j] = bp
+			case sminu > splus:
+				rhs[j] = bm
+			default:
+				// In this case the updating sums are equal and
+				// rhs[j] can be chosen as +1 or -1. The first time
+				// this happens -1 is chosen, thereafter +1. This is
+				// a simple way to get good estimates of matrices
+				// like Byers' well-known example.
+				rhs[j] += pmone
+				pmone = 1
+			}
+
+			// Compute the remaining right hand side.
+			bi.Daxpy(n-j-1, -rhs[j], z[(j+1)*ldz+j:], ldz, rhs[j+1:], 1)
+		}
+
+		// Solve for U-part, look-ahead for rhs[n-1] = ±1. Any
+		// ill-conditioning of the original matrix is transferred to U
+		// and not to L, and U[n-1,n-1] is an approximation to the
+		// smallest singular value of L*U.
+		copy(xp[:n-1], rhs[:n-1])
+		xp[n-1] = rhs[n-1] + 1
+		rhs[n-1] -= 1
+		var splus, sminu float64
+		for i := n - 1; i >= 0; i-- {
+			temp := 1 / z[i*ldz+i]
+			xp[i] *= temp
+			rhs[i] *= temp
+			for k := i + 1; k < n; k++ {
+				xp[i] -= xp[k] * (z[i*ldz+k] * temp)
+				rhs[i] -= rhs[k] * (z[i*ldz+k] * temp)
+			}
+			splus += math.Abs(xp[i])
+			sminu += math.Abs(rhs[i])
+		}
+		if splus > sminu {
+			copy(rhs[:n], xp[:n])
+		}
+
+		// Apply the permutations jpiv to the computed solution.
+		for i := n - 2; i >= 0; i-- {
+			if jpiv[i] != i {
+				rhs[i], rhs[jpiv[i]] = rhs[jpiv[i]], rhs[i]
+			}
+		}
+
+		// Compute the sum of squares.
+		scale, sum = impl.Dlassq(n, rhs, 1, rdscal, rdsum)
+		return sum, scale
+	}
+
+	// Compute approximate null vector xm of Z.
+	var work [4 * maxdim]float64
+	var iwork [maxdim]int
+	impl.Dgecon(lapack.MaxRowSum, n, z, ldz, 1, work[:], iwork[:])
+	var xm [maxdim]float64
+	copy(xm[:n], work[n:2*n])
+
+	// Compute rhs.
+	for i := n - 2; i >= 0; i-- {
+		if ipiv[i] != i {
+			xm[i], xm[ipiv[i]] = xm[ipiv[i]], xm[i]
+		}
+	}
+	temp := 1 / bi.Dnrm2(n, xm[:], 1)
+	bi.Dscal(n, temp, xm[:], 1)
+	copy(xp[:n], xm[:n])
+	bi.Daxpy(n, 1, rhs, 1, xp[:], 1)
+	bi.Daxpy(n, -1, xm[:], 1, rhs, 1)
+	impl.Dgesc2(n, z, ldz, rhs, ipiv, jpiv)
+	impl.Dgesc2(n, z, ldz, xp[:], ipiv, jpiv)
+	if bi.Dasum(n, xp[:], 1) > bi.Dasum(n, rhs, 1) {
+		copy(rhs[:n], xp[:n])
+	}
+
+	// Compute the sum of squares.
+	scale, sum = impl.Dlassq(n, rhs, 1, rdscal, rdsum)
+	return sum, scale
+}
@@ -0,0 +1,69 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dlatrz reduces the m×n upper trapezoidal matrix A, where m <= n and A has
+// the form
+//  A = [ A1 A2 ]
+// with A1 an m×m upper triangular matrix and A2 an m×l matrix in its last l
+// columns, to upper triangular form by means of orthogonal transformations.
+// The factorization has the form
+//  A = [ R 0 ] * Z,
+// where Z is an n×n orthogonal matrix and R is an m×m upper triangular
+// matrix. The columns between m and n-l-1 of A must be zero and are not
+// referenced.
+//
+// On return, the upper triangle of the first m columns of A contains R and
+// the last l columns of A, together with tau, represent Z as a product of m
+// elementary reflectors
+//  Z = H_0 * H_1 * ... * H_{m-1}.
+// Each H_k has the form
+//  H_k = I - tau[k] * u_k * u_k^T,
+// where u_k is a vector of length n with u_k[k] = 1, whose last l elements
+// are stored in A[k, n-l:n] and whose other elements are zero.
+//
+// tau must have length at least m and work must have length at least m.
+//
+// Dlatrz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dlatrz(m, n, l int, a []float64, lda int, tau, work []float64) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < m {
+		panic(nLTM)
+	}
+	if l < 0 || n-m < l {
+		panic("lapack: l out of range")
+	}
+	checkMatrix(m, n, a, lda)
+	if len(tau) < m {
+		panic(badTau)
+	}
+	if len(work) < m {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 {
+		return
+	}
+	if m == n {
+		for i := range tau[:m] {
+			tau[i] = 0
+		}
+		return
+	}
+
+	for i := m - 1; i >= 0; i-- {
+		// Generate elementary reflector H_i to annihilate
+		// [ A[i,i] A[i,n-l:n] ].
+		a[i*lda+i], tau[i] = impl.Dlarfg(l+1, a[i*lda+i], a[i*lda+n-l:], 1)
+
+		// Apply H_i to A[0:i, i:n] from the right.
+		impl.Dlarz(blas.Right, i, n-i, l, a[i*lda+n-l:], 1, tau[i], a[i:], lda, work)
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dopgtr generates a real orthogonal matrix Q which is defined as the product
+// of n-1 elementary reflectors of order n as returned by Dsptrd using packed
+// storage.
+//
+// The construction of Q depends on the value of uplo:
+//  Q = H_{n-1} * ... * H_1 * H_0  if uplo == blas.Upper
+//  Q = H_0 * H_1 * ... * H_{n-1}  if uplo == blas.Lower
+// where H_i is constructed from the elementary reflectors as computed by Dsptrd.
+// See the documentation for Dsptrd for more information.
+//
+// ap must have length at least n*(n+1)/2, tau must have length at least n-1,
+// and work must have length at least n-1, otherwise Dopgtr will panic.
+//
+// On return, q contains the n×n orthogonal matrix Q.
+//
+// Dopgtr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dopgtr(uplo blas.Uplo, n int, ap, tau, q []float64, ldq int, work []float64) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(tau) < n-1:
+		panic(badTau)
+	case len(work) < n-1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, q, max(1, ldq))
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	if uplo == blas.Upper {
+		// Q was determined by a call to Dsptrd with uplo == blas.Upper.
+		// Unpack the vectors which define the elementary reflectors shifted
+		// one column to the left, and set the last row and column of Q to
+		// those of the unit matrix.
+		for i := 0; i < n-1; i++ {
+			k := i*n - i*(i-1)/2
+			for j := 0; j < i; j++ {
+				q[i*ldq+j] = 0
+			}
+			for j := i; j < n-1; j++ {
+				q[i*ldq+j] = ap[k+j+1-i]
+			}
+			q[i*ldq+n-1] = 0
+		}
+		for j := 0; j < n-1; j++ {
+			q[(n-1)*ldq+j] = 0
+		}
+		q[(n-1)*ldq+n-1] = 1
+
+		// Generate Q[0:n-1, 0:n-1].
+		impl.Dorg2l(n-1, n-1, n-1, q, ldq, tau, work)
+		return
+	}
+
+	// Q was determined by a call to Dsptrd with uplo == blas.Lower.
+	// Unpack the vectors which define the elementary reflectors shifted one
+	// column to the right, and set the first row and column of Q to those of
+	// the unit matrix.
+	q[0] = 1
+	for j := 1; j < n; j++ {
+		q[j] = 0
+	}
+	for i := 1; i < n; i++ {
+		k := i * (i + 1) / 2
+		q[i*ldq] = 0
+		for j := 1; j <= i; j++ {
+			q[i*ldq+j] = ap[k+j-1]
+		}
+		for j := i + 1; j < n; j++ {
+			q[i*ldq+j] = 0
+		}
+	}
+	if n > 1 {
+		// Generate Q[1:n, 1:n].
+		impl.Dorg2r(n-1, n-1, n-1, q[ldq+1:], ldq, tau, work)
+	}
+}
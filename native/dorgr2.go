@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dorgr2 generates an m×n matrix Q with orthonormal rows which is defined as
+// the last m rows of a product of k elementary reflectors of order n
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dgerqf. It must be that n >= m >= k.
+//
+// On entry, the (m-k+i)-th row of A must contain the vector which defines the
+// elementary reflector H_i, for i=0,...,k-1, and tau[i] must contain its
+// scalar factor. On return, a contains the m×n matrix Q.
+//
+// tau must have length at least k, and Dorgr2 will panic otherwise.
+//
+// work contains temporary memory, and must have length at least m. Dorgr2 will
+// panic otherwise.
+//
+// Dorgr2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dorgr2(m, n, k int, a []float64, lda int, tau, work []float64) {
+	checkMatrix(m, n, a, lda)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if len(work) < m {
+		panic(badWork)
+	}
+	if n < m {
+		panic(nLTM)
+	}
+	if k < 0 {
+		panic(kLT0)
+	}
+	if k > m {
+		panic(kGTM)
+	}
+	if m == 0 {
+		return
+	}
+
+	// Initialize rows 0:m-k to rows of the unit matrix.
+	for l := 0; l < m-k; l++ {
+		for j := 0; j < n; j++ {
+			a[l*lda+j] = 0
+		}
+		a[l*lda+n-m+l] = 1
+	}
+
+	bi := blas64.Implementation()
+	for i := 0; i < k; i++ {
+		ii := m - k + i
+
+		// Apply H_i to A[0:m-k+i, 0:n-k+i] from the right.
+		a[ii*lda+n-m+ii] = 1
+		impl.Dlarf(blas.Right, ii, n-m+ii+1, a[ii*lda:], 1, tau[i], a, lda, work)
+		bi.Dscal(n-m+ii, -tau[i], a[ii*lda:], 1)
+		a[ii*lda+n-m+ii] = 1 - tau[i]
+
+		// Set A[m-k+i, n-k+i+1:n] to zero.
+		for l := n - m + ii + 1; l < n; l++ {
+			a[ii*lda+l] = 0
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dorgrq generates the m×n matrix Q with orthonormal rows defined as the
+// last m rows of a product of k elementary reflectors of order n
+//  Q = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dgerqf.
+//
+// It must hold that
+//  0 <= k <= m <= n,
+// and Dorgrq will panic otherwise.
+//
+// On entry, the (m-k+i)-th row of A must contain the vector which defines the
+// elementary reflector H_i, for i=0,...,k-1, and tau[i] must contain its
+// scalar factor. On return, a contains the m×n matrix Q.
+//
+// tau must have length at least k, and Dorgrq will panic otherwise.
+//
+// work must have length at least max(1,lwork), and lwork must be at least
+// max(1,m), otherwise Dorgrq will panic. For optimum performance lwork must
+// be a sufficiently large multiple of m.
+//
+// If lwork == -1, instead of computing Dorgrq the optimal work length is stored
+// into work[0].
+//
+// Dorgrq is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dorgrq(m, n, k int, a []float64, lda int, tau, work []float64, lwork int) {
+	switch {
+	case m < 0:
+		panic(mLT0)
+	case n < m:
+		panic(nLTM)
+	case k < 0:
+		panic(kLT0)
+	case k > m:
+		panic(kGTM)
+	case lwork < max(1, m) && lwork != -1:
+		panic(badWork)
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	}
+	if lwork != -1 {
+		checkMatrix(m, n, a, lda)
+		if len(tau) < k {
+			panic(badTau)
+		}
+	}
+
+	if m == 0 {
+		work[0] = 1
+		return
+	}
+
+	nb := impl.Ilaenv(1, "DORGRQ", " ", m, n, k, -1)
+	if lwork == -1 {
+		work[0] = float64(m * nb)
+		return
+	}
+
+	nbmin := 2
+	var nx, ldwork int
+	iws := m
+	if nb > 1 && nb < k {
+		// Determine when to cross over from blocked to unblocked code.
+		nx = max(0, impl.Ilaenv(3, "DORGRQ", " ", m, n, k, -1))
+		if nx < k {
+			// Determine if workspace is large enough for blocked code.
+			iws = m * nb
+			if lwork < iws {
+				// Not enough workspace to use optimal nb: reduce nb and determine
+				// the minimum value of nb.
+				nb = lwork / m
+				nbmin = max(2, impl.Ilaenv(2, "DORGRQ", " ", m, n, k, -1))
+			}
+			ldwork = nb
+		}
+	}
+
+	var kk int
+	if nb >= nbmin && nb < k && nx < k {
+		// Use blocked code after the first block. The last kk rows are handled
+		// by the block method.
+		kk = min(k, ((k-nx+nb-1)/nb)*nb)
+
+		// Set A(0:m-kk, n-kk:n) to zero.
+		for i := 0; i < m-kk; i++ {
+			for j := n - kk; j < n; j++ {
+				a[i*lda+j] = 0
+			}
+		}
+	}
+
+	// Use unblocked code for the first or only block.
+	impl.Dorgr2(m-kk, n-kk, k-kk, a, lda, tau, work)
+	if kk > 0 {
+		// Use blocked code.
+		for i := k - kk; i < k; i += nb {
+			ib := min(nb, k-i)
+			ii := m - k + i
+			if ii > 0 {
+				// Form the triangular factor of the block reflector
+				// H = H_{i+ib-1} * ... * H_{i+1} * H_i.
+				impl.Dlarft(lapack.Backward, lapack.RowWise, n-k+i+ib, ib,
+					a[ii*lda:], lda, tau[i:], work, ldwork)
+
+				// Apply H^T to A[0:m-k+i, 0:n-k+i+ib] from the right.
+				impl.Dlarfb(blas.Right, blas.Trans, lapack.Backward, lapack.RowWise,
+					ii, n-k+i+ib, ib, a[ii*lda:], lda, work, ldwork,
+					a, lda, work[ib*ldwork:], ldwork)
+			}
+
+			// Apply H^T to columns 0:n-k+i+ib of current block.
+			impl.Dorgr2(ib, n-k+i+ib, ib, a[ii*lda:], lda, tau[i:], work)
+
+			// Set columns n-k+i+ib:n of current block to zero.
+			for l := ii; l < ii+ib; l++ {
+				for j := n - k + i + ib; j < n; j++ {
+					a[l*lda+j] = 0
+				}
+			}
+		}
+	}
+	work[0] = float64(iws)
+}
@@ -0,0 +1,160 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dormql multiplies an m×n matrix C by an orthogonal matrix Q as
+//  C = Q * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Q^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Q,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Q^T,  if side == blas.Right and trans == blas.Trans,
+// where Q is defined as the product of k elementary reflectors
+//  Q = H_{k-1} * ... * H_1 * H_0.
+//
+// If side == blas.Left, A is an m×k matrix and 0 <= k <= m.
+// If side == blas.Right, A is an n×k matrix and 0 <= k <= n.
+// The ith column of A contains the vector which defines the elementary
+// reflector H_i and tau[i] contains its scalar factor. tau must have length k
+// and Dormql will panic otherwise. Dgeqlf returns A and tau in the required
+// form.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Dormql will
+// panic. Larger values of lwork will generally give better performance. On
+// return, work[0] will contain the optimal value of lwork.
+//
+// If lwork is -1, instead of performing Dormql, the optimal workspace size will
+// be stored into work[0].
+//
+// Dormql is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormql(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case k < 0 || nq < k:
+		panic("lapack: invalid value of k")
+	case len(work) < lwork:
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	if lwork != -1 {
+		checkMatrix(nq, k, a, lda)
+		checkMatrix(m, n, c, ldc)
+		if len(tau) != k {
+			panic(badTau)
+		}
+	}
+
+	if m == 0 || n == 0 || k == 0 {
+		work[0] = 1
+		return
+	}
+
+	const (
+		nbmax = 64
+		ldt   = nbmax
+		tsize = nbmax * ldt
+	)
+	var opts string
+	if side == blas.Left {
+		opts = "L"
+	} else {
+		opts = "R"
+	}
+	if trans == blas.Trans {
+		opts += "T"
+	} else {
+		opts += "N"
+	}
+	nb := min(nbmax, impl.Ilaenv(1, "DORMQL", opts, m, n, k, -1))
+	lworkopt := max(1, nw)*nb + tsize
+	if lwork == -1 {
+		work[0] = float64(lworkopt)
+		return
+	}
+
+	nbmin := 2
+	if 1 < nb && nb < k {
+		if lwork < nw*nb+tsize {
+			nb = (lwork - tsize) / nw
+			nbmin = max(2, impl.Ilaenv(2, "DORMQL", opts, m, n, k, -1))
+		}
+	}
+
+	if nb < nbmin || k <= nb {
+		// Call unblocked code.
+		impl.Dorm2l(side, trans, m, n, k, a, lda, tau, c, ldc, work)
+		work[0] = float64(lworkopt)
+		return
+	}
+
+	var (
+		ldwork = nb
+		left   = side == blas.Left
+		notran = trans == blas.NoTrans
+	)
+	if (left && notran) || (!left && !notran) {
+		for i := 0; i < k; i += nb {
+			ib := min(nb, k-i)
+			impl.Dlarft(lapack.Backward, lapack.ColumnWise, nq-k+i+ib, ib,
+				a[i:], lda,
+				tau[i:],
+				work[:tsize], ldt)
+			if left {
+				impl.Dlarfb(side, trans, lapack.Backward, lapack.ColumnWise, m-k+i+ib, n, ib,
+					a[i:], lda,
+					work[:tsize], ldt,
+					c, ldc,
+					work[tsize:], ldwork)
+			} else {
+				impl.Dlarfb(side, trans, lapack.Backward, lapack.ColumnWise, m, n-k+i+ib, ib,
+					a[i:], lda,
+					work[:tsize], ldt,
+					c, ldc,
+					work[tsize:], ldwork)
+			}
+		}
+	} else {
+		for i := ((k - 1) / nb) * nb; i >= 0; i -= nb {
+			ib := min(nb, k-i)
+			impl.Dlarft(lapack.Backward, lapack.ColumnWise, nq-k+i+ib, ib,
+				a[i:], lda,
+				tau[i:],
+				work[:tsize], ldt)
+			if left {
+				impl.Dlarfb(side, trans, lapack.Backward, lapack.ColumnWise, m-k+i+ib, n, ib,
+					a[i:], lda,
+					work[:tsize], ldt,
+					c, ldc,
+					work[tsize:], ldwork)
+			} else {
+				impl.Dlarfb(side, trans, lapack.Backward, lapack.ColumnWise, m, n-k+i+ib, ib,
+					a[i:], lda,
+					work[:tsize], ldt,
+					c, ldc,
+					work[tsize:], ldwork)
+			}
+		}
+	}
+	work[0] = float64(lworkopt)
+}
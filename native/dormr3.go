@@ -0,0 +1,87 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dormr3 multiplies an m×n matrix C by an orthogonal matrix Z as
+//  C = Z * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Z^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Z,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Z^T,  if side == blas.Right and trans == blas.Trans,
+// where Z is defined as the product of k elementary reflectors
+//  Z = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dtzrzf. Dormr3 is the unblocked version of Dormrz.
+//
+// A is a k×m matrix if side == blas.Left and a k×n matrix if
+// side == blas.Right. The last l columns of the i-th row of A contain the
+// stored part of the vector defining H_i, and tau[i] contains its scalar
+// factor. tau must have length at least k.
+//
+// work must have length at least n if side == blas.Left and at least m if
+// side == blas.Right.
+//
+// Dormr3 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormr3(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float64, lda int, tau, c []float64, ldc int, work []float64) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case k < 0 || nq < k:
+		panic("lapack: invalid value of k")
+	case l < 0 || nq < l:
+		panic("lapack: l out of range")
+	}
+	checkMatrix(k, nq, a, lda)
+	checkMatrix(m, n, c, ldc)
+	if len(tau) < k {
+		panic(badTau)
+	}
+	if len(work) < nw {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 || k == 0 {
+		return
+	}
+
+	ja := nq - l
+	left := side == blas.Left
+	notran := trans == blas.NoTrans
+	if left == notran {
+		// Z = H_0 * ... * H_{k-1} is applied from the left or Z^T from
+		// the right, so the reflectors are applied in reverse order.
+		for i := k - 1; i >= 0; i-- {
+			if left {
+				// H_i is applied to C[i:m, 0:n].
+				impl.Dlarz(side, m-i, n, l, a[i*lda+ja:], 1, tau[i], c[i*ldc:], ldc, work)
+			} else {
+				// H_i is applied to C[0:m, i:n].
+				impl.Dlarz(side, m, n-i, l, a[i*lda+ja:], 1, tau[i], c[i:], ldc, work)
+			}
+		}
+		return
+	}
+	for i := 0; i < k; i++ {
+		if left {
+			impl.Dlarz(side, m-i, n, l, a[i*lda+ja:], 1, tau[i], c[i*ldc:], ldc, work)
+		} else {
+			impl.Dlarz(side, m, n-i, l, a[i*lda+ja:], 1, tau[i], c[i:], ldc, work)
+		}
+	}
+}
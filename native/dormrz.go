@@ -0,0 +1,150 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dormrz multiplies an m×n matrix C by an orthogonal matrix Z as
+//  C = Z * C,    if side == blas.Left  and trans == blas.NoTrans,
+//  C = Z^T * C,  if side == blas.Left  and trans == blas.Trans,
+//  C = C * Z,    if side == blas.Right and trans == blas.NoTrans,
+//  C = C * Z^T,  if side == blas.Right and trans == blas.Trans,
+// where Z is defined as the product of k elementary reflectors
+//  Z = H_0 * H_1 * ... * H_{k-1}
+// as returned by Dtzrzf.
+//
+// A is a k×m matrix if side == blas.Left and a k×n matrix if
+// side == blas.Right, and 0 <= k <= m or 0 <= k <= n, respectively. The last
+// l columns of the i-th row of A contain the stored part of the vector
+// defining H_i, and tau[i] contains its scalar factor. tau must have length
+// at least k.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Dormrz will
+// panic. Larger values of lwork will generally give better performance. On
+// return, work[0] will contain the optimal value of lwork.
+//
+// If lwork is -1, instead of performing Dormrz, the optimal workspace size will
+// be stored into work[0].
+//
+// Dormrz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormrz(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case k < 0 || nq < k:
+		panic("lapack: invalid value of k")
+	case l < 0 || nq < l:
+		panic("lapack: l out of range")
+	case len(work) < max(1, lwork):
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	if lwork != -1 {
+		checkMatrix(k, nq, a, lda)
+		checkMatrix(m, n, c, ldc)
+		if len(tau) < k {
+			panic(badTau)
+		}
+	}
+
+	if m == 0 || n == 0 {
+		work[0] = 1
+		return
+	}
+
+	const (
+		nbmax = 64
+		ldt   = nbmax
+		tsize = nbmax * ldt
+	)
+	opts := string(side) + string(trans)
+	nb := min(nbmax, impl.Ilaenv(1, "DORMRQ", opts, m, n, k, -1))
+	lworkopt := max(1, nw)*nb + tsize
+	if lwork == -1 {
+		work[0] = float64(lworkopt)
+		return
+	}
+	if k == 0 {
+		work[0] = float64(lworkopt)
+		return
+	}
+
+	nbmin := 2
+	if 1 < nb && nb < k {
+		if lwork < nw*nb+tsize {
+			nb = (lwork - tsize) / nw
+			nbmin = max(2, impl.Ilaenv(2, "DORMRQ", opts, m, n, k, -1))
+		}
+	}
+
+	if nb < nbmin || k <= nb {
+		// Call unblocked code.
+		impl.Dormr3(side, trans, m, n, k, l, a, lda, tau, c, ldc, work)
+		work[0] = float64(lworkopt)
+		return
+	}
+
+	var (
+		ldwork = nb
+		left   = side == blas.Left
+		notran = trans == blas.NoTrans
+		ja     = nq - l
+	)
+	transt := blas.Trans
+	if !notran {
+		transt = blas.NoTrans
+	}
+	apply := func(i int) {
+		ib := min(nb, k-i)
+
+		// Form the triangular factor of the block reflector
+		// H = H_{i+ib-1} . . . H_{i+1} H_i.
+		impl.Dlarzt(lapack.Backward, lapack.RowWise, l, ib, a[i*lda+ja:], lda, tau[i:],
+			work[:tsize], ldt)
+
+		// Apply H or H^T to C[i:m, 0:n] or C[0:m, i:n].
+		if left {
+			impl.Dlarzb(side, transt, lapack.Backward, lapack.RowWise, m-i, n, ib, l,
+				a[i*lda+ja:], lda,
+				work[:tsize], ldt,
+				c[i*ldc:], ldc,
+				work[tsize:], ldwork)
+		} else {
+			impl.Dlarzb(side, transt, lapack.Backward, lapack.RowWise, m, n-i, ib, l,
+				a[i*lda+ja:], lda,
+				work[:tsize], ldt,
+				c[i:], ldc,
+				work[tsize:], ldwork)
+		}
+	}
+	if left != notran {
+		for i := 0; i < k; i += nb {
+			apply(i)
+		}
+	} else {
+		for i := ((k - 1) / nb) * nb; i >= 0; i -= nb {
+			apply(i)
+		}
+	}
+	work[0] = float64(lworkopt)
+}
@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dormtr overwrites the general m×n matrix C with
+//  Q * C    if side == blas.Left and trans == blas.NoTrans
+//  Q^T * C  if side == blas.Left and trans == blas.Trans
+//  C * Q    if side == blas.Right and trans == blas.NoTrans
+//  C * Q^T  if side == blas.Right and trans == blas.Trans
+// where Q is the orthogonal matrix of order nq, with nq = m if side == blas.Left
+// and nq = n if side == blas.Right, defined as the product of nq-1 elementary
+// reflectors as returned by Dsytrd:
+//  Q = H_{nq-2} * ... * H_1 * H_0  if uplo == blas.Upper,
+//  Q = H_0 * H_1 * ... * H_{nq-2}  if uplo == blas.Lower.
+//
+// a and tau must contain the elementary reflectors as returned by Dsytrd with
+// the same value of uplo. tau must have length at least nq-1, and Dormtr will
+// panic otherwise.
+//
+// work must have length at least max(1,lwork), and lwork must be at least n if
+// side == blas.Left and at least m if side == blas.Right, otherwise Dormtr will
+// panic. For optimum performance lwork should be at least n*nb if side ==
+// blas.Left, and at least m*nb if side == blas.Right, where nb is the optimal
+// block size. On return, work[0] will contain the optimal value of lwork.
+//
+// If lwork == -1, instead of performing Dormtr, the optimal workspace size will
+// be stored into work[0].
+//
+// Dormtr is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dormtr(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int) {
+	var nq, nw int
+	switch side {
+	default:
+		panic(badSide)
+	case blas.Left:
+		nq = m
+		nw = n
+	case blas.Right:
+		nq = n
+		nw = m
+	}
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case m < 0 || n < 0:
+		panic(negDimension)
+	case len(work) < lwork:
+		panic(shortWork)
+	case lwork < max(1, nw) && lwork != -1:
+		panic(badWork)
+	}
+	upper := uplo == blas.Upper
+	left := side == blas.Left
+	if lwork != -1 {
+		checkMatrix(nq, nq, a, lda)
+		checkMatrix(m, n, c, ldc)
+		if len(tau) < nq-1 {
+			panic(badTau)
+		}
+	}
+
+	var mi, ni int
+	if left {
+		mi = m - 1
+		ni = n
+	} else {
+		mi = m
+		ni = n - 1
+	}
+	if lwork == -1 {
+		if m == 0 || n == 0 || nq == 1 {
+			work[0] = 1
+			return
+		}
+		if upper {
+			impl.Dormql(side, trans, mi, ni, nq-1, a, lda, tau, c, ldc, work, -1)
+		} else {
+			impl.Dormqr(side, trans, mi, ni, nq-1, a, lda, tau, c, ldc, work, -1)
+		}
+		return
+	}
+
+	if m == 0 || n == 0 || nq == 1 {
+		work[0] = 1
+		return
+	}
+
+	if upper {
+		// Q was determined by a call to Dsytrd with uplo == blas.Upper.
+		impl.Dormql(side, trans, mi, ni, nq-1, a[1:], lda, tau[:nq-1], c, ldc, work, lwork)
+		return
+	}
+	// Q was determined by a call to Dsytrd with uplo == blas.Lower.
+	if left {
+		impl.Dormqr(side, trans, mi, ni, nq-1, a[lda:], lda, tau[:nq-1], c[ldc:], ldc, work, lwork)
+	} else {
+		impl.Dormqr(side, trans, mi, ni, nq-1, a[lda:], lda, tau[:nq-1], c[1:], ldc, work, lwork)
+	}
+}
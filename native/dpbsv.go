@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dpbsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite band matrix with kd super- or
+// subdiagonals, and X and B are n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with kd off-diagonals. On entry, ab contains the upper or lower
+// triangle of A in the band storage format described in the documentation of
+// Dpbtrf, and on return it contains the factor U or L in the same format.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dpbsv returns whether the factorization was successfully completed. If ok
+// is false, the leading minor of some order of A is not positive definite, so
+// the factorization could not be completed and the solution has not been
+// computed.
+func (impl Implementation) Dpbsv(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the Cholesky factorization of A.
+	ok = impl.Dpbtrf(uplo, n, kd, ab, ldab)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.Dpbtrs(uplo, n, kd, nrhs, ab, ldab, b, ldb)
+	return true
+}
@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpbtf2 computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A with kd super- or subdiagonals. The factorization has
+// the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with kd off-diagonals. On entry, ab contains the upper or lower
+// triangle of A in the row-major band format described in the documentation
+// of Dpbtrf. On return, ab contains the factor U or L in the same format.
+//
+// Dpbtf2 returns whether the factorization was successfully completed. If ok
+// is false, the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+//
+// Dpbtf2 is the unblocked version of the algorithm, see Dpbtrf for the
+// blocked version.
+//
+// Dpbtf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Dpbtf2(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	bi := blas64.Implementation()
+
+	// In the band storage, the trailing submatrix of A within the band is a
+	// general matrix with stride ldab-1.
+	kld := max(1, ldab-1)
+	if uplo == blas.Upper {
+		// Compute the Cholesky factorization A = U^T * U.
+		for j := 0; j < n; j++ {
+			// Compute U[j,j] and test for non-positive-definiteness.
+			ajj := ab[j*ldab]
+			if ajj <= 0 || math.IsNaN(ajj) {
+				return false
+			}
+			ajj = math.Sqrt(ajj)
+			ab[j*ldab] = ajj
+			// Compute elements j+1:j+kn of row j and update the trailing
+			// submatrix within the band.
+			kn := min(kd, n-j-1)
+			if kn > 0 {
+				bi.Dscal(kn, 1/ajj, ab[j*ldab+1:], 1)
+				bi.Dsyr(blas.Upper, kn, -1, ab[j*ldab+1:], 1, ab[(j+1)*ldab:], kld)
+			}
+		}
+		return true
+	}
+	// Compute the Cholesky factorization A = L * L^T.
+	for j := 0; j < n; j++ {
+		// Compute L[j,j] and test for non-positive-definiteness.
+		ajj := ab[j*ldab+kd]
+		if ajj <= 0 || math.IsNaN(ajj) {
+			return false
+		}
+		ajj = math.Sqrt(ajj)
+		ab[j*ldab+kd] = ajj
+		// Compute elements j+1:j+kn of column j and update the trailing
+		// submatrix within the band.
+		kn := min(kd, n-j-1)
+		if kn > 0 {
+			bi.Dscal(kn, 1/ajj, ab[(j+1)*ldab+kd-1:], kld)
+			bi.Dsyr(blas.Lower, kn, -1, ab[(j+1)*ldab+kd-1:], kld, ab[(j+1)*ldab+kd:], kld)
+		}
+	}
+	return true
+}
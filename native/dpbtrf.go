@@ -0,0 +1,213 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpbtrf computes the Cholesky factorization of an n×n symmetric positive
+// definite band matrix A with kd super- or subdiagonals. The factorization has
+// the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular band matrix and L is a lower triangular band
+// matrix, both with kd off-diagonals.
+//
+// The upper or lower triangle of A is stored in ab in row-major band format as
+// used by blas64.SymmetricBand. If uplo == blas.Upper, the element A[i,j] is
+// stored in ab[i*ldab+j-i] for i <= j <= min(i+kd,n-1), and if
+// uplo == blas.Lower, A[i,j] is stored in ab[i*ldab+kd+j-i] for
+// max(0,i-kd) <= j <= i. For example, when n = 6 and kd = 2, the layout of ab
+// is
+//  uplo == blas.Upper    uplo == blas.Lower
+//   a00 a01 a02             *   *  a00
+//   a11 a12 a13             *  a10 a11
+//   a22 a23 a24            a20 a21 a22
+//   a33 a34 a35            a31 a32 a33
+//   a44 a45  *             a42 a43 a44
+//   a55  *   *             a53 a54 a55
+// where the elements marked * are not used. ldab must be at least kd+1,
+// otherwise Dpbtrf will panic. On return, ab contains the factor U or L in the
+// same format.
+//
+// Dpbtrf returns whether the factorization was successfully completed. If ok
+// is false, the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (impl Implementation) Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool) {
+	if uplo != blas.Upper && uplo != blas.Lower {
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// nbmax is the maximum block size and the number of columns of the local
+	// work array.
+	const (
+		nbmax  = 32
+		ldwork = nbmax + 1
+	)
+
+	opts := string(blas.Upper)
+	if uplo == blas.Lower {
+		opts = string(blas.Lower)
+	}
+	nb := impl.Ilaenv(1, "DPBTRF", opts, n, kd, -1, -1)
+	// The block size must not exceed the semi-bandwidth kd, and must not
+	// exceed the limit set by the size of the local work array.
+	nb = min(nb, nbmax)
+
+	if nb <= 1 || kd < nb {
+		// Use unblocked code.
+		return impl.Dpbtf2(uplo, n, kd, ab, ldab)
+	}
+
+	// Use blocked code.
+
+	// The elements of the block A13 (or the transpose of A31) that lie
+	// outside the band are stored in the work array. The strictly upper
+	// triangle of work must be zero.
+	work := make([]float64, ldwork*nbmax)
+
+	bi := blas64.Implementation()
+
+	// In the band storage, a submatrix of A within the band is a general
+	// matrix with stride ldab-1.
+	kld := ldab - 1
+	if uplo == blas.Upper {
+		// Compute the Cholesky factorization A = U^T * U, processing the
+		// band matrix one diagonal block at a time.
+		for i := 0; i < n; i += nb {
+			ib := min(nb, n-i)
+
+			// Factorize the diagonal block.
+			if !impl.Dpotf2(uplo, ib, ab[i*ldab:], kld) {
+				return false
+			}
+			if i+ib >= n {
+				break
+			}
+
+			// Update the relevant part of the trailing submatrix. If A11
+			// denotes the diagonal block which has just been factorized,
+			// then we need to update the remaining blocks in the diagram:
+			//  A11   A12   A13
+			//        A22   A23
+			//              A33
+			// The numbers of rows and columns in the partitioning are ib,
+			// i2 and i3 respectively. The blocks A12, A22 and A23 are empty
+			// if ib == kd. The upper triangle of A13 lies outside the band.
+			i2 := min(kd-ib, n-i-ib)
+			i3 := min(ib, n-i-kd)
+
+			if i2 > 0 {
+				// Update A12.
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, ib, i2,
+					1, ab[i*ldab:], kld, ab[i*ldab+ib:], kld)
+				// Update A22.
+				bi.Dsyrk(blas.Upper, blas.Trans, i2, ib,
+					-1, ab[i*ldab+ib:], kld, 1, ab[(i+ib)*ldab:], kld)
+			}
+
+			if i3 > 0 {
+				// Copy the lower triangle of A13 into the work array.
+				for ii := 0; ii < ib; ii++ {
+					for jj := 0; jj <= min(ii, i3-1); jj++ {
+						work[ii*ldwork+jj] = ab[(i+ii)*ldab+kd+jj-ii]
+					}
+				}
+				// Update A13 in the work array.
+				bi.Dtrsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, ib, i3,
+					1, ab[i*ldab:], kld, work, ldwork)
+				// Update A23.
+				if i2 > 0 {
+					bi.Dgemm(blas.Trans, blas.NoTrans, i2, i3, ib,
+						-1, ab[i*ldab+ib:], kld, work, ldwork,
+						1, ab[(i+ib)*ldab+kd-ib:], kld)
+				}
+				// Update A33.
+				bi.Dsyrk(blas.Upper, blas.Trans, i3, ib,
+					-1, work, ldwork, 1, ab[(i+kd)*ldab:], kld)
+				// Copy the lower triangle of A13 back into place.
+				for ii := 0; ii < ib; ii++ {
+					for jj := 0; jj <= min(ii, i3-1); jj++ {
+						ab[(i+ii)*ldab+kd+jj-ii] = work[ii*ldwork+jj]
+					}
+				}
+			}
+		}
+		return true
+	}
+
+	// Compute the Cholesky factorization A = L * L^T, processing the band
+	// matrix one diagonal block at a time.
+	for i := 0; i < n; i += nb {
+		ib := min(nb, n-i)
+
+		// Factorize the diagonal block.
+		if !impl.Dpotf2(uplo, ib, ab[i*ldab+kd:], kld) {
+			return false
+		}
+		if i+ib >= n {
+			break
+		}
+
+		// Update the relevant part of the trailing submatrix. If A11 denotes
+		// the diagonal block which has just been factorized, then we need to
+		// update the remaining blocks in the diagram:
+		//  A11
+		//  A21   A22
+		//  A31   A32   A33
+		// The numbers of rows and columns in the partitioning are ib, i2
+		// and i3 respectively. The blocks A21, A22 and A32 are empty if
+		// ib == kd. The lower triangle of A31 lies outside the band.
+		i2 := min(kd-ib, n-i-ib)
+		i3 := min(ib, n-i-kd)
+
+		if i2 > 0 {
+			// Update A21.
+			bi.Dtrsm(blas.Right, blas.Lower, blas.Trans, blas.NonUnit, i2, ib,
+				1, ab[i*ldab+kd:], kld, ab[(i+ib)*ldab+kd-ib:], kld)
+			// Update A22.
+			bi.Dsyrk(blas.Lower, blas.NoTrans, i2, ib,
+				-1, ab[(i+ib)*ldab+kd-ib:], kld, 1, ab[(i+ib)*ldab+kd:], kld)
+		}
+
+		if i3 > 0 {
+			// Copy the transpose of the upper triangle of A31 into the work
+			// array, so that the update of A31 is a left-sided triangular
+			// solve as in the upper case.
+			for ii := 0; ii < i3; ii++ {
+				for jj := ii; jj < ib; jj++ {
+					work[jj*ldwork+ii] = ab[(i+kd+ii)*ldab+jj-ii]
+				}
+			}
+			// Update A31^T in the work array.
+			bi.Dtrsm(blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, ib, i3,
+				1, ab[i*ldab+kd:], kld, work, ldwork)
+			// Update A32.
+			if i2 > 0 {
+				bi.Dgemm(blas.Trans, blas.Trans, i3, i2, ib,
+					-1, work, ldwork, ab[(i+ib)*ldab+kd-ib:], kld,
+					1, ab[(i+kd)*ldab+ib:], kld)
+			}
+			// Update A33.
+			bi.Dsyrk(blas.Lower, blas.Trans, i3, ib,
+				-1, work, ldwork, 1, ab[(i+kd)*ldab+kd:], kld)
+			// Copy the upper triangle of A31 back into place.
+			for ii := 0; ii < i3; ii++ {
+				for jj := ii; jj < ib; jj++ {
+					ab[(i+kd+ii)*ldab+jj-ii] = work[jj*ldwork+ii]
+				}
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,89 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpftrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in rectangular full packed (RFP) format. The
+// factorization has the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+//
+// On entry, a contains the upper or lower triangle of A in the RFP format
+// described in the documentation of Dtrttf. On return, a contains the factor
+// U or L in the same format. transr must be blas.NoTrans or blas.Trans, and a
+// must have length at least n*(n+1)/2, otherwise Dpftrf will panic.
+//
+// Dpftrf returns whether the matrix A is positive definite. If ok is false,
+// the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (impl Implementation) Dpftrf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		if a[0] <= 0 {
+			return false
+		}
+		a[0] = math.Sqrt(a[0])
+		return true
+	}
+
+	// Partition A as
+	//  A = [ A11 A21^T ] = G * G^T = [ G11  0  ] * [ G11^T G21^T ]
+	//      [ A21 A22   ]             [ G21 G22 ]   [   0   G22^T ]
+	// where G is lower triangular, so that
+	//  A11 = G11 * G11^T,
+	//  G21 = A21 * G11^-T,
+	//  A22 - G21 * G21^T = G22 * G22^T.
+	// The RFP array stores G11 and G22 either as lower triangles or as
+	// transposed upper triangles, and G21 either as it is or transposed.
+	l := rfpBlocks(transr, uplo, n)
+	n1, n2, ldr := l.n1, l.n2, l.ldr
+	a1 := a[l.off1:]
+	a2 := a[l.off2:]
+	s := a[l.offS:]
+
+	bi := blas64.Implementation()
+	ok = impl.Dpotrf(l.uplo1, n1, a1, ldr)
+	if !ok {
+		return false
+	}
+	if l.s21 {
+		op := blas.Trans
+		if l.uplo1 == blas.Upper {
+			op = blas.NoTrans
+		}
+		bi.Dtrsm(blas.Right, l.uplo1, op, blas.NonUnit, n2, n1, 1, a1, ldr, s, ldr)
+		bi.Dsyrk(l.uplo2, blas.NoTrans, n2, n1, -1, s, ldr, 1, a2, ldr)
+	} else {
+		op := blas.NoTrans
+		if l.uplo1 == blas.Upper {
+			op = blas.Trans
+		}
+		bi.Dtrsm(blas.Left, l.uplo1, op, blas.NonUnit, n1, n2, 1, a1, ldr, s, ldr)
+		bi.Dsyrk(l.uplo2, blas.Trans, n2, n1, -1, s, ldr, 1, a2, ldr)
+	}
+	return impl.Dpotrf(l.uplo2, n2, a2, ldr)
+}
@@ -0,0 +1,89 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpftri computes the inverse of a real symmetric positive definite matrix A
+// stored in rectangular full packed (RFP) format using its Cholesky
+// factorization.
+//
+// On entry, a contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Dpftrf, in the RFP
+// format described in the documentation of Dtrttf. On return, a contains the
+// upper or lower triangle of the (symmetric) inverse of A in the same format,
+// overwriting the input factor U or L. transr must be blas.NoTrans or
+// blas.Trans, and a must have length at least n*(n+1)/2, otherwise Dpftri will
+// panic.
+//
+// Dpftri returns whether the matrix A is invertible. If Dpftri returns false,
+// a diagonal element of the factor is zero and the inverse could not be
+// computed.
+func (impl Implementation) Dpftri(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Invert the triangular Cholesky factor U or L.
+	ok = impl.Dtftri(transr, uplo, blas.NonUnit, n, a)
+	if !ok {
+		return false
+	}
+	if n == 1 {
+		a[0] *= a[0]
+		return true
+	}
+
+	// Write the inverse of the factor as the lower triangular matrix
+	//  Y = [ Y11  0  ]
+	//      [ Y21 Y22 ]
+	// so that
+	//  inv(A) = Y^T * Y = [ Y11^T*Y11 + Y21^T*Y21  Y21^T*Y22 ]
+	//                     [       Y22^T*Y21        Y22^T*Y22 ]
+	// The RFP array stores Y11 and Y22 either as lower triangles or as
+	// transposed upper triangles, and Y21 either as it is or transposed.
+	l := rfpBlocks(transr, uplo, n)
+	n1, n2, ldr := l.n1, l.n2, l.ldr
+	a1 := a[l.off1:]
+	a2 := a[l.off2:]
+	s := a[l.offS:]
+
+	bi := blas64.Implementation()
+	impl.Dlauum(l.uplo1, n1, a1, ldr)
+	if l.s21 {
+		bi.Dsyrk(l.uplo1, blas.Trans, n1, n2, 1, s, ldr, 1, a1, ldr)
+		// Compute Y21 := Y22^T*Y21.
+		op := blas.Trans
+		if l.uplo2 == blas.Upper {
+			op = blas.NoTrans
+		}
+		bi.Dtrmm(blas.Left, l.uplo2, op, blas.NonUnit, n2, n1, 1, a2, ldr, s, ldr)
+	} else {
+		bi.Dsyrk(l.uplo1, blas.NoTrans, n1, n2, 1, s, ldr, 1, a1, ldr)
+		// Compute Y21^T := Y21^T*Y22.
+		op := blas.NoTrans
+		if l.uplo2 == blas.Upper {
+			op = blas.Trans
+		}
+		bi.Dtrmm(blas.Right, l.uplo2, op, blas.NonUnit, n1, n2, 1, a2, ldr, s, ldr)
+	}
+	impl.Dlauum(l.uplo2, n2, a2, ldr)
+	return true
+}
@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpftrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// as computed by Dpftrf and stored in a in the rectangular full packed format
+// described in the documentation of Dtrttf. transr must be blas.NoTrans or
+// blas.Trans, and a must have length at least n*(n+1)/2, otherwise Dpftrs will
+// panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (Implementation) Dpftrs(transr blas.Transpose, uplo blas.Uplo, n, nrhs int, a []float64, b []float64, ldb int) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+	checkMatrix(n, nrhs, b, max(1, ldb))
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	if n == 1 {
+		bi.Dscal(nrhs, 1/(a[0]*a[0]), b, 1)
+		return
+	}
+
+	// The factorization is A = G * G^T where G is lower triangular and is
+	// partitioned as in Dpftrf.
+	l := rfpBlocks(transr, uplo, n)
+	n1, n2, ldr := l.n1, l.n2, l.ldr
+	a1 := a[l.off1:]
+	a2 := a[l.off2:]
+	s := a[l.offS:]
+	b1 := b
+	b2 := b[n1*ldb:]
+
+	// The operations that multiply by G11, G21 and G22, and the transposed
+	// operations that multiply by their transposes.
+	op1, opS, op2 := blas.NoTrans, blas.NoTrans, blas.NoTrans
+	op1T, opST, op2T := blas.Trans, blas.Trans, blas.Trans
+	if l.uplo1 == blas.Upper {
+		op1, op1T = op1T, op1
+	}
+	if !l.s21 {
+		opS, opST = opST, opS
+	}
+	if l.uplo2 == blas.Upper {
+		op2, op2T = op2T, op2
+	}
+
+	// Solve G * Y = B, overwriting B with Y.
+	bi.Dtrsm(blas.Left, l.uplo1, op1, blas.NonUnit, n1, nrhs, 1, a1, ldr, b1, ldb)
+	bi.Dgemm(opS, blas.NoTrans, n2, nrhs, n1, -1, s, ldr, b1, ldb, 1, b2, ldb)
+	bi.Dtrsm(blas.Left, l.uplo2, op2, blas.NonUnit, n2, nrhs, 1, a2, ldr, b2, ldb)
+
+	// Solve G^T * X = Y, overwriting B with X.
+	bi.Dtrsm(blas.Left, l.uplo2, op2T, blas.NonUnit, n2, nrhs, 1, a2, ldr, b2, ldb)
+	bi.Dgemm(opST, blas.NoTrans, n1, nrhs, n2, -1, s, ldr, b2, ldb, 1, b1, ldb)
+	bi.Dtrsm(blas.Left, l.uplo1, op1T, blas.NonUnit, n1, nrhs, 1, a1, ldr, b1, ldb)
+}
@@ -0,0 +1,62 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "math"
+
+// Dpoequ computes row and column scalings intended to equilibrate the n×n
+// symmetric positive definite matrix A and reduce its condition number (with
+// respect to the two-norm). On return, s contains the scale factors
+//  s[i] = 1 / sqrt(A[i,i]),
+// chosen so that the scaled matrix B with elements
+//  B[i,j] = s[i] * A[i,j] * s[j]
+// has ones on the diagonal. This choice of s puts the condition number of B
+// within a factor n of the smallest possible condition number over all
+// possible diagonal scalings. Only the diagonal of A is referenced.
+//
+// s must have length at least n, otherwise Dpoequ will panic.
+//
+// scond is the ratio of the smallest s[i] to the largest s[i]. If
+// scond >= 0.1 and amax is neither too large nor too small, it is not worth
+// scaling by s. amax is the absolute value of the largest element of A. If
+// amax is very close to overflow or very close to underflow, the matrix should
+// be scaled.
+//
+// Dpoequ returns false if a diagonal element of A is not positive. In that
+// case scond is not computed and s is only partially computed.
+func (impl Implementation) Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool) {
+	checkMatrix(n, n, a, lda)
+	if len(s) < n {
+		panic(badSlice)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1, 0, true
+	}
+
+	// Find the minimum and maximum diagonal elements.
+	s[0] = a[0]
+	smin := s[0]
+	amax = s[0]
+	for i := 1; i < n; i++ {
+		s[i] = a[i*lda+i]
+		smin = math.Min(smin, s[i])
+		amax = math.Max(amax, s[i])
+	}
+
+	if smin <= 0 {
+		// A diagonal element is not positive.
+		return 0, amax, false
+	}
+
+	// Set the scale factors to the reciprocals of the square roots of the
+	// diagonal elements.
+	for i := 0; i < n; i++ {
+		s[i] = 1 / math.Sqrt(s[i])
+	}
+	// Compute scond = min(s[i]) / max(s[i]).
+	return math.Sqrt(smin) / math.Sqrt(amax), amax, true
+}
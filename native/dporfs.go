@@ -0,0 +1,202 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dporfs improves the computed solution to a system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix, and provides error
+// bounds and backward error estimates for the solution.
+//
+// a contains the triangle of A specified by uplo, and af contains the
+// corresponding triangular factor from the Cholesky factorization of A as
+// computed by Dpotrf. B is an n×nrhs matrix of right hand sides and x on entry
+// contains the n×nrhs solution matrix as computed by Dpotrs. On return, x
+// contains the improved solution.
+//
+// For each right hand side j, ferr[j] contains on return an estimated bound
+// for the forward error
+//  max_i |X[i,j] - Xtrue[i,j]| / max_i |X[i,j]|,
+// where Xtrue is the true solution. The estimate is almost always a slight
+// overestimate of the true error. berr[j] contains the componentwise relative
+// backward error of the j-th solution vector, that is, the smallest relative
+// change in any element of A or B that makes X[:,j] an exact solution.
+//
+// ferr and berr must have length at least nrhs, work must have length at
+// least 3*n and iwork must have length at least n, otherwise Dporfs will
+// panic.
+func (impl Implementation) Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 3*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return
+	}
+
+	const itmax = 5
+
+	upper := uplo == blas.Upper
+
+	// nz is the maximum number of nonzero elements in each row of A, plus 1.
+	nz := float64(n + 1)
+	eps := dlamchE
+	safmin := dlamchS
+	safe1 := nz * safmin
+	safe2 := safe1 / eps
+
+	bi := blas64.Implementation()
+	var isave [3]int
+	for j := 0; j < nrhs; j++ {
+		count := 1
+		lstres := 3.0
+		for {
+			// Loop until the stopping criterion is satisfied.
+
+			// Compute the residual R = B - A * X.
+			bi.Dcopy(n, b[j:], ldb, work[n:2*n], 1)
+			bi.Dsymv(uplo, n, -1, a, lda, x[j:], ldx, 1, work[n:2*n], 1)
+
+			// Compute the componentwise relative backward error from
+			// formula
+			//  max_i |R[i]| / (|A|*|X| + |B|)[i],
+			// where |A| denotes the matrix with elements of A replaced by
+			// their absolute values. If the i-th component of the
+			// denominator is less than safe2, then safe1 is added to the
+			// i-th components of the numerator and denominator before
+			// dividing.
+			for i := 0; i < n; i++ {
+				work[i] = math.Abs(b[i*ldb+j])
+			}
+			// Compute |A|*|X| + |B|.
+			if upper {
+				for k := 0; k < n; k++ {
+					var s float64
+					xk := math.Abs(x[k*ldx+j])
+					for i := 0; i < k; i++ {
+						aik := math.Abs(a[i*lda+k])
+						work[i] += aik * xk
+						s += aik * math.Abs(x[i*ldx+j])
+					}
+					work[k] += math.Abs(a[k*lda+k])*xk + s
+				}
+			} else {
+				for k := 0; k < n; k++ {
+					var s float64
+					xk := math.Abs(x[k*ldx+j])
+					work[k] += math.Abs(a[k*lda+k]) * xk
+					for i := k + 1; i < n; i++ {
+						aik := math.Abs(a[i*lda+k])
+						work[i] += aik * xk
+						s += aik * math.Abs(x[i*ldx+j])
+					}
+					work[k] += s
+				}
+			}
+			var s float64
+			for i := 0; i < n; i++ {
+				if work[i] > safe2 {
+					s = math.Max(s, math.Abs(work[n+i])/work[i])
+				} else {
+					s = math.Max(s, (math.Abs(work[n+i])+safe1)/(work[i]+safe1))
+				}
+			}
+			berr[j] = s
+
+			// Test the stopping criterion. Continue iterating if
+			//  1) the residual berr[j] is larger than machine epsilon, and
+			//  2) berr[j] decreased by at least a factor of 2 during the
+			//     last iteration, and
+			//  3) at most itmax iterations have been performed.
+			if berr[j] <= eps || 2*berr[j] > lstres || count > itmax {
+				break
+			}
+			// Update the solution and try again.
+			impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+			bi.Daxpy(n, 1, work[n:2*n], 1, x[j:], ldx)
+			lstres = berr[j]
+			count++
+		}
+
+		// Bound the error in the solution using the formula
+		//  norm(X - Xtrue) / norm(X) <= ferr = norm(|inv(A)| * (|R| + nz*eps*(|A|*|X|+|B|))) / norm(X),
+		// where norm(Z) is the magnitude of the largest component of Z,
+		// inv(A) is the inverse of A, |Z| denotes the vector with elements
+		// of Z replaced by their absolute values, R is the residual vector
+		// computed above and nz is the maximum number of nonzero elements
+		// in any row of A, plus 1.
+		//
+		// The 1-norm of |inv(A)|*diag(work[:n]) is estimated using Dlacn2,
+		// where work[i] = |R[i]| + nz*eps*(|A|*|X|+|B|)[i].
+		for i := 0; i < n; i++ {
+			if work[i] > safe2 {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i]
+			} else {
+				work[i] = math.Abs(work[n+i]) + nz*eps*work[i] + safe1
+			}
+		}
+		var kase int
+		ferr[j] = 0
+		isave = [3]int{}
+		for {
+			ferr[j], kase = impl.Dlacn2(n, work[2*n:3*n], work[n:2*n], iwork, ferr[j], kase, &isave)
+			if kase == 0 {
+				break
+			}
+			if kase == 1 {
+				// Multiply by diag(W)*inv(A^T).
+				impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+			} else {
+				// Multiply by inv(A)*diag(W).
+				for i := 0; i < n; i++ {
+					work[n+i] *= work[i]
+				}
+				impl.Dpotrs(uplo, n, 1, af, ldaf, work[n:2*n], 1)
+			}
+		}
+
+		// Normalize the error.
+		lstres = 0
+		for i := 0; i < n; i++ {
+			lstres = math.Max(lstres, math.Abs(x[i*ldx+j]))
+		}
+		if lstres != 0 {
+			ferr[j] /= lstres
+		}
+	}
+}
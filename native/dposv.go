@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dposv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are
+// n×nrhs matrices.
+//
+// The Cholesky decomposition is used to factor A as
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular. On return,
+// the factor U or L is stored in the corresponding triangle of a. The opposite
+// triangle of a is not referenced.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// Dposv returns false if the leading minor of some order of A is not positive
+// definite. In that case the factorization could not be completed and the
+// solution has not been computed.
+func (impl Implementation) Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the Cholesky factorization of A.
+	ok = impl.Dpotrf(uplo, n, a, lda)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.Dpotrs(uplo, n, nrhs, a, lda, b, ldb)
+	return true
+}
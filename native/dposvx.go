@@ -0,0 +1,203 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dposvx uses the Cholesky factorization to compute the solution to a real
+// system of linear equations
+//  A * X = B,
+// where A is an n×n symmetric positive definite matrix and X and B are n×nrhs
+// matrices. Error bounds on the solution and a condition estimate are also
+// provided.
+//
+// Dposvx performs the following steps:
+//
+//  1. If fact == lapack.EquilibrateAndFactor, real scaling factors are
+//     computed by Dpoequ to equilibrate the system:
+//      diag(s)*A*diag(s) * inv(diag(s))*X = diag(s)*B.
+//     Whether or not the system will be equilibrated depends on the scaling
+//     of the matrix A, but if equilibration is used, A is overwritten by
+//     diag(s)*A*diag(s) and B by diag(s)*B.
+//  2. If fact == lapack.NotFactored or lapack.EquilibrateAndFactor, the
+//     Cholesky decomposition is used to factor the matrix A (after
+//     equilibration if fact == lapack.EquilibrateAndFactor) as
+//      A = U^T * U  if uplo == blas.Upper,
+//      A = L * L^T  if uplo == blas.Lower,
+//     where U is an upper triangular matrix and L is lower triangular.
+//  3. If the leading minor of some order of A is not positive definite, then
+//     the routine returns with ok == false. Otherwise, the factored form of A
+//     is used to estimate the condition number of the matrix A. If the
+//     reciprocal of the condition number is less than machine precision,
+//     ok == false is returned as a warning, but the routine still goes on to
+//     solve for X and compute error bounds as described below.
+//  4. The system of equations is solved for X using the factored form of A.
+//  5. Iterative refinement is applied to improve the computed solution matrix
+//     and calculate error bounds and backward error estimates for it.
+//  6. If equilibration was used, the matrix X is premultiplied by diag(s) so
+//     that it solves the original system before equilibration.
+//
+// Only the triangle of a and af specified by uplo is referenced.
+//
+// If fact == lapack.Factored, af must contain on entry the triangular factor
+// from the Cholesky factorization of A as computed by Dpotrf, and equed
+// specifies the form of equilibration that was applied to A, which must then
+// contain the equilibrated matrix. equed must be either lapack.NoEquilibration
+// or lapack.SymmetricEquilibration, and in the latter case the scale factors
+// in s must be positive. If fact is not lapack.Factored, equed is ignored, and
+// on return af contains the triangular factor of the (equilibrated) matrix A.
+//
+// s must have length at least n, and on return contains the scale factors
+// for A. It is only referenced if equilibration is used.
+//
+// On entry, b contains the n×nrhs right hand side matrix B. On return, b is
+// overwritten by diag(s)*B if equilibration was used, and is not modified
+// otherwise. x is an n×nrhs matrix that on return contains the solution matrix
+// X of the original system.
+//
+// ferr and berr must have length at least nrhs and on return contain the
+// estimated forward error bound and the componentwise relative backward error
+// of each solution vector, respectively. See Dporfs for details.
+//
+// work must have length at least 3*n and iwork must have length at least n,
+// otherwise Dposvx will panic.
+//
+// Dposvx returns the form of equilibration that was applied to A in equedOut
+// and the estimate of the reciprocal condition number of A after
+// equilibration in rcond. If the factorization fails because A is not
+// positive definite, rcond is zero, and X and the error bounds are not
+// computed.
+func (impl Implementation) Dposvx(fact lapack.FactorizationType, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed lapack.EquilibrationType, s, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond float64, ok bool) {
+	nofact := fact == lapack.NotFactored
+	equil := fact == lapack.EquilibrateAndFactor
+	switch {
+	case !nofact && !equil && fact != lapack.Factored:
+		panic("lapack: bad FactorizationType")
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, af, ldaf)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(s) < n:
+		panic(badSlice)
+	case len(ferr) < nrhs:
+		panic("lapack: ferr has insufficient length")
+	case len(berr) < nrhs:
+		panic("lapack: berr has insufficient length")
+	case len(work) < 3*n:
+		panic(badWork)
+	case len(iwork) < n:
+		panic(badWork)
+	}
+
+	var rcequ bool
+	var scond float64
+	if nofact || equil {
+		equed = lapack.NoEquilibration
+	} else {
+		switch equed {
+		default:
+			panic("lapack: bad EquilibrationType")
+		case lapack.NoEquilibration:
+		case lapack.SymmetricEquilibration:
+			rcequ = true
+		}
+		if rcequ {
+			smlnum := dlamchS
+			bignum := 1 / smlnum
+			smin := bignum
+			var smax float64
+			for _, v := range s[:n] {
+				smin = math.Min(smin, v)
+				smax = math.Max(smax, v)
+			}
+			if smin <= 0 {
+				panic("lapack: non-positive scale factor")
+			}
+			scond = 1
+			if n > 0 {
+				scond = math.Max(smin, smlnum) / math.Min(smax, bignum)
+			}
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			ferr[j] = 0
+			berr[j] = 0
+		}
+		return equed, 1, true
+	}
+
+	if equil {
+		// Compute row and column scalings to equilibrate the matrix A.
+		var amax float64
+		var eqok bool
+		scond, amax, eqok = impl.Dpoequ(n, a, lda, s)
+		if eqok {
+			// Equilibrate the matrix.
+			equed = impl.Dlaqsy(uplo, n, a, lda, s, scond, amax)
+			rcequ = equed == lapack.SymmetricEquilibration
+		}
+	}
+
+	// Scale the right hand side.
+	if rcequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				b[i*ldb+j] *= s[i]
+			}
+		}
+	}
+
+	if nofact || equil {
+		// Compute the Cholesky factorization of A.
+		impl.Dlacpy(uplo, n, n, a, lda, af, ldaf)
+		if !impl.Dpotrf(uplo, n, af, ldaf) {
+			return equed, 0, false
+		}
+	}
+
+	// Compute the norm of the matrix A and the reciprocal of its
+	// condition number.
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a, lda, work)
+	rcond = impl.Dpocon(uplo, n, af, ldaf, anorm, work, iwork)
+
+	// Compute the solution matrix X.
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dpotrs(uplo, n, nrhs, af, ldaf, x, ldx)
+
+	// Use iterative refinement to improve the computed solution and
+	// compute error bounds and backward error estimates for it.
+	impl.Dporfs(uplo, n, nrhs, a, lda, af, ldaf, b, ldb, x, ldx, ferr, berr, work, iwork)
+
+	// Transform the solution matrix X to a solution of the original system.
+	if rcequ {
+		for i := 0; i < n; i++ {
+			for j := 0; j < nrhs; j++ {
+				x[i*ldx+j] *= s[i]
+			}
+		}
+		for j := 0; j < nrhs; j++ {
+			ferr[j] /= scond
+		}
+	}
+
+	// Set ok to false if the matrix is singular to working precision.
+	return equed, rcond, rcond >= dlamchE
+}
@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dpotri computes the inverse of a real symmetric positive definite matrix A
+// using its Cholesky factorization.
+//
+// On entry, a contains the triangular factor U or L from the Cholesky
+// factorization A = U^T*U or A = L*L^T, as computed by Dpotrf.
+// On return, a contains the upper or lower triangle of the (symmetric)
+// inverse of A, overwriting the input factor U or L.
+//
+// Dpotri returns whether the matrix A is invertible. If Dpotri returns false,
+// a diagonal element of the factor is zero and the inverse could not be
+// computed.
+func (impl Implementation) Dpotri(uplo blas.Uplo, n int, a []float64, lda int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Invert the triangular Cholesky factor U or L.
+	ok = impl.Dtrtri(uplo, blas.NonUnit, n, a, lda)
+	if !ok {
+		return false
+	}
+
+	// Form inv(U)*inv(U)^T or inv(L)^T*inv(L).
+	impl.Dlauum(uplo, n, a, lda)
+	return true
+}
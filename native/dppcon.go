@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dppcon estimates the reciprocal of the condition number of an n×n symmetric
+// positive definite matrix A stored in packed format given the Cholesky
+// factorization of A computed by Dpptrf. The condition number computed is
+// based on the 1-norm and the ∞-norm.
+//
+// ap contains the factor U or L of the factorization of A as returned by
+// Dpptrf. See the documentation for Dpptrf for a description of the packed
+// storage format of ap.
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least 3*n and Dppcon will panic
+// otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dppcon will panic
+// otherwise.
+func (impl Implementation) Dppcon(uplo blas.Uplo, n int, ap []float64, anorm float64, work []float64, iwork []int) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	bi := blas64.Implementation()
+	var rcond, ainvnm float64
+	var kase int
+	var normin bool
+	var isave [3]int
+	smlnum := dlamchS
+
+	// Estimate the 1-norm of the inverse.
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			if ainvnm != 0 {
+				rcond = (1 / ainvnm) / anorm
+			}
+			return rcond
+		}
+		var sl, su float64
+		if uplo == blas.Upper {
+			// Multiply by inv(U^T).
+			sl = impl.Dlatps(blas.Upper, blas.Trans, blas.NonUnit, normin, n, ap, work, work[2*n:])
+			normin = true
+			// Multiply by inv(U).
+			su = impl.Dlatps(blas.Upper, blas.NoTrans, blas.NonUnit, normin, n, ap, work, work[2*n:])
+		} else {
+			// Multiply by inv(L).
+			sl = impl.Dlatps(blas.Lower, blas.NoTrans, blas.NonUnit, normin, n, ap, work, work[2*n:])
+			normin = true
+			// Multiply by inv(L^T).
+			su = impl.Dlatps(blas.Lower, blas.Trans, blas.NonUnit, normin, n, ap, work, work[2*n:])
+		}
+		// Multiply by 1/scale if doing so will not cause overflow.
+		scale := sl * su
+		if scale != 1 {
+			ix := bi.Idamax(n, work, 1)
+			if scale == 0 || scale < math.Abs(work[ix])*smlnum {
+				return rcond
+			}
+			impl.Drscl(n, scale, work, 1)
+		}
+	}
+}
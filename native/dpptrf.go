@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpptrf computes the Cholesky factorization of an n×n symmetric positive
+// definite matrix A stored in packed format. The factorization has the form
+//  A = U^T * U  if uplo == blas.Upper,
+//  A = L * L^T  if uplo == blas.Lower,
+// where U is an upper triangular matrix and L is lower triangular.
+//
+// The matrix A is stored in ap in row-major packed format as used by
+// blas64.SymmetricPacked, that is if uplo == blas.Upper, the rows of the upper
+// triangle are stored one after another and the element A[i,j] for i <= j is
+// stored in ap[i*n-i*(i-1)/2+j-i], and if uplo == blas.Lower, the rows of the
+// lower triangle are stored one after another and the element A[i,j] for
+// i >= j is stored in ap[i*(i+1)/2+j]. For example, when n = 4 the elements
+// are stored as
+//  uplo == blas.Upper        uplo == blas.Lower
+//   0  1  2  3                0
+//      4  5  6                1  2
+//         7  8                3  4  5
+//            9                6  7  8  9
+// ap must have length at least n*(n+1)/2, otherwise Dpptrf will panic. On
+// return, ap contains the factor U or L in the same format.
+//
+// Dpptrf returns whether the matrix A is positive definite. If ok is false,
+// the leading minor of some order is not positive definite and the
+// factorization could not be completed.
+func (Implementation) Dpptrf(uplo blas.Uplo, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	bi := blas64.Implementation()
+	if uplo == blas.Upper {
+		// Compute the Cholesky factorization A = U^T * U. jj is the index
+		// of the diagonal element A[j,j] in ap.
+		var jj int
+		for j := 0; j < n; j++ {
+			// Compute U[j,j] and test for non-positive-definiteness.
+			ajj := ap[jj]
+			if ajj <= 0 || math.IsNaN(ajj) {
+				return false
+			}
+			ajj = math.Sqrt(ajj)
+			ap[jj] = ajj
+			// Compute the elements j+1:n of row j and update the trailing
+			// submatrix.
+			if j < n-1 {
+				bi.Dscal(n-j-1, 1/ajj, ap[jj+1:], 1)
+				bi.Dspr(blas.Upper, n-j-1, -1, ap[jj+1:], 1, ap[jj+n-j:])
+			}
+			jj += n - j
+		}
+		return true
+	}
+
+	// Compute the Cholesky factorization A = L * L^T.
+	for j := 0; j < n; j++ {
+		// jc is the index of the element A[j,0] and jj is the index of the
+		// diagonal element A[j,j] in ap.
+		jc := j * (j + 1) / 2
+		jj := jc + j
+		// Compute the elements 0:j of row j.
+		if j > 0 {
+			bi.Dtpsv(blas.Lower, blas.NoTrans, blas.NonUnit, j, ap, ap[jc:], 1)
+		}
+		// Compute L[j,j] and test for non-positive-definiteness.
+		ajj := ap[jj] - bi.Ddot(j, ap[jc:], 1, ap[jc:], 1)
+		if ajj <= 0 || math.IsNaN(ajj) {
+			ap[jj] = ajj
+			return false
+		}
+		ap[jj] = math.Sqrt(ajj)
+	}
+	return true
+}
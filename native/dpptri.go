@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpptri computes the inverse of an n×n symmetric positive definite matrix A
+// stored in packed format, using its Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// as computed by Dpptrf.
+//
+// On entry, ap contains the factor U or L in the packed format described in
+// the documentation of Dpptrf. On return, ap contains the upper or lower
+// triangle of the symmetric inverse of A in the same format. ap must have
+// length at least n*(n+1)/2, otherwise Dpptri will panic.
+//
+// Dpptri returns whether the factor U or L is nonsingular. If ok is false, A
+// is singular and the inverse has not been computed.
+func (impl Implementation) Dpptri(uplo blas.Uplo, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Invert the triangular Cholesky factor U or L.
+	ok = impl.Dtptri(uplo, blas.NonUnit, n, ap)
+	if !ok {
+		return false
+	}
+
+	bi := blas64.Implementation()
+	if uplo == blas.Upper {
+		// Compute the product inv(U) * inv(U)^T. jj is the index of the
+		// diagonal element A[j,j] in ap.
+		var jj int
+		for j := 0; j < n; j++ {
+			jjn := jj + n - j
+			ap[jj] = bi.Ddot(n-j, ap[jj:], 1, ap[jj:], 1)
+			if j < n-1 {
+				bi.Dtpmv(blas.Upper, blas.NoTrans, blas.NonUnit, n-j-1, ap[jjn:], ap[jj+1:], 1)
+			}
+			jj = jjn
+		}
+		return true
+	}
+
+	// Compute the product inv(L)^T * inv(L).
+	for j := 0; j < n; j++ {
+		// jc is the index of the element A[j,0] in ap.
+		jc := j * (j + 1) / 2
+		if j > 0 {
+			bi.Dspr(blas.Lower, j, 1, ap[jc:], 1, ap)
+		}
+		bi.Dscal(j+1, ap[jc+j], ap[jc:], 1)
+	}
+	return true
+}
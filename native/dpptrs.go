@@ -0,0 +1,59 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dpptrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite matrix and B is an n×nrhs matrix, using the
+// Cholesky factorization
+//  A = U^T*U  if uplo == blas.Upper,
+//  A = L*L^T  if uplo == blas.Lower,
+// computed by Dpptrf. ap contains the factor U or L in the packed format
+// described in the documentation of Dpptrf, and must have length at least
+// n*(n+1)/2, otherwise Dpptrs will panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (Implementation) Dpptrs(uplo blas.Uplo, n, nrhs int, ap []float64, b []float64, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+	if uplo == blas.Upper {
+		// Solve U^T * U * X = B where A = U^T * U.
+		for i := 0; i < nrhs; i++ {
+			// Solve U^T * X = B, overwriting B with X.
+			bi.Dtpsv(blas.Upper, blas.Trans, blas.NonUnit, n, ap, b[i:], ldb)
+			// Solve U * X = B, overwriting B with X.
+			bi.Dtpsv(blas.Upper, blas.NoTrans, blas.NonUnit, n, ap, b[i:], ldb)
+		}
+		return
+	}
+	// Solve L * L^T * X = B where A = L * L^T.
+	for i := 0; i < nrhs; i++ {
+		// Solve L * X = B, overwriting B with X.
+		bi.Dtpsv(blas.Lower, blas.NoTrans, blas.NonUnit, n, ap, b[i:], ldb)
+		// Solve L^T * X = B, overwriting B with X.
+		bi.Dtpsv(blas.Lower, blas.Trans, blas.NonUnit, n, ap, b[i:], ldb)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dptcon computes the reciprocal of the condition number (in the 1-norm) of
+// an n×n symmetric positive definite tridiagonal matrix A using the
+// factorization A = L*D*L^T or A = U^T*D*U computed by Dpttrf.
+//
+// The reciprocal of the condition number is computed as
+//  rcond = 1 / (anorm * |inv(A)|)
+// and |inv(A)| is computed by a direct method.
+//
+// d and e contain the factorization of A as returned by Dpttrf. d must have
+// length at least n and e must have length at least n-1, otherwise Dptcon
+// will panic.
+//
+// anorm is the 1-norm of the original matrix A.
+//
+// work is a temporary data slice of length at least n and Dptcon will panic
+// otherwise.
+func (Implementation) Dptcon(n int, d, e []float64, anorm float64, work []float64) float64 {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	} else if anorm == 0 {
+		return 0
+	}
+
+	// Check that d[0:n] is positive.
+	for i := 0; i < n; i++ {
+		if d[i] <= 0 {
+			return 0
+		}
+	}
+
+	// Solve M(A) * x = e, where M(A) = (m[i,j]) is given by
+	//  m[i,j] =  |A[i,j]|, i == j,
+	//  m[i,j] = -|A[i,j]|, i != j,
+	// and e = [1,1,...,1]^T. Note M(A) = M(L)*D*M(L)^T.
+
+	// Solve M(L) * b = e.
+	work[0] = 1
+	for i := 1; i < n; i++ {
+		work[i] = 1 + work[i-1]*math.Abs(e[i-1])
+	}
+
+	// Solve D * M(L)^T * x = b.
+	work[n-1] /= d[n-1]
+	for i := n - 2; i >= 0; i-- {
+		work[i] = work[i]/d[i] + work[i+1]*math.Abs(e[i])
+	}
+
+	// Compute ainvnm = max(x[i]), 0 <= i < n.
+	ix := blas64.Implementation().Idamax(n, work, 1)
+	ainvnm := math.Abs(work[ix])
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,47 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dptsv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, and X and
+// B are n×nrhs matrices.
+//
+// A is factored as A = L*D*L^T, and the factored form of A is then used to
+// solve the system of equations. On entry, d and e contain the n diagonal and
+// n-1 subdiagonal elements of A, respectively. On return, d and e contain the
+// factorization of A as computed by Dpttrf. d must have length at least n and
+// e must have length at least n-1, otherwise Dptsv will panic.
+//
+// On entry, b contains the right hand side matrix B. On return, if ok is true,
+// b contains the solution matrix X.
+//
+// Dptsv returns whether the factorization was successfully completed. If ok
+// is false, the leading principal minor of some order is not positive
+// definite and the solution has not been computed.
+func (impl Implementation) Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Compute the L*D*L^T factorization of A.
+	ok = impl.Dpttrf(n, d, e)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.Dpttrs(n, nrhs, d, e, b, ldb)
+	return true
+}
@@ -0,0 +1,50 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dpttrf computes the L*D*L^T factorization of an n×n symmetric positive
+// definite tridiagonal matrix A. The factorization may also be regarded as
+// having the form A = U^T*D*U.
+//
+// On entry, d and e contain the n diagonal and n-1 subdiagonal elements of A,
+// respectively. On return, d contains the n diagonal elements of the diagonal
+// matrix D and e contains the n-1 subdiagonal elements of the unit lower
+// bidiagonal factor L. e can also be regarded as the superdiagonal of the unit
+// upper bidiagonal factor U from the U^T*D*U factorization of A. d must have
+// length at least n and e must have length at least n-1, otherwise Dpttrf will
+// panic.
+//
+// Dpttrf returns whether the factorization was successfully completed. If ok
+// is false, the leading principal minor of some order is not positive
+// definite and the factorization could not be completed.
+func (Implementation) Dpttrf(n int, d, e []float64) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the L*D*L^T factorization of A.
+	for i := 0; i < n-1; i++ {
+		// Drop out of the loop if d[i] <= 0, since A is then not positive
+		// definite.
+		if d[i] <= 0 {
+			return false
+		}
+		ei := e[i]
+		e[i] = ei / d[i]
+		d[i+1] -= e[i] * ei
+	}
+	return d[n-1] > 0
+}
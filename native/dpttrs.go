@@ -0,0 +1,54 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dpttrs solves a system of linear equations
+//  A * X = B
+// where A is an n×n symmetric positive definite tridiagonal matrix, using the
+// L*D*L^T factorization of A computed by Dpttrf. d and e contain the
+// factorization of A as returned by Dpttrf. d must have length at least n and
+// e must have length at least n-1, otherwise Dpttrs will panic.
+//
+// On entry, b contains the n×nrhs right-hand side matrix B, on return it
+// contains the solution matrix X.
+func (Implementation) Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	checkMatrix(n, nrhs, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Solve L * X = B, overwriting B with X.
+	for i := 1; i < n; i++ {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for j := range bi {
+			bi[j] -= b[(i-1)*ldb+j] * e[i-1]
+		}
+	}
+	// Solve D * L^T * X = B, overwriting B with X.
+	bn := b[(n-1)*ldb : (n-1)*ldb+nrhs]
+	for j := range bn {
+		bn[j] /= d[n-1]
+	}
+	for i := n - 2; i >= 0; i-- {
+		bi := b[i*ldb : i*ldb+nrhs]
+		for j := range bi {
+			bi[j] = bi[j]/d[i] - b[(i+1)*ldb+j]*e[i]
+		}
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsbev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric band matrix A with kd super- or subdiagonals.
+//
+// On entry, ab contains the upper or lower triangle of A in the row-major band
+// format described in the documentation of Dpbtrf. On return, ab is
+// overwritten by values generated during the reduction to tridiagonal form.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsbev will panic otherwise.
+//
+// If jobz == lapack.ComputeEV, z contains the orthonormal eigenvectors of A on
+// return, with the i-th column of z holding the eigenvector associated with
+// w[i]. z must be an n×n matrix with stride ldz. If jobz == lapack.None, z is
+// not referenced.
+//
+// work is temporary storage and must have length at least max(1,3*n-2),
+// otherwise Dsbev will panic.
+//
+// Dsbev returns whether the computation succeeded. If ok is false, the
+// QR algorithm failed to converge and some of the eigenvalues, and
+// eigenvectors if requested, have not been computed.
+func (impl Implementation) Dsbev(jobz lapack.EVJob, uplo blas.Uplo, n, kd int, ab []float64, ldab int, w, z []float64, ldz int, work []float64) (ok bool) {
+	switch {
+	case jobz != lapack.None && jobz != lapack.ComputeEV:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	if len(w) < n {
+		panic(badW)
+	}
+	wantz := jobz == lapack.ComputeEV
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	if len(work) < max(1, 3*n-2) {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	diag := 0
+	if uplo == blas.Lower {
+		diag = kd
+	}
+	if n == 1 {
+		w[0] = ab[diag]
+		if wantz {
+			z[0] = 1
+		}
+		return true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansb(lapack.MaxAbs, uplo, n, kd, ab, ldab, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	bi := blas64.Implementation()
+	if scaled {
+		for i := 0; i < n; i++ {
+			if uplo == blas.Upper {
+				bi.Dscal(min(kd+1, n-i), sigma, ab[i*ldab:], 1)
+			} else {
+				l := min(kd, i)
+				bi.Dscal(l+1, sigma, ab[i*ldab+kd-l:], 1)
+			}
+		}
+	}
+
+	// Reduce the band matrix to tridiagonal form.
+	var inde int
+	indwrk := inde + n
+	vect := lapack.EVComp(lapack.None)
+	if wantz {
+		vect = lapack.TridiagEV
+	}
+	impl.Dsbtrd(vect, uplo, n, kd, ab, ldab, w, work[inde:], z, ldz)
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, call Dsteqr.
+	if !wantz {
+		ok = impl.Dsterf(n, w, work[inde:])
+	} else {
+		ok = impl.Dsteqr(lapack.OriginalEV, n, w, work[inde:], z, ldz, work[indwrk:])
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	return ok
+}
@@ -0,0 +1,160 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsbtrd reduces an n×n symmetric band matrix A with kd super- or
+// subdiagonals to symmetric tridiagonal form T by an orthogonal similarity
+// transformation
+//  Q^T * A * Q = T.
+//
+// On entry, ab contains the upper or lower triangle of A in the row-major band
+// format described in the documentation of Dpbtrf. On return, the diagonal
+// elements of ab are overwritten by the diagonal elements of T, and if kd > 0,
+// the elements of the first superdiagonal (if uplo == blas.Upper) or the first
+// subdiagonal (if uplo == blas.Lower) are overwritten by the off-diagonal
+// elements of T. The rest of the band of ab is set to zero.
+//
+// The diagonal elements of T are stored in d, which must have length at least
+// n, and the off-diagonal elements of T are stored in e, which must have
+// length at least n-1. Dsbtrd will panic if these conditions are not met.
+//
+// The bandwidth of A is reduced one diagonal at a time by Givens rotations,
+// and each bulge created outside the band is chased off the bottom of the
+// matrix. vect specifies whether the rotations are accumulated into q:
+//  vect == lapack.None: q is not referenced,
+//  vect == lapack.OriginalEV: on entry q contains an n×n orthogonal matrix X
+//   and on return q contains X*Q,
+//  vect == lapack.TridiagEV: q is initialized to the identity and on return
+//   contains the orthogonal matrix Q.
+// If q is referenced, it must be an n×n matrix with stride ldq, otherwise
+// Dsbtrd will panic.
+//
+// Dsbtrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsbtrd(vect lapack.EVComp, uplo blas.Uplo, n, kd int, ab []float64, ldab int, d, e, q []float64, ldq int) {
+	switch {
+	case vect != lapack.None && vect != lapack.OriginalEV && vect != lapack.TridiagEV:
+		panic(badEVComp)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	}
+	checkSymBandMatrix(n, kd, ab, ldab)
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	wantq := vect != lapack.None
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	if vect == lapack.TridiagEV {
+		impl.Dlaset(blas.All, n, n, 0, 1, q, ldq)
+	}
+
+	bi := blas64.Implementation()
+
+	// The element A[i,j] in the lower triangle of A, that is with i >= j, is
+	// stored in ab[idx(i,j)]. Moving one row down in the lower triangle
+	// changes the index by incRow, and moving one column to the right
+	// changes it by incCol.
+	var idx func(i, j int) int
+	var incRow, incCol int
+	if uplo == blas.Upper {
+		idx = func(i, j int) int { return j*ldab + i - j }
+		incRow, incCol = 1, ldab-1
+	} else {
+		idx = func(i, j int) int { return i*ldab + kd + j - i }
+		incRow, incCol = ldab-1, 1
+	}
+
+	// Reduce the bandwidth from kb to kb-1 in each sweep.
+	for kb := min(kd, n-1); kb >= 2; kb-- {
+		for j := 0; j < n-kb; j++ {
+			// Annihilate the element A[j+kb,j] by a rotation in the
+			// plane (j+kb-1,j+kb). The rotation creates a bulge at
+			// distance kb+1 from the diagonal which is chased down by
+			// further rotations in planes kb rows apart.
+			p := j + kb
+			x := ab[idx(p, j)]
+			if x == 0 {
+				continue
+			}
+			jj := j
+			for {
+				// Generate the rotation that annihilates the element
+				// x = A[p,jj] against A[p-1,jj].
+				c, s, r := impl.Dlartg(ab[idx(p-1, jj)], x)
+				ab[idx(p-1, jj)] = r
+				if p-jj <= kb {
+					ab[idx(p, jj)] = 0
+				}
+
+				// Apply the rotation from the left to the rows p-1 and p
+				// of the columns between jj and p-1.
+				if cnt := p - jj - 2; cnt > 0 {
+					bi.Drot(cnt, ab[idx(p-1, jj+1):], incCol, ab[idx(p, jj+1):], incCol, c, s)
+				}
+
+				// Apply the rotation from both sides to the diagonal block.
+				a11 := ab[idx(p-1, p-1)]
+				a21 := ab[idx(p, p-1)]
+				a22 := ab[idx(p, p)]
+				cs := c * s
+				ab[idx(p-1, p-1)] = c*c*a11 + 2*cs*a21 + s*s*a22
+				ab[idx(p, p)] = s*s*a11 - 2*cs*a21 + c*c*a22
+				ab[idx(p, p-1)] = (c*c-s*s)*a21 + cs*(a22-a11)
+
+				// Apply the rotation from the right to the columns p-1 and
+				// p of the rows below the diagonal block.
+				if cnt := min(n-1, p-1+kb) - p; cnt > 0 {
+					bi.Drot(cnt, ab[idx(p+1, p-1):], incRow, ab[idx(p+1, p):], incRow, c, s)
+				}
+
+				if wantq {
+					// Accumulate the rotation into Q.
+					bi.Drot(n, q[p-1:], ldq, q[p:], ldq, c, s)
+				}
+
+				// Create the bulge at A[p+kb,p-1].
+				if p+kb >= n {
+					break
+				}
+				t := ab[idx(p+kb, p)]
+				x = s * t
+				ab[idx(p+kb, p)] = c * t
+				if x == 0 {
+					break
+				}
+				jj = p - 1
+				p += kb
+			}
+		}
+	}
+
+	// Copy the diagonal and off-diagonal elements of T into d and e.
+	for i := 0; i < n; i++ {
+		d[i] = ab[idx(i, i)]
+	}
+	for i := 0; i < n-1; i++ {
+		if kd > 0 {
+			e[i] = ab[idx(i+1, i)]
+		} else {
+			e[i] = 0
+		}
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsfrk performs one of the symmetric rank-k operations
+//  C = alpha * A * A^T + beta * C  if trans == blas.NoTrans,
+//  C = alpha * A^T * A + beta * C  if trans == blas.Trans,
+// where alpha and beta are scalars, C is an n×n symmetric matrix stored in
+// rectangular full packed (RFP) format and A is an n×k matrix if
+// trans == blas.NoTrans and a k×n matrix otherwise.
+//
+// c contains the upper or lower triangle of C in the RFP format described in
+// the documentation of Dtrttf. transr must be blas.NoTrans or blas.Trans, and
+// c must have length at least n*(n+1)/2, otherwise Dsfrk will panic.
+func (Implementation) Dsfrk(transr blas.Transpose, uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case trans != blas.NoTrans && trans != blas.Trans:
+		panic(badTrans)
+	case n < 0:
+		panic(nLT0)
+	case k < 0:
+		panic(kLT0)
+	case len(c) < n*(n+1)/2:
+		panic(badARF)
+	}
+	if n > 0 && k > 0 {
+		if trans == blas.NoTrans {
+			checkMatrix(n, k, a, lda)
+		} else {
+			checkMatrix(k, n, a, lda)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 || ((alpha == 0 || k == 0) && beta == 1) {
+		return
+	}
+
+	bi := blas64.Implementation()
+	nt := n * (n + 1) / 2
+	if beta == 0 {
+		// Set C to zero explicitly, Dsyrk of the native BLAS does not
+		// overwrite the lower triangle of C when beta is zero and A is
+		// transposed.
+		for i := 0; i < nt; i++ {
+			c[i] = 0
+		}
+		if alpha == 0 || k == 0 {
+			return
+		}
+	} else if alpha == 0 || k == 0 {
+		bi.Dscal(nt, beta, c, 1)
+		return
+	}
+	if n == 1 {
+		bi.Dsyrk(uplo, trans, 1, k, alpha, a, lda, beta, c, 1)
+		return
+	}
+
+	// Partition C and A as
+	//  C = [ C11 C21^T ],  A = [ A1 ]  or  A = [ A1 A2 ],
+	//      [ C21 C22   ]       [ A2 ]
+	// so that, if trans == blas.NoTrans,
+	//  C11 = alpha * A1 * A1^T + beta * C11,
+	//  C21 = alpha * A2 * A1^T + beta * C21,
+	//  C22 = alpha * A2 * A2^T + beta * C22,
+	// and analogously if trans == blas.Trans.
+	l := rfpBlocks(transr, uplo, n)
+	n1, n2, ldr := l.n1, l.n2, l.ldr
+	c1 := c[l.off1:]
+	c2 := c[l.off2:]
+	s := c[l.offS:]
+
+	a1 := a
+	var a2 []float64
+	opA, opB := blas.NoTrans, blas.Trans
+	if trans == blas.NoTrans {
+		a2 = a[n1*lda:]
+	} else {
+		a2 = a[n1:]
+		opA, opB = blas.Trans, blas.NoTrans
+	}
+
+	bi.Dsyrk(l.uplo1, trans, n1, k, alpha, a1, lda, beta, c1, ldr)
+	bi.Dsyrk(l.uplo2, trans, n2, k, alpha, a2, lda, beta, c2, ldr)
+	if l.s21 {
+		bi.Dgemm(opA, opB, n2, n1, k, alpha, a2, lda, a1, lda, beta, s, ldr)
+	} else {
+		bi.Dgemm(opA, opB, n1, n2, k, alpha, a1, lda, a2, lda, beta, s, ldr)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsgesv computes the solution to a real system of linear equations
+//  A * X = B,
+// where A is an n×n matrix and X and B are n×nrhs matrices, using mixed
+// precision iterative refinement.
+//
+// Dsgesv first attempts to factorize the matrix in single precision and use
+// this factorization within an iterative refinement procedure to produce a
+// solution with double precision normwise backward error quality. If the
+// approach fails, the method switches to a double precision factorization and
+// solve.
+//
+// The iterative refinement process is stopped if iter > 30 or
+//  ||R||_∞ < sqrt(n) * ||X||_∞ * ||A||_∞ * eps,
+// for all columns of the residual matrix R = B - A*X, where eps is the double
+// precision machine epsilon. The value 30 for the maximum number of
+// iterations is consistent with LAPACK.
+//
+// On entry, b contains the right hand side matrix B and is not modified. On
+// return, x contains the n×nrhs solution matrix X. If the iterative refinement
+// was successful, a is not modified and ipiv contains the pivot indices of the
+// single precision LU factorization of A. Otherwise, a and ipiv contain the
+// double precision LU factorization of A as computed by Dgetrf. ipiv must have
+// length at least n.
+//
+// work must have length at least n*nrhs and swork must have length at least
+// n*(n+nrhs), otherwise Dsgesv will panic.
+//
+// Dsgesv returns the number of iterations in iter:
+//  iter >= 0: iterative refinement converged after iter iterations,
+//  iter == -2: an element of A or B or of an intermediate result overflowed
+//              when converted to single precision,
+//  iter == -3: the single precision LU factorization failed because the
+//              matrix is singular in single precision,
+//  iter == -31: iterative refinement failed to converge.
+// If iter is negative, the double precision factorization was used. ok is
+// false if the double precision factorization found that A is exactly
+// singular, in which case the solution has not been computed.
+func (impl Implementation) Dsgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool) {
+	switch {
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	checkMatrix(n, nrhs, x, ldx)
+	switch {
+	case len(ipiv) < n:
+		panic(badIpiv)
+	case len(work) < n*nrhs:
+		panic(badWork)
+	case len(swork) < n*(n+nrhs):
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return 0, true
+	}
+
+	const (
+		itermax = 30
+		bwdmax  = 1.0
+	)
+
+	// The residual is stored in work as an n×nrhs matrix, the single
+	// precision copy of A in swork[:n*n] and the single precision copy of
+	// the right hand side or of the residual in swork[n*n:].
+	ldw := nrhs
+	sa := swork[:n*n]
+	ldsa := n
+	sx := swork[n*n:]
+	ldsx := nrhs
+
+	anrm := impl.Dlange(lapack.MaxRowSum, n, n, a, lda, nil)
+	cte := anrm * dlamchE * math.Sqrt(float64(n)) * bwdmax
+
+	bi := blas64.Implementation()
+	// converged reports whether all columns of the residual are small
+	// enough.
+	converged := func() bool {
+		for j := 0; j < nrhs; j++ {
+			xnrm := math.Abs(x[bi.Idamax(n, x[j:], ldx)*ldx+j])
+			rnrm := math.Abs(work[bi.Idamax(n, work[j:], ldw)*ldw+j])
+			if rnrm > xnrm*cte {
+				return false
+			}
+		}
+		return true
+	}
+	// residual computes R = B - A*X.
+	residual := func() {
+		impl.Dlacpy(blas.All, n, nrhs, b, ldb, work, ldw)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, nrhs, n, -1, a, lda, x, ldx, 1, work, ldw)
+	}
+
+	// Convert B and A to single precision.
+	if !impl.Dlag2s(n, nrhs, b, ldb, sx, ldsx) {
+		iter = -2
+		goto doublePrecision
+	}
+	if !impl.Dlag2s(n, n, a, lda, sa, ldsa) {
+		iter = -2
+		goto doublePrecision
+	}
+	// Compute the LU factorization of SA.
+	if !impl.Sgetrf(n, n, sa, ldsa, ipiv) {
+		iter = -3
+		goto doublePrecision
+	}
+	// Solve the system SA*SX = SB and convert SX to double precision.
+	impl.Sgetrs(blas.NoTrans, n, nrhs, sa, ldsa, ipiv, sx, ldsx)
+	impl.Slag2d(n, nrhs, sx, ldsx, x, ldx)
+
+	residual()
+	if converged() {
+		// The solution is acceptable without refinement.
+		return 0, true
+	}
+
+	for iiter := 1; iiter <= itermax; iiter++ {
+		// Convert R to single precision and solve SA*SX = SR.
+		if !impl.Dlag2s(n, nrhs, work, ldw, sx, ldsx) {
+			iter = -2
+			goto doublePrecision
+		}
+		impl.Sgetrs(blas.NoTrans, n, nrhs, sa, ldsa, ipiv, sx, ldsx)
+
+		// Convert SX back to double precision and update the current
+		// iterate.
+		impl.Slag2d(n, nrhs, sx, ldsx, work, ldw)
+		for i := 0; i < n; i++ {
+			bi.Daxpy(nrhs, 1, work[i*ldw:], 1, x[i*ldx:], 1)
+		}
+
+		residual()
+		if converged() {
+			return iiter, true
+		}
+	}
+	// Iterative refinement failed to converge in itermax iterations.
+	iter = -itermax - 1
+
+doublePrecision:
+	// Single precision iterative refinement failed to converge to a
+	// satisfactory solution, so proceed with double precision.
+	if !impl.Dgetrf(n, n, a, lda, ipiv) {
+		return iter, false
+	}
+	impl.Dlacpy(blas.All, n, nrhs, b, ldb, x, ldx)
+	impl.Dgetrs(blas.NoTrans, n, nrhs, a, lda, ipiv, x, ldx)
+	return iter, true
+}
@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dspev computes all eigenvalues and, optionally, the eigenvectors of an n×n
+// symmetric matrix A stored in packed format.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On return, ap is
+// overwritten by values generated during the reduction to tridiagonal form.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dspev will panic otherwise.
+//
+// If jobz == lapack.ComputeEV, z contains the orthonormal eigenvectors of A on
+// return, with the i-th column of z holding the eigenvector associated with
+// w[i]. z must be an n×n matrix with stride ldz. If jobz == lapack.None, z is
+// not referenced.
+//
+// work is temporary storage and must have length at least 3*n, otherwise
+// Dspev will panic.
+//
+// Dspev returns whether the computation succeeded. If ok is false, the
+// QR algorithm failed to converge and some of the eigenvalues, and
+// eigenvectors if requested, have not been computed.
+func (impl Implementation) Dspev(jobz lapack.EVJob, uplo blas.Uplo, n int, ap, w, z []float64, ldz int, work []float64) (ok bool) {
+	switch {
+	case jobz != lapack.None && jobz != lapack.ComputeEV:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	wantz := jobz == lapack.ComputeEV
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	if len(work) < 3*n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		w[0] = ap[0]
+		if wantz {
+			z[0] = 1
+		}
+		return true
+	}
+
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansp(lapack.MaxAbs, uplo, n, ap, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	bi := blas64.Implementation()
+	if scaled {
+		bi.Dscal(n*(n+1)/2, sigma, ap, 1)
+	}
+
+	// Reduce the packed matrix to tridiagonal form.
+	var inde int
+	indtau := inde + n
+	indwrk := indtau + n
+	impl.Dsptrd(uplo, n, ap, w, work[inde:], work[indtau:])
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, first call
+	// Dopgtr to generate the orthogonal matrix, then call Dsteqr.
+	if !wantz {
+		ok = impl.Dsterf(n, w, work[inde:])
+	} else {
+		impl.Dopgtr(uplo, n, ap, work[indtau:], z, ldz, work[indwrk:])
+		ok = impl.Dsteqr(lapack.OriginalEV, n, w, work[inde:], z, ldz, work[indtau:])
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	return ok
+}
@@ -0,0 +1,171 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsptrd reduces a symmetric n×n matrix A stored in packed format to symmetric
+// tridiagonal form T by an orthogonal similarity transformation
+//  Q^T * A * Q = T
+// where Q is an orthonormal matrix and T is symmetric and tridiagonal.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On exit, the
+// diagonal and sub/super-diagonal are overwritten by the corresponding elements
+// of the tridiagonal matrix T. The remaining elements in the triangle, along
+// with the array tau, contain the data to construct Q as the product of
+// elementary reflectors.
+//
+// If uplo == blas.Upper, Q is constructed with
+//  Q = H_{n-2} * ... * H_1 * H_0
+// where
+//  H_i = I - tau_i * v * v^T
+// v is constructed as v[i+1:n] = 0, v[i] = 1, v[0:i-1] is stored in A[0:i-1, i+1].
+// If uplo == blas.Lower, Q is constructed with
+//  Q = H_0 * H_1 * ... * H_{n-2}
+// where
+//  H_i = I - tau_i * v * v^T
+// v is constructed as v[0:i+1] = 0, v[i+1] = 1, v[i+2:n] is stored in A[i+2:n, i].
+// The elements of A are laid out as described in the documentation of Dsytrd.
+//
+// d must have length n, and e and tau must have length n-1. Dsptrd will panic
+// if these conditions are not met.
+//
+// Dsptrd is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsptrd(uplo blas.Uplo, n int, ap, d, e, tau []float64) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(d) < n:
+		panic(badD)
+	case len(e) < n-1:
+		panic(badE)
+	case len(tau) < n-1:
+		panic(badTau)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+
+	// The columns of A are not stored contiguously, so the vector v of each
+	// elementary reflector is copied into a contiguous slice. The symmetric
+	// matrix-vector product and the rank-2 update are computed using the
+	// contiguous row segments of the triangle.
+	v := make([]float64, n)
+	if uplo == blas.Upper {
+		// rowStart returns the index of the diagonal element A[r,r] in ap.
+		rowStart := func(r int) int {
+			return r*n - r*(r-1)/2
+		}
+		// Reduce the upper triangle of A.
+		for i := n - 2; i >= 0; i-- {
+			// Generate elementary reflector H_i = I - tau * v * v^T to
+			// annihilate A[0:i, i+1].
+			for r := 0; r < i; r++ {
+				v[r] = ap[rowStart(r)+i+1-r]
+			}
+			var taui float64
+			e[i], taui = impl.Dlarfg(i+1, ap[rowStart(i)+1], v, 1)
+			if taui != 0 {
+				// Apply H_i from both sides to A[0:i+1,0:i+1].
+				v[i] = 1
+
+				// Compute x := tau * A * v storing x in tau[0:i+1].
+				x := tau[:i+1]
+				for r := range x {
+					x[r] = 0
+				}
+				for r := 0; r <= i; r++ {
+					k := rowStart(r)
+					x[r] += bi.Ddot(i+1-r, ap[k:], 1, v[r:], 1)
+					bi.Daxpy(i-r, v[r], ap[k+1:], 1, x[r+1:], 1)
+				}
+				bi.Dscal(i+1, taui, x, 1)
+
+				// Compute w := x - 1/2 * tau * (x^T * v) * v.
+				alpha := -0.5 * taui * bi.Ddot(i+1, x, 1, v, 1)
+				bi.Daxpy(i+1, alpha, v, 1, x, 1)
+
+				// Apply the transformation as a rank-2 update
+				// A = A - v * w^T - w * v^T.
+				for r := 0; r <= i; r++ {
+					k := rowStart(r)
+					bi.Daxpy(i+1-r, -v[r], x[r:], 1, ap[k:], 1)
+					bi.Daxpy(i+1-r, -x[r], v[r:], 1, ap[k:], 1)
+				}
+			}
+			for r := 0; r < i; r++ {
+				ap[rowStart(r)+i+1-r] = v[r]
+			}
+			ap[rowStart(i)+1] = e[i]
+			d[i+1] = ap[rowStart(i+1)]
+			tau[i] = taui
+		}
+		d[0] = ap[0]
+		return
+	}
+
+	// rowStart returns the index of the element A[r,0] in ap.
+	rowStart := func(r int) int {
+		return r * (r + 1) / 2
+	}
+	// Reduce the lower triangle of A.
+	for i := 0; i < n-1; i++ {
+		// Generate elementary reflector H_i = I - tau * v * v^T to
+		// annihilate A[i+2:n, i].
+		m := n - i - 1
+		for r := 1; r < m; r++ {
+			v[r] = ap[rowStart(i+1+r)+i]
+		}
+		var taui float64
+		e[i], taui = impl.Dlarfg(m, ap[rowStart(i+1)+i], v[1:], 1)
+		if taui != 0 {
+			// Apply H_i from both sides to A[i+1:n, i+1:n].
+			v[0] = 1
+
+			// Compute x := tau * A * v, storing x in tau[i:n-1].
+			x := tau[i : n-1]
+			for r := range x {
+				x[r] = 0
+			}
+			for r := 0; r < m; r++ {
+				k := rowStart(i+1+r) + i + 1
+				x[r] += bi.Ddot(r+1, ap[k:], 1, v, 1)
+				bi.Daxpy(r, v[r], ap[k:], 1, x, 1)
+			}
+			bi.Dscal(m, taui, x, 1)
+
+			// Compute w := x - 1/2 * tau * (x^T * v) * v.
+			alpha := -0.5 * taui * bi.Ddot(m, x, 1, v, 1)
+			bi.Daxpy(m, alpha, v, 1, x, 1)
+
+			// Apply the transformation as a rank-2 update
+			// A = A - v * w^T - w * v^T.
+			for r := 0; r < m; r++ {
+				k := rowStart(i+1+r) + i + 1
+				bi.Daxpy(r+1, -v[r], x, 1, ap[k:], 1)
+				bi.Daxpy(r+1, -x[r], v, 1, ap[k:], 1)
+			}
+		}
+		for r := 1; r < m; r++ {
+			ap[rowStart(i+1+r)+i] = v[r]
+		}
+		ap[rowStart(i+1)+i] = e[i]
+		d[i] = ap[rowStart(i)+i]
+		tau[i] = taui
+	}
+	d[n-1] = ap[rowStart(n-1)+n-1]
+}
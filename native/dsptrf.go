@@ -0,0 +1,294 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// Dsptrf computes the factorization of a real symmetric indefinite n×n
+// matrix A stored in packed format using the Bunch-Kaufman diagonal pivoting
+// method. The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// On entry, ap contains the upper or lower triangle of A in the row-major
+// packed format described in the documentation of Dpptrf. On return, ap
+// contains the block diagonal matrix D and the multipliers used to obtain the
+// factor U or L, stored in the same way as by Dsytrf in the corresponding
+// triangle of the unpacked matrix. The pivot indices are stored in ipiv as
+// described in the documentation for Dsytrf. ipiv must have length at least
+// n, otherwise Dsptrf will panic.
+//
+// Dsptrf returns whether the block diagonal matrix D is nonsingular. If ok is
+// false, the factorization has been completed, but D is exactly singular and
+// division by zero will occur if it is used to solve a system of equations.
+func (Implementation) Dsptrf(uplo blas.Uplo, n int, ap []float64, ipiv []int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(ipiv) < n:
+		panic(badIpiv)
+	}
+
+	// alpha is used for pivot selection.
+	alpha := (1 + math.Sqrt(17)) / 8
+
+	ok = true
+	if uplo == blas.Upper {
+		// idx returns the index of the element A[i,j], i <= j, in ap.
+		idx := func(i, j int) int {
+			return i*n - i*(i-1)/2 + j - i
+		}
+		// Factorize A as U*D*U^T using the upper triangle of A. k is the
+		// main loop index, decreasing from n-1 in steps of 1 or 2.
+		for k := n - 1; k >= 0; {
+			kstep := 1
+
+			// Determine rows and columns to be interchanged and whether a
+			// 1×1 or 2×2 pivot block will be used.
+			absakk := math.Abs(ap[idx(k, k)])
+			// imax is the row index of the largest off-diagonal element
+			// in column k, and colmax is its absolute value.
+			var imax int
+			var colmax float64
+			for i := 0; i < k; i++ {
+				if v := math.Abs(ap[idx(i, k)]); v > colmax || i == 0 {
+					imax = i
+					colmax = v
+				}
+			}
+
+			var kp int
+			if math.Max(absakk, colmax) == 0 || math.IsNaN(absakk) {
+				// Column k is zero or contains a NaN.
+				ok = false
+				kp = k
+			} else {
+				if absakk >= alpha*colmax {
+					// No interchange, use 1×1 pivot block.
+					kp = k
+				} else {
+					// rowmax is the absolute value of the largest
+					// off-diagonal element in row imax.
+					var rowmax float64
+					for j := imax + 1; j <= k; j++ {
+						rowmax = math.Max(rowmax, math.Abs(ap[idx(imax, j)]))
+					}
+					for i := 0; i < imax; i++ {
+						rowmax = math.Max(rowmax, math.Abs(ap[idx(i, imax)]))
+					}
+					switch {
+					case absakk >= alpha*colmax*(colmax/rowmax):
+						// No interchange, use 1×1 pivot block.
+						kp = k
+					case math.Abs(ap[idx(imax, imax)]) >= alpha*rowmax:
+						// Interchange rows and columns k and imax, use
+						// 1×1 pivot block.
+						kp = imax
+					default:
+						// Interchange rows and columns k-1 and imax,
+						// use 2×2 pivot block.
+						kp = imax
+						kstep = 2
+					}
+				}
+
+				// Interchange rows and columns kk and kp in the leading
+				// submatrix A[0:k+1,0:k+1].
+				kk := k - kstep + 1
+				if kp != kk {
+					for i := 0; i < kp; i++ {
+						ap[idx(i, kk)], ap[idx(i, kp)] = ap[idx(i, kp)], ap[idx(i, kk)]
+					}
+					for j := kp + 1; j < kk; j++ {
+						ap[idx(j, kk)], ap[idx(kp, j)] = ap[idx(kp, j)], ap[idx(j, kk)]
+					}
+					ap[idx(kk, kk)], ap[idx(kp, kp)] = ap[idx(kp, kp)], ap[idx(kk, kk)]
+					if kstep == 2 {
+						ap[idx(k-1, k)], ap[idx(kp, k)] = ap[idx(kp, k)], ap[idx(k-1, k)]
+					}
+				}
+
+				// Update the leading submatrix.
+				if kstep == 1 {
+					// Perform a rank-1 update of A[0:k,0:k] as
+					//  A := A - U(k)*D(k)*U(k)^T = A - W(k)*1/D(k)*W(k)^T
+					// and store U(k) in column k.
+					r1 := 1 / ap[idx(k, k)]
+					for i := 0; i < k; i++ {
+						aik := ap[idx(i, k)]
+						for j := i; j < k; j++ {
+							ap[idx(i, j)] -= r1 * aik * ap[idx(j, k)]
+						}
+					}
+					for i := 0; i < k; i++ {
+						ap[idx(i, k)] *= r1
+					}
+				} else if k > 1 {
+					// Perform a rank-2 update of A[0:k-1,0:k-1] as
+					//  A := A - ( U(k-1) U(k) )*D(k)*( U(k-1) U(k) )^T
+					//     = A - ( W(k-1) W(k) )*inv(D(k))*( W(k-1) W(k) )^T
+					// and store U(k) and U(k-1) in columns k and k-1.
+					d12 := ap[idx(k-1, k)]
+					d22 := ap[idx(k-1, k-1)] / d12
+					d11 := ap[idx(k, k)] / d12
+					t := 1 / (d11*d22 - 1)
+					d12 = t / d12
+					for j := k - 2; j >= 0; j-- {
+						wkm1 := d12 * (d11*ap[idx(j, k-1)] - ap[idx(j, k)])
+						wk := d12 * (d22*ap[idx(j, k)] - ap[idx(j, k-1)])
+						for i := j; i >= 0; i-- {
+							ap[idx(i, j)] -= ap[idx(i, k)]*wk + ap[idx(i, k-1)]*wkm1
+						}
+						ap[idx(j, k)] = wk
+						ap[idx(j, k-1)] = wkm1
+					}
+				}
+			}
+
+			// Store details of the interchanges in ipiv.
+			if kstep == 1 {
+				ipiv[k] = kp
+			} else {
+				ipiv[k] = -kp - 1
+				ipiv[k-1] = -kp - 1
+			}
+			k -= kstep
+		}
+		return ok
+	}
+
+	// idx returns the index of the element A[i,j], i >= j, in ap.
+	idx := func(i, j int) int {
+		return i*(i+1)/2 + j
+	}
+	// Factorize A as L*D*L^T using the lower triangle of A. k is the main
+	// loop index, increasing from 0 in steps of 1 or 2.
+	for k := 0; k < n; {
+		kstep := 1
+
+		// Determine rows and columns to be interchanged and whether a 1×1
+		// or 2×2 pivot block will be used.
+		absakk := math.Abs(ap[idx(k, k)])
+		// imax is the row index of the largest off-diagonal element in
+		// column k, and colmax is its absolute value.
+		var imax int
+		var colmax float64
+		for i := k + 1; i < n; i++ {
+			if v := math.Abs(ap[idx(i, k)]); v > colmax || i == k+1 {
+				imax = i
+				colmax = v
+			}
+		}
+
+		var kp int
+		if math.Max(absakk, colmax) == 0 || math.IsNaN(absakk) {
+			// Column k is zero or contains a NaN.
+			ok = false
+			kp = k
+		} else {
+			if absakk >= alpha*colmax {
+				// No interchange, use 1×1 pivot block.
+				kp = k
+			} else {
+				// rowmax is the absolute value of the largest
+				// off-diagonal element in row imax.
+				var rowmax float64
+				for j := k; j < imax; j++ {
+					rowmax = math.Max(rowmax, math.Abs(ap[idx(imax, j)]))
+				}
+				for i := imax + 1; i < n; i++ {
+					rowmax = math.Max(rowmax, math.Abs(ap[idx(i, imax)]))
+				}
+				switch {
+				case absakk >= alpha*colmax*(colmax/rowmax):
+					// No interchange, use 1×1 pivot block.
+					kp = k
+				case math.Abs(ap[idx(imax, imax)]) >= alpha*rowmax:
+					// Interchange rows and columns k and imax, use 1×1
+					// pivot block.
+					kp = imax
+				default:
+					// Interchange rows and columns k+1 and imax, use 2×2
+					// pivot block.
+					kp = imax
+					kstep = 2
+				}
+			}
+
+			// Interchange rows and columns kk and kp in the trailing
+			// submatrix A[k:n,k:n].
+			kk := k + kstep - 1
+			if kp != kk {
+				for i := kp + 1; i < n; i++ {
+					ap[idx(i, kk)], ap[idx(i, kp)] = ap[idx(i, kp)], ap[idx(i, kk)]
+				}
+				for j := kk + 1; j < kp; j++ {
+					ap[idx(j, kk)], ap[idx(kp, j)] = ap[idx(kp, j)], ap[idx(j, kk)]
+				}
+				ap[idx(kk, kk)], ap[idx(kp, kp)] = ap[idx(kp, kp)], ap[idx(kk, kk)]
+				if kstep == 2 {
+					ap[idx(k+1, k)], ap[idx(kp, k)] = ap[idx(kp, k)], ap[idx(k+1, k)]
+				}
+			}
+
+			// Update the trailing submatrix.
+			if kstep == 1 {
+				// Perform a rank-1 update of A[k+1:n,k+1:n] as
+				//  A := A - L(k)*D(k)*L(k)^T = A - W(k)*(1/D(k))*W(k)^T
+				// and store L(k) in column k.
+				d11 := 1 / ap[idx(k, k)]
+				for i := k + 1; i < n; i++ {
+					aik := ap[idx(i, k)]
+					for j := k + 1; j <= i; j++ {
+						ap[idx(i, j)] -= d11 * aik * ap[idx(j, k)]
+					}
+				}
+				for i := k + 1; i < n; i++ {
+					ap[idx(i, k)] *= d11
+				}
+			} else if k < n-2 {
+				// Perform a rank-2 update of A[k+2:n,k+2:n] as
+				//  A := A - ( L(k) L(k+1) )*D(k)*( L(k) L(k+1) )^T
+				//     = A - ( W(k) W(k+1) )*inv(D(k))*( W(k) W(k+1) )^T
+				// and store L(k) and L(k+1) in columns k and k+1.
+				d21 := ap[idx(k+1, k)]
+				d11 := ap[idx(k+1, k+1)] / d21
+				d22 := ap[idx(k, k)] / d21
+				t := 1 / (d11*d22 - 1)
+				d21 = t / d21
+				for j := k + 2; j < n; j++ {
+					wk := d21 * (d11*ap[idx(j, k)] - ap[idx(j, k+1)])
+					wkp1 := d21 * (d22*ap[idx(j, k+1)] - ap[idx(j, k)])
+					for i := j; i < n; i++ {
+						ap[idx(i, j)] -= ap[idx(i, k)]*wk + ap[idx(i, k+1)]*wkp1
+					}
+					ap[idx(j, k)] = wk
+					ap[idx(j, k+1)] = wkp1
+				}
+			}
+		}
+
+		// Store details of the interchanges in ipiv.
+		if kstep == 1 {
+			ipiv[k] = kp
+		} else {
+			ipiv[k] = -kp - 1
+			ipiv[k+1] = -kp - 1
+		}
+		k += kstep
+	}
+	return ok
+}
@@ -0,0 +1,420 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/lapack"
+)
+
+// Dstebz computes the eigenvalues of a symmetric tridiagonal matrix T using
+// bisection. The user may ask for all eigenvalues, all eigenvalues in the
+// half-open interval (vl,vu], or the il-th through iu-th eigenvalues, with
+// indices starting at 0, by setting rng to lapack.RangeAll,
+// lapack.RangeInterval or lapack.RangeIndex respectively. If rng is
+// lapack.RangeInterval, vl must be less than vu, and if rng is
+// lapack.RangeIndex, il and iu must satisfy 0 <= il <= iu < n.
+//
+// d contains the n diagonal elements of T and e contains the n-1
+// off-diagonal elements of T.
+//
+// abstol is the absolute tolerance for the eigenvalues. An eigenvalue is
+// considered to be located if it has been determined to lie in an interval
+// whose width is abstol or less. If abstol is not positive, eps*|T| is used
+// instead, where eps is the machine precision and |T| is the 1-norm of T.
+// Eigenvalues are computed most accurately when abstol is set to twice the
+// underflow threshold, not zero.
+//
+// T is split into unreduced blocks at the positions where the off-diagonal
+// elements are negligible. On return, the first nsplit elements of isplit
+// hold the last row of each block, so that the j-th block consists of rows
+// isplit[j-1]+1 through isplit[j], with isplit[-1] taken to be -1. isplit
+// must have length at least n.
+//
+// On return, the first m elements of w contain the computed eigenvalues and
+// iblock[i] contains the index of the block to which the eigenvalue w[i]
+// belongs. If byBlock is true, the eigenvalues are grouped by block, and
+// sorted in ascending order within each block. This ordering is required by
+// Dstein. If byBlock is false, the eigenvalues are sorted in ascending order
+// over the entire matrix. If an eigenvalue failed to converge, the
+// corresponding iblock[i] is set to -iblock[i]-1. w and iblock must have
+// length at least n.
+//
+// work must have length at least 4*n and iwork must have length at least 3*n.
+//
+// Dstebz returns ok == false if some of the eigenvalues failed to converge,
+// or if the number of eigenvalues found does not match the number requested
+// because of non-monotonic arithmetic. In the latter case, the computed
+// eigenvalues are still accurate.
+//
+// Dstebz is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstebz(rng lapack.EVRange, byBlock bool, n int, vl, vu float64, il, iu int, abstol float64, d, e []float64, w []float64, iblock, isplit []int, work []float64, iwork []int) (m, nsplit int, ok bool) {
+	const (
+		fudge  = 2.1
+		relfac = 2
+	)
+
+	switch rng {
+	default:
+		panic(badRange)
+	case lapack.RangeAll:
+	case lapack.RangeInterval:
+		if vl >= vu {
+			panic(badInterval)
+		}
+	case lapack.RangeIndex:
+		if il < 0 || max(0, n-1) < il {
+			panic(badIl)
+		}
+		if iu < min(n-1, il) || max(0, n-1) < iu {
+			panic(badIu)
+		}
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if len(iblock) < n || len(isplit) < n {
+		panic(badIndex)
+	}
+	if len(work) < 4*n || len(iwork) < 3*n {
+		panic(badWork)
+	}
+
+	// Simplification.
+	if rng == lapack.RangeIndex && il == 0 && iu == n-1 {
+		rng = lapack.RangeAll
+	}
+
+	safmin := dlamchS
+	ulp := dlamchP
+	rtoli := ulp * relfac
+
+	// nb is the minimum vector length for vector bisection, or 0 if only
+	// scalar is to be done.
+	nb := impl.Ilaenv(1, "DSTEBZ", " ", n, -1, -1, -1)
+	if nb <= 1 {
+		nb = 0
+	}
+
+	// Special case when n == 1.
+	if n == 1 {
+		isplit[0] = 0
+		if rng == lapack.RangeInterval && (vl >= d[0] || vu < d[0]) {
+			return 0, 1, true
+		}
+		w[0] = d[0]
+		iblock[0] = 0
+		return 1, 1, true
+	}
+
+	// Compute the splitting points. The squared off-diagonal elements are
+	// stored in work[:n-1], with a zero at each splitting point.
+	e2 := work[:n]
+	e2[n-1] = 0
+	pivmin := 1.0
+	for j := 1; j < n; j++ {
+		tmp1 := e[j-1] * e[j-1]
+		if math.Abs(d[j]*d[j-1])*ulp*ulp+safmin > tmp1 {
+			isplit[nsplit] = j - 1
+			nsplit++
+			e2[j-1] = 0
+		} else {
+			e2[j-1] = tmp1
+			pivmin = math.Max(pivmin, tmp1)
+		}
+	}
+	isplit[nsplit] = n - 1
+	nsplit++
+	pivmin *= safmin
+
+	// Compute the interval and atoli.
+	var (
+		atoli            float64
+		wl, wlu, wu, wul float64
+		nwl, nwu         int
+	)
+	if rng == lapack.RangeIndex {
+		// Compute the Gerschgorin interval for the entire (split) matrix
+		// and use it as the initial interval.
+		gu := d[0]
+		gl := d[0]
+		var tmp1 float64
+		for j := 0; j < n-1; j++ {
+			tmp2 := math.Sqrt(e2[j])
+			gu = math.Max(gu, d[j]+tmp1+tmp2)
+			gl = math.Min(gl, d[j]-tmp1-tmp2)
+			tmp1 = tmp2
+		}
+		gu = math.Max(gu, d[n-1]+tmp1)
+		gl = math.Min(gl, d[n-1]-tmp1)
+		tnorm := math.Max(math.Abs(gl), math.Abs(gu))
+		gl -= fudge*tnorm*ulp*float64(n) + fudge*2*pivmin
+		gu += fudge*tnorm*ulp*float64(n) + fudge*pivmin
+
+		// Compute the iteration parameters.
+		itmax := int((math.Log(tnorm+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		if abstol <= 0 {
+			atoli = ulp * tnorm
+		} else {
+			atoli = abstol
+		}
+
+		// Find an interval containing the eigenvalues il through iu by
+		// inverting N(w).
+		ab := work[n : n+4]
+		c := work[n+4 : n+6]
+		nab := iwork[:4]
+		nval := iwork[4:6]
+		ab[0], ab[1] = gl, gu
+		ab[2], ab[3] = gl, gu
+		c[0], c[1] = gl, gu
+		nab[0], nab[1] = -1, n+1
+		nab[2], nab[3] = -1, n+1
+		nval[0] = il
+		nval[1] = iu + 1
+		impl.Dlaebz(3, itmax, n, 2, 2, nb, atoli, rtoli, pivmin,
+			d, e, e2, nval, ab, c, nab, w, iblock)
+
+		// The output intervals may not be ordered by the eigenvalue
+		// count.
+		if nval[1] == iu+1 {
+			wl, wlu, nwl = ab[0], ab[1], nab[0]
+			wu, wul, nwu = ab[3], ab[2], nab[3]
+		} else {
+			wl, wlu, nwl = ab[2], ab[3], nab[2]
+			wu, wul, nwu = ab[1], ab[0], nab[1]
+		}
+		if nwl < 0 || nwl >= n || nwu < 1 || nwu > n {
+			return 0, nsplit, false
+		}
+	} else {
+		tnorm := math.Max(math.Abs(d[0])+math.Abs(e[0]), math.Abs(d[n-1])+math.Abs(e[n-2]))
+		for j := 1; j < n-1; j++ {
+			tnorm = math.Max(tnorm, math.Abs(d[j])+math.Abs(e[j-1])+math.Abs(e[j]))
+		}
+		if abstol <= 0 {
+			atoli = ulp * tnorm
+		} else {
+			atoli = abstol
+		}
+		if rng == lapack.RangeInterval {
+			wl = vl
+			wu = vu
+		}
+	}
+
+	// Find the eigenvalues. Loop over the blocks and recompute nwl and nwu.
+	// nwl accumulates the number of eigenvalues <= wl and nwu accumulates
+	// the number of eigenvalues <= wu.
+	var ncnvrg, toofew bool
+	nwl = 0
+	nwu = 0
+	iend := -1
+	for jb := 0; jb < nsplit; jb++ {
+		ioff := iend + 1
+		ibegin := ioff
+		iend = isplit[jb]
+		in := iend - ioff + 1
+
+		if in == 1 {
+			// Special case of a 1×1 block.
+			if rng == lapack.RangeAll || wl >= d[ibegin]-pivmin {
+				nwl++
+			}
+			if rng == lapack.RangeAll || wu >= d[ibegin]-pivmin {
+				nwu++
+			}
+			if rng == lapack.RangeAll || (wl < d[ibegin]-pivmin && wu >= d[ibegin]-pivmin) {
+				w[m] = d[ibegin]
+				iblock[m] = jb
+				m++
+			}
+			continue
+		}
+
+		// General case of a block of size in > 1. Compute the
+		// Gerschgorin interval and use it as the initial interval.
+		gu := d[ibegin]
+		gl := d[ibegin]
+		var tmp1 float64
+		for j := ibegin; j < iend; j++ {
+			tmp2 := math.Abs(e[j])
+			gu = math.Max(gu, d[j]+tmp1+tmp2)
+			gl = math.Min(gl, d[j]-tmp1-tmp2)
+			tmp1 = tmp2
+		}
+		gu = math.Max(gu, d[iend]+tmp1)
+		gl = math.Min(gl, d[iend]-tmp1)
+		bnorm := math.Max(math.Abs(gl), math.Abs(gu))
+		gl -= fudge*bnorm*ulp*float64(in) + fudge*pivmin
+		gu += fudge*bnorm*ulp*float64(in) + fudge*pivmin
+
+		// Compute atoli for the current block.
+		if abstol <= 0 {
+			atoli = ulp * math.Max(math.Abs(gl), math.Abs(gu))
+		} else {
+			atoli = abstol
+		}
+
+		if rng != lapack.RangeAll {
+			if gu < wl {
+				nwl += in
+				nwu += in
+				continue
+			}
+			gl = math.Max(gl, wl)
+			gu = math.Min(gu, wu)
+			if gl >= gu {
+				continue
+			}
+		}
+
+		// Set up the initial interval and count the eigenvalues at its
+		// bounds.
+		ab := work[n : n+2*in]
+		c := work[n+2*in : n+3*in]
+		nab := iwork[:2*in]
+		ab[0] = gl
+		ab[1] = gu
+		im, _ := impl.Dlaebz(1, 0, in, in, 1, nb, atoli, rtoli, pivmin,
+			d[ibegin:], e[ibegin:], e2[ibegin:], nil, ab, c, nab, w[m:], iblock[m:])
+
+		nwl += nab[0]
+		nwu += nab[1]
+		iwoff := m - nab[0]
+
+		// Compute the eigenvalues.
+		itmax := int((math.Log(gu-gl+pivmin)-math.Log(pivmin))/math.Log(2)) + 2
+		iout, iinfo := impl.Dlaebz(2, itmax, in, in, 1, nb, atoli, rtoli, pivmin,
+			d[ibegin:], e[ibegin:], e2[ibegin:], nil, ab, c, nab, w[m:], iblock[m:])
+
+		// Copy the eigenvalues into w and iblock. Use -jb-1 for the block
+		// number of unconverged eigenvalues.
+		for j := 0; j < iout; j++ {
+			tmp1 := 0.5 * (ab[2*j] + ab[2*j+1])
+			ib := jb
+			if j >= iout-iinfo {
+				// Flag non-convergence.
+				ncnvrg = true
+				ib = -jb - 1
+			}
+			for je := nab[2*j] + iwoff; je < nab[2*j+1]+iwoff; je++ {
+				w[je] = tmp1
+				iblock[je] = ib
+			}
+		}
+		m += im
+	}
+
+	// If rng is lapack.RangeIndex, then (wl,wu] contains eigenvalues nwl
+	// through nwu-1. If nwl < il or nwu > iu+1, discard the extra
+	// eigenvalues.
+	if rng == lapack.RangeIndex {
+		idiscl := il - nwl
+		idiscu := nwu - iu - 1
+		if idiscl > 0 || idiscu > 0 {
+			var im int
+			for je := 0; je < m; je++ {
+				switch {
+				case w[je] <= wlu && idiscl > 0:
+					idiscl--
+				case w[je] >= wul && idiscu > 0:
+					idiscu--
+				default:
+					w[im] = w[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscl > 0 || idiscu > 0 {
+			// Deal with the effects of bad arithmetic. Some low
+			// eigenvalues to be discarded are not in (wl,wlu], or high
+			// eigenvalues to be discarded are not in (wul,wu], so just
+			// kill off the smallest idiscl and largest idiscu
+			// eigenvalues. The killed eigenvalues are marked in iwork.
+			killed := iwork[:m]
+			for je := range killed {
+				killed[je] = 0
+			}
+			if idiscl > 0 {
+				wkill := wu
+				for jdisc := 0; jdisc < idiscl; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if killed[je] == 0 && (w[je] < wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					killed[iw] = 1
+				}
+			}
+			if idiscu > 0 {
+				wkill := wl
+				for jdisc := 0; jdisc < idiscu; jdisc++ {
+					iw := -1
+					for je := 0; je < m; je++ {
+						if killed[je] == 0 && (w[je] > wkill || iw == -1) {
+							iw = je
+							wkill = w[je]
+						}
+					}
+					killed[iw] = 1
+				}
+			}
+			var im int
+			for je := 0; je < m; je++ {
+				if killed[je] == 0 {
+					w[im] = w[je]
+					iblock[im] = iblock[je]
+					im++
+				}
+			}
+			m = im
+		}
+		if idiscl < 0 || idiscu < 0 {
+			toofew = true
+		}
+	}
+
+	// If byBlock is true, do nothing since the eigenvalues are already
+	// sorted by block. Otherwise sort the eigenvalues from smallest to
+	// largest.
+	if !byBlock && nsplit > 1 {
+		for je := 0; je < m-1; je++ {
+			ie := -1
+			tmp1 := w[je]
+			for j := je + 1; j < m; j++ {
+				if w[j] < tmp1 {
+					ie = j
+					tmp1 = w[j]
+				}
+			}
+			if ie != -1 {
+				w[ie], w[je] = w[je], tmp1
+				iblock[ie], iblock[je] = iblock[je], iblock[ie]
+			}
+		}
+	}
+
+	return m, nsplit, !ncnvrg && !toofew
+}
@@ -0,0 +1,243 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dstedc computes all eigenvalues and, optionally, the eigenvectors of a
+// symmetric tridiagonal matrix using the divide and conquer method. The
+// eigenvectors of a full or band symmetric matrix can also be found if Dsytrd
+// has been used to reduce this matrix to tridiagonal form.
+//
+// d, on entry, contains the diagonal elements of the tridiagonal matrix. On
+// exit, d contains the eigenvalues in ascending order. d must have length n
+// and Dstedc will panic otherwise.
+//
+// e, on entry, contains the off-diagonal elements of the tridiagonal matrix,
+// and is overwritten during the call to Dstedc. e must have length n-1 and
+// Dstedc will panic otherwise.
+//
+// z, on entry, contains the n×n orthogonal matrix used in the reduction to
+// tridiagonal form if compz == lapack.OriginalEV. On exit, if
+// compz == lapack.OriginalEV, z contains the orthonormal eigenvectors of the
+// original symmetric matrix, and if compz == lapack.TridiagEV, z contains the
+// orthonormal eigenvectors of the symmetric tridiagonal matrix. z is not used
+// if compz == lapack.None.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If compz == lapack.None or n <= 1, lwork and liwork must be
+// at least 1. Otherwise, if n <= 25, lwork must be at least 2*(n-1) and
+// liwork at least 1. For larger n, if compz == lapack.OriginalEV, lwork must
+// be at least 1 + 3*n + 2*n*lg(n) + 4*n^2 and liwork at least
+// 6 + 6*n + 5*n*lg(n), where lg(n) is the smallest integer k such that
+// 2^k >= n, and if compz == lapack.TridiagEV, lwork must be at least
+// 1 + 4*n + n^2 and liwork at least 3 + 5*n. Dstedc will panic if these
+// conditions are not met.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dstedc the minimum
+// required lengths of work and iwork are stored into work[0] and iwork[0].
+//
+// Dstedc returns whether the computation succeeded.
+//
+// Dstedc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	var icompz int
+	switch compz {
+	default:
+		panic(badEVComp)
+	case lapack.None:
+		icompz = 0
+	case lapack.OriginalEV:
+		icompz = 1
+	case lapack.TridiagEV:
+		icompz = 2
+	}
+	if icompz > 0 {
+		checkMatrix(n, n, z, ldz)
+	}
+
+	// Compute the workspace requirements.
+	smlsiz := impl.Ilaenv(9, "DSTEDC", " ", 0, 0, 0, 0)
+	var lwmin, liwmin int
+	switch {
+	case n <= 1 || icompz == 0:
+		lwmin = 1
+		liwmin = 1
+	case n <= smlsiz:
+		lwmin = 2 * (n - 1)
+		liwmin = 1
+	default:
+		var lgn int
+		for 1<<uint(lgn) < n {
+			lgn++
+		}
+		if icompz == 1 {
+			lwmin = 1 + 3*n + 2*n*lgn + 4*n*n
+			liwmin = 6 + 6*n + 5*n*lgn
+		} else {
+			lwmin = 1 + 4*n + n*n
+			liwmin = 3 + 5*n
+		}
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		if icompz != 0 {
+			z[0] = 1
+		}
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return true
+	}
+
+	// If compz == lapack.None, use Dsterf to compute the eigenvalues.
+	// Dsterf is much faster than any other algorithm for finding
+	// eigenvalues only, so it is used instead of the divide and conquer
+	// method.
+	if icompz == 0 {
+		ok = impl.Dsterf(n, d, e)
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return ok
+	}
+
+	// If n is smaller than the minimum divide size (smlsiz+1), then solve
+	// the problem with another solver.
+	if n <= smlsiz {
+		ok = impl.Dsteqr(compz, n, d, e, z, ldz, work)
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return ok
+	}
+
+	// If compz == lapack.OriginalEV, the z matrix must be stored elsewhere
+	// for later use.
+	var storez int
+	if icompz == 1 {
+		storez = n * n
+	}
+	if icompz == 2 {
+		impl.Dlaset(blas.All, n, n, 0, 1, z, ldz)
+	}
+
+	// Scale.
+	orgnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+	if orgnrm == 0 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return true
+	}
+
+	bi := blas64.Implementation()
+	eps := dlamchE
+
+	start := 0
+	for start < n {
+		// Let finish be the position of the next subdiagonal entry such
+		// that e[finish] <= tiny or finish = n-1 if no such subdiagonal
+		// exists. The matrix identified by the elements between start and
+		// finish constitutes an independent sub-problem.
+		finish := start
+		for finish < n-1 {
+			tiny := eps * math.Sqrt(math.Abs(d[finish])) * math.Sqrt(math.Abs(d[finish+1]))
+			if math.Abs(e[finish]) <= tiny {
+				break
+			}
+			finish++
+		}
+
+		// (Sub) Problem determined. Compute its size and solve it.
+		m := finish - start + 1
+		if m == 1 {
+			start = finish + 1
+			continue
+		}
+		if m > smlsiz {
+			// Scale.
+			orgnrm = impl.Dlanst(lapack.MaxAbs, m, d[start:], e[start:])
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m, 1, d[start:], 1)
+			impl.Dlascl(lapack.General, 0, 0, orgnrm, 1, m-1, 1, e[start:], 1)
+
+			var strtrw int
+			if icompz == 2 {
+				strtrw = start
+			}
+			ok = impl.Dlaed0(icompz, m, n, d[start:], e[start:], z[strtrw*ldz+start:], ldz,
+				work, n, work[storez:], iwork)
+			if !ok {
+				return false
+			}
+
+			// Scale back.
+			impl.Dlascl(lapack.General, 0, 0, 1, orgnrm, m, 1, d[start:], 1)
+		} else {
+			if icompz == 1 {
+				// Since Dsteqr won't update a z matrix which is larger than
+				// the length of d, we must solve the sub-problem in a
+				// workspace and then multiply back into z.
+				ok = impl.Dsteqr(lapack.TridiagEV, m, d[start:], e[start:], work, m, work[m*m:])
+				impl.Dlacpy(blas.All, n, m, z[start:], ldz, work[storez:], m)
+				bi.Dgemm(blas.NoTrans, blas.NoTrans, n, m, m, 1, work[storez:], m, work, m,
+					0, z[start:], ldz)
+			} else {
+				ok = impl.Dsteqr(lapack.TridiagEV, m, d[start:], e[start:], z[start*ldz+start:], ldz, work)
+			}
+			if !ok {
+				return false
+			}
+		}
+		start = finish + 1
+	}
+
+	// Use selection sort to minimize swaps of eigenvectors.
+	for i := 0; i < n-1; i++ {
+		k := i
+		p := d[i]
+		for j := i + 1; j < n; j++ {
+			if d[j] < p {
+				k = j
+				p = d[j]
+			}
+		}
+		if k != i {
+			d[k] = d[i]
+			d[i] = p
+			bi.Dswap(n, z[i:], ldz, z[k:], ldz)
+		}
+	}
+
+	work[0] = float64(lwmin)
+	iwork[0] = liwmin
+	return true
+}
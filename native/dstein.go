@@ -0,0 +1,247 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/gonum/blas/blas64"
+)
+
+// Dstein computes the eigenvectors of a real symmetric tridiagonal matrix T
+// corresponding to specified eigenvalues, using inverse iteration.
+//
+// d contains the n diagonal elements of T and e contains the n-1
+// off-diagonal elements of T.
+//
+// w contains the m eigenvalues for which eigenvectors are to be computed.
+// The eigenvalues must be grouped by split-off block, as returned by Dstebz
+// with byBlock set to true, and they must be in ascending order within each
+// block. iblock[j] must hold the index of the block containing w[j], and
+// isplit[b] must hold the last row of the b-th block, so that the b-th block
+// consists of rows isplit[b-1]+1 through isplit[b], with isplit[-1] taken to
+// be -1. These are the values returned by Dstebz.
+//
+// On return, the first m columns of z contain the computed eigenvectors,
+// the j-th column corresponding to w[j]. Each eigenvector is normalized and
+// is zero outside its block. z must be an n×m matrix with leading dimension
+// ldz.
+//
+// work must have length at least 5*n and iwork must have length at least n.
+//
+// On return, ifail[:m] holds the indices of the eigenvectors that failed to
+// converge within the maximum number of iterations, followed by -1 for the
+// remaining elements. Dstein returns whether all eigenvectors converged.
+//
+// Dstein is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dstein(n int, d, e []float64, m int, w []float64, iblock, isplit []int, z []float64, ldz int, work []float64, iwork []int, ifail []int) (ok bool) {
+	const (
+		maxits = 5
+		extra  = 2
+	)
+
+	if n < 0 {
+		panic(nLT0)
+	}
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < m {
+		panic(nLTM)
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n-1 {
+		panic(badE)
+	}
+	if len(w) < m {
+		panic(badW)
+	}
+	if len(iblock) < m || len(ifail) < m {
+		panic(badIndex)
+	}
+	if m > 0 && len(isplit) < iblock[m-1]+1 {
+		panic(badIndex)
+	}
+	if len(work) < 5*n || len(iwork) < n {
+		panic(badWork)
+	}
+	checkMatrix(n, m, z, ldz)
+	for j := 1; j < m; j++ {
+		if iblock[j] < iblock[j-1] {
+			panic(badEVOrder)
+		}
+		if iblock[j] == iblock[j-1] && w[j] < w[j-1] {
+			panic(badEVOrder)
+		}
+	}
+	for i := range ifail[:m] {
+		ifail[i] = -1
+	}
+
+	// Quick return if possible.
+	if n == 0 || m == 0 {
+		return true
+	}
+	if n == 1 {
+		z[0] = 1
+		return true
+	}
+
+	bi := blas64.Implementation()
+	eps := dlamchP
+	// The starting vectors are generated from a fixed seed so that the
+	// results are reproducible.
+	rnd := rand.New(rand.NewSource(1))
+
+	// Initialize pointers into the workspace.
+	const indrv1 = 0
+	indrv2 := indrv1 + n
+	indrv3 := indrv2 + n
+	indrv4 := indrv3 + n
+	indrv5 := indrv4 + n
+
+	// Compute the eigenvectors of the matrix blocks.
+	var nfail int
+	j1 := 0
+	for nblk := 0; nblk <= iblock[m-1]; nblk++ {
+		// Find the starting and ending indices of block nblk.
+		b1 := 0
+		if nblk > 0 {
+			b1 = isplit[nblk-1] + 1
+		}
+		bn := isplit[nblk]
+		blksiz := bn - b1 + 1
+
+		var gpind int
+		var onenrm, ortol, dtpcrt float64
+		if blksiz > 1 {
+			gpind = j1
+
+			// Compute the reorthogonalization criterion and the stopping
+			// criterion.
+			onenrm = math.Abs(d[b1]) + math.Abs(e[b1])
+			onenrm = math.Max(onenrm, math.Abs(d[bn])+math.Abs(e[bn-1]))
+			for i := b1 + 1; i < bn; i++ {
+				onenrm = math.Max(onenrm, math.Abs(d[i])+math.Abs(e[i-1])+math.Abs(e[i]))
+			}
+			ortol = 1e-3 * onenrm
+			dtpcrt = math.Sqrt(0.1 / float64(blksiz))
+		}
+
+		// Loop through the eigenvalues of block nblk.
+		var xjm float64
+		jblk := 0
+		j := j1
+		for ; j < m; j++ {
+			if iblock[j] != nblk {
+				break
+			}
+			jblk++
+			xj := w[j]
+
+			if blksiz == 1 {
+				// Skip all the work if the block size is one.
+				work[indrv1] = 1
+			} else {
+				// If eigenvalues j and j-1 are too close, add a
+				// relatively small perturbation.
+				if jblk > 1 {
+					eps1 := math.Abs(eps * xj)
+					pertol := 10 * eps1
+					sep := xj - xjm
+					if sep < pertol {
+						xj = xjm + pertol
+					}
+				}
+
+				// Get a random starting vector.
+				for i := 0; i < blksiz; i++ {
+					work[indrv1+i] = 2*rnd.Float64() - 1
+				}
+
+				// Copy the matrix T so that it won't be destroyed in the
+				// factorization.
+				bi.Dcopy(blksiz, d[b1:], 1, work[indrv4:], 1)
+				bi.Dcopy(blksiz-1, e[b1:], 1, work[indrv2+1:], 1)
+				bi.Dcopy(blksiz-1, e[b1:], 1, work[indrv3:], 1)
+
+				// Compute the LU factors with partial pivoting, P*T = L*U.
+				impl.Dlagtf(blksiz, work[indrv4:], xj, work[indrv2+1:], work[indrv3:], 0, work[indrv5:], iwork)
+
+				// Perform the inverse iteration.
+				var its, nrmchk int
+				converged := false
+				for its < maxits {
+					its++
+
+					// Normalize and scale the right-hand side vector Pb.
+					jmax := bi.Idamax(blksiz, work[indrv1:], 1)
+					scl := float64(blksiz) * onenrm * math.Max(eps, math.Abs(work[indrv4+blksiz-1])) /
+						math.Abs(work[indrv1+jmax])
+					bi.Dscal(blksiz, scl, work[indrv1:], 1)
+
+					// Solve the system L*U = Pb.
+					impl.Dlagts(-1, blksiz, work[indrv4:], work[indrv2+1:], work[indrv3:], work[indrv5:], iwork, work[indrv1:], 0)
+
+					// Reorthogonalize by modified Gram-Schmidt if the
+					// eigenvalues are close enough.
+					if jblk > 1 {
+						if math.Abs(xj-xjm) > ortol {
+							gpind = j
+						}
+						for i := gpind; i < j; i++ {
+							ztr := -bi.Ddot(blksiz, work[indrv1:], 1, z[b1*ldz+i:], ldz)
+							bi.Daxpy(blksiz, ztr, z[b1*ldz+i:], ldz, work[indrv1:], 1)
+						}
+					}
+
+					// Check the infinity norm of the iterate.
+					jmax = bi.Idamax(blksiz, work[indrv1:], 1)
+					nrm := math.Abs(work[indrv1+jmax])
+
+					// Continue for additional iterations after the norm
+					// reaches the stopping criterion.
+					if nrm < dtpcrt {
+						continue
+					}
+					nrmchk++
+					if nrmchk < extra+1 {
+						continue
+					}
+					converged = true
+					break
+				}
+				if !converged {
+					// The stopping criterion was not satisfied, record
+					// the eigenvector index in ifail.
+					ifail[nfail] = j
+					nfail++
+				}
+
+				// Accept the iterate as the j-th eigenvector.
+				scl := 1 / bi.Dnrm2(blksiz, work[indrv1:], 1)
+				jmax := bi.Idamax(blksiz, work[indrv1:], 1)
+				if work[indrv1+jmax] < 0 {
+					scl = -scl
+				}
+				bi.Dscal(blksiz, scl, work[indrv1:], 1)
+			}
+
+			for i := 0; i < n; i++ {
+				z[i*ldz+j] = 0
+			}
+			bi.Dcopy(blksiz, work[indrv1:], 1, z[b1*ldz+j:], ldz)
+
+			// Save the shift to check the eigenvalue spacing at the next
+			// iteration.
+			xjm = xj
+		}
+		j1 = j
+	}
+	return nfail == 0
+}
@@ -0,0 +1,357 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dstemr computes selected eigenvalues and, optionally, eigenvectors of a
+// real symmetric tridiagonal matrix T using the Multiple Relatively Robust
+// Representations (MRRR) algorithm. Any such unreduced matrix has a well
+// defined set of pairwise different real eigenvalues, and the corresponding
+// real eigenvectors are pairwise orthogonal.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, d contains the n diagonal elements of T and e contains the n-1
+// off-diagonal elements of T in its first n-1 elements. e[n-1] is used as
+// workspace. d and e must have length at least n and they are overwritten on
+// return.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// orthonormal eigenvectors of T, the i-th column corresponding to w[i], and
+// the support of the i-th eigenvector is stored in isuppz[2*i] through
+// isuppz[2*i+1]. The eigenvector is non-zero only in these rows. z must have
+// at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise, and isuppz must have length at least twice the number of columns
+// of z. If jobz != lapack.ComputeEV, z and isuppz are not referenced.
+//
+// If tryrac is true, Dstemr checks whether the tridiagonal matrix defines its
+// eigenvalues to high relative accuracy, and if so, computes them to high
+// relative accuracy.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If jobz == lapack.ComputeEV, lwork must be at least
+// max(1,18*n) and liwork must be at least max(1,10*n). Otherwise, lwork must
+// be at least max(1,12*n) and liwork must be at least max(1,8*n). If lwork ==
+// -1 or liwork == -1, instead of computing Dstemr the minimum work length is
+// stored into work[0] and the minimum iwork length is stored into iwork[0].
+//
+// Dstemr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	const minrgp = 1e-3
+
+	wantz := jobz == lapack.ComputeEV
+	alleig := rng == lapack.RangeAll
+	valeig := rng == lapack.RangeInterval
+	indeig := rng == lapack.RangeIndex
+	switch {
+	case !alleig && !valeig && !indeig:
+		panic(badRange)
+	case n < 0:
+		panic(nLT0)
+	case valeig && n > 0 && vl >= vu:
+		panic(badInterval)
+	case indeig && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case indeig && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+
+	lwmin := max(1, 12*n)
+	liwmin := max(1, 8*n)
+	if wantz {
+		lwmin = max(1, 18*n)
+		liwmin = max(1, 10*n)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return 0, true
+	}
+	if len(d) < n {
+		panic(badD)
+	}
+	if len(e) < n {
+		panic(badE)
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+	if wantz {
+		nzc := n
+		if indeig {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(isuppz) < 2*nzc {
+			panic(badIsuppz)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+	if n == 1 {
+		if alleig || indeig || (vl < d[0] && d[0] <= vu) {
+			m = 1
+			w[0] = d[0]
+			if wantz {
+				z[0] = 1
+				isuppz[0] = 0
+				isuppz[1] = 0
+			}
+		}
+		return m, true
+	}
+
+	var nsplit int
+	if n == 2 {
+		// r1 is the eigenvalue of larger absolute value and [cs sn] is
+		// the unit right eigenvector for r1.
+		var r1, r2, cs, sn float64
+		if !wantz {
+			r1, r2 = impl.Dlae2(d[0], e[0], d[1])
+		} else {
+			r1, r2, cs, sn = impl.Dlaev2(d[0], e[0], d[1])
+		}
+		// Order the eigenvalues so that r2 <= r1.
+		if r1 < r2 {
+			r1, r2 = r2, r1
+			cs, sn = -sn, cs
+		}
+		// setSupport stores the support of the eigenvector in column m
+		// of z. At most one of sn and cs can be zero.
+		setSupport := func(z0, z1 float64) {
+			isuppz[2*m] = 0
+			isuppz[2*m+1] = 1
+			if z0 == 0 {
+				isuppz[2*m] = 1
+			}
+			if z1 == 0 {
+				isuppz[2*m+1] = 0
+			}
+		}
+		if alleig || (valeig && vl < r2 && r2 <= vu) || (indeig && il == 0) {
+			w[m] = r2
+			if wantz {
+				z[m] = -sn
+				z[ldz+m] = cs
+				setSupport(-sn, cs)
+			}
+			m++
+		}
+		if alleig || (valeig && vl < r1 && r1 <= vu) || (indeig && iu == 1) {
+			w[m] = r1
+			if wantz {
+				z[m] = cs
+				z[ldz+m] = sn
+				setSupport(cs, sn)
+			}
+			m++
+		}
+	} else {
+		// Continue with the general n.
+
+		// Workspace layout.
+		const indgrs = 0
+		inderr := 2 * n
+		indgp := 3 * n
+		indd := 4 * n
+		inde2 := 5 * n
+		indwrk := 6 * n
+
+		const iinspl = 0
+		iindbl := n
+		iindw := 2 * n
+		iindwk := 3 * n
+
+		// Get machine constants.
+		safmin := dlamchS
+		eps := dlamchP
+		smlnum := safmin / eps
+		bignum := 1 / smlnum
+		rmin := math.Sqrt(smlnum)
+		rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+		bi := blas64.Implementation()
+
+		// Scale the matrix to the allowable range, if necessary. The
+		// scale factor is the same as the one used by Dsyevr.
+		wl, wu := vl, vu
+		scale := 1.0
+		tnrm := impl.Dlanst(lapack.MaxAbs, n, d, e)
+		if tnrm > 0 && tnrm < rmin {
+			scale = rmin / tnrm
+		} else if tnrm > rmax {
+			scale = rmax / tnrm
+		}
+		if scale != 1 {
+			bi.Dscal(n, scale, d, 1)
+			bi.Dscal(n-1, scale, e, 1)
+			tnrm *= scale
+			if valeig {
+				// If eigenvalues in an interval have to be found,
+				// scale the interval as well.
+				wl *= scale
+				wu *= scale
+			}
+		}
+
+		// Test whether the matrix warrants the more expensive relative
+		// approach, but only allow it if tryrac was set. The splitting
+		// criterion used by Dlarre preserves relative accuracy if thresh
+		// is positive and is based on the size of the off-diagonal
+		// otherwise.
+		thresh := -eps
+		if tryrac && impl.Dlarrr(n, d, e) {
+			thresh = eps
+		} else {
+			tryrac = false
+		}
+		if tryrac {
+			// Copy the original diagonal, it is needed to guarantee
+			// relative accuracy.
+			copy(work[indd:indd+n], d[:n])
+		}
+		// Store the squares of the off-diagonal values of T.
+		for j := 0; j < n-1; j++ {
+			work[inde2+j] = e[j] * e[j]
+		}
+
+		// Set the tolerance parameters for bisection.
+		var rtol1, rtol2 float64
+		if !wantz {
+			// Dlarre computes the eigenvalues to full precision.
+			rtol1 = 4 * eps
+			rtol2 = 4 * eps
+		} else {
+			// Dlarre computes the eigenvalues to less than full
+			// precision. Dlarrv will refine the eigenvalue
+			// approximations, so a less accurate initial bisection in
+			// Dlarre is sufficient. Note that these settings only
+			// affect the subset case and Dlarre.
+			rtol1 = math.Sqrt(eps)
+			rtol2 = math.Max(math.Sqrt(eps)*5e-3, 4*eps)
+		}
+
+		isplit := iwork[iinspl : iinspl+n]
+		iblock := iwork[iindbl : iindbl+n]
+		indexw := iwork[iindw : iindw+n]
+		var pivmin float64
+		wl, wu, nsplit, m, pivmin, ok = impl.Dlarre(rng, n, wl, wu, il, iu, d, e, work[inde2:inde2+n],
+			rtol1, rtol2, thresh, isplit, w, work[inderr:inderr+n], work[indgp:indgp+n],
+			iblock, indexw, work[indgrs:indgrs+2*n], work[indwrk:], iwork[iindwk:])
+		if !ok {
+			return 0, false
+		}
+		// Note that if rng != lapack.RangeInterval, Dlarre computes
+		// bounds on the desired part of the spectrum. All desired
+		// eigenvalues are contained in (wl,wu].
+
+		if wantz {
+			// Compute the desired eigenvectors corresponding to the
+			// computed eigenvalues.
+			if m > 0 {
+				ok = impl.Dlarrv(n, wl, wu, d, e, pivmin, isplit, m, 0, m-1, minrgp, rtol1, rtol2,
+					w, work[inderr:inderr+n], work[indgp:indgp+n], iblock, indexw,
+					work[indgrs:indgrs+2*n], z, ldz, isuppz, work[indwrk:], iwork[iindwk:])
+				if !ok {
+					return 0, false
+				}
+			}
+		} else {
+			// Dlarre computes the eigenvalues of the (shifted) root
+			// representation, while Dlarrv returns the eigenvalues of
+			// L * D * L^T. So the eigenvalues need to be shifted back.
+			for j := 0; j < m; j++ {
+				w[j] += e[isplit[iblock[j]]]
+			}
+		}
+
+		if tryrac && m > 0 {
+			// Refine the computed eigenvalues so that they are relatively
+			// accurate with respect to the original matrix T.
+			ibegin := 0
+			wbegin := 0
+			for jblk := 0; jblk <= iblock[m-1]; jblk++ {
+				iend := isplit[jblk]
+				in := iend - ibegin + 1
+				wend := wbegin - 1
+				// Check if any eigenvalues have to be refined in this
+				// block.
+				for wend < m-1 && iblock[wend+1] == jblk {
+					wend++
+				}
+				if wend < wbegin {
+					ibegin = iend + 1
+					continue
+				}
+				offset := indexw[wbegin]
+				ifirst := indexw[wbegin]
+				ilast := indexw[wend]
+				impl.Dlarrj(in, work[indd+ibegin:], work[inde2+ibegin:], ifirst, ilast, 4*eps, offset,
+					w[wbegin:], work[inderr+wbegin:], work[indwrk:], iwork[iindwk:], pivmin, tnrm)
+				ibegin = iend + 1
+				wbegin = wend + 1
+			}
+		}
+
+		// If the matrix was scaled, rescale the eigenvalues
+		// appropriately.
+		if scale != 1 {
+			bi.Dscal(m, 1/scale, w, 1)
+		}
+	}
+
+	// If the eigenvalues are not in increasing order, sort them, possibly
+	// along with the eigenvectors. The refinement to high relative accuracy
+	// may swap the order of very close eigenvalues.
+	if nsplit > 1 || tryrac {
+		if !wantz {
+			impl.Dlasrt(lapack.SortIncreasing, m, w)
+			return m, true
+		}
+		bi := blas64.Implementation()
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp {
+					i = jj
+					tmp = w[jj]
+				}
+			}
+			if i != -1 {
+				w[i] = w[j]
+				w[j] = tmp
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	return m, true
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dsycon estimates the reciprocal of the condition number of a symmetric
+// indefinite matrix A given the factorization of A computed by Dsytrf. The
+// condition number computed is based on the 1-norm and the ∞-norm.
+//
+// An estimate is obtained for norm(inv(A)), and the reciprocal of the
+// condition number is computed as
+//  rcond = 1 / (anorm * norm(inv(A))).
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// ipiv must have length at least n and contain the pivot indices as returned
+// by Dsytrf.
+//
+// work is a temporary data slice of length at least 2*n and Dsycon will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and Dsycon will panic otherwise.
+func (impl Implementation) Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return impl.dsycon(uplo, n, a, lda, ipiv, anorm, work, iwork, false)
+}
+
+// dsycon estimates the reciprocal of the condition number of a symmetric
+// indefinite matrix factorized by Dsytrf or, if rook is true, by DsytrfRook.
+func (impl Implementation) dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int, rook bool) float64 {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case anorm < 0:
+		panic("lapack: anorm < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < 2*n {
+		panic(badWork)
+	}
+	if len(iwork) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 1
+	}
+	if anorm == 0 {
+		return 0
+	}
+
+	// Check that the diagonal matrix D is nonsingular.
+	for i := 0; i < n; i++ {
+		if ipiv[i] >= 0 && a[i*lda+i] == 0 {
+			return 0
+		}
+	}
+
+	// Estimate the 1-norm of the inverse.
+	var ainvnm float64
+	var kase int
+	var isave [3]int
+	for {
+		ainvnm, kase = impl.Dlacn2(n, work[n:], work, iwork, ainvnm, kase, &isave)
+		if kase == 0 {
+			break
+		}
+		// Multiply by inv(L*D*L^T) or inv(U*D*U^T).
+		impl.dsytrs(uplo, n, 1, a, lda, ipiv, work, 1, rook)
+	}
+
+	// Compute the estimate of the reciprocal condition number.
+	if ainvnm == 0 {
+		return 0
+	}
+	return (1 / ainvnm) / anorm
+}
@@ -0,0 +1,27 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// DsyconRook estimates the reciprocal of the condition number of a symmetric
+// indefinite matrix A given the factorization of A computed by DsytrfRook. The
+// condition number computed is based on the 1-norm and the ∞-norm.
+//
+// An estimate is obtained for norm(inv(A)), and the reciprocal of the
+// condition number is computed as
+//  rcond = 1 / (anorm * norm(inv(A))).
+//
+// anorm is the 1-norm and the ∞-norm of the original matrix A.
+//
+// ipiv must have length at least n and contain the pivot indices as returned
+// by DsytrfRook.
+//
+// work is a temporary data slice of length at least 2*n and DsyconRook will panic otherwise.
+//
+// iwork is a temporary data slice of length at least n and DsyconRook will panic otherwise.
+func (impl Implementation) DsyconRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64 {
+	return impl.dsycon(uplo, n, a, lda, ipiv, anorm, work, iwork, true)
+}
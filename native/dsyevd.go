@@ -0,0 +1,160 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsyevd computes all eigenvalues and, optionally, the eigenvectors of a real
+// symmetric matrix A. If eigenvectors are desired, it uses a divide and conquer
+// algorithm.
+//
+// w contains the eigenvalues in ascending order upon return. w must have length
+// at least n, and Dsyevd will panic otherwise.
+//
+// On entry, a contains the elements of the symmetric matrix A in the triangular
+// portion specified by uplo. If jobz == lapack.ComputeEV a contains the
+// orthonormal eigenvectors of A on exit, otherwise on exit the specified
+// triangular region is overwritten.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. Dsyevd will panic if
+// these conditions are not met.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dsyevd the optimal work
+// length is stored into work[0] and the minimum iwork length is stored into
+// iwork[0].
+//
+// Dsyevd returns whether the computation succeeded.
+func (impl Implementation) Dsyevd(jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+
+	var lwmin, liwmin, lopt int
+	if n <= 1 {
+		lwmin = 1
+		liwmin = 1
+		lopt = 1
+	} else {
+		if wantz {
+			liwmin = 3 + 5*n
+			lwmin = 1 + 6*n + 2*n*n
+		} else {
+			liwmin = 1
+			lwmin = 2*n + 1
+		}
+		opts := "L"
+		if uplo == blas.Upper {
+			opts = "U"
+		}
+		nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+		lopt = max(lwmin, 2*n+n*nb)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lopt)
+		iwork[0] = liwmin
+		return true
+	}
+	if len(w) < n {
+		panic(badSlice)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	if n == 0 {
+		return true
+	}
+	if n == 1 {
+		w[0] = a[0]
+		if wantz {
+			a[0] = 1
+		}
+		work[0] = float64(lopt)
+		iwork[0] = liwmin
+		return true
+	}
+
+	// Get machine constants.
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Sqrt(bignum)
+
+	// Scale matrix to allowable range, if necessary.
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+	}
+
+	// Call Dsytrd to reduce symmetric matrix to tridiagonal form.
+	inde := 0
+	indtau := inde + n
+	indwrk := indtau + n
+	llwork := lwork - indwrk
+	indwk2 := indwrk + n*n
+	llwrk2 := lwork - indwk2
+	impl.Dsytrd(uplo, n, a, lda, w, work[inde:], work[indtau:], work[indwrk:], llwork)
+
+	// For eigenvalues only, call Dsterf. For eigenvectors, first call Dstedc
+	// to generate the eigenvector matrix of the tridiagonal matrix in
+	// workspace, then call Dormtr to multiply it by the Householder
+	// transformations stored in a.
+	if !wantz {
+		ok = impl.Dsterf(n, w, work[inde:])
+	} else {
+		ok = impl.Dstedc(lapack.TridiagEV, n, w, work[inde:], work[indwrk:], n,
+			work[indwk2:], llwrk2, iwork, liwork)
+		if ok {
+			impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, n, a, lda, work[indtau:],
+				work[indwrk:], n, work[indwk2:], llwrk2)
+			impl.Dlacpy(blas.All, n, n, work[indwrk:], n, a, lda)
+		}
+	}
+	if !ok {
+		return false
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi := blas64.Implementation()
+		bi.Dscal(n, 1/sigma, w, 1)
+	}
+	work[0] = float64(lopt)
+	iwork[0] = liwmin
+	return true
+}
@@ -0,0 +1,280 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsyevr computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. The matrix is first reduced to tridiagonal form by
+// Dsytrd, and the eigenpairs of the tridiagonal matrix are then computed by
+// the Multiple Relatively Robust Representations algorithm in Dstemr. Once
+// the tridiagonal form is available, k eigenpairs are computed in O(n*k)
+// operations. If all eigenvalues but no eigenvectors are desired, Dsterf is
+// used instead of Dstemr. If Dstemr or Dsterf fail, the eigenpairs are
+// computed by bisection in Dstebz and inverse iteration in Dstein.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by uplo. On return, this triangular portion
+// of a, including the diagonal, is overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues. If abstol is not
+// greater than 2*n*eps, where eps is the machine precision, Dsyevr checks
+// whether the tridiagonal matrix defines its eigenvalues to high relative
+// accuracy, and if so, computes them to high relative accuracy. Otherwise
+// abstol is only used by Dstebz when Dstemr fails. If abstol is not positive,
+// Dstebz uses eps*|T| instead, where |T| is the 1-norm of the tridiagonal
+// matrix obtained by reducing A.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// orthonormal eigenvectors of A, the i-th column corresponding to w[i], and
+// the support of the i-th eigenvector is stored in isuppz[2*i] through
+// isuppz[2*i+1]. The support refers to the eigenvectors of the tridiagonal
+// matrix, so the eigenvectors of A are in general fully populated. z must
+// have at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise, and isuppz must have length at least twice the number of columns
+// of z. If jobz != lapack.ComputeEV, z and isuppz are not referenced.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. lwork must be at least max(1,26*n) and liwork must be at
+// least max(1,10*n), and Dsyevr will panic otherwise. If lwork == -1 or
+// liwork == -1, instead of computing Dsyevr the optimal work length is stored
+// into work[0] and the minimum iwork length is stored into iwork[0].
+//
+// Dsyevr returns the number of eigenvalues found and whether the computation
+// succeeded.
+func (impl Implementation) Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	alleig := rng == lapack.RangeAll
+	valeig := rng == lapack.RangeInterval
+	indeig := rng == lapack.RangeIndex
+	switch {
+	case !alleig && !valeig && !indeig:
+		panic(badRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case valeig && n > 0 && vl >= vu:
+		panic(badInterval)
+	case indeig && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case indeig && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	checkMatrix(n, n, a, lda)
+
+	lwmin := max(1, 26*n)
+	liwmin := max(1, 10*n)
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := max(impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1), impl.Ilaenv(1, "DORMTR", opts, n, -1, -1, -1))
+	lwkopt := max((nb+1)*n, lwmin)
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwkopt)
+		iwork[0] = liwmin
+		return 0, true
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+	if wantz {
+		nzc := n
+		if indeig {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(isuppz) < 2*nzc {
+			panic(badIsuppz)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0, true
+	}
+	if n == 1 {
+		work[0] = 26
+		if alleig || indeig || (vl < a[0] && a[0] <= vu) {
+			m = 1
+			w[0] = a[0]
+			if wantz {
+				z[0] = 1
+				isuppz[0] = 0
+				isuppz[1] = 0
+			}
+		}
+		return m, true
+	}
+
+	// Get machine constants.
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	abstll := abstol
+	vll, vuu := vl, vu
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if abstol > 0 {
+			abstll = abstol * sigma
+		}
+		if valeig {
+			vll *= sigma
+			vuu *= sigma
+		}
+	}
+
+	// Initialize indices into workspaces.
+	const indtau = 0
+	indd := indtau + n
+	inde := indd + n
+	inddd := inde + n
+	indee := inddd + n
+	indwk := indee + n
+	llwork := lwork - indwk
+
+	// Call Dsytrd to reduce the symmetric matrix to tridiagonal form.
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwk:], llwork)
+
+	// If all eigenvalues but no eigenvectors are desired, call Dsterf.
+	// Otherwise call Dstemr to compute the selected eigenvalues and, if
+	// desired, the eigenvectors of the tridiagonal matrix. If this fails,
+	// then try Dstebz.
+	bi := blas64.Implementation()
+	var done bool
+	copy(work[indee:indee+n-1], work[inde:inde+n-1])
+	if alleig && !wantz {
+		copy(w[:n], work[indd:indd+n])
+		done = impl.Dsterf(n, w, work[indee:])
+		if done {
+			m = n
+		}
+	} else {
+		// Dstemr overwrites its input, so pass copies of the
+		// tridiagonal matrix.
+		copy(work[inddd:inddd+n], work[indd:indd+n])
+		tryrac := abstol <= 2*float64(n)*eps
+		m, done = impl.Dstemr(jobz, rng, n, work[inddd:], work[indee:], vll, vuu, il, iu, w,
+			z, ldz, isuppz, tryrac, work[indwk:], llwork, iwork, liwork)
+		if done && wantz && m > 0 {
+			// Apply the orthogonal matrix used in the reduction to
+			// tridiagonal form.
+			indwkn := inde
+			llwrkn := lwork - indwkn
+			impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, m, a, lda, work[indtau:], z, ldz,
+				work[indwkn:], llwrkn)
+		}
+	}
+
+	// If Dstemr or Dsterf failed, call Dstebz and, if eigenvectors are
+	// desired, Dstein.
+	if !done {
+		const indibl = 0
+		indisp := indibl + n
+		indifl := indisp + n
+		indiwo := indifl + n
+		m, _, ok = impl.Dstebz(rng, wantz, n, vll, vuu, il, iu, abstll, work[indd:], work[inde:],
+			w, iwork[indibl:], iwork[indisp:], work[indwk:], iwork[indiwo:])
+		if !ok {
+			return 0, false
+		}
+		if wantz {
+			if !impl.Dstein(n, work[indd:], work[inde:], m, w, iwork[indibl:], iwork[indisp:],
+				z, ldz, work[indwk:], iwork[indiwo:], iwork[indifl:]) {
+				return 0, false
+			}
+			// The eigenvectors computed by Dstein are non-zero only
+			// within their block.
+			for j := 0; j < m; j++ {
+				b := iwork[indibl+j]
+				isuppz[2*j] = 0
+				if b > 0 {
+					isuppz[2*j] = iwork[indisp+b-1] + 1
+				}
+				isuppz[2*j+1] = iwork[indisp+b]
+			}
+
+			// Apply the orthogonal matrix used in the reduction to
+			// tridiagonal form.
+			indwkn := inde
+			llwrkn := lwork - indwkn
+			impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, m, a, lda, work[indtau:], z, ldz,
+				work[indwkn:], llwrkn)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+
+	// If eigenvalues are not in order, then sort them, along with the
+	// eigenvectors.
+	if wantz {
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp1 := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp1 {
+					i = jj
+					tmp1 = w[jj]
+				}
+			}
+			if i != -1 {
+				w[i] = w[j]
+				w[j] = tmp1
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				isuppz[2*i], isuppz[2*j] = isuppz[2*j], isuppz[2*i]
+				isuppz[2*i+1], isuppz[2*j+1] = isuppz[2*j+1], isuppz[2*i+1]
+			}
+		}
+	}
+	work[0] = float64(lwkopt)
+	iwork[0] = liwmin
+	return m, true
+}
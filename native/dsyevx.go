@@ -0,0 +1,274 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsyevx computes selected eigenvalues and, optionally, eigenvectors of a real
+// symmetric matrix A. The matrix is first reduced to tridiagonal form by
+// Dsytrd. The eigenvalues are then computed by bisection in Dstebz and the
+// eigenvectors, if desired, by inverse iteration in Dstein. If all
+// eigenvalues are requested and abstol is not positive, Dsterf or Dsteqr is
+// used instead.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a contains the elements of the symmetric matrix A in the
+// triangular portion specified by uplo. On return, this triangular portion
+// of a, including the diagonal, is overwritten.
+//
+// abstol is the absolute error tolerance for the eigenvalues. An eigenvalue
+// is considered to be located if it has been determined to lie in an
+// interval whose width is abstol or less. If abstol is not positive,
+// eps*|T| is used instead, where eps is the machine precision and |T| is the
+// 1-norm of the tridiagonal matrix obtained by reducing A. Eigenvalues are
+// computed most accurately when abstol is set to twice the underflow
+// threshold, not zero. If some eigenvectors fail to converge, setting abstol
+// to twice the underflow threshold may help.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// orthonormal eigenvectors of A, the i-th column corresponding to w[i]. If an
+// eigenvector fails to converge, the column of z contains the latest
+// approximation to the eigenvector and its index is stored in ifail. z must
+// have at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise, and ifail must have length at least n. If jobz !=
+// lapack.ComputeEV, z and ifail are not referenced.
+//
+// On return, ifail[:m] holds the indices of the eigenvectors that failed to
+// converge, followed by -1 for the remaining elements.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n), and Dsyevx will panic otherwise. For
+// good performance, lwork should generally be larger. If lwork == -1, instead
+// of computing Dsyevx the optimal work length is stored into work[0]. iwork
+// must have length at least 5*n.
+//
+// Dsyevx returns the number of eigenvalues found and whether the computation
+// succeeded. If ok is false and jobz == lapack.ComputeEV, some eigenvectors
+// failed to converge and their indices are stored in ifail.
+func (impl Implementation) Dsyevx(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	alleig := rng == lapack.RangeAll
+	valeig := rng == lapack.RangeInterval
+	indeig := rng == lapack.RangeIndex
+	switch {
+	case !alleig && !valeig && !indeig:
+		panic(badRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case valeig && n > 0 && vl >= vu:
+		panic(badInterval)
+	case indeig && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case indeig && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+	checkMatrix(n, n, a, lda)
+
+	lwmin := max(1, 8*n)
+	lwkopt := lwmin
+	if n > 1 {
+		opts := "L"
+		if uplo == blas.Upper {
+			opts = "U"
+		}
+		nb := max(impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1), impl.Ilaenv(1, "DORMTR", opts, n, -1, -1, -1))
+		lwkopt = max(lwmin, (nb+3)*n)
+	}
+	if lwork == -1 {
+		work[0] = float64(lwkopt)
+		return 0, true
+	}
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if len(iwork) < 5*n {
+		panic(badWork)
+	}
+	if wantz {
+		nzc := n
+		if indeig {
+			nzc = iu - il + 1
+		}
+		checkMatrix(n, nzc, z, ldz)
+		if len(ifail) < n {
+			panic(badIndex)
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0, true
+	}
+	if n == 1 {
+		work[0] = 1
+		if alleig || indeig || (vl < a[0] && a[0] <= vu) {
+			m = 1
+			w[0] = a[0]
+		}
+		if wantz {
+			z[0] = 1
+			ifail[0] = -1
+		}
+		return m, true
+	}
+
+	// Get machine constants.
+	safmin := dlamchS
+	eps := dlamchP
+	smlnum := safmin / eps
+	bignum := 1 / smlnum
+	rmin := math.Sqrt(smlnum)
+	rmax := math.Min(math.Sqrt(bignum), 1/math.Sqrt(math.Sqrt(safmin)))
+
+	// Scale matrix to allowable range, if necessary.
+	abstll := abstol
+	vll, vuu := vl, vu
+	anrm := impl.Dlansy(lapack.MaxAbs, uplo, n, a, lda, work)
+	scaled := false
+	var sigma float64
+	if anrm > 0 && anrm < rmin {
+		scaled = true
+		sigma = rmin / anrm
+	} else if anrm > rmax {
+		scaled = true
+		sigma = rmax / anrm
+	}
+	if scaled {
+		kind := lapack.LowerTri
+		if uplo == blas.Upper {
+			kind = lapack.UpperTri
+		}
+		impl.Dlascl(kind, 0, 0, 1, sigma, n, n, a, lda)
+		if abstol > 0 {
+			abstll = abstol * sigma
+		}
+		if valeig {
+			vll = vl * sigma
+			vuu = vu * sigma
+		}
+	}
+
+	// Initialize indices into workspaces.
+	const indtau = 0
+	inde := indtau + n
+	indd := inde + n
+	indwrk := indd + n
+	llwork := lwork - indwrk
+	const indibl = 0
+	indisp := indibl + n
+	indiwo := indisp + n
+
+	// Call Dsytrd to reduce the symmetric matrix to tridiagonal form.
+	impl.Dsytrd(uplo, n, a, lda, work[indd:], work[inde:], work[indtau:], work[indwrk:], llwork)
+
+	// If all eigenvalues are desired and abstol is not positive, then call
+	// Dsterf or Dorgtr and Dsteqr. If this fails for some eigenvalue, then
+	// try Dstebz.
+	bi := blas64.Implementation()
+	done := false
+	if (alleig || (indeig && il == 0 && iu == n-1)) && abstol <= 0 {
+		copy(w[:n], work[indd:indd+n])
+		indee := indwrk + 2*n
+		if !wantz {
+			copy(work[indee:indee+n-1], work[inde:inde+n-1])
+			done = impl.Dsterf(n, w, work[indee:])
+		} else {
+			impl.Dlacpy(blas.All, n, n, a, lda, z, ldz)
+			impl.Dorgtr(uplo, n, z, ldz, work[indtau:], work[indwrk:], llwork)
+			copy(work[indee:indee+n-1], work[inde:inde+n-1])
+			done = impl.Dsteqr(lapack.OriginalEV, n, w, work[indee:], z, ldz, work[indwrk:])
+			if done {
+				for i := range ifail[:n] {
+					ifail[i] = -1
+				}
+			}
+		}
+		if done {
+			m = n
+		}
+	}
+
+	// Otherwise, call Dstebz and, if eigenvectors are desired, Dstein.
+	ok = true
+	if !done {
+		m, _, ok = impl.Dstebz(rng, wantz, n, vll, vuu, il, iu, abstll, work[indd:], work[inde:],
+			w, iwork[indibl:], iwork[indisp:], work[indwrk:], iwork[indiwo:])
+		if !ok {
+			return 0, false
+		}
+		if wantz {
+			ok = impl.Dstein(n, work[indd:], work[inde:], m, w, iwork[indibl:], iwork[indisp:],
+				z, ldz, work[indwrk:], iwork[indiwo:], ifail)
+
+			// Apply the orthogonal matrix used in the reduction to
+			// tridiagonal form to the eigenvectors returned by Dstein.
+			indwkn := inde
+			llwrkn := lwork - indwkn
+			impl.Dormtr(blas.Left, uplo, blas.NoTrans, n, m, a, lda, work[indtau:], z, ldz,
+				work[indwkn:], llwrkn)
+		}
+	}
+
+	// If the matrix was scaled, then rescale eigenvalues appropriately.
+	if scaled {
+		bi.Dscal(m, 1/sigma, w, 1)
+	}
+
+	// If eigenvalues are not in order, then sort them, along with the
+	// eigenvectors.
+	if wantz {
+		for j := 0; j < m-1; j++ {
+			i := -1
+			tmp1 := w[j]
+			for jj := j + 1; jj < m; jj++ {
+				if w[jj] < tmp1 {
+					i = jj
+					tmp1 = w[jj]
+				}
+			}
+			if i != -1 {
+				w[i] = w[j]
+				w[j] = tmp1
+				bi.Dswap(n, z[i:], ldz, z[j:], ldz)
+				if !ok {
+					// Keep the indices of the failed eigenvectors
+					// consistent with the new column order.
+					for k := 0; k < m && ifail[k] != -1; k++ {
+						switch ifail[k] {
+						case i:
+							ifail[k] = j
+						case j:
+							ifail[k] = i
+						}
+					}
+				}
+			}
+		}
+	}
+
+	work[0] = float64(lwkopt)
+	return m, ok
+}
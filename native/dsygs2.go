@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsygs2 reduces a real symmetric-definite generalized eigenproblem to
+// standard form. It is the unblocked version of Dsygst and has the same
+// interface.
+//
+// Dsygs2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsygs2(itype int, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int) {
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+
+	bi := blas64.Implementation()
+	if itype == 1 {
+		if uplo == blas.Upper {
+			// Compute inv(U^T)*A*inv(U).
+			for k := 0; k < n; k++ {
+				// Update the upper triangle of A[k:n,k:n].
+				bkk := b[k*ldb+k]
+				akk := a[k*lda+k] / (bkk * bkk)
+				a[k*lda+k] = akk
+				if k < n-1 {
+					bi.Dscal(n-k-1, 1/bkk, a[k*lda+k+1:], 1)
+					ct := -0.5 * akk
+					bi.Daxpy(n-k-1, ct, b[k*ldb+k+1:], 1, a[k*lda+k+1:], 1)
+					bi.Dsyr2(uplo, n-k-1, -1, a[k*lda+k+1:], 1, b[k*ldb+k+1:], 1,
+						a[(k+1)*lda+k+1:], lda)
+					bi.Daxpy(n-k-1, ct, b[k*ldb+k+1:], 1, a[k*lda+k+1:], 1)
+					bi.Dtrsv(uplo, blas.Trans, blas.NonUnit, n-k-1, b[(k+1)*ldb+k+1:], ldb,
+						a[k*lda+k+1:], 1)
+				}
+			}
+			return
+		}
+		// Compute inv(L)*A*inv(L^T).
+		for k := 0; k < n; k++ {
+			// Update the lower triangle of A[k:n,k:n].
+			bkk := b[k*ldb+k]
+			akk := a[k*lda+k] / (bkk * bkk)
+			a[k*lda+k] = akk
+			if k < n-1 {
+				bi.Dscal(n-k-1, 1/bkk, a[(k+1)*lda+k:], lda)
+				ct := -0.5 * akk
+				bi.Daxpy(n-k-1, ct, b[(k+1)*ldb+k:], ldb, a[(k+1)*lda+k:], lda)
+				bi.Dsyr2(uplo, n-k-1, -1, a[(k+1)*lda+k:], lda, b[(k+1)*ldb+k:], ldb,
+					a[(k+1)*lda+k+1:], lda)
+				bi.Daxpy(n-k-1, ct, b[(k+1)*ldb+k:], ldb, a[(k+1)*lda+k:], lda)
+				bi.Dtrsv(uplo, blas.NoTrans, blas.NonUnit, n-k-1, b[(k+1)*ldb+k+1:], ldb,
+					a[(k+1)*lda+k:], lda)
+			}
+		}
+		return
+	}
+	if uplo == blas.Upper {
+		// Compute U*A*U^T.
+		for k := 0; k < n; k++ {
+			// Update the upper triangle of A[0:k+1,0:k+1].
+			akk := a[k*lda+k]
+			bkk := b[k*ldb+k]
+			bi.Dtrmv(uplo, blas.NoTrans, blas.NonUnit, k, b, ldb, a[k:], lda)
+			ct := 0.5 * akk
+			bi.Daxpy(k, ct, b[k:], ldb, a[k:], lda)
+			bi.Dsyr2(uplo, k, 1, a[k:], lda, b[k:], ldb, a, lda)
+			bi.Daxpy(k, ct, b[k:], ldb, a[k:], lda)
+			bi.Dscal(k, bkk, a[k:], lda)
+			a[k*lda+k] = akk * bkk * bkk
+		}
+		return
+	}
+	// Compute L^T*A*L.
+	for k := 0; k < n; k++ {
+		// Update the lower triangle of A[0:k+1,0:k+1].
+		akk := a[k*lda+k]
+		bkk := b[k*ldb+k]
+		bi.Dtrmv(uplo, blas.Trans, blas.NonUnit, k, b, ldb, a[k*lda:], 1)
+		ct := 0.5 * akk
+		bi.Daxpy(k, ct, b[k*ldb:], 1, a[k*lda:], 1)
+		bi.Dsyr2(uplo, k, 1, a[k*lda:], 1, b[k*ldb:], 1, a, lda)
+		bi.Daxpy(k, ct, b[k*ldb:], 1, a[k*lda:], 1)
+		bi.Dscal(k, bkk, a[k*lda:], 1)
+		a[k*lda+k] = akk * bkk * bkk
+	}
+}
@@ -0,0 +1,162 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsygst reduces a real symmetric-definite generalized eigenproblem to standard
+// form.
+//
+// If itype == 1, the problem is
+//  A*x = lambda*B*x,
+// and A is overwritten by
+//  inv(U^T)*A*inv(U) if uplo == blas.Upper,
+//  inv(L)*A*inv(L^T) if uplo == blas.Lower.
+//
+// If itype == 2 or 3, the problem is
+//  A*B*x = lambda*x or B*A*x = lambda*x,
+// and A is overwritten by
+//  U*A*U^T if uplo == blas.Upper,
+//  L^T*A*L if uplo == blas.Lower.
+//
+// On entry, a contains the upper or lower triangle of the n×n symmetric matrix
+// A as specified by uplo. On return, the same triangle is overwritten by the
+// transformed matrix.
+//
+// b must contain the triangular factor from the Cholesky factorization of B
+// as returned by Dpotrf, that is, B = U^T*U if uplo == blas.Upper and
+// B = L*L^T if uplo == blas.Lower.
+//
+// Dsygst will panic if itype is not 1, 2 or 3.
+//
+// Dsygst is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dsygst(itype int, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int) {
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+
+	// Quick return if possible.
+	if n == 0 {
+		return
+	}
+
+	// Determine the block size for this environment.
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := impl.Ilaenv(1, "DSYGST", opts, n, -1, -1, -1)
+	if nb <= 1 || n <= nb {
+		// Use unblocked code.
+		impl.Dsygs2(itype, uplo, n, a, lda, b, ldb)
+		return
+	}
+
+	// Use blocked code.
+	bi := blas64.Implementation()
+	if itype == 1 {
+		if uplo == blas.Upper {
+			// Compute inv(U^T)*A*inv(U).
+			for k := 0; k < n; k += nb {
+				kb := min(n-k, nb)
+				// Update the upper triangle of A[k:n,k:n].
+				impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+				if k+kb < n {
+					bi.Dtrsm(blas.Left, uplo, blas.Trans, blas.NonUnit, kb, n-k-kb,
+						1, b[k*ldb+k:], ldb, a[k*lda+k+kb:], lda)
+					bi.Dsymm(blas.Left, uplo, kb, n-k-kb, -0.5, a[k*lda+k:], lda,
+						b[k*ldb+k+kb:], ldb, 1, a[k*lda+k+kb:], lda)
+					// Apply the rank-2k update
+					//  A[k+kb:n,k+kb:n] -= A[k:k+kb,k+kb:n]^T * B[k:k+kb,k+kb:n] + B[k:k+kb,k+kb:n]^T * A[k:k+kb,k+kb:n]
+					// as a sequence of rank-2 updates instead of with
+					// Dsyr2k because Dsyr2k does not handle lda != ldb
+					// correctly when trans == blas.Trans.
+					for i := k; i < k+kb; i++ {
+						bi.Dsyr2(uplo, n-k-kb, -1, a[i*lda+k+kb:], 1, b[i*ldb+k+kb:], 1,
+							a[(k+kb)*lda+k+kb:], lda)
+					}
+					bi.Dsymm(blas.Left, uplo, kb, n-k-kb, -0.5, a[k*lda+k:], lda,
+						b[k*ldb+k+kb:], ldb, 1, a[k*lda+k+kb:], lda)
+					bi.Dtrsm(blas.Right, uplo, blas.NoTrans, blas.NonUnit, kb, n-k-kb,
+						1, b[(k+kb)*ldb+k+kb:], ldb, a[k*lda+k+kb:], lda)
+				}
+			}
+			return
+		}
+		// Compute inv(L)*A*inv(L^T).
+		for k := 0; k < n; k += nb {
+			kb := min(n-k, nb)
+			// Update the lower triangle of A[k:n,k:n].
+			impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+			if k+kb < n {
+				// Compute A[k+kb:n,k:k+kb] * inv(L[k:k+kb,k:k+kb]^T) one
+				// row at a time with Dtrsv instead of with a single call to
+				// Dtrsm because Dtrsm does not handle lda != ldb correctly
+				// for this combination of side, uplo and trans.
+				for i := k + kb; i < n; i++ {
+					bi.Dtrsv(uplo, blas.NoTrans, blas.NonUnit, kb, b[k*ldb+k:], ldb, a[i*lda+k:], 1)
+				}
+				bi.Dsymm(blas.Right, uplo, n-k-kb, kb, -0.5, a[k*lda+k:], lda,
+					b[(k+kb)*ldb+k:], ldb, 1, a[(k+kb)*lda+k:], lda)
+				bi.Dsyr2k(uplo, blas.NoTrans, n-k-kb, kb, -1, a[(k+kb)*lda+k:], lda,
+					b[(k+kb)*ldb+k:], ldb, 1, a[(k+kb)*lda+k+kb:], lda)
+				bi.Dsymm(blas.Right, uplo, n-k-kb, kb, -0.5, a[k*lda+k:], lda,
+					b[(k+kb)*ldb+k:], ldb, 1, a[(k+kb)*lda+k:], lda)
+				bi.Dtrsm(blas.Left, uplo, blas.NoTrans, blas.NonUnit, n-k-kb, kb,
+					1, b[(k+kb)*ldb+k+kb:], ldb, a[(k+kb)*lda+k:], lda)
+			}
+		}
+		return
+	}
+	if uplo == blas.Upper {
+		// Compute U*A*U^T.
+		for k := 0; k < n; k += nb {
+			kb := min(n-k, nb)
+			// Update the upper triangle of A[0:k+kb,0:k+kb].
+			bi.Dtrmm(blas.Left, uplo, blas.NoTrans, blas.NonUnit, k, kb,
+				1, b, ldb, a[k:], lda)
+			bi.Dsymm(blas.Right, uplo, k, kb, 0.5, a[k*lda+k:], lda,
+				b[k:], ldb, 1, a[k:], lda)
+			bi.Dsyr2k(uplo, blas.NoTrans, k, kb, 1, a[k:], lda,
+				b[k:], ldb, 1, a, lda)
+			bi.Dsymm(blas.Right, uplo, k, kb, 0.5, a[k*lda+k:], lda,
+				b[k:], ldb, 1, a[k:], lda)
+			bi.Dtrmm(blas.Right, uplo, blas.Trans, blas.NonUnit, k, kb,
+				1, b[k*ldb+k:], ldb, a[k:], lda)
+			impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+		}
+		return
+	}
+	// Compute L^T*A*L.
+	for k := 0; k < n; k += nb {
+		kb := min(n-k, nb)
+		// Update the lower triangle of A[0:k+kb,0:k+kb].
+		bi.Dtrmm(blas.Right, uplo, blas.NoTrans, blas.NonUnit, kb, k,
+			1, b, ldb, a[k*lda:], lda)
+		bi.Dsymm(blas.Left, uplo, kb, k, 0.5, a[k*lda+k:], lda,
+			b[k*ldb:], ldb, 1, a[k*lda:], lda)
+		// Apply the rank-2k update
+		//  A[0:k,0:k] += A[k:k+kb,0:k]^T * B[k:k+kb,0:k] + B[k:k+kb,0:k]^T * A[k:k+kb,0:k]
+		// as a sequence of rank-2 updates for the same reason as above.
+		for i := k; i < k+kb; i++ {
+			bi.Dsyr2(uplo, k, 1, a[i*lda:], 1, b[i*ldb:], 1, a, lda)
+		}
+		bi.Dsymm(blas.Left, uplo, kb, k, 0.5, a[k*lda+k:], lda,
+			b[k*ldb:], ldb, 1, a[k*lda:], lda)
+		bi.Dtrmm(blas.Left, uplo, blas.Trans, blas.NonUnit, kb, k,
+			1, b[k*ldb+k:], ldb, a[k*lda:], lda)
+		impl.Dsygs2(itype, uplo, kb, a[k*lda+k:], lda, b[k*ldb+k:], ldb)
+	}
+}
@@ -0,0 +1,124 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dsygv computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form
+//  A*x = lambda*B*x   if itype == 1,
+//  A*B*x = lambda*x   if itype == 2,
+//  B*A*x = lambda*x   if itype == 3,
+// where A and B are n×n symmetric matrices and B is also positive definite.
+// B is first factorized by Dpotrf, the problem is then reduced to standard
+// form by Dsygst and solved by Dsyev.
+//
+// On entry, a and b contain the upper or lower triangles of A and B as
+// specified by uplo. On return, if jobz == lapack.ComputeEV, a contains the
+// matrix Z of eigenvectors, the i-th column corresponding to w[i]. The
+// eigenvectors are normalized as follows:
+//  Z^T*B*Z = I      if itype == 1 or 2,
+//  Z^T*inv(B)*Z = I if itype == 3.
+// If jobz != lapack.ComputeEV, the triangle of a specified by uplo, including
+// the diagonal, is overwritten. On return, b contains the triangular factor U
+// or L from the Cholesky factorization B = U^T*U or B = L*L^T.
+//
+// w contains the eigenvalues in ascending order upon return. w must have
+// length at least n, and Dsygv will panic otherwise.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,3*n-1), and Dsygv will panic otherwise. For
+// good performance, lwork should generally be larger. If lwork == -1, instead
+// of computing Dsygv the optimal work length is stored into work[0].
+//
+// Dsygv returns whether the computation succeeded. If ok is false, either B
+// is not positive definite or the eigenvalue computation did not converge.
+func (impl Implementation) Dsygv(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+
+	lwkmin := max(1, 3*n-1)
+	opts := "L"
+	if uplo == blas.Upper {
+		opts = "U"
+	}
+	nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+	lwkopt := max(lwkmin, (nb+2)*n)
+	if lwork == -1 {
+		work[0] = float64(lwkopt)
+		return true
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < lwkmin || len(work) < lwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return true
+	}
+
+	// Form a Cholesky factorization of B.
+	if ok = impl.Dpotrf(uplo, n, b, ldb); !ok {
+		work[0] = float64(lwkopt)
+		return false
+	}
+
+	// Transform the problem to a standard eigenvalue problem and solve.
+	impl.Dsygst(itype, uplo, n, a, lda, b, ldb)
+	ok = impl.Dsyev(jobz, uplo, n, a, lda, w, work, lwork)
+
+	if ok && wantz {
+		// Backtransform the eigenvectors to the eigenvectors of the
+		// original problem.
+		impl.dsygvBacktransform(itype, uplo, n, n, b, ldb, a, lda)
+	}
+
+	work[0] = float64(lwkopt)
+	return ok
+}
+
+// dsygvBacktransform transforms the m eigenvectors stored in the columns of z
+// of the standard eigenproblem computed by Dsygst into the eigenvectors of the
+// original generalized problem of type itype. b contains the Cholesky factor
+// of B as returned by Dpotrf.
+func (Implementation) dsygvBacktransform(itype int, uplo blas.Uplo, n, m int, b []float64, ldb int, z []float64, ldz int) {
+	bi := blas64.Implementation()
+	if itype == 1 || itype == 2 {
+		// For A*x = lambda*B*x and A*B*x = lambda*x,
+		// backtransform eigenvectors: x = inv(L)^T*y or inv(U)*y.
+		trans := blas.Trans
+		if uplo == blas.Upper {
+			trans = blas.NoTrans
+		}
+		bi.Dtrsm(blas.Left, uplo, trans, blas.NonUnit, n, m, 1, b, ldb, z, ldz)
+		return
+	}
+	// For B*A*x = lambda*x,
+	// backtransform eigenvectors: x = L*y or U^T*y.
+	trans := blas.NoTrans
+	if uplo == blas.Upper {
+		trans = blas.Trans
+	}
+	bi.Dtrmm(blas.Left, uplo, trans, blas.NonUnit, n, m, 1, b, ldb, z, ldz)
+}
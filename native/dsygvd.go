@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dsygvd computes all eigenvalues and, optionally, the eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form
+//  A*x = lambda*B*x   if itype == 1,
+//  A*B*x = lambda*x   if itype == 2,
+//  B*A*x = lambda*x   if itype == 3,
+// where A and B are n×n symmetric matrices and B is also positive definite.
+// It has the same interface as Dsygv but solves the reduced standard problem
+// by Dsyevd which uses a divide and conquer algorithm if eigenvectors are
+// desired.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. If n <= 1, lwork and liwork must be at least 1. Otherwise,
+// if jobz == lapack.ComputeEV, lwork must be at least 1 + 6*n + 2*n^2 and
+// liwork must be at least 3 + 5*n, and if jobz != lapack.ComputeEV, lwork must
+// be at least 2*n + 1 and liwork must be at least 1. Dsygvd will panic if
+// these conditions are not met.
+//
+// If lwork == -1 or liwork == -1, instead of computing Dsygvd the optimal work
+// length is stored into work[0] and the minimum iwork length is stored into
+// iwork[0].
+//
+// Dsygvd returns whether the computation succeeded. If ok is false, either B
+// is not positive definite or the eigenvalue computation did not converge.
+func (impl Implementation) Dsygvd(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+
+	var lwmin, liwmin int
+	switch {
+	case n <= 1:
+		lwmin = 1
+		liwmin = 1
+	case wantz:
+		lwmin = 1 + 6*n + 2*n*n
+		liwmin = 3 + 5*n
+	default:
+		lwmin = 2*n + 1
+		liwmin = 1
+	}
+	lopt := lwmin
+	liopt := liwmin
+	if n > 1 {
+		opts := "L"
+		if uplo == blas.Upper {
+			opts = "U"
+		}
+		nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+		lopt = max(lwmin, 2*n+n*nb)
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lopt)
+		iwork[0] = liopt
+		return true
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if len(w) < n {
+		panic(badW)
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Form a Cholesky factorization of B.
+	if ok = impl.Dpotrf(uplo, n, b, ldb); !ok {
+		return false
+	}
+
+	// Transform the problem to a standard eigenvalue problem and solve.
+	impl.Dsygst(itype, uplo, n, a, lda, b, ldb)
+	ok = impl.Dsyevd(jobz, uplo, n, a, lda, w, work, lwork, iwork, liwork)
+	lopt = max(lopt, int(work[0]))
+	liopt = max(liopt, iwork[0])
+
+	if ok && wantz {
+		// Backtransform the eigenvectors to the eigenvectors of the
+		// original problem.
+		impl.dsygvBacktransform(itype, uplo, n, n, b, ldb, a, lda)
+	}
+
+	work[0] = float64(lopt)
+	iwork[0] = liopt
+	return ok
+}
@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dsygvx computes selected eigenvalues and, optionally, eigenvectors of a real
+// generalized symmetric-definite eigenproblem of the form
+//  A*x = lambda*B*x   if itype == 1,
+//  A*B*x = lambda*x   if itype == 2,
+//  B*A*x = lambda*x   if itype == 3,
+// where A and B are n×n symmetric matrices and B is also positive definite.
+// B is first factorized by Dpotrf, the problem is then reduced to standard
+// form by Dsygst and solved by Dsyevx.
+//
+// The eigenvalues to compute are selected by rng. If rng is lapack.RangeAll,
+// all eigenvalues are computed. If rng is lapack.RangeInterval, the
+// eigenvalues in the half-open interval (vl,vu] are computed, and vl must be
+// less than vu. If rng is lapack.RangeIndex, the il-th through iu-th
+// eigenvalues are computed, with indices starting at 0, and il and iu must
+// satisfy 0 <= il <= iu < n.
+//
+// On entry, a and b contain the upper or lower triangles of A and B as
+// specified by uplo. On return, the triangle of a specified by uplo,
+// including the diagonal, is overwritten, and b contains the triangular
+// factor U or L from the Cholesky factorization B = U^T*U or B = L*L^T.
+//
+// abstol is the absolute error tolerance for the eigenvalues. See the
+// documentation of Dsyevx for details.
+//
+// On return, the first m elements of w contain the selected eigenvalues in
+// ascending order. w must have length at least n.
+//
+// If jobz == lapack.ComputeEV, the first m columns of z contain on return the
+// eigenvectors, the i-th column corresponding to w[i]. The eigenvectors are
+// normalized as follows:
+//  Z^T*B*Z = I      if itype == 1 or 2,
+//  Z^T*inv(B)*Z = I if itype == 3.
+// If an eigenvector fails to converge, the column of z contains the latest
+// approximation to the eigenvector and its index is stored in ifail. z must
+// have at least iu-il+1 columns if rng is lapack.RangeIndex and n columns
+// otherwise, and ifail must have length at least n. If jobz !=
+// lapack.ComputeEV, z and ifail are not referenced.
+//
+// work is temporary storage, and lwork specifies the usable memory length.
+// lwork must be at least max(1,8*n), and Dsygvx will panic otherwise. For
+// good performance, lwork should generally be larger. If lwork == -1, instead
+// of computing Dsygvx the optimal work length is stored into work[0]. iwork
+// must have length at least 5*n.
+//
+// Dsygvx returns the number of eigenvalues found and whether the computation
+// succeeded. If ok is false, either B is not positive definite or some
+// eigenvectors failed to converge and their indices are stored in ifail.
+func (impl Implementation) Dsygvx(itype int, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool) {
+	wantz := jobz == lapack.ComputeEV
+	alleig := rng == lapack.RangeAll
+	valeig := rng == lapack.RangeInterval
+	indeig := rng == lapack.RangeIndex
+	switch {
+	case itype < 1 || 3 < itype:
+		panic(badItype)
+	case jobz != lapack.ComputeEV && jobz != lapack.None:
+		panic(badEVJob)
+	case !alleig && !valeig && !indeig:
+		panic(badRange)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case valeig && n > 0 && vl >= vu:
+		panic(badInterval)
+	case indeig && (il < 0 || il > max(0, n-1)):
+		panic(badIl)
+	case indeig && (iu < min(n-1, il) || iu >= max(1, n)):
+		panic(badIu)
+	}
+
+	lwmin := max(1, 8*n)
+	lwkopt := lwmin
+	if n > 1 {
+		opts := "L"
+		if uplo == blas.Upper {
+			opts = "U"
+		}
+		nb := impl.Ilaenv(1, "DSYTRD", opts, n, -1, -1, -1)
+		lwkopt = max(lwmin, (nb+3)*n)
+	}
+	if lwork == -1 {
+		work[0] = float64(lwkopt)
+		return 0, true
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return 0, true
+	}
+
+	// Form a Cholesky factorization of B.
+	if ok = impl.Dpotrf(uplo, n, b, ldb); !ok {
+		work[0] = float64(lwkopt)
+		return 0, false
+	}
+
+	// Transform the problem to a standard eigenvalue problem and solve.
+	impl.Dsygst(itype, uplo, n, a, lda, b, ldb)
+	m, ok = impl.Dsyevx(jobz, rng, uplo, n, a, lda, vl, vu, il, iu, abstol, w, z, ldz, work, lwork, iwork, ifail)
+
+	if wantz && m > 0 {
+		// Backtransform the eigenvectors to the eigenvectors of the
+		// original problem.
+		impl.dsygvBacktransform(itype, uplo, n, m, b, ldb, z, ldz)
+	}
+
+	work[0] = float64(lwkopt)
+	return m, ok
+}
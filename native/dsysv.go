@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dsysv computes the solution to a real system of linear equations
+//  A * X = B
+// where A is an n×n symmetric indefinite matrix and X and B are n×nrhs
+// matrices.
+//
+// The Bunch-Kaufman diagonal pivoting method is used to factor A as
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks. On return, the factorization is stored in a and ipiv as
+// described in the documentation for Dsytrf. ipiv must have length at least n
+// and Dsysv will panic otherwise.
+//
+// The factored form of A is then used to solve the system of equations
+// A * X = B. On entry, b contains the right hand side matrix B. On return, if
+// ok is true, b contains the solution matrix X.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Dsysv will panic otherwise. If lwork == -1, instead
+// of computing Dsysv the optimal work length is stored into work[0].
+//
+// Dsysv returns false if D is exactly singular. In that case the
+// factorization has been completed, but the solution has not been computed.
+func (impl Implementation) Dsysv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool) {
+	return impl.dsysv(uplo, n, nrhs, a, lda, ipiv, b, ldb, work, lwork, false)
+}
+
+// dsysv solves a symmetric indefinite system of linear equations using either
+// the Bunch-Kaufman or, if rook is true, the bounded Bunch-Kaufman ("rook")
+// diagonal pivoting method.
+func (impl Implementation) dsysv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int, rook bool) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+
+	if lwork == -1 {
+		// Query the optimal work length of the factorization.
+		return impl.dsytrf(uplo, n, a, lda, ipiv, work, -1, rook)
+	}
+
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Compute the factorization A = U*D*U^T or A = L*D*L^T.
+	ok = impl.dsytrf(uplo, n, a, lda, ipiv, work, lwork, rook)
+	if !ok {
+		return false
+	}
+	// Solve the system A*X = B, overwriting B with X.
+	impl.dsytrs(uplo, n, nrhs, a, lda, ipiv, b, ldb, rook)
+	return true
+}
@@ -0,0 +1,342 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsytf2Rook computes the factorization of a real symmetric indefinite n×n
+// matrix A using the bounded Bunch-Kaufman ("rook") diagonal pivoting method.
+// The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks. The storage of the factors in a and the pivot indices in
+// ipiv are described in the documentation for DsytrfRook.
+//
+// ipiv must have length at least n, otherwise Dsytf2Rook will panic.
+//
+// Dsytf2Rook returns whether the block diagonal matrix D is nonsingular. If ok
+// is false, the factorization has been completed, but D is exactly singular
+// and division by zero will occur if it is used to solve a system of
+// equations.
+//
+// This is the unblocked version of the algorithm.
+//
+// Dsytf2Rook is an internal routine. It is exported for testing purposes.
+func (Implementation) Dsytf2Rook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	bi := blas64.Implementation()
+
+	// alpha is used for pivot selection.
+	alpha := (1 + math.Sqrt(17)) / 8
+	sfmin := dlamchS
+
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize A as U*D*U^T using the upper triangle of A. k is the
+		// main loop index, decreasing from n-1 in steps of 1 or 2.
+		for k := n - 1; k >= 0; {
+			kstep := 1
+			p := k
+
+			// Determine rows and columns to be interchanged and whether a
+			// 1×1 or 2×2 pivot block will be used.
+			absakk := math.Abs(a[k*lda+k])
+			// imax is the row index of the largest off-diagonal element
+			// in column k, and colmax is its absolute value.
+			var imax int
+			var colmax float64
+			if k > 0 {
+				imax = bi.Idamax(k, a[k:], lda)
+				colmax = math.Abs(a[imax*lda+k])
+			}
+
+			var kp int
+			if math.Max(absakk, colmax) == 0 {
+				// Column k is zero.
+				ok = false
+				kp = k
+			} else {
+				// The negated comparisons below handle NaN and Inf.
+				if !(absakk < alpha*colmax) {
+					// No interchange, use 1×1 pivot block.
+					kp = k
+				} else {
+					for {
+						// jmax is the column index of the largest
+						// off-diagonal element in row imax, and rowmax is
+						// its absolute value.
+						var jmax int
+						var rowmax float64
+						if imax != k {
+							jmax = imax + 1 + bi.Idamax(k-imax, a[imax*lda+imax+1:], 1)
+							rowmax = math.Abs(a[imax*lda+jmax])
+						}
+						if imax > 0 {
+							itemp := bi.Idamax(imax, a[imax:], lda)
+							dtemp := math.Abs(a[itemp*lda+imax])
+							if dtemp > rowmax {
+								rowmax = dtemp
+								jmax = itemp
+							}
+						}
+
+						if !(math.Abs(a[imax*lda+imax]) < alpha*rowmax) {
+							// Interchange rows and columns k and imax, use
+							// 1×1 pivot block.
+							kp = imax
+							break
+						}
+						if p == jmax || rowmax <= colmax {
+							// Interchange rows and columns k-1 and imax,
+							// use 2×2 pivot block.
+							kp = imax
+							kstep = 2
+							break
+						}
+						// Pivot not found, set variables and repeat.
+						p = imax
+						colmax = rowmax
+						imax = jmax
+					}
+				}
+
+				// Swap two rows and two columns.
+
+				// First swap.
+				if kstep == 2 && p != k {
+					// Interchange rows and columns k and p in the leading
+					// submatrix A[0:k+1,0:k+1].
+					bi.Dswap(p, a[k:], lda, a[p:], lda)
+					bi.Dswap(k-p-1, a[(p+1)*lda+k:], lda, a[p*lda+p+1:], 1)
+					a[k*lda+k], a[p*lda+p] = a[p*lda+p], a[k*lda+k]
+				}
+
+				// Second swap.
+				kk := k - kstep + 1
+				if kp != kk {
+					// Interchange rows and columns kk and kp in the
+					// leading submatrix A[0:k+1,0:k+1].
+					bi.Dswap(kp, a[kk:], lda, a[kp:], lda)
+					bi.Dswap(kk-kp-1, a[(kp+1)*lda+kk:], lda, a[kp*lda+kp+1:], 1)
+					a[kk*lda+kk], a[kp*lda+kp] = a[kp*lda+kp], a[kk*lda+kk]
+					if kstep == 2 {
+						a[(k-1)*lda+k], a[kp*lda+k] = a[kp*lda+k], a[(k-1)*lda+k]
+					}
+				}
+
+				// Update the leading submatrix.
+				if kstep == 1 {
+					// Perform a rank-1 update of A[0:k,0:k] as
+					//  A := A - U(k)*D(k)*U(k)^T = A - W(k)*1/D(k)*W(k)^T
+					// and store U(k) in column k.
+					if k > 0 {
+						if math.Abs(a[k*lda+k]) >= sfmin {
+							d11 := 1 / a[k*lda+k]
+							bi.Dsyr(blas.Upper, k, -d11, a[k:], lda, a, lda)
+							bi.Dscal(k, d11, a[k:], lda)
+						} else {
+							d11 := a[k*lda+k]
+							for i := 0; i < k; i++ {
+								a[i*lda+k] /= d11
+							}
+							bi.Dsyr(blas.Upper, k, -d11, a[k:], lda, a, lda)
+						}
+					}
+				} else if k > 1 {
+					// Perform a rank-2 update of A[0:k-1,0:k-1] as
+					//  A := A - ( U(k-1) U(k) )*D(k)*( U(k-1) U(k) )^T
+					//     = A - ( W(k-1) W(k) )*inv(D(k))*( W(k-1) W(k) )^T
+					// and store U(k) and U(k-1) in columns k and k-1.
+					d12 := a[(k-1)*lda+k]
+					d22 := a[(k-1)*lda+k-1] / d12
+					d11 := a[k*lda+k] / d12
+					t := 1 / (d11*d22 - 1)
+					for j := k - 2; j >= 0; j-- {
+						wkm1 := t * (d11*a[j*lda+k-1] - a[j*lda+k])
+						wk := t * (d22*a[j*lda+k] - a[j*lda+k-1])
+						for i := j; i >= 0; i-- {
+							a[i*lda+j] -= (a[i*lda+k]/d12)*wk + (a[i*lda+k-1]/d12)*wkm1
+						}
+						a[j*lda+k] = wk / d12
+						a[j*lda+k-1] = wkm1 / d12
+					}
+				}
+			}
+
+			// Store details of the interchanges in ipiv.
+			if kstep == 1 {
+				ipiv[k] = kp
+			} else {
+				ipiv[k] = -p - 1
+				ipiv[k-1] = -kp - 1
+			}
+			k -= kstep
+		}
+		return ok
+	}
+
+	// Factorize A as L*D*L^T using the lower triangle of A. k is the main
+	// loop index, increasing from 0 in steps of 1 or 2.
+	for k := 0; k < n; {
+		kstep := 1
+		p := k
+
+		// Determine rows and columns to be interchanged and whether a 1×1
+		// or 2×2 pivot block will be used.
+		absakk := math.Abs(a[k*lda+k])
+		// imax is the row index of the largest off-diagonal element in
+		// column k, and colmax is its absolute value.
+		var imax int
+		var colmax float64
+		if k < n-1 {
+			imax = k + 1 + bi.Idamax(n-k-1, a[(k+1)*lda+k:], lda)
+			colmax = math.Abs(a[imax*lda+k])
+		}
+
+		var kp int
+		if math.Max(absakk, colmax) == 0 {
+			// Column k is zero.
+			ok = false
+			kp = k
+		} else {
+			// The negated comparisons below handle NaN and Inf.
+			if !(absakk < alpha*colmax) {
+				// No interchange, use 1×1 pivot block.
+				kp = k
+			} else {
+				for {
+					// jmax is the column index of the largest off-diagonal
+					// element in row imax, and rowmax is its absolute
+					// value.
+					var jmax int
+					var rowmax float64
+					if imax != k {
+						jmax = k + bi.Idamax(imax-k, a[imax*lda+k:], 1)
+						rowmax = math.Abs(a[imax*lda+jmax])
+					}
+					if imax < n-1 {
+						itemp := imax + 1 + bi.Idamax(n-imax-1, a[(imax+1)*lda+imax:], lda)
+						dtemp := math.Abs(a[itemp*lda+imax])
+						if dtemp > rowmax {
+							rowmax = dtemp
+							jmax = itemp
+						}
+					}
+
+					if !(math.Abs(a[imax*lda+imax]) < alpha*rowmax) {
+						// Interchange rows and columns k and imax, use 1×1
+						// pivot block.
+						kp = imax
+						break
+					}
+					if p == jmax || rowmax <= colmax {
+						// Interchange rows and columns k+1 and imax, use
+						// 2×2 pivot block.
+						kp = imax
+						kstep = 2
+						break
+					}
+					// Pivot not found, set variables and repeat.
+					p = imax
+					colmax = rowmax
+					imax = jmax
+				}
+			}
+
+			// Swap two rows and two columns.
+
+			// First swap.
+			if kstep == 2 && p != k {
+				// Interchange rows and columns k and p in the trailing
+				// submatrix A[k:n,k:n].
+				if p < n-1 {
+					bi.Dswap(n-p-1, a[(p+1)*lda+k:], lda, a[(p+1)*lda+p:], lda)
+				}
+				bi.Dswap(p-k-1, a[(k+1)*lda+k:], lda, a[p*lda+k+1:], 1)
+				a[k*lda+k], a[p*lda+p] = a[p*lda+p], a[k*lda+k]
+			}
+
+			// Second swap.
+			kk := k + kstep - 1
+			if kp != kk {
+				// Interchange rows and columns kk and kp in the trailing
+				// submatrix A[k:n,k:n].
+				if kp < n-1 {
+					bi.Dswap(n-kp-1, a[(kp+1)*lda+kk:], lda, a[(kp+1)*lda+kp:], lda)
+				}
+				bi.Dswap(kp-kk-1, a[(kk+1)*lda+kk:], lda, a[kp*lda+kk+1:], 1)
+				a[kk*lda+kk], a[kp*lda+kp] = a[kp*lda+kp], a[kk*lda+kk]
+				if kstep == 2 {
+					a[(k+1)*lda+k], a[kp*lda+k] = a[kp*lda+k], a[(k+1)*lda+k]
+				}
+			}
+
+			// Update the trailing submatrix.
+			if kstep == 1 {
+				// Perform a rank-1 update of A[k+1:n,k+1:n] as
+				//  A := A - L(k)*D(k)*L(k)^T = A - W(k)*(1/D(k))*W(k)^T
+				// and store L(k) in column k.
+				if k < n-1 {
+					if math.Abs(a[k*lda+k]) >= sfmin {
+						d11 := 1 / a[k*lda+k]
+						bi.Dsyr(blas.Lower, n-k-1, -d11, a[(k+1)*lda+k:], lda, a[(k+1)*lda+k+1:], lda)
+						bi.Dscal(n-k-1, d11, a[(k+1)*lda+k:], lda)
+					} else {
+						d11 := a[k*lda+k]
+						for i := k + 1; i < n; i++ {
+							a[i*lda+k] /= d11
+						}
+						bi.Dsyr(blas.Lower, n-k-1, -d11, a[(k+1)*lda+k:], lda, a[(k+1)*lda+k+1:], lda)
+					}
+				}
+			} else if k < n-2 {
+				// Perform a rank-2 update of A[k+2:n,k+2:n] as
+				//  A := A - ( L(k) L(k+1) )*D(k)*( L(k) L(k+1) )^T
+				//     = A - ( W(k) W(k+1) )*inv(D(k))*( W(k) W(k+1) )^T
+				// and store L(k) and L(k+1) in columns k and k+1.
+				d21 := a[(k+1)*lda+k]
+				d11 := a[(k+1)*lda+k+1] / d21
+				d22 := a[k*lda+k] / d21
+				t := 1 / (d11*d22 - 1)
+				for j := k + 2; j < n; j++ {
+					wk := t * (d11*a[j*lda+k] - a[j*lda+k+1])
+					wkp1 := t * (d22*a[j*lda+k+1] - a[j*lda+k])
+					for i := j; i < n; i++ {
+						a[i*lda+j] -= (a[i*lda+k]/d21)*wk + (a[i*lda+k+1]/d21)*wkp1
+					}
+					a[j*lda+k] = wk / d21
+					a[j*lda+k+1] = wkp1 / d21
+				}
+			}
+		}
+
+		// Store details of the interchanges in ipiv.
+		if kstep == 1 {
+			ipiv[k] = kp
+		} else {
+			ipiv[k] = -p - 1
+			ipiv[k+1] = -kp - 1
+		}
+		k += kstep
+	}
+	return ok
+}
@@ -0,0 +1,166 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dsytrf computes the factorization of a real symmetric indefinite n×n matrix
+// A using the Bunch-Kaufman diagonal pivoting method. The form of the
+// factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// If uplo == blas.Upper, U is stored as
+//  U = P(n-1)*U(n-1)* ... *P(k)*U(k)* ...,
+// where k decreases from n-1 to 0 in steps of 1 or 2, and if uplo ==
+// blas.Lower, L is stored as
+//  L = P(0)*L(0)* ... *P(k)*L(k)* ...,
+// where k increases from 0 to n-1 in steps of 1 or 2. P(k) is a permutation
+// matrix as defined by ipiv[k], and U(k) (or L(k)) is a unit upper (lower)
+// triangular matrix that differs from the identity only in the column of the
+// 1×1 or the two columns of the 2×2 pivot block D(k). The non-trivial
+// elements of U(k) (or L(k)) and the elements of D are stored in the
+// corresponding triangle of a.
+//
+// ipiv contains details of the interchanges and the block structure of D.
+// ipiv must have length at least n and the pivot indices are zero-based. If
+// ipiv[k] >= 0, then rows and columns k and ipiv[k] were interchanged and
+// D[k,k] is a 1×1 diagonal block. If uplo == blas.Upper and
+//  ipiv[k] = ipiv[k-1] = -p-1 < 0,
+// then rows and columns k-1 and p were interchanged and D[k-1:k+1,k-1:k+1] is
+// a 2×2 diagonal block. If uplo == blas.Lower and
+//  ipiv[k] = ipiv[k+1] = -p-1 < 0,
+// then rows and columns k+1 and p were interchanged and D[k:k+2,k:k+2] is a
+// 2×2 diagonal block.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and Dsytrf will panic otherwise. The amount of blocking
+// is limited by the usable length. If lwork == -1, instead of computing
+// Dsytrf the optimal work length is stored into work[0].
+//
+// Dsytrf returns whether the block diagonal matrix D is nonsingular. If ok is
+// false, the factorization has been completed, but D is exactly singular and
+// division by zero will occur if it is used to solve a system of equations.
+func (impl Implementation) Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	return impl.dsytrf(uplo, n, a, lda, ipiv, work, lwork, false)
+}
+
+// dsytrf computes the factorization of a real symmetric indefinite matrix
+// using either the Bunch-Kaufman or the bounded Bunch-Kaufman ("rook")
+// diagonal pivoting method. See Dsytrf and DsytrfRook for details.
+func (impl Implementation) dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int, rook bool) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case lwork < 1 && lwork != -1:
+		panic(badWork)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+
+	// Determine the block size.
+	name := "DSYTRF"
+	if rook {
+		name = "DSYTRF_ROOK"
+	}
+	nb := impl.Ilaenv(1, name, string(uplo), n, -1, -1, -1)
+	if lwork == -1 {
+		work[0] = float64(max(1, n*nb))
+		return true
+	}
+
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	nbmin := 2
+	ldwork := nb
+	if 1 < nb && nb < n {
+		if lwork < n*nb {
+			nb = max(lwork/n, 1)
+			nbmin = max(2, impl.Ilaenv(2, name, string(uplo), n, -1, -1, -1))
+			ldwork = nb
+		}
+	}
+	if nb < nbmin {
+		nb = n
+	}
+
+	unblocked := impl.Dsytf2
+	blocked := impl.Dlasyf
+	if rook {
+		unblocked = impl.Dsytf2Rook
+		blocked = impl.DlasyfRook
+	}
+
+	ok = true
+	if uplo == blas.Upper {
+		// Factorize A as U*D*U^T using the upper triangle of A. k is the
+		// number of leading columns of A that remain to be factorized,
+		// decreasing from n in steps of kb, the number of columns
+		// factorized by the last call to Dlasyf or Dsytf2.
+		for k := n; k > 0; {
+			var kb int
+			var blockOk bool
+			if k > nb {
+				// Factorize columns k-kb:k of A and use blocked code to
+				// update columns 0:k-kb.
+				kb, blockOk = blocked(uplo, k, nb, a, lda, ipiv, work, ldwork)
+			} else {
+				// Use unblocked code to factorize columns 0:k of A.
+				blockOk = unblocked(uplo, k, a, lda, ipiv)
+				kb = k
+			}
+			if !blockOk {
+				ok = false
+			}
+			k -= kb
+		}
+		return ok
+	}
+
+	// Factorize A as L*D*L^T using the lower triangle of A. k is the first
+	// column of A that remains to be factorized, increasing from 0 in steps
+	// of kb, the number of columns factorized by the last call to Dlasyf or
+	// Dsytf2.
+	for k := 0; k < n; {
+		var kb int
+		var blockOk bool
+		if k < n-nb {
+			// Factorize columns k:k+kb of A and use blocked code to update
+			// columns k+kb:n.
+			kb, blockOk = blocked(uplo, n-k, nb, a[k*lda+k:], lda, ipiv[k:], work, ldwork)
+		} else {
+			// Use unblocked code to factorize columns k:n of A.
+			blockOk = unblocked(uplo, n-k, a[k*lda+k:], lda, ipiv[k:])
+			kb = n - k
+		}
+		if !blockOk {
+			ok = false
+		}
+		// Adjust ipiv.
+		for j := k; j < k+kb; j++ {
+			if ipiv[j] >= 0 {
+				ipiv[j] += k
+			} else {
+				ipiv[j] -= k
+			}
+		}
+		k += kb
+	}
+	return ok
+}
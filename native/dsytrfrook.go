@@ -0,0 +1,49 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// DsytrfRook computes the factorization of a real symmetric indefinite n×n
+// matrix A using the bounded Bunch-Kaufman ("rook") diagonal pivoting method.
+// The form of the factorization is
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// where U (or L) is a product of permutation and unit upper (lower)
+// triangular matrices, and D is symmetric and block diagonal with 1×1 and 2×2
+// diagonal blocks.
+//
+// In contrast to Dsytrf, the rook pivoting strategy bounds the elements of U
+// (or L), which makes the factorization more accurate for some matrices at
+// the cost of a possibly larger number of comparisons during the pivot search.
+//
+// The factors U (or L) and D are stored in a in the same way as in Dsytrf,
+// but the pivot indices in ipiv differ for 2×2 diagonal blocks. ipiv must
+// have length at least n and the pivot indices are zero-based. If
+// ipiv[k] >= 0, then rows and columns k and ipiv[k] were interchanged and
+// D[k,k] is a 1×1 diagonal block. If uplo == blas.Upper and both
+//  ipiv[k] = -p-1 < 0  and  ipiv[k-1] = -q-1 < 0,
+// then rows and columns k and p were interchanged, rows and columns k-1 and q
+// were interchanged, and D[k-1:k+1,k-1:k+1] is a 2×2 diagonal block. If
+// uplo == blas.Lower and both
+//  ipiv[k] = -p-1 < 0  and  ipiv[k+1] = -q-1 < 0,
+// then rows and columns k and p were interchanged, rows and columns k+1 and q
+// were interchanged, and D[k:k+2,k:k+2] is a 2×2 diagonal block.
+//
+// The factorization computed by DsytrfRook must be used with DsytrsRook,
+// DsytriRook and DsyconRook.
+//
+// work is temporary storage, and lwork specifies the usable memory length. At
+// minimum, lwork >= 1, and DsytrfRook will panic otherwise. The amount of
+// blocking is limited by the usable length. If lwork == -1, instead of
+// computing DsytrfRook the optimal work length is stored into work[0].
+//
+// DsytrfRook returns whether the block diagonal matrix D is nonsingular. If ok
+// is false, the factorization has been completed, but D is exactly singular
+// and division by zero will occur if it is used to solve a system of
+// equations.
+func (impl Implementation) DsytrfRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool) {
+	return impl.dsytrf(uplo, n, a, lda, ipiv, work, lwork, true)
+}
@@ -0,0 +1,207 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsytri computes the inverse of a real symmetric indefinite n×n matrix A
+// using its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by Dsytrf.
+//
+// On entry, a and ipiv must contain the details of the block diagonal matrix D
+// and the multipliers used to obtain the factor U or L as returned by Dsytrf.
+// On return, a contains the upper or lower triangle of the (symmetric) inverse
+// of A, overwriting the factors.
+//
+// ipiv must have length at least n and work must have length at least n,
+// otherwise Dsytri will panic.
+//
+// Dsytri returns whether the matrix A is invertible. If Dsytri returns false,
+// D is exactly singular and the inverse could not be computed.
+func (impl Implementation) Dsytri(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool) {
+	return impl.dsytri(uplo, n, a, lda, ipiv, work, false)
+}
+
+// dsytri computes the inverse of a symmetric indefinite matrix factorized by
+// Dsytrf or, if rook is true, by DsytrfRook.
+func (Implementation) dsytri(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, rook bool) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+	if len(work) < n {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	// Check that the diagonal matrix D is nonsingular.
+	for i := 0; i < n; i++ {
+		if ipiv[i] >= 0 && a[i*lda+i] == 0 {
+			return false
+		}
+	}
+
+	bi := blas64.Implementation()
+
+	if uplo == blas.Upper {
+		// swap interchanges rows and columns k and kp in the leading
+		// submatrix A[0:k+1,0:k+1], and if kstep == 2 the elements
+		// A[k,k+1] and A[kp,k+1].
+		swap := func(k, kp, kstep int) {
+			bi.Dswap(kp, a[k:], lda, a[kp:], lda)
+			bi.Dswap(k-kp-1, a[(kp+1)*lda+k:], lda, a[kp*lda+kp+1:], 1)
+			a[k*lda+k], a[kp*lda+kp] = a[kp*lda+kp], a[k*lda+k]
+			if kstep == 2 {
+				a[k*lda+k+1], a[kp*lda+k+1] = a[kp*lda+k+1], a[k*lda+k+1]
+			}
+		}
+
+		// Compute inv(A) from the factorization A = U*D*U^T. k is the main
+		// loop index, increasing from 0 to n-1 in steps of 1 or 2,
+		// depending on the size of the diagonal blocks.
+		for k := 0; k < n; {
+			var kstep int
+			if ipiv[k] >= 0 {
+				// 1×1 diagonal block. Invert the diagonal block.
+				a[k*lda+k] = 1 / a[k*lda+k]
+				// Compute column k of the inverse.
+				if k > 0 {
+					bi.Dcopy(k, a[k:], lda, work, 1)
+					bi.Dsymv(blas.Upper, k, -1, a, lda, work, 1, 0, a[k:], lda)
+					a[k*lda+k] -= bi.Ddot(k, work, 1, a[k:], lda)
+				}
+				kstep = 1
+			} else {
+				// 2×2 diagonal block. Invert the diagonal block.
+				t := math.Abs(a[k*lda+k+1])
+				ak := a[k*lda+k] / t
+				akp1 := a[(k+1)*lda+k+1] / t
+				akkp1 := a[k*lda+k+1] / t
+				d := t * (ak*akp1 - 1)
+				a[k*lda+k] = akp1 / d
+				a[(k+1)*lda+k+1] = ak / d
+				a[k*lda+k+1] = -akkp1 / d
+				// Compute columns k and k+1 of the inverse.
+				if k > 0 {
+					bi.Dcopy(k, a[k:], lda, work, 1)
+					bi.Dsymv(blas.Upper, k, -1, a, lda, work, 1, 0, a[k:], lda)
+					a[k*lda+k] -= bi.Ddot(k, work, 1, a[k:], lda)
+					a[k*lda+k+1] -= bi.Ddot(k, a[k:], lda, a[k+1:], lda)
+					bi.Dcopy(k, a[k+1:], lda, work, 1)
+					bi.Dsymv(blas.Upper, k, -1, a, lda, work, 1, 0, a[k+1:], lda)
+					a[(k+1)*lda+k+1] -= bi.Ddot(k, work, 1, a[k+1:], lda)
+				}
+				kstep = 2
+			}
+
+			// Interchange rows and columns k and kp in the leading
+			// submatrix A[0:k+kstep,0:k+kstep].
+			kp := ipiv[k]
+			if kp < 0 {
+				kp = -kp - 1
+			}
+			if kp != k {
+				swap(k, kp, kstep)
+			}
+			if rook && kstep == 2 {
+				// Interchange rows and columns k+1 and -ipiv[k+1]-1.
+				kp = -ipiv[k+1] - 1
+				if kp != k+1 {
+					swap(k+1, kp, 1)
+				}
+			}
+			k += kstep
+		}
+		return true
+	}
+
+	// swap interchanges rows and columns k and kp in the trailing submatrix
+	// A[k:n,k:n], and if kstep == 2 the elements A[k,k-1] and A[kp,k-1].
+	swap := func(k, kp, kstep int) {
+		if kp < n-1 {
+			bi.Dswap(n-kp-1, a[(kp+1)*lda+k:], lda, a[(kp+1)*lda+kp:], lda)
+		}
+		bi.Dswap(kp-k-1, a[(k+1)*lda+k:], lda, a[kp*lda+k+1:], 1)
+		a[k*lda+k], a[kp*lda+kp] = a[kp*lda+kp], a[k*lda+k]
+		if kstep == 2 {
+			a[k*lda+k-1], a[kp*lda+k-1] = a[kp*lda+k-1], a[k*lda+k-1]
+		}
+	}
+
+	// Compute inv(A) from the factorization A = L*D*L^T. k is the main loop
+	// index, decreasing from n-1 to 0 in steps of 1 or 2, depending on the
+	// size of the diagonal blocks.
+	for k := n - 1; k >= 0; {
+		var kstep int
+		if ipiv[k] >= 0 {
+			// 1×1 diagonal block. Invert the diagonal block.
+			a[k*lda+k] = 1 / a[k*lda+k]
+			// Compute column k of the inverse.
+			if k < n-1 {
+				bi.Dcopy(n-k-1, a[(k+1)*lda+k:], lda, work, 1)
+				bi.Dsymv(blas.Lower, n-k-1, -1, a[(k+1)*lda+k+1:], lda, work, 1, 0, a[(k+1)*lda+k:], lda)
+				a[k*lda+k] -= bi.Ddot(n-k-1, work, 1, a[(k+1)*lda+k:], lda)
+			}
+			kstep = 1
+		} else {
+			// 2×2 diagonal block. Invert the diagonal block.
+			t := math.Abs(a[k*lda+k-1])
+			ak := a[(k-1)*lda+k-1] / t
+			akp1 := a[k*lda+k] / t
+			akkp1 := a[k*lda+k-1] / t
+			d := t * (ak*akp1 - 1)
+			a[(k-1)*lda+k-1] = akp1 / d
+			a[k*lda+k] = ak / d
+			a[k*lda+k-1] = -akkp1 / d
+			// Compute columns k-1 and k of the inverse.
+			if k < n-1 {
+				bi.Dcopy(n-k-1, a[(k+1)*lda+k:], lda, work, 1)
+				bi.Dsymv(blas.Lower, n-k-1, -1, a[(k+1)*lda+k+1:], lda, work, 1, 0, a[(k+1)*lda+k:], lda)
+				a[k*lda+k] -= bi.Ddot(n-k-1, work, 1, a[(k+1)*lda+k:], lda)
+				a[k*lda+k-1] -= bi.Ddot(n-k-1, a[(k+1)*lda+k:], lda, a[(k+1)*lda+k-1:], lda)
+				bi.Dcopy(n-k-1, a[(k+1)*lda+k-1:], lda, work, 1)
+				bi.Dsymv(blas.Lower, n-k-1, -1, a[(k+1)*lda+k+1:], lda, work, 1, 0, a[(k+1)*lda+k-1:], lda)
+				a[(k-1)*lda+k-1] -= bi.Ddot(n-k-1, work, 1, a[(k+1)*lda+k-1:], lda)
+			}
+			kstep = 2
+		}
+
+		// Interchange rows and columns k and kp in the trailing submatrix
+		// A[k-kstep+1:n,k-kstep+1:n].
+		kp := ipiv[k]
+		if kp < 0 {
+			kp = -kp - 1
+		}
+		if kp != k {
+			swap(k, kp, kstep)
+		}
+		if rook && kstep == 2 {
+			// Interchange rows and columns k-1 and -ipiv[k-1]-1.
+			kp = -ipiv[k-1] - 1
+			if kp != k-1 {
+				swap(k-1, kp, 1)
+			}
+		}
+		k -= kstep
+	}
+	return true
+}
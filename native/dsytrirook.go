@@ -0,0 +1,27 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// DsytriRook computes the inverse of a real symmetric indefinite n×n matrix A
+// using its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by DsytrfRook.
+//
+// On entry, a and ipiv must contain the details of the block diagonal matrix D
+// and the multipliers used to obtain the factor U or L as returned by
+// DsytrfRook. On return, a contains the upper or lower triangle of the
+// (symmetric) inverse of A, overwriting the factors.
+//
+// ipiv must have length at least n and work must have length at least n,
+// otherwise DsytriRook will panic.
+//
+// DsytriRook returns whether the matrix A is invertible. If DsytriRook returns
+// false, D is exactly singular and the inverse could not be computed.
+func (impl Implementation) DsytriRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool) {
+	return impl.dsytri(uplo, n, a, lda, ipiv, work, true)
+}
@@ -0,0 +1,209 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dsytrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric indefinite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by Dsytrf. a and ipiv must contain the details of the block
+// diagonal matrix D and the multipliers used to obtain the factor U or L as
+// returned by Dsytrf. ipiv must have length at least n, otherwise Dsytrs will
+// panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	impl.dsytrs(uplo, n, nrhs, a, lda, ipiv, b, ldb, false)
+}
+
+// dsytrs solves a system of linear equations with a symmetric indefinite
+// matrix factorized by Dsytrf or, if rook is true, by DsytrfRook.
+func (Implementation) dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, rook bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, nrhs, b, ldb)
+	if len(ipiv) < n {
+		panic(badIpiv)
+	}
+
+	// Quick return if possible.
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas64.Implementation()
+
+	// swap interchanges the rows i and ipiv[i] of B, where ipiv[i] may be
+	// encoded as negative for 2×2 diagonal blocks.
+	swap := func(i int) {
+		ip := ipiv[i]
+		if ip < 0 {
+			ip = -ip - 1
+		}
+		if ip != i {
+			bi.Dswap(nrhs, b[i*ldb:], 1, b[ip*ldb:], 1)
+		}
+	}
+
+	// solve2 solves the 2×2 system D(k)*X[k,:] = B[k,:] for the diagonal
+	// block D(k) with diagonal elements akm1 and ak and off-diagonal element
+	// akm1k, and rows km1 and k of B.
+	solve2 := func(akm1, ak, akm1k float64, km1, k int) {
+		akm1 /= akm1k
+		ak /= akm1k
+		denom := akm1*ak - 1
+		for j := 0; j < nrhs; j++ {
+			bkm1 := b[km1*ldb+j] / akm1k
+			bk := b[k*ldb+j] / akm1k
+			b[km1*ldb+j] = (ak*bkm1 - bk) / denom
+			b[k*ldb+j] = (akm1*bk - bkm1) / denom
+		}
+	}
+
+	if uplo == blas.Upper {
+		// Solve A*X = B, where A = U*D*U^T.
+
+		// First solve U*D*X = B, overwriting B with X. k is the main loop
+		// index, decreasing from n-1 to 0 in steps of 1 or 2, depending on
+		// the size of the diagonal blocks.
+		for k := n - 1; k >= 0; {
+			if ipiv[k] >= 0 {
+				// 1×1 diagonal block. Interchange rows k and ipiv[k].
+				swap(k)
+				// Multiply by inv(U(k)), where U(k) is the transformation
+				// stored in column k of A.
+				bi.Dger(k, nrhs, -1, a[k:], lda, b[k*ldb:], 1, b, ldb)
+				// Multiply by the inverse of the diagonal block.
+				bi.Dscal(nrhs, 1/a[k*lda+k], b[k*ldb:], 1)
+				k--
+				continue
+			}
+			// 2×2 diagonal block. Interchange rows k-1 and -ipiv[k]-1 and,
+			// for rook pivoting, rows k and -ipiv[k]-1 before.
+			if rook {
+				swap(k)
+			}
+			swap(k - 1)
+			// Multiply by inv(U(k)), where U(k) is the transformation
+			// stored in columns k-1 and k of A.
+			bi.Dger(k-1, nrhs, -1, a[k:], lda, b[k*ldb:], 1, b, ldb)
+			bi.Dger(k-1, nrhs, -1, a[k-1:], lda, b[(k-1)*ldb:], 1, b, ldb)
+			// Multiply by the inverse of the diagonal block.
+			solve2(a[(k-1)*lda+k-1], a[k*lda+k], a[(k-1)*lda+k], k-1, k)
+			k -= 2
+		}
+
+		// Next solve U^T*X = B, overwriting B with X. k is the main loop
+		// index, increasing from 0 to n-1 in steps of 1 or 2, depending on
+		// the size of the diagonal blocks.
+		for k := 0; k < n; {
+			if ipiv[k] >= 0 {
+				// 1×1 diagonal block. Multiply by inv(U^T(k)), where U(k)
+				// is the transformation stored in column k of A.
+				if k > 0 {
+					bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k:], lda, 1, b[k*ldb:], 1)
+				}
+				// Interchange rows k and ipiv[k].
+				swap(k)
+				k++
+				continue
+			}
+			// 2×2 diagonal block. Multiply by inv(U^T(k+1)), where U(k+1)
+			// is the transformation stored in columns k and k+1 of A.
+			if k > 0 {
+				bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k:], lda, 1, b[k*ldb:], 1)
+				bi.Dgemv(blas.Trans, k, nrhs, -1, b, ldb, a[k+1:], lda, 1, b[(k+1)*ldb:], 1)
+			}
+			// Interchange rows k and -ipiv[k]-1 and, for rook pivoting,
+			// rows k+1 and -ipiv[k+1]-1 after.
+			swap(k)
+			if rook {
+				swap(k + 1)
+			}
+			k += 2
+		}
+		return
+	}
+
+	// Solve A*X = B, where A = L*D*L^T.
+
+	// First solve L*D*X = B, overwriting B with X. k is the main loop index,
+	// increasing from 0 to n-1 in steps of 1 or 2, depending on the size of
+	// the diagonal blocks.
+	for k := 0; k < n; {
+		if ipiv[k] >= 0 {
+			// 1×1 diagonal block. Interchange rows k and ipiv[k].
+			swap(k)
+			// Multiply by inv(L(k)), where L(k) is the transformation
+			// stored in column k of A.
+			if k < n-1 {
+				bi.Dger(n-k-1, nrhs, -1, a[(k+1)*lda+k:], lda, b[k*ldb:], 1, b[(k+1)*ldb:], ldb)
+			}
+			// Multiply by the inverse of the diagonal block.
+			bi.Dscal(nrhs, 1/a[k*lda+k], b[k*ldb:], 1)
+			k++
+			continue
+		}
+		// 2×2 diagonal block. Interchange rows k+1 and -ipiv[k+1]-1 and, for
+		// rook pivoting, rows k and -ipiv[k]-1 before.
+		if rook {
+			swap(k)
+		}
+		swap(k + 1)
+		// Multiply by inv(L(k)), where L(k) is the transformation stored in
+		// columns k and k+1 of A.
+		if k < n-2 {
+			bi.Dger(n-k-2, nrhs, -1, a[(k+2)*lda+k:], lda, b[k*ldb:], 1, b[(k+2)*ldb:], ldb)
+			bi.Dger(n-k-2, nrhs, -1, a[(k+2)*lda+k+1:], lda, b[(k+1)*ldb:], 1, b[(k+2)*ldb:], ldb)
+		}
+		// Multiply by the inverse of the diagonal block.
+		solve2(a[k*lda+k], a[(k+1)*lda+k+1], a[(k+1)*lda+k], k, k+1)
+		k += 2
+	}
+
+	// Next solve L^T*X = B, overwriting B with X. k is the main loop index,
+	// decreasing from n-1 to 0 in steps of 1 or 2, depending on the size of
+	// the diagonal blocks.
+	for k := n - 1; k >= 0; {
+		if ipiv[k] >= 0 {
+			// 1×1 diagonal block. Multiply by inv(L^T(k)), where L(k) is
+			// the transformation stored in column k of A.
+			if k < n-1 {
+				bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k:], lda, 1, b[k*ldb:], 1)
+			}
+			// Interchange rows k and ipiv[k].
+			swap(k)
+			k--
+			continue
+		}
+		// 2×2 diagonal block. Multiply by inv(L^T(k-1)), where L(k-1) is the
+		// transformation stored in columns k-1 and k of A.
+		if k < n-1 {
+			bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k:], lda, 1, b[k*ldb:], 1)
+			bi.Dgemv(blas.Trans, n-k-1, nrhs, -1, b[(k+1)*ldb:], ldb, a[(k+1)*lda+k-1:], lda, 1, b[(k-1)*ldb:], 1)
+		}
+		// Interchange rows k and -ipiv[k]-1 and, for rook pivoting, rows k-1
+		// and -ipiv[k-1]-1 after.
+		swap(k)
+		if rook {
+			swap(k - 1)
+		}
+		k -= 2
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// DsytrsRook solves a system of n linear equations A*X = B where A is an n×n
+// symmetric indefinite matrix and B is an n×nrhs matrix. The matrix A is
+// represented by its factorization
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// as computed by DsytrfRook. a and ipiv must contain the details of the block
+// diagonal matrix D and the multipliers used to obtain the factor U or L as
+// returned by DsytrfRook. ipiv must have length at least n, otherwise
+// DsytrsRook will panic.
+//
+// On entry, b contains the right-hand side matrix B, on return it contains the
+// solution matrix X.
+func (impl Implementation) DsytrsRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) {
+	impl.dsytrs(uplo, n, nrhs, a, lda, ipiv, b, ldb, true)
+}
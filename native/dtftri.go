@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dtftri computes the inverse of an n×n triangular matrix A stored in
+// rectangular full packed (RFP) format. a contains the upper or lower triangle
+// of A in the RFP format described in the documentation of Dtrttf. transr must
+// be blas.NoTrans or blas.Trans, and a must have length at least n*(n+1)/2,
+// otherwise Dtftri will panic. On return, a contains the inverse of A in the
+// same format.
+//
+// Dtftri returns whether the matrix A is nonsingular. If ok is false, A is
+// exactly singular and the inverse has not been computed.
+func (impl Implementation) Dtftri(transr blas.Transpose, uplo blas.Uplo, diag blas.Diag, n int, a []float64) (ok bool) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case len(a) < n*(n+1)/2:
+		panic(badARF)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	l := rfpBlocks(transr, uplo, n)
+	nonUnit := diag == blas.NonUnit
+
+	// Check for singularity.
+	if nonUnit {
+		for i := 0; i < n; i++ {
+			if a[l.index(i, i)] == 0 {
+				return false
+			}
+		}
+	}
+	if n == 1 {
+		if nonUnit {
+			a[0] = 1 / a[0]
+		}
+		return true
+	}
+
+	// Write A, or A^T if uplo == blas.Upper, as the lower triangular matrix
+	//  T = [ T11  0  ]
+	//      [ T21 T22 ]
+	// with the inverse
+	//  inv(T) = [      inv(T11)           0     ]
+	//           [ -inv(T22)*T21*inv(T11) inv(T22) ]
+	// The RFP array stores T11 and T22 either as lower triangles or as
+	// transposed upper triangles, and T21 either as it is or transposed.
+	n1, n2, ldr := l.n1, l.n2, l.ldr
+	a1 := a[l.off1:]
+	a2 := a[l.off2:]
+	s := a[l.offS:]
+
+	bi := blas64.Implementation()
+	impl.Dtrtri(l.uplo1, diag, n1, a1, ldr)
+	if l.s21 {
+		// Compute T21 := -T21*inv(T11).
+		op := blas.NoTrans
+		if l.uplo1 == blas.Upper {
+			op = blas.Trans
+		}
+		bi.Dtrmm(blas.Right, l.uplo1, op, diag, n2, n1, -1, a1, ldr, s, ldr)
+		impl.Dtrtri(l.uplo2, diag, n2, a2, ldr)
+		// Compute T21 := inv(T22)*T21.
+		op = blas.NoTrans
+		if l.uplo2 == blas.Upper {
+			op = blas.Trans
+		}
+		bi.Dtrmm(blas.Left, l.uplo2, op, diag, n2, n1, 1, a2, ldr, s, ldr)
+		return true
+	}
+	// Compute T21^T := -inv(T11)^T*T21^T.
+	op := blas.Trans
+	if l.uplo1 == blas.Upper {
+		op = blas.NoTrans
+	}
+	bi.Dtrmm(blas.Left, l.uplo1, op, diag, n1, n2, -1, a1, ldr, s, ldr)
+	impl.Dtrtri(l.uplo2, diag, n2, a2, ldr)
+	// Compute T21^T := T21^T*inv(T22)^T.
+	op = blas.Trans
+	if l.uplo2 == blas.Upper {
+		op = blas.NoTrans
+	}
+	bi.Dtrmm(blas.Right, l.uplo2, op, diag, n1, n2, 1, a2, ldr, s, ldr)
+	return true
+}
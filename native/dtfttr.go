@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dtfttr copies an n×n symmetric or triangular matrix A stored in rectangular
+// full packed (RFP) format in arf into the upper or lower triangle of a in
+// conventional format. The RFP format is described in the documentation of
+// Dtrttf. The elements of a outside of the triangle are not referenced.
+//
+// transr must be blas.NoTrans or blas.Trans, and arf must have length at
+// least n*(n+1)/2, otherwise Dtfttr will panic.
+func (Implementation) Dtfttr(transr blas.Transpose, uplo blas.Uplo, n int, arf []float64, a []float64, lda int) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+	checkMatrix(n, n, a, max(1, lda))
+
+	l := rfpBlocks(transr, uplo, n)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j < n; j++ {
+				a[i*lda+j] = arf[l.index(i, j)]
+			}
+		} else {
+			for j := 0; j <= i; j++ {
+				a[i*lda+j] = arf[l.index(i, j)]
+			}
+		}
+	}
+}
@@ -0,0 +1,715 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtgevc computes some or all of the right and/or left eigenvectors of a pair
+// of real matrices (S,P), where S is a quasi-triangular matrix and P is upper
+// triangular. Matrix pairs of this type are produced by the generalized Schur
+// factorization of a matrix pair (A,B):
+//  A = Q * S * Z^T,
+//  B = Q * P * Z^T,
+// as computed by Dhgeqz.
+//
+// The right eigenvector x and the left eigenvector y of (S,P) corresponding
+// to an eigenvalue w are defined by
+//  S * x = w * P * x,
+//  y^H * S = w * y^H * P,
+// where y^H denotes the conjugate transpose of y. The eigenvalues are not
+// input to this routine, but are computed directly from the diagonal blocks
+// of S and P.
+//
+// S must be block upper triangular with 1×1 and 2×2 diagonal blocks, and the
+// 2×2 diagonal blocks of P corresponding to 2×2 blocks of S must be diagonal
+// with positive diagonal elements, as returned by Dhgeqz. Dtgevc will panic
+// otherwise.
+//
+// If side == lapack.RightEV, only right eigenvectors will be computed.
+// If side == lapack.LeftEV, only left eigenvectors will be computed.
+// If side == lapack.RightLeftEV, both right and left eigenvectors will be computed.
+// For other values of side, Dtgevc will panic.
+//
+// If howmny == lapack.AllEV, all right and/or left eigenvectors will be
+// computed.
+// If howmny == lapack.AllEVMulQ, all right and/or left eigenvectors will be
+// computed and multiplied from left by the matrices in VR and/or VL.
+// If howmny == lapack.SelectedEV, right and/or left eigenvectors will be
+// computed as indicated by selected.
+// For other values of howmny, Dtgevc will panic.
+//
+// selected specifies which eigenvectors will be computed. It must have length n
+// if howmny == lapack.SelectedEV, and it is not referenced otherwise.
+// If w_j is a real eigenvalue, the corresponding real eigenvector will be
+// computed if selected[j] is true.
+// If w_j and w_{j+1} are the real and imaginary parts of a complex eigenvalue,
+// the corresponding complex eigenvector is computed if either selected[j] or
+// selected[j+1] is true.
+//
+// VL and VR are n×mm matrices. If howmny is lapack.AllEV or
+// lapack.AllEVMulQ, mm must be at least n. If howmny ==
+// lapack.SelectedEV, mm must be large enough to store the selected
+// eigenvectors. Each selected real eigenvector occupies one column and each
+// selected complex eigenvector occupies two columns. If mm is not sufficiently
+// large, Dtgevc will panic.
+//
+// On entry, if howmny == lapack.AllEVMulQ, it is assumed that VL (if side
+// is lapack.LeftEV or lapack.RightLeftEV) contains an n×n matrix Q, and that
+// VR (if side is lapack.RightEV or lapack.RightLeftEV) contains an n×n matrix
+// Z. Q and Z are typically the orthogonal matrices of left and right Schur
+// vectors returned by Dhgeqz.
+//
+// On return, if side is lapack.LeftEV or lapack.RightLeftEV,
+// VL will contain:
+//  if howmny == lapack.AllEV,      the matrix Y of left eigenvectors of (S,P),
+//  if howmny == lapack.AllEVMulQ,  the matrix Q*Y,
+//  if howmny == lapack.SelectedEV, the left eigenvectors of (S,P) specified by
+//                                  selected, stored consecutively in the
+//                                  columns of VL, in the same order as their
+//                                  eigenvalues.
+// VL is not referenced if side == lapack.RightEV.
+//
+// On return, if side is lapack.RightEV or lapack.RightLeftEV,
+// VR will contain:
+//  if howmny == lapack.AllEV,      the matrix X of right eigenvectors of (S,P),
+//  if howmny == lapack.AllEVMulQ,  the matrix Z*X,
+//  if howmny == lapack.SelectedEV, the right eigenvectors of (S,P) specified by
+//                                  selected, stored consecutively in the
+//                                  columns of VR, in the same order as their
+//                                  eigenvalues.
+// VR is not referenced if side == lapack.LeftEV.
+//
+// Complex eigenvectors corresponding to a complex eigenvalue are stored in VL
+// and VR in two consecutive columns, the first holding the real part, and the
+// second the imaginary part.
+//
+// Each eigenvector will be normalized so that the element of largest magnitude
+// has magnitude 1. Here the magnitude of a complex number (x,y) is taken to be
+// |x| + |y|.
+//
+// work must have length at least 6*n, otherwise Dtgevc will panic.
+//
+// Dtgevc returns the number of columns in VL and/or VR actually used to store
+// the eigenvectors. ok will be false if a 2×2 diagonal block of (S,P) does not
+// have a complex eigenvalue, in which case the eigenvectors have not all been
+// computed.
+//
+// Dtgevc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgevc(side lapack.EVSide, howmny lapack.HowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool) {
+	switch side {
+	default:
+		panic(badSide)
+	case lapack.RightEV, lapack.LeftEV, lapack.RightLeftEV:
+	}
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV, lapack.AllEVMulQ, lapack.SelectedEV:
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, s, lds)
+	checkMatrix(n, n, p, ldp)
+	if len(work) < 6*n {
+		panic(badWork)
+	}
+
+	ilall := howmny != lapack.SelectedEV
+	ilback := howmny == lapack.AllEVMulQ
+	compl := side == lapack.LeftEV || side == lapack.RightLeftEV
+	compr := side == lapack.RightEV || side == lapack.RightLeftEV
+
+	// Count the number of eigenvectors to be computed.
+	if ilall {
+		m = n
+	} else {
+		if len(selected) != n {
+			panic("lapack: bad selected length")
+		}
+		for j := 0; j < n; {
+			if j == n-1 || s[(j+1)*lds+j] == 0 {
+				// Diagonal 1×1 block corresponding to a real
+				// eigenvalue.
+				if selected[j] {
+					m++
+				}
+				j++
+			} else {
+				// Diagonal 2×2 block corresponding to a complex
+				// eigenvalue.
+				if selected[j] || selected[j+1] {
+					m += 2
+				}
+				j += 2
+			}
+		}
+	}
+	if m > mm {
+		panic("lapack: insufficient number of columns")
+	}
+
+	// Check the 2×2 blocks in (S,P).
+	for j := 0; j < n-1; j++ {
+		if s[(j+1)*lds+j] == 0 {
+			continue
+		}
+		if p[j*ldp+j] == 0 || p[(j+1)*ldp+j+1] == 0 || p[j*ldp+j+1] != 0 {
+			panic("lapack: bad 2×2 diagonal block of P")
+		}
+		if j < n-2 && s[(j+2)*lds+j+1] != 0 {
+			panic("lapack: S is not quasi-triangular")
+		}
+	}
+	if compl && m > 0 {
+		checkMatrix(n, m, vl, ldvl)
+	}
+	if compr && m > 0 {
+		checkMatrix(n, m, vr, ldvr)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return 0, true
+	}
+
+	const safety = 100
+	safmin := dlamchS
+	ulp := dlamchP
+	small := safmin * float64(n) / ulp
+	big := 1 / small
+	bignum := 1 / (safmin * float64(n))
+
+	// Compute the 1-norm of each column of the strictly upper triangular
+	// part (i.e., excluding all elements belonging to the diagonal blocks)
+	// of S and P to check for possible overflow in the triangular solver.
+	anorm := math.Abs(s[0])
+	if n > 1 {
+		anorm += math.Abs(s[lds])
+	}
+	bnorm := math.Abs(p[0])
+	work[0] = 0
+	work[n] = 0
+	for j := 1; j < n; j++ {
+		var temp, temp2 float64
+		iend := j
+		if s[j*lds+j-1] != 0 {
+			iend = j - 1
+		}
+		for i := 0; i < iend; i++ {
+			temp += math.Abs(s[i*lds+j])
+			temp2 += math.Abs(p[i*ldp+j])
+		}
+		work[j] = temp
+		work[n+j] = temp2
+		for i := iend; i <= min(j+1, n-1); i++ {
+			temp += math.Abs(s[i*lds+j])
+			temp2 += math.Abs(p[i*ldp+j])
+		}
+		anorm = math.Max(anorm, temp)
+		bnorm = math.Max(bnorm, temp2)
+	}
+	ascale := 1 / math.Max(anorm, safmin)
+	bscale := 1 / math.Max(bnorm, safmin)
+
+	// realCoef computes the coefficients a and b in (a*S - b*P)*x = 0 for
+	// the real eigenvalue given by S[j,j] and P[j,j], scaled to avoid
+	// underflow.
+	realCoef := func(sjj, pjj float64) (acoef, bcoefr float64) {
+		temp := 1 / math.Max(math.Max(math.Abs(sjj)*ascale, math.Abs(pjj)*bscale), safmin)
+		salfar := (temp * sjj) * ascale
+		sbeta := (temp * pjj) * bscale
+		acoef = sbeta * ascale
+		bcoefr = salfar * bscale
+		// Scale to avoid underflow.
+		scale := 1.0
+		lsa := math.Abs(sbeta) >= safmin && math.Abs(acoef) < small
+		lsb := math.Abs(salfar) >= safmin && math.Abs(bcoefr) < small
+		if lsa {
+			scale = (small / math.Abs(sbeta)) * math.Min(anorm, big)
+		}
+		if lsb {
+			scale = math.Max(scale, (small/math.Abs(salfar))*math.Min(bnorm, big))
+		}
+		if lsa || lsb {
+			scale = math.Min(scale, 1/(safmin*math.Max(1, math.Max(math.Abs(acoef), math.Abs(bcoefr)))))
+			if lsa {
+				acoef = ascale * (scale * sbeta)
+			} else {
+				acoef *= scale
+			}
+			if lsb {
+				bcoefr = bscale * (scale * salfar)
+			} else {
+				bcoefr *= scale
+			}
+		}
+		return acoef, bcoefr
+	}
+
+	// scaleComplexCoef scales the coefficients a and b = br + i*bi of a
+	// complex eigenvalue to avoid over- and underflow.
+	scaleComplexCoef := func(acoef, bcoefr, bcoefi float64) (float64, float64, float64) {
+		acoefa := math.Abs(acoef)
+		bcoefa := math.Abs(bcoefr) + math.Abs(bcoefi)
+		scale := 1.0
+		if acoefa*ulp < safmin && acoefa >= safmin {
+			scale = (safmin / ulp) / acoefa
+		}
+		if bcoefa*ulp < safmin && bcoefa >= safmin {
+			scale = math.Max(scale, (safmin/ulp)/bcoefa)
+		}
+		if safmin*acoefa > ascale {
+			scale = ascale / (safmin * acoefa)
+		}
+		if safmin*bcoefa > bscale {
+			scale = math.Min(scale, bscale/(safmin*bcoefa))
+		}
+		if scale != 1 {
+			acoef *= scale
+			bcoefr *= scale
+			bcoefi *= scale
+		}
+		return acoef, bcoefr, bcoefi
+	}
+
+	bi := blas64.Implementation()
+	var (
+		bdiag            [2]float64
+		sums, sump, sum  [4]float64
+		x                [4]float64
+		acoef, acoefa    float64
+		bcoefr, bcoefi   float64
+		bcoefa, xmax     float64
+		ilcplx, il2by2   bool
+		ieig, ibeg, iend int
+	)
+
+	// In the computation below, the real and imaginary parts of the
+	// current eigenvector are held in work[2*n:3*n] and work[3*n:4*n], and
+	// the back-transformed eigenvector in work[4*n:6*n]. The small 2×2
+	// systems are solved in sum and x stored in row-major order.
+
+	if compl {
+		// Compute left eigenvectors.
+		ieig = 0
+		ilcplx = false
+		for je := 0; je < n; je++ {
+			// Skip this iteration if howmny == lapack.SelectedEV and
+			// the eigenvalue has not been selected, or if this would
+			// be the second of a complex pair.
+			if ilcplx {
+				ilcplx = false
+				continue
+			}
+			nw := 1
+			if je < n-1 && s[(je+1)*lds+je] != 0 {
+				ilcplx = true
+				nw = 2
+			}
+			if !ilall {
+				if ilcplx && !selected[je] && !selected[je+1] {
+					continue
+				}
+				if !ilcplx && !selected[je] {
+					continue
+				}
+			}
+
+			if !ilcplx && math.Abs(s[je*lds+je]) <= safmin && math.Abs(p[je*ldp+je]) <= safmin {
+				// Singular matrix pencil, return unit
+				// eigenvector.
+				for jr := 0; jr < n; jr++ {
+					vl[jr*ldvl+ieig] = 0
+				}
+				vl[ieig*ldvl+ieig] = 1
+				ieig++
+				continue
+			}
+
+			// Clear vector.
+			for jr := 0; jr < nw*n; jr++ {
+				work[2*n+jr] = 0
+			}
+
+			// Compute coefficients in (a*S - b*P)^T * y = 0 where a
+			// is acoef and b is bcoefr + i*bcoefi.
+			if !ilcplx {
+				// Real eigenvalue.
+				acoef, bcoefr = realCoef(s[je*lds+je], p[je*ldp+je])
+				bcoefi = 0
+				acoefa = math.Abs(acoef)
+				bcoefa = math.Abs(bcoefr)
+				// First component is 1.
+				work[2*n+je] = 1
+				xmax = 1
+			} else {
+				// Complex eigenvalue.
+				acoef, _, bcoefr, _, bcoefi = impl.Dlag2(s[je*lds+je:], lds, p[je*ldp+je:], ldp, safmin*safety)
+				bcoefi = -bcoefi
+				if bcoefi == 0 {
+					return m, false
+				}
+				acoef, bcoefr, bcoefi = scaleComplexCoef(acoef, bcoefr, bcoefi)
+				acoefa = math.Abs(acoef)
+				bcoefa = math.Abs(bcoefr) + math.Abs(bcoefi)
+
+				// Compute first two components of eigenvector.
+				temp := acoef * s[(je+1)*lds+je]
+				temp2r := acoef*s[je*lds+je] - bcoefr*p[je*ldp+je]
+				temp2i := -bcoefi * p[je*ldp+je]
+				if math.Abs(temp) > math.Abs(temp2r)+math.Abs(temp2i) {
+					work[2*n+je] = 1
+					work[3*n+je] = 0
+					work[2*n+je+1] = -temp2r / temp
+					work[3*n+je+1] = -temp2i / temp
+				} else {
+					work[2*n+je+1] = 1
+					work[3*n+je+1] = 0
+					temp = acoef * s[je*lds+je+1]
+					work[2*n+je] = (bcoefr*p[(je+1)*ldp+je+1] - acoef*s[(je+1)*lds+je+1]) / temp
+					work[3*n+je] = bcoefi * p[(je+1)*ldp+je+1] / temp
+				}
+				xmax = math.Max(math.Abs(work[2*n+je])+math.Abs(work[3*n+je]),
+					math.Abs(work[2*n+je+1])+math.Abs(work[3*n+je+1]))
+			}
+			dmin := math.Max(math.Max(ulp*acoefa*anorm, ulp*bcoefa*bnorm), safmin)
+
+			// Triangular solve of (a*S - b*P)^T * y = 0, rowwise in
+			// (a*S - b*P)^T, or columnwise in (a*S - b*P).
+			il2by2 = false
+			for j := je + nw; j < n; j++ {
+				if il2by2 {
+					il2by2 = false
+					continue
+				}
+				na := 1
+				bdiag[0] = p[j*ldp+j]
+				if j < n-1 && s[(j+1)*lds+j] != 0 {
+					il2by2 = true
+					bdiag[1] = p[(j+1)*ldp+j+1]
+					na = 2
+				}
+
+				// Check whether scaling is necessary for dot
+				// products.
+				xscale := 1 / math.Max(1, xmax)
+				temp := math.Max(math.Max(work[j], work[n+j]), acoefa*work[j]+bcoefa*work[n+j])
+				if il2by2 {
+					temp = math.Max(temp, math.Max(math.Max(work[j+1], work[n+j+1]), acoefa*work[j+1]+bcoefa*work[n+j+1]))
+				}
+				if temp > bignum*xscale {
+					for jw := 0; jw < nw; jw++ {
+						bi.Dscal(j-je, xscale, work[(jw+2)*n+je:], 1)
+					}
+					xmax *= xscale
+				}
+
+				// Compute dot products
+				//        j-1
+				//  sum = sum  conj(a*S[k,j] - b*P[k,j]) * x[k].
+				//        k=je
+				// To reduce the op count, this is done as
+				//  conj(a) * sum S[k,j]*x[k] - conj(b) * sum P[k,j]*x[k]
+				// which may cause underflow problems if S or P are
+				// close to underflow.
+				for jw := 0; jw < nw; jw++ {
+					for ja := 0; ja < na; ja++ {
+						sums[ja*2+jw] = 0
+						sump[ja*2+jw] = 0
+						for jr := je; jr < j; jr++ {
+							sums[ja*2+jw] += s[jr*lds+j+ja] * work[(jw+2)*n+jr]
+							sump[ja*2+jw] += p[jr*ldp+j+ja] * work[(jw+2)*n+jr]
+						}
+					}
+				}
+				for ja := 0; ja < na; ja++ {
+					if ilcplx {
+						sum[ja*2] = -acoef*sums[ja*2] + bcoefr*sump[ja*2] - bcoefi*sump[ja*2+1]
+						sum[ja*2+1] = -acoef*sums[ja*2+1] + bcoefr*sump[ja*2+1] + bcoefi*sump[ja*2]
+					} else {
+						sum[ja*2] = -acoef*sums[ja*2] + bcoefr*sump[ja*2]
+					}
+				}
+
+				// Solve (a*S - b*P)^T * y = sum with scaling and
+				// perturbation of the denominator.
+				scale, xnorm, _ := impl.Dlaln2(true, na, nw, dmin, acoef, s[j*lds+j:], lds, bdiag[0], bdiag[1], sum[:], 2, bcoefr, bcoefi, x[:], 2)
+				if scale < 1 {
+					for jw := 0; jw < nw; jw++ {
+						bi.Dscal(j-je, scale, work[(jw+2)*n+je:], 1)
+					}
+					xmax *= scale
+				}
+				xmax = math.Max(xmax, xnorm)
+				for jw := 0; jw < nw; jw++ {
+					for ja := 0; ja < na; ja++ {
+						work[(jw+2)*n+j+ja] = x[ja*2+jw]
+					}
+				}
+			}
+
+			// Copy eigenvector to VL, back transforming if
+			// howmny == lapack.AllEVMulQ.
+			if ilback {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dgemv(blas.NoTrans, n, n-je, 1, vl[je:], ldvl, work[(jw+2)*n+je:], 1, 0, work[(jw+4)*n:], 1)
+				}
+				for jw := 0; jw < nw; jw++ {
+					bi.Dcopy(n, work[(jw+4)*n:], 1, vl[je+jw:], ldvl)
+				}
+				ibeg = 0
+			} else {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dcopy(n, work[(jw+2)*n:], 1, vl[ieig+jw:], ldvl)
+				}
+				ibeg = je
+			}
+
+			// Scale eigenvector.
+			xmax = 0
+			for j := ibeg; j < n; j++ {
+				if ilcplx {
+					xmax = math.Max(xmax, math.Abs(vl[j*ldvl+ieig])+math.Abs(vl[j*ldvl+ieig+1]))
+				} else {
+					xmax = math.Max(xmax, math.Abs(vl[j*ldvl+ieig]))
+				}
+			}
+			if xmax > safmin {
+				xscale := 1 / xmax
+				for jw := 0; jw < nw; jw++ {
+					bi.Dscal(n-ibeg, xscale, vl[ibeg*ldvl+ieig+jw:], ldvl)
+				}
+			}
+			ieig += nw
+		}
+	}
+
+	if compr {
+		// Compute right eigenvectors.
+		ieig = m
+		ilcplx = false
+		for je := n - 1; je >= 0; je-- {
+			// Skip this iteration if howmny == lapack.SelectedEV and
+			// the eigenvalue has not been selected, or if this would
+			// be the second of a complex pair. If this is a complex
+			// pair, the 2×2 diagonal block corresponding to the
+			// eigenvalue is in rows and columns je-1 and je.
+			if ilcplx {
+				ilcplx = false
+				continue
+			}
+			nw := 1
+			if je > 0 && s[je*lds+je-1] != 0 {
+				ilcplx = true
+				nw = 2
+			}
+			if !ilall {
+				if ilcplx && !selected[je] && !selected[je-1] {
+					continue
+				}
+				if !ilcplx && !selected[je] {
+					continue
+				}
+			}
+
+			if !ilcplx && math.Abs(s[je*lds+je]) <= safmin && math.Abs(p[je*ldp+je]) <= safmin {
+				// Singular matrix pencil, return unit
+				// eigenvector.
+				ieig--
+				for jr := 0; jr < n; jr++ {
+					vr[jr*ldvr+ieig] = 0
+				}
+				vr[ieig*ldvr+ieig] = 1
+				continue
+			}
+
+			// Clear vector.
+			for jr := 0; jr < nw*n; jr++ {
+				work[2*n+jr] = 0
+			}
+
+			// Compute coefficients in (a*S - b*P) * x = 0 where a is
+			// acoef and b is bcoefr + i*bcoefi.
+			if !ilcplx {
+				// Real eigenvalue.
+				acoef, bcoefr = realCoef(s[je*lds+je], p[je*ldp+je])
+				bcoefi = 0
+				acoefa = math.Abs(acoef)
+				bcoefa = math.Abs(bcoefr)
+				// First component is 1.
+				work[2*n+je] = 1
+				xmax = 1
+				// Compute contribution from column je of S and P
+				// to sum.
+				for jr := 0; jr < je; jr++ {
+					work[2*n+jr] = bcoefr*p[jr*ldp+je] - acoef*s[jr*lds+je]
+				}
+			} else {
+				// Complex eigenvalue.
+				acoef, _, bcoefr, _, bcoefi = impl.Dlag2(s[(je-1)*lds+je-1:], lds, p[(je-1)*ldp+je-1:], ldp, safmin*safety)
+				if bcoefi == 0 {
+					return m, false
+				}
+				acoef, bcoefr, bcoefi = scaleComplexCoef(acoef, bcoefr, bcoefi)
+				acoefa = math.Abs(acoef)
+				bcoefa = math.Abs(bcoefr) + math.Abs(bcoefi)
+
+				// Compute first two components of eigenvector and
+				// contribution to sums.
+				temp := acoef * s[je*lds+je-1]
+				temp2r := acoef*s[je*lds+je] - bcoefr*p[je*ldp+je]
+				temp2i := -bcoefi * p[je*ldp+je]
+				if math.Abs(temp) >= math.Abs(temp2r)+math.Abs(temp2i) {
+					work[2*n+je] = 1
+					work[3*n+je] = 0
+					work[2*n+je-1] = -temp2r / temp
+					work[3*n+je-1] = -temp2i / temp
+				} else {
+					work[2*n+je-1] = 1
+					work[3*n+je-1] = 0
+					temp = acoef * s[(je-1)*lds+je]
+					work[2*n+je] = (bcoefr*p[(je-1)*ldp+je-1] - acoef*s[(je-1)*lds+je-1]) / temp
+					work[3*n+je] = bcoefi * p[(je-1)*ldp+je-1] / temp
+				}
+				xmax = math.Max(math.Abs(work[2*n+je])+math.Abs(work[3*n+je]),
+					math.Abs(work[2*n+je-1])+math.Abs(work[3*n+je-1]))
+
+				// Compute contribution from columns je and je-1 of
+				// S and P to the sums.
+				creala := acoef * work[2*n+je-1]
+				cimaga := acoef * work[3*n+je-1]
+				crealb := bcoefr*work[2*n+je-1] - bcoefi*work[3*n+je-1]
+				cimagb := bcoefi*work[2*n+je-1] + bcoefr*work[3*n+je-1]
+				cre2a := acoef * work[2*n+je]
+				cim2a := acoef * work[3*n+je]
+				cre2b := bcoefr*work[2*n+je] - bcoefi*work[3*n+je]
+				cim2b := bcoefi*work[2*n+je] + bcoefr*work[3*n+je]
+				for jr := 0; jr < je-1; jr++ {
+					work[2*n+jr] = -creala*s[jr*lds+je-1] + crealb*p[jr*ldp+je-1] - cre2a*s[jr*lds+je] + cre2b*p[jr*ldp+je]
+					work[3*n+jr] = -cimaga*s[jr*lds+je-1] + cimagb*p[jr*ldp+je-1] - cim2a*s[jr*lds+je] + cim2b*p[jr*ldp+je]
+				}
+			}
+			dmin := math.Max(math.Max(ulp*acoefa*anorm, ulp*bcoefa*bnorm), safmin)
+
+			// Columnwise triangular solve of (a*S - b*P) * x = 0.
+			il2by2 = false
+			for j := je - nw; j >= 0; j-- {
+				// If a 2×2 block is in position j-1:j, wait until
+				// next iteration to process it (when it will be
+				// j:j+1).
+				if !il2by2 && j > 0 && s[j*lds+j-1] != 0 {
+					il2by2 = true
+					continue
+				}
+				bdiag[0] = p[j*ldp+j]
+				na := 1
+				if il2by2 {
+					na = 2
+					bdiag[1] = p[(j+1)*ldp+j+1]
+				}
+
+				// Compute x[j] (and x[j+1], if 2×2 block).
+				for jw := 0; jw < nw; jw++ {
+					for ja := 0; ja < na; ja++ {
+						sum[ja*2+jw] = work[(jw+2)*n+j+ja]
+					}
+				}
+				scale, xnorm, _ := impl.Dlaln2(false, na, nw, dmin, acoef, s[j*lds+j:], lds, bdiag[0], bdiag[1], sum[:], 2, bcoefr, bcoefi, x[:], 2)
+				if scale < 1 {
+					for jw := 0; jw < nw; jw++ {
+						bi.Dscal(je+1, scale, work[(jw+2)*n:], 1)
+					}
+				}
+				xmax = math.Max(scale*xmax, xnorm)
+				for jw := 0; jw < nw; jw++ {
+					for ja := 0; ja < na; ja++ {
+						work[(jw+2)*n+j+ja] = x[ja*2+jw]
+					}
+				}
+
+				// w = w + x[j]*(a*S[:,j] - b*P[:,j]) with scaling.
+				if j > 0 {
+					// Check whether scaling is necessary for sum.
+					xscale := 1 / math.Max(1, xmax)
+					temp := acoefa*work[j] + bcoefa*work[n+j]
+					if il2by2 {
+						temp = math.Max(temp, acoefa*work[j+1]+bcoefa*work[n+j+1])
+					}
+					temp = math.Max(temp, math.Max(acoefa, bcoefa))
+					if temp > bignum*xscale {
+						for jw := 0; jw < nw; jw++ {
+							bi.Dscal(je+1, xscale, work[(jw+2)*n:], 1)
+						}
+						xmax *= xscale
+					}
+
+					// Compute the contributions of the
+					// off-diagonals of column j (and j+1, if 2×2
+					// block) of S and P to the sums.
+					for ja := 0; ja < na; ja++ {
+						creala := acoef * work[2*n+j+ja]
+						crealb := bcoefr * work[2*n+j+ja]
+						if ilcplx {
+							cimaga := acoef * work[3*n+j+ja]
+							crealb -= bcoefi * work[3*n+j+ja]
+							cimagb := bcoefi*work[2*n+j+ja] + bcoefr*work[3*n+j+ja]
+							for jr := 0; jr < j; jr++ {
+								work[2*n+jr] += -creala*s[jr*lds+j+ja] + crealb*p[jr*ldp+j+ja]
+								work[3*n+jr] += -cimaga*s[jr*lds+j+ja] + cimagb*p[jr*ldp+j+ja]
+							}
+						} else {
+							for jr := 0; jr < j; jr++ {
+								work[2*n+jr] += -creala*s[jr*lds+j+ja] + crealb*p[jr*ldp+j+ja]
+							}
+						}
+					}
+				}
+				il2by2 = false
+			}
+
+			// Copy eigenvector to VR, back transforming if
+			// howmny == lapack.AllEVMulQ.
+			ieig -= nw
+			if ilback {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dgemv(blas.NoTrans, n, je+1, 1, vr, ldvr, work[(jw+2)*n:], 1, 0, work[(jw+4)*n:], 1)
+				}
+				for jw := 0; jw < nw; jw++ {
+					bi.Dcopy(n, work[(jw+4)*n:], 1, vr[ieig+jw:], ldvr)
+				}
+				iend = n
+			} else {
+				for jw := 0; jw < nw; jw++ {
+					bi.Dcopy(n, work[(jw+2)*n:], 1, vr[ieig+jw:], ldvr)
+				}
+				iend = je + 1
+			}
+
+			// Scale eigenvector.
+			xmax = 0
+			for j := 0; j < iend; j++ {
+				if ilcplx {
+					xmax = math.Max(xmax, math.Abs(vr[j*ldvr+ieig])+math.Abs(vr[j*ldvr+ieig+1]))
+				} else {
+					xmax = math.Max(xmax, math.Abs(vr[j*ldvr+ieig]))
+				}
+			}
+			if xmax > safmin {
+				xscale := 1 / xmax
+				for jw := 0; jw < nw; jw++ {
+					bi.Dscal(iend, xscale, vr[ieig+jw:], ldvr)
+				}
+			}
+		}
+	}
+	return m, true
+}
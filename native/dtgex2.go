@@ -0,0 +1,344 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtgex2 swaps adjacent diagonal blocks (A11,B11) and (A22,B22) of order 1 or
+// 2 in an n×n upper (quasi) triangular matrix pair (A,B) by an orthogonal
+// equivalence transformation. (A,B) must be in generalized real Schur
+// canonical form as returned by Dgges, that is, A is block upper triangular
+// with 1×1 and 2×2 diagonal blocks and B is upper triangular. On return,
+// (A,B) is overwritten by the reordered pair
+//  (A,B) := Q^T * (A,B) * Z,
+// again in generalized real Schur canonical form.
+//
+// If wantq is true, the transformation is accumulated in the n×n matrix Q as
+//  Q := Q * Qt,
+// otherwise Q is not referenced. If wantz is true, the transformation is
+// accumulated in the n×n matrix Z as
+//  Z := Z * Zt,
+// otherwise Z is not referenced.
+//
+// j1 is the index of the first row of the first block (A11,B11). n1 and n2 are
+// the order of the first and second block, respectively.
+//
+// work must have length at least lwork, and lwork must be at least
+// max(1,n*(n1+n2),2*(n1+n2)*(n1+n2)). Otherwise Dtgex2 will panic.
+//
+// If ok is false, the transformed matrix pair would be too far from
+// generalized Schur form and the blocks are not swapped. In that case (A,B),
+// Q and Z are not modified.
+//
+// Dtgex2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgex2(wantq, wantz bool, n int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, j1, n1, n2 int, work []float64, lwork int) (ok bool) {
+	const (
+		ldst   = 4
+		twenty = 20.0
+	)
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	switch {
+	case j1 < 0 || (n <= j1 && n > 0):
+		panic("lapack: index j1 out of range")
+	case n1 < 0 || 2 < n1:
+		panic("lapack: invalid value of n1")
+	case n2 < 0 || 2 < n2:
+		panic("lapack: invalid value of n2")
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+
+	// Quick return if possible.
+	if n <= 1 || n1 == 0 || n2 == 0 {
+		return true
+	}
+	if j1+n1 >= n {
+		return true
+	}
+	m := n1 + n2
+	if lwork < max(1, max(n*m, 2*m*m)) {
+		panic(badWork)
+	}
+
+	// Make a local copy of the selected block.
+	var li, ir, s, t [ldst * ldst]float64
+	impl.Dlacpy(blas.All, m, m, a[j1*lda+j1:], lda, s[:], ldst)
+	impl.Dlacpy(blas.All, m, m, b[j1*ldb+j1:], ldb, t[:], ldst)
+
+	// Compute threshold for testing acceptance of swapping.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+	dnorma := impl.Dlange(lapack.NormFrob, m, m, s[:], ldst, nil)
+	dnormb := impl.Dlange(lapack.NormFrob, m, m, t[:], ldst, nil)
+	thresha := math.Max(twenty*eps*dnorma, smlnum)
+	threshb := math.Max(twenty*eps*dnormb, smlnum)
+
+	bi := blas64.Implementation()
+
+	if m == 2 {
+		// Swap 1×1 and 1×1 blocks.
+		//
+		// Compute orthogonal QL and RQ that swap 1×1 and 1×1 blocks
+		// using Givens rotations and perform the swap tentatively.
+		f := s[ldst+1]*t[0] - t[ldst+1]*s[0]
+		g := s[ldst+1]*t[1] - t[ldst+1]*s[1]
+		sa := math.Abs(s[ldst+1]) * math.Abs(t[0])
+		sb := math.Abs(s[0]) * math.Abs(t[ldst+1])
+		ir[1], ir[0], _ = impl.Dlartg(f, g)
+		ir[ldst] = -ir[1]
+		ir[ldst+1] = ir[0]
+		bi.Drot(2, s[:], ldst, s[1:], ldst, ir[0], ir[ldst])
+		bi.Drot(2, t[:], ldst, t[1:], ldst, ir[0], ir[ldst])
+		if sa >= sb {
+			li[0], li[ldst], _ = impl.Dlartg(s[0], s[ldst])
+		} else {
+			li[0], li[ldst], _ = impl.Dlartg(t[0], t[ldst])
+		}
+		bi.Drot(2, s[:], 1, s[ldst:], 1, li[0], li[ldst])
+		bi.Drot(2, t[:], 1, t[ldst:], 1, li[0], li[ldst])
+		li[ldst+1] = li[0]
+		li[1] = -li[ldst]
+
+		// Weak stability test:
+		//  |S21| <= O(eps * F-norm((A)))
+		//  and |T21| <= O(eps * F-norm((B)))
+		weak := math.Abs(s[ldst]) <= thresha && math.Abs(t[ldst]) <= threshb
+		if !weak {
+			return false
+		}
+
+		// Strong stability test:
+		//  F-norm((A - QL^T * S * QR)) <= O(eps * F-norm((A)))
+		//  and F-norm((B - QL^T * T * QR)) <= O(eps * F-norm((B)))
+		impl.Dlacpy(blas.All, m, m, a[j1*lda+j1:], lda, work[m*m:], m)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, 1, li[:], ldst, s[:], ldst, 0, work, m)
+		bi.Dgemm(blas.NoTrans, blas.Trans, m, m, m, -1, work, m, ir[:], ldst, 1, work[m*m:], m)
+		sa = impl.Dlange(lapack.NormFrob, m, m, work[m*m:], m, nil)
+
+		impl.Dlacpy(blas.All, m, m, b[j1*ldb+j1:], ldb, work[m*m:], m)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, 1, li[:], ldst, t[:], ldst, 0, work, m)
+		bi.Dgemm(blas.NoTrans, blas.Trans, m, m, m, -1, work, m, ir[:], ldst, 1, work[m*m:], m)
+		sb = impl.Dlange(lapack.NormFrob, m, m, work[m*m:], m, nil)
+		strong := sa <= thresha && sb <= threshb
+		if !strong {
+			return false
+		}
+
+		// Update A[j1:j1+m,m+j1:n], B[j1:j1+m,m+j1:n], A[0:j1,j1:j1+m]
+		// and B[0:j1,j1:j1+m].
+		bi.Drot(j1+2, a[j1:], lda, a[j1+1:], lda, ir[0], ir[ldst])
+		bi.Drot(j1+2, b[j1:], ldb, b[j1+1:], ldb, ir[0], ir[ldst])
+		bi.Drot(n-j1, a[j1*lda+j1:], 1, a[(j1+1)*lda+j1:], 1, li[0], li[ldst])
+		bi.Drot(n-j1, b[j1*ldb+j1:], 1, b[(j1+1)*ldb+j1:], 1, li[0], li[ldst])
+
+		// Set n1×n2 (2,1) blocks to zero.
+		a[(j1+1)*lda+j1] = 0
+		b[(j1+1)*ldb+j1] = 0
+
+		// Accumulate transformations into Q and Z if requested.
+		if wantz {
+			bi.Drot(n, z[j1:], ldz, z[j1+1:], ldz, ir[0], ir[ldst])
+		}
+		if wantq {
+			bi.Drot(n, q[j1:], ldq, q[j1+1:], ldq, li[0], li[ldst])
+		}
+		return true
+	}
+
+	// Swap 1×1 and 2×2 blocks, or 2×2 and 2×2 blocks.
+	//
+	// Solve the generalized Sylvester equation
+	//  S11 * R - L * S22 = scale * S12
+	//  T11 * R - L * T22 = scale * T12
+	// for R and L. Solutions in li and ir.
+	impl.Dlacpy(blas.All, n1, n2, t[n1:], ldst, li[:], ldst)
+	impl.Dlacpy(blas.All, n1, n2, s[n1:], ldst, ir[n2*ldst+n1:], ldst)
+	var iwork [ldst + 2]int
+	scale, _, _, _, solved := impl.Dtgsy2(blas.NoTrans, 0, n1, n2, s[:], ldst, s[n1*ldst+n1:], ldst,
+		ir[n2*ldst+n1:], ldst, t[:], ldst, t[n1*ldst+n1:], ldst, li[:], ldst, 1, 0, iwork[:])
+	if !solved {
+		return false
+	}
+
+	// Compute orthogonal matrix QL:
+	//  QL^T * LI = [ TL ]
+	//              [ 0  ]
+	// where
+	//  LI = [      -L              ]
+	//       [ scale * identity(n2) ]
+	for i := 0; i < n2; i++ {
+		bi.Dscal(n1, -1, li[i:], ldst)
+		li[(n1+i)*ldst+i] = scale
+	}
+	var taul, taur [ldst]float64
+	impl.Dgeqr2(m, n2, li[:], ldst, taul[:], work)
+	impl.Dorg2r(m, m, n2, li[:], ldst, taul[:], work)
+
+	// Compute orthogonal matrix RQ:
+	//  IR * RQ^T = [ 0  TR ],
+	// where
+	//  IR = [ scale * identity(n1), R ].
+	for i := 0; i < n1; i++ {
+		ir[(n2+i)*ldst+i] = scale
+	}
+	impl.Dgerq2(n1, m, ir[n2*ldst:], ldst, taur[:], work)
+	impl.Dorgr2(m, m, n1, ir[:], ldst, taur[:], work)
+
+	// Perform the swapping tentatively.
+	bi.Dgemm(blas.Trans, blas.NoTrans, m, m, m, 1, li[:], ldst, s[:], ldst, 0, work, m)
+	bi.Dgemm(blas.NoTrans, blas.Trans, m, m, m, 1, work, m, ir[:], ldst, 0, s[:], ldst)
+	bi.Dgemm(blas.Trans, blas.NoTrans, m, m, m, 1, li[:], ldst, t[:], ldst, 0, work, m)
+	bi.Dgemm(blas.NoTrans, blas.Trans, m, m, m, 1, work, m, ir[:], ldst, 0, t[:], ldst)
+	scpy := s
+	tcpy := t
+	ircop := ir
+	licop := li
+
+	// Triangularize the B-part by an RQ factorization. Apply the
+	// transformation (from left) to the A-part, giving S.
+	impl.Dgerq2(m, m, t[:], ldst, taur[:], work)
+	impl.Dormr2(blas.Right, blas.Trans, m, m, m, t[:], ldst, taur[:], s[:], ldst, work)
+	impl.Dormr2(blas.Left, blas.NoTrans, m, m, m, t[:], ldst, taur[:], ir[:], ldst, work)
+
+	// Compute F-norm(S21) in brqa21. (T21 is 0.)
+	brqa21 := impl.Dlange(lapack.NormFrob, n1, n2, s[n2*ldst:], ldst, nil)
+
+	// Triangularize the B-part by a QR factorization. Apply the
+	// transformation (from right) to the A-part, giving S.
+	impl.Dgeqr2(m, m, tcpy[:], ldst, taul[:], work)
+	impl.Dorm2r(blas.Left, blas.Trans, m, m, m, tcpy[:], ldst, taul[:], scpy[:], ldst, work)
+	impl.Dorm2r(blas.Right, blas.NoTrans, m, m, m, tcpy[:], ldst, taul[:], licop[:], ldst, work)
+
+	// Compute F-norm(S21) in bqra21. (T21 is 0.)
+	bqra21 := impl.Dlange(lapack.NormFrob, n1, n2, scpy[n2*ldst:], ldst, nil)
+
+	// Decide which method to use.
+	//  Weak stability test:
+	//   F-norm(S21) <= O(eps * F-norm((S)))
+	if bqra21 <= brqa21 && bqra21 <= thresha {
+		s = scpy
+		t = tcpy
+		ir = ircop
+		li = licop
+	} else if brqa21 >= thresha {
+		return false
+	}
+
+	// Set lower triangle of B-part to zero.
+	for i := 1; i < m; i++ {
+		for j := 0; j < i; j++ {
+			t[i*ldst+j] = 0
+		}
+	}
+
+	// Strong stability test:
+	//  F-norm((A - QL * S * QR)) <= O(eps * F-norm((A)))
+	//  and F-norm((B - QL * T * QR)) <= O(eps * F-norm((B)))
+	impl.Dlacpy(blas.All, m, m, a[j1*lda+j1:], lda, work[m*m:], m)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, 1, li[:], ldst, s[:], ldst, 0, work, m)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, -1, work, m, ir[:], ldst, 1, work[m*m:], m)
+	sa := impl.Dlange(lapack.NormFrob, m, m, work[m*m:], m, nil)
+
+	impl.Dlacpy(blas.All, m, m, b[j1*ldb+j1:], ldb, work[m*m:], m)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, 1, li[:], ldst, t[:], ldst, 0, work, m)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, -1, work, m, ir[:], ldst, 1, work[m*m:], m)
+	sb := impl.Dlange(lapack.NormFrob, m, m, work[m*m:], m, nil)
+	strong := sa <= thresha && sb <= threshb
+	if !strong {
+		return false
+	}
+
+	// If the swap is accepted ("weakly" and "strongly"), apply the
+	// transformations and set n1×n2 (2,1) block to zero.
+	impl.Dlaset(blas.All, n1, n2, 0, 0, s[n2*ldst:], ldst)
+
+	// Copy back m×m diagonal block starting at index j1 of (A,B).
+	impl.Dlacpy(blas.All, m, m, s[:], ldst, a[j1*lda+j1:], lda)
+	impl.Dlacpy(blas.All, m, m, t[:], ldst, b[j1*ldb+j1:], ldb)
+	t = [ldst * ldst]float64{}
+
+	// Standardize existing 2×2 blocks. The left and right rotations are
+	// collected in work and t, respectively.
+	impl.Dlaset(blas.All, m, m, 0, 0, work, m)
+	work[0] = 1
+	t[0] = 1
+	if n2 > 1 {
+		_, _, _, csl, snl, csr, snr := impl.Dlagv2(a[j1*lda+j1:], lda, b[j1*ldb+j1:], ldb)
+		work[0] = csl
+		work[1] = -snl
+		work[m] = snl
+		work[m+1] = csl
+		t[0] = csr
+		t[1] = -snr
+		t[ldst] = snr
+		t[ldst+1] = csr
+	}
+	work[m*m-1] = 1
+	t[(m-1)*ldst+m-1] = 1
+	if n1 > 1 {
+		_, _, _, csl, snl, csr, snr := impl.Dlagv2(a[(j1+n2)*lda+j1+n2:], lda, b[(j1+n2)*ldb+j1+n2:], ldb)
+		work[n2*m+n2] = csl
+		work[n2*m+n2+1] = -snl
+		work[(n2+1)*m+n2] = snl
+		work[(n2+1)*m+n2+1] = csl
+		t[n2*ldst+n2] = csr
+		t[n2*ldst+n2+1] = -snr
+		t[(n2+1)*ldst+n2] = snr
+		t[(n2+1)*ldst+n2+1] = csr
+	}
+	bi.Dgemm(blas.Trans, blas.NoTrans, n2, n1, n2, 1, work, m, a[j1*lda+j1+n2:], lda, 0, work[m*m:], n1)
+	impl.Dlacpy(blas.All, n2, n1, work[m*m:], n1, a[j1*lda+j1+n2:], lda)
+	bi.Dgemm(blas.Trans, blas.NoTrans, n2, n1, n2, 1, work, m, b[j1*ldb+j1+n2:], ldb, 0, work[m*m:], n1)
+	impl.Dlacpy(blas.All, n2, n1, work[m*m:], n1, b[j1*ldb+j1+n2:], ldb)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, m, m, m, 1, li[:], ldst, work, m, 0, work[m*m:], m)
+	impl.Dlacpy(blas.All, m, m, work[m*m:], m, li[:], ldst)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, n2, n1, n1, 1, a[j1*lda+j1+n2:], lda, t[n2*ldst+n2:], ldst, 0, work, n1)
+	impl.Dlacpy(blas.All, n2, n1, work, n1, a[j1*lda+j1+n2:], lda)
+	bi.Dgemm(blas.NoTrans, blas.NoTrans, n2, n1, n1, 1, b[j1*ldb+j1+n2:], ldb, t[n2*ldst+n2:], ldst, 0, work, n1)
+	impl.Dlacpy(blas.All, n2, n1, work, n1, b[j1*ldb+j1+n2:], ldb)
+	bi.Dgemm(blas.Trans, blas.NoTrans, m, m, m, 1, ir[:], ldst, t[:], ldst, 0, work, m)
+	impl.Dlacpy(blas.All, m, m, work, m, ir[:], ldst)
+
+	// Accumulate transformations into Q and Z if requested.
+	if wantq {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, m, m, 1, q[j1:], ldq, li[:], ldst, 0, work, m)
+		impl.Dlacpy(blas.All, n, m, work, m, q[j1:], ldq)
+	}
+	if wantz {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, n, m, m, 1, z[j1:], ldz, ir[:], ldst, 0, work, m)
+		impl.Dlacpy(blas.All, n, m, work, m, z[j1:], ldz)
+	}
+
+	// Update A[j1:j1+m,j1+m:n], B[j1:j1+m,j1+m:n], A[0:j1,j1:j1+m] and
+	// B[0:j1,j1:j1+m].
+	i := j1 + m
+	if i < n {
+		bi.Dgemm(blas.Trans, blas.NoTrans, m, n-i, m, 1, li[:], ldst, a[j1*lda+i:], lda, 0, work, n-i)
+		impl.Dlacpy(blas.All, m, n-i, work, n-i, a[j1*lda+i:], lda)
+		bi.Dgemm(blas.Trans, blas.NoTrans, m, n-i, m, 1, li[:], ldst, b[j1*ldb+i:], ldb, 0, work, n-i)
+		impl.Dlacpy(blas.All, m, n-i, work, n-i, b[j1*ldb+i:], ldb)
+	}
+	i = j1
+	if i > 0 {
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, i, m, m, 1, a[j1:], lda, ir[:], ldst, 0, work, m)
+		impl.Dlacpy(blas.All, i, m, work, m, a[j1:], lda)
+		bi.Dgemm(blas.NoTrans, blas.NoTrans, i, m, m, 1, b[j1:], ldb, ir[:], ldst, 0, work, m)
+		impl.Dlacpy(blas.All, i, m, work, m, b[j1:], ldb)
+	}
+	return true
+}
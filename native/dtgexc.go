@@ -0,0 +1,247 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Dtgexc reorders the generalized real Schur decomposition of an n×n real
+// matrix pair (A,B) using an orthogonal equivalence transformation
+//  (A,B) := Q^T * (A,B) * Z,
+// so that the diagonal block of (A,B) with row index ifst is moved to row
+// ilst.
+//
+// (A,B) must be in generalized real Schur canonical form as returned by Dgges,
+// that is, A is block upper triangular with 1×1 and 2×2 diagonal blocks and B
+// is upper triangular. On return, (A,B) is overwritten by the reordered pair,
+// again in generalized real Schur canonical form.
+//
+// If wantq is true, the n×n orthogonal matrix Q is updated as Q := Q * Qt,
+// otherwise Q is not referenced. If wantz is true, the n×n orthogonal matrix Z
+// is updated as Z := Z * Zt, otherwise Z is not referenced.
+//
+// ifst and ilst specify the reordering of the diagonal blocks of (A,B). The
+// block with row index ifst is moved to row ilst, by a sequence of swapping
+// between adjacent blocks. It must hold that
+//  0 <= ifst < n, and  0 <= ilst < n,
+// otherwise Dtgexc will panic.
+//
+// If ifst points to the second row of a 2×2 block, ifstOut will point to the
+// first row, otherwise it will be equal to ifst.
+//
+// ilstOut will point to the first row of the block in its final position. If
+// ok is true, ilstOut may differ from ilst by +1 or -1.
+//
+// If ok is false, two adjacent blocks were too close to swap because the
+// problem is very ill-conditioned. (A,B) may have been partially reordered,
+// and ilstOut will point to the first row of the current position of the
+// block being moved.
+//
+// work must have length at least lwork, and lwork must be at least 1 if
+// n <= 1, and at least 4*n+16 otherwise. Otherwise Dtgexc will panic. If
+// lwork is -1, instead of performing Dtgexc, the minimum value of lwork is
+// stored into work[0].
+//
+// Dtgexc is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgexc(wantq, wantz bool, n int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, ifst, ilst int, work []float64, lwork int) (ifstOut, ilstOut int, ok bool) {
+	if n < 0 {
+		panic(nLT0)
+	}
+	lwmin := 1
+	if n > 1 {
+		lwmin = 4*n + 16
+	}
+	if lwork == -1 {
+		work[0] = float64(lwmin)
+		return ifst, ilst, true
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	switch {
+	case (ifst < 0 || n <= ifst) && n > 0:
+		panic("lapack: ifst out of range")
+	case (ilst < 0 || n <= ilst) && n > 0:
+		panic("lapack: ilst out of range")
+	case lwork < lwmin || len(work) < lwork:
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n <= 1 {
+		work[0] = float64(lwmin)
+		return ifst, ilst, true
+	}
+
+	// Determine the first row of the specified block and find out if it
+	// is 1×1 or 2×2.
+	if ifst > 0 && a[ifst*lda+ifst-1] != 0 {
+		ifst--
+	}
+	nbf := 1 // Size of the first block.
+	if ifst+1 < n && a[(ifst+1)*lda+ifst] != 0 {
+		nbf = 2
+	}
+	// Determine the first row of the final block and find out if it is
+	// 1×1 or 2×2.
+	if ilst > 0 && a[ilst*lda+ilst-1] != 0 {
+		ilst--
+	}
+	nbl := 1 // Size of the last block.
+	if ilst+1 < n && a[(ilst+1)*lda+ilst] != 0 {
+		nbl = 2
+	}
+
+	work[0] = float64(lwmin)
+	switch {
+	case ifst == ilst:
+		return ifst, ilst, true
+
+	case ifst < ilst:
+		// Update ilst.
+		switch {
+		case nbf == 2 && nbl == 1:
+			ilst--
+		case nbf == 1 && nbl == 2:
+			ilst++
+		}
+		here := ifst
+		for here < ilst {
+			// Swap with next one below.
+			if nbf == 1 || nbf == 2 {
+				// Current block either 1×1 or 2×2.
+				nbnext := 1 // Size of the next block.
+				if here+nbf+1 < n && a[(here+nbf+1)*lda+here+nbf] != 0 {
+					nbnext = 2
+				}
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, nbf, nbnext, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here += nbnext
+				// Test if 2×2 block breaks into two 1×1 blocks.
+				if nbf == 2 && a[(here+1)*lda+here] == 0 {
+					nbf = 3
+				}
+				continue
+			}
+
+			// Current block consists of two 1×1 blocks, each of which
+			// must be swapped individually.
+			nbnext := 1 // Size of the next block.
+			if here+3 < n && a[(here+3)*lda+here+2] != 0 {
+				nbnext = 2
+			}
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here+1, 1, nbnext, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			if nbnext == 1 {
+				// Swap two 1×1 blocks.
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, 1, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here++
+				continue
+			}
+			// Recompute nbnext in case of 2×2 split.
+			if a[(here+2)*lda+here+1] == 0 {
+				nbnext = 1
+			}
+			if nbnext == 2 {
+				// 2×2 block did not split.
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, nbnext, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here += 2
+				continue
+			}
+			// 2×2 block did split.
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, 1, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			here++
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, 1, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			here++
+		}
+		return ifst, here, true
+
+	default: // ifst > ilst
+		here := ifst
+		for here > ilst {
+			// Swap with next one above.
+			if nbf == 1 || nbf == 2 {
+				// Current block either 1×1 or 2×2.
+				nbnext := 1
+				if here >= 2 && a[(here-1)*lda+here-2] != 0 {
+					nbnext = 2
+				}
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here-nbnext, nbnext, nbf, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here -= nbnext
+				// Test if 2×2 block breaks into two 1×1 blocks.
+				if nbf == 2 && a[(here+1)*lda+here] == 0 {
+					nbf = 3
+				}
+				continue
+			}
+
+			// Current block consists of two 1×1 blocks, each of which
+			// must be swapped individually.
+			nbnext := 1
+			if here >= 2 && a[(here-1)*lda+here-2] != 0 {
+				nbnext = 2
+			}
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here-nbnext, nbnext, 1, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			if nbnext == 1 {
+				// Swap two 1×1 blocks.
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, nbnext, 1, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here--
+				continue
+			}
+			// Recompute nbnext in case of 2×2 split.
+			if a[here*lda+here-1] == 0 {
+				nbnext = 1
+			}
+			if nbnext == 2 {
+				// 2×2 block did not split.
+				ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here-1, 2, 1, work, lwork)
+				if !ok {
+					return ifst, here, false
+				}
+				here -= 2
+				continue
+			}
+			// 2×2 block did split.
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, 1, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			here--
+			ok = impl.Dtgex2(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, here, 1, 1, work, lwork)
+			if !ok {
+				return ifst, here, false
+			}
+			here--
+		}
+		return ifst, here, true
+	}
+}
@@ -0,0 +1,326 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dtgsen reorders the generalized real Schur decomposition of an n×n real
+// matrix pair (A,B) (in terms of an orthogonal equivalence transformation
+//  (A,B) := Q^T * (A,B) * Z)
+// so that a selected cluster of eigenvalues appears in the leading diagonal
+// blocks of the upper quasi-triangular matrix A and the upper triangular B.
+// The leading columns of Q and Z form orthonormal bases of the corresponding
+// left and right deflating subspaces. Optionally, Dtgsen computes estimates
+// of the reciprocal condition numbers of the cluster of eigenvalues and of
+// the deflating subspaces.
+//
+// On entry, (A,B) must be in generalized real Schur canonical form as
+// returned by Dgges, that is, A is block upper triangular with 1×1 and 2×2
+// diagonal blocks and B is upper triangular. On return, (A,B) is overwritten
+// by the reordered pair, again in generalized real Schur canonical form, with
+// the selected eigenvalues in the leading diagonal blocks and with the 1×1
+// diagonal elements of B non-negative.
+//
+// If wantq is true, on entry q must contain the n×n matrix Q and on return it
+// is postmultiplied by the left orthogonal transformation that reorders
+// (A,B). If wantz is true, on entry z must contain the n×n matrix Z and on
+// return it is postmultiplied by the right orthogonal transformation that
+// reorders (A,B). If wantq or wantz is false, the corresponding matrix is not
+// referenced.
+//
+// selected specifies the eigenvalues in the selected cluster. To select a
+// real eigenvalue w[j], selected[j] must be true. To select a complex
+// conjugate pair of eigenvalues w[j] and w[j+1] corresponding to a 2×2
+// diagonal block, either selected[j] or selected[j+1] or both must be true.
+// selected must have length n.
+//
+// On return, the generalized eigenvalues of the reordered pair are
+//  (alphar[j] + i*alphai[j]) / beta[j], j = 0, ..., n-1,
+// in the same order as on the diagonal of (A,B). If alphai[j] is zero, the
+// j-th eigenvalue is real. Otherwise, alphai[j] > 0 and the j-th and (j+1)-st
+// eigenvalues form a complex conjugate pair. alphar, alphai and beta must
+// have length n.
+//
+// ijob specifies the computed condition numbers:
+//  ijob == 0: Only reorder with respect to selected.
+//  ijob == 1: Reciprocal of norms of "projections" onto left and right
+//             eigenspaces with respect to the selected cluster (pl and pr).
+//  ijob == 2: Upper bounds on Difu and Difl using a Frobenius norm-based
+//             estimate (difu and difl).
+//  ijob == 3: Estimate of Difu and Difl using the 1-norm. This is about 5
+//             times as expensive as ijob == 2.
+//  ijob == 4: Compute pl, pr, difu and difl as for ijob 1 and 2. This is an
+//             economic version to get it all.
+//  ijob == 5: Compute pl, pr, difu and difl as for ijob 1 and 3.
+// For other values of ijob Dtgsen will panic.
+//
+// m is the dimension of the left and right deflating subspaces, that is, the
+// number of selected eigenvalues counting each complex conjugate pair as two.
+//
+// pl and pr are lower bounds on the reciprocal of the norm of the
+// "projections" onto the left and right eigenspaces with respect to the
+// selected cluster. 0 < pl, pr <= 1. If m == 0 or m == n, pl = pr = 1. They
+// are computed only if ijob is 1, 4 or 5, otherwise they are zero.
+//
+// difu and difl are the estimates of Difu and Difl, the separations of the
+// leading m×m and the trailing (n-m)×(n-m) diagonal block pairs of (A,B).
+// If m == 0 or m == n, difu and difl are the Frobenius norm of the pair
+// (A,B). They are computed only if ijob >= 2, otherwise they are zero.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. lwork must be at least
+//  max(1,4*n+16)                if ijob == 0,
+//  max(1,4*n+16,2*m*(n-m)+1)    if ijob is 1, 2 or 4,
+//  max(1,4*n+16,4*m*(n-m)+1)    if ijob is 3 or 5,
+// and liwork must be at least
+//  1                   if ijob == 0,
+//  n+2                 if ijob is 1, 2 or 4,
+//  2*m*(n-m)+n+2       if ijob is 3 or 5.
+// Dtgsen will panic if these conditions are not met. If lwork == -1 or
+// liwork == -1, instead of performing Dtgsen, the minimum lengths of work and
+// iwork for the given selection are stored into work[0] and iwork[0],
+// respectively, and (A,B), Q and Z are not modified.
+//
+// ok will be false if the reordering failed because some selected eigenvalues
+// are so close to eigenvalues that were not selected that they could not be
+// swapped. In that case (A,B) may have been partially reordered, alphar,
+// alphai and beta are those of the partially reordered pair, and pl, pr, difu
+// and difl are set to zero.
+func (impl Implementation) Dtgsen(ijob int, wantq, wantz bool, selected []bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, pl, pr, difu, difl float64, ok bool) {
+	switch {
+	case ijob < 0 || 5 < ijob:
+		panic("lapack: invalid value of ijob")
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if wantz {
+		checkMatrix(n, n, z, ldz)
+	}
+	if len(selected) != n {
+		panic("lapack: bad length of selected")
+	}
+
+	wantp := ijob == 1 || ijob >= 4
+	wantd1 := ijob == 2 || ijob == 4
+	wantd2 := ijob == 3 || ijob == 5
+	wantd := wantd1 || wantd2
+
+	// Set m to the dimension of the specified pair of deflating subspaces.
+	for k := 0; k < n; k++ {
+		if k < n-1 && a[(k+1)*lda+k] != 0 {
+			if selected[k] || selected[k+1] {
+				m += 2
+			}
+			k++
+			continue
+		}
+		if selected[k] {
+			m++
+		}
+	}
+
+	n1 := m
+	n2 := n - m
+	nn := n1 * n2
+	var lwmin, liwmin int
+	switch {
+	case wantd2:
+		lwmin = max(1, max(4*n+16, 4*nn+1))
+		liwmin = 2*nn + n + 2
+	case wantp || wantd1:
+		lwmin = max(1, max(4*n+16, 2*nn+1))
+		liwmin = n + 2
+	default:
+		lwmin = max(1, 4*n+16)
+		liwmin = 1
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return m, 0, 0, 0, 0, true
+	}
+	switch {
+	case len(alphar) != n:
+		panic("lapack: bad length of alphar")
+	case len(alphai) != n:
+		panic("lapack: bad length of alphai")
+	case len(beta) != n:
+		panic("lapack: bad length of beta")
+	case lwork < lwmin || len(work) < lwork:
+		panic(badWork)
+	case liwork < liwmin || len(iwork) < liwork:
+		panic(badWork)
+	}
+
+	ok = true
+	if m == n || m == 0 {
+		// Quick return if possible.
+		if wantp {
+			pl = 1
+			pr = 1
+		}
+		if wantd {
+			anorm := impl.Dlange(lapack.NormFrob, n, n, a, lda, nil)
+			bnorm := impl.Dlange(lapack.NormFrob, n, n, b, ldb, nil)
+			difu = impl.Dlapy2(anorm, bnorm)
+			difl = difu
+		}
+	} else {
+		// Collect the selected blocks at the top-left corner of (A,B).
+		var ks int
+		for k := 0; k < n; k++ {
+			swap := selected[k]
+			pair := k < n-1 && a[(k+1)*lda+k] != 0
+			if pair {
+				swap = swap || selected[k+1]
+			}
+			if swap {
+				// Swap the k-th block to position ks.
+				if k != ks {
+					_, ks, ok = impl.Dtgexc(wantq, wantz, n, a, lda, b, ldb, q, ldq, z, ldz, k, ks, work, lwork)
+				}
+				if !ok {
+					// Blocks too close to swap.
+					break
+				}
+				ks++
+				if pair {
+					ks++
+				}
+			}
+			if pair {
+				k++
+			}
+		}
+
+		a22 := a[n1*lda+n1:]
+		b22 := b[n1*ldb+n1:]
+		if ok && wantp {
+			// Solve the generalized Sylvester equation for R and L
+			//  A11*R - L*A22 = scale*A12,
+			//  B11*R - L*B22 = scale*B12,
+			// and compute pl and pr.
+			impl.Dlacpy(blas.All, n1, n2, a[n1:], lda, work, n2)
+			impl.Dlacpy(blas.All, n1, n2, b[n1:], ldb, work[nn:], n2)
+			scale, _, _ := impl.Dtgsyl(blas.NoTrans, 0, n1, n2, a, lda, a22, lda, work, n2,
+				b, ldb, b22, ldb, work[nn:], n2, work[2*nn:], lwork-2*nn, iwork)
+
+			// Estimate the reciprocal of norms of "projections" onto
+			// left and right eigenspaces.
+			rnorm := impl.Dlange(lapack.NormFrob, n1, n2, work, n2, nil)
+			if rnorm == 0 {
+				pl = 1
+			} else {
+				pl = scale / (math.Sqrt(scale*scale/rnorm+rnorm) * math.Sqrt(rnorm))
+			}
+			rnorm = impl.Dlange(lapack.NormFrob, n1, n2, work[nn:], n2, nil)
+			if rnorm == 0 {
+				pr = 1
+			} else {
+				pr = scale / (math.Sqrt(scale*scale/rnorm+rnorm) * math.Sqrt(rnorm))
+			}
+		}
+
+		if ok && wantd1 {
+			// Frobenius norm-based estimates of Difu and Difl.
+			_, difu, _ = impl.Dtgsyl(blas.NoTrans, 3, n1, n2, a, lda, a22, lda, work, n2,
+				b, ldb, b22, ldb, work[nn:], n2, work[2*nn:], lwork-2*nn, iwork)
+			_, difl, _ = impl.Dtgsyl(blas.NoTrans, 3, n2, n1, a22, lda, a, lda, work, n1,
+				b22, ldb, b, ldb, work[nn:], n1, work[2*nn:], lwork-2*nn, iwork)
+		}
+
+		if ok && wantd2 {
+			// 1-norm-based estimates of Difu and Difl using reverse
+			// communication with Dlacn2. In each step a generalized
+			// Sylvester equation or its transposed variant is solved.
+			mn2 := 2 * nn
+			x := work[:mn2]
+			v := work[mn2 : 2*mn2]
+			isgn := iwork[:mn2]
+			iw := iwork[mn2:]
+			w := work[2*mn2:]
+			lw := lwork - 2*mn2
+
+			// Estimate Difu.
+			var (
+				est   float64
+				kase  int
+				isave [3]int
+				scale float64
+			)
+			for {
+				est, kase = impl.Dlacn2(mn2, v, x, isgn, est, kase, &isave)
+				if kase == 0 {
+					break
+				}
+				trans := blas.NoTrans
+				if kase != 1 {
+					trans = blas.Trans
+				}
+				scale, _, _ = impl.Dtgsyl(trans, 0, n1, n2, a, lda, a22, lda, x, n2,
+					b, ldb, b22, ldb, x[nn:], n2, w, lw, iw)
+			}
+			difu = scale / est
+
+			// Estimate Difl.
+			est = 0
+			kase = 0
+			isave = [3]int{}
+			for {
+				est, kase = impl.Dlacn2(mn2, v, x, isgn, est, kase, &isave)
+				if kase == 0 {
+					break
+				}
+				trans := blas.NoTrans
+				if kase != 1 {
+					trans = blas.Trans
+				}
+				scale, _, _ = impl.Dtgsyl(trans, 0, n2, n1, a22, lda, a, lda, x, n1,
+					b22, ldb, b, ldb, x[nn:], n1, w, lw, iw)
+			}
+			difl = scale / est
+		}
+	}
+
+	// Compute the generalized eigenvalues of the reordered pair (A,B) and
+	// normalize the generalized Schur form.
+	safmin := dlamchS
+	for k := 0; k < n; k++ {
+		if k < n-1 && a[(k+1)*lda+k] != 0 {
+			// Compute the eigenvalues of the 2×2 block at position k.
+			beta[k], beta[k+1], alphar[k], alphar[k+1], alphai[k] = impl.Dlag2(a[k*lda+k:], lda, b[k*ldb+k:], ldb, safmin)
+			alphai[k+1] = -alphai[k]
+			k++
+			continue
+		}
+		if math.Signbit(b[k*ldb+k]) {
+			// If B[k,k] is negative, make it positive.
+			for i := 0; i < n; i++ {
+				a[k*lda+i] *= -1
+				b[k*ldb+i] *= -1
+				if wantq {
+					q[i*ldq+k] *= -1
+				}
+			}
+		}
+		alphar[k] = a[k*lda+k]
+		alphai[k] = 0
+		beta[k] = b[k*ldb+k]
+	}
+
+	work[0] = float64(lwmin)
+	iwork[0] = liwmin
+	return m, pl, pr, difu, difl, ok
+}
@@ -0,0 +1,291 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtgsna estimates reciprocal condition numbers for specified eigenvalues
+// and/or eigenvectors of an n×n matrix pair (A,B) in generalized real Schur
+// canonical form, or of any matrix pair (Q*A*Z^T, Q*B*Z^T) with orthogonal Q
+// and Z.
+//
+// On entry, (A,B) must be in generalized real Schur canonical form as
+// returned by Dgges, that is, A is block upper triangular with 1×1 and 2×2
+// diagonal blocks and B is upper triangular. (A,B) is not modified.
+//
+// job specifies which condition numbers are computed. If job is
+// lapack.CondEigenvalues, the condition numbers of the eigenvalues are
+// computed and stored in s. If job is lapack.CondSubspace, the condition
+// numbers of the eigenvectors are computed and stored in dif. If job is
+// lapack.CondBoth, both are computed. For other values of job Dtgsna will
+// panic.
+//
+// howmny specifies the eigenpairs for which condition numbers are computed.
+// If howmny is lapack.AllEV, the condition numbers are computed for all
+// eigenpairs. If howmny is lapack.SelectedEV, the condition numbers are
+// computed only for the eigenpairs specified by selected. In that case, to
+// select a real eigenvalue w[j], selected[j] must be true, and to select a
+// complex conjugate pair of eigenvalues w[j] and w[j+1] corresponding to a
+// 2×2 diagonal block, either selected[j] or selected[j+1] or both must be
+// true. selected must have length n. If howmny is lapack.AllEV, selected is
+// not referenced. For other values of howmny Dtgsna will panic.
+//
+// If job is lapack.CondEigenvalues or lapack.CondBoth, vl and vr must contain
+// in their columns the left and right eigenvectors, respectively, of (A,B)
+// corresponding to the eigenpairs specified by howmny and selected, stored
+// consecutively in the same order as the eigenvalues. Eigenvectors of complex
+// conjugate pairs are stored as in Dtgevc in two consecutive columns holding
+// their real and imaginary parts. vl and vr must be n×m matrices. If job is
+// lapack.CondSubspace, vl and vr are not referenced.
+//
+// On return, s[k] and dif[k] contain the reciprocal condition numbers of the
+// k-th selected eigenvalue and eigenvector, respectively, with each complex
+// conjugate pair occupying two consecutive elements with equal values. If the
+// eigenvalue is infinite or undefined, s[k] is set to -1. If the reordering
+// of the k-th eigenpair fails because the problem is very ill-conditioned,
+// dif[k] is set to zero. If job is lapack.CondSubspace, s is not referenced,
+// and if job is lapack.CondEigenvalues, dif is not referenced. Otherwise s and
+// dif must have length at least m.
+//
+// mm is the number of columns of vl and vr available, and must be at least m,
+// the number of selected eigenvalues counting each complex conjugate pair as
+// two. m is returned by Dtgsna.
+//
+// work must have length at least lwork, and lwork must be at least max(1,n)
+// if job is lapack.CondEigenvalues, and at least 2*n*(n+2)+16 otherwise. If
+// job is lapack.CondSubspace or lapack.CondBoth, iwork must have length at
+// least n+2. Dtgsna will panic if these conditions are not met. If lwork is
+// -1, instead of performing Dtgsna, the minimum value of lwork is stored into
+// work[0].
+//
+// Dtgsna is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, a []float64, lda int, b []float64, ldb int, vl []float64, ldvl int, vr []float64, ldvr int, s, dif []float64, mm int, work []float64, lwork int, iwork []int) (m int) {
+	var wants, wantdf bool
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantdf = true
+	case lapack.CondBoth:
+		wants = true
+		wantdf = true
+	}
+	var somcon bool
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV:
+	case lapack.SelectedEV:
+		somcon = true
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	lwmin := max(1, n)
+	if wantdf && n > 0 {
+		lwmin = 2*n*(n+2) + 16
+	}
+	if lwork == -1 {
+		work[0] = float64(lwmin)
+		return 0
+	}
+	checkMatrix(n, n, a, lda)
+	checkMatrix(n, n, b, ldb)
+	if somcon && len(selected) != n {
+		panic("lapack: bad length of selected")
+	}
+
+	// Set m to the number of eigenpairs for which condition numbers are
+	// required.
+	if somcon {
+		for k := 0; k < n; k++ {
+			if k < n-1 && a[(k+1)*lda+k] != 0 {
+				if selected[k] || selected[k+1] {
+					m += 2
+				}
+				k++
+				continue
+			}
+			if selected[k] {
+				m++
+			}
+		}
+	} else {
+		m = n
+	}
+
+	if mm < m {
+		panic("lapack: mm < m")
+	}
+	if wants {
+		if m > 0 {
+			checkMatrix(n, m, vl, ldvl)
+			checkMatrix(n, m, vr, ldvr)
+		}
+		if len(s) < m {
+			panic(badS)
+		}
+	}
+	if wantdf {
+		if len(dif) < m {
+			panic("lapack: dif has insufficient length")
+		}
+		if len(iwork) < n+2 {
+			panic("lapack: insufficient length of iwork")
+		}
+	}
+	if lwork < lwmin || len(work) < lwork {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		work[0] = 1
+		return m
+	}
+
+	bi := blas64.Implementation()
+
+	// Get machine constants.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+
+	var ks int
+	for k := 0; k < n; k++ {
+		// Determine whether A[k,k] begins a 1×1 or a 2×2 block.
+		pair := k < n-1 && a[(k+1)*lda+k] != 0
+
+		// Determine whether condition numbers are required for the k-th
+		// eigenpair.
+		if somcon {
+			if pair {
+				if !selected[k] && !selected[k+1] {
+					k++
+					continue
+				}
+			} else if !selected[k] {
+				continue
+			}
+		}
+
+		if wants {
+			// Compute the reciprocal condition number of the k-th
+			// eigenvalue.
+			if !pair {
+				// Real eigenvalue.
+				rnrm := bi.Dnrm2(n, vr[ks:], ldvr)
+				lnrm := bi.Dnrm2(n, vl[ks:], ldvl)
+				bi.Dgemv(blas.NoTrans, n, n, 1, a, lda, vr[ks:], ldvr, 0, work, 1)
+				uhav := bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				bi.Dgemv(blas.NoTrans, n, n, 1, b, ldb, vr[ks:], ldvr, 0, work, 1)
+				uhbv := bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				cond := impl.Dlapy2(uhav, uhbv)
+				if cond == 0 {
+					s[ks] = -1
+				} else {
+					s[ks] = cond / (rnrm * lnrm)
+				}
+			} else {
+				// Complex eigenvalue pair.
+				rnrm := impl.Dlapy2(bi.Dnrm2(n, vr[ks:], ldvr), bi.Dnrm2(n, vr[ks+1:], ldvr))
+				lnrm := impl.Dlapy2(bi.Dnrm2(n, vl[ks:], ldvl), bi.Dnrm2(n, vl[ks+1:], ldvl))
+
+				bi.Dgemv(blas.NoTrans, n, n, 1, a, lda, vr[ks:], ldvr, 0, work, 1)
+				tmprr := bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				tmpri := bi.Ddot(n, work, 1, vl[ks+1:], ldvl)
+				bi.Dgemv(blas.NoTrans, n, n, 1, a, lda, vr[ks+1:], ldvr, 0, work, 1)
+				tmpii := bi.Ddot(n, work, 1, vl[ks+1:], ldvl)
+				tmpir := bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				uhav := impl.Dlapy2(tmprr+tmpii, tmpir-tmpri)
+
+				bi.Dgemv(blas.NoTrans, n, n, 1, b, ldb, vr[ks:], ldvr, 0, work, 1)
+				tmprr = bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				tmpri = bi.Ddot(n, work, 1, vl[ks+1:], ldvl)
+				bi.Dgemv(blas.NoTrans, n, n, 1, b, ldb, vr[ks+1:], ldvr, 0, work, 1)
+				tmpii = bi.Ddot(n, work, 1, vl[ks+1:], ldvl)
+				tmpir = bi.Ddot(n, work, 1, vl[ks:], ldvl)
+				uhbv := impl.Dlapy2(tmprr+tmpii, tmpir-tmpri)
+
+				cond := impl.Dlapy2(uhav, uhbv)
+				s[ks] = cond / (rnrm * lnrm)
+				s[ks+1] = s[ks]
+			}
+		}
+
+		if wantdf {
+			// Estimate the reciprocal condition number of the k-th
+			// eigenvector.
+			if n == 1 {
+				dif[ks] = impl.Dlapy2(a[0], b[0])
+				break
+			}
+
+			var cond float64
+			if pair {
+				// Compute the eigenvalues of the 2×2 block at
+				// position k and the smallest singular value of
+				// the corresponding 2×2 pencil.
+				beta, _, alphar, _, alphai := impl.Dlag2(a[k*lda+k:], lda, b[k*ldb+k:], ldb, smlnum*eps)
+				c1 := 2 * (alphar*alphar + alphai*alphai + beta*beta)
+				c2 := 4 * beta * beta * alphai * alphai
+				root1 := (c1 + math.Sqrt(c1*c1-4*c2)) / 2
+				root2 := c2 / root1
+				cond = math.Min(math.Sqrt(root1), math.Sqrt(root2))
+			}
+
+			// Copy the matrix pair (A,B) to the work array and swap
+			// the diagonal block beginning at A[k,k] to the [0,0]
+			// position.
+			wa := work[:n*n]
+			wb := work[n*n : 2*n*n]
+			impl.Dlacpy(blas.All, n, n, a, lda, wa, n)
+			impl.Dlacpy(blas.All, n, n, b, ldb, wb, n)
+			_, _, ok := impl.Dtgexc(false, false, n, wa, n, wb, n, nil, 1, nil, 1, k, 0, work[2*n*n:], lwork-2*n*n)
+			if !ok {
+				// Ill-conditioned problem, swap rejected.
+				dif[ks] = 0
+			} else {
+				// Reordering successful, solve the generalized
+				// Sylvester equation for R and L
+				//  A22 * R - L * A11 = A12,
+				//  B22 * R - L * B11 = B12,
+				// and compute the estimate of Difl[(A11,B11),(A22,B22)].
+				n1 := 1
+				if wa[n] != 0 {
+					n1 = 2
+				}
+				n2 := n - n1
+				if n2 == 0 {
+					dif[ks] = cond
+				} else {
+					_, dif[ks], _ = impl.Dtgsyl(blas.NoTrans, 3, n2, n1, wa[n1*n+n1:], n, wa, n, wa[n1*n:], n,
+						wb[n1*n+n1:], n, wb, n, wb[n1*n:], n, work[2*n*n:], lwork-2*n*n, iwork)
+					if pair {
+						dif[ks] = math.Min(dif[ks], cond)
+					}
+				}
+			}
+			if pair {
+				dif[ks+1] = dif[ks]
+			}
+		}
+
+		if pair {
+			ks++
+			k++
+		}
+		ks++
+	}
+	work[0] = float64(lwmin)
+	return m
+}
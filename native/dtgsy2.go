@@ -0,0 +1,270 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dtgsy2 solves the generalized Sylvester equation
+//  A * R - L * B = scale * C,
+//  D * R - L * E = scale * F,
+// if trans == blas.NoTrans, or the transposed system
+//  A^T * R + D^T * L = scale * C,
+//  R * B^T + L * E^T = scale * (-F),
+// if trans == blas.Trans, using Level 1 and 2 BLAS. Here (A,D) is an m×m
+// matrix pair in generalized real Schur form, that is, A is upper
+// quasi-triangular and D is upper triangular, (B,E) is an n×n matrix pair in
+// generalized real Schur form, and C and F are m×n matrices. The 1×1 and 2×2
+// diagonal blocks of A must correspond to the 1×1 and 2×2 diagonal blocks of
+// B. The solution (R,L) overwrites (C,F).
+//
+// scale is a scaling factor in (0,1] chosen to avoid overflow.
+//
+// ijob specifies what kind of functionality is to be performed. If ijob is 0,
+// the equation is solved. If ijob is 1 or 2, and trans == blas.NoTrans, the
+// equation is solved and in addition the contribution of the solution to the
+// Frobenius-norm based estimate of the reciprocal of Dif[(A,D),(B,E)] is
+// computed by Dlatdf using, respectively, a look-ahead strategy or an
+// approximate null vector. If ijob > 0, trans must be blas.NoTrans.
+//
+// On entry, rdsum and rdscal must contain the sum of squares and the scaling
+// factor of the contributions computed by previous calls of Dtgsy2. On
+// return, they are updated by the contribution of the current call and
+// returned in sum and dscale. If trans == blas.Trans or ijob == 0, sum and
+// dscale are equal to rdsum and rdscal.
+//
+// pq is the number of subsystems solved.
+//
+// iwork must have length at least m+n+2.
+//
+// ok is false if some of the solved subsystems were (nearly) singular and
+// perturbed values were used to solve them.
+//
+// Dtgsy2 is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgsy2(trans blas.Transpose, ijob, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, d []float64, ldd int, e []float64, lde int, f []float64, ldf int, rdsum, rdscal float64, iwork []int) (scale, sum, dscale float64, pq int, ok bool) {
+	const ldz = 8
+	notran := trans == blas.NoTrans
+	switch {
+	case !notran && trans != blas.Trans:
+		panic(badTrans)
+	case ijob < 0 || 2 < ijob:
+		panic("lapack: invalid value of ijob")
+	case !notran && ijob != 0:
+		panic("lapack: ijob must be 0 for transposed system")
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case len(iwork) < m+n+2:
+		panic(badWork)
+	}
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
+	checkMatrix(m, n, c, ldc)
+	checkMatrix(m, m, d, ldd)
+	checkMatrix(n, n, e, lde)
+	checkMatrix(m, n, f, ldf)
+
+	sum = rdsum
+	dscale = rdscal
+	scale = 1
+	ok = true
+	if m == 0 || n == 0 {
+		return scale, sum, dscale, 0, ok
+	}
+
+	// Determine the block structure of A. The diagonal blocks of A start
+	// at rows iwork[0:p] and iwork[p] is set to m.
+	var p int
+	for i := 0; i < m; {
+		iwork[p] = i
+		p++
+		if i == m-1 {
+			break
+		}
+		if a[(i+1)*lda+i] != 0 {
+			i += 2
+		} else {
+			i++
+		}
+	}
+	iwork[p] = m
+
+	// Determine the block structure of B. The diagonal blocks of B start
+	// at rows iwork[p+1:q] and iwork[q] is set to n.
+	q := p + 1
+	for j := 0; j < n; {
+		iwork[q] = j
+		q++
+		if j == n-1 {
+			break
+		}
+		if b[(j+1)*ldb+j] != 0 {
+			j += 2
+		} else {
+			j++
+		}
+	}
+	iwork[q] = n
+	pq = p * (q - p - 1)
+
+	bi := blas64.Implementation()
+	var (
+		z          [ldz * ldz]float64
+		rhs        [ldz]float64
+		ipiv, jpiv [ldz]int
+	)
+	// buildZ sets up in z the zdim×zdim matrix of the linear system that
+	// corresponds to the (i,j) subsystem
+	//  A[is:ie,is:ie] * R - L * B[js:je,js:je] = C[is:ie,js:je],
+	//  D[is:ie,is:ie] * R - L * E[js:je,js:je] = F[is:ie,js:je],
+	// with the unknowns ordered as vec(R) followed by vec(L), where vec
+	// stacks the columns of its argument. If notran is false, z is
+	// transposed.
+	buildZ := func(is, js, mb, nb int) {
+		mn := mb * nb
+		zdim := 2 * mn
+		for i := 0; i < zdim; i++ {
+			for j := 0; j < zdim; j++ {
+				z[i*ldz+j] = 0
+			}
+		}
+		for col := 0; col < nb; col++ {
+			for r := 0; r < mb; r++ {
+				row := r + mb*col
+				for k := 0; k < mb; k++ {
+					z[row*ldz+k+mb*col] = a[(is+r)*lda+is+k]
+					z[(mn+row)*ldz+k+mb*col] = d[(is+r)*ldd+is+k]
+				}
+				for k := 0; k < nb; k++ {
+					z[row*ldz+mn+r+mb*k] = -b[(js+k)*ldb+js+col]
+					z[(mn+row)*ldz+mn+r+mb*k] = -e[(js+k)*lde+js+col]
+				}
+			}
+		}
+		if !notran {
+			for i := 0; i < zdim; i++ {
+				for j := i + 1; j < zdim; j++ {
+					z[i*ldz+j], z[j*ldz+i] = z[j*ldz+i], z[i*ldz+j]
+				}
+			}
+		}
+	}
+	// packRHS copies C[is:is+mb,js:js+nb] and F[is:is+mb,js:js+nb] into
+	// rhs in the order of the unknowns of the subsystem.
+	packRHS := func(is, js, mb, nb int) {
+		mn := mb * nb
+		for col := 0; col < nb; col++ {
+			for r := 0; r < mb; r++ {
+				rhs[r+mb*col] = c[(is+r)*ldc+js+col]
+				rhs[mn+r+mb*col] = f[(is+r)*ldf+js+col]
+			}
+		}
+	}
+	// unpackRHS is the inverse of packRHS.
+	unpackRHS := func(is, js, mb, nb int) {
+		mn := mb * nb
+		for col := 0; col < nb; col++ {
+			for r := 0; r < mb; r++ {
+				c[(is+r)*ldc+js+col] = rhs[r+mb*col]
+				f[(is+r)*ldf+js+col] = rhs[mn+r+mb*col]
+			}
+		}
+	}
+	// solve solves the subsystem of order zdim set up in z and rhs.
+	solve := func(zdim int) {
+		if !impl.Dgetc2(zdim, z[:], ldz, ipiv[:], jpiv[:]) {
+			ok = false
+		}
+		if ijob > 0 {
+			sum, dscale = impl.Dlatdf(ijob, zdim, z[:], ldz, rhs[:], sum, dscale, ipiv[:], jpiv[:])
+			return
+		}
+		scaloc := impl.Dgesc2(zdim, z[:], ldz, rhs[:], ipiv[:], jpiv[:])
+		if scaloc != 1 {
+			for k := 0; k < m; k++ {
+				bi.Dscal(n, scaloc, c[k*ldc:], 1)
+				bi.Dscal(n, scaloc, f[k*ldf:], 1)
+			}
+			scale *= scaloc
+		}
+	}
+
+	if notran {
+		// Solve (i,j)-subsystem
+		//  A[i,i] * R[i,j] - L[i,j] * B[j,j] = C[i,j],
+		//  D[i,i] * R[i,j] - L[i,j] * E[j,j] = F[i,j],
+		// for i = p-1, p-2, ..., 0; j = 0, 1, ..., q-p-2.
+		for j := p + 1; j < q; j++ {
+			js := iwork[j]
+			je := iwork[j+1]
+			nb := je - js
+			for i := p - 1; i >= 0; i-- {
+				is := iwork[i]
+				ie := iwork[i+1]
+				mb := ie - is
+
+				buildZ(is, js, mb, nb)
+				packRHS(is, js, mb, nb)
+				solve(2 * mb * nb)
+				unpackRHS(is, js, mb, nb)
+
+				// Substitute R[i,j] and L[i,j] into the remaining
+				// equations.
+				if i > 0 {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, is, nb, mb, -1, a[is:], lda, c[is*ldc+js:], ldc,
+						1, c[js:], ldc)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, is, nb, mb, -1, d[is:], ldd, c[is*ldc+js:], ldc,
+						1, f[js:], ldf)
+				}
+				if j < q-1 {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, mb, n-je, nb, 1, f[is*ldf+js:], ldf, b[js*ldb+je:], ldb,
+						1, c[is*ldc+je:], ldc)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, mb, n-je, nb, 1, f[is*ldf+js:], ldf, e[js*lde+je:], lde,
+						1, f[is*ldf+je:], ldf)
+				}
+			}
+		}
+		return scale, sum, dscale, pq, ok
+	}
+
+	// Solve transposed (i,j)-subsystem
+	//  A[i,i]^T * R[i,j] + D[i,i]^T * L[i,j] = C[i,j],
+	//  R[i,j] * B[j,j]^T + L[i,j] * E[j,j]^T = -F[i,j],
+	// for i = 0, 1, ..., p-1; j = q-p-2, q-p-3, ..., 0.
+	for i := 0; i < p; i++ {
+		is := iwork[i]
+		ie := iwork[i+1]
+		mb := ie - is
+		for j := q - 1; j >= p+1; j-- {
+			js := iwork[j]
+			je := iwork[j+1]
+			nb := je - js
+
+			buildZ(is, js, mb, nb)
+			packRHS(is, js, mb, nb)
+			solve(2 * mb * nb)
+			unpackRHS(is, js, mb, nb)
+
+			// Substitute R[i,j] and L[i,j] into the remaining
+			// equations.
+			if j > p+1 {
+				bi.Dgemm(blas.NoTrans, blas.Trans, mb, js, nb, 1, c[is*ldc+js:], ldc, b[js:], ldb,
+					1, f[is*ldf:], ldf)
+				bi.Dgemm(blas.NoTrans, blas.Trans, mb, js, nb, 1, f[is*ldf+js:], ldf, e[js:], lde,
+					1, f[is*ldf:], ldf)
+			}
+			if i < p-1 {
+				bi.Dgemm(blas.Trans, blas.NoTrans, m-ie, nb, mb, -1, a[is*lda+ie:], lda, c[is*ldc+js:], ldc,
+					1, c[ie*ldc+js:], ldc)
+				bi.Dgemm(blas.Trans, blas.NoTrans, m-ie, nb, mb, -1, d[is*ldd+ie:], ldd, f[is*ldf+js:], ldf,
+					1, c[ie*ldc+js:], ldc)
+			}
+		}
+	}
+	return scale, sum, dscale, pq, ok
+}
@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+)
+
+// Dtgsyl solves the generalized Sylvester equation
+//  A * R - L * B = scale * C,
+//  D * R - L * E = scale * F,
+// if trans == blas.NoTrans, or the transposed system
+//  A^T * R + D^T * L = scale * C,
+//  R * B^T + L * E^T = scale * (-F),
+// if trans == blas.Trans. Here (A,D) is an m×m matrix pair and (B,E) is an n×n
+// matrix pair, both in generalized real Schur form, that is, A and B are upper
+// quasi-triangular and D and E are upper triangular. C and F are m×n
+// matrices. The solution (R,L) overwrites (C,F). scale is an output scaling
+// factor in (0,1] chosen to avoid overflow.
+//
+// In matrix notation the system is equivalent to solving Z*x = scale*b, where
+// Z is the 2*m*n×2*m*n matrix
+//  Z = [ kron(I_n, A)  -kron(B^T, I_m) ]
+//      [ kron(I_n, D)  -kron(E^T, I_m) ],
+// and the transposed system is equivalent to solving Z^T*x = scale*b.
+//
+// If trans == blas.NoTrans, Dtgsyl can also compute an estimate dif of the
+// separation
+//  Dif[(A,D),(B,E)] = sigma_min(Z)
+// of the two matrix pairs. ijob specifies the functionality:
+//  ijob == 0: Solve the equation only.
+//  ijob == 1: Solve the equation and compute a Frobenius norm-based
+//             estimate of Dif using Dlatdf with a look-ahead strategy.
+//  ijob == 2: Solve the equation and compute a Frobenius norm-based
+//             estimate of Dif using Dgecon.
+//  ijob == 3: Only compute the estimate of Dif as for ijob == 1.
+//  ijob == 4: Only compute the estimate of Dif as for ijob == 2.
+// If ijob is 3 or 4, C and F are overwritten by arbitrary values. If ijob > 0,
+// trans must be blas.NoTrans. For other values of ijob Dtgsyl will panic.
+//
+// dif is an estimate of Dif[(A,D),(B,E)], computed as the reciprocal of a
+// lower bound of 1/Dif. It is computed only if trans == blas.NoTrans and
+// ijob > 0, otherwise it is zero.
+//
+// work must have length at least lwork, and lwork must be at least 2*m*n if
+// ijob is 1 or 2 and trans == blas.NoTrans, and at least 1 otherwise. If
+// lwork is -1, instead of performing Dtgsyl, the optimal workspace size will
+// be stored in work[0].
+//
+// iwork must have length at least m+n+2.
+//
+// If ok is false, (A,D) and (B,E) have common or close eigenvalues and
+// perturbed values were used to solve the equation.
+//
+// Dtgsyl uses the Level 2 solver Dtgsy2 on the whole system.
+//
+// Dtgsyl is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtgsyl(trans blas.Transpose, ijob, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, d []float64, ldd int, e []float64, lde int, f []float64, ldf int, work []float64, lwork int, iwork []int) (scale, dif float64, ok bool) {
+	notran := trans == blas.NoTrans
+	switch {
+	case !notran && trans != blas.Trans:
+		panic(badTrans)
+	case notran && (ijob < 0 || 4 < ijob):
+		panic("lapack: invalid value of ijob")
+	case !notran && ijob != 0:
+		panic("lapack: ijob must be 0 for transposed system")
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	case len(work) < lwork:
+		panic(shortWork)
+	}
+	lwmin := 1
+	if notran && (ijob == 1 || ijob == 2) {
+		lwmin = max(1, 2*m*n)
+	}
+	if lwork == -1 {
+		work[0] = float64(lwmin)
+		return 1, 0, true
+	}
+	if lwork < lwmin {
+		panic(badWork)
+	}
+	if len(iwork) < m+n+2 {
+		panic(badWork)
+	}
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		work[0] = float64(lwmin)
+		return 1, 0, true
+	}
+
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
+	checkMatrix(m, n, c, ldc)
+	checkMatrix(m, m, d, ldd)
+	checkMatrix(n, n, e, lde)
+	checkMatrix(m, n, f, ldf)
+
+	isolve := 1
+	var ifunc int
+	if notran {
+		if ijob >= 3 {
+			ifunc = ijob - 2
+			impl.Dlaset(blas.All, m, n, 0, 0, c, ldc)
+			impl.Dlaset(blas.All, m, n, 0, 0, f, ldf)
+		} else if ijob >= 1 {
+			isolve = 2
+		}
+	}
+
+	ok = true
+	var scale2 float64
+	for iround := 1; iround <= isolve; iround++ {
+		var (
+			dsum    float64
+			dscale  float64
+			pq      int
+			nonsing bool
+		)
+		scale, dsum, dscale, pq, nonsing = impl.Dtgsy2(trans, ifunc, m, n, a, lda, b, ldb, c, ldc,
+			d, ldd, e, lde, f, ldf, 1, 0, iwork)
+		ok = ok && nonsing
+		if dscale != 0 {
+			if ijob == 1 || ijob == 3 {
+				dif = math.Sqrt(float64(2*m*n)) / (dscale * math.Sqrt(dsum))
+			} else {
+				dif = math.Sqrt(float64(pq)) / (dscale * math.Sqrt(dsum))
+			}
+		}
+		if isolve == 2 && iround == 1 {
+			// Store the solution and compute the estimate of Dif in
+			// the second round.
+			ifunc = ijob
+			scale2 = scale
+			impl.Dlacpy(blas.All, m, n, c, ldc, work, n)
+			impl.Dlacpy(blas.All, m, n, f, ldf, work[m*n:], n)
+			impl.Dlaset(blas.All, m, n, 0, 0, c, ldc)
+			impl.Dlaset(blas.All, m, n, 0, 0, f, ldf)
+		} else if isolve == 2 && iround == 2 {
+			// Restore the solution.
+			impl.Dlacpy(blas.All, m, n, work, n, c, ldc)
+			impl.Dlacpy(blas.All, m, n, work[m*n:], n, f, ldf)
+			scale = scale2
+		}
+	}
+
+	work[0] = float64(lwmin)
+	return scale, dif, ok
+}
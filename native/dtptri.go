@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+// Dtptri computes the inverse of an n×n triangular matrix A stored in packed
+// format. ap contains the upper or lower triangle of A in the row-major packed
+// format as used by blas64.TriangularPacked, see the documentation of Dpptrf
+// for the details of the storage. ap must have length at least n*(n+1)/2,
+// otherwise Dtptri will panic. On return, ap contains the inverse of A in the
+// same format.
+//
+// Dtptri returns whether the matrix A is nonsingular. If ok is false, A is
+// exactly singular and the inverse has not been computed.
+func (Implementation) Dtptri(uplo blas.Uplo, diag blas.Diag, n int, ap []float64) (ok bool) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case diag != blas.NonUnit && diag != blas.Unit:
+		panic(badDiag)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return true
+	}
+
+	nonUnit := diag == blas.NonUnit
+	upper := uplo == blas.Upper
+
+	// Check for singularity if non-unit.
+	if nonUnit {
+		for j := 0; j < n; j++ {
+			// jj is the index of the diagonal element A[j,j] in ap.
+			jj := j*(j+1)/2 + j
+			if upper {
+				jj = j*n - j*(j-1)/2
+			}
+			if ap[jj] == 0 {
+				return false
+			}
+		}
+	}
+
+	bi := blas64.Implementation()
+	if upper {
+		// Compute the inverse of the upper triangular matrix one row at a
+		// time, starting from the bottom. jc is the index of the diagonal
+		// element A[j,j] in ap and jclast is the index of A[j+1,j+1].
+		jc := n*(n+1)/2 - 1
+		var jclast int
+		for j := n - 1; j >= 0; j-- {
+			ajj := -1.0
+			if nonUnit {
+				ap[jc] = 1 / ap[jc]
+				ajj = -ap[jc]
+			}
+			if j < n-1 {
+				// Compute the elements j+1:n of row j.
+				bi.Dtpmv(blas.Upper, blas.Trans, diag, n-j-1, ap[jclast:], ap[jc+1:], 1)
+				bi.Dscal(n-j-1, ajj, ap[jc+1:], 1)
+			}
+			jclast = jc
+			jc -= n - j + 1
+		}
+		return true
+	}
+
+	// Compute the inverse of the lower triangular matrix one row at a time,
+	// starting from the top. jc is the index of the element A[j,0] in ap.
+	var jc int
+	for j := 0; j < n; j++ {
+		ajj := -1.0
+		if nonUnit {
+			ap[jc+j] = 1 / ap[jc+j]
+			ajj = -ap[jc+j]
+		}
+		if j > 0 {
+			// Compute the elements 0:j of row j.
+			bi.Dtpmv(blas.Lower, blas.Trans, diag, j, ap, ap[jc:], 1)
+			bi.Dscal(j, ajj, ap[jc:], 1)
+		}
+		jc += j + 1
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dtpttf copies an n×n symmetric or triangular matrix A stored in packed
+// format in ap into arf in rectangular full packed (RFP) format. The packed
+// format is described in the documentation of Dpptrf and the RFP format in
+// the documentation of Dtrttf.
+//
+// transr must be blas.NoTrans or blas.Trans, and ap and arf must have length
+// at least n*(n+1)/2, otherwise Dtpttf will panic.
+func (Implementation) Dtpttf(transr blas.Transpose, uplo blas.Uplo, n int, ap, arf []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(ap) < n*(n+1)/2:
+		panic(badAP)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+
+	l := rfpBlocks(transr, uplo, n)
+	var k int
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j < n; j++ {
+				arf[l.index(i, j)] = ap[k]
+				k++
+			}
+		} else {
+			for j := 0; j <= i; j++ {
+				arf[l.index(i, j)] = ap[k]
+				k++
+			}
+		}
+	}
+}
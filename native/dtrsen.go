@@ -0,0 +1,253 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dtrsen reorders the real Schur factorization of an n×n real matrix
+//  A = Q*T*Q^T
+// so that a selected cluster of eigenvalues appears in the leading diagonal
+// blocks of the upper quasi-triangular matrix T, and the leading columns of Q
+// form an orthonormal basis of the corresponding right invariant subspace.
+// Optionally, Dtrsen computes the reciprocal condition numbers of the cluster
+// of eigenvalues and of the invariant subspace.
+//
+// On entry, T must be in Schur canonical form as returned by Dhseqr, that is,
+// block upper triangular with 1×1 and 2×2 diagonal blocks; each 2×2 diagonal
+// block has its diagonal elements equal and its off-diagonal elements of
+// opposite sign. On return, T is overwritten by the reordered matrix, again
+// in Schur canonical form, with the selected eigenvalues in the leading
+// diagonal blocks.
+//
+// If compq == lapack.UpdateSchur, on entry q must contain the n×n matrix Q of
+// Schur vectors and on return it is postmultiplied by the orthogonal
+// transformation that reorders T. If compq == lapack.None, q is not
+// referenced. For other values of compq Dtrsen will panic.
+//
+// selected specifies the eigenvalues in the selected cluster. To select a
+// real eigenvalue T[j,j], selected[j] must be true. To select a complex
+// conjugate pair of eigenvalues corresponding to a 2×2 diagonal block
+// T[j:j+2,j:j+2], either selected[j] or selected[j+1] or both must be true.
+// selected must have length n.
+//
+// On return, wr and wi contain the real and imaginary parts, respectively, of
+// the reordered eigenvalues of T in the same order as on the diagonal of T.
+// wr and wi must have length n.
+//
+// job specifies which condition numbers are computed. If job is
+// lapack.CondEigenvalues, lapack.CondSubspace or lapack.CondBoth, the
+// condition number for the cluster of eigenvalues, for the invariant
+// subspace, or for both, respectively, are computed. If job is lapack.None,
+// no condition numbers are computed. For other values of job Dtrsen will
+// panic.
+//
+// m is the dimension of the invariant subspace, that is, the number of
+// selected eigenvalues counting each complex conjugate pair as two.
+//
+// s is the lower bound on the reciprocal condition number of the average of
+// the selected eigenvalues. It is computed only if job is
+// lapack.CondEigenvalues or lapack.CondBoth, otherwise it is zero. If m == 0
+// or m == n, s is 1.
+//
+// sep is the estimated reciprocal condition number of the invariant
+// subspace. It is computed only if job is lapack.CondSubspace or
+// lapack.CondBoth, otherwise it is zero. If m == 0 or m == n, sep is the
+// 1-norm of T.
+//
+// work and iwork are temporary storage, and lwork and liwork specify their
+// usable lengths. lwork must be at least
+//  max(1,n)            if job == lapack.None,
+//  max(1,n,m*(n-m))    if job == lapack.CondEigenvalues,
+//  max(1,n,2*m*(n-m))  if job == lapack.CondSubspace or lapack.CondBoth,
+// and liwork must be at least 1 if job is lapack.None or
+// lapack.CondEigenvalues, and at least max(1,m*(n-m)) otherwise. Dtrsen will
+// panic if these conditions are not met. If lwork == -1 or liwork == -1,
+// instead of performing Dtrsen, the minimum lengths of work and iwork for the
+// given selection are stored into work[0] and iwork[0], respectively, and T
+// and Q are not modified.
+//
+// ok will be false if the reordering failed because some selected
+// eigenvalues are so close to eigenvalues that were not selected that they
+// could not be swapped. In that case T may have been partially reordered and
+// wr, wi, s and sep are those of the partially reordered T, with s and sep
+// set to zero.
+func (impl Implementation) Dtrsen(job lapack.CondJob, compq lapack.EVComp, selected []bool, n int, t []float64, ldt int, q []float64, ldq int, wr, wi []float64, work []float64, lwork int, iwork []int, liwork int) (m int, s, sep float64, ok bool) {
+	var wants, wantsp bool
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.None:
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantsp = true
+	case lapack.CondBoth:
+		wants = true
+		wantsp = true
+	}
+	var wantq bool
+	switch compq {
+	default:
+		panic("lapack: bad value of compq")
+	case lapack.None:
+	case lapack.UpdateSchur:
+		wantq = true
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, t, ldt)
+	if wantq {
+		checkMatrix(n, n, q, ldq)
+	}
+	if len(selected) != n {
+		panic("lapack: bad length of selected")
+	}
+
+	// Set m to the dimension of the specified invariant subspace.
+	for k := 0; k < n; k++ {
+		if k < n-1 && t[(k+1)*ldt+k] != 0 {
+			if selected[k] || selected[k+1] {
+				m += 2
+			}
+			k++
+			continue
+		}
+		if selected[k] {
+			m++
+		}
+	}
+
+	n1 := m
+	n2 := n - m
+	nn := n1 * n2
+	var lwmin, liwmin int
+	switch {
+	case wantsp:
+		lwmin = max(1, max(n, 2*nn))
+		liwmin = max(1, nn)
+	case wants:
+		lwmin = max(1, max(n, nn))
+		liwmin = 1
+	default:
+		lwmin = max(1, n)
+		liwmin = 1
+	}
+	if lwork == -1 || liwork == -1 {
+		work[0] = float64(lwmin)
+		iwork[0] = liwmin
+		return m, 0, 0, true
+	}
+	switch {
+	case len(wr) != n:
+		panic("lapack: bad length of wr")
+	case len(wi) != n:
+		panic("lapack: bad length of wi")
+	case lwork < lwmin || len(work) < lwork:
+		panic(badWork)
+	case liwork < liwmin || len(iwork) < liwork:
+		panic(badWork)
+	}
+
+	ok = true
+	if m == n || m == 0 {
+		// Quick return if possible.
+		if wants {
+			s = 1
+		}
+		if wantsp {
+			sep = impl.Dlange(lapack.MaxColumnSum, n, n, t, ldt, work)
+		}
+	} else {
+		// Collect the selected blocks at the top-left corner of T.
+		var ks int
+		for k := 0; k < n; k++ {
+			swap := selected[k]
+			pair := k < n-1 && t[(k+1)*ldt+k] != 0
+			if pair {
+				swap = swap || selected[k+1]
+			}
+			if swap {
+				// Swap the k-th block to position ks.
+				if k != ks {
+					_, ks, ok = impl.Dtrexc(compq, n, t, ldt, q, ldq, k, ks, work)
+				}
+				if !ok {
+					// Blocks too close to swap.
+					s = 0
+					sep = 0
+					break
+				}
+				ks++
+				if pair {
+					ks++
+				}
+			}
+			if pair {
+				k++
+			}
+		}
+
+		if ok && wants {
+			// Solve the Sylvester equation for R:
+			//  T11*R - R*T22 = scale*T12
+			// where T11 is n1×n1, T22 is n2×n2 and T12 is n1×n2.
+			impl.Dlacpy(blas.All, n1, n2, t[n1:], ldt, work, n2)
+			scale, _ := impl.Dtrsyl(blas.NoTrans, blas.NoTrans, -1, n1, n2, t, ldt, t[n1*ldt+n1:], ldt, work, n2)
+
+			// Estimate the reciprocal of the condition number of the
+			// cluster of eigenvalues.
+			rnorm := impl.Dlange(lapack.NormFrob, n1, n2, work, n2, nil)
+			if rnorm == 0 {
+				s = 1
+			} else {
+				s = scale / (math.Sqrt(scale*scale/rnorm+rnorm) * math.Sqrt(rnorm))
+			}
+		}
+
+		if ok && wantsp {
+			// Estimate sep(T11,T22).
+			var (
+				est   float64
+				kase  int
+				isave [3]int
+			)
+			var scale float64
+			for {
+				est, kase = impl.Dlacn2(nn, work[nn:], work, iwork, est, kase, &isave)
+				if kase == 0 {
+					break
+				}
+				if kase == 1 {
+					// Solve T11*R - R*T22 = scale*X.
+					scale, _ = impl.Dtrsyl(blas.NoTrans, blas.NoTrans, -1, n1, n2, t, ldt, t[n1*ldt+n1:], ldt, work, n2)
+				} else {
+					// Solve T11^T*R - R*T22^T = scale*X.
+					scale, _ = impl.Dtrsyl(blas.Trans, blas.Trans, -1, n1, n2, t, ldt, t[n1*ldt+n1:], ldt, work, n2)
+				}
+			}
+			sep = scale / est
+		}
+	}
+
+	// Store the output eigenvalues in wr and wi.
+	for k := 0; k < n; k++ {
+		wr[k] = t[k*ldt+k]
+		wi[k] = 0
+	}
+	for k := 0; k < n-1; k++ {
+		if t[(k+1)*ldt+k] != 0 {
+			wi[k] = math.Sqrt(math.Abs(t[k*ldt+k+1])) * math.Sqrt(math.Abs(t[(k+1)*ldt+k]))
+			wi[k+1] = -wi[k]
+		}
+	}
+
+	return m, s, sep, ok
+}
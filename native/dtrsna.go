@@ -0,0 +1,308 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtrsna estimates reciprocal condition numbers for specified eigenvalues
+// and/or right eigenvectors of an n×n upper quasi-triangular matrix T, or for
+// the eigenvalues and/or right eigenvectors of a matrix A = Q*T*Q^T with
+// orthogonal Q.
+//
+// On entry, T must be in Schur canonical form as returned by Dhseqr, that is,
+// block upper triangular with 1×1 and 2×2 diagonal blocks; each 2×2 diagonal
+// block has its diagonal elements equal and its off-diagonal elements of
+// opposite sign. T is not modified.
+//
+// job specifies which condition numbers are computed. If job is
+// lapack.CondEigenvalues, the condition numbers of the eigenvalues are
+// computed and stored in s. If job is lapack.CondSubspace, the condition
+// numbers of the right eigenvectors are computed and stored in sep. If job is
+// lapack.CondBoth, both are computed. For other values of job Dtrsna will
+// panic.
+//
+// howmny specifies the eigenpairs for which condition numbers are computed.
+// If howmny is lapack.AllEV, the condition numbers are computed for all
+// eigenpairs. If howmny is lapack.SelectedEV, the condition numbers are
+// computed only for the eigenpairs specified by selected. In that case, to
+// select a real eigenvalue T[j,j], selected[j] must be true, and to select a
+// complex conjugate pair of eigenvalues corresponding to a 2×2 diagonal block
+// T[j:j+2,j:j+2], either selected[j] or selected[j+1] or both must be true.
+// selected must have length n. If howmny is lapack.AllEV, selected is not
+// referenced. For other values of howmny Dtrsna will panic.
+//
+// If job is lapack.CondEigenvalues or lapack.CondBoth, vl and vr must contain
+// in their columns the left and right eigenvectors, respectively, of T (or of
+// A = Q*T*Q^T, if the eigenvectors have been multiplied by Q) corresponding
+// to the eigenpairs specified by howmny and selected, stored consecutively in
+// the same order as the eigenvalues. Eigenvectors of complex conjugate pairs
+// are stored as in Dtrevc3 in two consecutive columns holding their real and
+// imaginary parts. Such eigenvectors are computed by Dtrevc3 or Dtrevc. vl
+// and vr must be n×m matrices. If job is lapack.CondSubspace, vl and vr are
+// not referenced.
+//
+// On return, s[k] and sep[k] contain the reciprocal condition numbers of the
+// k-th selected eigenvalue and right eigenvector, respectively, with each
+// complex conjugate pair occupying two consecutive elements with equal values.
+// If job is lapack.CondSubspace, s is not referenced, and if job is
+// lapack.CondEigenvalues, sep is not referenced. Otherwise s and sep must have
+// length at least m.
+//
+// mm is the number of columns of vl and vr available, and must be at least m,
+// the number of selected eigenvalues counting each complex conjugate pair as
+// two. m is returned by Dtrsna.
+//
+// work is temporary storage with a leading dimension of ldwork. If job is
+// lapack.CondSubspace or lapack.CondBoth, ldwork must be at least max(1,n)
+// and work must have length at least ldwork*(n+6), and iwork must have length
+// at least 2*(n-1). Otherwise work and iwork are not referenced. Dtrsna will
+// panic if these conditions are not met.
+//
+// Dtrsna is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtrsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, t []float64, ldt int, vl []float64, ldvl int, vr []float64, ldvr int, s, sep []float64, mm int, work []float64, ldwork int, iwork []int) (m int) {
+	var wants, wantsp bool
+	switch job {
+	default:
+		panic("lapack: bad CondJob")
+	case lapack.CondEigenvalues:
+		wants = true
+	case lapack.CondSubspace:
+		wantsp = true
+	case lapack.CondBoth:
+		wants = true
+		wantsp = true
+	}
+	var somcon bool
+	switch howmny {
+	default:
+		panic(badHowMany)
+	case lapack.AllEV:
+	case lapack.SelectedEV:
+		somcon = true
+	}
+	if n < 0 {
+		panic(nLT0)
+	}
+	checkMatrix(n, n, t, ldt)
+	if somcon && len(selected) != n {
+		panic("lapack: bad length of selected")
+	}
+
+	// Set m to the number of eigenpairs for which condition numbers are
+	// required.
+	if somcon {
+		for k := 0; k < n; k++ {
+			if k < n-1 && t[(k+1)*ldt+k] != 0 {
+				if selected[k] || selected[k+1] {
+					m += 2
+				}
+				k++
+				continue
+			}
+			if selected[k] {
+				m++
+			}
+		}
+	} else {
+		m = n
+	}
+
+	if mm < m {
+		panic("lapack: mm < m")
+	}
+	if wants {
+		if m > 0 {
+			checkMatrix(n, m, vl, ldvl)
+			checkMatrix(n, m, vr, ldvr)
+		}
+		if len(s) < m {
+			panic(badS)
+		}
+	}
+	if wantsp {
+		if len(sep) < m {
+			panic("lapack: sep has insufficient length")
+		}
+		if ldwork < max(1, n) {
+			panic(badWorkStride)
+		}
+		if len(work) < ldwork*(n+6) {
+			panic(badWork)
+		}
+		if len(iwork) < 2*(n-1) {
+			panic("lapack: insufficient length of iwork")
+		}
+	}
+
+	// Quick return if possible.
+	if n == 0 {
+		return m
+	}
+	if n == 1 {
+		if somcon && !selected[0] {
+			return m
+		}
+		if wants {
+			s[0] = 1
+		}
+		if wantsp {
+			sep[0] = math.Abs(t[0])
+		}
+		return m
+	}
+
+	bi := blas64.Implementation()
+
+	// Get machine constants.
+	eps := dlamchP
+	smlnum := dlamchS / eps
+	bignum := 1 / smlnum
+
+	// Partition work into the copy of T, the vector b of Dlaqtr, the vectors
+	// v and x of Dlacn2 and the workspace for Dlaqtr and Dtrexc.
+	var wrk, b, v, x, wtmp []float64
+	if wantsp {
+		wrk = work[:n*ldwork]
+		b = work[n*ldwork : n*ldwork+n]
+		v = work[n*ldwork+n : n*ldwork+3*n]
+		x = work[n*ldwork+3*n : n*ldwork+5*n]
+		wtmp = work[n*ldwork+5*n : n*ldwork+6*n]
+	}
+
+	var ks int
+	for k := 0; k < n; k++ {
+		// Determine whether T[k,k] begins a 1×1 or a 2×2 block.
+		pair := k < n-1 && t[(k+1)*ldt+k] != 0
+
+		// Determine whether condition numbers are required for the k-th
+		// eigenpair.
+		if somcon {
+			if pair {
+				if !selected[k] && !selected[k+1] {
+					k++
+					continue
+				}
+			} else if !selected[k] {
+				continue
+			}
+		}
+
+		if wants {
+			// Compute the reciprocal condition number of the k-th
+			// eigenvalue.
+			if !pair {
+				prod := bi.Ddot(n, vr[ks:], ldvr, vl[ks:], ldvl)
+				rnrm := bi.Dnrm2(n, vr[ks:], ldvr)
+				lnrm := bi.Dnrm2(n, vl[ks:], ldvl)
+				s[ks] = math.Abs(prod) / (rnrm * lnrm)
+			} else {
+				// Complex eigenvalue.
+				prod1 := bi.Ddot(n, vr[ks:], ldvr, vl[ks:], ldvl)
+				prod1 += bi.Ddot(n, vr[ks+1:], ldvr, vl[ks+1:], ldvl)
+				prod2 := bi.Ddot(n, vl[ks:], ldvl, vr[ks+1:], ldvr)
+				prod2 -= bi.Ddot(n, vl[ks+1:], ldvl, vr[ks:], ldvr)
+				rnrm := impl.Dlapy2(bi.Dnrm2(n, vr[ks:], ldvr), bi.Dnrm2(n, vr[ks+1:], ldvr))
+				lnrm := impl.Dlapy2(bi.Dnrm2(n, vl[ks:], ldvl), bi.Dnrm2(n, vl[ks+1:], ldvl))
+				cond := impl.Dlapy2(prod1, prod2) / (rnrm * lnrm)
+				s[ks] = cond
+				s[ks+1] = cond
+			}
+		}
+
+		if wantsp {
+			// Estimate the reciprocal condition number of the k-th
+			// eigenvector.
+
+			// Copy the matrix T to the work array and swap the diagonal
+			// block beginning at T[k,k] to the [0,0] position.
+			impl.Dlacpy(blas.All, n, n, t, ldt, wrk, ldwork)
+			_, _, ok := impl.Dtrexc(lapack.None, n, wrk, ldwork, nil, 1, k, 0, wtmp)
+
+			var scale, est float64
+			if !ok {
+				// Could not swap because blocks not well separated.
+				scale = 1
+				est = bignum
+			} else {
+				// Reordering successful.
+				var n2, nn int
+				var mu float64
+				if wrk[ldwork] == 0 {
+					// Form C = T22 - lambda*I in wrk[1:n,1:n].
+					for i := 1; i < n; i++ {
+						wrk[i*ldwork+i] -= wrk[0]
+					}
+					n2 = 1
+					nn = n - 1
+				} else {
+					// Triangularize the 2×2 block by unitary
+					// transformation
+					//  U = [  cs   i*ss ]
+					//      [ i*ss   cs  ]
+					// such that the [0,0] position of the work
+					// array is the complex eigenvalue lambda with
+					// positive imaginary part and the [1,1]
+					// position is the complex eigenvalue lambda with
+					// negative imaginary part.
+					mu = math.Sqrt(math.Abs(wrk[1])) * math.Sqrt(math.Abs(wrk[ldwork]))
+					delta := impl.Dlapy2(mu, wrk[ldwork])
+					cs := mu / delta
+					sn := -wrk[ldwork] / delta
+
+					// Form
+					//  C^T = wrk[1:n,1:n] + i*[ b[0] ... b[n-2] ]
+					//                         [       mu        ]
+					//                         [          ...    ]
+					//                         [              mu ]
+					// where C^T is the transpose of the matrix C.
+					for j := 2; j < n; j++ {
+						wrk[ldwork+j] *= cs
+						wrk[j*ldwork+j] -= wrk[0]
+					}
+					wrk[ldwork+1] = 0
+					b[0] = 2 * mu
+					for i := 1; i < n-1; i++ {
+						b[i] = sn * wrk[i+1]
+					}
+					n2 = 2
+					nn = 2 * (n - 1)
+				}
+
+				// Estimate norm(inv(C^T)).
+				var kase int
+				var isave [3]int
+				for {
+					est, kase = impl.Dlacn2(nn, v, x, iwork, est, kase, &isave)
+					if kase == 0 {
+						break
+					}
+					// Solve C^T*x = scale*c if kase is 1, and
+					// C*x = scale*c otherwise, for a real
+					// eigenvalue, or the complex counterparts in
+					// real arithmetic for a complex eigenvalue.
+					scale, _ = impl.Dlaqtr(kase == 1, n2 == 1, n-1, wrk[ldwork+1:], ldwork, b, mu, x, wtmp)
+				}
+			}
+
+			sep[ks] = scale / math.Max(est, smlnum)
+			if pair {
+				sep[ks+1] = sep[ks]
+			}
+		}
+
+		if pair {
+			ks++
+			k++
+		}
+		ks++
+	}
+	return m
+}
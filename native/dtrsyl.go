@@ -0,0 +1,202 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtrsyl solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where A is an m×m and B an n×n upper quasi-triangular matrix in Schur
+// canonical form, C and X are m×n matrices, op(A) is A or A^T as specified
+// by trana, and op(B) is B or B^T as specified by tranb. isgn must be 1 or -1.
+//
+// On entry, c contains the right-hand side matrix C. On return, it is
+// overwritten by the solution matrix X.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X.
+//
+// ok will be false if A and -isgn*B have common or very close eigenvalues,
+// in which case perturbed values are used to solve the equation.
+func (impl Implementation) Dtrsyl(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int) (scale float64, ok bool) {
+	switch {
+	case trana != blas.NoTrans && trana != blas.Trans && trana != blas.ConjTrans:
+		panic(badTrans)
+	case tranb != blas.NoTrans && tranb != blas.Trans && tranb != blas.ConjTrans:
+		panic(badTrans)
+	case isgn != 1 && isgn != -1:
+		panic(badIsgn)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	}
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, true
+	}
+	checkMatrix(m, n, c, ldc)
+
+	notrna := trana == blas.NoTrans
+	notrnb := tranb == blas.NoTrans
+
+	// Set constants to control overflow.
+	eps := dlamchP
+	smlnum := dlamchS * float64(m*n) / eps
+	bignum := 1 / smlnum
+	smin := math.Max(smlnum, math.Max(eps*impl.Dlange(lapack.MaxAbs, m, m, a, lda, nil),
+		eps*impl.Dlange(lapack.MaxAbs, n, n, b, ldb, nil)))
+	sgn := float64(isgn)
+
+	bi := blas64.Implementation()
+
+	// rhs returns the right-hand side
+	//  C[k,l] - R[k,l]
+	// of the equation for the element X[k,l] that lies in the block of X
+	// with rows k1 through k2 and columns l1 through l2. R[k,l] contains
+	// the contributions of the elements of X that have already been
+	// computed, for example, if trana == tranb == blas.NoTrans,
+	//  R[k,l] = sum_{i>k2} A[k,i]*X[i,l] + isgn * sum_{j<l1} X[k,j]*B[j,l].
+	rhs := func(k, l, k1, k2, l1, l2 int) float64 {
+		var suml, sumr float64
+		if notrna {
+			if k2 < m-1 {
+				suml = bi.Ddot(m-k2-1, a[k*lda+k2+1:], 1, c[(k2+1)*ldc+l:], ldc)
+			}
+		} else {
+			suml = bi.Ddot(k1, a[k:], lda, c[l:], ldc)
+		}
+		if notrnb {
+			sumr = bi.Ddot(l1, c[k*ldc:], 1, b[l:], ldb)
+		} else if l2 < n-1 {
+			sumr = bi.Ddot(n-l2-1, c[k*ldc+l2+1:], 1, b[l*ldb+l2+1:], 1)
+		}
+		return c[k*ldc+l] - (suml + sgn*sumr)
+	}
+
+	// blockAt returns the first and last index of the 1×1 or 2×2 diagonal
+	// block of the quasi-triangular nt×nt matrix T that starts at index j
+	// if forward is true, or that ends at index j if forward is false.
+	blockAt := func(nt int, t []float64, ldt int, j int, forward bool) (j1, j2 int) {
+		if forward {
+			if j < nt-1 && t[(j+1)*ldt+j] != 0 {
+				return j, j + 1
+			}
+			return j, j
+		}
+		if j > 0 && t[j*ldt+j-1] != 0 {
+			return j - 1, j
+		}
+		return j, j
+	}
+
+	// The blocks of X are computed one block column at a time. If
+	// tranb == blas.NoTrans, the block columns are computed from left to
+	// right, otherwise from right to left. Within each block column, the
+	// blocks are computed from bottom to top if trana == blas.NoTrans,
+	// otherwise from top to bottom.
+	lForward := notrnb
+	kForward := !notrna
+	var vec, x [4]float64
+	scale = 1
+	ok = true
+	lstart := 0
+	if !lForward {
+		lstart = n - 1
+	}
+	for l := lstart; 0 <= l && l < n; {
+		l1, l2 := blockAt(n, b, ldb, l, lForward)
+		kstart := 0
+		if !kForward {
+			kstart = m - 1
+		}
+		for k := kstart; 0 <= k && k < m; {
+			k1, k2 := blockAt(m, a, lda, k, kForward)
+
+			scaloc := 1.0
+			switch {
+			case k1 == k2 && l1 == l2:
+				v := rhs(k1, l1, k1, k2, l1, l2)
+				a11 := a[k1*lda+k1] + sgn*b[l1*ldb+l1]
+				da11 := math.Abs(a11)
+				if da11 <= smin {
+					a11 = smin
+					da11 = smin
+					ok = false
+				}
+				db := math.Abs(v)
+				if da11 < 1 && db > 1 && db > bignum*da11 {
+					scaloc = 1 / db
+				}
+				x[0] = v * scaloc / a11
+			case k1 != k2 && l1 == l2:
+				vec[0] = rhs(k1, l1, k1, k2, l1, l2)
+				vec[2] = rhs(k2, l1, k1, k2, l1, l2)
+				var ok2 bool
+				scaloc, _, ok2 = impl.Dlaln2(!notrna, 2, 1, smin, 1, a[k1*lda+k1:], lda,
+					1, 1, vec[:], 2, -sgn*b[l1*ldb+l1], 0, x[:], 2)
+				ok = ok && ok2
+			case k1 == k2 && l1 != l2:
+				vec[0] = sgn * rhs(k1, l1, k1, k2, l1, l2)
+				vec[2] = sgn * rhs(k1, l2, k1, k2, l1, l2)
+				var ok2 bool
+				scaloc, _, ok2 = impl.Dlaln2(notrnb, 2, 1, smin, 1, b[l1*ldb+l1:], ldb,
+					1, 1, vec[:], 2, -sgn*a[k1*lda+k1], 0, x[:], 2)
+				ok = ok && ok2
+			default:
+				vec[0] = rhs(k1, l1, k1, k2, l1, l2)
+				vec[1] = rhs(k1, l2, k1, k2, l1, l2)
+				vec[2] = rhs(k2, l1, k1, k2, l1, l2)
+				vec[3] = rhs(k2, l2, k1, k2, l1, l2)
+				var ok2 bool
+				scaloc, _, ok2 = impl.Dlasy2(!notrna, !notrnb, isgn, 2, 2, a[k1*lda+k1:], lda,
+					b[l1*ldb+l1:], ldb, vec[:], 2, x[:], 2)
+				ok = ok && ok2
+			}
+			if scaloc != 1 {
+				for i := 0; i < m; i++ {
+					bi.Dscal(n, scaloc, c[i*ldc:], 1)
+				}
+				scale *= scaloc
+			}
+			switch {
+			case k1 == k2 && l1 == l2:
+				c[k1*ldc+l1] = x[0]
+			case k1 != k2 && l1 == l2:
+				c[k1*ldc+l1] = x[0]
+				c[k2*ldc+l1] = x[2]
+			case k1 == k2 && l1 != l2:
+				c[k1*ldc+l1] = x[0]
+				c[k1*ldc+l2] = x[2]
+			default:
+				c[k1*ldc+l1] = x[0]
+				c[k1*ldc+l2] = x[1]
+				c[k2*ldc+l1] = x[2]
+				c[k2*ldc+l2] = x[3]
+			}
+
+			if kForward {
+				k = k2 + 1
+			} else {
+				k = k1 - 1
+			}
+		}
+		if lForward {
+			l = l2 + 1
+		} else {
+			l = l1 - 1
+		}
+	}
+	return scale, ok
+}
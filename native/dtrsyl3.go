@@ -0,0 +1,246 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+// Dtrsyl3 solves the real Sylvester matrix equation
+//  op(A)*X + isgn*X*op(B) = scale*C,
+// where A is an m×m and B an n×n upper quasi-triangular matrix in Schur
+// canonical form, C and X are m×n matrices, op(A) is A or A^T as specified
+// by trana, and op(B) is B or B^T as specified by tranb. isgn must be 1 or -1.
+//
+// Dtrsyl3 is the blocked version of Dtrsyl. It partitions A and B into
+// blocks of about nb rows and columns without splitting 2×2 diagonal blocks,
+// solves the small Sylvester equations for the diagonal blocks using Dtrsyl
+// and updates the remaining right-hand side using Level 3 BLAS.
+//
+// On entry, c contains the right-hand side matrix C. On return, it is
+// overwritten by the solution matrix X.
+//
+// scale is a scaling factor less than or equal to 1 which is chosen to avoid
+// overflow in X and in the intermediate updates of C. Unlike the reference
+// implementation, Dtrsyl3 applies each scaling to the whole of C instead of
+// keeping separate scaling factors for each block.
+//
+// iwork must have length at least liwork, and liwork must be at least
+//  (m+nb-1)/nb + (n+nb-1)/nb + 2,
+// where nb is the block size, otherwise Dtrsyl3 will panic. If liwork is -1,
+// instead of performing Dtrsyl3, the minimum value of liwork is stored into
+// iwork[0].
+//
+// ok will be false if A and -isgn*B have common or very close eigenvalues,
+// in which case perturbed values are used to solve the equation.
+func (impl Implementation) Dtrsyl3(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, iwork []int, liwork int) (scale float64, ok bool) {
+	switch {
+	case trana != blas.NoTrans && trana != blas.Trans && trana != blas.ConjTrans:
+		panic(badTrans)
+	case tranb != blas.NoTrans && tranb != blas.Trans && tranb != blas.ConjTrans:
+		panic(badTrans)
+	case isgn != 1 && isgn != -1:
+		panic(badIsgn)
+	case m < 0:
+		panic(mLT0)
+	case n < 0:
+		panic(nLT0)
+	}
+
+	nb := max(8, impl.Ilaenv(1, "DTRSYL", "", m, n, -1, -1))
+	nba := max(1, (m+nb-1)/nb)
+	nbb := max(1, (n+nb-1)/nb)
+	liwmin := nba + nbb + 2
+	if liwork == -1 {
+		iwork[0] = liwmin
+		return 1, true
+	}
+	if liwork < liwmin || len(iwork) < liwork {
+		panic(badWork)
+	}
+	checkMatrix(m, m, a, lda)
+	checkMatrix(n, n, b, ldb)
+
+	// Quick return if possible.
+	if m == 0 || n == 0 {
+		return 1, true
+	}
+	checkMatrix(m, n, c, ldc)
+
+	// Use the unblocked code if one of the matrices fits into a single
+	// block.
+	if nba == 1 || nbb == 1 {
+		return impl.Dtrsyl(trana, tranb, isgn, m, n, a, lda, b, ldb, c, ldc)
+	}
+
+	// Partition A and B into diagonal blocks. The i-th block of A consists
+	// of rows and columns ka[i] through ka[i+1]-1, and the j-th block of B
+	// of rows and columns kb[j] through kb[j+1]-1.
+	ka := iwork[:nba+1]
+	kb := iwork[nba+1 : nba+nbb+2]
+	nba = dtrsyl3Partition(m, a, lda, nb, ka)
+	nbb = dtrsyl3Partition(n, b, ldb, nb, kb)
+
+	notrna := trana == blas.NoTrans
+	notrnb := tranb == blas.NoTrans
+	sgn := float64(isgn)
+
+	bi := blas64.Implementation()
+
+	// rescale scales C by s except for the block with rows i1 through i2-1
+	// and columns j1 through j2-1.
+	rescale := func(s float64, i1, i2, j1, j2 int) {
+		for i := 0; i < m; i++ {
+			if i < i1 || i2 <= i {
+				bi.Dscal(n, s, c[i*ldc:], 1)
+				continue
+			}
+			if j1 > 0 {
+				bi.Dscal(j1, s, c[i*ldc:], 1)
+			}
+			if j2 < n {
+				bi.Dscal(n-j2, s, c[i*ldc+j2:], 1)
+			}
+		}
+	}
+
+	scale = 1
+	ok = true
+	for ii := 0; ii < nba; ii++ {
+		// Solve the blocks of X from the bottom up if op(A) = A, and
+		// from the top down if op(A) = A^T.
+		i := ii
+		if notrna {
+			i = nba - 1 - ii
+		}
+		i1 := ka[i]
+		i2 := ka[i+1]
+		mi := i2 - i1
+		for jj := 0; jj < nbb; jj++ {
+			// Solve the blocks of X from the left if op(B) = B, and from
+			// the right if op(B) = B^T.
+			j := jj
+			if !notrnb {
+				j = nbb - 1 - jj
+			}
+			j1 := kb[j]
+			j2 := kb[j+1]
+			nj := j2 - j1
+
+			// Solve
+			//  op(A[i,i])*X[i,j] + isgn*X[i,j]*op(B[j,j]) = scaloc*C[i,j].
+			scaloc, ok2 := impl.Dtrsyl(trana, tranb, isgn, mi, nj, a[i1*lda+i1:], lda,
+				b[j1*ldb+j1:], ldb, c[i1*ldc+j1:], ldc)
+			ok = ok && ok2
+			if scaloc != 1 {
+				rescale(scaloc, i1, i2, j1, j2)
+				scale *= scaloc
+			}
+			x := c[i1*ldc+j1:]
+			xnrm := impl.Dlange(lapack.MaxRowSum, mi, nj, x, ldc, nil)
+
+			// Update the blocks of C in the j-th block column that are
+			// yet to be solved:
+			//  C[k,j] -= op(A)[k,i]*X[i,j].
+			for k := 0; k < nba; k++ {
+				if (notrna && k >= i) || (!notrna && k <= i) {
+					continue
+				}
+				k1 := ka[k]
+				mk := ka[k+1] - k1
+				var anrm float64
+				if notrna {
+					anrm = impl.Dlange(lapack.MaxRowSum, mk, mi, a[k1*lda+i1:], lda, nil)
+				} else {
+					// Bound the one norm of A[i,k] without workspace.
+					anrm = float64(mi) * impl.Dlange(lapack.MaxAbs, mi, mk, a[i1*lda+k1:], lda, nil)
+				}
+				cnrm := impl.Dlange(lapack.MaxRowSum, mk, nj, c[k1*ldc+j1:], ldc, nil)
+				if s := dlarmm(anrm, xnrm, cnrm); s != 1 {
+					rescale(s, 0, 0, 0, 0)
+					scale *= s
+					xnrm *= s
+				}
+				if notrna {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, mk, nj, mi, -1, a[k1*lda+i1:], lda, x, ldc,
+						1, c[k1*ldc+j1:], ldc)
+				} else {
+					bi.Dgemm(blas.Trans, blas.NoTrans, mk, nj, mi, -1, a[i1*lda+k1:], lda, x, ldc,
+						1, c[k1*ldc+j1:], ldc)
+				}
+			}
+
+			// Update the blocks of C in the i-th block row that are yet
+			// to be solved:
+			//  C[i,l] -= isgn*X[i,j]*op(B)[j,l].
+			for l := 0; l < nbb; l++ {
+				if (notrnb && l <= j) || (!notrnb && l >= j) {
+					continue
+				}
+				l1 := kb[l]
+				nl := kb[l+1] - l1
+				var bnrm float64
+				if notrnb {
+					bnrm = impl.Dlange(lapack.MaxRowSum, nj, nl, b[j1*ldb+l1:], ldb, nil)
+				} else {
+					// Bound the one norm of B[l,j] without workspace.
+					bnrm = float64(nl) * impl.Dlange(lapack.MaxAbs, nl, nj, b[l1*ldb+j1:], ldb, nil)
+				}
+				cnrm := impl.Dlange(lapack.MaxRowSum, mi, nl, c[i1*ldc+l1:], ldc, nil)
+				if s := dlarmm(xnrm, bnrm, cnrm); s != 1 {
+					rescale(s, 0, 0, 0, 0)
+					scale *= s
+					xnrm *= s
+				}
+				if notrnb {
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, mi, nl, nj, -sgn, x, ldc, b[j1*ldb+l1:], ldb,
+						1, c[i1*ldc+l1:], ldc)
+				} else {
+					bi.Dgemm(blas.NoTrans, blas.Trans, mi, nl, nj, -sgn, x, ldc, b[l1*ldb+j1:], ldb,
+						1, c[i1*ldc+l1:], ldc)
+				}
+			}
+		}
+	}
+	return scale, ok
+}
+
+// dtrsyl3Partition stores into k the first rows of the diagonal blocks of
+// the n×n upper quasi-triangular matrix t when partitioned into blocks of
+// nb rows and columns, followed by n. A block is enlarged by one row and
+// column if it would otherwise split a 2×2 diagonal block of t. The number of
+// blocks is returned.
+func dtrsyl3Partition(n int, t []float64, ldt, nb int, k []int) int {
+	var nblk int
+	for i := 0; i < n; nblk++ {
+		k[nblk] = i
+		i = min(i+nb, n)
+		if i < n && t[i*ldt+i-1] != 0 {
+			i++
+		}
+	}
+	k[nblk] = n
+	return nblk
+}
+
+// dlarmm returns a factor s in (0,1] such that the linear update
+//  (s*C) - A*(s*B)
+// cannot overflow, where anorm, bnorm and cnorm are upper bounds on the
+// infinity norms of A, B and C, respectively.
+func dlarmm(anorm, bnorm, cnorm float64) float64 {
+	bignum := 1 / (dlamchS / dlamchP) / 4
+	if bnorm <= 1 {
+		if anorm*bnorm > bignum-cnorm {
+			return 0.5
+		}
+	} else {
+		if anorm > (bignum-cnorm)/bnorm {
+			return 0.5 / bnorm
+		}
+	}
+	return 1
+}
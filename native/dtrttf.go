@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import "github.com/gonum/blas"
+
+// Dtrttf copies the upper or lower triangle of an n×n matrix A stored in
+// conventional format in a into arf in rectangular full packed (RFP) format.
+//
+// The RFP format stores the n*(n+1)/2 elements of the triangle of a symmetric
+// or triangular matrix in a rectangular array so that the blocks of the
+// matrix can be accessed with Level 3 BLAS. The RFP array is stored in
+// row-major order and has the dimensions
+//                      n odd          n even
+//  transr == NoTrans   n×(n+1)/2      (n+1)×(n/2)
+//  transr == Trans     (n+1)/2×n      (n/2)×(n+1)
+// If transr == blas.Trans, the RFP array is the transpose of the one for
+// transr == blas.NoTrans. For example, with transr == blas.NoTrans the
+// elements A[i,j], written as ij, are stored as
+//  n = 5, uplo == blas.Upper  n = 5, uplo == blas.Lower
+//   02 03 04                   00 33 43
+//   12 13 14                   10 11 44
+//   22 23 24                   20 21 22
+//   00 33 34                   30 31 32
+//   01 11 44                   40 41 42
+//
+//  n = 6, uplo == blas.Upper  n = 6, uplo == blas.Lower
+//   03 04 05                   33 43 53
+//   13 14 15                   00 44 54
+//   23 24 25                   10 11 55
+//   33 34 35                   20 21 22
+//   00 44 45                   30 31 32
+//   01 11 55                   40 41 42
+//   02 12 22                   50 51 52
+// This is the same arrangement of the elements as used by the Fortran LAPACK,
+// so the RFP array is the transpose of the corresponding column-major array.
+//
+// transr must be blas.NoTrans or blas.Trans, and arf must have length at
+// least n*(n+1)/2, otherwise Dtrttf will panic.
+func (Implementation) Dtrttf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64, lda int, arf []float64) {
+	switch {
+	case transr != blas.NoTrans && transr != blas.Trans:
+		panic(badTrans)
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case len(arf) < n*(n+1)/2:
+		panic(badARF)
+	}
+	checkMatrix(n, n, a, max(1, lda))
+
+	l := rfpBlocks(transr, uplo, n)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j < n; j++ {
+				arf[l.index(i, j)] = a[i*lda+j]
+			}
+		} else {
+			for j := 0; j <= i; j++ {
+				arf[l.index(i, j)] = a[i*lda+j]
+			}
+		}
+	}
+}
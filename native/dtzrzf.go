@@ -0,0 +1,134 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+// Dtzrzf reduces the m×n upper trapezoidal matrix A, m <= n, to upper
+// triangular form by means of orthogonal transformations. The factorization
+// has the form
+//  A = [ R 0 ] * Z,
+// where Z is an n×n orthogonal matrix and R is an m×m upper triangular
+// matrix.
+//
+// On return, the upper triangle of the first m columns of A contains R and
+// the elements in the last n-m columns of A, together with tau, represent Z
+// as a product of m elementary reflectors
+//  Z = H_0 * H_1 * ... * H_{m-1}.
+// Each H_k has the form
+//  H_k = I - tau[k] * u_k * u_k^T,
+// where u_k is a vector of length n with u_k[k] = 1, whose last n-m elements
+// are stored in A[k, m:n] and whose other elements are zero.
+//
+// tau must have length at least m.
+//
+// work must have length at least max(1, lwork), and lwork must be at least
+// max(1, m), otherwise Dtzrzf will panic. For optimal performance lwork
+// should be larger. If lwork == -1, instead of performing Dtzrzf, the optimal
+// work length will be stored into work[0].
+//
+// Dtzrzf is an internal routine. It is exported for testing purposes.
+func (impl Implementation) Dtzrzf(m, n int, a []float64, lda int, tau, work []float64, lwork int) {
+	if m < 0 {
+		panic(mLT0)
+	}
+	if n < m {
+		panic(nLTM)
+	}
+	checkMatrix(m, n, a, lda)
+	if len(work) < max(1, lwork) {
+		panic(shortWork)
+	}
+	if lwork != -1 && lwork < max(1, m) {
+		panic(badWork)
+	}
+
+	var nb, lwkopt int
+	if m == 0 || m == n {
+		lwkopt = 1
+	} else {
+		nb = impl.Ilaenv(1, "DGERQF", " ", m, n, -1, -1)
+		lwkopt = m * nb
+	}
+	work[0] = float64(lwkopt)
+	if lwork == -1 {
+		return
+	}
+
+	if len(tau) < m {
+		panic(badTau)
+	}
+
+	// Quick return if possible.
+	if m == 0 {
+		return
+	}
+	if m == n {
+		for i := range tau[:m] {
+			tau[i] = 0
+		}
+		return
+	}
+
+	nbmin := 2
+	nx := 1
+	var ldwork int
+	if 1 < nb && nb < m {
+		// Determine when to cross over from blocked to unblocked code.
+		nx = max(0, impl.Ilaenv(3, "DGERQF", " ", m, n, -1, -1))
+		if nx < m {
+			// Determine whether workspace is large enough for blocked code.
+			if lwork < m*nb {
+				// Not enough workspace to use optimal nb. Reduce
+				// nb and determine the minimum value of nb.
+				nb = lwork / m
+				nbmin = max(2, impl.Ilaenv(2, "DGERQF", " ", m, n, -1, -1))
+			}
+			ldwork = nb
+		}
+	}
+
+	var mu int
+	if nbmin <= nb && nb < m && nx < m {
+		// Use blocked code initially.
+		// The last kk rows are handled by the block method.
+		ki := ((m - nx - 1) / nb) * nb
+		kk := min(m, ki+nb)
+
+		var i int
+		for i = m - kk + ki; i >= m-kk; i -= nb {
+			ib := min(m-i, nb)
+
+			// Compute the TZ factorization of the current block
+			// A[i:i+ib, i:n].
+			impl.Dlatrz(ib, n-i, n-m, a[i*lda+i:], lda, tau[i:], work)
+			if i > 0 {
+				// Form the triangular factor of the block reflector
+				// H = H_{i+ib-1} . . . H_{i+1} H_i.
+				impl.Dlarzt(lapack.Backward, lapack.RowWise, n-m, ib,
+					a[i*lda+m:], lda, tau[i:], work, ldwork)
+
+				// Apply H to A[0:i, i:n] from the right.
+				impl.Dlarzb(blas.Right, blas.NoTrans, lapack.Backward, lapack.RowWise,
+					i, n-i, ib, n-m, a[i*lda+m:], lda,
+					work, ldwork,
+					a[i:], lda,
+					work[ib*ldwork:], ldwork)
+			}
+		}
+		mu = i + nb
+	} else {
+		mu = m
+	}
+
+	// Use unblocked code to factor the last or only block.
+	if mu > 0 {
+		impl.Dlatrz(mu, n, n-m, a, lda, tau, work)
+	}
+	work[0] = float64(lwkopt)
+}
@@ -5,6 +5,7 @@
 package native
 
 import (
+	"github.com/gonum/blas"
 	"github.com/gonum/lapack"
 )
 
@@ -19,42 +20,66 @@ var _ lapack.Float64 = Implementation{}
 const (
 	absIncNotOne    = "lapack: increment not one or negative one"
 	badAlpha        = "lapack: bad alpha length"
+	badAP           = "lapack: ap has insufficient length"
+	badARF          = "lapack: arf has insufficient length"
 	badAuxv         = "lapack: auxv has insufficient length"
 	badBeta         = "lapack: bad beta length"
+	badCtol         = "lapack: ctol < 1"
 	badD            = "lapack: d has insufficient length"
 	badDecompUpdate = "lapack: bad decomp update"
+	badDelta        = "lapack: delta has insufficient length"
 	badDiag         = "lapack: bad diag"
 	badDims         = "lapack: bad input dimensions"
 	badDirect       = "lapack: bad direct"
+	badDL           = "lapack: dl has insufficient length"
+	badDU           = "lapack: du has insufficient length"
+	badDU2          = "lapack: du2 has insufficient length"
 	badE            = "lapack: e has insufficient length"
 	badEVComp       = "lapack: bad EVComp"
 	badEVJob        = "lapack: bad EVJob"
+	badEVOrder      = "lapack: eigenvalues not ordered by block"
 	badEVSide       = "lapack: bad EVSide"
 	badGSVDJob      = "lapack: bad GSVDJob"
 	badHowMany      = "lapack: bad HowMany"
 	badIlo          = "lapack: ilo out of range"
+	badIndex        = "lapack: index slice has insufficient length"
 	badIhi          = "lapack: ihi out of range"
+	badIl           = "lapack: il out of range"
+	badInterval     = "lapack: vl >= vu"
+	badItype        = "lapack: bad itype"
 	badIpiv         = "lapack: bad permutation length"
+	badIsgn         = "lapack: bad isgn"
+	badIsuppz       = "lapack: isuppz has insufficient length"
+	badIu           = "lapack: iu out of range"
 	badJob          = "lapack: bad Job"
 	badK1           = "lapack: k1 out of range"
 	badK2           = "lapack: k2 out of range"
 	badKperm        = "lapack: incorrect permutation length"
 	badLdA          = "lapack: index of a out of range"
+	badLeftSVJob    = "lapack: bad LeftSVJob"
+	badMatrixType   = "lapack: bad MatrixType"
 	badNb           = "lapack: nb out of range"
 	badNorm         = "lapack: bad norm"
 	badPivot        = "lapack: bad pivot"
+	badRange        = "lapack: bad EVRange"
+	badRightSVJob   = "lapack: bad RightSVJob"
 	badS            = "lapack: s has insufficient length"
 	badShifts       = "lapack: bad shifts"
 	badSide         = "lapack: bad side"
 	badSlice        = "lapack: bad input slice length"
 	badSort         = "lapack: bad Sort"
 	badStore        = "lapack: bad store"
+	badSVDComp      = "lapack: bad SVDComp"
+	badSVA          = "lapack: sva has insufficient length"
+	badSVDAccuracy  = "lapack: bad SVDAccuracy"
+	badSVDJob       = "lapack: bad SVDJob"
 	badTau          = "lapack: tau has insufficient length"
 	badTauQ         = "lapack: tauQ has insufficient length"
 	badTauP         = "lapack: tauP has insufficient length"
 	badTrans        = "lapack: bad trans"
 	badVn1          = "lapack: vn1 has insufficient length"
 	badVn2          = "lapack: vn2 has insufficient length"
+	badW            = "lapack: w has insufficient length"
 	badUplo         = "lapack: illegal triangle"
 	badWork         = "lapack: insufficient working memory"
 	badWorkStride   = "lapack: insufficient working array stride"
@@ -67,6 +92,7 @@ const (
 	nanScale        = "lapack: NaN scale factor"
 	negDimension    = "lapack: negative matrix dimension"
 	negZ            = "lapack: negative z value"
+	negVl           = "lapack: negative vl"
 	nLT0            = "lapack: n < 0"
 	nLTM            = "lapack: n < m"
 	offsetGTM       = "lapack: offset > m"
@@ -90,6 +116,176 @@ func checkMatrix(m, n int, a []float64, lda int) {
 	}
 }
 
+// checkMatrix32 verifies the parameters of a float32 matrix input.
+func checkMatrix32(m, n int, a []float32, lda int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if lda < n {
+		panic("lapack: stride less than number of columns")
+	}
+	if len(a) < (m-1)*lda+n {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// checkBandMatrix verifies the parameters of an m×n band matrix input with kl
+// subdiagonals and ku superdiagonals stored in row-major band format.
+func checkBandMatrix(m, n, kl, ku int, ab []float64, ldab int) {
+	if m < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if n < 0 {
+		panic("lapack: has negative number of columns")
+	}
+	if kl < 0 {
+		panic("lapack: kl < 0")
+	}
+	if ku < 0 {
+		panic("lapack: ku < 0")
+	}
+	if ldab < kl+ku+1 {
+		panic("lapack: stride less than band width")
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+	if len(ab) < (min(m, n+kl)-1)*ldab+kl+ku+1 {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// checkSymBandMatrix verifies the parameters of an n×n symmetric or triangular
+// band matrix input with kd sub- or superdiagonals stored in row-major band
+// format.
+func checkSymBandMatrix(n, kd int, ab []float64, ldab int) {
+	if n < 0 {
+		panic("lapack: has negative number of rows")
+	}
+	if kd < 0 {
+		panic("lapack: kd < 0")
+	}
+	if ldab < kd+1 {
+		panic("lapack: stride less than band width")
+	}
+	if n == 0 {
+		return
+	}
+	if len(ab) < (n-1)*ldab+kd+1 {
+		panic("lapack: insufficient matrix slice length")
+	}
+}
+
+// rfpLayout describes the storage of an n×n symmetric or triangular matrix A
+// in rectangular full packed (RFP) format. A is partitioned as
+//  A = [ A11 A12 ]
+//      [ A21 A22 ]
+// where A11 is n1×n1 and A22 is n2×n2. The triangles of the diagonal blocks
+// A11 and A22 that correspond to the triangle of A are stored as upper or
+// lower triangles, given by uplo1 and uplo2, in the blocks of the RFP array
+// starting at off1 and off2. The off-diagonal block A21 or A12 is stored at
+// offS, either as the n2×n1 matrix A21 if s21 is true, or as the n1×n2
+// matrix A12 = A21^T otherwise. All blocks share the stride ldr, which is
+// the number of columns of the RFP array.
+type rfpLayout struct {
+	n1, n2           int
+	ldr              int
+	off1, off2, offS int
+	uplo1, uplo2     blas.Uplo
+	s21              bool
+}
+
+// rfpBlocks returns the layout of the n×n matrix A with the triangle uplo
+// stored in RFP format as described in the documentation of Dtrttf.
+func rfpBlocks(transr blas.Transpose, uplo blas.Uplo, n int) rfpLayout {
+	var l rfpLayout
+	// The row and column indices of the blocks in the RFP array when it is
+	// not transposed.
+	var r1, c1, r2, c2, rs, cs int
+	k := n / 2
+	if n%2 == 1 {
+		l.ldr = (n + 1) / 2
+		if uplo == blas.Lower {
+			l.n1, l.n2 = n-k, k
+			r1, c1 = 0, 0
+			r2, c2 = 0, 1
+			rs, cs = l.n1, 0
+			l.s21 = true
+		} else {
+			l.n1, l.n2 = k, n-k
+			r1, c1 = l.n2, 0
+			r2, c2 = l.n1, 0
+			rs, cs = 0, 0
+		}
+	} else {
+		l.n1, l.n2 = k, k
+		l.ldr = k
+		if uplo == blas.Lower {
+			r1, c1 = 1, 0
+			r2, c2 = 0, 0
+			rs, cs = k+1, 0
+			l.s21 = true
+		} else {
+			r1, c1 = k+1, 0
+			r2, c2 = k, 0
+			rs, cs = 0, 0
+		}
+	}
+	l.uplo1, l.uplo2 = blas.Lower, blas.Upper
+	if transr != blas.NoTrans {
+		// The transposed RFP array swaps the row and column indices of
+		// the blocks and the triangles they store.
+		r1, c1 = c1, r1
+		r2, c2 = c2, r2
+		rs, cs = cs, rs
+		l.uplo1, l.uplo2 = blas.Upper, blas.Lower
+		l.s21 = !l.s21
+		if n%2 == 1 {
+			l.ldr = n
+		} else {
+			l.ldr = n + 1
+		}
+	}
+	l.off1 = r1*l.ldr + c1
+	l.off2 = r2*l.ldr + c2
+	l.offS = rs*l.ldr + cs
+	return l
+}
+
+// index returns the index in the RFP array of the element A[i,j], which must
+// be in the stored triangle of A.
+func (l rfpLayout) index(i, j int) int {
+	// Map A[i,j] to the element A21[p,q] of the lower triangle.
+	p, q := i, j
+	if p < q {
+		p, q = q, p
+	}
+	if q >= l.n1 {
+		return rfpTriIndex(l.uplo2, l.off2, l.ldr, p-l.n1, q-l.n1)
+	}
+	if p < l.n1 {
+		return rfpTriIndex(l.uplo1, l.off1, l.ldr, p, q)
+	}
+	p -= l.n1
+	if l.s21 {
+		return l.offS + p*l.ldr + q
+	}
+	return l.offS + q*l.ldr + p
+}
+
+// rfpTriIndex returns the index of the element [p,q], p >= q, of a symmetric
+// or triangular block stored in the uplo triangle starting at off with
+// stride ldr.
+func rfpTriIndex(uplo blas.Uplo, off, ldr, p, q int) int {
+	if uplo == blas.Upper {
+		p, q = q, p
+	}
+	return off + p*ldr + q
+}
+
 func checkVector(n int, v []float64, inc int) {
 	if n < 0 {
 		panic("lapack: negative vector length")
@@ -159,18 +159,28 @@ func (impl Implementation) Ilaenv(ispec int, s string, opts string, n1, n2, n3,
 				}
 				return 32
 			}
+		case "GG":
+			switch c3 {
+			default:
+				panic("lapack: bad function name")
+			case "HD3":
+				if sname {
+					return 32
+				}
+				return 32
+			}
 		case "PB":
 			switch c3 {
 			default:
 				panic("lapack: bad function name")
 			case "TRF":
 				if sname {
-					if n4 <= 64 {
+					if n2 <= 64 {
 						return 1
 					}
 					return 32
 				}
-				if n4 <= 64 {
+				if n2 <= 64 {
 					return 1
 				}
 				return 32
@@ -189,6 +199,13 @@ func (impl Implementation) Ilaenv(ispec int, s string, opts string, n1, n2, n3,
 					return 64
 				}
 				return 64
+			case "SYL":
+				// The upper bound is to prevent overly aggressive
+				// scaling.
+				if sname {
+					return min(max(48, (min(n1, n2)*16)/100), 240)
+				}
+				return min(max(24, (min(n1, n2)*8)/100), 80)
 			}
 		case "LA":
 			switch c3 {
@@ -296,6 +313,16 @@ func (impl Implementation) Ilaenv(ispec int, s string, opts string, n1, n2, n3,
 					return 2
 				}
 			}
+		case "GG":
+			switch c3 {
+			default:
+				panic("lapack: bad function name")
+			case "HD3":
+				if sname {
+					return 2
+				}
+				return 2
+			}
 		}
 	case 3:
 		switch c2 {
@@ -355,6 +382,16 @@ func (impl Implementation) Ilaenv(ispec int, s string, opts string, n1, n2, n3,
 					return 128
 				}
 			}
+		case "GG":
+			switch c3 {
+			default:
+				panic("lapack: bad function name")
+			case "HD3":
+				if sname {
+					return 128
+				}
+				return 128
+			}
 		}
 	case 4:
 		// Used by xHSEQR
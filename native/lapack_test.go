@@ -12,10 +12,150 @@ import (
 
 var impl = Implementation{}
 
+func TestDbdsdc(t *testing.T) {
+	testlapack.DbdsdcTest(t, impl)
+}
+
 func TestDbdsqr(t *testing.T) {
 	testlapack.DbdsqrTest(t, impl)
 }
 
+func TestDbdsvdx(t *testing.T) {
+	testlapack.DbdsvdxTest(t, impl)
+}
+
+func TestDgbcon(t *testing.T) {
+	testlapack.DgbconTest(t, impl)
+}
+
+func TestDgbsv(t *testing.T) {
+	testlapack.DgbsvTest(t, impl)
+}
+
+func TestDgbtf2(t *testing.T) {
+	testlapack.Dgbtf2Test(t, impl)
+}
+
+func TestDgbtrf(t *testing.T) {
+	testlapack.DgbtrfTest(t, impl)
+}
+
+func TestDgbtrs(t *testing.T) {
+	testlapack.DgbtrsTest(t, impl)
+}
+
+func TestDgeequ(t *testing.T) {
+	testlapack.DgeequTest(t, impl)
+}
+
+func TestDgeequb(t *testing.T) {
+	testlapack.DgeequbTest(t, impl)
+}
+
+func TestDgees(t *testing.T) {
+	testlapack.DgeesTest(t, impl)
+}
+
+func TestDgeesx(t *testing.T) {
+	testlapack.DgeesxTest(t, impl)
+}
+
+func TestDgeevx(t *testing.T) {
+	testlapack.DgeevxTest(t, impl)
+}
+
+func TestDgelsy(t *testing.T) {
+	testlapack.DgelsyTest(t, impl)
+}
+
+func TestDgerfs(t *testing.T) {
+	testlapack.DgerfsTest(t, impl)
+}
+
+func TestDgesv(t *testing.T) {
+	testlapack.DgesvTest(t, impl)
+}
+
+func TestDgesvx(t *testing.T) {
+	testlapack.DgesvxTest(t, impl)
+}
+
+func TestDgesc2(t *testing.T) {
+	testlapack.Dgesc2Test(t, impl)
+}
+
+func TestDgetc2(t *testing.T) {
+	testlapack.Dgetc2Test(t, impl)
+}
+
+func TestDggbak(t *testing.T) {
+	testlapack.DggbakTest(t, impl)
+}
+
+func TestDggbal(t *testing.T) {
+	testlapack.DggbalTest(t, impl)
+}
+
+func TestDgges(t *testing.T) {
+	testlapack.DggesTest(t, impl)
+}
+
+func TestDggesx(t *testing.T) {
+	testlapack.DggesxTest(t, impl)
+}
+
+func TestDggev(t *testing.T) {
+	testlapack.DggevTest(t, impl)
+}
+
+func TestDggev3(t *testing.T) {
+	testlapack.Dggev3Test(t, impl)
+}
+
+func TestDggglm(t *testing.T) {
+	testlapack.DggglmTest(t, impl)
+}
+
+func TestDgghd3(t *testing.T) {
+	testlapack.Dgghd3Test(t, impl)
+}
+
+func TestDgghrd(t *testing.T) {
+	testlapack.DgghrdTest(t, impl)
+}
+
+func TestDgglse(t *testing.T) {
+	testlapack.DgglseTest(t, impl)
+}
+
+func TestDggqrf(t *testing.T) {
+	testlapack.DggqrfTest(t, impl)
+}
+
+func TestDggrqf(t *testing.T) {
+	testlapack.DggrqfTest(t, impl)
+}
+
+func TestDgtcon(t *testing.T) {
+	testlapack.DgtconTest(t, impl)
+}
+
+func TestDgtsv(t *testing.T) {
+	testlapack.DgtsvTest(t, impl)
+}
+
+func TestDgttrf(t *testing.T) {
+	testlapack.DgttrfTest(t, impl)
+}
+
+func TestDgttrs(t *testing.T) {
+	testlapack.DgttrsTest(t, impl)
+}
+
+func TestDhgeqz(t *testing.T) {
+	testlapack.DhgeqzTest(t, impl)
+}
+
 func TestDhseqr(t *testing.T) {
 	testlapack.DhseqrTest(t, impl)
 }
@@ -52,6 +192,10 @@ func TestDgehrd(t *testing.T) {
 	testlapack.DgehrdTest(t, impl)
 }
 
+func TestDgejsv(t *testing.T) {
+	testlapack.DgejsvTest(t, impl)
+}
+
 func TestDgelqf(t *testing.T) {
 	testlapack.DgelqfTest(t, impl)
 }
@@ -68,6 +212,14 @@ func TestDgels(t *testing.T) {
 	testlapack.DgelsTest(t, impl)
 }
 
+func TestDgelsd(t *testing.T) {
+	testlapack.DgelsdTest(t, impl)
+}
+
+func TestDgelss(t *testing.T) {
+	testlapack.DgelssTest(t, impl)
+}
+
 func TestDgerq2(t *testing.T) {
 	testlapack.Dgerq2Test(t, impl)
 }
@@ -88,10 +240,30 @@ func TestDgerqf(t *testing.T) {
 	testlapack.DgerqfTest(t, impl)
 }
 
+func TestDgesdd(t *testing.T) {
+	testlapack.DgesddTest(t, impl)
+}
+
+func TestDgesvj(t *testing.T) {
+	testlapack.DgesvjTest(t, impl)
+}
+
 func TestDgesvd(t *testing.T) {
 	testlapack.DgesvdTest(t, impl)
 }
 
+func TestDgesvdx(t *testing.T) {
+	testlapack.DgesvdxTest(t, impl)
+}
+
+func TestDgsvj0(t *testing.T) {
+	testlapack.Dgsvj0Test(t, impl)
+}
+
+func TestDgsvj1(t *testing.T) {
+	testlapack.Dgsvj1Test(t, impl)
+}
+
 func TestDgetri(t *testing.T) {
 	testlapack.DgetriTest(t, impl)
 }
@@ -132,6 +304,18 @@ func TestDlae2(t *testing.T) {
 	testlapack.Dlae2Test(t, impl)
 }
 
+func TestDlaed0(t *testing.T) {
+	testlapack.Dlaed0Test(t, impl)
+}
+
+func TestDlaed1(t *testing.T) {
+	testlapack.Dlaed1Test(t, impl)
+}
+
+func TestDlaed4(t *testing.T) {
+	testlapack.Dlaed4Test(t, impl)
+}
+
 func TestDlaev2(t *testing.T) {
 	testlapack.Dlaev2Test(t, impl)
 }
@@ -140,10 +324,22 @@ func TestDlaexc(t *testing.T) {
 	testlapack.DlaexcTest(t, impl)
 }
 
+func TestDlag2(t *testing.T) {
+	testlapack.Dlag2Test(t, impl)
+}
+
+func TestDlag2s(t *testing.T) {
+	testlapack.Dlag2sTest(t, impl)
+}
+
 func TestDlags2(t *testing.T) {
 	testlapack.Dlags2Test(t, impl)
 }
 
+func TestDlagv2(t *testing.T) {
+	testlapack.Dlagv2Test(t, impl)
+}
+
 func TestDlahqr(t *testing.T) {
 	testlapack.DlahqrTest(t, impl)
 }
@@ -152,14 +348,46 @@ func TestDlahr2(t *testing.T) {
 	testlapack.Dlahr2Test(t, impl)
 }
 
+func TestDlaic1(t *testing.T) {
+	testlapack.Dlaic1Test(t, impl)
+}
+
 func TestDlaln2(t *testing.T) {
 	testlapack.Dlaln2Test(t, impl)
 }
 
+func TestDlalsd(t *testing.T) {
+	testlapack.DlalsdTest(t, impl)
+}
+
+func TestDlangb(t *testing.T) {
+	testlapack.DlangbTest(t, impl)
+}
+
 func TestDlange(t *testing.T) {
 	testlapack.DlangeTest(t, impl)
 }
 
+func TestDlangt(t *testing.T) {
+	testlapack.DlangtTest(t, impl)
+}
+
+func TestDlanhs(t *testing.T) {
+	testlapack.DlanhsTest(t, impl)
+}
+
+func TestDlansb(t *testing.T) {
+	testlapack.DlansbTest(t, impl)
+}
+
+func TestDlansf(t *testing.T) {
+	testlapack.DlansfTest(t, impl)
+}
+
+func TestDlansp(t *testing.T) {
+	testlapack.DlanspTest(t, impl)
+}
+
 func TestDlapy2(t *testing.T) {
 	testlapack.Dlapy2Test(t, impl)
 }
@@ -172,6 +400,18 @@ func TestDlapmt(t *testing.T) {
 	testlapack.DlapmtTest(t, impl)
 }
 
+func TestDlaqge(t *testing.T) {
+	testlapack.DlaqgeTest(t, impl)
+}
+
+func TestDlaqsy(t *testing.T) {
+	testlapack.DlaqsyTest(t, impl)
+}
+
+func TestDlaqtr(t *testing.T) {
+	testlapack.DlaqtrTest(t, impl)
+}
+
 func TestDlas2(t *testing.T) {
 	testlapack.Dlas2Test(t, impl)
 }
@@ -196,6 +436,14 @@ func TestDlasy2(t *testing.T) {
 	testlapack.Dlasy2Test(t, impl)
 }
 
+func TestDlasyf(t *testing.T) {
+	testlapack.DlasyfTest(t, impl)
+}
+
+func TestDlasyfRook(t *testing.T) {
+	testlapack.DlasyfRookTest(t, impl)
+}
+
 func TestDlanst(t *testing.T) {
 	testlapack.DlanstTest(t, impl)
 }
@@ -256,10 +504,38 @@ func TestDlarfx(t *testing.T) {
 	testlapack.DlarfxTest(t, impl)
 }
 
+func TestDlarre(t *testing.T) {
+	testlapack.DlarreTest(t, impl)
+}
+
+func TestDlarrv(t *testing.T) {
+	testlapack.DlarrvTest(t, impl)
+}
+
 func TestDlartg(t *testing.T) {
 	testlapack.DlartgTest(t, impl)
 }
 
+func TestDlarzb(t *testing.T) {
+	testlapack.DlarzbTest(t, impl)
+}
+
+func TestDlarzt(t *testing.T) {
+	testlapack.DlarztTest(t, impl)
+}
+
+func TestDlasd0(t *testing.T) {
+	testlapack.Dlasd0Test(t, impl)
+}
+
+func TestDlasd4(t *testing.T) {
+	testlapack.Dlasd4Test(t, impl)
+}
+
+func TestDlasdq(t *testing.T) {
+	testlapack.DlasdqTest(t, impl)
+}
+
 func TestDlasq1(t *testing.T) {
 	testlapack.Dlasq1Test(t, impl)
 }
@@ -288,6 +564,18 @@ func TestDlasv2(t *testing.T) {
 	testlapack.Dlasv2Test(t, impl)
 }
 
+func TestDlat2s(t *testing.T) {
+	testlapack.Dlat2sTest(t, impl)
+}
+
+func TestDlatbs(t *testing.T) {
+	testlapack.DlatbsTest(t, impl)
+}
+
+func TestDlatps(t *testing.T) {
+	testlapack.DlatpsTest(t, impl)
+}
+
 func TestDlatrd(t *testing.T) {
 	testlapack.DlatrdTest(t, impl)
 }
@@ -296,6 +584,18 @@ func TestDlatrs(t *testing.T) {
 	testlapack.DlatrsTest(t, impl)
 }
 
+func TestDlauu2(t *testing.T) {
+	testlapack.Dlauu2Test(t, impl)
+}
+
+func TestDlauum(t *testing.T) {
+	testlapack.DlauumTest(t, impl)
+}
+
+func TestDopgtr(t *testing.T) {
+	testlapack.DopgtrTest(t, impl)
+}
+
 func TestDorg2r(t *testing.T) {
 	testlapack.Dorg2rTest(t, impl)
 }
@@ -328,6 +628,10 @@ func TestDorgqr(t *testing.T) {
 	testlapack.DorgqrTest(t, impl)
 }
 
+func TestDorgrq(t *testing.T) {
+	testlapack.DorgrqTest(t, impl)
+}
+
 func TestDorgtr(t *testing.T) {
 	testlapack.DorgtrTest(t, impl)
 }
@@ -352,6 +656,18 @@ func TestDormqr(t *testing.T) {
 	testlapack.DormqrTest(t, impl)
 }
 
+func TestDormrq(t *testing.T) {
+	testlapack.DormrqTest(t, impl)
+}
+
+func TestDormrz(t *testing.T) {
+	testlapack.DormrzTest(t, impl)
+}
+
+func TestDormtr(t *testing.T) {
+	testlapack.DormtrTest(t, impl)
+}
+
 func TestDormr2(t *testing.T) {
 	testlapack.Dormr2Test(t, impl)
 }
@@ -360,10 +676,58 @@ func TestDorm2r(t *testing.T) {
 	testlapack.Dorm2rTest(t, impl)
 }
 
+func TestDpbcon(t *testing.T) {
+	testlapack.DpbconTest(t, impl)
+}
+
+func TestDpbsv(t *testing.T) {
+	testlapack.DpbsvTest(t, impl)
+}
+
+func TestDpbtf2(t *testing.T) {
+	testlapack.Dpbtf2Test(t, impl)
+}
+
+func TestDpbtrf(t *testing.T) {
+	testlapack.DpbtrfTest(t, impl)
+}
+
+func TestDpbtrs(t *testing.T) {
+	testlapack.DpbtrsTest(t, impl)
+}
+
+func TestDpftrf(t *testing.T) {
+	testlapack.DpftrfTest(t, impl)
+}
+
+func TestDpftri(t *testing.T) {
+	testlapack.DpftriTest(t, impl)
+}
+
+func TestDpftrs(t *testing.T) {
+	testlapack.DpftrsTest(t, impl)
+}
+
 func TestDpocon(t *testing.T) {
 	testlapack.DpoconTest(t, impl)
 }
 
+func TestDpoequ(t *testing.T) {
+	testlapack.DpoequTest(t, impl)
+}
+
+func TestDporfs(t *testing.T) {
+	testlapack.DporfsTest(t, impl)
+}
+
+func TestDposv(t *testing.T) {
+	testlapack.DposvTest(t, impl)
+}
+
+func TestDposvx(t *testing.T) {
+	testlapack.DposvxTest(t, impl)
+}
+
 func TestDpotf2(t *testing.T) {
 	testlapack.Dpotf2Test(t, impl)
 }
@@ -372,10 +736,106 @@ func TestDpotrf(t *testing.T) {
 	testlapack.DpotrfTest(t, impl)
 }
 
+func TestDpotri(t *testing.T) {
+	testlapack.DpotriTest(t, impl)
+}
+
+func TestDpotrs(t *testing.T) {
+	testlapack.DpotrsTest(t, impl)
+}
+
+func TestDppcon(t *testing.T) {
+	testlapack.DppconTest(t, impl)
+}
+
+func TestDpptrf(t *testing.T) {
+	testlapack.DpptrfTest(t, impl)
+}
+
+func TestDpptri(t *testing.T) {
+	testlapack.DpptriTest(t, impl)
+}
+
+func TestDpptrs(t *testing.T) {
+	testlapack.DpptrsTest(t, impl)
+}
+
+func TestDpstf2(t *testing.T) {
+	testlapack.Dpstf2Test(t, impl)
+}
+
+func TestDpstrf(t *testing.T) {
+	testlapack.DpstrfTest(t, impl)
+}
+
+func TestDptcon(t *testing.T) {
+	testlapack.DptconTest(t, impl)
+}
+
+func TestDptsv(t *testing.T) {
+	testlapack.DptsvTest(t, impl)
+}
+
+func TestDpttrf(t *testing.T) {
+	testlapack.DpttrfTest(t, impl)
+}
+
+func TestDpttrs(t *testing.T) {
+	testlapack.DpttrsTest(t, impl)
+}
+
 func TestDrscl(t *testing.T) {
 	testlapack.DrsclTest(t, impl)
 }
 
+func TestDsbev(t *testing.T) {
+	testlapack.DsbevTest(t, impl)
+}
+
+func TestDsbtrd(t *testing.T) {
+	testlapack.DsbtrdTest(t, impl)
+}
+
+func TestDsfrk(t *testing.T) {
+	testlapack.DsfrkTest(t, impl)
+}
+
+func TestDsgesv(t *testing.T) {
+	testlapack.DsgesvTest(t, impl)
+}
+
+func TestDspev(t *testing.T) {
+	testlapack.DspevTest(t, impl)
+}
+
+func TestDsposv(t *testing.T) {
+	testlapack.DsposvTest(t, impl)
+}
+
+func TestDsptrd(t *testing.T) {
+	testlapack.DsptrdTest(t, impl)
+}
+
+func TestDsptrf(t *testing.T) {
+	testlapack.DsptrfTest(t, impl)
+}
+
+func TestDstebz(t *testing.T) {
+	testlapack.DstebzTest(t, impl)
+}
+
+func TestDstedc(t *testing.T) {
+	testlapack.DstedcTest(t, impl)
+}
+
+func TestDstein(t *testing.T) {
+	testlapack.DsteinTest(t, impl)
+}
+
+func TestDstemr(t *testing.T) {
+	testlapack.DstemrTest(t, impl)
+}
+
 func TestDsteqr(t *testing.T) {
 	testlapack.DsteqrTest(t, impl)
 }
@@ -384,22 +844,142 @@ func TestDsterf(t *testing.T) {
 	testlapack.DsterfTest(t, impl)
 }
 
+func TestDsycon(t *testing.T) {
+	testlapack.DsyconTest(t, impl)
+}
+
+func TestDsyconRook(t *testing.T) {
+	testlapack.DsyconRookTest(t, impl)
+}
+
 func TestDsyev(t *testing.T) {
 	testlapack.DsyevTest(t, impl)
 }
 
+func TestDsyevd(t *testing.T) {
+	testlapack.DsyevdTest(t, impl)
+}
+
+func TestDsyevr(t *testing.T) {
+	testlapack.DsyevrTest(t, impl)
+}
+
+func TestDsyevx(t *testing.T) {
+	testlapack.DsyevxTest(t, impl)
+}
+
+func TestDsygs2(t *testing.T) {
+	testlapack.Dsygs2Test(t, impl)
+}
+
+func TestDsygst(t *testing.T) {
+	testlapack.DsygstTest(t, impl)
+}
+
+func TestDsygv(t *testing.T) {
+	testlapack.DsygvTest(t, impl)
+}
+
+func TestDsygvd(t *testing.T) {
+	testlapack.DsygvdTest(t, impl)
+}
+
+func TestDsygvx(t *testing.T) {
+	testlapack.DsygvxTest(t, impl)
+}
+
+func TestDsysv(t *testing.T) {
+	testlapack.DsysvTest(t, impl)
+}
+
+func TestDsysvRook(t *testing.T) {
+	testlapack.DsysvRookTest(t, impl)
+}
+
 func TestDsytd2(t *testing.T) {
 	testlapack.Dsytd2Test(t, impl)
 }
 
+func TestDsytf2(t *testing.T) {
+	testlapack.Dsytf2Test(t, impl)
+}
+
+func TestDsytf2Rook(t *testing.T) {
+	testlapack.Dsytf2RookTest(t, impl)
+}
+
 func TestDsytrd(t *testing.T) {
 	testlapack.DsytrdTest(t, impl)
 }
 
+func TestDsytrf(t *testing.T) {
+	testlapack.DsytrfTest(t, impl)
+}
+
+func TestDsytrfRook(t *testing.T) {
+	testlapack.DsytrfRookTest(t, impl)
+}
+
+func TestDsytri(t *testing.T) {
+	testlapack.DsytriTest(t, impl)
+}
+
+func TestDsytriRook(t *testing.T) {
+	testlapack.DsytriRookTest(t, impl)
+}
+
+func TestDsytrs(t *testing.T) {
+	testlapack.DsytrsTest(t, impl)
+}
+
+func TestDsytrsRook(t *testing.T) {
+	testlapack.DsytrsRookTest(t, impl)
+}
+
+func TestDtftri(t *testing.T) {
+	testlapack.DtftriTest(t, impl)
+}
+
+func TestDtfttr(t *testing.T) {
+	testlapack.DtfttrTest(t, impl)
+}
+
+func TestDtgevc(t *testing.T) {
+	testlapack.DtgevcTest(t, impl)
+}
+
+func TestDtgex2(t *testing.T) {
+	testlapack.Dtgex2Test(t, impl)
+}
+
+func TestDtgexc(t *testing.T) {
+	testlapack.DtgexcTest(t, impl)
+}
+
+func TestDtgsen(t *testing.T) {
+	testlapack.DtgsenTest(t, impl)
+}
+
 func TestDtgsja(t *testing.T) {
 	testlapack.DtgsjaTest(t, impl)
 }
 
+func TestDtgsna(t *testing.T) {
+	testlapack.DtgsnaTest(t, impl)
+}
+
+func TestDtgsyl(t *testing.T) {
+	testlapack.DtgsylTest(t, impl)
+}
+
+func TestDtptri(t *testing.T) {
+	testlapack.DtptriTest(t, impl)
+}
+
+func TestDtpttf(t *testing.T) {
+	testlapack.DtpttfTest(t, impl)
+}
+
 func TestDtrcon(t *testing.T) {
 	testlapack.DtrconTest(t, impl)
 }
@@ -412,6 +992,22 @@ func TestDtrexc(t *testing.T) {
 	testlapack.DtrexcTest(t, impl)
 }
 
+func TestDtrsen(t *testing.T) {
+	testlapack.DtrsenTest(t, impl)
+}
+
+func TestDtrsna(t *testing.T) {
+	testlapack.DtrsnaTest(t, impl)
+}
+
+func TestDtrsyl(t *testing.T) {
+	testlapack.DtrsylTest(t, impl)
+}
+
+func TestDtrsyl3(t *testing.T) {
+	testlapack.Dtrsyl3Test(t, impl)
+}
+
 func TestDtrti2(t *testing.T) {
 	testlapack.Dtrti2Test(t, impl)
 }
@@ -420,6 +1016,14 @@ func TestDtrtri(t *testing.T) {
 	testlapack.DtrtriTest(t, impl)
 }
 
+func TestDtrttf(t *testing.T) {
+	testlapack.DtrttfTest(t, impl)
+}
+
+func TestDtzrzf(t *testing.T) {
+	testlapack.DtzrzfTest(t, impl)
+}
+
 func TestIladlc(t *testing.T) {
 	testlapack.IladlcTest(t, impl)
 }
@@ -427,3 +1031,11 @@ func TestIladlc(t *testing.T) {
 func TestIladlr(t *testing.T) {
 	testlapack.IladlrTest(t, impl)
 }
+
+func TestSgetrf(t *testing.T) {
+	testlapack.SgetrfTest(t, impl)
+}
+
+func TestSpotrf(t *testing.T) {
+	testlapack.SpotrfTest(t, impl)
+}
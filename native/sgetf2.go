@@ -0,0 +1,68 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas/blas32"
+)
+
+// Sgetf2 is the single precision version of Dgetf2. It computes the LU
+// decomposition of the m×n float32 matrix A using partial pivoting with row
+// interchanges as
+//  A = P * L * U
+// where P is a permutation matrix, L is a unit lower triangular matrix, and
+// U is a (usually) non-unit upper triangular matrix. On exit, L and U are
+// stored in place into a.
+//
+// ipiv is a permutation vector. It indicates that row i of the matrix was
+// changed with ipiv[i]. ipiv must have length at least min(m,n), and will panic
+// otherwise. ipiv is zero-indexed.
+//
+// Sgetf2 returns whether the matrix A is singular. The LU decomposition will
+// be computed regardless of the singularity of A.
+//
+// Sgetf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Sgetf2(m, n int, a []float32, lda int, ipiv []int) (ok bool) {
+	mn := min(m, n)
+	checkMatrix32(m, n, a, lda)
+	if len(ipiv) < mn {
+		panic(badIpiv)
+	}
+	if m == 0 || n == 0 {
+		return true
+	}
+	bi := blas32.Implementation()
+	const sfmin = 1.0 / (1 << 126) // The smallest normal float32.
+	ok = true
+	for j := 0; j < mn; j++ {
+		// Find a pivot and test for singularity.
+		jp := j + bi.Isamax(m-j, a[j*lda+j:], lda)
+		ipiv[j] = jp
+		if a[jp*lda+j] == 0 {
+			ok = false
+		} else {
+			// Swap the rows if necessary.
+			if jp != j {
+				bi.Sswap(n, a[j*lda:], 1, a[jp*lda:], 1)
+			}
+			if j < m-1 {
+				aj := a[j*lda+j]
+				if math.Abs(float64(aj)) >= sfmin {
+					bi.Sscal(m-j-1, 1/aj, a[(j+1)*lda+j:], lda)
+				} else {
+					for i := j + 1; i < m; i++ {
+						a[i*lda+j] /= aj
+					}
+				}
+			}
+		}
+		if j < mn-1 {
+			bi.Sger(m-j-1, n-j-1, -1, a[(j+1)*lda+j:], lda, a[j*lda+j+1:], 1, a[(j+1)*lda+j+1:], lda)
+		}
+	}
+	return ok
+}
@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+// Slag2d converts the m×n float32 matrix SA to the float64 matrix A.
+//
+// Slag2d is an internal routine. It is exported for testing purposes.
+func (Implementation) Slag2d(m, n int, sa []float32, ldsa int, a []float64, lda int) {
+	checkMatrix32(m, n, sa, ldsa)
+	checkMatrix(m, n, a, lda)
+
+	for i := 0; i < m; i++ {
+		for j, v := range sa[i*ldsa : i*ldsa+n] {
+			a[i*lda+j] = float64(v)
+		}
+	}
+}
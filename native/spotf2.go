@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"math"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas32"
+)
+
+// Spotf2 is the single precision version of Dpotf2. It computes the Cholesky
+// decomposition of the symmetric positive definite float32 matrix a. If
+// ul == blas.Upper, then a is stored as an upper-triangular matrix, and
+// a = U^T U is stored in place into a. If ul == blas.Lower, then a = L L^T
+// is computed and stored in-place into a. If a is not positive definite, false
+// is returned. This is the unblocked version of the algorithm.
+//
+// Spotf2 is an internal routine. It is exported for testing purposes.
+func (Implementation) Spotf2(ul blas.Uplo, n int, a []float32, lda int) (ok bool) {
+	if ul != blas.Upper && ul != blas.Lower {
+		panic(badUplo)
+	}
+	checkMatrix32(n, n, a, lda)
+
+	if n == 0 {
+		return true
+	}
+
+	bi := blas32.Implementation()
+	if ul == blas.Upper {
+		for j := 0; j < n; j++ {
+			ajj := a[j*lda+j]
+			if j != 0 {
+				ajj -= bi.Sdot(j, a[j:], lda, a[j:], lda)
+			}
+			if ajj <= 0 || math.IsNaN(float64(ajj)) {
+				a[j*lda+j] = ajj
+				return false
+			}
+			ajj = float32(math.Sqrt(float64(ajj)))
+			a[j*lda+j] = ajj
+			if j < n-1 {
+				bi.Sgemv(blas.Trans, j, n-j-1,
+					-1, a[j+1:], lda, a[j:], lda,
+					1, a[j*lda+j+1:], 1)
+				bi.Sscal(n-j-1, 1/ajj, a[j*lda+j+1:], 1)
+			}
+		}
+		return true
+	}
+	for j := 0; j < n; j++ {
+		ajj := a[j*lda+j]
+		if j != 0 {
+			ajj -= bi.Sdot(j, a[j*lda:], 1, a[j*lda:], 1)
+		}
+		if ajj <= 0 || math.IsNaN(float64(ajj)) {
+			a[j*lda+j] = ajj
+			return false
+		}
+		ajj = float32(math.Sqrt(float64(ajj)))
+		a[j*lda+j] = ajj
+		if j < n-1 {
+			bi.Sgemv(blas.NoTrans, n-j-1, j,
+				-1, a[(j+1)*lda:], lda, a[j*lda:], 1,
+				1, a[(j+1)*lda+j:], lda)
+			bi.Sscal(n-j-1, 1/ajj, a[(j+1)*lda+j:], lda)
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package native
+
+import (
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas32"
+)
+
+// Spotrs solves a system of n linear equations A*X = B where A is an n×n
+// symmetric positive definite float32 matrix and B is an n×nrhs float32
+// matrix, using the Cholesky factorization A = U^T*U or A = L*L^T computed by
+// Spotrf. On entry, B contains the right-hand side matrix B, on return it
+// contains the solution matrix X.
+//
+// Spotrs is an internal routine. It is exported for testing purposes.
+func (Implementation) Spotrs(uplo blas.Uplo, n, nrhs int, a []float32, lda int, b []float32, ldb int) {
+	switch {
+	case uplo != blas.Upper && uplo != blas.Lower:
+		panic(badUplo)
+	case n < 0:
+		panic(nLT0)
+	case nrhs < 0:
+		panic("lapack: nrhs < 0")
+	}
+	checkMatrix32(n, n, a, lda)
+	checkMatrix32(n, nrhs, b, ldb)
+
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	bi := blas32.Implementation()
+	if uplo == blas.Upper {
+		// Solve U^T * U * X = B where U is stored in the upper triangle of A.
+
+		// Solve U^T * X = B, overwriting B with X.
+		bi.Strsm(blas.Left, blas.Upper, blas.Trans, blas.NonUnit, n, nrhs, 1, a, lda, b, ldb)
+		// Solve U * X = B, overwriting B with X.
+		bi.Strsm(blas.Left, blas.Upper, blas.NoTrans, blas.NonUnit, n, nrhs, 1, a, lda, b, ldb)
+	} else {
+		// Solve L * L^T * X = B where L is stored in the lower triangle of A.
+
+		// Solve L * X = B, overwriting B with X.
+		bi.Strsm(blas.Left, blas.Lower, blas.NoTrans, blas.NonUnit, n, nrhs, 1, a, lda, b, ldb)
+		// Solve L^T * X = B, overwriting B with X.
+		bi.Strsm(blas.Left, blas.Lower, blas.Trans, blas.NonUnit, n, nrhs, 1, a, lda, b, ldb)
+	}
+}
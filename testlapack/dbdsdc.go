@@ -0,0 +1,152 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dbdsdcer interface {
+	Dbdsdc(uplo blas.Uplo, compq lapack.SVDComp, n int, d, e, u []float64, ldu int, vt []float64, ldvt int, work []float64, iwork []int) (ok bool)
+	Dbdsqrer
+}
+
+func DbdsdcTest(t *testing.T, impl Dbdsdcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 10, 25, 26, 27, 50, 77, 100, 150} {
+			for _, ld := range []int{max(1, n), n + 5} {
+				for _, kind := range []string{"random", "split", "graded", "clustered"} {
+					testDbdsdc(t, impl, uplo, n, ld, kind, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDbdsdc(t *testing.T, impl Dbdsdcer, uplo blas.Uplo, n, ld int, kind string, rnd *rand.Rand) {
+	prefix := fmt.Sprintf("Case uplo=%c,n=%v,ld=%v,kind=%v:", uplo, n, ld, kind)
+
+	// Generate the bidiagonal matrix.
+	d := make([]float64, n)
+	e := make([]float64, max(0, n-1))
+	switch kind {
+	case "random":
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case "split":
+		// Zero and tiny elements split the matrix into independent
+		// subproblems and exercise the deflation paths.
+		for i := range d {
+			d[i] = float64(i%3) + 1e-10*rnd.NormFloat64()
+		}
+		for i := range e {
+			if i%11 == 10 {
+				e[i] = 0
+			} else {
+				e[i] = 1e-10 * rnd.NormFloat64()
+			}
+		}
+	case "clustered":
+		// Nearly equal singular values in the subproblems of the divide
+		// and conquer tree exercise the deflation of close singular
+		// values.
+		for i := range d {
+			d[i] = 1
+		}
+		for i := range e {
+			e[i] = 1e-15
+		}
+	case "graded":
+		for i := range d {
+			d[i] = float64(i + 1)
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	}
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	// Compute the singular values only.
+	work := nanSlice(4 * n)
+	iwork := make([]int, 8*n)
+	ok := impl.Dbdsdc(uplo, lapack.None, n, d, e, nil, 1, nil, 1, work, iwork)
+	if !ok {
+		t.Errorf("%v Dbdsdc failed without singular vectors", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(d))) {
+		t.Errorf("%v singular values are not sorted", prefix)
+	}
+	for i, v := range d {
+		if v < 0 {
+			t.Errorf("%v singular value %v is negative", prefix, i)
+		}
+	}
+
+	// Compare the singular values with those computed by Dbdsqr.
+	dAns := make([]float64, n)
+	copy(dAns, dCopy)
+	eAns := make([]float64, len(eCopy))
+	copy(eAns, eCopy)
+	impl.Dbdsqr(uplo, n, 0, 0, 0, dAns, eAns, nil, 1, nil, 1, nil, 1, make([]float64, 4*n))
+	if !floats.EqualApprox(d, dAns, 1e-10) {
+		t.Errorf("%v singular value mismatch with Dbdsqr", prefix)
+	}
+
+	// Compute the singular values and vectors.
+	dVec := make([]float64, n)
+	copy(dVec, dCopy)
+	copy(e, eCopy)
+	u := nanGeneral(n, n, ld)
+	vt := nanGeneral(n, n, ld)
+	work = nanSlice(3*n*n + 4*n)
+	ok = impl.Dbdsdc(uplo, lapack.BidiagSV, n, dVec, e, u.Data, u.Stride, vt.Data, vt.Stride, work, iwork)
+	if !ok {
+		t.Errorf("%v Dbdsdc failed with singular vectors", prefix)
+		return
+	}
+	if !floats.EqualApprox(d, dVec, 1e-10) {
+		t.Errorf("%v singular value mismatch with and without singular vectors", prefix)
+	}
+	if !isOrthonormal(u) {
+		t.Errorf("%v U is not orthogonal", prefix)
+	}
+	if !isOrthonormal(vt) {
+		t.Errorf("%v VT is not orthogonal", prefix)
+	}
+
+	// Check that U * S * VT reconstructs B.
+	b := constructBidiagonal(uplo, n, dCopy, eCopy)
+	us := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			us.Data[i*us.Stride+j] = u.Data[i*u.Stride+j] * dVec[j]
+		}
+	}
+	ans := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vt, 0, ans)
+	if !equalApproxGeneral(ans, b, 1e-10) {
+		t.Errorf("%v U*S*VT does not reconstruct B", prefix)
+	}
+}
@@ -0,0 +1,222 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dbdsvdxer interface {
+	Dbdsvdx(uplo blas.Uplo, compq lapack.SVDComp, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, s, z []float64, ldz int, work []float64, iwork []int) (ns int, ok bool)
+	Dbdsqrer
+}
+
+func DbdsvdxTest(t *testing.T, impl Dbdsvdxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compq := range []lapack.SVDComp{lapack.None, lapack.BidiagSV} {
+		for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 27, 50} {
+					for _, kind := range []string{"random", "zeroD", "zeroE", "graded", "clustered"} {
+						for _, ldz := range []int{0, n + 5} {
+							testDbdsvdx(t, impl, compq, rng, uplo, n, ldz, kind, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDbdsvdx(t *testing.T, impl Dbdsvdxer, compq lapack.SVDComp, rng lapack.EVRange, uplo blas.Uplo, n, ldz int, kind string, rnd *rand.Rand) {
+	wantz := compq == lapack.BidiagSV
+
+	// Generate the bidiagonal matrix.
+	d := make([]float64, n)
+	e := make([]float64, max(0, n-1))
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	for i := range e {
+		e[i] = rnd.NormFloat64()
+	}
+	switch kind {
+	case "zeroD":
+		// Zero diagonal elements at the top, bottom and in the middle
+		// of B make B singular and split TGK into blocks of odd order.
+		for i := range d {
+			if i == 0 || i == n-1 || i%4 == 2 {
+				d[i] = 0
+			}
+		}
+	case "zeroE":
+		for i := range e {
+			if i%3 == 1 {
+				e[i] = 0
+			}
+		}
+	case "graded":
+		for i := range d {
+			d[i] *= math.Pow(10, -float64(i)/2)
+		}
+		for i := range e {
+			e[i] *= math.Pow(10, -float64(i)/2)
+		}
+	case "clustered":
+		for i := range d {
+			d[i] = 1
+		}
+		for i := range e {
+			e[i] = 1e-12 * rnd.NormFloat64()
+		}
+	}
+
+	// Compute all singular values with Dbdsqr for reference.
+	sAll := make([]float64, n)
+	copy(sAll, d)
+	eAll := make([]float64, len(e))
+	copy(eAll, e)
+	impl.Dbdsqr(uplo, n, 0, 0, 0, sAll, eAll, nil, 1, nil, 1, nil, 1, make([]float64, 4*n))
+	smax := 1.0
+	if n > 0 {
+		smax = math.Max(1, sAll[0])
+	}
+
+	var b blas64.General
+	if n > 0 {
+		b = constructBidiagonal(uplo, n, d, e)
+	}
+	for _, sel := range singularSelections(rng, sAll) {
+		il, iu := sel.il, sel.iu
+		ncol := n
+		if rng == lapack.RangeIndex {
+			ncol = iu - il + 1
+		}
+		ld := ldz
+		if ld == 0 {
+			ld = max(1, ncol)
+		}
+		prefix := fmt.Sprintf("Case compq=%c,rng=%c,uplo=%c,n=%v,ldz=%v,kind=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			compq, rng, uplo, n, ld, kind, sel.vl, sel.vu, il, iu)
+
+		dCopy := make([]float64, len(d))
+		copy(dCopy, d)
+		eCopy := make([]float64, len(e))
+		copy(eCopy, e)
+		s := nanSlice(n)
+		var z []float64
+		if wantz {
+			z = nanSlice(max(0, (2*n-1)*ld+ncol))
+		}
+		work := nanSlice(16 * n)
+		iwork := make([]int, 10*n)
+		for i := range iwork {
+			iwork[i] = rnd.Int()
+		}
+
+		ns, ok := impl.Dbdsvdx(uplo, compq, rng, n, d, e, sel.vl, sel.vu, il, iu, s, z, ld, work, iwork)
+		if !ok {
+			t.Errorf("%v Dbdsvdx failed", prefix)
+			continue
+		}
+		if !floats.Equal(d, dCopy) || !floats.Equal(e, eCopy) {
+			t.Errorf("%v d or e modified", prefix)
+		}
+		if ns != iu-il+1 {
+			t.Errorf("%v unexpected number of singular values; got %v, want %v", prefix, ns, iu-il+1)
+			continue
+		}
+		if ns == 0 {
+			continue
+		}
+		if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s[:ns]))) {
+			t.Errorf("%v singular values are not sorted", prefix)
+		}
+		if !floats.EqualApprox(s[:ns], sAll[il:iu+1], 1e-13*smax) {
+			t.Errorf("%v singular value mismatch with Dbdsqr", prefix)
+		}
+		if !wantz {
+			continue
+		}
+
+		// Check that U and V have orthonormal columns.
+		var hasNaN bool
+		for i := 0; i < 2*n; i++ {
+			hasNaN = hasNaN || floats.HasNaN(z[i*ld:i*ld+ns])
+		}
+		if hasNaN {
+			t.Errorf("%v NaN in singular vectors", prefix)
+			continue
+		}
+		if !hasOrthonormalColumns(n, ns, z, ld) {
+			t.Errorf("%v U is not orthonormal", prefix)
+		}
+		if !hasOrthonormalColumns(n, ns, z[n*ld:], ld) {
+			t.Errorf("%v V is not orthonormal", prefix)
+		}
+
+		// Check that B * V = U * S.
+		u := blas64.General{Rows: n, Cols: ns, Stride: ld, Data: z}
+		v := blas64.General{Rows: n, Cols: ns, Stride: ld, Data: z[n*ld:]}
+		bv := zeros(n, ns, ns)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, v, 0, bv)
+		us := zeros(n, ns, ns)
+		for i := 0; i < n; i++ {
+			for j := 0; j < ns; j++ {
+				us.Data[i*us.Stride+j] = u.Data[i*u.Stride+j] * s[j]
+			}
+		}
+		if !equalApproxGeneral(bv, us, 1e-12*smax) {
+			t.Errorf("%v B*V != U*S", prefix)
+		}
+	}
+}
+
+// singularSelections returns subsets of the singular values s, sorted in
+// descending order, to be computed by a routine that supports the range rng.
+// The indices of the returned selections count from the largest singular
+// value, and interval bounds are positive, unless all singular values are
+// well above zero, and only placed in gaps between well separated singular
+// values.
+func singularSelections(rng lapack.EVRange, s []float64) []eigenSelection {
+	n := len(s)
+	w := make([]float64, n)
+	for i, v := range s {
+		w[n-1-i] = v
+	}
+	sels := eigenSelections(rng, w)
+	for i, sel := range sels {
+		if rng == lapack.RangeInterval && sel.vl < 0 {
+			// Exclude negligible singular values from the interval
+			// because they may be computed as exact zeros.
+			tiny := 1e-8 * math.Max(1, w[n-1])
+			k := sel.il
+			for k <= sel.iu && w[k] <= tiny {
+				k++
+			}
+			switch {
+			case k == 0:
+				sel.vl = 0
+			case k <= sel.iu:
+				sel.vl = (w[k-1] + w[k]) / 2
+			default:
+				sel.vl = (w[k-1] + sel.vu) / 2
+			}
+			sel.il = k
+		}
+		sel.il, sel.iu = n-1-sel.iu, n-1-sel.il
+		sels[i] = sel
+	}
+	return sels
+}
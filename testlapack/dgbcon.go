@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgbconer interface {
+	Dgbcon(norm lapack.MatrixNorm, n, kl, ku int, ab []float64, ldab int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgbtrfer
+	Dgetrier
+	Dlanger
+}
+
+func DgbconTest(t *testing.T, impl Dgbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+			for _, kl := range []int{0, 1, 2, 5} {
+				for _, ku := range []int{0, 1, 2, 5} {
+					for _, extra := range []int{0, 3} {
+						for _, singular := range []bool{false, true} {
+							testDgbcon(t, impl, norm, n, kl, ku, 2*kl+ku+1+extra, singular, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbcon(t *testing.T, impl Dgbconer, norm lapack.MatrixNorm, n, kl, ku, ldab int, singular bool, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("norm = %v, n = %v, kl = %v, ku = %v, ldab = %v, singular = %v", string(norm), n, kl, ku, ldab, singular)
+
+	ab := randomBand(n, n, kl, ku, ldab, rnd)
+	if singular && n > 0 {
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		for i := max(0, j-ku); i <= min(n-1, j+kl); i++ {
+			ab[i*ldab+kl+j-i] = 0
+		}
+	}
+	a := bandToGeneral(n, n, kl, ku, ab, ldab)
+	work := nanSlice(max(1, 4*n))
+	iwork := make([]int, n)
+	anorm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+
+	ipiv := make([]int, n)
+	ok := impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv)
+	abFac := make([]float64, len(ab))
+	copy(abFac, ab)
+
+	got := impl.Dgbcon(norm, n, kl, ku, ab, ldab, ipiv, anorm, work, iwork)
+	if n == 0 {
+		if got != 1 {
+			t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+		}
+		return
+	}
+	if !floats.Same(ab, abFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if !ok {
+		if got != 0 {
+			t.Errorf("Unexpected rcond for singular U: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+
+	// Compute the true reciprocal condition number from the inverse of A.
+	ipivGe := make([]int, n)
+	impl.Dgetrf(n, n, a.Data, a.Stride, ipivGe)
+	impl.Dgetri(n, a.Data, a.Stride, ipivGe, work, -1)
+	lwork := int(work[0])
+	work = make([]float64, lwork)
+	if !impl.Dgetri(n, a.Data, a.Stride, ipivGe, work, lwork) {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	ainvnorm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+	want := 1 / anorm / ainvnorm
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimated reciprocal condition number must not be smaller than the true
+	// one.
+	if got < want*(1-1e-8) || got > 10*want || math.IsNaN(got) {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dgbsver interface {
+	Dgbsv(n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int) (ok bool)
+	Dgbtrser
+}
+
+func DgbsvTest(t *testing.T, impl Dgbsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, kl, ku, nrhs, ldab, ldb int
+	}{
+		{0, 0, 0, 0, 0, 0},
+		{0, 1, 2, 3, 0, 0},
+		{3, 1, 1, 0, 0, 0},
+		{1, 0, 0, 1, 0, 0},
+		{5, 0, 0, 1, 0, 0},
+		{5, 1, 0, 3, 0, 0},
+		{5, 0, 1, 3, 0, 0},
+		{5, 2, 1, 3, 10, 11},
+		{10, 4, 4, 2, 0, 0},
+		{50, 3, 7, 4, 0, 0},
+		{100, 10, 5, 10, 30, 12},
+		{150, 40, 70, 3, 0, 0},
+	} {
+		for _, singular := range []bool{false, true} {
+			testDgbsv(t, impl, test.n, test.kl, test.ku, test.nrhs, test.ldab, test.ldb, singular, rnd)
+		}
+	}
+}
+
+func testDgbsv(t *testing.T, impl Dgbsver, n, kl, ku, nrhs, ldab, ldb int, singular bool, rnd *rand.Rand) {
+	if ldab == 0 {
+		ldab = 2*kl + ku + 1
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	ab := randomBand(n, n, kl, ku, ldab, rnd)
+	if singular && n > 0 {
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		for i := max(0, j-ku); i <= min(n-1, j+kl); i++ {
+			ab[i*ldab+kl+j-i] = 0
+		}
+	}
+	a := bandToGeneral(n, n, kl, ku, ab, ldab)
+	abCopy := make([]float64, len(ab))
+	copy(abCopy, ab)
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+	ipiv := make([]int, n)
+
+	ok := impl.Dgbsv(n, kl, ku, nrhs, ab, ldab, ipiv, b.Data, b.Stride)
+	errStr := fmt.Sprintf("n = %v, kl = %v, ku = %v, nrhs = %v, ldab = %v, ldb = %v, singular = %v", n, kl, ku, nrhs, ldab, ldb, singular)
+	if singular && n > 0 {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the LU factorization is the same as computed by Dgbtrf.
+	ipivWant := make([]int, n)
+	impl.Dgbtrf(n, n, kl, ku, abCopy, ldab, ipivWant)
+	if !floats.Same(ab, abCopy) {
+		t.Errorf("Unexpected LU factorization: %s", errStr)
+	}
+	for i, v := range ipiv {
+		if v != ipivWant[i] {
+			t.Errorf("Unexpected pivot indices: %s", errStr)
+			break
+		}
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-10) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
@@ -0,0 +1,15 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import "testing"
+
+type Dgbtf2er interface {
+	Dgbtf2(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+}
+
+func Dgbtf2Test(t *testing.T, impl Dgbtf2er) {
+	dgbtrfTest(t, impl.Dgbtf2)
+}
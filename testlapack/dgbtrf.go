@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dgbtrfer interface {
+	Dgbtrf(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) (ok bool)
+}
+
+func DgbtrfTest(t *testing.T, impl Dgbtrfer) {
+	dgbtrfTest(t, impl.Dgbtrf)
+}
+
+// dgbtrfTest tests a routine that computes the LU factorization of a band
+// matrix by checking that the factors reconstruct the original matrix.
+func dgbtrfTest(t *testing.T, dgbtrf func(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, kl, ku int
+	}{
+		// Large cases that exercise the blocked code.
+		{150, 150, 40, 70},
+		{200, 150, 35, 66},
+		{130, 200, 33, 80},
+	} {
+		for _, extra := range []int{0, 3} {
+			for _, singular := range []bool{false, true} {
+				testDgbtrf(t, dgbtrf, test.m, test.n, test.kl, test.ku, 2*test.kl+test.ku+1+extra, singular, rnd)
+			}
+		}
+	}
+	for _, m := range []int{0, 1, 2, 3, 5, 10, 25} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 2, 4} {
+					for _, extra := range []int{0, 3} {
+						for _, singular := range []bool{false, true} {
+							testDgbtrf(t, dgbtrf, m, n, kl, ku, 2*kl+ku+1+extra, singular, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrf(t *testing.T, dgbtrf func(m, n, kl, ku int, ab []float64, ldab int, ipiv []int) bool, m, n, kl, ku, ldab int, singular bool, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	kv := kl + ku
+	mn := min(m, n)
+	errStr := fmt.Sprintf("m = %v, n = %v, kl = %v, ku = %v, ldab = %v, singular = %v", m, n, kl, ku, ldab, singular)
+
+	// Generate a random band matrix A with kl additional superdiagonals for
+	// the fill-in. The fill-in elements need not be set, so they are left
+	// as NaN.
+	ab := randomBand(m, n, kl, ku, ldab, rnd)
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := i + ku + 1; j <= min(n-1, i+kv); j++ {
+			ab[i*ldab+kl+j-i] = math.NaN()
+		}
+	}
+	if singular {
+		if mn == 0 {
+			return
+		}
+		// Make one column of A zero.
+		jz := rnd.Intn(mn)
+		for i := max(0, jz-ku); i <= min(m-1, jz+kl); i++ {
+			ab[i*ldab+kl+jz-i] = 0
+		}
+	}
+	a := bandToGeneral(m, n, kl, ku, ab, ldab)
+
+	ipiv := make([]int, mn)
+	for i := range ipiv {
+		ipiv[i] = -1
+	}
+	ok := dgbtrf(m, n, kl, ku, ab, ldab, ipiv)
+	if singular && ok {
+		t.Errorf("Singular matrix not detected: %s", errStr)
+	}
+	if !singular && !ok {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+	}
+
+	for j, p := range ipiv {
+		if p < j || min(m-1, j+kl) < p {
+			t.Errorf("Pivot index out of range: %s", errStr)
+			break
+		}
+	}
+
+	// Check that the elements of ab outside of the band of U and L have not
+	// been modified.
+	for i := 0; i < min(m, n+kl); i++ {
+		for k := 0; k < ldab; k++ {
+			j := i - kl + k
+			if j >= max(0, i-kl) && j <= min(n-1, i+kv) {
+				continue
+			}
+			if !math.IsNaN(ab[i*ldab+k]) {
+				t.Errorf("Element outside of the band modified: %s", errStr)
+				return
+			}
+		}
+	}
+
+	if mn == 0 {
+		return
+	}
+
+	// Reconstruct the matrix A from its factorization
+	//  A = P(0) * L(0) * ... * P(mn-1) * L(mn-1) * U
+	// starting with the upper trapezoidal matrix U.
+	lu := zeros(m, n, n)
+	for i := 0; i < mn; i++ {
+		for j := i; j <= min(n-1, i+kv); j++ {
+			lu.Data[i*n+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	for j := mn - 1; j >= 0; j-- {
+		for i := j + 1; i <= min(m-1, j+kl); i++ {
+			l := ab[i*ldab+kl+j-i]
+			for k := 0; k < n; k++ {
+				lu.Data[i*n+k] += l * lu.Data[j*n+k]
+			}
+		}
+		if p := ipiv[j]; p != j {
+			for k := 0; k < n; k++ {
+				lu.Data[j*n+k], lu.Data[p*n+k] = lu.Data[p*n+k], lu.Data[j*n+k]
+			}
+		}
+	}
+	if !equalApproxGeneral(lu, a, tol) {
+		t.Errorf("P*L*U != A: %s", errStr)
+	}
+}
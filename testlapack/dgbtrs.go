@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dgbtrser interface {
+	Dgbtrfer
+	Dgbtrs(trans blas.Transpose, n, kl, ku, nrhs int, ab []float64, ldab int, ipiv []int, b []float64, ldb int)
+}
+
+func DgbtrsTest(t *testing.T, impl Dgbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		// Large case that uses the blocked factorization.
+		testDgbtrs(t, impl, trans, 150, 40, 70, 3, 2*40+70+1, 3, rnd)
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, kl := range []int{0, 1, 2, 4} {
+				for _, ku := range []int{0, 1, 2, 4} {
+					for _, nrhs := range []int{0, 1, 3} {
+						for _, ldab := range []int{2*kl + ku + 1, 2*kl + ku + 4} {
+							for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+								testDgbtrs(t, impl, trans, n, kl, ku, nrhs, ldab, ldb, rnd)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgbtrs(t *testing.T, impl Dgbtrser, trans blas.Transpose, n, kl, ku, nrhs, ldab, ldb int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("trans = %v, n = %v, kl = %v, ku = %v, nrhs = %v, ldab = %v, ldb = %v", trans, n, kl, ku, nrhs, ldab, ldb)
+
+	ab := randomBand(n, n, kl, ku, ldab, rnd)
+	a := bandToGeneral(n, n, kl, ku, ab, ldab)
+	ipiv := make([]int, n)
+	if !impl.Dgbtrf(n, n, kl, ku, ab, ldab, ipiv) {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	abFac := make([]float64, len(ab))
+	copy(abFac, ab)
+
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	impl.Dgbtrs(trans, n, kl, ku, nrhs, ab, ldab, ipiv, b.Data, b.Stride)
+
+	if !floats.Same(ab, abFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the solution satisfies op(A)*X = B by computing the
+	// normwise residual
+	//  |op(A)*X - B| / (|op(A)| * |X|)
+	// in the max-abs norm. A random triangular band matrix can be badly
+	// conditioned, so X is not compared elementwise.
+	var anorm, xnorm float64
+	for i := 0; i < n; i++ {
+		var rowSum, colSum float64
+		for j := 0; j < n; j++ {
+			rowSum += math.Abs(a.Data[i*a.Stride+j])
+			colSum += math.Abs(a.Data[j*a.Stride+i])
+		}
+		anorm = math.Max(anorm, math.Max(rowSum, colSum))
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	r := cloneGeneral(bCopy)
+	blas64.Gemm(trans, blas.NoTrans, 1, a, b, -1, r)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			resid = math.Max(resid, math.Abs(r.Data[i*r.Stride+j]))
+		}
+	}
+	if resid > tol*anorm*xnorm || math.IsNaN(resid) {
+		t.Errorf("op(A)*X != B, residual = %v: %s", resid/anorm/xnorm, errStr)
+	}
+}
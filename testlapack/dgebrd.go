@@ -147,5 +147,44 @@ func DgebrdTest(t *testing.T, impl Dgebrder) {
 		if !floats.EqualApprox(tauP, tauPAns, 1e-10) {
 			t.Errorf("tauP mismatch")
 		}
+
+		// Test with minimum work. The workspace is too small for the
+		// blocked code, so the block size must be reduced.
+		work = make([]float64, max(m, n))
+		lwork = len(work)
+		for i := range work {
+			work[i] = math.NaN()
+		}
+		copy(a, aCopy)
+		for i := range d {
+			d[i] = 0
+		}
+		for i := range e {
+			e[i] = 0
+		}
+		for i := range tauP {
+			tauP[i] = 0
+		}
+		for i := range tauQ {
+			tauQ[i] = 0
+		}
+		impl.Dgebrd(m, n, a, lda, d, e, tauQ, tauP, work, lwork)
+
+		// Test answers
+		if !floats.EqualApprox(a, aAns, 1e-10) {
+			t.Errorf("a mismatch")
+		}
+		if !floats.EqualApprox(d, dAns, 1e-10) {
+			t.Errorf("d mismatch")
+		}
+		if !floats.EqualApprox(e, eAns, 1e-10) {
+			t.Errorf("e mismatch")
+		}
+		if !floats.EqualApprox(tauQ, tauQAns, 1e-10) {
+			t.Errorf("tauQ mismatch")
+		}
+		if !floats.EqualApprox(tauP, tauPAns, 1e-10) {
+			t.Errorf("tauP mismatch")
+		}
 	}
 }
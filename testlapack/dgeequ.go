@@ -0,0 +1,173 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dgeequer interface {
+	Dgeequ(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+}
+
+func DgeequTest(t *testing.T, impl Dgeequer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{5, 0, 0},
+		{1, 1, 0},
+		{1, 5, 0},
+		{5, 1, 0},
+		{5, 5, 0},
+		{5, 10, 0},
+		{10, 5, 0},
+		{5, 10, 15},
+		{10, 5, 15},
+		{40, 30, 0},
+	} {
+		for _, zero := range []string{"none", "row", "col"} {
+			testDgeequ(t, impl, test.m, test.n, test.lda, zero, rnd)
+		}
+	}
+}
+
+func testDgeequ(t *testing.T, impl Dgeequer, m, n, lda int, zero string, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	a := badlyScaledGeneral(m, n, lda, rnd)
+	switch {
+	case zero == "row" && m > 0 && n > 0:
+		i := rnd.Intn(m)
+		for j := 0; j < n; j++ {
+			a.Data[i*lda+j] = 0
+		}
+	case zero == "col" && m > 0 && n > 0:
+		j := rnd.Intn(n)
+		for i := 0; i < m; i++ {
+			a.Data[i*lda+j] = 0
+		}
+	default:
+		zero = "none"
+	}
+	aCopy := cloneGeneral(a)
+	r := nanSlice(m)
+	c := nanSlice(n)
+
+	rowcnd, colcnd, amax, ok := impl.Dgeequ(m, n, a.Data, a.Stride, r, c)
+	errStr := fmt.Sprintf("m = %v, n = %v, lda = %v, zero = %v", m, n, lda, zero)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if zero != "none" {
+		if ok {
+			t.Errorf("Zero %s not detected: %s", zero, errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	var wantAmax float64
+	for i := 0; i < m; i++ {
+		for _, v := range a.Data[i*lda : i*lda+n] {
+			wantAmax = math.Max(wantAmax, math.Abs(v))
+		}
+	}
+	if amax != wantAmax {
+		t.Errorf("Unexpected amax: got %v, want %v: %s", amax, wantAmax, errStr)
+	}
+	if want := floats.Min(r) / floats.Max(r); math.Abs(rowcnd-want) > 1e-14*want {
+		t.Errorf("Unexpected rowcnd: got %v, want %v: %s", rowcnd, want, errStr)
+	}
+	if want := floats.Min(c) / floats.Max(c); math.Abs(colcnd-want) > 1e-14*want {
+		t.Errorf("Unexpected colcnd: got %v, want %v: %s", colcnd, want, errStr)
+	}
+
+	// Check that the largest element in each column of the scaled matrix
+	// is 1. The column scaling is computed after the row scaling and can
+	// only increase the elements, so the largest element in each row is at
+	// least 1.
+	s := scaledGeneral(a, r, c)
+	rowMax, colMax := rowColMax(s)
+	for _, v := range rowMax {
+		if v < 1-1e-14 {
+			t.Errorf("Largest element in a row of the scaled matrix is less than 1: %s", errStr)
+			break
+		}
+	}
+	for _, v := range colMax {
+		if math.Abs(v-1) > 1e-14 {
+			t.Errorf("Largest element in a column of the scaled matrix is not 1: %s", errStr)
+			break
+		}
+	}
+}
+
+// badlyScaledGeneral returns an m×n general matrix whose rows and columns
+// have been scaled by random factors that vary over many orders of magnitude.
+func badlyScaledGeneral(m, n, stride int, rnd *rand.Rand) blas64.General {
+	a := blas64.General{Rows: m, Cols: n, Stride: stride, Data: make([]float64, m*stride)}
+	dr := make([]float64, m)
+	for i := range dr {
+		dr[i] = math.Pow(10, float64(rnd.Intn(11)-5))
+	}
+	dc := make([]float64, n)
+	for j := range dc {
+		dc[j] = math.Pow(10, float64(rnd.Intn(11)-5))
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			a.Data[i*stride+j] = dr[i] * rnd.NormFloat64() * dc[j]
+		}
+	}
+	return a
+}
+
+// scaledGeneral returns the matrix diag(r)*A*diag(c). If r or c is nil, the
+// corresponding scaling is not applied.
+func scaledGeneral(a blas64.General, r, c []float64) blas64.General {
+	s := cloneGeneral(a)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			if r != nil {
+				s.Data[i*s.Stride+j] *= r[i]
+			}
+			if c != nil {
+				s.Data[i*s.Stride+j] *= c[j]
+			}
+		}
+	}
+	return s
+}
+
+// rowColMax returns the largest absolute values of the elements in each row
+// and in each column of A.
+func rowColMax(a blas64.General) (rowMax, colMax []float64) {
+	rowMax = make([]float64, a.Rows)
+	colMax = make([]float64, a.Cols)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			v := math.Abs(a.Data[i*a.Stride+j])
+			rowMax[i] = math.Max(rowMax[i], v)
+			colMax[j] = math.Max(colMax[j], v)
+		}
+	}
+	return rowMax, colMax
+}
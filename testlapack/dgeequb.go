@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+)
+
+type Dgeequber interface {
+	Dgeequb(m, n int, a []float64, lda int, r, c []float64) (rowcnd, colcnd, amax float64, ok bool)
+}
+
+func DgeequbTest(t *testing.T, impl Dgeequber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{5, 0, 0},
+		{1, 1, 0},
+		{1, 5, 0},
+		{5, 1, 0},
+		{5, 5, 0},
+		{5, 10, 0},
+		{10, 5, 0},
+		{5, 10, 15},
+		{10, 5, 15},
+		{40, 30, 0},
+	} {
+		for _, zero := range []string{"none", "row", "col"} {
+			testDgeequb(t, impl, test.m, test.n, test.lda, zero, rnd)
+		}
+	}
+}
+
+func testDgeequb(t *testing.T, impl Dgeequber, m, n, lda int, zero string, rnd *rand.Rand) {
+	const radix = 2
+
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	a := badlyScaledGeneral(m, n, lda, rnd)
+	switch {
+	case zero == "row" && m > 0 && n > 0:
+		i := rnd.Intn(m)
+		for j := 0; j < n; j++ {
+			a.Data[i*lda+j] = 0
+		}
+	case zero == "col" && m > 0 && n > 0:
+		j := rnd.Intn(n)
+		for i := 0; i < m; i++ {
+			a.Data[i*lda+j] = 0
+		}
+	default:
+		zero = "none"
+	}
+	aCopy := cloneGeneral(a)
+	r := nanSlice(m)
+	c := nanSlice(n)
+
+	rowcnd, colcnd, _, ok := impl.Dgeequb(m, n, a.Data, a.Stride, r, c)
+	errStr := fmt.Sprintf("m = %v, n = %v, lda = %v, zero = %v", m, n, lda, zero)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if zero != "none" {
+		if ok {
+			t.Errorf("Zero %s not detected: %s", zero, errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	// Check that the scale factors are powers of the radix.
+	for _, v := range append(append([]float64{}, r...), c...) {
+		if frac, _ := math.Frexp(v); frac != 0.5 {
+			t.Errorf("Scale factor %v is not a power of the radix: %s", v, errStr)
+			break
+		}
+	}
+	if want := floats.Min(r) / floats.Max(r); rowcnd != want {
+		t.Errorf("Unexpected rowcnd: got %v, want %v: %s", rowcnd, want, errStr)
+	}
+	if want := floats.Min(c) / floats.Max(c); colcnd != want {
+		t.Errorf("Unexpected colcnd: got %v, want %v: %s", colcnd, want, errStr)
+	}
+
+	// Check that the largest element in each column of the scaled matrix
+	// is within a factor of the radix from 1, and that the largest element
+	// in each row is not much smaller.
+	s := scaledGeneral(a, r, c)
+	rowMax, colMax := rowColMax(s)
+	for _, v := range colMax {
+		if v <= 1.0/radix || radix <= v {
+			t.Errorf("Largest element %v in a column of the scaled matrix is out of range: %s", v, errStr)
+			break
+		}
+	}
+	for _, v := range rowMax {
+		if v <= 1.0/(radix*radix) {
+			t.Errorf("Largest element %v in a row of the scaled matrix is out of range: %s", v, errStr)
+			break
+		}
+	}
+}
@@ -0,0 +1,214 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dgeeser interface {
+	Dgees(jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, n int, a []float64, lda int, wr, wi []float64, vs []float64, ldvs int, work []float64, lwork int, bwork []bool) (sdim int, ok bool)
+}
+
+// dgeesTest is a test case for Dgees and Dgeesx.
+type dgeesTest struct {
+	a      blas64.General
+	evWant []complex128 // If nil, the eigenvalues are not known.
+}
+
+// dgeesCases returns the test matrices for Dgees and Dgeesx.
+func dgeesCases(rnd *rand.Rand) []dgeesTest {
+	var tests []dgeesTest
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31, 53} {
+		tests = append(tests, dgeesTest{a: randomGeneral(n, n, n, rnd)})
+		// Sparse unbalanced matrix that allows for isolation of
+		// eigenvalues by permutation.
+		tests = append(tests, dgeesTest{a: unbalancedSparseGeneral(n, n, n, 2*n, rnd)})
+		if n > 0 {
+			tests = append(tests, dgeesTest{
+				a:      Circulant(n).Matrix(),
+				evWant: Circulant(n).Eigenvalues(),
+			})
+		}
+	}
+	return tests
+}
+
+// dgeesSelectors returns the eigenvalue selection functions used in the tests
+// of Dgees and Dgeesx. The first one is nil which means no ordering.
+func dgeesSelectors(rnd *rand.Rand) []func(wr, wi float64) bool {
+	thresh := rnd.NormFloat64()
+	return []func(wr, wi float64) bool{
+		nil,
+		// Select eigenvalues with the real part above a random
+		// threshold.
+		func(wr, wi float64) bool { return wr > thresh },
+		// Select complex eigenvalues only.
+		func(wr, wi float64) bool { return wi != 0 },
+		// Select eigenvalues inside the unit disc.
+		func(wr, wi float64) bool { return cmplx.Abs(complex(wr, wi)) < 1 },
+	}
+}
+
+func DgeesTest(t *testing.T, impl Dgeeser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range dgeesCases(rnd) {
+		for _, jobvs := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+			for i, selctg := range dgeesSelectors(rnd) {
+				for _, extra := range []int{0, 11} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						n := test.a.Rows
+						var lwork int
+						switch wl {
+						case minimumWork:
+							lwork = max(1, 3*n)
+						case optimumWork:
+							work := make([]float64, 1)
+							impl.Dgees(jobvs, selctg, n, nil, max(1, n), nil, nil, nil, max(1, n), work, -1, nil)
+							lwork = int(work[0])
+						}
+						prefix := fmt.Sprintf("Dgees: n=%v, jobvs=%v, selctg=%v, extra=%v, work=%v",
+							n, string(jobvs), i, extra, wl)
+						testDgees(t, prefix, test, jobvs, selctg, extra, func(a, vs blas64.General, wr, wi []float64, bwork []bool) (int, bool) {
+							work := nanSlice(lwork)
+							return impl.Dgees(jobvs, selctg, n, a.Data, a.Stride, wr, wi, vs.Data, vs.Stride, work, lwork, bwork)
+						})
+					}
+				}
+			}
+		}
+	}
+}
+
+// testDgees checks the Schur factorization computed by dgees which must call
+// Dgees or Dgeesx with the given jobvs and selctg.
+func testDgees(t *testing.T, prefix string, test dgeesTest, jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, extra int, dgees func(a, vs blas64.General, wr, wi []float64, bwork []bool) (sdim int, ok bool)) {
+	const tol = 1e-12
+
+	n := test.a.Rows
+	wantvs := jobvs == lapack.ComputeSchurVectors
+
+	a := nanGeneral(n, n, n+extra)
+	copyGeneral(a, test.a)
+	vs := blas64.General{Stride: 1}
+	if wantvs {
+		vs = nanGeneral(n, n, n+extra)
+	}
+	wr := nanSlice(n)
+	wi := nanSlice(n)
+	var bwork []bool
+	if selctg != nil {
+		bwork = make([]bool, n)
+	}
+
+	sdim, ok := dgees(a, vs, wr, wi, bwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if selctg == nil && sdim != 0 {
+		t.Errorf("%v: unexpected nonzero sdim=%v without ordering", prefix, sdim)
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(vs) {
+		t.Errorf("%v: out-of-range write to VS", prefix)
+	}
+
+	// Check that T is in Schur canonical form.
+	if !isSchurCanonicalGeneral(a) {
+		t.Errorf("%v: T is not in Schur canonical form", prefix)
+	}
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if a.Data[i*a.Stride+j] != 0 {
+				t.Errorf("%v: T is not quasi-triangular at [%v,%v]", prefix, i, j)
+			}
+		}
+	}
+
+	// Check that the eigenvalues agree with the diagonal blocks of T, and
+	// with the known eigenvalues if available.
+	for j := 0; j < n; j++ {
+		ev := complex(wr[j], wi[j])
+		size, first := schurBlockSize(a, j)
+		if size == 1 {
+			if wi[j] != 0 || wr[j] != a.Data[j*a.Stride+j] {
+				t.Errorf("%v: eigenvalue %v does not match the 1×1 block of T", prefix, j)
+			}
+		} else {
+			i := j
+			if !first {
+				i--
+			}
+			aii, b, c, d := extract2x2Block(a.Data[i*a.Stride+i:], a.Stride)
+			ev1, ev2 := schurBlockEigenvalues(aii, b, c, d)
+			want := ev1
+			if !first {
+				want = ev2
+			}
+			if cmplx.Abs(ev-want) > tol*math.Max(1, cmplx.Abs(want)) {
+				t.Errorf("%v: eigenvalue %v does not match the 2×2 block of T, want %v, got %v", prefix, j, want, ev)
+			}
+		}
+		if test.evWant != nil {
+			found, _ := containsComplex(test.evWant, ev, tol*math.Max(1, cmplx.Abs(ev)))
+			if !found {
+				t.Errorf("%v: unexpected eigenvalue %v", prefix, ev)
+			}
+		}
+	}
+
+	// Check that the selected eigenvalues lead the Schur form.
+	if selctg != nil {
+		var count int
+		for j := 0; j < n; j++ {
+			sel := selctg(wr[j], wi[j])
+			if wi[j] != 0 && j < n-1 {
+				// Both eigenvalues of a complex conjugate pair are
+				// selected if either one is.
+				sel = sel || selctg(wr[j+1], wi[j+1])
+			}
+			if sel && j > count {
+				t.Errorf("%v: selected eigenvalue %v not at the top left", prefix, j)
+			}
+			if sel {
+				count++
+				if wi[j] != 0 {
+					count++
+				}
+			}
+			if wi[j] != 0 {
+				j++
+			}
+		}
+		if sdim != count {
+			t.Errorf("%v: unexpected sdim, want %v, got %v", prefix, count, sdim)
+		}
+	}
+
+	if !wantvs {
+		return
+	}
+	if !isOrthonormal(vs) {
+		t.Errorf("%v: VS is not orthogonal", prefix)
+	}
+	// Check that VS^T*A*VS == T.
+	if resid := qzResidual(test.a, eye(n, n), eye(n, n), vs, vs, a); resid > tol*float64(n) {
+		t.Errorf("%v: VS^T*A*VS differs from T, resid=%v", prefix, resid)
+	}
+}
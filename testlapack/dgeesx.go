@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dgeesxer interface {
+	Dgeesx(jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, sense lapack.CondJob, n int, a []float64, lda int, wr, wi []float64, vs []float64, ldvs int, work []float64, lwork int, iwork []int, liwork int, bwork []bool) (sdim int, rconde, rcondv float64, ok bool)
+}
+
+func DgeesxTest(t *testing.T, impl Dgeesxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range dgeesCases(rnd) {
+		for _, jobvs := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+			for i, selctg := range dgeesSelectors(rnd) {
+				for _, sense := range []lapack.CondJob{lapack.CondJob(lapack.None), lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+					if selctg == nil && sense != lapack.CondJob(lapack.None) {
+						continue
+					}
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						testDgeesx(t, impl, test, jobvs, selctg, i, sense, 11, wl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgeesx(t *testing.T, impl Dgeesxer, test dgeesTest, jobvs lapack.SchurJob, selctg func(wr, wi float64) bool, sel int, sense lapack.CondJob, extra int, wl worklen) {
+	// rTol allows for roundoff in the computation of rconde which can
+	// make it exceed 1 slightly.
+	const rTol = 1e-14
+
+	n := test.a.Rows
+	wantse := sense == lapack.CondEigenvalues || sense == lapack.CondBoth
+	wantsv := sense == lapack.CondSubspace || sense == lapack.CondBoth
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 3*n)
+		if sense != lapack.CondJob(lapack.None) {
+			lwork = max(lwork, n+n*n/2)
+		}
+	case optimumWork:
+		work := make([]float64, 1)
+		iwork := make([]int, 1)
+		impl.Dgeesx(jobvs, selctg, sense, n, nil, max(1, n), nil, nil, nil, max(1, n), work, -1, iwork, -1, nil)
+		lwork = int(work[0])
+	}
+	liwork := 1
+	if wantsv {
+		liwork = max(1, n*n/4)
+	}
+
+	prefix := fmt.Sprintf("Dgeesx: n=%v, jobvs=%v, selctg=%v, sense=%v, extra=%v, work=%v",
+		n, string(jobvs), sel, string(sense), extra, wl)
+
+	var rconde, rcondv float64
+	testDgees(t, prefix, test, jobvs, selctg, extra, func(a, vs blas64.General, wr, wi []float64, bwork []bool) (int, bool) {
+		work := nanSlice(lwork)
+		iwork := make([]int, liwork)
+		var sdim int
+		var ok bool
+		sdim, rconde, rcondv, ok = impl.Dgeesx(jobvs, selctg, sense, n, a.Data, a.Stride, wr, wi, vs.Data, vs.Stride,
+			work, lwork, iwork, liwork, bwork)
+		return sdim, ok
+	})
+	if n == 0 {
+		return
+	}
+
+	switch {
+	case !wantse && rconde != 0:
+		t.Errorf("%v: unexpected nonzero rconde=%v", prefix, rconde)
+	case wantse && (rconde <= 0 || 1+rTol < rconde):
+		t.Errorf("%v: rconde out of range (0,1], got %v", prefix, rconde)
+	}
+	switch {
+	case !wantsv && rcondv != 0:
+		t.Errorf("%v: unexpected nonzero rcondv=%v", prefix, rcondv)
+	case wantsv && rcondv <= 0:
+		t.Errorf("%v: rcondv not positive, got %v", prefix, rcondv)
+	}
+}
@@ -0,0 +1,274 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgeevxer interface {
+	Dgeevx(balanc lapack.Job, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, sense lapack.CondJob, n int, a []float64, lda int, wr, wi []float64, vl []float64, ldvl int, vr []float64, ldvr int, scale, rconde, rcondv, work []float64, lwork int, iwork []int) (ilo, ihi int, abnrm float64, first int)
+}
+
+func DgeevxTest(t *testing.T, impl Dgeevxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range dgeesCases(rnd) {
+		for _, balanc := range []lapack.Job{lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale} {
+			for _, jobvl := range []lapack.LeftEVJob{lapack.ComputeLeftEV, lapack.None} {
+				for _, jobvr := range []lapack.RightEVJob{lapack.ComputeRightEV, lapack.None} {
+					for _, sense := range []lapack.CondJob{lapack.CondJob(lapack.None), lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+						wantse := sense == lapack.CondEigenvalues || sense == lapack.CondBoth
+						if wantse && (jobvl == lapack.None || jobvr == lapack.None) {
+							continue
+						}
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							testDgeevx(t, impl, test, balanc, jobvl, jobvr, sense, 11, wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgeevx(t *testing.T, impl Dgeevxer, test dgeesTest, balanc lapack.Job, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, sense lapack.CondJob, extra int, wl worklen) {
+	const tol = 1e-13
+
+	n := test.a.Rows
+	wantvl := jobvl == lapack.ComputeLeftEV
+	wantvr := jobvr == lapack.ComputeRightEV
+	wantse := sense == lapack.CondEigenvalues || sense == lapack.CondBoth
+	wantsv := sense == lapack.CondSubspace || sense == lapack.CondBoth
+
+	prefix := fmt.Sprintf("n=%v, balanc=%v, jobvl=%v, jobvr=%v, sense=%v, extra=%v, work=%v",
+		n, string(balanc), string(jobvl), string(jobvr), string(sense), extra, wl)
+
+	a := nanGeneral(n, n, n+extra)
+	copyGeneral(a, test.a)
+	vl := blas64.General{Stride: 1}
+	if wantvl {
+		vl = nanGeneral(n, n, n+extra)
+	}
+	vr := blas64.General{Stride: 1}
+	if wantvr {
+		vr = nanGeneral(n, n, n+extra)
+	}
+	wr := nanSlice(n)
+	wi := nanSlice(n)
+	scale := nanSlice(n)
+	var rconde, rcondv []float64
+	if wantse {
+		rconde = nanSlice(n)
+	}
+	if wantsv {
+		rcondv = nanSlice(n)
+	}
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		if wantvl || wantvr {
+			lwork = 3 * n
+			if wantsv {
+				lwork = max(lwork, n*n+6*n)
+			}
+		} else {
+			lwork = 2 * n
+			if sense != lapack.CondJob(lapack.None) {
+				lwork = max(lwork, n*n+6*n)
+			}
+		}
+		lwork = max(1, lwork)
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dgeevx(balanc, jobvl, jobvr, sense, n, nil, max(1, n), nil, nil, nil, max(1, n), nil, max(1, n),
+			nil, nil, nil, work, -1, nil)
+		lwork = int(work[0])
+	}
+	work := nanSlice(lwork)
+	iwork := make([]int, max(1, 2*n-2))
+
+	ilo, ihi, abnrm, first := impl.Dgeevx(balanc, jobvl, jobvr, sense, n, a.Data, a.Stride, wr, wi,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, scale, rconde, rcondv, work, len(work), iwork)
+	if first > 0 {
+		t.Logf("%v: all eigenvalues haven't been computed, first=%v", prefix, first)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(vl) {
+		t.Errorf("%v: out-of-range write to VL", prefix)
+	}
+	if !generalOutsideAllNaN(vr) {
+		t.Errorf("%v: out-of-range write to VR", prefix)
+	}
+
+	// Check the balancing.
+	if ilo < 0 || ihi < ilo-1 || n <= ihi {
+		t.Errorf("%v: invalid ilo=%v, ihi=%v", prefix, ilo, ihi)
+	}
+	if (balanc == lapack.None || balanc == lapack.Scale) && (ilo != 0 || ihi != n-1) {
+		t.Errorf("%v: unexpected ilo=%v, ihi=%v for balanc=%v", prefix, ilo, ihi, string(balanc))
+	}
+	if balanc == lapack.None {
+		for i, v := range scale {
+			if v != 1 {
+				t.Errorf("%v: unexpected scale[%v]=%v for balanc=None", prefix, i, v)
+			}
+		}
+	}
+	if balanc == lapack.None || balanc == lapack.Permute {
+		// Permutations do not change the 1-norm of A.
+		var anorm float64
+		for j := 0; j < n; j++ {
+			var sum float64
+			for i := 0; i < n; i++ {
+				sum += math.Abs(test.a.Data[i*test.a.Stride+j])
+			}
+			anorm = math.Max(anorm, sum)
+		}
+		if math.Abs(abnrm-anorm) > tol*anorm {
+			t.Errorf("%v: unexpected abnrm, want %v, got %v", prefix, anorm, abnrm)
+		}
+	}
+
+	// Check the eigenvalues.
+	for j := 0; j < n; j++ {
+		if wi[j] == 0 {
+			continue
+		}
+		if wi[j] < 0 || j == n-1 || wr[j] != wr[j+1] || wi[j] != -wi[j+1] {
+			t.Errorf("%v: complex conjugate pair not in order at %v", prefix, j)
+			break
+		}
+		j++
+	}
+	if test.evWant != nil {
+		for j := 0; j < n; j++ {
+			ev := complex(wr[j], wi[j])
+			found, _ := containsComplex(test.evWant, ev, tol*math.Max(1, cmplx.Abs(ev)))
+			if !found {
+				t.Errorf("%v: unexpected eigenvalue %v", prefix, ev)
+			}
+		}
+	}
+
+	// Check the eigenvectors.
+	for j := 0; j < n; j++ {
+		lambda := complex(wr[j], wi[j])
+		for _, v := range []struct {
+			want bool
+			left bool
+			m    blas64.General
+		}{
+			{wantvl, true, vl},
+			{wantvr, false, vr},
+		} {
+			if !v.want {
+				continue
+			}
+			var xRe, xIm []float64
+			switch {
+			case wi[j] == 0:
+				xRe = columnOf(v.m, j)
+			case wi[j] > 0:
+				xRe = columnOf(v.m, j)
+				xIm = columnOf(v.m, j+1)
+			default:
+				xRe = columnOf(v.m, j-1)
+				xIm = columnOf(v.m, j)
+				floats.Scale(-1, xIm)
+			}
+			norm := floats.Norm(xRe, 2)
+			if xIm != nil {
+				norm = math.Hypot(norm, floats.Norm(xIm, 2))
+			}
+			if math.Abs(norm-1) > tol {
+				t.Errorf("%v: eigenvector %v not normalized, left=%v, norm=%v", prefix, j, v.left, norm)
+			}
+			var isEV bool
+			if v.left {
+				isEV = isLeftEigenvectorOf(test.a, xRe, xIm, lambda, tol*math.Max(1, abnrm))
+			} else {
+				isEV = isRightEigenvectorOf(test.a, xRe, xIm, lambda, tol*math.Max(1, abnrm))
+			}
+			if !isEV {
+				t.Errorf("%v: unexpected eigenvector %v, left=%v", prefix, j, v.left)
+			}
+		}
+	}
+
+	// Check the reciprocal condition numbers.
+	for j := 0; j < n; j++ {
+		pair := wi[j] > 0
+		if wantse {
+			// rconde[j] is zero for a defective eigenvalue.
+			if rconde[j] < 0 || rconde[j] > 1+tol {
+				t.Errorf("%v: rconde[%v]=%v out of range [0,1]", prefix, j, rconde[j])
+			}
+			if balanc == lapack.None || balanc == lapack.Permute {
+				// Without scaling, the reciprocal condition
+				// number of the j-th eigenvalue is |u_j^H*v_j|
+				// for the computed normalized eigenvectors.
+				uRe, vRe := columnOf(vl, j), columnOf(vr, j)
+				var want float64
+				if !pair {
+					want = math.Abs(floats.Dot(uRe, vRe))
+				} else {
+					uIm, vIm := columnOf(vl, j+1), columnOf(vr, j+1)
+					want = math.Hypot(floats.Dot(uRe, vRe)+floats.Dot(uIm, vIm), floats.Dot(uRe, vIm)-floats.Dot(uIm, vRe))
+				}
+				if math.Abs(rconde[j]-want) > tol {
+					t.Errorf("%v: unexpected rconde[%v], want %v, got %v", prefix, j, want, rconde[j])
+				}
+			}
+			if pair && rconde[j+1] != rconde[j] {
+				t.Errorf("%v: rconde[%v] and rconde[%v] of a complex pair differ", prefix, j, j+1)
+			}
+		}
+		if wantsv {
+			if rcondv[j] <= 0 || math.IsNaN(rcondv[j]) {
+				t.Errorf("%v: rcondv[%v]=%v is not positive", prefix, j, rcondv[j])
+			}
+			if pair && rcondv[j+1] != rcondv[j] {
+				t.Errorf("%v: rcondv[%v] and rcondv[%v] of a complex pair differ", prefix, j, j+1)
+			}
+		}
+		if pair {
+			j++
+		}
+	}
+
+	if wantvl || wantvr || sense != lapack.CondJob(lapack.None) {
+		// Check that computing only the eigenvalues gives the same
+		// result.
+		copyGeneral(a, test.a)
+		wrWant := make([]float64, n)
+		wiWant := make([]float64, n)
+		_, _, _, first = impl.Dgeevx(balanc, lapack.None, lapack.None, lapack.CondJob(lapack.None), n, a.Data, a.Stride, wrWant, wiWant,
+			nil, 1, nil, 1, scale, nil, nil, work, len(work), nil)
+		if first > 0 {
+			t.Logf("%v: all eigenvalues haven't been computed when computing eigenvalues only", prefix)
+			return
+		}
+		for j := 0; j < n; j++ {
+			got := complex(wr[j], wi[j])
+			want := complex(wrWant[j], wiWant[j])
+			if cmplx.Abs(got-want) > tol*math.Max(1, cmplx.Abs(want)) {
+				t.Errorf("%v: eigenvalue %v differs when computing eigenvalues only", prefix, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,316 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgejsver interface {
+	Dgejsv(joba lapack.SVDAccuracy, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, restrictRange, transpose, perturb bool, m, n int, a []float64, lda int, sva, u []float64, ldu int, v []float64, ldv int, work []float64, lwork int, iwork []int) (ok bool)
+	Dgesvder
+}
+
+func DgejsvTest(t *testing.T, impl Dgejsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{1, 1, 0},
+		{5, 1, 0},
+
+		{5, 5, 0},
+		{9, 5, 0},
+		{5, 5, 10},
+		{9, 5, 10},
+
+		{30, 30, 0},
+		{40, 30, 0},
+		{100, 30, 40},
+
+		// The triangular factor is large enough to be pre-processed in
+		// Dgesvj.
+		{70, 70, 0},
+	} {
+		for _, joba := range []lapack.SVDAccuracy{
+			lapack.ColumnAccuracy,
+			lapack.ColumnAccuracyCond,
+			lapack.RowColumnAccuracy,
+			lapack.RowColumnAccuracyCond,
+			lapack.AbsoluteAccuracy,
+			lapack.RankRevealing,
+		} {
+			for _, job := range []struct {
+				jobu lapack.LeftSVJob
+				jobv lapack.RightSVJob
+			}{
+				{lapack.None, lapack.None},
+				{lapack.ComputeLeftSV, lapack.None},
+				{lapack.ComputeAllLeftSV, lapack.None},
+				{lapack.ComputeLeftSV, lapack.RightSVWork},
+				{lapack.None, lapack.ComputeRightSV},
+				{lapack.LeftSVWork, lapack.ComputeRightSV},
+				{lapack.ComputeLeftSV, lapack.ComputeRightSV},
+				{lapack.ComputeAllLeftSV, lapack.ComputeRightSV},
+				{lapack.ComputeLeftSV, lapack.ComputeRightSVJacobi},
+				{lapack.ComputeAllLeftSV, lapack.ComputeRightSVJacobi},
+			} {
+				for _, transpose := range []bool{false, true} {
+					for _, perturb := range []bool{false, true} {
+						lda := test.lda
+						if lda == 0 {
+							lda = test.n
+						}
+						a := randomGeneral(test.m, test.n, lda, rnd)
+						testDgejsv(t, impl, joba, job.jobu, job.jobv, false, transpose, perturb, a, test.n, "random")
+					}
+				}
+			}
+		}
+	}
+
+	// Check matrices that exercise the special cases of the algorithm.
+	for _, test := range []struct {
+		m, n int
+	}{
+		{10, 10},
+		{20, 10},
+		{40, 40},
+	} {
+		m := test.m
+		n := test.n
+		for _, joba := range []lapack.SVDAccuracy{
+			lapack.ColumnAccuracy,
+			lapack.RowColumnAccuracyCond,
+			lapack.AbsoluteAccuracy,
+			lapack.RankRevealing,
+		} {
+			for _, job := range []struct {
+				jobu lapack.LeftSVJob
+				jobv lapack.RightSVJob
+			}{
+				{lapack.None, lapack.None},
+				{lapack.ComputeLeftSV, lapack.None},
+				{lapack.None, lapack.ComputeRightSV},
+				{lapack.ComputeAllLeftSV, lapack.ComputeRightSV},
+				{lapack.ComputeLeftSV, lapack.ComputeRightSVJacobi},
+			} {
+				for _, restrictRange := range []bool{false, true} {
+					// Rank deficient matrix with randomly placed
+					// zero columns.
+					rank := n / 2
+					a := randomGeneral(m, n, n, rnd)
+					for _, j := range rnd.Perm(n)[rank:] {
+						for i := 0; i < m; i++ {
+							a.Data[i*n+j] = 0
+						}
+					}
+					testDgejsv(t, impl, joba, job.jobu, job.jobv, restrictRange, false, false, a, rank, "rank deficient")
+
+					// Matrix with orthogonal columns of very
+					// different norms.
+					q := randomOrthogonal(m, rnd)
+					a = zeros(m, n, n)
+					copyGeneral(a, q)
+					d := gradedScaling(n, 1e10, rnd)
+					for i := 0; i < m; i++ {
+						for j := 0; j < n; j++ {
+							a.Data[i*n+j] *= d[j]
+						}
+					}
+					testDgejsv(t, impl, joba, job.jobu, job.jobv, restrictRange, false, false, a, n, "orthogonal columns")
+
+					// Graded matrix with a large dynamic range
+					// so that the triangular factors are
+					// perturbed.
+					a = gradedGeneral(m, n, n, 1e30, 1e100, rnd)
+					testDgejsv(t, impl, joba, job.jobu, job.jobv, restrictRange, false, true, a, -1, "graded")
+				}
+			}
+		}
+	}
+
+	// Check that the singular values of graded matrices are computed to
+	// high relative accuracy.
+	for _, test := range []struct {
+		m, n    int
+		rowCond float64
+		colCond float64
+	}{
+		{5, 5, 1, 1e10},
+		{8, 5, 1, 1e20},
+		{10, 10, 1, 1e30},
+		{12, 8, 1, 1e100},
+		{5, 5, 1e10, 1e10},
+		{8, 5, 1e20, 1e20},
+		{10, 10, 1e30, 1},
+		{12, 8, 1e50, 1e50},
+	} {
+		joba := lapack.ColumnAccuracy
+		if test.rowCond > 1 {
+			joba = lapack.RowColumnAccuracy
+		}
+		for _, jobu := range []lapack.LeftSVJob{lapack.None, lapack.ComputeLeftSV} {
+			for cas := 0; cas < 10; cas++ {
+				testDgejsvGraded(t, impl, joba, jobu, test.m, test.n, test.rowCond, test.colCond, rnd)
+			}
+		}
+	}
+}
+
+func testDgejsv(t *testing.T, impl Dgejsver, joba lapack.SVDAccuracy, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, restrictRange, transpose, perturb bool, a blas64.General, wantRank int, kind string) {
+	m := a.Rows
+	n := a.Cols
+	lda := a.Stride
+	aCopy := cloneGeneral(a)
+
+	lsvec := jobu == lapack.ComputeLeftSV || jobu == lapack.ComputeAllLeftSV
+	rsvec := jobv == lapack.ComputeRightSV || jobv == lapack.ComputeRightSVJacobi
+	var ucol int
+	switch jobu {
+	case lapack.ComputeLeftSV:
+		ucol = n
+	case lapack.ComputeAllLeftSV:
+		ucol = m
+	case lapack.LeftSVWork:
+		if m == n {
+			ucol = n
+		}
+	}
+	ldu := max(1, ucol)
+	u := nanSlice(m * ldu)
+	ldv := max(1, n)
+	var v []float64
+	if rsvec || (jobv == lapack.RightSVWork && m == n) {
+		v = nanSlice(n * ldv)
+	}
+
+	sva := nanSlice(n)
+	iwork := make([]int, m+3*n)
+	work := make([]float64, 1)
+	impl.Dgejsv(joba, jobu, jobv, restrictRange, transpose, perturb, m, n, a.Data, a.Stride, sva, u, ldu, v, ldv, work, -1, iwork)
+	lwork := int(work[0])
+	work = nanSlice(lwork)
+
+	ok := impl.Dgejsv(joba, jobu, jobv, restrictRange, transpose, perturb, m, n, a.Data, a.Stride, sva, u, ldu, v, ldv, work, lwork, iwork)
+	errStr := fmt.Sprintf("%s matrix, joba = %c, jobu = %c, jobv = %c, restrictRange = %v, transpose = %v, perturb = %v, m = %v, n = %v, lda = %v",
+		kind, joba, jobu, jobv, restrictRange, transpose, perturb, m, n, lda)
+	if !ok {
+		t.Errorf("Dgejsv did not converge: %s", errStr)
+		return
+	}
+	if wantRank >= 0 && iwork[0] != wantRank {
+		t.Errorf("Unexpected numerical rank: %s, got %v, want %v", errStr, iwork[0], wantRank)
+	}
+	if iwork[2] != 0 {
+		t.Errorf("Unexpected warning: %s", errStr)
+	}
+
+	s := make([]float64, n)
+	for i, sv := range sva {
+		s[i] = work[0] / work[1] * sv
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+		t.Errorf("Singular values not sorted: %s", errStr)
+	}
+
+	// Compare the singular values with those computed by Dgesvd.
+	sAns := make([]float64, n)
+	aSvd := cloneGeneral(aCopy)
+	work = make([]float64, 1)
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, len(work))
+	if !floats.EqualApprox(s, sAns, 1e-10) {
+		t.Errorf("Singular value mismatch with Dgesvd: %s", errStr)
+	}
+
+	bi := blas64.Implementation()
+	if lsvec {
+		if !hasOrthonormalColumns(m, ucol, u, ldu) || floats.HasNaN(u) {
+			t.Errorf("U does not have orthonormal columns: %s", errStr)
+		}
+		// Check that ||A^T * u_i|| = sigma_i.
+		x := make([]float64, n)
+		for i := 0; i < n; i++ {
+			bi.Dgemv(blas.Trans, m, n, 1, aCopy.Data, aCopy.Stride, u[i:], ldu, 0, x, 1)
+			if math.Abs(floats.Norm(x, 2)-s[i]) > 1e-10*math.Max(1, s[i]) {
+				t.Errorf("||A^T * u_%v|| != sigma_%v: %s", i, i, errStr)
+			}
+		}
+	}
+	if rsvec {
+		if !isOrthonormal(blas64.General{Rows: n, Cols: n, Stride: ldv, Data: v}) {
+			t.Errorf("V is not orthogonal: %s", errStr)
+		}
+		// Check that ||A * v_i|| = sigma_i.
+		x := make([]float64, m)
+		for i := 0; i < n; i++ {
+			bi.Dgemv(blas.NoTrans, m, n, 1, aCopy.Data, aCopy.Stride, v[i:], ldv, 0, x, 1)
+			if math.Abs(floats.Norm(x, 2)-s[i]) > 1e-10*math.Max(1, s[i]) {
+				t.Errorf("||A * v_%v|| != sigma_%v: %s", i, i, errStr)
+			}
+		}
+	}
+	if !lsvec || !rsvec {
+		return
+	}
+
+	// Check that A = U * Sigma * V^T.
+	us := blas64.General{Rows: m, Cols: n, Stride: ldu, Data: make([]float64, len(u))}
+	copy(us.Data, u)
+	for j := 0; j < n; j++ {
+		bi.Dscal(m, s[j], us.Data[j:], us.Stride)
+	}
+	usvt := zeros(m, n, lda)
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, us, blas64.General{Rows: n, Cols: n, Stride: ldv, Data: v}, 0, usvt)
+	if !equalApproxGeneral(usvt, aCopy, 1e-10) {
+		t.Errorf("A != U * Sigma * V^T: %s", errStr)
+	}
+}
+
+// testDgejsvGraded checks the relative accuracy of the singular values of
+// the m×n matrix A = D1*B*D2 where B has random elements and D1 and D2 are
+// diagonal matrices with condition numbers rowCond and colCond.
+func testDgejsvGraded(t *testing.T, impl Dgejsver, joba lapack.SVDAccuracy, jobu lapack.LeftSVJob, m, n int, rowCond, colCond float64, rnd *rand.Rand) {
+	lda := n
+	a := gradedGeneral(m, n, lda, rowCond, colCond, rnd)
+	want := svdHighPrecision(m, n, a.Data, lda)
+
+	jobv := lapack.RightSVJob(lapack.None)
+	var u, v []float64
+	if jobu == lapack.ComputeLeftSV {
+		jobv = lapack.ComputeRightSV
+		u = nanSlice(m * n)
+		v = nanSlice(n * n)
+	}
+	sva := nanSlice(n)
+	iwork := make([]int, m+3*n)
+	work := make([]float64, 1)
+	impl.Dgejsv(joba, jobu, jobv, false, false, false, m, n, a.Data, lda, sva, u, n, v, n, work, -1, iwork)
+	lwork := int(work[0])
+	work = nanSlice(lwork)
+	ok := impl.Dgejsv(joba, jobu, jobv, false, false, false, m, n, a.Data, lda, sva, u, n, v, n, work, lwork, iwork)
+	errStr := fmt.Sprintf("joba = %c, jobu = %c, m = %v, n = %v, rowCond = %v, colCond = %v", joba, jobu, m, n, rowCond, colCond)
+	if !ok {
+		t.Errorf("Dgejsv did not converge: %s", errStr)
+		return
+	}
+	for i := range sva {
+		got := work[0] / work[1] * sva[i]
+		if math.Abs(got-want[i]) > 1e-12*want[i] {
+			t.Errorf("Singular value %v not computed to high relative accuracy: %s, got %v, want %v", i, errStr, got, want[i])
+		}
+	}
+}
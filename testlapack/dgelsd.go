@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+)
+
+type Dgelsder interface {
+	Dgelsd(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int, iwork []int) (rank int, ok bool)
+	Dgesvder
+}
+
+func DgelsdTest(t *testing.T, impl Dgelsder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldb int
+	}{
+		{0, 0, 0, 0},
+		{0, 5, 0, 0},
+		{5, 0, 0, 0},
+		{1, 1, 0, 0},
+		{1, 5, 0, 0},
+		{5, 1, 0, 0},
+
+		{5, 5, 0, 0},
+		{5, 9, 0, 0},
+		{9, 5, 0, 0},
+		{5, 5, 10, 11},
+		{5, 9, 10, 11},
+		{9, 5, 10, 11},
+
+		// The bidiagonal problem is small enough to be solved directly
+		// by Dlasdq.
+		{20, 20, 0, 0},
+		{20, 30, 0, 0},
+		{30, 20, 0, 0},
+		{20, 30, 40, 50},
+		{30, 20, 40, 50},
+
+		// The bidiagonal problem is solved by divide and conquer.
+		{60, 60, 0, 0},
+		{60, 80, 0, 0},
+		{80, 60, 0, 0},
+		{60, 80, 90, 100},
+		{80, 60, 90, 100},
+		{120, 120, 0, 0},
+
+		// m and n differ enough that A is first reduced by a QR or LQ
+		// decomposition.
+		{30, 100, 0, 0},
+		{100, 30, 0, 0},
+		{60, 150, 160, 170},
+		{150, 60, 70, 80},
+	} {
+		for _, nrhs := range []int{1, 4} {
+			for _, rank := range []int{-1, 0, 1} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					testDgelsd(t, impl, test.m, test.n, nrhs, test.lda, test.ldb, rank, wl, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgelsd(t *testing.T, impl Dgelsder, m, n, nrhs, lda, ldb, rankKind int, wl worklen, rnd *rand.Rand) {
+	const smlsiz = 25
+	minmn := min(m, n)
+	a, b, rcond, wantRank := lstsqProblem(m, n, nrhs, lda, ldb, rankKind, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	s := nanSlice(minmn)
+	mn := max(1, minmn)
+	nlvl := max(0, int(math.Log2(float64(mn)/float64(smlsiz+1)))+1)
+	liwork := 3*mn*nlvl + 11*mn
+
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dgelsd(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, -1, iwork)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("a changed during call to get work length")
+	}
+	if iwork[0] != liwork {
+		t.Errorf("m = %v, n = %v, nrhs = %v: unexpected minimum iwork length, want %v, got %v", m, n, nrhs, liwork, iwork[0])
+	}
+	iwork = make([]int, iwork[0])
+	var lwork int
+	switch wl {
+	case minimumWork:
+		wlalsd := 9*minmn + 2*minmn*smlsiz + 8*minmn*nlvl + minmn*nrhs + (smlsiz+1)*(smlsiz+1)
+		lwork = 3*minmn + max(max(m, nrhs), wlalsd)
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	rank, ok := impl.Dgelsd(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, lwork, iwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, rank kind = %v, wl = %v", m, n, nrhs, a.Stride, b.Stride, rankKind, wl)
+	if !ok {
+		t.Errorf("Dgelsd did not complete successfully: %s", errStr)
+		return
+	}
+	checkLstsqSolution(t, impl, errStr, aCopy, bCopy, b, s, rank, wantRank)
+}
@@ -0,0 +1,230 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgelsser interface {
+	Dgelss(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, s []float64, rcond float64, work []float64, lwork int) (rank int, ok bool)
+	Dgesvder
+}
+
+func DgelssTest(t *testing.T, impl Dgelsser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldb int
+	}{
+		{0, 0, 0, 0},
+		{0, 5, 0, 0},
+		{5, 0, 0, 0},
+		{1, 1, 0, 0},
+		{1, 5, 0, 0},
+		{5, 1, 0, 0},
+
+		{5, 5, 0, 0},
+		{5, 9, 0, 0},
+		{9, 5, 0, 0},
+		{5, 5, 10, 11},
+		{5, 9, 10, 11},
+		{9, 5, 10, 11},
+
+		// m and n are close so that A is bidiagonalized directly.
+		{30, 30, 0, 0},
+		{30, 40, 0, 0},
+		{40, 30, 0, 0},
+		{30, 40, 50, 60},
+		{40, 30, 50, 60},
+
+		// m and n differ enough that A is first reduced by a QR or LQ
+		// decomposition.
+		{30, 100, 0, 0},
+		{100, 30, 0, 0},
+		{30, 100, 110, 120},
+		{100, 30, 40, 50},
+	} {
+		for _, nrhs := range []int{1, 4} {
+			for _, rank := range []int{-1, 0, 1} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					testDgelss(t, impl, test.m, test.n, nrhs, test.lda, test.ldb, rank, wl, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgelss(t *testing.T, impl Dgelsser, m, n, nrhs, lda, ldb, rankKind int, wl worklen, rnd *rand.Rand) {
+	minmn := min(m, n)
+	a, b, rcond, wantRank := lstsqProblem(m, n, nrhs, lda, ldb, rankKind, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	s := nanSlice(minmn)
+
+	work := make([]float64, 1)
+	impl.Dgelss(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, -1)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("a changed during call to get work length")
+	}
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 3*minmn+max(2*minmn, max(max(m, n), nrhs)))
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	rank, ok := impl.Dgelss(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, s, rcond, work, lwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, rank kind = %v, wl = %v", m, n, nrhs, a.Stride, b.Stride, rankKind, wl)
+	if !ok {
+		t.Errorf("Dgelss did not complete successfully: %s", errStr)
+		return
+	}
+	checkLstsqSolution(t, impl, errStr, aCopy, bCopy, b, s, rank, wantRank)
+}
+
+// lstsqProblem returns a random m×n matrix A and a random max(m,n)×nrhs
+// right hand side matrix B of a least squares problem, together with the
+// rcond parameter for the solvers and the expected effective rank of A.
+// If rankKind is -1, A has full rank and rcond is negative. If rankKind is 0,
+// A has full rank and if it is 1, about a third of the singular values of A
+// are zero or negligible with respect to rcond.
+func lstsqProblem(m, n, nrhs, lda, ldb, rankKind int, rnd *rand.Rand) (a, b blas64.General, rcond float64, rank int) {
+	minmn := min(m, n)
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+
+	// Generate the singular values. The non-negligible ones lie in [1, 10]
+	// so that the solution is well conditioned.
+	d := make([]float64, minmn)
+	for i := range d {
+		d[i] = 1 + 9*rnd.Float64()
+	}
+	rcond = 1e-8
+	switch rankKind {
+	case -1:
+		rcond = -1
+	case 1:
+		for i := range d {
+			switch i % 3 {
+			case 1:
+				d[i] = 0
+			case 2:
+				d[i] = 1e-12 * d[i]
+			}
+		}
+	}
+	for _, v := range d {
+		if v >= 1 {
+			rank++
+		}
+	}
+
+	// Fill all of a with random numbers so that it can be compared after
+	// the workspace query.
+	a = blas64.General{Rows: m, Cols: n, Stride: lda, Data: randomSlice(m*lda, rnd)}
+	if minmn > 0 {
+		Dlagge(m, n, max(0, m-1), max(0, n-1), d, a.Data, a.Stride, rnd, make([]float64, m+n))
+	}
+	b = blas64.General{Rows: max(m, n), Cols: nrhs, Stride: ldb, Data: nanSlice(max(m, n) * ldb)}
+	for i := 0; i < m; i++ {
+		for j := 0; j < nrhs; j++ {
+			b.Data[i*b.Stride+j] = rnd.NormFloat64()
+		}
+	}
+	return a, b, rcond, rank
+}
+
+// checkLstsqSolution checks that the leading n×nrhs part of x contains the
+// minimum-norm solution of the least squares problem given by the m×n matrix
+// a and the right hand sides in the leading m×nrhs part of b. The solution is
+// compared with one computed from the singular value decomposition of a by
+// Dgesvd. The effective rank and, if s is not nil, the singular values s are
+// also checked.
+func checkLstsqSolution(t *testing.T, impl Dgesvder, errStr string, a, b, x blas64.General, s []float64, rank, wantRank int) {
+	m := a.Rows
+	n := a.Cols
+	nrhs := b.Cols
+	minmn := min(m, n)
+	if rank != wantRank {
+		t.Errorf("Unexpected rank: got %v, want %v: %s", rank, wantRank, errStr)
+	}
+	if minmn == 0 {
+		return
+	}
+
+	// Compute the full singular value decomposition of A.
+	aSvd := cloneGeneral(a)
+	sAns := make([]float64, minmn)
+	u := blas64.General{Rows: m, Cols: m, Stride: m, Data: make([]float64, m*m)}
+	vt := blas64.General{Rows: n, Cols: n, Stride: n, Data: make([]float64, n*n)}
+	work := make([]float64, 1)
+	impl.Dgesvd(lapack.SVDAll, lapack.SVDAll, m, n, aSvd.Data, aSvd.Stride, sAns, u.Data, u.Stride, vt.Data, vt.Stride, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgesvd(lapack.SVDAll, lapack.SVDAll, m, n, aSvd.Data, aSvd.Stride, sAns, u.Data, u.Stride, vt.Data, vt.Stride, work, len(work))
+	if s != nil && !floats.EqualApprox(s[:minmn], sAns, 1e-10) {
+		t.Errorf("Singular value mismatch with Dgesvd: %s", errStr)
+	}
+
+	// Compute the minimum-norm solution
+	//  X = V * Σ_r^+ * U^T * B,
+	// where Σ_r contains the wantRank largest singular values.
+	bm := blas64.General{Rows: m, Cols: nrhs, Stride: b.Stride, Data: b.Data}
+	utb := zeros(m, nrhs, nrhs)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, u, bm, 0, utb)
+	y := zeros(n, nrhs, nrhs)
+	for i := 0; i < wantRank; i++ {
+		for j := 0; j < nrhs; j++ {
+			y.Data[i*y.Stride+j] = utb.Data[i*utb.Stride+j] / sAns[i]
+		}
+	}
+	want := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, vt, y, 0, want)
+
+	got := blas64.General{Rows: n, Cols: nrhs, Stride: x.Stride, Data: x.Data}
+	var maxDiff, maxWant float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			maxDiff = math.Max(maxDiff, math.Abs(got.Data[i*got.Stride+j]-want.Data[i*want.Stride+j]))
+			maxWant = math.Max(maxWant, math.Abs(want.Data[i*want.Stride+j]))
+		}
+	}
+	if maxDiff > 1e-10*math.Max(1, maxWant) {
+		t.Errorf("Solution mismatch: max difference = %v: %s", maxDiff, errStr)
+	}
+
+	if m <= n || rank != n {
+		return
+	}
+	// If A has full column rank, rows n to m-1 of B contain the residual
+	// components.
+	r := cloneGeneral(bm)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, a, got, 1, r)
+	for j := 0; j < nrhs; j++ {
+		var rss, rssB float64
+		for i := 0; i < m; i++ {
+			rss += r.Data[i*r.Stride+j] * r.Data[i*r.Stride+j]
+		}
+		for i := n; i < m; i++ {
+			rssB += x.Data[i*x.Stride+j] * x.Data[i*x.Stride+j]
+		}
+		if math.Abs(rss-rssB) > 1e-10*math.Max(1, rss) {
+			t.Errorf("Residual sum of squares mismatch in column %v: got %v, want %v: %s", j, rssB, rss, errStr)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+)
+
+type Dgelsyer interface {
+	Dgelsy(m, n, nrhs int, a []float64, lda int, b []float64, ldb int, jpvt []int, rcond float64, work []float64, lwork int) (rank int)
+	Dgesvder
+}
+
+func DgelsyTest(t *testing.T, impl Dgelsyer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldb int
+	}{
+		{0, 0, 0, 0},
+		{0, 5, 0, 0},
+		{5, 0, 0, 0},
+		{1, 1, 0, 0},
+		{1, 5, 0, 0},
+		{5, 1, 0, 0},
+
+		{5, 5, 0, 0},
+		{5, 9, 0, 0},
+		{9, 5, 0, 0},
+		{5, 5, 10, 11},
+		{5, 9, 10, 11},
+		{9, 5, 10, 11},
+
+		{30, 30, 0, 0},
+		{30, 100, 0, 0},
+		{100, 30, 0, 0},
+		{30, 100, 110, 120},
+		{100, 30, 40, 50},
+	} {
+		for _, nrhs := range []int{1, 4} {
+			for _, rank := range []int{-1, 0, 1} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					testDgelsy(t, impl, test.m, test.n, nrhs, test.lda, test.ldb, rank, false, wl, rnd)
+					if rank != 1 {
+						// Leading columns are only meaningful
+						// for matrices of full rank.
+						testDgelsy(t, impl, test.m, test.n, nrhs, test.lda, test.ldb, rank, true, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgelsy(t *testing.T, impl Dgelsyer, m, n, nrhs, lda, ldb, rankKind int, leading bool, wl worklen, rnd *rand.Rand) {
+	minmn := min(m, n)
+	a, b, rcond, wantRank := lstsqProblem(m, n, nrhs, lda, ldb, rankKind, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	// Mark every other column as leading if requested, otherwise all
+	// columns are free.
+	jpvt := make([]int, n)
+	for j := range jpvt {
+		jpvt[j] = -1
+		if leading && j%2 == 0 {
+			jpvt[j] = 0
+		}
+	}
+
+	work := make([]float64, 1)
+	impl.Dgelsy(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, jpvt, rcond, work, -1)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("a changed during call to get work length")
+	}
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = 1
+		if minmn > 0 {
+			lwork = max(minmn+3*n+1, 2*minmn+nrhs)
+		}
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	rank := impl.Dgelsy(m, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, jpvt, rcond, work, lwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, rank kind = %v, leading = %v, wl = %v",
+		m, n, nrhs, a.Stride, b.Stride, rankKind, leading, wl)
+
+	if minmn == 0 {
+		return
+	}
+
+	// Check that jpvt is a permutation.
+	seen := make([]bool, n)
+	for _, p := range jpvt {
+		if p < 0 || n <= p || seen[p] {
+			t.Errorf("jpvt is not a permutation: %s", errStr)
+			return
+		}
+		seen[p] = true
+	}
+	checkLstsqSolution(t, impl, errStr, aCopy, bCopy, b, nil, rank, wantRank)
+}
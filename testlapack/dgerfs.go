@@ -0,0 +1,142 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dgerfser interface {
+	Dgerfs(trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dgetrser
+}
+
+func DgerfsTest(t *testing.T, impl Dgerfser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{0, 0, 0, 0},
+			{0, 3, 0, 0},
+			{3, 0, 0, 0},
+			{1, 1, 0, 0},
+			{1, 3, 0, 0},
+			{5, 1, 0, 0},
+			{5, 3, 0, 0},
+			{5, 3, 10, 11},
+			{20, 4, 0, 0},
+			{50, 2, 60, 5},
+		} {
+			testDgerfs(t, impl, trans, test.n, test.nrhs, test.lda, test.ldb, rnd)
+		}
+	}
+}
+
+func testDgerfs(t *testing.T, impl Dgerfser, trans blas.Transpose, n, nrhs, lda, ldb int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	a := blas64.General{Rows: n, Cols: n, Stride: lda, Data: randomSlice(n*lda, rnd)}
+	aCopy := cloneGeneral(a)
+	xWant := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	// Compute the LU factorization of A and a solution that is perturbed
+	// so that the refinement has something to do.
+	af := cloneGeneral(a)
+	ipiv := make([]int, n)
+	impl.Dgetrf(n, n, af.Data, af.Stride, ipiv)
+	x := cloneGeneral(b)
+	impl.Dgetrs(trans, n, nrhs, af.Data, af.Stride, ipiv, x.Data, x.Stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			x.Data[i*x.Stride+j] *= 1 + 1e-8*rnd.NormFloat64()
+		}
+	}
+
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(3 * n)
+	iwork := make([]int, n)
+	impl.Dgerfs(trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	errStr := fmt.Sprintf("trans = %v, n = %v, nrhs = %v, lda = %v, ldb = %v", trans, n, nrhs, lda, ldb)
+
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if !floats.Equal(b.Data, bCopy.Data) {
+		t.Errorf("B modified: %s", errStr)
+	}
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			if ferr[j] != 0 || berr[j] != 0 {
+				t.Errorf("ferr and berr not zero for n == 0: %s", errStr)
+				break
+			}
+		}
+		return
+	}
+	checkErrorBounds(t, errStr, trans, aCopy, bCopy, x, xWant, ferr, berr)
+}
+
+// checkErrorBounds checks that the backward errors in berr are small and
+// correspond to the solution X of op(A)*X = B, and that the forward error
+// bounds in ferr bound the true errors of X with respect to xWant.
+func checkErrorBounds(t *testing.T, errStr string, trans blas.Transpose, a, b, x, xWant blas64.General, ferr, berr []float64) {
+	const eps = 1.0 / (1 << 53)
+	n := a.Rows
+	for j := 0; j < b.Cols; j++ {
+		// Compute the componentwise relative backward error
+		//  max_i |B - op(A)*X|_i / (|op(A)|*|X| + |B|)_i.
+		var bwd float64
+		for i := 0; i < n; i++ {
+			r := b.Data[i*b.Stride+j]
+			s := math.Abs(r)
+			for k := 0; k < n; k++ {
+				aik := a.Data[i*a.Stride+k]
+				if trans == blas.Trans {
+					aik = a.Data[k*a.Stride+i]
+				}
+				xk := x.Data[k*x.Stride+j]
+				r -= aik * xk
+				s += math.Abs(aik) * math.Abs(xk)
+			}
+			if s != 0 {
+				bwd = math.Max(bwd, math.Abs(r)/s)
+			}
+		}
+		if berr[j] > 10*eps || bwd > 10*eps {
+			t.Errorf("Backward error too large for column %v: berr = %v, computed %v: %s", j, berr[j], bwd, errStr)
+		}
+
+		// Check that the forward error bound holds.
+		var fwd, xmax float64
+		for i := 0; i < n; i++ {
+			fwd = math.Max(fwd, math.Abs(x.Data[i*x.Stride+j]-xWant.Data[i*xWant.Stride+j]))
+			xmax = math.Max(xmax, math.Abs(x.Data[i*x.Stride+j]))
+		}
+		if xmax != 0 {
+			fwd /= xmax
+		}
+		if fwd > ferr[j] {
+			t.Errorf("Forward error bound does not hold for column %v: error = %v, ferr = %v: %s", j, fwd, ferr[j], errStr)
+		}
+	}
+}
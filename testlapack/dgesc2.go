@@ -0,0 +1,110 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dgesc2er interface {
+	Dgesc2(n int, a []float64, lda int, rhs []float64, ipiv, jpiv []int) (scale float64)
+	Dgetc2er
+}
+
+func Dgesc2Test(t *testing.T, impl Dgesc2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 8, 10, 20} {
+		for _, extra := range []int{0, 5} {
+			for _, overflow := range []bool{false, true} {
+				for cas := 0; cas < 10; cas++ {
+					testDgesc2(t, impl, n, extra, overflow, rnd)
+				}
+			}
+		}
+	}
+}
+
+// testDgesc2 checks the solution computed by Dgesc2 for a diagonally dominant
+// matrix A and a known solution x. If overflow is true, A is tiny and the
+// right hand side huge, so that the solution must be scaled down to avoid
+// overflow.
+func testDgesc2(t *testing.T, impl Dgesc2er, n, extra int, overflow bool, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	prefix := fmt.Sprintf("Case n=%v,extra=%v,overflow=%v:", n, extra, overflow)
+
+	a := randomGeneral(n, n, n+extra, rnd)
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] += float64(2 * n)
+	}
+	x := randomGeneral(n, 1, 1, rnd)
+	aScale, xScale := 1.0, 1.0
+	if overflow {
+		aScale, xScale = 1e-10, 1e300
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			a.Data[i*a.Stride+j] *= aScale
+		}
+	}
+	// Compute rhs = A*x with x scaled so that rhs does not overflow but
+	// the solution of A*x = rhs would.
+	rhs := zeros(n, 1, 1)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, xScale, a, x, 0, rhs)
+	aCopy := cloneGeneral(a)
+
+	ipiv := make([]int, n)
+	jpiv := make([]int, n)
+	if !impl.Dgetc2(n, a.Data, a.Stride, ipiv, jpiv) {
+		t.Errorf("%v unexpected perturbation of the factorization of a non-singular matrix", prefix)
+		return
+	}
+
+	sol := cloneGeneral(rhs)
+	scale := impl.Dgesc2(n, a.Data, a.Stride, sol.Data, ipiv, jpiv)
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v out-of-range write to A", prefix)
+	}
+
+	if overflow {
+		if scale <= 0 || 1e-200 < scale {
+			t.Errorf("%v solution not scaled down, scale=%v", prefix, scale)
+			return
+		}
+	} else if scale != 1 {
+		t.Errorf("%v unexpected scaling of a representable solution, scale=%v", prefix, scale)
+	}
+	for i, v := range sol.Data {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			t.Errorf("%v element %v of the solution is %v", prefix, i, v)
+			return
+		}
+	}
+
+	// The solution is scale*xScale*x.
+	for i := 0; i < n; i++ {
+		want := scale * xScale * x.Data[i]
+		if math.Abs(sol.Data[i]-want) > tol*math.Max(1, math.Abs(want)) {
+			t.Errorf("%v unexpected solution element %v; got %v, want %v", prefix, i, sol.Data[i], want)
+		}
+	}
+
+	// Check the residual |A*x - scale*rhs| / (|A|*|x|).
+	r := zeros(n, 1, 1)
+	for i := range r.Data {
+		r.Data[i] = scale * rhs.Data[i]
+	}
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aCopy, sol, -1, r)
+	denom := math.Max(math.SmallestNonzeroFloat64, float64(n)*maxAbsGeneral(aCopy)*maxAbsGeneral(sol))
+	if resid := maxAbsGeneral(r) / denom; resid > tol {
+		t.Errorf("%v unexpected residual of the solution, resid=%v", prefix, resid)
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgesdder interface {
+	Dgesdd(jobz lapack.SVDJob, m, n int, a []float64, lda int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ok bool)
+	Dgesvder
+}
+
+func DgesddTest(t *testing.T, impl Dgesdder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{5, 0, 0},
+		{1, 1, 0},
+		{1, 5, 0},
+		{5, 1, 0},
+
+		{5, 5, 0},
+		{5, 9, 0},
+		{9, 5, 0},
+		{5, 5, 10},
+		{5, 9, 10},
+		{9, 5, 10},
+
+		// m and n are close so that A is bidiagonalized directly.
+		{30, 30, 0},
+		{30, 40, 0},
+		{40, 30, 0},
+		{30, 40, 50},
+		{40, 30, 50},
+
+		// m and n differ enough that A is first reduced by a QR or LQ
+		// decomposition.
+		{30, 100, 0},
+		{100, 30, 0},
+		{30, 100, 110},
+		{100, 30, 40},
+	} {
+		for _, jobz := range []lapack.SVDJob{lapack.SVDAll, lapack.SVDInPlace, lapack.SVDOverwrite, lapack.SVDNone} {
+			for _, wl := range []worklen{minimumWork, optimumWork} {
+				testDgesdd(t, impl, jobz, test.m, test.n, test.lda, wl, rnd)
+			}
+		}
+	}
+}
+
+func testDgesdd(t *testing.T, impl Dgesdder, jobz lapack.SVDJob, m, n, lda int, wl worklen, rnd *rand.Rand) {
+	minmn := min(m, n)
+	if lda == 0 {
+		lda = max(1, n)
+	}
+
+	// Fill all of a with random numbers because svdCheck compares the
+	// elements outside the matrix too.
+	a := blas64.General{
+		Rows:   m,
+		Cols:   n,
+		Stride: lda,
+		Data:   make([]float64, m*lda),
+	}
+	for i := range a.Data {
+		a.Data[i] = rnd.NormFloat64()
+	}
+	aCopy := cloneGeneral(a)
+
+	// Allocate u and vt with the shapes required by jobz.
+	var ucol, vtrow int
+	var uInA, vtInA bool
+	switch jobz {
+	case lapack.SVDAll:
+		ucol, vtrow = m, n
+	case lapack.SVDInPlace:
+		ucol, vtrow = minmn, minmn
+	case lapack.SVDOverwrite:
+		if m >= n {
+			uInA = true
+			vtrow = n
+		} else {
+			ucol = m
+			vtInA = true
+		}
+	}
+	ldu := max(1, ucol)
+	ldvt := max(1, n)
+	u := nanSlice(max(1, m*ldu))
+	vt := nanSlice(max(1, vtrow*ldvt))
+	s := nanSlice(minmn)
+	iwork := make([]int, 8*minmn)
+
+	work := make([]float64, 1)
+	impl.Dgesdd(jobz, m, n, a.Data, a.Stride, s, u, ldu, vt, ldvt, work, -1, iwork)
+	if !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("a changed during call to get work length")
+	}
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = dgesddMinWork(jobz, m, n)
+	case optimumWork:
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	ok := impl.Dgesdd(jobz, m, n, a.Data, a.Stride, s, u, ldu, vt, ldvt, work, lwork, iwork)
+	errStr := fmt.Sprintf("jobz = %c, m = %v, n = %v, lda = %v, wl = %v", jobz, m, n, lda, wl)
+	if !ok {
+		t.Errorf("Dgesdd did not complete successfully: %s", errStr)
+		return
+	}
+	if minmn == 0 {
+		return
+	}
+
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+		t.Errorf("Singular values not sorted: %s", errStr)
+	}
+
+	// Compare the singular values with those computed by Dgesvd.
+	sAns := make([]float64, minmn)
+	aSvd := cloneGeneral(aCopy)
+	work = make([]float64, 1)
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, len(work))
+	if !floats.EqualApprox(s, sAns, 1e-10) {
+		t.Errorf("Singular value mismatch with Dgesvd: %s", errStr)
+	}
+
+	if jobz == lapack.SVDNone {
+		return
+	}
+	if uInA {
+		u = a.Data
+		ldu = a.Stride
+	}
+	if vtInA {
+		vt = a.Data
+		ldvt = a.Stride
+	}
+	if jobz == lapack.SVDAll {
+		svdCheck(t, false, errStr, m, n, s, a.Data, u, ldu, vt, ldvt, aCopy.Data, lda)
+		return
+	}
+
+	// Check that the computed singular vectors are orthonormal.
+	uMat := blas64.General{Rows: m, Cols: minmn, Stride: ldu, Data: u}
+	vtMat := blas64.General{Rows: minmn, Cols: n, Stride: ldvt, Data: vt}
+	if !hasOrthonormalColumns(m, minmn, u, ldu) {
+		t.Errorf("U not orthonormal: %s", errStr)
+	}
+	if !hasOrthonormalColumns(n, minmn, transposeGeneral(vtMat).Data, minmn) {
+		t.Errorf("VT not orthonormal: %s", errStr)
+	}
+
+	// svdCheck reads the full m×m U and n×n VT, so embed the computed
+	// singular vectors in zero matrices.
+	uFull := zeros(m, m, m)
+	copyGeneral(blas64.General{Rows: m, Cols: minmn, Stride: m, Data: uFull.Data}, uMat)
+	vtFull := zeros(n, n, n)
+	copyGeneral(blas64.General{Rows: minmn, Cols: n, Stride: n, Data: vtFull.Data}, vtMat)
+	svdCheck(t, true, errStr, m, n, s, a.Data, uFull.Data, m, vtFull.Data, n, aCopy.Data, lda)
+}
+
+// dgesddMinWork returns the minimum workspace length documented for Dgesdd.
+func dgesddMinWork(jobz lapack.SVDJob, m, n int) int {
+	mn := min(m, n)
+	mx := max(m, n)
+	switch jobz {
+	case lapack.SVDNone:
+		return max(1, 3*mn+max(mx, 7*mn))
+	case lapack.SVDOverwrite:
+		return max(1, 3*mn+max(mx, 5*mn*mn+4*mn))
+	case lapack.SVDInPlace:
+		return max(1, 4*mn*mn+7*mn)
+	default:
+		return max(1, 4*mn*mn+6*mn+mx)
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dgesver interface {
+	Dgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int) (ok bool)
+	Dgetrser
+}
+
+func DgesvTest(t *testing.T, impl Dgesver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, nrhs, lda, ldb int
+	}{
+		{0, 0, 0, 0},
+		{0, 3, 0, 0},
+		{3, 0, 0, 0},
+		{1, 1, 0, 0},
+		{5, 1, 0, 0},
+		{5, 3, 0, 0},
+		{5, 3, 10, 11},
+		{50, 4, 0, 0},
+		{100, 10, 110, 12},
+	} {
+		for _, singular := range []bool{false, true} {
+			testDgesv(t, impl, test.n, test.nrhs, test.lda, test.ldb, singular, rnd)
+		}
+	}
+}
+
+func testDgesv(t *testing.T, impl Dgesver, n, nrhs, lda, ldb int, singular bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	a := blas64.General{Rows: n, Cols: n, Stride: lda, Data: randomSlice(n*lda, rnd)}
+	if singular && n > 0 {
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		for i := 0; i < n; i++ {
+			a.Data[i*lda+j] = 0
+		}
+	}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	ipiv := make([]int, n)
+
+	ok := impl.Dgesv(n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+	errStr := fmt.Sprintf("n = %v, nrhs = %v, lda = %v, ldb = %v, singular = %v", n, nrhs, lda, ldb, singular)
+	if singular && n > 0 {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the LU factorization is the same as computed by Dgetrf.
+	lu := cloneGeneral(aCopy)
+	ipivWant := make([]int, n)
+	impl.Dgetrf(n, n, lu.Data, lu.Stride, ipivWant)
+	if !equalApproxGeneral(a, lu, 1e-14) {
+		t.Errorf("Unexpected LU factorization: %s", errStr)
+	}
+	for i, v := range ipiv {
+		if v != ipivWant[i] {
+			t.Errorf("Unexpected pivot indices: %s", errStr)
+			break
+		}
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aCopy, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-10) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
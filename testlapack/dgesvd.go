@@ -42,6 +42,9 @@ func DgesvdTest(t *testing.T, impl Dgesvder) {
 		{5, 9, 10, 11, 12},
 		{9, 5, 10, 11, 12},
 
+		{100, 150, 0, 0, 0},
+		{150, 100, 0, 0, 0},
+
 		{300, 300, 0, 0, 0},
 		{300, 400, 0, 0, 0},
 		{400, 300, 0, 0, 0},
@@ -0,0 +1,244 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgesvdxer interface {
+	Dgesvdx(jobU, jobVT lapack.SVDJob, rng lapack.EVRange, m, n int, a []float64, lda int, vl, vu float64, il, iu int, s, u []float64, ldu int, vt []float64, ldvt int, work []float64, lwork int, iwork []int) (ns int, ok bool)
+	Dgesvder
+}
+
+func DgesvdxTest(t *testing.T, impl Dgesvdxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{5, 0, 0},
+		{1, 1, 0},
+		{1, 5, 0},
+		{5, 1, 0},
+
+		{5, 5, 0},
+		{5, 7, 0},
+		{7, 5, 0},
+		{5, 5, 10},
+		{5, 7, 10},
+		{7, 5, 10},
+
+		// m and n are close so that A is bidiagonalized directly.
+		{30, 30, 0},
+		{30, 40, 0},
+		{40, 30, 0},
+		{30, 40, 50},
+		{40, 30, 50},
+
+		// m and n differ enough that A is first reduced by a QR or LQ
+		// decomposition.
+		{20, 100, 0},
+		{100, 20, 0},
+		{20, 100, 110},
+		{100, 20, 30},
+	} {
+		for _, job := range []struct {
+			u, vt lapack.SVDJob
+		}{
+			{lapack.SVDInPlace, lapack.SVDInPlace},
+			{lapack.SVDInPlace, lapack.SVDNone},
+			{lapack.SVDNone, lapack.SVDInPlace},
+			{lapack.SVDNone, lapack.SVDNone},
+		} {
+			for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+				for _, kind := range []string{"random", "rankdef"} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						testDgesvdx(t, impl, job.u, job.vt, rng, test.m, test.n, test.lda, kind, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDgesvdx(t *testing.T, impl Dgesvdxer, jobU, jobVT lapack.SVDJob, rng lapack.EVRange, m, n, lda int, kind string, wl worklen, rnd *rand.Rand) {
+	wantu := jobU == lapack.SVDInPlace
+	wantvt := jobVT == lapack.SVDInPlace
+	minmn := min(m, n)
+	if lda == 0 {
+		lda = max(1, n)
+	}
+
+	// Generate the matrix A.
+	aMat := blas64.General{
+		Rows:   m,
+		Cols:   n,
+		Stride: lda,
+		Data:   make([]float64, m*lda),
+	}
+	switch kind {
+	case "random":
+		for i := range aMat.Data {
+			aMat.Data[i] = rnd.NormFloat64()
+		}
+	case "rankdef":
+		// A is the product of an m×r and an r×n matrix, so that half of
+		// its singular values are zero in exact arithmetic.
+		r := minmn / 2
+		if r > 0 {
+			b := randomGeneral(m, r, r, rnd)
+			c := randomGeneral(r, n, n, rnd)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, c, 0, aMat)
+		}
+	}
+	anrm := math.Max(1, floats.Norm(aMat.Data, math.Inf(1)))
+
+	// Compute all singular values with Dgesvd for reference.
+	sAll := make([]float64, minmn)
+	if minmn > 0 {
+		aSvd := cloneGeneral(aMat)
+		work := make([]float64, 1)
+		impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAll, nil, 1, nil, 1, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAll, nil, 1, nil, 1, work, len(work))
+	}
+
+	for _, sel := range singularSelections(rng, sAll) {
+		il, iu := sel.il, sel.iu
+		ncol := minmn
+		if rng == lapack.RangeIndex {
+			ncol = iu - il + 1
+		}
+		prefix := fmt.Sprintf("Case jobU=%c,jobVT=%c,rng=%c,m=%v,n=%v,lda=%v,kind=%v,wl=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			jobU, jobVT, rng, m, n, lda, kind, wl, sel.vl, sel.vu, il, iu)
+
+		a := cloneGeneral(aMat)
+		s := nanSlice(minmn)
+		ldu := max(1, ncol)
+		ldvt := max(1, n)
+		var u, vt []float64
+		if wantu {
+			u = nanSlice(max(1, m*ldu))
+		}
+		if wantvt {
+			vt = nanSlice(max(1, ncol*ldvt))
+		}
+		iwork := make([]int, 10*minmn)
+
+		work := make([]float64, 1)
+		impl.Dgesvdx(jobU, jobVT, rng, m, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, s, u, ldu, vt, ldvt, work, -1, iwork)
+		if !floats.Equal(a.Data, aMat.Data) {
+			t.Errorf("%v a changed during call to get work length", prefix)
+		}
+		var lwork int
+		switch wl {
+		case minimumWork:
+			lwork = dgesvdxMinWork(m, n)
+		case optimumWork:
+			lwork = int(work[0])
+		}
+		work = nanSlice(lwork)
+
+		ns, ok := impl.Dgesvdx(jobU, jobVT, rng, m, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, s, u, ldu, vt, ldvt, work, lwork, iwork)
+		if !ok {
+			t.Errorf("%v Dgesvdx failed", prefix)
+			continue
+		}
+		if ns != iu-il+1 {
+			t.Errorf("%v unexpected number of singular values; got %v, want %v", prefix, ns, iu-il+1)
+			continue
+		}
+		if ns == 0 {
+			continue
+		}
+		if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s[:ns]))) {
+			t.Errorf("%v singular values are not sorted", prefix)
+		}
+		if !floats.EqualApprox(s[:ns], sAll[il:iu+1], 1e-12*anrm) {
+			t.Errorf("%v singular value mismatch with Dgesvd", prefix)
+		}
+
+		// Check that the computed singular vectors are orthonormal.
+		uMat := blas64.General{Rows: m, Cols: ns, Stride: ldu, Data: u}
+		vtMat := blas64.General{Rows: ns, Cols: n, Stride: ldvt, Data: vt}
+		if wantu {
+			var hasNaN bool
+			for i := 0; i < m; i++ {
+				hasNaN = hasNaN || floats.HasNaN(u[i*ldu:i*ldu+ns])
+			}
+			if hasNaN || !hasOrthonormalColumns(m, ns, u, ldu) {
+				t.Errorf("%v U is not orthonormal", prefix)
+				continue
+			}
+		}
+		if wantvt {
+			vtMat = transposeGeneral(vtMat)
+			if floats.HasNaN(vtMat.Data) || !hasOrthonormalColumns(n, ns, vtMat.Data, vtMat.Stride) {
+				t.Errorf("%v VT is not orthonormal", prefix)
+				continue
+			}
+		}
+
+		// Check that A * V = U * S if both U and V are available, and
+		// that the norms of A * V and A^T * U are S otherwise.
+		if wantvt {
+			av := zeros(m, ns, ns)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aMat, vtMat, 0, av)
+			if wantu {
+				us := zeros(m, ns, ns)
+				for i := 0; i < m; i++ {
+					for j := 0; j < ns; j++ {
+						us.Data[i*us.Stride+j] = uMat.Data[i*uMat.Stride+j] * s[j]
+					}
+				}
+				if !equalApproxGeneral(av, us, 1e-12*anrm) {
+					t.Errorf("%v A*V != U*S", prefix)
+				}
+			} else {
+				for j := 0; j < ns; j++ {
+					nrm := blas64.Nrm2(m, blas64.Vector{Inc: av.Stride, Data: av.Data[j:]})
+					if math.Abs(nrm-s[j]) > 1e-12*anrm {
+						t.Errorf("%v |A*v| != s for singular value %v", prefix, j)
+					}
+				}
+			}
+		}
+		if wantu && !wantvt {
+			atu := zeros(n, ns, ns)
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, aMat, uMat, 0, atu)
+			for j := 0; j < ns; j++ {
+				nrm := blas64.Nrm2(n, blas64.Vector{Inc: atu.Stride, Data: atu.Data[j:]})
+				if math.Abs(nrm-s[j]) > 1e-12*anrm {
+					t.Errorf("%v |A^T*u| != s for singular value %v", prefix, j)
+				}
+			}
+		}
+	}
+}
+
+// dgesvdxMinWork returns the minimum workspace length documented for Dgesvdx.
+// The crossover to the QR or LQ path is the one returned by Ilaenv.
+func dgesvdxMinWork(m, n int) int {
+	mn := min(m, n)
+	mx := max(m, n)
+	if mn == 0 {
+		return 1
+	}
+	if mx >= int(float64(mn)*1.6) {
+		return 3*mn*mn + 21*mn
+	}
+	return 4*mn + max(mx, 2*mn*mn+16*mn)
+}
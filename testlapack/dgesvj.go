@@ -0,0 +1,345 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgesvjer interface {
+	Dgesvj(joba lapack.MatrixType, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, m, n int, a []float64, lda int, sva []float64, mv int, v []float64, ldv int, work []float64, lwork int) (ok bool)
+	Dgesvder
+}
+
+func DgesvjTest(t *testing.T, impl Dgesvjer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{5, 1, 0},
+
+		{5, 5, 0},
+		{9, 5, 0},
+		{5, 5, 10},
+		{9, 5, 10},
+
+		{30, 30, 0},
+		{40, 30, 0},
+		{100, 30, 0},
+		{40, 30, 50},
+
+		// n is large enough that the triangular structure of A is
+		// exploited.
+		{70, 70, 0},
+		{100, 70, 0},
+		{100, 70, 80},
+	} {
+		for _, joba := range []lapack.MatrixType{lapack.General, lapack.UpperTri, lapack.LowerTri} {
+			for _, jobu := range []lapack.LeftSVJob{lapack.ComputeLeftSV, lapack.ControlLeftSV, lapack.None} {
+				for _, jobv := range []lapack.RightSVJob{lapack.ComputeRightSV, lapack.ApplyRightSV, lapack.None} {
+					testDgesvj(t, impl, joba, jobu, jobv, test.m, test.n, test.lda, rnd)
+				}
+			}
+		}
+	}
+
+	// Check that the singular values of graded matrices are computed to
+	// high relative accuracy.
+	for _, test := range []struct {
+		m, n int
+		cond float64
+	}{
+		{5, 5, 1e10},
+		{8, 5, 1e20},
+		{10, 10, 1e30},
+		{12, 8, 1e100},
+	} {
+		for cas := 0; cas < 10; cas++ {
+			testDgesvjGraded(t, impl, test.m, test.n, test.cond, rnd)
+		}
+	}
+}
+
+func testDgesvj(t *testing.T, impl Dgesvjer, joba lapack.MatrixType, jobu lapack.LeftSVJob, jobv lapack.RightSVJob, m, n, lda int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	a := randomGeneral(m, n, lda, rnd)
+	switch joba {
+	case lapack.UpperTri:
+		for i := 0; i < m; i++ {
+			for j := 0; j < min(i, n); j++ {
+				a.Data[i*lda+j] = 0
+			}
+		}
+	case lapack.LowerTri:
+		for i := 0; i < m; i++ {
+			for j := i + 1; j < n; j++ {
+				a.Data[i*lda+j] = 0
+			}
+		}
+	}
+	aCopy := cloneGeneral(a)
+
+	// If the rotations are applied, V is initialized to an (n+2)×n
+	// matrix whose first n rows are the identity, so that on return the
+	// first n rows contain the right singular vectors and the last two
+	// rows their product with the random initial rows.
+	var mv, ldv int
+	var v, vCopy []float64
+	switch jobv {
+	case lapack.ComputeRightSV:
+		ldv = max(1, n)
+		v = nanSlice(n * ldv)
+	case lapack.ApplyRightSV:
+		mv = n + 2
+		ldv = max(1, n)
+		v = make([]float64, mv*ldv)
+		for i := 0; i < n; i++ {
+			v[i*ldv+i] = 1
+		}
+		for i := n * ldv; i < len(v); i++ {
+			v[i] = rnd.NormFloat64()
+		}
+		vCopy = make([]float64, len(v))
+		copy(vCopy, v)
+	}
+
+	sva := nanSlice(n)
+	work := make([]float64, 1)
+	impl.Dgesvj(joba, jobu, jobv, m, n, a.Data, a.Stride, sva, mv, v, ldv, work, -1)
+	lwork := int(work[0])
+	if lwork != max(6, m+n) {
+		t.Errorf("unexpected minimum work length: got %v, want %v", lwork, max(6, m+n))
+	}
+	work = nanSlice(lwork)
+	if jobu == lapack.ControlLeftSV {
+		work[0] = float64(max(1, m))
+	}
+
+	ok := impl.Dgesvj(joba, jobu, jobv, m, n, a.Data, a.Stride, sva, mv, v, ldv, work, lwork)
+	errStr := fmt.Sprintf("joba = %c, jobu = %c, jobv = %c, m = %v, n = %v, lda = %v", joba, jobu, jobv, m, n, lda)
+	if !ok {
+		t.Errorf("Dgesvj did not converge: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	s := make([]float64, n)
+	for i, sv := range sva {
+		s[i] = work[0] * sv
+	}
+	if !sort.IsSorted(sort.Reverse(sort.Float64Slice(s))) {
+		t.Errorf("Singular values not sorted: %s", errStr)
+	}
+
+	// Compare the singular values with those computed by Dgesvd.
+	sAns := make([]float64, n)
+	aSvd := cloneGeneral(aCopy)
+	work = make([]float64, 1)
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgesvd(lapack.SVDNone, lapack.SVDNone, m, n, aSvd.Data, aSvd.Stride, sAns, nil, 1, nil, 1, work, len(work))
+	if !floats.EqualApprox(s, sAns, 1e-10) {
+		t.Errorf("Singular value mismatch with Dgesvd: %s", errStr)
+	}
+
+	switch jobv {
+	case lapack.None:
+		return
+	case lapack.ApplyRightSV:
+		// Check that the last two rows of V contain the initial rows
+		// multiplied by the right singular vectors.
+		want := blas64.General{Rows: 2, Cols: n, Stride: n, Data: make([]float64, 2*n)}
+		blas64.Gemm(blas.NoTrans, blas.NoTrans,
+			1, blas64.General{Rows: 2, Cols: n, Stride: ldv, Data: vCopy[n*ldv:]},
+			blas64.General{Rows: n, Cols: n, Stride: ldv, Data: v},
+			0, want)
+		if !equalApprox(2, n, v[n*ldv:], ldv, want.Data, 1e-12) {
+			t.Errorf("Rotations not applied to V: %s", errStr)
+		}
+	}
+	vMat := blas64.General{Rows: n, Cols: n, Stride: ldv, Data: v}
+	if !isOrthonormal(vMat) {
+		t.Errorf("V is not orthogonal: %s", errStr)
+	}
+
+	if jobu == lapack.None {
+		return
+	}
+	if !hasOrthonormalColumns(m, n, a.Data, a.Stride) {
+		t.Errorf("U does not have orthonormal columns: %s", errStr)
+	}
+
+	// Check that A = U * Sigma * V^T.
+	us := cloneGeneral(a)
+	for j := 0; j < n; j++ {
+		blas64.Implementation().Dscal(m, s[j], us.Data[j:], us.Stride)
+	}
+	usvt := zeros(m, n, lda)
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, us, vMat, 0, usvt)
+	if !equalApproxGeneral(usvt, aCopy, 1e-10) {
+		t.Errorf("A != U * Sigma * V^T: %s", errStr)
+	}
+}
+
+// testDgesvjGraded checks the relative accuracy of the singular values of
+// the m×n matrix A = B*D where B has random columns and D is a diagonal matrix
+// with condition number cond.
+func testDgesvjGraded(t *testing.T, impl Dgesvjer, m, n int, cond float64, rnd *rand.Rand) {
+	lda := n
+	a := gradedGeneral(m, n, lda, 1, cond, rnd)
+	want := svdHighPrecision(m, n, a.Data, lda)
+
+	sva := nanSlice(n)
+	lwork := max(6, m+n)
+	work := nanSlice(lwork)
+	ok := impl.Dgesvj(lapack.General, lapack.None, lapack.None, m, n, a.Data, lda, sva, 0, nil, 1, work, lwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, cond = %v", m, n, cond)
+	if !ok {
+		t.Errorf("Dgesvj did not converge: %s", errStr)
+		return
+	}
+	for i := range sva {
+		got := work[0] * sva[i]
+		if math.Abs(got-want[i]) > 1e-12*want[i] {
+			t.Errorf("Singular value %v not computed to high relative accuracy: %s, got %v, want %v", i, errStr, got, want[i])
+		}
+	}
+}
+
+// gradedGeneral returns the m×n matrix D1*B*D2 where B has random normal
+// elements and D1 and D2 are diagonal matrices with randomly ordered
+// logarithmically spaced elements with condition numbers rowCond and colCond.
+func gradedGeneral(m, n, lda int, rowCond, colCond float64, rnd *rand.Rand) blas64.General {
+	a := randomGeneral(m, n, lda, rnd)
+	dr := gradedScaling(m, rowCond, rnd)
+	dc := gradedScaling(n, colCond, rnd)
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			a.Data[i*lda+j] *= dr[i] * dc[j]
+		}
+	}
+	return a
+}
+
+// gradedScaling returns n logarithmically spaced numbers between 1 and 1/cond
+// in random order.
+func gradedScaling(n int, cond float64, rnd *rand.Rand) []float64 {
+	d := make([]float64, n)
+	for i := range d {
+		if n > 1 {
+			d[i] = math.Pow(cond, -float64(i)/float64(n-1))
+		} else {
+			d[i] = 1
+		}
+	}
+	for i := n - 1; i > 0; i-- {
+		j := rnd.Intn(i + 1)
+		d[i], d[j] = d[j], d[i]
+	}
+	return d
+}
+
+// svdHighPrecision returns the singular values of the m×n matrix A with
+// m >= n in non-increasing order. The singular values are computed by the
+// one-sided Jacobi method in extended precision arithmetic so that they are
+// correctly rounded up to a few ulps.
+func svdHighPrecision(m, n int, a []float64, lda int) []float64 {
+	const prec = 256
+	newFloat := func() *big.Float { return new(big.Float).SetPrec(prec) }
+	dot := func(x, y []*big.Float) *big.Float {
+		sum := newFloat()
+		tmp := newFloat()
+		for i := range x {
+			sum.Add(sum, tmp.Mul(x[i], y[i]))
+		}
+		return sum
+	}
+
+	cols := make([][]*big.Float, n)
+	for j := range cols {
+		cols[j] = make([]*big.Float, m)
+		for i := range cols[j] {
+			cols[j][i] = newFloat().SetFloat64(a[i*lda+j])
+		}
+	}
+
+	tol := newFloat().SetMantExp(newFloat().SetInt64(1), -prec/2)
+	one := newFloat().SetInt64(1)
+	tmp := newFloat()
+	for sweep := 0; sweep < 100; sweep++ {
+		var rotated bool
+		for p := 0; p < n-1; p++ {
+			for q := p + 1; q < n; q++ {
+				alpha := dot(cols[p], cols[p])
+				beta := dot(cols[q], cols[q])
+				gamma := dot(cols[p], cols[q])
+				if gamma.Sign() == 0 {
+					continue
+				}
+				// Skip the rotation if |gamma| <= tol*sqrt(alpha*beta).
+				tmp.Mul(alpha, beta)
+				tmp.Sqrt(tmp)
+				tmp.Mul(tmp, tol)
+				if newFloat().Abs(gamma).Cmp(tmp) <= 0 {
+					continue
+				}
+				rotated = true
+
+				// Compute the rotation that makes columns p and q
+				// orthogonal.
+				zeta := newFloat().Sub(beta, alpha)
+				zeta.Quo(zeta, tmp.Mul(gamma, newFloat().SetInt64(2)))
+				tn := newFloat().Mul(zeta, zeta)
+				tn.Add(tn, one)
+				tn.Sqrt(tn)
+				tn.Add(tn, newFloat().Abs(zeta))
+				tn.Quo(one, tn)
+				if zeta.Sign() < 0 {
+					tn.Neg(tn)
+				}
+				c := newFloat().Mul(tn, tn)
+				c.Add(c, one)
+				c.Sqrt(c)
+				c.Quo(one, c)
+				s := newFloat().Mul(c, tn)
+				for i := 0; i < m; i++ {
+					ap := newFloat().Mul(c, cols[p][i])
+					ap.Sub(ap, tmp.Mul(s, cols[q][i]))
+					aq := newFloat().Mul(s, cols[p][i])
+					aq.Add(aq, tmp.Mul(c, cols[q][i]))
+					cols[p][i] = ap
+					cols[q][i] = aq
+				}
+			}
+		}
+		if !rotated {
+			break
+		}
+	}
+
+	s := make([]float64, n)
+	for j := range s {
+		nrm := dot(cols[j], cols[j])
+		s[j], _ = nrm.Sqrt(nrm).Float64()
+	}
+	sort.Sort(sort.Reverse(sort.Float64Slice(s)))
+	return s
+}
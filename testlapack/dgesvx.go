@@ -0,0 +1,191 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dgesvxer interface {
+	Dgesvx(fact lapack.FactorizationType, trans blas.Transpose, n, nrhs int, a []float64, lda int, af []float64, ldaf int, ipiv []int, equed lapack.EquilibrationType, r, c, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond, rpvgrw float64, ok bool)
+	Dgeconer
+	Dgeequer
+}
+
+func DgesvxTest(t *testing.T, impl Dgesvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fact := range []lapack.FactorizationType{lapack.NotFactored, lapack.EquilibrateAndFactor, lapack.Factored} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				n, nrhs, lda, ldb int
+			}{
+				{0, 0, 0, 0},
+				{0, 3, 0, 0},
+				{3, 0, 0, 0},
+				{1, 1, 0, 0},
+				{1, 3, 0, 0},
+				{5, 1, 0, 0},
+				{5, 3, 0, 0},
+				{5, 3, 10, 11},
+				{20, 4, 0, 0},
+				{50, 2, 60, 5},
+			} {
+				for _, singular := range []bool{false, true} {
+					testDgesvx(t, impl, fact, trans, test.n, test.nrhs, test.lda, test.ldb, singular, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgesvx(t *testing.T, impl Dgesvxer, fact lapack.FactorizationType, trans blas.Transpose, n, nrhs, lda, ldb int, singular bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	errStr := fmt.Sprintf("fact = %c, trans = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, singular = %v",
+		fact, trans, n, nrhs, lda, ldb, singular)
+
+	a := badlyScaledGeneral(n, n, lda, rnd)
+	if singular && n > 0 {
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		for i := 0; i < n; i++ {
+			a.Data[i*lda+j] = 0
+		}
+	}
+	aCopy := cloneGeneral(a)
+	xWant := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(trans, blas.NoTrans, 1, a, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	af := blas64.General{Rows: n, Cols: n, Stride: lda, Data: nanSlice(n * lda)}
+	ipiv := make([]int, n)
+	r := nanSlice(n)
+	c := nanSlice(n)
+	equed := lapack.EquilibrationType('X')
+	if fact == lapack.Factored {
+		// Equilibrate and factor A in advance.
+		equed = lapack.NoEquilibration
+		if n > 0 {
+			_, _, _, ok := impl.Dgeequ(n, n, a.Data, a.Stride, r, c)
+			if ok {
+				equed = lapack.BothEquilibration
+				a = scaledGeneral(a, r, c)
+			}
+		}
+		copyGeneral(af, a)
+		impl.Dgetrf(n, n, af.Data, af.Stride, ipiv)
+		if singular && n > 0 {
+			// Dgesvx must not be called with a singular factored
+			// matrix.
+			return
+		}
+	}
+	aEquil := cloneGeneral(a)
+	x := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: nanSlice(n * ldb)}
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(4 * n)
+	iwork := make([]int, n)
+
+	equedOut, rcond, rpvgrw, ok := impl.Dgesvx(fact, trans, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, ipiv, equed,
+		r, c, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+
+	switch fact {
+	case lapack.NotFactored:
+		if equedOut != lapack.NoEquilibration {
+			t.Errorf("Unexpected equilibration %c: %s", equedOut, errStr)
+		}
+	case lapack.Factored:
+		if equedOut != equed {
+			t.Errorf("Equilibration type changed from %c to %c: %s", equed, equedOut, errStr)
+		}
+	}
+	if fact != lapack.EquilibrateAndFactor || equedOut == lapack.NoEquilibration {
+		if !equalApproxGeneral(a, aEquil, 0) {
+			t.Errorf("A modified: %s", errStr)
+		}
+	}
+	if n == 0 {
+		if !ok || rcond != 1 || rpvgrw != 1 {
+			t.Errorf("Unexpected result for n == 0: %s", errStr)
+		}
+		return
+	}
+
+	// Check the reciprocal pivot growth factor against its definition
+	// using the equilibrated matrix A and its computed LU factorization.
+	ncols := n
+	if singular {
+		for i := 0; i < n; i++ {
+			if af.Data[i*af.Stride+i] == 0 {
+				ncols = i + 1
+				break
+			}
+		}
+	}
+	wantRpvgrw := 1.0
+	for j := 0; j < ncols; j++ {
+		var amax, umax float64
+		for i := 0; i < n; i++ {
+			amax = math.Max(amax, math.Abs(a.Data[i*a.Stride+j]))
+		}
+		for i := 0; i <= j; i++ {
+			umax = math.Max(umax, math.Abs(af.Data[i*af.Stride+j]))
+		}
+		if umax != 0 {
+			wantRpvgrw = math.Min(wantRpvgrw, amax/umax)
+		}
+	}
+	if math.Abs(rpvgrw-wantRpvgrw) > 1e-14*wantRpvgrw {
+		t.Errorf("Unexpected rpvgrw: got %v, want %v: %s", rpvgrw, wantRpvgrw, errStr)
+	}
+
+	if singular {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+		if rcond != 0 {
+			t.Errorf("Unexpected rcond for singular matrix: got %v, want 0: %s", rcond, errStr)
+		}
+		return
+	}
+	const eps = 1.0 / (1 << 53)
+	if ok != (rcond >= eps) {
+		t.Errorf("Unexpected ok = %v for rcond = %v: %s", ok, rcond, errStr)
+	}
+
+	// Check that rcond is the condition estimate of the equilibrated
+	// matrix A.
+	norm := lapack.MaxColumnSum
+	if trans == blas.Trans {
+		norm = lapack.MaxRowSum
+	}
+	anorm := impl.Dlange(norm, n, n, a.Data, a.Stride, make([]float64, n))
+	wantRcond := impl.Dgecon(norm, n, af.Data, af.Stride, anorm, make([]float64, 4*n), make([]int, n))
+	if math.Abs(rcond-wantRcond) > 1e-14*wantRcond {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", rcond, wantRcond, errStr)
+	}
+
+	if nrhs == 0 {
+		return
+	}
+	// Check that X solves the original system of equations, that the
+	// backward errors are small and that the forward error bounds hold.
+	checkErrorBounds(t, errStr, trans, aCopy, bCopy, x, xWant, ferr, berr)
+}
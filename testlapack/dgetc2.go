@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dgetc2er interface {
+	Dgetc2(n int, a []float64, lda int, ipiv, jpiv []int) (ok bool)
+	Dgesc2(n int, a []float64, lda int, rhs []float64, ipiv, jpiv []int) (scale float64)
+}
+
+// Dgetc2Test tests Dgetc2 and Dgesc2 by checking the residual of the solution
+// of a linear system computed from the complete pivoting LU factorization.
+func Dgetc2Test(t *testing.T, impl Dgetc2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 8, 10, 20} {
+		for _, extra := range []int{0, 5} {
+			for cas := 0; cas < 10; cas++ {
+				testDgetc2(t, impl, n, extra, rnd)
+			}
+		}
+	}
+}
+
+func testDgetc2(t *testing.T, impl Dgetc2er, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	a := randomGeneral(n, n, n+extra, rnd)
+	aCopy := cloneGeneral(a)
+	ipiv := make([]int, n)
+	jpiv := make([]int, n)
+
+	ok := impl.Dgetc2(n, a.Data, a.Stride, ipiv, jpiv)
+
+	prefix := fmt.Sprintf("Case n=%v, extra=%v", n, extra)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !ok {
+		t.Logf("%v: Dgetc2 returned ok=false", prefix)
+	}
+	for i := 0; i < n; i++ {
+		if ipiv[i] < i || n <= ipiv[i] {
+			t.Errorf("%v: ipiv[%v] out of range, got %v", prefix, i, ipiv[i])
+		}
+		if jpiv[i] < i || n <= jpiv[i] {
+			t.Errorf("%v: jpiv[%v] out of range, got %v", prefix, i, jpiv[i])
+		}
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that the strictly lower triangular elements of L are bounded by
+	// one in magnitude which is the consequence of pivoting.
+	if ok {
+		for i := 1; i < n; i++ {
+			for j := 0; j < i; j++ {
+				if math.Abs(a.Data[i*a.Stride+j]) > 1 {
+					t.Errorf("%v: element L[%v,%v] larger than one in magnitude", prefix, i, j)
+				}
+			}
+		}
+	}
+
+	b := randomGeneral(n, 1, 1, rnd)
+	x := cloneGeneral(b)
+	scale := impl.Dgesc2(n, a.Data, a.Stride, x.Data, ipiv, jpiv)
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: scale out of range (0,1], got %v", prefix, scale)
+	}
+
+	// Compute the residual |A*x - scale*b| / (|A|*|x|).
+	r := cloneGeneral(b)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aCopy, x, -scale, r)
+	denom := math.Max(1, float64(n)*maxAbsGeneral(aCopy)*maxAbsGeneral(x))
+	if resid := maxAbsGeneral(r) / denom; resid > tol {
+		t.Errorf("%v: unexpected residual of the solution, resid=%v", prefix, resid)
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dggbaker interface {
+	Dggbak(job lapack.Job, side lapack.EVSide, n, ilo, ihi int, lscale, rscale []float64, m int, v []float64, ldv int)
+}
+
+func DggbakTest(t *testing.T, impl Dggbaker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.Job{lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale} {
+		for _, side := range []lapack.EVSide{lapack.LeftEV, lapack.RightEV} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 20; cas++ {
+						m := 1 + rnd.Intn(max(1, n))
+						v := randomGeneral(n, m, m+extra, rnd)
+						ilo, ihi := randomIloIhi(n, rnd)
+						testDggbak(t, impl, job, side, n, ilo, ihi, v, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggbak(t *testing.T, impl Dggbaker, job lapack.Job, side lapack.EVSide, n, ilo, ihi int, v blas64.General, rnd *rand.Rand) {
+	const tol = 1e-15
+	m := v.Cols
+	extra := v.Stride - v.Cols
+
+	// Create random D_L, D_R and P_L, P_R represented by lscale and
+	// rscale, respectively.
+	lscale := nanSlice(n)
+	rscale := nanSlice(n)
+	dl := eye(n, n)
+	dr := eye(n, n)
+	pl := eye(n, n)
+	pr := eye(n, n)
+	for _, s := range []struct {
+		scale []float64
+		d, p  blas64.General
+	}{
+		{lscale, dl, pl},
+		{rscale, dr, pr},
+	} {
+		for i := ilo; i <= ihi; i++ {
+			s.scale[i] = 1
+			if (job == lapack.Scale || job == lapack.PermuteScale) && ilo != ihi {
+				s.scale[i] = 2 * rnd.Float64()
+				s.d.Data[i*s.d.Stride+i] = s.scale[i]
+			}
+		}
+		if job == lapack.Permute || job == lapack.PermuteScale {
+			for i := n - 1; i > ihi; i-- {
+				s.scale[i] = float64(rnd.Intn(i + 1))
+				blas64.Swap(n,
+					blas64.Vector{s.p.Stride, s.p.Data[i:]},
+					blas64.Vector{s.p.Stride, s.p.Data[int(s.scale[i]):]})
+			}
+			for i := 0; i < ilo; i++ {
+				s.scale[i] = float64(i + rnd.Intn(ihi-i+1))
+				blas64.Swap(n,
+					blas64.Vector{s.p.Stride, s.p.Data[i:]},
+					blas64.Vector{s.p.Stride, s.p.Data[int(s.scale[i]):]})
+			}
+		} else {
+			for i := 0; i < ilo; i++ {
+				s.scale[i] = float64(i)
+			}
+			for i := ihi + 1; i < n; i++ {
+				s.scale[i] = float64(i)
+			}
+		}
+	}
+
+	got := cloneGeneral(v)
+	impl.Dggbak(job, side, n, ilo, ihi, lscale, rscale, m, got.Data, got.Stride)
+
+	prefix := fmt.Sprintf("Case job=%v, side=%v, n=%v, ilo=%v, ihi=%v, m=%v, extra=%v",
+		job, side, n, ilo, ihi, m, extra)
+
+	if !generalOutsideAllNaN(got) {
+		t.Errorf("%v: out-of-range write to V\n%v", prefix, got.Data)
+	}
+
+	d, p := dr, pr
+	if side == lapack.LeftEV {
+		d, p = dl, pl
+	}
+	// Compute P*D*V and store into want.
+	dv := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, d, v, 0, dv)
+	want := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, p, dv, 0, want)
+
+	if !equalApproxGeneral(want, got, tol) {
+		t.Errorf("%v: unexpected value of V", prefix)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dggbaler interface {
+	Dggbal(job lapack.Job, n int, a []float64, lda int, b []float64, ldb int, lscale, rscale, work []float64) (ilo, ihi int)
+}
+
+func DggbalTest(t *testing.T, impl Dggbaler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.Job{lapack.None, lapack.Permute, lapack.Scale, lapack.PermuteScale} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31, 53} {
+			for _, extra := range []int{0, 11} {
+				for cas := 0; cas < 40; cas++ {
+					a := unbalancedSparseGeneral(n, n, n+extra, n, rnd)
+					b := unbalancedSparseGeneral(n, n, n+extra, n, rnd)
+					testDggbal(t, impl, job, a, b)
+				}
+			}
+		}
+	}
+}
+
+func testDggbal(t *testing.T, impl Dggbaler, job lapack.Job, a, b blas64.General) {
+	const tol = 1e-14
+
+	n := a.Rows
+	extra := a.Stride - n
+
+	lscale := nanSlice(n)
+	rscale := nanSlice(n)
+	work := nanSlice(6 * n)
+
+	wantA := cloneGeneral(a)
+	wantB := cloneGeneral(b)
+
+	ilo, ihi := impl.Dggbal(job, n, a.Data, a.Stride, b.Data, b.Stride, lscale, rscale, work)
+
+	prefix := fmt.Sprintf("Case job=%v, n=%v, extra=%v", job, n, extra)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A\n%v", prefix, a.Data)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B\n%v", prefix, b.Data)
+	}
+
+	if n == 0 {
+		if ilo != 0 || ihi != -1 {
+			t.Errorf("%v: unexpected ilo=%v, ihi=%v when n=0. Want 0, -1", prefix, ilo, ihi)
+		}
+		return
+	}
+
+	if job == lapack.None {
+		if ilo != 0 || ihi != n-1 {
+			t.Errorf("%v: unexpected ilo=%v, ihi=%v when job=None. Want 0, %v", prefix, ilo, ihi, n-1)
+		}
+		for i := 0; i < n; i++ {
+			if lscale[i] != 1 || rscale[i] != 1 {
+				t.Errorf("%v: unexpected lscale[%v]=%v, rscale[%v]=%v when job=None. Want 1", prefix, i, lscale[i], i, rscale[i])
+				break
+			}
+		}
+		if !equalApproxGeneral(a, wantA, 0) || !equalApproxGeneral(b, wantB, 0) {
+			t.Errorf("%v: unexpected modification of A or B when job=None", prefix)
+		}
+		return
+	}
+
+	if ilo < 0 || ihi < ilo || n <= ihi {
+		t.Errorf("%v: invalid ordering of ilo=%v and ihi=%v", prefix, ilo, ihi)
+	}
+
+	// Check that A and B are upper triangular in rows and columns [0:ilo]
+	// and [ihi+1:n].
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if j >= ilo && i <= ihi {
+				continue
+			}
+			if a.Data[i*a.Stride+j] != 0 || b.Data[i*b.Stride+j] != 0 {
+				t.Errorf("%v: (A,B) is not upper triangular outside of [ilo:ihi+1] at [%v,%v]", prefix, i, j)
+			}
+		}
+	}
+
+	if job == lapack.Permute || job == lapack.PermuteScale {
+		// Create the permutation matrices P_L and P_R.
+		pl := eye(n, n)
+		pr := eye(n, n)
+		for _, p := range []struct {
+			m     blas64.General
+			scale []float64
+		}{
+			{pl, lscale},
+			{pr, rscale},
+		} {
+			for j := n - 1; j > ihi; j-- {
+				blas64.Swap(n,
+					blas64.Vector{p.m.Stride, p.m.Data[j:]},
+					blas64.Vector{p.m.Stride, p.m.Data[int(p.scale[j]):]})
+			}
+			for j := 0; j < ilo; j++ {
+				blas64.Swap(n,
+					blas64.Vector{p.m.Stride, p.m.Data[j:]},
+					blas64.Vector{p.m.Stride, p.m.Data[int(p.scale[j]):]})
+			}
+		}
+		// Compute P_L^T*A*P_R and P_L^T*B*P_R.
+		for _, want := range []blas64.General{wantA, wantB} {
+			tmp := zeros(n, n, n)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, want, pr, 0, tmp)
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, pl, tmp, 0, want)
+		}
+	}
+	if job == lapack.Scale || job == lapack.PermuteScale {
+		// Compute D_L*A*D_R and D_L*B*D_R.
+		for _, want := range []blas64.General{wantA, wantB} {
+			for i := ilo; i <= ihi; i++ {
+				for j := 0; j < n; j++ {
+					want.Data[i*want.Stride+j] *= lscale[i]
+					want.Data[j*want.Stride+i] *= rscale[i]
+				}
+			}
+		}
+	}
+	if !equalApproxGeneral(wantA, a, tol) {
+		t.Errorf("%v: unexpected value of A, ilo=%v, ihi=%v", prefix, ilo, ihi)
+	}
+	if !equalApproxGeneral(wantB, b, tol) {
+		t.Errorf("%v: unexpected value of B, ilo=%v, ihi=%v", prefix, ilo, ihi)
+	}
+}
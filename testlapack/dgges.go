@@ -0,0 +1,232 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dggeser interface {
+	Dgges(jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vsl []float64, ldvsl int, vsr []float64, ldvsr int, work []float64, lwork int, bwork []bool) (sdim int, ok bool)
+}
+
+// dggesTest is a test case for Dgges and Dggesx.
+type dggesTest struct {
+	a, b   blas64.General
+	evWant []complex128 // If nil, the eigenvalues are not known.
+}
+
+// dggesCases returns the test matrix pairs for Dgges and Dggesx.
+func dggesCases(rnd *rand.Rand) []dggesTest {
+	var tests []dggesTest
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+		tests = append(tests, dggesTest{
+			a: randomGeneral(n, n, n, rnd),
+			b: randomGeneral(n, n, n, rnd),
+		})
+		if n > 0 {
+			// The eigenvalues of (A,I) are the eigenvalues of A.
+			tests = append(tests, dggesTest{
+				a:      Circulant(n).Matrix(),
+				b:      eye(n, n),
+				evWant: Circulant(n).Eigenvalues(),
+			})
+		}
+	}
+	return tests
+}
+
+// dggesSelectors returns the eigenvalue selection functions used in the tests
+// of Dgges and Dggesx. The first one is nil which means no ordering.
+func dggesSelectors(rnd *rand.Rand) []func(alphar, alphai, beta float64) bool {
+	thresh := rnd.NormFloat64()
+	return []func(alphar, alphai, beta float64) bool{
+		nil,
+		// Select eigenvalues with the real part above a random
+		// threshold.
+		func(alphar, alphai, beta float64) bool { return alphar > thresh*beta },
+		// Select complex eigenvalues only.
+		func(alphar, alphai, beta float64) bool { return alphai != 0 },
+		// Select eigenvalues inside the unit disc.
+		func(alphar, alphai, beta float64) bool { return math.Hypot(alphar, alphai) < math.Abs(beta) },
+	}
+}
+
+func DggesTest(t *testing.T, impl Dggeser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range dggesCases(rnd) {
+		for _, jobvsl := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+			for _, jobvsr := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+				for i, selctg := range dggesSelectors(rnd) {
+					for _, extra := range []int{0, 11} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							n := test.a.Rows
+							var lwork int
+							switch wl {
+							case minimumWork:
+								lwork = 1
+								if n > 0 {
+									lwork = max(8*n, 6*n+16)
+								}
+							case optimumWork:
+								work := make([]float64, 1)
+								impl.Dgges(jobvsl, jobvsr, selctg, n, nil, max(1, n), nil, max(1, n), nil, nil, nil,
+									nil, max(1, n), nil, max(1, n), work, -1, nil)
+								lwork = int(work[0])
+							}
+							prefix := fmt.Sprintf("Dgges: n=%v, jobvsl=%v, jobvsr=%v, selctg=%v, extra=%v, work=%v",
+								n, string(jobvsl), string(jobvsr), i, extra, wl)
+							testDgges(t, prefix, test, jobvsl, jobvsr, selctg, extra, func(a, b, vsl, vsr blas64.General, alphar, alphai, beta []float64, bwork []bool) (int, bool) {
+								work := nanSlice(lwork)
+								return impl.Dgges(jobvsl, jobvsr, selctg, n, a.Data, a.Stride, b.Data, b.Stride,
+									alphar, alphai, beta, vsl.Data, vsl.Stride, vsr.Data, vsr.Stride, work, lwork, bwork)
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+// testDgges checks the generalized Schur factorization computed by dgges
+// which must call Dgges or Dggesx with the given jobvsl, jobvsr and selctg.
+func testDgges(t *testing.T, prefix string, test dggesTest, jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, extra int, dgges func(a, b, vsl, vsr blas64.General, alphar, alphai, beta []float64, bwork []bool) (sdim int, ok bool)) {
+	const tol = 1e-12
+
+	n := test.a.Rows
+	wantvsl := jobvsl == lapack.ComputeSchurVectors
+	wantvsr := jobvsr == lapack.ComputeSchurVectors
+
+	a := nanGeneral(n, n, n+extra)
+	copyGeneral(a, test.a)
+	b := nanGeneral(n, n, n+extra)
+	copyGeneral(b, test.b)
+	vsl := blas64.General{Stride: 1}
+	if wantvsl {
+		vsl = nanGeneral(n, n, n+extra)
+	}
+	vsr := blas64.General{Stride: 1}
+	if wantvsr {
+		vsr = nanGeneral(n, n, n+extra)
+	}
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+	var bwork []bool
+	if selctg != nil {
+		bwork = make([]bool, n)
+	}
+
+	sdim, ok := dgges(a, b, vsl, vsr, alphar, alphai, beta, bwork)
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if selctg == nil && sdim != 0 {
+		t.Errorf("%v: unexpected nonzero sdim=%v without ordering", prefix, sdim)
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+	if !generalOutsideAllNaN(vsl) {
+		t.Errorf("%v: out-of-range write to VSL", prefix)
+	}
+	if !generalOutsideAllNaN(vsr) {
+		t.Errorf("%v: out-of-range write to VSR", prefix)
+	}
+
+	// Check that (S,T) is in generalized Schur canonical form.
+	if !isGeneralizedSchur(a, b) {
+		t.Errorf("%v: (S,T) is not in generalized Schur canonical form", prefix)
+	}
+
+	// Check that the eigenvalues agree with the diagonal blocks of (S,T),
+	// and with the known eigenvalues if available.
+	for j := 0; j < n; j++ {
+		ev := generalizedBlockEigenvalues(a, b, j)
+		if len(ev) == 1 {
+			if alphai[j] != 0 || alphar[j] != a.Data[j*a.Stride+j] || beta[j] != b.Data[j*b.Stride+j] {
+				t.Errorf("%v: eigenvalue %v does not match the 1×1 block of (S,T)", prefix, j)
+			}
+			if beta[j] < 0 {
+				t.Errorf("%v: T[%v,%v] is negative", prefix, j, j)
+			}
+		} else if alphai[j] <= 0 || alphai[j+1] >= 0 {
+			t.Errorf("%v: eigenvalues %v and %v do not form a complex conjugate pair", prefix, j, j+1)
+		}
+		for k, want := range ev {
+			got := complex(alphar[j+k], alphai[j+k]) / complex(beta[j+k], 0)
+			if cmplx.Abs(got-want) > tol*math.Max(1, cmplx.Abs(want)) {
+				t.Errorf("%v: eigenvalue %v does not match the diagonal block of (S,T), want %v, got %v", prefix, j+k, want, got)
+			}
+			if test.evWant != nil {
+				found, _ := containsComplex(test.evWant, got, tol*math.Max(1, cmplx.Abs(got)))
+				if !found {
+					t.Errorf("%v: unexpected eigenvalue %v", prefix, got)
+				}
+			}
+		}
+		j += len(ev) - 1
+	}
+
+	// Check that the selected eigenvalues lead the generalized Schur form.
+	if selctg != nil {
+		var count int
+		for j := 0; j < n; j++ {
+			sel := selctg(alphar[j], alphai[j], beta[j])
+			if alphai[j] != 0 && j < n-1 {
+				// Both eigenvalues of a complex conjugate pair are
+				// selected if either one is.
+				sel = sel || selctg(alphar[j+1], alphai[j+1], beta[j+1])
+			}
+			if sel && j > count {
+				t.Errorf("%v: selected eigenvalue %v not at the top left", prefix, j)
+			}
+			if sel {
+				count++
+				if alphai[j] != 0 {
+					count++
+				}
+			}
+			if alphai[j] != 0 {
+				j++
+			}
+		}
+		if sdim != count {
+			t.Errorf("%v: unexpected sdim, want %v, got %v", prefix, count, sdim)
+		}
+	}
+
+	if wantvsl && !isOrthonormal(vsl) {
+		t.Errorf("%v: VSL is not orthogonal", prefix)
+	}
+	if wantvsr && !isOrthonormal(vsr) {
+		t.Errorf("%v: VSR is not orthogonal", prefix)
+	}
+	if wantvsl && wantvsr {
+		// Check that VSL^T*A*VSR == S and VSL^T*B*VSR == T.
+		if resid := qzResidual(test.a, eye(n, n), eye(n, n), vsl, vsr, a); resid > tol*float64(n) {
+			t.Errorf("%v: VSL^T*A*VSR differs from S, resid=%v", prefix, resid)
+		}
+		if resid := qzResidual(test.b, eye(n, n), eye(n, n), vsl, vsr, b); resid > tol*float64(n) {
+			t.Errorf("%v: VSL^T*B*VSR differs from T, resid=%v", prefix, resid)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dggesxer interface {
+	Dggesx(jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, sense lapack.CondJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, vsl []float64, ldvsl int, vsr []float64, ldvsr int, work []float64, lwork int, iwork []int, liwork int, bwork []bool) (sdim int, rconde, rcondv [2]float64, ok bool)
+}
+
+func DggesxTest(t *testing.T, impl Dggesxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range dggesCases(rnd) {
+		for _, jobvsl := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+			for _, jobvsr := range []lapack.SchurJob{lapack.ComputeSchurVectors, lapack.SchurJob(lapack.None)} {
+				for i, selctg := range dggesSelectors(rnd) {
+					for _, sense := range []lapack.CondJob{lapack.CondJob(lapack.None), lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+						if selctg == nil && sense != lapack.CondJob(lapack.None) {
+							continue
+						}
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							testDggesx(t, impl, test, jobvsl, jobvsr, selctg, i, sense, 11, wl)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggesx(t *testing.T, impl Dggesxer, test dggesTest, jobvsl, jobvsr lapack.SchurJob, selctg func(alphar, alphai, beta float64) bool, sel int, sense lapack.CondJob, extra int, wl worklen) {
+	// rTol allows for roundoff in the computation of rconde which can
+	// make it exceed 1 slightly.
+	const rTol = 1e-14
+
+	n := test.a.Rows
+	wantse := sense == lapack.CondEigenvalues || sense == lapack.CondBoth
+	wantsv := sense == lapack.CondSubspace || sense == lapack.CondBoth
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = 1
+		if n > 0 {
+			lwork = max(8*n, 6*n+16)
+			if sense != lapack.CondJob(lapack.None) {
+				lwork += n * n / 2
+			}
+		}
+	case optimumWork:
+		work := make([]float64, 1)
+		iwork := make([]int, 1)
+		impl.Dggesx(jobvsl, jobvsr, selctg, sense, n, nil, max(1, n), nil, max(1, n), nil, nil, nil,
+			nil, max(1, n), nil, max(1, n), work, -1, iwork, -1, nil)
+		lwork = int(work[0])
+	}
+	liwork := 1
+	if sense != lapack.CondJob(lapack.None) {
+		liwork = n + 2
+	}
+
+	prefix := fmt.Sprintf("Dggesx: n=%v, jobvsl=%v, jobvsr=%v, selctg=%v, sense=%v, extra=%v, work=%v",
+		n, string(jobvsl), string(jobvsr), sel, string(sense), extra, wl)
+
+	var rconde, rcondv [2]float64
+	testDgges(t, prefix, test, jobvsl, jobvsr, selctg, extra, func(a, b, vsl, vsr blas64.General, alphar, alphai, beta []float64, bwork []bool) (int, bool) {
+		work := nanSlice(lwork)
+		iwork := make([]int, liwork)
+		var sdim int
+		var ok bool
+		sdim, rconde, rcondv, ok = impl.Dggesx(jobvsl, jobvsr, selctg, sense, n, a.Data, a.Stride, b.Data, b.Stride,
+			alphar, alphai, beta, vsl.Data, vsl.Stride, vsr.Data, vsr.Stride, work, lwork, iwork, liwork, bwork)
+		return sdim, ok
+	})
+	if n == 0 {
+		return
+	}
+
+	for i := range rconde {
+		switch {
+		case !wantse && rconde[i] != 0:
+			t.Errorf("%v: unexpected nonzero rconde[%v]=%v", prefix, i, rconde[i])
+		case wantse && (rconde[i] <= 0 || 1+rTol < rconde[i]):
+			t.Errorf("%v: rconde[%v] out of range (0,1], got %v", prefix, i, rconde[i])
+		}
+	}
+	for i := range rcondv {
+		switch {
+		case !wantsv && rcondv[i] != 0:
+			t.Errorf("%v: unexpected nonzero rcondv[%v]=%v", prefix, i, rcondv[i])
+		case wantsv && rcondv[i] <= 0:
+			t.Errorf("%v: rcondv[%v] not positive, got %v", prefix, i, rcondv[i])
+		}
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dggev3er interface {
+	Dggev3(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+}
+
+type dggevFunc func(jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta, vl []float64, ldvl int, vr []float64, ldvr int, work []float64, lwork int) (ok bool)
+
+// dggevTest is a test case for Dggev and Dggev3.
+type dggevTest struct {
+	a, b   blas64.General
+	evWant []complex128 // If nil, the eigenvalues are not known.
+}
+
+func Dggev3Test(t *testing.T, impl Dggev3er) {
+	testDggevAll(t, "Dggev3", impl.Dggev3)
+}
+
+// testDggevAll runs the tests for Dggev or Dggev3 given by name and dggev.
+func testDggevAll(t *testing.T, name string, dggev dggevFunc) {
+	rnd := rand.New(rand.NewSource(1))
+
+	var tests []dggevTest
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31, 53} {
+		// Random pair.
+		tests = append(tests, dggevTest{
+			a: randomGeneral(n, n, n, rnd),
+			b: randomGeneral(n, n, n, rnd),
+		})
+		// Sparse unbalanced pair that allows for isolation of
+		// eigenvalues by permutation.
+		tests = append(tests, dggevTest{
+			a: unbalancedSparseGeneral(n, n, n, 2*n, rnd),
+			b: unbalancedSparseGeneral(n, n, n, 2*n, rnd),
+		})
+		// Pair with a singular B and therefore infinite eigenvalues.
+		b := randomGeneral(n, n, n, rnd)
+		if n > 1 {
+			for j := 0; j < n; j++ {
+				b.Data[j] = b.Data[b.Stride+j]
+			}
+		}
+		tests = append(tests, dggevTest{
+			a: randomGeneral(n, n, n, rnd),
+			b: b,
+		})
+		if n > 0 {
+			// Pair (D*C,D) where C is a circulant matrix and D a
+			// random nonsingular diagonal matrix. The eigenvalues
+			// of the pair are the eigenvalues of C.
+			c := Circulant(n).Matrix()
+			d := zeros(n, n, n)
+			for i := 0; i < n; i++ {
+				di := 0.5 + rnd.Float64()
+				if rnd.Intn(2) == 0 {
+					di *= -1
+				}
+				d.Data[i*d.Stride+i] = di
+				for j := 0; j < n; j++ {
+					c.Data[i*c.Stride+j] *= di
+				}
+			}
+			tests = append(tests, dggevTest{
+				a:      c,
+				b:      d,
+				evWant: Circulant(n).Eigenvalues(),
+			})
+		}
+	}
+
+	for _, test := range tests {
+		for _, jobvl := range []lapack.LeftEVJob{lapack.ComputeLeftEV, lapack.None} {
+			for _, jobvr := range []lapack.RightEVJob{lapack.ComputeRightEV, lapack.None} {
+				for _, extra := range []int{0, 11} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						testDggev(t, name, dggev, test, jobvl, jobvr, extra, wl)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggev(t *testing.T, name string, dggev dggevFunc, test dggevTest, jobvl lapack.LeftEVJob, jobvr lapack.RightEVJob, extra int, wl worklen) {
+	const tol = 1e-12
+
+	n := test.a.Rows
+	wantvl := jobvl == lapack.ComputeLeftEV
+	wantvr := jobvr == lapack.ComputeRightEV
+
+	prefix := fmt.Sprintf("%v: n=%v, jobvl=%v, jobvr=%v, extra=%v, work=%v",
+		name, n, string(jobvl), string(jobvr), extra, wl)
+
+	a := nanGeneral(n, n, n+extra)
+	copyGeneral(a, test.a)
+	b := nanGeneral(n, n, n+extra)
+	copyGeneral(b, test.b)
+	vl := blas64.General{Stride: 1}
+	if wantvl {
+		vl = nanGeneral(n, n, n+extra)
+	}
+	vr := blas64.General{Stride: 1}
+	if wantvr {
+		vr = nanGeneral(n, n, n+extra)
+	}
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 8*n)
+	case optimumWork:
+		work := make([]float64, 1)
+		dggev(jobvl, jobvr, n, nil, max(1, n), nil, max(1, n), nil, nil, nil, nil, max(1, n), nil, max(1, n), work, -1)
+		lwork = int(work[0])
+	}
+	work := nanSlice(lwork)
+
+	ok := dggev(jobvl, jobvr, n, a.Data, a.Stride, b.Data, b.Stride, alphar, alphai, beta,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, work, len(work))
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(vl) {
+		t.Errorf("%v: out-of-range write to VL", prefix)
+	}
+	if !generalOutsideAllNaN(vr) {
+		t.Errorf("%v: out-of-range write to VR", prefix)
+	}
+
+	// Check the eigenvalues.
+	for j := 0; j < n; j++ {
+		if alphai[j] == 0 {
+			continue
+		}
+		if alphai[j] < 0 || j == n-1 || alphai[j+1] >= 0 {
+			t.Errorf("%v: complex conjugate pair not in order at %v", prefix, j)
+			break
+		}
+		j++
+	}
+	if test.evWant != nil {
+		for j := 0; j < n; j++ {
+			ev := complex(alphar[j], alphai[j]) / complex(beta[j], 0)
+			found, _ := containsComplex(test.evWant, ev, tol*math.Max(1, cmplx.Abs(ev)))
+			if !found {
+				t.Errorf("%v: unexpected eigenvalue %v", prefix, ev)
+			}
+		}
+	}
+
+	// Check the eigenvectors.
+	for j := 0; j < n; j++ {
+		alpha := complex(alphar[j], alphai[j])
+		for _, v := range []struct {
+			want bool
+			left bool
+			m    blas64.General
+		}{
+			{wantvl, true, vl},
+			{wantvr, false, vr},
+		} {
+			if !v.want {
+				continue
+			}
+			var xRe, xIm []float64
+			switch {
+			case alphai[j] == 0:
+				xRe = columnOf(v.m, j)
+			case alphai[j] > 0:
+				xRe = columnOf(v.m, j)
+				xIm = columnOf(v.m, j+1)
+			default:
+				xRe = columnOf(v.m, j-1)
+				xIm = columnOf(v.m, j)
+				for i := range xIm {
+					xIm[i] *= -1
+				}
+			}
+			var vmax float64
+			for i, re := range xRe {
+				mag := math.Abs(re)
+				if xIm != nil {
+					mag += math.Abs(xIm[i])
+				}
+				vmax = math.Max(vmax, mag)
+			}
+			if math.Abs(vmax-1) > tol {
+				t.Errorf("%v: eigenvector %v not normalized, left=%v, max=%v", prefix, j, v.left, vmax)
+			}
+			if resid := generalizedEVResidual(v.left, test.a, test.b, alpha, beta[j], xRe, xIm); resid > tol {
+				t.Errorf("%v: unexpected eigenvector %v, left=%v, resid=%v", prefix, j, v.left, resid)
+			}
+		}
+	}
+
+	if wantvl || wantvr {
+		// Check that computing only the eigenvalues gives the same
+		// result.
+		copyGeneral(a, test.a)
+		copyGeneral(b, test.b)
+		alpharWant := make([]float64, n)
+		alphaiWant := make([]float64, n)
+		betaWant := make([]float64, n)
+		ok = dggev(lapack.None, lapack.None, n, a.Data, a.Stride, b.Data, b.Stride, alpharWant, alphaiWant, betaWant,
+			nil, 1, nil, 1, work, len(work))
+		if !ok {
+			t.Errorf("%v: unexpected failure computing eigenvalues only", prefix)
+			return
+		}
+		for j := 0; j < n; j++ {
+			got := complex(alphar[j], alphai[j]) * complex(betaWant[j], 0)
+			want := complex(alpharWant[j], alphaiWant[j]) * complex(beta[j], 0)
+			scale := (cmplx.Abs(complex(alphar[j], alphai[j])) + math.Abs(beta[j])) *
+				(cmplx.Abs(complex(alpharWant[j], alphaiWant[j])) + math.Abs(betaWant[j]))
+			if cmplx.Abs(got-want) > tol*scale {
+				t.Errorf("%v: eigenvalue %v differs when computing eigenvalues only", prefix, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dggglmer interface {
+	Dggglm(n, m, p int, a []float64, lda int, b []float64, ldb int, d, x, y, work []float64, lwork int) (ok bool)
+	Dgelser
+	Dgglser
+}
+
+func DggglmTest(t *testing.T, impl Dggglmer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, m, p int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{1, 0, 1},
+		{2, 1, 1},
+		{3, 2, 1},
+		{5, 2, 5},
+		{5, 5, 2},
+		{4, 0, 6},
+		{3, 3, 3},
+		{10, 3, 8},
+		{20, 10, 15},
+		{50, 20, 40},
+		{60, 40, 30},
+	} {
+		for _, extra := range []int{0, 11} {
+			for _, wl := range []worklen{minimumWork, optimumWork} {
+				testDggglm(t, impl, test.n, test.m, test.p, extra, wl, rnd)
+			}
+		}
+	}
+}
+
+func testDggglm(t *testing.T, impl Dggglmer, n, m, p, extra int, wl worklen, rnd *rand.Rand) {
+	a := blas64.General{Rows: n, Cols: m, Stride: max(1, m+extra)}
+	a.Data = randomSlice(n*a.Stride, rnd)
+	aCopy := cloneGeneral(a)
+	b := blas64.General{Rows: n, Cols: p, Stride: max(1, p+extra)}
+	b.Data = randomSlice(n*b.Stride, rnd)
+	bCopy := cloneGeneral(b)
+	d := randomSlice(n, rnd)
+	dCopy := make([]float64, n)
+	copy(dCopy, d)
+	x := nanSlice(m)
+	y := nanSlice(p)
+
+	work := make([]float64, 1)
+	impl.Dggglm(n, m, p, a.Data, a.Stride, b.Data, b.Stride, d, x, y, work, -1)
+	lwork := max(1, n+m+p)
+	if wl == optimumWork {
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	ok := impl.Dggglm(n, m, p, a.Data, a.Stride, b.Data, b.Stride, d, x, y, work, lwork)
+	errStr := fmt.Sprintf("n = %v, m = %v, p = %v, extra = %v, wl = %v", n, m, p, extra, wl)
+	if !ok {
+		t.Errorf("Dggglm failed: %s", errStr)
+		return
+	}
+	if n == 0 {
+		for _, v := range x {
+			if v != 0 {
+				t.Errorf("x not zero when n == 0: %s", errStr)
+				break
+			}
+		}
+		for _, v := range y {
+			if v != 0 {
+				t.Errorf("y not zero when n == 0: %s", errStr)
+				break
+			}
+		}
+		return
+	}
+
+	// Check that the constraint d = A*x + B*y is satisfied.
+	r := blas64.Vector{Inc: 1, Data: make([]float64, n)}
+	copy(r.Data, dCopy)
+	if m > 0 {
+		blas64.Gemv(blas.NoTrans, -1, aCopy, blas64.Vector{Inc: 1, Data: x}, 1, r)
+	}
+	if p > 0 {
+		blas64.Gemv(blas.NoTrans, -1, bCopy, blas64.Vector{Inc: 1, Data: y}, 1, r)
+	}
+	for _, v := range r.Data {
+		if math.Abs(v) > 1e-12 {
+			t.Errorf("Constraint not satisfied: d != A*x + B*y: %s", errStr)
+			break
+		}
+	}
+
+	if n == m {
+		// The constraint alone determines x and y is zero.
+		for _, v := range y {
+			if math.Abs(v) > 1e-12 {
+				t.Errorf("y not zero when n == m: %s", errStr)
+				break
+			}
+		}
+		return
+	}
+
+	// Check that y is optimal. If the columns of N form an orthonormal
+	// basis of the null space of A^T, the constraint implies
+	//  N^T*B*y = N^T*d,
+	// and y must be the minimum-norm solution of this underdetermined
+	// system, which is computed here by Dgels.
+	nm := orthComplement(impl, aCopy)
+	ntb := zeros(n-m, p, p)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, nm, bCopy, 0, ntb)
+	yWant := zeros(p, 1, 1)
+	blas64.Gemv(blas.Trans, 1, nm, blas64.Vector{Inc: 1, Data: dCopy}, 0, blas64.Vector{Inc: 1, Data: yWant.Data[:n-m]})
+	work = make([]float64, 1)
+	impl.Dgels(blas.NoTrans, n-m, p, 1, ntb.Data, ntb.Stride, yWant.Data, yWant.Stride, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgels(blas.NoTrans, n-m, p, 1, ntb.Data, ntb.Stride, yWant.Data, yWant.Stride, work, len(work))
+	for i, v := range y {
+		if math.Abs(v-yWant.Data[i]) > 1e-10 {
+			t.Errorf("Solution is not optimal: %s", errStr)
+			break
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dgghd3er interface {
+	Dgghd3(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int)
+}
+
+func Dgghd3Test(t *testing.T, impl Dgghd3er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compq := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+		for _, compz := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18} {
+				for _, ld := range []int{max(1, n), n + 5} {
+					for cas := 0; cas < 5; cas++ {
+						ilo, ihi := randomIloIhi(n, rnd)
+						testDgghd3(t, impl, compq, compz, n, ilo, ihi, ld, -1, rnd)
+					}
+				}
+			}
+		}
+	}
+	// Test the blocked code path with optimal and insufficient workspace.
+	for _, compq := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+		for _, compz := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+			const n = 160
+			for _, lwork := range []int{-1, 6 * n * 2, 6 * n * 5} {
+				testDgghd3(t, impl, compq, compz, n, 5, 150, n+3, lwork, rnd)
+			}
+		}
+	}
+}
+
+// testDgghd3 tests Dgghd3 with the given lwork. If lwork is -1, the optimal
+// workspace size is used.
+func testDgghd3(t *testing.T, impl Dgghd3er, compq, compz lapack.EVComp, n, ilo, ihi, ld, lwork int, rnd *rand.Rand) {
+	testDgghrd(t, "Dgghd3", func(a, b, q, z blas64.General) {
+		if lwork == -1 {
+			work := make([]float64, 1)
+			impl.Dgghd3(compq, compz, n, ilo, ihi, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride, work, -1)
+			lwork = int(work[0])
+		}
+		work := nanSlice(lwork)
+		impl.Dgghd3(compq, compz, n, ilo, ihi, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride, work, lwork)
+	}, compq, compz, n, ilo, ihi, ld, rnd)
+}
@@ -0,0 +1,154 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dgghrder interface {
+	Dgghrd(compq, compz lapack.EVComp, n, ilo, ihi int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int)
+}
+
+func DgghrdTest(t *testing.T, impl Dgghrder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compq := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+		for _, compz := range []lapack.EVComp{lapack.None, lapack.ExplicitQZ, lapack.UpdateQZ} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18} {
+				for _, ld := range []int{max(1, n), n + 5} {
+					for cas := 0; cas < 5; cas++ {
+						ilo, ihi := randomIloIhi(n, rnd)
+						testDgghrd(t, "Dgghrd", func(a, b, q, z blas64.General) {
+							impl.Dgghrd(compq, compz, n, ilo, ihi, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride)
+						}, compq, compz, n, ilo, ihi, ld, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+// randomIloIhi returns random ilo and ihi such that 0 <= ilo <= ihi < n if
+// n > 0, and ilo = 0, ihi = -1 if n = 0.
+func randomIloIhi(n int, rnd *rand.Rand) (ilo, ihi int) {
+	if n == 0 {
+		return 0, -1
+	}
+	ilo = rnd.Intn(n)
+	ihi = rnd.Intn(n)
+	if ilo > ihi {
+		ilo, ihi = ihi, ilo
+	}
+	return ilo, ihi
+}
+
+// testDgghrd checks a reduction of a pair (A,B) to generalized upper
+// Hessenberg form computed by reduce which must call either Dgghrd or Dgghd3
+// with the given compq and compz.
+func testDgghrd(t *testing.T, name string, reduce func(a, b, q, z blas64.General), compq, compz lapack.EVComp, n, ilo, ihi, ld int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	prefix := fmt.Sprintf("%v: compq=%v, compz=%v, n=%v, ilo=%v, ihi=%v, ld=%v",
+		name, string(compq), string(compz), n, ilo, ihi, ld)
+
+	// Generate a random general A and a random upper triangular B such
+	// that A is upper triangular in rows and columns [0:ilo] and
+	// [ihi+1:n].
+	a := randomGeneral(n, n, ld, rnd)
+	b := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if j < ilo || ihi < i {
+				a.Data[i*a.Stride+j] = 0
+			}
+			b.Data[i*b.Stride+j] = 0
+		}
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	// Q1 and Z1 are the initial orthogonal matrices, for ExplicitQZ they
+	// are the identity.
+	q1 := eye(n, n)
+	if compq == lapack.UpdateQZ {
+		q1 = randomOrthogonal(n, rnd)
+	}
+	z1 := eye(n, n)
+	if compz == lapack.UpdateQZ {
+		z1 = randomOrthogonal(n, rnd)
+	}
+	q := blas64.General{Stride: 1}
+	if compq != lapack.None {
+		q = nanGeneral(n, n, ld)
+		if compq == lapack.UpdateQZ {
+			copyGeneral(q, q1)
+		}
+	}
+	z := blas64.General{Stride: 1}
+	if compz != lapack.None {
+		z = nanGeneral(n, n, ld)
+		if compz == lapack.UpdateQZ {
+			copyGeneral(z, z1)
+		}
+	}
+
+	reduce(a, b, q, z)
+
+	if n == 0 {
+		return
+	}
+	if !isUpperHessenberg(a) {
+		t.Errorf("%v: H is not upper Hessenberg", prefix)
+	}
+	if !isUpperTriangular(b) {
+		t.Errorf("%v: T is not upper triangular", prefix)
+	}
+	if compq == lapack.None || compz == lapack.None {
+		// Without both Q and Z only the structure can be checked.
+		return
+	}
+	if !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+
+	// Check that Q^T * (Q1*A*Z1^T) * Z == H and Q^T * (Q1*B*Z1^T) * Z == T.
+	if resid := qzResidual(aCopy, q1, z1, q, z, a); resid > tol*float64(n) {
+		t.Errorf("%v: Q^T*A*Z differs from H, resid=%v", prefix, resid)
+	}
+	if resid := qzResidual(bCopy, q1, z1, q, z, b); resid > tol*float64(n) {
+		t.Errorf("%v: Q^T*B*Z differs from T, resid=%v", prefix, resid)
+	}
+}
+
+// qzResidual returns
+//  max |Q^T * (Q1*A*Z1^T) * Z - R| / max(1, max |A|)
+// for n×n matrices A, Q1, Z1, Q, Z and R.
+func qzResidual(a, q1, z1, q, z, r blas64.General) float64 {
+	n := a.Rows
+	tmp := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, a, z1, 0, tmp)
+	m := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q1, tmp, 0, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, m, z, 0, tmp)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, tmp, 0, m)
+	var resid, norm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			resid = math.Max(resid, math.Abs(m.Data[i*m.Stride+j]-r.Data[i*r.Stride+j]))
+			norm = math.Max(norm, math.Abs(a.Data[i*a.Stride+j]))
+		}
+	}
+	return resid / math.Max(1, norm)
+}
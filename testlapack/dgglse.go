@@ -0,0 +1,158 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dgglser interface {
+	Dgglse(m, n, p int, a []float64, lda int, b []float64, ldb int, c, d, x, work []float64, lwork int) (ok bool)
+	Dgeqrf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+	Dggqrfer
+}
+
+func DgglseTest(t *testing.T, impl Dgglser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, p int
+	}{
+		{0, 0, 0},
+		{1, 1, 0},
+		{0, 1, 1},
+		{1, 2, 1},
+		{3, 2, 1},
+		{5, 5, 2},
+		{10, 8, 3},
+		{2, 6, 5},
+		{4, 6, 2},
+		{3, 3, 3},
+		{20, 12, 4},
+		{50, 40, 10},
+		{30, 60, 35},
+	} {
+		for _, extra := range []int{0, 11} {
+			for _, wl := range []worklen{minimumWork, optimumWork} {
+				testDgglse(t, impl, test.m, test.n, test.p, extra, wl, rnd)
+			}
+		}
+	}
+}
+
+func testDgglse(t *testing.T, impl Dgglser, m, n, p, extra int, wl worklen, rnd *rand.Rand) {
+	a := blas64.General{Rows: m, Cols: n, Stride: max(1, n+extra)}
+	a.Data = randomSlice(m*a.Stride, rnd)
+	aCopy := cloneGeneral(a)
+	b := blas64.General{Rows: p, Cols: n, Stride: max(1, n+extra)}
+	b.Data = randomSlice(p*b.Stride, rnd)
+	bCopy := cloneGeneral(b)
+	c := randomSlice(m, rnd)
+	cCopy := make([]float64, m)
+	copy(cCopy, c)
+	d := randomSlice(p, rnd)
+	dCopy := make([]float64, p)
+	copy(dCopy, d)
+	x := nanSlice(n)
+
+	work := make([]float64, 1)
+	impl.Dgglse(m, n, p, a.Data, a.Stride, b.Data, b.Stride, c, d, x, work, -1)
+	lwork := max(1, m+n+p)
+	if wl == optimumWork {
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	ok := impl.Dgglse(m, n, p, a.Data, a.Stride, b.Data, b.Stride, c, d, x, work, lwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, p = %v, extra = %v, wl = %v", m, n, p, extra, wl)
+	if !ok {
+		t.Errorf("Dgglse failed: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	xv := blas64.Vector{Inc: 1, Data: x}
+
+	// Check that the constraint B*x = d is satisfied.
+	if p > 0 {
+		bx := blas64.Vector{Inc: 1, Data: make([]float64, p)}
+		blas64.Gemv(blas.NoTrans, 1, bCopy, xv, 0, bx)
+		for i, v := range bx.Data {
+			if math.Abs(v-dCopy[i]) > 1e-12 {
+				t.Errorf("Constraint not satisfied: B*x != d: %s", errStr)
+				break
+			}
+		}
+	}
+
+	if m == 0 {
+		return
+	}
+	// Compute the residual r = c - A*x.
+	r := blas64.Vector{Inc: 1, Data: make([]float64, m)}
+	copy(r.Data, cCopy)
+	blas64.Gemv(blas.NoTrans, -1, aCopy, xv, 1, r)
+
+	// Check that the residual sum of squares is returned in c[n-p:m].
+	var rss, rssGot float64
+	for _, v := range r.Data {
+		rss += v * v
+	}
+	for _, v := range c[n-p : m] {
+		rssGot += v * v
+	}
+	if math.Abs(rss-rssGot) > 1e-12*math.Max(1, rss) {
+		t.Errorf("Unexpected residual sum of squares: got %v, want %v: %s", rssGot, rss, errStr)
+	}
+
+	// Check that x is optimal, that is, that the gradient A^T*(c-A*x) of
+	// the objective function is orthogonal to the null space of B.
+	if n == p {
+		return
+	}
+	grad := blas64.Vector{Inc: 1, Data: make([]float64, n)}
+	blas64.Gemv(blas.Trans, 1, aCopy, r, 0, grad)
+	bt := transposeGeneral(bCopy)
+	if p == 0 {
+		bt = blas64.General{Rows: n, Cols: 0, Stride: 1}
+	}
+	nb := orthComplement(impl, bt)
+	proj := blas64.Vector{Inc: 1, Data: make([]float64, n-p)}
+	blas64.Gemv(blas.Trans, 1, nb, grad, 0, proj)
+	for _, v := range proj.Data {
+		if math.Abs(v) > 1e-10 {
+			t.Errorf("Solution is not optimal: %s", errStr)
+			break
+		}
+	}
+}
+
+// orthComplement returns an n×(n-k) matrix whose columns form an orthonormal
+// basis of the orthogonal complement of the range of the n×k matrix A, which
+// must have full column rank.
+func orthComplement(impl Dgglser, a blas64.General) blas64.General {
+	n := a.Rows
+	k := a.Cols
+	qr := zeros(n, n, n)
+	copyGeneral(qr, a)
+	tau := make([]float64, k)
+	work := make([]float64, 1)
+	impl.Dgeqrf(n, k, qr.Data, qr.Stride, tau, work, -1)
+	work = make([]float64, max(1, int(work[0])))
+	impl.Dgeqrf(n, k, qr.Data, qr.Stride, tau, work, len(work))
+	q := constructQFromQR(impl, n, k, qr.Data, qr.Stride, tau)
+	return blas64.General{
+		Rows:   n,
+		Cols:   n - k,
+		Stride: q.Stride,
+		Data:   q.Data[k:],
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dggqrfer interface {
+	Dggqrf(n, m, p int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int)
+	Dorgqr(m, n, k int, a []float64, lda int, tau, work []float64, lwork int)
+	Dorgrq(m, n, k int, a []float64, lda int, tau, work []float64, lwork int)
+}
+
+func DggqrfTest(t *testing.T, impl Dggqrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 5, 10, 40} {
+		for _, m := range []int{0, 1, 3, 10, 50} {
+			for _, p := range []int{0, 1, 4, 10, 60} {
+				for _, extra := range []int{0, 11} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						testDggqrf(t, impl, n, m, p, extra, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggqrf(t *testing.T, impl Dggqrfer, n, m, p, extra int, wl worklen, rnd *rand.Rand) {
+	a := blas64.General{Rows: n, Cols: m, Stride: max(1, m+extra)}
+	a.Data = randomSlice(n*a.Stride, rnd)
+	aCopy := cloneGeneral(a)
+	b := blas64.General{Rows: n, Cols: p, Stride: max(1, p+extra)}
+	b.Data = randomSlice(n*b.Stride, rnd)
+	bCopy := cloneGeneral(b)
+	taua := nanSlice(min(n, m))
+	taub := nanSlice(min(n, p))
+
+	work := make([]float64, 1)
+	impl.Dggqrf(n, m, p, a.Data, a.Stride, taua, b.Data, b.Stride, taub, work, -1)
+	lwork := max(1, max(n, max(m, p)))
+	if wl == optimumWork {
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	impl.Dggqrf(n, m, p, a.Data, a.Stride, taua, b.Data, b.Stride, taub, work, lwork)
+	errStr := fmt.Sprintf("n = %v, m = %v, p = %v, extra = %v, wl = %v", n, m, p, extra, wl)
+	if n == 0 {
+		return
+	}
+
+	// Construct the n×n orthogonal matrix Q from the QR factorization of A.
+	q := constructQFromQR(impl, n, n, a.Data, a.Stride, taua)
+	if !isOrthonormal(q) {
+		t.Errorf("Q is not orthogonal: %s", errStr)
+	}
+	if m > 0 {
+		// Check that A = Q * R.
+		r := extractUpperTrapezoid(a, 0)
+		checkProduct(t, "A != Q*R", errStr, aCopy, q, r)
+	}
+
+	if p == 0 {
+		return
+	}
+	// Construct the p×p orthogonal matrix Z from the RQ factorization of B.
+	z := constructQFromRQ(impl, p, b.Data[max(0, n-p)*b.Stride:], b.Stride, taub)
+	if !isOrthonormal(z) {
+		t.Errorf("Z is not orthogonal: %s", errStr)
+	}
+	// Check that B = Q * T * Z.
+	tm := extractUpperTrapezoid(b, p-n)
+	qt := zeros(n, p, p)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, tm, 0, qt)
+	checkProduct(t, "B != Q*T*Z", errStr, bCopy, qt, z)
+}
+
+// constructQFromQR returns the m×m orthogonal matrix Q defined by the first k
+// columns of the m×n matrix A and tau as returned by Dgeqrf, where
+// k = len(tau).
+func constructQFromQR(impl Dggqrfer, m, n int, a []float64, lda int, tau []float64) blas64.General {
+	k := len(tau)
+	q := zeros(m, m, max(1, m))
+	for i := 0; i < m; i++ {
+		copy(q.Data[i*q.Stride:i*q.Stride+k], a[i*lda:i*lda+k])
+	}
+	work := make([]float64, 1)
+	impl.Dorgqr(m, m, k, q.Data, q.Stride, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dorgqr(m, m, k, q.Data, q.Stride, tau, work, len(work))
+	return q
+}
+
+// constructQFromRQ returns the n×n orthogonal matrix Q defined by the k rows
+// of length n stored in a and tau as returned by Dgerqf, where k = len(tau).
+func constructQFromRQ(impl Dggqrfer, n int, a []float64, lda int, tau []float64) blas64.General {
+	k := len(tau)
+	q := zeros(n, n, max(1, n))
+	for i := 0; i < k; i++ {
+		copy(q.Data[(n-k+i)*q.Stride:(n-k+i+1)*q.Stride], a[i*lda:i*lda+n])
+	}
+	work := make([]float64, 1)
+	impl.Dorgrq(n, n, k, q.Data, q.Stride, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dorgrq(n, n, k, q.Data, q.Stride, tau, work, len(work))
+	return q
+}
+
+// extractUpperTrapezoid returns a copy of the general matrix A in which the
+// elements A[i,j] with j-i < d have been set to zero.
+func extractUpperTrapezoid(a blas64.General, d int) blas64.General {
+	r := zeros(a.Rows, a.Cols, max(1, a.Cols))
+	for i := 0; i < a.Rows; i++ {
+		for j := max(0, i+d); j < a.Cols; j++ {
+			r.Data[i*r.Stride+j] = a.Data[i*a.Stride+j]
+		}
+	}
+	return r
+}
+
+// checkProduct checks that the general matrix want is equal to the product
+// of the general matrices x and y.
+func checkProduct(t *testing.T, msg, errStr string, want, x, y blas64.General) {
+	got := zeros(want.Rows, want.Cols, max(1, want.Cols))
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, x, y, 0, got)
+	if !equalApproxGeneral(got, want, 1e-13) {
+		t.Errorf("%s: %s", msg, errStr)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dggrqfer interface {
+	Dggqrfer
+	Dggrqf(m, p, n int, a []float64, lda int, taua, b []float64, ldb int, taub, work []float64, lwork int)
+}
+
+func DggrqfTest(t *testing.T, impl Dggrqfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, m := range []int{0, 1, 2, 5, 10, 40} {
+		for _, p := range []int{0, 1, 3, 10, 50} {
+			for _, n := range []int{0, 1, 4, 10, 60} {
+				for _, extra := range []int{0, 11} {
+					for _, wl := range []worklen{minimumWork, optimumWork} {
+						testDggrqf(t, impl, m, p, n, extra, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDggrqf(t *testing.T, impl Dggrqfer, m, p, n, extra int, wl worklen, rnd *rand.Rand) {
+	a := blas64.General{Rows: m, Cols: n, Stride: max(1, n+extra)}
+	a.Data = randomSlice(m*a.Stride, rnd)
+	aCopy := cloneGeneral(a)
+	b := blas64.General{Rows: p, Cols: n, Stride: max(1, n+extra)}
+	b.Data = randomSlice(p*b.Stride, rnd)
+	bCopy := cloneGeneral(b)
+	taua := nanSlice(min(m, n))
+	taub := nanSlice(min(p, n))
+
+	work := make([]float64, 1)
+	impl.Dggrqf(m, p, n, a.Data, a.Stride, taua, b.Data, b.Stride, taub, work, -1)
+	lwork := max(1, max(n, max(m, p)))
+	if wl == optimumWork {
+		lwork = int(work[0])
+	}
+	work = nanSlice(lwork)
+
+	impl.Dggrqf(m, p, n, a.Data, a.Stride, taua, b.Data, b.Stride, taub, work, lwork)
+	errStr := fmt.Sprintf("m = %v, p = %v, n = %v, extra = %v, wl = %v", m, p, n, extra, wl)
+	if n == 0 {
+		return
+	}
+
+	// Construct the n×n orthogonal matrix Q from the RQ factorization of A.
+	q := constructQFromRQ(impl, n, a.Data[max(0, m-n)*a.Stride:], a.Stride, taua)
+	if !isOrthonormal(q) {
+		t.Errorf("Q is not orthogonal: %s", errStr)
+	}
+	if m > 0 {
+		// Check that A = R * Q.
+		r := extractUpperTrapezoid(a, n-m)
+		checkProduct(t, "A != R*Q", errStr, aCopy, r, q)
+	}
+
+	if p == 0 {
+		return
+	}
+	// Construct the p×p orthogonal matrix Z from the QR factorization of B.
+	z := constructQFromQR(impl, p, n, b.Data, b.Stride, taub)
+	if !isOrthonormal(z) {
+		t.Errorf("Z is not orthogonal: %s", errStr)
+	}
+	// Check that B = Z * T * Q.
+	tm := extractUpperTrapezoid(b, 0)
+	zt := zeros(p, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, z, tm, 0, zt)
+	checkProduct(t, "B != Z*T*Q", errStr, bCopy, zt, q)
+}
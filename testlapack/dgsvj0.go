@@ -0,0 +1,178 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dgsvj0er interface {
+	Dgsvj0(jobv lapack.RightSVJob, m, n int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64, lwork int) (ok bool)
+}
+
+func Dgsvj0Test(t *testing.T, impl Dgsvj0er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, mv, ld int
+	}{
+		{1, 1, 1, 0},
+		{5, 1, 3, 0},
+		{5, 5, 5, 0},
+		{9, 5, 7, 0},
+		{9, 5, 7, 12},
+		{20, 20, 20, 0},
+		{40, 20, 30, 0},
+		{40, 20, 30, 45},
+	} {
+		for _, nsweep := range []int{1, 2, 30} {
+			testDgsvj(t, rnd, test.m, test.n, test.mv, test.ld, nsweep, "Dgsvj0", true,
+				func(jobv lapack.RightSVJob, m, n int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64) bool {
+					return impl.Dgsvj0(jobv, m, n, a, lda, d, sva, mv, v, ldv, eps, sfmin, tol, nsweep, work, len(work))
+				})
+		}
+	}
+}
+
+// testDgsvj checks a pre-processor for Dgesvj called through dgsvj. The
+// result with jobv == lapack.ComputeRightSV is checked against the input
+// matrix, and the results with the other values of jobv against that result.
+// If orth is true and the pre-processor reports convergence, all pairs of
+// columns must be numerically orthogonal.
+func testDgsvj(t *testing.T, rnd *rand.Rand, m, n, mv, ld, nsweep int, name string, orth bool,
+	dgsvj func(jobv lapack.RightSVJob, m, n int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64) bool) {
+	const tol = 1e-12
+
+	lda := ld
+	if lda == 0 {
+		lda = n
+	}
+	ldv := ld
+	if ldv == 0 {
+		ldv = n
+	}
+	sweepTol := math.Sqrt(float64(m)) * dlamchE
+
+	aIn := randomGeneral(m, n, lda, rnd)
+	dIn := make([]float64, n)
+	for i := range dIn {
+		dIn[i] = 0.5 + rnd.Float64()
+	}
+	// gIn is A*diag(d) on entry.
+	gIn := scaledColumns(aIn, dIn)
+	svaIn := make([]float64, n)
+	for j := range svaIn {
+		svaIn[j] = blas64.Nrm2(m, blas64.Vector{Inc: gIn.Stride, Data: gIn.Data[j:]})
+	}
+	gnorm := math.Max(1, floats.Norm(gIn.Data, 2))
+	vApply := randomGeneral(mv, n, ldv, rnd)
+
+	var gWant, vWant blas64.General
+	for _, jobv := range []lapack.RightSVJob{lapack.ComputeRightSV, lapack.ApplyRightSV, lapack.None} {
+		prefix := fmt.Sprintf("Case %v jobv=%c,m=%v,n=%v,mv=%v,ld=%v,nsweep=%v:", name, jobv, m, n, mv, ld, nsweep)
+
+		a := cloneGeneral(aIn)
+		d := make([]float64, n)
+		copy(d, dIn)
+		sva := make([]float64, n)
+		copy(sva, svaIn)
+		var v blas64.General
+		switch jobv {
+		case lapack.ComputeRightSV:
+			v = eye(n, ldv)
+		case lapack.ApplyRightSV:
+			v = cloneGeneral(vApply)
+		default:
+			v = blas64.General{Stride: 1}
+		}
+		work := nanSlice(m)
+
+		ok := dgsvj(jobv, m, n, a.Data, a.Stride, d, sva, mv, v.Data, v.Stride, dlamchE, dlamchS, sweepTol, nsweep, work)
+
+		g := scaledColumns(a, d)
+		for j := 0; j < n; j++ {
+			nrm := blas64.Nrm2(m, blas64.Vector{Inc: g.Stride, Data: g.Data[j:]})
+			if math.Abs(sva[j]-nrm) > tol*gnorm {
+				t.Errorf("%v sva[%v]=%v, want %v", prefix, j, sva[j], nrm)
+			}
+			if j > 0 && sva[j] > sva[j-1] {
+				t.Errorf("%v sva not non-increasing at %v", prefix, j)
+			}
+		}
+
+		switch jobv {
+		case lapack.ComputeRightSV:
+			// The transformations of the columns of a are accumulated
+			// in v, so A = A_in*V.
+			av := zeros(m, n, n)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aIn, v, 0, av)
+			if !equalApproxGeneral(av, a, tol*gnorm) {
+				t.Errorf("%v A != A_in*V", prefix)
+			}
+			// A*diag(d) = A_in*diag(d_in)*Q with Q orthogonal, so
+			// Q = diag(d_in)^{-1}*V*diag(d).
+			q := zeros(n, n, n)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					q.Data[i*q.Stride+j] = v.Data[i*v.Stride+j] / dIn[i] * d[j]
+				}
+			}
+			if !isOrthonormal(q) {
+				t.Errorf("%v diag(d_in)^{-1}*V*diag(d) is not orthogonal", prefix)
+			}
+			gWant = g
+			vWant = v
+		case lapack.ApplyRightSV:
+			// The same rotations must have been applied to v.
+			want := zeros(mv, n, n)
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, vApply, vWant, 0, want)
+			if !equalApproxGeneral(v, want, tol*math.Max(1, floats.Norm(vApply.Data, 2))) {
+				t.Errorf("%v V != V_in*V", prefix)
+			}
+			fallthrough
+		default:
+			if !equalApproxGeneral(g, gWant, tol*gnorm) {
+				t.Errorf("%v A*diag(d) differs from the result with jobv=%c", prefix, lapack.ComputeRightSV)
+			}
+		}
+
+		if !orth || jobv != lapack.ComputeRightSV || !ok {
+			continue
+		}
+		// The columns of A*diag(d) are orthogonal within the tolerance.
+		for p := 0; p < n; p++ {
+			for q := p + 1; q < n; q++ {
+				if sva[p] == 0 || sva[q] == 0 {
+					continue
+				}
+				dot := blas64.Dot(m,
+					blas64.Vector{Inc: g.Stride, Data: g.Data[p:]},
+					blas64.Vector{Inc: g.Stride, Data: g.Data[q:]},
+				)
+				if cos := math.Abs(dot) / sva[p] / sva[q]; cos > 100*float64(n)*sweepTol {
+					t.Errorf("%v columns %v and %v not orthogonal after convergence: cos=%v", prefix, p, q, cos)
+				}
+			}
+		}
+	}
+}
+
+// scaledColumns returns the m×n matrix A*diag(d) with stride n.
+func scaledColumns(a blas64.General, d []float64) blas64.General {
+	g := zeros(a.Rows, a.Cols, a.Cols)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			g.Data[i*g.Stride+j] = a.Data[i*a.Stride+j] * d[j]
+		}
+	}
+	return g
+}
@@ -0,0 +1,43 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/lapack"
+)
+
+type Dgsvj1er interface {
+	Dgsvj1(jobv lapack.RightSVJob, m, n, n1 int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64, lwork int) (ok bool)
+}
+
+func Dgsvj1Test(t *testing.T, impl Dgsvj1er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, n1, mv, ld int
+	}{
+		{1, 1, 1, 1, 0},
+		{2, 2, 1, 2, 0},
+		{5, 5, 2, 5, 0},
+		{9, 5, 3, 7, 0},
+		{9, 5, 3, 7, 12},
+		{20, 20, 10, 20, 0},
+		{40, 20, 5, 30, 0},
+		{40, 20, 15, 30, 45},
+	} {
+		n1 := test.n1
+		for _, nsweep := range []int{1, 2, 30} {
+			// Only the pairs with one column in each block are
+			// rotated, so the columns are not orthogonal on
+			// convergence.
+			testDgsvj(t, rnd, test.m, test.n, test.mv, test.ld, nsweep, "Dgsvj1", false,
+				func(jobv lapack.RightSVJob, m, n int, a []float64, lda int, d, sva []float64, mv int, v []float64, ldv int, eps, sfmin, tol float64, nsweep int, work []float64) bool {
+					return impl.Dgsvj1(jobv, m, n, n1, a, lda, d, sva, mv, v, ldv, eps, sfmin, tol, nsweep, work, len(work))
+				})
+		}
+	}
+}
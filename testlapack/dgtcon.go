@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/lapack"
+)
+
+type Dgtconer interface {
+	Dgtcon(norm lapack.MatrixNorm, n int, dl, d, du, du2 []float64, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dgttrfer
+	Dgetrier
+	Dlanger
+}
+
+func DgtconTest(t *testing.T, impl Dgtconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxColumnSum, lapack.MaxRowSum} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+			for _, singular := range []bool{false, true} {
+				for cas := 0; cas < 5; cas++ {
+					testDgtcon(t, impl, norm, n, singular, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgtcon(t *testing.T, impl Dgtconer, norm lapack.MatrixNorm, n int, singular bool, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("norm = %v, n = %v, singular = %v", string(norm), n, singular)
+
+	dl := randomSlice(max(0, n-1), rnd)
+	d := randomSlice(n, rnd)
+	du := randomSlice(max(0, n-1), rnd)
+	if singular && n > 0 {
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		d[j] = 0
+		if j < n-1 {
+			dl[j] = 0
+		}
+		if j > 0 {
+			du[j-1] = 0
+		}
+	}
+	a := tridiagToGeneral(n, dl, d, du)
+	work := nanSlice(max(1, 4*n))
+	iwork := make([]int, n)
+	anorm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+
+	du2 := make([]float64, max(0, n-2))
+	ipiv := make([]int, n)
+	ok := impl.Dgttrf(n, dl, d, du, du2, ipiv)
+
+	got := impl.Dgtcon(norm, n, dl, d, du, du2, ipiv, anorm, work, iwork)
+	if n == 0 {
+		if got != 1 {
+			t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+		}
+		return
+	}
+	if !ok {
+		if got != 0 {
+			t.Errorf("Unexpected rcond for singular U: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+
+	// Compute the true reciprocal condition number from the inverse of A.
+	ipivGe := make([]int, n)
+	impl.Dgetrf(n, n, a.Data, a.Stride, ipivGe)
+	impl.Dgetri(n, a.Data, a.Stride, ipivGe, work, -1)
+	lwork := int(work[0])
+	work = make([]float64, lwork)
+	if !impl.Dgetri(n, a.Data, a.Stride, ipivGe, work, lwork) {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	ainvnorm := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+	want := 1 / anorm / ainvnorm
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimated reciprocal condition number must not be smaller than the true
+	// one.
+	if got < want*(1-1e-8) || got > 10*want || math.IsNaN(got) {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+	}
+}
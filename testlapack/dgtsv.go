@@ -0,0 +1,132 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dgtsver interface {
+	Dgtsv(n, nrhs int, dl, d, du []float64, b []float64, ldb int) (ok bool)
+	Dgttrfer
+}
+
+func DgtsvTest(t *testing.T, impl Dgtsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, nrhs := range []int{0, 1, 3} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+				for _, singular := range []bool{false, true} {
+					testDgtsv(t, impl, n, nrhs, ldb, singular, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgtsv(t *testing.T, impl Dgtsver, n, nrhs, ldb int, singular bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("n = %v, nrhs = %v, ldb = %v, singular = %v", n, nrhs, ldb, singular)
+
+	dl := randomSlice(max(0, n-1), rnd)
+	d := randomSlice(n, rnd)
+	du := randomSlice(max(0, n-1), rnd)
+	if singular {
+		if n == 0 {
+			return
+		}
+		// Make the last column of A zero so that the singularity is
+		// detected only after all of the elimination steps.
+		d[n-1] = 0
+		if n > 1 {
+			du[n-2] = 0
+		}
+	}
+	a := tridiagToGeneral(n, dl, d, du)
+
+	// Compute the expected factor U.
+	dlFac := make([]float64, len(dl))
+	copy(dlFac, dl)
+	dFac := make([]float64, len(d))
+	copy(dFac, d)
+	duFac := make([]float64, len(du))
+	copy(duFac, du)
+	du2 := make([]float64, max(0, n-2))
+	impl.Dgttrf(n, dlFac, dFac, duFac, du2, make([]int, n))
+
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dgtsv(n, nrhs, dl, d, du, b.Data, b.Stride)
+	if singular {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+
+	// Check that d, du and dl contain the diagonal, the first and the second
+	// superdiagonal of U, respectively.
+	for i := 0; i < n; i++ {
+		if math.Abs(d[i]-dFac[i]) > tol*math.Max(1, math.Abs(dFac[i])) {
+			t.Errorf("Unexpected diagonal of U: %s", errStr)
+			break
+		}
+	}
+	for i := 0; i < n-1; i++ {
+		if math.Abs(du[i]-duFac[i]) > tol*math.Max(1, math.Abs(duFac[i])) {
+			t.Errorf("Unexpected first superdiagonal of U: %s", errStr)
+			break
+		}
+	}
+	for i := 0; i < n-2; i++ {
+		if math.Abs(dl[i]-du2[i]) > tol*math.Max(1, math.Abs(du2[i])) {
+			t.Errorf("Unexpected second superdiagonal of U: %s", errStr)
+			break
+		}
+	}
+
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the solution satisfies A*X = B by computing the normwise
+	// residual
+	//  |A*X - B| / (|A| * |X|)
+	// in the max-abs norm.
+	var anorm, xnorm float64
+	for i := 0; i < n; i++ {
+		var rowSum float64
+		for j := 0; j < n; j++ {
+			rowSum += math.Abs(a.Data[i*a.Stride+j])
+		}
+		anorm = math.Max(anorm, rowSum)
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	r := cloneGeneral(bCopy)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, b, -1, r)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			resid = math.Max(resid, math.Abs(r.Data[i*r.Stride+j]))
+		}
+	}
+	if resid > tol*anorm*xnorm || math.IsNaN(resid) {
+		t.Errorf("A*X != B, residual = %v: %s", resid/anorm/xnorm, errStr)
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+type Dgttrfer interface {
+	Dgttrf(n int, dl, d, du, du2 []float64, ipiv []int) (ok bool)
+}
+
+func DgttrfTest(t *testing.T, impl Dgttrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, singular := range []bool{false, true} {
+			for cas := 0; cas < 5; cas++ {
+				testDgttrf(t, impl, n, singular, rnd)
+			}
+		}
+	}
+}
+
+func testDgttrf(t *testing.T, impl Dgttrfer, n int, singular bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("n = %v, singular = %v", n, singular)
+
+	dl := randomSlice(max(0, n-1), rnd)
+	d := randomSlice(n, rnd)
+	du := randomSlice(max(0, n-1), rnd)
+	if singular {
+		if n == 0 {
+			return
+		}
+		// Make one column of A zero.
+		j := rnd.Intn(n)
+		d[j] = 0
+		if j < n-1 {
+			dl[j] = 0
+		}
+		if j > 0 {
+			du[j-1] = 0
+		}
+	}
+	a := tridiagToGeneral(n, dl, d, du)
+
+	du2 := nanSlice(max(0, n-2))
+	ipiv := make([]int, n)
+	for i := range ipiv {
+		ipiv[i] = -1
+	}
+	ok := impl.Dgttrf(n, dl, d, du, du2, ipiv)
+	if singular && ok {
+		t.Errorf("Singular matrix not detected: %s", errStr)
+	}
+	if !singular && !ok {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+	}
+
+	for i, p := range ipiv {
+		if (p != i && p != i+1) || p >= n {
+			t.Errorf("Pivot index out of range: %s", errStr)
+			break
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+
+	// Reconstruct the matrix A from its factorization
+	//  A = P(0) * L(0) * ... * P(n-2) * L(n-2) * U
+	// starting with the upper triangular matrix U.
+	lu := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		lu.Data[i*n+i] = d[i]
+		if i < n-1 {
+			lu.Data[i*n+i+1] = du[i]
+		}
+		if i < n-2 {
+			lu.Data[i*n+i+2] = du2[i]
+		}
+	}
+	for j := n - 2; j >= 0; j-- {
+		for k := 0; k < n; k++ {
+			lu.Data[(j+1)*n+k] += dl[j] * lu.Data[j*n+k]
+		}
+		if p := ipiv[j]; p != j {
+			for k := 0; k < n; k++ {
+				lu.Data[j*n+k], lu.Data[p*n+k] = lu.Data[p*n+k], lu.Data[j*n+k]
+			}
+		}
+	}
+	if !equalApproxGeneral(lu, a, tol) {
+		t.Errorf("P*L*U != A: %s", errStr)
+	}
+}
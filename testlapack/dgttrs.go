@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dgttrser interface {
+	Dgttrfer
+	Dgttrs(trans blas.Transpose, n, nrhs int, dl, d, du, du2 []float64, ipiv []int, b []float64, ldb int)
+}
+
+func DgttrsTest(t *testing.T, impl Dgttrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans, blas.ConjTrans} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, nrhs := range []int{0, 1, 3} {
+				for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+					testDgttrs(t, impl, trans, n, nrhs, ldb, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDgttrs(t *testing.T, impl Dgttrser, trans blas.Transpose, n, nrhs, ldb int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("trans = %v, n = %v, nrhs = %v, ldb = %v", trans, n, nrhs, ldb)
+
+	dl := randomSlice(max(0, n-1), rnd)
+	d := randomSlice(n, rnd)
+	du := randomSlice(max(0, n-1), rnd)
+	a := tridiagToGeneral(n, dl, d, du)
+	du2 := make([]float64, max(0, n-2))
+	ipiv := make([]int, n)
+	if !impl.Dgttrf(n, dl, d, du, du2, ipiv) {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	fac := make([]float64, 0, 4*n)
+	fac = append(append(append(append(fac, dl...), d...), du...), du2...)
+
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	impl.Dgttrs(trans, n, nrhs, dl, d, du, du2, ipiv, b.Data, b.Stride)
+
+	got := make([]float64, 0, 4*n)
+	got = append(append(append(append(got, dl...), d...), du...), du2...)
+	if !floats.Same(got, fac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the solution satisfies op(A)*X = B by computing the
+	// normwise residual
+	//  |op(A)*X - B| / (|op(A)| * |X|)
+	// in the max-abs norm. A random tridiagonal matrix can be badly
+	// conditioned, so X is not compared elementwise.
+	var anorm, xnorm float64
+	for i := 0; i < n; i++ {
+		var rowSum, colSum float64
+		for j := 0; j < n; j++ {
+			rowSum += math.Abs(a.Data[i*a.Stride+j])
+			colSum += math.Abs(a.Data[j*a.Stride+i])
+		}
+		anorm = math.Max(anorm, math.Max(rowSum, colSum))
+		for j := 0; j < nrhs; j++ {
+			xnorm = math.Max(xnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+	}
+	r := cloneGeneral(bCopy)
+	blas64.Gemm(trans, blas.NoTrans, 1, a, b, -1, r)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			resid = math.Max(resid, math.Abs(r.Data[i*r.Stride+j]))
+		}
+	}
+	if resid > tol*anorm*xnorm || math.IsNaN(resid) {
+		t.Errorf("op(A)*X != B, residual = %v: %s", resid/anorm/xnorm, errStr)
+	}
+}
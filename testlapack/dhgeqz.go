@@ -0,0 +1,195 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/lapack"
+)
+
+type Dhgeqzer interface {
+	Dhgeqz(job lapack.EVJob, compq, compz lapack.EVComp, n, ilo, ihi int, h []float64, ldh int, t []float64, ldt int, alphar, alphai, beta, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int) (unconverged int)
+}
+
+func DhgeqzTest(t *testing.T, impl Dhgeqzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, comp := range []lapack.EVComp{lapack.ExplicitQZ, lapack.UpdateQZ} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31, 53} {
+			for _, ld := range []int{max(1, n), n + 5} {
+				for _, singular := range []bool{false, true} {
+					for cas := 0; cas < 10; cas++ {
+						ilo, ihi := randomIloIhi(n, rnd)
+						testDhgeqz(t, impl, comp, n, ilo, ihi, ld, singular, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDhgeqz(t *testing.T, impl Dhgeqzer, comp lapack.EVComp, n, ilo, ihi, ld int, singular bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	prefix := fmt.Sprintf("comp=%v, n=%v, ilo=%v, ihi=%v, ld=%v, singular=%v",
+		string(comp), n, ilo, ihi, ld, singular)
+
+	// Generate a random upper Hessenberg H that is upper triangular in rows
+	// and columns [0:ilo] and [ihi+1:n], and a random upper triangular T.
+	// If singular is true, some diagonal elements of T are set to zero so
+	// that the pair has infinite eigenvalues.
+	h := randomHessenberg(n, ld, rnd)
+	for i := 1; i < n; i++ {
+		if i-1 < ilo || ihi < i {
+			h.Data[i*h.Stride+i-1] = 0
+		}
+	}
+	tm := randomGeneral(n, n, ld, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			tm.Data[i*tm.Stride+j] = 0
+		}
+		if singular && rnd.Intn(3) == 0 {
+			tm.Data[i*tm.Stride+i] = 0
+		}
+	}
+	hCopy := cloneGeneral(h)
+	tCopy := cloneGeneral(tm)
+
+	q1 := eye(n, n)
+	z1 := eye(n, n)
+	q := nanGeneral(n, n, ld)
+	z := nanGeneral(n, n, ld)
+	if comp == lapack.UpdateQZ {
+		q1 = randomOrthogonal(n, rnd)
+		z1 = randomOrthogonal(n, rnd)
+		copyGeneral(q, q1)
+		copyGeneral(z, z1)
+	}
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+	work := make([]float64, 1)
+	impl.Dhgeqz(lapack.EigenvaluesAndSchur, comp, comp, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, -1)
+	work = nanSlice(int(work[0]))
+	unconverged := impl.Dhgeqz(lapack.EigenvaluesAndSchur, comp, comp, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, len(work))
+	if unconverged != 0 {
+		t.Errorf("%v: QZ iteration did not converge, unconverged=%v", prefix, unconverged)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !generalOutsideAllNaN(h) {
+		t.Errorf("%v: out-of-range write to H", prefix)
+	}
+	if !generalOutsideAllNaN(tm) {
+		t.Errorf("%v: out-of-range write to T", prefix)
+	}
+	if !isUpperTriangular(tm) {
+		t.Errorf("%v: P is not upper triangular", prefix)
+	}
+	if !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+	if resid := qzResidual(hCopy, q1, z1, q, z, h); resid > tol*float64(n) {
+		t.Errorf("%v: Q^T*H*Z differs from S, resid=%v", prefix, resid)
+	}
+	if resid := qzResidual(tCopy, q1, z1, q, z, tm); resid > tol*float64(n) {
+		t.Errorf("%v: Q^T*T*Z differs from P, resid=%v", prefix, resid)
+	}
+
+	// Check the structure of (S,P) and that it is consistent with the
+	// computed eigenvalues.
+	for j := 0; j < n; j++ {
+		if beta[j] < 0 {
+			t.Errorf("%v: beta[%v]=%v is negative", prefix, j, beta[j])
+		}
+		if 0 < j && j < n-1 && h.Data[j*h.Stride+j-1] != 0 && h.Data[(j+1)*h.Stride+j] != 0 {
+			t.Errorf("%v: S has two consecutive nonzero subdiagonal elements at row %v", prefix, j)
+		}
+		for i := j + 2; i < n; i++ {
+			if h.Data[i*h.Stride+j] != 0 {
+				t.Errorf("%v: S is not quasi-triangular at [%v,%v]", prefix, i, j)
+			}
+		}
+	}
+	for j := 0; j < n; j++ {
+		if j == n-1 || h.Data[(j+1)*h.Stride+j] == 0 {
+			// 1×1 block.
+			if alphai[j] != 0 {
+				t.Errorf("%v: unexpected nonzero alphai[%v]=%v for a 1×1 block", prefix, j, alphai[j])
+			}
+			if alphar[j] != h.Data[j*h.Stride+j] || beta[j] != tm.Data[j*tm.Stride+j] {
+				t.Errorf("%v: eigenvalue %v does not match the diagonal of (S,P)", prefix, j)
+			}
+			continue
+		}
+		// 2×2 block.
+		// The eigenvalues of the pair must be complex conjugates, however
+		// alpha and beta are scaled differently for each of them.
+		d := complex(alphar[j], alphai[j])*complex(beta[j+1], 0) - complex(alphar[j+1], -alphai[j+1])*complex(beta[j], 0)
+		if alphai[j] <= 0 || alphai[j+1] >= 0 || cmplx.Abs(d) > tol*cmplx.Abs(complex(alphar[j], alphai[j]))*beta[j+1] {
+			t.Errorf("%v: unexpected complex conjugate pair at %v: alpha=(%v,%v), (%v,%v)",
+				prefix, j, alphar[j], alphai[j], alphar[j+1], alphai[j+1])
+		}
+		p00 := tm.Data[j*tm.Stride+j]
+		p01 := tm.Data[j*tm.Stride+j+1]
+		p11 := tm.Data[(j+1)*tm.Stride+j+1]
+		if p01 != 0 || p00 <= 0 || p11 <= 0 {
+			t.Errorf("%v: 2×2 block of P at %v is not in positive diagonal form", prefix, j)
+		}
+		// Check that det(beta*S_jj - alpha*P_jj) is small.
+		alpha := complex(alphar[j], alphai[j])
+		b := complex(beta[j], 0)
+		s00 := complex(h.Data[j*h.Stride+j], 0)
+		s01 := complex(h.Data[j*h.Stride+j+1], 0)
+		s10 := complex(h.Data[(j+1)*h.Stride+j], 0)
+		s11 := complex(h.Data[(j+1)*h.Stride+j+1], 0)
+		det := (b*s00-alpha*complex(p00, 0))*(b*s11-alpha*complex(p11, 0)) - b*s01*b*s10
+		scale := (cmplx.Abs(b) + cmplx.Abs(alpha)) * (math.Abs(h.Data[j*h.Stride+j]) + math.Abs(h.Data[(j+1)*h.Stride+j+1]) +
+			math.Abs(h.Data[j*h.Stride+j+1]) + math.Abs(h.Data[(j+1)*h.Stride+j]) + p00 + p11)
+		if cmplx.Abs(det) > tol*scale*scale {
+			t.Errorf("%v: eigenvalue %v does not match the 2×2 block of (S,P), |det|=%v", prefix, j, cmplx.Abs(det))
+		}
+		j++
+	}
+
+	// Check that computing eigenvalues only gives the same eigenvalues.
+	copyGeneral(h, hCopy)
+	copyGeneral(tm, tCopy)
+	alpharWant := make([]float64, n)
+	alphaiWant := make([]float64, n)
+	betaWant := make([]float64, n)
+	copy(alpharWant, alphar)
+	copy(alphaiWant, alphai)
+	copy(betaWant, beta)
+	unconverged = impl.Dhgeqz(lapack.EigenvaluesOnly, lapack.None, lapack.None, n, ilo, ihi, h.Data, h.Stride, tm.Data, tm.Stride,
+		alphar, alphai, beta, nil, 1, nil, 1, work, len(work))
+	if unconverged != 0 {
+		t.Errorf("%v: QZ iteration for eigenvalues only did not converge", prefix)
+		return
+	}
+	for j := 0; j < n; j++ {
+		got := complex(alphar[j], alphai[j]) * complex(betaWant[j], 0)
+		want := complex(alpharWant[j], alphaiWant[j]) * complex(beta[j], 0)
+		scale := (cmplx.Abs(complex(alphar[j], alphai[j])) + beta[j]) *
+			(cmplx.Abs(complex(alpharWant[j], alphaiWant[j])) + betaWant[j])
+		if cmplx.Abs(got-want) > tol*scale {
+			t.Errorf("%v: eigenvalue %v differs when computing eigenvalues only", prefix, j)
+		}
+	}
+}
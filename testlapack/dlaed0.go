@@ -0,0 +1,136 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dlaed0er interface {
+	Dlaed0(icompq, n, qsiz int, d, e, q []float64, ldq int, qstore []float64, ldqs int, work []float64, iwork []int) (ok bool)
+	Dsterfer
+}
+
+func Dlaed0Test(t *testing.T, impl Dlaed0er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, icompq := range []int{0, 1, 2} {
+		for _, n := range []int{1, 2, 10, 25, 26, 50, 77, 100} {
+			for _, ldq := range []int{n, n + 5} {
+				testDlaed0(t, impl, icompq, n, ldq, rnd)
+			}
+		}
+	}
+}
+
+func testDlaed0(t *testing.T, impl Dlaed0er, icompq, n, ldq int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	prefix := fmt.Sprintf("Case icompq=%v,n=%v,ldq=%v:", icompq, n, ldq)
+
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	e := make([]float64, n-1)
+	for i := range e {
+		e[i] = rnd.NormFloat64()
+	}
+	tmat := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		tmat.Data[i*tmat.Stride+i] = d[i]
+		if i < n-1 {
+			tmat.Data[(i+1)*tmat.Stride+i] = e[i]
+			tmat.Data[i*tmat.Stride+i+1] = e[i]
+		}
+	}
+	dAns := make([]float64, n)
+	copy(dAns, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, dAns, eCopy)
+
+	// For icompq == 1, q holds an orthogonal matrix Q and the eigenvectors
+	// of Q*T*Q^T are computed. For icompq == 2, q holds the identity.
+	qsiz := n
+	var q0, q, qstore blas64.General
+	ldqs := 1
+	switch icompq {
+	case 1:
+		q0 = randomOrthogonal(n, rnd)
+		q = nanGeneral(n, n, ldq)
+		copyGeneral(q, q0)
+		ldqs = ldq + 2
+		qstore = nanGeneral(qsiz, n, ldqs)
+	case 2:
+		q = eye(n, ldq)
+	default:
+		q = nanGeneral(0, 0, 1)
+	}
+
+	lgn := 0
+	for 1<<uint(lgn) < n {
+		lgn++
+	}
+	var work []float64
+	var iwork []int
+	if icompq == 2 {
+		work = nanSlice(4*n + n*n)
+		iwork = make([]int, 3+5*n)
+	} else {
+		work = nanSlice(1 + 3*n + 2*n*lgn + n*n + 2*qsiz*n)
+		iwork = make([]int, 6+6*n+5*n*lgn)
+	}
+	for i := range iwork {
+		iwork[i] = -1
+	}
+
+	ok := impl.Dlaed0(icompq, n, qsiz, d, e, q.Data, q.Stride, qstore.Data, ldqs, work, iwork)
+	if !ok {
+		t.Errorf("%v Dlaed0 failed", prefix)
+		return
+	}
+
+	if !sort.Float64sAreSorted(d) {
+		t.Errorf("%v eigenvalues are not sorted", prefix)
+	}
+	if !floats.EqualApprox(d, dAns, tol) {
+		t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+	}
+	if icompq == 0 {
+		return
+	}
+
+	// The columns of q are the eigenvectors of T for icompq == 2 and of
+	// Q*T*Q^T for icompq == 1.
+	if !isOrthonormal(q) {
+		t.Errorf("%v eigenvectors not orthonormal", prefix)
+	}
+	a := tmat
+	if icompq == 1 {
+		tq := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tmat, q0, 0, tq)
+		a = zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q0, tq, 0, a)
+	}
+	aq := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, q, 0, aq)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			resid = math.Max(resid, math.Abs(aq.Data[i*aq.Stride+j]-d[j]*q.Data[i*q.Stride+j]))
+		}
+	}
+	if resid > tol*math.Max(1, floats.Norm(tmat.Data, math.Inf(1))) {
+		t.Errorf("%v eigenvector residual %v too large", prefix, resid)
+	}
+}
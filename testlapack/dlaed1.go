@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlaed1er interface {
+	Dlaed1(n int, d, q []float64, ldq int, indxq []int, rho float64, cutpnt int, work []float64, iwork []int) (ok bool)
+	Dsteqrer
+	Dsterfer
+}
+
+func Dlaed1Test(t *testing.T, impl Dlaed1er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{2, 3, 4, 5, 10, 21, 50} {
+		for _, ldq := range []int{n, n + 5} {
+			for _, kind := range []string{"random", "equal", "negative rho"} {
+				// The workspace of length 4*n+n*n is only sufficient
+				// when the halves have about the same size, as they
+				// do in Dlaed0.
+				testDlaed1(t, impl, n, ldq, n/2, kind, rnd)
+			}
+		}
+	}
+}
+
+func testDlaed1(t *testing.T, impl Dlaed1er, n, ldq, cutpnt int, kind string, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	prefix := fmt.Sprintf("Case n=%v,ldq=%v,cutpnt=%v,kind=%v:", n, ldq, cutpnt, kind)
+
+	// Generate the tridiagonal matrix T.
+	d := make([]float64, n)
+	e := make([]float64, n-1)
+	switch kind {
+	case "random", "negative rho":
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case "equal":
+		// Repeated eigenvalues of the halves exercise deflation.
+		for i := range d {
+			d[i] = float64(i % 2)
+		}
+		for i := range e {
+			e[i] = 1e-3
+		}
+	}
+	e[cutpnt-1] = math.Abs(e[cutpnt-1])
+	if kind == "negative rho" {
+		e[cutpnt-1] *= -1
+	}
+	tmat := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		tmat.Data[i*tmat.Stride+i] = d[i]
+		if i < n-1 {
+			tmat.Data[(i+1)*tmat.Stride+i] = e[i]
+			tmat.Data[i*tmat.Stride+i+1] = e[i]
+		}
+	}
+	dAns := make([]float64, n)
+	copy(dAns, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, dAns, eCopy)
+
+	// Split T at cutpnt into
+	//  T = [ T1  0 ] + rho * v * v^T,
+	//      [  0 T2 ]
+	// where v has ±1 in positions cutpnt-1 and cutpnt, and compute the
+	// eigendecompositions T1 = Q1*D1*Q1^T and T2 = Q2*D2*Q2^T.
+	rho := e[cutpnt-1]
+	d[cutpnt-1] -= math.Abs(rho)
+	d[cutpnt] -= math.Abs(rho)
+	q := nanGeneral(n, n, ldq)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			q.Data[i*q.Stride+j] = 0
+		}
+	}
+	work := make([]float64, 2*n)
+	impl.Dsteqr(lapack.TridiagEV, cutpnt, d, e, q.Data, q.Stride, work)
+	impl.Dsteqr(lapack.TridiagEV, n-cutpnt, d[cutpnt:], e[cutpnt:], q.Data[cutpnt*q.Stride+cutpnt:], q.Stride, work)
+
+	// Dsteqr sorts the eigenvalues of each half in ascending order.
+	indxq := make([]int, n)
+	for i := 0; i < cutpnt; i++ {
+		indxq[i] = i
+	}
+	for i := cutpnt; i < n; i++ {
+		indxq[i] = i - cutpnt
+	}
+
+	work = nanSlice(4*n + n*n)
+	iwork := make([]int, 4*n)
+	for i := range iwork {
+		iwork[i] = -1
+	}
+	ok := impl.Dlaed1(n, d, q.Data, q.Stride, indxq, rho, cutpnt, work, iwork)
+	if !ok {
+		t.Errorf("%v Dlaed1 failed", prefix)
+		return
+	}
+
+	// indxq must be a permutation that sorts d in ascending order.
+	seen := make([]bool, n)
+	for _, j := range indxq {
+		if j < 0 || n <= j || seen[j] {
+			t.Errorf("%v indxq is not a permutation: %v", prefix, indxq)
+			return
+		}
+		seen[j] = true
+	}
+	dSorted := make([]float64, n)
+	for i, j := range indxq {
+		dSorted[i] = d[j]
+	}
+	if !sort.Float64sAreSorted(dSorted) {
+		t.Errorf("%v d is not sorted by indxq", prefix)
+	}
+	if !floats.EqualApprox(dSorted, dAns, tol) {
+		t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+	}
+
+	// The columns of q are orthonormal eigenvectors of T.
+	if !isOrthonormal(q) {
+		t.Errorf("%v eigenvectors not orthonormal", prefix)
+	}
+	tq := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tmat, q, 0, tq)
+	var resid float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			resid = math.Max(resid, math.Abs(tq.Data[i*tq.Stride+j]-d[j]*q.Data[i*q.Stride+j]))
+		}
+	}
+	if resid > tol*math.Max(1, floats.Norm(tmat.Data, math.Inf(1))) {
+		t.Errorf("%v eigenvector residual %v too large", prefix, resid)
+	}
+}
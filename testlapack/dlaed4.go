@@ -0,0 +1,135 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/floats"
+)
+
+type Dlaed4er interface {
+	Dlaed4(n, i int, d, z, delta []float64, rho float64) (dlam float64, ok bool)
+}
+
+func Dlaed4Test(t *testing.T, impl Dlaed4er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 20, 50} {
+		for _, kind := range []string{"random", "clustered", "small z"} {
+			for cas := 0; cas < 10; cas++ {
+				testDlaed4(t, impl, n, kind, rnd)
+			}
+		}
+	}
+}
+
+func testDlaed4(t *testing.T, impl Dlaed4er, n int, kind string, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	// Generate distinct sorted poles d and a unit vector z.
+	d := make([]float64, n)
+	z := make([]float64, n)
+	switch kind {
+	case "random":
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range z {
+			z[i] = rnd.NormFloat64()
+		}
+	case "clustered":
+		// Poles in pairs that are very close to each other.
+		for i := range d {
+			d[i] = float64(i/2) + 1e-8*float64(i%2)
+		}
+		for i := range z {
+			z[i] = rnd.NormFloat64()
+		}
+	case "small z":
+		// Some components of z are tiny, so that some roots are very
+		// close to a pole.
+		for i := range d {
+			d[i] = float64(i)
+		}
+		for i := range z {
+			z[i] = rnd.NormFloat64()
+			if i%3 == 1 {
+				z[i] *= 1e-6
+			}
+		}
+	}
+	sort.Float64s(d)
+	floats.Scale(1/floats.Norm(z, 2), z)
+	rho := 0.1 + rnd.Float64()
+
+	var dmax float64
+	for _, v := range d {
+		dmax = math.Max(dmax, math.Abs(v))
+	}
+
+	for i := 0; i < n; i++ {
+		prefix := fmt.Sprintf("Case n=%v,kind=%v,i=%v:", n, kind, i)
+
+		delta := nanSlice(n)
+		lam, ok := impl.Dlaed4(n, i, d, z, delta, rho)
+		if !ok {
+			t.Errorf("%v Dlaed4 did not converge", prefix)
+			continue
+		}
+
+		// The roots interlace with the poles.
+		if lam < d[i] {
+			t.Errorf("%v root %v below pole d[%v]=%v", prefix, lam, i, d[i])
+		}
+		if i < n-1 && lam > d[i+1] {
+			t.Errorf("%v root %v above pole d[%v]=%v", prefix, lam, i+1, d[i+1])
+		}
+		if i == n-1 && lam > d[n-1]+rho*(1+tol) {
+			t.Errorf("%v root %v above bound %v", prefix, lam, d[n-1]+rho)
+		}
+
+		if n <= 2 {
+			// delta contains the normalized eigenvector of
+			// diag(d) + rho * z * z^T corresponding to lam.
+			if math.Abs(floats.Norm(delta, 2)-1) > tol {
+				t.Errorf("%v eigenvector not normalized", prefix)
+			}
+			zv := floats.Dot(z, delta)
+			for j := 0; j < n; j++ {
+				r := d[j]*delta[j] + rho*z[j]*zv - lam*delta[j]
+				if math.Abs(r) > tol*(dmax+rho) {
+					t.Errorf("%v eigenvector residual %v too large", prefix, r)
+				}
+			}
+			continue
+		}
+
+		// delta contains the differences between the poles and the root.
+		for j := 0; j < n; j++ {
+			if math.Abs(delta[j]-(d[j]-lam)) > tol*dmax+tol {
+				t.Errorf("%v delta[%v]=%v, want %v", prefix, j, delta[j], d[j]-lam)
+			}
+		}
+
+		// The root satisfies the secular equation
+		//  1/rho + sum_j z[j]^2 / (d[j] - lam) = 0.
+		// The terms are computed with delta, which holds d[j] - lam
+		// without the cancellation in the subtraction.
+		f := 1 / rho
+		scale := 1 / rho
+		for j := 0; j < n; j++ {
+			term := z[j] * z[j] / delta[j]
+			f += term
+			scale += math.Abs(term)
+		}
+		if math.Abs(f) > float64(n)*tol*scale {
+			t.Errorf("%v secular equation residual %v too large (scale %v)", prefix, f, scale)
+		}
+	}
+}
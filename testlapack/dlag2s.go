@@ -0,0 +1,83 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+)
+
+type Dlag2ser interface {
+	Dlag2s(m, n int, a []float64, lda int, sa []float32, ldsa int) (ok bool)
+}
+
+func Dlag2sTest(t *testing.T, impl Dlag2ser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda, ldsa int
+	}{
+		{0, 0, 0, 0},
+		{1, 1, 0, 0},
+		{3, 5, 0, 0},
+		{5, 3, 0, 0},
+		{10, 10, 0, 0},
+		{3, 5, 8, 10},
+		{5, 3, 8, 10},
+		{10, 10, 12, 15},
+	} {
+		for _, big := range []float64{0, math.MaxFloat32, 2 * math.MaxFloat32, -2 * math.MaxFloat32, math.Inf(1), math.Inf(-1)} {
+			testDlag2s(t, impl, test.m, test.n, test.lda, test.ldsa, big, rnd)
+		}
+	}
+}
+
+// testDlag2s checks Dlag2s with a random matrix A. If big is not zero, it is
+// placed at a random position in A and the conversion must fail if big is
+// outside the range of float32.
+func testDlag2s(t *testing.T, impl Dlag2ser, m, n, lda, ldsa int, big float64, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldsa == 0 {
+		ldsa = max(1, n)
+	}
+	if big != 0 && (m == 0 || n == 0) {
+		return
+	}
+	prefix := fmt.Sprintf("Case m=%v,n=%v,lda=%v,ldsa=%v,big=%v:", m, n, lda, ldsa, big)
+
+	a := randomGeneral(m, n, lda, rnd)
+	if big != 0 {
+		a.Data[rnd.Intn(m)*lda+rnd.Intn(n)] = big
+	}
+	sa := make([]float32, max(0, (m-1)*ldsa+n))
+	for i := range sa {
+		sa[i] = float32(math.NaN())
+	}
+
+	ok := impl.Dlag2s(m, n, a.Data, a.Stride, sa, ldsa)
+
+	overflow := math.Abs(big) > math.MaxFloat32
+	if ok == overflow {
+		t.Errorf("%v unexpected ok=%v", prefix, ok)
+	}
+	if overflow {
+		return
+	}
+	for i := 0; i < m; i++ {
+		for j := 0; j < n; j++ {
+			if sa[i*ldsa+j] != float32(a.Data[i*lda+j]) {
+				t.Errorf("%v element (%v,%v) is %v, want %v", prefix, i, j, sa[i*ldsa+j], float32(a.Data[i*lda+j]))
+			}
+		}
+		for j := n; j < ldsa && i*ldsa+j < len(sa); j++ {
+			if !math.IsNaN(float64(sa[i*ldsa+j])) {
+				t.Errorf("%v element (%v,%v) outside the matrix modified", prefix, i, j)
+			}
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dlagv2er interface {
+	Dlagv2(a []float64, lda int, b []float64, ldb int) (alphar, alphai, beta [2]float64, csl, snl, csr, snr float64)
+}
+
+func Dlagv2Test(t *testing.T, impl Dlagv2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, lda := range []int{2, 5} {
+		for _, ldb := range []int{2, 5} {
+			for cas := 0; cas < 100; cas++ {
+				dlagv2Test(t, impl, rnd, lda, ldb, cas)
+			}
+		}
+	}
+}
+
+func dlagv2Test(t *testing.T, impl Dlagv2er, rnd *rand.Rand, lda, ldb, cas int) {
+	const tol = 1e-14
+
+	a := randomGeneral(2, 2, lda, rnd)
+	b := randomGeneral(2, 2, ldb, rnd)
+	b.Data[b.Stride] = 0
+	switch cas % 4 {
+	case 1:
+		// A is upper triangular.
+		a.Data[a.Stride] = 0
+	case 2:
+		// B is singular.
+		b.Data[0] = 0
+	case 3:
+		// B is singular.
+		b.Data[b.Stride+1] = 0
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	alphar, alphai, beta, csl, snl, csr, snr := impl.Dlagv2(a.Data, a.Stride, b.Data, b.Stride)
+
+	name := fmt.Sprintf("Case lda=%v, ldb=%v, cas=%v", lda, ldb, cas)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", name)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", name)
+	}
+
+	// Check that the returned matrices are equal to
+	//  [  csl snl ] * (A,B) * [ csr -snr ]
+	//  [ -snl csl ]           [ snr  csr ].
+	q := blas64.General{Rows: 2, Cols: 2, Stride: 2, Data: []float64{csl, snl, -snl, csl}}
+	z := blas64.General{Rows: 2, Cols: 2, Stride: 2, Data: []float64{csr, -snr, snr, csr}}
+	for _, v := range []struct {
+		name       string
+		orig, want blas64.General
+	}{
+		{"A", aCopy, a},
+		{"B", bCopy, b},
+	} {
+		tmp := zeros(2, 2, 2)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, v.orig, 0, tmp)
+		got := zeros(2, 2, 2)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, tmp, z, 0, got)
+		if !equalApproxGeneral(got, v.want, tol) {
+			t.Errorf("%v: unexpected transformed %v", name, v.name)
+		}
+	}
+
+	if b.Data[b.Stride] != 0 {
+		t.Errorf("%v: B[1,0] is not zero", name)
+	}
+	if alphai[0] == 0 {
+		// Real eigenvalues.
+		if a.Data[a.Stride] != 0 {
+			t.Errorf("%v: A[1,0] is not zero for real eigenvalues", name)
+		}
+		if alphai[1] != 0 {
+			t.Errorf("%v: unexpected non-zero alphai[1]", name)
+		}
+		for k := 0; k < 2; k++ {
+			if alphar[k] != a.Data[k*a.Stride+k] || beta[k] != b.Data[k*b.Stride+k] {
+				t.Errorf("%v: eigenvalue %v does not match the diagonal of (A,B)", name, k)
+			}
+		}
+		return
+	}
+
+	// Complex eigenvalues.
+	if alphai[0] < 0 || alphai[1] != -alphai[0] || alphar[0] != alphar[1] {
+		t.Errorf("%v: eigenvalues are not a complex conjugate pair with alphai[0] > 0", name)
+	}
+	if beta[0] != 1 || beta[1] != 1 {
+		t.Errorf("%v: unexpected beta for complex eigenvalues, got %v", name, beta)
+	}
+	if b.Data[1] != 0 {
+		t.Errorf("%v: B is not diagonal for complex eigenvalues", name)
+	}
+	if math.Abs(b.Data[0]) < math.Abs(b.Data[b.Stride+1]) || b.Data[b.Stride+1] == 0 {
+		t.Errorf("%v: diagonal of B does not satisfy |b11| >= |b22| > 0", name)
+	}
+	// Check that the eigenvalues of the transformed pair are the returned ones.
+	ev := generalizedBlockEigenvalues(a, b, 0)
+	want := complex(alphar[0], alphai[0])
+	if len(ev) != 2 || math.Abs(real(ev[0])-real(want)) > 1e-12*math.Max(1, math.Abs(real(want))) ||
+		math.Abs(imag(ev[0])-imag(want)) > 1e-12*math.Max(1, math.Abs(imag(want))) {
+		t.Errorf("%v: unexpected eigenvalues, want %v, got %v", name, want, ev)
+	}
+}
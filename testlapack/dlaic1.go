@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+)
+
+type Dlaic1er interface {
+	Dlaic1(job, j int, x []float64, sest float64, w []float64, gamma float64) (sestpr, s, c float64)
+}
+
+func Dlaic1Test(t *testing.T, impl Dlaic1er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, j := range []int{1, 2, 3, 5, 10} {
+		for cas := 0; cas < 6; cas++ {
+			x := make([]float64, j)
+			w := make([]float64, j)
+			for i := range x {
+				x[i] = rnd.NormFloat64()
+				w[i] = rnd.NormFloat64()
+			}
+			// Normalize x so that it is a unit vector.
+			blas64.Scal(j, 1/blas64.Nrm2(j, blas64.Vector{Inc: 1, Data: x}), blas64.Vector{Inc: 1, Data: x})
+			sest := 1 + 9*rnd.Float64()
+			gamma := rnd.NormFloat64()
+			// Exercise the special cases.
+			switch cas {
+			case 1:
+				sest = 0
+			case 2:
+				gamma = 1e-20
+			case 3:
+				for i := range w {
+					w[i] = 0
+				}
+			case 4:
+				sest = 1e-20
+			case 5:
+				sest = 0
+				gamma = 0
+				for i := range w {
+					w[i] = 0
+				}
+			}
+			for _, job := range []int{1, 2} {
+				testDlaic1(t, impl, job, j, x, sest, w, gamma)
+			}
+		}
+	}
+}
+
+func testDlaic1(t *testing.T, impl Dlaic1er, job, j int, x []float64, sest float64, w []float64, gamma float64) {
+	errStr := fmt.Sprintf("job = %v, j = %v, sest = %v, gamma = %v", job, j, sest, gamma)
+	sestpr, s, c := impl.Dlaic1(job, j, x, sest, w, gamma)
+
+	if math.Abs(s*s+c*c-1) > 1e-14 {
+		t.Errorf("s^2 + c^2 != 1: %s", errStr)
+	}
+
+	// sestpr^2 and [s c]^T are an eigenpair of the symmetric 2×2 matrix
+	//  M = [ sest^2 + alpha^2  alpha*gamma ]
+	//      [ alpha*gamma       gamma^2     ],
+	// where alpha = x^T*w. The eigenvalue is the largest one if job == 1 and
+	// the smallest one if job == 2.
+	alpha := blas64.Dot(j, blas64.Vector{Inc: 1, Data: x}, blas64.Vector{Inc: 1, Data: w})
+	m11 := sest*sest + alpha*alpha
+	m12 := alpha * gamma
+	m22 := gamma * gamma
+	mean := (m11 + m22) / 2
+	rad := math.Hypot((m11-m22)/2, m12)
+	lambda := mean + rad
+	if job == 2 {
+		lambda = mean - rad
+	}
+	tol := 1e-13 * math.Max(1, m11+m22)
+	if math.Abs(sestpr*sestpr-lambda) > tol {
+		t.Errorf("Unexpected sestpr: got %v, want %v: %s", sestpr, math.Sqrt(math.Max(0, lambda)), errStr)
+	}
+	if math.Abs(m11*s+m12*c-lambda*s) > tol || math.Abs(m12*s+m22*c-lambda*c) > tol {
+		t.Errorf("[s c] is not an eigenvector: %s", errStr)
+	}
+}
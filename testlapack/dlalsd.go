@@ -0,0 +1,145 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dlalsder interface {
+	Dlalsd(uplo blas.Uplo, smlsiz, n, nrhs int, d, e, b []float64, ldb int, rcond float64, work []float64, iwork []int) (rank int, ok bool)
+	Dbdsqrer
+}
+
+func DlalsdTest(t *testing.T, impl Dlalsder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{1, 2, 3, 5, 10, 26, 50, 100} {
+			for _, nrhs := range []int{1, 3} {
+				for _, smlsiz := range []int{3, 25} {
+					for _, rcond := range []float64{-1, 0.1, 0.5} {
+						for _, ldb := range []int{0, nrhs + 3} {
+							testDlalsd(t, impl, uplo, n, nrhs, smlsiz, ldb, rcond, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlalsd(t *testing.T, impl Dlalsder, uplo blas.Uplo, n, nrhs, smlsiz, ldb int, rcond float64, rnd *rand.Rand) {
+	const tol = 1e-10
+
+	if ldb == 0 {
+		ldb = nrhs
+	}
+	prefix := fmt.Sprintf("Case uplo=%c,n=%v,nrhs=%v,smlsiz=%v,ldb=%v,rcond=%v:", uplo, n, nrhs, smlsiz, ldb, rcond)
+
+	// Generate a diagonally dominant bidiagonal matrix so that it is well
+	// conditioned and the solution is accurate.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = 2 + rnd.Float64()
+		if rnd.Intn(2) == 0 {
+			d[i] *= -1
+		}
+	}
+	e := make([]float64, max(0, n-1))
+	for i := range e {
+		e[i] = 2*rnd.Float64() - 1
+	}
+	bMat := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		bMat.Data[i*bMat.Stride+i] = d[i]
+		if i < n-1 {
+			if uplo == blas.Upper {
+				bMat.Data[i*bMat.Stride+i+1] = e[i]
+			} else {
+				bMat.Data[(i+1)*bMat.Stride+i] = e[i]
+			}
+		}
+	}
+
+	// Compute the singular value decomposition B = Q*S*P^T with Dbdsqr.
+	s := make([]float64, n)
+	copy(s, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	pt := eye(n, n)
+	q := eye(n, n)
+	ok := impl.Dbdsqr(uplo, n, n, n, 0, s, eCopy, pt.Data, pt.Stride, q.Data, q.Stride, nil, 1, make([]float64, 4*n))
+	if !ok {
+		t.Errorf("%v Dbdsqr failed", prefix)
+		return
+	}
+
+	b := randomGeneral(n, nrhs, ldb, rnd)
+	bCopy := cloneGeneral(b)
+
+	nlvl := max(0, int(math.Log2(float64(n)/float64(smlsiz+1)))+1)
+	work := nanSlice(9*n + 2*n*smlsiz + 8*n*nlvl + n*nrhs + (smlsiz+1)*(smlsiz+1))
+	iwork := make([]int, 3*n*nlvl+11*n)
+	rank, ok := impl.Dlalsd(uplo, smlsiz, n, nrhs, d, e, b.Data, b.Stride, rcond, work, iwork)
+	if !ok {
+		t.Errorf("%v Dlalsd failed", prefix)
+		return
+	}
+
+	if !floats.EqualApprox(d, s, tol*s[0]) {
+		t.Errorf("%v unexpected singular values; got %v, want %v", prefix, d, s)
+	}
+
+	// The singular values below the threshold are treated as zero.
+	thresh := rcond
+	if thresh < 0 || 1 <= thresh {
+		thresh = dlamchE
+	}
+	thresh *= s[0]
+	var wantRank int
+	for _, v := range s {
+		if v > thresh {
+			wantRank++
+		}
+	}
+	if rank != wantRank {
+		t.Errorf("%v unexpected rank; got %v, want %v", prefix, rank, wantRank)
+	}
+
+	// Compute the minimum norm solution X = P*S^+*Q^T*B_in of the problem
+	// where only the first rank singular values are kept.
+	qtb := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, bCopy, 0, qtb)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			if i < wantRank {
+				qtb.Data[i*qtb.Stride+j] /= s[i]
+			} else {
+				qtb.Data[i*qtb.Stride+j] = 0
+			}
+		}
+	}
+	want := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, pt, qtb, 0, want)
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("%v unexpected solution", prefix)
+	}
+
+	if wantRank == n {
+		// For a matrix of full rank, X solves B*X = B_in.
+		bx := zeros(n, nrhs, nrhs)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, bMat, b, 0, bx)
+		if !equalApproxGeneral(bx, bCopy, tol) {
+			t.Errorf("%v B*X != B_in", prefix)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlangber interface {
+	Dlangb(norm lapack.MatrixNorm, m, n, kl, ku int, ab []float64, ldab int, work []float64) float64
+	Dlanger
+}
+
+func DlangbTest(t *testing.T, impl Dlangber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, m := range []int{0, 1, 2, 3, 5, 10} {
+			for _, n := range []int{0, 1, 2, 3, 5, 10} {
+				for _, kl := range []int{0, 1, 2, 4, 12} {
+					for _, ku := range []int{0, 1, 2, 4, 12} {
+						for _, ldab := range []int{kl + ku + 1, kl + ku + 4} {
+							testDlangb(t, impl, norm, m, n, kl, ku, ldab, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlangb(t *testing.T, impl Dlangber, norm lapack.MatrixNorm, m, n, kl, ku, ldab int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, m = %v, n = %v, kl = %v, ku = %v, ldab = %v", string(norm), m, n, kl, ku, ldab)
+
+	ab := randomBand(m, n, kl, ku, ldab, rnd)
+	abCopy := make([]float64, len(ab))
+	copy(abCopy, ab)
+	a := bandToGeneral(m, n, kl, ku, ab, ldab)
+	work := nanSlice(n)
+
+	got := impl.Dlangb(norm, m, n, kl, ku, ab, ldab, work)
+	if !floats.Same(ab, abCopy) {
+		t.Errorf("ab modified: %s", errStr)
+	}
+	if m == 0 || n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+	want := impl.Dlange(norm, m, n, a.Data, a.Stride, work)
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
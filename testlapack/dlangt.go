@@ -0,0 +1,53 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/lapack"
+)
+
+type Dlangter interface {
+	Dlangt(norm lapack.MatrixNorm, n int, dl, d, du []float64) float64
+	Dlanger
+}
+
+func DlangtTest(t *testing.T, impl Dlangter) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10} {
+			for cas := 0; cas < 10; cas++ {
+				testDlangt(t, impl, norm, n, rnd)
+			}
+		}
+	}
+}
+
+func testDlangt(t *testing.T, impl Dlangter, norm lapack.MatrixNorm, n int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, n = %v", string(norm), n)
+
+	dl := randomSlice(max(0, n-1), rnd)
+	d := randomSlice(n, rnd)
+	du := randomSlice(max(0, n-1), rnd)
+	a := tridiagToGeneral(n, dl, d, du)
+
+	got := impl.Dlangt(norm, n, dl, d, du)
+	if n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+	want := impl.Dlange(norm, n, n, a.Data, a.Stride, nanSlice(n))
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlanhser interface {
+	Dlanhs(norm lapack.MatrixNorm, n int, a []float64, lda int, work []float64) float64
+	Dlanger
+}
+
+func DlanhsTest(t *testing.T, impl Dlanhser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10} {
+			for _, lda := range []int{max(1, n), n + 5} {
+				testDlanhs(t, impl, norm, n, lda, rnd)
+			}
+		}
+	}
+}
+
+func testDlanhs(t *testing.T, impl Dlanhser, norm lapack.MatrixNorm, n, lda int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, n = %v, lda = %v", string(norm), n, lda)
+
+	// Generate a random general matrix A. Dlanhs must not reference the
+	// elements below the first subdiagonal.
+	a := randomGeneral(n, n, lda, rnd)
+	aCopy := make([]float64, len(a.Data))
+	copy(aCopy, a.Data)
+	work := nanSlice(n)
+
+	got := impl.Dlanhs(norm, n, a.Data, a.Stride, work)
+	if !floats.Same(a.Data, aCopy) {
+		t.Errorf("a modified: %s", errStr)
+	}
+	if n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+
+	// Zero out A below the first subdiagonal and compute the reference
+	// value with Dlange.
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			a.Data[i*a.Stride+j] = 0
+		}
+	}
+	want := impl.Dlange(norm, n, n, a.Data, a.Stride, work)
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
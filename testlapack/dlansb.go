@@ -0,0 +1,63 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlansber interface {
+	Dlansb(norm lapack.MatrixNorm, uplo blas.Uplo, n, kd int, ab []float64, ldab int, work []float64) float64
+	Dlansyer
+}
+
+func DlansbTest(t *testing.T, impl Dlansber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 5, 10} {
+				for _, kd := range []int{0, 1, 2, 4, 12} {
+					for _, ldab := range []int{kd + 1, kd + 4} {
+						testDlansb(t, impl, norm, uplo, n, kd, ldab, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlansb(t *testing.T, impl Dlansber, norm lapack.MatrixNorm, uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, uplo = %v, n = %v, kd = %v, ldab = %v", string(norm), uplo, n, kd, ldab)
+
+	ab := randomSymBand(uplo, n, kd, ldab, rnd)
+	abCopy := make([]float64, len(ab))
+	copy(abCopy, ab)
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+	work := nanSlice(n)
+
+	got := impl.Dlansb(norm, uplo, n, kd, ab, ldab, work)
+	if !floats.Same(ab, abCopy) {
+		t.Errorf("ab modified: %s", errStr)
+	}
+	if n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+	want := impl.Dlansy(norm, uplo, n, a.Data, a.Stride, work)
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlansfer interface {
+	Dlansf(norm lapack.MatrixNorm, transr blas.Transpose, uplo blas.Uplo, n int, a []float64, work []float64) float64
+	Dtpttfer
+	Dlansyer
+}
+
+func DlansfTest(t *testing.T, impl Dlansfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+					testDlansf(t, impl, norm, transr, uplo, n, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDlansf(t *testing.T, impl Dlansfer, norm lapack.MatrixNorm, transr blas.Transpose, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, transr = %v, uplo = %v, n = %v", string(norm), transr, uplo, n)
+
+	ap := randomSlice(n*(n+1)/2, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	arf := make([]float64, len(ap))
+	impl.Dtpttf(transr, uplo, n, ap, arf)
+	arfCopy := make([]float64, len(arf))
+	copy(arfCopy, arf)
+	work := nanSlice(n)
+
+	got := impl.Dlansf(norm, transr, uplo, n, arf, work)
+	if !floats.Same(arf, arfCopy) {
+		t.Errorf("a modified: %s", errStr)
+	}
+	if n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+	want := impl.Dlansy(norm, uplo, n, a.Data, a.Stride, work)
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlansper interface {
+	Dlansp(norm lapack.MatrixNorm, uplo blas.Uplo, n int, ap []float64, work []float64) float64
+	Dlansyer
+}
+
+func DlanspTest(t *testing.T, impl Dlansper) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, norm := range []lapack.MatrixNorm{lapack.MaxAbs, lapack.MaxColumnSum, lapack.MaxRowSum, lapack.NormFrob} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+				testDlansp(t, impl, norm, uplo, n, rnd)
+			}
+		}
+	}
+}
+
+func testDlansp(t *testing.T, impl Dlansper, norm lapack.MatrixNorm, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	errStr := fmt.Sprintf("norm = %v, uplo = %v, n = %v", string(norm), uplo, n)
+
+	ap := randomSlice(n*(n+1)/2, rnd)
+	apCopy := make([]float64, len(ap))
+	copy(apCopy, ap)
+	a := symPackedToGeneral(uplo, n, ap)
+	work := nanSlice(n)
+
+	got := impl.Dlansp(norm, uplo, n, ap, work)
+	if !floats.Same(ap, apCopy) {
+		t.Errorf("ap modified: %s", errStr)
+	}
+	if n == 0 {
+		if got != 0 {
+			t.Errorf("Unexpected norm of empty matrix: got %v, want 0: %s", got, errStr)
+		}
+		return
+	}
+	want := impl.Dlansy(norm, uplo, n, a.Data, a.Stride, work)
+	if math.Abs(got-want) > tol*math.Max(1, want) {
+		t.Errorf("Unexpected norm: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dlaqgeer interface {
+	Dlaqge(m, n int, a []float64, lda int, r, c []float64, rowcnd, colcnd, amax float64) lapack.EquilibrationType
+}
+
+func DlaqgeTest(t *testing.T, impl Dlaqgeer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{5, 0, 0},
+		{1, 1, 0},
+		{5, 5, 0},
+		{5, 10, 0},
+		{10, 5, 0},
+		{5, 10, 15},
+		{10, 5, 15},
+	} {
+		for _, scaling := range []struct {
+			rowcnd, colcnd, amax float64
+			want                 lapack.EquilibrationType
+		}{
+			{0.5, 0.5, 1, lapack.NoEquilibration},
+			{0.1, 0.1, 1, lapack.NoEquilibration},
+			{0.5, 0.05, 1, lapack.ColumnEquilibration},
+			{0.05, 0.5, 1, lapack.RowEquilibration},
+			{0.5, 0.5, 1e-300, lapack.RowEquilibration},
+			{0.5, 0.5, 1e300, lapack.RowEquilibration},
+			{0.05, 0.05, 1, lapack.BothEquilibration},
+			{0.5, 0.05, 1e300, lapack.BothEquilibration},
+		} {
+			m := test.m
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = max(1, n)
+			}
+			a := blas64.General{Rows: m, Cols: n, Stride: lda, Data: randomSlice(m*lda, rnd)}
+			aCopy := cloneGeneral(a)
+			r := make([]float64, m)
+			for i := range r {
+				r[i] = 1 + rnd.Float64()
+			}
+			c := make([]float64, n)
+			for i := range c {
+				c[i] = 1 + rnd.Float64()
+			}
+
+			got := impl.Dlaqge(m, n, a.Data, a.Stride, r, c, scaling.rowcnd, scaling.colcnd, scaling.amax)
+			errStr := fmt.Sprintf("m = %v, n = %v, lda = %v, rowcnd = %v, colcnd = %v, amax = %v",
+				m, n, lda, scaling.rowcnd, scaling.colcnd, scaling.amax)
+			want := scaling.want
+			if m == 0 || n == 0 {
+				want = lapack.NoEquilibration
+			}
+			if got != want {
+				t.Errorf("Unexpected equilibration type: got %c, want %c: %s", got, want, errStr)
+				continue
+			}
+			wantA := aCopy
+			switch want {
+			case lapack.RowEquilibration:
+				wantA = scaledGeneral(aCopy, r, nil)
+			case lapack.ColumnEquilibration:
+				wantA = scaledGeneral(aCopy, nil, c)
+			case lapack.BothEquilibration:
+				wantA = scaledGeneral(aCopy, r, c)
+			}
+			if !equalApproxGeneral(a, wantA, 1e-14) {
+				t.Errorf("Unexpected scaled matrix: %s", errStr)
+			}
+		}
+	}
+}
@@ -0,0 +1,86 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dlaqsyer interface {
+	Dlaqsy(uplo blas.Uplo, n int, a []float64, lda int, s []float64, scond, amax float64) lapack.EquilibrationType
+}
+
+func DlaqsyTest(t *testing.T, impl Dlaqsyer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{0, 0},
+			{1, 0},
+			{5, 0},
+			{10, 0},
+			{5, 15},
+			{10, 15},
+		} {
+			for _, scaling := range []struct {
+				scond, amax float64
+				want        lapack.EquilibrationType
+			}{
+				{0.5, 1, lapack.NoEquilibration},
+				{0.1, 1, lapack.NoEquilibration},
+				{0.05, 1, lapack.SymmetricEquilibration},
+				{0.5, 1e-300, lapack.SymmetricEquilibration},
+				{0.5, 1e300, lapack.SymmetricEquilibration},
+			} {
+				n := test.n
+				lda := test.lda
+				if lda == 0 {
+					lda = max(1, n)
+				}
+				a := blas64.General{Rows: n, Cols: n, Stride: lda, Data: randomSlice(n*lda, rnd)}
+				aCopy := cloneGeneral(a)
+				s := make([]float64, n)
+				for i := range s {
+					s[i] = 1 + rnd.Float64()
+				}
+
+				got := impl.Dlaqsy(uplo, n, a.Data, a.Stride, s, scaling.scond, scaling.amax)
+				errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v, scond = %v, amax = %v",
+					uplo, n, lda, scaling.scond, scaling.amax)
+				want := scaling.want
+				if n == 0 {
+					want = lapack.NoEquilibration
+				}
+				if got != want {
+					t.Errorf("Unexpected equilibration type: got %c, want %c: %s", got, want, errStr)
+					continue
+				}
+				wantA := aCopy
+				if want == lapack.SymmetricEquilibration {
+					// Only the triangle specified by uplo is
+					// scaled.
+					wantA = scaledGeneral(aCopy, s, s)
+					for i := 0; i < n; i++ {
+						for j := 0; j < n; j++ {
+							if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+								wantA.Data[i*lda+j] = aCopy.Data[i*lda+j]
+							}
+						}
+					}
+				}
+				if !equalApproxGeneral(a, wantA, 1e-14) {
+					t.Errorf("Unexpected scaled matrix: %s", errStr)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+)
+
+type Dlaqtrer interface {
+	Dlaqtr(trans, isReal bool, n int, t []float64, ldt int, b []float64, w float64, x, work []float64) (scale float64, ok bool)
+}
+
+func DlaqtrTest(t *testing.T, impl Dlaqtrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []bool{false, true} {
+		for _, isReal := range []bool{true, false} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 18, 31} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 10; cas++ {
+						testDlaqtr(t, impl, trans, isReal, n, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlaqtr(t *testing.T, impl Dlaqtrer, trans, isReal bool, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	prefix := fmt.Sprintf("Case trans=%v, isReal=%v, n=%v, extra=%v", trans, isReal, n, extra)
+
+	// Generate a random matrix T in Schur canonical form. In the complex
+	// case the first diagonal block of T must be 1×1.
+	tm := randomSchurCanonical(n, n+extra, rnd)
+	if !isReal && n > 1 {
+		tm.Data[tm.Stride] = 0
+	}
+	tCopy := cloneGeneral(tm)
+
+	var b []float64
+	var w float64
+	nx := n
+	if !isReal {
+		b = randomSlice(n, rnd)
+		w = rnd.NormFloat64()
+		nx = 2 * n
+	}
+	bCopy := make([]float64, len(b))
+	copy(bCopy, b)
+
+	x := randomSlice(nx, rnd)
+	xCopy := make([]float64, nx)
+	copy(xCopy, x)
+
+	work := nanSlice(n)
+	scale, ok := impl.Dlaqtr(trans, isReal, n, tm.Data, tm.Stride, b, w, x, work)
+
+	if !ok {
+		t.Errorf("%v: unexpected perturbation of T", prefix)
+	}
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: scale out of range (0,1], got %v", prefix, scale)
+	}
+	if !equalApproxGeneral(tm, tCopy, 0) {
+		t.Errorf("%v: unexpected modification of T", prefix)
+	}
+	for i := range b {
+		if b[i] != bCopy[i] {
+			t.Errorf("%v: unexpected modification of b[%v]", prefix, i)
+		}
+	}
+	if n == 0 {
+		return
+	}
+
+	// Form the complex matrix M = T + i*B and the complex vectors of the
+	// solution and of the right-hand side.
+	m := make([]complex128, n*n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			m[i*n+j] = complex(tCopy.Data[i*tCopy.Stride+j], 0)
+		}
+	}
+	p := make([]complex128, n)
+	c := make([]complex128, n)
+	for i := 0; i < n; i++ {
+		p[i] = complex(x[i], 0)
+		c[i] = complex(xCopy[i], 0)
+	}
+	if !isReal {
+		for j := 0; j < n; j++ {
+			m[j] += complex(0, bCopy[j])
+		}
+		for i := 1; i < n; i++ {
+			m[i*n+i] += complex(0, w)
+		}
+		for i := 0; i < n; i++ {
+			p[i] += complex(0, x[n+i])
+			c[i] += complex(0, xCopy[n+i])
+		}
+	}
+	if trans {
+		// Replace M with its conjugate transpose.
+		for i := 0; i < n; i++ {
+			m[i*n+i] = cmplx.Conj(m[i*n+i])
+			for j := i + 1; j < n; j++ {
+				m[i*n+j], m[j*n+i] = cmplx.Conj(m[j*n+i]), cmplx.Conj(m[i*n+j])
+			}
+		}
+	}
+
+	// Compute the residual |M*p - scale*c| / (|M|*|p| + |c|) in the
+	// ∞-norm.
+	var resid, mnorm, pnorm, cnorm float64
+	for i := 0; i < n; i++ {
+		var r complex128
+		var rowSum float64
+		for j := 0; j < n; j++ {
+			r += m[i*n+j] * p[j]
+			rowSum += cmplx.Abs(m[i*n+j])
+		}
+		r -= complex(scale, 0) * c[i]
+		resid = math.Max(resid, cmplx.Abs(r))
+		mnorm = math.Max(mnorm, rowSum)
+		pnorm = math.Max(pnorm, cmplx.Abs(p[i]))
+		cnorm = math.Max(cnorm, cmplx.Abs(c[i]))
+	}
+	resid /= mnorm*pnorm + cnorm
+	if resid > tol*float64(n) {
+		t.Errorf("%v: unexpected solution, resid=%v", prefix, resid)
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlarreer interface {
+	Dlarre(rng lapack.EVRange, n int, vl, vu float64, il, iu int, d, e, e2 []float64, rtol1, rtol2, spltol float64, isplit []int, w, werr, wgap []float64, iblock, indexw []int, gers []float64, work []float64, iwork []int) (vlOut, vuOut float64, nsplit, m int, pivmin float64, ok bool)
+	Dsterfer
+	Dsytrder
+}
+
+func DlarreTest(t *testing.T, impl Dlarreer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 10, 26, 50} {
+		for _, kind := range []string{"random", "wilkinson", "clustered", "tight", "split", "graded"} {
+			d, e := tridiagonalForEigen(impl, kind, n, rnd)
+			for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+				testDlarre(t, impl, rng, n, d, e, kind)
+			}
+		}
+	}
+}
+
+func testDlarre(t *testing.T, impl Dlarreer, rng lapack.EVRange, n int, d, e []float64, kind string) {
+	const eps = 1.0 / (1 << 53)
+
+	wAll := make([]float64, n)
+	copy(wAll, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, wAll, eCopy)
+
+	tnrm := math.Max(1, floats.Norm(d, math.Inf(1))+2*floats.Norm(e, math.Inf(1)))
+
+	for _, sel := range eigenSelections(rng, wAll) {
+		prefix := fmt.Sprintf("Case rng=%c,n=%v,kind=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			rng, n, kind, sel.vl, sel.vu, sel.il, sel.iu)
+
+		dl := make([]float64, n)
+		copy(dl, d)
+		l := make([]float64, n)
+		copy(l, e)
+		e2 := make([]float64, n)
+		for i, v := range e {
+			e2[i] = v * v
+		}
+		isplit := make([]int, n)
+		w := nanSlice(n)
+		werr := nanSlice(n)
+		wgap := nanSlice(n)
+		iblock := make([]int, n)
+		indexw := make([]int, n)
+		gers := nanSlice(2 * n)
+		work := nanSlice(6 * n)
+		iwork := make([]int, 5*n)
+
+		// Compute the eigenvalues to full accuracy as Dstemr does when
+		// no eigenvectors are wanted.
+		_, _, nsplit, m, _, ok := impl.Dlarre(rng, n, sel.vl, sel.vu, sel.il, sel.iu, dl, l, e2,
+			4*eps, 4*eps, -eps, isplit, w, werr, wgap, iblock, indexw, gers, work, iwork)
+		if !ok {
+			t.Errorf("%v Dlarre failed", prefix)
+			continue
+		}
+		if m != sel.iu-sel.il+1 {
+			t.Errorf("%v unexpected number of eigenvalues; got %v, want %v", prefix, m, sel.iu-sel.il+1)
+			continue
+		}
+		// Check the Gerschgorin intervals.
+		for i := 0; i < n; i++ {
+			var r float64
+			if i > 0 {
+				r += math.Abs(e[i-1])
+			}
+			if i < n-1 {
+				r += math.Abs(e[i])
+			}
+			if math.Abs(gers[2*i]-(d[i]-r)) > eps*tnrm || math.Abs(gers[2*i+1]-(d[i]+r)) > eps*tnrm {
+				t.Errorf("%v unexpected Gerschgorin interval %v: [%v,%v]", prefix, i, gers[2*i], gers[2*i+1])
+			}
+		}
+
+		if nsplit < 1 || isplit[nsplit-1] != n-1 {
+			t.Errorf("%v unexpected blocks: nsplit=%v, isplit=%v", prefix, nsplit, isplit[:nsplit])
+			continue
+		}
+		// The eigenvalues of each block are the eigenvalues of the
+		// corresponding diagonal block of T. Every block that contains
+		// selected eigenvalues must be described by a representation
+		// L*D*L^T + sigma*I of that block.
+		hasEig := make([]bool, nsplit)
+		for j := 0; j < m; j++ {
+			if 0 <= iblock[j] && iblock[j] < nsplit {
+				hasEig[iblock[j]] = true
+			}
+		}
+		blockEig := make([][]float64, nsplit)
+		ibegin := 0
+		for jblk := 0; jblk < nsplit; jblk++ {
+			iend := isplit[jblk]
+			if iend < ibegin {
+				t.Errorf("%v isplit not increasing: %v", prefix, isplit[:nsplit])
+				break
+			}
+			if iend < n-1 && math.Abs(e[iend]) > 1e-10*tnrm {
+				t.Errorf("%v split at a large off-diagonal element e[%v]=%v", prefix, iend, e[iend])
+			}
+			in := iend - ibegin + 1
+			db := make([]float64, in)
+			copy(db, d[ibegin:iend+1])
+			eb := make([]float64, max(0, in-1))
+			copy(eb, e[ibegin:iend])
+			impl.Dsterf(in, db, eb)
+			blockEig[jblk] = db
+
+			if in > 1 && hasEig[jblk] {
+				sigma := l[iend]
+				for i := ibegin; i <= iend; i++ {
+					diag := dl[i] + sigma
+					if i > ibegin {
+						diag += l[i-1] * l[i-1] * dl[i-1]
+					}
+					if math.Abs(diag-d[i]) > 1e-10*tnrm {
+						t.Errorf("%v block %v: diagonal %v of L*D*L^T+sigma*I is %v, want %v", prefix, jblk, i, diag, d[i])
+					}
+					if i < iend {
+						off := l[i] * dl[i]
+						if math.Abs(off-e[i]) > 1e-10*tnrm {
+							t.Errorf("%v block %v: off-diagonal %v of L*D*L^T is %v, want %v", prefix, jblk, i, off, e[i])
+						}
+					}
+				}
+			}
+			ibegin = iend + 1
+		}
+
+		// Each returned eigenvalue, shifted back, must lie within its error
+		// bound of the corresponding eigenvalue of its block.
+		got := make([]float64, m)
+		for j := 0; j < m; j++ {
+			jblk := iblock[j]
+			if jblk < 0 || nsplit <= jblk || indexw[j] < 0 || len(blockEig[jblk]) <= indexw[j] {
+				t.Errorf("%v invalid block %v or index %v of eigenvalue %v", prefix, jblk, indexw[j], j)
+				continue
+			}
+			if j > 0 && iblock[j] == iblock[j-1] && indexw[j] <= indexw[j-1] {
+				t.Errorf("%v indices within block %v not increasing", prefix, jblk)
+			}
+			want := blockEig[jblk][indexw[j]]
+			sigma := l[isplit[jblk]]
+			if math.Abs(w[j]+sigma-want) > werr[j]+1e-12*tnrm {
+				t.Errorf("%v eigenvalue %v: got %v, want %v, werr=%v", prefix, j, w[j]+sigma, want, werr[j])
+			}
+			got[j] = w[j] + sigma
+		}
+
+		// Together the eigenvalues are the selected eigenvalues of T.
+		sort.Float64s(got)
+		if !floats.EqualApprox(got, wAll[sel.il:sel.iu+1], 1e-12*tnrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dlarrver interface {
+	Dlarrv(n int, vl, vu float64, d, l []float64, pivmin float64, isplit []int, m, dol, dou int, minrgp, rtol1, rtol2 float64, w, werr, wgap []float64, iblock, indexw []int, gers, z []float64, ldz int, isuppz []int, work []float64, iwork []int) (ok bool)
+	Dlarreer
+}
+
+func DlarrvTest(t *testing.T, impl Dlarrver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 4, 10, 25, 26, 50} {
+		for _, kind := range []string{"random", "wilkinson", "clustered", "tight", "graded"} {
+			d, e := tridiagonalForEigen(impl, kind, n, rnd)
+			// Index ranges whose ends split the clusters of three or
+			// four close eigenvalues of the clustered kinds, so that an
+			// unwanted eigenvalue lies next to the first or the last
+			// wanted one.
+			for _, r := range [][2]int{
+				{0, n - 1},
+				{1, n - 2},
+				{1, 4},
+				{2, 6},
+				{5, 7},
+				{8, 13},
+				{n / 3, n / 2},
+				{n / 3, n/2 + 1},
+				{n - 2, n - 2},
+			} {
+				il, iu := r[0], r[1]
+				if il < 0 || iu < il || n <= iu {
+					continue
+				}
+				for _, ldz := range []int{0, n + 3} {
+					testDlarrv(t, impl, n, d, e, il, iu, ldz, kind)
+				}
+			}
+		}
+	}
+}
+
+func testDlarrv(t *testing.T, impl Dlarrver, n int, d, e []float64, il, iu, ldz int, kind string) {
+	const (
+		eps    = 1.0 / (1 << 53)
+		minrgp = 1e-3
+	)
+
+	wAll := make([]float64, n)
+	copy(wAll, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, wAll, eCopy)
+
+	tnrm := math.Max(1, floats.Norm(d, math.Inf(1))+2*floats.Norm(e, math.Inf(1)))
+	tmat := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		tmat.Data[i*tmat.Stride+i] = d[i]
+		if i < n-1 {
+			tmat.Data[(i+1)*tmat.Stride+i] = e[i]
+			tmat.Data[i*tmat.Stride+i+1] = e[i]
+		}
+	}
+
+	// Compute the representations and the eigenvalue approximations with
+	// the tolerances that Dstemr uses when eigenvectors are wanted.
+	dl := make([]float64, n)
+	copy(dl, d)
+	l := make([]float64, n)
+	copy(l, e)
+	e2 := make([]float64, n)
+	for i, v := range e {
+		e2[i] = v * v
+	}
+	rtol1 := math.Sqrt(eps)
+	rtol2 := math.Max(math.Sqrt(eps)*5e-3, 4*eps)
+	isplit := make([]int, n)
+	w := nanSlice(n)
+	werr := nanSlice(n)
+	wgap := nanSlice(n)
+	iblock := make([]int, n)
+	indexw := make([]int, n)
+	gers := nanSlice(2 * n)
+	vl, vu, nsplit, m, pivmin, ok := impl.Dlarre(lapack.RangeIndex, n, 0, 0, il, iu, dl, l, e2,
+		rtol1, rtol2, -eps, isplit, w, werr, wgap, iblock, indexw, gers, make([]float64, 6*n), make([]int, 5*n))
+	if !ok || m != iu-il+1 || nsplit != 1 {
+		t.Errorf("Case n=%v,kind=%v,il=%v,iu=%v: unexpected result from Dlarre: ok=%v,m=%v,nsplit=%v",
+			n, kind, il, iu, ok, m, nsplit)
+		return
+	}
+
+	// Compute all the eigenvectors, and also only some of them so that
+	// the ends of the computed range fall inside the clusters as well.
+	dolDou := [][2]int{{0, m - 1}}
+	if m > 2 {
+		dolDou = append(dolDou, [2]int{1, m - 2})
+	}
+	if m > 1 {
+		dolDou = append(dolDou, [2]int{m - 1, m - 1})
+	}
+	for _, dd := range dolDou {
+		dol, dou := dd[0], dd[1]
+		prefix := fmt.Sprintf("Case n=%v,kind=%v,il=%v,iu=%v,ldz=%v,dol=%v,dou=%v:", n, kind, il, iu, ldz, dol, dou)
+
+		dv := make([]float64, n)
+		copy(dv, dl)
+		lv := make([]float64, n)
+		copy(lv, l)
+		wv := make([]float64, n)
+		copy(wv, w)
+		werrv := make([]float64, n)
+		copy(werrv, werr)
+		wgapv := make([]float64, n)
+		copy(wgapv, wgap)
+		ldz := ldz
+		if ldz == 0 {
+			ldz = m
+		}
+		z := nanGeneral(n, m, ldz)
+		isuppz := make([]int, 2*m)
+		work := nanSlice(12 * n)
+		iwork := make([]int, 7*n)
+
+		ok := impl.Dlarrv(n, vl, vu, dv, lv, pivmin, isplit, m, dol, dou, minrgp, rtol1, rtol2,
+			wv, werrv, wgapv, iblock, indexw, gers, z.Data, z.Stride, isuppz, work, iwork)
+		if !ok {
+			t.Errorf("%v Dlarrv failed", prefix)
+			continue
+		}
+
+		// For a single block, the j-th eigenvalue is the (il+j)-th
+		// eigenvalue of T. It must not have converged to one of its
+		// neighbours.
+		if !floats.EqualApprox(wv[dol:dou+1], wAll[il+dol:il+dou+1], 1e-12*tnrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsterf; got %v, want %v", prefix, wv[dol:dou+1], wAll[il+dol:il+dou+1])
+		}
+
+		zc := z
+		zc.Data = z.Data[dol:]
+		zc.Cols = dou - dol + 1
+		if !selectedEigenCorrect(tmat, wv[dol:dou+1], zc, 1e-10*tnrm) {
+			t.Errorf("%v eigen decomposition mismatch", prefix)
+		}
+		for j := dol; j <= dou; j++ {
+			for i := 0; i < n; i++ {
+				if (i < isuppz[2*j] || isuppz[2*j+1] < i) && z.Data[i*z.Stride+j] != 0 {
+					t.Errorf("%v eigenvector %v non-zero outside its support", prefix, j)
+					break
+				}
+			}
+		}
+	}
+}
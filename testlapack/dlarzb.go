@@ -0,0 +1,95 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+type Dlarzber interface {
+	Dlarzb(side blas.Side, trans blas.Transpose, direct lapack.Direct, store lapack.StoreV, m, n, k, l int, v []float64, ldv int, t []float64, ldt int, c []float64, ldc int, work []float64, ldwork int)
+	Dlarzter
+}
+
+func DlarzbTest(t *testing.T, impl Dlarzber) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				m, n, k, l, ld int
+			}{
+				{3, 3, 1, 0, 0},
+				{3, 3, 1, 2, 0},
+				{4, 5, 2, 2, 0},
+				{5, 4, 2, 2, 0},
+				{8, 6, 3, 3, 0},
+				{6, 8, 3, 3, 0},
+				{10, 10, 4, 6, 0},
+				{12, 9, 5, 4, 0},
+				{9, 12, 5, 4, 0},
+				{8, 6, 3, 3, 20},
+				{12, 9, 5, 4, 20},
+			} {
+				testDlarzb(t, impl, side, trans, test.m, test.n, test.k, test.l, test.ld, rnd)
+			}
+		}
+	}
+}
+
+func testDlarzb(t *testing.T, impl Dlarzber, side blas.Side, trans blas.Transpose, m, n, k, l, ld int, rnd *rand.Rand) {
+	ldv := ld
+	if ldv == 0 {
+		ldv = max(1, l)
+	}
+	ldt := ld
+	if ldt == 0 {
+		ldt = k
+	}
+	ldc := ld
+	if ldc == 0 {
+		ldc = n
+	}
+	prefix := fmt.Sprintf("Case side=%v,trans=%v,m=%v,n=%v,k=%v,l=%v,ld=%v:", side, trans, m, n, k, l, ld)
+
+	v, tau := randomDlarzReflectors(k, l, ldv, rnd)
+	tm := zeros(k, k, ldt)
+	impl.Dlarzt(lapack.Backward, lapack.RowWise, l, k, v.Data, v.Stride, tau, tm.Data, tm.Stride)
+
+	c := randomGeneral(m, n, ldc, rnd)
+	want := cloneGeneral(c)
+
+	nw := n
+	if side == blas.Right {
+		nw = m
+	}
+	work := nanGeneral(nw, k, k)
+	impl.Dlarzb(side, trans, lapack.Backward, lapack.RowWise, m, n, k, l, v.Data, v.Stride, tm.Data, tm.Stride, c.Data, c.Stride, work.Data, work.Stride)
+
+	// Apply the reflectors H_i one after another with Dlarz. Since
+	// H = H_{k-1} * ... * H_1 * H_0 and each H_i is symmetric, H_0 is
+	// applied first when computing H * C or C * H^T, and last when
+	// computing H^T * C or C * H.
+	forward := (side == blas.Left) == (trans == blas.NoTrans)
+	w := make([]float64, max(m, n))
+	for cnt := 0; cnt < k; cnt++ {
+		i := cnt
+		if !forward {
+			i = k - 1 - cnt
+		}
+		if side == blas.Left {
+			impl.Dlarz(side, m-i, n, l, v.Data[i*v.Stride:], 1, tau[i], want.Data[i*want.Stride:], want.Stride, w)
+		} else {
+			impl.Dlarz(side, m, n-i, l, v.Data[i*v.Stride:], 1, tau[i], want.Data[i:], want.Stride, w)
+		}
+	}
+	if !equalApproxGeneral(c, want, 1e-13) {
+		t.Errorf("%v result mismatch with applying the reflectors in sequence", prefix)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dlarzter interface {
+	Dlarzt(direct lapack.Direct, store lapack.StoreV, n, k int, v []float64, ldv int, tau, t []float64, ldt int)
+	Dlarz(side blas.Side, m, n, l int, v []float64, incv int, tau float64, c []float64, ldc int, work []float64)
+}
+
+func DlarztTest(t *testing.T, impl Dlarzter) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		k, l, ldv, ldt int
+	}{
+		{1, 0, 0, 0},
+		{1, 1, 0, 0},
+		{1, 4, 0, 0},
+		{3, 1, 0, 0},
+		{3, 4, 0, 0},
+		{4, 3, 0, 0},
+		{8, 5, 0, 0},
+		{3, 4, 10, 10},
+		{8, 5, 10, 12},
+	} {
+		k := test.k
+		l := test.l
+		ldv := test.ldv
+		if ldv == 0 {
+			ldv = max(1, l)
+		}
+		ldt := test.ldt
+		if ldt == 0 {
+			ldt = k
+		}
+		prefix := fmt.Sprintf("Case k=%v,l=%v,ldv=%v,ldt=%v:", k, l, ldv, ldt)
+
+		v, tau := randomDlarzReflectors(k, l, ldv, rnd)
+		tm := nanGeneral(k, k, ldt)
+		for i := 0; i < k; i++ {
+			for j := i + 1; j < k; j++ {
+				tm.Data[i*tm.Stride+j] = 100
+			}
+		}
+		impl.Dlarzt(lapack.Backward, lapack.RowWise, l, k, v.Data, v.Stride, tau, tm.Data, tm.Stride)
+
+		// The strictly upper triangle of t must not be referenced.
+		tLower := zeros(k, k, k)
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				if j <= i {
+					tLower.Data[i*tLower.Stride+j] = tm.Data[i*tm.Stride+j]
+				} else if tm.Data[i*tm.Stride+j] != 100 {
+					t.Errorf("%v strictly upper triangle of T modified", prefix)
+				}
+			}
+		}
+
+		// Construct H = I - V^T * T * V of order k+l, where the i-th row
+		// of V is the full vector defining H_i.
+		n := k + l
+		vFull := dlarzVMat(k, l, v)
+		vtt := zeros(n, k, k)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, vFull, tLower, 0, vtt)
+		h := eye(n, n)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, vtt, vFull, 1, h)
+
+		// Compute H = H_{k-1} * ... * H_1 * H_0 by applying the
+		// reflectors in sequence.
+		want := dlarzProduct(impl, k, l, v, tau)
+		if !equalApproxGeneral(h, want, 1e-14) {
+			t.Errorf("%v H = I - V^T * T * V does not match the product of the reflectors", prefix)
+		}
+	}
+}
+
+// randomDlarzReflectors returns a random k×l matrix v with stride ldv and the
+// k scalar factors that make the reflectors defined by v orthogonal, as those
+// produced by Dtzrzf are. The last factor is zero if k > 2, so that one of
+// the reflectors is the identity.
+func randomDlarzReflectors(k, l, ldv int, rnd *rand.Rand) (v blas64.General, tau []float64) {
+	v = randomGeneral(k, l, ldv, rnd)
+	tau = make([]float64, k)
+	for i := range tau {
+		vi := blas64.Vector{Inc: 1, Data: v.Data[i*v.Stride : i*v.Stride+l]}
+		tau[i] = 2 / (1 + blas64.Dot(l, vi, vi))
+	}
+	if k > 2 {
+		tau[k-1] = 0
+	}
+	return v, tau
+}
+
+// dlarzVMat returns the k×(k+l) matrix whose i-th row is the vector defining
+// the i-th reflector stored in the k×l matrix v. The first k columns form the
+// identity and the last l columns are a copy of v.
+func dlarzVMat(k, l int, v blas64.General) blas64.General {
+	n := k + l
+	vFull := zeros(k, n, n)
+	for i := 0; i < k; i++ {
+		vFull.Data[i*vFull.Stride+i] = 1
+		copy(vFull.Data[i*vFull.Stride+k:i*vFull.Stride+n], v.Data[i*v.Stride:i*v.Stride+l])
+	}
+	return vFull
+}
+
+// dlarzProduct returns the product H_{k-1} * ... * H_1 * H_0 of order k+l of
+// the reflectors defined by v and tau, computed by applying them with Dlarz
+// to the identity matrix.
+func dlarzProduct(impl Dlarzter, k, l int, v blas64.General, tau []float64) blas64.General {
+	n := k + l
+	h := eye(n, n)
+	work := make([]float64, n)
+	for i := 0; i < k; i++ {
+		impl.Dlarz(blas.Left, n-i, n, l, v.Data[i*v.Stride:], 1, tau[i], h.Data[i*h.Stride:], h.Stride, work)
+	}
+	return h
+}
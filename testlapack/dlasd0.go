@@ -0,0 +1,103 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dlasd0er interface {
+	Dlasd0(n, sqre int, d, e, u []float64, ldu int, vt []float64, ldvt int, smlsiz int, iwork []int, work []float64) (ok bool)
+}
+
+func Dlasd0Test(t *testing.T, impl Dlasd0er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 5, 10, 26, 50, 100} {
+		for _, sqre := range []int{0, 1} {
+			for _, smlsiz := range []int{3, 25} {
+				for _, ld := range []int{0, n + 5} {
+					testDlasd0(t, impl, n, sqre, smlsiz, ld, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDlasd0(t *testing.T, impl Dlasd0er, n, sqre, smlsiz, ld int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	m := n + sqre
+	ldu := ld
+	if ldu == 0 {
+		ldu = n
+	}
+	ldvt := ld
+	if ldvt == 0 {
+		ldvt = m
+	}
+	prefix := fmt.Sprintf("Case n=%v,sqre=%v,smlsiz=%v,ldu=%v,ldvt=%v:", n, sqre, smlsiz, ldu, ldvt)
+
+	// Generate the n×m upper bidiagonal matrix B.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = rnd.NormFloat64()
+	}
+	e := make([]float64, m-1)
+	for i := range e {
+		e[i] = rnd.NormFloat64()
+	}
+	b := zeros(n, m, m)
+	for i := 0; i < n; i++ {
+		b.Data[i*b.Stride+i] = d[i]
+		if i < m-1 {
+			b.Data[i*b.Stride+i+1] = e[i]
+		}
+	}
+
+	// u and vt hold the identity on entry, as they do when Dbdsdc calls
+	// Dlasd0.
+	u := eye(n, ldu)
+	vt := eye(m, ldvt)
+	iwork := make([]int, 8*n)
+	work := nanSlice(3*m*m + 2*m)
+	ok := impl.Dlasd0(n, sqre, d, e, u.Data, u.Stride, vt.Data, vt.Stride, smlsiz, iwork, work)
+	if !ok {
+		t.Errorf("%v Dlasd0 failed", prefix)
+		return
+	}
+
+	for i, v := range d {
+		if v < 0 || math.IsNaN(v) {
+			t.Errorf("%v singular value %v is %v", prefix, i, v)
+		}
+	}
+	if !isOrthonormal(u) {
+		t.Errorf("%v U is not orthogonal", prefix)
+	}
+	if !isOrthonormal(vt) {
+		t.Errorf("%v VT is not orthogonal", prefix)
+	}
+
+	// Check that B = U * S * VT.
+	us := zeros(n, m, m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			us.Data[i*us.Stride+j] = u.Data[i*u.Stride+j] * d[j]
+		}
+	}
+	usvt := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, us, vt, 0, usvt)
+	bnorm := math.Max(1, floats.Norm(b.Data, math.Inf(1)))
+	if !equalApproxGeneral(usvt, b, tol*bnorm) {
+		t.Errorf("%v B != U * S * VT", prefix)
+	}
+}
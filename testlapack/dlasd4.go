@@ -0,0 +1,128 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/floats"
+)
+
+type Dlasd4er interface {
+	Dlasd4(n, i int, d, z, delta []float64, rho float64, work []float64) (sigma float64, ok bool)
+}
+
+func Dlasd4Test(t *testing.T, impl Dlasd4er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{1, 2, 3, 4, 5, 10, 20, 50} {
+		for _, kind := range []string{"random", "zero first", "clustered", "small z"} {
+			for cas := 0; cas < 10; cas++ {
+				testDlasd4(t, impl, n, kind, rnd)
+			}
+		}
+	}
+}
+
+func testDlasd4(t *testing.T, impl Dlasd4er, n int, kind string, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	// Generate distinct sorted non-negative d and a unit vector z.
+	d := make([]float64, n)
+	z := make([]float64, n)
+	switch kind {
+	case "random":
+		for i := range d {
+			d[i] = math.Abs(rnd.NormFloat64())
+		}
+	case "zero first":
+		// Dlasd2 passes a zero as the first element of d.
+		for i := range d {
+			d[i] = math.Abs(rnd.NormFloat64())
+		}
+		sort.Float64s(d)
+		d[0] = 0
+	case "clustered":
+		for i := range d {
+			d[i] = 1 + float64(i/2) + 1e-8*float64(i%2)
+		}
+	case "small z":
+		for i := range d {
+			d[i] = float64(i + 1)
+		}
+	}
+	sort.Float64s(d)
+	for i := range z {
+		z[i] = rnd.NormFloat64()
+		if kind == "small z" && i%3 == 1 {
+			z[i] *= 1e-6
+		}
+	}
+	floats.Scale(1/floats.Norm(z, 2), z)
+	rho := 0.1 + rnd.Float64()
+
+	dmax := d[n-1]
+	for i := 0; i < n; i++ {
+		prefix := fmt.Sprintf("Case n=%v,kind=%v,i=%v:", n, kind, i)
+
+		delta := nanSlice(n)
+		work := nanSlice(n)
+		sigma, ok := impl.Dlasd4(n, i, d, z, delta, rho, work)
+		if !ok {
+			t.Errorf("%v Dlasd4 did not converge", prefix)
+			continue
+		}
+
+		if n == 1 {
+			want := math.Sqrt(d[0]*d[0] + rho*z[0]*z[0])
+			if math.Abs(sigma-want) > tol*want {
+				t.Errorf("%v unexpected singular value; got %v, want %v", prefix, sigma, want)
+			}
+			if delta[0] != 1 || work[0] != 1 {
+				t.Errorf("%v unexpected delta or work; got %v and %v, want 1", prefix, delta[0], work[0])
+			}
+			continue
+		}
+
+		// The singular values interlace with d.
+		if sigma < d[i] {
+			t.Errorf("%v singular value %v below d[%v]=%v", prefix, sigma, i, d[i])
+		}
+		if i < n-1 && sigma > d[i+1] {
+			t.Errorf("%v singular value %v above d[%v]=%v", prefix, sigma, i+1, d[i+1])
+		}
+		if i == n-1 && sigma*sigma > (dmax*dmax+rho)*(1+tol) {
+			t.Errorf("%v singular value %v above bound %v", prefix, sigma, math.Sqrt(dmax*dmax+rho))
+		}
+
+		// delta and work contain the differences and sums of d and sigma.
+		for j := 0; j < n; j++ {
+			if math.Abs(delta[j]-(d[j]-sigma)) > tol*(dmax+sigma)+tol {
+				t.Errorf("%v delta[%v]=%v, want %v", prefix, j, delta[j], d[j]-sigma)
+			}
+			if math.Abs(work[j]-(d[j]+sigma)) > tol*(dmax+sigma)+tol {
+				t.Errorf("%v work[%v]=%v, want %v", prefix, j, work[j], d[j]+sigma)
+			}
+		}
+
+		// sigma^2 is a root of the secular equation
+		//  1/rho + sum_j z[j]^2 / ((d[j] - sigma) * (d[j] + sigma)) = 0.
+		// The terms are computed with delta and work, which hold the
+		// differences without the cancellation in the subtraction.
+		f := 1 / rho
+		scale := 1 / rho
+		for j := 0; j < n; j++ {
+			term := z[j] * z[j] / (delta[j] * work[j])
+			f += term
+			scale += math.Abs(term)
+		}
+		if math.Abs(f) > float64(n)*tol*scale {
+			t.Errorf("%v secular equation residual %v too large (scale %v)", prefix, f, scale)
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dlasdqer interface {
+	Dlasdq(uplo blas.Uplo, sqre, n, ncvt, nru, ncc int, d, e, vt []float64, ldvt int, u []float64, ldu int, c []float64, ldc int, work []float64) (ok bool)
+}
+
+func DlasdqTest(t *testing.T, impl Dlasdqer) {
+	rnd := rand.New(rand.NewSource(1))
+	bi := blas64.Implementation()
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, sqre := range []int{0, 1} {
+			for _, test := range []struct {
+				n, ncvt, nru, ncc, ldvt, ldu, ldc int
+			}{
+				{1, 1, 1, 1, 0, 0, 0},
+				{5, 5, 5, 5, 0, 0, 0},
+				{10, 10, 10, 10, 0, 0, 0},
+				{10, 11, 12, 13, 0, 0, 0},
+				{20, 13, 12, 11, 0, 0, 0},
+
+				{5, 5, 5, 5, 6, 7, 8},
+				{10, 10, 10, 10, 30, 40, 50},
+				{10, 12, 11, 13, 30, 40, 50},
+				{20, 12, 13, 11, 30, 40, 50},
+			} {
+				for cas := 0; cas < 20; cas++ {
+					n := test.n
+					ncvt := test.ncvt
+					nru := test.nru
+					ncc := test.ncc
+					ldvt := test.ldvt
+					ldu := test.ldu
+					ldc := test.ldc
+					np := n + sqre
+					if ldvt == 0 {
+						ldvt = ncvt
+					}
+					if ldu == 0 {
+						ldu = np
+					}
+					if ldc == 0 {
+						ldc = ncc
+					}
+
+					// B is n×np if uplo == blas.Upper and np×n if
+					// uplo == blas.Lower, where np = n+sqre. The order of
+					// P is the number of columns and the order of Q the
+					// number of rows of B.
+					nrow, ncol := n, np
+					if uplo == blas.Lower {
+						nrow, ncol = np, n
+					}
+
+					d := make([]float64, n)
+					for i := range d {
+						d[i] = rnd.NormFloat64()
+					}
+					e := make([]float64, np-1)
+					for i := range e {
+						e[i] = rnd.NormFloat64()
+					}
+					dCopy := make([]float64, len(d))
+					copy(dCopy, d)
+					eCopy := make([]float64, len(e))
+					copy(eCopy, e)
+					work := make([]float64, 4*n)
+					for i := range work {
+						work[i] = rnd.NormFloat64()
+					}
+
+					// First test the decomposition of the bidiagonal
+					// matrix. Set pt and q equal to I with the correct
+					// size. At the result of Dlasdq, pt and q will
+					// contain P^T and Q, which will be used in the next
+					// step to test the multiplication with VT, U and C.
+					ldq := np
+					q := make([]float64, nrow*ldq)
+					for i := 0; i < nrow; i++ {
+						q[i*ldq+i] = 1
+					}
+					ldpt := ncol
+					pt := make([]float64, np*ldpt)
+					for i := 0; i < ncol; i++ {
+						pt[i*ldpt+i] = 1
+					}
+
+					ok := impl.Dlasdq(uplo, sqre, n, ncol, nrow, 0, d, e, pt, ldpt, q, ldq, nil, 1, work)
+
+					errStr := fmt.Sprintf("uplo = %c, sqre = %v, n = %v, ncvt = %v, nru = %v, ncc = %v", uplo, sqre, n, ncvt, nru, ncc)
+					if !ok {
+						t.Errorf("Unexpected Dlasdq failure: %s", errStr)
+					}
+
+					bMat := zeros(nrow, ncol, ncol)
+					for i := 0; i < n; i++ {
+						bMat.Data[i*bMat.Stride+i] = dCopy[i]
+					}
+					for i := 0; i < np-1; i++ {
+						if uplo == blas.Upper {
+							bMat.Data[i*bMat.Stride+i+1] = eCopy[i]
+						} else {
+							bMat.Data[(i+1)*bMat.Stride+i] = eCopy[i]
+						}
+					}
+					qs := zeros(nrow, ncol, ncol)
+					for i := 0; i < nrow; i++ {
+						for j := 0; j < n; j++ {
+							qs.Data[i*qs.Stride+j] = q[i*ldq+j] * d[j]
+						}
+					}
+					ansMat := zeros(nrow, ncol, ncol)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, nrow, ncol, ncol, 1, qs.Data, qs.Stride, pt, ldpt, 0, ansMat.Data, ansMat.Stride)
+
+					same := true
+					for i := 0; i < nrow; i++ {
+						for j := 0; j < ncol; j++ {
+							if !floats.EqualWithinAbsOrRel(ansMat.Data[i*ansMat.Stride+j], bMat.Data[i*bMat.Stride+j], 1e-8, 1e-8) {
+								same = false
+							}
+						}
+					}
+					if !same {
+						t.Errorf("Bidiagonal mismatch. %s", errStr)
+					}
+					if !sort.Float64sAreSorted(d) {
+						t.Errorf("D is not sorted. %s", errStr)
+					}
+					for _, v := range d {
+						if v < 0 {
+							t.Errorf("Negative singular value. %s", errStr)
+							break
+						}
+					}
+
+					// The above computed the real P and Q. Now input data
+					// for V^T, U, and C to check that the multiplications
+					// happen properly.
+					dAns := make([]float64, len(d))
+					copy(dAns, d)
+
+					u := make([]float64, nru*ldu)
+					for i := range u {
+						u[i] = rnd.NormFloat64()
+					}
+					uCopy := make([]float64, len(u))
+					copy(uCopy, u)
+					vt := make([]float64, np*ldvt)
+					for i := range vt {
+						vt[i] = rnd.NormFloat64()
+					}
+					vtCopy := make([]float64, len(vt))
+					copy(vtCopy, vt)
+					c := make([]float64, np*ldc)
+					for i := range c {
+						c[i] = rnd.NormFloat64()
+					}
+					cCopy := make([]float64, len(c))
+					copy(cCopy, c)
+
+					// Reset input data
+					copy(d, dCopy)
+					copy(e, eCopy)
+					impl.Dlasdq(uplo, sqre, n, ncvt, nru, ncc, d, e, vt, ldvt, u, ldu, c, ldc, work)
+
+					// Check result.
+					if !floats.EqualApprox(d, dAns, 1e-14) {
+						t.Errorf("D mismatch second time. %s", errStr)
+					}
+					ans := make([]float64, len(vtCopy))
+					copy(ans, vtCopy)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, ncol, ncvt, ncol, 1, pt, ldpt, vtCopy, ldvt, 0, ans, ldvt)
+					if !floats.EqualApprox(ans, vt, 1e-10) {
+						t.Errorf("Vt result mismatch. %s", errStr)
+					}
+					ans = make([]float64, len(uCopy))
+					copy(ans, uCopy)
+					bi.Dgemm(blas.NoTrans, blas.NoTrans, nru, nrow, nrow, 1, uCopy, ldu, q, ldq, 0, ans, ldu)
+					if !floats.EqualApprox(ans, u, 1e-10) {
+						t.Errorf("U result mismatch. %s", errStr)
+					}
+					ans = make([]float64, len(cCopy))
+					copy(ans, cCopy)
+					bi.Dgemm(blas.Trans, blas.NoTrans, nrow, ncc, nrow, 1, q, ldq, cCopy, ldc, 0, ans, ldc)
+					if !floats.EqualApprox(ans, c, 1e-10) {
+						t.Errorf("C result mismatch. %s", errStr)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dlasyfer interface {
+	Dlasyf(uplo blas.Uplo, n, nb int, a []float64, lda int, ipiv []int, w []float64, ldw int) (kb int, ok bool)
+	Dsytf2(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) (ok bool)
+}
+
+func DlasyfTest(t *testing.T, impl Dlasyfer) {
+	rnd := rand.New(rand.NewSource(1))
+	ns := []int{1, 2, 3, 4, 5, 10, 25, 50}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, nb := range []int{2, 3, 4, 10, 64} {
+			// Factorize one panel with Dlasyf and the rest of the
+			// matrix with Dsytf2.
+			dsytrf := func(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool {
+				w := nanSlice(n * nb)
+				kb, ok := impl.Dlasyf(uplo, n, nb, a, lda, ipiv, w, nb)
+				if kb < 0 || kb > n || (nb < n && kb < nb-1) || (nb >= n && kb != n) {
+					t.Errorf("uplo = %v, n = %v, nb = %v: unexpected kb = %v", uplo, n, nb, kb)
+					return ok
+				}
+				if kb == n {
+					return ok
+				}
+				if uplo == blas.Upper {
+					return impl.Dsytf2(uplo, n-kb, a, lda, ipiv) && ok
+				}
+				ok2 := impl.Dsytf2(uplo, n-kb, a[kb*lda+kb:], lda, ipiv[kb:])
+				for i := kb; i < n; i++ {
+					if ipiv[i] >= 0 {
+						ipiv[i] += kb
+					} else {
+						ipiv[i] -= kb
+					}
+				}
+				return ok2 && ok
+			}
+			dsytrfTest(t, dsytrf, false, rnd, uplo, ns)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dlat2ser interface {
+	Dlat2s(uplo blas.Uplo, n int, a []float64, lda int, sa []float32, ldsa int) (ok bool)
+}
+
+func Dlat2sTest(t *testing.T, impl Dlat2ser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda, ldsa int
+		}{
+			{0, 0, 0},
+			{1, 0, 0},
+			{2, 0, 0},
+			{5, 0, 0},
+			{10, 0, 0},
+			{5, 8, 10},
+			{10, 12, 15},
+		} {
+			for _, big := range []float64{0, math.MaxFloat32, 2 * math.MaxFloat32, -2 * math.MaxFloat32, math.Inf(1), math.Inf(-1)} {
+				for _, inTri := range []bool{true, false} {
+					testDlat2s(t, impl, uplo, test.n, test.lda, test.ldsa, big, inTri, rnd)
+				}
+			}
+		}
+	}
+}
+
+// testDlat2s checks Dlat2s with a random matrix A. If big is not zero, it is
+// placed at a random position in the triangle of A specified by uplo if inTri
+// is true, and in the opposite triangle otherwise. The conversion must fail
+// only if big is in the triangle and outside the range of float32.
+func testDlat2s(t *testing.T, impl Dlat2ser, uplo blas.Uplo, n, lda, ldsa int, big float64, inTri bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldsa == 0 {
+		ldsa = max(1, n)
+	}
+	if big != 0 && (n == 0 || (n == 1 && !inTri)) {
+		return
+	}
+	prefix := fmt.Sprintf("Case uplo=%v,n=%v,lda=%v,ldsa=%v,big=%v,inTri=%v:", uplo, n, lda, ldsa, big, inTri)
+
+	inUplo := func(i, j int) bool {
+		if uplo == blas.Upper {
+			return i <= j
+		}
+		return j <= i
+	}
+
+	a := randomGeneral(n, n, lda, rnd)
+	if big != 0 {
+		for {
+			i := rnd.Intn(n)
+			j := rnd.Intn(n)
+			if inUplo(i, j) == inTri {
+				a.Data[i*lda+j] = big
+				break
+			}
+		}
+	}
+	sa := make([]float32, max(0, (n-1)*ldsa+n))
+	for i := range sa {
+		sa[i] = float32(math.NaN())
+	}
+
+	ok := impl.Dlat2s(uplo, n, a.Data, a.Stride, sa, ldsa)
+
+	overflow := inTri && math.Abs(big) > math.MaxFloat32
+	if ok == overflow {
+		t.Errorf("%v unexpected ok=%v", prefix, ok)
+	}
+	if overflow {
+		return
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			got := sa[i*ldsa+j]
+			if !inUplo(i, j) {
+				if !math.IsNaN(float64(got)) {
+					t.Errorf("%v element (%v,%v) outside the triangle modified", prefix, i, j)
+				}
+				continue
+			}
+			if want := float32(a.Data[i*lda+j]); got != want {
+				t.Errorf("%v element (%v,%v) is %v, want %v", prefix, i, j, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dlatbser interface {
+	Dlatbs(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, normin bool, n, kd int, ab []float64, ldab int, x []float64, cnorm []float64) (scale float64)
+}
+
+func DlatbsTest(t *testing.T, impl Dlatbser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, trans := range []blas.Transpose{blas.Trans, blas.NoTrans} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 7, 10, 20, 50} {
+				for _, kd := range []int{0, 1, 2, 5, (n + 1) / 2, max(0, n-1)} {
+					for _, extra := range []int{0, 3} {
+						imats := []int{7, 11, 12, 13, 14, 15, 16, 17, 18}
+						if n < 6 {
+							imats = append(imats, 19)
+						}
+						for _, imat := range imats {
+							testDlatbs(t, impl, imat, uplo, trans, n, kd, kd+1+extra, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDlatbs(t *testing.T, impl Dlatbser, imat int, uplo blas.Uplo, trans blas.Transpose, n, kd, ldab int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	// Generate a dense triangular test matrix and right hand side and
+	// restrict the matrix to the band.
+	lda := max(1, n)
+	a := nanSlice(n * lda)
+	b := nanSlice(n)
+	work := make([]float64, 3*n)
+	diag := dlattr(imat, uplo, trans, n, a, lda, b, work, rnd)
+	if imat <= 10 {
+		// b has not been generated.
+		dlarnv(b, 3, rnd)
+	}
+	ab := nanSlice(n * ldab)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j < n; j++ {
+				if j <= i+kd {
+					ab[i*ldab+j-i] = a[i*lda+j]
+				} else {
+					a[i*lda+j] = 0
+				}
+			}
+		} else {
+			for j := 0; j <= i; j++ {
+				if j >= i-kd {
+					ab[i*ldab+kd+j-i] = a[i*lda+j]
+				} else {
+					a[i*lda+j] = 0
+				}
+			}
+		}
+	}
+
+	cnorm := nanSlice(n)
+	x := make([]float64, n)
+
+	// Call Dlatbs with normin=false.
+	copy(x, b)
+	scale := impl.Dlatbs(uplo, trans, diag, false, n, kd, ab, ldab, x, cnorm)
+	prefix := fmt.Sprintf("Case imat=%v (n=%v,kd=%v,ldab=%v,trans=%v,uplo=%v,diag=%v", imat, n, kd, ldab, trans, uplo, diag)
+	for i, v := range cnorm {
+		if math.IsNaN(v) {
+			t.Errorf("%v: cnorm[%v] not computed (scale=%v,normin=false)", prefix, i, scale)
+		}
+	}
+	resid, hasNaN := dlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, work[:n])
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=false)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=false)", prefix, resid, scale)
+	}
+
+	// Call Dlatbs with normin=true because cnorm has been filled.
+	copy(x, b)
+	scale = impl.Dlatbs(uplo, trans, diag, true, n, kd, ab, ldab, x, cnorm)
+	resid, hasNaN = dlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, work[:n])
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=true)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=true)", prefix, resid, scale)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dlatpser interface {
+	Dlatps(uplo blas.Uplo, trans blas.Transpose, diag blas.Diag, normin bool, n int, ap []float64, x []float64, cnorm []float64) (scale float64)
+}
+
+func DlatpsTest(t *testing.T, impl Dlatpser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, trans := range []blas.Transpose{blas.Trans, blas.NoTrans} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 7, 10, 20, 50} {
+				imats := []int{7, 11, 12, 13, 14, 15, 16, 17, 18}
+				if n < 6 {
+					imats = append(imats, 19)
+				}
+				for _, imat := range imats {
+					testDlatps(t, impl, imat, uplo, trans, n, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDlatps(t *testing.T, impl Dlatpser, imat int, uplo blas.Uplo, trans blas.Transpose, n int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	// Generate a dense triangular test matrix and right hand side and store
+	// the matrix in packed format.
+	lda := max(1, n)
+	a := nanSlice(n * lda)
+	b := nanSlice(n)
+	work := make([]float64, 3*n)
+	diag := dlattr(imat, uplo, trans, n, a, lda, b, work, rnd)
+	if imat <= 10 {
+		// b has not been generated.
+		dlarnv(b, 3, rnd)
+	}
+	ap := make([]float64, n*(n+1)/2)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				ap[packedIndex(uplo, n, i, j)] = a[i*lda+j]
+			}
+		}
+	}
+
+	cnorm := nanSlice(n)
+	x := make([]float64, n)
+
+	// Call Dlatps with normin=false.
+	copy(x, b)
+	scale := impl.Dlatps(uplo, trans, diag, false, n, ap, x, cnorm)
+	prefix := fmt.Sprintf("Case imat=%v (n=%v,trans=%v,uplo=%v,diag=%v", imat, n, trans, uplo, diag)
+	for i, v := range cnorm {
+		if math.IsNaN(v) {
+			t.Errorf("%v: cnorm[%v] not computed (scale=%v,normin=false)", prefix, i, scale)
+		}
+	}
+	resid, hasNaN := dlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, work[:n])
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=false)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=false)", prefix, resid, scale)
+	}
+
+	// Call Dlatps with normin=true because cnorm has been filled.
+	copy(x, b)
+	scale = impl.Dlatps(uplo, trans, diag, true, n, ap, x, cnorm)
+	resid, hasNaN = dlatrsResidual(uplo, trans, diag, n, a, lda, scale, cnorm, x, b, work[:n])
+	if hasNaN {
+		t.Errorf("%v: unexpected NaN (scale=%v,normin=true)", prefix, scale)
+	} else if resid > tol {
+		t.Errorf("%v: residual %v too large (scale=%v,normin=true)", prefix, resid, scale)
+	}
+}
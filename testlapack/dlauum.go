@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dlauumer interface {
+	Dlauum(uplo blas.Uplo, n int, a []float64, lda int)
+}
+
+func DlauumTest(t *testing.T, impl Dlauumer) {
+	rnd := rand.New(rand.NewSource(1))
+	// Include small and large sizes to make sure that both unblocked and
+	// blocked paths are taken.
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25, 31, 32, 33, 63, 64, 65, 127, 128, 129}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		dlauuTest(t, impl.Dlauum, rnd, uplo, ns)
+	}
+}
+
+func dlauuTest(t *testing.T, dlauu func(blas.Uplo, int, []float64, int), rnd *rand.Rand, uplo blas.Uplo, ns []int) {
+	for _, n := range ns {
+		for _, lda := range []int{max(1, n), n + 11} {
+			errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v", uplo, n, lda)
+
+			// Allocate n×n matrix A and fill it with random numbers.
+			a := randomSlice(n*lda, rnd)
+			aCopy := make([]float64, len(a))
+			copy(aCopy, a)
+
+			// Compute the product U*U^T or L^T*L in place.
+			dlauu(uplo, n, a, lda)
+			if n == 0 {
+				continue
+			}
+
+			// Check that the opposite strict triangle has not been
+			// modified.
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+						if a[i*lda+j] != aCopy[i*lda+j] {
+							t.Errorf("%v: unexpected modification of the opposite triangle", errStr)
+							return
+						}
+					}
+				}
+			}
+
+			// Compute the expected result using the explicit
+			// triangular factor.
+			tri := blas64.Triangular{N: n, Stride: lda, Data: aCopy, Uplo: uplo, Diag: blas.NonUnit}
+			want := zeros(n, n, n)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && j <= i) {
+						want.Data[i*n+j] = aCopy[i*lda+j]
+					}
+				}
+			}
+			if uplo == blas.Upper {
+				// want = U * U^T.
+				blas64.Trmm(blas.Right, blas.Trans, 1, tri, want)
+			} else {
+				// want = L^T * L.
+				blas64.Trmm(blas.Left, blas.Trans, 1, tri, want)
+			}
+
+			// Compare the triangle of the result with the expected
+			// values.
+			got := blas64.General{Rows: n, Cols: n, Stride: lda, Data: a}
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+						got.Data[i*lda+j] = 0
+						want.Data[i*n+j] = 0
+					}
+				}
+			}
+			if !equalApproxGeneral(got, want, 1e-12*float64(n)) {
+				t.Errorf("%v: unexpected result", errStr)
+			}
+		}
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dopgtrer interface {
+	Dopgtr(uplo blas.Uplo, n int, ap, tau, q []float64, ldq int, work []float64)
+	Dsptrder
+}
+
+func DopgtrTest(t *testing.T, impl Dopgtrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, ldq := range []int{max(1, n), n + 3} {
+				testDopgtr(t, impl, uplo, n, ldq, rnd)
+			}
+		}
+	}
+}
+
+func testDopgtr(t *testing.T, impl Dopgtrer, uplo blas.Uplo, n, ldq int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, ldq = %v", uplo, n, ldq)
+
+	ap := randomSlice(n*(n+1)/2, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+
+	d := nanSlice(n)
+	e := nanSlice(max(0, n-1))
+	tau := nanSlice(max(0, n-1))
+	impl.Dsptrd(uplo, n, ap, d, e, tau)
+
+	q := nanGeneral(n, n, ldq)
+	work := nanSlice(max(0, n-1))
+	impl.Dopgtr(uplo, n, ap, tau, q.Data, q.Stride, work)
+	if n == 0 {
+		return
+	}
+
+	if !isOrthonormal(q) {
+		t.Errorf("Q is not orthonormal: %s", errStr)
+	}
+	want := constructQsptrd(uplo, n, ap, tau)
+	if !equalApproxGeneral(q, want, tol*float64(n)) {
+		t.Errorf("Unexpected Q: %s", errStr)
+	}
+	checkTridiagReduction(t, a, q, d, e, tol*float64(n), errStr)
+}
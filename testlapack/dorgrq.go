@@ -0,0 +1,126 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dorgrqer interface {
+	Dorgrq(m, n, k int, a []float64, lda int, tau, work []float64, lwork int)
+
+	Dlarfger
+}
+
+func DorgrqTest(t *testing.T, impl Dorgrqer) {
+	const tol = 1e-14
+
+	type Dorgr2er interface {
+		Dorgr2(m, n, k int, a []float64, lda int, tau, work []float64)
+	}
+	dorgr2er, hasDorgr2 := impl.(Dorgr2er)
+
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 7, 10, 15, 30, 50, 150} {
+		for _, extra := range []int{0, 11} {
+			for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+				var k int
+				if n >= 129 {
+					// For large matrices make sure that k
+					// is large enough to trigger blocked
+					// path.
+					k = 129 + rnd.Intn(n-129+1)
+				} else {
+					k = rnd.Intn(n + 1)
+				}
+				m := k + rnd.Intn(n-k+1)
+				if m == 0 || n == 0 {
+					m = 0
+					n = 0
+					k = 0
+				}
+
+				// Generate k elementary reflectors in the last
+				// k rows of A.
+				a := nanGeneral(m, n, n+extra)
+				tau := make([]float64, k)
+				for l := 0; l < k; l++ {
+					jj := n - k + l
+					v := randomSlice(jj, rnd)
+					_, tau[l] = impl.Dlarfg(len(v)+1, rnd.NormFloat64(), v, 1)
+					i := m - k + l
+					copy(a.Data[i*a.Stride:i*a.Stride+jj], v)
+				}
+				aCopy := cloneGeneral(a)
+
+				// Compute the full matrix Q by forming the
+				// Householder reflectors explicitly.
+				q := eye(n, n)
+				qCopy := eye(n, n)
+				for l := 0; l < k; l++ {
+					h := eye(n, n)
+					jj := n - k + l
+					i := m - k + l
+					v := blas64.Vector{Inc: 1, Data: make([]float64, n)}
+					copy(v.Data, a.Data[i*a.Stride:i*a.Stride+jj])
+					v.Data[jj] = 1
+					blas64.Ger(-tau[l], v, v, h)
+					copy(qCopy.Data, q.Data)
+					blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, qCopy, h, 0, q)
+				}
+				// View the last m rows of Q as 'want'.
+				want := blas64.General{
+					Rows:   m,
+					Cols:   n,
+					Stride: q.Stride,
+					Data:   q.Data[(n-m)*q.Stride:],
+				}
+
+				var lwork int
+				switch wl {
+				case minimumWork:
+					lwork = max(1, m)
+				case mediumWork:
+					work := make([]float64, 1)
+					impl.Dorgrq(m, n, k, nil, a.Stride, nil, work, -1)
+					lwork = (int(work[0]) + m) / 2
+					lwork = max(1, lwork)
+				case optimumWork:
+					work := make([]float64, 1)
+					impl.Dorgrq(m, n, k, nil, a.Stride, nil, work, -1)
+					lwork = int(work[0])
+				}
+				work := make([]float64, lwork)
+
+				// Compute the last m rows of Q by a call to
+				// Dorgrq.
+				impl.Dorgrq(m, n, k, a.Data, a.Stride, tau, work, len(work))
+
+				prefix := fmt.Sprintf("Case m=%v,n=%v,k=%v,wl=%v", m, n, k, wl)
+				if !generalOutsideAllNaN(a) {
+					t.Errorf("%v: out-of-range write to A", prefix)
+				}
+				if !equalApproxGeneral(want, a, tol) {
+					t.Errorf("%v: unexpected Q", prefix)
+				}
+
+				// Compute the last m rows of Q by a call to
+				// Dorgr2 and check that we get the same result.
+				if !hasDorgr2 {
+					continue
+				}
+				dorgr2er.Dorgr2(m, n, k, aCopy.Data, aCopy.Stride, tau, work)
+				if !equalApproxGeneral(aCopy, a, tol) {
+					t.Errorf("%v: mismatch between Dorgrq and Dorgr2", prefix)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+)
+
+type Dormrqer interface {
+	Dormr2er
+	Dormrq(side blas.Side, trans blas.Transpose, m, n, k int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+}
+
+func DormrqTest(t *testing.T, impl Dormrqer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				common, adim, cdim, lda, ldc int
+			}{
+				{0, 0, 0, 0, 0},
+				{6, 7, 8, 0, 0},
+				{6, 8, 7, 0, 0},
+				{7, 6, 8, 0, 0},
+				{7, 8, 6, 0, 0},
+				{8, 6, 7, 0, 0},
+				{8, 7, 6, 0, 0},
+				{100, 200, 300, 0, 0},
+				{100, 300, 200, 0, 0},
+				{200, 100, 300, 0, 0},
+				{200, 300, 100, 0, 0},
+				{300, 100, 200, 0, 0},
+				{300, 200, 100, 0, 0},
+				{100, 200, 300, 400, 500},
+				{100, 300, 200, 400, 500},
+				{200, 100, 300, 400, 500},
+				{200, 300, 100, 400, 500},
+				{300, 100, 200, 400, 500},
+				{300, 200, 100, 400, 500},
+				{100, 200, 300, 500, 400},
+				{100, 300, 200, 500, 400},
+				{200, 100, 300, 500, 400},
+				{200, 300, 100, 500, 400},
+				{300, 100, 200, 500, 400},
+				{300, 200, 100, 500, 400},
+			} {
+				for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+					testDormrq(t, impl, side, trans, test.common, test.adim, test.cdim, test.lda, test.ldc, wl, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDormrq(t *testing.T, impl Dormrqer, side blas.Side, trans blas.Transpose, common, adim, cdim, lda, ldc int, wl worklen, rnd *rand.Rand) {
+	// A is an adim×common matrix whose RQ factorization defines the
+	// common×common orthogonal matrix Q.
+	ma := adim
+	na := common
+	mc, nc := common, cdim
+	nw := nc
+	if side == blas.Right {
+		mc, nc = cdim, common
+		nw = mc
+	}
+	if lda == 0 {
+		lda = max(1, na)
+	}
+	if ldc == 0 {
+		ldc = max(1, nc)
+	}
+	a := randomSlice(ma*lda, rnd)
+	c := randomSlice(mc*ldc, rnd)
+
+	// Compute the RQ factorization of A.
+	k := min(ma, na)
+	tau := make([]float64, k)
+	work := make([]float64, 1)
+	impl.Dgerqf(ma, na, a, lda, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dgerqf(ma, na, a, lda, tau, work, len(work))
+	// The reflectors are stored in the last k rows of A.
+	a = a[(ma-k)*lda:]
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+
+	// Compute the reference result using the unblocked Dormr2.
+	want := make([]float64, len(c))
+	copy(want, c)
+	impl.Dormr2(side, trans, mc, nc, k, a, lda, tau, want, ldc, make([]float64, nw))
+
+	impl.Dormrq(side, trans, mc, nc, k, a, lda, tau, c, ldc, work, -1)
+	lwork := max(1, nw)
+	switch wl {
+	case mediumWork:
+		lwork = max(lwork, (int(work[0])+lwork)/2)
+	case optimumWork:
+		lwork = max(lwork, int(work[0]))
+	}
+	work = randomSlice(lwork, rnd)
+	impl.Dormrq(side, trans, mc, nc, k, a, lda, tau, c, ldc, work, lwork)
+
+	errStr := fmt.Sprintf("side = %v, trans = %v, common = %v, adim = %v, cdim = %v, lda = %v, ldc = %v, wl = %v",
+		side, trans, common, adim, cdim, lda, ldc, wl)
+	if !floats.Equal(a, aCopy) {
+		t.Errorf("A changed during call: %s", errStr)
+	}
+	if !floats.EqualApprox(c, want, 1e-12) {
+		t.Errorf("Dormrq and Dormr2 mismatch: %s", errStr)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dormrzer interface {
+	Dormrz(side blas.Side, trans blas.Transpose, m, n, k, l int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+}
+
+func DormrzTest(t *testing.T, impl Dormrzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, test := range []struct {
+				nq, k, cdim, lda, ldc int
+			}{
+				{0, 0, 3, 0, 0},
+				{3, 0, 3, 0, 0},
+				{1, 1, 3, 0, 0},
+				{5, 3, 4, 0, 0},
+				{5, 5, 4, 0, 0},
+				{8, 3, 1, 0, 0},
+				{10, 4, 7, 0, 0},
+				{10, 4, 7, 12, 15},
+
+				// k is large enough for the blocked code to be used.
+				{100, 50, 30, 0, 0},
+				{150, 100, 40, 0, 0},
+				{150, 100, 40, 160, 170},
+			} {
+				nq := test.nq
+				k := test.k
+				for _, l := range []int{0, (nq - k) / 2, nq - k} {
+					for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+						testDormrz(t, impl, side, trans, nq, k, l, test.cdim, test.lda, test.ldc, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDormrz(t *testing.T, impl Dormrzer, side blas.Side, trans blas.Transpose, nq, k, l, cdim, lda, ldc int, wl worklen, rnd *rand.Rand) {
+	m, n := nq, cdim
+	nw := n
+	if side == blas.Right {
+		m, n = cdim, nq
+		nw = m
+	}
+	if lda == 0 {
+		lda = max(1, nq)
+	}
+	if ldc == 0 {
+		ldc = max(1, n)
+	}
+
+	a := randomSlice(k*lda, rnd)
+	aCopy := make([]float64, len(a))
+	copy(aCopy, a)
+	// Choose tau so that the elementary reflectors are orthogonal and the
+	// elements of Z stay bounded.
+	tau := make([]float64, k)
+	for i := range tau {
+		nrm := blas64.Nrm2(l, blas64.Vector{Inc: 1, Data: a[i*lda+nq-l:]})
+		tau[i] = 2 / (1 + nrm*nrm)
+	}
+	c := blas64.General{Rows: m, Cols: n, Stride: ldc, Data: randomSlice(m*ldc, rnd)}
+	cCopy := cloneGeneral(c)
+
+	// Compute the expected result using the explicit Z.
+	z := constructZ(k, nq, l, a, lda, tau)
+	want := blas64.General{Rows: m, Cols: n, Stride: max(1, n), Data: make([]float64, m*max(1, n))}
+	if m > 0 && n > 0 {
+		switch {
+		case side == blas.Left && trans == blas.NoTrans:
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, z, cCopy, 0, want)
+		case side == blas.Left && trans == blas.Trans:
+			blas64.Gemm(blas.Trans, blas.NoTrans, 1, z, cCopy, 0, want)
+		case side == blas.Right && trans == blas.NoTrans:
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, cCopy, z, 0, want)
+		case side == blas.Right && trans == blas.Trans:
+			blas64.Gemm(blas.NoTrans, blas.Trans, 1, cCopy, z, 0, want)
+		}
+	}
+
+	work := make([]float64, 1)
+	impl.Dormrz(side, trans, m, n, k, l, a, lda, tau, c.Data, c.Stride, work, -1)
+	lwork := max(1, nw)
+	switch wl {
+	case mediumWork:
+		lwork = max(lwork, (int(work[0])+lwork)/2)
+	case optimumWork:
+		lwork = max(lwork, int(work[0]))
+	}
+	work = nanSlice(lwork)
+
+	impl.Dormrz(side, trans, m, n, k, l, a, lda, tau, c.Data, c.Stride, work, lwork)
+	errStr := fmt.Sprintf("side = %v, trans = %v, nq = %v, k = %v, l = %v, cdim = %v, lda = %v, ldc = %v, wl = %v",
+		side, trans, nq, k, l, cdim, lda, ldc, wl)
+	if !floats.Equal(a, aCopy) {
+		t.Errorf("A changed during call: %s", errStr)
+	}
+	if !equalApproxGeneral(c, want, 1e-12) {
+		t.Errorf("Unexpected result: %s", errStr)
+	}
+}
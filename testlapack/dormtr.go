@@ -0,0 +1,120 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dormtrer interface {
+	Dormtr(side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n int, a []float64, lda int, tau, c []float64, ldc int, work []float64, lwork int)
+	Dorgtrer
+}
+
+func DormtrTest(t *testing.T, impl Dormtrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []blas.Side{blas.Left, blas.Right} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+					for _, test := range []struct {
+						m, n, lda, ldc int
+					}{
+						{1, 1, 0, 0},
+						{2, 3, 0, 0},
+						{5, 4, 0, 0},
+						{10, 11, 0, 0},
+						{80, 70, 0, 0},
+						{100, 120, 0, 0},
+
+						{2, 3, 10, 8},
+						{5, 4, 10, 8},
+						{80, 70, 130, 90},
+					} {
+						testDormtr(t, impl, side, uplo, trans, test.m, test.n, test.lda, test.ldc, wl, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDormtr(t *testing.T, impl Dormtrer, side blas.Side, uplo blas.Uplo, trans blas.Transpose, m, n, lda, ldc int, wl worklen, rnd *rand.Rand) {
+	nq := m
+	if side == blas.Right {
+		nq = n
+	}
+	if lda == 0 {
+		lda = nq
+	}
+	if ldc == 0 {
+		ldc = n
+	}
+	prefix := fmt.Sprintf("Case side=%v,uplo=%v,trans=%v,m=%v,n=%v,lda=%v,ldc=%v,wl=%v:",
+		side, uplo, trans, m, n, lda, ldc, wl)
+
+	// Reduce a random symmetric matrix to tridiagonal form.
+	a := randomGeneral(nq, nq, lda, rnd)
+	d := make([]float64, nq)
+	e := make([]float64, nq-1)
+	tau := make([]float64, nq-1)
+	work := make([]float64, 1)
+	impl.Dsytrd(uplo, nq, a.Data, a.Stride, d, e, tau, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dsytrd(uplo, nq, a.Data, a.Stride, d, e, tau, work, len(work))
+
+	// Construct Q explicitly.
+	q := cloneGeneral(a)
+	impl.Dorgtr(uplo, nq, q.Data, q.Stride, tau, work, len(work))
+
+	c := randomGeneral(m, n, ldc, rnd)
+	cCopy := cloneGeneral(c)
+
+	// Compute the expected result.
+	want := zeros(m, n, n)
+	if side == blas.Left {
+		blas64.Gemm(trans, blas.NoTrans, 1, q, cCopy, 0, want)
+	} else {
+		blas64.Gemm(blas.NoTrans, trans, 1, cCopy, q, 0, want)
+	}
+
+	nw := n
+	if side == blas.Right {
+		nw = m
+	}
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, nw)
+	case mediumWork:
+		work := make([]float64, 1)
+		impl.Dormtr(side, uplo, trans, m, n, a.Data, a.Stride, tau, c.Data, c.Stride, work, -1)
+		lwork = (int(work[0]) + max(1, nw)) / 2
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dormtr(side, uplo, trans, m, n, a.Data, a.Stride, tau, c.Data, c.Stride, work, -1)
+		lwork = int(work[0])
+	}
+	lwork = max(lwork, max(1, nw))
+	work = make([]float64, lwork)
+
+	aCopy := cloneGeneral(a)
+	impl.Dormtr(side, uplo, trans, m, n, a.Data, a.Stride, tau, c.Data, c.Stride, work, lwork)
+
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v out-of-range elements of C modified", prefix)
+	}
+	if !equalApproxGeneral(a, aCopy, 0) {
+		t.Errorf("%v A modified", prefix)
+	}
+	if !equalApproxGeneral(c, want, 1e-12) {
+		t.Errorf("%v unexpected result", prefix)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dpbconer interface {
+	Dpbcon(uplo blas.Uplo, n, kd int, ab []float64, ldab int, anorm float64, work []float64, iwork []int) float64
+	Dpbtrfer
+	Dpotrier
+	Dlansyer
+}
+
+func DpbconTest(t *testing.T, impl Dpbconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+			for _, kd := range []int{0, 1, 2, 5, 20} {
+				for _, ldab := range []int{kd + 1, kd + 4} {
+					testDpbcon(t, impl, uplo, n, kd, ldab, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDpbcon(t *testing.T, impl Dpbconer, uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, ldab = %v", uplo, n, kd, ldab)
+
+	ab := randomSPDBand(uplo, n, kd, ldab, rnd)
+	// Scale A symmetrically by a random diagonal matrix D as D*A*D to vary
+	// the condition number while keeping A positive definite.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = math.Pow(10, -4*rnd.Float64())
+	}
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				ab[i*ldab+j-i] *= d[i] * d[j]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				ab[i*ldab+kd+j-i] *= d[i] * d[j]
+			}
+		}
+	}
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+	work := nanSlice(max(1, 3*n))
+	iwork := make([]int, n)
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+
+	if !impl.Dpbtrf(uplo, n, kd, ab, ldab) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	abFac := make([]float64, len(ab))
+	copy(abFac, ab)
+
+	got := impl.Dpbcon(uplo, n, kd, ab, ldab, anorm, work, iwork)
+	if n == 0 {
+		if got != 1 {
+			t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+		}
+		return
+	}
+	if !floats.Same(ab, abFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+
+	// Compute the true reciprocal condition number from the inverse of A.
+	if !impl.Dpotrf(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotrf: %s", errStr)
+		return
+	}
+	if !impl.Dpotri(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotri: %s", errStr)
+		return
+	}
+	ainvnorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+	want := 1 / anorm / ainvnorm
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimated reciprocal condition number must not be smaller than the true
+	// one.
+	if got < want*(1-1e-8) || got > 10*want || math.IsNaN(got) {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+	}
+}
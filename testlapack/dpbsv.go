@@ -0,0 +1,98 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dpbsver interface {
+	Dpbsv(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int) (ok bool)
+	Dpbtrfer
+}
+
+func DpbsvTest(t *testing.T, impl Dpbsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, kd := range []int{0, 1, 2, 4, 30} {
+				for _, nrhs := range []int{0, 1, 3} {
+					for _, ldab := range []int{kd + 1, kd + 4} {
+						for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+							for _, posdef := range []bool{true, false} {
+								testDpbsv(t, impl, uplo, n, kd, nrhs, ldab, ldb, posdef, rnd)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDpbsv(t *testing.T, impl Dpbsver, uplo blas.Uplo, n, kd, nrhs, ldab, ldb int, posdef bool, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, nrhs = %v, ldab = %v, ldb = %v, posdef = %v", uplo, n, kd, nrhs, ldab, ldb, posdef)
+
+	ab := randomSPDBand(uplo, n, kd, ldab, rnd)
+	if !posdef {
+		if n == 0 {
+			return
+		}
+		// Make one diagonal element of A negative.
+		i := rnd.Intn(n)
+		if uplo == blas.Upper {
+			ab[i*ldab] = -1
+		} else {
+			ab[i*ldab+kd] = -1
+		}
+	}
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+
+	// Compute the expected factorization.
+	abFac := make([]float64, len(ab))
+	copy(abFac, ab)
+	impl.Dpbtrf(uplo, n, kd, abFac, ldab)
+
+	// Generate a random solution X and the corresponding right-hand side B.
+	want := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := cloneGeneral(want)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dpbsv(uplo, n, kd, nrhs, ab, ldab, b.Data, b.Stride)
+
+	if !floats.Same(ab, abFac) {
+		t.Errorf("Unexpected factorization: %s", errStr)
+	}
+	if !posdef {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		if !floats.Same(b.Data, bCopy.Data) {
+			t.Errorf("b modified although the factorization failed: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("Unexpected solution: %s", errStr)
+	}
+}
@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dpbtf2er interface {
+	Dpbtf2(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+}
+
+func Dpbtf2Test(t *testing.T, impl Dpbtf2er) {
+	dpbtrfTest(t, impl.Dpbtf2)
+}
@@ -0,0 +1,139 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpbtrfer interface {
+	Dpbtrf(uplo blas.Uplo, n, kd int, ab []float64, ldab int) (ok bool)
+}
+
+func DpbtrfTest(t *testing.T, impl Dpbtrfer) {
+	dpbtrfTest(t, impl.Dpbtrf)
+}
+
+// dpbtrfTest tests a routine that computes the Cholesky factorization of a
+// symmetric positive definite band matrix by checking that the factor
+// reconstructs the original matrix.
+func dpbtrfTest(t *testing.T, dpbtrf func(uplo blas.Uplo, n, kd int, ab []float64, ldab int) bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, kd int
+		}{
+			// Large cases that exercise the blocked code.
+			{150, 70},
+			{200, 65},
+			{100, 99},
+		} {
+			for _, extra := range []int{0, 3} {
+				for _, posdef := range []bool{true, false} {
+					testDpbtrf(t, dpbtrf, uplo, test.n, test.kd, test.kd+1+extra, posdef, rnd)
+				}
+			}
+		}
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, kd := range []int{0, 1, 2, 4, 30} {
+				for _, extra := range []int{0, 3} {
+					for _, posdef := range []bool{true, false} {
+						testDpbtrf(t, dpbtrf, uplo, n, kd, kd+1+extra, posdef, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDpbtrf(t *testing.T, dpbtrf func(uplo blas.Uplo, n, kd int, ab []float64, ldab int) bool, uplo blas.Uplo, n, kd, ldab int, posdef bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, ldab = %v, posdef = %v", uplo, n, kd, ldab, posdef)
+
+	ab := randomSPDBand(uplo, n, kd, ldab, rnd)
+	if !posdef {
+		if n == 0 {
+			return
+		}
+		// Make one diagonal element of A negative.
+		i := rnd.Intn(n)
+		if uplo == blas.Upper {
+			ab[i*ldab] = -1
+		} else {
+			ab[i*ldab+kd] = -1
+		}
+	}
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+
+	ok := dpbtrf(uplo, n, kd, ab, ldab)
+	if !posdef {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+
+	// Check that the elements of ab outside of the band have not been
+	// modified.
+	for i := 0; i < n; i++ {
+		for k := 0; k < ldab; k++ {
+			var j int
+			if uplo == blas.Upper {
+				j = i + k
+			} else {
+				j = i - kd + k
+			}
+			if k <= kd && 0 <= j && j < n {
+				continue
+			}
+			if !math.IsNaN(ab[i*ldab+k]) {
+				t.Errorf("Element outside of the band modified: %s", errStr)
+				return
+			}
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+
+	// Reconstruct A as U^T * U or L * L^T.
+	f := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				f.Data[i*n+j] = ab[i*ldab+j-i]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				f.Data[i*n+j] = ab[i*ldab+kd+j-i]
+			}
+		}
+	}
+	got := zeros(n, n, n)
+	if uplo == blas.Upper {
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, f, 0, got)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, got)
+	}
+	if !equalApproxGeneral(got, a, tol*float64(n)) {
+		if uplo == blas.Upper {
+			t.Errorf("U^T*U != A: %s", errStr)
+		} else {
+			t.Errorf("L*L^T != A: %s", errStr)
+		}
+	}
+}
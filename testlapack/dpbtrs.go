@@ -0,0 +1,73 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dpbtrser interface {
+	Dpbtrfer
+	Dpbtrs(uplo blas.Uplo, n, kd, nrhs int, ab []float64, ldab int, b []float64, ldb int)
+}
+
+func DpbtrsTest(t *testing.T, impl Dpbtrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		// Large case that uses the blocked factorization.
+		testDpbtrs(t, impl, uplo, 150, 70, 3, 70+1, 3, rnd)
+		for _, n := range []int{0, 1, 2, 3, 5, 10, 25} {
+			for _, kd := range []int{0, 1, 2, 4, 30} {
+				for _, nrhs := range []int{0, 1, 3} {
+					for _, ldab := range []int{kd + 1, kd + 4} {
+						for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+							testDpbtrs(t, impl, uplo, n, kd, nrhs, ldab, ldb, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDpbtrs(t *testing.T, impl Dpbtrser, uplo blas.Uplo, n, kd, nrhs, ldab, ldb int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, nrhs = %v, ldab = %v, ldb = %v", uplo, n, kd, nrhs, ldab, ldb)
+
+	ab := randomSPDBand(uplo, n, kd, ldab, rnd)
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+	if !impl.Dpbtrf(uplo, n, kd, ab, ldab) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	abFac := make([]float64, len(ab))
+	copy(abFac, ab)
+
+	// Generate a random solution X and the corresponding right-hand side B.
+	want := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := cloneGeneral(want)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+	}
+
+	impl.Dpbtrs(uplo, n, kd, nrhs, ab, ldab, b.Data, b.Stride)
+
+	if !floats.Same(ab, abFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("Unexpected solution: %s", errStr)
+	}
+}
@@ -0,0 +1,72 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpftrfer interface {
+	Dpftrf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool)
+	Dtfttrer
+	Dtpttfer
+}
+
+func DpftrfTest(t *testing.T, impl Dpftrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+				testDpftrf(t, impl, transr, uplo, n, rnd)
+			}
+		}
+	}
+}
+
+func testDpftrf(t *testing.T, impl Dpftrfer, transr blas.Transpose, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v", transr, uplo, n)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	arf := make([]float64, len(ap))
+	impl.Dtpttf(transr, uplo, n, ap, arf)
+
+	ok := impl.Dpftrf(transr, uplo, n, arf)
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Reconstruct A from its factor and compare with the original matrix.
+	f := zeros(n, n, n)
+	impl.Dtfttr(transr, uplo, n, arf, f.Data, f.Stride)
+	got := zeros(n, n, n)
+	if uplo == blas.Upper {
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, f, 0, got)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, got)
+	}
+	if !equalApproxGeneral(got, a, tol*float64(n)) {
+		t.Errorf("Reconstructed matrix differs from A: %s", errStr)
+	}
+
+	// Check that a matrix that is not positive definite is detected.
+	i := rnd.Intn(n)
+	ap[packedIndex(uplo, n, i, i)] = -1
+	impl.Dtpttf(transr, uplo, n, ap, arf)
+	if impl.Dpftrf(transr, uplo, n, arf) {
+		t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+	}
+}
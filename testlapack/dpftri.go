@@ -0,0 +1,81 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpftrier interface {
+	Dpftri(transr blas.Transpose, uplo blas.Uplo, n int, a []float64) (ok bool)
+	Dpftrfer
+}
+
+func DpftriTest(t *testing.T, impl Dpftrier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+				testDpftri(t, impl, transr, uplo, n, rnd)
+			}
+		}
+	}
+}
+
+func testDpftri(t *testing.T, impl Dpftrier, transr blas.Transpose, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v", transr, uplo, n)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	arf := make([]float64, len(ap))
+	impl.Dtpttf(transr, uplo, n, ap, arf)
+	if !impl.Dpftrf(transr, uplo, n, arf) {
+		t.Errorf("Dpftrf failed for positive definite matrix: %s", errStr)
+		return
+	}
+	// Keep the factor for the singularity check below.
+	f := zeros(n, n, max(1, n))
+	impl.Dtfttr(transr, uplo, n, arf, f.Data, f.Stride)
+
+	ok := impl.Dpftri(transr, uplo, n, arf)
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that A * inv(A) = I.
+	ainv := zeros(n, n, n)
+	impl.Dtfttr(transr, uplo, n, arf, ainv.Data, ainv.Stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if uplo == blas.Upper {
+				ainv.Data[i*n+j] = ainv.Data[j*n+i]
+			} else {
+				ainv.Data[j*n+i] = ainv.Data[i*n+j]
+			}
+		}
+	}
+	ai := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, ainv, 0, ai)
+	if !equalApproxGeneral(ai, eye(n, n), 1e-11) {
+		t.Errorf("A * inv(A) != I: %s", errStr)
+	}
+
+	// Check that a singular factor is detected.
+	i := rnd.Intn(n)
+	f.Data[i*n+i] = 0
+	impl.Dtrttf(transr, uplo, n, f.Data, f.Stride, arf)
+	if impl.Dpftri(transr, uplo, n, arf) {
+		t.Errorf("Singular factor not detected: %s", errStr)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dpftrser interface {
+	Dpftrfer
+	Dpftrs(transr blas.Transpose, uplo blas.Uplo, n, nrhs int, a []float64, b []float64, ldb int)
+}
+
+func DpftrsTest(t *testing.T, impl Dpftrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+				for _, nrhs := range []int{0, 1, 3} {
+					for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+						testDpftrs(t, impl, transr, uplo, n, nrhs, ldb, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDpftrs(t *testing.T, impl Dpftrser, transr blas.Transpose, uplo blas.Uplo, n, nrhs, ldb int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v, nrhs = %v, ldb = %v", transr, uplo, n, nrhs, ldb)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	arf := make([]float64, len(ap))
+	impl.Dtpttf(transr, uplo, n, ap, arf)
+	if !impl.Dpftrf(transr, uplo, n, arf) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	arfFac := make([]float64, len(arf))
+	copy(arfFac, arf)
+
+	// Generate a random solution X and the corresponding right-hand side B.
+	want := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := cloneGeneral(want)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+	}
+
+	impl.Dpftrs(transr, uplo, n, nrhs, arf, b.Data, b.Stride)
+
+	if !floats.Same(arf, arfFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("Unexpected solution: %s", errStr)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpoequer interface {
+	Dpoequ(n int, a []float64, lda int, s []float64) (scond, amax float64, ok bool)
+}
+
+func DpoequTest(t *testing.T, impl Dpoequer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, lda int
+	}{
+		{0, 0},
+		{1, 0},
+		{2, 0},
+		{5, 0},
+		{5, 10},
+		{40, 0},
+		{40, 50},
+	} {
+		for _, posdiag := range []bool{true, false} {
+			testDpoequ(t, impl, test.n, test.lda, posdiag, rnd)
+		}
+	}
+}
+
+func testDpoequ(t *testing.T, impl Dpoequer, n, lda int, posdiag bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	a := badlyScaledSPD(n, lda, rnd)
+	if !posdiag && n > 0 {
+		i := rnd.Intn(n)
+		a.Data[i*lda+i] = 0
+		if rnd.Intn(2) == 0 {
+			a.Data[i*lda+i] = -1
+		}
+	}
+	s := nanSlice(n)
+
+	scond, amax, ok := impl.Dpoequ(n, a.Data, a.Stride, s)
+	errStr := fmt.Sprintf("n = %v, lda = %v, posdiag = %v", n, lda, posdiag)
+
+	if n == 0 {
+		if !ok || scond != 1 || amax != 0 {
+			t.Errorf("Unexpected result for n == 0: scond = %v, amax = %v, ok = %v", scond, amax, ok)
+		}
+		return
+	}
+
+	var wantAmax float64
+	for i := 0; i < n; i++ {
+		wantAmax = math.Max(wantAmax, a.Data[i*lda+i])
+	}
+	if amax != wantAmax {
+		t.Errorf("Unexpected amax: got %v, want %v: %s", amax, wantAmax, errStr)
+	}
+	if !posdiag {
+		if ok {
+			t.Errorf("Non-positive diagonal element not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+
+	// Check that the scaled matrix has a unit diagonal.
+	smin := math.Inf(1)
+	var smax float64
+	for i, si := range s {
+		d := si * a.Data[i*lda+i] * si
+		if math.Abs(d-1) > 1e-14 {
+			t.Errorf("Unexpected diagonal element %v of scaled matrix: got %v, want 1: %s", i, d, errStr)
+		}
+		smin = math.Min(smin, si)
+		smax = math.Max(smax, si)
+	}
+	if math.Abs(scond-smin/smax) > 1e-14*scond {
+		t.Errorf("Unexpected scond: got %v, want %v: %s", scond, smin/smax, errStr)
+	}
+}
+
+// badlyScaledSPD returns a random n×n symmetric positive definite matrix that
+// is badly scaled by a symmetric diagonal scaling. Both triangles of the matrix
+// are set.
+func badlyScaledSPD(n, stride int, rnd *rand.Rand) blas64.General {
+	a := randomSPD(n, stride, 10, rnd)
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = math.Pow(10, float64(rnd.Intn(11)-5))
+	}
+	return scaledGeneral(a, d, d)
+}
@@ -0,0 +1,96 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dporfser interface {
+	Dporfs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int)
+	Dpotrser
+}
+
+func DporfsTest(t *testing.T, impl Dporfser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{0, 0, 0, 0},
+			{0, 3, 0, 0},
+			{3, 0, 0, 0},
+			{1, 1, 0, 0},
+			{1, 3, 0, 0},
+			{5, 1, 0, 0},
+			{5, 3, 0, 0},
+			{5, 3, 10, 11},
+			{20, 4, 0, 0},
+			{50, 2, 60, 5},
+		} {
+			testDporfs(t, impl, uplo, test.n, test.nrhs, test.lda, test.ldb, rnd)
+		}
+	}
+}
+
+func testDporfs(t *testing.T, impl Dporfser, uplo blas.Uplo, n, nrhs, lda, ldb int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	aSym := randomSPD(n, lda, 1000, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	aCopy := cloneGeneral(a)
+	xWant := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	// Compute the Cholesky factorization of A and a solution that is
+	// perturbed so that the refinement has something to do.
+	af := cloneGeneral(a)
+	impl.Dpotrf(uplo, n, af.Data, af.Stride)
+	x := cloneGeneral(b)
+	impl.Dpotrs(uplo, n, nrhs, af.Data, af.Stride, x.Data, x.Stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < nrhs; j++ {
+			x.Data[i*x.Stride+j] *= 1 + 1e-8*rnd.NormFloat64()
+		}
+	}
+
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(3 * n)
+	iwork := make([]int, n)
+	impl.Dporfs(uplo, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+	errStr := fmt.Sprintf("uplo = %v, n = %v, nrhs = %v, lda = %v, ldb = %v", uplo, n, nrhs, lda, ldb)
+
+	if !floats.Same(a.Data, aCopy.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if !floats.Equal(b.Data, bCopy.Data) {
+		t.Errorf("B modified: %s", errStr)
+	}
+	if n == 0 {
+		for j := 0; j < nrhs; j++ {
+			if ferr[j] != 0 || berr[j] != 0 {
+				t.Errorf("ferr and berr not zero for n == 0: %s", errStr)
+				break
+			}
+		}
+		return
+	}
+	checkErrorBounds(t, errStr, blas.NoTrans, aSym, bCopy, x, xWant, ferr, berr)
+}
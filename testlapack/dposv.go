@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dposver interface {
+	Dposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int) (ok bool)
+	Dpotrfer
+}
+
+func DposvTest(t *testing.T, impl Dposver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{0, 0, 0, 0},
+			{0, 3, 0, 0},
+			{3, 0, 0, 0},
+			{1, 1, 0, 0},
+			{5, 1, 0, 0},
+			{5, 3, 0, 0},
+			{5, 3, 10, 11},
+			{50, 4, 0, 0},
+			{100, 10, 110, 12},
+		} {
+			for _, posdef := range []bool{true, false} {
+				testDposv(t, impl, uplo, test.n, test.nrhs, test.lda, test.ldb, posdef, rnd)
+			}
+		}
+	}
+}
+
+func testDposv(t *testing.T, impl Dposver, uplo blas.Uplo, n, nrhs, lda, ldb int, posdef bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	aSym := randomSPD(n, lda, 100, rnd)
+	if !posdef && n > 0 {
+		// Make a diagonal element of A negative.
+		i := rnd.Intn(n)
+		aSym.Data[i*lda+i] = -1
+	}
+	a := nanTriangleOf(uplo, aSym)
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dposv(uplo, n, nrhs, a.Data, a.Stride, b.Data, b.Stride)
+	errStr := fmt.Sprintf("uplo = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, posdef = %v", uplo, n, nrhs, lda, ldb, posdef)
+	if !posdef && n > 0 {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that the Cholesky factorization is the same as computed by
+	// Dpotrf.
+	want := nanTriangleOf(uplo, aSym)
+	impl.Dpotrf(uplo, n, want.Data, want.Stride)
+	if !equalApproxTriangle(uplo, a, want, 1e-14) {
+		t.Errorf("Unexpected Cholesky factorization: %s", errStr)
+	}
+	if nrhs == 0 {
+		return
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-12) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
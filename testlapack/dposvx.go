@@ -0,0 +1,155 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dposvxer interface {
+	Dposvx(fact lapack.FactorizationType, uplo blas.Uplo, n, nrhs int, a []float64, lda int, af []float64, ldaf int, equed lapack.EquilibrationType, s, b []float64, ldb int, x []float64, ldx int, ferr, berr, work []float64, iwork []int) (equedOut lapack.EquilibrationType, rcond float64, ok bool)
+	Dpoconer
+	Dpoequer
+}
+
+func DposvxTest(t *testing.T, impl Dposvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, fact := range []lapack.FactorizationType{lapack.NotFactored, lapack.EquilibrateAndFactor, lapack.Factored} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, test := range []struct {
+				n, nrhs, lda, ldb int
+			}{
+				{0, 0, 0, 0},
+				{0, 3, 0, 0},
+				{3, 0, 0, 0},
+				{1, 1, 0, 0},
+				{1, 3, 0, 0},
+				{5, 1, 0, 0},
+				{5, 3, 0, 0},
+				{5, 3, 10, 11},
+				{20, 4, 0, 0},
+				{50, 2, 60, 5},
+			} {
+				for _, posdef := range []bool{true, false} {
+					testDposvx(t, impl, fact, uplo, test.n, test.nrhs, test.lda, test.ldb, posdef, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDposvx(t *testing.T, impl Dposvxer, fact lapack.FactorizationType, uplo blas.Uplo, n, nrhs, lda, ldb int, posdef bool, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	errStr := fmt.Sprintf("fact = %c, uplo = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, posdef = %v",
+		fact, uplo, n, nrhs, lda, ldb, posdef)
+
+	aSym := badlyScaledSPD(n, lda, rnd)
+	if !posdef && n > 0 {
+		// Make a diagonal element of A negative.
+		i := rnd.Intn(n)
+		aSym.Data[i*lda+i] *= -1
+	}
+	xWant := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: make([]float64, n*ldb)}
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, xWant, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	a := nanTriangleOf(uplo, aSym)
+	af := blas64.General{Rows: n, Cols: n, Stride: lda, Data: nanSlice(n * lda)}
+	s := nanSlice(n)
+	equed := lapack.EquilibrationType('X')
+	if fact == lapack.Factored {
+		if !posdef && n > 0 {
+			// Dposvx must not be called with a factorization of
+			// a matrix that is not positive definite.
+			return
+		}
+		// Equilibrate and factor A in advance.
+		equed = lapack.NoEquilibration
+		if n > 0 {
+			_, _, ok := impl.Dpoequ(n, a.Data, a.Stride, s)
+			if ok {
+				equed = lapack.SymmetricEquilibration
+				a = nanTriangleOf(uplo, scaledGeneral(aSym, s, s))
+			}
+		}
+		copyGeneral(af, a)
+		impl.Dpotrf(uplo, n, af.Data, af.Stride)
+	}
+	aEquil := cloneGeneral(a)
+	x := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: nanSlice(n * ldb)}
+	ferr := nanSlice(nrhs)
+	berr := nanSlice(nrhs)
+	work := nanSlice(3 * n)
+	iwork := make([]int, n)
+
+	equedOut, rcond, ok := impl.Dposvx(fact, uplo, n, nrhs, a.Data, a.Stride, af.Data, af.Stride, equed,
+		s, b.Data, b.Stride, x.Data, x.Stride, ferr, berr, work, iwork)
+
+	switch fact {
+	case lapack.NotFactored:
+		if equedOut != lapack.NoEquilibration {
+			t.Errorf("Unexpected equilibration %c: %s", equedOut, errStr)
+		}
+	case lapack.Factored:
+		if equedOut != equed {
+			t.Errorf("Equilibration type changed from %c to %c: %s", equed, equedOut, errStr)
+		}
+	}
+	if fact != lapack.EquilibrateAndFactor || equedOut == lapack.NoEquilibration {
+		if !equalApproxTriangle(uplo, a, aEquil, 0) {
+			t.Errorf("A modified: %s", errStr)
+		}
+	}
+	if n == 0 {
+		if !ok || rcond != 1 {
+			t.Errorf("Unexpected result for n == 0: %s", errStr)
+		}
+		return
+	}
+
+	if !posdef {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		if rcond != 0 {
+			t.Errorf("Unexpected rcond for matrix that is not positive definite: got %v, want 0: %s", rcond, errStr)
+		}
+		return
+	}
+	const eps = 1.0 / (1 << 53)
+	if ok != (rcond >= eps) {
+		t.Errorf("Unexpected ok = %v for rcond = %v: %s", ok, rcond, errStr)
+	}
+
+	// Check that rcond is the condition estimate of the equilibrated
+	// matrix A.
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, make([]float64, n))
+	wantRcond := impl.Dpocon(uplo, n, af.Data, af.Stride, anorm, make([]float64, 3*n), make([]int, n))
+	if math.Abs(rcond-wantRcond) > 1e-14*wantRcond {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", rcond, wantRcond, errStr)
+	}
+
+	if nrhs == 0 {
+		return
+	}
+	// Check that X solves the original system of equations, that the
+	// backward errors are small and that the forward error bounds hold.
+	checkErrorBounds(t, errStr, blas.NoTrans, aSym, bCopy, x, xWant, ferr, berr)
+}
@@ -0,0 +1,79 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpotrier interface {
+	Dpotri(uplo blas.Uplo, n int, a []float64, lda int) (ok bool)
+	Dpotrfer
+}
+
+func DpotriTest(t *testing.T, impl Dpotrier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 63, 64, 65, 100, 129} {
+			for _, lda := range []int{max(1, n), n + 7} {
+				testDpotri(t, impl, uplo, n, lda, rnd)
+			}
+		}
+	}
+}
+
+func testDpotri(t *testing.T, impl Dpotrier, uplo blas.Uplo, n, lda int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v", uplo, n, lda)
+
+	aSym := randomSPD(n, lda, 1000, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	if !impl.Dpotrf(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Dpotrf failed for positive definite matrix: %s", errStr)
+		return
+	}
+	aFac := cloneGeneral(a)
+
+	ok := impl.Dpotri(uplo, n, a.Data, a.Stride)
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that the opposite strict triangle has not been modified.
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+				if !math.IsNaN(a.Data[i*lda+j]) {
+					t.Errorf("Unexpected modification of the opposite triangle: %s", errStr)
+					return
+				}
+			}
+		}
+	}
+
+	// Check that A * inv(A) = I.
+	ainv := genFromSym(blas64.Symmetric{N: n, Stride: a.Stride, Data: a.Data, Uplo: uplo})
+	ai := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, ainv, 0, ai)
+	if !equalApproxGeneral(ai, eye(n, n), 1e-11) {
+		t.Errorf("A * inv(A) != I: %s", errStr)
+	}
+
+	// Check that a singular factor is detected.
+	i := rnd.Intn(n)
+	aFac.Data[i*lda+i] = 0
+	if impl.Dpotri(uplo, n, aFac.Data, aFac.Stride) {
+		t.Errorf("Singular factor not detected: %s", errStr)
+	}
+}
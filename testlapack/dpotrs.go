@@ -0,0 +1,126 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dpotrser interface {
+	Dpotrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int)
+	Dpotrfer
+}
+
+func DpotrsTest(t *testing.T, impl Dpotrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb int
+		}{
+			{0, 0, 0, 0},
+			{0, 3, 0, 0},
+			{3, 0, 0, 0},
+			{1, 1, 0, 0},
+			{1, 3, 0, 0},
+			{5, 1, 0, 0},
+			{5, 3, 0, 0},
+			{5, 3, 10, 11},
+			{50, 4, 0, 0},
+			{100, 10, 110, 12},
+		} {
+			testDpotrs(t, impl, uplo, test.n, test.nrhs, test.lda, test.ldb, rnd)
+		}
+	}
+}
+
+func testDpotrs(t *testing.T, impl Dpotrser, uplo blas.Uplo, n, nrhs, lda, ldb int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	aSym := randomSPD(n, lda, 100, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	if !impl.Dpotrf(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Dpotrf failed for positive definite matrix")
+		return
+	}
+	aFac := cloneGeneral(a)
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	impl.Dpotrs(uplo, n, nrhs, a.Data, a.Stride, b.Data, b.Stride)
+	errStr := fmt.Sprintf("uplo = %v, n = %v, nrhs = %v, lda = %v, ldb = %v", uplo, n, nrhs, lda, ldb)
+
+	if !floats.Same(a.Data, aFac.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		if !floats.Equal(b.Data, bCopy.Data) {
+			t.Errorf("B modified for quick return: %s", errStr)
+		}
+		return
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-12) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
+
+// randomSPD returns a random n×n symmetric positive definite matrix with the
+// given stride and condition number. Both triangles of the matrix are set.
+func randomSPD(n, stride int, cond float64, rnd *rand.Rand) blas64.General {
+	a := blas64.General{Rows: n, Cols: n, Stride: stride, Data: make([]float64, n*stride)}
+	if n == 0 {
+		return a
+	}
+	d := make([]float64, n)
+	Dlatm1(d, 4, cond, false, 1, rnd)
+	Dlagsy(n, 0, d, a.Data, a.Stride, rnd, make([]float64, 2*n))
+	return a
+}
+
+// nanTriangleOf returns a copy of the square matrix A in which the strict
+// triangle opposite to the one specified by uplo is filled with NaN.
+func nanTriangleOf(uplo blas.Uplo, a blas64.General) blas64.General {
+	b := cloneGeneral(a)
+	for i := 0; i < a.Rows; i++ {
+		for j := 0; j < a.Cols; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+				b.Data[i*b.Stride+j] = math.NaN()
+			}
+		}
+	}
+	return b
+}
+
+// equalApproxTriangle returns whether the triangles specified by uplo of the
+// square matrices A and B are approximately equal within the given tolerance.
+func equalApproxTriangle(uplo blas.Uplo, a, b blas64.General, tol float64) bool {
+	n := a.Rows
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+				continue
+			}
+			diff := a.Data[i*a.Stride+j] - b.Data[i*b.Stride+j]
+			if math.IsNaN(diff) || math.Abs(diff) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
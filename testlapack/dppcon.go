@@ -0,0 +1,94 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dppconer interface {
+	Dppcon(uplo blas.Uplo, n int, ap []float64, anorm float64, work []float64, iwork []int) float64
+	Dpptrfer
+	Dpotrier
+	Dlansyer
+}
+
+func DppconTest(t *testing.T, impl Dppconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+			for i := 0; i < 3; i++ {
+				testDppcon(t, impl, uplo, n, rnd)
+			}
+		}
+	}
+}
+
+func testDppcon(t *testing.T, impl Dppconer, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v", uplo, n)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	// Scale A symmetrically by a random diagonal matrix D as D*A*D to vary
+	// the condition number while keeping A positive definite.
+	d := make([]float64, n)
+	for i := range d {
+		d[i] = math.Pow(10, -4*rnd.Float64())
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				ap[packedIndex(uplo, n, i, j)] *= d[i] * d[j]
+			}
+		}
+	}
+	a := symPackedToGeneral(uplo, n, ap)
+	work := nanSlice(max(1, 3*n))
+	iwork := make([]int, n)
+	anorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+
+	if !impl.Dpptrf(uplo, n, ap) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	apFac := make([]float64, len(ap))
+	copy(apFac, ap)
+
+	got := impl.Dppcon(uplo, n, ap, anorm, work, iwork)
+	if n == 0 {
+		if got != 1 {
+			t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+		}
+		return
+	}
+	if !floats.Same(ap, apFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+
+	// Compute the true reciprocal condition number from the inverse of A.
+	if !impl.Dpotrf(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotrf: %s", errStr)
+		return
+	}
+	if !impl.Dpotri(uplo, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotri: %s", errStr)
+		return
+	}
+	ainvnorm := impl.Dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+	want := 1 / anorm / ainvnorm
+
+	// The estimate of the norm of the inverse is a lower bound, so the
+	// estimated reciprocal condition number must not be smaller than the true
+	// one.
+	if got < want*(1-1e-8) || got > 10*want || math.IsNaN(got) {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpptrfer interface {
+	Dpptrf(uplo blas.Uplo, n int, ap []float64) (ok bool)
+}
+
+func DpptrfTest(t *testing.T, impl Dpptrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			testDpptrf(t, impl, uplo, n, rnd)
+		}
+	}
+}
+
+func testDpptrf(t *testing.T, impl Dpptrfer, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v", uplo, n)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	apCopy := make([]float64, len(ap))
+	copy(apCopy, ap)
+
+	ok := impl.Dpptrf(uplo, n, ap)
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Reconstruct A from its factor and compare with the original matrix.
+	f := triPackedToGeneral(uplo, n, ap)
+	got := zeros(n, n, n)
+	if uplo == blas.Upper {
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, f, 0, got)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, got)
+	}
+	if !equalApproxGeneral(got, a, tol*float64(n)) {
+		t.Errorf("Reconstructed matrix differs from A: %s", errStr)
+	}
+
+	// Check that a matrix that is not positive definite is detected.
+	i := rnd.Intn(n)
+	apCopy[packedIndex(uplo, n, i, i)] = -1
+	if impl.Dpptrf(uplo, n, apCopy) {
+		t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpptrier interface {
+	Dpptri(uplo blas.Uplo, n int, ap []float64) (ok bool)
+	Dpptrfer
+}
+
+func DpptriTest(t *testing.T, impl Dpptrier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			testDpptri(t, impl, uplo, n, rnd)
+		}
+	}
+}
+
+func testDpptri(t *testing.T, impl Dpptrier, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v", uplo, n)
+
+	ap := randomSPDPacked(uplo, n, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	if !impl.Dpptrf(uplo, n, ap) {
+		t.Errorf("Dpptrf failed for positive definite matrix: %s", errStr)
+		return
+	}
+	apFac := make([]float64, len(ap))
+	copy(apFac, ap)
+
+	ok := impl.Dpptri(uplo, n, ap)
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that A * inv(A) = I.
+	ainv := symPackedToGeneral(uplo, n, ap)
+	ai := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, ainv, 0, ai)
+	if !equalApproxGeneral(ai, eye(n, n), 1e-11) {
+		t.Errorf("A * inv(A) != I: %s", errStr)
+	}
+
+	// Check that a singular factor is detected.
+	i := rnd.Intn(n)
+	apFac[packedIndex(uplo, n, i, i)] = 0
+	if impl.Dpptri(uplo, n, apFac) {
+		t.Errorf("Singular factor not detected: %s", errStr)
+	}
+}
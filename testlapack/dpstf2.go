@@ -0,0 +1,24 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dpstf2er interface {
+	Dpstf2(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func Dpstf2Test(t *testing.T, impl Dpstf2er) {
+	rnd := rand.New(rand.NewSource(1))
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		dpstrfTest(t, impl.Dpstf2, rnd, uplo, ns)
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpstrfer interface {
+	Dpstrf(uplo blas.Uplo, n int, a []float64, lda int, piv []int, tol float64, work []float64) (rank int, ok bool)
+}
+
+func DpstrfTest(t *testing.T, impl Dpstrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	// Include sizes large enough for the blocked code to be used.
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25, 63, 64, 65, 100, 129, 150}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		dpstrfTest(t, impl.Dpstrf, rnd, uplo, ns)
+	}
+}
+
+func dpstrfTest(t *testing.T, dpstrf func(blas.Uplo, int, []float64, int, []int, float64, []float64) (int, bool), rnd *rand.Rand, uplo blas.Uplo, ns []int) {
+	for _, n := range ns {
+		for _, lda := range []int{max(1, n), n + 5} {
+			for _, rank := range []int{0, 1, n / 2, n - 1, n} {
+				if rank < 0 || n < rank {
+					continue
+				}
+				for _, tol := range []float64{-1, 1e-10} {
+					testDpstrf(t, dpstrf, uplo, n, lda, rank, tol, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDpstrf(t *testing.T, dpstrf func(blas.Uplo, int, []float64, int, []int, float64, []float64) (int, bool), uplo blas.Uplo, n, lda, wantRank int, tol float64, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v, rank = %v, tol = %v", uplo, n, lda, wantRank, tol)
+
+	// Generate a random symmetric positive semidefinite matrix A of rank
+	// wantRank as
+	//  A = Q * D * Q^T,
+	// where Q is a random orthogonal matrix and D is a diagonal matrix
+	// with wantRank positive entries.
+	a := blas64.General{Rows: n, Cols: n, Stride: lda, Data: make([]float64, n*lda)}
+	if n > 0 {
+		d := make([]float64, n)
+		if wantRank > 0 {
+			Dlatm1(d[:wantRank], 4, 10, false, 1, rnd)
+		}
+		Dlagsy(n, 0, d, a.Data, a.Stride, rnd, make([]float64, 2*n))
+	}
+	aSym := cloneGeneral(a)
+	a = nanTriangleOf(uplo, a)
+
+	piv := make([]int, n)
+	for i := range piv {
+		piv[i] = -1
+	}
+	work := nanSlice(2 * n)
+	rank, ok := dpstrf(uplo, n, a.Data, a.Stride, piv, tol, work)
+
+	if rank != wantRank {
+		t.Errorf("Unexpected rank: got %v, want %v: %s", rank, wantRank, errStr)
+		return
+	}
+	if ok != (rank == n) {
+		t.Errorf("Unexpected ok = %v for rank %v: %s", ok, rank, errStr)
+	}
+	if n == 0 || rank == 0 {
+		return
+	}
+
+	// Check that piv is a permutation.
+	seen := make([]bool, n)
+	for _, p := range piv {
+		if p < 0 || n <= p || seen[p] {
+			t.Errorf("piv is not a permutation: %s", errStr)
+			return
+		}
+		seen[p] = true
+	}
+
+	// Extract the leading rank rows of U or, equivalently, the transpose
+	// of the leading rank columns of L into the rank×n matrix R.
+	r := zeros(rank, n, n)
+	for i := 0; i < rank; i++ {
+		for j := i; j < n; j++ {
+			if uplo == blas.Upper {
+				r.Data[i*n+j] = a.Data[i*lda+j]
+			} else {
+				r.Data[i*n+j] = a.Data[j*lda+i]
+			}
+		}
+	}
+	// Compute R^T * R and compare it with P^T * A * P.
+	got := zeros(n, n, n)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, r, r, 0, got)
+	want := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			want.Data[i*n+j] = aSym.Data[piv[i]*lda+piv[j]]
+		}
+	}
+	if !equalApproxGeneral(got, want, 1e-13*float64(n)) {
+		t.Errorf("P^T * A * P != R^T * R: %s", errStr)
+	}
+}
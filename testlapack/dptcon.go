@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dptconer interface {
+	Dptcon(n int, d, e []float64, anorm float64, work []float64) float64
+	Dpttrfer
+	Dpotrier
+	Dlansyer
+}
+
+func DptconTest(t *testing.T, impl Dptconer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50} {
+		for cas := 0; cas < 10; cas++ {
+			testDptcon(t, impl, n, rnd)
+		}
+	}
+}
+
+func testDptcon(t *testing.T, impl Dptconer, n int, rnd *rand.Rand) {
+	const tol = 1e-10
+
+	errStr := fmt.Sprintf("n = %v", n)
+
+	d, e := randomSPDTridiag(n, rnd)
+	// Scale A symmetrically by a random diagonal matrix S as S*A*S to vary
+	// the condition number while keeping A positive definite.
+	s := make([]float64, n)
+	for i := range s {
+		s[i] = math.Pow(10, -4*rnd.Float64())
+	}
+	for i := range d {
+		d[i] *= s[i] * s[i]
+		if i < n-1 {
+			e[i] *= s[i] * s[i+1]
+		}
+	}
+	a := tridiagToGeneral(n, e, d, e)
+	work := nanSlice(max(1, n))
+	anorm := impl.Dlansy(lapack.MaxColumnSum, blas.Upper, n, a.Data, a.Stride, work)
+
+	if !impl.Dpttrf(n, d, e) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	dFac := make([]float64, len(d))
+	copy(dFac, d)
+	eFac := make([]float64, len(e))
+	copy(eFac, e)
+
+	got := impl.Dptcon(n, d, e, anorm, work)
+	if n == 0 {
+		if got != 1 {
+			t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+		}
+		return
+	}
+	if !floats.Same(d, dFac) || !floats.Same(e, eFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+
+	// Compute the true reciprocal condition number from the inverse of A.
+	if !impl.Dpotrf(blas.Upper, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotrf: %s", errStr)
+		return
+	}
+	if !impl.Dpotri(blas.Upper, n, a.Data, a.Stride) {
+		t.Errorf("Unexpected failure in Dpotri: %s", errStr)
+		return
+	}
+	ainvnorm := impl.Dlansy(lapack.MaxColumnSum, blas.Upper, n, a.Data, a.Stride, work)
+	want := 1 / anorm / ainvnorm
+
+	// The norm of the inverse is computed exactly by Dptcon.
+	if math.Abs(got-want) > tol*want || math.IsNaN(got) {
+		t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+	}
+}
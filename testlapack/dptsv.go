@@ -0,0 +1,89 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dptsver interface {
+	Dptsv(n, nrhs int, d, e []float64, b []float64, ldb int) (ok bool)
+	Dpttrfer
+}
+
+func DptsvTest(t *testing.T, impl Dptsver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, nrhs := range []int{0, 1, 3} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+				for _, posdef := range []bool{true, false} {
+					testDptsv(t, impl, n, nrhs, ldb, posdef, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDptsv(t *testing.T, impl Dptsver, n, nrhs, ldb int, posdef bool, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("n = %v, nrhs = %v, ldb = %v, posdef = %v", n, nrhs, ldb, posdef)
+
+	d, e := randomSPDTridiag(n, rnd)
+	if !posdef {
+		if n == 0 {
+			return
+		}
+		// Make one diagonal element of A negative.
+		d[rnd.Intn(n)] = -1
+	}
+	a := tridiagToGeneral(n, e, d, e)
+
+	// Compute the expected factorization.
+	dFac := make([]float64, len(d))
+	copy(dFac, d)
+	eFac := make([]float64, len(e))
+	copy(eFac, e)
+	impl.Dpttrf(n, dFac, eFac)
+
+	// Generate a random solution X and the corresponding right-hand side B.
+	want := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := cloneGeneral(want)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+	}
+	bCopy := cloneGeneral(b)
+
+	ok := impl.Dptsv(n, nrhs, d, e, b.Data, b.Stride)
+
+	if !floats.Same(d, dFac) || !floats.Same(e, eFac) {
+		t.Errorf("Unexpected factorization: %s", errStr)
+	}
+	if !posdef {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		if !floats.Same(b.Data, bCopy.Data) {
+			t.Errorf("b modified although the factorization failed: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("Unexpected solution: %s", errStr)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dpttrfer interface {
+	Dpttrf(n int, d, e []float64) (ok bool)
+}
+
+func DpttrfTest(t *testing.T, impl Dpttrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, posdef := range []bool{true, false} {
+			for cas := 0; cas < 5; cas++ {
+				testDpttrf(t, impl, n, posdef, rnd)
+			}
+		}
+	}
+}
+
+func testDpttrf(t *testing.T, impl Dpttrfer, n int, posdef bool, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("n = %v, posdef = %v", n, posdef)
+
+	d, e := randomSPDTridiag(n, rnd)
+	if !posdef {
+		if n == 0 {
+			return
+		}
+		// Make one diagonal element of A negative.
+		d[rnd.Intn(n)] = -1
+	}
+	a := tridiagToGeneral(n, e, d, e)
+
+	ok := impl.Dpttrf(n, d, e)
+	if !posdef {
+		if ok {
+			t.Errorf("Matrix that is not positive definite not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Reconstruct A as L * D * L^T.
+	l := zeros(n, n, n)
+	ld := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		l.Data[i*n+i] = 1
+		ld.Data[i*n+i] = d[i]
+		if i > 0 {
+			l.Data[i*n+i-1] = e[i-1]
+			ld.Data[i*n+i-1] = e[i-1] * d[i-1]
+		}
+	}
+	got := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.Trans, 1, ld, l, 0, got)
+	if !equalApproxGeneral(got, a, tol) {
+		t.Errorf("L*D*L^T != A: %s", errStr)
+	}
+}
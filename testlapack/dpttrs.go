@@ -0,0 +1,67 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dpttrser interface {
+	Dpttrfer
+	Dpttrs(n, nrhs int, d, e []float64, b []float64, ldb int)
+}
+
+func DpttrsTest(t *testing.T, impl Dpttrser) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+		for _, nrhs := range []int{0, 1, 3} {
+			for _, ldb := range []int{max(1, nrhs), nrhs + 2} {
+				testDpttrs(t, impl, n, nrhs, ldb, rnd)
+			}
+		}
+	}
+}
+
+func testDpttrs(t *testing.T, impl Dpttrser, n, nrhs, ldb int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("n = %v, nrhs = %v, ldb = %v", n, nrhs, ldb)
+
+	d, e := randomSPDTridiag(n, rnd)
+	a := tridiagToGeneral(n, e, d, e)
+	if !impl.Dpttrf(n, d, e) {
+		t.Errorf("Unexpected failure for positive definite matrix: %s", errStr)
+		return
+	}
+	dFac := make([]float64, len(d))
+	copy(dFac, d)
+	eFac := make([]float64, len(e))
+	copy(eFac, e)
+
+	// Generate a random solution X and the corresponding right-hand side B.
+	want := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	b := cloneGeneral(want)
+	if n > 0 && nrhs > 0 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, want, 0, b)
+	}
+
+	impl.Dpttrs(n, nrhs, d, e, b.Data, b.Stride)
+
+	if !floats.Same(d, dFac) || !floats.Same(e, eFac) {
+		t.Errorf("Factorization modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+	if !equalApproxGeneral(b, want, tol) {
+		t.Errorf("Unexpected solution: %s", errStr)
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dsbever interface {
+	Dsbev(jobz lapack.EVJob, uplo blas.Uplo, n, kd int, ab []float64, ldab int, w, z []float64, ldz int, work []float64) (ok bool)
+	Dsyever
+}
+
+func DsbevTest(t *testing.T, impl Dsbever) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, kd := range []int{0, 1, 2, 3, 5, 10, 60} {
+				for _, ldab := range []int{kd + 1, kd + 4} {
+					for _, ldz := range []int{max(1, n), n + 3} {
+						testDsbev(t, impl, uplo, n, kd, ldab, ldz, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsbev(t *testing.T, impl Dsbever, uplo blas.Uplo, n, kd, ldab, ldz int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, ldab = %v, ldz = %v", uplo, n, kd, ldab, ldz)
+
+	ab := randomSymBand(uplo, n, kd, ldab, rnd)
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+
+	// Compute the eigenvalues and eigenvectors.
+	abCopy := make([]float64, len(ab))
+	copy(abCopy, ab)
+	w := nanSlice(n)
+	z := nanGeneral(n, n, ldz)
+	work := nanSlice(max(1, 3*n-2))
+	ok := impl.Dsbev(lapack.ComputeEV, uplo, n, kd, abCopy, ldab, w, z.Data, z.Stride, work)
+	if !ok {
+		t.Errorf("Dsbev failed: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.Float64sAreSorted(w) {
+		t.Errorf("Eigenvalues not sorted in ascending order: %s", errStr)
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("Z is not orthogonal: %s", errStr)
+	}
+
+	// Check that A * Z = Z * diag(w).
+	az := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, z, 0, az)
+	zw := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			zw.Data[i*n+j] = z.Data[i*z.Stride+j] * w[j]
+		}
+	}
+	if !equalApproxGeneral(az, zw, tol*float64(n)) {
+		t.Errorf("A*Z != Z*diag(w): %s", errStr)
+	}
+
+	// Check that the eigenvalues agree with those computed without the
+	// eigenvectors.
+	copy(abCopy, ab)
+	wN := nanSlice(n)
+	ok = impl.Dsbev(lapack.None, uplo, n, kd, abCopy, ldab, wN, nil, 1, work)
+	if !ok {
+		t.Errorf("Dsbev failed for jobz == lapack.None: %s", errStr)
+		return
+	}
+	for i := range w {
+		if math.Abs(w[i]-wN[i]) > tol*float64(n) {
+			t.Errorf("Eigenvalue mismatch for jobz == lapack.None: %s", errStr)
+			break
+		}
+	}
+
+	// Check that the eigenvalues agree with those of the dense matrix.
+	wSy := make([]float64, n)
+	aSy := cloneGeneral(a)
+	impl.Dsyev(lapack.None, uplo, n, aSy.Data, aSy.Stride, wSy, work, -1)
+	lwork := int(work[0])
+	workSy := make([]float64, lwork)
+	if !impl.Dsyev(lapack.None, uplo, n, aSy.Data, aSy.Stride, wSy, workSy, lwork) {
+		t.Errorf("Dsyev failed: %s", errStr)
+		return
+	}
+	for i := range w {
+		if math.Abs(w[i]-wSy[i]) > tol*float64(n) {
+			t.Errorf("Eigenvalues differ from Dsyev: %s", errStr)
+			break
+		}
+	}
+}
@@ -0,0 +1,144 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dsbtrder interface {
+	Dsbtrd(vect lapack.EVComp, uplo blas.Uplo, n, kd int, ab []float64, ldab int, d, e, q []float64, ldq int)
+}
+
+func DsbtrdTest(t *testing.T, impl Dsbtrder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			for _, kd := range []int{0, 1, 2, 3, 5, 10, 60} {
+				for _, ldab := range []int{kd + 1, kd + 4} {
+					for _, ldq := range []int{max(1, n), n + 3} {
+						testDsbtrd(t, impl, uplo, n, kd, ldab, ldq, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsbtrd(t *testing.T, impl Dsbtrder, uplo blas.Uplo, n, kd, ldab, ldq int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v, kd = %v, ldab = %v, ldq = %v", uplo, n, kd, ldab, ldq)
+
+	ab := randomSymBand(uplo, n, kd, ldab, rnd)
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+
+	// Reduce A to tridiagonal form and form the orthogonal matrix Q.
+	abT := make([]float64, len(ab))
+	copy(abT, ab)
+	d := nanSlice(n)
+	e := nanSlice(max(0, n-1))
+	q := nanGeneral(n, n, ldq)
+	impl.Dsbtrd(lapack.TridiagEV, uplo, n, kd, abT, ldab, d, e, q.Data, q.Stride)
+
+	// Check that ab contains T on return.
+	for i := 0; i < n; i++ {
+		for k := 0; k < ldab; k++ {
+			var j int
+			if uplo == blas.Upper {
+				j = i + k
+			} else {
+				j = i - kd + k
+			}
+			v := abT[i*ldab+k]
+			switch {
+			case k > kd || j < 0 || n <= j:
+				if !math.IsNaN(v) {
+					t.Errorf("Element outside of the band modified: %s", errStr)
+					return
+				}
+			case j == i:
+				if v != d[i] {
+					t.Errorf("Diagonal of T not stored in ab: %s", errStr)
+					return
+				}
+			case j == i+1:
+				if v != e[i] {
+					t.Errorf("Superdiagonal of T not stored in ab: %s", errStr)
+					return
+				}
+			case j == i-1:
+				if v != e[j] {
+					t.Errorf("Subdiagonal of T not stored in ab: %s", errStr)
+					return
+				}
+			default:
+				if v != 0 {
+					t.Errorf("Band element outside of T not zero: %s", errStr)
+					return
+				}
+			}
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+
+	if !isOrthonormal(q) {
+		t.Errorf("Q is not orthogonal: %s", errStr)
+		return
+	}
+
+	// Check that Q^T * A * Q = T.
+	tri := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		tri.Data[i*n+i] = d[i]
+		if i < n-1 {
+			tri.Data[i*n+i+1] = e[i]
+			tri.Data[(i+1)*n+i] = e[i]
+		}
+	}
+	aq := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, q, 0, aq)
+	qaq := zeros(n, n, n)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, aq, 0, qaq)
+	if !equalApproxGeneral(qaq, tri, tol*float64(n)) {
+		t.Errorf("Q^T*A*Q != T: %s", errStr)
+	}
+
+	// Check that the same T is computed when Q is not formed.
+	abN := make([]float64, len(ab))
+	copy(abN, ab)
+	dN := nanSlice(n)
+	eN := nanSlice(max(0, n-1))
+	impl.Dsbtrd(lapack.None, uplo, n, kd, abN, ldab, dN, eN, nil, 1)
+	if !floats.Same(d, dN) || !floats.Same(e, eN) {
+		t.Errorf("T differs when Q is not computed: %s", errStr)
+	}
+
+	// Check that X*Q is computed when vect == lapack.OriginalEV.
+	x := randomOrthogonal(n, rnd)
+	xq := nanGeneral(n, n, ldq)
+	for i := 0; i < n; i++ {
+		copy(xq.Data[i*ldq:i*ldq+n], x.Data[i*x.Stride:i*x.Stride+n])
+	}
+	abV := make([]float64, len(ab))
+	copy(abV, ab)
+	impl.Dsbtrd(lapack.OriginalEV, uplo, n, kd, abV, ldab, dN, eN, xq.Data, xq.Stride)
+	want := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, x, q, 0, want)
+	if !equalApproxGeneral(xq, want, tol) {
+		t.Errorf("Unexpected X*Q: %s", errStr)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dsfrker interface {
+	Dsfrk(transr blas.Transpose, uplo blas.Uplo, trans blas.Transpose, n, k int, alpha float64, a []float64, lda int, beta float64, c []float64)
+	Dtfttrer
+}
+
+func DsfrkTest(t *testing.T, impl Dsfrker) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+					for _, k := range []int{0, 1, 3, 10} {
+						for _, alpha := range []float64{0, 1, -0.7} {
+							for _, beta := range []float64{0, 1, 0.4} {
+								testDsfrk(t, impl, transr, uplo, trans, n, k, alpha, beta, rnd)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsfrk(t *testing.T, impl Dsfrker, transr blas.Transpose, uplo blas.Uplo, trans blas.Transpose, n, k int, alpha, beta float64, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, trans = %v, n = %v, k = %v, alpha = %v, beta = %v",
+		transr, uplo, trans, n, k, alpha, beta)
+
+	rows, cols := n, k
+	if trans == blas.Trans {
+		rows, cols = k, n
+	}
+	lda := cols + 2
+	a := randomGeneral(rows, cols, lda, rnd)
+
+	c := randomTriangular(uplo, n, max(1, n), rnd)
+	arf := nanSlice(n * (n + 1) / 2)
+	impl.Dtrttf(transr, uplo, n, c.Data, c.Stride, arf)
+
+	// Compute the expected result of the symmetric rank-k update.
+	want := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			var sum float64
+			for l := 0; l < k; l++ {
+				if trans == blas.NoTrans {
+					sum += a.Data[i*lda+l] * a.Data[j*lda+l]
+				} else {
+					sum += a.Data[l*lda+i] * a.Data[l*lda+j]
+				}
+			}
+			want.Data[i*want.Stride+j] = alpha*sum + beta*c.Data[i*c.Stride+j]
+		}
+	}
+
+	impl.Dsfrk(transr, uplo, trans, n, k, alpha, a.Data, lda, beta, arf)
+
+	got := nanTriangular(uplo, n, max(1, n))
+	impl.Dtfttr(transr, uplo, n, arf, got.Data, got.Stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i > j) || (uplo == blas.Lower && i < j) {
+				continue
+			}
+			g := got.Data[i*got.Stride+j]
+			w := want.Data[i*want.Stride+j]
+			if math.IsNaN(g) || math.Abs(g-w) > tol*math.Max(1, math.Abs(w)) {
+				t.Errorf("Unexpected result at (%v,%v): got %v, want %v: %s", i, j, g, w, errStr)
+				return
+			}
+		}
+	}
+}
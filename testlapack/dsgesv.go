@@ -0,0 +1,164 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dsgesver interface {
+	Dsgesv(n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool)
+}
+
+// Matrix kinds used by the tests of the mixed precision solvers.
+const (
+	mixedWellConditioned = iota // Refinement is expected to converge.
+	mixedIllConditioned         // Refinement is expected to fail.
+	mixedOverflow               // A cannot be represented in single precision.
+	mixedSingular               // A is singular in double precision.
+)
+
+func DsgesvTest(t *testing.T, impl Dsgesver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		n, nrhs, lda, ldb, ldx int
+	}{
+		{0, 0, 0, 0, 0},
+		{0, 3, 0, 0, 0},
+		{3, 0, 0, 0, 0},
+		{1, 1, 0, 0, 0},
+		{2, 1, 0, 0, 0},
+		{5, 1, 0, 0, 0},
+		{5, 3, 0, 0, 0},
+		{5, 3, 10, 11, 12},
+		{50, 4, 0, 0, 0},
+		{100, 10, 110, 12, 13},
+	} {
+		for _, kind := range []int{mixedWellConditioned, mixedIllConditioned, mixedOverflow, mixedSingular} {
+			testDsgesv(t, impl, test.n, test.nrhs, test.lda, test.ldb, test.ldx, kind, rnd)
+		}
+	}
+}
+
+func testDsgesv(t *testing.T, impl Dsgesver, n, nrhs, lda, ldb, ldx, kind int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	if ldx == 0 {
+		ldx = max(1, nrhs)
+	}
+
+	a := blas64.General{Rows: n, Cols: n, Stride: lda, Data: randomSlice(n*lda, rnd)}
+	if n > 0 {
+		cond := 10.0
+		if kind == mixedIllConditioned {
+			cond = 1e15
+		}
+		d := make([]float64, n)
+		Dlatm1(d, 3, cond, false, 0, rnd)
+		Dlagge(n, n, max(0, n-1), max(0, n-1), d, a.Data, a.Stride, rnd, make([]float64, 2*n))
+		switch kind {
+		case mixedOverflow:
+			a.Data[rnd.Intn(n)*lda+rnd.Intn(n)] = 1e300
+		case mixedSingular:
+			// Make one column of A zero.
+			j := rnd.Intn(n)
+			for i := 0; i < n; i++ {
+				a.Data[i*lda+j] = 0
+			}
+		}
+	}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	x := blas64.General{Rows: n, Cols: nrhs, Stride: ldx, Data: nanSlice(n * ldx)}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	ipiv := make([]int, n)
+	work := nanSlice(n * nrhs)
+	swork := make([]float32, n*(n+nrhs))
+	for i := range swork {
+		swork[i] = float32(math.NaN())
+	}
+
+	iter, ok := impl.Dsgesv(n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride, x.Data, x.Stride, work, swork)
+	errStr := fmt.Sprintf("n = %v, nrhs = %v, lda = %v, ldb = %v, ldx = %v, kind = %v", n, nrhs, lda, ldb, ldx, kind)
+
+	if !floats.Equal(b.Data, bCopy.Data) {
+		t.Errorf("B changed during call: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		if iter != 0 || !ok {
+			t.Errorf("Unexpected result for quick return: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+		return
+	}
+	checkMixedIter(t, errStr, kind, n, iter, ok)
+	if !ok {
+		return
+	}
+	if iter >= 0 && !floats.Equal(a.Data, aCopy.Data) {
+		t.Errorf("A changed during successful refinement: %s", errStr)
+	}
+	checkMixedSolution(t, errStr, aCopy, bCopy, x)
+}
+
+// checkMixedIter checks the values of iter and ok returned by a mixed
+// precision solver for a matrix of the given kind.
+func checkMixedIter(t *testing.T, errStr string, kind, n, iter int, ok bool) {
+	switch kind {
+	case mixedWellConditioned:
+		if iter < 0 || !ok {
+			t.Errorf("Unexpected failure of refinement: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+	case mixedIllConditioned:
+		// Matrices of order 1 are always solved to full accuracy.
+		if (n > 1 && iter >= 0) || !ok {
+			t.Errorf("Unexpected result for ill-conditioned matrix: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+	case mixedOverflow:
+		if iter != -2 || !ok {
+			t.Errorf("Unexpected result for matrix with overflow: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+	case mixedSingular:
+		if iter != -3 || ok {
+			t.Errorf("Unexpected result for singular matrix: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+	}
+}
+
+// checkMixedSolution checks that every column x of X has a small normwise
+// backward error
+//  |b - A*x|_∞ / (|A|_∞ * |x|_∞)
+// with respect to the corresponding column b of B.
+func checkMixedSolution(t *testing.T, errStr string, a, b, x blas64.General) {
+	n := a.Rows
+	nrhs := b.Cols
+	r := cloneGeneral(b)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, a, x, 1, r)
+	var anrm float64
+	for i := 0; i < n; i++ {
+		anrm = math.Max(anrm, floats.Norm(a.Data[i*a.Stride:i*a.Stride+n], 1))
+	}
+	tol := 4 * float64(n) * dlamchE
+	for j := 0; j < nrhs; j++ {
+		var rnrm, xnrm float64
+		for i := 0; i < n; i++ {
+			rnrm = math.Max(rnrm, math.Abs(r.Data[i*r.Stride+j]))
+			xnrm = math.Max(xnrm, math.Abs(x.Data[i*x.Stride+j]))
+		}
+		if rnrm > tol*anrm*xnrm {
+			t.Errorf("Backward error too large for column %v: got %v, want <= %v: %s", j, rnrm/(anrm*xnrm), tol, errStr)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dsposver interface {
+	Dsposv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, b []float64, ldb int, x []float64, ldx int, work []float64, swork []float32) (iter int, ok bool)
+}
+
+func DsposvTest(t *testing.T, impl Dsposver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, nrhs, lda, ldb, ldx int
+		}{
+			{0, 0, 0, 0, 0},
+			{0, 3, 0, 0, 0},
+			{3, 0, 0, 0, 0},
+			{1, 1, 0, 0, 0},
+			{2, 1, 0, 0, 0},
+			{5, 1, 0, 0, 0},
+			{5, 3, 0, 0, 0},
+			{5, 3, 10, 11, 12},
+			{50, 4, 0, 0, 0},
+			{100, 10, 110, 12, 13},
+		} {
+			// The singular kind is used for matrices that are not
+			// positive definite.
+			for _, kind := range []int{mixedWellConditioned, mixedIllConditioned, mixedOverflow, mixedSingular} {
+				testDsposv(t, impl, uplo, test.n, test.nrhs, test.lda, test.ldb, test.ldx, kind, rnd)
+			}
+		}
+	}
+}
+
+func testDsposv(t *testing.T, impl Dsposver, uplo blas.Uplo, n, nrhs, lda, ldb, ldx, kind int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	if ldx == 0 {
+		ldx = max(1, nrhs)
+	}
+
+	// Generate a random symmetric positive definite matrix in aSym.
+	aSym := blas64.General{Rows: n, Cols: n, Stride: lda, Data: randomSlice(n*lda, rnd)}
+	if n > 0 {
+		cond := 10.0
+		if kind == mixedIllConditioned {
+			cond = 1e15
+		}
+		d := make([]float64, n)
+		Dlatm1(d, 3, cond, false, 0, rnd)
+		Dlagsy(n, max(0, n-1), d, aSym.Data, aSym.Stride, rnd, make([]float64, 2*n))
+		i := rnd.Intn(n)
+		switch kind {
+		case mixedOverflow:
+			aSym.Data[i*lda+i] = 1e300
+		case mixedSingular:
+			aSym.Data[i*lda+i] = -1
+		}
+	}
+	// Copy the triangle of aSym specified by uplo into a and fill the
+	// opposite triangle with NaN.
+	a := cloneGeneral(aSym)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j < i) || (uplo == blas.Lower && i < j) {
+				a.Data[i*lda+j] = math.NaN()
+			}
+		}
+	}
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	x := blas64.General{Rows: n, Cols: nrhs, Stride: ldx, Data: nanSlice(n * ldx)}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	work := nanSlice(n * nrhs)
+	swork := make([]float32, n*(n+nrhs))
+	for i := range swork {
+		swork[i] = float32(math.NaN())
+	}
+
+	iter, ok := impl.Dsposv(uplo, n, nrhs, a.Data, a.Stride, b.Data, b.Stride, x.Data, x.Stride, work, swork)
+	errStr := fmt.Sprintf("uplo = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, ldx = %v, kind = %v", uplo, n, nrhs, lda, ldb, ldx, kind)
+
+	if !floats.Equal(b.Data, bCopy.Data) {
+		t.Errorf("B changed during call: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		if iter != 0 || !ok {
+			t.Errorf("Unexpected result for quick return: iter = %v, ok = %v: %s", iter, ok, errStr)
+		}
+		return
+	}
+	checkMixedIter(t, errStr, kind, n, iter, ok)
+	if !ok {
+		return
+	}
+	if iter >= 0 && !floats.Same(a.Data, aCopy.Data) {
+		t.Errorf("A changed during successful refinement: %s", errStr)
+	}
+	checkMixedSolution(t, errStr, aSym, bCopy, x)
+}
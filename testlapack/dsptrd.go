@@ -0,0 +1,118 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dsptrder interface {
+	Dsptrd(uplo blas.Uplo, n int, ap, d, e, tau []float64)
+}
+
+func DsptrdTest(t *testing.T, impl Dsptrder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+			testDsptrd(t, impl, uplo, n, rnd)
+		}
+	}
+}
+
+func testDsptrd(t *testing.T, impl Dsptrder, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	errStr := fmt.Sprintf("uplo = %v, n = %v", uplo, n)
+
+	ap := randomSlice(n*(n+1)/2, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+
+	d := nanSlice(n)
+	e := nanSlice(max(0, n-1))
+	tau := nanSlice(max(0, n-1))
+	impl.Dsptrd(uplo, n, ap, d, e, tau)
+	if n == 0 {
+		return
+	}
+
+	// Check that the diagonal and the off-diagonal of T are stored in ap.
+	for i := 0; i < n; i++ {
+		if ap[packedIndex(uplo, n, i, i)] != d[i] {
+			t.Errorf("Diagonal of T not stored in ap: %s", errStr)
+			break
+		}
+	}
+	for i := 0; i < n-1; i++ {
+		var ei float64
+		if uplo == blas.Upper {
+			ei = ap[packedIndex(uplo, n, i, i+1)]
+		} else {
+			ei = ap[packedIndex(uplo, n, i+1, i)]
+		}
+		if ei != e[i] {
+			t.Errorf("Off-diagonal of T not stored in ap: %s", errStr)
+			break
+		}
+	}
+
+	// Check that Q^T * A * Q = T.
+	q := constructQsptrd(uplo, n, ap, tau)
+	if !isOrthonormal(q) {
+		t.Errorf("Q is not orthonormal: %s", errStr)
+	}
+	checkTridiagReduction(t, a, q, d, e, tol*float64(n), errStr)
+}
+
+// constructQsptrd returns the orthogonal matrix Q defined by the elementary
+// reflectors stored in ap and tau as returned by Dsptrd.
+func constructQsptrd(uplo blas.Uplo, n int, ap, tau []float64) blas64.General {
+	q := eye(n, n)
+	qCopy := zeros(n, n, n)
+	v := blas64.Vector{Inc: 1, Data: make([]float64, n)}
+	for i := 0; i < n-1; i++ {
+		for j := range v.Data {
+			v.Data[j] = 0
+		}
+		if uplo == blas.Upper {
+			for j := 0; j < i; j++ {
+				v.Data[j] = ap[packedIndex(uplo, n, j, i+1)]
+			}
+			v.Data[i] = 1
+		} else {
+			v.Data[i+1] = 1
+			for j := i + 2; j < n; j++ {
+				v.Data[j] = ap[packedIndex(uplo, n, j, i)]
+			}
+		}
+		h := eye(n, n)
+		blas64.Ger(-tau[i], v, v, h)
+		copyGeneral(qCopy, q)
+		if uplo == blas.Upper {
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, h, qCopy, 0, q)
+		} else {
+			blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, qCopy, h, 0, q)
+		}
+	}
+	return q
+}
+
+// checkTridiagReduction checks that Q^T * A * Q is equal to the symmetric
+// tridiagonal matrix with diagonal d and off-diagonal e.
+func checkTridiagReduction(t *testing.T, a, q blas64.General, d, e []float64, tol float64, errStr string) {
+	n := a.Rows
+	aq := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, q, 0, aq)
+	qaq := zeros(n, n, n)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, q, aq, 0, qaq)
+	tri := tridiagToGeneral(n, e, d, e)
+	if !equalApproxGeneral(qaq, tri, tol) {
+		t.Errorf("Q^T * A * Q != T: %s", errStr)
+	}
+}
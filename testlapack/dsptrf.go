@@ -0,0 +1,45 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dsptrfer interface {
+	Dsptrf(uplo blas.Uplo, n int, ap []float64, ipiv []int) (ok bool)
+}
+
+func DsptrfTest(t *testing.T, impl Dsptrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25, 50}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		// Pack the triangle of the dense matrix, factorize it and unpack the
+		// factors so that the checks for Dsytrf can be used.
+		dsptrf := func(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool {
+			ap := make([]float64, n*(n+1)/2)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+						ap[packedIndex(uplo, n, i, j)] = a[i*lda+j]
+					}
+				}
+			}
+			ok := impl.Dsptrf(uplo, n, ap, ipiv)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+						a[i*lda+j] = ap[packedIndex(uplo, n, i, j)]
+					}
+				}
+			}
+			return ok
+		}
+		dsytrfTest(t, dsptrf, false, rnd, uplo, ns)
+	}
+}
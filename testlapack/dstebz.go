@@ -0,0 +1,123 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dstebzer interface {
+	Dstebz(rng lapack.EVRange, byBlock bool, n int, vl, vu float64, il, iu int, abstol float64, d, e []float64, w []float64, iblock, isplit []int, work []float64, iwork []int) (m, nsplit int, ok bool)
+	Dsterfer
+	Dsytrder
+}
+
+func DstebzTest(t *testing.T, impl Dstebzer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 10, 25, 50, 100} {
+		for _, kind := range []string{"random", "wilkinson", "clustered", "split", "graded"} {
+			d, e := tridiagonalForEigen(impl, kind, n, rnd)
+			for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+				for _, byBlock := range []bool{false, true} {
+					for _, abstol := range []float64{0, 2 * dlamchS} {
+						testDstebz(t, impl, rng, byBlock, n, d, e, abstol, kind, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDstebz(t *testing.T, impl Dstebzer, rng lapack.EVRange, byBlock bool, n int, d, e []float64, abstol float64, kind string, rnd *rand.Rand) {
+	// Compute all eigenvalues with Dsterf for reference.
+	wAll := make([]float64, n)
+	copy(wAll, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, wAll, eCopy)
+
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy = make([]float64, len(e))
+	copy(eCopy, e)
+
+	tnrm := math.Max(1, floats.Norm(d, math.Inf(1))+2*floats.Norm(e, math.Inf(1)))
+	for _, sel := range eigenSelections(rng, wAll) {
+		prefix := fmt.Sprintf("Case rng=%c,byBlock=%v,n=%v,kind=%v,abstol=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			rng, byBlock, n, kind, abstol, sel.vl, sel.vu, sel.il, sel.iu)
+
+		w := nanSlice(n)
+		iblock := make([]int, n)
+		isplit := make([]int, n)
+		for i := range iblock {
+			iblock[i] = rnd.Int()
+			isplit[i] = rnd.Int()
+		}
+		work := nanSlice(4 * n)
+		iwork := make([]int, 3*n)
+
+		m, nsplit, ok := impl.Dstebz(rng, byBlock, n, sel.vl, sel.vu, sel.il, sel.iu, abstol, d, e, w, iblock, isplit, work, iwork)
+		if !floats.Equal(d, dCopy) || !floats.Equal(e, eCopy) {
+			t.Errorf("%v d or e modified", prefix)
+		}
+		if !ok {
+			t.Errorf("%v Dstebz failed", prefix)
+			continue
+		}
+		if m != sel.iu-sel.il+1 {
+			t.Errorf("%v unexpected number of eigenvalues; got %v, want %v", prefix, m, sel.iu-sel.il+1)
+			continue
+		}
+		if n == 0 {
+			continue
+		}
+
+		// Check the splitting points.
+		if nsplit < 1 || n < nsplit {
+			t.Errorf("%v invalid nsplit %v", prefix, nsplit)
+			continue
+		}
+		if isplit[nsplit-1] != n-1 {
+			t.Errorf("%v last block does not end at n-1", prefix)
+		}
+		for j := 1; j < nsplit; j++ {
+			if isplit[j] <= isplit[j-1] {
+				t.Errorf("%v isplit not increasing", prefix)
+				break
+			}
+		}
+		if m == 0 {
+			continue
+		}
+
+		for j := 0; j < m; j++ {
+			if iblock[j] < 0 || nsplit <= iblock[j] {
+				t.Errorf("%v invalid block index %v", prefix, iblock[j])
+				break
+			}
+		}
+		if byBlock {
+			for j := 1; j < m; j++ {
+				if iblock[j] < iblock[j-1] || (iblock[j] == iblock[j-1] && w[j] < w[j-1]) {
+					t.Errorf("%v eigenvalues not ordered by block", prefix)
+					break
+				}
+			}
+			sort.Float64s(w[:m])
+		} else if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v eigenvalues are not sorted", prefix)
+		}
+		if !floats.EqualApprox(w[:m], wAll[sel.il:sel.iu+1], 1e-12*tnrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+		}
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dstedcer interface {
+	Dstedc(compz lapack.EVComp, n int, d, e, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsterfer
+	Dorgtrer
+}
+
+func DstedcTest(t *testing.T, impl Dstedcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, compz := range []lapack.EVComp{lapack.None, lapack.OriginalEV, lapack.TridiagEV} {
+		for _, n := range []int{0, 1, 2, 3, 10, 25, 26, 50, 77, 100, 150} {
+			for _, ldz := range []int{max(1, n), n + 5} {
+				for _, kind := range []string{"random", "equal", "graded"} {
+					testDstedc(t, impl, compz, n, ldz, kind, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDstedc(t *testing.T, impl Dstedcer, compz lapack.EVComp, n, ldz int, kind string, rnd *rand.Rand) {
+	prefix := fmt.Sprintf("Case compz=%c,n=%v,ldz=%v,kind=%v:", compz, n, ldz, kind)
+
+	// Generate the tridiagonal matrix.
+	d := make([]float64, n)
+	e := make([]float64, max(0, n-1))
+	switch kind {
+	case "random":
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case "equal":
+		// Many equal diagonal elements and tiny off-diagonal elements
+		// exercise the deflation paths.
+		for i := range d {
+			d[i] = float64(i % 3)
+		}
+		for i := range e {
+			if i%7 == 6 {
+				e[i] = 0
+			} else {
+				e[i] = 1e-10 * rnd.NormFloat64()
+			}
+		}
+	case "graded":
+		for i := range d {
+			d[i] = float64(i + 1)
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	}
+	dCopy := make([]float64, len(d))
+	copy(dCopy, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+
+	// Construct the matrix whose eigenvectors are to be computed.
+	var z, truth blas64.General
+	switch compz {
+	case lapack.OriginalEV:
+		// Reduce a random symmetric matrix to tridiagonal form and use
+		// the corresponding orthogonal matrix as input.
+		a := randomGeneral(n, n, ldz, rnd)
+		truth = zeros(n, n, max(1, n))
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				v := a.Data[i*a.Stride+j]
+				truth.Data[i*truth.Stride+j] = v
+				truth.Data[j*truth.Stride+i] = v
+			}
+		}
+		tau := make([]float64, n)
+		work := make([]float64, 1)
+		impl.Dsytrd(blas.Upper, n, a.Data, a.Stride, d, e, tau, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dsytrd(blas.Upper, n, a.Data, a.Stride, d, e, tau, work, len(work))
+		impl.Dorgtr(blas.Upper, n, a.Data, a.Stride, tau, work, len(work))
+		z = a
+		copy(dCopy, d)
+		copy(eCopy, e)
+	case lapack.TridiagEV:
+		z = nanGeneral(n, n, ldz)
+		truth = zeros(n, n, max(1, n))
+		for i := 0; i < n; i++ {
+			truth.Data[i*truth.Stride+i] = d[i]
+			if i < n-1 {
+				truth.Data[(i+1)*truth.Stride+i] = e[i]
+				truth.Data[i*truth.Stride+i+1] = e[i]
+			}
+		}
+	default:
+		z = nanGeneral(n, n, ldz)
+	}
+
+	work := make([]float64, 1)
+	iwork := make([]int, 1)
+	impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, -1, iwork, -1)
+	work = nanSlice(int(work[0]))
+	iwork = make([]int, iwork[0])
+	for i := range iwork {
+		iwork[i] = rnd.Int()
+	}
+
+	ok := impl.Dstedc(compz, n, d, e, z.Data, z.Stride, work, len(work), iwork, len(iwork))
+	if !ok {
+		t.Errorf("%v Dstedc failed", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	if !sort.Float64sAreSorted(d) {
+		t.Errorf("%v eigenvalues are not sorted", prefix)
+	}
+
+	// Compare the eigenvalues with those computed by Dsterf.
+	dAns := make([]float64, len(dCopy))
+	copy(dAns, dCopy)
+	impl.Dsterf(n, dAns, eCopy)
+	if !floats.EqualApprox(d, dAns, 1e-10) {
+		t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+	}
+
+	if compz == lapack.None {
+		return
+	}
+	if !isOrthonormal(z) {
+		t.Errorf("%v eigenvectors not orthonormal", prefix)
+	}
+	if !eigenDecompCorrect(d, truth, z) {
+		t.Errorf("%v eigen decomposition mismatch", prefix)
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dsteiner interface {
+	Dstein(n int, d, e []float64, m int, w []float64, iblock, isplit []int, z []float64, ldz int, work []float64, iwork []int, ifail []int) (ok bool)
+	Dstebzer
+}
+
+func DsteinTest(t *testing.T, impl Dsteiner) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 3, 4, 10, 25, 50, 100} {
+		for _, kind := range []string{"random", "wilkinson", "clustered", "split", "graded"} {
+			d, e := tridiagonalForEigen(impl, kind, n, rnd)
+			for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeIndex} {
+				for _, ldz := range []int{0, n + 3} {
+					testDstein(t, impl, rng, n, d, e, ldz, kind, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDstein(t *testing.T, impl Dsteiner, rng lapack.EVRange, n int, d, e []float64, ldz int, kind string, rnd *rand.Rand) {
+	// Compute all eigenvalues with Dsterf for reference.
+	wAll := make([]float64, n)
+	copy(wAll, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, wAll, eCopy)
+
+	tmat := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		tmat.Data[i*tmat.Stride+i] = d[i]
+		if i < n-1 {
+			tmat.Data[(i+1)*tmat.Stride+i] = e[i]
+			tmat.Data[i*tmat.Stride+i+1] = e[i]
+		}
+	}
+	tnrm := math.Max(1, floats.Norm(d, math.Inf(1))+2*floats.Norm(e, math.Inf(1)))
+
+	for _, sel := range eigenSelections(rng, wAll) {
+		prefix := fmt.Sprintf("Case rng=%c,n=%v,kind=%v,ldz=%v,il=%v,iu=%v:", rng, n, kind, ldz, sel.il, sel.iu)
+
+		// Compute the eigenvalues grouped by block.
+		w := make([]float64, n)
+		iblock := make([]int, n)
+		isplit := make([]int, n)
+		m, _, ok := impl.Dstebz(rng, true, n, sel.vl, sel.vu, sel.il, sel.iu, 2*dlamchS, d, e, w, iblock, isplit,
+			make([]float64, 4*n), make([]int, 3*n))
+		if !ok {
+			t.Errorf("%v Dstebz failed", prefix)
+			continue
+		}
+
+		ldz := ldz
+		if ldz == 0 {
+			ldz = max(1, m)
+		}
+		z := nanGeneral(n, m, ldz)
+		work := nanSlice(5 * n)
+		iwork := make([]int, n)
+		ifail := make([]int, m)
+		for i := range ifail {
+			ifail[i] = rnd.Int()
+		}
+		dCopy := make([]float64, len(d))
+		copy(dCopy, d)
+		eCopy := make([]float64, len(e))
+		copy(eCopy, e)
+
+		ok = impl.Dstein(n, d, e, m, w, iblock, isplit, z.Data, z.Stride, work, iwork, ifail)
+		if !floats.Equal(d, dCopy) || !floats.Equal(e, eCopy) {
+			t.Errorf("%v d or e modified", prefix)
+		}
+		if !ok {
+			t.Errorf("%v Dstein failed", prefix)
+			continue
+		}
+		for _, v := range ifail {
+			if v != -1 {
+				t.Errorf("%v unexpected ifail %v", prefix, ifail)
+				break
+			}
+		}
+		if m == 0 {
+			continue
+		}
+
+		if !selectedEigenCorrect(tmat, w[:m], z, 1e-10*tnrm) {
+			t.Errorf("%v eigen decomposition mismatch", prefix)
+		}
+		for j := 0; j < m; j++ {
+			b1 := 0
+			if iblock[j] > 0 {
+				b1 = isplit[iblock[j]-1] + 1
+			}
+			bn := isplit[iblock[j]]
+			for i := 0; i < n; i++ {
+				if (i < b1 || bn < i) && z.Data[i*z.Stride+j] != 0 {
+					t.Errorf("%v eigenvector %v non-zero outside its block", prefix, j)
+					break
+				}
+			}
+		}
+	}
+}
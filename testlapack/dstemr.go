@@ -0,0 +1,293 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dstemrer interface {
+	Dstemr(jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, vl, vu float64, il, iu int, w, z []float64, ldz int, isuppz []int, tryrac bool, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsterfer
+	Dorgtrer
+}
+
+func DstemrTest(t *testing.T, impl Dstemrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+		for _, n := range []int{0, 1, 2, 3, 4, 10, 25, 26, 50, 100} {
+			for _, kind := range []string{"random", "wilkinson", "clustered", "tight", "split", "graded"} {
+				d, e := tridiagonalForEigen(impl, kind, n, rnd)
+				for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+					for _, tryrac := range []bool{false, true} {
+						for _, ldz := range []int{0, n + 3} {
+							testDstemr(t, impl, jobz, rng, n, d, e, tryrac, ldz, kind, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDstemr(t *testing.T, impl Dstemrer, jobz lapack.EVJob, rng lapack.EVRange, n int, d, e []float64, tryrac bool, ldz int, kind string, rnd *rand.Rand) {
+	wantz := jobz == lapack.ComputeEV
+
+	// Compute all eigenvalues with Dsterf for reference.
+	wAll := make([]float64, n)
+	copy(wAll, d)
+	eCopy := make([]float64, len(e))
+	copy(eCopy, e)
+	impl.Dsterf(n, wAll, eCopy)
+
+	for _, sel := range eigenSelections(rng, wAll) {
+		il, iu := sel.il, sel.iu
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		ldz := ldz
+		if ldz == 0 {
+			ldz = max(1, nzc)
+		}
+		prefix := fmt.Sprintf("Case jobz=%c,rng=%c,n=%v,kind=%v,tryrac=%v,ldz=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			jobz, rng, n, kind, tryrac, ldz, sel.vl, sel.vu, il, iu)
+
+		dWork := make([]float64, n)
+		copy(dWork, d)
+		eWork := make([]float64, n)
+		copy(eWork, e)
+		w := nanSlice(n)
+		var z blas64.General
+		var isuppz []int
+		if wantz {
+			z = nanGeneral(n, nzc, ldz)
+			isuppz = make([]int, 2*nzc)
+		}
+
+		work := make([]float64, 1)
+		iwork := make([]int, 1)
+		impl.Dstemr(jobz, rng, n, dWork, eWork, sel.vl, sel.vu, il, iu, w, z.Data, z.Stride, isuppz, tryrac, work, -1, iwork, -1)
+		work = nanSlice(int(work[0]))
+		iwork = make([]int, iwork[0])
+		for i := range iwork {
+			iwork[i] = rnd.Int()
+		}
+
+		m, ok := impl.Dstemr(jobz, rng, n, dWork, eWork, sel.vl, sel.vu, il, iu, w, z.Data, z.Stride, isuppz, tryrac, work, len(work), iwork, len(iwork))
+		if !ok {
+			t.Errorf("%v Dstemr failed", prefix)
+			continue
+		}
+		if m != sel.iu-sel.il+1 {
+			t.Errorf("%v unexpected number of eigenvalues; got %v, want %v", prefix, m, sel.iu-sel.il+1)
+			continue
+		}
+		if m == 0 {
+			continue
+		}
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v eigenvalues are not sorted", prefix)
+		}
+		tnrm := math.Max(1, floats.Norm(d, math.Inf(1))+2*floats.Norm(e, math.Inf(1)))
+		if !floats.EqualApprox(w[:m], wAll[sel.il:sel.iu+1], 1e-12*tnrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsterf", prefix)
+		}
+		if !wantz {
+			continue
+		}
+
+		// Check the computed eigenpairs against the tridiagonal matrix.
+		tmat := zeros(n, n, max(1, n))
+		for i := 0; i < n; i++ {
+			tmat.Data[i*tmat.Stride+i] = d[i]
+			if i < n-1 {
+				tmat.Data[(i+1)*tmat.Stride+i] = e[i]
+				tmat.Data[i*tmat.Stride+i+1] = e[i]
+			}
+		}
+		z.Cols = m
+		if !selectedEigenCorrect(tmat, w[:m], z, 1e-10*tnrm) {
+			t.Errorf("%v eigen decomposition mismatch", prefix)
+		}
+		for j := 0; j < m; j++ {
+			for i := 0; i < n; i++ {
+				if (i < isuppz[2*j] || isuppz[2*j+1] < i) && z.Data[i*z.Stride+j] != 0 {
+					t.Errorf("%v eigenvector %v non-zero outside its support", prefix, j)
+					break
+				}
+			}
+		}
+	}
+}
+
+// tridiagonalForEigen returns the diagonal and off-diagonal of an n×n
+// symmetric tridiagonal matrix of the given kind.
+func tridiagonalForEigen(impl Dsytrder, kind string, n int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	e = make([]float64, max(0, n-1))
+	switch kind {
+	case "random":
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			e[i] = rnd.NormFloat64()
+		}
+	case "wilkinson":
+		// Wilkinson matrices have pairs of very close eigenvalues.
+		for i := range d {
+			d[i] = math.Abs(float64(i - n/2))
+		}
+		for i := range e {
+			e[i] = 1
+		}
+	case "clustered", "tight":
+		// Reduce a symmetric matrix with clusters of close eigenvalues
+		// to tridiagonal form. The index ranges of eigenSelections split
+		// the tight clusters, so that the unwanted eigenvalues next to
+		// the ends of the range are very close to the wanted ones.
+		if n == 0 {
+			break
+		}
+		size, sep := 4, 1e-9
+		if kind == "tight" {
+			size, sep = 3, 1e-10
+		}
+		ev := make([]float64, n)
+		for i := range ev {
+			ev[i] = float64(i/size) + sep*float64(i%size)
+		}
+		a := make([]float64, n*n)
+		Dlagsy(n, n-1, ev, a, n, rnd, make([]float64, 2*n))
+		tau := make([]float64, n)
+		work := make([]float64, 1)
+		impl.Dsytrd(blas.Lower, n, a, n, d, e, tau, work, -1)
+		work = make([]float64, int(work[0]))
+		impl.Dsytrd(blas.Lower, n, a, n, d, e, tau, work, len(work))
+	case "split":
+		// Some zero off-diagonal elements split the matrix into
+		// unreduced blocks.
+		for i := range d {
+			d[i] = rnd.NormFloat64()
+		}
+		for i := range e {
+			if i%5 == 4 {
+				e[i] = 0
+			} else {
+				e[i] = rnd.NormFloat64()
+			}
+		}
+	case "graded":
+		for i := range d {
+			d[i] = math.Pow(10, -float64(i%10))
+		}
+		for i := range e {
+			e[i] = 0.1 * math.Sqrt(d[i]*d[i+1])
+		}
+	}
+	return d, e
+}
+
+// eigenSelection describes a subset of eigenvalues by the interval (vl,vu]
+// and the indices il through iu of the eigenvalues it contains.
+type eigenSelection struct {
+	vl, vu float64
+	il, iu int
+}
+
+// eigenSelections returns subsets of the eigenvalues w, sorted in ascending
+// order, to be computed by a routine that supports the range rng. Interval
+// bounds are only placed in gaps between well separated eigenvalues.
+func eigenSelections(rng lapack.EVRange, w []float64) []eigenSelection {
+	n := len(w)
+	if rng == lapack.RangeAll || n == 0 {
+		return []eigenSelection{{vl: 0, vu: 1, il: 0, iu: n - 1}}
+	}
+	if rng == lapack.RangeIndex {
+		return []eigenSelection{
+			{il: 0, iu: n - 1},
+			{il: 0, iu: min(2, n-1)},
+			{il: n / 3, iu: n / 2},
+			{il: n - 1, iu: n - 1},
+		}
+	}
+	// gapAfter returns the first index i >= k such that w[i] and w[i+1] are
+	// well separated, or n-1.
+	gapAfter := func(k int) int {
+		for i := k; i < n-1; i++ {
+			if w[i+1]-w[i] > 1e-6*math.Max(1, math.Abs(w[i])) {
+				return i
+			}
+		}
+		return n - 1
+	}
+	bound := func(i int) float64 {
+		if i == n-1 {
+			return w[n-1] + 1
+		}
+		return (w[i] + w[i+1]) / 2
+	}
+	var sels []eigenSelection
+	// Interval containing all eigenvalues.
+	sels = append(sels, eigenSelection{vl: w[0] - 1, vu: w[n-1] + 1, il: 0, iu: n - 1})
+	// Interval containing no eigenvalues.
+	sels = append(sels, eigenSelection{vl: w[n-1] + 1, vu: w[n-1] + 2, il: n, iu: n - 1})
+	// Interval containing the lower part of the spectrum.
+	iu := gapAfter(n / 3)
+	sels = append(sels, eigenSelection{vl: w[0] - 1, vu: bound(iu), il: 0, iu: iu})
+	// Interval containing a part from the middle of the spectrum.
+	il := gapAfter(n / 4)
+	if il < n-1 {
+		iu := gapAfter(max(il+1, n/2))
+		sels = append(sels, eigenSelection{vl: bound(il), vu: bound(iu), il: il + 1, iu: iu})
+	}
+	return sels
+}
+
+// selectedEigenCorrect returns whether the columns of z are orthonormal and
+// whether each column z_j is an eigenvector of the symmetric matrix a with
+// the eigenvalue w[j], that is
+//  |a * z_j - w[j] * z_j| <= tol.
+func selectedEigenCorrect(a blas64.General, w []float64, z blas64.General, tol float64) bool {
+	n := a.Rows
+	m := z.Cols
+	if m == 0 {
+		return true
+	}
+	ztz := zeros(m, m, m)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, z, z, 0, ztz)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			if math.IsNaN(ztz.Data[i*m+j]) || math.Abs(ztz.Data[i*m+j]-want) > 1e-10 {
+				return false
+			}
+		}
+	}
+	az := zeros(n, m, m)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, z, 0, az)
+	for j := 0; j < m; j++ {
+		for i := 0; i < n; i++ {
+			r := az.Data[i*m+j] - w[j]*z.Data[i*z.Stride+j]
+			if math.IsNaN(r) || math.Abs(r) > tol {
+				return false
+			}
+		}
+	}
+	return true
+}
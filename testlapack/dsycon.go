@@ -0,0 +1,87 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dsyconer interface {
+	Dsycon(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	Dsytrier
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+}
+
+func DsyconTest(t *testing.T, impl Dsyconer) {
+	dsyconTest(t, dsytrfOptimal(impl.Dsytrf), impl.Dsytri, impl.Dsycon, impl.Dlansy)
+}
+
+// dsyconTest tests a routine that estimates the condition number of a
+// symmetric indefinite matrix factorized by the routine dsytrf. The estimate
+// is compared to the condition number computed from the inverse returned by
+// the routine dsytri.
+func dsyconTest(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, dsytri func(blas.Uplo, int, []float64, int, []int, []float64) bool,
+	dsycon func(blas.Uplo, int, []float64, int, []int, float64, []float64, []int) float64, dlansy func(lapack.MatrixNorm, blas.Uplo, int, []float64, int, []float64) float64) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, kind := range []symIndefKind{symIndefRandom, symIndefKKT, symIndefZeroDiag, symIndefSingular} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50, 100} {
+				for _, lda := range []int{max(1, n), n + 3} {
+					errStr := fmt.Sprintf("uplo = %v, kind = %v, n = %v, lda = %v", uplo, kind, n, lda)
+
+					aSym, _, _ := randomSymIndef(kind, n, lda, rnd)
+					a := nanTriangleOf(uplo, aSym)
+					work := nanSlice(max(1, 2*n))
+					iwork := make([]int, n)
+					anorm := dlansy(lapack.MaxColumnSum, uplo, n, a.Data, a.Stride, work)
+
+					ipiv := make([]int, n)
+					ok := dsytrf(uplo, n, a.Data, a.Stride, ipiv)
+					aFac := cloneGeneral(a)
+
+					got := dsycon(uplo, n, a.Data, a.Stride, ipiv, anorm, work, iwork)
+					if n == 0 {
+						if got != 1 {
+							t.Errorf("Unexpected rcond for n == 0: got %v, want 1", got)
+						}
+						continue
+					}
+					if !ok {
+						if got != 0 {
+							t.Errorf("Unexpected rcond for singular D: got %v, want 0: %s", got, errStr)
+						}
+						continue
+					}
+					if !floats.Same(a.Data, aFac.Data) {
+						t.Errorf("Factorization modified: %s", errStr)
+					}
+
+					// Compute the true reciprocal condition number in the
+					// 1-norm from the inverse of A.
+					if !dsytri(uplo, n, aFac.Data, aFac.Stride, ipiv, work) {
+						t.Errorf("Unexpected singular matrix: %s", errStr)
+						continue
+					}
+					ainvnorm := dlansy(lapack.MaxColumnSum, uplo, n, aFac.Data, aFac.Stride, work)
+					want := 1 / anorm / ainvnorm
+
+					// The estimate of the norm of the inverse is a lower
+					// bound, so the estimated reciprocal condition number
+					// must not be smaller than the true one.
+					if got < want*(1-1e-8) || got > 10*want || math.IsNaN(got) {
+						t.Errorf("Unexpected rcond: got %v, want %v: %s", got, want, errStr)
+					}
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,22 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+type DsyconRooker interface {
+	DsyconRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, anorm float64, work []float64, iwork []int) float64
+	DsytriRooker
+	Dlansy(norm lapack.MatrixNorm, uplo blas.Uplo, n int, a []float64, lda int, work []float64) float64
+}
+
+func DsyconRookTest(t *testing.T, impl DsyconRooker) {
+	dsyconTest(t, dsytrfOptimal(impl.DsytrfRook), impl.DsytriRook, impl.DsyconRook, impl.Dlansy)
+}
@@ -0,0 +1,158 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dsyevrer interface {
+	Dsyevr(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, isuppz []int, work []float64, lwork int, iwork []int, liwork int) (m int, ok bool)
+	Dsyever
+}
+
+func DsyevrTest(t *testing.T, impl Dsyevrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+		for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+			for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 26, 33, 50, 100} {
+					for _, kind := range []string{"random", "clustered", "glued"} {
+						for _, lda := range []int{0, n + 5} {
+							testDsyevr(t, impl, jobz, rng, uplo, n, lda, kind, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsyevr(t *testing.T, impl Dsyevrer, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n, lda int, kind string, rnd *rand.Rand) {
+	wantz := jobz == lapack.ComputeEV
+	if lda == 0 {
+		lda = max(1, n)
+	}
+
+	// Generate a symmetric matrix.
+	sym := zeros(n, n, max(1, n))
+	switch kind {
+	case "random":
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				v := rnd.NormFloat64()
+				sym.Data[i*sym.Stride+j] = v
+				sym.Data[j*sym.Stride+i] = v
+			}
+		}
+	case "clustered":
+		if n == 0 {
+			break
+		}
+		// Eigenvalues in groups of three that are equal up to tiny
+		// perturbations.
+		ev := make([]float64, n)
+		for i := range ev {
+			ev[i] = float64(i/3) + 1e-10*float64(i%3)
+		}
+		Dlagsy(n, n-1, ev, sym.Data, sym.Stride, rnd, make([]float64, 2*n))
+	case "glued":
+		// Wilkinson matrices of order 11 glued together by tiny
+		// off-diagonal elements. Dstemr fails for some of these, which
+		// exercises the fallback to Dstebz and Dstein.
+		for i := 0; i < n; i++ {
+			sym.Data[i*sym.Stride+i] = math.Abs(float64(i%11 - 5))
+			if i < n-1 {
+				v := 1.0
+				if i%11 == 10 {
+					v = 1e-14
+				}
+				sym.Data[i*sym.Stride+i+1] = v
+				sym.Data[(i+1)*sym.Stride+i] = v
+			}
+		}
+	}
+
+	// Compute all eigenvalues with Dsyev for reference.
+	wAll := make([]float64, n)
+	aAll := cloneGeneral(sym)
+	work := make([]float64, 1)
+	impl.Dsyev(lapack.None, uplo, n, aAll.Data, aAll.Stride, wAll, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dsyev(lapack.None, uplo, n, aAll.Data, aAll.Stride, wAll, work, len(work))
+
+	anrm := math.Max(1, floats.Norm(sym.Data, math.Inf(1)))
+	for _, sel := range eigenSelections(rng, wAll) {
+		il, iu := sel.il, sel.iu
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		prefix := fmt.Sprintf("Case jobz=%c,rng=%c,uplo=%c,n=%v,lda=%v,kind=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			jobz, rng, uplo, n, lda, kind, sel.vl, sel.vu, il, iu)
+
+		// Store the matrix in the triangle specified by uplo and fill
+		// the other triangle with NaN.
+		a := nanGeneral(n, n, lda)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+					a.Data[i*a.Stride+j] = sym.Data[i*sym.Stride+j]
+				}
+			}
+		}
+		w := nanSlice(n)
+		var z blas64.General
+		var isuppz []int
+		if wantz {
+			z = nanGeneral(n, nzc, max(1, nzc))
+			isuppz = make([]int, 2*nzc)
+		}
+
+		work := make([]float64, 1)
+		iwork := make([]int, 1)
+		impl.Dsyevr(jobz, rng, uplo, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, 0, w, z.Data, z.Stride, isuppz, work, -1, iwork, -1)
+		work = nanSlice(int(work[0]))
+		iwork = make([]int, iwork[0])
+		for i := range iwork {
+			iwork[i] = rnd.Int()
+		}
+
+		m, ok := impl.Dsyevr(jobz, rng, uplo, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, 0, w, z.Data, z.Stride, isuppz, work, len(work), iwork, len(iwork))
+		if !ok {
+			t.Errorf("%v Dsyevr failed", prefix)
+			continue
+		}
+		if m != sel.iu-sel.il+1 {
+			t.Errorf("%v unexpected number of eigenvalues; got %v, want %v", prefix, m, sel.iu-sel.il+1)
+			continue
+		}
+		if m == 0 {
+			continue
+		}
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v eigenvalues are not sorted", prefix)
+		}
+		if !floats.EqualApprox(w[:m], wAll[sel.il:sel.iu+1], 1e-12*anrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsyev", prefix)
+		}
+		if !wantz {
+			continue
+		}
+		z.Cols = m
+		if !selectedEigenCorrect(sym, w[:m], z, 1e-10*anrm) {
+			t.Errorf("%v eigen decomposition mismatch", prefix)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+	"github.com/gonum/lapack"
+)
+
+type Dsyevxer interface {
+	Dsyevx(jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool)
+	Dsyever
+}
+
+func DsyevxTest(t *testing.T, impl Dsyevxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+		for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+			for _, uplo := range []blas.Uplo{blas.Lower, blas.Upper} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 26, 50, 100} {
+					for _, kind := range []string{"random", "clustered"} {
+						for _, lda := range []int{0, n + 5} {
+							for _, abstol := range []float64{0, 2 * dlamchS} {
+								testDsyevx(t, impl, jobz, rng, uplo, n, lda, kind, abstol, rnd)
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsyevx(t *testing.T, impl Dsyevxer, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n, lda int, kind string, abstol float64, rnd *rand.Rand) {
+	wantz := jobz == lapack.ComputeEV
+	if lda == 0 {
+		lda = max(1, n)
+	}
+
+	// Generate a symmetric matrix.
+	sym := zeros(n, n, max(1, n))
+	switch kind {
+	case "random":
+		for i := 0; i < n; i++ {
+			for j := i; j < n; j++ {
+				v := rnd.NormFloat64()
+				sym.Data[i*sym.Stride+j] = v
+				sym.Data[j*sym.Stride+i] = v
+			}
+		}
+	case "clustered":
+		if n == 0 {
+			break
+		}
+		// Eigenvalues in groups of three that are equal up to tiny
+		// perturbations.
+		ev := make([]float64, n)
+		for i := range ev {
+			ev[i] = float64(i/3) + 1e-10*float64(i%3)
+		}
+		Dlagsy(n, n-1, ev, sym.Data, sym.Stride, rnd, make([]float64, 2*n))
+	}
+
+	// Compute all eigenvalues with Dsyev for reference.
+	wAll := make([]float64, n)
+	aAll := cloneGeneral(sym)
+	work := make([]float64, 1)
+	impl.Dsyev(lapack.None, uplo, n, aAll.Data, aAll.Stride, wAll, work, -1)
+	work = make([]float64, int(work[0]))
+	impl.Dsyev(lapack.None, uplo, n, aAll.Data, aAll.Stride, wAll, work, len(work))
+
+	anrm := math.Max(1, floats.Norm(sym.Data, math.Inf(1)))
+	for _, sel := range eigenSelections(rng, wAll) {
+		il, iu := sel.il, sel.iu
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		prefix := fmt.Sprintf("Case jobz=%c,rng=%c,uplo=%c,n=%v,lda=%v,kind=%v,abstol=%v,vl=%v,vu=%v,il=%v,iu=%v:",
+			jobz, rng, uplo, n, lda, kind, abstol, sel.vl, sel.vu, il, iu)
+
+		// Store the matrix in the triangle specified by uplo and fill
+		// the other triangle with NaN.
+		a := nanGeneral(n, n, lda)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+					a.Data[i*a.Stride+j] = sym.Data[i*sym.Stride+j]
+				}
+			}
+		}
+		w := nanSlice(n)
+		var z blas64.General
+		var ifail []int
+		if wantz {
+			z = nanGeneral(n, nzc, max(1, nzc))
+			ifail = make([]int, n)
+			for i := range ifail {
+				ifail[i] = rnd.Int()
+			}
+		}
+
+		work := make([]float64, 1)
+		impl.Dsyevx(jobz, rng, uplo, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, abstol, w, z.Data, z.Stride, work, -1, nil, ifail)
+		work = nanSlice(int(work[0]))
+		iwork := make([]int, 5*n)
+		for i := range iwork {
+			iwork[i] = rnd.Int()
+		}
+
+		m, ok := impl.Dsyevx(jobz, rng, uplo, n, a.Data, a.Stride, sel.vl, sel.vu, il, iu, abstol, w, z.Data, z.Stride, work, len(work), iwork, ifail)
+		if !ok {
+			t.Errorf("%v Dsyevx failed", prefix)
+			continue
+		}
+		if m != sel.iu-sel.il+1 {
+			t.Errorf("%v unexpected number of eigenvalues; got %v, want %v", prefix, m, sel.iu-sel.il+1)
+			continue
+		}
+		if m == 0 {
+			continue
+		}
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v eigenvalues are not sorted", prefix)
+		}
+		if !floats.EqualApprox(w[:m], wAll[sel.il:sel.iu+1], 1e-12*anrm) {
+			t.Errorf("%v eigenvalue mismatch with Dsyev", prefix)
+		}
+		if !wantz {
+			continue
+		}
+		for _, v := range ifail[:m] {
+			if v != -1 {
+				t.Errorf("%v unexpected ifail %v", prefix, ifail[:m])
+				break
+			}
+		}
+		z.Cols = m
+		if !selectedEigenCorrect(sym, w[:m], z, 1e-10*anrm) {
+			t.Errorf("%v eigen decomposition mismatch", prefix)
+		}
+	}
+}
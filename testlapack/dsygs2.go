@@ -0,0 +1,32 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dsygs2er interface {
+	Dsygs2(itype int, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int)
+	Dpotrfer
+}
+
+func Dsygs2Test(t *testing.T, impl Dsygs2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []int{1, 2, 3} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 20} {
+				for _, lda := range []int{max(1, n), n + 11} {
+					for _, ldb := range []int{max(1, n), n + 3} {
+						testDsygst(t, "Dsygs2", impl.Dsygs2, impl, itype, uplo, n, lda, ldb, rnd)
+					}
+				}
+			}
+		}
+	}
+}
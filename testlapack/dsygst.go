@@ -0,0 +1,122 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dsygster interface {
+	Dsygst(itype int, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int)
+	Dpotrfer
+}
+
+func DsygstTest(t *testing.T, impl Dsygster) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []int{1, 2, 3} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 40, 64, 65, 100, 150} {
+				for _, lda := range []int{max(1, n), n + 11} {
+					for _, ldb := range []int{max(1, n), n + 3} {
+						testDsygst(t, "Dsygst", impl.Dsygst, impl, itype, uplo, n, lda, ldb, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testDsygst checks a reduction of a symmetric-definite generalized
+// eigenproblem to standard form computed by dsygst which must be either
+// Dsygst or Dsygs2.
+func testDsygst(t *testing.T, name string, dsygst func(int, blas.Uplo, int, []float64, int, []float64, int), impl Dpotrfer, itype int, uplo blas.Uplo, n, lda, ldb int, rnd *rand.Rand) {
+	const tol = 1e-14
+
+	prefix := fmt.Sprintf("%v: itype=%v, uplo=%c, n=%v, lda=%v, ldb=%v", name, itype, uplo, n, lda, ldb)
+
+	aSym, bSym, _ := randomSymmetricDefinitePair(n, rnd)
+
+	// Compute the Cholesky factorization of B and store it in the triangle
+	// specified by uplo. The other triangle of both A and B is filled with
+	// NaN.
+	a := symmetricTriangle(uplo, aSym, lda)
+	b := symmetricTriangle(uplo, bSym, ldb)
+	if !impl.Dpotrf(uplo, n, b.Data, b.Stride) {
+		t.Fatalf("%v: B is not positive definite", prefix)
+	}
+	bCopy := cloneGeneral(b)
+
+	dsygst(itype, uplo, n, a.Data, a.Stride, b.Data, b.Stride)
+
+	if n == 0 {
+		return
+	}
+	for i, v := range b.Data {
+		if v != bCopy.Data[i] && !(math.IsNaN(v) && math.IsNaN(bCopy.Data[i])) {
+			t.Errorf("%v: unexpected modification of B", prefix)
+			break
+		}
+	}
+
+	// Extract the transformed matrix C from the triangle of a and the
+	// factor F such that B = F*F^T, that is, F = U^T or F = L.
+	c := zeros(n, n, n)
+	f := zeros(n, n, n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			switch {
+			case uplo == blas.Upper && j >= i:
+				if math.IsNaN(a.Data[i*a.Stride+j]) {
+					t.Fatalf("%v: unexpected NaN in the upper triangle of A", prefix)
+				}
+				c.Data[i*c.Stride+j] = a.Data[i*a.Stride+j]
+				c.Data[j*c.Stride+i] = a.Data[i*a.Stride+j]
+				f.Data[j*f.Stride+i] = b.Data[i*b.Stride+j]
+			case uplo == blas.Lower && j <= i:
+				if math.IsNaN(a.Data[i*a.Stride+j]) {
+					t.Fatalf("%v: unexpected NaN in the lower triangle of A", prefix)
+				}
+				c.Data[i*c.Stride+j] = a.Data[i*a.Stride+j]
+				c.Data[j*c.Stride+i] = a.Data[i*a.Stride+j]
+				f.Data[i*f.Stride+j] = b.Data[i*b.Stride+j]
+			default:
+				if !math.IsNaN(a.Data[i*a.Stride+j]) {
+					t.Errorf("%v: out-of-triangle write to A at [%v,%v]", prefix, i, j)
+				}
+			}
+		}
+	}
+
+	// If itype == 1, check that F*C*F^T == A, otherwise check that
+	// F^T*A*F == C.
+	got := zeros(n, n, n)
+	want := aSym
+	tmp := zeros(n, n, n)
+	if itype == 1 {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, c, f, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, f, tmp, 0, got)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, f, 0, tmp)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, tmp, 0, got)
+		want = c
+	}
+	var resid, anorm, bnorm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			resid = math.Max(resid, math.Abs(got.Data[i*got.Stride+j]-want.Data[i*want.Stride+j]))
+			anorm = math.Max(anorm, math.Abs(aSym.Data[i*aSym.Stride+j]))
+			bnorm = math.Max(bnorm, math.Abs(bSym.Data[i*bSym.Stride+j]))
+		}
+	}
+	if resid > tol*float64(n)*anorm*bnorm {
+		t.Errorf("%v: unexpected result, resid=%v", prefix, resid)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dsygver interface {
+	Dsygv(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int) (ok bool)
+}
+
+func DsygvTest(t *testing.T, impl Dsygver) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []int{1, 2, 3} {
+		for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 40, 100} {
+					for _, lda := range []int{max(1, n), n + 11} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							testDsygv(t, impl, itype, jobz, uplo, n, lda, wl, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsygv(t *testing.T, impl Dsygver, itype int, jobz lapack.EVJob, uplo blas.Uplo, n, lda int, wl worklen, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	wantz := jobz == lapack.ComputeEV
+	prefix := fmt.Sprintf("itype=%v, jobz=%c, uplo=%c, n=%v, lda=%v, work=%v", itype, jobz, uplo, n, lda, wl)
+
+	aSym, bSym, bInv := randomSymmetricDefinitePair(n, rnd)
+	a := symmetricTriangle(uplo, aSym, lda)
+	b := symmetricTriangle(uplo, bSym, lda+3)
+	w := nanSlice(n)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = max(1, 3*n-1)
+	case optimumWork:
+		work := make([]float64, 1)
+		impl.Dsygv(itype, jobz, uplo, n, nil, lda, nil, lda+3, nil, work, -1)
+		lwork = int(work[0])
+	}
+	work := nanSlice(lwork)
+
+	ok := impl.Dsygv(itype, jobz, uplo, n, a.Data, a.Stride, b.Data, b.Stride, w, work, len(work))
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.Float64sAreSorted(w) {
+		t.Errorf("%v: eigenvalues are not sorted", prefix)
+	}
+	if !wantz {
+		// Check that the eigenvalues are the same as those computed
+		// together with the eigenvectors.
+		a = symmetricTriangle(uplo, aSym, lda)
+		b = symmetricTriangle(uplo, bSym, lda)
+		wWant := make([]float64, n)
+		impl.Dsygv(itype, lapack.ComputeEV, uplo, n, a.Data, a.Stride, b.Data, b.Stride, wWant, work, len(work))
+		for i := range w {
+			if math.Abs(w[i]-wWant[i]) > tol*math.Max(1, math.Abs(wWant[i])) {
+				t.Errorf("%v: eigenvalue %v differs when computing eigenvalues only", prefix, i)
+			}
+		}
+		return
+	}
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	resid, orth := symmetricDefiniteEVResidual(itype, aSym, bSym, bInv, w, a)
+	if resid > tol*float64(n) {
+		t.Errorf("%v: unexpected eigenvectors, resid=%v", prefix, resid)
+	}
+	if orth > tol*float64(n) {
+		t.Errorf("%v: eigenvectors not normalized, |Z^T*B*Z-I|=%v", prefix, orth)
+	}
+}
+
+// randomSymmetricDefinitePair returns a random n×n symmetric matrix A and a
+// random n×n symmetric positive definite matrix B together with its inverse.
+func randomSymmetricDefinitePair(n int, rnd *rand.Rand) (a, b, bInv blas64.General) {
+	a = zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := i; j < n; j++ {
+			v := rnd.NormFloat64()
+			a.Data[i*a.Stride+j] = v
+			a.Data[j*a.Stride+i] = v
+		}
+	}
+	// Construct B = Q*D*Q^T and inv(B) = Q*inv(D)*Q^T where Q is a random
+	// orthogonal matrix and D is a diagonal matrix with positive elements.
+	q := randomOrthogonal(n, rnd)
+	qd := cloneGeneral(q)
+	qdInv := cloneGeneral(q)
+	for j := 0; j < n; j++ {
+		d := 0.5 + 2*rnd.Float64()
+		for i := 0; i < n; i++ {
+			qd.Data[i*qd.Stride+j] *= d
+			qdInv.Data[i*qdInv.Stride+j] /= d
+		}
+	}
+	b = zeros(n, n, max(1, n))
+	bInv = zeros(n, n, max(1, n))
+	if n > 0 {
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, qd, q, 0, b)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, qdInv, q, 0, bInv)
+	}
+	// Make B and inv(B) exactly symmetric.
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b.Data[i*b.Stride+j] = b.Data[j*b.Stride+i]
+			bInv.Data[i*bInv.Stride+j] = bInv.Data[j*bInv.Stride+i]
+		}
+	}
+	return a, b, bInv
+}
+
+// symmetricTriangle returns a copy of the triangle of the symmetric matrix a
+// specified by uplo stored with the given stride. The other triangle is
+// filled with NaN.
+func symmetricTriangle(uplo blas.Uplo, a blas64.General, stride int) blas64.General {
+	n := a.Rows
+	t := nanGeneral(n, n, stride)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && j >= i) || (uplo == blas.Lower && j <= i) {
+				t.Data[i*t.Stride+j] = a.Data[i*a.Stride+j]
+			}
+		}
+	}
+	return t
+}
+
+// symmetricDefiniteEVResidual returns the residual of the eigenvalues w and
+// the eigenvectors stored in the columns of z of the symmetric-definite
+// generalized eigenproblem of type itype given by A and B,
+//  max |A*Z - B*Z*W|   if itype == 1,
+//  max |A*B*Z - Z*W|   if itype == 2,
+//  max |B*A*Z - Z*W|   if itype == 3,
+// relative to the norms of A, B and Z, where W = diag(w). It also returns
+//  max |Z^T*B*Z - I|      if itype == 1 or 2,
+//  max |Z^T*inv(B)*Z - I| if itype == 3.
+func symmetricDefiniteEVResidual(itype int, a, b, bInv blas64.General, w []float64, z blas64.General) (resid, orth float64) {
+	n := a.Rows
+	m := z.Cols
+	if m == 0 {
+		return 0, 0
+	}
+	zw := zeros(n, m, m)
+	for i := 0; i < n; i++ {
+		for j := 0; j < m; j++ {
+			zw.Data[i*zw.Stride+j] = z.Data[i*z.Stride+j] * w[j]
+		}
+	}
+	lhs := zeros(n, m, m)
+	rhs := zeros(n, m, m)
+	tmp := zeros(n, m, m)
+	switch itype {
+	case 1:
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, z, 0, lhs)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, zw, 0, rhs)
+	case 2:
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, z, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, tmp, 0, lhs)
+		copyGeneral(rhs, zw)
+	case 3:
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, z, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, tmp, 0, lhs)
+		copyGeneral(rhs, zw)
+	}
+	var anorm, bnorm, znorm float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			anorm = math.Max(anorm, math.Abs(a.Data[i*a.Stride+j]))
+			bnorm = math.Max(bnorm, math.Abs(b.Data[i*b.Stride+j]))
+		}
+		for j := 0; j < m; j++ {
+			resid = math.Max(resid, math.Abs(lhs.Data[i*lhs.Stride+j]-rhs.Data[i*rhs.Stride+j]))
+			znorm = math.Max(znorm, math.Abs(z.Data[i*z.Stride+j]))
+		}
+	}
+	resid /= math.Max(1, anorm) * math.Max(1, bnorm) * math.Max(1, znorm)
+	if math.IsNaN(resid) {
+		resid = math.Inf(1)
+	}
+
+	bz := zeros(n, m, m)
+	if itype == 3 {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, bInv, z, 0, bz)
+	} else {
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, b, z, 0, bz)
+	}
+	ztbz := zeros(m, m, m)
+	blas64.Gemm(blas.Trans, blas.NoTrans, 1, z, bz, 0, ztbz)
+	for i := 0; i < m; i++ {
+		for j := 0; j < m; j++ {
+			want := 0.0
+			if i == j {
+				want = 1
+			}
+			orth = math.Max(orth, math.Abs(ztbz.Data[i*ztbz.Stride+j]-want))
+		}
+	}
+	if math.IsNaN(orth) {
+		orth = math.Inf(1)
+	}
+	return resid, orth
+}
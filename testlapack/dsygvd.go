@@ -0,0 +1,111 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/lapack"
+)
+
+type Dsygvder interface {
+	Dsygvd(itype int, jobz lapack.EVJob, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, w, work []float64, lwork int, iwork []int, liwork int) (ok bool)
+	Dsygver
+}
+
+func DsygvdTest(t *testing.T, impl Dsygvder) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []int{1, 2, 3} {
+		for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+			for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 40, 100} {
+					for _, lda := range []int{max(1, n), n + 11} {
+						for _, wl := range []worklen{minimumWork, optimumWork} {
+							testDsygvd(t, impl, itype, jobz, uplo, n, lda, wl, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsygvd(t *testing.T, impl Dsygvder, itype int, jobz lapack.EVJob, uplo blas.Uplo, n, lda int, wl worklen, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	wantz := jobz == lapack.ComputeEV
+	prefix := fmt.Sprintf("itype=%v, jobz=%c, uplo=%c, n=%v, lda=%v, work=%v", itype, jobz, uplo, n, lda, wl)
+
+	aSym, bSym, bInv := randomSymmetricDefinitePair(n, rnd)
+	a := symmetricTriangle(uplo, aSym, lda)
+	b := symmetricTriangle(uplo, bSym, lda+3)
+	w := nanSlice(n)
+
+	var lwork, liwork int
+	switch wl {
+	case minimumWork:
+		switch {
+		case n <= 1:
+			lwork = 1
+			liwork = 1
+		case wantz:
+			lwork = 1 + 6*n + 2*n*n
+			liwork = 3 + 5*n
+		default:
+			lwork = 2*n + 1
+			liwork = 1
+		}
+	case optimumWork:
+		work := make([]float64, 1)
+		iwork := make([]int, 1)
+		impl.Dsygvd(itype, jobz, uplo, n, nil, lda, nil, lda+3, nil, work, -1, iwork, -1)
+		lwork = int(work[0])
+		liwork = iwork[0]
+	}
+	work := nanSlice(lwork)
+	iwork := make([]int, liwork)
+
+	ok := impl.Dsygvd(itype, jobz, uplo, n, a.Data, a.Stride, b.Data, b.Stride, w, work, len(work), iwork, len(iwork))
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !sort.Float64sAreSorted(w) {
+		t.Errorf("%v: eigenvalues are not sorted", prefix)
+	}
+	if !wantz {
+		// Check that the eigenvalues are the same as those computed by
+		// Dsygv.
+		a = symmetricTriangle(uplo, aSym, lda)
+		b = symmetricTriangle(uplo, bSym, lda)
+		wWant := make([]float64, n)
+		work := make([]float64, max(1, 3*n-1))
+		impl.Dsygv(itype, lapack.None, uplo, n, a.Data, a.Stride, b.Data, b.Stride, wWant, work, len(work))
+		for i := range w {
+			if math.Abs(w[i]-wWant[i]) > tol*math.Max(1, math.Abs(wWant[i])) {
+				t.Errorf("%v: eigenvalue %v differs from Dsygv", prefix, i)
+			}
+		}
+		return
+	}
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	resid, orth := symmetricDefiniteEVResidual(itype, aSym, bSym, bInv, w, a)
+	if resid > tol*float64(n) {
+		t.Errorf("%v: unexpected eigenvectors, resid=%v", prefix, resid)
+	}
+	if orth > tol*float64(n) {
+		t.Errorf("%v: eigenvectors not normalized, |Z^T*B*Z-I|=%v", prefix, orth)
+	}
+}
@@ -0,0 +1,121 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dsygvxer interface {
+	Dsygvx(itype int, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n int, a []float64, lda int, b []float64, ldb int, vl, vu float64, il, iu int, abstol float64, w, z []float64, ldz int, work []float64, lwork int, iwork, ifail []int) (m int, ok bool)
+	Dsygver
+}
+
+func DsygvxTest(t *testing.T, impl Dsygvxer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, itype := range []int{1, 2, 3} {
+		for _, jobz := range []lapack.EVJob{lapack.None, lapack.ComputeEV} {
+			for _, rng := range []lapack.EVRange{lapack.RangeAll, lapack.RangeInterval, lapack.RangeIndex} {
+				for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+					for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 40, 100} {
+						for _, lda := range []int{max(1, n), n + 11} {
+							testDsygvx(t, impl, itype, jobz, rng, uplo, n, lda, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDsygvx(t *testing.T, impl Dsygvxer, itype int, jobz lapack.EVJob, rng lapack.EVRange, uplo blas.Uplo, n, lda int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	wantz := jobz == lapack.ComputeEV
+	aSym, bSym, bInv := randomSymmetricDefinitePair(n, rnd)
+
+	// Compute all eigenvalues with Dsygv for reference.
+	wAll := make([]float64, n)
+	a := symmetricTriangle(uplo, aSym, lda)
+	b := symmetricTriangle(uplo, bSym, lda+3)
+	work := make([]float64, max(1, 3*n-1))
+	impl.Dsygv(itype, lapack.None, uplo, n, a.Data, a.Stride, b.Data, b.Stride, wAll, work, len(work))
+
+	for _, sel := range eigenSelections(rng, wAll) {
+		il, iu := sel.il, sel.iu
+		nzc := n
+		if rng == lapack.RangeIndex {
+			nzc = iu - il + 1
+		}
+		prefix := fmt.Sprintf("itype=%v, jobz=%c, rng=%c, uplo=%c, n=%v, lda=%v, vl=%v, vu=%v, il=%v, iu=%v",
+			itype, jobz, rng, uplo, n, lda, sel.vl, sel.vu, il, iu)
+
+		a := symmetricTriangle(uplo, aSym, lda)
+		b := symmetricTriangle(uplo, bSym, lda+3)
+		w := nanSlice(n)
+		z := blas64.General{Stride: 1}
+		var ifail []int
+		if wantz {
+			z = nanGeneral(n, nzc, max(1, nzc)+3)
+			ifail = make([]int, n)
+		}
+
+		work := make([]float64, 1)
+		impl.Dsygvx(itype, jobz, rng, uplo, n, nil, lda, nil, lda+3, sel.vl, sel.vu, il, iu, 0, nil, nil, z.Stride, work, -1, nil, nil)
+		work = nanSlice(int(work[0]))
+		iwork := make([]int, 5*n)
+
+		m, ok := impl.Dsygvx(itype, jobz, rng, uplo, n, a.Data, a.Stride, b.Data, b.Stride, sel.vl, sel.vu, il, iu, 0,
+			w, z.Data, z.Stride, work, len(work), iwork, ifail)
+		if !ok {
+			t.Errorf("%v: unexpected failure", prefix)
+			continue
+		}
+		if m != iu-il+1 {
+			t.Errorf("%v: unexpected number of eigenvalues; got %v, want %v", prefix, m, iu-il+1)
+			continue
+		}
+		if m == 0 {
+			continue
+		}
+		if !sort.Float64sAreSorted(w[:m]) {
+			t.Errorf("%v: eigenvalues are not sorted", prefix)
+		}
+		for i, v := range w[:m] {
+			want := wAll[il+i]
+			if math.Abs(v-want) > tol*math.Max(1, math.Abs(want)) {
+				t.Errorf("%v: eigenvalue %v mismatch with Dsygv; got %v, want %v", prefix, i, v, want)
+			}
+		}
+		if !wantz {
+			continue
+		}
+		if !generalOutsideAllNaN(z) {
+			t.Errorf("%v: out-of-range write to Z", prefix)
+		}
+		for _, v := range ifail[:m] {
+			if v != -1 {
+				t.Errorf("%v: unexpected ifail %v", prefix, ifail[:m])
+				break
+			}
+		}
+		z.Cols = m
+		resid, orth := symmetricDefiniteEVResidual(itype, aSym, bSym, bInv, w[:m], z)
+		if resid > tol*float64(n) {
+			t.Errorf("%v: unexpected eigenvectors, resid=%v", prefix, resid)
+		}
+		if orth > tol*float64(n) {
+			t.Errorf("%v: eigenvectors not normalized, |Z^T*B*Z-I|=%v", prefix, orth)
+		}
+	}
+}
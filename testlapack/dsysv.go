@@ -0,0 +1,97 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dsysver interface {
+	Dsysv(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool)
+}
+
+func DsysvTest(t *testing.T, impl Dsysver) {
+	dsysvTest(t, impl.Dsysv)
+}
+
+// dsysvTest tests a routine that solves a system of linear equations with a
+// symmetric indefinite matrix.
+func dsysvTest(t *testing.T, dsysv func(blas.Uplo, int, int, []float64, int, []int, []float64, int, []float64, int) bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, kind := range []symIndefKind{symIndefRandom, symIndefKKT, symIndefZeroDiag, symIndefSingular} {
+			for _, test := range []struct {
+				n, nrhs, lda, ldb int
+			}{
+				{0, 0, 0, 0},
+				{0, 3, 0, 0},
+				{1, 1, 0, 0},
+				{2, 2, 0, 0},
+				{5, 3, 0, 0},
+				{5, 3, 10, 11},
+				{50, 4, 0, 0},
+				{100, 10, 110, 12},
+			} {
+				for _, wl := range []worklen{minimumWork, optimumWork} {
+					testDsysv(t, dsysv, uplo, kind, test.n, test.nrhs, test.lda, test.ldb, wl, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDsysv(t *testing.T, dsysv func(blas.Uplo, int, int, []float64, int, []int, []float64, int, []float64, int) bool, uplo blas.Uplo, kind symIndefKind, n, nrhs, lda, ldb int, wl worklen, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	errStr := fmt.Sprintf("uplo = %v, kind = %v, n = %v, nrhs = %v, lda = %v, ldb = %v, wl = %v", uplo, kind, n, nrhs, lda, ldb, wl)
+
+	aSym, _, _ := randomSymIndef(kind, n, lda, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	ipiv := make([]int, n)
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	var lwork int
+	switch wl {
+	case minimumWork:
+		lwork = 1
+	case optimumWork:
+		work := make([]float64, 1)
+		dsysv(uplo, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride, work, -1)
+		lwork = int(work[0])
+	}
+	work := nanSlice(lwork)
+
+	ok := dsysv(uplo, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride, work, lwork)
+	if kind == symIndefSingular && n > 0 {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+		return
+	}
+	if !ok {
+		t.Errorf("Unexpected singular D: %s", errStr)
+		return
+	}
+	if n == 0 || nrhs == 0 {
+		return
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-10) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
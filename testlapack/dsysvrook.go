@@ -0,0 +1,19 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type DsysvRooker interface {
+	DsysvRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int, work []float64, lwork int) (ok bool)
+}
+
+func DsysvRookTest(t *testing.T, impl DsysvRooker) {
+	dsysvTest(t, impl.DsysvRook)
+}
@@ -0,0 +1,464 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dsytrfer interface {
+	Dsytrf(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+}
+
+func DsytrfTest(t *testing.T, impl Dsytrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	// Include sizes large enough for the blocked code to be used.
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25, 64, 65, 100, 129}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+			dsytrf := func(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool {
+				work := make([]float64, 1)
+				impl.Dsytrf(uplo, n, a, lda, ipiv, work, -1)
+				var lwork int
+				switch wl {
+				case minimumWork:
+					lwork = 1
+				case mediumWork:
+					// Use a block size that is smaller than the optimal
+					// one but still large enough for the blocked code.
+					lwork = max(1, 10*n)
+				case optimumWork:
+					lwork = int(work[0])
+				}
+				work = nanSlice(lwork)
+				return impl.Dsytrf(uplo, n, a, lda, ipiv, work, lwork)
+			}
+			dsytrfTest(t, dsytrf, false, rnd, uplo, ns)
+		}
+	}
+}
+
+// dsytrfTest tests a routine that computes the factorization of a symmetric
+// indefinite matrix A using the Bunch-Kaufman or, if rook is true, the
+// bounded Bunch-Kaufman diagonal pivoting method.
+func dsytrfTest(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, rook bool, rnd *rand.Rand, uplo blas.Uplo, ns []int) {
+	for _, n := range ns {
+		for _, lda := range []int{max(1, n), n + 5} {
+			for _, kind := range []symIndefKind{symIndefRandom, symIndefKKT, symIndefZeroDiag, symIndefSingular} {
+				testDsytrf(t, dsytrf, rook, uplo, n, lda, kind, rnd)
+			}
+		}
+	}
+}
+
+func testDsytrf(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, rook bool, uplo blas.Uplo, n, lda int, kind symIndefKind, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v, kind = %v", uplo, n, lda, kind)
+
+	aSym, npos, nneg := randomSymIndef(kind, n, lda, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	ipiv := make([]int, n)
+	for i := range ipiv {
+		ipiv[i] = n
+	}
+
+	ok := dsytrf(uplo, n, a.Data, a.Stride, ipiv)
+
+	if kind == symIndefSingular && n > 0 {
+		if ok {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+	} else if !ok {
+		t.Errorf("Unexpected singular D: %s", errStr)
+	}
+	if n == 0 {
+		return
+	}
+
+	if !validSymIndefPivots(uplo, n, ipiv, rook) {
+		t.Errorf("Invalid pivot indices %v: %s", ipiv, errStr)
+		return
+	}
+
+	if rook {
+		// The elements of U or L computed by the bounded Bunch-Kaufman
+		// method are bounded in magnitude by 1/(1-alpha).
+		alpha := (1 + math.Sqrt(17)) / 8
+		bound := 1 / (1 - alpha) * (1 + 1e-12)
+		if m := symIndefMaxMultiplier(uplo, n, a.Data, a.Stride, ipiv); m > bound {
+			t.Errorf("Unbounded multiplier %v: %s", m, errStr)
+		}
+	}
+
+	// Check that the factors reproduce the original matrix.
+	got := constructSymIndef(uplo, n, a.Data, a.Stride, ipiv, rook)
+	resid := residualSymIndef(aSym, got)
+	if resid > 100 {
+		t.Errorf("Residual |A - U*D*U^T| too large: %v: %s", resid, errStr)
+	}
+
+	// Check that D has the same inertia as A.
+	if kind == symIndefRandom || kind == symIndefKKT {
+		pos, neg, zero := symIndefInertia(uplo, n, a.Data, a.Stride, ipiv)
+		if pos != npos || neg != nneg || zero != 0 {
+			t.Errorf("Unexpected inertia (%v,%v,%v), want (%v,%v,0): %s", pos, neg, zero, npos, nneg, errStr)
+		}
+	}
+}
+
+// symIndefKind specifies the kind of a symmetric indefinite test matrix.
+type symIndefKind int
+
+const (
+	// symIndefRandom is a random nonsingular matrix with known inertia.
+	symIndefRandom symIndefKind = iota
+	// symIndefKKT is a nonsingular saddle point matrix
+	//  [ H  B^T ]
+	//  [ B   0  ]
+	// where H is positive definite and B has full row rank.
+	symIndefKKT
+	// symIndefZeroDiag is a random matrix with a zero diagonal (if n > 1)
+	// that forces the use of 2×2 pivot blocks.
+	symIndefZeroDiag
+	// symIndefSingular is a random matrix with a zero row and column.
+	symIndefSingular
+)
+
+func (k symIndefKind) String() string {
+	switch k {
+	case symIndefRandom:
+		return "random"
+	case symIndefKKT:
+		return "KKT"
+	case symIndefZeroDiag:
+		return "zero diagonal"
+	case symIndefSingular:
+		return "singular"
+	}
+	return "unknown"
+}
+
+// randomSymIndef returns a random n×n symmetric matrix of the given kind with
+// both triangles set. If the inertia of the matrix is known, the number of its
+// positive and negative eigenvalues is returned in npos and nneg.
+func randomSymIndef(kind symIndefKind, n, stride int, rnd *rand.Rand) (a blas64.General, npos, nneg int) {
+	a = blas64.General{Rows: n, Cols: n, Stride: stride, Data: make([]float64, n*stride)}
+	if n == 0 {
+		return a, 0, 0
+	}
+	switch kind {
+	case symIndefRandom:
+		// A = Q * D * Q^T, where Q is a random orthogonal matrix and D is a
+		// diagonal matrix with elements of random sign.
+		d := make([]float64, n)
+		for i := range d {
+			d[i] = 1 + 9*rnd.Float64()
+			if rnd.Intn(2) == 0 {
+				d[i] *= -1
+				nneg++
+			} else {
+				npos++
+			}
+		}
+		Dlagsy(n, 0, d, a.Data, a.Stride, rnd, make([]float64, 2*n))
+	case symIndefKKT:
+		m := (n + 1) / 2
+		p := n - m
+		h := randomSPD(m, m, 10, rnd)
+		for i := 0; i < m; i++ {
+			copy(a.Data[i*stride:i*stride+m], h.Data[i*m:i*m+m])
+		}
+		// A random p×m matrix has full row rank with probability one.
+		for i := m; i < n; i++ {
+			for j := 0; j < m; j++ {
+				v := rnd.NormFloat64()
+				a.Data[i*stride+j] = v
+				a.Data[j*stride+i] = v
+			}
+		}
+		npos, nneg = m, p
+	case symIndefZeroDiag, symIndefSingular:
+		for i := 0; i < n; i++ {
+			for j := 0; j <= i; j++ {
+				v := rnd.NormFloat64()
+				a.Data[i*stride+j] = v
+				a.Data[j*stride+i] = v
+			}
+		}
+		switch {
+		case kind == symIndefSingular:
+			k := rnd.Intn(n)
+			for i := 0; i < n; i++ {
+				a.Data[i*stride+k] = 0
+				a.Data[k*stride+i] = 0
+			}
+		case n > 1:
+			// A 1×1 matrix with zero diagonal would be singular.
+			for i := 0; i < n; i++ {
+				a.Data[i*stride+i] = 0
+			}
+		}
+		npos, nneg = -1, -1
+	}
+	return a, npos, nneg
+}
+
+// validSymIndefPivots returns whether ipiv contains valid pivot indices of the
+// factorization of an n×n symmetric indefinite matrix as computed by Dsytrf
+// or, if rook is true, by DsytrfRook.
+func validSymIndefPivots(uplo blas.Uplo, n int, ipiv []int, rook bool) bool {
+	// inRange returns whether the pivot index p of row k is within the
+	// part of the matrix that remained to be factorized at row k.
+	inRange := func(k, p int) bool {
+		if uplo == blas.Upper {
+			return 0 <= p && p <= k
+		}
+		return k <= p && p < n
+	}
+	for k := 0; k < n; {
+		if ipiv[k] >= 0 {
+			if !inRange(k, ipiv[k]) {
+				return false
+			}
+			k++
+			continue
+		}
+		if k == n-1 || ipiv[k+1] >= 0 {
+			return false
+		}
+		p, q := -ipiv[k]-1, -ipiv[k+1]-1
+		if !rook && p != q {
+			return false
+		}
+		if uplo == blas.Upper {
+			if !inRange(k, p) || !inRange(k+1, q) {
+				return false
+			}
+		} else {
+			if !inRange(k, p) || !inRange(k, q) {
+				return false
+			}
+		}
+		k += 2
+	}
+	return true
+}
+
+// symIndefBlocks returns the first row index and the size of the diagonal
+// blocks of D in the factorization of a symmetric indefinite matrix, in the
+// order in which the corresponding transformations are applied to D when
+// forming U*D*U^T or L*D*L^T.
+func symIndefBlocks(uplo blas.Uplo, n int, ipiv []int) (start, size []int) {
+	if uplo == blas.Upper {
+		for k := 0; k < n; {
+			s := 1
+			if ipiv[k] < 0 {
+				s = 2
+			}
+			start = append(start, k)
+			size = append(size, s)
+			k += s
+		}
+		return start, size
+	}
+	for k := n - 1; k >= 0; {
+		s := 1
+		if ipiv[k] < 0 {
+			s = 2
+		}
+		start = append(start, k-s+1)
+		size = append(size, s)
+		k -= s
+	}
+	return start, size
+}
+
+// constructSymIndef returns the n×n symmetric matrix
+//  A = U*D*U^T  if uplo == blas.Upper,
+//  A = L*D*L^T  if uplo == blas.Lower,
+// from its factorization as computed by Dsytrf or, if rook is true, by
+// DsytrfRook.
+func constructSymIndef(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, rook bool) blas64.General {
+	x := zeros(n, n, max(1, n))
+	ldx := x.Stride
+	start, size := symIndefBlocks(uplo, n, ipiv)
+
+	// Set x to the block diagonal matrix D.
+	for b, k := range start {
+		x.Data[k*ldx+k] = a[k*lda+k]
+		if size[b] == 2 {
+			x.Data[(k+1)*ldx+k+1] = a[(k+1)*lda+k+1]
+			off := a[(k+1)*lda+k]
+			if uplo == blas.Upper {
+				off = a[k*lda+k+1]
+			}
+			x.Data[k*ldx+k+1] = off
+			x.Data[(k+1)*ldx+k] = off
+		}
+	}
+
+	// swap interchanges rows and columns i and j of x.
+	swap := func(i, j int) {
+		if i == j {
+			return
+		}
+		for l := 0; l < n; l++ {
+			x.Data[i*ldx+l], x.Data[j*ldx+l] = x.Data[j*ldx+l], x.Data[i*ldx+l]
+		}
+		for l := 0; l < n; l++ {
+			x.Data[l*ldx+i], x.Data[l*ldx+j] = x.Data[l*ldx+j], x.Data[l*ldx+i]
+		}
+	}
+
+	// Apply the transformations P(k)*U(k) or P(k)*L(k) from the inside out.
+	for b, k := range start {
+		// rows are the rows of the non-trivial elements of U(k) or L(k),
+		// which are stored in the columns of the diagonal block.
+		r0, r1 := 0, k
+		if uplo == blas.Lower {
+			r0, r1 = k+size[b], n
+		}
+		// Compute x := U(k) * x * U(k)^T.
+		for i := r0; i < r1; i++ {
+			for c := k; c < k+size[b]; c++ {
+				u := a[i*lda+c]
+				for j := 0; j < n; j++ {
+					x.Data[i*ldx+j] += u * x.Data[c*ldx+j]
+				}
+			}
+		}
+		for j := r0; j < r1; j++ {
+			for c := k; c < k+size[b]; c++ {
+				u := a[j*lda+c]
+				for i := 0; i < n; i++ {
+					x.Data[i*ldx+j] += x.Data[i*ldx+c] * u
+				}
+			}
+		}
+		// Compute x := P(k) * x * P(k)^T.
+		switch {
+		case size[b] == 1:
+			swap(k, ipiv[k])
+		case uplo == blas.Upper:
+			swap(k, -ipiv[k]-1)
+			if rook {
+				swap(k+1, -ipiv[k+1]-1)
+			}
+		default:
+			swap(k+1, -ipiv[k+1]-1)
+			if rook {
+				swap(k, -ipiv[k]-1)
+			}
+		}
+	}
+	return x
+}
+
+// residualSymIndef returns
+//  |A - B|_1 / (n * |A|_1 * eps)
+// for n×n matrices A and B.
+func residualSymIndef(a, b blas64.General) float64 {
+	n := a.Rows
+	var anorm, dnorm float64
+	for j := 0; j < n; j++ {
+		var asum, dsum float64
+		for i := 0; i < n; i++ {
+			aij := a.Data[i*a.Stride+j]
+			asum += math.Abs(aij)
+			dsum += math.Abs(aij - b.Data[i*b.Stride+j])
+		}
+		anorm = math.Max(anorm, asum)
+		dnorm = math.Max(dnorm, dsum)
+	}
+	if anorm == 0 {
+		anorm = 1
+	}
+	return dnorm / (float64(n) * anorm * dlamchE)
+}
+
+// symIndefMaxMultiplier returns the largest absolute value of the non-trivial
+// elements of U or L in the factorization of a symmetric indefinite matrix.
+func symIndefMaxMultiplier(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) float64 {
+	var m float64
+	start, size := symIndefBlocks(uplo, n, ipiv)
+	for b, k := range start {
+		r0, r1 := 0, k
+		if uplo == blas.Lower {
+			r0, r1 = k+size[b], n
+		}
+		for i := r0; i < r1; i++ {
+			for c := k; c < k+size[b]; c++ {
+				m = math.Max(m, math.Abs(a[i*lda+c]))
+			}
+		}
+	}
+	return m
+}
+
+// symIndefInertia returns the number of positive, negative and zero
+// eigenvalues of the block diagonal matrix D in the factorization of a
+// symmetric indefinite matrix.
+func symIndefInertia(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) (pos, neg, zero int) {
+	start, size := symIndefBlocks(uplo, n, ipiv)
+	for b, k := range start {
+		if size[b] == 1 {
+			switch d := a[k*lda+k]; {
+			case d > 0:
+				pos++
+			case d < 0:
+				neg++
+			default:
+				zero++
+			}
+			continue
+		}
+		// Determine the signs of the eigenvalues of the 2×2 block from its
+		// determinant and trace.
+		off := a[(k+1)*lda+k]
+		if uplo == blas.Upper {
+			off = a[k*lda+k+1]
+		}
+		ak := a[k*lda+k]
+		akp1 := a[(k+1)*lda+k+1]
+		det := ak*akp1 - off*off
+		switch {
+		case det < 0:
+			pos++
+			neg++
+		case det > 0 && ak+akp1 > 0:
+			pos += 2
+		case det > 0:
+			neg += 2
+		default:
+			zero++
+			switch {
+			case ak+akp1 > 0:
+				pos++
+			case ak+akp1 < 0:
+				neg++
+			default:
+				zero++
+			}
+		}
+	}
+	return pos, neg, zero
+}
+
+// dsytrfOptimal returns a function that calls dsytrf with the optimal amount
+// of workspace.
+func dsytrfOptimal(dsytrf func(blas.Uplo, int, []float64, int, []int, []float64, int) bool) func(blas.Uplo, int, []float64, int, []int) bool {
+	return func(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool {
+		work := make([]float64, 1)
+		dsytrf(uplo, n, a, lda, ipiv, work, -1)
+		lwork := int(work[0])
+		work = make([]float64, lwork)
+		return dsytrf(uplo, n, a, lda, ipiv, work, lwork)
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type DsytrfRooker interface {
+	DsytrfRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64, lwork int) (ok bool)
+}
+
+func DsytrfRookTest(t *testing.T, impl DsytrfRooker) {
+	rnd := rand.New(rand.NewSource(1))
+	ns := []int{0, 1, 2, 3, 4, 5, 10, 25, 64, 65, 100, 129}
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+			dsytrf := func(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int) bool {
+				work := make([]float64, 1)
+				impl.DsytrfRook(uplo, n, a, lda, ipiv, work, -1)
+				var lwork int
+				switch wl {
+				case minimumWork:
+					lwork = 1
+				case mediumWork:
+					lwork = max(1, 10*n)
+				case optimumWork:
+					lwork = int(work[0])
+				}
+				work = nanSlice(lwork)
+				return impl.DsytrfRook(uplo, n, a, lda, ipiv, work, lwork)
+			}
+			dsytrfTest(t, dsytrf, true, rnd, uplo, ns)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dsytrier interface {
+	Dsytri(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool)
+	Dsytrfer
+}
+
+func DsytriTest(t *testing.T, impl Dsytrier) {
+	dsytriTest(t, dsytrfOptimal(impl.Dsytrf), impl.Dsytri)
+}
+
+// dsytriTest tests a routine that computes the inverse of a symmetric
+// indefinite matrix factorized by the routine dsytrf.
+func dsytriTest(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, dsytri func(blas.Uplo, int, []float64, int, []int, []float64) bool) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, kind := range []symIndefKind{symIndefRandom, symIndefKKT, symIndefZeroDiag} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 50, 100} {
+				for _, lda := range []int{max(1, n), n + 7} {
+					testDsytri(t, dsytrf, dsytri, uplo, kind, n, lda, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDsytri(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, dsytri func(blas.Uplo, int, []float64, int, []int, []float64) bool, uplo blas.Uplo, kind symIndefKind, n, lda int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("uplo = %v, kind = %v, n = %v, lda = %v", uplo, kind, n, lda)
+
+	aSym, _, _ := randomSymIndef(kind, n, lda, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	ipiv := make([]int, n)
+	if !dsytrf(uplo, n, a.Data, a.Stride, ipiv) {
+		t.Errorf("Unexpected singular D: %s", errStr)
+		return
+	}
+
+	work := nanSlice(n)
+	if !dsytri(uplo, n, a.Data, a.Stride, ipiv, work) {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Fill the opposite triangle of the inverse.
+	ainv := cloneGeneral(a)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			if uplo == blas.Upper {
+				ainv.Data[i*ainv.Stride+j] = ainv.Data[j*ainv.Stride+i]
+			} else {
+				ainv.Data[j*ainv.Stride+i] = ainv.Data[i*ainv.Stride+j]
+			}
+		}
+	}
+
+	// Check that A*inv(A) = I.
+	ans := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, ainv, 0, ans)
+	if !equalApproxGeneral(ans, eye(n, n), 1e-9) {
+		t.Errorf("A*inv(A) != I: %s", errStr)
+	}
+}
@@ -0,0 +1,20 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type DsytriRooker interface {
+	DsytriRook(uplo blas.Uplo, n int, a []float64, lda int, ipiv []int, work []float64) (ok bool)
+	DsytrfRooker
+}
+
+func DsytriRookTest(t *testing.T, impl DsytriRooker) {
+	dsytriTest(t, dsytrfOptimal(impl.DsytrfRook), impl.DsytriRook)
+}
@@ -0,0 +1,91 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/floats"
+)
+
+type Dsytrser interface {
+	Dsytrs(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	Dsytrfer
+}
+
+func DsytrsTest(t *testing.T, impl Dsytrser) {
+	dsytrsTest(t, dsytrfOptimal(impl.Dsytrf), impl.Dsytrs)
+}
+
+// dsytrsTest tests a routine that solves a system of linear equations with a
+// symmetric indefinite matrix factorized by the routine dsytrf.
+func dsytrsTest(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, dsytrs func(blas.Uplo, int, int, []float64, int, []int, []float64, int)) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, kind := range []symIndefKind{symIndefRandom, symIndefKKT, symIndefZeroDiag} {
+			for _, test := range []struct {
+				n, nrhs, lda, ldb int
+			}{
+				{0, 0, 0, 0},
+				{0, 3, 0, 0},
+				{3, 0, 0, 0},
+				{1, 1, 0, 0},
+				{1, 3, 0, 0},
+				{2, 2, 0, 0},
+				{5, 1, 0, 0},
+				{5, 3, 0, 0},
+				{5, 3, 10, 11},
+				{50, 4, 0, 0},
+				{100, 10, 110, 12},
+			} {
+				testDsytrs(t, dsytrf, dsytrs, uplo, kind, test.n, test.nrhs, test.lda, test.ldb, rnd)
+			}
+		}
+	}
+}
+
+func testDsytrs(t *testing.T, dsytrf func(blas.Uplo, int, []float64, int, []int) bool, dsytrs func(blas.Uplo, int, int, []float64, int, []int, []float64, int), uplo blas.Uplo, kind symIndefKind, n, nrhs, lda, ldb int, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	if ldb == 0 {
+		ldb = max(1, nrhs)
+	}
+	errStr := fmt.Sprintf("uplo = %v, kind = %v, n = %v, nrhs = %v, lda = %v, ldb = %v", uplo, kind, n, nrhs, lda, ldb)
+
+	aSym, _, _ := randomSymIndef(kind, n, lda, rnd)
+	a := nanTriangleOf(uplo, aSym)
+	ipiv := make([]int, n)
+	if !dsytrf(uplo, n, a.Data, a.Stride, ipiv) {
+		t.Errorf("Unexpected singular D: %s", errStr)
+		return
+	}
+	aFac := cloneGeneral(a)
+	b := blas64.General{Rows: n, Cols: nrhs, Stride: ldb, Data: randomSlice(n*ldb, rnd)}
+	bCopy := cloneGeneral(b)
+
+	dsytrs(uplo, n, nrhs, a.Data, a.Stride, ipiv, b.Data, b.Stride)
+
+	if !floats.Same(a.Data, aFac.Data) {
+		t.Errorf("A modified: %s", errStr)
+	}
+	if n == 0 || nrhs == 0 {
+		if !floats.Equal(b.Data, bCopy.Data) {
+			t.Errorf("B modified for quick return: %s", errStr)
+		}
+		return
+	}
+
+	// Check that the solution satisfies A*X = B.
+	ax := zeros(n, nrhs, nrhs)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aSym, b, 0, ax)
+	if !equalApproxGeneral(ax, bCopy, 1e-10) {
+		t.Errorf("A*X != B: %s", errStr)
+	}
+}
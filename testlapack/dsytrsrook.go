@@ -0,0 +1,20 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type DsytrsRooker interface {
+	DsytrsRook(uplo blas.Uplo, n, nrhs int, a []float64, lda int, ipiv []int, b []float64, ldb int)
+	DsytrfRooker
+}
+
+func DsytrsRookTest(t *testing.T, impl DsytrsRooker) {
+	dsytrsTest(t, dsytrfOptimal(impl.DsytrfRook), impl.DsytrsRook)
+}
@@ -0,0 +1,90 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtftrier interface {
+	Dtftri(transr blas.Transpose, uplo blas.Uplo, diag blas.Diag, n int, a []float64) (ok bool)
+	Dtfttrer
+}
+
+func DtftriTest(t *testing.T, impl Dtftrier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+					testDtftri(t, impl, transr, uplo, diag, n, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDtftri(t *testing.T, impl Dtftrier, transr blas.Transpose, uplo blas.Uplo, diag blas.Diag, n int, rnd *rand.Rand) {
+	const tol = 1e-10
+
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, diag = %v, n = %v", transr, uplo, diag, n)
+
+	// Generate a random well-conditioned triangular matrix A.
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i < j) || (uplo == blas.Lower && i > j) {
+				a.Data[i*n+j] = rnd.NormFloat64() / float64(n)
+			}
+		}
+		a.Data[i*n+i] = 1 + rnd.Float64()
+	}
+	arf := nanSlice(n * (n + 1) / 2)
+	impl.Dtrttf(transr, uplo, n, a.Data, a.Stride, arf)
+	if diag == blas.Unit {
+		for i := 0; i < n; i++ {
+			a.Data[i*n+i] = 1
+		}
+	}
+
+	ok := impl.Dtftri(transr, uplo, diag, n, arf)
+	if !ok {
+		t.Errorf("Unexpected failure: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	// Check that A * inv(A) = I.
+	ainv := zeros(n, n, n)
+	impl.Dtfttr(transr, uplo, n, arf, ainv.Data, ainv.Stride)
+	if diag == blas.Unit {
+		for i := 0; i < n; i++ {
+			ainv.Data[i*n+i] = 1
+		}
+	}
+	ai := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, ainv, 0, ai)
+	if !equalApproxGeneral(ai, eye(n, n), tol) {
+		t.Errorf("A * inv(A) != I: %s", errStr)
+	}
+
+	if diag == blas.Unit {
+		return
+	}
+	// Check that a singular matrix is detected.
+	i := rnd.Intn(n)
+	a.Data[i*n+i] = 0
+	impl.Dtrttf(transr, uplo, n, a.Data, a.Stride, arf)
+	if impl.Dtftri(transr, uplo, diag, n, arf) {
+		t.Errorf("Singular matrix not detected: %s", errStr)
+	}
+}
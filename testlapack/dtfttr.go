@@ -0,0 +1,57 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dtfttrer interface {
+	Dtfttr(transr blas.Transpose, uplo blas.Uplo, n int, arf []float64, a []float64, lda int)
+	Dtrttfer
+}
+
+func DtfttrTest(t *testing.T, impl Dtfttrer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+				for _, lda := range []int{max(1, n), n + 3} {
+					testDtfttr(t, impl, transr, uplo, n, lda, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDtfttr(t *testing.T, impl Dtfttrer, transr blas.Transpose, uplo blas.Uplo, n, lda int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v, lda = %v", transr, uplo, n, lda)
+
+	a := randomTriangular(uplo, n, lda, rnd)
+	arf := nanSlice(n * (n + 1) / 2)
+	impl.Dtrttf(transr, uplo, n, a.Data, lda, arf)
+
+	// Copy the RFP array back into a matrix with NaN outside of the
+	// triangle and check that A is recovered.
+	b := nanTriangular(uplo, n, lda)
+	impl.Dtfttr(transr, uplo, n, arf, b.Data, lda)
+	for k, got := range b.Data {
+		i, j := k/lda, k%lda
+		inTri := j < n && ((uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j))
+		if inTri && got != a.Data[k] {
+			t.Errorf("Unexpected element at (%v,%v): %s", i, j, errStr)
+			return
+		}
+		if !inTri && !math.IsNaN(got) {
+			t.Errorf("Element outside of the triangle modified: %s", errStr)
+			return
+		}
+	}
+}
@@ -0,0 +1,213 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dtgevcer interface {
+	Dtgevc(side lapack.EVSide, howmny lapack.HowMany, selected []bool, n int, s []float64, lds int, p []float64, ldp int, vl []float64, ldvl int, vr []float64, ldvr int, mm int, work []float64) (m int, ok bool)
+}
+
+func DtgevcTest(t *testing.T, impl Dtgevcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, side := range []lapack.EVSide{lapack.RightEV, lapack.LeftEV, lapack.RightLeftEV} {
+		for _, howmny := range []lapack.HowMany{lapack.AllEV, lapack.AllEVMulQ, lapack.SelectedEV} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 34} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 10; cas++ {
+						testDtgevc(t, impl, side, howmny, n, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtgevc(t *testing.T, impl Dtgevcer, side lapack.EVSide, howmny lapack.HowMany, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	right := side != lapack.LeftEV
+	left := side != lapack.RightEV
+
+	// Generate a random quasi-triangular S and an upper triangular P with
+	// positive diagonal elements such that the 2×2 diagonal blocks of P
+	// corresponding to 2×2 blocks of S are multiples of the identity. Each
+	// such block pair then has a complex conjugate pair of eigenvalues.
+	s := randomSchurCanonical(n, n+extra, rnd)
+	p := randomGeneral(n, n, n+extra, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			p.Data[i*p.Stride+j] = 0
+		}
+		p.Data[i*p.Stride+i] = 0.5 + math.Abs(p.Data[i*p.Stride+i])
+	}
+	for j := 0; j < n-1; j++ {
+		if s.Data[(j+1)*s.Stride+j] != 0 {
+			p.Data[j*p.Stride+j+1] = 0
+			p.Data[(j+1)*p.Stride+j+1] = p.Data[j*p.Stride+j]
+			j++
+		}
+	}
+	sCopy := cloneGeneral(s)
+	pCopy := cloneGeneral(p)
+
+	var selected []bool
+	mWant := n
+	if howmny == lapack.SelectedEV {
+		selected = make([]bool, n)
+		for i := range selected {
+			selected[i] = rnd.Float64() < 0.5
+		}
+		mWant = 0
+		for j := 0; j < n; j++ {
+			if j == n-1 || s.Data[(j+1)*s.Stride+j] == 0 {
+				if selected[j] {
+					mWant++
+				}
+				continue
+			}
+			if selected[j] || selected[j+1] {
+				mWant += 2
+			}
+			j++
+		}
+	}
+	selectedCopy := make([]bool, len(selected))
+	copy(selectedCopy, selected)
+
+	// If howmny == lapack.AllEVMulQ, VL and VR contain random orthogonal
+	// matrices Q and Z on entry and the eigenvectors are checked against
+	// the pair (Q*S*Z^T, Q*P*Z^T).
+	a, b := sCopy, pCopy
+	var vl, vr blas64.General
+	if howmny == lapack.AllEVMulQ {
+		q := randomOrthogonal(n, rnd)
+		z := randomOrthogonal(n, rnd)
+		a = zeros(n, n, n)
+		b = zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, sCopy, z, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, tmp, 0, a)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, pCopy, z, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q, tmp, 0, b)
+		if left {
+			vl = nanGeneral(n, n, n+extra)
+			copyGeneral(vl, q)
+		}
+		if right {
+			vr = nanGeneral(n, n, n+extra)
+			copyGeneral(vr, z)
+		}
+	} else {
+		if left {
+			vl = nanGeneral(n, mWant, n+extra)
+		}
+		if right {
+			vr = nanGeneral(n, mWant, n+extra)
+		}
+	}
+	if vl.Stride == 0 {
+		vl.Stride = 1
+	}
+	if vr.Stride == 0 {
+		vr.Stride = 1
+	}
+
+	work := nanSlice(6 * n)
+	m, ok := impl.Dtgevc(side, howmny, selected, n, s.Data, s.Stride, p.Data, p.Stride,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, mWant, work)
+
+	prefix := fmt.Sprintf("Case side=%v, howmny=%v, n=%v, extra=%v", side, howmny, n, extra)
+
+	if !ok {
+		t.Errorf("%v: unexpected failure", prefix)
+		return
+	}
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m. Want %v, got %v", prefix, mWant, m)
+	}
+	if !equalApproxGeneral(s, sCopy, 0) {
+		t.Errorf("%v: unexpected modification of S", prefix)
+	}
+	if !equalApproxGeneral(p, pCopy, 0) {
+		t.Errorf("%v: unexpected modification of P", prefix)
+	}
+	for i := range selected {
+		if selected[i] != selectedCopy[i] {
+			t.Errorf("%v: unexpected modification of selected[%v]", prefix, i)
+		}
+	}
+	if !generalOutsideAllNaN(vl) {
+		t.Errorf("%v: out-of-range write to VL", prefix)
+	}
+	if !generalOutsideAllNaN(vr) {
+		t.Errorf("%v: out-of-range write to VR", prefix)
+	}
+
+	// Check that the columns of VL and VR are eigenvectors and that they
+	// are normalized.
+	checkVec := func(v blas64.General, isLeft bool, k int, alpha complex128, beta float64, complexPair bool) {
+		name := "VR"
+		if isLeft {
+			name = "VL"
+		}
+		xRe := columnOf(v, k)
+		var xIm []float64
+		if complexPair {
+			xIm = columnOf(v, k+1)
+		}
+		var vmax float64
+		for i, re := range xRe {
+			mag := math.Abs(re)
+			if xIm != nil {
+				mag += math.Abs(xIm[i])
+			}
+			vmax = math.Max(vmax, mag)
+		}
+		if math.Abs(vmax-1) > tol {
+			t.Errorf("%v: magnitude of largest element of %v[:,%v] not 1, got %v", prefix, name, k, vmax)
+		}
+		if resid := generalizedEVResidual(isLeft, a, b, alpha, beta, xRe, xIm); resid > tol*float64(n) {
+			t.Errorf("%v: %v[:,%v] is not an eigenvector, resid=%v", prefix, name, k, resid)
+		}
+	}
+	var k int
+	for j := 0; j < n; j++ {
+		complexPair := j < n-1 && sCopy.Data[(j+1)*sCopy.Stride+j] != 0
+		if howmny == lapack.SelectedEV && !selected[j] && !(complexPair && selected[j+1]) {
+			if complexPair {
+				j++
+			}
+			continue
+		}
+		alpha := complex(sCopy.Data[j*sCopy.Stride+j], 0)
+		beta := pCopy.Data[j*pCopy.Stride+j]
+		if complexPair {
+			im := math.Sqrt(math.Abs(sCopy.Data[j*sCopy.Stride+j+1] * sCopy.Data[(j+1)*sCopy.Stride+j]))
+			alpha = complex(real(alpha), im)
+		}
+		if left {
+			checkVec(vl, true, k, alpha, beta, complexPair)
+		}
+		if right {
+			checkVec(vr, false, k, alpha, beta, complexPair)
+		}
+		if complexPair {
+			k += 2
+			j++
+		} else {
+			k++
+		}
+	}
+}
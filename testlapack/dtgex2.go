@@ -0,0 +1,156 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtgex2er interface {
+	Dtgex2(wantq, wantz bool, n int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, j1, n1, n2 int, work []float64, lwork int) (ok bool)
+}
+
+func Dtgex2Test(t *testing.T, impl Dtgex2er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, wantq := range []bool{false, true} {
+		for _, wantz := range []bool{false, true} {
+			for _, n := range []int{2, 3, 4, 5, 6, 10} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 20; cas++ {
+						testDtgex2(t, impl, wantq, wantz, n, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+// testDtgex2 swaps each pair of adjacent diagonal blocks of a random matrix
+// pair in generalized Schur canonical form.
+func testDtgex2(t *testing.T, impl Dtgex2er, wantq, wantz bool, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	aIn, bIn := randomGeneralizedSchur(n, n+extra, rnd)
+	q1 := randomOrthogonal(n, rnd)
+	z1 := randomOrthogonal(n, rnd)
+
+	// Find the first row of each diagonal block.
+	var starts []int
+	for i := 0; i < n; {
+		starts = append(starts, i)
+		size, _ := schurBlockSize(aIn, i)
+		i += size
+	}
+
+	for k := 0; k < len(starts)-1; k++ {
+		j1 := starts[k]
+		n1 := starts[k+1] - j1
+		n2 := n - starts[k+1]
+		if k+2 < len(starts) {
+			n2 = starts[k+2] - starts[k+1]
+		}
+
+		a := cloneGeneral(aIn)
+		b := cloneGeneral(bIn)
+		q := blas64.General{Stride: 1}
+		if wantq {
+			q = nanGeneral(n, n, n+extra)
+			copyGeneral(q, q1)
+		}
+		z := blas64.General{Stride: 1}
+		if wantz {
+			z = nanGeneral(n, n, n+extra)
+			copyGeneral(z, z1)
+		}
+		lwork := max(1, max(n*(n1+n2), 2*(n1+n2)*(n1+n2)))
+		work := nanSlice(lwork)
+
+		ok := impl.Dtgex2(wantq, wantz, n, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride,
+			j1, n1, n2, work, lwork)
+
+		prefix := fmt.Sprintf("Case wantq=%v,wantz=%v,n=%v,j1=%v,n1=%v,n2=%v,extra=%v:", wantq, wantz, n, j1, n1, n2, extra)
+
+		if !generalOutsideAllNaN(a) {
+			t.Errorf("%v out-of-range write to A", prefix)
+		}
+		if !generalOutsideAllNaN(b) {
+			t.Errorf("%v out-of-range write to B", prefix)
+		}
+		if wantq && !generalOutsideAllNaN(q) {
+			t.Errorf("%v out-of-range write to Q", prefix)
+		}
+		if wantz && !generalOutsideAllNaN(z) {
+			t.Errorf("%v out-of-range write to Z", prefix)
+		}
+
+		if !ok {
+			// The blocks were not swapped, so nothing may have
+			// changed.
+			if !equalApproxGeneral(a, aIn, 0) || !equalApproxGeneral(b, bIn, 0) {
+				t.Errorf("%v (A,B) modified although the blocks were not swapped", prefix)
+			}
+			if (wantq && !equalApproxGeneral(q, q1, 0)) || (wantz && !equalApproxGeneral(z, z1, 0)) {
+				t.Errorf("%v Q or Z modified although the blocks were not swapped", prefix)
+			}
+			t.Logf("%v Dtgex2 returned ok=false", prefix)
+			continue
+		}
+
+		if !isGeneralizedSchur(a, b) {
+			t.Errorf("%v (A,B) is not in generalized Schur canonical form", prefix)
+		}
+
+		// The blocks have exchanged their position and keep their
+		// eigenvalues.
+		if size, _ := schurBlockSize(a, j1); size != n2 {
+			t.Errorf("%v unexpected size of the block at %v; got %v, want %v", prefix, j1, size, n2)
+			continue
+		}
+		if size, _ := schurBlockSize(a, j1+n2); size != n1 {
+			t.Errorf("%v unexpected size of the block at %v; got %v, want %v", prefix, j1+n2, size, n1)
+			continue
+		}
+		for _, blk := range []struct{ want, got int }{{j1 + n1, j1}, {j1, j1 + n2}} {
+			evWant := generalizedBlockEigenvalues(aIn, bIn, blk.want)
+			evGot := generalizedBlockEigenvalues(a, b, blk.got)
+			for i, ev := range evWant {
+				if cmplx.Abs(evGot[i]-ev) > tol*math.Max(1, cmplx.Abs(ev)) {
+					t.Errorf("%v unexpected eigenvalue %v of the block at %v; got %v, want %v", prefix, i, blk.got, evGot[i], ev)
+				}
+			}
+		}
+
+		// The other diagonal blocks are unchanged.
+		for i := 0; i < n; i++ {
+			if j1 <= i && i < j1+n1+n2 {
+				continue
+			}
+			if a.Data[i*a.Stride+i] != aIn.Data[i*aIn.Stride+i] || b.Data[i*b.Stride+i] != bIn.Data[i*bIn.Stride+i] {
+				t.Errorf("%v diagonal element %v outside the swapped blocks modified", prefix, i)
+			}
+		}
+
+		if wantq && !isOrthonormal(q) {
+			t.Errorf("%v Q is not orthogonal", prefix)
+		}
+		if wantz && !isOrthonormal(z) {
+			t.Errorf("%v Z is not orthogonal", prefix)
+		}
+		if wantq && wantz {
+			if resid := qzResidual(aIn, q1, z1, q, z, a); resid > tol*float64(n) {
+				t.Errorf("%v Q^T*A*Z differs from reordered A, resid=%v", prefix, resid)
+			}
+			if resid := qzResidual(bIn, q1, z1, q, z, b); resid > tol*float64(n) {
+				t.Errorf("%v Q^T*B*Z differs from reordered B, resid=%v", prefix, resid)
+			}
+		}
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtgexcer interface {
+	Dtgexc(wantq, wantz bool, n int, a []float64, lda int, b []float64, ldb int, q []float64, ldq int, z []float64, ldz int, ifst, ilst int, work []float64, lwork int) (ifstOut, ilstOut int, ok bool)
+}
+
+func DtgexcTest(t *testing.T, impl Dtgexcer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, wantq := range []bool{false, true} {
+		for _, wantz := range []bool{false, true} {
+			for _, n := range []int{1, 2, 3, 4, 5, 6, 10, 18} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 30; cas++ {
+						ifst := rnd.Intn(n)
+						ilst := rnd.Intn(n)
+						testDtgexc(t, impl, wantq, wantz, n, ifst, ilst, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtgexc(t *testing.T, impl Dtgexcer, wantq, wantz bool, n, ifst, ilst, extra int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	a, b := randomGeneralizedSchur(n, n+extra, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	fstSize, fstFirst := schurBlockSize(a, ifst)
+	lstSize, lstFirst := schurBlockSize(a, ilst)
+
+	q1 := randomOrthogonal(n, rnd)
+	z1 := randomOrthogonal(n, rnd)
+	q := blas64.General{Stride: 1}
+	if wantq {
+		q = nanGeneral(n, n, n+extra)
+		copyGeneral(q, q1)
+	}
+	z := blas64.General{Stride: 1}
+	if wantz {
+		z = nanGeneral(n, n, n+extra)
+		copyGeneral(z, z1)
+	}
+
+	work := make([]float64, 1)
+	impl.Dtgexc(wantq, wantz, n, a.Data, a.Stride, b.Data, b.Stride, q.Data, q.Stride, z.Data, z.Stride,
+		ifst, ilst, work, -1)
+	work = nanSlice(int(work[0]))
+
+	ifstGot, ilstGot, ok := impl.Dtgexc(wantq, wantz, n, a.Data, a.Stride, b.Data, b.Stride,
+		q.Data, q.Stride, z.Data, z.Stride, ifst, ilst, work, len(work))
+
+	prefix := fmt.Sprintf("Case wantq=%v, wantz=%v, n=%v, ifst=%v, nbf=%v, ilst=%v, nbl=%v, extra=%v",
+		wantq, wantz, n, ifst, fstSize, ilst, lstSize, extra)
+
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+	if wantq && !generalOutsideAllNaN(q) {
+		t.Errorf("%v: out-of-range write to Q", prefix)
+	}
+	if wantz && !generalOutsideAllNaN(z) {
+		t.Errorf("%v: out-of-range write to Z", prefix)
+	}
+
+	if !ok {
+		t.Logf("%v: Dtgexc returned ok=false", prefix)
+		return
+	}
+
+	// Check that the indices of the blocks were correctly updated.
+	ifstWant := ifst
+	if !fstFirst {
+		ifstWant--
+	}
+	if ifstWant != ifstGot {
+		t.Errorf("%v: unexpected ifst index. Want %v, got %v", prefix, ifstWant, ifstGot)
+	}
+	ilstWant := ilst
+	if !lstFirst {
+		ilstWant--
+	}
+	if ifstWant < ilstWant {
+		switch {
+		case fstSize == 2 && lstSize == 1:
+			ilstWant--
+		case fstSize == 1 && lstSize == 2:
+			ilstWant++
+		}
+	}
+	if ilstWant != ilstGot {
+		t.Errorf("%v: unexpected ilst index. Want %v, got %v", prefix, ilstWant, ilstGot)
+	}
+
+	if !isGeneralizedSchur(a, b) {
+		t.Errorf("%v: (A,B) is not in generalized Schur canonical form", prefix)
+	}
+
+	// Check that the moved block has the same eigenvalues.
+	evWant := generalizedBlockEigenvalues(aCopy, bCopy, ifstGot)
+	evGot := generalizedBlockEigenvalues(a, b, ilstGot)
+	if len(evGot) != len(evWant) {
+		t.Errorf("%v: unexpected size of the moved block. Want %v, got %v", prefix, len(evWant), len(evGot))
+	} else {
+		for k, ev := range evWant {
+			if cmplx.Abs(evGot[k]-ev) > tol*math.Max(1, cmplx.Abs(ev)) {
+				t.Errorf("%v: unexpected eigenvalue %v of the moved block. Want %v, got %v", prefix, k, ev, evGot[k])
+			}
+		}
+	}
+
+	if wantq {
+		if !isOrthonormal(q) {
+			t.Errorf("%v: Q is not orthogonal", prefix)
+		}
+	}
+	if wantz {
+		if !isOrthonormal(z) {
+			t.Errorf("%v: Z is not orthogonal", prefix)
+		}
+	}
+	if wantq && wantz {
+		if resid := qzResidual(aCopy, q1, z1, q, z, a); resid > tol*float64(n) {
+			t.Errorf("%v: Q^T*A*Z differs from reordered A, resid=%v", prefix, resid)
+		}
+		if resid := qzResidual(bCopy, q1, z1, q, z, b); resid > tol*float64(n) {
+			t.Errorf("%v: Q^T*B*Z differs from reordered B, resid=%v", prefix, resid)
+		}
+	}
+}
+
+// randomGeneralizedSchur returns a random n×n matrix pair (A,B) in generalized
+// real Schur canonical form, that is, A is upper quasi-triangular with 1×1 and
+// 2×2 diagonal blocks and B is upper triangular with positive diagonal. The
+// 2×2 diagonal blocks of B corresponding to the 2×2 blocks of A are multiples
+// of the identity so that each 2×2 diagonal block pair has a complex conjugate
+// pair of eigenvalues.
+func randomGeneralizedSchur(n, stride int, rnd *rand.Rand) (a, b blas64.General) {
+	a = randomSchurCanonical(n, stride, rnd)
+	b = randomGeneral(n, n, stride, rnd)
+	for i := 0; i < n; i++ {
+		for j := 0; j < i; j++ {
+			b.Data[i*b.Stride+j] = 0
+		}
+		b.Data[i*b.Stride+i] = 0.5 + math.Abs(b.Data[i*b.Stride+i])
+	}
+	for j := 0; j < n-1; j++ {
+		if a.Data[(j+1)*a.Stride+j] != 0 {
+			b.Data[j*b.Stride+j+1] = 0
+			b.Data[(j+1)*b.Stride+j+1] = b.Data[j*b.Stride+j]
+			j++
+		}
+	}
+	return a, b
+}
+
+// isGeneralizedSchur returns whether the n×n matrix pair (A,B) is in
+// generalized real Schur canonical form, that is, whether A is upper
+// quasi-triangular with 1×1 and 2×2 diagonal blocks, B is upper triangular
+// and the 2×2 diagonal blocks of B corresponding to the 2×2 blocks of A are
+// diagonal.
+func isGeneralizedSchur(a, b blas64.General) bool {
+	n := a.Rows
+	if !isUpperTriangular(b) {
+		return false
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if a.Data[i*a.Stride+j] != 0 {
+				return false
+			}
+		}
+	}
+	for j := 0; j < n-1; j++ {
+		if a.Data[(j+1)*a.Stride+j] == 0 {
+			continue
+		}
+		if j+2 < n && a.Data[(j+2)*a.Stride+j+1] != 0 {
+			return false
+		}
+		if b.Data[j*b.Stride+j+1] != 0 {
+			return false
+		}
+		j++
+	}
+	return true
+}
+
+// generalizedBlockEigenvalues returns the eigenvalues of the 1×1 or 2×2
+// diagonal block pair of the matrix pair (A,B) in generalized real Schur form
+// that starts at row i. For a 2×2 block the eigenvalue with the positive
+// imaginary part is returned first.
+func generalizedBlockEigenvalues(a, b blas64.General, i int) []complex128 {
+	if i == a.Rows-1 || a.Data[(i+1)*a.Stride+i] == 0 {
+		return []complex128{complex(a.Data[i*a.Stride+i]/b.Data[i*b.Stride+i], 0)}
+	}
+	s00 := a.Data[i*a.Stride+i]
+	s01 := a.Data[i*a.Stride+i+1]
+	s10 := a.Data[(i+1)*a.Stride+i]
+	s11 := a.Data[(i+1)*a.Stride+i+1]
+	p00 := b.Data[i*b.Stride+i]
+	p01 := b.Data[i*b.Stride+i+1]
+	p11 := b.Data[(i+1)*b.Stride+i+1]
+	// The eigenvalues are the roots of
+	//  det(S - λ*P) = c2*λ^2 + c1*λ + c0.
+	c2 := complex(p00*p11, 0)
+	c1 := complex(-(s00*p11 + s11*p00 - s10*p01), 0)
+	c0 := complex(s00*s11-s01*s10, 0)
+	d := cmplx.Sqrt(c1*c1 - 4*c2*c0)
+	ev1 := (-c1 + d) / (2 * c2)
+	ev2 := (-c1 - d) / (2 * c2)
+	if imag(ev1) < imag(ev2) {
+		ev1, ev2 = ev2, ev1
+	}
+	return []complex128{ev1, ev2}
+}
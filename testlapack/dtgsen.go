@@ -0,0 +1,189 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtgsener interface {
+	Dtgsen(ijob int, wantq, wantz bool, selected []bool, n int, a []float64, lda int, b []float64, ldb int, alphar, alphai, beta []float64, q []float64, ldq int, z []float64, ldz int, work []float64, lwork int, iwork []int, liwork int) (m int, pl, pr, difu, difl float64, ok bool)
+}
+
+func DtgsenTest(t *testing.T, impl Dtgsener) {
+	rnd := rand.New(rand.NewSource(1))
+	for ijob := 0; ijob <= 5; ijob++ {
+		for _, wantq := range []bool{false, true} {
+			for _, wantz := range []bool{false, true} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18} {
+					for _, extra := range []int{0, 11} {
+						for cas := 0; cas < 5; cas++ {
+							testDtgsen(t, impl, ijob, wantq, wantz, n, extra, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtgsen(t *testing.T, impl Dtgsener, ijob int, wantq, wantz bool, n, extra int, rnd *rand.Rand) {
+	const (
+		tol = 1e-13
+		// pTol allows for roundoff in the computation of pl and pr which
+		// can make them exceed 1 slightly.
+		pTol = 1e-14
+	)
+
+	a, b := randomGeneralizedSchur(n, n+extra, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	q1 := randomOrthogonal(n, rnd)
+	z1 := randomOrthogonal(n, rnd)
+	q := blas64.General{Stride: 1}
+	if wantq {
+		q = nanGeneral(n, n, n+extra)
+		copyGeneral(q, q1)
+	}
+	z := blas64.General{Stride: 1}
+	if wantz {
+		z = nanGeneral(n, n, n+extra)
+		copyGeneral(z, z1)
+	}
+
+	// Select random eigenvalues and collect the eigenvalues of (A,B) into
+	// the selected and the unselected set.
+	selected := make([]bool, n)
+	for i := range selected {
+		selected[i] = rnd.Float64() < 0.5
+	}
+	var evSel, evRest []complex128
+	for j := 0; j < n; j++ {
+		ev := generalizedBlockEigenvalues(a, b, j)
+		sel := selected[j]
+		if len(ev) == 2 {
+			sel = sel || selected[j+1]
+			j++
+		}
+		if sel {
+			evSel = append(evSel, ev...)
+		} else {
+			evRest = append(evRest, ev...)
+		}
+	}
+	mWant := len(evSel)
+
+	prefix := fmt.Sprintf("Case ijob=%v, wantq=%v, wantz=%v, n=%v, extra=%v, m=%v",
+		ijob, wantq, wantz, n, extra, mWant)
+
+	// Query the minimum workspace sizes for the given selection.
+	work := []float64{0}
+	iwork := []int{0}
+	impl.Dtgsen(ijob, wantq, wantz, selected, n, a.Data, a.Stride, b.Data, b.Stride, nil, nil, nil,
+		q.Data, q.Stride, z.Data, z.Stride, work, -1, iwork, -1)
+	lwork := int(work[0])
+	liwork := iwork[0]
+	work = nanSlice(lwork)
+	iwork = make([]int, liwork)
+
+	alphar := nanSlice(n)
+	alphai := nanSlice(n)
+	beta := nanSlice(n)
+	m, pl, pr, difu, difl, ok := impl.Dtgsen(ijob, wantq, wantz, selected, n, a.Data, a.Stride, b.Data, b.Stride,
+		alphar, alphai, beta, q.Data, q.Stride, z.Data, z.Stride, work, lwork, iwork, liwork)
+
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m, want %v, got %v", prefix, mWant, m)
+	}
+	if !ok {
+		// Eigenvalues too close to swap are extremely unlikely for
+		// random matrices.
+		t.Errorf("%v: unexpected failure of reordering", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !generalOutsideAllNaN(a) {
+		t.Errorf("%v: out-of-range write to A", prefix)
+	}
+	if !generalOutsideAllNaN(b) {
+		t.Errorf("%v: out-of-range write to B", prefix)
+	}
+	if wantq && !generalOutsideAllNaN(q) {
+		t.Errorf("%v: out-of-range write to Q", prefix)
+	}
+	if wantz && !generalOutsideAllNaN(z) {
+		t.Errorf("%v: out-of-range write to Z", prefix)
+	}
+	if !isGeneralizedSchur(a, b) {
+		t.Errorf("%v: (A,B) is not in generalized Schur canonical form", prefix)
+	}
+
+	// Check that alphar, alphai and beta agree with the diagonal blocks of
+	// (A,B) and that the selected eigenvalues have been moved to the top
+	// left.
+	for j := 0; j < n; j++ {
+		ev := generalizedBlockEigenvalues(a, b, j)
+		if len(ev) == 1 && b.Data[j*b.Stride+j] < 0 {
+			t.Errorf("%v: B[%v,%v] is negative", prefix, j, j)
+		}
+		for k, v := range ev {
+			got := complex(alphar[j+k], alphai[j+k]) / complex(beta[j+k], 0)
+			if cmplx.Abs(got-v) > tol*math.Max(1, cmplx.Abs(v)) {
+				t.Errorf("%v: eigenvalue %v does not match the diagonal of (A,B), want %v, got %v",
+					prefix, j+k, v, got)
+			}
+			want := evRest
+			if j < m {
+				want = evSel
+			}
+			found, _ := containsComplex(want, v, tol*math.Max(1, cmplx.Abs(v)))
+			if !found {
+				t.Errorf("%v: unexpected eigenvalue %v at position %v", prefix, v, j+k)
+			}
+		}
+		j += len(ev) - 1
+	}
+
+	if wantq && !isOrthonormal(q) {
+		t.Errorf("%v: Q is not orthogonal", prefix)
+	}
+	if wantz && !isOrthonormal(z) {
+		t.Errorf("%v: Z is not orthogonal", prefix)
+	}
+	if wantq && wantz {
+		if resid := qzResidual(aCopy, q1, z1, q, z, a); resid > tol*float64(n) {
+			t.Errorf("%v: Q^T*A*Z differs from reordered A, resid=%v", prefix, resid)
+		}
+		if resid := qzResidual(bCopy, q1, z1, q, z, b); resid > tol*float64(n) {
+			t.Errorf("%v: Q^T*B*Z differs from reordered B, resid=%v", prefix, resid)
+		}
+	}
+
+	// Check the condition numbers.
+	wantp := ijob == 1 || ijob >= 4
+	wantd := ijob >= 2
+	switch {
+	case !wantp && (pl != 0 || pr != 0):
+		t.Errorf("%v: unexpected nonzero pl=%v, pr=%v", prefix, pl, pr)
+	case wantp && (m == 0 || m == n) && (pl != 1 || pr != 1):
+		t.Errorf("%v: unexpected pl=%v, pr=%v for trivial cluster, want 1", prefix, pl, pr)
+	case wantp && (pl <= 0 || 1+pTol < pl || pr <= 0 || 1+pTol < pr):
+		t.Errorf("%v: pl or pr out of range (0,1], got pl=%v, pr=%v", prefix, pl, pr)
+	}
+	switch {
+	case !wantd && (difu != 0 || difl != 0):
+		t.Errorf("%v: unexpected nonzero difu=%v, difl=%v", prefix, difu, difl)
+	case wantd && (difu <= 0 || difl <= 0 || math.IsInf(difu, 0) || math.IsInf(difl, 0)):
+		t.Errorf("%v: difu or difl not positive and finite, got difu=%v, difl=%v", prefix, difu, difl)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dtgsnaer interface {
+	Dtgevcer
+	Dtgsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, a []float64, lda int, b []float64, ldb int, vl []float64, ldvl int, vr []float64, ldvr int, s, dif []float64, mm int, work []float64, lwork int, iwork []int) (m int)
+}
+
+func DtgsnaTest(t *testing.T, impl Dtgsnaer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.CondJob{lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18} {
+			for _, extra := range []int{0, 11} {
+				for cas := 0; cas < 5; cas++ {
+					testDtgsna(t, impl, job, n, extra, false, rnd)
+					testDtgsna(t, impl, job, n, extra, true, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDtgsna(t *testing.T, impl Dtgsnaer, job lapack.CondJob, n, extra int, diagonal bool, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	var a, b blas64.General
+	if diagonal {
+		// Generate a diagonal matrix pair for which the condition numbers
+		// can be computed directly.
+		a = zeros(n, n, n+extra)
+		b = zeros(n, n, n+extra)
+		for i := 0; i < n; i++ {
+			a.Data[i*a.Stride+i] = rnd.NormFloat64()
+			b.Data[i*b.Stride+i] = 0.5 + rnd.Float64()
+		}
+	} else {
+		a, b = randomGeneralizedSchur(n, n+extra, rnd)
+	}
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+
+	wants := job == lapack.CondEigenvalues || job == lapack.CondBoth
+	wantdf := job == lapack.CondSubspace || job == lapack.CondBoth
+
+	// Compute all left and right eigenvectors.
+	vl := nanGeneral(n, n, n+extra)
+	vr := nanGeneral(n, n, n+extra)
+	if n > 0 {
+		_, ok := impl.Dtgevc(lapack.RightLeftEV, lapack.AllEV, nil, n, a.Data, a.Stride, b.Data, b.Stride,
+			vl.Data, vl.Stride, vr.Data, vr.Stride, n, make([]float64, 6*n))
+		if !ok {
+			t.Fatalf("Dtgevc failed")
+		}
+	}
+
+	prefix := fmt.Sprintf("Case job=%v, n=%v, extra=%v, diagonal=%v", string(job), n, extra, diagonal)
+
+	work := []float64{0}
+	impl.Dtgsna(job, lapack.AllEV, nil, n, a.Data, a.Stride, b.Data, b.Stride,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, nil, nil, n, work, -1, nil)
+	lwork := int(work[0])
+	work = nanSlice(lwork)
+	iwork := make([]int, n+2)
+
+	s := nanSlice(n)
+	dif := nanSlice(n)
+	m := impl.Dtgsna(job, lapack.AllEV, nil, n, a.Data, a.Stride, b.Data, b.Stride,
+		vl.Data, vl.Stride, vr.Data, vr.Stride, s, dif, n, work, lwork, iwork)
+
+	if m != n {
+		t.Errorf("%v: unexpected value of m, want %v, got %v", prefix, n, m)
+	}
+	if !equalApproxGeneral(a, aCopy, 0) || !equalApproxGeneral(b, bCopy, 0) {
+		t.Errorf("%v: unexpected modification of (A,B)", prefix)
+	}
+	for k := 0; k < n; k++ {
+		if wants && (s[k] <= 0 || math.IsInf(s[k], 0) || math.IsNaN(s[k])) {
+			t.Errorf("%v: s[%v] not positive and finite, got %v", prefix, k, s[k])
+		}
+		if wantdf && (dif[k] <= 0 || math.IsInf(dif[k], 0) || math.IsNaN(dif[k])) {
+			t.Errorf("%v: dif[%v] not positive and finite, got %v", prefix, k, dif[k])
+		}
+	}
+
+	if diagonal {
+		for k := 0; k < n; k++ {
+			akk := a.Data[k*a.Stride+k]
+			bkk := b.Data[k*b.Stride+k]
+			if wants {
+				want := math.Hypot(akk, bkk)
+				if math.Abs(s[k]-want) > tol*want {
+					t.Errorf("%v: unexpected s[%v], want %v, got %v", prefix, k, want, s[k])
+				}
+			}
+			if wantdf && n > 1 {
+				// The separation of the k-th eigenvalue from the
+				// rest is the smallest of the smallest singular
+				// values of the 2×2 matrices
+				//  [ a_jj  -a_kk ]
+				//  [ b_jj  -b_kk ],
+				// and dif[k] must be an upper bound on it.
+				difWant := math.Inf(1)
+				for j := 0; j < n; j++ {
+					if j == k {
+						continue
+					}
+					ajj := a.Data[j*a.Stride+j]
+					bjj := b.Data[j*b.Stride+j]
+					difWant = math.Min(difWant, sigmaMin2x2(ajj, -akk, bjj, -bkk))
+				}
+				if dif[k] < difWant*(1-tol) || 2*float64(n)*difWant < dif[k] {
+					t.Errorf("%v: unexpected dif[%v], want about %v, got %v", prefix, k, difWant, dif[k])
+				}
+			}
+		}
+	}
+
+	if n == 0 {
+		return
+	}
+
+	// Check that computing the condition numbers for a selection of
+	// eigenpairs gives the same results.
+	selected := make([]bool, n)
+	for i := range selected {
+		selected[i] = rnd.Float64() < 0.5
+	}
+	var cols []int
+	for j := 0; j < n; j++ {
+		size, _ := schurBlockSize(a, j)
+		sel := selected[j]
+		if size == 2 {
+			sel = sel || selected[j+1]
+		}
+		if sel {
+			cols = append(cols, j)
+			if size == 2 {
+				cols = append(cols, j+1)
+			}
+		}
+		j += size - 1
+	}
+	mWant := len(cols)
+	vlSel := nanGeneral(n, max(1, mWant), n+extra)
+	vrSel := nanGeneral(n, max(1, mWant), n+extra)
+	for i := 0; i < n; i++ {
+		for k, j := range cols {
+			vlSel.Data[i*vlSel.Stride+k] = vl.Data[i*vl.Stride+j]
+			vrSel.Data[i*vrSel.Stride+k] = vr.Data[i*vr.Stride+j]
+		}
+	}
+	sSel := nanSlice(mWant)
+	difSel := nanSlice(mWant)
+	m = impl.Dtgsna(job, lapack.SelectedEV, selected, n, a.Data, a.Stride, b.Data, b.Stride,
+		vlSel.Data, vlSel.Stride, vrSel.Data, vrSel.Stride, sSel, difSel, mWant, work, lwork, iwork)
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m for selected eigenpairs, want %v, got %v", prefix, mWant, m)
+		return
+	}
+	for k, j := range cols {
+		if wants && math.Abs(sSel[k]-s[j]) > tol*math.Max(1, s[j]) {
+			t.Errorf("%v: unexpected s for selected eigenpair %v, want %v, got %v", prefix, j, s[j], sSel[k])
+		}
+		if wantdf && math.Abs(difSel[k]-dif[j]) > tol*math.Max(1, dif[j]) {
+			t.Errorf("%v: unexpected dif for selected eigenpair %v, want %v, got %v", prefix, j, dif[j], difSel[k])
+		}
+	}
+}
+
+// sigmaMin2x2 returns the smallest singular value of the 2×2 matrix
+//  [ a b ]
+//  [ c d ].
+func sigmaMin2x2(a, b, c, d float64) float64 {
+	// The singular values are the square roots of the eigenvalues of
+	// M^T*M whose trace is the squared Frobenius norm of M and whose
+	// determinant is the squared determinant of M.
+	fro2 := a*a + b*b + c*c + d*d
+	det := math.Abs(a*d - b*c)
+	smax := math.Sqrt((fro2 + math.Sqrt(math.Max(0, fro2*fro2-4*det*det))) / 2)
+	if smax == 0 {
+		return 0
+	}
+	return det / smax
+}
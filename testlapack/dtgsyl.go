@@ -0,0 +1,129 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtgsyler interface {
+	Dtgsyl(trans blas.Transpose, ijob, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, d []float64, ldd int, e []float64, lde int, f []float64, ldf int, work []float64, lwork int, iwork []int) (scale, dif float64, ok bool)
+}
+
+func DtgsylTest(t *testing.T, impl Dtgsyler) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trans := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		ijobs := []int{0}
+		if trans == blas.NoTrans {
+			ijobs = []int{0, 1, 2, 3, 4}
+		}
+		for _, ijob := range ijobs {
+			for _, m := range []int{0, 1, 2, 3, 4, 5, 10} {
+				for _, n := range []int{0, 1, 2, 3, 4, 5, 10} {
+					for _, extra := range []int{0, 3} {
+						for cas := 0; cas < 3; cas++ {
+							testDtgsyl(t, impl, trans, ijob, m, n, extra, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtgsyl(t *testing.T, impl Dtgsyler, trans blas.Transpose, ijob, m, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	a, d := randomGeneralizedSchur(m, max(1, m)+extra, rnd)
+	b, e := randomGeneralizedSchur(n, max(1, n)+extra, rnd)
+	c := randomGeneral(m, n, max(1, n)+extra, rnd)
+	f := randomGeneral(m, n, max(1, n)+extra, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	cCopy := cloneGeneral(c)
+	dCopy := cloneGeneral(d)
+	eCopy := cloneGeneral(e)
+	fCopy := cloneGeneral(f)
+
+	iwork := make([]int, m+n+2)
+	work := make([]float64, 1)
+	impl.Dtgsyl(trans, ijob, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride,
+		d.Data, d.Stride, e.Data, e.Stride, f.Data, f.Stride, work, -1, iwork)
+	work = nanSlice(int(work[0]))
+
+	scale, dif, ok := impl.Dtgsyl(trans, ijob, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride,
+		d.Data, d.Stride, e.Data, e.Stride, f.Data, f.Stride, work, len(work), iwork)
+
+	prefix := fmt.Sprintf("Case trans=%v, ijob=%v, m=%v, n=%v, extra=%v", trans, ijob, m, n, extra)
+
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v: out-of-range write to C", prefix)
+	}
+	if !generalOutsideAllNaN(f) {
+		t.Errorf("%v: out-of-range write to F", prefix)
+	}
+	if !equalApproxGeneral(a, aCopy, 0) || !equalApproxGeneral(d, dCopy, 0) {
+		t.Errorf("%v: unexpected modification of (A,D)", prefix)
+	}
+	if !equalApproxGeneral(b, bCopy, 0) || !equalApproxGeneral(e, eCopy, 0) {
+		t.Errorf("%v: unexpected modification of (B,E)", prefix)
+	}
+	if !ok {
+		t.Logf("%v: Dtgsyl returned ok=false", prefix)
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	if ijob > 0 {
+		if dif <= 0 || math.IsInf(dif, 0) || math.IsNaN(dif) {
+			t.Errorf("%v: unexpected value of dif, got %v", prefix, dif)
+		}
+		if ijob > 2 {
+			return
+		}
+	}
+
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: scale out of range (0,1], got %v", prefix, scale)
+	}
+
+	// Compute the residuals of both equations and check that they are small
+	// relative to the norms of the terms.
+	r, l := c, f
+	res1 := cloneGeneral(cCopy)
+	res2 := cloneGeneral(fCopy)
+	if trans == blas.NoTrans {
+		//  A * R - L * B - scale * C
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, aCopy, r, -scale, res1)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, l, bCopy, 1, res1)
+		//  D * R - L * E - scale * F
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, dCopy, r, -scale, res2)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, -1, l, eCopy, 1, res2)
+	} else {
+		//  A^T * R + D^T * L - scale * C
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, aCopy, r, -scale, res1)
+		blas64.Gemm(blas.Trans, blas.NoTrans, 1, dCopy, l, 1, res1)
+		//  R * B^T + L * E^T + scale * F
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, r, bCopy, scale, res2)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, l, eCopy, 1, res2)
+	}
+	xnorm := math.Max(maxAbsGeneral(r), maxAbsGeneral(l))
+	anorm := float64(m) * math.Max(maxAbsGeneral(aCopy), maxAbsGeneral(dCopy))
+	bnorm := float64(n) * math.Max(maxAbsGeneral(bCopy), maxAbsGeneral(eCopy))
+	denom := math.Max(1, (anorm+bnorm)*xnorm+scale*math.Max(maxAbsGeneral(cCopy), maxAbsGeneral(fCopy)))
+	if resid := maxAbsGeneral(res1) / denom; resid > tol {
+		t.Errorf("%v: unexpected residual of the first equation, resid=%v", prefix, resid)
+	}
+	if resid := maxAbsGeneral(res2) / denom; resid > tol {
+		t.Errorf("%v: unexpected residual of the second equation, resid=%v", prefix, resid)
+	}
+}
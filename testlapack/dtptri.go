@@ -0,0 +1,99 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtptrier interface {
+	Dtptri(uplo blas.Uplo, diag blas.Diag, n int, ap []float64) (ok bool)
+}
+
+func DtptriTest(t *testing.T, impl Dtptrier) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, diag := range []blas.Diag{blas.NonUnit, blas.Unit} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25, 50} {
+				testDtptri(t, impl, uplo, diag, n, rnd)
+			}
+		}
+	}
+}
+
+func testDtptri(t *testing.T, impl Dtptrier, uplo blas.Uplo, diag blas.Diag, n int, rnd *rand.Rand) {
+	const tol = 1e-12
+
+	errStr := fmt.Sprintf("uplo = %v, diag = %v, n = %v", uplo, diag, n)
+
+	// Generate a random well-conditioned triangular matrix. If the matrix
+	// has a unit diagonal, the diagonal elements are set to NaN to check that
+	// they are not referenced.
+	ap := make([]float64, n*(n+1)/2)
+	for i := range ap {
+		ap[i] = rnd.Float64() - 0.5
+	}
+	for i := 0; i < n; i++ {
+		ii := packedIndex(uplo, n, i, i)
+		if diag == blas.Unit {
+			ap[ii] = math.NaN()
+		} else {
+			ap[ii] = 1 + rnd.Float64()
+			if rnd.Intn(2) == 0 {
+				ap[ii] *= -1
+			}
+		}
+	}
+	a := triPackedToGeneral(uplo, n, ap)
+	if diag == blas.Unit {
+		for i := 0; i < n; i++ {
+			a.Data[i*a.Stride+i] = 1
+		}
+	}
+	apCopy := make([]float64, len(ap))
+	copy(apCopy, ap)
+
+	ok := impl.Dtptri(uplo, diag, n, ap)
+	if !ok {
+		t.Errorf("Unexpected singular matrix: %s", errStr)
+		return
+	}
+	if n == 0 {
+		return
+	}
+
+	ainv := triPackedToGeneral(uplo, n, ap)
+	if diag == blas.Unit {
+		for i := 0; i < n; i++ {
+			if !math.IsNaN(ainv.Data[i*ainv.Stride+i]) {
+				t.Errorf("Diagonal element modified for unit triangular matrix: %s", errStr)
+				break
+			}
+			ainv.Data[i*ainv.Stride+i] = 1
+		}
+	}
+
+	// Check that A * inv(A) = I.
+	ai := zeros(n, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, a, ainv, 0, ai)
+	if !equalApproxGeneral(ai, eye(n, n), tol) {
+		t.Errorf("A * inv(A) != I: %s", errStr)
+	}
+
+	// Check that a singular matrix is detected.
+	if diag == blas.NonUnit {
+		i := rnd.Intn(n)
+		apCopy[packedIndex(uplo, n, i, i)] = 0
+		if impl.Dtptri(uplo, diag, n, apCopy) {
+			t.Errorf("Singular matrix not detected: %s", errStr)
+		}
+	}
+}
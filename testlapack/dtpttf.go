@@ -0,0 +1,52 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/floats"
+)
+
+type Dtpttfer interface {
+	Dtpttf(transr blas.Transpose, uplo blas.Uplo, n int, ap, arf []float64)
+	Dtrttfer
+}
+
+func DtpttfTest(t *testing.T, impl Dtpttfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+				testDtpttf(t, impl, transr, uplo, n, rnd)
+			}
+		}
+	}
+}
+
+func testDtpttf(t *testing.T, impl Dtpttfer, transr blas.Transpose, uplo blas.Uplo, n int, rnd *rand.Rand) {
+	errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v", transr, uplo, n)
+
+	nt := n * (n + 1) / 2
+	ap := randomSlice(nt, rnd)
+	apCopy := make([]float64, nt)
+	copy(apCopy, ap)
+	a := triPackedToGeneral(uplo, n, ap)
+
+	want := nanSlice(nt)
+	impl.Dtrttf(transr, uplo, n, a.Data, a.Stride, want)
+	got := nanSlice(nt)
+	impl.Dtpttf(transr, uplo, n, ap, got)
+
+	if !floats.Same(ap, apCopy) {
+		t.Errorf("ap modified: %s", errStr)
+	}
+	if !floats.Same(got, want) {
+		t.Errorf("Unexpected RFP array: %s", errStr)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dtrsener interface {
+	Dtrsen(job lapack.CondJob, compq lapack.EVComp, selected []bool, n int, t []float64, ldt int, q []float64, ldq int, wr, wi, work []float64, lwork int, iwork []int, liwork int) (m int, s, sep float64, ok bool)
+}
+
+func DtrsenTest(t *testing.T, impl Dtrsener) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.CondJob{lapack.CondJob(lapack.None), lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+		for _, compq := range []lapack.EVComp{lapack.None, lapack.UpdateSchur} {
+			for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+				for _, extra := range []int{0, 11} {
+					for cas := 0; cas < 10; cas++ {
+						testDtrsen(t, impl, job, compq, n, extra, rnd)
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtrsen(t *testing.T, impl Dtrsener, job lapack.CondJob, compq lapack.EVComp, n, extra int, rnd *rand.Rand) {
+	const (
+		tol = 1e-13
+		// sTol allows for roundoff in the computation of s which
+		// can make it exceed 1 slightly.
+		sTol = 1e-14
+	)
+
+	wantq := compq == lapack.UpdateSchur
+
+	// Generate a random matrix T in Schur canonical form and its Schur
+	// vectors Q0, so that A = Q0*T*Q0^T.
+	tmat := randomSchurCanonical(n, n+extra, rnd)
+	tCopy := cloneGeneral(tmat)
+	q0 := randomOrthogonal(n, rnd)
+	q := blas64.General{Stride: 1}
+	if wantq {
+		q = nanGeneral(n, n, n+extra)
+		copyGeneral(q, q0)
+	}
+
+	// Select random eigenvalues and collect the eigenvalues of T into the
+	// selected and the unselected set.
+	selected := make([]bool, n)
+	for i := range selected {
+		selected[i] = rnd.Float64() < 0.5
+	}
+	var evSel, evRest []complex128
+	for j := 0; j < n; j++ {
+		size, _ := schurBlockSize(tmat, j)
+		var ev []complex128
+		sel := selected[j]
+		if size == 1 {
+			ev = []complex128{complex(tmat.Data[j*tmat.Stride+j], 0)}
+		} else {
+			a, b, c, d := extract2x2Block(tmat.Data[j*tmat.Stride+j:], tmat.Stride)
+			ev1, ev2 := schurBlockEigenvalues(a, b, c, d)
+			ev = []complex128{ev1, ev2}
+			sel = sel || selected[j+1]
+			j++
+		}
+		if sel {
+			evSel = append(evSel, ev...)
+		} else {
+			evRest = append(evRest, ev...)
+		}
+	}
+	mWant := len(evSel)
+	selectedCopy := make([]bool, n)
+	copy(selectedCopy, selected)
+
+	prefix := fmt.Sprintf("Case job=%v, compq=%v, n=%v, extra=%v, m=%v",
+		string(job), string(compq), n, extra, mWant)
+
+	// Query the minimum workspace sizes for the given selection.
+	work := []float64{0}
+	iwork := []int{0}
+	impl.Dtrsen(job, compq, selected, n, tmat.Data, tmat.Stride, q.Data, q.Stride, nil, nil, work, -1, iwork, -1)
+	lwork := int(work[0])
+	liwork := iwork[0]
+	work = nanSlice(lwork)
+	iwork = make([]int, liwork)
+
+	wr := nanSlice(n)
+	wi := nanSlice(n)
+	m, s, sep, ok := impl.Dtrsen(job, compq, selected, n, tmat.Data, tmat.Stride, q.Data, q.Stride,
+		wr, wi, work, lwork, iwork, liwork)
+
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m, want %v, got %v", prefix, mWant, m)
+	}
+	for i := range selected {
+		if selected[i] != selectedCopy[i] {
+			t.Errorf("%v: unexpected modification of selected[%v]", prefix, i)
+		}
+	}
+	if !ok {
+		// Eigenvalues too close to swap are extremely unlikely for
+		// random matrices.
+		t.Errorf("%v: unexpected failure of reordering", prefix)
+		return
+	}
+	if n == 0 {
+		return
+	}
+	if !generalOutsideAllNaN(tmat) {
+		t.Errorf("%v: out-of-range write to T", prefix)
+	}
+	if wantq && !generalOutsideAllNaN(q) {
+		t.Errorf("%v: out-of-range write to Q", prefix)
+	}
+	if !isSchurCanonicalGeneral(tmat) {
+		t.Errorf("%v: T is not in Schur canonical form", prefix)
+	}
+	for i := 2; i < n; i++ {
+		for j := 0; j < i-1; j++ {
+			if tmat.Data[i*tmat.Stride+j] != 0 {
+				t.Errorf("%v: T is not quasi-triangular at [%v,%v]", prefix, i, j)
+			}
+		}
+	}
+
+	// Check that wr and wi agree with the diagonal blocks of T and that
+	// the selected eigenvalues have been moved to the top left.
+	for j := 0; j < n; j++ {
+		size, _ := schurBlockSize(tmat, j)
+		var ev []complex128
+		if size == 1 {
+			ev = []complex128{complex(tmat.Data[j*tmat.Stride+j], 0)}
+		} else {
+			a, b, c, d := extract2x2Block(tmat.Data[j*tmat.Stride+j:], tmat.Stride)
+			ev1, ev2 := schurBlockEigenvalues(a, b, c, d)
+			ev = []complex128{ev1, ev2}
+		}
+		for k, v := range ev {
+			if cmplx.Abs(complex(wr[j+k], wi[j+k])-v) > tol {
+				t.Errorf("%v: eigenvalue %v does not match the diagonal of T, want %v, got %v",
+					prefix, j+k, v, complex(wr[j+k], wi[j+k]))
+			}
+			want := evRest
+			if j < m {
+				want = evSel
+			}
+			found, _ := containsComplex(want, v, tol*math.Max(1, cmplx.Abs(v)))
+			if !found {
+				t.Errorf("%v: unexpected eigenvalue %v at position %v", prefix, v, j+k)
+			}
+		}
+		j += size - 1
+	}
+
+	if wantq {
+		if !isOrthonormal(q) {
+			t.Errorf("%v: Q is not orthogonal", prefix)
+		}
+		// Check that Q^T*A*Q == T where A = Q0*T0*Q0^T.
+		a := zeros(n, n, n)
+		tmp := zeros(n, n, n)
+		blas64.Gemm(blas.NoTrans, blas.Trans, 1, tCopy, q0, 0, tmp)
+		blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, q0, tmp, 0, a)
+		if resid := qzResidual(a, eye(n, n), eye(n, n), q, q, tmat); resid > tol*float64(n) {
+			t.Errorf("%v: Q^T*A*Q differs from T, resid=%v", prefix, resid)
+		}
+	}
+
+	// Check the condition numbers.
+	wantse := job == lapack.CondEigenvalues || job == lapack.CondBoth
+	wantsv := job == lapack.CondSubspace || job == lapack.CondBoth
+	switch {
+	case !wantse && s != 0:
+		t.Errorf("%v: unexpected nonzero s=%v", prefix, s)
+	case wantse && (m == 0 || m == n) && s != 1:
+		t.Errorf("%v: unexpected s=%v for trivial cluster, want 1", prefix, s)
+	case wantse && (s <= 0 || 1+sTol < s):
+		t.Errorf("%v: s out of range (0,1], got %v", prefix, s)
+	}
+	switch {
+	case !wantsv && sep != 0:
+		t.Errorf("%v: unexpected nonzero sep=%v", prefix, sep)
+	case wantsv && sep <= 0:
+		t.Errorf("%v: sep not positive, got %v", prefix, sep)
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/cmplx"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas/blas64"
+	"github.com/gonum/lapack"
+)
+
+type Dtrsnaer interface {
+	Dtrsna(job lapack.CondJob, howmny lapack.HowMany, selected []bool, n int, t []float64, ldt int, vl []float64, ldvl int, vr []float64, ldvr int, s, sep []float64, mm int, work []float64, ldwork int, iwork []int) (m int)
+}
+
+func DtrsnaTest(t *testing.T, impl Dtrsnaer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, job := range []lapack.CondJob{lapack.CondEigenvalues, lapack.CondSubspace, lapack.CondBoth} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 6, 10, 18, 31} {
+			for _, extra := range []int{0, 11} {
+				for cas := 0; cas < 10; cas++ {
+					testDtrsna(t, impl, job, n, extra, rnd)
+				}
+			}
+		}
+	}
+}
+
+func testDtrsna(t *testing.T, impl Dtrsnaer, job lapack.CondJob, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	wants := job == lapack.CondEigenvalues || job == lapack.CondBoth
+	wantsp := job == lapack.CondSubspace || job == lapack.CondBoth
+
+	prefix := fmt.Sprintf("Case job=%v, n=%v, extra=%v", string(job), n, extra)
+
+	tm := randomSchurCanonical(n, n+extra, rnd)
+	tCopy := cloneGeneral(tm)
+
+	// Compute the eigenvalues of T and the left and right eigenvectors
+	// stored as they would be by Dtrevc3.
+	ev := make([]complex128, n)
+	vl := nanGeneral(n, n, n+extra)
+	vr := nanGeneral(n, n, n+extra)
+	for k := 0; k < n; k++ {
+		pair := k < n-1 && tm.Data[(k+1)*tm.Stride+k] != 0
+		x, z, lambda := quasiTriangularEigenvectors(tCopy, k)
+		ev[k] = lambda
+		for i := 0; i < n; i++ {
+			vr.Data[i*vr.Stride+k] = real(x[i])
+			vl.Data[i*vl.Stride+k] = real(z[i])
+			if pair {
+				vr.Data[i*vr.Stride+k+1] = imag(x[i])
+				vl.Data[i*vl.Stride+k+1] = -imag(z[i])
+			}
+		}
+		if pair {
+			ev[k+1] = cmplx.Conj(lambda)
+			k++
+		}
+	}
+
+	s := nanSlice(n)
+	sep := nanSlice(n)
+	ldwork := max(1, n+extra)
+	work := nanSlice(max(1, ldwork*(n+6)))
+	iwork := make([]int, max(1, 2*(n-1)))
+	m := impl.Dtrsna(job, lapack.AllEV, nil, n, tm.Data, tm.Stride, vl.Data, vl.Stride, vr.Data, vr.Stride,
+		s, sep, n, work, ldwork, iwork)
+
+	if m != n {
+		t.Errorf("%v: unexpected value of m, want %v, got %v", prefix, n, m)
+	}
+	if !equalApproxGeneral(tm, tCopy, 0) {
+		t.Errorf("%v: unexpected modification of T", prefix)
+	}
+	if !wantsp && !isAllNaN(sep) {
+		t.Errorf("%v: unexpected modification of sep", prefix)
+	}
+	if !wants && !isAllNaN(s) {
+		t.Errorf("%v: unexpected modification of s", prefix)
+	}
+
+	for k := 0; k < n; k++ {
+		pair := k < n-1 && tm.Data[(k+1)*tm.Stride+k] != 0
+		if wants {
+			// Compare s[k] with |z^T*x| / (|z|*|x|) where x and z are
+			// the complex right and left eigenvectors.
+			x, z, _ := quasiTriangularEigenvectors(tCopy, k)
+			var prod complex128
+			var xnorm, znorm float64
+			for i := 0; i < n; i++ {
+				prod += z[i] * x[i]
+				xnorm = math.Hypot(xnorm, cmplx.Abs(x[i]))
+				znorm = math.Hypot(znorm, cmplx.Abs(z[i]))
+			}
+			want := cmplx.Abs(prod) / (xnorm * znorm)
+			if math.Abs(s[k]-want) > tol*want {
+				t.Errorf("%v: unexpected value of s[%v], want %v, got %v", prefix, k, want, s[k])
+			}
+			if pair && s[k+1] != s[k] {
+				t.Errorf("%v: s[%v] and s[%v] of a complex pair differ", prefix, k, k+1)
+			}
+		}
+		if wantsp {
+			if n == 1 {
+				if sep[0] != math.Abs(tCopy.Data[0]) {
+					t.Errorf("%v: unexpected value of sep[0], want %v, got %v", prefix, math.Abs(tCopy.Data[0]), sep[0])
+				}
+			} else {
+				// sep[k] is an estimate of the smallest singular
+				// value of T22 - λ_k*I where T22 has the
+				// remaining eigenvalues of T, so it must be
+				// positive and not much larger than the distance
+				// from λ_k to the nearest one of them.
+				dist := math.Inf(1)
+				for i, mu := range ev {
+					if i == k || (pair && i == k+1) {
+						continue
+					}
+					dist = math.Min(dist, cmplx.Abs(mu-ev[k]))
+				}
+				if pair {
+					dist = math.Min(dist, 2*math.Abs(imag(ev[k])))
+				}
+				if sep[k] <= 0 || sep[k] > float64(n)*dist*(1+tol) {
+					t.Errorf("%v: unexpected value of sep[%v]=%v, distance to the nearest eigenvalue=%v", prefix, k, sep[k], dist)
+				}
+			}
+			if pair && sep[k+1] != sep[k] {
+				t.Errorf("%v: sep[%v] and sep[%v] of a complex pair differ", prefix, k, k+1)
+			}
+		}
+		if pair {
+			k++
+		}
+	}
+
+	// Check that computing the condition numbers for selected eigenpairs
+	// gives the same values.
+	selected := make([]bool, n)
+	var mWant int
+	for k := 0; k < n; k++ {
+		selected[k] = rnd.Float64() < 0.5
+		pair := k < n-1 && tm.Data[(k+1)*tm.Stride+k] != 0
+		if pair {
+			selected[k+1] = rnd.Float64() < 0.5
+			if selected[k] || selected[k+1] {
+				mWant += 2
+			}
+			k++
+			continue
+		}
+		if selected[k] {
+			mWant++
+		}
+	}
+	vlSel := nanGeneral(n, mWant, max(1, mWant))
+	vrSel := nanGeneral(n, mWant, max(1, mWant))
+	sWant := make([]float64, 0, mWant)
+	sepWant := make([]float64, 0, mWant)
+	var ks int
+	for k := 0; k < n; k++ {
+		nb := 1
+		if k < n-1 && tm.Data[(k+1)*tm.Stride+k] != 0 {
+			nb = 2
+		}
+		if selected[k] || (nb == 2 && selected[k+1]) {
+			for j := k; j < k+nb; j++ {
+				for i := 0; i < n; i++ {
+					vlSel.Data[i*vlSel.Stride+ks] = vl.Data[i*vl.Stride+j]
+					vrSel.Data[i*vrSel.Stride+ks] = vr.Data[i*vr.Stride+j]
+				}
+				sWant = append(sWant, s[j])
+				sepWant = append(sepWant, sep[j])
+				ks++
+			}
+		}
+		k += nb - 1
+	}
+	sSel := nanSlice(mWant)
+	sepSel := nanSlice(mWant)
+	m = impl.Dtrsna(job, lapack.SelectedEV, selected, n, tm.Data, tm.Stride, vlSel.Data, vlSel.Stride, vrSel.Data, vrSel.Stride,
+		sSel, sepSel, mWant, work, ldwork, iwork)
+	if m != mWant {
+		t.Errorf("%v: unexpected value of m for selected eigenpairs, want %v, got %v", prefix, mWant, m)
+	}
+	for i := 0; i < mWant; i++ {
+		if wants && math.Abs(sSel[i]-sWant[i]) > tol*sWant[i] {
+			t.Errorf("%v: unexpected value of s[%v] for selected eigenpairs, want %v, got %v", prefix, i, sWant[i], sSel[i])
+		}
+		if wantsp && math.Abs(sepSel[i]-sepWant[i]) > tol*sepWant[i] {
+			t.Errorf("%v: unexpected value of sep[%v] for selected eigenpairs, want %v, got %v", prefix, i, sepWant[i], sepSel[i])
+		}
+	}
+}
+
+// quasiTriangularEigenvectors returns the right and left eigenvectors x and z
+// of the n×n matrix T in Schur canonical form that correspond to the
+// eigenvalue lambda of the diagonal block starting at T[k,k]. If the block is
+// 2×2, lambda is the eigenvalue with positive imaginary part. The vectors
+// satisfy
+//  T*x = lambda*x,  T^T*z = lambda*z,
+// so that the left eigenvector u with u^H*T = lambda*u^H is conj(z).
+func quasiTriangularEigenvectors(tm blas64.General, k int) (x, z []complex128, lambda complex128) {
+	n := tm.Rows
+	at := func(i, j int) complex128 { return complex(tm.Data[i*tm.Stride+j], 0) }
+	blockSize := func(i int) int {
+		if i < n-1 && tm.Data[(i+1)*tm.Stride+i] != 0 {
+			return 2
+		}
+		return 1
+	}
+	// solve2 solves the 2×2 complex system [a b; c d]*[u v]^T = [e f]^T.
+	solve2 := func(a, b, c, d, e, f complex128) (u, v complex128) {
+		det := a*d - b*c
+		return (e*d - b*f) / det, (a*f - c*e) / det
+	}
+
+	x = make([]complex128, n)
+	z = make([]complex128, n)
+	kb := blockSize(k)
+	if kb == 1 {
+		lambda = at(k, k)
+		x[k] = 1
+		z[k] = 1
+	} else {
+		a, b, c := at(k, k), at(k, k+1), at(k+1, k)
+		lambda = a + complex(0, math.Sqrt(math.Abs(real(b)))*math.Sqrt(math.Abs(real(c))))
+		x[k] = b
+		x[k+1] = lambda - a
+		z[k] = c
+		z[k+1] = lambda - a
+	}
+
+	// Back substitution for the right eigenvector.
+	for i := k - 1; i >= 0; i-- {
+		i1 := i
+		if i > 0 && tm.Data[i*tm.Stride+i-1] != 0 {
+			i1 = i - 1
+		}
+		rhs := func(r int) complex128 {
+			var sum complex128
+			for j := i + 1; j < k+kb; j++ {
+				sum -= at(r, j) * x[j]
+			}
+			return sum
+		}
+		if i1 == i {
+			x[i] = rhs(i) / (at(i, i) - lambda)
+			continue
+		}
+		x[i1], x[i] = solve2(at(i1, i1)-lambda, at(i1, i), at(i, i1), at(i, i)-lambda, rhs(i1), rhs(i))
+		i = i1
+	}
+
+	// Forward substitution for the left eigenvector.
+	for i := k + kb; i < n; i++ {
+		i2 := i + blockSize(i) - 1
+		rhs := func(r int) complex128 {
+			var sum complex128
+			for j := k; j < i; j++ {
+				sum -= at(j, r) * z[j]
+			}
+			return sum
+		}
+		if i2 == i {
+			z[i] = rhs(i) / (at(i, i) - lambda)
+			continue
+		}
+		z[i], z[i2] = solve2(at(i, i)-lambda, at(i2, i), at(i, i2), at(i2, i2)-lambda, rhs(i), rhs(i2))
+		i = i2
+	}
+	return x, z, lambda
+}
@@ -0,0 +1,92 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtrsyl3er interface {
+	Dtrsyl3(trana, tranb blas.Transpose, isgn, m, n int, a []float64, lda int, b []float64, ldb int, c []float64, ldc int, iwork []int, liwork int) (scale float64, ok bool)
+}
+
+func Dtrsyl3Test(t *testing.T, impl Dtrsyl3er) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, trana := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+		for _, tranb := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			for _, isgn := range []int{1, -1} {
+				// Sizes larger than the block size exercise the
+				// blocked code.
+				for _, m := range []int{0, 1, 2, 10, 49, 101, 150} {
+					for _, n := range []int{0, 1, 2, 10, 49, 101, 150} {
+						for _, extra := range []int{0, 3} {
+							testDtrsyl3(t, impl, trana, tranb, isgn, m, n, extra, rnd)
+						}
+					}
+				}
+			}
+		}
+	}
+}
+
+func testDtrsyl3(t *testing.T, impl Dtrsyl3er, trana, tranb blas.Transpose, isgn, m, n, extra int, rnd *rand.Rand) {
+	const tol = 1e-13
+
+	a := randomSchurCanonical(m, max(1, m)+extra, rnd)
+	b := randomSchurCanonical(n, max(1, n)+extra, rnd)
+	c := randomGeneral(m, n, max(1, n)+extra, rnd)
+	aCopy := cloneGeneral(a)
+	bCopy := cloneGeneral(b)
+	cCopy := cloneGeneral(c)
+
+	iwork := []int{0}
+	impl.Dtrsyl3(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride, iwork, -1)
+	liwork := iwork[0]
+	iwork = make([]int, liwork)
+
+	scale, ok := impl.Dtrsyl3(trana, tranb, isgn, m, n, a.Data, a.Stride, b.Data, b.Stride, c.Data, c.Stride, iwork, liwork)
+
+	prefix := fmt.Sprintf("Case trana=%v, tranb=%v, isgn=%v, m=%v, n=%v, extra=%v",
+		trana, tranb, isgn, m, n, extra)
+
+	if !generalOutsideAllNaN(c) {
+		t.Errorf("%v: out-of-range write to C", prefix)
+	}
+	if !equalApproxGeneral(a, aCopy, 0) {
+		t.Errorf("%v: unexpected modification of A", prefix)
+	}
+	if !equalApproxGeneral(b, bCopy, 0) {
+		t.Errorf("%v: unexpected modification of B", prefix)
+	}
+	if scale <= 0 || 1 < scale {
+		t.Errorf("%v: scale out of range (0,1], got %v", prefix, scale)
+	}
+	if !ok {
+		t.Logf("%v: Dtrsyl3 returned ok=false", prefix)
+	}
+	if m == 0 || n == 0 {
+		return
+	}
+
+	// Compute the residual
+	//  op(A)*X + isgn*X*op(B) - scale*C
+	// and check that it is small relative to the norms of the terms.
+	x := c
+	r := cloneGeneral(cCopy)
+	blas64.Gemm(trana, blas.NoTrans, 1, aCopy, x, -scale, r)
+	blas64.Gemm(blas.NoTrans, tranb, float64(isgn), x, bCopy, 1, r)
+	rnorm := maxAbsGeneral(r)
+	xnorm := maxAbsGeneral(x)
+	denom := math.Max(1, (float64(m)*maxAbsGeneral(aCopy)+float64(n)*maxAbsGeneral(bCopy))*xnorm+scale*maxAbsGeneral(cCopy))
+	if resid := rnorm / denom; resid > tol {
+		t.Errorf("%v: unexpected residual of the Sylvester equation, resid=%v", prefix, resid)
+	}
+}
@@ -0,0 +1,160 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/gonum/blas"
+)
+
+type Dtrttfer interface {
+	Dtrttf(transr blas.Transpose, uplo blas.Uplo, n int, a []float64, lda int, arf []float64)
+}
+
+func DtrttfTest(t *testing.T, impl Dtrttfer) {
+	// Check the examples of the RFP format given in the documentation where
+	// the element A[i,j] has the value 10*i+j.
+	for _, test := range []struct {
+		uplo blas.Uplo
+		n    int
+		want []float64
+	}{
+		{
+			uplo: blas.Upper,
+			n:    5,
+			want: []float64{
+				2, 3, 4,
+				12, 13, 14,
+				22, 23, 24,
+				0, 33, 34,
+				1, 11, 44,
+			},
+		},
+		{
+			uplo: blas.Lower,
+			n:    5,
+			want: []float64{
+				0, 33, 43,
+				10, 11, 44,
+				20, 21, 22,
+				30, 31, 32,
+				40, 41, 42,
+			},
+		},
+		{
+			uplo: blas.Upper,
+			n:    6,
+			want: []float64{
+				3, 4, 5,
+				13, 14, 15,
+				23, 24, 25,
+				33, 34, 35,
+				0, 44, 45,
+				1, 11, 55,
+				2, 12, 22,
+			},
+		},
+		{
+			uplo: blas.Lower,
+			n:    6,
+			want: []float64{
+				33, 43, 53,
+				0, 44, 54,
+				10, 11, 55,
+				20, 21, 22,
+				30, 31, 32,
+				40, 41, 42,
+				50, 51, 52,
+			},
+		},
+	} {
+		n := test.n
+		a := nanTriangular(test.uplo, n, n)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if (test.uplo == blas.Upper && i <= j) || (test.uplo == blas.Lower && i >= j) {
+					a.Data[i*n+j] = float64(10*i + j)
+				}
+			}
+		}
+		rows, cols := rfpDims(blas.NoTrans, n)
+		for _, transr := range []blas.Transpose{blas.NoTrans, blas.Trans} {
+			errStr := fmt.Sprintf("transr = %v, uplo = %v, n = %v", transr, test.uplo, n)
+			arf := nanSlice(n * (n + 1) / 2)
+			impl.Dtrttf(transr, test.uplo, n, a.Data, a.Stride, arf)
+			for i := 0; i < rows; i++ {
+				for j := 0; j < cols; j++ {
+					got := arf[i*cols+j]
+					if transr == blas.Trans {
+						got = arf[j*rows+i]
+					}
+					if got != test.want[i*cols+j] {
+						t.Errorf("Unexpected RFP array: %s", errStr)
+						return
+					}
+				}
+			}
+		}
+	}
+
+	// Check that every element of the triangle is stored exactly once and
+	// that the RFP arrays for transr == blas.Trans and blas.NoTrans are
+	// transposes of each other.
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, n := range []int{0, 1, 2, 3, 4, 5, 10, 25} {
+			for _, lda := range []int{max(1, n), n + 3} {
+				testDtrttf(t, impl, uplo, n, lda)
+			}
+		}
+	}
+}
+
+func testDtrttf(t *testing.T, impl Dtrttfer, uplo blas.Uplo, n, lda int) {
+	errStr := fmt.Sprintf("uplo = %v, n = %v, lda = %v", uplo, n, lda)
+
+	// Set the elements of the triangle to distinct values and the elements
+	// outside of it to NaN.
+	a := nanTriangular(uplo, n, lda)
+	var want []float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				v := float64(i*n + j)
+				a.Data[i*lda+j] = v
+				want = append(want, v)
+			}
+		}
+	}
+
+	nt := n * (n + 1) / 2
+	arf := nanSlice(nt)
+	impl.Dtrttf(blas.NoTrans, uplo, n, a.Data, lda, arf)
+	got := make([]float64, nt)
+	copy(got, arf)
+	sort.Float64s(got)
+	sort.Float64s(want)
+	for i, v := range got {
+		if math.IsNaN(v) || v != want[i] {
+			t.Errorf("RFP array does not contain the triangle of A: %s", errStr)
+			return
+		}
+	}
+
+	arfT := nanSlice(nt)
+	impl.Dtrttf(blas.Trans, uplo, n, a.Data, lda, arfT)
+	rows, cols := rfpDims(blas.NoTrans, n)
+	for i := 0; i < rows; i++ {
+		for j := 0; j < cols; j++ {
+			if arfT[j*rows+i] != arf[i*cols+j] {
+				t.Errorf("Transposed RFP array mismatch: %s", errStr)
+				return
+			}
+		}
+	}
+}
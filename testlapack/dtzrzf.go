@@ -0,0 +1,127 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Dtzrzfer interface {
+	Dtzrzf(m, n int, a []float64, lda int, tau, work []float64, lwork int)
+}
+
+func DtzrzfTest(t *testing.T, impl Dtzrzfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{0, 0, 0},
+		{0, 5, 0},
+		{1, 1, 0},
+		{1, 5, 0},
+		{3, 3, 0},
+		{3, 8, 0},
+		{5, 6, 0},
+		{10, 30, 0},
+		{3, 8, 10},
+		{10, 30, 40},
+
+		// Large enough for the blocked code to be used.
+		{140, 200, 0},
+		{200, 300, 0},
+		{140, 200, 210},
+	} {
+		for _, wl := range []worklen{minimumWork, mediumWork, optimumWork} {
+			testDtzrzf(t, impl, test.m, test.n, test.lda, wl, rnd)
+		}
+	}
+}
+
+func testDtzrzf(t *testing.T, impl Dtzrzfer, m, n, lda int, wl worklen, rnd *rand.Rand) {
+	if lda == 0 {
+		lda = max(1, n)
+	}
+	a := blas64.General{Rows: m, Cols: n, Stride: lda, Data: randomSlice(m*lda, rnd)}
+	aCopy := cloneGeneral(a)
+	tau := nanSlice(m)
+
+	work := make([]float64, 1)
+	impl.Dtzrzf(m, n, a.Data, a.Stride, tau, work, -1)
+	lwork := max(1, m)
+	switch wl {
+	case mediumWork:
+		lwork = max(lwork, (int(work[0])+lwork)/2)
+	case optimumWork:
+		lwork = max(lwork, int(work[0]))
+	}
+	work = nanSlice(lwork)
+
+	impl.Dtzrzf(m, n, a.Data, a.Stride, tau, work, lwork)
+	errStr := fmt.Sprintf("m = %v, n = %v, lda = %v, wl = %v", m, n, lda, wl)
+
+	// Check that the strictly lower triangle of A has not been modified.
+	for i := 0; i < m; i++ {
+		for j := 0; j < i; j++ {
+			if a.Data[i*lda+j] != aCopy.Data[i*lda+j] {
+				t.Errorf("Strictly lower triangle of A modified: %s", errStr)
+				return
+			}
+		}
+	}
+	if m == 0 {
+		return
+	}
+
+	z := constructZ(m, n, n-m, a.Data, a.Stride, tau)
+	if !isOrthonormal(z) {
+		t.Errorf("Z is not orthogonal: %s", errStr)
+	}
+
+	// Check that A = [R 0] * Z.
+	r := zeros(m, n, n)
+	for i := 0; i < m; i++ {
+		for j := i; j < m; j++ {
+			r.Data[i*r.Stride+j] = a.Data[i*lda+j]
+		}
+	}
+	got := zeros(m, n, n)
+	blas64.Gemm(blas.NoTrans, blas.NoTrans, 1, r, z, 0, got)
+	for i := 0; i < m; i++ {
+		for j := 0; j < i; j++ {
+			got.Data[i*got.Stride+j] = aCopy.Data[i*lda+j]
+		}
+	}
+	if !equalApproxGeneral(got, aCopy, 1e-12) {
+		t.Errorf("A != [R 0] * Z: %s", errStr)
+	}
+}
+
+// constructZ returns the nq×nq orthogonal matrix
+//  Z = H_0 * H_1 * ... * H_{k-1}
+// from the elementary reflectors stored in the k×nq matrix A and in tau as
+// returned by Dtzrzf. The last l columns of the i-th row of A contain the
+// stored part of the vector defining H_i.
+func constructZ(k, nq, l int, a []float64, lda int, tau []float64) blas64.General {
+	z := eye(nq, nq)
+	v := blas64.Vector{Inc: 1, Data: make([]float64, nq)}
+	zv := blas64.Vector{Inc: 1, Data: make([]float64, nq)}
+	for i := 0; i < k; i++ {
+		for j := range v.Data {
+			v.Data[j] = 0
+		}
+		v.Data[i] = 1
+		copy(v.Data[nq-l:], a[i*lda+nq-l:i*lda+nq])
+
+		// Compute Z = Z * H_i = Z - tau[i] * (Z*v) * v^T.
+		blas64.Gemv(blas.NoTrans, 1, z, v, 0, zv)
+		blas64.Ger(-tau[i], zv, v, z)
+	}
+	return z
+}
@@ -967,6 +967,212 @@ func zeros(m, n, stride int) blas64.General {
 	return a
 }
 
+// randomBand returns an m×n band matrix with kl subdiagonals and ku
+// superdiagonals stored in row-major band format with stride ldab. The
+// elements of the band are random and the other elements of the returned
+// slice are NaN.
+func randomBand(m, n, kl, ku, ldab int, rnd *rand.Rand) []float64 {
+	if ldab < kl+ku+1 {
+		panic("testlapack: stride less than band width")
+	}
+	ab := nanSlice(max(0, min(m, n+kl)) * ldab)
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			ab[i*ldab+kl+j-i] = rnd.NormFloat64()
+		}
+	}
+	return ab
+}
+
+// bandToGeneral returns the dense m×n matrix equivalent to the band matrix with
+// kl subdiagonals and ku superdiagonals stored in ab in row-major band format.
+func bandToGeneral(m, n, kl, ku int, ab []float64, ldab int) blas64.General {
+	a := zeros(m, n, max(1, n))
+	for i := 0; i < min(m, n+kl); i++ {
+		for j := max(0, i-kl); j <= min(n-1, i+ku); j++ {
+			a.Data[i*a.Stride+j] = ab[i*ldab+kl+j-i]
+		}
+	}
+	return a
+}
+
+// randomSymBand returns the upper or lower triangle of a random symmetric
+// n×n band matrix with kd super- or subdiagonals stored in row-major band
+// format. Elements of ab outside of the band are set to NaN.
+func randomSymBand(uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) []float64 {
+	if ldab < kd+1 {
+		panic("testlapack: stride less than band width")
+	}
+	ab := nanSlice(n * ldab)
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				ab[i*ldab+j-i] = rnd.NormFloat64()
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				ab[i*ldab+kd+j-i] = rnd.NormFloat64()
+			}
+		}
+	}
+	return ab
+}
+
+// randomSPDBand returns the upper or lower triangle of a random symmetric
+// positive definite n×n band matrix with kd super- or subdiagonals stored in
+// row-major band format. The matrix is made strictly diagonally dominant with
+// positive diagonal. Elements of ab outside of the band are set to NaN.
+func randomSPDBand(uplo blas.Uplo, n, kd, ldab int, rnd *rand.Rand) []float64 {
+	ab := randomSymBand(uplo, n, kd, ldab, rnd)
+	a := symBandToGeneral(uplo, n, kd, ab, ldab)
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := 0; j < n; j++ {
+			if j != i {
+				sum += math.Abs(a.Data[i*a.Stride+j])
+			}
+		}
+		d := sum + 0.01 + rnd.Float64()
+		if uplo == blas.Upper {
+			ab[i*ldab] = d
+		} else {
+			ab[i*ldab+kd] = d
+		}
+	}
+	return ab
+}
+
+// symBandToGeneral returns the dense n×n symmetric matrix equivalent to the
+// symmetric band matrix with kd super- or subdiagonals whose upper or lower
+// triangle is stored in ab in row-major band format.
+func symBandToGeneral(uplo blas.Uplo, n, kd int, ab []float64, ldab int) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		if uplo == blas.Upper {
+			for j := i; j <= min(n-1, i+kd); j++ {
+				a.Data[i*a.Stride+j] = ab[i*ldab+j-i]
+				a.Data[j*a.Stride+i] = ab[i*ldab+j-i]
+			}
+		} else {
+			for j := max(0, i-kd); j <= i; j++ {
+				a.Data[i*a.Stride+j] = ab[i*ldab+kd+j-i]
+				a.Data[j*a.Stride+i] = ab[i*ldab+kd+j-i]
+			}
+		}
+	}
+	return a
+}
+
+// tridiagToGeneral returns the dense n×n matrix equivalent to the tridiagonal
+// matrix with subdiagonal dl, diagonal d and superdiagonal du.
+func tridiagToGeneral(n int, dl, d, du []float64) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		a.Data[i*a.Stride+i] = d[i]
+		if i < n-1 {
+			a.Data[(i+1)*a.Stride+i] = dl[i]
+			a.Data[i*a.Stride+i+1] = du[i]
+		}
+	}
+	return a
+}
+
+// randomSPDTridiag returns the diagonal d and the off-diagonal e of a random
+// symmetric positive definite n×n tridiagonal matrix. The matrix is made
+// strictly diagonally dominant with positive diagonal.
+func randomSPDTridiag(n int, rnd *rand.Rand) (d, e []float64) {
+	d = make([]float64, n)
+	e = make([]float64, max(0, n-1))
+	for i := range e {
+		e[i] = rnd.NormFloat64()
+	}
+	for i := range d {
+		d[i] = 0.01 + rnd.Float64()
+		if i > 0 {
+			d[i] += math.Abs(e[i-1])
+		}
+		if i < n-1 {
+			d[i] += math.Abs(e[i])
+		}
+	}
+	return d, e
+}
+
+// randomSPDPacked returns the upper or lower triangle of a random symmetric
+// positive definite n×n matrix stored in row-major packed format. The matrix
+// is made strictly diagonally dominant with positive diagonal.
+func randomSPDPacked(uplo blas.Uplo, n int, rnd *rand.Rand) []float64 {
+	ap := randomSlice(n*(n+1)/2, rnd)
+	a := symPackedToGeneral(uplo, n, ap)
+	for i := 0; i < n; i++ {
+		var sum float64
+		for j := 0; j < n; j++ {
+			if j != i {
+				sum += math.Abs(a.Data[i*a.Stride+j])
+			}
+		}
+		ap[packedIndex(uplo, n, i, i)] = sum + 0.01 + rnd.Float64()
+	}
+	return ap
+}
+
+// packedIndex returns the index of the element A[i,j] of an n×n matrix in
+// row-major packed format. The element must be in the triangle given by uplo.
+func packedIndex(uplo blas.Uplo, n, i, j int) int {
+	if uplo == blas.Upper {
+		return i*n - i*(i-1)/2 + j - i
+	}
+	return i*(i+1)/2 + j
+}
+
+// symPackedToGeneral returns the dense n×n symmetric matrix whose upper or
+// lower triangle is stored in ap in row-major packed format.
+func symPackedToGeneral(uplo blas.Uplo, n int, ap []float64) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := 0; j <= i; j++ {
+			var v float64
+			if uplo == blas.Upper {
+				v = ap[packedIndex(uplo, n, j, i)]
+			} else {
+				v = ap[packedIndex(uplo, n, i, j)]
+			}
+			a.Data[i*a.Stride+j] = v
+			a.Data[j*a.Stride+i] = v
+		}
+	}
+	return a
+}
+
+// triPackedToGeneral returns the dense n×n upper or lower triangular matrix
+// stored in ap in row-major packed format. The elements in the opposite
+// triangle are set to zero.
+func triPackedToGeneral(uplo blas.Uplo, n int, ap []float64) blas64.General {
+	a := zeros(n, n, max(1, n))
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && i >= j) {
+				a.Data[i*a.Stride+j] = ap[packedIndex(uplo, n, i, j)]
+			}
+		}
+	}
+	return a
+}
+
+// rfpDims returns the number of rows and columns of the row-major rectangular
+// full packed array of an n×n symmetric or triangular matrix.
+func rfpDims(transr blas.Transpose, n int) (rows, cols int) {
+	if n%2 == 1 {
+		rows, cols = n, (n+1)/2
+	} else {
+		rows, cols = n+1, n/2
+	}
+	if transr == blas.Trans {
+		rows, cols = cols, rows
+	}
+	return rows, cols
+}
+
 // extract2x2Block returns the elements of T at [0,0], [0,1], [1,0], and [1,1].
 func extract2x2Block(t []float64, ldt int) (a, b, c, d float64) {
 	return t[0], t[1], t[ldt], t[ldt+1]
@@ -1245,6 +1451,61 @@ func isLeftEigenvectorOf(a blas64.General, yRe, yIm []float64, lambda complex128
 	return true
 }
 
+// generalizedEVResidual returns the relative residual of the right or left
+// generalized eigenvector of the matrix pair (A,B) corresponding to the
+// eigenvalue represented by the pair (alpha,beta). If left is false, the
+// residual of the right eigenvector x = xRe+i*xIm, where i is the imaginary
+// unit, is computed as
+//  |β A x - α B x|_∞ / ((|β| |A|_∞ + |α| |B|_∞) |x|_∞),
+// and if left is true, the residual of the left eigenvector y = xRe+i*xIm
+// defined by
+//  β y^H A = α y^H B,
+// which is equivalent for real A and B to
+//  β A^T y = conj(α) B^T y,
+// is computed analogously. xIm may be nil if x is real.
+func generalizedEVResidual(left bool, a, b blas64.General, alpha complex128, beta float64, xRe, xIm []float64) float64 {
+	n := a.Rows
+	if xIm == nil {
+		xIm = make([]float64, n)
+	}
+	trans := blas.NoTrans
+	if left {
+		trans = blas.Trans
+		alpha = cmplx.Conj(alpha)
+	}
+	op := func(m blas64.General) (re, im []float64, norm float64) {
+		re = make([]float64, n)
+		im = make([]float64, n)
+		blas64.Gemv(trans, 1, m, blas64.Vector{1, xRe}, 0, blas64.Vector{1, re})
+		blas64.Gemv(trans, 1, m, blas64.Vector{1, xIm}, 0, blas64.Vector{1, im})
+		for i := 0; i < n; i++ {
+			var sum float64
+			for j := 0; j < n; j++ {
+				if left {
+					sum += math.Abs(m.Data[j*m.Stride+i])
+				} else {
+					sum += math.Abs(m.Data[i*m.Stride+j])
+				}
+			}
+			norm = math.Max(norm, sum)
+		}
+		return re, im, norm
+	}
+	axRe, axIm, anorm := op(a)
+	bxRe, bxIm, bnorm := op(b)
+	var resid, xnorm float64
+	for i := 0; i < n; i++ {
+		r := complex(beta, 0)*complex(axRe[i], axIm[i]) - alpha*complex(bxRe[i], bxIm[i])
+		resid = math.Max(resid, cmplx.Abs(r))
+		xnorm = math.Max(xnorm, cmplx.Abs(complex(xRe[i], xIm[i])))
+	}
+	den := (math.Abs(beta)*anorm + cmplx.Abs(alpha)*bnorm) * xnorm
+	if den == 0 {
+		return resid
+	}
+	return resid / den
+}
+
 // rootsOfUnity returns the n complex numbers whose n-th power is equal to 1.
 func rootsOfUnity(n int) []complex128 {
 	w := make([]complex128, n)
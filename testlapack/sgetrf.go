@@ -0,0 +1,80 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"math/rand"
+	"testing"
+)
+
+type Sgetrfer interface {
+	Sgetrf(m, n int, a []float32, lda int, ipiv []int) (ok bool)
+}
+
+func SgetrfTest(t *testing.T, impl Sgetrfer) {
+	rnd := rand.New(rand.NewSource(1))
+	for _, test := range []struct {
+		m, n, lda int
+	}{
+		{10, 5, 0},
+		{5, 10, 0},
+		{10, 10, 0},
+		{300, 5, 0},
+		{3, 300, 0},
+		{4, 5, 0},
+		{150, 100, 0},
+		{100, 150, 0},
+		{150, 150, 0},
+		{1, 300, 0},
+		{300, 1, 0},
+		{10, 5, 20},
+		{5, 10, 20},
+		{150, 100, 200},
+		{100, 150, 200},
+		{150, 150, 200},
+	} {
+		for _, singular := range []bool{false, true} {
+			m := test.m
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = n
+			}
+			a := make([]float32, m*lda)
+			for i := range a {
+				a[i] = rnd.Float32()
+			}
+			if singular {
+				// Set a column to zero so that a zero pivot occurs
+				// in U.
+				j := rnd.Intn(min(m, n))
+				for i := 0; i < m; i++ {
+					a[i*lda+j] = 0
+				}
+			}
+			ipiv := make([]int, min(m, n))
+			for i := range ipiv {
+				ipiv[i] = rnd.Int()
+			}
+
+			aCopy := make([]float64, len(a))
+			for i, v := range a {
+				aCopy[i] = float64(v)
+			}
+			ok := impl.Sgetrf(m, n, a, lda, ipiv)
+			if singular && ok {
+				t.Errorf("Case m=%v,n=%v,lda=%v: unexpected success for singular matrix", m, n, lda)
+			}
+
+			// Check the factorization in double precision with a
+			// tolerance for single precision.
+			factorized := make([]float64, len(a))
+			for i, v := range a {
+				factorized[i] = float64(v)
+			}
+			checkPLU(t, ok, m, n, lda, ipiv, factorized, aCopy, 1e-4, false)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright ©2017 The gonum Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package testlapack
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/gonum/blas"
+	"github.com/gonum/blas/blas64"
+)
+
+type Spotrfer interface {
+	Spotrf(ul blas.Uplo, n int, a []float32, lda int) (ok bool)
+}
+
+func SpotrfTest(t *testing.T, impl Spotrfer) {
+	const eps32 = 1.0 / (1 << 24)
+	rnd := rand.New(rand.NewSource(1))
+	for _, uplo := range []blas.Uplo{blas.Upper, blas.Lower} {
+		for _, test := range []struct {
+			n, lda int
+		}{
+			{1, 0},
+			{2, 0},
+			{3, 0},
+			{10, 0},
+			{30, 0},
+			{63, 0},
+			{65, 0},
+			{129, 0},
+			{1, 10},
+			{3, 10},
+			{10, 20},
+			{65, 100},
+			{129, 200},
+		} {
+			n := test.n
+			lda := test.lda
+			if lda == 0 {
+				lda = n
+			}
+			prefix := fmt.Sprintf("Case uplo=%v,n=%v,lda=%v:", uplo, n, lda)
+
+			// Construct a positive definite matrix A = U * D * U^T
+			// with a moderate condition number, and round it to
+			// single precision.
+			d := make([]float64, n)
+			Dlatm1(d, 4, 100, false, 1, rnd)
+			a64 := make([]float64, n*lda)
+			Dlagsy(n, 0, d, a64, lda, rnd, make([]float64, 2*n))
+			a := make([]float32, len(a64))
+			for i, v := range a64 {
+				a[i] = float32(v)
+			}
+			aCopy := make([]float64, len(a))
+			for i, v := range a {
+				aCopy[i] = float64(v)
+			}
+
+			ok := impl.Spotrf(uplo, n, a, lda)
+			if !ok {
+				t.Errorf("%v unexpected failure for positive definite matrix", prefix)
+				continue
+			}
+
+			// Compute U^T * U or L * L^T in double precision from
+			// the triangle of a that holds the factor.
+			f := zeros(n, n, n)
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && j <= i) {
+						f.Data[i*f.Stride+j] = float64(a[i*lda+j])
+					}
+				}
+			}
+			ans := zeros(n, n, n)
+			if uplo == blas.Upper {
+				blas64.Gemm(blas.Trans, blas.NoTrans, 1, f, f, 0, ans)
+			} else {
+				blas64.Gemm(blas.NoTrans, blas.Trans, 1, f, f, 0, ans)
+			}
+			var errMax float64
+			for i := 0; i < n; i++ {
+				for j := 0; j < n; j++ {
+					if (uplo == blas.Upper && i <= j) || (uplo == blas.Lower && j <= i) {
+						errMax = math.Max(errMax, math.Abs(ans.Data[i*ans.Stride+j]-aCopy[i*lda+j]))
+					}
+				}
+			}
+			if errMax > 10*float64(n)*eps32 {
+				t.Errorf("%v unexpected result: max error %v", prefix, errMax)
+			}
+
+			// Make one element of D negative so that A is not
+			// positive definite, and check that Spotrf fails.
+			d[0] *= -1
+			Dlagsy(n, 0, d, a64, lda, rnd, make([]float64, 2*n))
+			for i, v := range a64 {
+				a[i] = float32(v)
+			}
+			ok = impl.Spotrf(uplo, n, a, lda)
+			if ok {
+				t.Errorf("%v unexpected success for not positive definite matrix", prefix)
+			}
+		}
+	}
+}